@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/klauer/clash-royale-api/go/pkg/analysis"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+const defaultMasteryMinProgress = 50.0
+
+// displayMasteries shows card mastery tasks closest to completion and what
+// finishing them is worth.
+func displayMasteries(player *clashroyale.Player, minProgress float64, topN int) {
+	printf("\n╔════════════════════════════════════════════════════════════════════╗\n")
+	printf("║                       CARD MASTERY PROGRESS                        ║\n")
+	printf("╚════════════════════════════════════════════════════════════════════╝\n\n")
+	printf("Player: %s (%s)\n", player.Name, player.Tag)
+
+	masteries := analysis.ParseCardMasteries(player)
+	if len(masteries) == 0 {
+		printf("\nNo card mastery badges reported for this player.\n")
+		return
+	}
+
+	completed := 0
+	for _, mastery := range masteries {
+		if mastery.IsComplete() {
+			completed++
+		}
+	}
+	printf("Masteries: %d tracked, %d fully completed\n\n", len(masteries), completed)
+
+	nearDone := analysis.MasteriesCloseToCompletion(masteries, minProgress, topN)
+	if len(nearDone) == 0 {
+		printf("No mastery tasks at or above %.0f%% progress.\n", minProgress)
+		return
+	}
+
+	printf("Closest to Completion (>= %.0f%%):\n", minProgress)
+	printf("═══════════════════════════════\n")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fprintf(w, "Card\tLevel\tProgress\tRemaining\tDone\tNext Reward\n")
+	fprintf(w, "────\t─────\t────────\t─────────\t────\t───────────\n")
+	totalGold, totalGems := 0, 0
+	for _, mastery := range nearDone {
+		fprintf(w, "%s\t%d/%d\t%d/%d\t%d\t%.0f%%\t%s\n",
+			mastery.Card,
+			mastery.Level, mastery.MaxLevel,
+			mastery.Progress, mastery.Target,
+			mastery.Remaining,
+			mastery.PercentComplete,
+			formatMasteryReward(mastery.NextReward))
+		totalGold += mastery.NextReward.Gold
+		totalGems += mastery.NextReward.Gems
+	}
+	flushWriter(w)
+
+	printf("\nFinishing these %d tasks is worth about %s.\n", len(nearDone),
+		formatMasteryReward(analysis.MasteryReward{Gold: totalGold, Gems: totalGems}))
+}
+
+func formatMasteryReward(reward analysis.MasteryReward) string {
+	switch {
+	case reward.Gold > 0 && reward.Gems > 0:
+		return fmt.Sprintf("%d gold + %d gems", reward.Gold, reward.Gems)
+	case reward.Gems > 0:
+		return fmt.Sprintf("%d gems", reward.Gems)
+	default:
+		return fmt.Sprintf("%d gold", reward.Gold)
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/analysis"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+func TestDisplayMasteries(t *testing.T) {
+	player := &clashroyale.Player{
+		Name:  "Tester",
+		Tag:   "#ABC",
+		Cards: []clashroyale.Card{{Name: "Hog Rider"}},
+		Badges: []clashroyale.Badge{
+			{Name: "MasteryHogRider", Level: 2, MaxLevel: 10, Progress: 8, Target: 10},
+			{Name: "MasteryKnight", Level: 1, MaxLevel: 10, Progress: 1, Target: 10},
+		},
+	}
+
+	out, err := captureStdout(t, func() error {
+		displayMasteries(player, 50, 10)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"2 tracked", "Hog Rider", "80%", "1500 gold + 10 gems"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Knight") {
+		t.Error("expected masteries below the progress threshold to be hidden")
+	}
+}
+
+func TestFormatMasteryReward(t *testing.T) {
+	if got := formatMasteryReward(analysis.MasteryReward{Gems: 5}); got != "5 gems" {
+		t.Errorf("formatMasteryReward(gems) = %q", got)
+	}
+	if got := formatMasteryReward(analysis.MasteryReward{Gold: 500}); got != "500 gold" {
+		t.Errorf("formatMasteryReward(gold) = %q", got)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/whatif"
+)
+
+// runValueRanking builds a deck per strategy from the card levels and ranks
+// each card's next upgrade by how much it raises the best decks per gold.
+func runValueRanking(cardLevels map[string]deck.CardLevelData, topDecks int, dataDir string, verbose bool) ([]whatif.UpgradeValue, []whatif.BudgetDeck, error) {
+	decks, err := buildStrategyDecks(cardLevels, dataDir, verbose)
+	if err != nil {
+		return nil, nil, err
+	}
+	if verbose {
+		printf("Ranking upgrades across the best %d of %d candidate decks...\n", topDecks, len(decks))
+	}
+
+	values, ranked, err := whatif.RankUpgradeValue(cardLevels, decks, topDecks, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to rank upgrade value: %w", err)
+	}
+	return values, ranked, nil
+}
+
+// displayValueRanking shows the best value upgrades, limited to topN
+func displayValueRanking(values []whatif.UpgradeValue, decks []whatif.BudgetDeck, playerName, tag string, topN int) {
+	printf("\n╔════════════════════════════════════════════════════════════════════╗\n")
+	printf("║                       BEST VALUE UPGRADES                          ║\n")
+	printf("╚════════════════════════════════════════════════════════════════════╝\n\n")
+	printf("Player: %s (%s)\n", playerName, tag)
+
+	printf("\nBest Decks:\n")
+	for i, d := range decks {
+		printf("  %d. %.2f  %s\n", i+1, d.Before, formatCardList(d.Cards))
+	}
+	printf("\n")
+
+	if len(values) == 0 {
+		printf("No affordable upgrade improves these decks.\n")
+		return
+	}
+	if topN > 0 && len(values) > topN {
+		values = values[:topN]
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fprintf(w, "#\tCard\tFrom\tTo\tGold\tScore Gain\tGain/1k Gold\tDecks\n")
+	fprintf(w, "─\t────\t────\t──\t────\t──────────\t────────────\t─────\n")
+	for i, v := range values {
+		fprintf(w, "%d\t%s\t%d\t%d\t%d\t%+.3f\t%.4f\t%d\n",
+			i+1, v.CardName, v.FromLevel, v.ToLevel, v.GoldCost, v.ScoreGain, v.GainPer1000Gold, v.Decks)
+	}
+	flushWriter(w)
+}
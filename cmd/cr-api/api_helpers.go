@@ -1,9 +1,14 @@
 package main
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"io"
 	"os"
+	"strings"
 
+	"github.com/klauer/clash-royale-api/go/internal/apicache"
 	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
 	"github.com/urfave/cli/v3"
 )
@@ -15,7 +20,16 @@ import (
 // subcommand was registered without `Sources`.
 const apiTokenEnvVar = "CLASH_ROYALE_API_TOKEN"
 
-const requiredAPITokenMessage = "API token is required. Set CLASH_ROYALE_API_TOKEN environment variable or use --api-token flag"
+const requiredAPITokenMessage = "API token is required. Set CLASH_ROYALE_API_TOKEN environment variable, use --api-token flag, or run 'cr-api auth login'"
+
+// apiResponseCache is attached to every API client created by the CLI. It is
+// configured once from the global --data-dir and --cache-max-age flags and
+// serves responses previously stored by `cr-api cache warm`.
+var apiResponseCache clashroyale.ResponseCache
+
+// apiBaseURL is the API host every client talks to, set once from the
+// global --api-base flag. Empty keeps the official API.
+var apiBaseURL string
 
 type apiClientOptions struct {
 	offlineAllowed bool
@@ -32,7 +46,82 @@ func requireAPIClientFromToken(apiToken string, opts apiClientOptions) (*clashro
 	if err != nil {
 		return nil, err
 	}
-	return clashroyale.NewClient(token), nil
+	client := newAPIClient(token)
+	if apiResponseCache != nil {
+		client.SetCache(apiResponseCache)
+	}
+	client.SetOffline(apiOfflineCache != nil)
+	return client, nil
+}
+
+// newAPIClient creates a client for token that reports request metrics
+// and talks to the configured API base, without the response cache.
+func newAPIClient(token string) *clashroyale.Client {
+	client := clashroyale.NewClientWithTokens(splitAPITokens(token))
+	client.SetRequestObserver(recordAPIRequest)
+	if apiBaseURL != "" {
+		client.SetBaseURL(apiBaseURL)
+	}
+	if apiVCRMode != "" {
+		client.SetVCR(apiVCRDir, apiVCRMode)
+	}
+	return client
+}
+
+// configureAPIBase resolves --api-base for this invocation.
+func configureAPIBase(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+	baseURL, err := clashroyale.ResolveBaseURL(cmd.String("api-base"))
+	if err != nil {
+		return ctx, err
+	}
+	apiBaseURL = baseURL
+	return ctx, nil
+}
+
+// splitAPITokens splits an --api-token value holding several
+// comma-separated tokens, which the client rotates across for heavy scans.
+func splitAPITokens(value string) []string {
+	return strings.Split(value, ",")
+}
+
+// printTokenStats reports per-token usage when the client rotates across
+// several tokens.
+func printTokenStats(w io.Writer, client *clashroyale.Client) {
+	for i, stats := range client.TokenStats() {
+		fprintf(w, "  token %d (%s): %d requests, %d throttled", i+1, stats.Token, stats.Requests, stats.Throttled)
+		if stats.Removed {
+			fprintf(w, ", removed: %s", stats.Reason)
+		}
+		fprintf(w, "\n")
+	}
+}
+
+// bulkWorkersFlag is the --workers flag of commands that fetch many
+// players at once
+func bulkWorkersFlag() cli.Flag {
+	return &cli.IntFlag{
+		Name:  "workers",
+		Value: clashroyale.DefaultBulkWorkers,
+		Usage: "Concurrent player requests (the API rate limit still applies; extra --api-token values raise it)",
+	}
+}
+
+// configureAPICache installs the read-only response cache for this
+// invocation, or the offline cache when --offline is set.
+func configureAPICache(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+	apiOfflineCache = nil
+	if cmd.Bool(offlineFlagName) {
+		apiOfflineCache = apicache.NewOffline(cmd.String("data-dir"))
+		apiResponseCache = apiOfflineCache
+		return ctx, nil
+	}
+	maxAge := cmd.Duration("cache-max-age")
+	if maxAge <= 0 {
+		apiResponseCache = nil
+		return ctx, nil
+	}
+	apiResponseCache = apicache.NewReadOnly(cmd.String("data-dir"), maxAge)
+	return ctx, nil
 }
 
 func requireAPIToken(cmd *cli.Command, opts apiClientOptions) (string, error) {
@@ -50,8 +139,18 @@ func resolveAPIToken(apiToken string) string {
 	return os.Getenv(apiTokenEnvVar)
 }
 
+// requireAPITokenValue resolves the token like resolveAPIToken, then falls
+// back to the token stored by `cr-api auth login`, prompting for the
+// encrypted file passphrase when needed. Offline and replay modes need no
+// token.
 func requireAPITokenValue(apiToken string, opts apiClientOptions) (string, error) {
 	resolved := resolveAPIToken(apiToken)
+	if apiOfflineCache != nil || apiVCRMode == clashroyale.VCRReplay {
+		return resolved, nil
+	}
+	if resolved == "" {
+		resolved = loadStoredToken(true)
+	}
 	if resolved == "" {
 		return "", errors.New(buildAPITokenRequiredMessage(opts))
 	}
@@ -66,7 +165,28 @@ func buildAPITokenRequiredMessage(opts apiClientOptions) string {
 		return requiredAPITokenMessage + opts.offlineHint
 	}
 	if opts.offlineAllowed {
-		return requiredAPITokenMessage + ". Use --from-analysis for offline mode"
+		return requiredAPITokenMessage + ". Use --from-analysis or --offline for offline mode"
 	}
 	return requiredAPITokenMessage
 }
+
+// apiErrorHint returns a next step for an API failure, or "" when the error
+// message already says enough
+func apiErrorHint(err error) string {
+	var rateErr *clashroyale.RateLimitError
+	switch {
+	case errors.Is(err, clashroyale.ErrOffline):
+		return "Run `cr-api cache warm` or `cr-api player --save` while online to cache this data"
+	case errors.Is(err, clashroyale.ErrInvalidTag):
+		return "Copy the tag from the in-game profile, e.g. #2PP; it uses the digit 0, never the letter O"
+	case errors.Is(err, clashroyale.ErrNotFound):
+		return "No player or clan has that tag; check it against the in-game profile"
+	case errors.As(err, &rateErr):
+		return fmt.Sprintf("The API is throttling this token; wait %s, lower --workers, or pass several tokens to --api-token", rateErr.RetryAfter)
+	case errors.Is(err, clashroyale.ErrMaintenance):
+		return "The Clash Royale API is down for maintenance; try again later, or use --offline with cached data"
+	case errors.Is(err, clashroyale.ErrAccessDenied):
+		return "The API rejected the token. Tokens only work from the IP addresses they allow: create one for this machine at https://developer.clashroyale.com, or use --api-base royaleapi"
+	}
+	return ""
+}
@@ -1,8 +1,15 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/urfave/cli/v3"
 )
 
 func TestResolveAPIToken_PrefersExplicitArg(t *testing.T) {
@@ -55,3 +62,71 @@ func TestRequireAPITokenValue_CustomMissingMessage(t *testing.T) {
 		t.Errorf("got %v, want exactly %q", err, "custom hint")
 	}
 }
+
+func TestRequireAPIClientFromToken_RotatesCommaSeparatedTokens(t *testing.T) {
+	client, err := requireAPIClientFromToken("token-one, token-two", apiClientOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := len(client.TokenStats()); got != 2 {
+		t.Errorf("client rotates across %d tokens, want 2", got)
+	}
+
+	single, err := requireAPIClientFromToken("token-one", apiClientOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if single.TokenStats() != nil {
+		t.Error("a single token should not create a rotation pool")
+	}
+}
+
+func TestConfigureAPIBase(t *testing.T) {
+	t.Cleanup(func() { apiBaseURL = "" })
+	run := func(args ...string) error {
+		root := &cli.Command{
+			Name:   "cr-api",
+			Flags:  []cli.Flag{&cli.StringFlag{Name: "api-base", Value: "official"}},
+			Before: configureAPIBase,
+			Action: func(context.Context, *cli.Command) error { return nil },
+		}
+		return root.Run(context.Background(), append([]string{"cr-api"}, args...))
+	}
+
+	if err := run("--api-base", "royaleapi"); err != nil {
+		t.Fatal(err)
+	}
+	if got := newAPIClient("token").BaseURL(); got != clashroyale.RoyaleAPIProxyBaseURL {
+		t.Errorf("BaseURL() = %q, want the RoyaleAPI proxy", got)
+	}
+	if err := run(); err != nil {
+		t.Fatal(err)
+	}
+	if got := newAPIClient("token").BaseURL(); got != clashroyale.DefaultBaseURL {
+		t.Errorf("BaseURL() = %q, want the official API", got)
+	}
+	if err := run("--api-base", "not a url"); err == nil {
+		t.Error("expected an error for an invalid --api-base")
+	}
+}
+
+func TestAPIErrorHint(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{clashroyale.ValidateTag("#2PPO"), "digit 0"},
+		{fmt.Errorf("failed: %w", clashroyale.APIError{StatusCode: 404}), "No player or clan"},
+		{&clashroyale.RateLimitError{APIError: clashroyale.APIError{StatusCode: 429}, RetryAfter: 30 * time.Second}, "wait 30s"},
+		{clashroyale.APIError{StatusCode: 503, Reason: "inMaintenance"}, "maintenance"},
+		{clashroyale.APIError{StatusCode: 403}, "--api-base royaleapi"},
+		{clashroyale.ErrOffline, "cache warm"},
+		{errors.New("disk full"), ""},
+	}
+	for _, tt := range tests {
+		got := apiErrorHint(tt.err)
+		if (tt.want == "") != (got == "") || !strings.Contains(got, tt.want) {
+			t.Errorf("apiErrorHint(%v) = %q, want it to mention %q", tt.err, got, tt.want)
+		}
+	}
+}
@@ -14,14 +14,51 @@ import (
 	"golang.org/x/text/language"
 
 	"github.com/klauer/clash-royale-api/go/internal/exporter/csv"
+	"github.com/klauer/clash-royale-api/go/internal/storage"
 	"github.com/klauer/clash-royale-api/go/pkg/analysis"
 	"github.com/klauer/clash-royale-api/go/pkg/archetypes"
 	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
 	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
 	"github.com/urfave/cli/v3"
 )
 
-const noCardsLabel = "None"
+const (
+	noCardsLabel = "None"
+
+	customArchetypesFlagName = "custom-archetypes"
+)
+
+// customArchetypesPath returns the custom archetypes file for this
+// invocation: --custom-archetypes, or custom_archetypes.json in the data dir.
+func customArchetypesPath(cmd *cli.Command) string {
+	if path := strings.TrimSpace(cmd.String(customArchetypesFlagName)); path != "" {
+		return path
+	}
+	return filepath.Join(cmd.String("data-dir"), evaluation.CustomArchetypesFile)
+}
+
+// configureCustomArchetypes registers the user's custom archetypes so every
+// command detects them. An explicitly named file must exist; a file that
+// fails to load only produces a warning.
+func configureCustomArchetypes(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+	evaluation.ResetArchetypeScorers()
+	path := customArchetypesPath(cmd)
+	if !storage.FileExists(path) {
+		if cmd.IsSet(customArchetypesFlagName) {
+			return ctx, fmt.Errorf("custom archetypes file not found: %s", path)
+		}
+		return ctx, nil
+	}
+	file, err := evaluation.ReadCustomArchetypes(path)
+	if err == nil {
+		err = file.Register()
+	}
+	if err != nil {
+		fprintf(os.Stderr, "Warning: ignoring custom archetypes: %v\n", err)
+	}
+	return ctx, nil
+}
 
 // addArchetypeCommands adds archetype analysis commands to the CLI
 func addArchetypeCommands() *cli.Command {
@@ -31,6 +68,8 @@ func addArchetypeCommands() *cli.Command {
 		Commands: []*cli.Command{
 			addArchetypeVarietyCommand(),
 			addArchetypeDetectCommand(),
+			addArchetypeScorersCommand(),
+			addArchetypeCalibrateCommand(),
 		},
 	}
 }
@@ -719,3 +758,153 @@ func (p *deckStrategyProvider) GetAllStrategies() []analysis.Strategy {
 		analysis.Strategy(deck.StrategySpell),
 	}
 }
+
+// addArchetypeScorersCommand adds the command listing the archetypes deck
+// evaluation can detect
+func addArchetypeScorersCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "scorers",
+		Usage: "List the archetypes deck evaluation detects, including custom ones from " + evaluation.CustomArchetypesFile,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "Output in JSON format",
+			},
+		},
+		Action: archetypeScorersCommand,
+	}
+}
+
+// archetypeScorerEntry is one archetype DetectArchetype can return
+type archetypeScorerEntry struct {
+	Archetype   string `json:"archetype"`
+	Source      string `json:"source"`
+	Description string `json:"description,omitempty"`
+}
+
+func archetypeScorersCommand(ctx context.Context, cmd *cli.Command) error {
+	scorers := evaluation.ArchetypeScorers()
+	entries := make([]archetypeScorerEntry, 0, len(scorers))
+	for _, scorer := range scorers {
+		entry := archetypeScorerEntry{Archetype: string(scorer.Archetype()), Source: roleSourceBuiltin}
+		if !evaluation.IsBuiltinArchetype(scorer.Archetype()) {
+			entry.Source = customArchetypesPath(cmd)
+		}
+		if keyword, ok := scorer.(*evaluation.KeywordArchetype); ok {
+			entry.Description = keyword.Description
+		}
+		entries = append(entries, entry)
+	}
+
+	if cmd.Bool("json") {
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode archetypes: %w", err)
+		}
+		printf("%s\n", data)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fprintf(w, "Archetype\tSource\tDescription\n")
+	fprintf(w, "---------\t------\t-----------\n")
+	for _, entry := range entries {
+		fprintf(w, "%s\t%s\t%s\n", entry.Archetype, entry.Source, entry.Description)
+	}
+	flushWriter(w)
+	return nil
+}
+
+// addArchetypeCalibrateCommand adds the command that measures and refits the
+// hybrid archetype thresholds on a labeled deck corpus
+func addArchetypeCalibrateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "calibrate",
+		Usage: "Measure hybrid archetype detection on a labeled deck corpus and fit the thresholds that maximize F1",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "corpus",
+				Usage: "Labeled deck corpus JSON to calibrate on (default: the built-in corpus)",
+			},
+			&cli.StringFlag{
+				Name:  "export",
+				Usage: "Write the built-in labeled deck corpus to this path and exit",
+			},
+			&cli.IntFlag{
+				Name:  "folds",
+				Value: 5,
+				Usage: "Cross-validation folds for the held-out estimate",
+			},
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "Output in JSON format",
+			},
+		},
+		Action: archetypeCalibrateCommand,
+	}
+}
+
+// archetypeCalibrationReport compares the default hybrid thresholds with the
+// ones fit to the corpus
+type archetypeCalibrationReport struct {
+	Corpus  string                       `json:"corpus"`
+	Current evaluation.HybridCalibration `json:"current"`
+	Fitted  evaluation.HybridCalibration `json:"fitted"`
+	// CrossValidated scores each fold with thresholds fit to the others
+	CrossValidated evaluation.HybridCalibration `json:"cross_validated"`
+	Folds          int                          `json:"folds"`
+}
+
+func archetypeCalibrateCommand(ctx context.Context, cmd *cli.Command) error {
+	if path := cmd.String("export"); path != "" {
+		if err := os.WriteFile(path, evaluation.ArchetypeCorpusJSON(), 0o644); err != nil {
+			return fmt.Errorf("failed to export archetype corpus: %w", err)
+		}
+		printf("Wrote the built-in archetype corpus to %s\n", path)
+		return nil
+	}
+
+	report := archetypeCalibrationReport{Corpus: roleSourceBuiltin}
+	corpus := evaluation.ArchetypeCorpus()
+	if path := cmd.String("corpus"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read archetype corpus: %w", err)
+		}
+		if corpus, err = evaluation.ParseArchetypeCorpus(data); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		report.Corpus = path
+	}
+	report.Current = evaluation.EvaluateHybridThresholds(corpus, evaluation.DefaultHybridThresholds)
+	report.Fitted = evaluation.CalibrateHybridThresholds(corpus)
+	report.Folds = max(cmd.Int("folds"), 2)
+	report.CrossValidated = evaluation.CrossValidateHybridThresholds(corpus, report.Folds)
+
+	if cmd.Bool("json") {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode calibration: %w", err)
+		}
+		printf("%s\n", data)
+		return nil
+	}
+
+	printf("Hybrid archetype calibration (%s corpus, %d decks)\n\n", report.Corpus, report.Current.Decks)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fprintf(w, "Thresholds\tConfidence\tRatio\tGap\tRelated\tTP/FP/FN\tPrecision\tRecall\tF1\tPure Acc\n")
+	for _, row := range []struct {
+		name        string
+		calibration evaluation.HybridCalibration
+	}{{"current", report.Current}, {"fitted", report.Fitted}} {
+		c, t := row.calibration, row.calibration.Thresholds
+		fprintf(w, "%s\t%.2f\t%.2f\t%.2f\t%t\t%d/%d/%d\t%.2f\t%.2f\t%.2f\t%.1f%%\n",
+			row.name, t.MinConfidence, t.MinScoreRatio, t.MaxScoreGap, t.AllowRelated,
+			c.TruePositives, c.FalsePositives, c.FalseNegatives, c.Precision, c.Recall, c.F1, c.PureAccuracy*100)
+	}
+	c := report.CrossValidated
+	fprintf(w, "%d-fold CV\t-\t-\t-\t-\t%d/%d/%d\t%.2f\t%.2f\t%.2f\t%.1f%%\n",
+		report.Folds, c.TruePositives, c.FalsePositives, c.FalseNegatives, c.Precision, c.Recall, c.F1, c.PureAccuracy*100)
+	flushWriter(w)
+	return nil
+}
@@ -1,9 +1,16 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/urfave/cli/v3"
 )
 
 func TestBuildAnalysisCardLevelsFromPlayer(t *testing.T) {
@@ -88,3 +95,75 @@ func TestConvertPlayerToAnalysisVariantsStayAligned(t *testing.T) {
 		t.Fatalf("expected TotalCards to match player cards, got %d", packageAnalysis.TotalCards)
 	}
 }
+
+func TestConfigureCustomArchetypes(t *testing.T) {
+	t.Cleanup(evaluation.ResetArchetypeScorers)
+	dataDir := t.TempDir()
+	custom := `{"archetypes":[{"name":"recruits-split-lane","required":["Royal Recruits"],"keywords":{"Royal Recruits":6,"Royal Hogs":4}}]}`
+	if err := os.WriteFile(filepath.Join(dataDir, evaluation.CustomArchetypesFile), []byte(custom), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) error {
+		root := &cli.Command{
+			Name: "cr-api",
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "data-dir"},
+				&cli.StringFlag{Name: customArchetypesFlagName},
+			},
+			Before: configureCustomArchetypes,
+			Action: func(context.Context, *cli.Command) error { return nil },
+		}
+		return root.Run(context.Background(), append([]string{"cr-api"}, args...))
+	}
+
+	if err := run("--data-dir", dataDir); err != nil {
+		t.Fatalf("configureCustomArchetypes() error = %v", err)
+	}
+	scorers := evaluation.ArchetypeScorers()
+	if last := scorers[len(scorers)-1].Archetype(); last != "recruits-split-lane" {
+		t.Errorf("last scorer = %s, want the custom archetype", last)
+	}
+
+	// A data dir without the file leaves only the built-in archetypes
+	if err := run("--data-dir", t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	for _, scorer := range evaluation.ArchetypeScorers() {
+		if !evaluation.IsBuiltinArchetype(scorer.Archetype()) {
+			t.Errorf("custom archetype %s still registered", scorer.Archetype())
+		}
+	}
+
+	err := run("--data-dir", dataDir, "--"+customArchetypesFlagName, filepath.Join(dataDir, "missing.json"))
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("missing --custom-archetypes error = %v, want not found", err)
+	}
+}
+
+func TestArchetypeCalibrateCommand(t *testing.T) {
+	corpusPath := filepath.Join(t.TempDir(), "corpus.json")
+	run := func(args ...string) (string, error) {
+		return captureStdout(t, func() error {
+			return addArchetypeCalibrateCommand().Run(context.Background(), append([]string{"calibrate"}, args...))
+		})
+	}
+
+	if _, err := run("--export", corpusPath); err != nil {
+		t.Fatalf("calibrate --export error = %v", err)
+	}
+	output, err := run("--corpus", corpusPath, "--json")
+	if err != nil {
+		t.Fatalf("calibrate --corpus error = %v", err)
+	}
+	var report archetypeCalibrationReport
+	if err := json.Unmarshal([]byte(output), &report); err != nil {
+		t.Fatalf("calibrate output is not JSON: %v\n%s", err, output)
+	}
+	if report.Corpus != corpusPath || report.Current.Decks != len(evaluation.ArchetypeCorpus()) {
+		t.Errorf("report = %+v, want the exported corpus", report)
+	}
+	if report.Fitted.Thresholds != evaluation.DefaultHybridThresholds {
+		t.Errorf("fitted thresholds = %+v, want the defaults fit to the same corpus", report.Fitted.Thresholds)
+	}
+}
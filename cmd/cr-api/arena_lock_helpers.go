@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/pkg/analysis"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/urfave/cli/v3"
+)
+
+const ignoreArenaFlagName = "ignore-arena"
+
+// ignoreArenaFlag lets deck tools use cards the player's arena has not
+// unlocked yet, e.g. to plan a deck for the next arena.
+func ignoreArenaFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:  ignoreArenaFlagName,
+		Usage: "Allow cards not yet unlocked at the player's arena",
+	}
+}
+
+// arenaLock returns the arena candidate cards must be unlocked in, or nil
+// when --ignore-arena is set or the arena is unknown.
+func arenaLock(cmd *cli.Command, arenaID int) *evaluation.PlayerContext {
+	if cmd.Bool(ignoreArenaFlagName) {
+		return nil
+	}
+	arena := evaluation.ArenaNumber(arenaID)
+	if arena == 0 {
+		return nil
+	}
+	return &evaluation.PlayerContext{ArenaID: arena, ArenaName: evaluation.ArenaName(arena)}
+}
+
+// arenaCardFilter returns a filter allowing only cards unlocked at the
+// player's arena, or nil when every card is allowed.
+func arenaCardFilter(cmd *cli.Command, arenaID int) analysis.CardFilter {
+	lock := arenaLock(cmd, arenaID)
+	if lock == nil {
+		return nil
+	}
+	return lock.IsCardUnlockedInArena
+}
+
+// applyArenaLockToAnalysis removes cards the player's arena has not unlocked
+// from the analysis used by the deck builder.
+func applyArenaLockToAnalysis(cmd *cli.Command, cardAnalysis *deck.CardAnalysis, verbose bool) {
+	lock := arenaLock(cmd, cardAnalysis.ArenaID)
+	if lock == nil {
+		return
+	}
+	removed := lock.LockedCards(slices.Sorted(maps.Keys(cardAnalysis.CardLevels)))
+	if len(removed) == 0 {
+		return
+	}
+	cardLevels := maps.Clone(cardAnalysis.CardLevels)
+	for _, name := range removed {
+		delete(cardLevels, name)
+	}
+	cardAnalysis.CardLevels = cardLevels
+	reportArenaLock(lock, len(cardLevels), removed, verbose)
+}
+
+// applyArenaLockToPlayer removes cards the player's arena has not unlocked
+// from the collection and returns their names, so callers can also exclude
+// them from seed decks loaded from storage.
+func applyArenaLockToPlayer(cmd *cli.Command, player *clashroyale.Player, verbose bool) []string {
+	if player == nil {
+		return nil
+	}
+	lock := arenaLock(cmd, player.Arena.ID)
+	if lock == nil {
+		return nil
+	}
+	var removed []string
+	player.Cards = slices.DeleteFunc(player.Cards, func(card clashroyale.Card) bool {
+		if lock.IsCardUnlockedInArena(card.Name) {
+			return false
+		}
+		removed = append(removed, card.Name)
+		return true
+	})
+	if len(removed) == 0 {
+		return nil
+	}
+	slices.Sort(removed)
+	reportArenaLock(lock, len(player.Cards), removed, verbose)
+	return removed
+}
+
+// validateIncludedCardsUnlocked rejects --include-cards the player's arena
+// has not unlocked
+func validateIncludedCardsUnlocked(cmd *cli.Command, includeCards []string, arenaID int) error {
+	lock := arenaLock(cmd, arenaID)
+	if lock == nil {
+		return nil
+	}
+	var trimmed []string
+	for _, card := range includeCards {
+		if card = strings.TrimSpace(card); card != "" {
+			trimmed = append(trimmed, card)
+		}
+	}
+	if locked := lock.LockedCards(trimmed); len(locked) > 0 {
+		return fmt.Errorf("included cards are not unlocked at %s (arena %d): %s (use --%s to allow them)",
+			lock.ArenaName, lock.ArenaID, strings.Join(locked, ", "), ignoreArenaFlagName)
+	}
+	return nil
+}
+
+func reportArenaLock(lock *evaluation.PlayerContext, kept int, removed []string, verbose bool) {
+	fprintf(os.Stderr, "Arena %d (%s): %d cards available, %d not yet unlocked (use --%s to keep them)\n",
+		lock.ArenaID, lock.ArenaName, kept, len(removed), ignoreArenaFlagName)
+	if verbose {
+		fprintf(os.Stderr, "  Locked: %s\n", strings.Join(removed, ", "))
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/recommend"
+	"github.com/urfave/cli/v3"
+)
+
+// runWithArenaFlag runs fn inside a command that has --ignore-arena
+func runWithArenaFlag(t *testing.T, fn func(cmd *cli.Command), args ...string) {
+	t.Helper()
+	cmd := &cli.Command{
+		Name:  "test",
+		Flags: []cli.Flag{ignoreArenaFlag()},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			fn(cmd)
+			return nil
+		},
+	}
+	if err := cmd.Run(context.Background(), append([]string{"test"}, args...)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func arenaTestPlayer() *clashroyale.Player {
+	return &clashroyale.Player{
+		Arena: clashroyale.Arena{ID: 54000006, Name: "Builder's Workshop"},
+		Cards: []clashroyale.Card{
+			{Name: "Knight"}, {Name: "Mega Knight"}, {Name: "Hog Rider"}, {Name: "Electro Wizard"}, {Name: "Miner"},
+		},
+	}
+}
+
+func TestApplyArenaLockToPlayer(t *testing.T) {
+	runWithArenaFlag(t, func(cmd *cli.Command) {
+		player := arenaTestPlayer()
+		removed := applyArenaLockToPlayer(cmd, player, false)
+		if !slices.Equal(removed, []string{"Electro Wizard", "Mega Knight"}) {
+			t.Errorf("removed = %v, want the cards unlocked after arena 6", removed)
+		}
+		if len(player.Cards) != 3 {
+			t.Errorf("remaining cards = %+v", player.Cards)
+		}
+
+		// Arena unknown, e.g. an analysis saved before arenas were recorded
+		unknown := &clashroyale.Player{Cards: arenaTestPlayer().Cards}
+		if removed := applyArenaLockToPlayer(cmd, unknown, false); removed != nil || len(unknown.Cards) != 5 {
+			t.Errorf("unknown arena removed %v", removed)
+		}
+	})
+
+	runWithArenaFlag(t, func(cmd *cli.Command) {
+		player := arenaTestPlayer()
+		if removed := applyArenaLockToPlayer(cmd, player, false); removed != nil || len(player.Cards) != 5 {
+			t.Errorf("--ignore-arena removed %v", removed)
+		}
+	}, "--ignore-arena")
+}
+
+func TestApplyArenaLockToAnalysis(t *testing.T) {
+	runWithArenaFlag(t, func(cmd *cli.Command) {
+		levels := map[string]deck.CardLevelData{"Knight": {}, "Mega Knight": {}, "Miner": {}}
+		analysis := deck.CardAnalysis{ArenaID: 54000006, CardLevels: levels}
+		applyArenaLockToAnalysis(cmd, &analysis, false)
+		if _, ok := analysis.CardLevels["Mega Knight"]; ok || len(analysis.CardLevels) != 2 {
+			t.Errorf("card levels = %v, want Mega Knight removed", analysis.CardLevels)
+		}
+		if len(levels) != 3 {
+			t.Error("the caller's card levels should not be modified")
+		}
+	})
+}
+
+func TestValidateIncludedCardsUnlocked(t *testing.T) {
+	runWithArenaFlag(t, func(cmd *cli.Command) {
+		if err := validateIncludedCardsUnlocked(cmd, []string{" Hog Rider ", "Miner"}, 54000006); err != nil {
+			t.Errorf("unlocked includes rejected: %v", err)
+		}
+		err := validateIncludedCardsUnlocked(cmd, []string{"Hog Rider", "Mega Knight"}, 54000006)
+		if err == nil || !strings.Contains(err.Error(), "Mega Knight") || !strings.Contains(err.Error(), "--ignore-arena") {
+			t.Errorf("err = %v, want Mega Knight rejected with the override named", err)
+		}
+	})
+	runWithArenaFlag(t, func(cmd *cli.Command) {
+		if err := validateIncludedCardsUnlocked(cmd, []string{"Mega Knight"}, 54000006); err != nil {
+			t.Errorf("--ignore-arena: %v", err)
+		}
+	}, "--ignore-arena")
+}
+
+func TestApplyRecommendationFiltersArenaLock(t *testing.T) {
+	runWithArenaFlag(t, func(cmd *cli.Command) {
+		result := &recommend.RecommendationResult{Recommendations: []*recommend.DeckRecommendation{
+			{ArchetypeName: "Beatdown", Deck: &deck.DeckRecommendation{Deck: []string{"Mega Knight", "Knight"}}},
+			{ArchetypeName: "Cycle", Deck: &deck.DeckRecommendation{Deck: []string{"Hog Rider", "Knight"}}},
+		}}
+		applyRecommendationFilters(result, deck.CardAnalysis{}, "", true, nil, arenaLock(cmd, 54000006))
+		if len(result.Recommendations) != 1 || result.TopArchetype != "Cycle" {
+			t.Errorf("recommendations = %d, top %q; want only the unlocked cycle deck", len(result.Recommendations), result.TopArchetype)
+		}
+	})
+}
@@ -18,7 +18,7 @@ func TestSaveTaggedJSONArtifactTimestamped(t *testing.T) {
 	dir := t.TempDir()
 	payload := map[string]string{"status": "ok"}
 
-	path, err := saveTaggedJSONArtifact(dir, "#TAG123", payload, taggedJSONArtifactOptions{
+	path, err := saveTaggedJSONArtifact(dir, "#9YLQ0", payload, taggedJSONArtifactOptions{
 		subdir:      "budget",
 		fileStem:    "budget",
 		timestamped: true,
@@ -27,7 +27,7 @@ func TestSaveTaggedJSONArtifactTimestamped(t *testing.T) {
 		t.Fatalf("saveTaggedJSONArtifact() error = %v", err)
 	}
 
-	pattern := regexp.MustCompile(`budget/\d{8}_\d{6}_budget_TAG123\.json$`)
+	pattern := regexp.MustCompile(`budget/\d{8}_\d{6}_budget_9YLQ0\.json$`)
 	if !pattern.MatchString(filepath.ToSlash(path)) {
 		t.Fatalf("saveTaggedJSONArtifact() path = %q, want timestamped budget path", path)
 	}
@@ -102,7 +102,7 @@ func TestSaveUpgradeImpactAnalysisSanitizesPlayerTag(t *testing.T) {
 
 	dir := t.TempDir()
 	impactAnalysis := &analysis.UpgradeImpactAnalysis{
-		PlayerTag: "#ABC123",
+		PlayerTag: "#2PQ0R",
 	}
 
 	path, err := saveUpgradeImpactAnalysis(dir, impactAnalysis)
@@ -110,7 +110,7 @@ func TestSaveUpgradeImpactAnalysisSanitizesPlayerTag(t *testing.T) {
 		t.Fatalf("saveUpgradeImpactAnalysis() error = %v", err)
 	}
 
-	pattern := regexp.MustCompile(`analysis/upgrade_impact_ABC123_\d{8}_\d{6}\.json$`)
+	pattern := regexp.MustCompile(`analysis/upgrade_impact_2PQ0R_\d{8}_\d{6}\.json$`)
 	if !pattern.MatchString(filepath.ToSlash(path)) {
 		t.Fatalf("saveUpgradeImpactAnalysis() path = %q, want sanitized timestamped analysis path", path)
 	}
@@ -127,7 +127,7 @@ func TestSaveUpgradeImpactAnalysisSanitizesPlayerTag(t *testing.T) {
 func TestSaveTaggedTextArtifactTimestamped(t *testing.T) {
 	dir := t.TempDir()
 	output, err := captureStdout(t, func() error {
-		path, err := saveTaggedTextArtifact(dir, "#TAG123", "report body", taggedTextArtifactOptions{
+		path, err := saveTaggedTextArtifact(dir, "#9YLQ0", "report body", taggedTextArtifactOptions{
 			subdir:      "reports",
 			fileStem:    "deck_evaluations",
 			extension:   "md",
@@ -138,7 +138,7 @@ func TestSaveTaggedTextArtifactTimestamped(t *testing.T) {
 			return err
 		}
 
-		pattern := regexp.MustCompile(`reports/\d{8}_\d{6}_deck_evaluations_TAG123\.md$`)
+		pattern := regexp.MustCompile(`reports/\d{8}_\d{6}_deck_evaluations_9YLQ0\.md$`)
 		if !pattern.MatchString(filepath.ToSlash(path)) {
 			t.Fatalf("saveTaggedTextArtifact() path = %q, want timestamped markdown path", path)
 		}
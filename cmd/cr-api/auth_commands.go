@@ -0,0 +1,256 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/internal/credentials"
+	"github.com/urfave/cli/v3"
+	"golang.org/x/term"
+)
+
+// addAuthCommands adds the auth command group that keeps the API token in
+// the OS keychain or an encrypted file instead of the environment
+func addAuthCommands() *cli.Command {
+	return &cli.Command{
+		Name:  "auth",
+		Usage: "Store the API token in the OS keychain or an encrypted file",
+		Commands: []*cli.Command{
+			{
+				Name:  "login",
+				Usage: "Save an API token; it is then used whenever --api-token and CLASH_ROYALE_API_TOKEN are unset",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "store",
+						Usage: "Where to keep the token: keychain or file (default: keychain when available, else file)",
+					},
+					&cli.StringFlag{
+						Name:  "token",
+						Usage: "Token to save; prefer the prompt or piped stdin so it stays out of shell history",
+					},
+				},
+				Action: authLoginCommand,
+			},
+			{
+				Name:   "logout",
+				Usage:  "Remove the stored API token",
+				Action: authLogoutCommand,
+			},
+			{
+				Name:   "status",
+				Usage:  "Show where the API token comes from",
+				Action: authStatusCommand,
+			},
+		},
+	}
+}
+
+func authLoginCommand(_ context.Context, cmd *cli.Command) error {
+	kind, err := credentials.ParseStoreKind(strings.TrimSpace(cmd.String("store")))
+	if err != nil {
+		return err
+	}
+	token := strings.TrimSpace(cmd.String("token"))
+	if token == "" {
+		token, err = readSecret("Clash Royale API token: ")
+		if err != nil {
+			return err
+		}
+	}
+	if token == "" {
+		return errors.New("no API token given")
+	}
+
+	passphrase := ""
+	if kind == credentials.StoreFile {
+		if passphrase, err = newPassphrase(); err != nil {
+			return err
+		}
+	}
+	if err := credentials.Save(kind, token, passphrase); err != nil {
+		return err
+	}
+	printf("Saved API token (%s) in %s\n", maskSecret(token), describeStore(kind))
+	return nil
+}
+
+func authLogoutCommand(_ context.Context, _ *cli.Command) error {
+	removed, err := credentials.Delete()
+	if err != nil {
+		return err
+	}
+	if !removed {
+		printf("No stored API token\n")
+		return nil
+	}
+	printf("Removed stored API token\n")
+	return nil
+}
+
+func authStatusCommand(_ context.Context, _ *cli.Command) error {
+	if os.Getenv(apiTokenEnvVar) != "" {
+		printf("%s is set and takes precedence over stored credentials\n", apiTokenEnvVar)
+	} else if file, err := config.LoadFile(config.FilePath()); err == nil {
+		if _, ok := file.Lookup("api-token"); ok {
+			printf("api-token is set in %s and takes precedence over stored credentials\n", file.Path)
+		}
+	}
+
+	token, kind, err := credentials.Load(storedTokenPassphrase)
+	if errors.Is(err, credentials.ErrNotStored) {
+		printf("No stored API token (run 'cr-api auth login')\n")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	printf("Stored API token %s in %s\n", maskSecret(token), describeStore(kind))
+	return nil
+}
+
+func describeStore(kind credentials.StoreKind) string {
+	if kind == credentials.StoreKeychain {
+		return credentials.NewKeychain().Name()
+	}
+	file, err := credentials.DefaultEncryptedFile()
+	if err != nil {
+		return "encrypted file"
+	}
+	return "encrypted file " + file.Path
+}
+
+// readSecret reads a line without echo from a terminal, or the first line
+// of piped stdin.
+func readSecret(prompt string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		fprintf(os.Stderr, "%s", prompt)
+		secret, err := term.ReadPassword(fd)
+		fprintf(os.Stderr, "\n")
+		if err != nil {
+			return "", fmt.Errorf("failed to read input: %w", err)
+		}
+		return strings.TrimSpace(string(secret)), nil
+	}
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read stdin: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// newPassphrase returns the passphrase for a new encrypted file from
+// CR_API_TOKEN_PASSPHRASE, or prompts for it twice.
+func newPassphrase() (string, error) {
+	if passphrase := os.Getenv(credentials.PassphraseEnvVar); passphrase != "" {
+		return passphrase, nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("set %s to encrypt the token without a terminal", credentials.PassphraseEnvVar)
+	}
+	passphrase, err := readSecret("Passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", errors.New("passphrase is empty")
+	}
+	confirm, err := readSecret("Repeat passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if confirm != passphrase {
+		return "", errors.New("passphrases do not match")
+	}
+	return passphrase, nil
+}
+
+// storedTokenPassphrase returns the encrypted file passphrase from
+// CR_API_TOKEN_PASSPHRASE, or prompts for it on a terminal.
+func storedTokenPassphrase() (string, error) {
+	if passphrase := os.Getenv(credentials.PassphraseEnvVar); passphrase != "" {
+		return passphrase, nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("the stored API token is encrypted; set %s", credentials.PassphraseEnvVar)
+	}
+	return readSecret("Passphrase for the stored API token: ")
+}
+
+// storedToken loads the token saved by `auth login` once per process. It
+// stays disabled until applyStoredToken runs, so tests that call the token
+// helpers directly never read the developer's own credentials.
+var storedToken = struct {
+	sync.Mutex
+	enabled bool
+	token   string
+	loaded  bool
+}{}
+
+// loadStoredToken returns the stored token, or "" when there is none. With
+// prompt false an encrypted file is only opened when CR_API_TOKEN_PASSPHRASE
+// is set, so commands that never use the token do not ask for a passphrase.
+func loadStoredToken(prompt bool) string {
+	storedToken.Lock()
+	defer storedToken.Unlock()
+	if !storedToken.enabled {
+		return ""
+	}
+	if storedToken.loaded {
+		return storedToken.token
+	}
+	kind, err := credentials.StoredKind()
+	if errors.Is(err, credentials.ErrNotStored) {
+		storedToken.loaded = true
+		return ""
+	}
+	if err == nil && kind == credentials.StoreFile && !prompt && os.Getenv(credentials.PassphraseEnvVar) == "" {
+		return ""
+	}
+	storedToken.loaded = true
+	if err == nil {
+		storedToken.token, _, err = credentials.Load(storedTokenPassphrase)
+	}
+	if err != nil {
+		fprintf(os.Stderr, "Warning: ignoring stored API token: %v\n", err)
+	}
+	return storedToken.token
+}
+
+// storedTokenSource feeds --api-token from `auth login`. It sits after the
+// environment and config file, so precedence is flag > env > config >
+// stored token.
+type storedTokenSource struct{}
+
+func (storedTokenSource) Lookup() (string, bool) {
+	token := loadStoredToken(false)
+	return token, token != ""
+}
+
+func (storedTokenSource) String() string {
+	return "token stored by 'cr-api auth login'"
+}
+
+func (storedTokenSource) GoString() string {
+	return "&storedTokenSource{}"
+}
+
+// applyStoredToken makes the stored token the last source of every
+// --api-token flag in the command tree.
+func applyStoredToken(root *cli.Command) {
+	storedToken.Lock()
+	storedToken.enabled = true
+	storedToken.Unlock()
+	visitConfigurableFlags(root, func(name string, sources *cli.ValueSourceChain, _ cli.Flag) {
+		if name == "api-token" {
+			sources.Chain = append(sources.Chain, storedTokenSource{})
+		}
+	})
+}
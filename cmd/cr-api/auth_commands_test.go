@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/internal/credentials"
+	"github.com/urfave/cli/v3"
+)
+
+// useStoredToken enables stored credentials for one test and resets the
+// per-process cache afterwards.
+func useStoredToken(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(config.FileEnvVar, "")
+	// An empty but set variable still wins over later sources
+	t.Setenv(apiTokenEnvVar, "")
+	os.Unsetenv(apiTokenEnvVar)
+	t.Setenv(credentials.PassphraseEnvVar, "test passphrase")
+	reset := func() {
+		storedToken.Lock()
+		storedToken.enabled, storedToken.loaded, storedToken.token = false, false, ""
+		storedToken.Unlock()
+	}
+	reset()
+	t.Cleanup(reset)
+}
+
+func runAuthCommand(t *testing.T, args ...string) string {
+	t.Helper()
+	root := &cli.Command{Name: "cr-api", Commands: []*cli.Command{addAuthCommands()}}
+	out, err := captureStdout(t, func() error {
+		return root.Run(context.Background(), append([]string{"cr-api", "auth"}, args...))
+	})
+	if err != nil {
+		t.Fatalf("auth %v: %v", args, err)
+	}
+	return out
+}
+
+func TestAuthLoginStatusLogout(t *testing.T) {
+	useStoredToken(t)
+
+	if out := runAuthCommand(t, "status"); !strings.Contains(out, "No stored API token") {
+		t.Errorf("status before login = %q", out)
+	}
+	out := runAuthCommand(t, "login", "--store", "file", "--token", "abcdefgh1234")
+	if !strings.Contains(out, "********1234") || !strings.Contains(out, credentials.EncryptedFileName) {
+		t.Errorf("login output = %q", out)
+	}
+	if strings.Contains(out, "abcdefgh1234") {
+		t.Errorf("login output shows the token: %q", out)
+	}
+	if out := runAuthCommand(t, "status"); !strings.Contains(out, "Stored API token ********1234") {
+		t.Errorf("status after login = %q", out)
+	}
+
+	t.Setenv(apiTokenEnvVar, "env-token")
+	if out := runAuthCommand(t, "status"); !strings.Contains(out, "takes precedence") {
+		t.Errorf("status with env token = %q", out)
+	}
+
+	if out := runAuthCommand(t, "logout"); !strings.Contains(out, "Removed stored API token") {
+		t.Errorf("logout output = %q", out)
+	}
+	if out := runAuthCommand(t, "logout"); !strings.Contains(out, "No stored API token") {
+		t.Errorf("second logout output = %q", out)
+	}
+}
+
+func TestStoredTokenPrecedence(t *testing.T) {
+	useStoredToken(t)
+	if err := credentials.Save(credentials.StoreFile, "stored-token", "test passphrase"); err != nil {
+		t.Fatal(err)
+	}
+
+	var got string
+	newRoot := func() *cli.Command {
+		return &cli.Command{
+			Name:  "cr-api",
+			Flags: []cli.Flag{&cli.StringFlag{Name: "api-token", Sources: cli.EnvVars(apiTokenEnvVar)}},
+			Commands: []*cli.Command{{
+				Name: "probe",
+				Action: func(_ context.Context, cmd *cli.Command) error {
+					got = cmd.String("api-token")
+					return nil
+				},
+			}},
+		}
+	}
+	run := func() {
+		t.Helper()
+		root := newRoot()
+		applyStoredToken(root)
+		if err := root.Run(context.Background(), []string{"cr-api", "probe"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	run()
+	if got != "stored-token" {
+		t.Errorf("api-token = %q, want the stored token", got)
+	}
+
+	t.Setenv(apiTokenEnvVar, "env-token")
+	run()
+	if got != "env-token" {
+		t.Errorf("api-token = %q, want the env token to win", got)
+	}
+	os.Unsetenv(apiTokenEnvVar)
+	if token, err := requireAPITokenValue("", apiClientOptions{}); err != nil || token != "stored-token" {
+		t.Errorf("requireAPITokenValue() = %q, %v, want the stored token", token, err)
+	}
+}
@@ -0,0 +1,398 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"runtime"
+	"text/tabwriter"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/genetic"
+	"github.com/urfave/cli/v3"
+)
+
+// benchWorkloadVersion changes whenever the standardized workload does, so
+// results from different workloads are never compared.
+const benchWorkloadVersion = 1
+
+// benchCollection is the fixed card collection the workload runs against, so
+// results do not depend on any player's cards.
+var benchCollection = []clashroyale.Card{
+	{Name: "Hog Rider", Level: 14, MaxLevel: 16, Rarity: "Rare", ElixirCost: 4},
+	{Name: "Giant", Level: 14, MaxLevel: 16, Rarity: "Rare", ElixirCost: 5},
+	{Name: "Royal Giant", Level: 14, MaxLevel: 16, Rarity: "Common", ElixirCost: 6},
+	{Name: "Balloon", Level: 13, MaxLevel: 16, Rarity: "Epic", ElixirCost: 5},
+	{Name: "Golem", Level: 13, MaxLevel: 16, Rarity: "Epic", ElixirCost: 8},
+	{Name: "X-Bow", Level: 13, MaxLevel: 16, Rarity: "Epic", ElixirCost: 6},
+	{Name: "Miner", Level: 13, MaxLevel: 16, Rarity: "Legendary", ElixirCost: 3},
+	{Name: "Goblin Barrel", Level: 13, MaxLevel: 16, Rarity: "Epic", ElixirCost: 3},
+	{Name: "Knight", Level: 15, MaxLevel: 16, Rarity: "Common", ElixirCost: 3, EvolutionLevel: 1, MaxEvolutionLevel: 1},
+	{Name: "Valkyrie", Level: 14, MaxLevel: 16, Rarity: "Rare", ElixirCost: 4},
+	{Name: "Mini P.E.K.K.A", Level: 14, MaxLevel: 16, Rarity: "Rare", ElixirCost: 4},
+	{Name: "Musketeer", Level: 14, MaxLevel: 16, Rarity: "Rare", ElixirCost: 4},
+	{Name: "Wizard", Level: 14, MaxLevel: 16, Rarity: "Rare", ElixirCost: 5},
+	{Name: "Baby Dragon", Level: 13, MaxLevel: 16, Rarity: "Epic", ElixirCost: 4},
+	{Name: "Electro Wizard", Level: 13, MaxLevel: 16, Rarity: "Legendary", ElixirCost: 4},
+	{Name: "Archers", Level: 15, MaxLevel: 16, Rarity: "Common", ElixirCost: 3, EvolutionLevel: 1, MaxEvolutionLevel: 1},
+	{Name: "Minions", Level: 15, MaxLevel: 16, Rarity: "Common", ElixirCost: 3},
+	{Name: "Minion Horde", Level: 15, MaxLevel: 16, Rarity: "Common", ElixirCost: 5},
+	{Name: "Skeleton Army", Level: 13, MaxLevel: 16, Rarity: "Epic", ElixirCost: 3},
+	{Name: "Goblin Gang", Level: 15, MaxLevel: 16, Rarity: "Common", ElixirCost: 3},
+	{Name: "Skeletons", Level: 15, MaxLevel: 16, Rarity: "Common", ElixirCost: 1},
+	{Name: "Ice Spirit", Level: 15, MaxLevel: 16, Rarity: "Common", ElixirCost: 1},
+	{Name: "Ice Golem", Level: 14, MaxLevel: 16, Rarity: "Rare", ElixirCost: 2},
+	{Name: "Bats", Level: 15, MaxLevel: 16, Rarity: "Common", ElixirCost: 2},
+	{Name: "Cannon", Level: 15, MaxLevel: 16, Rarity: "Common", ElixirCost: 3},
+	{Name: "Tesla", Level: 15, MaxLevel: 16, Rarity: "Common", ElixirCost: 4},
+	{Name: "Inferno Tower", Level: 14, MaxLevel: 16, Rarity: "Rare", ElixirCost: 5},
+	{Name: "Bomb Tower", Level: 14, MaxLevel: 16, Rarity: "Rare", ElixirCost: 4},
+	{Name: "Fireball", Level: 14, MaxLevel: 16, Rarity: "Rare", ElixirCost: 4},
+	{Name: "Poison", Level: 13, MaxLevel: 16, Rarity: "Epic", ElixirCost: 4},
+	{Name: "Rocket", Level: 14, MaxLevel: 16, Rarity: "Rare", ElixirCost: 6},
+	{Name: "Arrows", Level: 15, MaxLevel: 16, Rarity: "Common", ElixirCost: 3},
+	{Name: "Zap", Level: 15, MaxLevel: 16, Rarity: "Common", ElixirCost: 2},
+	{Name: "The Log", Level: 13, MaxLevel: 16, Rarity: "Legendary", ElixirCost: 2},
+	{Name: "Tornado", Level: 13, MaxLevel: 16, Rarity: "Epic", ElixirCost: 3},
+	{Name: "Earthquake", Level: 14, MaxLevel: 16, Rarity: "Rare", ElixirCost: 3},
+}
+
+// benchWorkload sizes the standardized workload.
+type benchWorkload struct {
+	Version       int   `json:"version"`
+	Decks         int   `json:"decks"`
+	GAPopulation  int   `json:"ga_population"`
+	GAGenerations int   `json:"ga_generations"`
+	Seed          int64 `json:"seed"`
+}
+
+// benchStage is one timed part of the workload.
+type benchStage struct {
+	Name      string  `json:"name"`
+	Ops       int     `json:"ops"`
+	Unit      string  `json:"unit"`
+	Seconds   float64 `json:"seconds"`
+	PerSecond float64 `json:"per_second"`
+}
+
+// benchReport is the result of a bench run; --output saves it for --baseline.
+type benchReport struct {
+	Version   string        `json:"version"`
+	GoVersion string        `json:"go_version"`
+	OS        string        `json:"os"`
+	Arch      string        `json:"arch"`
+	CPUs      int           `json:"cpus"`
+	StartedAt time.Time     `json:"started_at"`
+	Workload  benchWorkload `json:"workload"`
+	Stages    []benchStage  `json:"stages"`
+}
+
+func (r *benchReport) stage(name string) (benchStage, bool) {
+	for _, stage := range r.Stages {
+		if stage.Name == name {
+			return stage, true
+		}
+	}
+	return benchStage{}, false
+}
+
+// addBenchCommand adds the bench command
+func addBenchCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "bench",
+		Usage: "Run a standardized deck generation, evaluation, and GA workload and report throughput",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "decks",
+				Value: 2000,
+				Usage: "Decks to generate, evaluate, and classify",
+			},
+			&cli.IntFlag{
+				Name:  "ga-population",
+				Value: 50,
+				Usage: "Genetic algorithm population size",
+			},
+			&cli.IntFlag{
+				Name:  "ga-generations",
+				Value: 10,
+				Usage: "Genetic algorithm generations",
+			},
+			&cli.Int64Flag{
+				Name:  "seed",
+				Value: 1,
+				Usage: "Random seed for the workload",
+			},
+			&cli.StringFlag{
+				Name:  "baseline",
+				Usage: "Compare against a report saved with --output",
+			},
+			&cli.Float64Flag{
+				Name:  "max-regression",
+				Usage: "With --baseline, fail when a stage is more than this percent slower (0 = report only)",
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "Save the report as JSON to this file",
+			},
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "Print the report as JSON",
+			},
+		},
+		Action: benchCommand,
+	}
+}
+
+func benchCommand(ctx context.Context, cmd *cli.Command) error {
+	workload := benchWorkload{
+		Version:       benchWorkloadVersion,
+		Decks:         cmd.Int("decks"),
+		GAPopulation:  cmd.Int("ga-population"),
+		GAGenerations: cmd.Int("ga-generations"),
+		Seed:          cmd.Int64("seed"),
+	}
+	if workload.Decks < 1 {
+		return fmt.Errorf("decks must be at least 1")
+	}
+	if workload.GAPopulation < 2 || workload.GAGenerations < 1 {
+		return fmt.Errorf("ga-population must be at least 2 and ga-generations at least 1")
+	}
+
+	var baseline *benchReport
+	if path := cmd.String("baseline"); path != "" {
+		loaded, err := loadBenchReport(path)
+		if err != nil {
+			return err
+		}
+		if loaded.Workload != workload {
+			return fmt.Errorf("baseline %s ran a different workload (%+v); rerun it or match its flags", path, loaded.Workload)
+		}
+		baseline = loaded
+	}
+
+	report, err := runBenchWorkload(ctx, workload, cmd.Bool("verbose"))
+	if err != nil {
+		return err
+	}
+
+	if path := cmd.String("output"); path != "" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode bench report: %w", err)
+		}
+		if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+			return fmt.Errorf("failed to write bench report: %w", err)
+		}
+	}
+
+	if cmd.Bool("json") {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode bench report: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		displayBenchReport(report, baseline)
+	}
+
+	if maxRegression := cmd.Float64("max-regression"); baseline != nil && maxRegression > 0 {
+		if regressed := benchRegressions(report, baseline, maxRegression); len(regressed) > 0 {
+			return fmt.Errorf("performance regressed more than %.0f%% in: %v", maxRegression, regressed)
+		}
+	}
+	return nil
+}
+
+// runBenchWorkload times deck generation, evaluation, archetype detection,
+// and a GA run over benchCollection, all on one goroutine so results compare
+// across machines with different core counts.
+func runBenchWorkload(ctx context.Context, workload benchWorkload, verbose bool) (*benchReport, error) {
+	report := &benchReport{
+		Version:   version,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		CPUs:      runtime.NumCPU(),
+		StartedAt: time.Now().UTC(),
+		Workload:  workload,
+	}
+	player := &clashroyale.Player{Tag: "#BENCH", Name: "bench", Cards: benchCollection}
+
+	// Deck generation
+	fuzzer, err := deck.NewDeckFuzzer(player, &deck.FuzzingConfig{Seed: workload.Seed})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create deck fuzzer: %w", err)
+	}
+	rng := rand.New(rand.NewSource(workload.Seed))
+	decks := make([][]string, 0, workload.Decks)
+	started := time.Now()
+	for len(decks) < workload.Decks {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		generated, err := fuzzer.GenerateRandomDeckWithRng(rng)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate deck: %w", err)
+		}
+		decks = append(decks, generated)
+	}
+	report.Stages = append(report.Stages, newBenchStage("generate", len(decks), "decks", time.Since(started)))
+	if verbose {
+		fprintf(os.Stderr, "Generated %d decks\n", len(decks))
+	}
+
+	candidates := make([][]deck.CardCandidate, len(decks))
+	for i, cards := range decks {
+		candidates[i] = convertDeckToCandidates(cards, player)
+	}
+
+	// Evaluation
+	synergyDB := deck.NewSynergyDatabase()
+	started = time.Now()
+	for _, cards := range candidates {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		evaluation.Evaluate(cards, synergyDB, nil)
+	}
+	report.Stages = append(report.Stages, newBenchStage("evaluate", len(candidates), "evals", time.Since(started)))
+
+	// Archetype detection
+	started = time.Now()
+	for _, cards := range candidates {
+		evaluation.DetectArchetype(cards)
+	}
+	report.Stages = append(report.Stages, newBenchStage("archetype", len(candidates), "decks", time.Since(started)))
+	if verbose {
+		fprintf(os.Stderr, "Evaluated and classified %d decks\n", len(candidates))
+	}
+
+	// Genetic algorithm
+	gaStage, err := runBenchGenetic(player, workload)
+	if err != nil {
+		return nil, err
+	}
+	report.Stages = append(report.Stages, gaStage...)
+	return report, nil
+}
+
+// runBenchGenetic runs the GA with the default fuzz fitness and reports
+// generations/sec and evaluations/sec. The optimizer skips decks it has
+// already scored in the run, so evaluations counts distinct decks.
+func runBenchGenetic(player *clashroyale.Player, workload benchWorkload) ([]benchStage, error) {
+	candidates, err := buildGeneticCandidates(player, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	config := genetic.DefaultGeneticConfig()
+	config.PopulationSize = workload.GAPopulation
+	config.Generations = workload.GAGenerations
+	config.EliteCount = min(config.EliteCount, workload.GAPopulation/2)
+	config.TournamentSize = min(config.TournamentSize, workload.GAPopulation)
+	config.ConvergenceGenerations = 0
+	config.ParallelEvaluations = false
+
+	optimizer, err := genetic.NewGeneticOptimizer(candidates, deck.StrategyBalanced, &config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create genetic optimizer: %w", err)
+	}
+	optimizer.RNG = rand.New(rand.NewSource(workload.Seed))
+	fitness, _ := selectGAFitnessEvaluator(false, evaluation.GameModeStandard)
+	evaluations := 0
+	optimizer.FitnessFunc = func(cards []deck.CardCandidate) (float64, error) {
+		evaluations++
+		return fitness(cards)
+	}
+
+	started := time.Now()
+	result, err := optimizer.Optimize()
+	if err != nil {
+		return nil, fmt.Errorf("genetic optimization failed: %w", err)
+	}
+	elapsed := time.Since(started)
+	return []benchStage{
+		newBenchStage("ga-generations", int(result.Generations), "generations", elapsed),
+		newBenchStage("ga-evaluations", evaluations, "evals", elapsed),
+	}, nil
+}
+
+func newBenchStage(name string, ops int, unit string, elapsed time.Duration) benchStage {
+	stage := benchStage{Name: name, Ops: ops, Unit: unit, Seconds: elapsed.Seconds()}
+	if stage.Seconds > 0 {
+		stage.PerSecond = float64(ops) / stage.Seconds
+	}
+	return stage
+}
+
+func loadBenchReport(path string) (*benchReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+	var report benchReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+	return &report, nil
+}
+
+// benchChange is the throughput change from baseline in percent; negative
+// means slower.
+func benchChange(stage benchStage, baseline *benchReport) (float64, bool) {
+	if baseline == nil {
+		return 0, false
+	}
+	before, ok := baseline.stage(stage.Name)
+	if !ok || before.PerSecond <= 0 {
+		return 0, false
+	}
+	return (stage.PerSecond/before.PerSecond - 1) * 100, true
+}
+
+// benchRegressions lists the stages more than maxPercent slower than baseline.
+func benchRegressions(report, baseline *benchReport, maxPercent float64) []string {
+	var regressed []string
+	for _, stage := range report.Stages {
+		if change, ok := benchChange(stage, baseline); ok && change < -maxPercent {
+			regressed = append(regressed, stage.Name)
+		}
+	}
+	return regressed
+}
+
+func displayBenchReport(report, baseline *benchReport) {
+	printf("\nBENCHMARK\n")
+	printf("=========\n\n")
+	printf("cr-api %s, %s %s/%s, %d CPUs (single-threaded workload v%d, seed %d)\n\n",
+		report.Version, report.GoVersion, report.OS, report.Arch, report.CPUs, report.Workload.Version, report.Workload.Seed)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if baseline != nil {
+		fprintf(w, "Stage\tOps\tTime\tRate\tvs %s\t\n", baseline.Version)
+		fprintf(w, "-----\t---\t----\t----\t---\t\n")
+	} else {
+		fprintf(w, "Stage\tOps\tTime\tRate\t\n")
+		fprintf(w, "-----\t---\t----\t----\t\n")
+	}
+	for _, stage := range report.Stages {
+		fprintf(w, "%s\t%d\t%.2fs\t%.0f %s/sec\t", stage.Name, stage.Ops, stage.Seconds, stage.PerSecond, stage.Unit)
+		if baseline != nil {
+			if change, ok := benchChange(stage, baseline); ok {
+				fprintf(w, "%+.1f%%\t", change)
+			} else {
+				fprintf(w, "-\t")
+			}
+		}
+		fprintf(w, "\n")
+	}
+	flushWriter(w)
+
+	generate, _ := report.stage("generate")
+	evaluate, _ := report.stage("evaluate")
+	printf("\ndecks/sec: %.0f  evals/sec: %.0f\n", generate.PerSecond, evaluate.PerSecond)
+}
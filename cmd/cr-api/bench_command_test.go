@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+)
+
+func newBenchTestRoot() *cli.Command {
+	return &cli.Command{
+		Name:     "cr-api",
+		Flags:    []cli.Flag{&cli.BoolFlag{Name: "verbose"}},
+		Commands: []*cli.Command{addBenchCommand()},
+	}
+}
+
+func TestRunBenchWorkload(t *testing.T) {
+	workload := benchWorkload{Version: benchWorkloadVersion, Decks: 20, GAPopulation: 8, GAGenerations: 2, Seed: 3}
+	report, err := runBenchWorkload(context.Background(), workload, false)
+	if err != nil {
+		t.Fatalf("runBenchWorkload() error = %v", err)
+	}
+
+	wantOps := map[string]int{"generate": 20, "evaluate": 20, "archetype": 20}
+	for _, name := range []string{"generate", "evaluate", "archetype", "ga-generations", "ga-evaluations"} {
+		stage, ok := report.stage(name)
+		if !ok {
+			t.Fatalf("report missing stage %q", name)
+		}
+		if want, ok := wantOps[name]; ok && stage.Ops != want {
+			t.Errorf("stage %q ops = %d, want %d", name, stage.Ops, want)
+		}
+		if stage.Ops <= 0 || stage.PerSecond <= 0 {
+			t.Errorf("stage %q = %+v, want positive ops and rate", name, stage)
+		}
+	}
+}
+
+func TestBenchRegressions(t *testing.T) {
+	baseline := &benchReport{Stages: []benchStage{
+		{Name: "generate", PerSecond: 1000},
+		{Name: "evaluate", PerSecond: 1000},
+	}}
+	report := &benchReport{Stages: []benchStage{
+		{Name: "generate", PerSecond: 950},
+		{Name: "evaluate", PerSecond: 700},
+		{Name: "archetype", PerSecond: 10},
+	}}
+
+	change, ok := benchChange(report.Stages[1], baseline)
+	if !ok || change > -29.9 || change < -30.1 {
+		t.Errorf("benchChange(evaluate) = %.2f, %v; want -30, true", change, ok)
+	}
+	if _, ok := benchChange(report.Stages[2], baseline); ok {
+		t.Error("benchChange() for a stage missing from the baseline should report false")
+	}
+	if got := benchRegressions(report, baseline, 10); len(got) != 1 || got[0] != "evaluate" {
+		t.Errorf("benchRegressions() = %v, want [evaluate]", got)
+	}
+}
+
+func TestBenchCommandBaseline(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "bench.json")
+	args := []string{"cr-api", "bench", "--decks", "10", "--ga-population", "6", "--ga-generations", "1", "--json"}
+
+	if err := newBenchTestRoot().Run(context.Background(), append(args, "--output", output)); err != nil {
+		t.Fatalf("bench --output error = %v", err)
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved benchReport
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatalf("saved report is not JSON: %v", err)
+	}
+	if saved.Workload.Decks != 10 || len(saved.Stages) == 0 {
+		t.Fatalf("saved report = %+v", saved)
+	}
+
+	if err := newBenchTestRoot().Run(context.Background(), append(args, "--baseline", output)); err != nil {
+		t.Errorf("bench --baseline error = %v", err)
+	}
+
+	err = newBenchTestRoot().Run(context.Background(), []string{"cr-api", "bench", "--decks", "5", "--baseline", output})
+	if err == nil || !strings.Contains(err.Error(), "different workload") {
+		t.Errorf("bench with mismatched baseline error = %v, want workload mismatch", err)
+	}
+}
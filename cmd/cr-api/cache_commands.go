@@ -0,0 +1,329 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/apicache"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/urfave/cli/v3"
+)
+
+// cacheWarmResource identifies one cacheable API resource for a player.
+type cacheWarmResource string
+
+const (
+	cacheResourcePlayer    cacheWarmResource = "player"
+	cacheResourceBattleLog cacheWarmResource = "battlelog"
+	cacheResourceChests    cacheWarmResource = "chests"
+)
+
+// cacheWarmTask lists the resources still to fetch for a single player tag.
+type cacheWarmTask struct {
+	Tag       string
+	Resources []cacheWarmResource
+}
+
+// cacheWarmPlan is the deduplicated work list for a warm run.
+type cacheWarmPlan struct {
+	Tasks   []cacheWarmTask
+	Players int
+	Skipped int
+}
+
+// Requests returns the number of API calls the plan needs.
+func (p cacheWarmPlan) Requests() int {
+	total := 0
+	for _, task := range p.Tasks {
+		total += len(task.Resources)
+	}
+	return total
+}
+
+// cacheWarmStats summarizes a warm run.
+type cacheWarmStats struct {
+	Fetched        int
+	Skipped        int
+	Failed         int
+	BudgetExceeded int
+}
+
+// cacheWriter stores every response but never serves from the cache, so warm
+// always refreshes the resources it was asked to fetch.
+type cacheWriter struct {
+	store *apicache.Store
+}
+
+func (w cacheWriter) Get(string) ([]byte, bool) { return nil, false }
+
+func (w cacheWriter) Put(endpoint string, body []byte) error {
+	return w.store.Put(endpoint, body)
+}
+
+// addCacheCommands adds the API response cache commands to the CLI
+func addCacheCommands() *cli.Command {
+	return &cli.Command{
+		Name:  "cache",
+		Usage: "Manage the local API response cache",
+		Commands: []*cli.Command{
+			{
+				Name:  "warm",
+				Usage: "Prefetch players, battle logs, and chests so later analysis runs from cache",
+				Flags: []cli.Flag{
+					tagsFlag(&cli.StringSliceFlag{
+						Name:  "clan",
+						Usage: "Clan tag whose members should be cached (repeatable)",
+					}),
+					tagsFlag(&cli.StringSliceFlag{
+						Name:  "tag",
+						Usage: "Player tag to cache (repeatable)",
+					}),
+					&cli.StringFlag{
+						Name:  "tags-file",
+						Usage: "File with one player tag per line (blank lines and // comments ignored)",
+					},
+					&cli.BoolFlag{
+						Name:  "skip-battles",
+						Usage: "Do not fetch battle logs",
+					},
+					&cli.BoolFlag{
+						Name:  "skip-chests",
+						Usage: "Do not fetch upcoming chests",
+					},
+					&cli.BoolFlag{
+						Name:  "refresh",
+						Usage: "Re-fetch resources even when a fresh cache entry exists",
+					},
+					&cli.IntFlag{
+						Name:  "max-requests",
+						Usage: "API request budget for this run (0 = unlimited)",
+					},
+				},
+				Action: cacheWarmCommand,
+			},
+			{
+				Name:   "clear",
+				Usage:  "Delete all cached API responses",
+				Action: cacheClearCommand,
+			},
+		},
+	}
+}
+
+func cacheWarmCommand(ctx context.Context, cmd *cli.Command) error {
+	dataDir := cmd.String("data-dir")
+	maxAge := cmd.Duration("cache-max-age")
+	budget := cmd.Int("max-requests")
+	verbose := cmd.Bool("verbose")
+
+	if budget < 0 {
+		return fmt.Errorf("--max-requests must be >= 0")
+	}
+	if err := requireOnline(cmd, "cache warm"); err != nil {
+		return err
+	}
+
+	tags := cmd.StringSlice("tag")
+	if path := cmd.String("tags-file"); path != "" {
+		fileTags, err := readTagsFile(path)
+		if err != nil {
+			return err
+		}
+		tags = append(tags, fileTags...)
+	}
+	clans := cmd.StringSlice("clan")
+	if len(tags) == 0 && len(clans) == 0 {
+		return fmt.Errorf("provide at least one --clan, --tag, or --tags-file")
+	}
+
+	client, err := requireAPIClient(cmd, apiClientOptions{})
+	if err != nil {
+		return err
+	}
+	store := apicache.New(dataDir, maxAge)
+	client.SetCache(cacheWriter{store: store})
+
+	for _, clan := range clans {
+		if budget > 0 && client.RequestCount() >= int64(budget) {
+			return fmt.Errorf("request budget of %d exhausted before listing clan %s", budget, clan)
+		}
+		members, err := client.GetClanMembersWithContext(ctx, clan)
+		if err != nil {
+			return fmt.Errorf("failed to get members for clan %s: %w", clan, err)
+		}
+		printf("Clan %s: %d members\n", clashroyale.NormalizeTag(clan), len(members.Items))
+		for _, member := range members.Items {
+			tags = append(tags, member.Tag)
+		}
+	}
+
+	resources := []cacheWarmResource{cacheResourcePlayer}
+	if !cmd.Bool("skip-battles") {
+		resources = append(resources, cacheResourceBattleLog)
+	}
+	if !cmd.Bool("skip-chests") {
+		resources = append(resources, cacheResourceChests)
+	}
+
+	var fresh func(string) bool
+	if !cmd.Bool("refresh") {
+		fresh = store.Fresh
+	}
+	plan := planCacheWarm(tags, resources, fresh)
+	planned := plan.Requests()
+
+	printf("Warming cache for %d players: %d requests planned, %d fresh entries skipped\n", plan.Players, planned, plan.Skipped)
+	if budget > 0 {
+		remaining := budget - int(client.RequestCount())
+		if planned > remaining {
+			printf("Warning: plan exceeds request budget (%d remaining); later players will be left uncached\n", remaining)
+		}
+	}
+	if planned > 0 {
+		// The client is rate limited to one request per second per token.
+		tokens := max(len(client.TokenStats()), 1)
+		printf("Estimated time: ~%s\n", (time.Duration(planned) * time.Second / time.Duration(tokens)).Round(time.Second))
+	}
+
+	stats := runCacheWarm(ctx, client, plan.Tasks, budget, verbose)
+	stats.Skipped = plan.Skipped
+
+	printf("\nCache warm complete: %d fetched, %d skipped (fresh), %d failed", stats.Fetched, stats.Skipped, stats.Failed)
+	if stats.BudgetExceeded > 0 {
+		printf(", %d left uncached (budget)", stats.BudgetExceeded)
+	}
+	printf("\nAPI requests used: %d\n", client.RequestCount())
+	printTokenStats(os.Stdout, client)
+	printf("Cache: %s (served to other commands for %s, see --cache-max-age)\n", store.Dir(), maxAge)
+	if maxAge <= 0 {
+		printf("Warning: --cache-max-age is 0, so analysis commands will not read this cache\n")
+	}
+	return ctx.Err()
+}
+
+// planCacheWarm deduplicates tags and drops resources that are already fresh.
+func planCacheWarm(tags []string, resources []cacheWarmResource, fresh func(endpoint string) bool) cacheWarmPlan {
+	seen := make(map[string]bool, len(tags))
+	plan := cacheWarmPlan{Tasks: make([]cacheWarmTask, 0, len(tags))}
+
+	for _, raw := range tags {
+		tag := clashroyale.NormalizeTag(strings.ToUpper(strings.TrimSpace(raw)))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		plan.Players++
+
+		task := cacheWarmTask{Tag: tag}
+		for _, resource := range resources {
+			if fresh != nil && fresh(cacheWarmEndpoint(tag, resource)) {
+				plan.Skipped++
+				continue
+			}
+			task.Resources = append(task.Resources, resource)
+		}
+		if len(task.Resources) > 0 {
+			plan.Tasks = append(plan.Tasks, task)
+		}
+	}
+	return plan
+}
+
+func cacheWarmEndpoint(tag string, resource cacheWarmResource) string {
+	switch resource {
+	case cacheResourceBattleLog:
+		return clashroyale.PlayerBattleLogEndpoint(tag)
+	case cacheResourceChests:
+		return clashroyale.PlayerUpcomingChestsEndpoint(tag)
+	default:
+		return clashroyale.PlayerEndpoint(tag)
+	}
+}
+
+func runCacheWarm(ctx context.Context, client *clashroyale.Client, tasks []cacheWarmTask, budget int, verbose bool) cacheWarmStats {
+	var stats cacheWarmStats
+
+	for i, task := range tasks {
+		if ctx.Err() != nil {
+			return stats
+		}
+		if budget > 0 && client.RequestCount()+int64(len(task.Resources)) > int64(budget) {
+			for _, rest := range tasks[i:] {
+				stats.BudgetExceeded += len(rest.Resources)
+			}
+			printf("Request budget reached; stopping before %s\n", task.Tag)
+			return stats
+		}
+
+		results := make([]string, 0, len(task.Resources))
+		for _, resource := range task.Resources {
+			var err error
+			switch resource {
+			case cacheResourcePlayer:
+				_, err = client.GetPlayerWithContext(ctx, task.Tag)
+			case cacheResourceBattleLog:
+				_, err = client.GetPlayerBattleLogWithContext(ctx, task.Tag)
+			case cacheResourceChests:
+				_, err = client.GetPlayerUpcomingChestsWithContext(ctx, task.Tag)
+			}
+			if err != nil {
+				stats.Failed++
+				results = append(results, fmt.Sprintf("%s failed", resource))
+				if verbose {
+					fprintf(os.Stderr, "  %s %s: %v\n", task.Tag, resource, err)
+				}
+				continue
+			}
+			stats.Fetched++
+			results = append(results, fmt.Sprintf("%s ok", resource))
+		}
+		printf("[%d/%d] %s: %s\n", i+1, len(tasks), task.Tag, strings.Join(results, ", "))
+	}
+	return stats
+}
+
+// readTagsFile reads player tags from a file, one per line.
+func readTagsFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open tags file: %w", err)
+	}
+	defer closeFile(f)
+
+	var tags []string
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+		tag, err := clashroyale.ParseTag(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNo, err)
+		}
+		tags = append(tags, tag)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read tags file: %w", err)
+	}
+	if len(tags) == 0 {
+		return nil, errors.New("tags file contains no player tags")
+	}
+	return tags, nil
+}
+
+func cacheClearCommand(ctx context.Context, cmd *cli.Command) error {
+	_ = ctx
+	store := apicache.New(cmd.String("data-dir"), 0)
+	removed, err := store.Clear()
+	if err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+	printf("Removed %d cached responses from %s\n", removed, store.Dir())
+	return nil
+}
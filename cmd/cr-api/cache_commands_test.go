@@ -0,0 +1,74 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+func TestPlanCacheWarmDeduplicatesAndSkipsFresh(t *testing.T) {
+	resources := []cacheWarmResource{cacheResourcePlayer, cacheResourceBattleLog, cacheResourceChests}
+	fresh := func(endpoint string) bool {
+		return endpoint == clashroyale.PlayerEndpoint("#AAA")
+	}
+
+	plan := planCacheWarm([]string{"aaa", "#AAA", " #bbb ", ""}, resources, fresh)
+
+	if plan.Players != 2 {
+		t.Errorf("Players = %d, want 2", plan.Players)
+	}
+	if plan.Skipped != 1 {
+		t.Errorf("Skipped = %d, want 1", plan.Skipped)
+	}
+	if got := plan.Requests(); got != 5 {
+		t.Errorf("Requests() = %d, want 5", got)
+	}
+	if plan.Tasks[0].Tag != "#AAA" || len(plan.Tasks[0].Resources) != 2 {
+		t.Errorf("unexpected first task: %+v", plan.Tasks[0])
+	}
+	if plan.Tasks[1].Tag != "#BBB" {
+		t.Errorf("second task tag = %q, want #BBB", plan.Tasks[1].Tag)
+	}
+}
+
+func TestPlanCacheWarmDropsFullyCachedPlayers(t *testing.T) {
+	plan := planCacheWarm([]string{"#AAA"}, []cacheWarmResource{cacheResourcePlayer}, func(string) bool { return true })
+	if len(plan.Tasks) != 0 || plan.Players != 1 || plan.Skipped != 1 {
+		t.Errorf("unexpected plan: %+v", plan)
+	}
+}
+
+func TestReadTagsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tags.txt")
+	content := "#2PP\n\n// officers\n9yl2\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tags, err := readTagsFile(path)
+	if err != nil {
+		t.Fatalf("readTagsFile() error = %v", err)
+	}
+	if len(tags) != 2 || tags[0] != "#2PP" || tags[1] != "#9YL2" {
+		t.Errorf("readTagsFile() = %v", tags)
+	}
+
+	typo := filepath.Join(t.TempDir(), "typo.txt")
+	if err := os.WriteFile(typo, []byte("#2PP\n#9YLO\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readTagsFile(typo); err == nil || !strings.Contains(err.Error(), "typo.txt:2") || !strings.Contains(err.Error(), "did you mean #9YL0?") {
+		t.Errorf("readTagsFile() error = %v, want the line and a suggestion", err)
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty.txt")
+	if err := os.WriteFile(empty, []byte("\n// nothing\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readTagsFile(empty); err == nil {
+		t.Error("expected error for tags file without tags")
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	allowedCardsFileFlagName = "allowed-cards-file"
+	banRarityFlagName        = "ban-rarity"
+)
+
+// cardPoolFlags returns the flags that restrict a command to an event's card
+// pool, plus the arena unlock override. Every deck tool that accepts them
+// filters the collection the same way, so fuzz, build, and recommend agree on
+// what is legal.
+func cardPoolFlags() []cli.Flag {
+	return []cli.Flag{
+		ignoreArenaFlag(),
+		&cli.StringFlag{
+			Name:  allowedCardsFileFlagName,
+			Usage: "Card pool file: a list of allowed cards (one per line) or JSON with allowed, banned, and banned_rarities",
+		},
+		&cli.StringSliceFlag{
+			Name:  banRarityFlagName,
+			Usage: "Rarities that may not be used, e.g. Champion for a no-champions event (repeatable)",
+		},
+	}
+}
+
+// loadCardPoolFlags builds the card pool from --allowed-cards-file and
+// --ban-rarity. It returns nil when neither is set.
+func loadCardPoolFlags(cmd *cli.Command) (*deck.CardPool, error) {
+	pool := &deck.CardPool{}
+	if path := cmd.String(allowedCardsFileFlagName); path != "" {
+		loaded, err := deck.LoadCardPool(path)
+		if err != nil {
+			return nil, err
+		}
+		pool = loaded
+	}
+	pool.BanRarities(cmd.StringSlice(banRarityFlagName)...)
+	if pool.Empty() {
+		return nil, nil
+	}
+	return pool, nil
+}
+
+// applyCardPoolToAnalysis removes cards outside the pool from the analysis
+// used by the deck builder.
+func applyCardPoolToAnalysis(cardAnalysis *deck.CardAnalysis, pool *deck.CardPool, verbose bool) {
+	if pool == nil {
+		return
+	}
+	var removed []string
+	cardAnalysis.CardLevels, removed = pool.FilterCardLevels(cardAnalysis.CardLevels)
+	reportCardPool(pool, len(cardAnalysis.CardLevels), removed, verbose)
+}
+
+// applyCardPoolToPlayer removes cards outside the pool from the player's
+// collection and returns their names, so callers can also exclude them from
+// seed decks loaded from storage.
+func applyCardPoolToPlayer(player *clashroyale.Player, pool *deck.CardPool, verbose bool) []string {
+	if pool == nil || player == nil {
+		return nil
+	}
+	var removed []string
+	player.Cards = slices.DeleteFunc(player.Cards, func(card clashroyale.Card) bool {
+		if pool.Allows(card.Name, card.Rarity) {
+			return false
+		}
+		removed = append(removed, card.Name)
+		return true
+	})
+	slices.Sort(removed)
+	reportCardPool(pool, len(player.Cards), removed, verbose)
+	return removed
+}
+
+// validateIncludedCardsInPool rejects --include-cards that the pool forbids
+func validateIncludedCardsInPool(includeCards []string, pool *deck.CardPool) error {
+	if pool == nil {
+		return nil
+	}
+	var blocked []string
+	for _, card := range includeCards {
+		if card = strings.TrimSpace(card); card != "" && !pool.Allows(card, "") {
+			blocked = append(blocked, card)
+		}
+	}
+	if len(blocked) > 0 {
+		return fmt.Errorf("included cards are not in the card pool (%s): %s", pool.Describe(), strings.Join(blocked, ", "))
+	}
+	return nil
+}
+
+func reportCardPool(pool *deck.CardPool, kept int, removed []string, verbose bool) {
+	fprintf(os.Stderr, "Card pool %s: %d cards available, %d removed\n", pool.Describe(), kept, len(removed))
+	if verbose && len(removed) > 0 {
+		fprintf(os.Stderr, "  Removed: %s\n", strings.Join(removed, ", "))
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+func TestApplyCardPoolToPlayer(t *testing.T) {
+	player := &clashroyale.Player{Cards: []clashroyale.Card{
+		{Name: "Knight", Rarity: "Common"},
+		{Name: "Archer Queen", Rarity: "Champion"},
+		{Name: "Hog Rider", Rarity: "Rare"},
+		{Name: "Golem", Rarity: "Epic"},
+	}}
+	pool := &deck.CardPool{Banned: []string{"Golem"}}
+	pool.BanRarities("Champion")
+
+	removed := applyCardPoolToPlayer(player, pool, false)
+	if !slices.Equal(removed, []string{"Archer Queen", "Golem"}) {
+		t.Errorf("removed = %v", removed)
+	}
+	if len(player.Cards) != 2 || player.Cards[0].Name != "Knight" || player.Cards[1].Name != "Hog Rider" {
+		t.Errorf("remaining cards = %+v", player.Cards)
+	}
+
+	if removed := applyCardPoolToPlayer(player, nil, false); removed != nil || len(player.Cards) != 2 {
+		t.Error("a nil pool should leave the collection alone")
+	}
+}
+
+func TestValidateIncludedCardsInPool(t *testing.T) {
+	pool := &deck.CardPool{Allowed: []string{"Knight", "Archers"}}
+	if err := validateIncludedCardsInPool([]string{"Knight", " archers "}, pool); err != nil {
+		t.Errorf("allowed includes rejected: %v", err)
+	}
+	if err := validateIncludedCardsInPool([]string{"Knight", "Hog Rider"}, pool); err == nil {
+		t.Error("expected an error for an include outside the pool")
+	}
+	if err := validateIncludedCardsInPool([]string{"Hog Rider"}, nil); err != nil {
+		t.Errorf("nil pool: %v", err)
+	}
+}
@@ -0,0 +1,325 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/internal/storage"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	roleSourceBuiltin  = "built-in"
+	roleSourceOverride = "override"
+)
+
+// roleOverridesPath returns the user card role overrides file in the data dir
+func roleOverridesPath(cmd *cli.Command) string {
+	return filepath.Join(cmd.String("data-dir"), config.RoleOverridesFile)
+}
+
+// configureRoleOverrides applies the user's card role overrides so every
+// command classifies cards the same way. A file that fails to load only
+// produces a warning.
+func configureRoleOverrides(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+	path := roleOverridesPath(cmd)
+	if !storage.FileExists(path) {
+		config.SetRoleOverrides(nil)
+		return ctx, nil
+	}
+	overrides, err := config.LoadRoleOverrides(path)
+	if err != nil {
+		fprintf(os.Stderr, "Warning: ignoring card role overrides: %v\n", err)
+		overrides = nil
+	}
+	config.SetRoleOverrides(overrides)
+	return ctx, nil
+}
+
+// addCardRolesCommand adds the cards roles command group
+func addCardRolesCommand() *cli.Command {
+	formatFlag := func() cli.Flag {
+		return &cli.StringFlag{Name: "format", Value: batchFormatHuman, Usage: "Output format: human, json"}
+	}
+	return &cli.Command{
+		Name:  "roles",
+		Usage: "Inspect and override the role (win condition, spell, support, ...) each card plays",
+		Commands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "List card roles with their source (built-in or override)",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "role", Usage: "Only list cards with this role"},
+					&cli.BoolFlag{Name: "overrides", Usage: "Only list your overrides"},
+					formatFlag(),
+				},
+				Action: cardRolesListCommand,
+			},
+			{
+				Name:      "set",
+				Usage:     "Override a card's role (stored in <data-dir>/" + config.RoleOverridesFile + ")",
+				ArgsUsage: "<card> <role>",
+				Action:    cardRolesSetCommand,
+			},
+			{
+				Name:      "unset",
+				Usage:     "Remove role overrides so the cards use their built-in roles again",
+				ArgsUsage: "<card>...",
+				Action:    cardRolesUnsetCommand,
+			},
+			{
+				Name:  "validate",
+				Usage: "Check that every card from the cards endpoint has a role",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "cached", Usage: "Use the cached card database instead of the live endpoint when available"},
+					&cli.BoolFlag{Name: "strict", Usage: "Fail when any card has no role"},
+					formatFlag(),
+				},
+				Action: cardRolesValidateCommand,
+			},
+		},
+	}
+}
+
+// cardRoleEntry is one card's effective role
+type cardRoleEntry struct {
+	Card    string          `json:"card"`
+	Role    config.CardRole `json:"role"`
+	Source  string          `json:"source"`
+	Builtin config.CardRole `json:"builtin,omitempty"`
+}
+
+// cardRoleEntries lists every card with a built-in role or an override,
+// sorted by role then name
+func cardRoleEntries(overrides map[string]config.CardRole) []cardRoleEntry {
+	builtin := config.BuiltinRoleCards()
+	names := slices.Sorted(maps.Keys(builtin))
+	for name := range overrides {
+		if _, ok := builtin[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	entries := make([]cardRoleEntry, 0, len(names))
+	for _, name := range names {
+		entry := cardRoleEntry{Card: name, Role: builtin[name], Source: roleSourceBuiltin}
+		if role, ok := overrides[name]; ok {
+			entry.Role, entry.Source, entry.Builtin = role, roleSourceOverride, builtin[name]
+		}
+		entries = append(entries, entry)
+	}
+
+	order := config.AllCardRoles()
+	slices.SortFunc(entries, func(a, b cardRoleEntry) int {
+		if r := slices.Index(order, a.Role) - slices.Index(order, b.Role); r != 0 {
+			return r
+		}
+		return strings.Compare(a.Card, b.Card)
+	})
+	return entries
+}
+
+func cardRolesListCommand(ctx context.Context, cmd *cli.Command) error {
+	format, err := parseRolesFormat(cmd)
+	if err != nil {
+		return err
+	}
+	var only config.CardRole
+	if value := cmd.String("role"); value != "" {
+		if only, err = config.ParseCardRole(value); err != nil {
+			return err
+		}
+	}
+
+	entries := slices.DeleteFunc(cardRoleEntries(config.RoleOverrides()), func(e cardRoleEntry) bool {
+		return (only != "" && e.Role != only) || (cmd.Bool("overrides") && e.Source != roleSourceOverride)
+	})
+
+	if format == batchFormatJSON {
+		return printRolesJSON(entries)
+	}
+	if len(entries) == 0 {
+		printf("No cards match.\n")
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fprintf(w, "Card\tRole\tSource\n")
+	fprintf(w, "----\t----\t------\n")
+	for _, e := range entries {
+		source := e.Source
+		if e.Source == roleSourceOverride && e.Builtin != "" {
+			source = fmt.Sprintf("override (was %s)", e.Builtin)
+		}
+		fprintf(w, "%s\t%s\t%s\n", e.Card, e.Role, source)
+	}
+	flushWriter(w)
+	return nil
+}
+
+func cardRolesSetCommand(ctx context.Context, cmd *cli.Command) error {
+	args := configArgs(cmd)
+	if len(args) != 2 {
+		return errors.New("usage: cr-api cards roles set <card> <role>")
+	}
+	card := strings.TrimSpace(args[0])
+	role, err := config.ParseCardRole(args[1])
+	if err != nil {
+		return err
+	}
+	if card == "" {
+		return errors.New("card name is required")
+	}
+
+	path := roleOverridesPath(cmd)
+	overrides, err := loadRoleOverridesForEdit(path)
+	if err != nil {
+		return err
+	}
+	overrides[card] = role
+	if err := config.SaveRoleOverrides(path, overrides); err != nil {
+		return err
+	}
+
+	if _, known := config.LookupCardRarity(card); !known && config.BuiltinCardRole(card) == "" {
+		fprintf(os.Stderr, "Note: %s is not in the built-in card data; check the spelling if this is not a new card\n", card)
+	}
+	printf("%s is now %s (saved to %s)\n", card, role, path)
+	return nil
+}
+
+func cardRolesUnsetCommand(ctx context.Context, cmd *cli.Command) error {
+	args := configArgs(cmd)
+	if len(args) == 0 {
+		return errors.New("usage: cr-api cards roles unset <card>...")
+	}
+
+	path := roleOverridesPath(cmd)
+	overrides, err := loadRoleOverridesForEdit(path)
+	if err != nil {
+		return err
+	}
+	for _, card := range args {
+		card = strings.TrimSpace(card)
+		if _, ok := overrides[card]; !ok {
+			return fmt.Errorf("%s has no role override", card)
+		}
+		delete(overrides, card)
+	}
+	if err := config.SaveRoleOverrides(path, overrides); err != nil {
+		return err
+	}
+	printf("Removed %d role override(s) from %s\n", len(args), path)
+	return nil
+}
+
+// loadRoleOverridesForEdit reads the overrides file, treating a missing
+// file as empty
+func loadRoleOverridesForEdit(path string) (map[string]config.CardRole, error) {
+	if !storage.FileExists(path) {
+		return make(map[string]config.CardRole), nil
+	}
+	return config.LoadRoleOverrides(path)
+}
+
+// cardRolesValidation reports the cards in the card database with no role
+type cardRolesValidation struct {
+	Source  string   `json:"source"`
+	Cards   int      `json:"cards"`
+	Unknown []string `json:"unknown"`
+}
+
+func cardRolesValidateCommand(ctx context.Context, cmd *cli.Command) error {
+	format, err := parseRolesFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	var cards []clashroyale.Card
+	source := "live cards endpoint"
+	if cmd.Bool("cached") {
+		source = "card database"
+		cards, err = loadStaticCards(ctx, cmd.String("data-dir"), cmd.String("api-token"), cmd.Bool("verbose"))
+	} else {
+		client, clientErr := requireAPIClient(cmd, apiClientOptions{offlineHint: ", or use --cached"})
+		if clientErr != nil {
+			return clientErr
+		}
+		var list *clashroyale.CardList
+		if list, err = client.GetCardsWithContext(ctx); err == nil {
+			cards = list.Items
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get cards: %w", err)
+	}
+
+	result := validateCardRoles(cards)
+	result.Source = source
+	if format == batchFormatJSON {
+		if err := printRolesJSON(result); err != nil {
+			return err
+		}
+	} else {
+		printf("%s", formatCardRolesValidation(result))
+	}
+
+	if cmd.Bool("strict") && len(result.Unknown) > 0 {
+		return fmt.Errorf("%d cards have no role", len(result.Unknown))
+	}
+	return nil
+}
+
+// validateCardRoles lists the cards that have no built-in or override role
+func validateCardRoles(cards []clashroyale.Card) cardRolesValidation {
+	result := cardRolesValidation{Cards: len(cards), Unknown: []string{}}
+	for _, card := range cards {
+		if config.GetCardRole(card.Name) == "" {
+			result.Unknown = append(result.Unknown, card.Name)
+		}
+	}
+	slices.Sort(result.Unknown)
+	return result
+}
+
+func formatCardRolesValidation(result cardRolesValidation) string {
+	var buf bytes.Buffer
+	fprintf(&buf, "Checked %d cards from the %s\n", result.Cards, result.Source)
+	if len(result.Unknown) == 0 {
+		fprintf(&buf, "Every card has a role.\n")
+		return buf.String()
+	}
+	fprintf(&buf, "%d cards have no role and are scored as generic cards:\n", len(result.Unknown))
+	for _, name := range result.Unknown {
+		fprintf(&buf, "  - %s\n", name)
+	}
+	fprintf(&buf, "Assign one with: cr-api cards roles set \"<card>\" <role>\n")
+	return buf.String()
+}
+
+func parseRolesFormat(cmd *cli.Command) (string, error) {
+	format := strings.ToLower(strings.TrimSpace(cmd.String("format")))
+	if format != batchFormatHuman && format != batchFormatJSON {
+		return "", fmt.Errorf("unknown format: %s (supported: human, json)", format)
+	}
+	return format, nil
+}
+
+func printRolesJSON(v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal card roles: %w", err)
+	}
+	printf("%s\n", data)
+	return nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+func TestValidateCardRolesReportsUnknownCards(t *testing.T) {
+	t.Cleanup(func() { config.SetRoleOverrides(nil) })
+
+	cards := []clashroyale.Card{{Name: "Hog Rider"}, {Name: "Brand New Card"}, {Name: "Another New Card"}}
+	result := validateCardRoles(cards)
+	if result.Cards != 3 || !slices.Equal(result.Unknown, []string{"Another New Card", "Brand New Card"}) {
+		t.Fatalf("validateCardRoles() = %+v", result)
+	}
+	result.Source = "card database"
+	if out := formatCardRolesValidation(result); !strings.Contains(out, "2 cards have no role") || !strings.Contains(out, "Brand New Card") {
+		t.Errorf("formatted validation = %q", out)
+	}
+
+	config.SetRoleOverrides(map[string]config.CardRole{"Brand New Card": config.RoleSupport})
+	if result := validateCardRoles(cards); !slices.Equal(result.Unknown, []string{"Another New Card"}) {
+		t.Errorf("override did not give Brand New Card a role: %v", result.Unknown)
+	}
+}
+
+func TestCardRoleEntries(t *testing.T) {
+	entries := cardRoleEntries(map[string]config.CardRole{
+		"Knight":       config.RoleCycle,
+		"Goblin Drill": config.RoleWinCondition,
+	})
+
+	find := func(card string) cardRoleEntry {
+		t.Helper()
+		i := slices.IndexFunc(entries, func(e cardRoleEntry) bool { return e.Card == card })
+		if i < 0 {
+			t.Fatalf("%s missing from entries", card)
+		}
+		return entries[i]
+	}
+	if knight := find("Knight"); knight.Role != config.RoleCycle || knight.Source != roleSourceOverride || knight.Builtin != config.RoleSupport {
+		t.Errorf("Knight entry = %+v", knight)
+	}
+	if drill := find("Goblin Drill"); drill.Source != roleSourceOverride || drill.Builtin != "" {
+		t.Errorf("Goblin Drill entry = %+v", drill)
+	}
+	if hog := find("Hog Rider"); hog.Source != roleSourceBuiltin {
+		t.Errorf("Hog Rider entry = %+v", hog)
+	}
+	if entries[0].Role != config.RoleWinCondition || entries[len(entries)-1].Role != config.RoleCycle {
+		t.Errorf("entries not ordered by role: first %+v, last %+v", entries[0], entries[len(entries)-1])
+	}
+}
@@ -0,0 +1,333 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/urfave/cli/v3"
+)
+
+// CardStubsFile is the default name of the generated stubs file in the data dir
+const CardStubsFile = "card_stubs.json"
+
+// Internal databases checked by cards sync, in report order
+const (
+	cardSyncRole      = "role"
+	cardSyncElixir    = "elixir"
+	cardSyncSynergy   = "synergy"
+	cardSyncCounters  = "counters"
+	cardSyncArchetype = "archetype"
+)
+
+var cardSyncDatabases = []string{
+	cardSyncRole, cardSyncElixir, cardSyncSynergy, cardSyncCounters, cardSyncArchetype,
+}
+
+// addCardSyncCommand adds the cards sync command
+func addCardSyncCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "sync",
+		Usage: "Diff the live card list against the internal card databases and stub out missing cards",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "cached", Usage: "Use the cached card database instead of the live endpoint when available"},
+			&cli.StringSliceFlag{Name: "databases", Usage: "Databases to check: " + strings.Join(cardSyncDatabases, ", ") + " (default: all)"},
+			&cli.StringFlag{Name: "stubs-file", Usage: "Where to write stub entries for missing cards (default: <data-dir>/" + CardStubsFile + ")"},
+			&cli.BoolFlag{Name: "no-stubs", Usage: "Only report; do not write the stubs file"},
+			&cli.BoolFlag{Name: "warn-only", Usage: "Exit successfully even when cards need curation"},
+			&cli.StringFlag{Name: "format", Value: batchFormatHuman, Usage: "Output format: human, json"},
+		},
+		Action: cardSyncCommand,
+	}
+}
+
+// cardSyncStub is a starting point for curating one card. Values the API
+// does not provide are suggestions and need a human check.
+type cardSyncStub struct {
+	Name          string             `json:"name"`
+	ID            int                `json:"id"`
+	Type          string             `json:"type,omitempty"`
+	Rarity        string             `json:"rarity,omitempty"`
+	Elixir        int                `json:"elixir"`
+	SuggestedRole config.CardRole    `json:"suggested_role"`
+	Missing       []string           `json:"missing"`
+	Synergies     []deck.SynergyPair `json:"synergies"`
+	Counters      deck.CardCounters  `json:"counters"`
+}
+
+// cardSyncReport is the result of diffing the card list against the
+// internal databases
+type cardSyncReport struct {
+	Source    string              `json:"source"`
+	Generated time.Time           `json:"generated"`
+	Cards     int                 `json:"cards"`
+	Databases []string            `json:"databases"`
+	Missing   map[string][]string `json:"missing"`
+	Stubs     []cardSyncStub      `json:"stubs"`
+	StubsFile string              `json:"stubs_file,omitempty"`
+}
+
+// cardSyncData is the set of cards each internal database knows
+type cardSyncData struct {
+	synergy   map[string]bool
+	counters  map[string]bool
+	archetype map[string]bool
+}
+
+func cardSyncCommand(ctx context.Context, cmd *cli.Command) error {
+	format, err := parseRolesFormat(cmd)
+	if err != nil {
+		return err
+	}
+	databases, err := parseCardSyncDatabases(cmd.StringSlice("databases"))
+	if err != nil {
+		return err
+	}
+
+	var cards []clashroyale.Card
+	source := "live cards endpoint"
+	if cmd.Bool("cached") {
+		source = "card database"
+		cards, err = loadStaticCards(ctx, cmd.String("data-dir"), cmd.String("api-token"), cmd.Bool("verbose"))
+	} else {
+		client, clientErr := requireAPIClient(cmd, apiClientOptions{offlineHint: ", or use --cached"})
+		if clientErr != nil {
+			return clientErr
+		}
+		var list *clashroyale.CardList
+		if list, err = client.GetCardsWithContext(ctx); err == nil {
+			cards = list.Items
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get cards: %w", err)
+	}
+
+	data, err := loadCardSyncData()
+	if err != nil {
+		return err
+	}
+	report := diffCardDatabases(cards, databases, data)
+	report.Source = source
+	report.Generated = time.Now().UTC()
+
+	if len(report.Stubs) > 0 && !cmd.Bool("no-stubs") {
+		path := cmd.String("stubs-file")
+		if path == "" {
+			path = filepath.Join(cmd.String("data-dir"), CardStubsFile)
+		}
+		if err := writeCardSyncStubs(path, report); err != nil {
+			return err
+		}
+		report.StubsFile = path
+	}
+
+	if format == batchFormatJSON {
+		if err := printRolesJSON(report); err != nil {
+			return err
+		}
+	} else {
+		printf("%s", formatCardSyncReport(report))
+	}
+
+	if len(report.Stubs) > 0 && !cmd.Bool("warn-only") {
+		return fmt.Errorf("%d cards need curation", len(report.Stubs))
+	}
+	return nil
+}
+
+// parseCardSyncDatabases validates the --databases values; none means all
+func parseCardSyncDatabases(values []string) ([]string, error) {
+	if len(values) == 0 {
+		return slices.Clone(cardSyncDatabases), nil
+	}
+	var selected []string
+	for _, value := range values {
+		for name := range strings.SplitSeq(value, ",") {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name == "" {
+				continue
+			}
+			if !slices.Contains(cardSyncDatabases, name) {
+				return nil, fmt.Errorf("unknown database: %s (supported: %s)", name, strings.Join(cardSyncDatabases, ", "))
+			}
+			if !slices.Contains(selected, name) {
+				selected = append(selected, name)
+			}
+		}
+	}
+	// Keep report order stable regardless of flag order
+	slices.SortFunc(selected, func(a, b string) int {
+		return slices.Index(cardSyncDatabases, a) - slices.Index(cardSyncDatabases, b)
+	})
+	return selected, nil
+}
+
+// loadCardSyncData collects the cards known to the deck package databases.
+// Synergies include the user's overrides file.
+func loadCardSyncData() (cardSyncData, error) {
+	data := cardSyncData{
+		synergy:   make(map[string]bool),
+		counters:  make(map[string]bool),
+		archetype: make(map[string]bool),
+	}
+	for _, pair := range deck.NewSynergyDatabase().Pairs {
+		data.synergy[pair.Card1] = true
+		data.synergy[pair.Card2] = true
+	}
+
+	counters, err := deck.NewCountersDatabase()
+	if err != nil {
+		return data, err
+	}
+	for _, name := range counters.Cards() {
+		data.counters[name] = true
+	}
+
+	signals, err := deck.ArchetypeSignalCards()
+	if err != nil {
+		return data, err
+	}
+	for _, name := range signals {
+		data.archetype[name] = true
+	}
+	return data, nil
+}
+
+// diffCardDatabases lists, for each selected database, the cards it is
+// missing, and builds a stub for every card missing from at least one
+func diffCardDatabases(cards []clashroyale.Card, databases []string, data cardSyncData) cardSyncReport {
+	report := cardSyncReport{
+		Cards:     len(cards),
+		Databases: databases,
+		Missing:   make(map[string][]string, len(databases)),
+		Stubs:     []cardSyncStub{},
+	}
+	for _, name := range databases {
+		report.Missing[name] = []string{}
+	}
+
+	sorted := slices.Clone(cards)
+	slices.SortFunc(sorted, func(a, b clashroyale.Card) int { return strings.Compare(a.Name, b.Name) })
+	for _, card := range sorted {
+		var missing []string
+		for _, name := range databases {
+			if !cardSyncKnows(name, card.Name, data) {
+				missing = append(missing, name)
+				report.Missing[name] = append(report.Missing[name], card.Name)
+			}
+		}
+		if len(missing) > 0 {
+			report.Stubs = append(report.Stubs, newCardSyncStub(card, missing))
+		}
+	}
+	return report
+}
+
+func cardSyncKnows(database, name string, data cardSyncData) bool {
+	switch database {
+	case cardSyncRole:
+		return config.GetCardRole(name) != ""
+	case cardSyncElixir:
+		_, ok := config.LookupCardElixir(name)
+		return ok
+	case cardSyncSynergy:
+		return data.synergy[name]
+	case cardSyncCounters:
+		return data.counters[name]
+	case cardSyncArchetype:
+		return data.archetype[name]
+	}
+	return false
+}
+
+// newCardSyncStub fills what the API knows about a card and suggests the rest
+func newCardSyncStub(card clashroyale.Card, missing []string) cardSyncStub {
+	stub := cardSyncStub{
+		Name:      card.Name,
+		ID:        card.ID,
+		Type:      card.Type,
+		Rarity:    card.Rarity,
+		Elixir:    card.ElixirCost,
+		Missing:   missing,
+		Synergies: []deck.SynergyPair{},
+		Counters:  deck.CardCounters{Hard: []string{}, Soft: []string{}},
+	}
+	if stub.Rarity == "" {
+		stub.Rarity, _ = config.LookupCardRarity(card.Name)
+	}
+	if stub.Elixir == 0 {
+		stub.Elixir = config.GetCardElixir(card.Name, 0)
+	}
+	stub.SuggestedRole = config.GetCardRole(card.Name)
+	if stub.SuggestedRole == "" {
+		stub.SuggestedRole = suggestCardRole(stub.Type, stub.Elixir)
+	}
+	return stub
+}
+
+// suggestCardRole guesses a role from the card type and cost; the curator
+// confirms it with cards roles set
+func suggestCardRole(cardType string, elixir int) config.CardRole {
+	switch strings.ToLower(cardType) {
+	case "building":
+		return config.RoleBuilding
+	case "spell":
+		if elixir >= 4 {
+			return config.RoleSpellBig
+		}
+		return config.RoleSpellSmall
+	}
+	if elixir > 0 && elixir <= 2 {
+		return config.RoleCycle
+	}
+	return config.RoleSupport
+}
+
+func writeCardSyncStubs(path string, report cardSyncReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode card stubs: %w", err)
+	}
+	if err := config.WriteFile(path, append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write card stubs: %w", err)
+	}
+	return nil
+}
+
+func formatCardSyncReport(report cardSyncReport) string {
+	var buf bytes.Buffer
+	fprintf(&buf, "Checked %d cards from the %s against: %s\n", report.Cards, report.Source, strings.Join(report.Databases, ", "))
+	if len(report.Stubs) == 0 {
+		fprintf(&buf, "Every card is in every database.\n")
+		return buf.String()
+	}
+
+	fprintf(&buf, "\n%d cards need curation:\n", len(report.Stubs))
+	for _, name := range report.Databases {
+		missing := report.Missing[name]
+		if len(missing) == 0 {
+			continue
+		}
+		fprintf(&buf, "\n%s (%d missing)\n", name, len(missing))
+		for _, card := range missing {
+			fprintf(&buf, "  - %s\n", card)
+		}
+	}
+
+	if report.StubsFile != "" {
+		fprintf(&buf, "\nWrote stub entries to %s\n", report.StubsFile)
+	}
+	if len(report.Missing[cardSyncRole]) > 0 {
+		fprintf(&buf, "Assign roles with: cr-api cards roles set \"<card>\" <role>\n")
+	}
+	return buf.String()
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+func TestDiffCardDatabasesStubsMissingCards(t *testing.T) {
+	data := cardSyncData{
+		synergy:   map[string]bool{"Hog Rider": true},
+		counters:  map[string]bool{"Hog Rider": true},
+		archetype: map[string]bool{"Hog Rider": true},
+	}
+	cards := []clashroyale.Card{
+		{Name: "Zappy New Spell", ID: 28000099, ElixirCost: 3, Type: "Spell", Rarity: "Epic"},
+		{Name: "Hog Rider", ID: 26000021, ElixirCost: 4},
+	}
+
+	report := diffCardDatabases(cards, cardSyncDatabases, data)
+	if report.Cards != 2 || len(report.Stubs) != 1 {
+		t.Fatalf("report = %+v, want one stub", report)
+	}
+	stub := report.Stubs[0]
+	if stub.Name != "Zappy New Spell" || stub.ID != 28000099 || stub.Elixir != 3 || stub.Rarity != "Epic" {
+		t.Errorf("stub = %+v", stub)
+	}
+	if stub.SuggestedRole != config.RoleSpellSmall {
+		t.Errorf("suggested role = %q, want a small spell", stub.SuggestedRole)
+	}
+	if !slices.Equal(stub.Missing, cardSyncDatabases) {
+		t.Errorf("missing = %v, want every database", stub.Missing)
+	}
+	for _, name := range cardSyncDatabases {
+		if !slices.Equal(report.Missing[name], []string{"Zappy New Spell"}) {
+			t.Errorf("missing[%s] = %v", name, report.Missing[name])
+		}
+	}
+
+	report.Source = "live cards endpoint"
+	out := formatCardSyncReport(report)
+	if !strings.Contains(out, "1 cards need curation") || !strings.Contains(out, "cards roles set") {
+		t.Errorf("formatted report = %q", out)
+	}
+
+	// Restricting the databases only reports those
+	report = diffCardDatabases(cards, []string{cardSyncSynergy}, data)
+	if len(report.Missing) != 1 || !slices.Equal(report.Stubs[0].Missing, []string{cardSyncSynergy}) {
+		t.Errorf("synergy-only report = %+v", report)
+	}
+}
+
+func TestDiffCardDatabasesCleanWhenEverythingKnown(t *testing.T) {
+	t.Cleanup(func() { config.SetRoleOverrides(nil) })
+	config.SetRoleOverrides(map[string]config.CardRole{"Zappy New Spell": config.RoleSpellSmall})
+
+	report := diffCardDatabases([]clashroyale.Card{{Name: "Zappy New Spell"}}, []string{cardSyncRole}, cardSyncData{})
+	if len(report.Stubs) != 0 {
+		t.Fatalf("stubs = %+v, want none once the role is overridden", report.Stubs)
+	}
+	if out := formatCardSyncReport(report); !strings.Contains(out, "Every card is in every database") {
+		t.Errorf("formatted report = %q", out)
+	}
+}
+
+func TestParseCardSyncDatabases(t *testing.T) {
+	got, err := parseCardSyncDatabases([]string{"synergy, role", "ROLE"})
+	if err != nil || !slices.Equal(got, []string{cardSyncRole, cardSyncSynergy}) {
+		t.Errorf("parseCardSyncDatabases() = %v, %v", got, err)
+	}
+	if got, _ := parseCardSyncDatabases(nil); !slices.Equal(got, cardSyncDatabases) {
+		t.Errorf("default databases = %v", got)
+	}
+	if _, err := parseCardSyncDatabases([]string{"wincons"}); err == nil {
+		t.Error("expected an error for an unknown database")
+	}
+}
+
+func TestSuggestCardRole(t *testing.T) {
+	tests := []struct {
+		cardType string
+		elixir   int
+		want     config.CardRole
+	}{
+		{"Building", 5, config.RoleBuilding},
+		{"Spell", 6, config.RoleSpellBig},
+		{"Spell", 2, config.RoleSpellSmall},
+		{"Troop", 1, config.RoleCycle},
+		{"Troop", 5, config.RoleSupport},
+		{"", 0, config.RoleSupport},
+	}
+	for _, tt := range tests {
+		if got := suggestCardRole(tt.cardType, tt.elixir); got != tt.want {
+			t.Errorf("suggestCardRole(%q, %d) = %q, want %q", tt.cardType, tt.elixir, got, tt.want)
+		}
+	}
+}
+
+func TestWriteCardSyncStubs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", CardStubsFile)
+	report := diffCardDatabases([]clashroyale.Card{{Name: "Zappy New Spell"}}, []string{cardSyncSynergy}, cardSyncData{})
+	if err := writeCardSyncStubs(path, report); err != nil {
+		t.Fatalf("writeCardSyncStubs() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var written cardSyncReport
+	if err := json.Unmarshal(data, &written); err != nil {
+		t.Fatal(err)
+	}
+	if len(written.Stubs) != 1 || written.Stubs[0].Name != "Zappy New Spell" || written.Stubs[0].Counters.Hard == nil {
+		t.Errorf("written stubs = %+v", written.Stubs)
+	}
+}
@@ -0,0 +1,600 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/sqlstore"
+	"github.com/klauer/clash-royale-api/go/pkg/analysis"
+	"github.com/klauer/clash-royale-api/go/pkg/archetypes"
+	"github.com/klauer/clash-royale-api/go/pkg/clan"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/urfave/cli/v3"
+)
+
+// addClanCommands adds the clan commands to the CLI
+func addClanCommands() *cli.Command {
+	return &cli.Command{
+		Name:  "clan",
+		Usage: "Clan tools built on the clan and river race endpoints",
+		Commands: []*cli.Command{
+			{
+				Name:  "war-plan",
+				Usage: "Plan river race attacks: flag unused war decks and recommend a 4-deck war set per member",
+				Flags: []cli.Flag{
+					clanTagFlag(),
+					&cli.BoolFlag{
+						Name:  "unused-only",
+						Usage: "Only build war sets for members with unused attacks today",
+					},
+					bulkWorkersFlag(),
+					&cli.StringFlag{
+						Name:  "format",
+						Value: batchFormatHuman,
+						Usage: "Output format: human, json",
+					},
+				},
+				Action: clanWarPlanCommand,
+			},
+			{
+				Name:  "activity",
+				Usage: "Member donations, last seen, war participation, and trophy trends for kick/promote decisions",
+				Flags: []cli.Flag{
+					clanTagFlag(),
+					&cli.StringFlag{
+						Name:  "sort",
+						Value: "donations",
+						Usage: "Sort column: " + strings.Join(clan.SortKeys, ", "),
+					},
+					&cli.BoolFlag{
+						Name:  "reverse",
+						Usage: "Reverse the sort order",
+					},
+					&cli.IntFlag{
+						Name:  "trend-days",
+						Value: 7,
+						Usage: "Trophy trend window in days, read from the history database (0 = skip)",
+					},
+					&cli.FloatFlag{
+						Name:  "inactive-days",
+						Value: clan.DefaultActivityOptions().InactiveDays,
+						Usage: "Suggest kicking members not seen for this many days",
+					},
+					&cli.IntFlag{
+						Name:  "promote-donations",
+						Value: clan.DefaultActivityOptions().PromoteDonations,
+						Usage: "Donations needed for a promote suggestion",
+					},
+					&cli.IntFlag{
+						Name:  "promote-war-decks",
+						Value: clan.DefaultActivityOptions().PromoteWarDecks,
+						Usage: "River race decks used needed for a promote suggestion",
+					},
+					&cli.StringFlag{
+						Name:  "format",
+						Value: batchFormatHuman,
+						Usage: "Output format: human, csv, json",
+					},
+					&cli.StringFlag{
+						Name:  "output",
+						Usage: "Write output to a file instead of stdout",
+					},
+				},
+				Action: clanActivityCommand,
+			},
+			{
+				Name:  "donations",
+				Usage: "Route common and rare donations: who needs which cards most, what each member should request, and cards nobody needs",
+				Flags: []cli.Flag{
+					clanTagFlag(),
+					&cli.IntFlag{
+						Name:  "top",
+						Value: 15,
+						Usage: "Number of most requested cards to list (0 = all)",
+					},
+					bulkWorkersFlag(),
+					&cli.StringFlag{
+						Name:  "format",
+						Value: batchFormatHuman,
+						Usage: "Output format: human, json",
+					},
+				},
+				Action: clanDonationsCommand,
+			},
+		},
+	}
+}
+
+// clanWarDeck is one deck of a member's recommended war set
+type clanWarDeck struct {
+	Archetype string   `json:"archetype"`
+	Cards     []string `json:"cards"`
+	AvgElixir float64  `json:"avg_elixir"`
+	Score     float64  `json:"score"`
+}
+
+// clanWarMemberPlan is a member's river race status and recommended war set
+type clanWarMemberPlan struct {
+	Tag            string        `json:"tag"`
+	Name           string        `json:"name"`
+	Role           string        `json:"role"`
+	Fame           int           `json:"fame"`
+	DecksUsedToday int           `json:"decks_used_today"`
+	UnusedDecks    int           `json:"unused_decks"`
+	Decks          []clanWarDeck `json:"decks,omitempty"`
+	Error          string        `json:"error,omitempty"`
+}
+
+// clanWarPlan is the war plan for a whole clan
+type clanWarPlan struct {
+	ClanTag       string              `json:"clan_tag"`
+	ClanName      string              `json:"clan_name"`
+	PeriodType    string              `json:"period_type"`
+	BattleDay     bool                `json:"battle_day"`
+	UnusedMembers int                 `json:"members_with_unused_attacks"`
+	UnusedDecks   int                 `json:"unused_decks"`
+	Members       []clanWarMemberPlan `json:"members"`
+}
+
+func clanWarPlanCommand(ctx context.Context, cmd *cli.Command) error {
+	format := strings.ToLower(strings.TrimSpace(cmd.String("format")))
+	if format != batchFormatHuman && format != batchFormatJSON {
+		return fmt.Errorf("unknown format: %s (supported: human, json)", format)
+	}
+	verbose := cmd.Bool("verbose")
+	clanTag := cmd.String("tag")
+
+	client, err := requireAPIClient(cmd, apiClientOptions{})
+	if err != nil {
+		return err
+	}
+
+	members, err := client.GetClanMembersWithContext(ctx, clanTag)
+	if err != nil {
+		return fmt.Errorf("failed to get members for clan %s: %w", clanTag, err)
+	}
+	race, err := client.GetCurrentRiverRaceWithContext(ctx, clanTag)
+	if err != nil {
+		return fmt.Errorf("failed to get current river race for clan %s: %w", clanTag, err)
+	}
+
+	plan := newClanWarPlan(clashroyale.NormalizeTag(clanTag), members.Items, race)
+	var planned []*clanWarMemberPlan
+	var tags []string
+	for i := range plan.Members {
+		member := &plan.Members[i]
+		if cmd.Bool("unused-only") && member.UnusedDecks == 0 {
+			continue
+		}
+		planned = append(planned, member)
+		tags = append(tags, member.Tag)
+	}
+	opts := clashroyale.BulkOptions{Workers: cmd.Int("workers")}
+	if verbose {
+		opts.OnResult = func(done, total int, tag string, _ error) {
+			fprintf(os.Stderr, "[%d/%d] Fetched collection for %s\n", done, total, tag)
+		}
+	}
+	results, err := client.GetPlayers(ctx, tags, opts)
+	if err != nil {
+		return err
+	}
+
+	builder := archetypes.NewArchetypeBuilder(cmd.String("data-dir"))
+	for i, member := range planned {
+		if results[i].Err != nil {
+			member.Error = fmt.Sprintf("failed to get player: %v", results[i].Err)
+			continue
+		}
+		decks, err := planMemberWarDecks(builder, results[i].Player)
+		if err != nil {
+			member.Error = err.Error()
+			continue
+		}
+		member.Decks = decks
+	}
+	if verbose {
+		printTokenStats(os.Stderr, client)
+	}
+
+	if format == batchFormatJSON {
+		data, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		printf("%s\n", data)
+		return nil
+	}
+	printf("%s", formatClanWarPlan(plan))
+	return nil
+}
+
+// newClanWarPlan joins the member list with river race participation. Members
+// with unused attacks come first, then by clan rank. Unused decks are only
+// counted on battle days.
+func newClanWarPlan(clanTag string, members []clashroyale.Member, race *clashroyale.CurrentRiverRace) clanWarPlan {
+	plan := clanWarPlan{ClanTag: clanTag}
+	participants := make(map[string]clashroyale.RiverRaceParticipant)
+	if race != nil {
+		plan.ClanName = race.Clan.Name
+		plan.PeriodType = race.PeriodType
+		plan.BattleDay = race.IsBattleDay()
+		for _, participant := range race.Clan.Participants {
+			participants[participant.Tag] = participant
+		}
+	}
+
+	ranks := make(map[string]int, len(members))
+	for _, member := range members {
+		participant := participants[member.Tag]
+		memberPlan := clanWarMemberPlan{
+			Tag:            member.Tag,
+			Name:           member.Name,
+			Role:           member.Role,
+			Fame:           participant.Fame,
+			DecksUsedToday: participant.DecksUsedToday,
+		}
+		if plan.BattleDay {
+			memberPlan.UnusedDecks = participant.UnusedDecksToday()
+		}
+		if memberPlan.UnusedDecks > 0 {
+			plan.UnusedMembers++
+			plan.UnusedDecks += memberPlan.UnusedDecks
+		}
+		ranks[member.Tag] = member.ClanRank
+		plan.Members = append(plan.Members, memberPlan)
+	}
+
+	sort.SliceStable(plan.Members, func(i, j int) bool {
+		a, b := plan.Members[i], plan.Members[j]
+		if (a.UnusedDecks > 0) != (b.UnusedDecks > 0) {
+			return a.UnusedDecks > 0
+		}
+		return ranks[a.Tag] < ranks[b.Tag]
+	})
+	return plan
+}
+
+// planMemberWarDecks builds a no-repeat war set from the member's collection
+func planMemberWarDecks(builder *archetypes.ArchetypeBuilder, player *clashroyale.Player) ([]clanWarDeck, error) {
+	cardAnalysis, err := analysis.AnalyzeCardCollection(player, analysis.DefaultAnalysisOptions())
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze card collection: %w", err)
+	}
+
+	candidates, err := buildWarDecks(builder, convertToDeckCardAnalysis(cardAnalysis, player), clashroyale.WarDecksPerDay)
+	if err != nil {
+		return nil, err
+	}
+
+	decks := make([]clanWarDeck, 0, len(candidates))
+	for _, candidate := range candidates {
+		decks = append(decks, clanWarDeck{
+			Archetype: formatArchetypeName(candidate.Archetype),
+			Cards:     candidate.Deck.Deck,
+			AvgElixir: candidate.Deck.AvgElixir,
+			Score:     candidate.Score,
+		})
+	}
+	return decks, nil
+}
+
+func formatClanWarPlan(plan clanWarPlan) string {
+	var buf bytes.Buffer
+	title := plan.ClanTag
+	if plan.ClanName != "" {
+		title = fmt.Sprintf("%s (%s)", plan.ClanName, plan.ClanTag)
+	}
+	fprintf(&buf, "\nClan War Plan: %s\n", title)
+	fprintf(&buf, "========================\n")
+	if plan.BattleDay {
+		fprintf(&buf, "Period: battle day (%s)\n", plan.PeriodType)
+		fprintf(&buf, "Unused attacks: %d members, %d decks\n", plan.UnusedMembers, plan.UnusedDecks)
+	} else {
+		fprintf(&buf, "Period: training day, no war decks to play\n")
+	}
+
+	fprintf(&buf, "\n")
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fprintf(w, "Member\tRole\tFame\tDecks Today\tStatus\n")
+	for _, member := range plan.Members {
+		status := "done"
+		switch {
+		case !plan.BattleDay:
+			status = "-"
+		case member.UnusedDecks > 0:
+			status = fmt.Sprintf("%d unused", member.UnusedDecks)
+		}
+		fprintf(w, "%s\t%s\t%d\t%d/%d\t%s\n",
+			member.Name, member.Role, member.Fame, member.DecksUsedToday, clashroyale.WarDecksPerDay, status)
+	}
+	flushWriter(w)
+
+	for _, member := range plan.Members {
+		if len(member.Decks) == 0 && member.Error == "" {
+			continue
+		}
+		fprintf(&buf, "\n%s (%s)\n", member.Name, member.Tag)
+		if member.Error != "" {
+			fprintf(&buf, "  No war set: %s\n", member.Error)
+			continue
+		}
+		for i, deck := range member.Decks {
+			fprintf(&buf, "  %d. %-12s %.1f elixir  %s\n", i+1, deck.Archetype, deck.AvgElixir, strings.Join(deck.Cards, ", "))
+		}
+	}
+	return buf.String()
+}
+
+func clanActivityCommand(ctx context.Context, cmd *cli.Command) error {
+	format := strings.ToLower(strings.TrimSpace(cmd.String("format")))
+	if format != batchFormatHuman && format != batchFormatJSON && format != batchFormatCSV {
+		return fmt.Errorf("unknown format: %s (supported: human, csv, json)", format)
+	}
+	clanTag := cmd.String("tag")
+
+	client, err := requireAPIClient(cmd, apiClientOptions{})
+	if err != nil {
+		return err
+	}
+	members, err := client.GetClanMembersWithContext(ctx, clanTag)
+	if err != nil {
+		return fmt.Errorf("failed to get members for clan %s: %w", clanTag, err)
+	}
+	race, err := client.GetCurrentRiverRaceWithContext(ctx, clanTag)
+	if err != nil {
+		// Clans outside river races return 404; activity is still useful without war data.
+		fprintf(os.Stderr, "Warning: no river race data for clan %s: %v\n", clanTag, err)
+		race = nil
+	}
+
+	now := time.Now()
+	trends, err := loadClanTrophyTrends(cmd, members.Items, cmd.Int("trend-days"), now)
+	if err != nil {
+		return err
+	}
+
+	opts := clan.ActivityOptions{
+		InactiveDays:     cmd.Float("inactive-days"),
+		PromoteDonations: cmd.Int("promote-donations"),
+		PromoteWarDecks:  cmd.Int("promote-war-decks"),
+	}
+	rows := clan.BuildActivity(members.Items, race, trends, now, opts)
+	if err := clan.SortActivity(rows, cmd.String("sort"), cmd.Bool("reverse")); err != nil {
+		return err
+	}
+
+	var content string
+	switch format {
+	case batchFormatJSON:
+		data, err := json.MarshalIndent(rows, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		content = string(data) + "\n"
+	case batchFormatCSV:
+		var buf bytes.Buffer
+		if err := writeCSVDocument(&buf, clanActivityCSVHeader, clanActivityCSVRows(rows)); err != nil {
+			return err
+		}
+		content = buf.String()
+	default:
+		content = formatClanActivity(clashroyale.NormalizeTag(clanTag), rows)
+	}
+	return writeTextOutput(content, cmd.String("output"), textOutputOptions{saveMessage: "Clan activity saved to"})
+}
+
+func clanDonationsCommand(ctx context.Context, cmd *cli.Command) error {
+	format := strings.ToLower(strings.TrimSpace(cmd.String("format")))
+	if format != batchFormatHuman && format != batchFormatJSON {
+		return fmt.Errorf("unknown format: %s (supported: human, json)", format)
+	}
+	verbose := cmd.Bool("verbose")
+	clanTag := cmd.String("tag")
+
+	client, err := requireAPIClient(cmd, apiClientOptions{})
+	if err != nil {
+		return err
+	}
+	members, err := client.GetClanMembersWithContext(ctx, clanTag)
+	if err != nil {
+		return fmt.Errorf("failed to get members for clan %s: %w", clanTag, err)
+	}
+
+	tags := make([]string, len(members.Items))
+	for i, member := range members.Items {
+		tags[i] = member.Tag
+	}
+	opts := clashroyale.BulkOptions{Workers: cmd.Int("workers")}
+	if verbose {
+		opts.OnResult = func(done, total int, tag string, _ error) {
+			fprintf(os.Stderr, "[%d/%d] Fetched collection for %s\n", done, total, tag)
+		}
+	}
+	results, err := client.GetPlayers(ctx, tags, opts)
+	if err != nil {
+		return err
+	}
+	players := make([]*clashroyale.Player, 0, len(results))
+	for _, result := range results {
+		if result.Err != nil {
+			fprintf(os.Stderr, "Warning: skipping %s: %v\n", result.Tag, result.Err)
+			continue
+		}
+		players = append(players, result.Player)
+	}
+	if verbose {
+		printTokenStats(os.Stderr, client)
+	}
+
+	guide := clan.BuildDonationGuide(players)
+	if limit := cmd.Int("top"); limit > 0 && len(guide.Demand) > limit {
+		guide.Demand = guide.Demand[:limit]
+	}
+
+	if format == batchFormatJSON {
+		data, err := json.MarshalIndent(guide, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		printf("%s\n", data)
+		return nil
+	}
+	printf("%s", formatDonationGuide(clashroyale.NormalizeTag(clanTag), guide))
+	return nil
+}
+
+func formatDonationGuide(clanTag string, guide *clan.DonationGuide) string {
+	var buf bytes.Buffer
+	fprintf(&buf, "\nDonation Guide: %s (%d members)\n", clanTag, guide.Members)
+	fprintf(&buf, "==========================\n")
+
+	fprintf(&buf, "\nMost Needed Cards\n")
+	if len(guide.Demand) == 0 {
+		fprintf(&buf, "  No member's deck needs commons or rares right now.\n")
+	}
+	for _, d := range guide.Demand {
+		fprintf(&buf, "  %s (%s): %d cards for %d members\n", d.Card, d.Rarity, d.TotalNeeded, len(d.Members))
+		names := make([]string, len(d.Members))
+		for i, m := range d.Members {
+			names[i] = fmt.Sprintf("%s %d", m.Name, m.Needed)
+		}
+		fprintf(&buf, "    %s\n", strings.Join(names, ", "))
+	}
+
+	fprintf(&buf, "\nWhat To Request\n")
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fprintf(w, "Member\tCard\tRarity\tNeeded\tIn Deck\n")
+	for _, r := range guide.Requests {
+		inDeck := "no"
+		if r.InDeck {
+			inDeck = "yes"
+		}
+		fprintf(w, "%s\t%s\t%s\t%d\t%s\n", r.Name, r.Card, r.Rarity, r.Needed, inDeck)
+	}
+	flushWriter(w)
+
+	fprintf(&buf, "\nSafe To Donate Freely (%d)\n", len(guide.Unrequested))
+	if len(guide.Unrequested) == 0 {
+		fprintf(&buf, "  Every common and rare owned here is still needed by someone's deck.\n")
+	} else {
+		fprintf(&buf, "  %s\n", strings.Join(guide.Unrequested, ", "))
+	}
+	return buf.String()
+}
+
+// loadClanTrophyTrends reads each member's trophy change over the last days
+// from the history database. Without a database (or with days <= 0) no
+// trends are reported rather than creating an empty one.
+func loadClanTrophyTrends(cmd *cli.Command, members []clashroyale.Member, days int, now time.Time) (map[string]clan.TrophyTrend, error) {
+	if days <= 0 {
+		return nil, nil
+	}
+	if _, err := os.Stat(sqlstore.DefaultPath(cmd.String("data-dir"))); err != nil {
+		return nil, nil
+	}
+	db, err := openHistoryDB(cmd)
+	if err != nil {
+		return nil, err
+	}
+	defer closeFile(db)
+
+	since := now.AddDate(0, 0, -days)
+	trends := make(map[string]clan.TrophyTrend)
+	for _, member := range members {
+		points, err := db.TrophyProgression(member.Tag, since)
+		if err != nil {
+			return nil, err
+		}
+		if len(points) < 2 {
+			continue
+		}
+		trends[clashroyale.NormalizeTag(member.Tag)] = clan.TrophyTrend{
+			Change:  points[len(points)-1].Trophies - points[0].Trophies,
+			Samples: len(points),
+		}
+	}
+	return trends, nil
+}
+
+var clanActivityCSVHeader = []string{
+	"tag", "name", "role", "trophies", "donations", "donations_received", "donation_ratio",
+	"last_seen", "days_inactive", "war_fame", "war_decks_used", "trophy_trend", "suggestion",
+}
+
+func clanActivityCSVRows(rows []clan.MemberActivity) [][]string {
+	out := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		lastSeen := ""
+		if !row.LastSeen.IsZero() {
+			lastSeen = row.LastSeen.UTC().Format(time.RFC3339)
+		}
+		trend := ""
+		if row.TrophyTrend != nil {
+			trend = strconv.Itoa(row.TrophyTrend.Change)
+		}
+		out = append(out, []string{
+			row.Tag,
+			row.Name,
+			row.Role,
+			strconv.Itoa(row.Trophies),
+			strconv.Itoa(row.Donations),
+			strconv.Itoa(row.DonationsReceived),
+			strconv.FormatFloat(row.DonationRatio, 'f', 2, 64),
+			lastSeen,
+			strconv.FormatFloat(row.DaysInactive, 'f', 1, 64),
+			strconv.Itoa(row.WarFame),
+			strconv.Itoa(row.WarDecksUsed),
+			trend,
+			row.Suggestion,
+		})
+	}
+	return out
+}
+
+func formatClanActivity(clanTag string, rows []clan.MemberActivity) string {
+	var buf bytes.Buffer
+	fprintf(&buf, "\nClan Activity: %s (%d members)\n", clanTag, len(rows))
+	fprintf(&buf, "========================\n\n")
+
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fprintf(w, "Member\tRole\tTrophies\tTrend\tDonated\tReceived\tRatio\tLast Seen\tWar Fame\tWar Decks\tSuggestion\n")
+	promote, kick := 0, 0
+	for _, row := range rows {
+		trend := "-"
+		if row.TrophyTrend != nil {
+			trend = fmt.Sprintf("%+d", row.TrophyTrend.Change)
+		}
+		lastSeen := "-"
+		if !row.LastSeen.IsZero() {
+			lastSeen = formatDaysAgo(row.DaysInactive)
+		}
+		switch row.Suggestion {
+		case clan.SuggestPromote:
+			promote++
+		case clan.SuggestKick:
+			kick++
+		}
+		fprintf(w, "%s\t%s\t%d\t%s\t%d\t%d\t%.2f\t%s\t%d\t%d\t%s\n",
+			row.Name, row.Role, row.Trophies, trend, row.Donations, row.DonationsReceived,
+			row.DonationRatio, lastSeen, row.WarFame, row.WarDecksUsed, row.Suggestion)
+	}
+	flushWriter(w)
+	fprintf(&buf, "\nSuggestions: %d promote, %d kick\n", promote, kick)
+	return buf.String()
+}
+
+func formatDaysAgo(days float64) string {
+	if days < 1 {
+		return fmt.Sprintf("%dh ago", int(days*24))
+	}
+	return fmt.Sprintf("%.0fd ago", days)
+}
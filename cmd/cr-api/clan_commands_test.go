@@ -0,0 +1,149 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clan"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+func TestNewClanWarPlan(t *testing.T) {
+	members := []clashroyale.Member{
+		{Tag: "#A", Name: "Leader", Role: "leader", ClanRank: 1},
+		{Tag: "#B", Name: "Finished", Role: "member", ClanRank: 2},
+		{Tag: "#C", Name: "Newcomer", Role: "member", ClanRank: 3},
+	}
+	race := &clashroyale.CurrentRiverRace{
+		PeriodType: clashroyale.RiverRacePeriodWarDay,
+		Clan: clashroyale.RiverRaceClan{
+			Name: "Test Clan",
+			Participants: []clashroyale.RiverRaceParticipant{
+				{Tag: "#A", Fame: 400, DecksUsedToday: 2},
+				{Tag: "#B", Fame: 900, DecksUsedToday: 4},
+				{Tag: "#GONE", Fame: 100, DecksUsedToday: 1},
+			},
+		},
+	}
+
+	plan := newClanWarPlan("#CLAN", members, race)
+	if !plan.BattleDay {
+		t.Fatal("expected battle day")
+	}
+	if plan.UnusedMembers != 2 || plan.UnusedDecks != 6 {
+		t.Fatalf("unused = %d members / %d decks, want 2 / 6", plan.UnusedMembers, plan.UnusedDecks)
+	}
+
+	var order []string
+	for _, member := range plan.Members {
+		order = append(order, member.Name)
+	}
+	if got := strings.Join(order, ","); got != "Leader,Newcomer,Finished" {
+		t.Fatalf("member order = %s, want unused attackers first by rank", got)
+	}
+	if plan.Members[0].Fame != 400 || plan.Members[0].UnusedDecks != 2 {
+		t.Errorf("leader plan = %+v, want fame 400 and 2 unused decks", plan.Members[0])
+	}
+}
+
+func TestNewClanWarPlanTrainingDay(t *testing.T) {
+	members := []clashroyale.Member{{Tag: "#A", Name: "Leader"}}
+	race := &clashroyale.CurrentRiverRace{PeriodType: clashroyale.RiverRacePeriodTraining}
+
+	plan := newClanWarPlan("#CLAN", members, race)
+	if plan.BattleDay || plan.UnusedDecks != 0 || plan.Members[0].UnusedDecks != 0 {
+		t.Fatalf("training day should not flag unused attacks: %+v", plan)
+	}
+	if out := formatClanWarPlan(plan); !strings.Contains(out, "training day") {
+		t.Errorf("expected training day note, got:\n%s", out)
+	}
+}
+
+func TestFormatClanWarPlan(t *testing.T) {
+	plan := clanWarPlan{
+		ClanTag:       "#CLAN",
+		ClanName:      "Test Clan",
+		PeriodType:    clashroyale.RiverRacePeriodWarDay,
+		BattleDay:     true,
+		UnusedMembers: 1,
+		UnusedDecks:   3,
+		Members: []clanWarMemberPlan{
+			{
+				Tag: "#A", Name: "Leader", Role: "leader", DecksUsedToday: 1, UnusedDecks: 3,
+				Decks: []clanWarDeck{{Archetype: "Cycle", Cards: []string{"Hog Rider", "The Log"}, AvgElixir: 2.9}},
+			},
+			{Tag: "#B", Name: "Broken", Role: "member", DecksUsedToday: 4, Error: "failed to get player"},
+		},
+	}
+
+	out := formatClanWarPlan(plan)
+	for _, want := range []string{
+		"Test Clan (#CLAN)",
+		"Unused attacks: 1 members, 3 decks",
+		"1/4",
+		"3 unused",
+		"done",
+		"Hog Rider, The Log",
+		"No war set: failed to get player",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestClanActivityOutput(t *testing.T) {
+	rows := []clan.MemberActivity{
+		{
+			Tag: "#A", Name: "Alpha", Role: "member", Trophies: 7000, Donations: 400, DonationRatio: 4,
+			LastSeen: time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC), DaysInactive: 0.5,
+			WarFame: 2400, WarDecksUsed: 16, TrophyTrend: &clan.TrophyTrend{Change: -30, Samples: 3},
+			Suggestion: clan.SuggestPromote,
+		},
+		{Tag: "#B", Name: "Bravo", Role: "elder", Suggestion: clan.SuggestKick},
+	}
+
+	out := formatClanActivity("#CLAN", rows)
+	for _, want := range []string{"Clan Activity: #CLAN (2 members)", "Alpha", "-30", "12h ago", "promote", "Suggestions: 1 promote, 1 kick"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	csvRows := clanActivityCSVRows(rows)
+	if len(csvRows) != 2 || len(csvRows[0]) != len(clanActivityCSVHeader) {
+		t.Fatalf("CSV rows = %v, want 2 rows of %d columns", csvRows, len(clanActivityCSVHeader))
+	}
+	if got := csvRows[0][7]; got != "2026-10-17T10:00:00Z" {
+		t.Errorf("last_seen = %q", got)
+	}
+	if csvRows[0][11] != "-30" || csvRows[1][11] != "" || csvRows[1][7] != "" {
+		t.Errorf("trend/last_seen columns = %v / %v", csvRows[0], csvRows[1])
+	}
+}
+
+func TestFormatDonationGuide(t *testing.T) {
+	guide := &clan.DonationGuide{
+		Members: 2,
+		Demand: []clan.CardDemand{{
+			Card: "Knight", Rarity: "Common", TotalNeeded: 1600,
+			Members: []clan.CardNeed{{Name: "Alice", Needed: 1500, InDeck: true}, {Name: "Bob", Needed: 100, InDeck: true}},
+		}},
+		Requests:    []clan.RequestSuggestion{{Name: "Alice", Card: "Knight", Rarity: "Common", Needed: 1500, InDeck: true}},
+		Unrequested: []string{"Archers", "Zap"},
+	}
+	out := formatDonationGuide("#CLAN", guide)
+	for _, want := range []string{"#CLAN (2 members)", "Knight (Common): 1600 cards for 2 members", "Alice 1500, Bob 100", "Archers, Zap"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	out = formatDonationGuide("#CLAN", &clan.DonationGuide{})
+	for _, want := range []string{"No member's deck needs", "still needed by someone's deck"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
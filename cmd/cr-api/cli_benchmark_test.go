@@ -25,7 +25,7 @@ func BenchmarkCLICommands(b *testing.B) {
 			name: "PlayerCommand",
 			args: []string{
 				"player",
-				"--tag", "BENCH123",
+				"--tag", "8CRJ20",
 				"--export-csv",
 			},
 		},
@@ -40,7 +40,7 @@ func BenchmarkCLICommands(b *testing.B) {
 			name: "AnalyzeCommand",
 			args: []string{
 				"analyze",
-				"--tag", "BENCH123",
+				"--tag", "8CRJ20",
 				"--export-csv",
 			},
 		},
@@ -49,7 +49,7 @@ func BenchmarkCLICommands(b *testing.B) {
 			args: []string{
 				"deck",
 				"build",
-				"--tag", "BENCH123",
+				"--tag", "8CRJ20",
 				"--strategy", "balanced",
 				"--export-csv",
 			},
@@ -59,7 +59,7 @@ func BenchmarkCLICommands(b *testing.B) {
 			args: []string{
 				"export",
 				"all",
-				"--tag", "BENCH123",
+				"--tag", "8CRJ20",
 			},
 		},
 	}
@@ -94,7 +94,7 @@ func BenchmarkCSVExport(b *testing.B) {
 			name: "SmallExport",
 			args: []string{
 				"player",
-				"--tag", "SMALL123",
+				"--tag", "8P9Q0",
 				"--export-csv",
 			},
 		},
@@ -103,7 +103,7 @@ func BenchmarkCSVExport(b *testing.B) {
 			args: []string{
 				"export",
 				"all",
-				"--tag", "MED123",
+				"--tag", "8YQ2C",
 			},
 		},
 		{
@@ -111,7 +111,7 @@ func BenchmarkCSVExport(b *testing.B) {
 			args: []string{
 				"export",
 				"battles",
-				"--tag", "LARGE123",
+				"--tag", "LQRG28",
 				"--limit", "1000",
 			},
 		},
@@ -144,7 +144,7 @@ func BenchmarkConcurrentCommands(b *testing.B) {
 				err := cmd.Run(context.Background(), []string{
 					"cr-api",
 					"player",
-					"--tag", "CONCURRENT123",
+					"--tag", "CQRR0C29",
 					"--export-csv",
 				})
 				if err != nil {
@@ -156,9 +156,9 @@ func BenchmarkConcurrentCommands(b *testing.B) {
 
 	b.Run("ConcurrentMixedCommands", func(b *testing.B) {
 		commands := [][]string{
-			{"cr-api", "player", "--tag", "MIX123", "--export-csv"},
+			{"cr-api", "player", "--tag", "9UV28", "--export-csv"},
 			{"cr-api", "cards", "--export-csv"},
-			{"cr-api", "analyze", "--tag", "MIX123", "--export-csv"},
+			{"cr-api", "analyze", "--tag", "9UV28", "--export-csv"},
 		}
 
 		b.RunParallel(func(pb *testing.PB) {
@@ -191,7 +191,7 @@ func BenchmarkMemoryUsage(b *testing.B) {
 			err := cmd.Run(context.Background(), []string{
 				"cr-api",
 				"player",
-				"--tag", "MEM123",
+				"--tag", "2JUP8",
 				"--export-csv",
 				"--save",
 			})
@@ -210,7 +210,7 @@ func BenchmarkMemoryUsage(b *testing.B) {
 				"cr-api",
 				"export",
 				"all",
-				"--tag", "MEMALL123",
+				"--tag", "JUL822",
 			})
 			if err != nil {
 				b.Fatalf("Command failed: %v", err)
@@ -256,7 +256,7 @@ func TestCLIProfiling(t *testing.T) {
 				"cr-api",
 				"export",
 				"all",
-				"--tag", "CPU123",
+				"--tag", "CPU29",
 			})
 			if err != nil {
 				t.Errorf("Command failed during profiling: %v", err)
@@ -274,7 +274,7 @@ func TestCLIProfiling(t *testing.T) {
 				"cr-api",
 				"export",
 				"battles",
-				"--tag", "MEM123",
+				"--tag", "2JUP8",
 				"--limit", "100",
 			})
 			if err != nil {
@@ -298,7 +298,7 @@ func BenchmarkDataProcessing(b *testing.B) {
 			err := cmd.Run(context.Background(), []string{
 				"cr-api",
 				"player",
-				"--tag", "PROC123",
+				"--tag", "2RGV9",
 				"--export-csv",
 				"--save",
 			})
@@ -314,7 +314,7 @@ func BenchmarkDataProcessing(b *testing.B) {
 			err := cmd.Run(context.Background(), []string{
 				"cr-api",
 				"analyze",
-				"--tag", "ANALYSIS123",
+				"--tag", "YL2QQ89",
 				"--export-csv",
 				"--save",
 			})
@@ -331,7 +331,7 @@ func BenchmarkDataProcessing(b *testing.B) {
 				"cr-api",
 				"events",
 				"scan",
-				"--tag", "EVENT123",
+				"--tag", "8VUJ92",
 				"--days", "30",
 				"--export-csv",
 			})
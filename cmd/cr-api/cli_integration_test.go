@@ -27,7 +27,7 @@ func TestCLIIntegration(t *testing.T) {
 			name: "Player command with export",
 			args: []string{
 				"player",
-				"--tag", "TEST12345",
+				"--tag", "9YLQ20G8P",
 				"--export-csv",
 				"--save",
 			},
@@ -47,7 +47,7 @@ func TestCLIIntegration(t *testing.T) {
 			name: "Analyze command with export",
 			args: []string{
 				"analyze",
-				"--tag", "TEST12345",
+				"--tag", "9YLQ20G8P",
 				"--export-csv",
 				"--save",
 			},
@@ -59,7 +59,7 @@ func TestCLIIntegration(t *testing.T) {
 			args: []string{
 				"deck",
 				"build",
-				"--tag", "TEST12345",
+				"--tag", "9YLQ20G8P",
 				"--strategy", "balanced",
 				"--save",
 				"--export-csv",
@@ -72,7 +72,7 @@ func TestCLIIntegration(t *testing.T) {
 			args: []string{
 				"events",
 				"scan",
-				"--tag", "TEST12345",
+				"--tag", "9YLQ20G8P",
 				"--days", "7",
 				"--save",
 				"--export-csv",
@@ -85,7 +85,7 @@ func TestCLIIntegration(t *testing.T) {
 			args: []string{
 				"export",
 				"all",
-				"--tag", "TEST12345",
+				"--tag", "9YLQ20G8P",
 				"--timestamp",
 			},
 			validate: validateExportAll,
@@ -111,7 +111,7 @@ func TestCLIIntegration(t *testing.T) {
 			args: []string{
 				"deck",
 				"build-suite",
-				"--tag", "TEST12345",
+				"--tag", "9YLQ20G8P",
 				"--strategies", "balanced,aggro",
 				"--variations", "2",
 				"--save",
@@ -124,7 +124,7 @@ func TestCLIIntegration(t *testing.T) {
 			args: []string{
 				"deck",
 				"evaluate-batch",
-				"--tag", "TEST12345",
+				"--tag", "9YLQ20G8P",
 				"--deck-dir", "data/decks",
 				"--format", "json",
 				"--save-aggregated",
@@ -149,7 +149,7 @@ func TestCLIIntegration(t *testing.T) {
 			args: []string{
 				"deck",
 				"analyze-suite",
-				"--tag", "TEST12345",
+				"--tag", "9YLQ20G8P",
 				"--strategies", "all",
 				"--variations", "1",
 				"--top-n", "5",
@@ -373,7 +373,7 @@ func TestErrorHandling(t *testing.T) {
 			name: "Missing API token",
 			args: []string{
 				"player",
-				"--tag", "TEST123",
+				"--tag", "2PYQ98",
 			},
 			setup: func() {
 				os.Unsetenv("CLASH_ROYALE_API_TOKEN")
@@ -397,7 +397,7 @@ func TestErrorHandling(t *testing.T) {
 			args: []string{
 				"deck",
 				"build",
-				"--tag", "TEST123",
+				"--tag", "2PYQ98",
 				"--strategy", "invalid_strategy",
 			},
 			setup: func() {
@@ -410,7 +410,7 @@ func TestErrorHandling(t *testing.T) {
 			args: []string{
 				"export",
 				"player",
-				"--tag", "TEST123",
+				"--tag", "2PYQ98",
 				"--types", "invalid_type",
 			},
 			setup: func() {
@@ -458,7 +458,7 @@ func TestPerformanceAndConcurrency(t *testing.T) {
 		cmd := createTestCommand(tempDir)
 		args := []string{
 			"player",
-			"--tag", "PERF123",
+			"--tag", "9PG82",
 			"--export-csv",
 		}
 
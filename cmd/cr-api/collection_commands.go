@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/sqlstore"
+	"github.com/klauer/clash-royale-api/go/internal/storage"
+	"github.com/klauer/clash-royale-api/go/pkg/collection"
+	"github.com/urfave/cli/v3"
+)
+
+// addCollectionCommand adds the collection completion tracker to the CLI
+func addCollectionCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "collection",
+		Usage: "Track collection completion: owned vs total cards per rarity, missing cards, overflow, and a completion projection",
+		Flags: []cli.Flag{
+			playerTagFlag(true),
+			&cli.IntFlag{
+				Name:  "missing",
+				Value: 20,
+				Usage: "Number of missing cards to list (0 = all)",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: batchFormatHuman,
+				Usage: "Output format: human, json",
+			},
+		},
+		Action: collectionCommand,
+	}
+}
+
+func collectionCommand(ctx context.Context, cmd *cli.Command) error {
+	format := strings.ToLower(strings.TrimSpace(cmd.String("format")))
+	if format != batchFormatHuman && format != batchFormatJSON {
+		return fmt.Errorf("unknown format: %s (supported: human, json)", format)
+	}
+	tag := cmd.String("tag")
+	dataDir := cmd.String("data-dir")
+	verbose := cmd.Bool("verbose")
+
+	client, err := requireAPIClient(cmd, apiClientOptions{})
+	if err != nil {
+		return err
+	}
+	player, err := client.GetPlayerWithContext(ctx, tag)
+	if err != nil {
+		return fmt.Errorf("failed to get player: %w", err)
+	}
+	allCards, err := loadStaticCards(ctx, dataDir, cmd.String("api-token"), verbose)
+	if err != nil {
+		return err
+	}
+
+	history, err := collectionHistory(dataDir, tag)
+	if err != nil && verbose {
+		printf("Warning: Failed to read collection history: %v\n", err)
+	}
+
+	report := collection.Build(player, allCards, history, time.Now())
+
+	if format == batchFormatJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		printf("%s\n", data)
+		return nil
+	}
+	printf("%s", formatCollectionReport(report, cmd.Int("missing")))
+	return nil
+}
+
+// collectionHistory reads owned card counts from recorded player snapshots.
+// Without a history database there is nothing to project from.
+func collectionHistory(dataDir, tag string) ([]collection.Snapshot, error) {
+	path := sqlstore.DefaultPath(dataDir)
+	if !storage.FileExists(path) {
+		return nil, nil
+	}
+	db, err := sqlstore.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer closeFile(db)
+
+	snapshots, err := db.PlayerSnapshots(tag, time.Time{}, 0)
+	if err != nil {
+		return nil, err
+	}
+	history := make([]collection.Snapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		history = append(history, collection.Snapshot{Time: snapshot.FetchedAt, Owned: len(snapshot.Player.Cards)})
+	}
+	return history, nil
+}
+
+func formatCollectionReport(report *collection.Report, missingLimit int) string {
+	var buf bytes.Buffer
+	fprintf(&buf, "\nCollection for %s (%s)\n", report.PlayerName, report.PlayerTag)
+	fprintf(&buf, "==============================\n")
+	fprintf(&buf, "Owned: %d/%d cards (%.1f%%)\n\n", report.Owned, report.Total, report.Percent)
+
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fprintf(w, "Rarity\tOwned\tTotal\tComplete\n")
+	for _, r := range report.Rarities {
+		fprintf(w, "%s\t%d\t%d\t%.1f%%\n", r.Rarity, r.Owned, r.Total, r.Percent)
+	}
+	flushWriter(w)
+
+	if len(report.Missing) > 0 {
+		missing := report.Missing
+		fprintf(&buf, "\nMissing Cards (%d)\n", len(missing))
+		if missingLimit > 0 && len(missing) > missingLimit {
+			missing = missing[:missingLimit]
+		}
+		w = tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+		fprintf(w, "Card\tRarity\tUnlocks In\tAvailable\n")
+		for _, m := range missing {
+			available := "yes"
+			if !m.Unlocked {
+				available = "no"
+			}
+			fprintf(w, "%s\t%s\t%s (%d)\t%s\n", m.Name, m.Rarity, m.ArenaName, m.UnlockArena, available)
+		}
+		flushWriter(w)
+		if len(missing) < len(report.Missing) {
+			fprintf(&buf, "... and %d more (use --missing 0 to list all)\n", len(report.Missing)-len(missing))
+		}
+	}
+
+	if len(report.Overflow) > 0 {
+		fprintf(&buf, "\nDuplicate Overflow\n")
+		w = tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+		fprintf(w, "Card\tRarity\tExtra Cards\tStar Points\n")
+		for _, o := range report.Overflow {
+			fprintf(w, "%s\t%s\t%d\t%d\n", o.Name, o.Rarity, o.ExtraCards, o.StarPoints)
+		}
+		flushWriter(w)
+		fprintf(&buf, "Overflow converts to about %d star points (%d on hand)\n", report.OverflowPoints, report.StarPoints)
+	}
+
+	fprintf(&buf, "\n")
+	if p := report.Projection; p != nil {
+		fprintf(&buf, "Projected completion: %s (%.2f new cards/day since %s, %.0f days left)\n",
+			p.CompletesAt.Local().Format("2006-01-02"), p.CardsPerDay, p.Since.Local().Format("2006-01-02"), p.DaysRemaining)
+	} else {
+		fprintf(&buf, "No completion projection: %s", report.ProjectionReason)
+		if report.ProjectionReason != "collection complete" {
+			fprintf(&buf, " (record snapshots with `cr-api db sync --tag %s`)", report.PlayerTag)
+		}
+		fprintf(&buf, "\n")
+	}
+	return buf.String()
+}
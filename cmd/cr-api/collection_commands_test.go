@@ -0,0 +1,70 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/sqlstore"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/collection"
+)
+
+func TestFormatCollectionReport(t *testing.T) {
+	report := &collection.Report{
+		PlayerTag: "#ABC", PlayerName: "Tester", Owned: 3, Total: 6, Percent: 50,
+		Rarities: []collection.RarityCompletion{{Rarity: "Common", Owned: 2, Total: 2, Percent: 100}},
+		Missing: []collection.MissingCard{
+			{Name: "Musketeer", Rarity: "Rare", ArenaName: "Training Camp", Unlocked: true},
+			{Name: "Miner", Rarity: "Legendary", UnlockArena: 6, ArenaName: "Builder's Workshop"},
+		},
+		Overflow:       []collection.Overflow{{Name: "Knight", Rarity: "Common", ExtraCards: 30, StarPoints: 30}},
+		OverflowPoints: 30,
+		Projection: &collection.Projection{
+			CardsPerDay: 0.2, DaysRemaining: 15,
+			CompletesAt: time.Date(2026, 11, 1, 12, 0, 0, 0, time.UTC),
+			Since:       time.Date(2026, 10, 7, 12, 0, 0, 0, time.UTC),
+		},
+	}
+	out := formatCollectionReport(report, 1)
+	for _, want := range []string{"Owned: 3/6 cards (50.0%)", "Musketeer", "... and 1 more", "Knight", "about 30 star points", "0.20 new cards/day"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Miner") {
+		t.Errorf("missing list not limited:\n%s", out)
+	}
+
+	report.Projection, report.ProjectionReason = nil, "no recorded history"
+	if out := formatCollectionReport(report, 0); !strings.Contains(out, "db sync --tag #ABC") {
+		t.Errorf("expected a history hint:\n%s", out)
+	}
+}
+
+func TestCollectionHistory(t *testing.T) {
+	dataDir := t.TempDir()
+	if history, err := collectionHistory(dataDir, "#ABC"); err != nil || history != nil {
+		t.Fatalf("collectionHistory() without a database = %+v, %v", history, err)
+	}
+
+	db, err := sqlstore.Open(filepath.Join(dataDir, sqlstore.DBFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	player := &clashroyale.Player{Tag: "#ABC", Cards: []clashroyale.Card{{Name: "Knight"}, {Name: "Zap"}}}
+	if _, err := db.RecordPlayer(player, at, "test"); err != nil {
+		t.Fatal(err)
+	}
+	closeFile(db)
+
+	history, err := collectionHistory(dataDir, "#ABC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 1 || history[0].Owned != 2 || !history[0].Time.Equal(at) {
+		t.Errorf("history = %+v", history)
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/pkg/playercompare"
+	"github.com/urfave/cli/v3"
+)
+
+// addComparePlayersCommand adds the side-by-side player comparison to the CLI
+func addComparePlayersCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "compare-players",
+		Usage: "Compare players side by side: trophies, win rates, card levels per rarity, shared decks, and head-to-head",
+		Flags: []cli.Flag{
+			tagsFlag(&cli.StringSliceFlag{
+				Name:     "tags",
+				Usage:    "Player tags to compare (comma-separated or repeated, at least 2)",
+				Required: true,
+			}),
+			&cli.IntFlag{
+				Name:  "shared-decks",
+				Value: 5,
+				Usage: "Number of shared decks to list (0 = all)",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: batchFormatHuman,
+				Usage: "Output format: human, json",
+			},
+		},
+		Action: comparePlayersCommand,
+	}
+}
+
+func comparePlayersCommand(ctx context.Context, cmd *cli.Command) error {
+	format := strings.ToLower(strings.TrimSpace(cmd.String("format")))
+	if format != batchFormatHuman && format != batchFormatJSON {
+		return fmt.Errorf("unknown format: %s (supported: human, json)", format)
+	}
+	var tags []string
+	for _, tag := range cmd.StringSlice("tags") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) < 2 {
+		return fmt.Errorf("--tags needs at least 2 players to compare")
+	}
+
+	client, err := requireAPIClient(cmd, apiClientOptions{})
+	if err != nil {
+		return err
+	}
+
+	inputs := make([]playercompare.Input, 0, len(tags))
+	for _, tag := range tags {
+		player, err := client.GetPlayerWithContext(ctx, tag)
+		if err != nil {
+			return fmt.Errorf("failed to get player %s: %w", tag, err)
+		}
+		input := playercompare.Input{Player: player}
+		battles, err := client.GetPlayerBattleLogWithContext(ctx, tag)
+		if err != nil {
+			// Recent stats and head-to-head are skipped; the profile is still comparable.
+			fprintf(os.Stderr, "Warning: no battle log for %s: %v\n", tag, err)
+		} else {
+			input.Battles = *battles
+		}
+		inputs = append(inputs, input)
+	}
+
+	result := playercompare.Compare(inputs)
+	if limit := cmd.Int("shared-decks"); limit > 0 && len(result.SharedDecks) > limit {
+		result.SharedDecks = result.SharedDecks[:limit]
+	}
+
+	if format == batchFormatJSON {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		printf("%s\n", data)
+		return nil
+	}
+	printf("%s", formatPlayerComparison(result))
+	return nil
+}
+
+func formatPlayerComparison(result *playercompare.Comparison) string {
+	var buf bytes.Buffer
+	fprintf(&buf, "\nPlayer Comparison (%d players)\n", len(result.Players))
+	fprintf(&buf, "==============================\n\n")
+
+	names := make(map[string]string, len(result.Players))
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fprintf(w, "\t")
+	for _, p := range result.Players {
+		names[p.Tag] = p.Name
+		fprintf(w, "%s\t", p.Name)
+	}
+	fprintf(w, "\n")
+
+	row := func(label string, cell func(p playercompare.PlayerSummary) string) {
+		fprintf(w, "%s\t", label)
+		for _, p := range result.Players {
+			fprintf(w, "%s\t", cell(p))
+		}
+		fprintf(w, "\n")
+	}
+	row("Tag", func(p playercompare.PlayerSummary) string { return p.Tag })
+	row("Trophies", func(p playercompare.PlayerSummary) string { return fmt.Sprintf("%d", p.Trophies) })
+	row("Best", func(p playercompare.PlayerSummary) string { return fmt.Sprintf("%d", p.BestTrophies) })
+	row("Career Win Rate", func(p playercompare.PlayerSummary) string {
+		return fmt.Sprintf("%.1f%% (%d-%d)", p.WinRate, p.Wins, p.Losses)
+	})
+	row("Recent Win Rate", func(p playercompare.PlayerSummary) string {
+		if p.RecentBattles == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%.1f%% (%d/%d)", p.RecentWinRate, p.RecentWins, p.RecentBattles)
+	})
+	for _, rarity := range config.GetAllRarities() {
+		row("Avg "+rarity+" Lvl", func(p playercompare.PlayerSummary) string {
+			if avg, ok := p.AvgLevels[rarity]; ok {
+				return fmt.Sprintf("%.2f", avg)
+			}
+			return "-"
+		})
+	}
+	row("Top Deck Games", func(p playercompare.PlayerSummary) string {
+		if p.TopDeck == nil {
+			return "-"
+		}
+		return fmt.Sprintf("%d (%d wins)", p.TopDeck.Battles, p.TopDeck.Wins)
+	})
+	flushWriter(w)
+
+	for _, p := range result.Players {
+		if p.TopDeck != nil {
+			fprintf(&buf, "\n%s's most played deck: %s", p.Name, strings.Join(p.TopDeck.Cards, ", "))
+		}
+	}
+	fprintf(&buf, "\n")
+
+	fprintf(&buf, "\nShared Decks\n")
+	if len(result.SharedDecks) == 0 {
+		fprintf(&buf, "  None of these players share a deck.\n")
+	}
+	for _, d := range result.SharedDecks {
+		players := make([]string, len(d.Players))
+		for i, tag := range d.Players {
+			players[i] = names[tag]
+		}
+		fprintf(&buf, "  %s\n    played by %s (%d games, %d wins)\n",
+			strings.Join(d.Cards, ", "), strings.Join(players, ", "), d.Battles, d.Wins)
+	}
+
+	fprintf(&buf, "\nHead-to-Head\n")
+	if len(result.HeadToHead) == 0 {
+		fprintf(&buf, "  No battles between these players in their recent battle logs.\n")
+	}
+	for _, h := range result.HeadToHead {
+		fprintf(&buf, "  %s %d - %d %s", names[h.PlayerA], h.WinsA, h.WinsB, names[h.PlayerB])
+		if h.Draws > 0 {
+			fprintf(&buf, " (%d draws)", h.Draws)
+		}
+		fprintf(&buf, "\n")
+	}
+	return buf.String()
+}
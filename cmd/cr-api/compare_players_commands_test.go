@@ -0,0 +1,37 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/playercompare"
+)
+
+func TestFormatPlayerComparison(t *testing.T) {
+	result := &playercompare.Comparison{
+		Players: []playercompare.PlayerSummary{
+			{
+				Tag: "#AAA", Name: "Alice", Trophies: 7000, Wins: 60, Losses: 40, WinRate: 60,
+				RecentBattles: 2, RecentWins: 1, RecentWinRate: 50,
+				AvgLevels: map[string]float64{"Common": 13},
+				TopDeck:   &playercompare.DeckUsage{Cards: []string{"Hog Rider", "Musketeer"}, Battles: 2, Wins: 1},
+			},
+			{Tag: "#BBB", Name: "Bob", Trophies: 6500},
+		},
+		SharedDecks: []playercompare.SharedDeck{{Cards: []string{"Hog Rider", "Musketeer"}, Players: []string{"#AAA", "#BBB"}, Battles: 2}},
+		HeadToHead:  []playercompare.HeadToHead{{PlayerA: "#AAA", PlayerB: "#BBB", WinsB: 1}},
+	}
+	out := formatPlayerComparison(result)
+	for _, want := range []string{"Alice", "Bob", "60.0% (60-40)", "50.0% (1/2)", "13.00", "played by Alice, Bob", "Alice 0 - 1 Bob", "Alice's most played deck"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	out = formatPlayerComparison(&playercompare.Comparison{Players: result.Players})
+	for _, want := range []string{"share a deck", "No battles between"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,340 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/internal/datapath"
+	"github.com/urfave/cli/v3"
+)
+
+const configCommandName = "config"
+
+// configFileExemptFlags lists flag names that mean something else under a
+// top-level command, so the shared config key must not fill them in. The
+// clan commands' --tag is a clan tag, not the default player tag.
+var configFileExemptFlags = map[string][]string{
+	"clan": {"tag"},
+}
+
+// configSource feeds a flag from the config file. It sits after the flag's
+// environment variables, so precedence is flag > env > config > default.
+type configSource struct {
+	file *config.File
+	key  string
+}
+
+func (s *configSource) Lookup() (string, bool) {
+	return s.file.Lookup(s.key)
+}
+
+func (s *configSource) String() string {
+	return fmt.Sprintf("config key %q in %s", s.key, s.file.Path)
+}
+
+func (s *configSource) GoString() string {
+	return fmt.Sprintf("&configSource{key:%q,path:%q}", s.key, s.file.Path)
+}
+
+// applyConfigFile loads the config file and makes it a fallback source for
+// every flag in the command tree. A broken file is reported and ignored so
+// `cr-api config` can still be used to fix it.
+func applyConfigFile(root *cli.Command) {
+	file, err := config.LoadFile(config.FilePath())
+	if err != nil {
+		fprintf(os.Stderr, "Warning: ignoring config file: %v\n", err)
+		return
+	}
+	visitConfigurableFlags(root, func(name string, sources *cli.ValueSourceChain, _ cli.Flag) {
+		sources.Chain = append(sources.Chain, &configSource{file: file, key: name})
+	})
+}
+
+// visitConfigurableFlags calls fn once for every flag the config file can
+// set, skipping the config command itself and exempt flags.
+func visitConfigurableFlags(root *cli.Command, fn func(name string, sources *cli.ValueSourceChain, flag cli.Flag)) {
+	seen := map[cli.Flag]bool{}
+	var visit func(cmd *cli.Command, exempt []string)
+	visit = func(cmd *cli.Command, exempt []string) {
+		for _, flag := range cmd.Flags {
+			sources, name := flagSources(flag)
+			if sources == nil || seen[flag] || slices.Contains(exempt, name) {
+				continue
+			}
+			seen[flag] = true
+			fn(name, sources, flag)
+		}
+		for _, sub := range cmd.Commands {
+			if cmd == root && sub.Name == configCommandName {
+				continue
+			}
+			subExempt := exempt
+			if cmd == root {
+				subExempt = configFileExemptFlags[sub.Name]
+			}
+			visit(sub, subExempt)
+		}
+	}
+	visit(root, nil)
+}
+
+// flagSources returns the value source chain and long name of the flag
+// types the CLI uses.
+func flagSources(flag cli.Flag) (*cli.ValueSourceChain, string) {
+	switch f := flag.(type) {
+	case *cli.StringFlag:
+		return &f.Sources, f.Name
+	case *cli.IntFlag:
+		return &f.Sources, f.Name
+	case *cli.FloatFlag:
+		return &f.Sources, f.Name
+	case *cli.BoolFlag:
+		return &f.Sources, f.Name
+	case *cli.DurationFlag:
+		return &f.Sources, f.Name
+	case *cli.StringSliceFlag:
+		return &f.Sources, f.Name
+	default:
+		return nil, ""
+	}
+}
+
+// configurableFlags indexes the settable flags by name. One name can belong
+// to several commands.
+func configurableFlags(root *cli.Command) map[string][]cli.Flag {
+	flags := map[string][]cli.Flag{}
+	visitConfigurableFlags(root, func(name string, _ *cli.ValueSourceChain, flag cli.Flag) {
+		flags[name] = append(flags[name], flag)
+	})
+	return flags
+}
+
+// validateConfigValue checks that value parses for every flag named key.
+func validateConfigValue(key, value string, flags []cli.Flag) error {
+	for _, flag := range flags {
+		var err error
+		switch flag.(type) {
+		case *cli.IntFlag:
+			_, err = strconv.ParseInt(value, 0, 64)
+		case *cli.FloatFlag:
+			_, err = strconv.ParseFloat(value, 64)
+		case *cli.BoolFlag:
+			_, err = strconv.ParseBool(value)
+		case *cli.DurationFlag:
+			_, err = time.ParseDuration(value)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid value %q for %s: %w", value, key, err)
+		}
+	}
+	return nil
+}
+
+// addConfigCommands adds the config command group for ~/.cr-api/config.yaml
+func addConfigCommands() *cli.Command {
+	return &cli.Command{
+		Name:  configCommandName,
+		Usage: "Manage default flag values in the config file (" + config.FileEnvVar + " overrides its location)",
+		Commands: []*cli.Command{
+			{
+				Name:  "init",
+				Usage: "Create a commented config file",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "force",
+						Usage: "Overwrite an existing config file",
+					},
+				},
+				Action: configInitCommand,
+			},
+			{
+				Name:  "show",
+				Usage: "Show the config file path and its values (tokens and passwords are masked)",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					_ = ctx
+					return configShowCommand(cmd.Root())
+				},
+			},
+			{
+				Name:      "set",
+				Usage:     "Set a default value; keys are long flag names such as tag, workers, or api-token",
+				ArgsUsage: "<key> <value>",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					_ = ctx
+					args := configArgs(cmd)
+					if len(args) != 2 {
+						return errors.New("usage: cr-api config set <key> <value>")
+					}
+					return configSetCommand(cmd.Root(), args[0], args[1])
+				},
+			},
+			{
+				Name:      "unset",
+				Usage:     "Remove a value from the config file",
+				ArgsUsage: "<key>",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					_ = ctx
+					args := configArgs(cmd)
+					if len(args) != 1 {
+						return errors.New("usage: cr-api config unset <key>")
+					}
+					return configUnsetCommand(args[0])
+				},
+			},
+		},
+	}
+}
+
+// configArgs returns the positional arguments. When a key names a flag that
+// is already set (say data-dir from $DATA_DIR), the CLI library treats it as
+// a default-command lookup and prepends an empty argument, which is dropped.
+func configArgs(cmd *cli.Command) []string {
+	args := cmd.Args().Slice()
+	if len(args) > 0 && args[0] == "" {
+		args = args[1:]
+	}
+	return args
+}
+
+const configTemplate = `# cr-api configuration
+#
+# Keys are long flag names and apply to every command that has that flag.
+# Command-line flags and environment variables take precedence over values
+# set here. Edit this file or use: cr-api config set <key> <value>
+#
+# api-token: your-api-token
+# data-dir: %s
+# tag: "#ABC123"
+# workers: 4
+# storage: json
+# ga-population: 100
+# ga-generations: 200
+# ga-mutation-rate: 0.1
+`
+
+func configInitCommand(ctx context.Context, cmd *cli.Command) error {
+	_ = ctx
+	path := config.FilePath()
+	if _, err := os.Stat(path); err == nil && !cmd.Bool("force") {
+		return fmt.Errorf("config file already exists at %s (use --force to overwrite)", path)
+	}
+	if err := config.WriteFile(path, fmt.Appendf(nil, configTemplate, datapath.AppDirOrFallback())); err != nil {
+		return err
+	}
+	printf("Created %s\n", path)
+	return nil
+}
+
+func configShowCommand(root *cli.Command) error {
+	path := config.FilePath()
+	file, err := config.LoadFile(path)
+	if err != nil {
+		return err
+	}
+	printf("Config file: %s\n", path)
+	keys := file.Keys()
+	if len(keys) == 0 {
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			printf("\nNo config file yet; create one with `cr-api config init`.\n")
+		} else {
+			printf("\nNo values set.\n")
+		}
+		return nil
+	}
+
+	flags := configurableFlags(root)
+	printf("\n")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, key := range keys {
+		value, ok := file.Lookup(key)
+		if !ok {
+			value = "(unset)"
+		} else if isSecretConfigKey(key) {
+			value = maskSecret(value)
+		}
+		fprintf(w, "  %s\t%s\t%s\n", key, value, configKeyNote(key, flags[key]))
+	}
+	return w.Flush()
+}
+
+// configKeyNote explains when a config value will not take effect.
+func configKeyNote(key string, flags []cli.Flag) string {
+	if len(flags) == 0 {
+		return "(unknown key, ignored)"
+	}
+	for _, flag := range flags {
+		sources, _ := flagSources(flag)
+		for _, env := range sources.EnvKeys() {
+			if _, set := os.LookupEnv(env); set {
+				return "(overridden by $" + env + ")"
+			}
+		}
+	}
+	return ""
+}
+
+// isSecretConfigKey reports whether a key holds a credential, such as
+// api-token, sync-password, or s3-secret-key.
+func isSecretConfigKey(key string) bool {
+	for _, word := range []string{"token", "password", "secret"} {
+		if strings.Contains(key, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// maskSecret keeps only the last four characters of a secret.
+func maskSecret(value string) string {
+	if len(value) <= 4 {
+		return strings.Repeat("*", len(value))
+	}
+	return strings.Repeat("*", 8) + value[len(value)-4:]
+}
+
+func configSetCommand(root *cli.Command, key, value string) error {
+	flags := configurableFlags(root)[key]
+	if len(flags) == 0 {
+		return fmt.Errorf("unknown config key %q: keys are long flag names such as api-token, data-dir, tag, or workers", key)
+	}
+	if err := validateConfigValue(key, value, flags); err != nil {
+		return err
+	}
+
+	file, err := config.LoadFile(config.FilePath())
+	if err != nil {
+		return err
+	}
+	file.Set(key, value)
+	if err := file.Save(); err != nil {
+		return err
+	}
+	if isSecretConfigKey(key) {
+		value = maskSecret(value)
+	}
+	printf("Set %s = %s in %s\n", key, value, file.Path)
+	return nil
+}
+
+func configUnsetCommand(key string) error {
+	file, err := config.LoadFile(config.FilePath())
+	if err != nil {
+		return err
+	}
+	if !file.Unset(key) {
+		return fmt.Errorf("%s is not set in %s", key, file.Path)
+	}
+	if err := file.Save(); err != nil {
+		return err
+	}
+	printf("Removed %s from %s\n", key, file.Path)
+	return nil
+}
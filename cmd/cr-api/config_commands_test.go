@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/urfave/cli/v3"
+)
+
+type configProbe struct {
+	tag     string
+	workers int
+	clanTag string
+}
+
+// newConfigTestRoot builds a small command tree with the flag shapes the
+// config file feeds: an env-backed string, a plain int, and an exempt flag.
+func newConfigTestRoot(probe *configProbe) *cli.Command {
+	return &cli.Command{
+		Name: "cr-api",
+		Commands: []*cli.Command{
+			{
+				Name: "deck",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "tag", Required: true, Sources: cli.EnvVars("CR_API_TEST_TAG")},
+					&cli.IntFlag{Name: "workers", Value: 1},
+				},
+				Action: func(_ context.Context, cmd *cli.Command) error {
+					probe.tag = cmd.String("tag")
+					probe.workers = cmd.Int("workers")
+					return nil
+				},
+			},
+			{
+				Name: "clan",
+				Commands: []*cli.Command{{
+					Name:  "info",
+					Flags: []cli.Flag{&cli.StringFlag{Name: "tag", Value: "none"}},
+					Action: func(_ context.Context, cmd *cli.Command) error {
+						probe.clanTag = cmd.String("tag")
+						return nil
+					},
+				}},
+			},
+			addConfigCommands(),
+		},
+	}
+}
+
+func writeTestConfig(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(config.FileEnvVar, path)
+	return path
+}
+
+func TestConfigFilePrecedence(t *testing.T) {
+	writeTestConfig(t, "tag: \"#CFG\"\nworkers: 8\n")
+
+	tests := []struct {
+		name        string
+		env         string
+		args        []string
+		wantTag     string
+		wantWorkers int
+	}{
+		{name: "config fills unset flags", args: []string{"deck"}, wantTag: "#CFG", wantWorkers: 8},
+		{name: "env beats config", env: "#ENV", args: []string{"deck"}, wantTag: "#ENV", wantWorkers: 8},
+		{name: "flag beats env and config", env: "#ENV", args: []string{"deck", "--tag", "#FLAG", "--workers", "2"}, wantTag: "#FLAG", wantWorkers: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.env != "" {
+				t.Setenv("CR_API_TEST_TAG", tt.env)
+			}
+			var probe configProbe
+			root := newConfigTestRoot(&probe)
+			applyConfigFile(root)
+			if err := root.Run(context.Background(), append([]string{"cr-api"}, tt.args...)); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if probe.tag != tt.wantTag || probe.workers != tt.wantWorkers {
+				t.Errorf("tag, workers = %q, %d; want %q, %d", probe.tag, probe.workers, tt.wantTag, tt.wantWorkers)
+			}
+		})
+	}
+
+	var probe configProbe
+	root := newConfigTestRoot(&probe)
+	applyConfigFile(root)
+	if err := root.Run(context.Background(), []string{"cr-api", "clan", "info"}); err != nil {
+		t.Fatal(err)
+	}
+	if probe.clanTag != "none" {
+		t.Errorf("clan --tag = %q; the player tag key must not apply to clan commands", probe.clanTag)
+	}
+}
+
+func TestConfigFileBadValue(t *testing.T) {
+	writeTestConfig(t, "tag: \"#CFG\"\nworkers: lots\n")
+	root := newConfigTestRoot(&configProbe{})
+	applyConfigFile(root)
+	err := root.Run(context.Background(), []string{"cr-api", "deck"})
+	if err == nil || !strings.Contains(err.Error(), `config key "workers"`) {
+		t.Errorf("expected a parse error naming the config key, got %v", err)
+	}
+}
+
+func runConfigCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	return captureStdout(t, func() error {
+		root := newConfigTestRoot(&configProbe{})
+		applyConfigFile(root)
+		return root.Run(context.Background(), append([]string{"cr-api", "config"}, args...))
+	})
+}
+
+func TestConfigCommands(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "config.yaml")
+	t.Setenv(config.FileEnvVar, path)
+
+	if out, err := runConfigCommand(t, "show"); err != nil || !strings.Contains(out, "config init") {
+		t.Fatalf("show without a file = %q, %v", out, err)
+	}
+	if _, err := runConfigCommand(t, "init"); err != nil {
+		t.Fatalf("init error = %v", err)
+	}
+	if _, err := runConfigCommand(t, "init"); err == nil {
+		t.Error("init must not overwrite an existing file without --force")
+	}
+
+	if _, err := runConfigCommand(t, "set", "workers", "many"); err == nil {
+		t.Error("expected an invalid integer to be rejected")
+	}
+	if _, err := runConfigCommand(t, "set", "ga-unknown", "1"); err == nil {
+		t.Error("expected an unknown key to be rejected")
+	}
+	for _, kv := range [][2]string{{"tag", "#ABC123"}, {"workers", "6"}} {
+		if _, err := runConfigCommand(t, "set", kv[0], kv[1]); err != nil {
+			t.Fatalf("set %s error = %v", kv[0], err)
+		}
+	}
+
+	out, err := runConfigCommand(t, "show")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{path, "tag", "#ABC123", "workers", "6"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("show output missing %q:\n%s", want, out)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "# cr-api configuration") {
+		t.Errorf("set dropped the init template comments:\n%s", data)
+	}
+
+	if _, err := runConfigCommand(t, "unset", "workers"); err != nil {
+		t.Fatalf("unset error = %v", err)
+	}
+	file, err := config.LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := file.Lookup("workers"); ok {
+		t.Error("workers still set after unset")
+	}
+}
+
+func TestMaskSecret(t *testing.T) {
+	if got := maskSecret("abcdefghijk"); got != "********hijk" {
+		t.Errorf("maskSecret() = %q", got)
+	}
+	if got := maskSecret("abc"); got != "***" {
+		t.Errorf("maskSecret(short) = %q", got)
+	}
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/sqlstore"
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	storageBackendJSON   = "json"
+	storageBackendSQLite = "sqlite"
+)
+
+// addDBCommands adds the SQLite history database commands to the CLI
+func addDBCommands() *cli.Command {
+	return &cli.Command{
+		Name:  "db",
+		Usage: "Manage the SQLite history database (players, analyses, battles, decks)",
+		Commands: []*cli.Command{
+			{
+				Name:   "migrate",
+				Usage:  "Import existing JSON player, analysis, deck, and cached battle log files into the database",
+				Action: dbMigrateCommand,
+			},
+			{
+				Name:  "sync",
+				Usage: "Fetch a player and their battle log and record both in the database",
+				Flags: []cli.Flag{
+					playerTagFlag(true),
+					trackDecksFlag(),
+				},
+				Action: dbSyncCommand,
+			},
+			{
+				Name:  "trophies",
+				Usage: "Show trophy progression from recorded player snapshots and ladder battles",
+				Flags: []cli.Flag{
+					playerTagFlag(true),
+					&cli.IntFlag{
+						Name:  "days",
+						Value: 30,
+						Usage: "Only include the last N days (0 = all history)",
+					},
+					&cli.StringFlag{
+						Name:  "format",
+						Value: batchFormatHuman,
+						Usage: "Output format: human, json",
+					},
+				},
+				Action: dbTrophiesCommand,
+			},
+			{
+				Name:   "stats",
+				Usage:  "Show database location and row counts",
+				Action: dbStatsCommand,
+			},
+		},
+	}
+}
+
+// openHistoryDB opens the SQLite database inside the data directory.
+func openHistoryDB(cmd *cli.Command) (*sqlstore.Store, error) {
+	return sqlstore.Open(sqlstore.DefaultPath(cmd.String("data-dir")))
+}
+
+// recordInSQLite mirrors a save into the history database when
+// --storage=sqlite; JSON files are still written for offline commands.
+func recordInSQLite(cmd *cli.Command, record func(db *sqlstore.Store) error) error {
+	switch backend := strings.ToLower(cmd.String("storage")); backend {
+	case "", storageBackendJSON:
+		return nil
+	case storageBackendSQLite:
+	default:
+		return fmt.Errorf("unknown storage backend %q (supported: json, sqlite)", backend)
+	}
+
+	db, err := openHistoryDB(cmd)
+	if err != nil {
+		return err
+	}
+	defer closeFile(db)
+	return record(db)
+}
+
+func dbMigrateCommand(ctx context.Context, cmd *cli.Command) error {
+	_ = ctx
+	db, err := openHistoryDB(cmd)
+	if err != nil {
+		return err
+	}
+	defer closeFile(db)
+
+	report, err := db.ImportJSON(cmd.String("data-dir"))
+	if err != nil {
+		return fmt.Errorf("migration failed: %w", err)
+	}
+
+	printf("Imported into %s:\n", db.Path())
+	printf("  Players:  %d\n", report.Players)
+	printf("  Analyses: %d\n", report.Analyses)
+	printf("  Battles:  %d\n", report.Battles)
+	printf("  Decks:    %d\n", report.Decks)
+	printf("  Already imported: %d\n", report.Skipped)
+	if len(report.Errors) > 0 {
+		printf("Skipped %d unreadable files:\n", len(report.Errors))
+		for _, msg := range report.Errors {
+			printf("  %s\n", msg)
+		}
+	}
+	return nil
+}
+
+func dbSyncCommand(ctx context.Context, cmd *cli.Command) error {
+	tag := cmd.String("tag")
+	client, err := requireAPIClient(cmd, apiClientOptions{})
+	if err != nil {
+		return err
+	}
+
+	player, err := client.GetPlayerWithContext(ctx, tag)
+	if err != nil {
+		return fmt.Errorf("failed to get player: %w", err)
+	}
+	battles, err := client.GetPlayerBattleLogWithContext(ctx, tag)
+	if err != nil {
+		return fmt.Errorf("failed to get battle log: %w", err)
+	}
+
+	db, err := openHistoryDB(cmd)
+	if err != nil {
+		return err
+	}
+	defer closeFile(db)
+
+	if _, err := db.RecordPlayer(player, time.Now(), "db sync"); err != nil {
+		return err
+	}
+	added, err := db.RecordBattles(player.Tag, *battles)
+	if err != nil {
+		return err
+	}
+	printf("Recorded %s (%s): %d trophies, %d new battles\n", player.Name, player.Tag, player.Trophies, added)
+	if cmd.Bool("track-decks") {
+		tracked, err := recordStoredDeckOutcomes(player.Tag, *battles)
+		if err != nil {
+			return err
+		}
+		if tracked > 0 {
+			printf("Recorded %d new results for saved decks\n", tracked)
+		}
+	}
+	return nil
+}
+
+func dbTrophiesCommand(ctx context.Context, cmd *cli.Command) error {
+	_ = ctx
+	format := strings.ToLower(strings.TrimSpace(cmd.String("format")))
+	if format != batchFormatHuman && format != batchFormatJSON {
+		return fmt.Errorf("unknown format: %s (supported: human, json)", format)
+	}
+
+	var since time.Time
+	if days := cmd.Int("days"); days > 0 {
+		since = time.Now().AddDate(0, 0, -days)
+	}
+
+	db, err := openHistoryDB(cmd)
+	if err != nil {
+		return err
+	}
+	defer closeFile(db)
+
+	tag := cmd.String("tag")
+	points, err := db.TrophyProgression(tag, since)
+	if err != nil {
+		return err
+	}
+	if len(points) == 0 {
+		return fmt.Errorf("no trophy history for %s (run `cr-api db sync --tag %s` or `cr-api db migrate`)", tag, tag)
+	}
+
+	if format == batchFormatJSON {
+		data, err := json.MarshalIndent(points, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		printf("%s\n", data)
+		return nil
+	}
+	printf("%s", formatTrophyProgression(tag, points))
+	return nil
+}
+
+func formatTrophyProgression(tag string, points []sqlstore.TrophyPoint) string {
+	var buf bytes.Buffer
+	first, last := points[0], points[len(points)-1]
+	low, high := first.Trophies, first.Trophies
+	for _, point := range points {
+		low = min(low, point.Trophies)
+		high = max(high, point.Trophies)
+	}
+
+	fprintf(&buf, "\nTrophy Progression for %s\n", tag)
+	fprintf(&buf, "========================\n")
+	fprintf(&buf, "%s → %s: %d → %d (%+d), low %d, high %d\n\n",
+		first.Time.Local().Format("2006-01-02"), last.Time.Local().Format("2006-01-02"),
+		first.Trophies, last.Trophies, last.Trophies-first.Trophies, low, high)
+
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fprintf(w, "Time\tTrophies\tChange\tSource\n")
+	previous := first.Trophies
+	for _, point := range points {
+		fprintf(w, "%s\t%d\t%+d\t%s\n", point.Time.Local().Format("2006-01-02 15:04"), point.Trophies, point.Trophies-previous, point.Source)
+		previous = point.Trophies
+	}
+	flushWriter(w)
+	return buf.String()
+}
+
+func dbStatsCommand(ctx context.Context, cmd *cli.Command) error {
+	_ = ctx
+	db, err := openHistoryDB(cmd)
+	if err != nil {
+		return err
+	}
+	defer closeFile(db)
+
+	counts, err := db.Counts()
+	if err != nil {
+		return err
+	}
+	printf("Database: %s\n", db.Path())
+	printf("  Players:  %d snapshots\n", counts.Players)
+	printf("  Analyses: %d\n", counts.Analyses)
+	printf("  Battles:  %d\n", counts.Battles)
+	printf("  Decks:    %d\n", counts.Decks)
+	return nil
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/sqlstore"
+	"github.com/klauer/clash-royale-api/go/pkg/season"
+	"github.com/urfave/cli/v3"
+)
+
+func TestRecordInSQLite(t *testing.T) {
+	run := func(t *testing.T, backend string) (string, error) {
+		t.Helper()
+		dataDir := t.TempDir()
+		cmd := &cli.Command{
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "data-dir"},
+				&cli.StringFlag{Name: "storage"},
+			},
+			Action: func(context.Context, *cli.Command) error { return nil },
+		}
+		if err := cmd.Run(context.Background(), []string{"db-test", "--data-dir", dataDir, "--storage", backend}); err != nil {
+			t.Fatalf("failed to run command for test setup: %v", err)
+		}
+		return dataDir, recordInSQLite(cmd, func(db *sqlstore.Store) error {
+			_, err := db.RecordAnalysis("#ABC", time.Now(), map[string]string{"ok": "yes"}, "test")
+			return err
+		})
+	}
+
+	dataDir, err := run(t, storageBackendJSON)
+	if err != nil {
+		t.Fatalf("json backend error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, sqlstore.DBFileName)); !os.IsNotExist(err) {
+		t.Error("json backend should not create a database")
+	}
+
+	dataDir, err = run(t, storageBackendSQLite)
+	if err != nil {
+		t.Fatalf("sqlite backend error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, sqlstore.DBFileName)); err != nil {
+		t.Errorf("sqlite backend should create the database: %v", err)
+	}
+
+	if _, err := run(t, "postgres"); err == nil {
+		t.Error("expected error for unknown backend")
+	}
+}
+
+func TestFormatTrophyProgression(t *testing.T) {
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	out := formatTrophyProgression("#ABC", []sqlstore.TrophyPoint{
+		{Time: base, Trophies: 7000, Source: "snapshot"},
+		{Time: base.Add(time.Hour), Trophies: 6970, Source: "battle"},
+		{Time: base.Add(2 * time.Hour), Trophies: 7060, Source: "battle"},
+	})
+	for _, want := range []string{"7000 → 7060 (+60), low 6970, high 7060", "-30", "+90"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatSeasonReport(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	stats := season.Report([]season.Point{
+		{Time: now.AddDate(0, 0, -8), Trophies: 5300, Arena: "Arena A"},
+		{Time: now.AddDate(0, 0, -5), Trophies: 5600, Arena: "Arena B"},
+		{Time: now.AddDate(0, 0, -2), Trophies: 5900, Arena: "Arena B"},
+	}, now)
+
+	out := formatSeasonReport("#ABC", stats)
+	for _, want := range []string{"Season Report for #ABC", "2026-10", "+600", "Promoted to Arena B", "Projected end:"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -308,7 +308,7 @@ func runPhase2EvaluateAllDecks(ctx context.Context, builtDecks []suiteDeckInfo,
 				AvgElixir:         deckEvalResult.AvgElixir,
 				EvaluatedAt:       deckStart,
 				PlayerTag:         tag,
-				EvaluationVersion: "1.0.0",
+				EvaluationVersion: evaluation.CurrentScoringVersion,
 			}
 
 			_, _, err := storage.InsertDeck(entry)
@@ -19,7 +19,10 @@ func addDeckBuildCommand() *cli.Command {
 		&cli.BoolFlag{Name: "prioritize-upgrades", Usage: "Prioritize cards that can be upgraded soon"},
 		&cli.BoolFlag{Name: exportCSVFlagName, Usage: "Export deck analysis to CSV"},
 		&cli.BoolFlag{Name: saveFlagName, Usage: "Save deck to file"},
+		exportImageFlag(),
+		levelWindowFlag(),
 	}
+	flags = append(flags, cardPoolFlags()...)
 	flags = append(flags, deckSharedBuilderFlags()...)
 	flags = append(flags,
 		&cli.BoolFlag{Name: fromAnalysisFlagName, Aliases: []string{"a"}, Usage: "Enable offline mode: load analysis from JSON file instead of fetching from API"},
@@ -79,6 +82,7 @@ func addDeckBuildSuiteCommand() *cli.Command {
 			Usage: "Cards that must be excluded from all decks",
 		},
 	}
+	flags = append(flags, cardPoolFlags()...)
 	flags = append(flags, deckSharedBuilderFlags()...)
 	flags = append(flags, &cli.BoolFlag{
 		Name:  saveFlagName,
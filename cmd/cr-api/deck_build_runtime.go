@@ -18,6 +18,13 @@ func deckBuildCommand(ctx context.Context, cmd *cli.Command) error {
 	if err := configureCombatStats(cmd); err != nil {
 		return err
 	}
+	if err := validateExportImageFlag(cmd); err != nil {
+		return err
+	}
+	cardPool, err := loadCardPoolFlags(cmd)
+	if err != nil {
+		return err
+	}
 
 	builder, err := configureDeckBuilder(cmd, flags.DataDir, flags.Strategy)
 	if err != nil {
@@ -37,8 +44,16 @@ func deckBuildCommand(ctx context.Context, cmd *cli.Command) error {
 		return err
 	}
 	applyBoostedLevelsToCardAnalysis(&playerData.CardAnalysis, overrides)
+	window, err := levelWindow(cmd, analysisCandidates(playerData.CardAnalysis))
+	if err != nil {
+		return err
+	}
 
 	applyExcludeFilter(&playerData.CardAnalysis, flags.ExcludeCards)
+	applyCardPoolToAnalysis(&playerData.CardAnalysis, cardPool, cmd.Bool("verbose"))
+	applyArenaLockToAnalysis(cmd, &playerData.CardAnalysis, cmd.Bool("verbose"))
+	fullAnalysis := playerData.CardAnalysis
+	applyLevelWindowToAnalysis(window, &playerData.CardAnalysis, cmd.Bool("verbose"))
 
 	if strings.ToLower(strings.TrimSpace(flags.Strategy)) == deckStrategyAll {
 		return buildAllStrategies(ctx, cmd, builder, playerData.CardAnalysis, playerData.PlayerName, playerData.PlayerTag)
@@ -50,7 +65,10 @@ func deckBuildCommand(ctx context.Context, cmd *cli.Command) error {
 	}
 
 	validateElixirConstraints(deckRec, flags.MinElixir, flags.MaxElixir)
-	displayDeckRecommendationOffline(deckRec, playerData.PlayerName, playerData.PlayerTag)
+	displayDeckRecommendationOffline(deckRec, playerData.PlayerName, playerData.PlayerTag, newDeckLinkEncoder(flags.DataDir))
+	if window != nil {
+		displayLevelWindowBuild(window, fullAnalysis, deckRec)
+	}
 
 	upgrades := displayUpgradeRecommendationsIfEnabled(
 		cmd,
@@ -68,6 +86,9 @@ func deckBuildCommand(ctx context.Context, cmd *cli.Command) error {
 	if err := saveDeckIfRequested(cmd, builder, deckRec, playerData.PlayerTag, flags.DataDir); err != nil {
 		return err
 	}
+	if err := exportDeckImageIfRequested(ctx, cmd, "", deckRec.Deck); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -186,6 +207,10 @@ func deckBuildSuiteCommand(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
+	cardPool, err := loadCardPoolFlags(cmd)
+	if err != nil {
+		return err
+	}
 
 	if verbose {
 		printf("Building deck suite with %d strategies x %d variations = %d total decks\n",
@@ -211,6 +236,8 @@ func deckBuildSuiteCommand(ctx context.Context, cmd *cli.Command) error {
 
 	// Apply exclude filter
 	applyExcludeFilter(&playerData.CardAnalysis, excludeCards)
+	applyCardPoolToAnalysis(&playerData.CardAnalysis, cardPool, verbose)
+	applyArenaLockToAnalysis(cmd, &playerData.CardAnalysis, verbose)
 
 	// Build decks for all strategy x variation combinations
 	type deckResult struct {
@@ -333,7 +333,7 @@ func displayIdealDeck(cmd *cli.Command, builder *deck.Builder, cardAnalysis deck
 		return
 	}
 
-	displayDeckRecommendationOffline(idealDeckRec, playerName, playerTag)
+	displayDeckRecommendationOffline(idealDeckRec, playerName, playerTag, newDeckLinkEncoder(cmd.String("data-dir")))
 
 	// Show comparison
 	printf("\n")
@@ -403,11 +403,14 @@ func saveDeckIfRequested(cmd *cli.Command, builder *deck.Builder, deckRec *deck.
 	return nil
 }
 
-func displayDeckRecommendationOffline(rec *deck.DeckRecommendation, playerName, playerTag string) {
+func displayDeckRecommendationOffline(rec *deck.DeckRecommendation, playerName, playerTag string, links deckLinkEncoder) {
 	printDeckBuilderHeader("RECOMMENDED 1v1 LADDER DECK")
 
 	printf("Player: %s (%s)\n", playerName, playerTag)
 	printf("Average Elixir: %.2f\n", rec.AvgElixir)
+	if rec.TowerTroop != "" {
+		printf("Tower Troop: %s\n", rec.TowerTroop)
+	}
 
 	// Display combat stats information if available
 	if combatWeight := os.Getenv("COMBAT_STATS_WEIGHT"); combatWeight != "" {
@@ -441,6 +444,10 @@ func displayDeckRecommendationOffline(rec *deck.DeckRecommendation, playerName,
 	}
 	flushWriter(w)
 
+	if link := links.Link(rec.Deck); link != "" {
+		printf("\nCopy Deck: %s\n", link)
+	}
+
 	// Display strategic notes
 	if len(rec.Notes) > 0 {
 		printf("\nStrategic Notes:\n")
@@ -509,6 +516,7 @@ func buildAllStrategies(ctx context.Context, cmd *cli.Command, builder *deck.Bui
 	displayAllStrategiesHeader(playerName, playerTag)
 
 	filteredAnalysis := applyCardExclusions(cardAnalysis, excludeCards)
+	links := newDeckLinkEncoder(cmd.String("data-dir"))
 
 	for i, strategy := range strategies {
 		strategyBuilder, err := createStrategyBuilder(cmd)
@@ -527,7 +535,10 @@ func buildAllStrategies(ctx context.Context, cmd *cli.Command, builder *deck.Bui
 			continue
 		}
 
-		displayStrategyDeck(i+1, strategy, deckRec, verbose)
+		displayStrategyDeck(i+1, strategy, deckRec, links, verbose)
+		if err := exportDeckImageIfRequested(ctx, cmd, string(strategy), deckRec.Deck); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -619,7 +630,7 @@ func applyCardExclusions(cardAnalysis deck.CardAnalysis, excludeCards []string)
 }
 
 // displayStrategyDeck displays a single deck with its strategy label
-func displayStrategyDeck(rank int, strategy deck.Strategy, rec *deck.DeckRecommendation, verbose bool) {
+func displayStrategyDeck(rank int, strategy deck.Strategy, rec *deck.DeckRecommendation, links deckLinkEncoder, verbose bool) {
 	printf("Strategy #%d: %s\n", rank, strings.ToUpper(string(strategy)))
 	printf("═══════════════════════════════════════════════════════════════════\n")
 	printf("Average Elixir: %.2f\n\n", rec.AvgElixir)
@@ -644,6 +655,10 @@ func displayStrategyDeck(rank int, strategy deck.Strategy, rec *deck.DeckRecomme
 	}
 	flushWriter(w)
 
+	if link := links.Link(rec.Deck); link != "" {
+		printf("Copy Deck: %s\n", link)
+	}
+
 	// Display strategic notes if verbose
 	if verbose && len(rec.Notes) > 0 {
 		printf("\nStrategic Notes:\n")
@@ -662,6 +677,8 @@ func convertToDeckCardAnalysis(cardAnalysis *analysis.CardAnalysis, player *clas
 		AnalysisTime: cardAnalysis.AnalysisTime.Format(time.RFC3339),
 		PlayerName:   player.Name,
 		PlayerTag:    player.Tag,
+		TowerTroops:  player.TowerTroopNames(),
+		ArenaID:      player.Arena.ID,
 	}
 	for cardName, cardInfo := range cardAnalysis.CardLevels {
 		result.CardLevels[cardName] = deck.CardLevelData{
@@ -19,11 +19,13 @@ func addDeckCommands() *cli.Command {
 		Usage: "Deck building and analysis commands",
 		Commands: []*cli.Command{
 			addDeckEvaluateCommand(),
+			addDeckExplainCommand(),
 			addDeckBuildCommand(),
 			addDeckBuildSuiteCommand(),
 			addDeckEvaluateBatchCommand(),
 			addDeckAnalyzeSuiteCommand(),
 			addDeckWarCommand(),
+			addDeckSetCommand(),
 			addDeckAnalyzeCommand(),
 			addDeckOptimizeCommand(),
 			addDeckRecommendCommand(),
@@ -32,6 +34,16 @@ func addDeckCommands() *cli.Command {
 			addDeckPossibleCountCommand(),
 			addDeckFuzzCommand(),
 			addDeckCompareAlgorithmsCommand(),
+			addDeckMatchupCommand(),
+			addDeckCompareCommand(),
+			addDeckGuideCommand(),
+			addDeckInteractionCommand(),
+			addDeckCounterCommand(),
+			addDeckTemplatesCommand(),
+			addDeckLinkCommand(),
+			addDeckSynergyCommand(),
+			addDeckPluginsCommand(),
+			addDeckPredictCommand(),
 			addDeckResearchEvalCommand(),
 			addDiscoverCommands(),
 			addLeaderboardCommands(),
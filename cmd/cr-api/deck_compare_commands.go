@@ -0,0 +1,410 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/matchup"
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	compareRecommendDeckA  = "deck_a"
+	compareRecommendDeckB  = "deck_b"
+	compareRecommendEither = "either"
+
+	// Differences in overall score, and in average win probability against
+	// an archetype, under which neither deck is preferred
+	compareEvenScoreMargin = 0.1
+	compareEvenWinMargin   = 0.02
+)
+
+// addDeckCompareCommand adds the two-deck comparison command
+func addDeckCompareCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "compare",
+		Usage: "Diff two decks: category scores, synergies, archetypes, and cards, with a recommendation",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "deck-a",
+				Usage:    "First deck (8 cards separated by dashes)",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "deck-b",
+				Usage:    "Second deck (8 cards separated by dashes)",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "name-a",
+				Value: "Deck A",
+				Usage: "Display name for the first deck",
+			},
+			&cli.StringFlag{
+				Name:  "name-b",
+				Value: "Deck B",
+				Usage: "Display name for the second deck",
+			},
+			&cli.StringFlag{
+				Name:  "vs-archetype",
+				Usage: "Recommend the deck that plays better against this archetype (beatdown, control, cycle, bridge, siege, bait, graveyard, miner)",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: batchFormatHuman,
+				Usage: "Output format: human, json",
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "Output file path (optional, prints to stdout if not specified)",
+			},
+		},
+		Action: deckCompareTwoCommand,
+	}
+}
+
+// deckComparisonSide summarizes one deck of a comparison
+type deckComparisonSide struct {
+	Name            string               `json:"name"`
+	Deck            []string             `json:"deck"`
+	OverallScore    float64              `json:"overall_score"`
+	OverallRating   evaluation.Rating    `json:"overall_rating"`
+	Archetype       evaluation.Archetype `json:"archetype"`
+	AvgElixir       float64              `json:"avg_elixir"`
+	SynergyPairs    int                  `json:"synergy_pairs"`
+	SynergyCoverage float64              `json:"synergy_coverage"`
+}
+
+// deckComparisonCategory is one category score of both decks
+type deckComparisonCategory struct {
+	Name  string  `json:"name"`
+	DeckA float64 `json:"deck_a"`
+	DeckB float64 `json:"deck_b"`
+	// Delta is deck B's score minus deck A's
+	Delta float64 `json:"delta"`
+}
+
+// deckComparisonSynergy splits the synergy pairs of both decks
+type deckComparisonSynergy struct {
+	Shared []deck.SynergyPair `json:"shared"`
+	OnlyA  []deck.SynergyPair `json:"only_a"`
+	OnlyB  []deck.SynergyPair `json:"only_b"`
+}
+
+// deckComparisonVsArchetype averages each deck's matchups against the
+// reference decks of an archetype
+type deckComparisonVsArchetype struct {
+	Archetype       evaluation.Archetype `json:"archetype"`
+	ReferenceDecks  []string             `json:"reference_decks"`
+	WinProbabilityA float64              `json:"win_probability_a"`
+	WinProbabilityB float64              `json:"win_probability_b"`
+}
+
+// deckComparison is the full two-deck diff
+type deckComparison struct {
+	DeckA          deckComparisonSide         `json:"deck_a"`
+	DeckB          deckComparisonSide         `json:"deck_b"`
+	Categories     []deckComparisonCategory   `json:"categories"`
+	SharedCards    []string                   `json:"shared_cards"`
+	OnlyA          []string                   `json:"only_a"`
+	OnlyB          []string                   `json:"only_b"`
+	Synergy        deckComparisonSynergy      `json:"synergy"`
+	VsArchetype    *deckComparisonVsArchetype `json:"vs_archetype,omitempty"`
+	Recommended    string                     `json:"recommended"`
+	Recommendation string                     `json:"recommendation"`
+}
+
+func deckCompareTwoCommand(ctx context.Context, cmd *cli.Command) error {
+	_ = ctx
+	format := strings.ToLower(strings.TrimSpace(cmd.String("format")))
+	if format != batchFormatHuman && format != batchFormatJSON {
+		return fmt.Errorf("unknown format: %s (supported: human, json)", format)
+	}
+
+	deckA, err := parseDeckStringWithLabel(cmd.String("deck-a"), "deck-a")
+	if err != nil {
+		return err
+	}
+	deckB, err := parseDeckStringWithLabel(cmd.String("deck-b"), "deck-b")
+	if err != nil {
+		return err
+	}
+
+	comparison, err := compareTwoDecks(cmd.String("name-a"), deckA, cmd.String("name-b"), deckB,
+		evaluation.Archetype(strings.ToLower(strings.TrimSpace(cmd.String("vs-archetype")))))
+	if err != nil {
+		return err
+	}
+
+	var formatted string
+	if format == batchFormatJSON {
+		data, err := json.MarshalIndent(comparison, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		formatted = string(data) + "\n"
+	} else {
+		formatted = formatDeckComparisonHuman(comparison)
+	}
+
+	return writeTextOutput(formatted, cmd.String("output"), textOutputOptions{
+		saveMessage: "Comparison saved to",
+	})
+}
+
+// compareTwoDecks evaluates both decks and diffs them. A non-empty
+// vsArchetype bases the recommendation on matchups against that archetype's
+// reference decks instead of the overall scores.
+func compareTwoDecks(nameA string, deckA []string, nameB string, deckB []string, vsArchetype evaluation.Archetype) (*deckComparison, error) {
+	synergyDB := deck.NewSynergyDatabase()
+	cardsA := convertToCardCandidates(deckA)
+	cardsB := convertToCardCandidates(deckB)
+	resultA := evaluation.Evaluate(cardsA, synergyDB, nil)
+	resultB := evaluation.Evaluate(cardsB, synergyDB, nil)
+
+	comparison := &deckComparison{
+		DeckA:       newDeckComparisonSide(nameA, resultA),
+		DeckB:       newDeckComparisonSide(nameB, resultB),
+		SharedCards: sortedCardIntersection(deckA, deckB),
+		OnlyA:       sortedCardDifference(deckA, deckB),
+		OnlyB:       sortedCardDifference(deckB, deckA),
+		Synergy:     diffSynergyPairs(resultA.SynergyMatrix.Pairs, resultB.SynergyMatrix.Pairs),
+	}
+	for _, category := range getEvaluationCategories() {
+		a, b := category.get(resultA).Score, category.get(resultB).Score
+		comparison.Categories = append(comparison.Categories, deckComparisonCategory{
+			Name:  category.name,
+			DeckA: a,
+			DeckB: b,
+			Delta: b - a,
+		})
+	}
+
+	if vsArchetype == "" {
+		comparison.Recommended = pickComparedDeck(resultA.OverallScore, resultB.OverallScore, compareEvenScoreMargin)
+		comparison.Recommendation = describeRecommendation(comparison, fmt.Sprintf("overall score %.2f vs %.2f",
+			resultA.OverallScore, resultB.OverallScore))
+		return comparison, nil
+	}
+
+	vs, err := compareAgainstArchetype(cardsA, cardsB, vsArchetype)
+	if err != nil {
+		return nil, err
+	}
+	comparison.VsArchetype = vs
+	comparison.Recommended = pickComparedDeck(vs.WinProbabilityA, vs.WinProbabilityB, compareEvenWinMargin)
+	comparison.Recommendation = describeRecommendation(comparison, fmt.Sprintf("%.1f%% vs %.1f%% predicted win rate against %d %s reference decks",
+		vs.WinProbabilityA*100, vs.WinProbabilityB*100, len(vs.ReferenceDecks), vsArchetype))
+	return comparison, nil
+}
+
+func newDeckComparisonSide(name string, result evaluation.EvaluationResult) deckComparisonSide {
+	return deckComparisonSide{
+		Name:            name,
+		Deck:            result.Deck,
+		OverallScore:    result.OverallScore,
+		OverallRating:   result.OverallRating,
+		Archetype:       result.DetectedArchetype,
+		AvgElixir:       result.AvgElixir,
+		SynergyPairs:    result.SynergyMatrix.PairCount,
+		SynergyCoverage: result.SynergyMatrix.SynergyCoverage,
+	}
+}
+
+// compareAgainstArchetype plays both decks against every built-in archetype
+// corpus deck labeled with archetype and averages their win probabilities.
+func compareAgainstArchetype(cardsA, cardsB []deck.CardCandidate, archetype evaluation.Archetype) (*deckComparisonVsArchetype, error) {
+	analyzer := matchup.NewAnalyzer(nil, nil)
+	vs := &deckComparisonVsArchetype{Archetype: archetype}
+	available := make(map[evaluation.Archetype]bool)
+	var totalA, totalB float64
+	for _, reference := range evaluation.ArchetypeCorpus() {
+		available[reference.Archetype] = true
+		if reference.Archetype != archetype && reference.Secondary != archetype {
+			continue
+		}
+		names := make([]string, len(reference.Cards))
+		for i, card := range reference.Cards {
+			names[i] = card.Name
+		}
+		opponent := convertToCardCandidates(names)
+		resultA, err := analyzer.Analyze(cardsA, opponent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to analyze matchup against %s: %w", reference.Name, err)
+		}
+		resultB, err := analyzer.Analyze(cardsB, opponent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to analyze matchup against %s: %w", reference.Name, err)
+		}
+		totalA += resultA.WinProbabilityA
+		totalB += resultB.WinProbabilityA
+		vs.ReferenceDecks = append(vs.ReferenceDecks, reference.Name)
+	}
+	if len(vs.ReferenceDecks) == 0 {
+		archetypes := make([]string, 0, len(available))
+		for known := range available {
+			archetypes = append(archetypes, string(known))
+		}
+		slices.Sort(archetypes)
+		return nil, fmt.Errorf("no reference decks for archetype %q (available: %s)", archetype, strings.Join(archetypes, ", "))
+	}
+	count := float64(len(vs.ReferenceDecks))
+	vs.WinProbabilityA = math.Round(totalA/count*1000) / 1000
+	vs.WinProbabilityB = math.Round(totalB/count*1000) / 1000
+	return vs, nil
+}
+
+func pickComparedDeck(a, b, margin float64) string {
+	switch {
+	case math.Abs(a-b) < margin:
+		return compareRecommendEither
+	case a > b:
+		return compareRecommendDeckA
+	default:
+		return compareRecommendDeckB
+	}
+}
+
+func describeRecommendation(comparison *deckComparison, basis string) string {
+	situation := ""
+	if comparison.VsArchetype != nil {
+		situation = fmt.Sprintf(" against %s", comparison.VsArchetype.Archetype)
+	}
+	switch comparison.Recommended {
+	case compareRecommendDeckA:
+		return fmt.Sprintf("Play %s%s (%s)", comparison.DeckA.Name, situation, basis)
+	case compareRecommendDeckB:
+		return fmt.Sprintf("Play %s%s (%s)", comparison.DeckB.Name, situation, basis)
+	default:
+		return fmt.Sprintf("Either deck%s: too close to call (%s)", situation, basis)
+	}
+}
+
+// diffSynergyPairs splits pairs into those found in both decks and those
+// found in only one. Each list is sorted by score, strongest first.
+func diffSynergyPairs(pairsA, pairsB []deck.SynergyPair) deckComparisonSynergy {
+	key := func(pair deck.SynergyPair) string {
+		if pair.Card1 > pair.Card2 {
+			return pair.Card2 + "|" + pair.Card1
+		}
+		return pair.Card1 + "|" + pair.Card2
+	}
+	inB := make(map[string]bool, len(pairsB))
+	for _, pair := range pairsB {
+		inB[key(pair)] = true
+	}
+	inA := make(map[string]bool, len(pairsA))
+	diff := deckComparisonSynergy{Shared: []deck.SynergyPair{}, OnlyA: []deck.SynergyPair{}, OnlyB: []deck.SynergyPair{}}
+	for _, pair := range pairsA {
+		inA[key(pair)] = true
+		if inB[key(pair)] {
+			diff.Shared = append(diff.Shared, pair)
+		} else {
+			diff.OnlyA = append(diff.OnlyA, pair)
+		}
+	}
+	for _, pair := range pairsB {
+		if !inA[key(pair)] {
+			diff.OnlyB = append(diff.OnlyB, pair)
+		}
+	}
+	for _, pairs := range [][]deck.SynergyPair{diff.Shared, diff.OnlyA, diff.OnlyB} {
+		slices.SortStableFunc(pairs, func(x, y deck.SynergyPair) int {
+			return cmp.Compare(y.Score, x.Score)
+		})
+	}
+	return diff
+}
+
+func sortedCardIntersection(a, b []string) []string {
+	shared := []string{}
+	for _, card := range a {
+		if slices.Contains(b, card) {
+			shared = append(shared, card)
+		}
+	}
+	slices.Sort(shared)
+	return shared
+}
+
+func sortedCardDifference(a, b []string) []string {
+	only := []string{}
+	for _, card := range a {
+		if !slices.Contains(b, card) {
+			only = append(only, card)
+		}
+	}
+	slices.Sort(only)
+	return only
+}
+
+func formatDeckComparisonHuman(c *deckComparison) string {
+	var buf bytes.Buffer
+
+	fprintf(&buf, "\nDeck Comparison\n")
+	fprintf(&buf, "===============\n")
+	fprintf(&buf, "%s: %s\n", c.DeckA.Name, strings.Join(c.DeckA.Deck, " - "))
+	fprintf(&buf, "%s: %s\n\n", c.DeckB.Name, strings.Join(c.DeckB.Deck, " - "))
+
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fprintf(w, "Metric\t%s\t%s\tDelta\n", c.DeckA.Name, c.DeckB.Name)
+	fprintf(w, "------\t------\t------\t-----\n")
+	fprintf(w, "Overall Score\t%.2f (%s)\t%.2f (%s)\t%+.2f\n", c.DeckA.OverallScore, c.DeckA.OverallRating,
+		c.DeckB.OverallScore, c.DeckB.OverallRating, c.DeckB.OverallScore-c.DeckA.OverallScore)
+	for _, category := range c.Categories {
+		fprintf(w, "%s\t%.2f\t%.2f\t%+.2f\n", category.Name, category.DeckA, category.DeckB, category.Delta)
+	}
+	fprintf(w, "Archetype\t%s\t%s\t\n", c.DeckA.Archetype, c.DeckB.Archetype)
+	fprintf(w, "Avg Elixir\t%.2f\t%.2f\t%+.2f\n", c.DeckA.AvgElixir, c.DeckB.AvgElixir, c.DeckB.AvgElixir-c.DeckA.AvgElixir)
+	fprintf(w, "Synergy Pairs\t%d (%.0f%%)\t%d (%.0f%%)\t%+d\n", c.DeckA.SynergyPairs, c.DeckA.SynergyCoverage,
+		c.DeckB.SynergyPairs, c.DeckB.SynergyCoverage, c.DeckB.SynergyPairs-c.DeckA.SynergyPairs)
+	flushWriter(w)
+
+	fprintf(&buf, "\nCards\n")
+	fprintf(&buf, "  Shared:        %s\n", joinOrNone(c.SharedCards))
+	fprintf(&buf, "  Only in %s: %s\n", c.DeckA.Name, joinOrNone(c.OnlyA))
+	fprintf(&buf, "  Only in %s: %s\n", c.DeckB.Name, joinOrNone(c.OnlyB))
+
+	fprintf(&buf, "\nSynergies\n")
+	for _, group := range []struct {
+		label string
+		pairs []deck.SynergyPair
+	}{
+		{"Shared", c.Synergy.Shared},
+		{"Only in " + c.DeckA.Name, c.Synergy.OnlyA},
+		{"Only in " + c.DeckB.Name, c.Synergy.OnlyB},
+	} {
+		fprintf(&buf, "  %s:\n", group.label)
+		if len(group.pairs) == 0 {
+			fprintf(&buf, "    (none)\n")
+		}
+		for _, pair := range group.pairs {
+			fprintf(&buf, "    %s + %s (%.2f, %s)\n", pair.Card1, pair.Card2, pair.Score, pair.SynergyType)
+		}
+	}
+
+	if c.VsArchetype != nil {
+		fprintf(&buf, "\nAgainst %s (%d reference decks)\n", c.VsArchetype.Archetype, len(c.VsArchetype.ReferenceDecks))
+		fprintf(&buf, "  %s: %.1f%% predicted win rate\n", c.DeckA.Name, c.VsArchetype.WinProbabilityA*100)
+		fprintf(&buf, "  %s: %.1f%% predicted win rate\n", c.DeckB.Name, c.VsArchetype.WinProbabilityB*100)
+	}
+
+	fprintf(&buf, "\nRecommendation: %s\n", c.Recommendation)
+	return buf.String()
+}
+
+func joinOrNone(cards []string) string {
+	if len(cards) == 0 {
+		return "(none)"
+	}
+	return strings.Join(cards, ", ")
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCompareTwoDecks(t *testing.T) {
+	hog := []string{"Hog Rider", "Musketeer", "Cannon", "Ice Spirit", "Skeletons", "The Log", "Fireball", "Ice Golem"}
+	hogTesla := []string{"Hog Rider", "Musketeer", "Tesla", "Ice Spirit", "Skeletons", "The Log", "Earthquake", "Ice Golem"}
+
+	comparison, err := compareTwoDecks("Hog", hog, "Hog Tesla", hogTesla, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comparison.SharedCards) != 6 ||
+		!slices.Equal(comparison.OnlyA, []string{"Cannon", "Fireball"}) ||
+		!slices.Equal(comparison.OnlyB, []string{"Earthquake", "Tesla"}) {
+		t.Errorf("cards: shared %v, only A %v, only B %v", comparison.SharedCards, comparison.OnlyA, comparison.OnlyB)
+	}
+	if len(comparison.Categories) != len(getEvaluationCategories()) {
+		t.Errorf("got %d categories, want %d", len(comparison.Categories), len(getEvaluationCategories()))
+	}
+	for _, category := range comparison.Categories {
+		if category.Delta != category.DeckB-category.DeckA {
+			t.Errorf("%s delta = %v, want %v", category.Name, category.Delta, category.DeckB-category.DeckA)
+		}
+	}
+	for _, pair := range comparison.Synergy.OnlyA {
+		if !slices.Contains(hog, pair.Card1) || !slices.Contains(hog, pair.Card2) {
+			t.Errorf("pair %s + %s is not from deck A", pair.Card1, pair.Card2)
+		}
+	}
+	if comparison.VsArchetype != nil || comparison.Recommendation == "" {
+		t.Errorf("recommendation without an archetype = %q, vs = %+v", comparison.Recommendation, comparison.VsArchetype)
+	}
+
+	comparison, err = compareTwoDecks("Hog", hog, "Hog Tesla", hogTesla, "beatdown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comparison.VsArchetype == nil || len(comparison.VsArchetype.ReferenceDecks) == 0 ||
+		!strings.Contains(comparison.Recommendation, "against beatdown") {
+		t.Errorf("vs beatdown = %+v, recommendation %q", comparison.VsArchetype, comparison.Recommendation)
+	}
+
+	if _, err := compareTwoDecks("Hog", hog, "Hog Tesla", hogTesla, "lavaloon"); err == nil || !strings.Contains(err.Error(), "available:") {
+		t.Errorf("unknown archetype: err = %v", err)
+	}
+}
+
+func TestDeckCompareCommandJSON(t *testing.T) {
+	output, err := captureStdout(t, func() error {
+		return addDeckCompareCommand().Run(context.Background(), []string{"compare",
+			"--deck-a", "Hog Rider-Musketeer-Cannon-Ice Spirit-Skeletons-The Log-Fireball-Ice Golem",
+			"--deck-b", "Golem-Night Witch-Baby Dragon-Lumberjack-Mega Minion-Zap-Lightning-Tornado",
+			"--name-b", "Golem", "--vs-archetype", "Cycle", "--format", "json"})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var comparison deckComparison
+	if err := json.Unmarshal([]byte(output), &comparison); err != nil {
+		t.Fatalf("output is not a comparison: %v\n%s", err, output)
+	}
+	if comparison.DeckB.Name != "Golem" || comparison.VsArchetype == nil || comparison.VsArchetype.Archetype != "cycle" ||
+		len(comparison.SharedCards) != 0 || comparison.Recommended == "" {
+		t.Errorf("comparison = %+v", comparison)
+	}
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/klauer/clash-royale-api/go/pkg/analysis/predict"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/urfave/cli/v3"
+)
+
+// addDeckCounterCommand adds the counter-deck generator command
+func addDeckCounterCommand() *cli.Command {
+	flags := []cli.Flag{
+		playerTagFlagWithUsage(true, "Player tag whose collection is used to build the counter deck"),
+		&cli.StringFlag{
+			Name:  "opponent-deck",
+			Usage: "Opponent deck (8 cards separated by dashes)",
+		},
+		&cli.IntFlag{
+			Name:  "from-battle",
+			Usage: "Import the opponent deck from the Nth most recent battle in the player's battle log (1 = latest)",
+		},
+		&cli.StringFlag{Name: strategyFlagName, Aliases: []string{"s"}, Value: optimizeFocusBalanced, Usage: "Strategy used to fill the slots not taken by counters"},
+		&cli.IntFlag{
+			Name:  "max-counters",
+			Value: 4,
+			Usage: "Maximum number of cards locked in as counters",
+		},
+		&cli.StringFlag{
+			Name:  "counters-file",
+			Usage: "Custom counters database JSON (default: built-in database)",
+		},
+		winModelFlag(),
+		&cli.StringSliceFlag{Name: excludeCardsFlagName, Usage: "Cards to exclude from the counter deck (by name)"},
+		&cli.BoolFlag{Name: fromAnalysisFlagName, Aliases: []string{"a"}, Usage: "Enable offline mode: load analysis from JSON file instead of fetching from API"},
+		&cli.StringFlag{Name: analysisDirFlagName, Usage: "Directory containing analysis JSON files (default: data/analysis)"},
+		&cli.StringFlag{Name: analysisFileFlagName, Usage: "Specific analysis file path (overrides --analysis-dir lookup)"},
+		&cli.StringFlag{
+			Name:  "format",
+			Value: batchFormatHuman,
+			Usage: "Output format: human, json",
+		},
+		&cli.StringFlag{
+			Name:  "output",
+			Usage: "Output file path (optional, prints to stdout if not specified)",
+		},
+	}
+	flags = append(flags, deckSharedBuilderFlags()...)
+
+	return &cli.Command{
+		Name:   "counter",
+		Usage:  "Generate the best counter deck from your collection against an opponent deck",
+		Flags:  flags,
+		Action: deckCounterCommand,
+	}
+}
+
+func deckCounterCommand(ctx context.Context, cmd *cli.Command) error {
+	tag := cmd.String("tag")
+	dataDir := cmd.String("data-dir")
+	format := strings.ToLower(strings.TrimSpace(cmd.String("format")))
+	verbose := cmd.Bool("verbose")
+
+	if format != "" && format != batchFormatHuman && format != batchFormatJSON {
+		return fmt.Errorf("unknown format: %s (supported: human, json)", format)
+	}
+
+	opponent, err := resolveOpponentDeck(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	db, err := loadCountersDatabase(cmd.String("counters-file"))
+	if err != nil {
+		return err
+	}
+
+	if err := configureCombatStats(cmd); err != nil {
+		return err
+	}
+	builder, err := configureDeckBuilder(cmd, dataDir, cmd.String(strategyFlagName))
+	if err != nil {
+		return err
+	}
+
+	playerData, err := loadPlayerCardAnalysis(ctx, cmd, builder, tag)
+	if err != nil {
+		return err
+	}
+	applyExcludeFilter(&playerData.CardAnalysis, cmd.StringSlice(excludeCardsFlagName))
+
+	result, err := deck.BuildCounterDeck(builder, playerData.CardAnalysis, opponent, db, deck.CounterDeckOptions{
+		MaxCounterCards: cmd.Int("max-counters"),
+	})
+	if err != nil {
+		return err
+	}
+
+	// The opponent's card levels and trophies are unknown, so only the
+	// archetype matchup informs the model
+	model, err := loadWinModel(cmd)
+	if err != nil {
+		return err
+	}
+	if model != nil && result.Recommendation != nil {
+		p := model.Predict(predict.Features{
+			Archetype:         classifyMetaArchetype(result.Recommendation.Deck),
+			OpponentArchetype: classifyMetaArchetype(opponent),
+		})
+		result.ModelWinProbability = &p
+	}
+
+	var formatted string
+	if format == batchFormatJSON {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		formatted = string(data) + "\n"
+	} else {
+		formatted = formatCounterDeckHuman(result, playerData.PlayerName, playerData.PlayerTag)
+	}
+
+	return writeTextOutput(formatted, cmd.String("output"), textOutputOptions{
+		saveMessage: "Counter deck saved to",
+		verboseOnly: true,
+		verbose:     verbose,
+	})
+}
+
+// resolveOpponentDeck reads the opponent deck from --opponent-deck or the battle log.
+func resolveOpponentDeck(ctx context.Context, cmd *cli.Command) ([]string, error) {
+	deckStr := cmd.String("opponent-deck")
+	battleIndex := cmd.Int("from-battle")
+
+	switch {
+	case deckStr != "" && battleIndex > 0:
+		return nil, fmt.Errorf("use either --opponent-deck or --from-battle, not both")
+	case deckStr != "":
+		return parseDeckStringWithLabel(deckStr, "opponent-deck")
+	case battleIndex > 0:
+		client, err := requireAPIClient(cmd, apiClientOptions{offlineHint: ", or provide --opponent-deck"})
+		if err != nil {
+			return nil, err
+		}
+		battleLog, err := client.GetPlayerBattleLogWithContext(ctx, cmd.String("tag"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get battle log: %w", err)
+		}
+		return opponentDeckFromBattleLog(*battleLog, battleIndex)
+	default:
+		return nil, fmt.Errorf("an opponent deck is required: use --opponent-deck or --from-battle")
+	}
+}
+
+// opponentDeckFromBattleLog returns the opponent's cards from the 1-based battle index.
+func opponentDeckFromBattleLog(battles []clashroyale.Battle, index int) ([]string, error) {
+	if index < 1 || index > len(battles) {
+		return nil, fmt.Errorf("battle %d not found (battle log has %d battles)", index, len(battles))
+	}
+	battle := battles[index-1]
+	if len(battle.Opponent) == 0 || len(battle.Opponent[0].Cards) == 0 {
+		return nil, fmt.Errorf("battle %d has no opponent deck", index)
+	}
+
+	cards := make([]string, 0, len(battle.Opponent[0].Cards))
+	for _, card := range battle.Opponent[0].Cards {
+		cards = append(cards, card.Name)
+	}
+	return cards, nil
+}
+
+func loadCountersDatabase(path string) (*deck.CountersDatabase, error) {
+	if path != "" {
+		return deck.LoadCountersDatabase(path)
+	}
+	return deck.NewCountersDatabase()
+}
+
+func formatCounterDeckHuman(result *deck.CounterDeckResult, playerName, playerTag string) string {
+	var buf bytes.Buffer
+
+	fprintf(&buf, "\nCounter Deck for %s (%s)\n", playerName, playerTag)
+	fprintf(&buf, "========================\n")
+	fprintf(&buf, "Opponent: %s\n", strings.Join(result.OpponentDeck, " - "))
+	fprintf(&buf, "Counter:  %s\n", strings.Join(result.Recommendation.Deck, " - "))
+	fprintf(&buf, "Average Elixir: %.2f\n", result.Recommendation.AvgElixir)
+	fprintf(&buf, "Counter Coverage: %.0f%%\n", result.CoverageScore*100)
+	if p := result.ModelWinProbability; p != nil {
+		fprintf(&buf, "Model Win Probability: %.1f%% (archetype matchup only)\n", *p*100)
+	}
+	fprintf(&buf, "\n")
+
+	if len(result.CounterPicks) > 0 {
+		fprintf(&buf, "Locked-in Counters:\n")
+		for _, pick := range result.CounterPicks {
+			fprintf(&buf, "  %s -> %s\n", pick.Card, strings.Join(pick.Answers, ", "))
+		}
+		fprintf(&buf, "\n")
+	}
+
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fprintf(w, "Opponent Card\tHard Counters\tSoft Counters\n")
+	fprintf(w, "-------------\t-------------\t-------------\n")
+	for _, cov := range result.Coverage {
+		name := cov.Card
+		if cov.WinCondition {
+			name += " (win con)"
+		}
+		hard, soft := "-", "-"
+		if len(cov.HardCounters) > 0 {
+			hard = strings.Join(cov.HardCounters, ", ")
+		}
+		if len(cov.SoftCounters) > 0 {
+			soft = strings.Join(cov.SoftCounters, ", ")
+		}
+		if !cov.KnownCard {
+			hard, soft = "(no counter data)", ""
+		}
+		fprintf(w, "%s\t%s\t%s\n", name, hard, soft)
+	}
+	flushWriter(w)
+
+	if len(result.Unanswered) > 0 {
+		fprintf(&buf, "\nUnanswered: %s\n", strings.Join(result.Unanswered, ", "))
+	}
+	return buf.String()
+}
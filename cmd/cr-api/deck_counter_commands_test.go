@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+func TestOpponentDeckFromBattleLog(t *testing.T) {
+	battles := []clashroyale.Battle{
+		{Opponent: []clashroyale.BattleTeam{{Cards: []clashroyale.Card{{Name: "Hog Rider"}, {Name: "Musketeer"}}}}},
+		{Opponent: []clashroyale.BattleTeam{{}}},
+	}
+
+	cards, err := opponentDeckFromBattleLog(battles, 1)
+	if err != nil {
+		t.Fatalf("opponentDeckFromBattleLog() error = %v", err)
+	}
+	if len(cards) != 2 || cards[0] != "Hog Rider" {
+		t.Errorf("opponentDeckFromBattleLog() = %v", cards)
+	}
+
+	if _, err := opponentDeckFromBattleLog(battles, 2); err == nil {
+		t.Error("expected error for battle without opponent cards")
+	}
+	if _, err := opponentDeckFromBattleLog(battles, 3); err == nil {
+		t.Error("expected error for out-of-range battle index")
+	}
+}
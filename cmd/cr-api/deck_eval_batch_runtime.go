@@ -417,7 +417,7 @@ func saveEvalDeckToStorage(
 		AvgElixir:         result.AvgElixir,
 		EvaluatedAt:       evaluatedAt,
 		PlayerTag:         playerTag,
-		EvaluationVersion: "1.0.0",
+		EvaluationVersion: evaluation.CurrentScoringVersion,
 	}
 
 	_, isNew, err := storage.InsertDeck(entry)
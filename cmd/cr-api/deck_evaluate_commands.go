@@ -9,7 +9,7 @@ func addDeckEvaluateCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "evaluate",
 		Usage: "Evaluate a deck with comprehensive analysis and scoring",
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			&cli.StringFlag{
 				Name:  "deck",
 				Usage: "Deck string (8 cards separated by dashes, e.g., Knight-Archers-Fireball-...)",
@@ -42,7 +42,27 @@ func addDeckEvaluateCommand() *cli.Command {
 				Value: 5,
 				Usage: "Number of top upgrades to show in upgrade impact analysis",
 			},
-		},
+			&cli.StringFlag{
+				Name:  "mode",
+				Value: "1v1",
+				Usage: "Evaluation profile: 1v1 (ladder) or 2v2",
+			},
+			&cli.StringFlag{
+				Name:  "tower-troop",
+				Usage: "Tower troop defending the deck: Tower Princess, Cannoneer, Dagger Duchess (default: the player's equipped troop, else Tower Princess)",
+			},
+			trophyBandFlag(),
+			substitutesFlag(),
+			exportImageFlag(),
+			&cli.StringFlag{
+				Name:  "partner-deck",
+				Usage: "Partner deck for joint 2v2 evaluation (8 cards separated by dashes; implies --mode 2v2)",
+			},
+			tagFlag(&cli.StringFlag{
+				Name:  "partner-tag",
+				Usage: "Partner player tag (without #) for card level context in joint 2v2 evaluation",
+			}),
+		}, reportExportFlags()...),
 		Action: deckEvaluateCommand,
 	}
 }
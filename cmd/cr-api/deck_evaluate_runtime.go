@@ -7,12 +7,13 @@ import (
 	"math/bits"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
-	"sync"
 	"text/tabwriter"
 	"time"
 
 	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/internal/exporter/report"
 	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
 	"github.com/klauer/clash-royale-api/go/pkg/deck"
 	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
@@ -20,11 +21,6 @@ import (
 	"github.com/urfave/cli/v3"
 )
 
-var (
-	combatStatsOnce     sync.Once
-	combatStatsRegistry *clashroyale.CardStatsRegistry
-)
-
 func validateEvaluateFlags(deckString, fromAnalysis, playerTag, apiToken string, showUpgradeImpact bool) error {
 	// Validation: Must provide either --deck or --from-analysis
 	if deckString == "" && fromAnalysis == "" {
@@ -47,6 +43,62 @@ func validateEvaluateFlags(deckString, fromAnalysis, playerTag, apiToken string,
 	return nil
 }
 
+// parseTowerTroopFlag validates a --tower-troop value and returns its
+// canonical name, or "" when the flag is unset.
+func parseTowerTroopFlag(value string) (string, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
+	}
+	profile, ok := deck.LookupTowerTroop(value)
+	if !ok {
+		return "", fmt.Errorf("unknown tower troop %q (supported: %s)", value, strings.Join(deck.TowerTroopNames(), ", "))
+	}
+	return profile.Name, nil
+}
+
+// loadMetaFileFlag loads the --meta-file snapshot, or returns nil when the
+// flag is unset.
+func loadMetaFileFlag(cmd *cli.Command) (*evaluation.MetaContext, error) {
+	path := strings.TrimSpace(cmd.String(metaFileFlagName))
+	if path == "" {
+		return nil, nil
+	}
+	return evaluation.LoadMetaContext(path)
+}
+
+// parseGameModeFlag parses --game-mode
+func parseGameModeFlag(cmd *cli.Command) (evaluation.GameMode, error) {
+	mode, err := evaluation.ParseGameMode(cmd.String(gameModeFlagName))
+	if err != nil {
+		return "", fmt.Errorf("invalid --%s: %w", gameModeFlagName, err)
+	}
+	return mode, nil
+}
+
+// applyTrophyBandFlag applies --trophy-band to opts. "auto" keeps the band
+// inferred from the player's trophies, "none" turns bands off, and a trophy
+// count picks the band containing it.
+func applyTrophyBandFlag(cmd *cli.Command, opts *evaluation.EvaluateOptions, playerContext *evaluation.PlayerContext) error {
+	value := strings.TrimSpace(cmd.String(trophyBandFlagName))
+	if value == "" || strings.EqualFold(value, trophyBandAuto) {
+		return nil
+	}
+	var band evaluation.TrophyBand
+	if trophies, err := strconv.Atoi(value); err == nil {
+		if trophies < 0 {
+			return fmt.Errorf("invalid --%s: trophies cannot be negative", trophyBandFlagName)
+		}
+		band = evaluation.TrophyBandFor(trophies)
+	} else if band, err = evaluation.ParseTrophyBand(value); err != nil {
+		return fmt.Errorf("invalid --%s: %w", trophyBandFlagName, err)
+	}
+	opts.TrophyBand = band
+	if band == evaluation.TrophyBandNone && playerContext != nil {
+		playerContext.TrophyBand = evaluation.TrophyBandNone
+	}
+	return nil
+}
+
 // fetchPlayerContextIfNeeded fetches player context from API when available and applies arena overrides.
 func fetchPlayerContextIfNeeded(ctx context.Context, playerTag, apiToken string, arena int, verbose bool) *evaluation.PlayerContext {
 	var playerContext *evaluation.PlayerContext
@@ -139,7 +191,7 @@ func persistEvaluationResult(result *evaluation.EvaluationResult, playerTag stri
 		AvgElixir:         result.AvgElixir,
 		EvaluatedAt:       time.Now(),
 		PlayerTag:         playerTag,
-		EvaluationVersion: "1.0.0",
+		EvaluationVersion: evaluation.CurrentScoringVersion,
 	}
 
 	deckID, isNew, err := storage.InsertDeck(entry)
@@ -233,6 +285,17 @@ func deckEvaluateCommand(ctx context.Context, cmd *cli.Command) error {
 	if err := validateEvaluateFlags(deckString, fromAnalysis, playerTag, apiToken, showUpgradeImpact); err != nil {
 		return err
 	}
+	mode, err := evaluation.ParseEvaluationMode(cmd.String("mode"))
+	if err != nil {
+		return err
+	}
+	towerTroop, err := parseTowerTroopFlag(cmd.String("tower-troop"))
+	if err != nil {
+		return err
+	}
+	if err := validateExportImageFlag(cmd); err != nil {
+		return err
+	}
 
 	// Load deck cards
 	deckCardNames, err := loadDeckCardsFromInput(deckString, fromAnalysis)
@@ -240,6 +303,13 @@ func deckEvaluateCommand(ctx context.Context, cmd *cli.Command) error {
 		return err
 	}
 
+	if partnerDeck := cmd.String("partner-deck"); partnerDeck != "" {
+		if cmd.IsSet("mode") && mode != evaluation.Mode2v2 {
+			return fmt.Errorf("--partner-deck requires --mode 2v2")
+		}
+		return evaluateDeckPair(ctx, cmd, deckCardNames, partnerDeck)
+	}
+
 	if verbose {
 		printf("Evaluating deck: %v\n", deckCardNames)
 		printf("Output format: %s\n", format)
@@ -253,11 +323,21 @@ func deckEvaluateCommand(ctx context.Context, cmd *cli.Command) error {
 	playerContext := fetchPlayerContextIfNeeded(ctx, playerTag, apiToken, arena, verbose)
 
 	// Evaluate the deck
-	result := evaluation.Evaluate(deckCards, synergyDB, playerContext)
+	evalOpts := evaluation.EvaluateOptions{Mode: mode, TowerTroop: towerTroop}
+	if err := applyTrophyBandFlag(cmd, &evalOpts, playerContext); err != nil {
+		return err
+	}
+	result := evaluation.EvaluateWithOptions(deckCards, synergyDB, playerContext, evalOpts)
+	if subOpts, ok := substitutionOptions(cmd); ok {
+		subOpts.Evaluate = evalOpts
+		result.Substitutions = evaluation.SuggestSubstitutions(deckCards, synergyDB, playerContext, subOpts)
+	}
 
-	// Save to persistent storage.
-	if err := persistEvaluationResult(&result, playerTag, verbose); err != nil && verbose {
-		fprintf(os.Stderr, "warning: failed to persist evaluation result: %v\n", err)
+	// Save to persistent storage. The deck leaderboard tracks ladder scores only.
+	if mode == evaluation.ModeLadder {
+		if err := persistEvaluationResult(&result, playerTag, verbose); err != nil && verbose {
+			fprintf(os.Stderr, "warning: failed to persist evaluation result: %v\n", err)
+		}
 	}
 
 	// Format output
@@ -270,11 +350,61 @@ func deckEvaluateCommand(ctx context.Context, cmd *cli.Command) error {
 	if err := writeEvaluationOutput(formattedOutput, outputFile, verbose); err != nil {
 		return err
 	}
+	if err := exportDeckImageIfRequested(ctx, cmd, "", deckCardNames); err != nil {
+		return err
+	}
+	if err := exportReportsIfRequested(cmd, func() *report.Report {
+		return report.FromEvaluation(&result)
+	}); err != nil {
+		return err
+	}
 
 	// Perform upgrade analysis if requested
 	return performUpgradeAnalysisIfRequested(ctx, showUpgradeImpact, format, deckCardNames, playerTag, topUpgrades, apiToken, verbose)
 }
 
+// evaluateDeckPair jointly evaluates the player's deck and a partner deck for 2v2
+func evaluateDeckPair(ctx context.Context, cmd *cli.Command, deckCardNames []string, partnerDeck string) error {
+	apiToken := cmd.String("api-token")
+	arena := cmd.Int("arena")
+	verbose := cmd.Bool("verbose")
+
+	partnerCardNames, err := parseDeckStringWithLabel(partnerDeck, "partner-deck")
+	if err != nil {
+		return err
+	}
+
+	if verbose {
+		printf("Evaluating 2v2 pair: %v + %v\n", deckCardNames, partnerCardNames)
+	}
+
+	playerContext := fetchPlayerContextIfNeeded(ctx, cmd.String("tag"), apiToken, arena, verbose)
+	partnerContext := fetchPlayerContextIfNeeded(ctx, cmd.String("partner-tag"), apiToken, arena, verbose)
+
+	result := evaluation.EvaluatePair(
+		convertToCardCandidates(deckCardNames),
+		convertToCardCandidates(partnerCardNames),
+		deck.NewSynergyDatabase(),
+		playerContext,
+		partnerContext,
+	)
+
+	var formattedOutput string
+	switch strings.ToLower(cmd.String("format")) {
+	case batchFormatHuman:
+		formattedOutput = evaluation.FormatPairHuman(&result)
+	case batchFormatJSON:
+		formattedOutput, err = evaluation.FormatPairJSON(&result)
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown format for 2v2 pair evaluation: %s (supported: human, json)", cmd.String("format"))
+	}
+
+	return writeEvaluationOutput(formattedOutput, cmd.String("output"), verbose)
+}
+
 // performDeckUpgradeImpactAnalysis performs upgrade impact analysis for a specific deck
 // It fetches the player's card levels and shows which deck card upgrades would have the most impact
 func performDeckUpgradeImpactAnalysis(ctx context.Context, deckCardNames []string, playerTag string, topN int, apiToken string, verbose bool) error {
@@ -573,7 +703,7 @@ func convertToCardCandidates(cardNames []string) []deck.CardCandidate {
 			Rarity:   inferRarity(name),
 			Elixir:   config.GetCardElixir(name, 0),
 			Role:     inferRole(name),
-			Stats:    inferStats(name),
+			Stats:    inferStats(name, 11),
 		}
 		deckCards = append(deckCards, candidate)
 	}
@@ -603,30 +733,14 @@ func inferRole(name string) *deck.CardRole {
 	return &role
 }
 
-// inferStats returns combat stats for a card, preferring static card stats data.
-func inferStats(name string) *clashroyale.CombatStats {
-	combatStatsOnce.Do(func() {
-		paths := []string{
-			"data/static/cards_stats.json",
-			"../data/static/cards_stats.json",
-			"../../data/static/cards_stats.json",
-		}
-		for _, p := range paths {
-			registry, err := clashroyale.LoadStats(p)
-			if err == nil {
-				combatStatsRegistry = registry
-				return
-			}
-		}
-	})
-
-	if combatStatsRegistry != nil {
-		if stats := combatStatsRegistry.GetStats(name); stats != nil {
-			return stats
-		}
+// inferStats returns combat stats for a card at a standard level from the
+// bundled card stats dataset.
+func inferStats(name string, level int) *clashroyale.CombatStats {
+	if stats := deck.CombatStatsFor(nil, name, level); stats != nil {
+		return stats
 	}
 
-	// Fallback defaults when static stats are unavailable.
+	// Fallback defaults for cards missing from the dataset.
 	return &clashroyale.CombatStats{
 		Targets:         "Air & Ground",
 		DamagePerSecond: 100,
@@ -717,7 +831,7 @@ func buildCandidatesFromDetails(details []deck.CardDetail) []deck.CardCandidate
 			rarity = inferRarity(detail.Name)
 		}
 
-		deckCards = append(deckCards, deck.CardCandidate{
+		candidate := deck.CardCandidate{
 			Name:              detail.Name,
 			Level:             detail.Level,
 			MaxLevel:          detail.MaxLevel,
@@ -726,8 +840,9 @@ func buildCandidatesFromDetails(details []deck.CardDetail) []deck.CardCandidate
 			Role:              role,
 			EvolutionLevel:    detail.EvolutionLevel,
 			MaxEvolutionLevel: detail.MaxEvolutionLevel,
-			Stats:             inferStats(detail.Name),
-		})
+		}
+		candidate.Stats = inferStats(detail.Name, candidate.StandardLevel())
+		deckCards = append(deckCards, candidate)
 	}
 
 	return deckCards
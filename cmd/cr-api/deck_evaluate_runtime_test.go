@@ -1,10 +1,13 @@
 package main
 
 import (
+	"context"
 	"strings"
 	"testing"
 
 	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/urfave/cli/v3"
 )
 
 func TestCalculateUpgradeGoldCostHandlesNonPositiveFromLevel(t *testing.T) {
@@ -62,3 +65,42 @@ func TestCalculateDeckCardUpgrades_SetsUnlocksNewDeck(t *testing.T) {
 		t.Fatalf("expected reason to mention unlock impact, got %q", impacts[0].Reason)
 	}
 }
+
+func TestApplyTrophyBandFlag(t *testing.T) {
+	apply := func(args ...string) (evaluation.EvaluateOptions, *evaluation.PlayerContext, error) {
+		var opts evaluation.EvaluateOptions
+		playerContext := &evaluation.PlayerContext{TrophyBand: evaluation.TrophyBandHigh}
+		var applyErr error
+		cmd := &cli.Command{
+			Name:  "test",
+			Flags: []cli.Flag{trophyBandFlag()},
+			Action: func(ctx context.Context, cmd *cli.Command) error {
+				applyErr = applyTrophyBandFlag(cmd, &opts, playerContext)
+				return nil
+			},
+		}
+		if err := cmd.Run(context.Background(), append([]string{"test"}, args...)); err != nil {
+			t.Fatal(err)
+		}
+		return opts, playerContext, applyErr
+	}
+
+	opts, playerContext, err := apply()
+	if err != nil || opts.TrophyBand != evaluation.TrophyBandNone || playerContext.TrophyBand != evaluation.TrophyBandHigh {
+		t.Errorf("auto: band %q, player band %q, err %v; want the player's band kept", opts.TrophyBand, playerContext.TrophyBand, err)
+	}
+	if opts, _, err = apply("--trophy-band", "mid"); err != nil || opts.TrophyBand != evaluation.TrophyBandMid {
+		t.Errorf("mid: band %q, err %v", opts.TrophyBand, err)
+	}
+	if opts, _, err = apply("--trophy-band", "4500"); err != nil || opts.TrophyBand != evaluation.TrophyBandMid {
+		t.Errorf("4500 trophies: band %q, err %v; want mid", opts.TrophyBand, err)
+	}
+	if _, playerContext, err = apply("--trophy-band", "none"); err != nil || playerContext.TrophyBand != evaluation.TrophyBandNone {
+		t.Errorf("none: player band %q, err %v; want cleared", playerContext.TrophyBand, err)
+	}
+	for _, bad := range []string{"legendary", "-100"} {
+		if _, _, err = apply("--trophy-band", bad); err == nil || !strings.Contains(err.Error(), "--trophy-band") {
+			t.Errorf("%s: err = %v, want a --trophy-band error", bad, err)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	explainFormatText     = "text"
+	explainFormatJSON     = "json"
+	explainFormatMarkdown = "markdown"
+)
+
+// addDeckExplainCommand adds the deck explain command
+func addDeckExplainCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "explain",
+		Usage: "Explain how a deck's evaluation score was built",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "deck",
+				Usage:    "Deck to explain (8 cards separated by commas, e.g., \"Hog Rider,Fireball,...\"; dashes also accepted)",
+				Required: true,
+			},
+			playerTagFlagWithUsage(false, "Player tag (without #) for card level context and missing-card analysis"),
+			&cli.IntFlag{
+				Name:  "arena",
+				Value: 0,
+				Usage: "Arena level for card unlock context (0 = no restriction)",
+			},
+			&cli.StringFlag{
+				Name:  "mode",
+				Value: "1v1",
+				Usage: "Evaluation profile: 1v1 (ladder) or 2v2",
+			},
+			&cli.StringFlag{
+				Name:  "tower-troop",
+				Usage: "Tower troop defending the deck: Tower Princess, Cannoneer, Dagger Duchess (default: the player's equipped troop, else Tower Princess)",
+			},
+			metaFileFlag(),
+			gameModeFlag(),
+			trophyBandFlag(),
+			&cli.IntFlag{
+				Name:  "opponent-level",
+				Usage: "Card level (1-16) of opponent troops in the spell interaction checks (default: each spell's own level)",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: explainFormatText,
+				Usage: "Output format: text, json, markdown",
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "Output file path (optional, prints to stdout if not specified)",
+			},
+		},
+		Action: deckExplainCommand,
+	}
+}
+
+// deckExplainCommand evaluates a deck and reports why it scored as it did
+func deckExplainCommand(ctx context.Context, cmd *cli.Command) error {
+	verbose := cmd.Bool("verbose")
+
+	deckCardNames, err := parseExplainDeck(cmd.String("deck"))
+	if err != nil {
+		return err
+	}
+	mode, err := evaluation.ParseEvaluationMode(cmd.String("mode"))
+	if err != nil {
+		return err
+	}
+	format := strings.ToLower(cmd.String("format"))
+	towerTroop, err := parseTowerTroopFlag(cmd.String("tower-troop"))
+	if err != nil {
+		return err
+	}
+
+	metaContext, err := loadMetaFileFlag(cmd)
+	if err != nil {
+		return err
+	}
+	gameMode, err := parseGameModeFlag(cmd)
+	if err != nil {
+		return err
+	}
+	opponentLevel := int(cmd.Int("opponent-level"))
+	if maxLevel := config.GetMaxLevel(rarityCommon); opponentLevel < 0 || opponentLevel > maxLevel {
+		return fmt.Errorf("--opponent-level must be between 1 and %d, got %d", maxLevel, opponentLevel)
+	}
+
+	synergyDB := deck.NewSynergyDatabase()
+	playerContext := fetchPlayerContextIfNeeded(ctx, cmd.String("tag"), cmd.String("api-token"), cmd.Int("arena"), verbose)
+	deckCards := convertToCardCandidates(deckCardNames)
+	evalOpts := evaluation.EvaluateOptions{
+		Mode:       mode,
+		TowerTroop: towerTroop,
+		Meta:       metaContext,
+		GameMode:   gameMode,
+	}
+	if err := applyTrophyBandFlag(cmd, &evalOpts, playerContext); err != nil {
+		return err
+	}
+	result := evaluation.EvaluateWithOptions(deckCards, synergyDB, playerContext, evalOpts)
+
+	explanation := evaluation.Explain(&result, synergyDB)
+	explanation.Interactions = evaluation.SpellInteractions(deckCards, playerContext, opponentLevel)
+	openingHands := evaluation.AnalyzeOpeningHands(deckCards)
+	explanation.OpeningHands = &openingHands
+	formatted, err := formatDeckExplanation(explanation, format)
+	if err != nil {
+		return err
+	}
+	return writeEvaluationOutput(formatted, cmd.String("output"), verbose)
+}
+
+// parseExplainDeck splits a comma-separated deck so names containing dashes
+// (X-Bow, P.E.K.K.A variants) survive. Dash-separated decks are accepted too.
+func parseExplainDeck(deckStr string) ([]string, error) {
+	if !strings.Contains(deckStr, ",") {
+		return parseDeckStringWithLabel(deckStr, "--deck")
+	}
+
+	cards := make([]string, 0, deckCardCount)
+	for part := range strings.SplitSeq(deckStr, ",") {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			cards = append(cards, trimmed)
+		}
+	}
+	if len(cards) != deckCardCount {
+		return nil, fmt.Errorf("--deck must contain exactly %d cards, got %d", deckCardCount, len(cards))
+	}
+	return cards, nil
+}
+
+func formatDeckExplanation(explanation evaluation.Explanation, format string) (string, error) {
+	switch format {
+	case explainFormatText:
+		return evaluation.FormatExplainText(&explanation), nil
+	case explainFormatMarkdown:
+		return evaluation.FormatExplainMarkdown(&explanation), nil
+	case explainFormatJSON:
+		data, err := json.MarshalIndent(explanation, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to format JSON: %w", err)
+		}
+		return string(data) + "\n", nil
+	default:
+		return "", fmt.Errorf("unknown format: %s (supported: text, json, markdown)", format)
+	}
+}
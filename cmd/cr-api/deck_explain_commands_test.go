@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+)
+
+func TestParseExplainDeck(t *testing.T) {
+	cards, err := parseExplainDeck("X-Bow, Tesla, Archers, Knight, Fireball, The Log, Skeletons, Ice Spirit")
+	if err != nil {
+		t.Fatalf("parseExplainDeck() error = %v", err)
+	}
+	if cards[0] != "X-Bow" || cards[7] != "Ice Spirit" {
+		t.Errorf("unexpected cards: %v", cards)
+	}
+
+	if _, err := parseExplainDeck("Knight-Archers-Fireball-Zap-Hog Rider-Cannon-Skeletons-Ice Spirit"); err != nil {
+		t.Errorf("expected dash-separated deck to parse, got %v", err)
+	}
+	if _, err := parseExplainDeck("Knight,Archers"); err == nil {
+		t.Error("expected an error for a short deck")
+	}
+}
+
+func TestFormatDeckExplanation(t *testing.T) {
+	cards := []string{"Hog Rider", "Fireball", "Zap", "Cannon", "Musketeer", "Ice Spirit", "Skeletons", "Valkyrie"}
+	synergyDB := deck.NewSynergyDatabase()
+	result := evaluation.Evaluate(convertToCardCandidates(cards), synergyDB, nil)
+	explanation := evaluation.Explain(&result, synergyDB)
+
+	out, err := formatDeckExplanation(explanation, explainFormatJSON)
+	if err != nil {
+		t.Fatalf("formatDeckExplanation(json) error = %v", err)
+	}
+	var decoded evaluation.Explanation
+	if err := json.Unmarshal([]byte(out), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(decoded.Contributions) == 0 || len(decoded.Sections) == 0 {
+		t.Errorf("JSON explanation missing contributions or sections: %+v", decoded)
+	}
+
+	out, err = formatDeckExplanation(explanation, explainFormatMarkdown)
+	if err != nil || !strings.HasPrefix(out, "# Why this score") {
+		t.Errorf("unexpected markdown output (err %v): %.40q", err, out)
+	}
+	if _, err := formatDeckExplanation(explanation, "csv"); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+}
+
+func TestParseTowerTroopFlag(t *testing.T) {
+	if got, err := parseTowerTroopFlag(""); err != nil || got != "" {
+		t.Errorf("parseTowerTroopFlag(\"\") = %q, %v", got, err)
+	}
+	if got, err := parseTowerTroopFlag("cannoneer"); err != nil || got != deck.TowerTroopCannoneer {
+		t.Errorf("parseTowerTroopFlag(cannoneer) = %q, %v", got, err)
+	}
+	if _, err := parseTowerTroopFlag("Royal Chef Deluxe"); err == nil {
+		t.Error("expected an error for an unknown tower troop")
+	}
+}
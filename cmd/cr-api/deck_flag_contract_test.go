@@ -37,6 +37,15 @@ func TestDeckRecommendFlagContract(t *testing.T) {
 	)
 }
 
+func TestDeckSetFlagContract(t *testing.T) {
+	assertRuntimeFlagsDeclared(
+		t,
+		"deck_set.go",
+		"deckSetCommand",
+		addDeckSetCommand(),
+	)
+}
+
 func assertRuntimeFlagsDeclared(t *testing.T, fileName, funcName string, command *cli.Command) {
 	t.Helper()
 
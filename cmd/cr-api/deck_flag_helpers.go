@@ -1,6 +1,9 @@
 package main
 
-import "github.com/urfave/cli/v3"
+import (
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/urfave/cli/v3"
+)
 
 const (
 	strategyFlagName           = "strategy"
@@ -18,6 +21,11 @@ const (
 	topNFlagName               = "top-n"
 	evolutionSlotsFlagName     = "evolution-slots"
 	uniquenessWeightFlagName   = "uniqueness-weight"
+	metaFileFlagName           = "meta-file"
+	gameModeFlagName           = "game-mode"
+	trophyBandFlagName         = "trophy-band"
+	trophyBandAuto             = "auto"
+	substitutesFlagName        = "substitutes"
 	defaultEvolutionSlots      = 2
 	defaultSynergyWeight       = 0.15
 	defaultUniquenessWeight    = 0.2
@@ -32,6 +40,10 @@ const (
 	fuzzWeightUsage            = "Weight for fuzz-based card scoring (0.0-1.0, default 0.10 = 10%)"
 	fuzzDeckLimitUsage         = "Number of top fuzz decks to analyze for card stats (default 100)"
 	evolutionSlotsDefaultUsage = "Number of evolution slots available (default 2)"
+	metaFileUsage              = "Meta snapshot JSON (from `meta snapshot` or `meta scrape`); scores how well decks answer popular win conditions"
+	gameModeUsage              = "Event scoring preset: standard, double-elixir, triple-elixir, rage, sudden-death"
+	trophyBandUsage            = "Trophy band whose weights and meta to score for: auto (from the player's trophies), none, low, mid, high, top, or a trophy count"
+	substitutesUsage           = "Owned substitutes to suggest for each missing or underleveled card, with their score impact (0 = none)"
 )
 
 func deckEvolutionFlags() []cli.Flag {
@@ -68,3 +80,27 @@ func deckSharedBuilderFlags() []cli.Flag {
 	flags = append(flags, boostedCardLevelFlag())
 	return flags
 }
+
+func metaFileFlag() *cli.StringFlag {
+	return &cli.StringFlag{Name: metaFileFlagName, Usage: metaFileUsage}
+}
+
+func gameModeFlag() *cli.StringFlag {
+	return &cli.StringFlag{Name: gameModeFlagName, Value: string(evaluation.GameModeStandard), Usage: gameModeUsage}
+}
+
+func trophyBandFlag() *cli.StringFlag {
+	return &cli.StringFlag{Name: trophyBandFlagName, Value: trophyBandAuto, Usage: trophyBandUsage}
+}
+
+func substitutesFlag() *cli.IntFlag {
+	return &cli.IntFlag{Name: substitutesFlagName, Value: evaluation.DefaultSubstitutionOptions().MaxPerCard, Usage: substitutesUsage}
+}
+
+// substitutionOptions returns the substitution settings for --substitutes,
+// reporting false when suggestions are turned off
+func substitutionOptions(cmd *cli.Command) (evaluation.SubstitutionOptions, bool) {
+	opts := evaluation.DefaultSubstitutionOptions()
+	opts.MaxPerCard = cmd.Int(substitutesFlagName)
+	return opts, opts.MaxPerCard > 0
+}
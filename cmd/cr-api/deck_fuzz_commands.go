@@ -1,7 +1,10 @@
 package main
 
 import (
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
 	"github.com/klauer/clash-royale-api/go/pkg/deck/genetic"
+	"github.com/klauer/clash-royale-api/go/pkg/fuzzstorage"
 	"github.com/urfave/cli/v3"
 )
 
@@ -12,7 +15,7 @@ func basicFlags() []cli.Flag {
 		&cli.StringFlag{
 			Name:  "mode",
 			Value: "random",
-			Usage: "Fuzzing mode: random or genetic",
+			Usage: "Fuzzing mode: random, role-template (one card per role slot), or genetic",
 		},
 		&cli.IntFlag{
 			Name:  "count",
@@ -24,6 +27,8 @@ func basicFlags() []cli.Flag {
 			Value: 1,
 			Usage: "Number of parallel workers for deck generation",
 		},
+		metaFileFlag(),
+		gameModeFlag(),
 	}
 }
 
@@ -38,6 +43,10 @@ func cardConstraintFlags() []cli.Flag {
 			Name:  "exclude-cards",
 			Usage: "Cards that must be excluded from all generated decks",
 		},
+		&cli.StringFlag{
+			Name:  "constraints",
+			Usage: `Deck composition rules separated by ";", e.g. "at least 1 building; exactly 2 spells with one <=2 elixir; no more than 1 champion"`,
+		},
 	}
 }
 
@@ -99,7 +108,7 @@ func scoreFilterFlags() []cli.Flag {
 		&cli.StringFlag{
 			Name:  "format",
 			Value: "summary",
-			Usage: "Output format: summary, json, csv, detailed",
+			Usage: "Output format: summary, json, csv, detailed, parquet",
 		},
 	}
 }
@@ -111,6 +120,7 @@ func outputFlags() []cli.Flag {
 			Name:  "output-dir",
 			Usage: "Directory to save results (default: stdout only)",
 		},
+		exportImageFlag(),
 		&cli.BoolFlag{
 			Name:    "verbose",
 			Aliases: []string{"v"},
@@ -133,6 +143,14 @@ func outputFlags() []cli.Flag {
 			Value: 0,
 			Usage: "Random seed for reproducibility (0 = random)",
 		},
+		&cli.BoolFlag{
+			Name:  "deterministic",
+			Usage: "With --seed, generate the same decks and top-N output whatever the number of workers (random mode)",
+		},
+		&cli.StringFlag{
+			Name:  "session",
+			Usage: "Record a random-mode run as a resumable session in <output-dir>/<name>.session.json (see deck fuzz resume)",
+		},
 	}
 }
 
@@ -188,6 +206,29 @@ func geneticAlgorithmBasicFlags(gaDefaults genetic.GeneticConfig) []cli.Flag {
 			Value: gaDefaults.ParallelEvaluations,
 			Usage: "Enable parallel evaluation for genetic algorithm",
 		},
+		&cli.BoolFlag{
+			Name:  "ga-adaptive-mutation",
+			Usage: "Adapt the mutation rate every generation (1/5 success rule, raised when diversity collapses)",
+		},
+		&cli.IntFlag{
+			Name:  "ga-min-evolved-cards",
+			Value: gaDefaults.MinEvolvedCards,
+			Usage: "Genetic algorithm: every deck must contain at least N cards whose evolution you own (0 = no minimum)",
+		},
+		&cli.IntFlag{
+			Name:  "ga-evolution-slots",
+			Value: gaDefaults.EvolutionSlotLimit,
+			Usage: "Genetic algorithm: at most N evolved cards per deck (0 = no limit; equal to --ga-min-evolved-cards for an exact count)",
+		},
+		&cli.StringFlag{
+			Name:  "ga-seed-file",
+			Usage: "Seed the first GA population from a file of deck links or card lists (one per line) or a meta snapshot JSON; unowned cards are substituted",
+		},
+		&cli.Float64Flag{
+			Name:  "ga-novelty-weight",
+			Value: gaDefaults.NoveltyWeight,
+			Usage: "Genetic algorithm: reward decks unlike the current population and the stored fuzz archive by this weight (0 = off, ~1-3 to explore)",
+		},
 	}
 }
 
@@ -230,11 +271,24 @@ func geneticAlgorithmIslandFlags(gaDefaults genetic.GeneticConfig) []cli.Flag {
 			Value: gaDefaults.MigrationSize,
 			Usage: "Number of migrants per island migration",
 		},
+		&cli.StringFlag{
+			Name:  "ga-island-topology",
+			Value: string(gaDefaults.MigrationTopology),
+			Usage: "Island migration topology: ring, fully-connected, random",
+		},
+		&cli.StringFlag{
+			Name:  "ga-island-strategies",
+			Usage: "Comma-separated archetypes or objectives assigned to islands round-robin, e.g. cycle,beatdown,defense",
+		},
 		&cli.BoolFlag{
 			Name:  "ga-use-archetypes",
 			Value: gaDefaults.UseArchetypes,
 			Usage: "Use legacy archetype-aware GA fitness objective (default uses archetype-free composite objective)",
 		},
+		&cli.StringFlag{
+			Name:  "ga-objectives",
+			Usage: "Comma-separated objectives for NSGA-II multi-objective mode: attack, defense, synergy, f2p, or all (returns a Pareto front)",
+		},
 	}
 }
 
@@ -245,10 +299,25 @@ func storageFlags() []cli.Flag {
 			Name:  "storage",
 			Usage: "Path to persistent storage database for saving evaluated decks",
 		},
+		&cli.IntFlag{
+			Name:  "eval-cache-size",
+			Value: evaluation.DefaultEvaluationCacheSize,
+			Usage: "Evaluations kept in the on-disk cache (data-dir/cache/evaluations.json) and reused across rounds and runs (0 = off)",
+		},
 		&cli.BoolFlag{
 			Name:  "save-top",
 			Usage: "Save top decks to persistent storage for reuse in subsequent fuzz runs",
 		},
+		&cli.IntFlag{
+			Name:  "keep-per-archetype",
+			Value: fuzzstorage.DefaultKeepPerArchetype,
+			Usage: "With --save-top, keep the best N stored decks per archetype (0 = no archetype quota)",
+		},
+		&cli.IntFlag{
+			Name:  "keep-per-elixir-bucket",
+			Value: fuzzstorage.DefaultKeepPerElixirBucket,
+			Usage: "With --save-top, keep the best N stored decks per low/medium/high elixir bucket (0 = no bucket quota)",
+		},
 		&cli.IntFlag{
 			Name:  "analyze-top",
 			Usage: "analyze top N saved decks and suggest card constraints based on frequency",
@@ -301,7 +370,7 @@ func archetypeFlags() []cli.Flag {
 	return []cli.Flag{
 		&cli.StringSliceFlag{
 			Name:  "archetypes",
-			Usage: "Force generation from specific archetypes (comma-separated: beatdown,control,cycle,bridge,siege,bait,graveyard,miner,hybrid)",
+			Usage: "Force generation from specific archetypes (comma-separated: beatdown,control,cycle,bridge,siege,bait,graveyard,miner,hybrid, or a custom archetype; see `archetypes scorers`)",
 		},
 		&cli.IntFlag{
 			Name:  "refine",
@@ -321,6 +390,15 @@ func archetypeFlags() []cli.Flag {
 			Name:  "ensure-elixir-buckets",
 			Usage: "Ensure top decks are spread across low/medium/high average elixir buckets",
 		},
+		&cli.BoolFlag{
+			Name:  "cluster",
+			Usage: "Group near-duplicate decks and show only the best deck of each group, with its variant count",
+		},
+		&cli.Float64Flag{
+			Name:  "cluster-threshold",
+			Value: deck.DefaultClusterThreshold,
+			Usage: "Jaccard similarity of card sets at which --cluster groups two decks (0.75 = 7 of 8 cards shared)",
+		},
 	}
 }
 
@@ -339,17 +417,34 @@ func addDeckFuzzCommand() *cli.Command {
 	flags := []cli.Flag{}
 	flags = append(flags, basicFlags()...)
 	flags = append(flags, cardConstraintFlags()...)
+	flags = append(flags, cardPoolFlags()...)
+	flags = append(flags, levelWindowFlag())
 	flags = append(flags, savedDeckFlags()...)
 	flags = append(flags, scoreFilterFlags()...)
 	flags = append(flags, outputFlags()...)
 	flags = append(flags, geneticAlgorithmFlags(gaDefaults)...)
 	flags = append(flags, advancedFlags()...)
+	flags = append(flags, distributedFlags()...)
+	flags = append(flags, notifyFlags()...)
+	flags = append(flags, metricsAddrFlag())
 	return &cli.Command{
 		Name:  "fuzz",
 		Usage: "Generate and evaluate random deck combinations using Monte Carlo sampling",
 		Commands: []*cli.Command{
 			addDeckFuzzListCommand(),
 			addDeckFuzzUpdateCommand(),
+			addDeckFuzzMigrateCommand(),
+			addDeckFuzzTagCommand(),
+			addDeckFuzzFavoriteCommand(),
+			addDeckFuzzNoteCommand(),
+			addDeckFuzzPruneCommand(),
+			addDeckFuzzDeleteCommand(),
+			addDeckFuzzExportCommand(),
+			addDeckFuzzImportCommand(),
+			addDeckFuzzLineageCommand(),
+			addDeckFuzzSimilarCommand(),
+			addDeckFuzzResumeCommand(),
+			addDeckFuzzWorkerCommand(),
 		},
 		Flags:  flags,
 		Action: deckFuzzCommand,
@@ -387,6 +482,11 @@ func addDeckFuzzListCommand() *cli.Command {
 				Name:  "max-elixir",
 				Usage: "Maximum average elixir",
 			},
+			deckTagFilterFlag(),
+			favoritesFilterFlag(),
+			withCardsFilterFlag(),
+			withoutCardsFilterFlag(),
+			winConditionFilterFlag(),
 			&cli.IntFlag{
 				Name:  "max-same-archetype",
 				Usage: "Maximum decks per archetype in returned results (0 = unlimited)",
@@ -395,7 +495,7 @@ func addDeckFuzzListCommand() *cli.Command {
 			&cli.StringFlag{
 				Name:  "format",
 				Value: "summary",
-				Usage: "Output format: summary, json, csv, detailed",
+				Usage: "Output format: summary, json, csv, detailed, parquet",
 			},
 			playerTagFlagWithUsage(false, "Player tag (without #) to re-evaluate saved decks with your card levels"),
 			&cli.StringFlag{
@@ -449,6 +549,11 @@ func addDeckFuzzUpdateCommand() *cli.Command {
 				Name:  "max-elixir",
 				Usage: "Maximum average elixir",
 			},
+			deckTagFilterFlag(),
+			favoritesFilterFlag(),
+			withCardsFilterFlag(),
+			withoutCardsFilterFlag(),
+			winConditionFilterFlag(),
 			&cli.IntFlag{
 				Name:  "workers",
 				Value: 1,
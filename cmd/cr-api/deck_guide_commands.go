@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/guide"
+	"github.com/urfave/cli/v3"
+)
+
+// addDeckGuideCommand adds the deck play guide command
+func addDeckGuideCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "guide",
+		Usage: "Generate a play guide for a deck: openings, defense by threat, spell usage, and game plan",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "deck",
+				Usage:    "Deck to write the guide for (8 cards separated by commas or dashes)",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "name",
+				Usage: "Deck name used as the guide title (default: generated from the cards)",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: batchFormatMarkdown,
+				Usage: "Output format: markdown, json",
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "Output file path (optional, prints to stdout if not specified)",
+			},
+		},
+		Action: deckGuideCommand,
+	}
+}
+
+func deckGuideCommand(ctx context.Context, cmd *cli.Command) error {
+	_ = ctx
+	format := strings.ToLower(strings.TrimSpace(cmd.String("format")))
+	if format != batchFormatMarkdown && format != batchFormatJSON {
+		return fmt.Errorf("unknown format: %s (supported: markdown, json)", format)
+	}
+
+	cardNames, err := parseExplainDeck(cmd.String("deck"))
+	if err != nil {
+		return err
+	}
+	cards := convertToCardCandidates(cardNames)
+
+	name := cmd.String("name")
+	if name == "" {
+		name = deck.GenerateDeckName(cards, string(evaluation.DetectArchetype(cards).Primary))
+	}
+
+	playGuide, err := guide.Generate(name, cards, deck.NewSynergyDatabase(), deck.NewCounterMatrixWithDefaults())
+	if err != nil {
+		return fmt.Errorf("failed to generate guide: %w", err)
+	}
+
+	formatted := playGuide.Markdown()
+	if format == batchFormatJSON {
+		data, err := json.MarshalIndent(playGuide, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		formatted = string(data) + "\n"
+	}
+
+	return writeTextOutput(formatted, cmd.String("output"), textOutputOptions{
+		saveMessage: "Guide saved to",
+	})
+}
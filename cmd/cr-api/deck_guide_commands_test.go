@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/internal/storage"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/guide"
+)
+
+func TestDeckGuideCommand(t *testing.T) {
+	run := func(args ...string) (string, error) {
+		return captureStdout(t, func() error {
+			return addDeckGuideCommand().Run(context.Background(), append([]string{"guide"}, args...))
+		})
+	}
+	hog := "Hog Rider,Musketeer,Cannon,Ice Spirit,Skeletons,The Log,Fireball,Ice Golem"
+
+	output, err := run("--deck", hog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(output, "# Hog 2.6 Cycle Play Guide") || !strings.Contains(output, "## Defense by Threat") {
+		t.Errorf("markdown guide:\n%s", output)
+	}
+
+	outputPath := filepath.Join(t.TempDir(), "guide.json")
+	if _, err := run("--deck", strings.ReplaceAll(hog, ",", "-"), "--name", "My Hog", "--format", "json", "--output", outputPath); err != nil {
+		t.Fatal(err)
+	}
+	var decoded guide.Guide
+	if err := storage.ReadJSON(outputPath, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Name != "My Hog" || len(decoded.Deck) != 8 || len(decoded.Defense) == 0 {
+		t.Errorf("JSON guide = %+v", decoded)
+	}
+
+	if _, err := run("--deck", hog, "--format", "pdf"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
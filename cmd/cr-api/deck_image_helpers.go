@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/internal/storage"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/deckimage"
+	"github.com/urfave/cli/v3"
+)
+
+const exportImageFlagName = "export-image"
+
+func exportImageFlag() *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:  exportImageFlagName,
+		Usage: "Render the deck as a card-art grid image; the format follows the extension (.png or .svg)",
+	}
+}
+
+// validateExportImageFlag rejects an unsupported --export-image extension
+// before any deck work starts.
+func validateExportImageFlag(cmd *cli.Command) error {
+	path := cmd.String(exportImageFlagName)
+	if path == "" {
+		return nil
+	}
+	_, err := deckimage.FormatForPath(path)
+	return err
+}
+
+// exportDeckImageIfRequested renders cards to the --export-image path. A
+// non-empty variant is appended to the file name (out.png -> out-aggro.png)
+// for commands that produce several decks. Status goes to stderr so
+// machine-readable stdout stays clean.
+func exportDeckImageIfRequested(ctx context.Context, cmd *cli.Command, variant string, cards []string) error {
+	path := cmd.String(exportImageFlagName)
+	if path == "" {
+		return nil
+	}
+	if variant != "" {
+		ext := filepath.Ext(path)
+		path = strings.TrimSuffix(path, ext) + "-" + variant + ext
+	}
+	return exportDeckImage(ctx, cmd.String("data-dir"), cmd.String("api-token"), cmd.Bool("verbose"), path, cards)
+}
+
+// exportDeckImage writes a deck image to path. Card art is downloaded once
+// into the data directory's card art cache; cards whose art cannot be loaded
+// are drawn as placeholders with a warning rather than failing the command.
+func exportDeckImage(ctx context.Context, dataDir, apiToken string, verbose bool, path string, cards []string) error {
+	db, err := loadStaticCards(ctx, dataDir, apiToken, verbose)
+	var art *deckimage.ArtCache
+	if err != nil {
+		fprintf(os.Stderr, "Warning: %v; rendering the deck image without card art\n", err)
+	} else {
+		art = deckimage.NewArtCache(storage.NewPathBuilder(dataDir).GetCardArtDir())
+	}
+
+	tiles, err := deckimage.BuildTiles(ctx, cards, db, art)
+	if err != nil && art != nil {
+		fprintf(os.Stderr, "Warning: placeholder tiles used for:\n%v\n", err)
+	}
+	// Cards missing from the card database still get their rarity color and
+	// elixir cost from the built-in tables
+	for i := range tiles {
+		if tiles[i].Rarity == "" {
+			tiles[i].Rarity = inferRarity(tiles[i].Name)
+			tiles[i].Elixir = config.GetCardElixir(tiles[i].Name, 0)
+		}
+	}
+	if err := deckimage.WriteFile(path, tiles); err != nil {
+		return err
+	}
+	fprintf(os.Stderr, "Deck image saved to: %s\n", path)
+	return nil
+}
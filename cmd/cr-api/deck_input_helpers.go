@@ -43,6 +43,6 @@ func newDefaultDeckCandidate(name string) deck.CardCandidate {
 		Rarity:   inferRarity(name),
 		Elixir:   config.GetCardElixir(name, 0),
 		Role:     inferRole(name),
-		Stats:    inferStats(name),
+		Stats:    inferStats(name, 11),
 	}
 }
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/interactions"
+	"github.com/urfave/cli/v3"
+)
+
+// addDeckInteractionCommand adds the deck interaction command
+func addDeckInteractionCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "interaction",
+		Usage: "Check whether one card kills another in a single hit at given levels (e.g., Fireball vs Musketeer)",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "attacker",
+				Usage:    "Attacking card, usually a spell (e.g., Fireball)",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "target",
+				Usage:    "Target card (e.g., Musketeer)",
+				Required: true,
+			},
+			&cli.IntFlag{
+				Name:  "attacker-level",
+				Value: 11,
+				Usage: "Attacker card level (1-16)",
+			},
+			&cli.IntFlag{
+				Name:  "target-level",
+				Usage: "Target card level (1-16, default: the attacker's level)",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: batchFormatHuman,
+				Usage: "Output format: human, json",
+			},
+		},
+		Action: deckInteractionCommand,
+	}
+}
+
+func deckInteractionCommand(_ context.Context, cmd *cli.Command) error {
+	attacker := interactions.Card{Name: strings.TrimSpace(cmd.String("attacker")), Level: int(cmd.Int("attacker-level"))}
+	target := interactions.Card{Name: strings.TrimSpace(cmd.String("target")), Level: int(cmd.Int("target-level"))}
+	if target.Level == 0 {
+		target.Level = attacker.Level
+	}
+	maxLevel := config.GetMaxLevel(rarityCommon)
+	for _, card := range []interactions.Card{attacker, target} {
+		if card.Level < 1 || card.Level > maxLevel {
+			return fmt.Errorf("%s level must be between 1 and %d, got %d", card.Name, maxLevel, card.Level)
+		}
+	}
+
+	check, err := interactions.NewCalculator(nil).Check(attacker, target)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(cmd.String("format")) {
+	case batchFormatHuman:
+		printf("%s\n", check.Result)
+		if note := check.Note(); note != "" {
+			printf("⚠ %s\n", note)
+		}
+	case batchFormatJSON:
+		data, err := json.MarshalIndent(check, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		printf("%s\n", data)
+	default:
+		return fmt.Errorf("unknown format: %s (supported: human, json)", cmd.String("format"))
+	}
+	return nil
+}
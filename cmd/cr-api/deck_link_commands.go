@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/urfave/cli/v3"
+)
+
+// addDeckLinkCommand adds the copy-deck link encode/decode command
+func addDeckLinkCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "link",
+		Usage: "Convert between deck card lists and official copy-deck links",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "deck",
+				Usage: "Deck to encode (8 cards separated by dashes)",
+			},
+			&cli.StringFlag{
+				Name:  "decode",
+				Usage: "Copy-deck link (or ID;ID;... list) to decode into card names",
+			},
+		},
+		Action: deckLinkCommand,
+	}
+}
+
+func deckLinkCommand(ctx context.Context, cmd *cli.Command) error {
+	deckStr := cmd.String("deck")
+	link := cmd.String("decode")
+	if (deckStr == "") == (link == "") {
+		return fmt.Errorf("provide exactly one of --deck or --decode")
+	}
+
+	cards, err := loadStaticCards(ctx, cmd.String("data-dir"), cmd.String("api-token"), cmd.Bool("verbose"))
+	if err != nil {
+		return err
+	}
+	index := deck.NewCardIDIndex(cards)
+
+	if deckStr != "" {
+		names, err := parseDeckStringWithLabel(deckStr, "deck")
+		if err != nil {
+			return err
+		}
+		url, err := deck.EncodeDeckLink(index, names)
+		if err != nil {
+			return err
+		}
+		printf("%s\n", url)
+		return nil
+	}
+
+	names, err := deck.DecodeDeckLink(index, link)
+	if err != nil {
+		return err
+	}
+	printf("%s\n", strings.Join(names, "-"))
+	return nil
+}
+
+// deckLinkEncoder produces copy-deck links for command output. It prefers the
+// cached card database and falls back to the built-in card ID table so output
+// never requires an extra API call.
+type deckLinkEncoder struct {
+	index *deck.CardIDIndex
+}
+
+func newDeckLinkEncoder(dataDir string) deckLinkEncoder {
+	index, err := deck.LoadCardIDIndex(dataDir)
+	if err != nil {
+		return deckLinkEncoder{}
+	}
+	return deckLinkEncoder{index: index}
+}
+
+// Link returns the copy-deck link for cards, or "" when it cannot be built.
+func (e deckLinkEncoder) Link(cards []string) string {
+	if e.index != nil {
+		if link, err := deck.EncodeDeckLink(e.index, cards); err == nil {
+			return link
+		}
+	}
+	if link := evaluation.GenerateDeckLink(cards); link.Valid {
+		return link.URL
+	}
+	return ""
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/internal/storage"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+func TestDeckLinkEncoder(t *testing.T) {
+	cards := []string{"Hog Rider", "Musketeer", "Cannon", "Ice Spirit", "Skeletons", "The Log", "Fireball", "Ice Golem"}
+
+	fallback := newDeckLinkEncoder(t.TempDir())
+	if link := fallback.Link(cards); !strings.HasPrefix(link, "https://link.clashroyale.com/deck/en?deck=") {
+		t.Errorf("fallback Link() = %q", link)
+	}
+
+	dataDir := t.TempDir()
+	items := make([]clashroyale.Card, 0, len(cards))
+	for i, name := range cards {
+		items = append(items, clashroyale.Card{ID: 90000000 + i, Name: name})
+	}
+	if err := storage.WriteJSON(storage.NewPathBuilder(dataDir).GetStaticCardsPath(), clashroyale.CardList{Items: items}); err != nil {
+		t.Fatal(err)
+	}
+	cached := newDeckLinkEncoder(dataDir)
+	if link := cached.Link(cards); !strings.Contains(link, "deck=90000000;90000001;") {
+		t.Errorf("cached Link() = %q, want IDs from the cached card database", link)
+	}
+
+	if link := fallback.Link([]string{"Knight"}); link != "" {
+		t.Errorf("Link() for incomplete deck = %q, want empty", link)
+	}
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/pkg/analysis/predict"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/matchup"
+	"github.com/urfave/cli/v3"
+)
+
+// addDeckMatchupCommand adds the deck matchup command
+func addDeckMatchupCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "matchup",
+		Usage: "Score one deck against another (counter coverage, spell answers, win probability)",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "deck-a",
+				Usage:    "First deck (8 cards separated by dashes)",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "deck-b",
+				Usage:    "Second deck (8 cards separated by dashes)",
+				Required: true,
+			},
+			&cli.IntFlag{
+				Name:  "level-a",
+				Usage: "Card level for every deck A card (1-16, default 11)",
+			},
+			&cli.IntFlag{
+				Name:  "level-b",
+				Usage: "Card level for every deck B card (1-16, default 11)",
+			},
+			winModelFlag(),
+			&cli.StringFlag{
+				Name:  "format",
+				Value: batchFormatHuman,
+				Usage: "Output format: human, json, csv",
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "Output file path (optional, prints to stdout if not specified)",
+			},
+		},
+		Action: deckMatchupCommand,
+	}
+}
+
+func deckMatchupCommand(ctx context.Context, cmd *cli.Command) error {
+	_ = ctx
+	format := strings.ToLower(strings.TrimSpace(cmd.String("format")))
+	outputFile := cmd.String("output")
+	verbose := cmd.Bool("verbose")
+
+	deckANames, err := parseDeckStringWithLabel(cmd.String("deck-a"), "deck-a")
+	if err != nil {
+		return err
+	}
+	deckBNames, err := parseDeckStringWithLabel(cmd.String("deck-b"), "deck-b")
+	if err != nil {
+		return err
+	}
+
+	deckA, err := matchupDeckCandidates(deckANames, int(cmd.Int("level-a")), "--level-a")
+	if err != nil {
+		return err
+	}
+	deckB, err := matchupDeckCandidates(deckBNames, int(cmd.Int("level-b")), "--level-b")
+	if err != nil {
+		return err
+	}
+
+	analyzer := matchup.NewAnalyzer(deck.NewCounterMatrixWithDefaults(), deck.NewSynergyDatabase())
+	result, err := analyzer.Analyze(deckA, deckB)
+	if err != nil {
+		return fmt.Errorf("failed to analyze matchup: %w", err)
+	}
+
+	model, err := loadWinModel(cmd)
+	if err != nil {
+		return err
+	}
+	if model != nil {
+		p := model.Predict(predict.Features{
+			LevelDelta:        averageCandidateLevel(deckA) - averageCandidateLevel(deckB),
+			Archetype:         classifyMetaArchetype(deckANames),
+			OpponentArchetype: classifyMetaArchetype(deckBNames),
+		})
+		result.ModelWinProbabilityA = &p
+	}
+
+	formatted, err := formatMatchupResult(result, format)
+	if err != nil {
+		return err
+	}
+
+	return writeTextOutput(formatted, outputFile, textOutputOptions{
+		saveMessage: "Matchup saved to",
+		verboseOnly: true,
+		verbose:     verbose,
+	})
+}
+
+// matchupDeckCandidates builds a matchup deck, optionally with every card at
+// the given level on the shared 1-16 scale.
+func matchupDeckCandidates(names []string, level int, flagName string) ([]deck.CardCandidate, error) {
+	cards := convertToCardCandidates(names)
+	if level == 0 {
+		return cards, nil
+	}
+	if level < 1 || level > config.GetMaxLevel(rarityCommon) {
+		return nil, fmt.Errorf("%s must be between 1 and %d, got %d", flagName, config.GetMaxLevel(rarityCommon), level)
+	}
+	for i := range cards {
+		cards[i].Level = level
+		cards[i].MaxLevel = config.GetMaxLevel(cards[i].Rarity)
+		cards[i].Stats = inferStats(cards[i].Name, level)
+	}
+	return cards, nil
+}
+
+func formatMatchupResult(result *matchup.Result, format string) (string, error) {
+	switch format {
+	case "", batchFormatHuman:
+		return formatMatchupHuman(result), nil
+	case batchFormatJSON:
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to format JSON: %w", err)
+		}
+		return string(data) + "\n", nil
+	case batchFormatCSV:
+		return formatMatchupCSV(result)
+	default:
+		return "", fmt.Errorf("unknown format: %s (supported: human, json, csv)", format)
+	}
+}
+
+func formatMatchupHuman(result *matchup.Result) string {
+	var buf bytes.Buffer
+
+	fprintf(&buf, "\nDeck Matchup\n")
+	fprintf(&buf, "============\n")
+	fprintf(&buf, "Deck A: %s\n", strings.Join(result.DeckA.Deck, " - "))
+	fprintf(&buf, "Deck B: %s\n\n", strings.Join(result.DeckB.Deck, " - "))
+
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fprintf(w, "Metric\tDeck A\tDeck B\n")
+	fprintf(w, "------\t------\t------\n")
+	fprintf(w, "Archetype\t%s\t%s\n", result.DeckA.Archetype, result.DeckB.Archetype)
+	fprintf(w, "Overall Score\t%.2f\t%.2f\n", result.DeckA.OverallScore, result.DeckB.OverallScore)
+	fprintf(w, "Counter Coverage\t%.0f%%\t%.0f%%\n", result.DeckA.CounterCoverage*100, result.DeckB.CounterCoverage*100)
+	fprintf(w, "Spell Coverage\t%.0f%%\t%.0f%%\n", result.DeckA.SpellCoverage*100, result.DeckB.SpellCoverage*100)
+	fprintf(w, "Win Probability\t%.1f%%\t%.1f%%\n", result.WinProbabilityA*100, result.WinProbabilityB*100)
+	if p := result.ModelWinProbabilityA; p != nil {
+		fprintf(w, "Model Win Probability\t%.1f%%\t%.1f%%\n", *p*100, (1-*p)*100)
+	}
+	flushWriter(w)
+
+	for _, side := range []struct {
+		label  string
+		report matchup.SideReport
+	}{
+		{"Deck A", result.DeckA},
+		{"Deck B", result.DeckB},
+	} {
+		fprintf(&buf, "\n%s answers to opponent threats:\n", side.label)
+		for _, answer := range side.report.ThreatAnswers {
+			fprintf(&buf, "  ✓ %s: %s (%.0f%%)\n", answer.Threat, strings.Join(answer.Answers, ", "), answer.Effectiveness*100)
+		}
+		for _, threat := range side.report.UnansweredThreats {
+			fprintf(&buf, "  ✗ %s: no answer\n", threat)
+		}
+		for _, spell := range side.report.SpellAnswers {
+			if !spell.Answered {
+				fprintf(&buf, "  ✗ %s: no spell answer\n", spell.Target)
+			}
+		}
+		for _, check := range side.report.LevelInteractions {
+			fprintf(&buf, "  ⚠ %s\n", check.Note())
+		}
+	}
+
+	fprintf(&buf, "\n%s\n", result.Summary)
+	return buf.String()
+}
+
+func formatMatchupCSV(result *matchup.Result) (string, error) {
+	header := []string{"Side", "Kind", "Target", "Answers", "Effectiveness"}
+	rows := make([][]string, 0)
+
+	appendSide := func(label string, side matchup.SideReport) {
+		for _, answer := range side.ThreatAnswers {
+			rows = append(rows, []string{label, "threat", answer.Threat, strings.Join(answer.Answers, ";"), fmt.Sprintf("%.2f", answer.Effectiveness)})
+		}
+		for _, threat := range side.UnansweredThreats {
+			rows = append(rows, []string{label, "threat", threat, "", "0.00"})
+		}
+		for _, spell := range side.SpellAnswers {
+			effectiveness := "0.00"
+			if spell.Answered {
+				effectiveness = "1.00"
+			}
+			rows = append(rows, []string{label, "spell", spell.Target, strings.Join(spell.Spells, ";"), effectiveness})
+		}
+		for _, check := range side.LevelInteractions {
+			effectiveness := "0.00"
+			if check.Kills {
+				effectiveness = "1.00"
+			}
+			rows = append(rows, []string{label, "level_interaction", check.Target.Name, check.Attacker.Name, effectiveness})
+		}
+	}
+	appendSide("deck_a", result.DeckA)
+	appendSide("deck_b", result.DeckB)
+	rows = append(rows,
+		[]string{"deck_a", "win_probability", "", "", fmt.Sprintf("%.3f", result.WinProbabilityA)},
+		[]string{"deck_b", "win_probability", "", "", fmt.Sprintf("%.3f", result.WinProbabilityB)},
+	)
+	if p := result.ModelWinProbabilityA; p != nil {
+		rows = append(rows,
+			[]string{"deck_a", "model_win_probability", "", "", fmt.Sprintf("%.3f", *p)},
+			[]string{"deck_b", "model_win_probability", "", "", fmt.Sprintf("%.3f", 1-*p)},
+		)
+	}
+
+	var buf bytes.Buffer
+	if err := writeCSVDocument(&buf, header, rows); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
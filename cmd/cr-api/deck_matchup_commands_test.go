@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck/matchup"
+)
+
+func TestFormatMatchupResult(t *testing.T) {
+	deckA := convertToCardCandidates([]string{"Hog Rider", "Musketeer", "Cannon", "Ice Spirit", "Skeletons", "The Log", "Fireball", "Ice Golem"})
+	deckB := convertToCardCandidates([]string{"Goblin Barrel", "Princess", "Goblin Gang", "Knight", "Inferno Tower", "Rocket", "The Log", "Ice Spirit"})
+
+	result, err := matchup.NewAnalyzer(nil, nil).Analyze(deckA, deckB)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	jsonOut, err := formatMatchupResult(result, batchFormatJSON)
+	if err != nil {
+		t.Fatalf("json format error = %v", err)
+	}
+	var decoded matchup.Result
+	if err := json.Unmarshal([]byte(jsonOut), &decoded); err != nil {
+		t.Fatalf("json output did not round-trip: %v", err)
+	}
+	if decoded.WinProbabilityA != result.WinProbabilityA {
+		t.Errorf("win probability mismatch after round-trip: %v vs %v", decoded.WinProbabilityA, result.WinProbabilityA)
+	}
+
+	csvOut, err := formatMatchupResult(result, batchFormatCSV)
+	if err != nil {
+		t.Fatalf("csv format error = %v", err)
+	}
+	if !strings.HasPrefix(csvOut, "Side,Kind,Target,Answers,Effectiveness") {
+		t.Errorf("unexpected CSV header: %q", strings.SplitN(csvOut, "\n", 2)[0])
+	}
+	if !strings.Contains(csvOut, "win_probability") {
+		t.Error("CSV output should include win probability rows")
+	}
+
+	humanOut, err := formatMatchupResult(result, batchFormatHuman)
+	if err != nil {
+		t.Fatalf("human format error = %v", err)
+	}
+	if !strings.Contains(humanOut, "Win Probability") {
+		t.Error("human output should include win probability")
+	}
+
+	if _, err := formatMatchupResult(result, "xml"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestMatchupDeckLevels(t *testing.T) {
+	names := []string{"Hog Rider", "Musketeer", "Cannon", "Ice Spirit", "Skeletons", "The Log", "Fireball", "Ice Golem"}
+	deckA, err := matchupDeckCandidates(names, 11, "--level-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	deckB, err := matchupDeckCandidates([]string{"Golem", "Night Witch", "Wizard", "Baby Dragon", "Lightning", "Zap", "Lumberjack", "Tornado"}, 13, "--level-b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deckB[2].StandardLevel() != 13 || deckB[2].Stats.Hitpoints <= deckA[1].Stats.Hitpoints {
+		t.Errorf("level 13 Wizard: level %d, %d HP", deckB[2].StandardLevel(), deckB[2].Stats.Hitpoints)
+	}
+
+	result, err := matchup.NewAnalyzer(nil, nil).Analyze(deckA, deckB)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	humanOut, err := formatMatchupResult(result, batchFormatHuman)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(humanOut, "⚠ Fireball (level 11) no longer one-shots Wizard (level 13)") {
+		t.Errorf("human output missing the level interaction:\n%s", humanOut)
+	}
+	csvOut, err := formatMatchupResult(result, batchFormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(csvOut, "deck_a,level_interaction,Wizard,Fireball,0.00") {
+		t.Errorf("CSV output missing the level interaction:\n%s", csvOut)
+	}
+
+	if _, err := matchupDeckCandidates(names, 17, "--level-a"); err == nil {
+		t.Error("expected an error for level 17")
+	}
+}
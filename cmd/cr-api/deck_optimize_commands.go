@@ -1,6 +1,8 @@
 package main
 
 import (
+	"slices"
+
 	"github.com/urfave/cli/v3"
 )
 
@@ -61,7 +63,7 @@ func addDeckRecommendCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "recommend",
 		Usage: "Get meta-based deck recommendations",
-		Flags: []cli.Flag{
+		Flags: slices.Concat([]cli.Flag{
 			playerTagFlagWithUsage(false, "Player tag (without #) for personalized recommendations"),
 			&cli.StringFlag{
 				Name:  "archetype",
@@ -100,7 +102,9 @@ func addDeckRecommendCommand() *cli.Command {
 				Name:  "export-csv",
 				Usage: "Export recommendations to CSV",
 			},
-		},
+			gameModeFlag(),
+			substitutesFlag(),
+		}, cardPoolFlags()),
 		Action: deckRecommendCommand,
 	}
 }
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/sqlstore"
+	"github.com/klauer/clash-royale-api/go/internal/storage"
+	"github.com/klauer/clash-royale-api/go/pkg/analysis/predict"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/urfave/cli/v3"
+)
+
+const winModelFlagName = "win-model"
+
+// winModelFlag selects the trained win model used by matchup and counter.
+func winModelFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  winModelFlagName,
+		Usage: "Trained win model (default: <data-dir>/win_model.json when present; train with `cr-api deck predict train`)",
+	}
+}
+
+// winModelPath returns the win model for this invocation: --win-model, or
+// win_model.json in the data dir.
+func winModelPath(cmd *cli.Command) string {
+	if path := strings.TrimSpace(cmd.String(winModelFlagName)); path != "" {
+		return path
+	}
+	return filepath.Join(cmd.String("data-dir"), predict.ModelFile)
+}
+
+// loadWinModel returns the trained win model, or nil when none has been
+// trained. A model named with --win-model must exist; a default model that
+// fails to load only produces a warning.
+func loadWinModel(cmd *cli.Command) (*predict.Model, error) {
+	path := winModelPath(cmd)
+	if !storage.FileExists(path) {
+		if cmd.IsSet(winModelFlagName) {
+			return nil, fmt.Errorf("win model not found: %s", path)
+		}
+		return nil, nil
+	}
+	model, err := predict.Load(path)
+	if err != nil {
+		if cmd.IsSet(winModelFlagName) {
+			return nil, err
+		}
+		fprintf(os.Stderr, "Warning: ignoring win model: %v (re-run `cr-api deck predict train`)\n", err)
+		return nil, nil
+	}
+	return model, nil
+}
+
+// averageCandidateLevel returns the mean level of cards
+func averageCandidateLevel(cards []deck.CardCandidate) float64 {
+	if len(cards) == 0 {
+		return 0
+	}
+	total := 0
+	for _, card := range cards {
+		total += card.Level
+	}
+	return float64(total) / float64(len(cards))
+}
+
+// addDeckPredictCommand adds the win prediction model commands
+func addDeckPredictCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "predict",
+		Usage: "Train the win prediction model used by matchup and counter",
+		Commands: []*cli.Command{
+			{
+				Name:  "train",
+				Usage: "Fit win probabilities to card levels, trophies and archetype matchups in recorded battles",
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:  "days",
+						Value: 90,
+						Usage: "Only use battles from the last N days (0 = all history)",
+					},
+					&cli.StringSliceFlag{
+						Name:  "battle-types",
+						Value: []string{"PvP", "pathOfLegend"},
+						Usage: "Battle types to train on (empty = all)",
+					},
+					&cli.FloatFlag{
+						Name:  "prior-games",
+						Value: predict.DefaultTrainOptions().PriorGames,
+						Usage: "Pseudo-games that shrink archetype matchup win rates toward 50%",
+					},
+					&cli.IntFlag{
+						Name:  "top",
+						Value: 10,
+						Usage: "Number of most-played archetype matchups to show",
+					},
+					&cli.StringFlag{
+						Name:  "output",
+						Usage: "Model file to write (default: --win-model or <data-dir>/win_model.json)",
+					},
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "Show the trained model without writing it",
+					},
+				},
+				Action: deckPredictTrainCommand,
+			},
+		},
+	}
+}
+
+func deckPredictTrainCommand(ctx context.Context, cmd *cli.Command) error {
+	_ = ctx
+	opts := predict.DefaultTrainOptions()
+	opts.PriorGames = cmd.Float("prior-games")
+	if opts.PriorGames < 0 {
+		return fmt.Errorf("--prior-games cannot be negative")
+	}
+
+	var since time.Time
+	if days := cmd.Int("days"); days > 0 {
+		since = time.Now().AddDate(0, 0, -days)
+	}
+
+	db, err := openHistoryDB(cmd)
+	if err != nil {
+		return err
+	}
+	defer closeFile(db)
+	battles, err := db.Battles(since)
+	if err != nil {
+		return err
+	}
+
+	examples := battleExamples(decidedBattles(battles, cmd.StringSlice("battle-types")))
+	if len(examples) == 0 {
+		return fmt.Errorf("no decided battles recorded in %s (record some with `cr-api db sync`)", db.Path())
+	}
+	model, err := predict.Train(examples, opts)
+	if err != nil {
+		return err
+	}
+
+	printf("%s", formatWinModel(model, cmd.Int("top")))
+	if cmd.Bool("dry-run") {
+		return nil
+	}
+
+	path := strings.TrimSpace(cmd.String("output"))
+	if path == "" {
+		path = winModelPath(cmd)
+	}
+	if err := model.Save(path); err != nil {
+		return err
+	}
+	printf("\nWin model saved to: %s\n", path)
+	return nil
+}
+
+// battleExamples turns decided battles into training examples. Level and
+// trophy gaps are left at 0 unless both sides are known; battles recorded
+// before levels were stored have none.
+func battleExamples(battles []sqlstore.StoredBattle) []predict.Example {
+	archetypes := make(map[string]string)
+	archetype := func(cards []string) string {
+		key := strings.Join(cards, "|")
+		if name, ok := archetypes[key]; ok {
+			return name
+		}
+		name := classifyMetaArchetype(cards)
+		archetypes[key] = name
+		return name
+	}
+
+	examples := make([]predict.Example, 0, len(battles))
+	for _, battle := range battles {
+		example := predict.Example{
+			Features: predict.Features{
+				Archetype:         archetype(battle.Deck),
+				OpponentArchetype: archetype(battle.OpponentDeck),
+			},
+			Won: battle.Crowns > battle.OpponentCrowns,
+		}
+		if battle.DeckLevel > 0 && battle.OpponentDeckLevel > 0 {
+			example.LevelDelta = battle.DeckLevel - battle.OpponentDeckLevel
+		}
+		if battle.StartingTrophies > 0 && battle.OpponentStartingTrophies > 0 {
+			example.TrophyDelta = float64(battle.StartingTrophies - battle.OpponentStartingTrophies)
+		}
+		examples = append(examples, example)
+	}
+	return examples
+}
+
+func formatWinModel(model *predict.Model, top int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Trained on %d battles: %.1f%% accuracy, log loss %.3f\n",
+		model.Battles, model.Accuracy*100, model.LogLoss)
+	fmt.Fprintf(&b, "Weights: level delta %+.3f per level, trophy delta %+.3f per 100 trophies, archetype matchup %+.3f\n",
+		model.Weights.LevelDelta, model.Weights.TrophyDelta, model.Weights.Matchup)
+	if top <= 0 || len(model.Matchups) == 0 {
+		return b.String()
+	}
+
+	// Each pairing is stored both ways; show it once from the winning side.
+	// Mirror matchups always split evenly, so they are left out.
+	matchups := slices.DeleteFunc(slices.Clone(model.Matchups), func(record predict.MatchupRecord) bool {
+		if record.Archetype == record.Opponent {
+			return true
+		}
+		if 2*record.Wins == record.Games {
+			return record.Archetype > record.Opponent
+		}
+		return 2*record.Wins < record.Games
+	})
+	if len(matchups) == 0 {
+		return b.String()
+	}
+	slices.SortStableFunc(matchups, func(a, b predict.MatchupRecord) int {
+		return cmp.Compare(b.Games, a.Games)
+	})
+	matchups = matchups[:min(top, len(matchups))]
+
+	fmt.Fprintf(&b, "\nMost played archetype matchups:\n")
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fprintln(w, "  Archetype\tOpponent\tGames\tWin Rate\tPredicted")
+	for _, record := range matchups {
+		fprintf(w, "  %s\t%s\t%d\t%.1f%%\t%.1f%%\n", record.Archetype, record.Opponent, record.Games,
+			100*float64(record.Wins)/float64(record.Games),
+			100*model.Predict(predict.Features{Archetype: record.Archetype, OpponentArchetype: record.Opponent}))
+	}
+	flushWriter(w)
+	return b.String()
+}
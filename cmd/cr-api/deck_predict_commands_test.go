@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/sqlstore"
+	"github.com/klauer/clash-royale-api/go/pkg/analysis/predict"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/matchup"
+)
+
+var (
+	predictHogDeck   = []string{"Hog Rider", "Musketeer", "Cannon", "Ice Spirit", "Skeletons", "The Log", "Fireball", "Ice Golem"}
+	predictGolemDeck = []string{"Golem", "Night Witch", "Baby Dragon", "Lumberjack", "Mega Minion", "Tornado", "Lightning", "Barbarian Barrel"}
+)
+
+func TestBattleExamples(t *testing.T) {
+	at := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	battles := []sqlstore.StoredBattle{
+		{
+			PlayerTag: "#AAA", OpponentTag: "#BBB", Time: at, Crowns: 2, OpponentCrowns: 1,
+			Deck: predictHogDeck, OpponentDeck: predictGolemDeck,
+			DeckLevel: 13.5, OpponentDeckLevel: 12, StartingTrophies: 7000, OpponentStartingTrophies: 7150,
+		},
+		// Recorded before levels were stored
+		{
+			PlayerTag: "#AAA", OpponentTag: "#CCC", Time: at.Add(time.Hour), Crowns: 0, OpponentCrowns: 1,
+			Deck: predictHogDeck, OpponentDeck: predictGolemDeck, StartingTrophies: 7030,
+		},
+	}
+
+	examples := battleExamples(battles)
+	if len(examples) != 2 {
+		t.Fatalf("got %d examples, want 2", len(examples))
+	}
+	first := examples[0]
+	if !first.Won || first.LevelDelta != 1.5 || first.TrophyDelta != -150 {
+		t.Errorf("first example = %+v, want a win with level delta 1.5 and trophy delta -150", first)
+	}
+	if first.Archetype != classifyMetaArchetype(predictHogDeck) || first.OpponentArchetype != classifyMetaArchetype(predictGolemDeck) {
+		t.Errorf("archetypes = %q vs %q", first.Archetype, first.OpponentArchetype)
+	}
+	if second := examples[1]; second.Won || second.LevelDelta != 0 || second.TrophyDelta != 0 {
+		t.Errorf("second example = %+v, want a loss with unknown gaps left at 0", second)
+	}
+}
+
+func TestFormatWinModel(t *testing.T) {
+	examples := make([]predict.Example, 0, 12)
+	for i := range 12 {
+		examples = append(examples, predict.Example{
+			Features: predict.Features{Archetype: "Siege", OpponentArchetype: "Beatdown"},
+			Won:      i < 3,
+		})
+	}
+	model, err := predict.Train(examples, predict.DefaultTrainOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := formatWinModel(model, 5)
+	for _, want := range []string{"Trained on 12 battles", "Most played archetype matchups:", "Beatdown   Siege     12     75.0%"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Siege     Beatdown") {
+		t.Errorf("matchup should only be shown from the winning side:\n%s", out)
+	}
+}
+
+func TestDeckMatchupCommandWinModel(t *testing.T) {
+	hog, golem := classifyMetaArchetype(predictHogDeck), classifyMetaArchetype(predictGolemDeck)
+	examples := make([]predict.Example, 0, 40)
+	for i := range 40 {
+		examples = append(examples, predict.Example{
+			Features: predict.Features{LevelDelta: float64(i%3 - 1), Archetype: hog, OpponentArchetype: golem},
+			Won:      i%4 != 0,
+		})
+	}
+	model, err := predict.Train(examples, predict.DefaultTrainOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), predict.ModelFile)
+	if err := model.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := captureStdout(t, func() error {
+		return addDeckMatchupCommand().Run(context.Background(), []string{"matchup",
+			"--deck-a", strings.Join(predictHogDeck, "-"),
+			"--deck-b", strings.Join(predictGolemDeck, "-"),
+			"--win-model", path,
+			"--format", batchFormatJSON,
+		})
+	})
+	if err != nil {
+		t.Fatalf("matchup error = %v", err)
+	}
+	var result matchup.Result
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, output)
+	}
+	if result.ModelWinProbabilityA == nil || *result.ModelWinProbabilityA <= 0.5 {
+		t.Errorf("model win probability = %v, want the hog deck favored", result.ModelWinProbabilityA)
+	}
+
+	human := formatMatchupHuman(&result)
+	if !strings.Contains(human, "Model Win Probability") {
+		t.Errorf("human output missing model win probability:\n%s", human)
+	}
+
+	err = addDeckMatchupCommand().Run(context.Background(), []string{"matchup",
+		"--deck-a", strings.Join(predictHogDeck, "-"),
+		"--deck-b", strings.Join(predictGolemDeck, "-"),
+		"--win-model", filepath.Join(t.TempDir(), "missing.json"),
+	})
+	if err == nil || !strings.Contains(err.Error(), "win model not found") {
+		t.Errorf("missing --win-model error = %v", err)
+	}
+}
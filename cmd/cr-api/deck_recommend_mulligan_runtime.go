@@ -12,6 +12,7 @@ import (
 
 	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
 	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
 	"github.com/klauer/clash-royale-api/go/pkg/mulligan"
 	"github.com/klauer/clash-royale-api/go/pkg/recommend"
 	"github.com/urfave/cli/v3"
@@ -37,6 +38,14 @@ func deckRecommendCommand(ctx context.Context, cmd *cli.Command) error {
 	if count <= 0 {
 		return fmt.Errorf("--count must be >= 1")
 	}
+	cardPool, err := loadCardPoolFlags(cmd)
+	if err != nil {
+		return err
+	}
+	gameMode, err := parseGameModeFlag(cmd)
+	if err != nil {
+		return err
+	}
 
 	var deckCardAnalysis deck.CardAnalysis
 	var playerName, playerTag string
@@ -105,11 +114,16 @@ func deckRecommendCommand(ctx context.Context, cmd *cli.Command) error {
 		}
 	}
 
+	applyCardPoolToAnalysis(&deckCardAnalysis, cardPool, verbose)
+	applyArenaLockToAnalysis(cmd, &deckCardAnalysis, verbose)
+
 	// Create recommender with options
 	options := recommend.DefaultOptions()
 	options.Limit = count
 	options.Arena = arena
 	options.League = league
+	options.GameMode = gameMode
+	options.Substitutes = cmd.Int(substitutesFlagName)
 
 	recommender := recommend.NewRecommender(dataDir, options)
 
@@ -118,7 +132,7 @@ func deckRecommendCommand(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return fmt.Errorf("failed to generate recommendations: %w", err)
 	}
-	applyRecommendationFilters(result, deckCardAnalysis, archetypeFilter, includeUnowned)
+	applyRecommendationFilters(result, deckCardAnalysis, archetypeFilter, includeUnowned, cardPool, arenaLock(cmd, deckCardAnalysis.ArenaID))
 
 	// Display results
 	displayRecommendations(result, verbose)
@@ -139,6 +153,8 @@ func applyRecommendationFilters(
 	analysis deck.CardAnalysis,
 	archetypeFilter string,
 	includeUnowned bool,
+	cardPool *deck.CardPool,
+	arena *evaluation.PlayerContext,
 ) {
 	if result == nil {
 		return
@@ -155,6 +171,14 @@ func applyRecommendationFilters(
 		if !includeUnowned && containsUnownedCard(rec, analysis.CardLevels) {
 			continue
 		}
+		// Archetype templates can suggest unowned cards outside the pool
+		if cardPool != nil && rec.Deck != nil && !cardPool.AllowsDeck(rec.Deck.Deck) {
+			continue
+		}
+		// ...and cards the player's arena has not unlocked
+		if arena != nil && rec.Deck != nil && len(arena.LockedCards(rec.Deck.Deck)) > 0 {
+			continue
+		}
 		filtered = append(filtered, rec)
 	}
 
@@ -213,6 +237,9 @@ func displaySingleRecommendation(rank int, rec *recommend.DeckRecommendation, ve
 	printf("─────────────────────────────────────────────────────\n")
 	printf("Compatibility: %.1f%% | Synergy: %.1f%% | Overall: %.1f%%\n",
 		rec.CompatibilityScore, rec.SynergyScore, rec.OverallScore)
+	if rec.GameMode != "" {
+		printf("%s fit: %.1f/10\n", evaluation.LookupGameMode(rec.GameMode).Name, rec.GameModeScore)
+	}
 	printf("Avg Elixir: %.2f\n", rec.Deck.AvgElixir)
 
 	// Display cards in table format
@@ -236,6 +263,10 @@ func displaySingleRecommendation(rank int, rec *recommend.DeckRecommendation, ve
 		}
 	}
 
+	if len(rec.Substitutions) > 0 {
+		printf("\nSubstitutes (score before → after):\n%s", evaluation.FormatSubstitutions(rec.Substitutions))
+	}
+
 	if verbose {
 		// Display upgrade cost if available
 		if rec.UpgradeCost.CardsNeeded > 0 {
@@ -99,9 +99,9 @@ func deckResearchEvalCommand(ctx context.Context, cmd *cli.Command) error {
 
 	dataDir := cmd.String("data-dir")
 	statsPath := filepath.Join(dataDir, "cards_stats.json")
-	stats, statsErr := clashroyale.LoadStats(statsPath)
+	stats, statsErr := clashroyale.LoadStatsWithDefaults(statsPath)
 	if statsErr != nil {
-		fprintf(os.Stderr, "Warning: failed to load combat stats at %s: %v\n", statsPath, statsErr)
+		fprintf(os.Stderr, "Warning: ignoring combat stats at %s, using bundled stats: %v\n", statsPath, statsErr)
 	}
 
 	client, err := requireAPIClientFromToken(apiToken, apiClientOptions{})
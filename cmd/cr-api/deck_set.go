@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/urfave/cli/v3"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// deckSetCommand builds --count decks from the player's collection with no
+// card shared between them
+func deckSetCommand(ctx context.Context, cmd *cli.Command) error {
+	tag := cmd.String("tag")
+	dataDir := cmd.String("data-dir")
+	strategy := cmd.String("strategy")
+	count := cmd.Int("count")
+	balance := cmd.Float64("balance")
+	includeCards := cmd.StringSlice("include-cards")
+	excludeCards := cmd.StringSlice("exclude-cards")
+	verbose := cmd.Bool("verbose")
+	duel := cmd.Bool("duel")
+	facedBattles := cmd.Int("faced-battles")
+
+	if count < 1 {
+		return fmt.Errorf("count must be at least 1")
+	}
+	if balance < 0 || balance > 1 {
+		return fmt.Errorf("balance must be between 0 and 1, got %.2f", balance)
+	}
+	if facedBattles < 0 {
+		return fmt.Errorf("faced-battles must not be negative")
+	}
+	if strings.ToLower(strings.TrimSpace(strategy)) == deckStrategyAll {
+		return fmt.Errorf("deck set builds one set per run; choose a single strategy instead of %q", deckStrategyAll)
+	}
+
+	if err := configureCombatStats(cmd); err != nil {
+		return err
+	}
+	cardPool, err := loadCardPoolFlags(cmd)
+	if err != nil {
+		return err
+	}
+	if err := validateIncludedCardsInPool(includeCards, cardPool); err != nil {
+		return err
+	}
+
+	builder, err := configureDeckBuilder(cmd, dataDir, strategy)
+	if err != nil {
+		return err
+	}
+	if err := configureFuzzIntegration(cmd, builder); err != nil {
+		return err
+	}
+
+	playerData, err := loadPlayerCardAnalysis(ctx, cmd, builder, tag)
+	if err != nil {
+		return err
+	}
+	overrides, err := parseBoostedCardLevels(cmd.StringSlice(boostedCardLevelFlagName))
+	if err != nil {
+		return err
+	}
+	applyBoostedLevelsToCardAnalysis(&playerData.CardAnalysis, overrides)
+	applyExcludeFilter(&playerData.CardAnalysis, excludeCards)
+	applyCardPoolToAnalysis(&playerData.CardAnalysis, cardPool, verbose)
+	applyArenaLockToAnalysis(cmd, &playerData.CardAnalysis, verbose)
+
+	opts := deck.DeckSetOptions{Count: count, Balance: balance, Duel: duel}
+	if duel {
+		opts.Classify = classifyDeckSetArchetype
+	}
+	if balance == 0 {
+		// The library treats zero as "use the default"; on the command line
+		// an explicit 0 means optimize the average alone.
+		opts.Balance = -1
+	}
+	set, err := builder.BuildDeckSet(playerData.CardAnalysis, opts)
+	if err != nil {
+		return fmt.Errorf("failed to build deck set: %w", err)
+	}
+	if duel && facedBattles > 0 {
+		if err := validateDeckSetAgainstBattles(ctx, cmd, set, facedBattles); err != nil {
+			fprintf(os.Stderr, "Skipping faced-archetype check: %v\n", err)
+		}
+	}
+
+	if cmd.Bool("json") {
+		return outputDeckSetJSON(set)
+	}
+	displayDeckSet(playerData.PlayerName, playerData.PlayerTag, set)
+	return nil
+}
+
+// classifyDeckSetArchetype names a deck's primary archetype for duel mode
+func classifyDeckSetArchetype(cards []deck.CardCandidate) string {
+	return string(evaluation.DetectArchetype(cards).Primary)
+}
+
+// validateDeckSetAgainstBattles checks the set against the opponents from
+// the player's recent battles
+func validateDeckSetAgainstBattles(ctx context.Context, cmd *cli.Command, set *deck.DeckSet, limit int) error {
+	db, err := loadCountersDatabase(cmd.String("counters-file"))
+	if err != nil {
+		return err
+	}
+	client, err := requireAPIClient(cmd, apiClientOptions{offlineHint: ", or pass --faced-battles 0"})
+	if err != nil {
+		return err
+	}
+	battleLog, err := client.GetPlayerBattleLogWithContext(ctx, cmd.String("tag"))
+	if err != nil {
+		return fmt.Errorf("failed to get battle log: %w", err)
+	}
+	_, err = set.ValidateCoverage(facedDecksFromBattleLog(*battleLog, limit), db)
+	return err
+}
+
+// facedDecksFromBattleLog returns the opponent decks from the most recent
+// limit battles, labeled with their archetype
+func facedDecksFromBattleLog(battles []clashroyale.Battle, limit int) []deck.FacedDeck {
+	faced := make([]deck.FacedDeck, 0, min(limit, len(battles)))
+	for _, battle := range battles[:min(limit, len(battles))] {
+		if len(battle.Opponent) == 0 || len(battle.Opponent[0].Cards) == 0 {
+			continue
+		}
+		cards := make([]string, 0, len(battle.Opponent[0].Cards))
+		for _, card := range battle.Opponent[0].Cards {
+			cards = append(cards, card.Name)
+		}
+		faced = append(faced, deck.FacedDeck{Archetype: classifyMetaArchetype(cards), Cards: cards})
+	}
+	return faced
+}
+
+func outputDeckSetJSON(set *deck.DeckSet) error {
+	data, err := json.MarshalIndent(set, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal deck set: %w", err)
+	}
+
+	fmt.Println(string(data))
+	return nil
+}
+
+func displayDeckSet(playerName, playerTag string, set *deck.DeckSet) {
+	printf("\nDECK SET (NO SHARED CARDS)\n")
+	printf("==========================\n\n")
+	printf("Player: %s (%s)\n", playerName, playerTag)
+	printf("Decks: %d\n", len(set.Decks))
+	printf("Set score: %.2f (average %.2f, weakest %.2f)\n", set.Objective, set.Average, set.Weakest)
+	printf("Improvement passes: %d\n", set.Passes)
+	if set.Duel {
+		names := make([]string, len(set.Archetypes))
+		for i, archetype := range set.Archetypes {
+			names[i] = formatDeckSetArchetype(archetype)
+		}
+		printf("Duel mode: %s\n", strings.Join(names, ", "))
+		if len(set.SharedWeaknesses) > 0 {
+			printf("Shared weaknesses: %s\n", strings.Join(set.SharedWeaknesses, ", "))
+		}
+	}
+
+	for i, rec := range set.Decks {
+		if set.Duel {
+			printf("\nDeck %d - %s - score %.2f\n", i+1, formatDeckSetArchetype(set.Archetypes[i]), set.Scores[i])
+		} else {
+			printf("\nDeck %d - score %.2f\n", i+1, set.Scores[i])
+		}
+		printf("Average Elixir: %.2f\n", rec.AvgElixir)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fprintf(w, "#\tCard\tLevel\t\tElixir\tRole\n")
+		fprintf(w, "-\t----\t-----\t\t------\t----\n")
+		for j, card := range rec.DeckDetail {
+			levelStr := fmt.Sprintf("%d/%d", card.Level, card.MaxLevel)
+			if evoBadge := deck.FormatEvolutionBadge(card.EvolutionLevel); evoBadge != "" {
+				levelStr = fmt.Sprintf("%s (%s)", levelStr, evoBadge)
+			}
+			fprintf(w, "%d\t%s\t%s\t%d\t%s\n", j+1, card.Name, levelStr, card.Elixir, card.Role)
+		}
+		flushWriter(w)
+	}
+
+	if set.Coverage != nil {
+		displayDeckSetCoverage(set.Coverage)
+	}
+}
+
+func displayDeckSetCoverage(coverage *deck.DeckSetCoverage) {
+	printf("\nFaced Archetypes (%d battles, coverage %.0f%%)\n", coverage.Battles, coverage.Score*100)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fprintf(w, "Archetype\tFaced\tBest Deck\tCoverage\t\n")
+	fprintf(w, "---------\t-----\t---------\t--------\t\n")
+	for _, entry := range coverage.Archetypes {
+		status := ""
+		if !entry.Covered {
+			status = "UNCOVERED"
+		}
+		fprintf(w, "%s\t%d\t%d\t%.0f%%\t%s\n", formatDeckSetArchetype(entry.Archetype), entry.Faced, entry.BestDeck, entry.Coverage*100, status)
+	}
+	flushWriter(w)
+}
+
+func formatDeckSetArchetype(archetype string) string {
+	if archetype == "" {
+		return "Unknown"
+	}
+	return cases.Title(language.English).String(strings.ReplaceAll(archetype, "_", " "))
+}
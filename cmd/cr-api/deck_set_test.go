@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+func TestFacedDecksFromBattleLog(t *testing.T) {
+	hog := []clashroyale.Card{
+		{Name: "Hog Rider"}, {Name: "Musketeer"}, {Name: "Cannon"}, {Name: "Fireball"},
+		{Name: "The Log"}, {Name: "Ice Spirit"}, {Name: "Skeletons"}, {Name: "Ice Golem"},
+	}
+	battles := []clashroyale.Battle{
+		{Opponent: []clashroyale.BattleTeam{{Cards: hog}}},
+		{Opponent: []clashroyale.BattleTeam{{}}},
+		{Opponent: []clashroyale.BattleTeam{{Cards: hog}}},
+	}
+
+	faced := facedDecksFromBattleLog(battles, 2)
+	if len(faced) != 1 {
+		t.Fatalf("facedDecksFromBattleLog() = %+v, want only the first battle with cards", faced)
+	}
+	if len(faced[0].Cards) != 8 || faced[0].Cards[0] != "Hog Rider" || faced[0].Archetype == "" {
+		t.Errorf("faced deck = %+v", faced[0])
+	}
+	if got := facedDecksFromBattleLog(battles, 10); len(got) != 2 {
+		t.Errorf("limit past the log length returned %d decks, want 2", len(got))
+	}
+}
+
+func TestFormatDeckSetArchetype(t *testing.T) {
+	if got := formatDeckSetArchetype("bridge_spam"); got != "Bridge Spam" {
+		t.Errorf("formatDeckSetArchetype() = %q", got)
+	}
+	if got := formatDeckSetArchetype(""); got != "Unknown" {
+		t.Errorf("formatDeckSetArchetype(\"\") = %q", got)
+	}
+}
@@ -297,7 +297,7 @@ func TestDeckBuildSuite(t *testing.T) {
 			ctx := context.Background()
 			args := []string{
 				"deck", "build-suite",
-				"--tag", "#TEST123",
+				"--tag", "#2PYQ98",
 				"--strategies", tt.strategies,
 				"--variations", string(rune(tt.variations)),
 				"--min-elixir", string(rune(int(tt.minElixir * 10))),
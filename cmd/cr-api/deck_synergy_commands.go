@@ -0,0 +1,421 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/sqlstore"
+	"github.com/klauer/clash-royale-api/go/internal/storage"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	synergyFileFlagName    = "synergy-file"
+	learnedSynergyFlagName = "learned-synergy"
+	synergyBlendFlagName   = "synergy-blend"
+)
+
+// synergyOverridesPath returns the user synergy overrides file for this
+// invocation: --synergy-file, or synergy_overrides.json in the data dir.
+func synergyOverridesPath(cmd *cli.Command) string {
+	if path := strings.TrimSpace(cmd.String(synergyFileFlagName)); path != "" {
+		return path
+	}
+	return filepath.Join(cmd.String("data-dir"), deck.SynergyOverridesFile)
+}
+
+// learnedSynergyPath returns the learned synergy overlay for this
+// invocation: --learned-synergy, or synergy_learned.json in the data dir.
+func learnedSynergyPath(cmd *cli.Command) string {
+	if path := strings.TrimSpace(cmd.String(learnedSynergyFlagName)); path != "" {
+		return path
+	}
+	return filepath.Join(cmd.String("data-dir"), deck.LearnedSynergyFile)
+}
+
+// configureSynergyOverrides points deck.NewSynergyDatabase at the user
+// overrides file and the learned overlay. Explicitly named files must exist;
+// a file that fails to parse only produces a warning so every other command
+// keeps working.
+func configureSynergyOverrides(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+	path := synergyOverridesPath(cmd)
+	if storage.FileExists(path) {
+		if _, err := deck.ReadSynergyFile(path); err != nil {
+			fprintf(os.Stderr, "Warning: ignoring synergy overrides: %v (check with `cr-api deck synergy validate`)\n", err)
+		}
+	} else if cmd.IsSet(synergyFileFlagName) {
+		return ctx, fmt.Errorf("synergy file not found: %s", path)
+	}
+	deck.SetSynergyOverridesPath(path)
+
+	blend := cmd.Float(synergyBlendFlagName)
+	if blend < 0 || blend > 1 {
+		return ctx, fmt.Errorf("--%s must be between 0 and 1", synergyBlendFlagName)
+	}
+	learnedPath := learnedSynergyPath(cmd)
+	if storage.FileExists(learnedPath) {
+		if _, err := deck.ReadLearnedSynergy(learnedPath); err != nil {
+			fprintf(os.Stderr, "Warning: ignoring learned synergy: %v (re-run `cr-api deck synergy learn`)\n", err)
+		}
+	} else if cmd.IsSet(learnedSynergyFlagName) {
+		return ctx, fmt.Errorf("learned synergy file not found: %s", learnedPath)
+	}
+	deck.SetLearnedSynergy(learnedPath, blend)
+	return ctx, nil
+}
+
+// addDeckSynergyCommand adds synergy database maintenance commands
+func addDeckSynergyCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "synergy",
+		Usage: "Inspect and maintain the synergy pair database",
+		Commands: []*cli.Command{
+			{
+				Name:  "validate",
+				Usage: "Lint a synergy pairs file and report unknown card names",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "file",
+						Usage: "Synergy file to check (default: the overrides file, --synergy-file or <data-dir>/synergy_overrides.json)",
+					},
+					&cli.BoolFlag{
+						Name:  "builtin",
+						Usage: "Check the built-in synergy pairs instead of a file",
+					},
+					&cli.BoolFlag{
+						Name:  "strict",
+						Usage: "Fail on warnings as well as errors",
+					},
+					&cli.StringFlag{
+						Name:  "format",
+						Value: batchFormatHuman,
+						Usage: "Output format: human, json",
+					},
+				},
+				Action: deckSynergyValidateCommand,
+			},
+			{
+				Name:  "learn",
+				Usage: "Learn synergy adjustments from win rates of card pairs in recorded battles",
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:  "days",
+						Value: 90,
+						Usage: "Only use battles from the last N days (0 = all history)",
+					},
+					&cli.StringSliceFlag{
+						Name:  "battle-types",
+						Value: []string{"PvP", "pathOfLegend"},
+						Usage: "Battle types to learn from (empty = all)",
+					},
+					&cli.IntFlag{
+						Name:  "min-games",
+						Value: deck.DefaultSynergyLearnOptions().MinGames,
+						Usage: "Fewest games a card pair needs to be learned",
+					},
+					&cli.FloatFlag{
+						Name:  "prior-games",
+						Value: deck.DefaultSynergyLearnOptions().PriorGames,
+						Usage: "Pseudo-games that shrink small samples toward the expected win rate",
+					},
+					&cli.IntFlag{
+						Name:  "top",
+						Value: 10,
+						Usage: "Number of strongest and weakest pairs to show",
+					},
+					&cli.StringFlag{
+						Name:  "output",
+						Usage: "Overlay file to write (default: --learned-synergy or <data-dir>/synergy_learned.json)",
+					},
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "Show what was learned without writing the overlay",
+					},
+				},
+				Action: deckSynergyLearnCommand,
+			},
+		},
+	}
+}
+
+func deckSynergyLearnCommand(ctx context.Context, cmd *cli.Command) error {
+	_ = ctx
+	opts := deck.SynergyLearnOptions{MinGames: cmd.Int("min-games"), PriorGames: cmd.Float("prior-games")}
+	if opts.MinGames < 1 {
+		return fmt.Errorf("--min-games must be at least 1")
+	}
+	if opts.PriorGames < 0 {
+		return fmt.Errorf("--prior-games cannot be negative")
+	}
+
+	var since time.Time
+	if days := cmd.Int("days"); days > 0 {
+		since = time.Now().AddDate(0, 0, -days)
+	}
+
+	db, err := openHistoryDB(cmd)
+	if err != nil {
+		return err
+	}
+	defer closeFile(db)
+	battles, err := db.Battles(since)
+	if err != nil {
+		return err
+	}
+
+	outcomes, used := battleDeckOutcomes(battles, cmd.StringSlice("battle-types"))
+	if used == 0 {
+		return fmt.Errorf("no decided battles recorded in %s (record some with `cr-api db sync`)", db.Path())
+	}
+	learned := deck.LearnSynergy(outcomes, opts)
+
+	printf("%s", formatLearnedSynergy(learned, used, cmd.Int("top")))
+	if cmd.Bool("dry-run") {
+		return nil
+	}
+
+	path := strings.TrimSpace(cmd.String("output"))
+	if path == "" {
+		path = learnedSynergyPath(cmd)
+	}
+	if err := storage.WriteJSON(path, learned); err != nil {
+		return fmt.Errorf("failed to write learned synergy: %w", err)
+	}
+	printf("\nLearned synergy saved to: %s (blended at --%s %.2f)\n", path, synergyBlendFlagName, cmd.Float(synergyBlendFlagName))
+	return nil
+}
+
+// battleDeckOutcomes turns recorded battles into one outcome per deck,
+// skipping draws, other battle types, and the second copy of battles recorded
+// from both players' logs. It returns the outcomes and how many battles were used.
+func battleDeckOutcomes(battles []sqlstore.StoredBattle, battleTypes []string) ([]deck.DeckOutcome, int) {
+	decided := decidedBattles(battles, battleTypes)
+	outcomes := make([]deck.DeckOutcome, 0, 2*len(decided))
+	for _, battle := range decided {
+		won := battle.Crowns > battle.OpponentCrowns
+		outcomes = append(outcomes,
+			deck.DeckOutcome{Cards: battle.Deck, Won: won},
+			deck.DeckOutcome{Cards: battle.OpponentDeck, Won: !won},
+		)
+	}
+	return outcomes, len(decided)
+}
+
+// decidedBattles filters recorded battles down to those of battleTypes (all
+// when empty) that were not draws, keeping one copy of battles recorded from
+// both players' logs.
+func decidedBattles(battles []sqlstore.StoredBattle, battleTypes []string) []sqlstore.StoredBattle {
+	allowed := make(map[string]bool, len(battleTypes))
+	for _, battleType := range battleTypes {
+		if battleType = strings.TrimSpace(battleType); battleType != "" {
+			allowed[strings.ToLower(battleType)] = true
+		}
+	}
+
+	seen := make(map[string]bool, len(battles))
+	decided := make([]sqlstore.StoredBattle, 0, len(battles))
+	for _, battle := range battles {
+		if len(allowed) > 0 && !allowed[strings.ToLower(battle.Type)] {
+			continue
+		}
+		if battle.Crowns == battle.OpponentCrowns {
+			continue
+		}
+		tags := []string{battle.PlayerTag, clashroyale.NormalizeTag(battle.OpponentTag)}
+		slices.Sort(tags)
+		key := battle.Time.UTC().Format(time.RFC3339) + "|" + strings.Join(tags, "|")
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		decided = append(decided, battle)
+	}
+	return decided
+}
+
+func formatLearnedSynergy(learned *deck.LearnedSynergy, battles, top int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Learned from %d battles (%d decks): %d card pairs with at least %d games\n",
+		battles, learned.Decks, len(learned.Pairs), learned.MinGames)
+	if len(learned.Pairs) == 0 || top <= 0 {
+		return b.String()
+	}
+
+	writePairs := func(title string, pairs []deck.LearnedSynergyPair) {
+		fmt.Fprintf(&b, "\n%s\n", title)
+		w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+		fprintln(w, "  Pair\tGames\tWin Rate\tExpected\tAdjustment")
+		for _, pair := range pairs {
+			fprintf(w, "  %s + %s\t%d\t%.1f%%\t%.1f%%\t%+.2f\n",
+				pair.Card1, pair.Card2, pair.Games, pair.WinRate*100, pair.ExpectedWinRate*100, pair.Adjustment)
+		}
+		flushWriter(w)
+	}
+
+	n := min(top, len(learned.Pairs))
+	writePairs("Strongest pairs:", learned.Pairs[:n])
+	var weakest []deck.LearnedSynergyPair
+	for i := len(learned.Pairs) - 1; i >= 0 && len(weakest) < n; i-- {
+		if learned.Pairs[i].Adjustment >= 0 {
+			break
+		}
+		weakest = append(weakest, learned.Pairs[i])
+	}
+	if len(weakest) > 0 {
+		writePairs("Weakest pairs:", weakest)
+	}
+	return b.String()
+}
+
+// synergyValidation is the result of linting one synergy file
+type synergyValidation struct {
+	File     string                  `json:"file"`
+	Pairs    int                     `json:"pairs"`
+	Removes  int                     `json:"removes,omitempty"`
+	Issues   []deck.SynergyIssue     `json:"issues"`
+	Errors   int                     `json:"errors"`
+	Warnings int                     `json:"warnings"`
+	Merge    *deck.SynergyMergeStats `json:"merge,omitempty"`
+	// CardsUnchecked explains why card names were not checked, if they were not
+	CardsUnchecked string `json:"cards_unchecked,omitempty"`
+}
+
+func deckSynergyValidateCommand(ctx context.Context, cmd *cli.Command) error {
+	format := strings.ToLower(strings.TrimSpace(cmd.String("format")))
+	if format != batchFormatHuman && format != batchFormatJSON {
+		return fmt.Errorf("unknown format: %s (supported: human, json)", format)
+	}
+
+	var file *deck.SynergyFile
+	path := "built-in synergy pairs"
+	builtin := cmd.Bool("builtin")
+	if builtin {
+		file = deck.DefaultSynergyFile()
+	} else {
+		path = strings.TrimSpace(cmd.String("file"))
+		if path == "" {
+			path = synergyOverridesPath(cmd)
+		}
+		var err error
+		if file, err = deck.ReadSynergyFile(path); err != nil {
+			return err
+		}
+	}
+
+	var cardNames []string
+	cards, cardsErr := loadStaticCards(ctx, cmd.String("data-dir"), cmd.String("api-token"), cmd.Bool("verbose"))
+	if cardsErr == nil {
+		cardNames = staticCardNames(cards)
+	}
+
+	result := validateSynergyFile(path, file, cardNames, !builtin)
+	if cardsErr != nil {
+		result.CardsUnchecked = cardsErr.Error()
+	}
+
+	if format == batchFormatJSON {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal validation result: %w", err)
+		}
+		printf("%s\n", data)
+	} else {
+		printf("%s", formatSynergyValidation(result))
+	}
+
+	if result.Errors > 0 || (cmd.Bool("strict") && result.Warnings > 0) {
+		return fmt.Errorf("%s failed validation: %d errors, %d warnings", path, result.Errors, result.Warnings)
+	}
+	return nil
+}
+
+// staticCardNames lists the names in the card database
+func staticCardNames(cards []clashroyale.Card) []string {
+	names := make([]string, 0, len(cards))
+	for _, card := range cards {
+		if card.Name != "" {
+			names = append(names, card.Name)
+		}
+	}
+	return names
+}
+
+// validateSynergyFile lints file against cardNames (skipped when empty). For
+// an overrides file it also reports how it changes the built-in pairs and
+// flags removals that match no built-in pair.
+func validateSynergyFile(path string, file *deck.SynergyFile, cardNames []string, overrides bool) synergyValidation {
+	var knownCard func(string) bool
+	if len(cardNames) > 0 {
+		known := make(map[string]bool, len(cardNames))
+		for _, name := range cardNames {
+			known[name] = true
+		}
+		knownCard = func(name string) bool { return known[name] }
+	}
+
+	result := synergyValidation{
+		File:    path,
+		Pairs:   len(file.Pairs),
+		Removes: len(file.Remove),
+		Issues:  file.Validate(knownCard),
+	}
+	if overrides {
+		builtin := deck.DefaultSynergyFile()
+		builtinDB := deck.SynergyDatabase{Pairs: builtin.Pairs}
+		for i, ref := range file.Remove {
+			if ref.Card1 != "" && ref.Card2 != "" && builtinDB.GetSynergyPair(ref.Card1, ref.Card2) == nil {
+				result.Issues = append(result.Issues, deck.SynergyIssue{
+					Location: fmt.Sprintf("remove[%d]", i),
+					Warning:  true,
+					Message:  fmt.Sprintf("no built-in %s + %s pair to remove", ref.Card1, ref.Card2),
+				})
+			}
+		}
+		_, stats := file.Apply(builtin.Pairs)
+		result.Merge = &stats
+	}
+
+	for _, issue := range result.Issues {
+		if issue.Warning {
+			result.Warnings++
+		} else {
+			result.Errors++
+		}
+	}
+	return result
+}
+
+func formatSynergyValidation(result synergyValidation) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Synergy file: %s\n", result.File)
+	fmt.Fprintf(&b, "Pairs: %d", result.Pairs)
+	if result.Removes > 0 {
+		fmt.Fprintf(&b, ", removals: %d", result.Removes)
+	}
+	b.WriteString("\n")
+	if result.Merge != nil {
+		fmt.Fprintf(&b, "Against built-in pairs: %d replaced, %d added, %d removed\n",
+			result.Merge.Replaced, result.Merge.Added, result.Merge.Removed)
+	}
+	if result.CardsUnchecked != "" {
+		fmt.Fprintf(&b, "Card names not checked: %s\n", result.CardsUnchecked)
+	}
+
+	if len(result.Issues) > 0 {
+		b.WriteString("\n")
+		for _, issue := range result.Issues {
+			fmt.Fprintf(&b, "  %s\n", issue)
+		}
+	}
+	fmt.Fprintf(&b, "\n%d errors, %d warnings\n", result.Errors, result.Warnings)
+	return b.String()
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/sqlstore"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+func TestValidateSynergyFile(t *testing.T) {
+	file := &deck.SynergyFile{
+		Pairs: []deck.SynergyPair{
+			{Card1: "Giant", Card2: "Witch", SynergyType: deck.SynergyTankSupport, Score: 0.5},
+			{Card1: "Hog Rider", Card2: "Ice Golum", SynergyType: deck.SynergyWinCondition, Score: 0.8},
+		},
+		Remove: []deck.SynergyPairRef{{Card1: "Knight", Card2: "Archers"}},
+	}
+	cards := []string{"Giant", "Witch", "Hog Rider", "Ice Golem", "Knight", "Archers"}
+
+	result := validateSynergyFile("overrides.json", file, cards, true)
+	if result.Errors != 1 || result.Warnings != 1 {
+		t.Fatalf("errors=%d warnings=%d, want 1 and 1: %+v", result.Errors, result.Warnings, result.Issues)
+	}
+	if result.Merge == nil || result.Merge.Replaced != 1 || result.Merge.Added != 1 {
+		t.Errorf("merge = %+v, want 1 replaced and 1 added", result.Merge)
+	}
+
+	out := formatSynergyValidation(result)
+	for _, want := range []string{
+		`error: pairs[1]: unknown card "Ice Golum"`,
+		"warning: remove[0]: no built-in Knight + Archers pair to remove",
+		"Against built-in pairs: 1 replaced, 1 added, 0 removed",
+		"1 errors, 1 warnings",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	unchecked := validateSynergyFile("overrides.json", file, nil, false)
+	if unchecked.Errors != 0 || unchecked.Merge != nil {
+		t.Errorf("without card names: errors=%d merge=%v, want no errors and no merge", unchecked.Errors, unchecked.Merge)
+	}
+}
+
+func TestBattleDeckOutcomes(t *testing.T) {
+	at := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	hog := []string{"Hog Rider", "Ice Golem"}
+	golem := []string{"Golem", "Night Witch"}
+	battles := []sqlstore.StoredBattle{
+		{PlayerTag: "#AAA", OpponentTag: "#BBB", Time: at, Type: "PvP", Crowns: 2, OpponentCrowns: 1, Deck: hog, OpponentDeck: golem},
+		// The same battle recorded from the opponent's log
+		{PlayerTag: "#BBB", OpponentTag: "#AAA", Time: at, Type: "PvP", Crowns: 1, OpponentCrowns: 2, Deck: golem, OpponentDeck: hog},
+		{PlayerTag: "#AAA", OpponentTag: "#CCC", Time: at.Add(time.Hour), Type: "PvP", Crowns: 1, OpponentCrowns: 1, Deck: hog, OpponentDeck: golem},
+		{PlayerTag: "#AAA", OpponentTag: "#DDD", Time: at.Add(2 * time.Hour), Type: "friendly", Crowns: 3, Deck: hog, OpponentDeck: golem},
+	}
+
+	outcomes, used := battleDeckOutcomes(battles, []string{"pvp"})
+	if used != 1 || len(outcomes) != 2 {
+		t.Fatalf("used %d battles, %d outcomes; want 1 and 2", used, len(outcomes))
+	}
+	if !outcomes[0].Won || outcomes[0].Cards[0] != "Hog Rider" || outcomes[1].Won {
+		t.Errorf("outcomes = %+v, want the Hog deck winning", outcomes)
+	}
+
+	if _, all := battleDeckOutcomes(battles, nil); all != 2 {
+		t.Errorf("with no type filter used %d battles, want 2 (draw skipped)", all)
+	}
+}
+
+func TestFormatLearnedSynergy(t *testing.T) {
+	learned := &deck.LearnedSynergy{Decks: 40, MinGames: 5, Pairs: []deck.LearnedSynergyPair{
+		{Card1: "Hog Rider", Card2: "Ice Golem", Games: 12, WinRate: 0.75, ExpectedWinRate: 0.5, Adjustment: 0.6},
+		{Card1: "Golem", Card2: "Zap", Games: 8, WinRate: 0.25, ExpectedWinRate: 0.5, Adjustment: -0.4},
+	}}
+
+	out := formatLearnedSynergy(learned, 20, 5)
+	for _, want := range []string{
+		"Learned from 20 battles (40 decks): 2 card pairs with at least 5 games",
+		"Hog Rider + Ice Golem  12     75.0%",
+		"Weakest pairs:",
+		"Golem + Zap",
+		"-0.40",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
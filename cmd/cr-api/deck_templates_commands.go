@@ -0,0 +1,312 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/templates"
+	"github.com/urfave/cli/v3"
+)
+
+// addDeckTemplatesCommand adds the deck templates command group
+func addDeckTemplatesCommand() *cli.Command {
+	formatFlag := func() cli.Flag {
+		return &cli.StringFlag{Name: "format", Value: batchFormatHuman, Usage: "Output format: human, json"}
+	}
+	templatesFileFlag := func() cli.Flag {
+		return &cli.StringFlag{Name: "templates-file", Usage: "Custom template library JSON (default: built-in library)"}
+	}
+	return &cli.Command{
+		Name:  "templates",
+		Usage: "Proven deck skeletons with flex slots filled from your collection",
+		Commands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "List the deck templates and their flex slots",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "archetype", Usage: "Only list templates of this archetype"},
+					templatesFileFlag(),
+					formatFlag(),
+				},
+				Action: deckTemplatesListCommand,
+			},
+			{
+				Name:  "fill",
+				Usage: "Fill template flex slots from a player's collection",
+				Flags: []cli.Flag{
+					playerTagFlagWithUsage(true, "Player tag whose collection fills the flex slots"),
+					&cli.StringSliceFlag{Name: "template", Usage: "Template ids to fill (default: all templates)"},
+					&cli.StringFlag{Name: "mode", Value: string(templates.FillBest), Usage: "Fill mode: best (one deck per template), variants (several flex choices)"},
+					&cli.IntFlag{Name: "limit", Value: 5, Usage: "Decks per template in variants mode"},
+					templatesFileFlag(),
+					formatFlag(),
+					&cli.StringFlag{Name: "output", Usage: "Output file path (optional, prints to stdout if not specified)"},
+				},
+				Action: deckTemplatesFillCommand,
+			},
+		},
+	}
+}
+
+func loadTemplateLibrary(path string) (*templates.Library, error) {
+	if path != "" {
+		return templates.Load(path)
+	}
+	return templates.Default()
+}
+
+func parseTemplateFormat(cmd *cli.Command) (string, error) {
+	format := strings.ToLower(strings.TrimSpace(cmd.String("format")))
+	if format != "" && format != batchFormatHuman && format != batchFormatJSON {
+		return "", fmt.Errorf("unknown format: %s (supported: human, json)", format)
+	}
+	return format, nil
+}
+
+func deckTemplatesListCommand(_ context.Context, cmd *cli.Command) error {
+	format, err := parseTemplateFormat(cmd)
+	if err != nil {
+		return err
+	}
+	lib, err := loadTemplateLibrary(cmd.String("templates-file"))
+	if err != nil {
+		return err
+	}
+
+	archetype := strings.ToLower(strings.TrimSpace(cmd.String("archetype")))
+	list := make([]templates.Template, 0, len(lib.Templates))
+	for _, t := range lib.Templates {
+		if archetype == "" || strings.EqualFold(string(t.Archetype), archetype) {
+			list = append(list, t)
+		}
+	}
+
+	if format == batchFormatJSON {
+		data, err := json.MarshalIndent(list, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	fmt.Print(formatTemplateListHuman(list))
+	return nil
+}
+
+func formatTemplateListHuman(list []templates.Template) string {
+	var buf bytes.Buffer
+	if len(list) == 0 {
+		fprintf(&buf, "No templates found\n")
+		return buf.String()
+	}
+	for _, t := range list {
+		fprintf(&buf, "\n%s - %s (%s)\n", t.ID, t.Name, t.Archetype)
+		fprintf(&buf, "  %s\n", t.Description)
+		fprintf(&buf, "  Core: %s\n", strings.Join(t.Core, " - "))
+		for i, slot := range t.Flex {
+			fprintf(&buf, "  Flex %d: %s\n", i+1, describeFlexSlot(slot))
+		}
+	}
+	return buf.String()
+}
+
+// describeFlexSlot summarizes a flex slot, e.g.
+// "cheap spell: The Log / Zap (or any spells_small)"
+func describeFlexSlot(slot templates.FlexSlot) string {
+	text := strings.Join(slot.Options, " / ")
+	if slot.Role != "" {
+		fallback := "any " + string(slot.Role)
+		if slot.MaxElixir > 0 {
+			fallback += fmt.Sprintf(" up to %d elixir", slot.MaxElixir)
+		}
+		if text == "" {
+			text = fallback
+		} else {
+			text += " (or " + fallback + ")"
+		}
+	}
+	if slot.Note != "" {
+		text = slot.Note + ": " + text
+	}
+	return text
+}
+
+// templateDeckEntry is a filled deck with its evaluation
+type templateDeckEntry struct {
+	templates.FilledDeck
+	OverallScore  float64           `json:"overall_score"`
+	OverallRating evaluation.Rating `json:"overall_rating"`
+	AvgElixir     float64           `json:"average_elixir"`
+}
+
+// templateFillEntry is the outcome of filling one template
+type templateFillEntry struct {
+	Template    templates.Template  `json:"template"`
+	Decks       []templateDeckEntry `json:"decks"`
+	MissingCore []string            `json:"missing_core,omitempty"`
+	EmptySlots  []int               `json:"empty_slots,omitempty"`
+}
+
+// templateFillOutput is the JSON output of deck templates fill
+type templateFillOutput struct {
+	PlayerName string              `json:"player_name"`
+	PlayerTag  string              `json:"player_tag"`
+	Mode       templates.FillMode  `json:"mode"`
+	Results    []templateFillEntry `json:"results"`
+}
+
+func deckTemplatesFillCommand(ctx context.Context, cmd *cli.Command) error {
+	format, err := parseTemplateFormat(cmd)
+	if err != nil {
+		return err
+	}
+	mode, err := templates.ParseFillMode(cmd.String("mode"))
+	if err != nil {
+		return err
+	}
+	lib, err := loadTemplateLibrary(cmd.String("templates-file"))
+	if err != nil {
+		return err
+	}
+	selected, err := selectTemplates(lib, cmd.StringSlice("template"))
+	if err != nil {
+		return err
+	}
+
+	client, err := requireAPIClient(cmd, apiClientOptions{})
+	if err != nil {
+		return err
+	}
+	player, err := client.GetPlayerWithContext(ctx, cmd.String("tag"))
+	if err != nil {
+		return fmt.Errorf("failed to get player data: %w", err)
+	}
+
+	output, err := fillTemplates(player, selected, templates.FillOptions{Mode: mode, Limit: cmd.Int("limit")})
+	if err != nil {
+		return err
+	}
+
+	var formatted string
+	if format == batchFormatJSON {
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		formatted = string(data) + "\n"
+	} else {
+		formatted = formatTemplateFillHuman(output)
+	}
+
+	return writeTextOutput(formatted, cmd.String("output"), textOutputOptions{
+		saveMessage: "Template decks saved to",
+		verboseOnly: true,
+		verbose:     cmd.Bool("verbose"),
+	})
+}
+
+// selectTemplates returns the templates with the given ids, or every template
+// when ids is empty
+func selectTemplates(lib *templates.Library, ids []string) ([]templates.Template, error) {
+	if len(ids) == 0 {
+		return lib.Templates, nil
+	}
+	selected := make([]templates.Template, 0, len(ids))
+	for _, id := range ids {
+		t, ok := lib.Find(strings.TrimSpace(id))
+		if !ok {
+			return nil, fmt.Errorf("unknown template %q (see 'deck templates list')", id)
+		}
+		selected = append(selected, t)
+	}
+	return selected, nil
+}
+
+// fillTemplates fills each template from the player's collection and scores
+// the resulting decks with the player's card levels
+func fillTemplates(player *clashroyale.Player, list []templates.Template, opts templates.FillOptions) (*templateFillOutput, error) {
+	collection, err := buildGeneticCandidates(player, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if opts.SynergyDB == nil {
+		opts.SynergyDB = deck.NewSynergyDatabase()
+	}
+	playerContext := evaluation.NewPlayerContextFromPlayer(player)
+
+	output := &templateFillOutput{
+		PlayerName: player.Name,
+		PlayerTag:  player.Tag,
+		Mode:       opts.Mode,
+		Results:    make([]templateFillEntry, 0, len(list)),
+	}
+	if output.Mode == "" {
+		output.Mode = templates.FillBest
+	}
+	for _, t := range list {
+		result := templates.Fill(t, collection, opts)
+		entry := templateFillEntry{
+			Template:    t,
+			Decks:       make([]templateDeckEntry, 0, len(result.Decks)),
+			MissingCore: result.MissingCore,
+			EmptySlots:  result.EmptySlots,
+		}
+		for _, filled := range result.Decks {
+			eval := evaluation.Evaluate(convertDeckToCandidates(filled.Cards, player), opts.SynergyDB, playerContext)
+			entry.Decks = append(entry.Decks, templateDeckEntry{
+				FilledDeck:    filled,
+				OverallScore:  eval.OverallScore,
+				OverallRating: eval.OverallRating,
+				AvgElixir:     eval.AvgElixir,
+			})
+		}
+		output.Results = append(output.Results, entry)
+	}
+	return output, nil
+}
+
+func formatTemplateFillHuman(output *templateFillOutput) string {
+	var buf bytes.Buffer
+	fprintf(&buf, "\nDeck Templates for %s (%s)\n", output.PlayerName, output.PlayerTag)
+	fprintf(&buf, "========================\n")
+
+	for _, entry := range output.Results {
+		fprintf(&buf, "\n%s - %s (%s)\n", entry.Template.ID, entry.Template.Name, entry.Template.Archetype)
+		if len(entry.MissingCore) > 0 {
+			fprintf(&buf, "  Missing core cards: %s\n", strings.Join(entry.MissingCore, ", "))
+		}
+		for _, slot := range entry.EmptySlots {
+			fprintf(&buf, "  No owned card for flex %d: %s\n", slot, describeFlexSlot(entry.Template.Flex[slot-1]))
+		}
+		if len(entry.Decks) == 0 {
+			if len(entry.MissingCore) == 0 && len(entry.EmptySlots) == 0 {
+				fprintf(&buf, "  Flex slots share too few owned cards to fill every slot\n")
+			}
+			continue
+		}
+
+		w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+		fprintf(w, "  #\tScore\tRating\tElixir\tFlex Picks\n")
+		for i, d := range entry.Decks {
+			picks := make([]string, len(d.Flex))
+			for j, choice := range d.Flex {
+				picks[j] = choice.Card
+				if !choice.Preferred {
+					picks[j] += "*"
+				}
+			}
+			fprintf(w, "  %d\t%.2f\t%s\t%.1f\t%s\n", i+1, d.OverallScore, d.OverallRating, d.AvgElixir, strings.Join(picks, ", "))
+		}
+		flushWriter(w)
+		fprintf(&buf, "  Best deck: %s\n", strings.Join(entry.Decks[0].Cards, " - "))
+	}
+	fprintf(&buf, "\n* not one of the template's listed options\n")
+	return buf.String()
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/templates"
+)
+
+func TestFillTemplatesReportsDecksAndMissingCards(t *testing.T) {
+	lib, err := templates.Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+	selected, err := selectTemplates(lib, []string{"hog-2.6", "log-bait"})
+	if err != nil {
+		t.Fatalf("selectTemplates() error = %v", err)
+	}
+	if _, err := selectTemplates(lib, []string{"nope"}); err == nil {
+		t.Error("expected an error for an unknown template")
+	}
+
+	player := &clashroyale.Player{Name: "Tester", Tag: "#TEST"}
+	for _, name := range []string{"Hog Rider", "Musketeer", "Cannon", "Ice Golem", "Ice Spirit", "Skeletons", "The Log", "Fireball"} {
+		player.Cards = append(player.Cards, clashroyale.Card{Name: name, Level: 14, MaxLevel: 16})
+	}
+
+	output, err := fillTemplates(player, selected, templates.FillOptions{})
+	if err != nil {
+		t.Fatalf("fillTemplates() error = %v", err)
+	}
+	if len(output.Results) != 2 {
+		t.Fatalf("got %d results, want 2", len(output.Results))
+	}
+	hog, bait := output.Results[0], output.Results[1]
+	if len(hog.Decks) != 1 || hog.Decks[0].OverallScore <= 0 {
+		t.Errorf("hog-2.6 decks = %+v, want one scored deck", hog.Decks)
+	}
+	if len(bait.Decks) != 0 || len(bait.MissingCore) == 0 {
+		t.Errorf("log-bait = %+v, want missing core cards", bait)
+	}
+
+	human := formatTemplateFillHuman(output)
+	for _, want := range []string{"hog-2.6", "Best deck: Hog Rider", "Missing core cards: Goblin Barrel"} {
+		if !strings.Contains(human, want) {
+			t.Errorf("human output missing %q:\n%s", want, human)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
 	"github.com/urfave/cli/v3"
 )
 
@@ -24,6 +25,35 @@ func addDeckWarCommand() *cli.Command {
 	}
 }
 
+// addDeckSetCommand adds the deck set command
+func addDeckSetCommand() *cli.Command {
+	flags := []cli.Flag{
+		playerTagFlag(true),
+		&cli.IntFlag{Name: "count", Value: 4, Usage: "Number of decks in the set (2 for duels, 4 for war)"},
+		&cli.Float64Flag{Name: "balance", Value: deck.DefaultDeckSetBalance, Usage: "Weight (0-1) of the weakest deck in the set score; 0 optimizes the average only"},
+		&cli.StringFlag{Name: strategyFlagName, Aliases: []string{"s"}, Value: optimizeFocusBalanced, Usage: "Deck building strategy: balanced, aggro, control, cycle, splash, spell, synergy-first"},
+		&cli.StringSliceFlag{Name: includeCardsFlagName, Usage: "Cards that must appear somewhere in the set (by name)"},
+		&cli.StringSliceFlag{Name: excludeCardsFlagName, Usage: "Cards to leave out of every deck (by name)"},
+		&cli.BoolFlag{Name: "json", Usage: "Output the deck set in JSON format"},
+		&cli.BoolFlag{Name: "duel", Usage: "Duel mode: spread archetypes and cover each deck's weaknesses with another deck"},
+		&cli.IntFlag{Name: "faced-battles", Value: 25, Usage: "Duel mode: check the set against archetypes from this many recent battles (0 to skip)"},
+		&cli.StringFlag{Name: "counters-file", Usage: "Counters database JSON for the faced-archetype check (default: built-in)"},
+	}
+	flags = append(flags, cardPoolFlags()...)
+	flags = append(flags, deckSharedBuilderFlags()...)
+	flags = append(flags,
+		&cli.BoolFlag{Name: fromAnalysisFlagName, Aliases: []string{"a"}, Usage: "Enable offline mode: load analysis from JSON file instead of fetching from API"},
+		&cli.StringFlag{Name: analysisDirFlagName, Usage: "Directory containing analysis JSON files (default: data/analysis)"},
+		&cli.StringFlag{Name: analysisFileFlagName, Usage: "Specific analysis file path (overrides --analysis-dir lookup)"},
+	)
+	return &cli.Command{
+		Name:   "set",
+		Usage:  "Build several decks at once with no shared cards (Classic Challenge duels, war)",
+		Flags:  flags,
+		Action: deckSetCommand,
+	}
+}
+
 // addDeckMulliganCommand adds the deck mulligan command
 func addDeckMulliganCommand() *cli.Command {
 	return &cli.Command{
@@ -149,10 +179,10 @@ func addDeckResearchEvalCommand() *cli.Command {
 		Name:  "research-eval",
 		Usage: "Benchmark archetype-free deck-building methods against baseline",
 		Flags: []cli.Flag{
-			&cli.StringSliceFlag{
+			tagsFlag(&cli.StringSliceFlag{
 				Name:  "tags",
 				Usage: "Player tags to benchmark (without #). If omitted, uses phase-1 defaults",
-			},
+			}),
 			&cli.StringFlag{
 				Name:  "methods",
 				Value: "baseline,genetic,constraint,role-first",
@@ -176,7 +206,7 @@ func addDeckResearchEvalCommand() *cli.Command {
 			&cli.StringFlag{
 				Name:  "data-dir",
 				Value: "data",
-				Usage: "Directory containing card metadata (cards_stats.json)",
+				Usage: "Directory with an optional cards_stats.json overriding the bundled combat stats",
 			},
 			&cli.IntFlag{
 				Name:  "min-wincons",
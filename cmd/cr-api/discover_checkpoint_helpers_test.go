@@ -13,19 +13,19 @@ import (
 func TestDiscoverPlayerTagFromValue(t *testing.T) {
 	t.Parallel()
 
-	got, err := discoverPlayerTagFromValue("#P2ABC")
+	got, err := discoverPlayerTagFromValue("#P2QRC")
 	if err != nil {
 		t.Fatalf("discoverPlayerTagFromValue() error = %v", err)
 	}
 
-	if got.input != "#P2ABC" {
-		t.Fatalf("input = %q, want %q", got.input, "#P2ABC")
+	if got.input != "#P2QRC" {
+		t.Fatalf("input = %q, want %q", got.input, "#P2QRC")
 	}
-	if got.sanitized != "P2ABC" {
-		t.Fatalf("sanitized = %q, want %q", got.sanitized, "P2ABC")
+	if got.sanitized != "P2QRC" {
+		t.Fatalf("sanitized = %q, want %q", got.sanitized, "P2QRC")
 	}
-	if got.canonical != "#P2ABC" {
-		t.Fatalf("canonical = %q, want %q", got.canonical, "#P2ABC")
+	if got.canonical != "#P2QRC" {
+		t.Fatalf("canonical = %q, want %q", got.canonical, "#P2QRC")
 	}
 }
 
@@ -33,7 +33,7 @@ func TestLoadDiscoverCheckpointState(t *testing.T) {
 	homeDir := t.TempDir()
 	t.Setenv("HOME", homeDir)
 
-	checkpointPath := discoverCheckpointPath("P2ABC")
+	checkpointPath := discoverCheckpointPath("P2QRC")
 	if err := os.MkdirAll(filepath.Dir(checkpointPath), 0o755); err != nil {
 		t.Fatalf("MkdirAll() error = %v", err)
 	}
@@ -44,7 +44,7 @@ func TestLoadDiscoverCheckpointState(t *testing.T) {
 			Position:  7,
 			Generated: 9,
 		},
-		PlayerTag: "#P2ABC",
+		PlayerTag: "#P2QRC",
 		Strategy:  deck.StrategySmartSample,
 		Timestamp: time.Unix(1234, 0),
 	}
@@ -52,16 +52,16 @@ func TestLoadDiscoverCheckpointState(t *testing.T) {
 		t.Fatalf("SaveDiscoveryCheckpoint() error = %v", err)
 	}
 
-	got, err := loadDiscoverCheckpointState("P2ABC", "missing ", "")
+	got, err := loadDiscoverCheckpointState("P2QRC", "missing ", "")
 	if err != nil {
 		t.Fatalf("loadDiscoverCheckpointState() error = %v", err)
 	}
 
-	if got.tag.sanitized != "P2ABC" {
-		t.Fatalf("tag.sanitized = %q, want %q", got.tag.sanitized, "P2ABC")
+	if got.tag.sanitized != "P2QRC" {
+		t.Fatalf("tag.sanitized = %q, want %q", got.tag.sanitized, "P2QRC")
 	}
-	if got.tag.canonical != "#P2ABC" {
-		t.Fatalf("tag.canonical = %q, want %q", got.tag.canonical, "#P2ABC")
+	if got.tag.canonical != "#P2QRC" {
+		t.Fatalf("tag.canonical = %q, want %q", got.tag.canonical, "#P2QRC")
 	}
 	if got.checkpointPath != checkpointPath {
 		t.Fatalf("checkpointPath = %q, want %q", got.checkpointPath, checkpointPath)
@@ -74,18 +74,18 @@ func TestLoadDiscoverCheckpointState(t *testing.T) {
 func TestLoadDiscoverCheckpointStateNoCheckpoint(t *testing.T) {
 	t.Setenv("HOME", t.TempDir())
 
-	_, err := loadDiscoverCheckpointState("P2ABC", "missing player #", "")
+	_, err := loadDiscoverCheckpointState("P2QRC", "missing player #", "")
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
-	if err.Error() != "missing player #P2ABC" {
-		t.Fatalf("error = %q, want %q", err.Error(), "missing player #P2ABC")
+	if err.Error() != "missing player #P2QRC" {
+		t.Fatalf("error = %q, want %q", err.Error(), "missing player #P2QRC")
 	}
 }
 
 func TestLoadDiscoverCheckpointStateInvalidCheckpoint(t *testing.T) {
 	t.Setenv("HOME", t.TempDir())
-	checkpointPath := discoverCheckpointPath("P2ABC")
+	checkpointPath := discoverCheckpointPath("P2QRC")
 	if err := os.MkdirAll(filepath.Dir(checkpointPath), 0o755); err != nil {
 		t.Fatalf("MkdirAll() error = %v", err)
 	}
@@ -93,7 +93,7 @@ func TestLoadDiscoverCheckpointStateInvalidCheckpoint(t *testing.T) {
 		t.Fatalf("WriteFile() error = %v", err)
 	}
 
-	_, err := loadDiscoverCheckpointState("P2ABC", "missing ", "")
+	_, err := loadDiscoverCheckpointState("P2QRC", "missing ", "")
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
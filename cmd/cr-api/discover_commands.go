@@ -85,7 +85,7 @@ func (e *simpleEvaluator) Evaluate(deckCards []string) (*leaderboard.DeckEntry,
 		AvgElixir:         avgElixir,
 		EvaluatedAt:       time.Now(),
 		PlayerTag:         e.playerTag,
-		EvaluationVersion: "1.0",
+		EvaluationVersion: evaluation.CurrentScoringVersion,
 	}
 
 	return entry, nil
@@ -95,9 +95,7 @@ func (e *simpleEvaluator) resolveCandidates(cardNames []string) ([]deck.CardCand
 	candidates := make([]deck.CardCandidate, 0, len(cardNames))
 
 	for _, name := range cardNames {
-		// Get card stats
-		stats := e.cardRegistry.GetStats(name)
-		if stats == nil {
+		if e.cardRegistry.GetStats(name) == nil {
 			return nil, fmt.Errorf("card not found: %s", name)
 		}
 
@@ -140,8 +138,8 @@ func (e *simpleEvaluator) resolveCandidates(cardNames []string) ([]deck.CardCand
 			HasEvolution:      hasEvolution,
 			EvolutionLevel:    evolutionLevel,
 			MaxEvolutionLevel: levelInfo.MaxEvolutionLevel,
-			Stats:             stats,
 		}
+		candidate.Stats = deck.CombatStatsFor(e.cardRegistry, name, candidate.StandardLevel())
 
 		candidates = append(candidates, candidate)
 	}
@@ -398,9 +396,9 @@ func initializeDiscoveryResources(ctx context.Context, playerTag string, verbose
 
 	// Load card stats
 	statsPath := datapath.AppPathOrFallback("cards_stats.json")
-	statsRegistry, err := clashroyale.LoadStats(statsPath)
+	statsRegistry, err := clashroyale.LoadStatsWithDefaults(statsPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load card stats: %w", err)
+		fprintf(os.Stderr, "Warning: ignoring card stats at %s: %v\n", statsPath, err)
 	}
 
 	// Create player context
@@ -41,14 +41,14 @@ func TestBuildDiscoverRunArgs(t *testing.T) {
 	cmd := &cli.Command{
 		Flags: discoverRunFlags(true),
 		Action: func(_ context.Context, cmd *cli.Command) error {
-			got = buildDiscoverRunArgs(cmd, "P2ABC", false)
+			got = buildDiscoverRunArgs(cmd, "P2QRC", false)
 			return nil
 		},
 	}
 
 	err := cmd.Run(context.Background(), []string{
 		"discover-test",
-		"--tag=P2ABC",
+		"--tag=P2QRC",
 		"--strategy=genetic",
 		"--sample-size=777",
 		"--generations=12",
@@ -68,7 +68,7 @@ func TestBuildDiscoverRunArgs(t *testing.T) {
 
 	want := []string{
 		"deck", "discover", "run",
-		"--tag=#P2ABC",
+		"--tag=#P2QRC",
 		"--strategy=genetic",
 		"--sample-size=777",
 		"--generations=12",
@@ -95,14 +95,14 @@ func TestBuildDiscoverRunArgsResumeBackground(t *testing.T) {
 	cmd := &cli.Command{
 		Flags: discoverRunFlags(false),
 		Action: func(_ context.Context, cmd *cli.Command) error {
-			got = buildDiscoverRunArgs(cmd, "P2ABC", true)
+			got = buildDiscoverRunArgs(cmd, "P2QRC", true)
 			return nil
 		},
 	}
 
 	err := cmd.Run(context.Background(), []string{
 		"discover-test",
-		"--tag=P2ABC",
+		"--tag=P2QRC",
 		"--background",
 		"--verbose",
 	})
@@ -113,7 +113,7 @@ func TestBuildDiscoverRunArgsResumeBackground(t *testing.T) {
 	want := []string{
 		"deck", "discover", "run",
 		"--resume",
-		"--tag=#P2ABC",
+		"--tag=#P2QRC",
 		"--verbose",
 	}
 	if !reflect.DeepEqual(got, want) {
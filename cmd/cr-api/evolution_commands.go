@@ -18,8 +18,9 @@ import (
 // addEvolutionCommands adds evolution-related subcommands to the CLI.
 func addEvolutionCommands() *cli.Command {
 	return &cli.Command{
-		Name:  "evolutions",
-		Usage: "Evolution tracking commands",
+		Name:    "evolutions",
+		Aliases: []string{"evolution"},
+		Usage:   "Evolution tracking commands",
 		Commands: []*cli.Command{
 			{
 				Name:  "shards",
@@ -73,6 +74,29 @@ func addEvolutionCommands() *cli.Command {
 				},
 				Action: evolutionRecommendCommand,
 			},
+			{
+				Name:  "plan",
+				Usage: "Plan evolution unlocks: shards needed, which top decks benefit, and the order to spend shards",
+				Flags: []cli.Flag{
+					playerTagFlag(true),
+					&cli.IntFlag{
+						Name:  "decks",
+						Value: 3,
+						Usage: "Number of top decks (built from your collection) to measure each evolution against",
+					},
+					&cli.IntFlag{
+						Name:  "shards-per-evolution",
+						Value: 10,
+						Usage: "Shards required to unlock one evolution level",
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Output the plan as JSON",
+					},
+					unlockedEvolutionsFlag(),
+				},
+				Action: evolutionPlanCommand,
+			},
 		},
 	}
 }
@@ -285,7 +309,21 @@ func evolutionRecommendCommand(ctx context.Context, cmd *cli.Command) error {
 		return err
 	}
 
-	// Build max evolution level lookup
+	candidates := evolutionCandidates(player, cards)
+
+	// Create recommender and get recommendations
+	recommender := deck.NewEvolutionRecommender(shardInventory.Shards, unlockedEvolutions)
+	recommendations := recommender.Recommend(candidates, topN)
+
+	// Display results
+	fmt.Print(deck.FormatRecommendations(recommendations, verbose))
+
+	return nil
+}
+
+// evolutionCandidates builds classified card candidates from the player's
+// collection, taking max evolution levels from the card database
+func evolutionCandidates(player *clashroyale.Player, cards []clashroyale.Card) []deck.CardCandidate {
 	maxEvolutionLevels := make(map[string]int)
 	for _, card := range cards {
 		if card.MaxEvolutionLevel > 0 {
@@ -293,7 +331,6 @@ func evolutionRecommendCommand(ctx context.Context, cmd *cli.Command) error {
 		}
 	}
 
-	// Build card candidates from player data
 	candidates := make([]deck.CardCandidate, 0, len(player.Cards))
 	for _, card := range player.Cards {
 		candidate := deck.CardCandidate{
@@ -308,15 +345,6 @@ func evolutionRecommendCommand(ctx context.Context, cmd *cli.Command) error {
 		candidates = append(candidates, candidate)
 	}
 
-	// Classify candidates
 	deck.ClassifyAllCandidates(candidates)
-
-	// Create recommender and get recommendations
-	recommender := deck.NewEvolutionRecommender(shardInventory.Shards, unlockedEvolutions)
-	recommendations := recommender.Recommend(candidates, topN)
-
-	// Display results
-	fmt.Print(deck.FormatRecommendations(recommendations, verbose))
-
-	return nil
+	return candidates
 }
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/klauer/clash-royale-api/go/internal/storage"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/urfave/cli/v3"
+)
+
+// evolutionPlanCommand lists the evolutions the player can work toward, the
+// shards each still needs, which top decks gain most, and a spending order
+func evolutionPlanCommand(ctx context.Context, cmd *cli.Command) error {
+	dataDir := cmd.String("data-dir")
+	verbose := cmd.Bool("verbose")
+	playerTag := cmd.String("tag")
+	deckCount := cmd.Int("decks")
+	shardsPerEvolution := cmd.Int("shards-per-evolution")
+
+	if deckCount < 0 {
+		return fmt.Errorf("decks must not be negative")
+	}
+	if shardsPerEvolution < 1 {
+		return fmt.Errorf("shards-per-evolution must be at least 1")
+	}
+
+	client, err := requireAPIClient(cmd, apiClientOptions{})
+	if err != nil {
+		return err
+	}
+	if verbose {
+		printf("Fetching player data for %s...\n", playerTag)
+	}
+	player, err := client.GetPlayerWithContext(ctx, playerTag)
+	if err != nil {
+		return fmt.Errorf("failed to fetch player: %w", err)
+	}
+
+	pathBuilder := storage.NewPathBuilder(dataDir)
+	shardInventory, err := storage.LoadEvolutionShardInventory(pathBuilder.GetEvolutionShardsPath())
+	if err != nil {
+		return fmt.Errorf("failed to load shard inventory: %w", err)
+	}
+	cards, err := loadStaticCards(ctx, dataDir, cmd.String("api-token"), verbose)
+	if err != nil {
+		return err
+	}
+
+	candidates := evolutionCandidates(player, cards)
+	decks := buildEvolutionPlanDecks(player, candidates, dataDir, deckCount, verbose)
+
+	recommender := deck.NewEvolutionRecommender(shardInventory.Shards, unlockedEvolutionsFromCommand(cmd))
+	recommender.SetShardsPerEvolution(shardsPerEvolution)
+	plan := evaluation.PlanEvolutions(candidates, decks, recommender)
+
+	if cmd.Bool("json") {
+		data, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal evolution plan: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	displayEvolutionPlan(player.Name, player.Tag, plan, len(shardInventory.Shards) == 0)
+	return nil
+}
+
+// buildEvolutionPlanDecks builds a deck for every strategy from the
+// collection and keeps the limit best distinct ones by evaluation score
+func buildEvolutionPlanDecks(player *clashroyale.Player, candidates []deck.CardCandidate, dataDir string, limit int, verbose bool) []evaluation.EvolutionPlanDeck {
+	if limit == 0 {
+		return nil
+	}
+
+	cardLevels := make(map[string]deck.CardLevelData, len(candidates))
+	byName := make(map[string]deck.CardCandidate, len(candidates))
+	for _, card := range candidates {
+		cardLevels[card.Name] = deck.CardLevelData{
+			Level:             card.Level,
+			MaxLevel:          card.MaxLevel,
+			Rarity:            card.Rarity,
+			Elixir:            card.Elixir,
+			EvolutionLevel:    card.EvolutionLevel,
+			MaxEvolutionLevel: card.MaxEvolutionLevel,
+		}
+		byName[card.Name] = card
+	}
+
+	synergyDB := deck.NewSynergyDatabase()
+	playerContext := evaluation.NewPlayerContextFromPlayer(player)
+	seen := make(map[string]bool)
+	var decks []evaluation.EvolutionPlanDeck
+	for _, strategy := range getAllDeckStrategies() {
+		builder := deck.NewBuilder(dataDir)
+		if err := builder.SetStrategy(strategy); err != nil {
+			continue
+		}
+		rec, err := builder.BuildDeckFromAnalysis(deck.CardAnalysis{CardLevels: cardLevels})
+		if err != nil {
+			if verbose {
+				printf("Skipping %s deck: %v\n", strategy, err)
+			}
+			continue
+		}
+		key := strings.Join(slices.Sorted(slices.Values(rec.Deck)), "|")
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		deckCards := make([]deck.CardCandidate, 0, len(rec.Deck))
+		for _, name := range rec.Deck {
+			card := byName[name]
+			card.Stats = inferStats(name, card.Level)
+			deckCards = append(deckCards, card)
+		}
+		result := evaluation.Evaluate(deckCards, synergyDB, playerContext)
+		decks = append(decks, evaluation.EvolutionPlanDeck{Label: string(strategy), Cards: rec.Deck, Score: result.OverallScore})
+	}
+
+	slices.SortStableFunc(decks, func(a, b evaluation.EvolutionPlanDeck) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		}
+		return 0
+	})
+	return decks[:min(limit, len(decks))]
+}
+
+func displayEvolutionPlan(playerName, playerTag string, plan *evaluation.EvolutionPlan, noShards bool) {
+	printf("\nEVOLUTION PLAN\n")
+	printf("==============\n\n")
+	printf("Player: %s (%s)\n", playerName, playerTag)
+	if len(plan.Steps) == 0 {
+		printf("No evolutions left to unlock.\n")
+		return
+	}
+	printf("Evolutions to unlock: %d (%d ready now, %d shards still needed)\n", len(plan.Steps), plan.Ready, plan.ShardsMissing)
+	if noShards {
+		printf("No shard inventory recorded; track it with `cr-api evolutions shards set`.\n")
+	}
+
+	if len(plan.Decks) > 0 {
+		printf("\nTop Decks\n")
+		for i, d := range plan.Decks {
+			printf("  %d. %-9s %.2f  %s\n", i+1, d.Label, d.Score, strings.Join(d.Cards, ", "))
+		}
+	}
+
+	printf("\nRecommended Order\n")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fprintf(w, "#\tCard\tEvo\tShards\tNeeded\tLevel\tDecks\tPriority\t\n")
+	fprintf(w, "-\t----\t---\t------\t------\t-----\t-----\t--------\t\n")
+	for i, step := range plan.Steps {
+		status := ""
+		if step.Ready {
+			status = "READY"
+		}
+		fprintf(w, "%d\t%s\t%d/%d\t%d/%d\t%d\t%d/%d\t%s\t%.1f\t%s\n",
+			i+1, step.CardName, step.TargetLevel, step.EvolutionMaxLevel,
+			step.CurrentShards, step.ShardsNeeded, step.ShardsMissing,
+			step.CardLevel, step.MaxLevel, formatEvolutionDeckGains(step.DeckGains), step.Priority, status)
+	}
+	flushWriter(w)
+}
+
+// formatEvolutionDeckGains lists the top decks (1-based) an evolution helps,
+// with the evolution score each gains
+func formatEvolutionDeckGains(gains []evaluation.EvolutionDeckGain) string {
+	if len(gains) == 0 {
+		return "-"
+	}
+	parts := make([]string, len(gains))
+	for i, gain := range gains {
+		parts[i] = fmt.Sprintf("#%d +%.1f", gain.Deck+1, gain.Gain)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+)
+
+func TestEvolutionCandidatesUsesCardDatabaseEvolutionLevels(t *testing.T) {
+	player := &clashroyale.Player{Cards: []clashroyale.Card{
+		{Name: "Knight", Level: 14, MaxLevel: 16, ElixirCost: 3},
+		{Name: "Hog Rider", Level: 13, MaxLevel: 16, ElixirCost: 4},
+	}}
+	cards := []clashroyale.Card{{Name: "Knight", MaxEvolutionLevel: 1}, {Name: "Hog Rider"}}
+
+	candidates := evolutionCandidates(player, cards)
+	if len(candidates) != 2 || candidates[0].MaxEvolutionLevel != 1 || candidates[1].MaxEvolutionLevel != 0 {
+		t.Fatalf("candidates = %+v", candidates)
+	}
+	if candidates[0].Role == nil {
+		t.Error("candidates should be classified")
+	}
+}
+
+func TestFormatEvolutionDeckGains(t *testing.T) {
+	if got := formatEvolutionDeckGains(nil); got != "-" {
+		t.Errorf("no gains = %q", got)
+	}
+	got := formatEvolutionDeckGains([]evaluation.EvolutionDeckGain{{Deck: 0, Gain: 5}, {Deck: 2, Gain: 2.5}})
+	if got != "#1 +5.0, #3 +2.5" {
+		t.Errorf("gains = %q", got)
+	}
+}
@@ -81,7 +81,7 @@ func TestExportPlayerCommandRequiresAPIToken(t *testing.T) {
 	t.Setenv(apiTokenEnvVar, "")
 
 	cmd := exportPlayerCommand()
-	err := cmd.Run(context.Background(), []string{"export-player", "--tag", "TESTTAG"})
+	err := cmd.Run(context.Background(), []string{"export-player", "--tag", "QGLJ2Y"})
 	if err == nil {
 		t.Fatalf("expected missing token error")
 	}
@@ -135,7 +135,7 @@ func TestDeckWarCommandRequiresAPIToken(t *testing.T) {
 	t.Setenv(apiTokenEnvVar, "")
 
 	cmd := addDeckWarCommand()
-	err := cmd.Run(context.Background(), []string{"war", "--tag", "TESTTAG"})
+	err := cmd.Run(context.Background(), []string{"war", "--tag", "QGLJ2Y"})
 	if err == nil {
 		t.Fatalf("expected missing token error")
 	}
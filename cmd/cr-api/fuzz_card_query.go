@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/fuzzstorage"
+	"github.com/urfave/cli/v3"
+)
+
+// withCardsFilterFlag filters saved decks to those holding every listed card
+func withCardsFilterFlag() cli.Flag {
+	return &cli.StringSliceFlag{
+		Name:  "with-cards",
+		Usage: `Only decks with every one of these cards, e.g. "Hog Rider,Log" (partial names are fine when unambiguous)`,
+	}
+}
+
+// withoutCardsFilterFlag filters saved decks to those holding none of the
+// listed cards
+func withoutCardsFilterFlag() cli.Flag {
+	return &cli.StringSliceFlag{
+		Name:  "without-cards",
+		Usage: "Only decks with none of these cards",
+	}
+}
+
+// winConditionFilterFlag filters saved decks by win condition
+func winConditionFilterFlag() cli.Flag {
+	return &cli.StringSliceFlag{
+		Name:  "win-condition",
+		Usage: `Only decks with a win condition matching one of these names, e.g. "hog" for Hog Rider or Royal Hogs`,
+	}
+}
+
+// knownCardNames lists the cards in the bundled combat stats, which use the
+// API's card names (the role table also holds aliases such as "Log")
+func knownCardNames() []string {
+	return slices.Sorted(maps.Keys(clashroyale.DefaultStats().Stats))
+}
+
+// cardsMatching returns the known cards whose name contains query, or just
+// the card named query when there is one. Both ignore case.
+func cardsMatching(query string, known []string) []string {
+	query = strings.ToLower(strings.TrimSpace(query))
+	var matches []string
+	for _, name := range known {
+		lower := strings.ToLower(name)
+		if lower == query {
+			return []string{name}
+		}
+		if strings.Contains(lower, query) {
+			matches = append(matches, name)
+		}
+	}
+	return matches
+}
+
+// resolveQueryCards turns card queries into card names. A query matching no
+// known card is kept as given, since storage may hold cards newer than the
+// bundled data; one matching several cards is an error.
+func resolveQueryCards(queries, known []string) ([]string, error) {
+	var cards []string
+	for _, query := range queries {
+		if strings.TrimSpace(query) == "" {
+			continue
+		}
+		switch matches := cardsMatching(query, known); len(matches) {
+		case 0:
+			cards = append(cards, strings.TrimSpace(query))
+		case 1:
+			cards = append(cards, matches[0])
+		default:
+			return nil, fmt.Errorf("card %q is ambiguous: %s", query, strings.Join(matches, ", "))
+		}
+	}
+	return cards, nil
+}
+
+// resolveWinConditions returns the win condition cards matching any of the
+// queries
+func resolveWinConditions(queries, known []string) ([]string, error) {
+	var cards []string
+	for _, query := range queries {
+		if strings.TrimSpace(query) == "" {
+			continue
+		}
+		found := false
+		for _, name := range cardsMatching(query, known) {
+			if config.GetCardRole(name) == config.RoleWinCondition && !slices.Contains(cards, name) {
+				cards = append(cards, name)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no win condition matches %q", query)
+		}
+	}
+	return cards, nil
+}
+
+// applyCardQueryFilters resolves the --with-cards, --without-cards, and
+// --win-condition flags into opts
+func applyCardQueryFilters(cmd *cli.Command, opts *fuzzstorage.QueryOptions) error {
+	known := knownCardNames()
+	var err error
+	if opts.RequireAllCards, err = resolveQueryCards(cmd.StringSlice("with-cards"), known); err != nil {
+		return err
+	}
+	if opts.ExcludeCards, err = resolveQueryCards(cmd.StringSlice("without-cards"), known); err != nil {
+		return err
+	}
+	opts.WinConditions, err = resolveWinConditions(cmd.StringSlice("win-condition"), known)
+	return err
+}
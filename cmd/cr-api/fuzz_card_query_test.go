@@ -0,0 +1,59 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestResolveQueryCards(t *testing.T) {
+	known := knownCardNames()
+
+	cards, err := resolveQueryCards([]string{"hog rider", "Log", "Golem", "Brand New Card"}, known)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"Hog Rider", "The Log", "Golem", "Brand New Card"}; !slices.Equal(cards, want) {
+		t.Errorf("resolveQueryCards() = %v, want %v", cards, want)
+	}
+	if _, err := resolveQueryCards([]string{"Spirit"}, known); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("ambiguous card error = %v", err)
+	}
+
+	wins, err := resolveWinConditions([]string{"hog"}, known)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(wins, "Hog Rider") || !slices.Contains(wins, "Royal Hogs") {
+		t.Errorf("resolveWinConditions(hog) = %v, want Hog Rider and Royal Hogs", wins)
+	}
+	if _, err := resolveWinConditions([]string{"Musketeer"}, known); err == nil {
+		t.Error("expected an error for a card that is not a win condition")
+	}
+}
+
+func TestDeckFuzzListCardFilters(t *testing.T) {
+	seedCurationDecks(t)
+
+	for _, tc := range []struct {
+		args    []string
+		want    string
+		exclude string
+	}{
+		{[]string{"--with-cards", "Hog Rider,Log"}, "Hog Rider", "Night Witch"},
+		{[]string{"--without-cards", "Hog Rider"}, "Night Witch", "Hog Rider"},
+		{[]string{"--win-condition", "golem"}, "Night Witch", "Hog Rider"},
+	} {
+		output, err := runCurationCommand(t, append(tc.args, "--format", "csv")...)
+		if err != nil {
+			t.Fatalf("%v: %v", tc.args, err)
+		}
+		if !strings.Contains(output, tc.want) || strings.Contains(output, tc.exclude) {
+			t.Errorf("%v output =\n%s", tc.args, output)
+		}
+	}
+
+	if _, err := runCurationCommand(t, "--win-condition", "musketeer"); err == nil {
+		t.Error("expected an error for an unknown win condition")
+	}
+}
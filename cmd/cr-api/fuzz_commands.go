@@ -5,7 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"math"
+	"io"
 	"math/rand"
 	"os"
 	"os/signal"
@@ -21,6 +21,8 @@ import (
 	"time"
 
 	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/internal/metrics"
+	"github.com/klauer/clash-royale-api/go/internal/notify"
 	"github.com/klauer/clash-royale-api/go/internal/playertag"
 	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
 	"github.com/klauer/clash-royale-api/go/pkg/deck"
@@ -41,9 +43,19 @@ type stageCanceler struct {
 const (
 	gaFitnessModeLegacy        = "legacy-evaluation"
 	gaFitnessModeArchetypeFree = "archetype-free-composite"
+	gaFitnessModeGameMode      = "game-mode-evaluation"
 )
 
-func selectGAFitnessEvaluator(useArchetypes bool) (func([]deck.CardCandidate) (float64, error), string) {
+func selectGAFitnessEvaluator(useArchetypes bool, gameMode evaluation.GameMode) (func([]deck.CardCandidate) (float64, error), string) {
+	// The composite research score has no notion of event rules, so game
+	// modes evolve against the evaluation engine with the mode's preset
+	if preset := evaluation.LookupGameMode(gameMode); !preset.Standard() {
+		synergyDB := deck.NewSynergyDatabase()
+		return func(deckCards []deck.CardCandidate) (float64, error) {
+			result := evaluation.EvaluateWithOptions(deckCards, synergyDB, nil, evaluation.EvaluateOptions{GameMode: preset.Mode})
+			return result.OverallScore, nil
+		}, gaFitnessModeGameMode + ":" + string(preset.Mode)
+	}
 	if useArchetypes {
 		return nil, gaFitnessModeLegacy
 	}
@@ -77,6 +89,11 @@ func (sc *stageCanceler) Cancel() {
 
 // deckFuzzCommand is the action function for the deck fuzz command
 func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
+	return runDeckFuzz(ctx, cmd, nil)
+}
+
+// runDeckFuzz runs deck fuzz, continuing session when it is not nil.
+func runDeckFuzz(ctx context.Context, cmd *cli.Command, session *fuzzSession) error {
 	playerTag := cmd.String("tag")
 	count := cmd.Int("count")
 	workers := cmd.Int("workers")
@@ -115,6 +132,8 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 	uniquenessWeight := cmd.Float64("uniqueness-weight")
 	ensureArchetypes := cmd.Bool("ensure-archetypes")
 	ensureElixirBuckets := cmd.Bool("ensure-elixir-buckets")
+	clusterDecks := cmd.Bool("cluster")
+	clusterThreshold := cmd.Float64("cluster-threshold")
 	mode := strings.ToLower(cmd.String("mode"))
 	gaPopulation := cmd.Int("ga-population")
 	gaGenerations := cmd.Int("ga-generations")
@@ -131,6 +150,69 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 	gaMigrationInterval := cmd.Int("ga-migration-interval")
 	gaMigrationSize := cmd.Int("ga-migration-size")
 	gaUseArchetypes := cmd.Bool("ga-use-archetypes")
+	gaAdaptiveMutation := cmd.Bool("ga-adaptive-mutation")
+	gaMinEvolvedCards := cmd.Int("ga-min-evolved-cards")
+	gaEvolutionSlots := cmd.Int("ga-evolution-slots")
+	gaNoveltyWeight := cmd.Float64("ga-novelty-weight")
+	distributed := cmd.Bool("distributed")
+	sessionName := cmd.String("session")
+	deterministic := cmd.Bool("deterministic")
+	gaIslandTopology, topologyErr := genetic.ParseIslandTopology(cmd.String("ga-island-topology"))
+	if topologyErr != nil {
+		return fmt.Errorf("invalid --ga-island-topology: %w", topologyErr)
+	}
+	gaIslandStrategies, strategiesErr := genetic.ParseIslandStrategies(cmd.String("ga-island-strategies"))
+	if strategiesErr != nil {
+		return fmt.Errorf("invalid --ga-island-strategies: %w", strategiesErr)
+	}
+	gaObjectives, objectivesErr := genetic.ParseObjectives(cmd.String("ga-objectives"))
+	if objectivesErr != nil {
+		return fmt.Errorf("invalid --ga-objectives: %w", objectivesErr)
+	}
+	if gaNoveltyWeight < 0 {
+		return fmt.Errorf("--ga-novelty-weight must not be negative")
+	}
+	if len(gaObjectives) == 1 {
+		return fmt.Errorf("--ga-objectives needs at least 2 objectives for a Pareto front")
+	}
+	if len(gaObjectives) > 0 && mode != fuzzModeGenetic {
+		return fmt.Errorf("--ga-objectives requires --mode %s", fuzzModeGenetic)
+	}
+	seedFile := strings.TrimSpace(cmd.String("ga-seed-file"))
+	if seedFile != "" && mode != fuzzModeGenetic {
+		return fmt.Errorf("--ga-seed-file requires --mode %s", fuzzModeGenetic)
+	}
+	constraintRules, constraintsErr := research.ParseCountRules(cmd.String("constraints"))
+	if constraintsErr != nil {
+		return fmt.Errorf("invalid --constraints: %w", constraintsErr)
+	}
+	metaContext, metaErr := loadMetaFileFlag(cmd)
+	if metaErr != nil {
+		return metaErr
+	}
+	gameMode, gameModeErr := parseGameModeFlag(cmd)
+	if gameModeErr != nil {
+		return gameModeErr
+	}
+	evalOpts := evaluation.EvaluateOptions{Meta: metaContext, GameMode: gameMode}
+	cardPool, poolErr := loadCardPoolFlags(cmd)
+	if poolErr != nil {
+		return poolErr
+	}
+	if err := validateIncludedCardsInPool(includeCards, cardPool); err != nil {
+		return err
+	}
+	if err := validateExportImageFlag(cmd); err != nil {
+		return err
+	}
+	if err := startMetricsServer(ctx, cmd); err != nil {
+		return err
+	}
+	fuzzEvaluationCache = openFuzzEvaluationCache(cmd, verbose)
+	defer func() {
+		closeFuzzEvaluationCache(fuzzEvaluationCache, verbose)
+		fuzzEvaluationCache = nil
+	}()
 
 	var interrupted atomic.Bool
 	var canceler stageCanceler
@@ -157,28 +239,41 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 	if mode == "" {
 		mode = "random"
 	}
-	if mode != "random" && mode != fuzzModeGenetic {
-		return fmt.Errorf("invalid --mode value: %s (must be random or genetic)", mode)
+	if mode != "random" && mode != fuzzModeRoleTemplate && mode != fuzzModeGenetic {
+		return fmt.Errorf("invalid --mode value: %s (must be random, %s or genetic)", mode, fuzzModeRoleTemplate)
 	}
-
-	// Validate archetypes
-	validArchetypes := map[string]bool{
-		"beatdown":  true,
-		"control":   true,
-		"cycle":     true,
-		"bridge":    true,
-		"siege":     true,
-		"bait":      true,
-		"graveyard": true,
-		"miner":     true,
-		"hybrid":    true,
+	if mode == fuzzModeRoleTemplate && (synergyPairs || evolutionCentric) {
+		return fmt.Errorf("--mode %s cannot be combined with --synergy-pairs or --evolution-centric", fuzzModeRoleTemplate)
 	}
-	for _, arch := range archetypes {
-		arch = strings.ToLower(strings.TrimSpace(arch))
-		if !validArchetypes[arch] {
-			return fmt.Errorf("invalid archetype '%s' (must be one of: beatdown, control, cycle, bridge, siege, bait, graveyard, miner, hybrid)", arch)
+	if distributed {
+		if err := validateDistributedFuzzFlags(cmd, mode); err != nil {
+			return err
 		}
 	}
+	if deterministic {
+		if cmd.Int("seed") == 0 {
+			return fmt.Errorf("--deterministic requires a non-zero --seed")
+		}
+		if mode == fuzzModeGenetic {
+			return fmt.Errorf("--deterministic supports random and %s modes only", fuzzModeRoleTemplate)
+		}
+		if sessionName != "" {
+			return fmt.Errorf("--deterministic cannot be combined with --session")
+		}
+	}
+	if sessionName != "" && session == nil {
+		if outputDir == "" {
+			return fmt.Errorf("--session requires --output-dir")
+		}
+		if mode == fuzzModeGenetic || distributed {
+			return fmt.Errorf("--session supports random and %s modes only", fuzzModeRoleTemplate)
+		}
+	}
+
+	normalizedArchetypes, err := resolveFuzzArchetypes(archetypes)
+	if err != nil {
+		return err
+	}
 
 	if minOverall < 0 || minOverall > 10 {
 		return fmt.Errorf("--min-overall must be between 0 and 10")
@@ -192,6 +287,10 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("--top must be at least 1")
 	}
 
+	if clusterThreshold <= 0 || clusterThreshold > 1 {
+		return fmt.Errorf("--cluster-threshold must be greater than 0 and at most 1")
+	}
+
 	// Validate sort-by field
 	validSortFields := map[string]bool{
 		"overall":     true,
@@ -218,7 +317,6 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 
 	var player *clashroyale.Player
 	var playerName string
-	var err error
 
 	// Load player data
 	if fromAnalysis {
@@ -259,10 +357,31 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 		fprintf(os.Stderr, "Cards available: %d\n", len(player.Cards))
 	}
 
-	// Normalize archetypes to lowercase
-	normalizedArchetypes := make([]string, 0, len(archetypes))
-	for _, arch := range archetypes {
-		normalizedArchetypes = append(normalizedArchetypes, strings.ToLower(strings.TrimSpace(arch)))
+	// The ladder average comes from the whole collection, before any event
+	// or arena restrictions
+	window, windowErr := levelWindow(cmd, playerCandidates(player))
+	if windowErr != nil {
+		return windowErr
+	}
+
+	// Cards outside the pool leave the collection and are excluded from
+	// seed decks, so random, genetic, and saved-deck runs all respect it
+	excludeCards = mergeUniqueCards(excludeCards, applyCardPoolToPlayer(player, cardPool, verbose))
+	if err := validateIncludedCardsUnlocked(cmd, includeCards, player.Arena.ID); err != nil {
+		return err
+	}
+	excludeCards = mergeUniqueCards(excludeCards, applyArenaLockToPlayer(cmd, player, verbose))
+	if err := validateIncludedCardsInWindow(window, includeCards, player); err != nil {
+		return err
+	}
+	// Budget mode keeps the unfiltered collection to look for the best deck
+	// after upgrades
+	var fullPlayer *clashroyale.Player
+	if window != nil {
+		full := *player
+		full.Cards = slices.Clone(player.Cards)
+		fullPlayer = &full
+		excludeCards = mergeUniqueCards(excludeCards, applyLevelWindowToPlayer(window, player, verbose))
 	}
 
 	// Initialize fuzzer configuration
@@ -277,6 +396,7 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 		MinSynergyScore:   minSynergy,
 		SynergyFirst:      synergyPairs,
 		EvolutionCentric:  evolutionCentric,
+		RoleTemplate:      mode == fuzzModeRoleTemplate,
 		MinEvolutionCards: minEvoCards,
 		MinEvoLevel:       minEvoLevel,
 		EvoWeight:         evoWeight,
@@ -284,6 +404,8 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 		ArchetypeFilter:   normalizedArchetypes,
 		UniquenessWeight:  uniquenessWeight,
 		EnsureArchetypes:  ensureArchetypes,
+		Deterministic:     deterministic,
+		Accept:            deckConstraintChecker(constraintRules, player),
 	}
 
 	// Handle --include-from-saved: extract cards from saved top decks
@@ -304,8 +426,37 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 		fuzzerCfg.Seed = int64(seed)
 	}
 
+	// A session needs a known seed to rebuild its random streams from. A
+	// resumed session keeps its seed and worker count and only generates
+	// the decks it has left.
+	if session != nil {
+		fuzzerCfg.Seed = session.Seed
+		workers = session.Workers
+		fuzzerCfg.Workers = workers
+		fuzzerCfg.Count = session.Remaining()
+	} else if sessionName != "" {
+		if fuzzerCfg.Seed == 0 {
+			fuzzerCfg.Seed = time.Now().UnixNano()
+		}
+		session, err = newFuzzSession(cmd, sessionName, outputDir, playerTag, fuzzerCfg.Seed, workers, count)
+		if err != nil {
+			return err
+		}
+		if verbose {
+			fprintf(os.Stderr, "Fuzz session manifest: %s\n", session.path)
+		}
+	}
+
+	// lineage records the parents of mutated, varied, and bred decks so
+	// --save-top can store how the best decks evolved
+	lineage := deck.NewLineageRecorder()
+
+	// Seed decks, mutations, and variations were explored by the first run
+	// of a resumed session
+	resumingSession := session != nil && session.Resumed()
+
 	var seedDecks [][]string
-	if resumeFrom > 0 && !interrupted.Load() {
+	if resumeFrom > 0 && !resumingSession && !interrupted.Load() {
 		savedDecks, err := loadSavedDecksForSeeding(resumeFrom, player, verbose)
 		if err != nil {
 			return fmt.Errorf("failed to load saved decks for resume: %w", err)
@@ -323,15 +474,46 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 			fprintf(os.Stderr, "Warning: --synergy-pairs is ignored in genetic mode\n")
 		}
 		if evolutionCentric {
-			fprintf(os.Stderr, "Warning: --evolution-centric is ignored in genetic mode\n")
+			fprintf(os.Stderr, "Warning: --evolution-centric is ignored in genetic mode; use --ga-min-evolved-cards and --ga-evolution-slots\n")
+		}
+		if gaNoveltyWeight > 0 && len(gaObjectives) > 0 {
+			fprintf(os.Stderr, "Warning: --ga-novelty-weight is ignored with --ga-objectives\n")
 		}
 	}
 
 	var generatedDecks [][]string
 	var generationTime time.Duration
 	var stats deck.FuzzingStats
+	var paretoFront []genetic.ParetoSolution
+	// pooledResults holds the best decks from a streaming or distributed run,
+	// which never materialize the full set of generated decks.
+	var pooledResults []FuzzingResult
+	var streamStats *fuzzStreamStats
 
-	if mode == fuzzModeGenetic {
+	if distributed {
+		keep := cmd.Int("keep-per-batch")
+		if keep <= 0 {
+			keep = fuzzPoolSize(top)
+		}
+		startTime := time.Now()
+		distributedCtx, cancelDistributed := context.WithCancel(ctx)
+		canceler.Set(cancelDistributed)
+		pooledResults, err = runDistributedFuzz(distributedCtx, player, playerTag, fuzzerCfg, distributedFuzzOptions{
+			endpoints: splitEndpoints(cmd.StringSlice("workers-endpoint")),
+			token:     cmd.String("worker-token"),
+			batchSize: cmd.Int("batch-size"),
+			keep:      keep,
+			sortBy:    sortBy,
+			storage:   storagePath,
+			verbose:   verbose,
+		}, &stats)
+		canceler.Clear()
+		cancelDistributed()
+		if err != nil {
+			return fmt.Errorf("distributed fuzzing failed: %w", err)
+		}
+		generationTime = time.Since(startTime)
+	} else if mode == fuzzModeGenetic {
 		if verbose {
 			fprintf(os.Stderr, "\nStarting deck fuzzing (genetic mode)...\n")
 			if refineRounds > 1 {
@@ -343,9 +525,18 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 		if err != nil {
 			return err
 		}
-		fitnessEvaluator, gaFitnessMode := selectGAFitnessEvaluator(gaUseArchetypes)
+		fitnessEvaluator, gaFitnessMode := selectGAFitnessEvaluator(gaUseArchetypes, gameMode)
+		if len(constraintRules) > 0 {
+			fitnessEvaluator = constrainedGAFitness(fitnessEvaluator, constraintRules)
+			gaFitnessMode += "+constraints=" + constraintRuleKey(constraintRules)
+		}
+		fitnessEvaluator = cachedGAFitness(fuzzEvaluationCache, fitnessEvaluator, gaFitnessMode)
 		if verbose {
-			fprintf(os.Stderr, "GA objective: %s\n", gaFitnessMode)
+			if len(gaObjectives) > 0 {
+				fprintf(os.Stderr, "GA objectives (Pareto): %s\n", joinObjectives(gaObjectives))
+			} else {
+				fprintf(os.Stderr, "GA objective: %s\n", gaFitnessMode)
+			}
 		}
 
 		// Store initial seed decks for first round
@@ -358,7 +549,7 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 				return fmt.Errorf("failed to load saved decks for seeding: %w", err)
 			}
 			if len(savedDecks) > 0 {
-				mutations := generateDeckMutations(savedDecks, player, count, fuzzerCfg.MutationIntensity, verbose)
+				mutations := generateDeckMutations(savedDecks, player, count, fuzzerCfg.MutationIntensity, lineage, verbose)
 				mutations = filterDecksByIncludeExclude(mutations, includeCards, excludeCards)
 				initialSeedDecks = append(initialSeedDecks, mutations...)
 			}
@@ -369,15 +560,42 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 			if err != nil {
 				return fmt.Errorf("failed to load deck from storage: %w", err)
 			}
-			variations := generateVariations(baseDeck, player, count, fuzzerCfg.MutationIntensity, verbose)
+			variations := generateVariations(baseDeck, player, count, fuzzerCfg.MutationIntensity, lineage, verbose)
 			if len(variations) > 0 {
 				variations = filterDecksByIncludeExclude(variations, includeCards, excludeCards)
 				initialSeedDecks = append(initialSeedDecks, variations...)
 			}
 		}
 
+		// Seed-file decks go first so they are sure to enter the population
+		if seedFile != "" && !interrupted.Load() {
+			fileSeeds, err := loadGASeedDecks(ctx, cmd, seedFile, player, candidates, verbose)
+			if err != nil {
+				return fmt.Errorf("failed to load --ga-seed-file: %w", err)
+			}
+			fileSeeds = filterDecksByIncludeExclude(fileSeeds, includeCards, excludeCards)
+			initialSeedDecks = append(fileSeeds, initialSeedDecks...)
+		}
+
 		// Iterative refinement loop
+		adaptiveBase := genetic.DefaultGeneticConfig()
+		adaptiveBase.PopulationSize = gaPopulation
+		adaptiveBase.MutationRate = gaMutationRate
+		adaptiveBase.MutationIntensity = gaMutationIntensity
+		adaptiveBase.EliteCount = gaEliteCount
+		adaptive := genetic.NewAdaptiveParams(adaptiveBase)
 		currentSeedDecks := initialSeedDecks
+
+		var noveltyArchive [][]string
+		if gaNoveltyWeight > 0 {
+			archive, err := storedFuzzDecks(noveltyArchiveSize)
+			if err != nil {
+				fprintf(os.Stderr, "Warning: novelty search runs without the stored deck archive: %v\n", err)
+			} else if verbose {
+				fprintf(os.Stderr, "Novelty search: weight %.2f against %d stored decks\n", gaNoveltyWeight, len(archive))
+			}
+			noveltyArchive = archive
+		}
 		var allRoundResults [][]*genetic.DeckGenome
 		var totalTime time.Duration
 
@@ -402,35 +620,22 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 			gaConfig.IslandCount = gaIslandCount
 			gaConfig.MigrationInterval = gaMigrationInterval
 			gaConfig.MigrationSize = gaMigrationSize
+			gaConfig.MigrationTopology = gaIslandTopology
+			gaConfig.IslandStrategies = gaIslandStrategies
 			gaConfig.UseArchetypes = gaUseArchetypes
-
-			// Progressive refinement: adjust parameters each round
-			if round == 1 {
-				// First round: use user-specified parameters
-				gaConfig.MutationRate = gaMutationRate
-				gaConfig.MutationIntensity = gaMutationIntensity
-				gaConfig.EliteCount = gaEliteCount
-			} else {
-				// Subsequent rounds: reduce exploration, increase exploitation
-				// Gradually reduce mutation rate (min 0.02)
-				mutationRate := gaMutationRate * math.Pow(0.7, float64(round-1))
-				if mutationRate < 0.02 {
-					mutationRate = 0.02
-				}
-				gaConfig.MutationRate = mutationRate
-				// Gradually reduce mutation intensity (min 0.1)
-				mutationIntensity := gaMutationIntensity * math.Pow(0.7, float64(round-1))
-				if mutationIntensity < 0.1 {
-					mutationIntensity = 0.1
-				}
-				gaConfig.MutationIntensity = mutationIntensity
-				// Gradually increase elite count (max 20% of population)
-				eliteCount := gaEliteCount + round - 1
-				maxElite := gaPopulation / 5
-				if eliteCount > maxElite {
-					eliteCount = maxElite
-				}
-				gaConfig.EliteCount = eliteCount
+			gaConfig.MinEvolvedCards = gaMinEvolvedCards
+			gaConfig.EvolutionSlotLimit = gaEvolutionSlots
+			gaConfig.NoveltyWeight = gaNoveltyWeight
+			gaConfig.NoveltyArchive = noveltyArchive
+			gaConfig.Objectives = gaObjectives
+
+			// Progressive refinement: the adaptive controller starts from the
+			// user-specified parameters and narrows or widens the search each
+			// round depending on whether the previous round improved.
+			adaptive.ApplyTo(&gaConfig)
+			if verbose && round > 1 {
+				fprintf(os.Stderr, "Round %d parameters: mutation rate %.3f, intensity %.2f, elite %d\n",
+					round, gaConfig.MutationRate, gaConfig.MutationIntensity, gaConfig.EliteCount)
 			}
 
 			// Use seed decks from previous round
@@ -443,6 +648,10 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 				return fmt.Errorf("failed to create genetic optimizer: %w", err)
 			}
 			optimizer.FitnessFunc = fitnessEvaluator
+			optimizer.Lineage = lineage
+			if gaAdaptiveMutation {
+				optimizer.Adaptive = adaptive
+			}
 			if seed != 0 {
 				optimizer.RNG = rand.New(rand.NewSource(int64(seed) + int64(round)))
 			}
@@ -462,10 +671,15 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 						}
 					}
 					evalsDone := int64(gens) * int64(totalPop)
+					adaptiveStatus := fmt.Sprintf(" | div %.2f", progress.Diversity)
+					if progress.MutationRate > 0 {
+						adaptiveStatus = fmt.Sprintf(" | mut %.3f%s", progress.MutationRate, adaptiveStatus)
+					}
+					adaptiveStatus += formatIslandProgress(progress.Islands)
 					if refineRounds > 1 {
 						fprintf(
 							os.Stderr,
-							"\rRound %d: GA gen %d/%d | evals ~%d | best %.2f | avg %.2f | elapsed %s | eta %s",
+							"\rRound %d: GA gen %d/%d | evals ~%d | best %.2f | avg %.2f | elapsed %s | eta %s%s",
 							round,
 							progress.Generation,
 							totalGens,
@@ -474,11 +688,12 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 							progress.AvgFitness,
 							formatDurationFloor(elapsed.Seconds()),
 							etaStr,
+							adaptiveStatus,
 						)
 					} else {
 						fprintf(
 							os.Stderr,
-							"\rGA gen %d/%d | evals ~%d | best %.2f | avg %.2f | elapsed %s | eta %s",
+							"\rGA gen %d/%d | evals ~%d | best %.2f | avg %.2f | elapsed %s | eta %s%s",
 							progress.Generation,
 							totalGens,
 							evalsDone,
@@ -486,11 +701,14 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 							progress.AvgFitness,
 							formatDurationFloor(elapsed.Seconds()),
 							etaStr,
+							adaptiveStatus,
 						)
 					}
 				}
 			}
 
+			optimizer.Progress = countGAGenerations(optimizer.Progress)
+
 			startTime := time.Now()
 			result, err := optimizer.Optimize()
 			if verbose {
@@ -507,6 +725,12 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 
 			// Store results from this round
 			allRoundResults = append(allRoundResults, result.HallOfFame)
+			if len(result.Scores) > 0 {
+				adaptive.ObserveRound(result.Scores[0], result.Diversity)
+			}
+			if len(result.ParetoFront) > 0 {
+				paretoFront = result.ParetoFront
+			}
 
 			// Prepare seed decks for next round: use top decks from this round
 			if round < refineRounds {
@@ -553,6 +777,7 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 		}
 
 		generatedDecks = filterDecksByIncludeExclude(generatedDecks, includeCards, excludeCards)
+		generatedDecks = filterDecksByConstraints(generatedDecks, constraintRules, player)
 		stats.Generated = len(generatedDecks)
 		stats.Success = len(generatedDecks)
 	} else {
@@ -565,7 +790,7 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 		// Runtime estimation for large batches
 		const sampleSize = 1000
 		var estimate *runtimeEstimate
-		if count > sampleSize {
+		if count > sampleSize && !resumingSession {
 			estimate, err = estimateRuntime(fuzzer, count, sampleSize)
 			if err != nil {
 				fprintf(os.Stderr, "Warning: could not estimate runtime: %v\n", err)
@@ -590,7 +815,7 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 		if verbose {
 			fprintf(os.Stderr, "\nStarting deck fuzzing...\n")
 			fprintf(os.Stderr, "Configuration:\n")
-			fprintf(os.Stderr, "  Mode: random\n")
+			fprintf(os.Stderr, "  Mode: %s\n", mode)
 			fprintf(os.Stderr, "  Count: %d\n", count)
 			fprintf(os.Stderr, "  Workers: %d\n", workers)
 			if synergyPairs {
@@ -617,108 +842,127 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 			fprintf(os.Stderr, "\n")
 		}
 
-		// Generate decks
-		startTime := time.Now()
-
-		// Start progress reporter for generation
-		var generationDone sync.WaitGroup
-		stopProgress := make(chan struct{})
-		if verbose {
-			generationDone.Go(func() {
-				ticker := time.NewTicker(500 * time.Millisecond)
-				defer ticker.Stop()
-
-				lastCount := 0
-				startTime := time.Now()
-
-				for {
-					select {
-					case <-stopProgress:
-						return
-					case <-ticker.C:
-						stats := fuzzer.GetStats()
-						currentCount := stats.Generated
-						elapsed := time.Since(startTime)
-
-						// Calculate rate
-						rate := float64(currentCount) / elapsed.Seconds()
-
-						// Only print if progress has been made
-						if currentCount > lastCount {
-							eta := time.Duration(float64(count-currentCount)/rate) * time.Second
-							fprintf(os.Stderr, "\rGenerating... %d/%d decks (%.1f decks/sec, ETA: %v) ",
-								currentCount, count, rate, eta.Round(time.Second))
-							lastCount = currentCount
-						}
-					}
-				}
-			})
-		}
-
-		generationCtx, cancelGeneration := context.WithCancel(ctx)
-		canceler.Set(cancelGeneration)
-
-		if workers > 1 {
-			generatedDecks, err = fuzzer.GenerateDecksParallelWithContext(generationCtx)
-		} else {
-			generatedDecks, err = fuzzer.GenerateDecksWithContext(generationCtx, count)
-		}
-
-		// Prepend seed decks to generated decks
-		if len(seedDecks) > 0 {
-			generatedDecks = append(seedDecks, generatedDecks...)
-		}
-		canceler.Clear()
-		cancelGeneration()
-		if err != nil && !(interrupted.Load() && errors.Is(err, context.Canceled)) {
-			return fmt.Errorf("failed to generate decks: %w", err)
-		}
-
-		// Stop progress reporter
-		close(stopProgress)
-		generationDone.Wait()
-		fprintln(os.Stderr) // New line after progress
-
-		generationTime = time.Since(startTime)
-		stats = fuzzer.GetStats()
-	}
-
-	if mode != fuzzModeGenetic {
-		// Handle --from-saved: add mutations of saved decks
-		if fromSaved > 0 && !interrupted.Load() {
+		// Seed decks, saved-deck mutations and variations are evaluated
+		// ahead of the generated decks.
+		extraDecks := seedDecks
+		if fromSaved > 0 && !resumingSession && !interrupted.Load() {
 			savedDecks, err := loadSavedDecksForSeeding(fromSaved, player, verbose)
 			if err != nil {
 				return fmt.Errorf("failed to load saved decks for seeding: %w", err)
 			}
 			if len(savedDecks) > 0 {
-				mutations := generateDeckMutations(savedDecks, player, count, fuzzerCfg.MutationIntensity, verbose)
-				generatedDecks = append(generatedDecks, mutations...)
+				mutations := generateDeckMutations(savedDecks, player, count, fuzzerCfg.MutationIntensity, lineage, verbose)
+				extraDecks = append(extraDecks, mutations...)
 				if verbose {
 					fprintf(os.Stderr, "Added %d mutations from %d saved decks\n", len(mutations), len(savedDecks))
 				}
 			}
 		}
-
-		// Handle --based-on: load a specific deck and generate variations
-		if basedOn != "" && !interrupted.Load() {
+		if basedOn != "" && !resumingSession && !interrupted.Load() {
 			baseDeck, err := loadDeckFromStorage(basedOn, verbose)
 			if err != nil {
 				return fmt.Errorf("failed to load deck from storage: %w", err)
 			}
-			variations := generateVariations(baseDeck, player, count, fuzzerCfg.MutationIntensity, verbose)
+			variations := generateVariations(baseDeck, player, count, fuzzerCfg.MutationIntensity, lineage, verbose)
 			if len(variations) > 0 {
-				generatedDecks = append(generatedDecks, variations...)
+				extraDecks = append(extraDecks, variations...)
 				if verbose {
 					fprintf(os.Stderr, "Added %d variations based on deck: %s\n", len(variations), strings.Join(baseDeck, ", "))
 				}
 			}
 		}
+
+		var storage *leaderboard.Storage
+		if storagePath != "" {
+			storage, err = leaderboard.NewStorage(storagePath)
+			if err != nil {
+				if verbose {
+					fprintf(os.Stderr, "Warning: failed to open storage: %v\n", err)
+				}
+				storage = nil
+			} else {
+				defer closeFile(storage)
+			}
+		}
+
+		streamOpts := fuzzStreamOptions{
+			workers:    workers,
+			keep:       fuzzPoolSize(top),
+			sortBy:     sortBy,
+			minOverall: minOverall,
+			minSynergy: minSynergy,
+			archetypes: normalizedArchetypes,
+			groupKeep:  top,
+			storage:    storage,
+			evalOpts:   evalOpts,
+			accept:     deckConstraintChecker(constraintRules, player),
+		}
+		if ensureArchetypes {
+			streamOpts.groupBy = append(streamOpts.groupBy, resultArchetype)
+		}
+		if ensureElixirBuckets {
+			streamOpts.groupBy = append(streamOpts.groupBy, resultElixirBucket)
+		}
+		if session != nil {
+			if resumingSession {
+				fuzzer.ResumeFrom(session.RNG)
+			}
+			streamOpts.explored = session.explored
+			streamOpts.prior = session.Results
+			streamOpts.checkpointEvery = fuzzSessionCheckpointInterval
+			streamOpts.checkpoint = func(kept []FuzzingResult, streamed fuzzStreamStats) {
+				if err := session.Checkpoint(fuzzer, kept, streamed, fuzzSessionRunning); err != nil {
+					fprintf(os.Stderr, "Warning: %v\n", err)
+				}
+			}
+		}
+		if verbose {
+			streamOpts.total = fuzzerCfg.Count + len(extraDecks)
+			fprintf(os.Stderr, "Generating and evaluating %d decks with %d workers...\n", streamOpts.total, workers)
+		}
+
+		// Generation, evaluation and filtering run as one pipeline that only
+		// keeps the best decks, so memory does not grow with --count.
+		startTime := time.Now()
+		streamCtx, cancelStream := context.WithCancel(ctx)
+		canceler.Set(cancelStream)
+		decks := chainDecks(streamCtx, extraDecks, fuzzer.StreamDecks(streamCtx))
+		var streamed fuzzStreamStats
+		pooledResults, streamed, err = streamFuzzResults(streamCtx, decks, player, playerTag, streamOpts)
+		canceler.Clear()
+		cancelStream()
+		if err != nil && !(interrupted.Load() && errors.Is(err, context.Canceled)) {
+			return fmt.Errorf("failed to fuzz decks: %w", err)
+		}
+		if session != nil {
+			status := fuzzSessionComplete
+			if interrupted.Load() {
+				status = fuzzSessionInterrupted
+			}
+			if err := session.Checkpoint(fuzzer, pooledResults, streamed, status); err != nil {
+				return err
+			}
+			if status == fuzzSessionInterrupted {
+				fprintf(os.Stderr, "Session saved: resume with 'cr-api deck fuzz resume %s'\n", session.path)
+			}
+			streamed = session.Totals(streamed)
+		}
+		streamStats = &streamed
+
+		generationTime = time.Since(startTime)
+		stats = fuzzer.GetStats()
+		stats.Generated += len(extraDecks)
+		stats.Success += len(extraDecks)
 	}
 
 	if verbose {
+		generatedCount := len(generatedDecks)
+		if pooledResults != nil || streamStats != nil {
+			generatedCount = stats.Generated
+		}
 		fprintf(os.Stderr, "\nGenerated %d decks in %v (%.1f decks/sec)\n",
-			len(generatedDecks), generationTime.Round(time.Millisecond),
-			float64(len(generatedDecks))/generationTime.Seconds())
+			generatedCount, generationTime.Round(time.Millisecond),
+			float64(generatedCount)/generationTime.Seconds())
 		fprintf(os.Stderr, "Success: %d, Failed: %d\n", stats.Success, stats.Failed)
 		if stats.SkippedElixir > 0 {
 			fprintf(os.Stderr, "Skipped (elixir): %d\n", stats.SkippedElixir)
@@ -726,36 +970,41 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 		fprintf(os.Stderr, "\n")
 	}
 
-	if len(generatedDecks) == 0 {
-		if interrupted.Load() {
-			fprintln(os.Stderr, "\nInterrupted before any decks were generated.")
-			return nil
+	// Evaluate decks (streaming and distributed runs are already evaluated)
+	evaluationResults := pooledResults
+	if mode == fuzzModeGenetic && !distributed {
+		if len(generatedDecks) == 0 {
+			if interrupted.Load() {
+				fprintln(os.Stderr, "\nInterrupted before any decks were generated.")
+				return nil
+			}
+			return fmt.Errorf("no decks were successfully generated")
 		}
-		return fmt.Errorf("no decks were successfully generated")
-	}
 
-	// Evaluate decks
-	if verbose {
-		fprintf(os.Stderr, "Evaluating %d decks with %d workers...\n", len(generatedDecks), workers)
-	}
-
-	evaluationCtx, cancelEvaluation := context.WithCancel(ctx)
-	canceler.Set(cancelEvaluation)
-	evaluationResults, evalErr := evaluateGeneratedDecks(
-		evaluationCtx,
-		generatedDecks,
-		player,
-		playerTag,
-		storagePath,
-		workers,
-		verbose,
-	)
-	canceler.Clear()
-	cancelEvaluation()
-	if evalErr != nil && !(interrupted.Load() && errors.Is(evalErr, context.Canceled)) {
-		return fmt.Errorf("failed to evaluate decks: %w", evalErr)
+		if verbose {
+			fprintf(os.Stderr, "Evaluating %d decks with %d workers...\n", len(generatedDecks), workers)
+		}
+
+		evaluationCtx, cancelEvaluation := context.WithCancel(ctx)
+		canceler.Set(cancelEvaluation)
+		var evalErr error
+		evaluationResults, evalErr = evaluateGeneratedDecks(
+			evaluationCtx,
+			generatedDecks,
+			player,
+			playerTag,
+			storagePath,
+			evalOpts,
+			workers,
+			verbose,
+		)
+		canceler.Clear()
+		cancelEvaluation()
+		if evalErr != nil && !(interrupted.Load() && errors.Is(evalErr, context.Canceled)) {
+			return fmt.Errorf("failed to evaluate decks: %w", evalErr)
+		}
 	}
-	if len(evaluationResults) == 0 {
+	if (streamStats != nil && streamStats.Evaluated == 0) || (streamStats == nil && len(evaluationResults) == 0) {
 		if interrupted.Load() {
 			fprintln(os.Stderr, "\nInterrupted before any decks were evaluated.")
 			return nil
@@ -763,40 +1012,65 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("no decks were evaluated")
 	}
 
-	// Filter by score thresholds
+	// Filter by score thresholds. A streaming run filtered as it went, so
+	// its counts come from the stream rather than the kept pool.
 	filteredResults := filterResultsByScore(evaluationResults, minOverall, minSynergy, verbose)
+	passedScore, passedArchetype := len(filteredResults), 0
+	if streamStats != nil {
+		passedScore, passedArchetype = streamStats.PassedScore, streamStats.PassedArchetype
+	}
 
-	if len(filteredResults) == 0 {
+	if passedScore == 0 {
 		return fmt.Errorf("no decks passed the score filters (min-overall: %.1f, min-synergy: %.1f)", minOverall, minSynergy)
 	}
 
 	if verbose {
-		fprintf(os.Stderr, "%d decks passed score filters\n", len(filteredResults))
+		fprintf(os.Stderr, "%d decks passed score filters\n", passedScore)
 	}
 
 	// Filter by archetype if specified
 	archetypeFilteredResults := filterResultsByArchetype(filteredResults, normalizedArchetypes, verbose)
+	if streamStats == nil {
+		passedArchetype = len(archetypeFilteredResults)
+	}
 
-	if len(archetypeFilteredResults) == 0 && len(normalizedArchetypes) > 0 {
+	if passedArchetype == 0 && len(normalizedArchetypes) > 0 {
 		return fmt.Errorf("no decks matched the specified archetypes: %s", strings.Join(normalizedArchetypes, ", "))
 	}
 
 	if len(normalizedArchetypes) > 0 {
 		if verbose {
-			fprintf(os.Stderr, "%d decks passed archetype filter (%s)\n", len(archetypeFilteredResults), strings.Join(normalizedArchetypes, ", "))
+			fprintf(os.Stderr, "%d decks passed archetype filter (%s)\n", passedArchetype, strings.Join(normalizedArchetypes, ", "))
 		}
 		filteredResults = archetypeFilteredResults
 	}
 
 	// Deduplicate results (remove identical decks)
 	dedupedResults := deduplicateResults(filteredResults)
-	if verbose {
+	totalFiltered := len(dedupedResults)
+	if streamStats != nil {
+		// Duplicates were dropped from the pool; the stream only knows how
+		// many decks passed the filters.
+		totalFiltered = passedArchetype
+		if verbose {
+			fprintf(os.Stderr, "Kept the best %d unique decks\n", len(dedupedResults))
+		}
+	} else if verbose {
 		fprintf(os.Stderr, "Removed %d duplicate decks, %d unique decks remaining\n", len(filteredResults)-len(dedupedResults), len(dedupedResults))
 	}
 
 	// Sort results
 	sortFuzzingResultsImpl(dedupedResults, sortBy)
 
+	// Collapse near-duplicates to the best deck of each cluster
+	if clusterDecks {
+		unclustered := len(dedupedResults)
+		dedupedResults = clusterResults(dedupedResults, clusterThreshold)
+		if verbose {
+			fprintf(os.Stderr, "Grouped %d decks into %d clusters of near-duplicates\n", unclustered, len(dedupedResults))
+		}
+	}
+
 	// Ensure archetype coverage if requested
 	if ensureArchetypes && mode != fuzzModeGenetic {
 		dedupedResults = ensureArchetypeCoverage(dedupedResults, top, verbose)
@@ -807,10 +1081,28 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 
 	// Get top N results
 	topResults := getTopResultsImpl(dedupedResults, top)
+	links := newDeckLinkEncoder(cmd.String("data-dir"))
+	for i := range topResults {
+		topResults[i].DeckLink = links.Link(topResults[i].Deck)
+	}
 
-	// Format and output results
-	if err := formatFuzzingResultsImpl(topResults, format, playerName, playerTag, fuzzerCfg, mode, generationTime, &stats, len(dedupedResults)); err != nil {
-		return fmt.Errorf("failed to format results: %w", err)
+	// Format and output results. Parquet saved with --output-dir is not
+	// also written to stdout.
+	if format != fuzzOutputParquet || outputDir == "" {
+		if err := formatFuzzingResultsImpl(topResults, format, playerName, playerTag, fuzzerCfg, mode, generationTime, &stats, totalFiltered); err != nil {
+			return fmt.Errorf("failed to format results: %w", err)
+		}
+	}
+	// Keep machine-readable formats clean on stdout.
+	reportOut := io.Writer(os.Stdout)
+	if format == fuzzOutputJSON || format == fuzzOutputCSV || format == fuzzOutputParquet {
+		reportOut = os.Stderr
+	}
+	if len(paretoFront) > 0 {
+		formatParetoFront(reportOut, gaObjectives, paretoFront, top)
+	}
+	if window != nil && len(topResults) > 0 {
+		displayLevelWindowFuzz(reportOut, window, fullPlayer, topResults[0].Deck, playerTag != "", evalOpts)
 	}
 
 	// Save to file if output-dir specified
@@ -823,9 +1115,28 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 		}
 	}
 
-	// Save top decks to persistent storage if requested
-	if saveTop {
-		if err := saveTopDecksToStorage(topResults, verbose); err != nil {
+	if len(topResults) > 0 {
+		if err := exportDeckImageIfRequested(ctx, cmd, "", topResults[0].Deck); err != nil {
+			return err
+		}
+	}
+
+	// Check for a new best before saving, which would raise the bar
+	if notifier := notifierFromCommand(cmd); notifier != nil {
+		notifyFuzzPersonalBest(ctx, notifier, topResults, playerTag)
+	}
+
+	// Save top decks to persistent storage if requested. Event scores are not
+	// comparable with the ladder scores kept there.
+	if saveTop && gameMode != evaluation.GameModeStandard {
+		fprintf(os.Stderr, "Skipping --save-top: %s scores are not stored alongside standard scores\n",
+			evaluation.LookupGameMode(gameMode).Name)
+	} else if saveTop {
+		retention := fuzzstorage.RetentionPolicy{
+			PerArchetype:    cmd.Int("keep-per-archetype"),
+			PerElixirBucket: cmd.Int("keep-per-elixir-bucket"),
+		}
+		if err := saveTopDecksToStorage(topResults, retention, lineage, verbose); err != nil {
 			return fmt.Errorf("failed to save top decks to storage: %w", err)
 		}
 	}
@@ -835,6 +1146,8 @@ func deckFuzzCommand(ctx context.Context, cmd *cli.Command) error {
 
 // FuzzingResult represents a single fuzzing result with deck and evaluation
 type FuzzingResult struct {
+	// Name is generated from the deck's win conditions, elixir, and archetype
+	Name                string `json:",omitempty"`
 	Deck                []string
 	OverallScore        float64
 	ContextualScore     float64
@@ -849,7 +1162,13 @@ type FuzzingResult struct {
 	AvgElixir           float64
 	Archetype           string
 	ArchetypeConfidence float64
-	EvaluatedAt         time.Time
+	// HybridPrimary and HybridSecondary are the two halves of a hybrid deck
+	HybridPrimary   string `json:",omitempty"`
+	HybridSecondary string `json:",omitempty"`
+	EvaluatedAt     time.Time
+	DeckLink        string `json:",omitempty"`
+	// Variants counts the near-duplicate decks --cluster folded into this one
+	Variants int `json:",omitempty"`
 }
 
 // evaluateGeneratedDecks evaluates a list of generated decks
@@ -859,6 +1178,7 @@ func evaluateGeneratedDecks(
 	player *clashroyale.Player,
 	playerTag string,
 	storagePath string,
+	evalOpts evaluation.EvaluateOptions,
 	workers int,
 	verbose bool,
 ) ([]FuzzingResult, error) {
@@ -882,11 +1202,11 @@ func evaluateGeneratedDecks(
 
 	// Use parallel evaluation if workers > 1
 	if workers > 1 {
-		return evaluateDecksParallel(ctx, decks, player, playerTag, playerContext, storage, workers, verbose)
+		return evaluateDecksParallel(ctx, decks, player, playerTag, playerContext, evalOpts, storage, workers, verbose)
 	}
 
 	// Sequential evaluation (original behavior)
-	return evaluateDecksSequential(ctx, decks, player, playerTag, playerContext, storage, verbose)
+	return evaluateDecksSequential(ctx, decks, player, playerTag, playerContext, evalOpts, storage, verbose)
 }
 
 // evaluateDecksSequential evaluates decks sequentially (original implementation)
@@ -896,6 +1216,7 @@ func evaluateDecksSequential(
 	player *clashroyale.Player,
 	playerTag string,
 	playerContext *evaluation.PlayerContext,
+	evalOpts evaluation.EvaluateOptions,
 	storage *leaderboard.Storage,
 	verbose bool,
 ) ([]FuzzingResult, error) {
@@ -923,7 +1244,7 @@ func evaluateDecksSequential(
 		if err := ctx.Err(); err != nil {
 			return results, err
 		}
-		result := evaluateSingleDeck(deckCards, player, playerTag, synergyDB, playerContext)
+		result := evaluateSingleDeck(deckCards, player, playerTag, synergyDB, playerContext, evalOpts)
 		results = append(results, result)
 
 		// Save to persistent storage if available
@@ -952,6 +1273,7 @@ func evaluateDecksParallel(
 	player *clashroyale.Player,
 	playerTag string,
 	playerContext *evaluation.PlayerContext,
+	evalOpts evaluation.EvaluateOptions,
 	storage *leaderboard.Storage,
 	workers int,
 	verbose bool,
@@ -993,7 +1315,7 @@ func evaluateDecksParallel(
 						return
 					}
 					// Evaluate deck and send to result channel
-					result := evaluateSingleDeck(deckCards, player, playerTag, synergyDB, playerContext)
+					result := evaluateSingleDeck(deckCards, player, playerTag, synergyDB, playerContext, evalOpts)
 					select {
 					case <-ctx.Done():
 						return
@@ -1055,45 +1377,63 @@ func evaluateSingleDeck(
 	playerTag string,
 	synergyDB *deck.SynergyDatabase,
 	playerContext *evaluation.PlayerContext,
+	evalOpts evaluation.EvaluateOptions,
 ) FuzzingResult {
 	// Convert deck strings to CardCandidates
 	candidates := convertDeckToCandidates(deckCards, player)
 
-	// Run evaluation
-	evalResult := evaluation.Evaluate(candidates, synergyDB, playerContext)
-
-	contextualScore := evalResult.OverallScore
-	ladderScore := 0.0
-	normalizedScore := evalResult.OverallScore
-	deckLevelRatio := 1.0
-	normalizationFactor := 1.0
-	if evalResult.OverallBreakdown != nil {
-		contextualScore = evalResult.OverallBreakdown.ContextualScore
-		ladderScore = evalResult.OverallBreakdown.LadderScore
-		normalizedScore = evalResult.OverallBreakdown.NormalizedScore
-		deckLevelRatio = evalResult.OverallBreakdown.DeckLevelRatio
-		normalizationFactor = evalResult.OverallBreakdown.NormalizationFactor
+	// Reuse a cached evaluation of the same cards at the same levels
+	var (
+		cacheKey string
+		scores   evaluation.CachedEvaluation
+		hit      bool
+	)
+	if variant, ok := evaluation.EvaluationVariant(playerContext, evalOpts); ok && fuzzEvaluationCache != nil {
+		cacheKey = evaluation.EvaluationCacheKey(candidates, variant)
+		scores, hit = fuzzEvaluationCache.Get(cacheKey)
+	}
+	if !hit {
+		started := time.Now()
+		result := evaluation.EvaluateWithOptions(candidates, synergyDB, playerContext, evalOpts)
+		metrics.EvaluationSeconds.ObserveSince(started)
+		scores = evaluation.SummarizeEvaluation(result)
+		// A failed scorer plugin gets another try the next time the deck comes up
+		if cacheKey != "" && !result.PluginFailed() {
+			fuzzEvaluationCache.Put(cacheKey, scores)
+		}
 	}
 
 	return FuzzingResult{
+		Name:                deck.GenerateDeckName(candidates, namingArchetype(string(scores.Archetype), string(scores.HybridPrimary), string(scores.HybridSecondary))),
 		Deck:                deckCards,
-		OverallScore:        evalResult.OverallScore,
-		ContextualScore:     contextualScore,
-		LadderScore:         ladderScore,
-		NormalizedScore:     normalizedScore,
-		DeckLevelRatio:      deckLevelRatio,
-		NormalizationFactor: normalizationFactor,
-		AttackScore:         evalResult.Attack.Score,
-		DefenseScore:        evalResult.Defense.Score,
-		SynergyScore:        evalResult.Synergy.Score,
-		VersatilityScore:    evalResult.Versatility.Score,
-		AvgElixir:           evalResult.AvgElixir,
-		Archetype:           string(evalResult.DetectedArchetype),
-		ArchetypeConfidence: evalResult.ArchetypeConfidence,
+		OverallScore:        scores.OverallScore,
+		ContextualScore:     scores.ContextualScore,
+		LadderScore:         scores.LadderScore,
+		NormalizedScore:     scores.NormalizedScore,
+		DeckLevelRatio:      scores.DeckLevelRatio,
+		NormalizationFactor: scores.NormalizationFactor,
+		AttackScore:         scores.Attack,
+		DefenseScore:        scores.Defense,
+		SynergyScore:        scores.Synergy,
+		VersatilityScore:    scores.Versatility,
+		AvgElixir:           scores.AvgElixir,
+		Archetype:           string(scores.Archetype),
+		ArchetypeConfidence: scores.ArchetypeConfidence,
+		HybridPrimary:       string(scores.HybridPrimary),
+		HybridSecondary:     string(scores.HybridSecondary),
 		EvaluatedAt:         time.Now(),
 	}
 }
 
+// namingArchetype is the archetype to name a deck after: both halves of a
+// hybrid, otherwise the detected archetype
+func namingArchetype(archetype, hybridPrimary, hybridSecondary string) string {
+	if hybridPrimary != "" {
+		return hybridPrimary + "/" + hybridSecondary
+	}
+	return archetype
+}
+
 // saveDeckToStorage saves a deck evaluation result to persistent storage
 func saveDeckToStorage(result FuzzingResult, _ string, storage *leaderboard.Storage) {
 	// Reconstruct evalResult for storage (we only store what we need)
@@ -1111,7 +1451,7 @@ func saveDeckToStorage(result FuzzingResult, _ string, storage *leaderboard.Stor
 		AvgElixir:         result.AvgElixir,
 		EvaluatedAt:       result.EvaluatedAt,
 		PlayerTag:         "",
-		EvaluationVersion: "1.0.0",
+		EvaluationVersion: evaluation.CurrentScoringVersion,
 	}
 	if _, _, err := storage.InsertDeck(entry); err != nil {
 		fprintf(os.Stderr, "Warning: failed to store deck: %v\n", err)
@@ -1314,6 +1654,33 @@ func filterResultsByScore(results []FuzzingResult, minOverall, minSynergy float6
 	return filtered
 }
 
+// fuzzArchetypeNames lists the archetypes results can be filtered on: those
+// of the registered scorers, custom ones included, then hybrid.
+func fuzzArchetypeNames() []string {
+	scorers := evaluation.ArchetypeScorers()
+	names := make([]string, 0, len(scorers)+1)
+	for _, scorer := range scorers {
+		names = append(names, string(scorer.Archetype()))
+	}
+	return append(names, string(evaluation.ArchetypeHybrid))
+}
+
+// resolveFuzzArchetypes maps --archetypes values onto the registered names,
+// ignoring case and surrounding spaces.
+func resolveFuzzArchetypes(values []string) ([]string, error) {
+	known := fuzzArchetypeNames()
+	resolved := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		i := slices.IndexFunc(known, func(name string) bool { return strings.EqualFold(name, value) })
+		if i < 0 {
+			return nil, fmt.Errorf("invalid archetype '%s' (must be one of: %s)", value, strings.Join(known, ", "))
+		}
+		resolved = append(resolved, known[i])
+	}
+	return resolved, nil
+}
+
 // filterResultsByArchetype filters results to only include decks matching specified archetypes
 func filterResultsByArchetype(results []FuzzingResult, archetypes []string, _ bool) []FuzzingResult {
 	if len(archetypes) == 0 {
@@ -1344,8 +1711,8 @@ func ensureArchetypeCoverage(results []FuzzingResult, top int, verbose bool) []F
 		return results
 	}
 
-	// Define all archetypes we want to cover
-	allArchetypes := []string{"beatdown", "control", "cycle", "bridge", "siege", "bait", "graveyard", "miner", "hybrid", "unknown"}
+	// Cover every registered archetype, custom ones included
+	allArchetypes := append(fuzzArchetypeNames(), string(evaluation.ArchetypeUnknown))
 
 	// Group results by archetype
 	archetypeGroups := make(map[string][]FuzzingResult)
@@ -1535,6 +1902,43 @@ func deduplicateResults(results []FuzzingResult) []FuzzingResult {
 	return deduped
 }
 
+// clusterResults groups near-duplicate results (see deck.ClusterDecks) and
+// keeps the first, and so best, deck of each cluster with its variant count.
+// Results must already be sorted.
+func clusterResults(results []FuzzingResult, threshold float64) []FuzzingResult {
+	decks := make([][]string, len(results))
+	for i, result := range results {
+		decks[i] = result.Deck
+	}
+	clusters := deck.ClusterDecks(decks, threshold)
+	representatives := make([]FuzzingResult, len(clusters))
+	for i, cluster := range clusters {
+		representatives[i] = results[cluster.Representative]
+		representatives[i].Variants = cluster.Variants()
+	}
+	return representatives
+}
+
+// fuzzLineageDepth bounds how many generations of ancestors --save-top
+// stores for each saved deck
+const fuzzLineageDepth = 20
+
+// lineageRecords collects the recorded ancestry of results for storage
+func lineageRecords(results []FuzzingResult, lineage *deck.LineageRecorder) []fuzzstorage.LineageRecord {
+	var records []fuzzstorage.LineageRecord
+	for _, result := range results {
+		for _, edge := range lineage.Ancestry(result.Deck, fuzzLineageDepth) {
+			records = append(records, fuzzstorage.LineageRecord{
+				Cards:      edge.Cards,
+				Parents:    edge.Parents,
+				Operation:  edge.Operation,
+				Generation: edge.Generation,
+			})
+		}
+	}
+	return records
+}
+
 // deckKeyForResult creates a unique key for a deck based on canonicalized card names.
 func deckKeyForResult(result FuzzingResult) string {
 	return deck.CanonicalDeckKey(result.Deck)
@@ -1552,6 +1956,7 @@ func loadPlayerFromAnalysis(analysisFile, analysisDir, playerTag string) (*clash
 	player := &clashroyale.Player{
 		Name:  loadedAnalysis.PlayerName,
 		Tag:   loadedAnalysis.PlayerTag,
+		Arena: clashroyale.Arena{ID: loadedAnalysis.CardAnalysis.ArenaID},
 		Cards: make([]clashroyale.Card, 0, len(loadedAnalysis.CardAnalysis.CardLevels)),
 	}
 
@@ -1571,8 +1976,85 @@ func loadPlayerFromAnalysis(analysisFile, analysisDir, playerTag string) (*clash
 	return player, loadedAnalysis.PlayerName, nil
 }
 
-// saveTopDecksToStorage saves the top fuzzing results to persistent storage
-func saveTopDecksToStorage(results []FuzzingResult, verbose bool) error {
+// notifyFuzzPersonalBest posts an event when the run's best deck beats every
+// deck in persistent storage.
+func notifyFuzzPersonalBest(ctx context.Context, notifier notify.Notifier, results []FuzzingResult, playerTag string) {
+	if len(results) == 0 {
+		return
+	}
+	best := results[0]
+	for _, result := range results[1:] {
+		if result.OverallScore > best.OverallScore {
+			best = result
+		}
+	}
+
+	previous, err := storedBestFuzzScore()
+	if err != nil {
+		fprintf(os.Stderr, "Warning: failed to read the stored best score: %v\n", err)
+		return
+	}
+	if best.OverallScore <= previous {
+		return
+	}
+	sendNotification(ctx, notifier, notify.Event{
+		Kind:    notify.KindPersonalBest,
+		Title:   fmt.Sprintf("New personal best deck found (score %.1f)", best.OverallScore),
+		Message: fmt.Sprintf("%s deck, %.2f avg elixir, previous best %.2f\n%s", best.Archetype, best.AvgElixir, previous, strings.Join(best.Deck, ", ")),
+		Time:    time.Now().UTC(),
+		Fields: map[string]any{
+			"player_tag":    playerTag,
+			"cards":         best.Deck,
+			"overall_score": best.OverallScore,
+			"previous_best": previous,
+			"archetype":     best.Archetype,
+			"avg_elixir":    best.AvgElixir,
+			"deck_link":     best.DeckLink,
+		},
+	})
+}
+
+// storedBestFuzzScore returns the highest overall score in persistent
+// storage, or 0 when it is empty.
+func storedBestFuzzScore() (float64, error) {
+	storage, err := fuzzstorage.NewStorage("")
+	if err != nil {
+		return 0, err
+	}
+	defer closeFile(storage)
+	top, err := storage.GetTopN(1)
+	if err != nil || len(top) == 0 {
+		return 0, err
+	}
+	return top[0].OverallScore, nil
+}
+
+// noveltyArchiveSize is how many top stored decks novelty search steers
+// away from.
+const noveltyArchiveSize = 200
+
+// storedFuzzDecks returns the card lists of the limit best decks in
+// persistent storage.
+func storedFuzzDecks(limit int) ([][]string, error) {
+	storage, err := fuzzstorage.NewStorage("")
+	if err != nil {
+		return nil, err
+	}
+	defer closeFile(storage)
+	top, err := storage.GetTopN(limit)
+	if err != nil {
+		return nil, err
+	}
+	decks := make([][]string, len(top))
+	for i, entry := range top {
+		decks[i] = entry.Cards
+	}
+	return decks, nil
+}
+
+// saveTopDecksToStorage saves the top fuzzing results to persistent storage and
+// prunes it to the retention quotas
+func saveTopDecksToStorage(results []FuzzingResult, retention fuzzstorage.RetentionPolicy, lineage *deck.LineageRecorder, verbose bool) error {
 	storage, err := fuzzstorage.NewStorage("")
 	if err != nil {
 		return fmt.Errorf("failed to open storage: %w", err)
@@ -1583,22 +2065,31 @@ func saveTopDecksToStorage(results []FuzzingResult, verbose bool) error {
 	entries := make([]fuzzstorage.DeckEntry, len(results))
 	for i, result := range results {
 		entries[i] = fuzzstorage.DeckEntry{
-			Cards:            result.Deck,
-			OverallScore:     result.OverallScore,
-			AttackScore:      result.AttackScore,
-			DefenseScore:     result.DefenseScore,
-			SynergyScore:     result.SynergyScore,
-			VersatilityScore: result.VersatilityScore,
-			AvgElixir:        result.AvgElixir,
-			Archetype:        result.Archetype,
-			ArchetypeConf:    result.ArchetypeConfidence,
-			EvaluatedAt:      result.EvaluatedAt,
-		}
+			Name:              result.Name,
+			Cards:             result.Deck,
+			OverallScore:      result.OverallScore,
+			AttackScore:       result.AttackScore,
+			DefenseScore:      result.DefenseScore,
+			SynergyScore:      result.SynergyScore,
+			VersatilityScore:  result.VersatilityScore,
+			AvgElixir:         result.AvgElixir,
+			Archetype:         result.Archetype,
+			ArchetypeConf:     result.ArchetypeConfidence,
+			HybridPrimary:     result.HybridPrimary,
+			HybridSecondary:   result.HybridSecondary,
+			EvaluatedAt:       result.EvaluatedAt,
+			EvaluationVersion: evaluation.CurrentScoringVersion,
+		}
+	}
+
+	saved, pruned, err := storage.SaveTopDecksWithRetention(entries, retention)
+	if err != nil {
+		return fmt.Errorf("failed to save decks: %w", err)
 	}
 
-	saved, err := storage.SaveTopDecks(entries)
+	traced, err := storage.SaveLineage(lineageRecords(results, lineage))
 	if err != nil {
-		return fmt.Errorf("failed to save decks: %w", err)
+		return fmt.Errorf("failed to save deck lineage: %w", err)
 	}
 
 	total, _ := storage.Count()
@@ -1607,6 +2098,12 @@ func saveTopDecksToStorage(results []FuzzingResult, verbose bool) error {
 	if verbose {
 		fprintf(os.Stderr, "\nTop decks saved to storage: %s\n", dbPath)
 		fprintf(os.Stderr, "  New decks saved: %d\n", saved)
+		if pruned > 0 {
+			fprintf(os.Stderr, "  Pruned to retention quotas: %d\n", pruned)
+		}
+		if traced > 0 {
+			fprintf(os.Stderr, "  Decks with new lineage: %d\n", traced)
+		}
 		fprintf(os.Stderr, "  Total decks in storage: %d\n", total)
 	}
 
@@ -1627,7 +2124,10 @@ func deckFuzzListCommand(ctx context.Context, cmd *cli.Command) error {
 	}
 	defer closeFile(storage)
 
-	queryOpts := buildFuzzQueryOptions(cmd)
+	queryOpts, err := buildFuzzQueryOptions(cmd)
+	if err != nil {
+		return err
+	}
 
 	// Query decks
 	decks, err := storage.Query(queryOpts)
@@ -1638,6 +2138,9 @@ func deckFuzzListCommand(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return fmt.Errorf("failed to query archetype histogram: %w", err)
 	}
+	if err := storage.AttachRecords(decks); err != nil {
+		return fmt.Errorf("failed to load deck results: %w", err)
+	}
 
 	var theoreticalByID map[int]fuzzstorage.DeckEntry
 	if playerTag != "" && len(decks) > 0 {
@@ -1709,6 +2212,8 @@ func dispatchFuzzListFormatter(
 		return formatListResultsJSON(decks, dbPath, total, histogram, theoreticalByID)
 	case fuzzOutputCSV:
 		return formatListResultsCSV(decks, theoreticalByID)
+	case fuzzOutputParquet:
+		return writeParquetToStdout(func(w io.Writer) error { return formatListResultsParquet(w, decks, theoreticalByID) })
 	case fuzzOutputDetailed:
 		return formatListResultsDetailed(decks, dbPath, total, histogram, theoreticalByID)
 	default:
@@ -1728,7 +2233,10 @@ func deckFuzzUpdateCommand(ctx context.Context, cmd *cli.Command) error {
 	}
 	defer closeFile(storage)
 
-	queryOpts := buildFuzzQueryOptions(cmd)
+	queryOpts, err := buildFuzzQueryOptions(cmd)
+	if err != nil {
+		return err
+	}
 
 	entries, err := storage.Query(queryOpts)
 	if err != nil {
@@ -1797,10 +2305,11 @@ func resolveFuzzWorkers(cmd *cli.Command, autoSwitch, verbose bool) int {
 }
 
 // buildFuzzQueryOptions reads the shared filter flags (--top, --archetype,
-// --min/max-score, --min/max-elixir) and turns them into a QueryOptions
+// --min/max-score, --min/max-elixir, --deck-tag, --favorites, and the card
+// filters) and turns them into a QueryOptions
 // struct. Used by both `deck fuzz list` and `deck fuzz update` so a flag
 // added in one place applies in both.
-func buildFuzzQueryOptions(cmd *cli.Command) fuzzstorage.QueryOptions {
+func buildFuzzQueryOptions(cmd *cli.Command) (fuzzstorage.QueryOptions, error) {
 	opts := fuzzstorage.QueryOptions{Limit: cmd.Int("top")}
 	if v := cmd.String("archetype"); v != "" {
 		opts.Archetype = v
@@ -1817,7 +2326,12 @@ func buildFuzzQueryOptions(cmd *cli.Command) fuzzstorage.QueryOptions {
 	if v := cmd.Float64("max-elixir"); v > 0 {
 		opts.MaxAvgElixir = v
 	}
-	return opts
+	opts.Tags = cmd.StringSlice(deckTagFlagName)
+	opts.FavoritesOnly = cmd.Bool("favorites")
+	if err := applyCardQueryFilters(cmd, &opts); err != nil {
+		return opts, err
+	}
+	return opts, nil
 }
 
 // loadFuzzPlayerContext fetches the player profile and derived
@@ -1890,7 +2404,7 @@ func reevaluateStoredDecks(entries []fuzzstorage.DeckEntry, player *clashroyale.
 		wg.Go(func() {
 			synergyDB := deck.NewSynergyDatabase()
 			for work := range workChan {
-				result := evaluateSingleDeck(work.entry.Cards, player, playerTag, synergyDB, playerContext)
+				result := evaluateSingleDeck(work.entry.Cards, player, playerTag, synergyDB, playerContext, evaluation.EvaluateOptions{})
 				updated := applyEvaluationToEntry(work.entry, result)
 				resultChan <- storedDeckResult{index: work.index, entry: updated}
 			}
@@ -1925,7 +2439,7 @@ func reevaluateStoredDecksSequential(entries []fuzzstorage.DeckEntry, player *cl
 	bar := newReevaluateProgressBar(len(entries), verbose)
 
 	for i, entry := range entries {
-		result := evaluateSingleDeck(entry.Cards, player, playerTag, synergyDB, playerContext)
+		result := evaluateSingleDeck(entry.Cards, player, playerTag, synergyDB, playerContext, evaluation.EvaluateOptions{})
 		results[i] = applyEvaluationToEntry(entry, result)
 		advanceReevaluateBar(bar)
 	}
@@ -1974,7 +2488,13 @@ func applyEvaluationToEntry(entry fuzzstorage.DeckEntry, result FuzzingResult) f
 	entry.AvgElixir = result.AvgElixir
 	entry.Archetype = result.Archetype
 	entry.ArchetypeConf = result.ArchetypeConfidence
+	entry.HybridPrimary = result.HybridPrimary
+	entry.HybridSecondary = result.HybridSecondary
+	if entry.Name == "" {
+		entry.Name = result.Name
+	}
 	entry.EvaluatedAt = result.EvaluatedAt
+	entry.EvaluationVersion = evaluation.CurrentScoringVersion
 	return entry
 }
 
@@ -1992,7 +2512,7 @@ func formatListResultsSummary(
 
 	w := new(tabwriter.Writer)
 	w.Init(os.Stdout, 0, 8, 2, ' ', 0)
-	fprintln(w, "Rank\tDeck\tOverall\tAttack\tDefense\tSynergy\tElixir\tArchetype")
+	fprintln(w, "Rank\tName\tDeck\tOverall\tAttack\tDefense\tSynergy\tElixir\tArchetype\tRecord\tTags")
 
 	for i, deck := range decks {
 		deckStr := strings.Join(deck.Cards, ", ")
@@ -2002,13 +2522,15 @@ func formatListResultsSummary(
 		synergy := formatScoreTransition(theoreticalByID, deck.ID, deck.SynergyScore, func(entry fuzzstorage.DeckEntry) float64 { return entry.SynergyScore })
 		if len(deckStr) > 50 {
 			firstLine := strings.Join(deck.Cards[:4], ", ")
-			fprintf(w, "%d\t%s,\t%s\t%s\t%s\t%s\t%.2f\t%s\n",
-				i+1, firstLine, overall, attack, defense, synergy, deck.AvgElixir, deck.Archetype)
+			fprintf(w, "%d\t%s\t%s,\t%s\t%s\t%s\t%s\t%.2f\t%s\t%s\t%s\n",
+				i+1, storedDeckName(deck), firstLine, overall, attack, defense, synergy, deck.AvgElixir, storedArchetypeLabel(deck),
+				formatDeckRecord(deck.Record), curationLabel(deck))
 			secondLine := strings.Join(deck.Cards[4:], ", ")
-			fprintf(w, "\t%s\n", secondLine)
+			fprintf(w, "\t\t%s\n", secondLine)
 		} else {
-			fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%.2f\t%s\n",
-				i+1, deckStr, overall, attack, defense, synergy, deck.AvgElixir, deck.Archetype)
+			fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%.2f\t%s\t%s\t%s\n",
+				i+1, storedDeckName(deck), deckStr, overall, attack, defense, synergy, deck.AvgElixir, storedArchetypeLabel(deck),
+				formatDeckRecord(deck.Record), curationLabel(deck))
 		}
 	}
 
@@ -2022,13 +2544,17 @@ func formatListResultsSummary(
 }
 
 const (
-	jsonKeyCards        = "cards"
-	jsonKeyOverallScore = "overall_score"
-	jsonKeyArchetype    = "archetype"
-	jsonKeyResults      = "results"
-	csvHeaderArchetype  = "Archetype"
-	csvHeaderAttack     = "Attack"
-	fuzzModeGenetic     = "genetic"
+	jsonKeyCards             = "cards"
+	jsonKeyOverallScore      = "overall_score"
+	jsonKeyArchetype         = "archetype"
+	jsonKeyResults           = "results"
+	csvHeaderArchetype       = "Archetype"
+	csvHeaderHybridPrimary   = "HybridPrimary"
+	csvHeaderHybridSecondary = "HybridSecondary"
+	csvHeaderName            = "Name"
+	csvHeaderAttack          = "Attack"
+	fuzzModeGenetic          = "genetic"
+	fuzzModeRoleTemplate     = "role-template"
 )
 
 // formatListResultsJSON formats list results in JSON format
@@ -2043,6 +2569,7 @@ func formatListResultsJSON(
 	for _, deck := range decks {
 		result := map[string]any{
 			"id":                deck.ID,
+			"name":              storedDeckName(deck),
 			jsonKeyCards:        deck.Cards,
 			jsonKeyOverallScore: deck.OverallScore,
 			"attack_score":      deck.AttackScore,
@@ -2053,6 +2580,23 @@ func formatListResultsJSON(
 			jsonKeyArchetype:    deck.Archetype,
 			"archetype_conf":    deck.ArchetypeConf,
 			"evaluated_at":      deck.EvaluatedAt,
+			"tags":              deckTags(deck),
+			"favorite":          deck.Favorite,
+		}
+		if deck.Notes != "" {
+			result["notes"] = deck.Notes
+		}
+		if deck.HybridPrimary != "" {
+			result["hybrid_primary"] = deck.HybridPrimary
+			result["hybrid_secondary"] = deck.HybridSecondary
+		}
+		if deck.Record.Games() > 0 {
+			result["record"] = map[string]any{
+				"wins":     deck.Record.Wins,
+				"losses":   deck.Record.Losses,
+				"draws":    deck.Record.Draws,
+				"win_rate": deck.Record.WinRate(),
+			}
 		}
 		if theoreticalByID != nil {
 			if theoretical, ok := theoreticalByID[deck.ID]; ok {
@@ -2080,7 +2624,7 @@ func formatListResultsJSON(
 
 // formatListResultsCSV formats list results in CSV format
 func formatListResultsCSV(decks []fuzzstorage.DeckEntry, theoreticalByID map[int]fuzzstorage.DeckEntry) error {
-	header := []string{"Rank", "Deck", "Overall", csvHeaderAttack, "Defense", "Synergy", "Versatility", "AvgElixir", csvHeaderArchetype}
+	header := []string{"Rank", "Deck", "Overall", csvHeaderAttack, "Defense", "Synergy", "Versatility", "AvgElixir", csvHeaderArchetype, csvHeaderHybridPrimary, csvHeaderHybridSecondary, csvHeaderName, "Tags", "Favorite", "Notes", "Wins", "Losses", "Draws"}
 	if theoreticalByID != nil {
 		header = []string{
 			"Rank", "Deck",
@@ -2089,6 +2633,8 @@ func formatListResultsCSV(decks []fuzzstorage.DeckEntry, theoreticalByID map[int
 			"StoredDefense", "PlayerDefense",
 			"StoredSynergy", "PlayerSynergy",
 			"Versatility", "AvgElixir", csvHeaderArchetype,
+			csvHeaderHybridPrimary, csvHeaderHybridSecondary, csvHeaderName, "Tags", "Favorite", "Notes",
+			"Wins", "Losses", "Draws",
 		}
 	}
 	rows := make([][]string, 0, len(decks))
@@ -2118,6 +2664,12 @@ func formatListResultsCSV(decks []fuzzstorage.DeckEntry, theoreticalByID map[int
 				fmt.Sprintf("%.2f", deck.VersatilityScore),
 				fmt.Sprintf("%.2f", deck.AvgElixir),
 				deck.Archetype,
+				deck.HybridPrimary,
+				deck.HybridSecondary,
+				storedDeckName(deck),
+				strings.Join(deck.Tags, ";"),
+				strconv.FormatBool(deck.Favorite),
+				deck.Notes,
 			)
 		} else {
 			row = append(row,
@@ -2128,8 +2680,19 @@ func formatListResultsCSV(decks []fuzzstorage.DeckEntry, theoreticalByID map[int
 				fmt.Sprintf("%.2f", deck.VersatilityScore),
 				fmt.Sprintf("%.2f", deck.AvgElixir),
 				deck.Archetype,
+				deck.HybridPrimary,
+				deck.HybridSecondary,
+				storedDeckName(deck),
+				strings.Join(deck.Tags, ";"),
+				strconv.FormatBool(deck.Favorite),
+				deck.Notes,
 			)
 		}
+		row = append(row,
+			strconv.Itoa(deck.Record.Wins),
+			strconv.Itoa(deck.Record.Losses),
+			strconv.Itoa(deck.Record.Draws),
+		)
 		rows = append(rows, row)
 	}
 	return writeCSVDocument(os.Stdout, header, rows)
@@ -2148,7 +2711,7 @@ func formatListResultsDetailed(
 	printf("Total decks: %d\n\n", total)
 
 	for i, deck := range decks {
-		printf("=== Deck %d ===\n", i+1)
+		printf("=== Deck %d: %s ===\n", i+1, storedDeckName(deck))
 		printf("Cards: %s\n", strings.Join(deck.Cards, ", "))
 		if theoreticalByID != nil {
 			if theoretical, ok := theoreticalByID[deck.ID]; ok {
@@ -2168,7 +2731,17 @@ func formatListResultsDetailed(
 				deck.OverallScore, deck.AttackScore, deck.DefenseScore, deck.SynergyScore, deck.VersatilityScore)
 		}
 		printf("Avg Elixir: %.2f | Archetype: %s (%.0f%% confidence)\n",
-			deck.AvgElixir, deck.Archetype, deck.ArchetypeConf*100)
+			deck.AvgElixir, storedArchetypeLabel(deck), deck.ArchetypeConf*100)
+		if deck.Record.Games() > 0 {
+			printf("Record: %dW %dL %dD (%.0f%% win rate) | Theoretical score: %.2f\n",
+				deck.Record.Wins, deck.Record.Losses, deck.Record.Draws, deck.Record.WinRate()*100, deck.OverallScore)
+		}
+		if label := curationLabel(deck); label != "" {
+			printf("Tags: %s\n", label)
+		}
+		if deck.Notes != "" {
+			printf("Notes: %s\n", deck.Notes)
+		}
 		printf("Evaluated: %s\n\n", deck.EvaluatedAt.Format(time.RFC3339))
 	}
 
@@ -2304,7 +2877,7 @@ func loadSavedDecksForSeeding(n int, _ *clashroyale.Player, verbose bool) ([][]s
 // generateDeckMutations generates mutations of saved decks by swapping cards
 //
 //nolint:gocognit,gocyclo // Mutation pipeline uses explicit branching for reproducibility.
-func generateDeckMutations(savedDecks [][]string, player *clashroyale.Player, count, mutationIntensity int, verbose bool) [][]string {
+func generateDeckMutations(savedDecks [][]string, player *clashroyale.Player, count, mutationIntensity int, lineage *deck.LineageRecorder, verbose bool) [][]string {
 	if player == nil || len(player.Cards) == 0 {
 		if verbose {
 			fprintf(os.Stderr, "No player cards available for mutations\n")
@@ -2321,11 +2894,11 @@ func generateDeckMutations(savedDecks [][]string, player *clashroyale.Player, co
 	mutations := make([][]string, 0)
 	mutationsPerDeck := max(count/len(savedDecks), 1)
 
-	for _, deck := range savedDecks {
+	for _, savedDeck := range savedDecks {
 		for i := 0; i < mutationsPerDeck; i++ {
 			// Create mutation by swapping 1-2 random cards
-			mutation := make([]string, len(deck))
-			copy(mutation, deck)
+			mutation := make([]string, len(savedDeck))
+			copy(mutation, savedDeck)
 
 			// Swap cards based on mutation intensity
 			numSwaps := 1 + (i % mutationIntensity) // Vary from 1 to mutationIntensity
@@ -2344,6 +2917,7 @@ func generateDeckMutations(savedDecks [][]string, player *clashroyale.Player, co
 				}
 			}
 
+			lineage.Record(mutation, deck.LineageMutation, savedDeck)
 			mutations = append(mutations, mutation)
 		}
 	}
@@ -2407,7 +2981,7 @@ func loadDeckFromStorage(deckRef string, verbose bool) ([]string, error) {
 // generateVariations generates variations of a base deck by swapping some cards
 //
 //nolint:gocyclo // Variation generation includes multiple guarded mutation paths.
-func generateVariations(baseDeck []string, player *clashroyale.Player, count, mutationIntensity int, verbose bool) [][]string {
+func generateVariations(baseDeck []string, player *clashroyale.Player, count, mutationIntensity int, lineage *deck.LineageRecorder, verbose bool) [][]string {
 	if player == nil || len(player.Cards) == 0 {
 		if verbose {
 			fprintf(os.Stderr, "No player cards available for variations\n")
@@ -2457,6 +3031,7 @@ func generateVariations(baseDeck []string, player *clashroyale.Player, count, mu
 			}
 		}
 
+		lineage.Record(variation, deck.LineageVariation, baseDeck)
 		variations = append(variations, variation)
 	}
 
@@ -2520,6 +3095,23 @@ func formatDurationFloor(seconds float64) string {
 	return fmt.Sprintf("%dm %ds", minutes, secs)
 }
 
+// formatIslandProgress renders each island's best and average fitness for
+// the GA progress line, labelled with the island's strategy when it has one
+func formatIslandProgress(islands []genetic.IslandProgress) string {
+	if len(islands) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(islands))
+	for _, island := range islands {
+		label := strconv.Itoa(island.Island)
+		if island.Strategy != "" {
+			label += " " + island.Strategy
+		}
+		parts = append(parts, fmt.Sprintf("%s %.2f/%.2f", label, island.BestFitness, island.AvgFitness))
+	}
+	return " | islands " + strings.Join(parts, ", ")
+}
+
 // confirmAction prompts the user to confirm before proceeding
 func confirmAction(prompt string) (bool, error) {
 	fprintf(os.Stderr, "%s", prompt)
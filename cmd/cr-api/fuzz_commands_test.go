@@ -1,10 +1,14 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"testing"
@@ -12,6 +16,8 @@ import (
 
 	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
 	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/genetic"
 	"github.com/klauer/clash-royale-api/go/pkg/fuzzstorage"
 )
 
@@ -135,6 +141,29 @@ func TestEnsureElixirBucketDistribution_MissingBucketFallsBackToScoreOrder(t *te
 	}
 }
 
+func TestResolveFuzzArchetypes(t *testing.T) {
+	t.Cleanup(evaluation.ResetArchetypeScorers)
+	if err := evaluation.RegisterArchetypeScorer(&evaluation.KeywordArchetype{
+		Name:     "RoyalHogs",
+		Keywords: map[string]float64{"Royal Hogs": 10},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := resolveFuzzArchetypes([]string{" Cycle", "HYBRID", "royalhogs"})
+	if err != nil {
+		t.Fatalf("resolveFuzzArchetypes() error = %v", err)
+	}
+	if want := []string{"cycle", "hybrid", "RoyalHogs"}; !slices.Equal(got, want) {
+		t.Errorf("resolveFuzzArchetypes() = %v, want %v", got, want)
+	}
+
+	_, err = resolveFuzzArchetypes([]string{"zap"})
+	if err == nil || !strings.Contains(err.Error(), "miner, RoyalHogs, hybrid") {
+		t.Errorf("unknown archetype error = %v, want the registered names", err)
+	}
+}
+
 func TestLimitArchetypeRepetition(t *testing.T) {
 	input := []FuzzingResult{
 		{Deck: []string{"1"}, Archetype: "cycle"},
@@ -301,7 +330,7 @@ func TestFormatListResultsCSV_MissingTheoreticalEntryLeavesStoredColumnsBlank(t
 
 func TestSelectGAFitnessEvaluator(t *testing.T) {
 	t.Run("archetype-free mode uses composite fitness evaluator", func(t *testing.T) {
-		evaluator, mode := selectGAFitnessEvaluator(false)
+		evaluator, mode := selectGAFitnessEvaluator(false, evaluation.GameModeStandard)
 		if evaluator == nil {
 			t.Fatal("expected archetype-free mode to return evaluator")
 		}
@@ -319,7 +348,7 @@ func TestSelectGAFitnessEvaluator(t *testing.T) {
 	})
 
 	t.Run("legacy mode uses built-in evaluator", func(t *testing.T) {
-		evaluator, mode := selectGAFitnessEvaluator(true)
+		evaluator, mode := selectGAFitnessEvaluator(true, evaluation.GameModeStandard)
 		if evaluator != nil {
 			t.Fatal("expected legacy mode to use built-in evaluator")
 		}
@@ -327,6 +356,27 @@ func TestSelectGAFitnessEvaluator(t *testing.T) {
 			t.Fatalf("mode = %q, want %q", mode, gaFitnessModeLegacy)
 		}
 	})
+
+	t.Run("game modes evolve against the mode's evaluation", func(t *testing.T) {
+		evaluator, mode := selectGAFitnessEvaluator(true, evaluation.GameModeTripleElixir)
+		if evaluator == nil {
+			t.Fatal("expected a game mode evaluator even with --ga-use-archetypes")
+		}
+		if mode != gaFitnessModeGameMode+":triple-elixir" {
+			t.Fatalf("mode = %q", mode)
+		}
+
+		cards := testCompositeDeckCandidates()
+		score, err := evaluator(cards)
+		if err != nil {
+			t.Fatalf("unexpected evaluator error: %v", err)
+		}
+		want := evaluation.EvaluateWithOptions(cards, deck.NewSynergyDatabase(), nil,
+			evaluation.EvaluateOptions{GameMode: evaluation.GameModeTripleElixir}).OverallScore
+		if score != want {
+			t.Fatalf("score = %v, want %v", score, want)
+		}
+	})
 }
 
 func testCompositeDeckCandidates() []deck.CardCandidate {
@@ -380,11 +430,11 @@ func TestSaveResultsToFileCanonicalizesPlayerTag(t *testing.T) {
 		},
 	}
 
-	if err := saveResultsToFileImpl(results, outputDir, fuzzOutputJSON, " abc123 "); err != nil {
+	if err := saveResultsToFileImpl(results, outputDir, fuzzOutputJSON, " 2pq0r "); err != nil {
 		t.Fatalf("saveResultsToFileImpl failed: %v", err)
 	}
 
-	matches, err := filepath.Glob(filepath.Join(outputDir, "fuzz_ABC123_*.json"))
+	matches, err := filepath.Glob(filepath.Join(outputDir, "fuzz_2PQ0R_*.json"))
 	if err != nil {
 		t.Fatalf("glob failed: %v", err)
 	}
@@ -395,24 +445,310 @@ func TestSaveResultsToFileCanonicalizesPlayerTag(t *testing.T) {
 
 func TestLoadPlayerFromAnalysisCanonicalizesPlayerTag(t *testing.T) {
 	analysisDir := t.TempDir()
-	analysisPath := filepath.Join(analysisDir, "latest_analysis_ABC123.json")
+	analysisPath := filepath.Join(analysisDir, "latest_analysis_2PQ0R.json")
 
-	data := `{"player_name":"Test","player_tag":"#ABC123","analysis_time":"2026-02-28T12:00:00Z","card_levels":{"Knight":{"level":11,"max_level":14,"rarity":"Common","elixir":3}}}`
+	data := `{"player_name":"Test","player_tag":"#2PQ0R","analysis_time":"2026-02-28T12:00:00Z","card_levels":{"Knight":{"level":11,"max_level":14,"rarity":"Common","elixir":3}}}`
 	if err := os.WriteFile(analysisPath, []byte(data), 0o644); err != nil {
 		t.Fatalf("write analysis: %v", err)
 	}
 
-	player, playerName, err := loadPlayerFromAnalysis("", analysisDir, " abc123 ")
+	player, playerName, err := loadPlayerFromAnalysis("", analysisDir, " 2pq0r ")
 	if err != nil {
 		t.Fatalf("loadPlayerFromAnalysis failed: %v", err)
 	}
 	if playerName != "Test" {
 		t.Fatalf("playerName = %q, want Test", playerName)
 	}
-	if player.Tag != "#ABC123" {
-		t.Fatalf("player.Tag = %q, want #ABC123", player.Tag)
+	if player.Tag != "#2PQ0R" {
+		t.Fatalf("player.Tag = %q, want #2PQ0R", player.Tag)
 	}
 	if len(player.Cards) != 1 || !strings.EqualFold(player.Cards[0].Name, "Knight") {
 		t.Fatalf("expected one Knight card, got %+v", player.Cards)
 	}
 }
+
+func TestFormatParetoFront(t *testing.T) {
+	objectives := []genetic.Objective{genetic.ObjectiveAttack, genetic.ObjectiveDefense}
+	front := []genetic.ParetoSolution{
+		{Genome: &genetic.DeckGenome{Cards: []string{"Hog Rider", "Musketeer"}}, Objectives: []float64{9, 3}},
+		{Genome: &genetic.DeckGenome{Cards: []string{"Golem", "Night Witch"}}, Objectives: []float64{4, 8}},
+		{Genome: &genetic.DeckGenome{Cards: []string{"X-Bow", "Tesla"}}, Objectives: []float64{2, 10}},
+	}
+
+	var buf bytes.Buffer
+	formatParetoFront(&buf, objectives, front, 2)
+	output := buf.String()
+
+	for _, want := range []string{"Pareto Front (3 non-dominated decks, objectives: attack, defense)", "ATTACK", "DEFENSE", "Hog Rider, Musketeer", "1 more"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "X-Bow") {
+		t.Errorf("expected output limited to 2 decks:\n%s", output)
+	}
+	if got := paretoBar(10); got != strings.Repeat("█", paretoBarWidth) {
+		t.Errorf("paretoBar(10) = %q", got)
+	}
+	if got := paretoBar(-1); got != strings.Repeat("░", paretoBarWidth) {
+		t.Errorf("paretoBar(-1) = %q", got)
+	}
+}
+
+func TestSplitEndpoints(t *testing.T) {
+	got := splitEndpoints([]string{"http://a:8091, http://b:8091", "", " http://c:8091 ,"})
+	want := []string{"http://a:8091", "http://b:8091", "http://c:8091"}
+	if !slices.Equal(got, want) {
+		t.Errorf("splitEndpoints() = %v, want %v", got, want)
+	}
+}
+
+func TestSaveResultsToFileWritesParquet(t *testing.T) {
+	outputDir := t.TempDir()
+	results := []FuzzingResult{
+		{
+			Deck:         []string{"Knight", "Archers", "Fireball", "Zap", "Giant", "Musketeer", "Minions", "Arrows"},
+			OverallScore: 8.5,
+			Archetype:    "beatdown",
+			EvaluatedAt:  time.Now(),
+		},
+	}
+
+	if err := saveResultsToFileImpl(results, outputDir, fuzzOutputParquet, "2PQ0R"); err != nil {
+		t.Fatalf("saveResultsToFileImpl failed: %v", err)
+	}
+
+	matches, err := filepath.Glob(filepath.Join(outputDir, "fuzz_2PQ0R_*.parquet"))
+	if err != nil || len(matches) != 1 {
+		t.Fatalf("expected one parquet file, got %v (%v)", matches, err)
+	}
+	data, err := os.ReadFile(matches[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(data, []byte("PAR1")) || !bytes.HasSuffix(data, []byte("PAR1")) {
+		t.Fatalf("file is not parquet: %q", data)
+	}
+	if !bytes.Contains(data, []byte("Knight, Archers, Fireball")) || !bytes.Contains(data, []byte("deck_link")) {
+		t.Errorf("parquet file is missing the deck row or schema")
+	}
+}
+
+func TestFormatListResultsParquet_TheoreticalColumns(t *testing.T) {
+	decks := []fuzzstorage.DeckEntry{{ID: 21, Cards: []string{"Knight"}, OverallScore: 5.5, RunID: "run-1"}}
+
+	var plain bytes.Buffer
+	if err := formatListResultsParquet(&plain, decks, nil); err != nil {
+		t.Fatalf("formatListResultsParquet returned error: %v", err)
+	}
+	if !bytes.Contains(plain.Bytes(), []byte("run_id")) || bytes.Contains(plain.Bytes(), []byte("stored_overall_score")) {
+		t.Errorf("unexpected schema without theoretical scores")
+	}
+
+	var withStored bytes.Buffer
+	if err := formatListResultsParquet(&withStored, decks, map[int]fuzzstorage.DeckEntry{}); err != nil {
+		t.Fatalf("formatListResultsParquet returned error: %v", err)
+	}
+	if !bytes.Contains(withStored.Bytes(), []byte("stored_overall_score")) {
+		t.Errorf("expected stored_* columns when decks were re-evaluated")
+	}
+}
+
+func TestFuzzOutputsReportHybridArchetypes(t *testing.T) {
+	results := []FuzzingResult{
+		{
+			Deck:                []string{"Hog Rider", "Miner", "Musketeer", "Ice Spirit", "Skeletons", "Cannon", "Fireball", "The Log"},
+			OverallScore:        8.1,
+			Archetype:           "hybrid",
+			ArchetypeConfidence: 0.8,
+			HybridPrimary:       "cycle",
+			HybridSecondary:     "miner",
+			EvaluatedAt:         time.Now(),
+		},
+	}
+
+	csvOutput, err := captureStdout(t, func() error { return formatResultsCSVImpl(results) })
+	if err != nil {
+		t.Fatalf("formatResultsCSVImpl returned error: %v", err)
+	}
+	if !strings.Contains(csvOutput, "Archetype,HybridPrimary,HybridSecondary") || !strings.Contains(csvOutput, ",hybrid,cycle,miner") {
+		t.Errorf("CSV output is missing the hybrid archetypes:\n%s", csvOutput)
+	}
+
+	detailed, err := captureStdout(t, func() error { return formatResultsDetailedImpl(results, "Player", "#2PQ0R") })
+	if err != nil {
+		t.Fatalf("formatResultsDetailedImpl returned error: %v", err)
+	}
+	if !strings.Contains(detailed, "Archetype: hybrid (cycle/miner)") {
+		t.Errorf("detailed output is missing the hybrid archetypes:\n%s", detailed)
+	}
+
+	var parquetOutput bytes.Buffer
+	if err := formatResultsParquetImpl(&parquetOutput, results); err != nil {
+		t.Fatalf("formatResultsParquetImpl returned error: %v", err)
+	}
+	if !bytes.Contains(parquetOutput.Bytes(), []byte("hybrid_secondary")) {
+		t.Errorf("parquet schema is missing the hybrid columns")
+	}
+
+	stored := []fuzzstorage.DeckEntry{{ID: 1, Cards: results[0].Deck, Archetype: "hybrid", HybridPrimary: "cycle", HybridSecondary: "miner"}}
+	listOutput, err := captureStdout(t, func() error { return formatListResultsCSV(stored, nil) })
+	if err != nil {
+		t.Fatalf("formatListResultsCSV returned error: %v", err)
+	}
+	if !strings.Contains(listOutput, ",hybrid,cycle,miner") {
+		t.Errorf("list CSV output is missing the hybrid archetypes:\n%s", listOutput)
+	}
+}
+
+func TestFuzzOutputsIncludeDeckNames(t *testing.T) {
+	cards := []string{"Hog Rider", "Musketeer", "Cannon", "Ice Golem", "Ice Spirit", "Skeletons", "Fireball", "The Log"}
+	result := evaluateSingleDeck(cards, nil, "", deck.NewSynergyDatabase(), nil, evaluation.EvaluateOptions{})
+	if !strings.HasPrefix(result.Name, "Hog 2.6") {
+		t.Fatalf("Name = %q, want a Hog 2.6 name", result.Name)
+	}
+
+	csvOutput, err := captureStdout(t, func() error { return formatResultsCSVImpl([]FuzzingResult{result}) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(csvOutput, ",Name,") || !strings.Contains(csvOutput, ","+result.Name+",") {
+		t.Errorf("CSV output is missing the deck name:\n%s", csvOutput)
+	}
+
+	// Decks stored before names get one generated from their cards
+	stored := fuzzstorage.DeckEntry{Cards: cards, Archetype: "cycle"}
+	if got := storedDeckName(stored); got != "Hog 2.6 Cycle" {
+		t.Errorf("storedDeckName() = %q, want Hog 2.6 Cycle", got)
+	}
+	stored.Name = "Hog 2.6 Cycle #2"
+	if got := storedDeckName(stored); got != stored.Name {
+		t.Errorf("storedDeckName() = %q, want the stored name", got)
+	}
+}
+
+func TestClusterResultsKeepsBestOfEachCluster(t *testing.T) {
+	hog := []string{"Hog Rider", "Musketeer", "Cannon", "Ice Golem", "Ice Spirit", "Skeletons", "Fireball", "The Log"}
+	tesla := slices.Clone(hog)
+	tesla[2] = "Tesla"
+	golem := []string{"Golem", "Night Witch", "Baby Dragon", "Lumberjack", "Tornado", "Lightning", "Barbarian Barrel", "Mega Minion"}
+	results := []FuzzingResult{
+		{Name: "Hog", Deck: hog, OverallScore: 9},
+		{Name: "Hog Tesla", Deck: tesla, OverallScore: 8.5},
+		{Name: "Golem", Deck: golem, OverallScore: 8},
+	}
+
+	clustered := clusterResults(results, deck.DefaultClusterThreshold)
+	if len(clustered) != 2 || clustered[0].Name != "Hog" || clustered[0].Variants != 1 || clustered[1].Variants != 0 {
+		t.Fatalf("clusterResults() = %+v", clustered)
+	}
+
+	output, err := captureStdout(t, func() error {
+		return formatResultsSummaryImpl(clustered, "Player", "#TAG", &deck.FuzzingConfig{}, "", time.Second, &deck.FuzzingStats{}, len(results))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(output, "1. Hog: 1 more deck(s) sharing most cards") {
+		t.Errorf("summary is missing the variant count:\n%s", output)
+	}
+}
+
+// writeFuzzAnalysisFixture writes an analysis file with enough cards for
+// deck fuzz --from-analysis runs and returns its path.
+func writeFuzzAnalysisFixture(t *testing.T) string {
+	t.Helper()
+	cardLevels := make(map[string]deck.CardLevelData)
+	for _, card := range []string{
+		"Hog Rider", "Giant", "Fireball", "Zap", "Cannon", "Tesla", "Musketeer", "Valkyrie",
+		"Knight", "Archers", "Skeletons", "Ice Spirit", "Baby Dragon", "The Log", "Arrows", "Goblin Gang",
+	} {
+		cardLevels[card] = deck.CardLevelData{Level: 11, MaxLevel: 14, Rarity: "Common"}
+	}
+	return writeDeckAnalysisFixture(t, t.TempDir(), "analysis.json",
+		deck.CardAnalysis{PlayerName: "Test", PlayerTag: "#2PP0Q", CardLevels: cardLevels}, time.Now())
+}
+
+func TestDeckFuzzDeterministicIgnoresWorkerCount(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	analysisPath := writeFuzzAnalysisFixture(t)
+
+	run := func(workers string) []FuzzingResult {
+		// Runs over 1,000 decks ask before starting
+		stdin, answer, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := answer.WriteString("y\n"); err != nil {
+			t.Fatal(err)
+		}
+		closeFile(answer)
+		oldStdin := os.Stdin
+		os.Stdin = stdin
+		defer func() {
+			os.Stdin = oldStdin
+			closeFile(stdin)
+		}()
+
+		output, err := captureStdout(t, func() error {
+			return addDeckFuzzCommand().Run(context.Background(), []string{"fuzz",
+				"--from-analysis", "--analysis-file", analysisPath, "--tag", "2PYQ98",
+				"--count", "2500", "--top", "15", "--workers", workers, "--seed", "9",
+				"--deterministic", "--eval-cache-size", "0", "--format", "json"})
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var parsed struct {
+			Results []FuzzingResult `json:"results"`
+		}
+		if err := json.Unmarshal([]byte(output), &parsed); err != nil {
+			t.Fatal(err)
+		}
+		return parsed.Results
+	}
+
+	two, five := run("2"), run("5")
+	if len(two) != 15 || len(five) != len(two) {
+		t.Fatalf("got %d and %d results, want 15 each", len(two), len(five))
+	}
+	for i := range two {
+		if !slices.Equal(two[i].Deck, five[i].Deck) || two[i].OverallScore != five[i].OverallScore {
+			t.Errorf("result %d: %v (%.3f) with 2 workers, %v (%.3f) with 5",
+				i, two[i].Deck, two[i].OverallScore, five[i].Deck, five[i].OverallScore)
+		}
+	}
+
+	err := addDeckFuzzCommand().Run(context.Background(), []string{"fuzz", "--tag", "2PYQ98", "--deterministic"})
+	if err == nil || !strings.Contains(err.Error(), "--seed") {
+		t.Errorf("--deterministic without --seed: err = %v", err)
+	}
+}
+
+func TestFuzzResultBetterBreaksTiesByCards(t *testing.T) {
+	a := FuzzingResult{Deck: []string{"Zap", "Knight"}, OverallScore: 7}
+	b := FuzzingResult{Deck: []string{"Arrows", "Knight"}, OverallScore: 7}
+	better := fuzzResultBetter("overall")
+	if !better(b, a) || better(a, b) {
+		t.Error("tied results should rank by their sorted card list")
+	}
+	results := []FuzzingResult{a, b, {Deck: []string{"Giant"}, OverallScore: 8}}
+	sortFuzzingResultsImpl(results, "overall")
+	if results[0].OverallScore != 8 || results[1].Deck[0] != "Arrows" {
+		t.Errorf("sorted results = %+v", results)
+	}
+}
+
+func TestFormatIslandProgress(t *testing.T) {
+	if got := formatIslandProgress(nil); got != "" {
+		t.Fatalf("formatIslandProgress(nil) = %q, want empty", got)
+	}
+	got := formatIslandProgress([]genetic.IslandProgress{
+		{Island: 1, Strategy: "cycle", BestFitness: 7.2, AvgFitness: 6.5},
+		{Island: 2, BestFitness: 6.9, AvgFitness: 6.1},
+	})
+	want := " | islands 1 cycle 7.20/6.50, 2 6.90/6.10"
+	if got != want {
+		t.Fatalf("formatIslandProgress() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"math"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/research"
+)
+
+// constraintFitnessPenalty is the share of GA fitness kept per broken
+// --constraints rule, so near misses still guide the search toward valid decks
+const constraintFitnessPenalty = 0.5
+
+// constraintRuleKey identifies a rule set, for cache variants
+func constraintRuleKey(rules []research.CountRule) string {
+	texts := make([]string, len(rules))
+	for i, rule := range rules {
+		texts[i] = rule.Text
+	}
+	return strings.Join(texts, ";")
+}
+
+// constrainedGAFitness halves the fitness of a deck for each rule it breaks.
+// A nil evaluator scores decks like the GA's default evaluation.
+func constrainedGAFitness(evaluator func([]deck.CardCandidate) (float64, error), rules []research.CountRule) func([]deck.CardCandidate) (float64, error) {
+	if len(rules) == 0 {
+		return evaluator
+	}
+	if evaluator == nil {
+		synergyDB := deck.NewSynergyDatabase()
+		evaluator = func(deckCards []deck.CardCandidate) (float64, error) {
+			return evaluation.Evaluate(deckCards, synergyDB, nil).OverallScore, nil
+		}
+	}
+	cfg := research.ConstraintConfig{Rules: rules}
+	return func(deckCards []deck.CardCandidate) (float64, error) {
+		fitness, err := evaluator(deckCards)
+		if err != nil {
+			return 0, err
+		}
+		// The air-defense, tank-killer and splash categories read combat stats
+		broken := len(cfg.RuleViolations(evaluation.WithCombatStats(deckCards)))
+		return fitness * math.Pow(constraintFitnessPenalty, float64(broken)), nil
+	}
+}
+
+// deckConstraintChecker reports whether a deck of card names satisfies every
+// rule, or returns nil when there are no rules
+func deckConstraintChecker(rules []research.CountRule, player *clashroyale.Player) func([]string) bool {
+	if len(rules) == 0 {
+		return nil
+	}
+	cfg := research.ConstraintConfig{Rules: rules}
+	return func(deckCards []string) bool {
+		return len(cfg.RuleViolations(evaluation.WithCombatStats(convertDeckToCandidates(deckCards, player)))) == 0
+	}
+}
+
+// filterDecksByConstraints keeps the decks that satisfy every rule
+func filterDecksByConstraints(decks [][]string, rules []research.CountRule, player *clashroyale.Player) [][]string {
+	check := deckConstraintChecker(rules, player)
+	if check == nil {
+		return decks
+	}
+	filtered := make([][]string, 0, len(decks))
+	for _, deckCards := range decks {
+		if check(deckCards) {
+			filtered = append(filtered, deckCards)
+		}
+	}
+	return filtered
+}
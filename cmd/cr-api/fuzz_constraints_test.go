@@ -0,0 +1,43 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/research"
+)
+
+func TestFilterDecksByConstraints(t *testing.T) {
+	rules, err := research.ParseCountRules("at least 1 building; at most 1 spell")
+	if err != nil {
+		t.Fatalf("ParseCountRules() error = %v", err)
+	}
+	withCannon := []string{"Hog Rider", "Cannon", "Fireball", "Musketeer", "Ice Spirit", "Skeletons", "Knight", "Ice Golem"}
+	twoSpells := []string{"Hog Rider", "Cannon", "Fireball", "The Log", "Ice Spirit", "Skeletons", "Knight", "Musketeer"}
+	noBuilding := []string{"Hog Rider", "Valkyrie", "Fireball", "Musketeer", "Ice Spirit", "Skeletons", "Knight", "Ice Golem"}
+
+	got := filterDecksByConstraints([][]string{withCannon, twoSpells, noBuilding}, rules, nil)
+	if len(got) != 1 || !slices.Equal(got[0], withCannon) {
+		t.Errorf("filterDecksByConstraints() = %v, want only the first deck", got)
+	}
+	if got := filterDecksByConstraints([][]string{noBuilding}, nil, nil); len(got) != 1 {
+		t.Errorf("filterDecksByConstraints() without rules = %v, want the deck unchanged", got)
+	}
+}
+
+func TestConstrainedGAFitnessPenalizesBrokenRules(t *testing.T) {
+	rules, err := research.ParseCountRules("at least 2 buildings; no spells")
+	if err != nil {
+		t.Fatalf("ParseCountRules() error = %v", err)
+	}
+	fitness := constrainedGAFitness(func([]deck.CardCandidate) (float64, error) { return 8, nil }, rules)
+	cards := convertDeckToCandidates([]string{"Hog Rider", "Cannon", "Fireball", "Musketeer", "Ice Spirit", "Skeletons", "Knight", "Ice Golem"}, nil)
+	got, err := fitness(cards)
+	if err != nil {
+		t.Fatalf("fitness() error = %v", err)
+	}
+	if got != 2 {
+		t.Errorf("fitness() = %.2f, want 2.00 after two broken rules", got)
+	}
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/klauer/clash-royale-api/go/pkg/fuzzstorage"
+	"github.com/urfave/cli/v3"
+)
+
+// deckTagFlagName filters saved decks by tag; --tag is the player tag
+const deckTagFlagName = "deck-tag"
+
+func deckTagFilterFlag() cli.Flag {
+	return &cli.StringSliceFlag{
+		Name:  deckTagFlagName,
+		Usage: "Only decks with this tag (repeatable; decks must have every tag)",
+	}
+}
+
+func favoritesFilterFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:  "favorites",
+		Usage: "Only favorite decks",
+	}
+}
+
+// addDeckFuzzTagCommand adds the subcommand that tags saved decks
+func addDeckFuzzTagCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "tag",
+		Usage:     "Add tags to a saved deck, or list the tags in use",
+		ArgsUsage: "<deck id or name> <tag>...",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "remove",
+				Usage: "Remove the tags instead of adding them",
+			},
+			&cli.BoolFlag{
+				Name:  "list",
+				Usage: "List every tag with its number of decks",
+			},
+		},
+		Action: deckFuzzTagCommand,
+	}
+}
+
+// addDeckFuzzFavoriteCommand adds the subcommand that marks saved decks as
+// favorites
+func addDeckFuzzFavoriteCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "favorite",
+		Usage:     "Mark saved decks as favorites",
+		ArgsUsage: "<deck id or name>...",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "remove",
+				Usage: "Unmark the decks instead",
+			},
+		},
+		Action: deckFuzzFavoriteCommand,
+	}
+}
+
+// addDeckFuzzNoteCommand adds the subcommand that annotates a saved deck
+func addDeckFuzzNoteCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "note",
+		Usage:     "Set, show, or clear the notes of a saved deck",
+		ArgsUsage: "<deck id or name> [text...]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "clear",
+				Usage: "Remove the deck's notes",
+			},
+		},
+		Action: deckFuzzNoteCommand,
+	}
+}
+
+// openCurationStorage opens fuzz storage and finds the deck named by ref
+func openCurationStorage(ref string) (*fuzzstorage.Storage, *fuzzstorage.DeckEntry, error) {
+	storage, err := fuzzstorage.NewStorage("")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open storage: %w", err)
+	}
+	if ref == "" {
+		return storage, nil, nil
+	}
+	entry, err := storage.FindDeck(ref)
+	if err != nil {
+		closeFile(storage)
+		return nil, nil, err
+	}
+	return storage, entry, nil
+}
+
+func deckFuzzTagCommand(ctx context.Context, cmd *cli.Command) error {
+	args := cmd.Args().Slice()
+	if cmd.Bool("list") {
+		storage, _, err := openCurationStorage("")
+		if err != nil {
+			return err
+		}
+		defer closeFile(storage)
+		counts, err := storage.TagCounts()
+		if err != nil {
+			return err
+		}
+		if len(counts) == 0 {
+			printf("No tagged decks\n")
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fprintf(w, "Tag\tDecks\n")
+		for _, tag := range slices.Sorted(maps.Keys(counts)) {
+			fprintf(w, "%s\t%d\n", tag, counts[tag])
+		}
+		flushWriter(w)
+		return nil
+	}
+	if len(args) < 2 {
+		return errors.New("usage: cr-api deck fuzz tag <deck id or name> <tag>...")
+	}
+
+	storage, entry, err := openCurationStorage(args[0])
+	if err != nil {
+		return err
+	}
+	defer closeFile(storage)
+
+	update := storage.AddTags
+	if cmd.Bool("remove") {
+		update = storage.RemoveTags
+	}
+	tags, err := update(entry.ID, args[1:]...)
+	if err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		printf("%s has no tags\n", describeStoredDeck(*entry))
+		return nil
+	}
+	printf("%s tags: %s\n", describeStoredDeck(*entry), strings.Join(tags, ", "))
+	return nil
+}
+
+func deckFuzzFavoriteCommand(ctx context.Context, cmd *cli.Command) error {
+	refs := cmd.Args().Slice()
+	if len(refs) == 0 {
+		return errors.New("usage: cr-api deck fuzz favorite <deck id or name>...")
+	}
+	storage, _, err := openCurationStorage("")
+	if err != nil {
+		return err
+	}
+	defer closeFile(storage)
+
+	favorite := !cmd.Bool("remove")
+	for _, ref := range refs {
+		entry, err := storage.FindDeck(ref)
+		if err != nil {
+			return err
+		}
+		if err := storage.SetFavorite(entry.ID, favorite); err != nil {
+			return err
+		}
+		if favorite {
+			printf("%s is a favorite\n", describeStoredDeck(*entry))
+		} else {
+			printf("%s is no longer a favorite\n", describeStoredDeck(*entry))
+		}
+	}
+	return nil
+}
+
+func deckFuzzNoteCommand(ctx context.Context, cmd *cli.Command) error {
+	args := cmd.Args().Slice()
+	if len(args) == 0 {
+		return errors.New("usage: cr-api deck fuzz note <deck id or name> [text...]")
+	}
+	storage, entry, err := openCurationStorage(args[0])
+	if err != nil {
+		return err
+	}
+	defer closeFile(storage)
+
+	notes := strings.Join(args[1:], " ")
+	switch {
+	case cmd.Bool("clear"):
+		notes = ""
+	case notes == "":
+		if entry.Notes == "" {
+			printf("%s has no notes\n", describeStoredDeck(*entry))
+		} else {
+			printf("%s\n", entry.Notes)
+		}
+		return nil
+	}
+	if err := storage.SetNotes(entry.ID, notes); err != nil {
+		return err
+	}
+	if notes == "" {
+		printf("Cleared the notes of %s\n", describeStoredDeck(*entry))
+	} else {
+		printf("Saved the notes of %s\n", describeStoredDeck(*entry))
+	}
+	return nil
+}
+
+// describeStoredDeck names a stored deck in command output
+func describeStoredDeck(entry fuzzstorage.DeckEntry) string {
+	return fmt.Sprintf("Deck %d (%s)", entry.ID, storedDeckName(entry))
+}
+
+// deckTags returns a deck's tags, never nil so JSON shows an empty list
+func deckTags(entry fuzzstorage.DeckEntry) []string {
+	if entry.Tags == nil {
+		return []string{}
+	}
+	return entry.Tags
+}
+
+// curationLabel summarizes a deck's favorite flag and tags for tables
+func curationLabel(entry fuzzstorage.DeckEntry) string {
+	labels := entry.Tags
+	if entry.Favorite {
+		labels = append([]string{"favorite"}, labels...)
+	}
+	return strings.Join(labels, ", ")
+}
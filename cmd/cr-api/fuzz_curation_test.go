@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/fuzzstorage"
+)
+
+func seedCurationDecks(t *testing.T) []int {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	storage, err := fuzzstorage.NewStorage("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer storage.Close()
+
+	decks := [][]string{
+		{"Hog Rider", "Musketeer", "Cannon", "Ice Golem", "Ice Spirit", "Skeletons", "Fireball", "The Log"},
+		{"Golem", "Night Witch", "Baby Dragon", "Lumberjack", "Tornado", "Lightning", "Barbarian Barrel", "Mega Minion"},
+	}
+	ids := make([]int, len(decks))
+	for i, cards := range decks {
+		entry := &fuzzstorage.DeckEntry{
+			Cards:        cards,
+			OverallScore: 8 - float64(i),
+			AvgElixir:    3.5,
+			Archetype:    "cycle",
+			EvaluatedAt:  time.Now(),
+		}
+		if _, _, err := storage.InsertDeck(entry); err != nil {
+			t.Fatal(err)
+		}
+		ids[i] = entry.ID
+	}
+	return ids
+}
+
+func runCurationCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	root := addDeckFuzzListCommand()
+	root.Name = "fuzz"
+	root.Commands = append(root.Commands, addDeckFuzzTagCommand(), addDeckFuzzFavoriteCommand(), addDeckFuzzNoteCommand())
+	return captureStdout(t, func() error {
+		return root.Run(context.Background(), append([]string{"fuzz"}, args...))
+	})
+}
+
+func TestDeckFuzzCurationCommands(t *testing.T) {
+	seedCurationDecks(t)
+
+	output, err := runCurationCommand(t, "tag", "1", "Ladder", "push")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(output, "tags: ladder, push") {
+		t.Errorf("tag output = %q", output)
+	}
+	if output, err = runCurationCommand(t, "tag", "--remove", "1", "push"); err != nil || !strings.Contains(output, "tags: ladder") {
+		t.Errorf("tag --remove output = %q, %v", output, err)
+	}
+	if _, err := runCurationCommand(t, "favorite", "2"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runCurationCommand(t, "note", "2", "beats", "golem"); err != nil {
+		t.Fatal(err)
+	}
+	if output, err = runCurationCommand(t, "note", "2"); err != nil || output != "beats golem\n" {
+		t.Errorf("note output = %q, %v", output, err)
+	}
+	if output, err = runCurationCommand(t, "tag", "--list"); err != nil || !strings.Contains(output, "ladder") {
+		t.Errorf("tag --list output = %q, %v", output, err)
+	}
+
+	if _, err := runCurationCommand(t, "tag", "1"); err == nil || !strings.HasPrefix(err.Error(), "usage:") {
+		t.Errorf("tag without tags error = %v, want usage", err)
+	}
+	if _, err := runCurationCommand(t, "favorite", "no such deck"); err == nil {
+		t.Error("favorite of a missing deck should fail")
+	}
+}
+
+func TestDeckFuzzListFiltersCuratedDecks(t *testing.T) {
+	seedCurationDecks(t)
+	if _, err := runCurationCommand(t, "tag", "1", "ladder"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runCurationCommand(t, "favorite", "2"); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := runCurationCommand(t, "--deck-tag", "ladder", "--format", "csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(output), "\n"); len(lines) != 2 || !strings.Contains(lines[1], "Hog Rider") || !strings.Contains(lines[1], ",ladder,false,") {
+		t.Errorf("--deck-tag output =\n%s", output)
+	}
+
+	output, err = runCurationCommand(t, "--favorites", "--format", "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(output, "Golem") || strings.Contains(output, "Hog Rider") || !strings.Contains(output, `"favorite": true`) {
+		t.Errorf("--favorites output =\n%s", output)
+	}
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/fuzzdist"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/klauer/clash-royale-api/go/pkg/leaderboard"
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	defaultFuzzBatchSize   = 100000
+	defaultFuzzWorkerAddr  = "127.0.0.1:8091"
+	fuzzWorkerTokenEnvName = "CR_API_FUZZ_WORKER_TOKEN"
+)
+
+// distributedFlags returns flags for fanning a random fuzz run out to workers
+func distributedFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "distributed",
+			Usage: "Run generation and evaluation on remote workers (see `deck fuzz worker`)",
+		},
+		&cli.StringSliceFlag{
+			Name:    "workers-endpoint",
+			Usage:   "Worker base URL, e.g. http://10.0.0.5:8091 (repeatable or comma-separated)",
+			Sources: cli.EnvVars("CR_API_FUZZ_WORKERS"),
+		},
+		&cli.IntFlag{
+			Name:  "batch-size",
+			Value: defaultFuzzBatchSize,
+			Usage: "Decks per worker batch in distributed mode",
+		},
+		&cli.IntFlag{
+			Name:  "keep-per-batch",
+			Usage: "Best decks each worker returns per batch (default: max(10*top, 100))",
+		},
+		&cli.StringFlag{
+			Name:    "worker-token",
+			Usage:   "Bearer token shared with the workers",
+			Sources: cli.EnvVars(fuzzWorkerTokenEnvName),
+		},
+	}
+}
+
+// addDeckFuzzWorkerCommand adds the fuzz worker subcommand
+func addDeckFuzzWorkerCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "worker",
+		Usage: "Serve fuzz batches for a `deck fuzz --distributed` coordinator",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "listen",
+				Value: defaultFuzzWorkerAddr,
+				Usage: "Address to listen on (use 0.0.0.0:8091 to accept remote coordinators)",
+			},
+			&cli.StringFlag{
+				Name:    "auth-token",
+				Usage:   "Require this bearer token from coordinators",
+				Sources: cli.EnvVars(fuzzWorkerTokenEnvName),
+			},
+			&cli.IntFlag{
+				Name:  "workers",
+				Value: runtime.NumCPU(),
+				Usage: "Parallel evaluation workers per batch",
+			},
+			&cli.IntFlag{
+				Name:  "max-batches",
+				Value: 1,
+				Usage: "Batches run concurrently; extra requests are sent back to the coordinator",
+			},
+			&cli.IntFlag{
+				Name:  "max-batch-size",
+				Value: fuzzdist.DefaultMaxBatchCount,
+				Usage: "Largest batch accepted; bigger batches are rejected with 400",
+			},
+		},
+		Action: deckFuzzWorkerCommand,
+	}
+}
+
+func deckFuzzWorkerCommand(ctx context.Context, cmd *cli.Command) error {
+	addr := cmd.String("listen")
+	token := cmd.String("auth-token")
+	if token == "" && !isLoopbackAddr(addr) {
+		fprintf(os.Stderr, "Warning: fuzz worker is listening on %s without --auth-token\n", addr)
+	}
+
+	workers := cmd.Int("workers")
+	if workers < 1 || workers > fuzzdist.DefaultMaxBatchWorkers {
+		return fmt.Errorf("--workers must be between 1 and %d", fuzzdist.DefaultMaxBatchWorkers)
+	}
+
+	handler := fuzzdist.NewWorkerHandler(&fuzzWorkerRunner{workers: workers}, fuzzdist.WorkerOptions{
+		AuthToken:     token,
+		MaxConcurrent: cmd.Int("max-batches"),
+		MaxBatchCount: cmd.Int("max-batch-size"),
+		Version:       version,
+		Scoring:       evaluation.ScoringFingerprint,
+	})
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	printf("Fuzz worker listening on http://%s\n", addr)
+	if err := fuzzdist.ListenAndServe(ctx, addr, handler); err != nil {
+		return fmt.Errorf("worker error: %w", err)
+	}
+	return nil
+}
+
+// fuzzWorkerRunner runs a batch with the same generation and evaluation code
+// as a local random-mode fuzz run.
+type fuzzWorkerRunner struct {
+	workers int
+}
+
+func (r *fuzzWorkerRunner) RunBatch(ctx context.Context, req fuzzdist.BatchRequest) (*fuzzdist.BatchResponse, error) {
+	cfg := req.Config
+	cfg.Workers = r.workers
+	fuzzer, err := deck.NewDeckFuzzer(req.Player, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", fuzzdist.ErrInvalidBatch, err)
+	}
+
+	kept, streamed, err := streamFuzzResults(ctx, fuzzer.StreamDecks(ctx), req.Player, req.PlayerTag, fuzzStreamOptions{
+		workers:    r.workers,
+		keep:       max(req.Keep, 1),
+		sortBy:     req.SortBy,
+		minOverall: cfg.MinOverallScore,
+		minSynergy: cfg.MinSynergyScore,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fuzz batch: %w", err)
+	}
+	stats := fuzzer.GetStats()
+
+	payload, err := json.Marshal(kept)
+	if err != nil {
+		return nil, err
+	}
+	return &fuzzdist.BatchResponse{
+		Generated: stats.Success,
+		Evaluated: streamed.Evaluated,
+		Results:   payload,
+	}, nil
+}
+
+// distributedFuzzOptions are the coordinator settings for a distributed run.
+type distributedFuzzOptions struct {
+	endpoints []string
+	token     string
+	batchSize int
+	keep      int
+	sortBy    string
+	storage   string
+	verbose   bool
+}
+
+// runDistributedFuzz fans a random-mode run out to workers and merges the
+// best decks from every batch. stats is filled with totals across workers.
+func runDistributedFuzz(
+	ctx context.Context,
+	player *clashroyale.Player,
+	playerTag string,
+	cfg *deck.FuzzingConfig,
+	opts distributedFuzzOptions,
+	stats *deck.FuzzingStats,
+) ([]FuzzingResult, error) {
+	coordinator := &fuzzdist.Coordinator{
+		Endpoints: opts.endpoints,
+		AuthToken: opts.token,
+		Warn: func(format string, args ...any) {
+			fprintf(os.Stderr, "Warning: "+format+"\n", args...)
+		},
+	}
+
+	healthy := coordinator.Health(ctx)
+	if len(healthy) == 0 {
+		return nil, fmt.Errorf("none of the %d worker endpoints are reachable", len(opts.endpoints))
+	}
+	// Workers that score differently would refuse every batch
+	scoring := evaluation.ScoringFingerprint()
+	coordinator.Endpoints = make([]string, 0, len(healthy))
+	totalCPUs := 0
+	for _, endpoint := range opts.endpoints {
+		health, ok := healthy[endpoint]
+		if !ok {
+			continue
+		}
+		if health.Scoring != scoring {
+			fprintf(os.Stderr, "Warning: worker %s scores decks as %q, not %q; skipping it\n", endpoint, health.Scoring, scoring)
+			continue
+		}
+		coordinator.Endpoints = append(coordinator.Endpoints, endpoint)
+		totalCPUs += health.CPUs
+	}
+	if len(coordinator.Endpoints) == 0 {
+		return nil, fmt.Errorf("no reachable worker scores decks like this run (%s)", scoring)
+	}
+
+	batches := fuzzdist.Plan(cfg.Count, opts.batchSize)
+	fprintf(os.Stderr, "Distributing %d decks in %d batches across %d workers (%d CPUs)\n",
+		cfg.Count, len(batches), len(coordinator.Endpoints), totalCPUs)
+
+	start := time.Now()
+	coordinator.Progress = func(done, total int, resp *fuzzdist.BatchResponse) {
+		if opts.verbose {
+			fprintf(os.Stderr, "\rBatches %d/%d | last: %s (%d decks in %s) | elapsed %s ",
+				done, total, resp.Worker, resp.Generated,
+				(time.Duration(resp.DurationMS) * time.Millisecond).Round(time.Millisecond),
+				time.Since(start).Round(time.Second))
+		}
+	}
+
+	template := fuzzdist.BatchRequest{
+		Player:    player,
+		PlayerTag: playerTag,
+		Config:    *cfg,
+		Keep:      opts.keep,
+		SortBy:    opts.sortBy,
+		Scoring:   scoring,
+	}
+	// Each worker evaluates with its own --workers
+	template.Config.Workers = 0
+	responses, runErr := coordinator.Run(ctx, template, cfg.Count, opts.batchSize)
+	if opts.verbose {
+		fprintln(os.Stderr)
+	}
+
+	var merged []FuzzingResult
+	for _, resp := range responses {
+		var batch []FuzzingResult
+		if err := json.Unmarshal(resp.Results, &batch); err != nil {
+			return nil, fmt.Errorf("invalid results from %s for batch %d: %w", resp.Worker, resp.BatchID, err)
+		}
+		merged = append(merged, batch...)
+		stats.Generated += resp.Generated
+		stats.Success += resp.Evaluated
+	}
+	// Keep partial results when interrupted, like a local run does.
+	if runErr != nil && len(merged) == 0 {
+		return nil, runErr
+	}
+	if runErr != nil {
+		fprintf(os.Stderr, "Warning: distributed run incomplete (%d of %d batches): %v\n", len(responses), len(batches), runErr)
+	}
+
+	if opts.storage != "" {
+		storage, err := leaderboard.NewStorage(opts.storage)
+		if err != nil {
+			fprintf(os.Stderr, "Warning: failed to open storage: %v\n", err)
+		} else {
+			for _, result := range merged {
+				saveDeckToStorage(result, playerTag, storage)
+			}
+			closeFile(storage)
+		}
+	}
+	return merged, nil
+}
+
+// validateDistributedFuzzFlags rejects options that only work on a local run.
+func validateDistributedFuzzFlags(cmd *cli.Command, mode string) error {
+	if mode != "random" {
+		return fmt.Errorf("--distributed only supports --mode random")
+	}
+	if len(splitEndpoints(cmd.StringSlice("workers-endpoint"))) == 0 {
+		return fmt.Errorf("--distributed requires --workers-endpoint (or CR_API_FUZZ_WORKERS)")
+	}
+	if cmd.Int("batch-size") < 1 {
+		return fmt.Errorf("--batch-size must be at least 1")
+	}
+	for _, name := range []string{"from-saved", "resume-from"} {
+		if cmd.Int(name) > 0 {
+			return fmt.Errorf("--%s cannot be combined with --distributed", name)
+		}
+	}
+	// Workers do not receive the --constraints rules
+	for _, name := range []string{"based-on", metaFileFlagName, "constraints"} {
+		if cmd.String(name) != "" {
+			return fmt.Errorf("--%s cannot be combined with --distributed", name)
+		}
+	}
+	// Workers score with the standard preset
+	if mode, err := evaluation.ParseGameMode(cmd.String(gameModeFlagName)); err == nil && mode != evaluation.GameModeStandard {
+		return fmt.Errorf("--%s cannot be combined with --distributed", gameModeFlagName)
+	}
+	return nil
+}
+
+// splitEndpoints flattens comma-separated endpoint values and drops blanks.
+func splitEndpoints(values []string) []string {
+	var endpoints []string
+	for _, value := range values {
+		for part := range strings.SplitSeq(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				endpoints = append(endpoints, part)
+			}
+		}
+	}
+	return endpoints
+}
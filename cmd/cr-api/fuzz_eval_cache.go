@@ -0,0 +1,80 @@
+package main
+
+import (
+	"os"
+
+	"github.com/klauer/clash-royale-api/go/internal/storage"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/urfave/cli/v3"
+)
+
+// fuzzEvaluationCache is consulted by evaluateSingleDeck. deck fuzz opens it
+// for the length of a run; it is nil (never hits) everywhere else.
+var fuzzEvaluationCache *evaluation.EvaluationCache
+
+// openFuzzEvaluationCache opens the on-disk evaluation cache unless
+// --eval-cache-size is 0. A cache that cannot be read is replaced by an
+// empty one rather than failing the run.
+func openFuzzEvaluationCache(cmd *cli.Command, verbose bool) *evaluation.EvaluationCache {
+	size := cmd.Int("eval-cache-size")
+	if size <= 0 {
+		return nil
+	}
+	path := storage.NewPathBuilder(cmd.String("data-dir")).GetEvaluationCachePath()
+	cache, err := evaluation.OpenEvaluationCache(path, size)
+	if err != nil {
+		fprintf(os.Stderr, "Warning: starting with an empty evaluation cache: %v\n", err)
+		return evaluation.NewEvaluationCache(size)
+	}
+	if verbose {
+		fprintf(os.Stderr, "Evaluation cache: %d entries loaded from %s\n", cache.Stats().Loaded, path)
+	}
+	return cache
+}
+
+// closeFuzzEvaluationCache saves the cache and reports its hit rate.
+func closeFuzzEvaluationCache(cache *evaluation.EvaluationCache, verbose bool) {
+	if cache == nil {
+		return
+	}
+	if err := cache.Save(); err != nil {
+		fprintf(os.Stderr, "Warning: failed to save evaluation cache: %v\n", err)
+	}
+	if verbose {
+		stats := cache.Stats()
+		fprintf(os.Stderr, "Evaluation cache: %d hits, %d misses (%.1f%% hit rate), %d entries\n",
+			stats.Hits, stats.Misses, stats.HitRate()*100, stats.Entries)
+	}
+}
+
+// cachedGAFitness memoizes a GA fitness function in cache under the fitness
+// mode. A nil evaluator stands for the genome's own evaluation. Fitness
+// computed while a scorer plugin failed is not cached.
+func cachedGAFitness(cache *evaluation.EvaluationCache, evaluator func([]deck.CardCandidate) (float64, error), fitnessMode string) func([]deck.CardCandidate) (float64, error) {
+	if cache == nil {
+		return evaluator
+	}
+	if evaluator == nil {
+		synergyDB := deck.NewSynergyDatabase()
+		evaluator = func(deckCards []deck.CardCandidate) (float64, error) {
+			return evaluation.Evaluate(deckCards, synergyDB, nil).OverallScore, nil
+		}
+	}
+	variant := "ga-fitness=" + fitnessMode
+	return func(deckCards []deck.CardCandidate) (float64, error) {
+		key := evaluation.EvaluationCacheKey(deckCards, variant)
+		if cached, ok := cache.Get(key); ok {
+			return cached.OverallScore, nil
+		}
+		failures := evaluation.ScorerPluginFailures()
+		fitness, err := evaluator(deckCards)
+		if err != nil {
+			return 0, err
+		}
+		if evaluation.ScorerPluginFailures() == failures {
+			cache.Put(key, evaluation.CachedEvaluation{OverallScore: fitness})
+		}
+		return fitness, nil
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+)
+
+func TestCachedGAFitness(t *testing.T) {
+	calls := 0
+	evaluator := func([]deck.CardCandidate) (float64, error) {
+		calls++
+		return 4.2, nil
+	}
+	if got := cachedGAFitness(nil, evaluator, "mode"); got == nil {
+		t.Fatal("expected the evaluator back without a cache")
+	}
+
+	cache := evaluation.NewEvaluationCache(10)
+	cached := cachedGAFitness(cache, evaluator, "mode")
+	cards := []deck.CardCandidate{{Name: "Knight", Level: 14}, {Name: "Hog Rider", Level: 13}}
+	for range 3 {
+		fitness, err := cached(cards)
+		if err != nil || fitness != 4.2 {
+			t.Fatalf("cached fitness = %v, %v", fitness, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("evaluator called %d times, want 1", calls)
+	}
+
+	// Another fitness mode must not reuse the entry
+	if _, err := cachedGAFitness(cache, evaluator, "other")(cards); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("evaluator called %d times after a mode change, want 2", calls)
+	}
+}
+
+func TestEvaluateSingleDeckUsesEvaluationCache(t *testing.T) {
+	fuzzEvaluationCache = evaluation.NewEvaluationCache(10)
+	defer func() { fuzzEvaluationCache = nil }()
+
+	cards := []string{"Hog Rider", "Musketeer", "Valkyrie", "Ice Spirit", "Skeletons", "Cannon", "Fireball", "The Log"}
+	synergyDB := deck.NewSynergyDatabase()
+	first := evaluateSingleDeck(cards, nil, "", synergyDB, nil, evaluation.EvaluateOptions{})
+	second := evaluateSingleDeck(cards, nil, "", synergyDB, nil, evaluation.EvaluateOptions{})
+
+	if first.OverallScore != second.OverallScore || first.Archetype != second.Archetype {
+		t.Errorf("cached result %+v differs from %+v", second, first)
+	}
+	stats := fuzzEvaluationCache.Stats()
+	if stats.Hits != 1 || stats.Misses != 1 {
+		t.Errorf("stats = %+v, want 1 hit and 1 miss", stats)
+	}
+}
+
+type failingScorerPlugin struct{}
+
+func (failingScorerPlugin) Name() string { return "offline" }
+
+func (failingScorerPlugin) Score([]deck.CardCandidate) (float64, error) {
+	return 0, errors.New("model not loaded")
+}
+
+func TestEvaluationCacheSkipsFailedScorerPlugins(t *testing.T) {
+	fuzzEvaluationCache = evaluation.NewEvaluationCache(10)
+	defer func() { fuzzEvaluationCache = nil }()
+	t.Cleanup(evaluation.ResetScorerPlugins)
+	if err := evaluation.RegisterScorerPlugin(failingScorerPlugin{}, 0.3); err != nil {
+		t.Fatal(err)
+	}
+
+	cards := []string{"Hog Rider", "Musketeer", "Valkyrie", "Ice Spirit", "Skeletons", "Cannon", "Fireball", "The Log"}
+	evaluateSingleDeck(cards, nil, "", deck.NewSynergyDatabase(), nil, evaluation.EvaluateOptions{})
+	fitness := cachedGAFitness(fuzzEvaluationCache, nil, "mode")
+	if _, err := fitness(convertDeckToCandidates(cards, nil)); err != nil {
+		t.Fatal(err)
+	}
+	if entries := fuzzEvaluationCache.Stats().Entries; entries != 0 {
+		t.Errorf("cache has %d entries, want none after a failed plugin", entries)
+	}
+}
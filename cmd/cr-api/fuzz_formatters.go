@@ -3,6 +3,8 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"math"
 	"os"
 	"path/filepath"
 	"sort"
@@ -13,6 +15,8 @@ import (
 
 	"github.com/klauer/clash-royale-api/go/internal/playertag"
 	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/genetic"
+	"github.com/klauer/clash-royale-api/go/pkg/fuzzstorage"
 )
 
 const (
@@ -22,34 +26,43 @@ const (
 )
 
 func sortFuzzingResultsImpl(results []FuzzingResult, sortBy string) {
+	better := fuzzResultBetter(sortBy)
 	sort.Slice(results, func(i, j int) bool {
-		var iValue, jValue float64
-
-		switch sortBy {
-		case "overall":
-			iValue = results[i].OverallScore
-			jValue = results[j].OverallScore
-		case "attack":
-			iValue = results[i].AttackScore
-			jValue = results[j].AttackScore
-		case "defense":
-			iValue = results[i].DefenseScore
-			jValue = results[j].DefenseScore
-		case "synergy":
-			iValue = results[i].SynergyScore
-			jValue = results[j].SynergyScore
-		case "versatility":
-			iValue = results[i].VersatilityScore
-			jValue = results[j].VersatilityScore
-		case "elixir":
-			return results[i].AvgElixir < results[j].AvgElixir
-		default:
-			iValue = results[i].OverallScore
-			jValue = results[j].OverallScore
+		return better(results[i], results[j])
+	})
+}
+
+// fuzzResultBetter returns the ordering used for --sort-by: a ranks ahead of b.
+// Ties are broken by the sorted card list, so the ranking does not depend on
+// the order results arrived in.
+func fuzzResultBetter(sortBy string) func(a, b FuzzingResult) bool {
+	value, ascending := fuzzSortValue(sortBy)
+	return func(a, b FuzzingResult) bool {
+		va, vb := value(a), value(b)
+		if va != vb {
+			return (va < vb) == ascending
 		}
+		return deckKeyForResult(a) < deckKeyForResult(b)
+	}
+}
 
-		return iValue > jValue
-	})
+// fuzzSortValue returns the score --sort-by ranks on and whether lower
+// values rank first.
+func fuzzSortValue(sortBy string) (func(FuzzingResult) float64, bool) {
+	switch sortBy {
+	case "attack":
+		return func(r FuzzingResult) float64 { return r.AttackScore }, false
+	case "defense":
+		return func(r FuzzingResult) float64 { return r.DefenseScore }, false
+	case "synergy":
+		return func(r FuzzingResult) float64 { return r.SynergyScore }, false
+	case "versatility":
+		return func(r FuzzingResult) float64 { return r.VersatilityScore }, false
+	case "elixir":
+		return func(r FuzzingResult) float64 { return r.AvgElixir }, true
+	default:
+		return func(r FuzzingResult) float64 { return r.OverallScore }, false
+	}
 }
 
 func getTopResultsImpl(results []FuzzingResult, top int) []FuzzingResult {
@@ -77,6 +90,8 @@ func formatFuzzingResultsImpl(
 		return formatResultsCSVImpl(results)
 	case fuzzOutputDetailed:
 		return formatResultsDetailedImpl(results, playerName, playerTag)
+	case fuzzOutputParquet:
+		return writeParquetToStdout(func(w io.Writer) error { return formatResultsParquetImpl(w, results) })
 	default:
 		return formatResultsSummaryImpl(results, playerName, playerTag, fuzzerConfig, mode, generationTime, stats, totalFiltered)
 	}
@@ -118,15 +133,16 @@ func formatResultsSummaryImpl(
 
 	w := new(tabwriter.Writer)
 	w.Init(os.Stdout, 0, 8, 2, ' ', 0)
-	fprintln(w, "Rank\tDeck\tOverall\tLadder\tNorm\tAttack\tDefense\tSynergy\tElixir")
+	fprintln(w, "Rank\tName\tDeck\tOverall\tLadder\tNorm\tAttack\tDefense\tSynergy\tElixir\tArchetype")
 
 	for i, result := range results {
 		deckStr := strings.Join(result.Deck, ", ")
 
 		if len(deckStr) > 50 {
 			firstLine := strings.Join(result.Deck[:4], ", ")
-			fprintf(w, "%d\t%s\t%.2f\t%.2f\t%.2f\t%.2f\t%.2f\t%.2f\t%.2f\n",
+			fprintf(w, "%d\t%s\t%s\t%.2f\t%.2f\t%.2f\t%.2f\t%.2f\t%.2f\t%.2f\t%s\n",
 				i+1,
+				result.Name,
 				firstLine+",",
 				result.OverallScore,
 				result.LadderScore,
@@ -135,13 +151,15 @@ func formatResultsSummaryImpl(
 				result.DefenseScore,
 				result.SynergyScore,
 				result.AvgElixir,
+				resultArchetypeLabel(result),
 			)
 
 			secondLine := strings.Join(result.Deck[4:], ", ")
-			fprintf(w, "\t%s\n", secondLine)
+			fprintf(w, "\t\t%s\n", secondLine)
 		} else {
-			fprintf(w, "%d\t%s\t%.2f\t%.2f\t%.2f\t%.2f\t%.2f\t%.2f\t%.2f\n",
+			fprintf(w, "%d\t%s\t%s\t%.2f\t%.2f\t%.2f\t%.2f\t%.2f\t%.2f\t%.2f\t%s\n",
 				i+1,
+				result.Name,
 				deckStr,
 				result.OverallScore,
 				result.LadderScore,
@@ -150,14 +168,76 @@ func formatResultsSummaryImpl(
 				result.DefenseScore,
 				result.SynergyScore,
 				result.AvgElixir,
+				resultArchetypeLabel(result),
 			)
 		}
 	}
 
 	flushWriter(w)
+
+	printClusterVariants(results)
+	printDeckLinks(results)
 	return nil
 }
 
+// archetypeLabel names a deck's archetype, spelling out both halves of a
+// hybrid, e.g. "hybrid (beatdown/miner)".
+func archetypeLabel(archetype, hybridPrimary, hybridSecondary string) string {
+	if hybridPrimary == "" {
+		return archetype
+	}
+	return fmt.Sprintf("%s (%s/%s)", archetype, hybridPrimary, hybridSecondary)
+}
+
+func resultArchetypeLabel(result FuzzingResult) string {
+	return archetypeLabel(result.Archetype, result.HybridPrimary, result.HybridSecondary)
+}
+
+func storedArchetypeLabel(entry fuzzstorage.DeckEntry) string {
+	return archetypeLabel(entry.Archetype, entry.HybridPrimary, entry.HybridSecondary)
+}
+
+// storedDeckName returns a stored deck's name, generating one for decks saved
+// before names were stored
+func storedDeckName(entry fuzzstorage.DeckEntry) string {
+	if entry.Name != "" {
+		return entry.Name
+	}
+	archetype := namingArchetype(entry.Archetype, entry.HybridPrimary, entry.HybridSecondary)
+	return deck.GenerateDeckName(convertDeckToCandidates(entry.Cards, nil), archetype)
+}
+
+// printClusterVariants lists the near-duplicates --cluster folded into each
+// ranked result.
+func printClusterVariants(results []FuzzingResult) {
+	header := false
+	for i, result := range results {
+		if result.Variants == 0 {
+			continue
+		}
+		if !header {
+			printf("\nNear-Duplicate Variants:\n")
+			header = true
+		}
+		printf("  %d. %s: %d more deck(s) sharing most cards\n", i+1, result.Name, result.Variants)
+	}
+}
+
+// printDeckLinks lists copy-deck links for ranked results that have one.
+func printDeckLinks(results []FuzzingResult) {
+	header := false
+	for i, result := range results {
+		if result.DeckLink == "" {
+			continue
+		}
+		if !header {
+			printf("\nCopy Deck Links:\n")
+			header = true
+		}
+		printf("  %d. %s\n", i+1, result.DeckLink)
+	}
+}
+
 func formatResultsJSONImpl(
 	results []FuzzingResult,
 	playerName string,
@@ -181,6 +261,8 @@ func formatResultsJSONImpl(
 			"mode":              mode,
 			"count":             fuzzerConfig.Count,
 			"workers":           fuzzerConfig.Workers,
+			"seed":              fuzzerConfig.Seed,
+			"deterministic":     fuzzerConfig.Deterministic,
 			"include_cards":     fuzzerConfig.IncludeCards,
 			"exclude_cards":     fuzzerConfig.ExcludeCards,
 			"min_avg_elixir":    fuzzerConfig.MinAvgElixir,
@@ -197,7 +279,10 @@ func formatResultsJSONImpl(
 }
 
 func formatResultsCSVImpl(results []FuzzingResult) error {
-	header := []string{"Rank", "Deck", "Overall", "Contextual", "Ladder", "Normalized", "LevelRatio", "NormFactor", csvHeaderAttack, "Defense", "Synergy", "Versatility", "AvgElixir", "Archetype"}
+	header := []string{
+		"Rank", "Deck", "Overall", "Contextual", "Ladder", "Normalized", "LevelRatio", "NormFactor", csvHeaderAttack, "Defense", "Synergy", "Versatility", "AvgElixir",
+		csvHeaderArchetype, csvHeaderHybridPrimary, csvHeaderHybridSecondary, csvHeaderName, "Variants",
+	}
 	rows := make([][]string, 0, len(results))
 	for i, result := range results {
 		deckStr := strings.Join(result.Deck, ", ")
@@ -216,6 +301,10 @@ func formatResultsCSVImpl(results []FuzzingResult) error {
 			fmt.Sprintf("%.2f", result.VersatilityScore),
 			fmt.Sprintf("%.2f", result.AvgElixir),
 			result.Archetype,
+			result.HybridPrimary,
+			result.HybridSecondary,
+			result.Name,
+			strconv.Itoa(result.Variants),
 		})
 	}
 	return writeCSVDocument(os.Stdout, header, rows)
@@ -230,7 +319,7 @@ func formatResultsDetailedImpl(
 	printf("\nTop %d Decks:\n\n", len(results))
 
 	for i, result := range results {
-		printf("=== Deck %d ===\n", i+1)
+		printf("=== Deck %d: %s ===\n", i+1, result.Name)
 		printf("Cards: %s\n", strings.Join(result.Deck, ", "))
 		printf("Overall: %.2f | Attack: %.2f | Defense: %.2f | Synergy: %.2f | Versatility: %.2f\n",
 			result.OverallScore, result.AttackScore, result.DefenseScore, result.SynergyScore, result.VersatilityScore)
@@ -239,7 +328,13 @@ func formatResultsDetailedImpl(
 		printf("Level Ratio: %.3f | Normalization Factor: %.3f\n",
 			result.DeckLevelRatio, result.NormalizationFactor)
 		printf("Avg Elixir: %.2f | Archetype: %s (%.0f%% confidence)\n",
-			result.AvgElixir, result.Archetype, result.ArchetypeConfidence*100)
+			result.AvgElixir, resultArchetypeLabel(result), result.ArchetypeConfidence*100)
+		if result.Variants > 0 {
+			printf("Variants: %d near-duplicate deck(s)\n", result.Variants)
+		}
+		if result.DeckLink != "" {
+			printf("Copy Deck: %s\n", result.DeckLink)
+		}
 		printf("Evaluated: %s\n\n", result.EvaluatedAt.Format(time.RFC3339))
 	}
 
@@ -264,6 +359,8 @@ func saveResultsToFileImpl(results []FuzzingResult, outputDir, format, playerTag
 		filename = fmt.Sprintf("fuzz_%s_%s.json", cleanTag, timestamp)
 	case fuzzOutputCSV:
 		filename = fmt.Sprintf("fuzz_%s_%s.csv", cleanTag, timestamp)
+	case fuzzOutputParquet:
+		filename = fmt.Sprintf("fuzz_%s_%s.parquet", cleanTag, timestamp)
 	default:
 		filename = fmt.Sprintf("fuzz_%s_%s.txt", cleanTag, timestamp)
 	}
@@ -292,7 +389,61 @@ func saveResultsToFileImpl(results []FuzzingResult, outputDir, format, playerTag
 		return formatResultsJSONImpl(results, displayTag, playerTag, config, "unknown", 0, stats, len(results))
 	case fuzzOutputCSV:
 		return formatResultsCSVImpl(results)
+	case fuzzOutputParquet:
+		return formatResultsParquetImpl(file, results)
 	default:
 		return formatResultsSummaryImpl(results, displayTag, playerTag, &deck.FuzzingConfig{}, "unknown", 0, &deck.FuzzingStats{}, len(results))
 	}
 }
+
+// paretoBarWidth is the width of a full (10/10) objective bar.
+const paretoBarWidth = 10
+
+// formatParetoFront prints the multi-objective GA front: one row per
+// non-dominated deck with a score and bar per objective, so trade-offs
+// between decks can be compared at a glance.
+func formatParetoFront(out io.Writer, objectives []genetic.Objective, front []genetic.ParetoSolution, limit int) {
+	shown := front
+	if limit > 0 && len(shown) > limit {
+		shown = shown[:limit]
+	}
+
+	fprintf(out, "\nPareto Front (%d non-dominated decks, objectives: %s)\n", len(front), joinObjectives(objectives))
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	header := []string{"#"}
+	for _, objective := range objectives {
+		header = append(header, strings.ToUpper(string(objective)))
+	}
+	header = append(header, "Deck")
+	fprintf(w, "%s\n", strings.Join(header, "\t"))
+	for i, solution := range shown {
+		row := []string{strconv.Itoa(i + 1)}
+		for _, score := range solution.Objectives {
+			row = append(row, fmt.Sprintf("%4.1f %s", score, paretoBar(score)))
+		}
+		cards := []string{}
+		if solution.Genome != nil {
+			cards = solution.Genome.Cards
+		}
+		row = append(row, strings.Join(cards, ", "))
+		fprintf(w, "%s\n", strings.Join(row, "\t"))
+	}
+	flushWriter(w)
+	if len(shown) < len(front) {
+		fprintf(out, "... %d more (raise --top to show)\n", len(front)-len(shown))
+	}
+}
+
+// paretoBar renders a 0-10 score as a fixed-width bar.
+func paretoBar(score float64) string {
+	filled := int(math.Round(math.Max(0, math.Min(10, score)) / 10 * paretoBarWidth))
+	return strings.Repeat("█", filled) + strings.Repeat("░", paretoBarWidth-filled)
+}
+
+func joinObjectives(objectives []genetic.Objective) string {
+	names := make([]string, len(objectives))
+	for i, objective := range objectives {
+		names[i] = string(objective)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/pkg/fuzzstorage"
+	"github.com/urfave/cli/v3"
+)
+
+// addDeckFuzzLineageCommand adds the subcommand that shows how a saved deck
+// evolved
+func addDeckFuzzLineageCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "lineage",
+		Usage:     "Show the family tree of mutations, variations, and crossovers behind a saved deck",
+		ArgsUsage: "<deck id or name>",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "depth",
+				Value: 5,
+				Usage: "Generations of ancestors to show",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: "summary",
+				Usage: "Output format: summary, json",
+			},
+		},
+		Action: deckFuzzLineageCommand,
+	}
+}
+
+// lineageJSON is a lineage tree node in JSON output
+type lineageJSON struct {
+	ID         int            `json:"id,omitempty"`
+	Name       string         `json:"name,omitempty"`
+	Cards      []string       `json:"cards"`
+	Score      float64        `json:"overall_score,omitempty"`
+	Operation  string         `json:"operation,omitempty"`
+	Generation int            `json:"generation,omitempty"`
+	Repeated   bool           `json:"repeated,omitempty"`
+	Parents    []*lineageJSON `json:"parents,omitempty"`
+}
+
+func deckFuzzLineageCommand(ctx context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() != 1 {
+		return errors.New("usage: cr-api deck fuzz lineage <deck id or name>")
+	}
+	depth := cmd.Int("depth")
+	if depth < 1 {
+		return fmt.Errorf("--depth must be at least 1")
+	}
+	format := strings.ToLower(cmd.String("format"))
+	if format != "summary" && format != "json" {
+		return fmt.Errorf("invalid format %q (must be summary or json)", format)
+	}
+
+	storage, entry, err := openCurationStorage(cmd.Args().First())
+	if err != nil {
+		return err
+	}
+	defer closeFile(storage)
+
+	tree, err := storage.Lineage(entry.Cards, depth)
+	if err != nil {
+		return err
+	}
+	if format == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(lineageTreeJSON(tree))
+	}
+
+	printf("%s\n", lineageLabel(tree))
+	if tree.Operation == "" {
+		printf("No recorded parents: the deck was generated from scratch or saved before lineage was tracked\n")
+		return nil
+	}
+	printLineageParents(os.Stdout, tree, "")
+	return nil
+}
+
+// printLineageParents draws the operation that produced node and its
+// parents below it, one level deeper per generation
+func printLineageParents(w io.Writer, node *fuzzstorage.LineageNode, indent string) {
+	if node.Operation == "" {
+		return
+	}
+	operation := node.Operation
+	if node.Generation > 0 {
+		operation = fmt.Sprintf("%s, generation %d", operation, node.Generation)
+	}
+	fprintf(w, "%s└─ %s\n", indent, operation)
+	indent += "   "
+	for i, parent := range node.Parents {
+		branch, next := "├─ ", "│  "
+		if i == len(node.Parents)-1 {
+			branch, next = "└─ ", "   "
+		}
+		fprintf(w, "%s%s%s\n", indent, branch, lineageLabel(parent))
+		if !parent.Repeated {
+			printLineageParents(w, parent, indent+next)
+		}
+	}
+}
+
+// lineageLabel describes a deck in the tree: saved decks by ID, name, and
+// score, others by their cards
+func lineageLabel(node *fuzzstorage.LineageNode) string {
+	label := strings.Join(node.Cards, ", ") + " (not saved)"
+	if node.Deck != nil {
+		label = fmt.Sprintf("#%d %s (%.2f)", node.Deck.ID, storedDeckName(*node.Deck), node.Deck.OverallScore)
+	}
+	if node.Repeated {
+		label += " (shown above)"
+	}
+	return label
+}
+
+func lineageTreeJSON(node *fuzzstorage.LineageNode) *lineageJSON {
+	out := &lineageJSON{
+		Cards:      node.Cards,
+		Operation:  node.Operation,
+		Generation: node.Generation,
+		Repeated:   node.Repeated,
+	}
+	if node.Deck != nil {
+		out.ID = node.Deck.ID
+		out.Name = storedDeckName(*node.Deck)
+		out.Score = node.Deck.OverallScore
+	}
+	for _, parent := range node.Parents {
+		out.Parents = append(out.Parents, lineageTreeJSON(parent))
+	}
+	return out
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/fuzzstorage"
+)
+
+func TestDeckFuzzLineageCommand(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	seed := []string{"Hog Rider", "Musketeer", "Cannon", "Ice Golem", "Ice Spirit", "Skeletons", "Fireball", "The Log"}
+	other := []string{"Golem", "Night Witch", "Baby Dragon", "Lumberjack", "Tornado", "Lightning", "Barbarian Barrel", "Mega Minion"}
+	bred := []string{"Hog Rider", "Musketeer", "Cannon", "Ice Golem", "Tornado", "Lightning", "Barbarian Barrel", "Mega Minion"}
+	best := []string{"Hog Rider", "Musketeer", "Tesla", "Ice Golem", "Tornado", "Lightning", "Barbarian Barrel", "Mega Minion"}
+
+	lineage := deck.NewLineageRecorder()
+	lineage.SetGeneration(3)
+	lineage.Record(bred, deck.LineageCrossover, seed, other)
+	lineage.SetGeneration(4)
+	lineage.Record(best, deck.LineageMutation, bred)
+
+	results := []FuzzingResult{
+		{Name: "Hog Tesla", Deck: best, OverallScore: 9, Archetype: "control", EvaluatedAt: time.Now()},
+		{Name: "Hog Cannon", Deck: seed, OverallScore: 8, Archetype: "cycle", EvaluatedAt: time.Now()},
+	}
+	if err := saveTopDecksToStorage(results, fuzzstorage.RetentionPolicy{}, lineage, false); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) (string, error) {
+		return captureStdout(t, func() error {
+			return addDeckFuzzLineageCommand().Run(context.Background(), append([]string{"lineage"}, args...))
+		})
+	}
+
+	output, err := run("Hog Tesla")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"#1 Hog Tesla (9.00)",
+		"└─ mutation, generation 4",
+		"(not saved)",
+		"└─ crossover, generation 3",
+		"├─ #2 Hog Cannon (8.00)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("lineage output is missing %q:\n%s", want, output)
+		}
+	}
+
+	output, err = run("--format", "json", "--depth", "1", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tree lineageJSON
+	if err := json.Unmarshal([]byte(output), &tree); err != nil {
+		t.Fatal(err)
+	}
+	if tree.ID != 1 || tree.Operation != deck.LineageMutation || len(tree.Parents) != 1 || len(tree.Parents[0].Parents) != 0 {
+		t.Errorf("JSON tree = %+v, want one level of parents", tree)
+	}
+
+	if output, err = run("2"); err != nil || !strings.Contains(output, "No recorded parents") {
+		t.Errorf("lineage of a seed deck = %q, %v", output, err)
+	}
+}
@@ -0,0 +1,286 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/datapath"
+	"github.com/klauer/clash-royale-api/go/internal/playertag"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/klauer/clash-royale-api/go/pkg/fuzzstorage"
+	"github.com/klauer/clash-royale-api/go/pkg/leaderboard"
+	"github.com/urfave/cli/v3"
+)
+
+// addDeckFuzzMigrateCommand adds the fuzz migrate subcommand
+func addDeckFuzzMigrateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "migrate",
+		Usage: "Re-score saved decks written by older versions of the scoring algorithm",
+		Flags: []cli.Flag{
+			playerTagFlagWithUsage(false, "Player tag (without #) to apply level-aware scoring and migrate that player's leaderboard"),
+			&cli.IntFlag{
+				Name:  "workers",
+				Value: 1,
+				Usage: "Number of parallel workers for re-evaluation",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Report which decks would be re-scored without changing storage",
+			},
+			&cli.BoolFlag{
+				Name:    "verbose",
+				Aliases: []string{"v"},
+				Usage:   "Show detailed progress information",
+			},
+		},
+		Action: deckFuzzMigrateCommand,
+	}
+}
+
+// scoringMigration summarizes one storage migration
+type scoringMigration struct {
+	Pending    int
+	Migrated   int
+	ByVersion  map[string]int
+	ScoreDelta float64 // sum of new minus old overall scores
+}
+
+func (m scoringMigration) avgDelta() float64 {
+	if m.Migrated == 0 {
+		return 0
+	}
+	return m.ScoreDelta / float64(m.Migrated)
+}
+
+func deckFuzzMigrateCommand(ctx context.Context, cmd *cli.Command) error {
+	playerTag := cmd.String("tag")
+	verbose := cmd.Bool("verbose")
+	dryRun := cmd.Bool("dry-run")
+	workers := resolveFuzzWorkers(cmd, true, verbose)
+
+	storage, err := fuzzstorage.NewStorage("")
+	if err != nil {
+		return fmt.Errorf("failed to open storage: %w", err)
+	}
+	defer closeFile(storage)
+
+	counts, err := storage.VersionCounts()
+	if err != nil {
+		return err
+	}
+	printf("Current scoring version: %s\n", evaluation.CurrentScoringVersion)
+	printVersionCounts(counts)
+
+	var player *clashroyale.Player
+	var playerContext *evaluation.PlayerContext
+	if playerTag != "" && !dryRun {
+		player, playerContext, err = loadFuzzPlayerContext(ctx, cmd, playerTag, verbose)
+		if err != nil {
+			return err
+		}
+	}
+
+	start := time.Now()
+	summary, err := migrateFuzzStorage(storage, player, playerTag, playerContext, workers, dryRun)
+	if err != nil {
+		return err
+	}
+	printMigrationSummary("fuzz storage", summary, dryRun)
+
+	if playerTag != "" {
+		lbSummary, found, err := migratePlayerLeaderboard(playerTag, player, playerContext, dryRun)
+		if err != nil {
+			return err
+		}
+		if found {
+			printMigrationSummary("leaderboard for "+playerTag, lbSummary, dryRun)
+		}
+	}
+
+	if verbose {
+		fprintf(os.Stderr, "Migration finished in %v\n", time.Since(start).Round(time.Millisecond))
+		fprintf(os.Stderr, "Database: %s\n", storage.GetDBPath())
+	}
+	return nil
+}
+
+// migrateFuzzStorage re-scores every stored deck whose evaluation version is
+// older than the current one. Decks scored by a newer release are left
+// untouched.
+func migrateFuzzStorage(
+	storage *fuzzstorage.Storage,
+	player *clashroyale.Player,
+	playerTag string,
+	playerContext *evaluation.PlayerContext,
+	workers int,
+	dryRun bool,
+) (scoringMigration, error) {
+	summary := scoringMigration{ByVersion: make(map[string]int)}
+
+	entries, err := storage.Query(fuzzstorage.QueryOptions{ExcludeEvaluationVersion: evaluation.CurrentScoringVersion})
+	if err != nil {
+		return summary, fmt.Errorf("failed to query decks: %w", err)
+	}
+	entries = slices.DeleteFunc(entries, func(e fuzzstorage.DeckEntry) bool {
+		return !evaluation.NeedsRescore(e.EvaluationVersion)
+	})
+	summary.Pending = len(entries)
+	if dryRun || len(entries) == 0 {
+		for _, entry := range entries {
+			summary.ByVersion[entry.EvaluationVersion]++
+		}
+		return summary, nil
+	}
+
+	// Migration always shows progress; it can touch the whole database
+	updated := reevaluateStoredDecks(entries, player, playerTag, playerContext, workers, true)
+	for i := range updated {
+		if err := storage.UpdateDeck(&updated[i]); err != nil {
+			return summary, fmt.Errorf("failed to update deck %d: %w", updated[i].ID, err)
+		}
+		summary.Migrated++
+		summary.ByVersion[entries[i].EvaluationVersion]++
+		summary.ScoreDelta += updated[i].OverallScore - entries[i].OverallScore
+	}
+	return summary, nil
+}
+
+// migratePlayerLeaderboard re-scores the player's `deck fuzz --storage`
+// leaderboard. found is false when the player has no leaderboard yet, so a
+// migration never creates an empty database.
+func migratePlayerLeaderboard(
+	playerTag string,
+	player *clashroyale.Player,
+	playerContext *evaluation.PlayerContext,
+	dryRun bool,
+) (scoringMigration, bool, error) {
+	summary := scoringMigration{ByVersion: make(map[string]int)}
+
+	sanitized, err := playertag.Sanitize(playerTag)
+	if err != nil {
+		return summary, false, err
+	}
+	dbPath, err := datapath.LeaderboardDBPath(sanitized)
+	if err != nil {
+		return summary, false, err
+	}
+	if _, err := os.Stat(dbPath); err != nil {
+		return summary, false, nil
+	}
+
+	storage, err := leaderboard.NewStorage(playerTag)
+	if err != nil {
+		return summary, true, fmt.Errorf("failed to open leaderboard: %w", err)
+	}
+	defer closeFile(storage)
+
+	entries, err := storage.Query(leaderboard.QueryOptions{ExcludeEvaluationVersion: evaluation.CurrentScoringVersion})
+	if err != nil {
+		return summary, true, fmt.Errorf("failed to query leaderboard: %w", err)
+	}
+	entries = slices.DeleteFunc(entries, func(e leaderboard.DeckEntry) bool {
+		return !evaluation.NeedsRescore(e.EvaluationVersion)
+	})
+	summary.Pending = len(entries)
+	if dryRun {
+		for _, entry := range entries {
+			summary.ByVersion[entry.EvaluationVersion]++
+		}
+		return summary, true, nil
+	}
+
+	synergyDB := deck.NewSynergyDatabase()
+	bar := newReevaluateProgressBar(len(entries), len(entries) > 0)
+	for i := range entries {
+		entry := &entries[i]
+		oldVersion, oldScore := entry.EvaluationVersion, entry.OverallScore
+		result := evaluateSingleDeck(entry.Cards, player, playerTag, synergyDB, playerContext, evaluation.EvaluateOptions{})
+		entry.OverallScore = result.OverallScore
+		entry.AttackScore = result.AttackScore
+		entry.DefenseScore = result.DefenseScore
+		entry.SynergyScore = result.SynergyScore
+		entry.VersatilityScore = result.VersatilityScore
+		entry.AvgElixir = result.AvgElixir
+		entry.Archetype = result.Archetype
+		entry.ArchetypeConf = result.ArchetypeConfidence
+		entry.EvaluatedAt = result.EvaluatedAt
+		entry.EvaluationVersion = evaluation.CurrentScoringVersion
+		if _, _, err := storage.InsertDeck(entry); err != nil {
+			return summary, true, fmt.Errorf("failed to update leaderboard deck %d: %w", entry.ID, err)
+		}
+		advanceReevaluateBar(bar)
+		summary.Migrated++
+		summary.ByVersion[oldVersion]++
+		summary.ScoreDelta += entry.OverallScore - oldScore
+	}
+	if summary.Migrated > 0 {
+		if _, err := storage.RecalculateStats(); err != nil {
+			fprintf(os.Stderr, "Warning: failed to recalculate leaderboard stats: %v\n", err)
+		}
+	}
+	return summary, true, nil
+}
+
+// printVersionCounts lists stored decks per scoring version, oldest first,
+// with the registry's description of each version.
+func printVersionCounts(counts map[string]int) {
+	versions := make([]string, 0, len(counts))
+	for v := range counts {
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, evaluation.CompareScoringVersions)
+
+	for _, v := range versions {
+		label, note := v, "unknown version"
+		if v == "" {
+			label, note = "unversioned", "saved before scoring versions were recorded"
+		} else if info, ok := evaluation.LookupScoringVersion(v); ok {
+			note = info.Changes
+		}
+		printf("  %-12s %6d decks  %s\n", label, counts[v], note)
+	}
+}
+
+func printMigrationSummary(target string, summary scoringMigration, dryRun bool) {
+	if summary.Pending == 0 {
+		printf("All decks in %s are scored with version %s\n", target, evaluation.CurrentScoringVersion)
+		return
+	}
+	if dryRun {
+		printf("Would re-score %d decks in %s%s\n", summary.Pending, target, formatVersionBreakdown(summary.ByVersion))
+		return
+	}
+	printf("Re-scored %d decks in %s%s, average score change %+.2f\n",
+		summary.Migrated, target, formatVersionBreakdown(summary.ByVersion), summary.avgDelta())
+}
+
+// formatVersionBreakdown renders " (1.0.0: 3, unversioned: 2)"
+func formatVersionBreakdown(byVersion map[string]int) string {
+	versions := make([]string, 0, len(byVersion))
+	for v := range byVersion {
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, evaluation.CompareScoringVersions)
+
+	out := ""
+	for i, v := range versions {
+		label := v
+		if v == "" {
+			label = "unversioned"
+		}
+		if i > 0 {
+			out += ", "
+		}
+		out += fmt.Sprintf("%s: %d", label, byVersion[v])
+	}
+	if out == "" {
+		return ""
+	}
+	return " (" + out + ")"
+}
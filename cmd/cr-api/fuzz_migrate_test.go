@@ -0,0 +1,137 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/klauer/clash-royale-api/go/pkg/fuzzstorage"
+	"github.com/klauer/clash-royale-api/go/pkg/leaderboard"
+)
+
+var migrateTestDecks = [][]string{
+	{"Knight", "Archers", "Fireball", "Hog Rider", "The Log", "Musketeer", "Ice Spirit", "Cannon"},
+	{"Golem", "Night Witch", "Baby Dragon", "Lightning", "Tornado", "Mega Minion", "Lumberjack", "Barbarian Barrel"},
+	{"Giant", "Prince", "Dark Prince", "Zap", "Fireball", "Minions", "Skeletons", "Mini P.E.K.K.A"},
+}
+
+func TestMigrateFuzzStorageRescoresOlderVersions(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	storage, err := fuzzstorage.NewStorage("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeFile(storage)
+
+	versions := []string{"", "1.0.0", "9.0.0"}
+	for i, cards := range migrateTestDecks {
+		entry := fuzzstorage.DeckEntry{
+			Cards:             cards,
+			OverallScore:      1,
+			Archetype:         "cycle",
+			EvaluatedAt:       time.Now(),
+			EvaluationVersion: versions[i],
+		}
+		if _, err := storage.SaveTopDecks([]fuzzstorage.DeckEntry{entry}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dry, err := migrateFuzzStorage(storage, nil, "", nil, 1, true)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if dry.Pending != 2 || dry.Migrated != 0 || dry.ByVersion[""] != 1 || dry.ByVersion["1.0.0"] != 1 {
+		t.Fatalf("dry run summary = %+v; the 9.0.0 deck should be skipped", dry)
+	}
+
+	summary, err := migrateFuzzStorage(storage, nil, "", nil, 2, false)
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if summary.Migrated != 2 {
+		t.Fatalf("migrated %d decks, want 2", summary.Migrated)
+	}
+
+	counts, err := storage.VersionCounts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counts[evaluation.CurrentScoringVersion] != 2 || counts["9.0.0"] != 1 || counts[""] != 0 {
+		t.Errorf("version counts after migration = %v", counts)
+	}
+	entries, err := storage.GetTopN(len(migrateTestDecks))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != len(migrateTestDecks) {
+		t.Fatalf("stored %d decks, want %d", len(entries), len(migrateTestDecks))
+	}
+	for _, entry := range entries {
+		if entry.EvaluationVersion == evaluation.CurrentScoringVersion && entry.OverallScore == 1 {
+			t.Errorf("deck %v was marked migrated without a new score", entry.Cards)
+		}
+	}
+
+	again, err := migrateFuzzStorage(storage, nil, "", nil, 1, false)
+	if err != nil || again.Pending != 0 {
+		t.Errorf("second migration = %+v, %v; want nothing pending", again, err)
+	}
+}
+
+func TestMigratePlayerLeaderboard(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if _, found, err := migratePlayerLeaderboard("#2PQ0R", nil, nil, false); err != nil || found {
+		t.Fatalf("missing leaderboard: found=%v err=%v", found, err)
+	}
+
+	storage, err := leaderboard.NewStorage("#2PQ0R")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, version := range []string{"1.0.0", evaluation.CurrentScoringVersion} {
+		entry := &leaderboard.DeckEntry{
+			Cards:             migrateTestDecks[i],
+			OverallScore:      1,
+			EvaluatedAt:       time.Now(),
+			PlayerTag:         "#2PQ0R",
+			EvaluationVersion: version,
+		}
+		if _, _, err := storage.InsertDeck(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	closeFile(storage)
+
+	summary, found, err := migratePlayerLeaderboard("2PQ0R", nil, nil, false)
+	if err != nil || !found {
+		t.Fatalf("migrate leaderboard: found=%v err=%v", found, err)
+	}
+	if summary.Migrated != 1 || summary.ByVersion["1.0.0"] != 1 {
+		t.Errorf("summary = %+v, want one 1.0.0 deck migrated", summary)
+	}
+
+	storage, err = leaderboard.NewStorage("#2PQ0R")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeFile(storage)
+	stale, err := storage.Query(leaderboard.QueryOptions{ExcludeEvaluationVersion: evaluation.CurrentScoringVersion})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stale) != 0 {
+		t.Errorf("decks still on an old version: %+v", stale)
+	}
+}
+
+func TestFormatVersionBreakdown(t *testing.T) {
+	got := formatVersionBreakdown(map[string]int{"1.0.0": 3, "": 2})
+	if want := " (unversioned: 2, 1.0.0: 3)"; got != want {
+		t.Errorf("formatVersionBreakdown() = %q, want %q", got, want)
+	}
+	if got := formatVersionBreakdown(nil); got != "" {
+		t.Errorf("empty breakdown = %q", got)
+	}
+}
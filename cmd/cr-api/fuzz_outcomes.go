@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/fuzzstorage"
+	"github.com/urfave/cli/v3"
+)
+
+// trackDecksFlag enables linking a player's battles to decks saved by
+// deck fuzz
+func trackDecksFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:  "track-decks",
+		Value: true,
+		Usage: "Record wins and losses of decks saved by deck fuzz (shown in deck fuzz list)",
+	}
+}
+
+// storedDeckOutcomes turns a player's 1v1 battles into outcomes for the
+// decks they played. Battles are keyed like the history database, prefixed
+// with the player's tag.
+func storedDeckOutcomes(tag string, battles []clashroyale.Battle) []fuzzstorage.BattleOutcome {
+	tag = strings.ToUpper(clashroyale.NormalizeTag(tag))
+	outcomes := make([]fuzzstorage.BattleOutcome, 0, len(battles))
+	for _, battle := range battles {
+		if len(battle.Team) != 1 || len(battle.Opponent) != 1 || len(battle.Team[0].Cards) != 8 {
+			continue
+		}
+		cards := make([]string, len(battle.Team[0].Cards))
+		for i, card := range battle.Team[0].Cards {
+			cards[i] = card.Name
+		}
+		outcomes = append(outcomes, fuzzstorage.BattleOutcome{
+			BattleKey:  tag + "|" + watchBattleKey(battle),
+			PlayerTag:  tag,
+			Cards:      cards,
+			Result:     strings.ToLower(battleResult(battle)),
+			BattleTime: battle.UTCDate,
+		})
+	}
+	return outcomes
+}
+
+// recordStoredDeckOutcomes records the battles played with saved decks in
+// fuzz storage and returns how many were new.
+func recordStoredDeckOutcomes(tag string, battles []clashroyale.Battle) (int, error) {
+	outcomes := storedDeckOutcomes(tag, battles)
+	if len(outcomes) == 0 {
+		return 0, nil
+	}
+	storage, err := fuzzstorage.NewStorage("")
+	if err != nil {
+		return 0, fmt.Errorf("failed to open storage: %w", err)
+	}
+	defer closeFile(storage)
+	return storage.RecordOutcomes(outcomes)
+}
+
+// formatDeckRecord renders a saved deck's real-battle record as
+// "12-8-1 (57%)", or "-" when none were recorded.
+func formatDeckRecord(record fuzzstorage.DeckRecord) string {
+	if record.Games() == 0 {
+		return "-"
+	}
+	score := fmt.Sprintf("%d-%d", record.Wins, record.Losses)
+	if record.Draws > 0 {
+		score += fmt.Sprintf("-%d", record.Draws)
+	}
+	return fmt.Sprintf("%s (%.0f%%)", score, record.WinRate()*100)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+func deckBattle(at time.Time, cards []string, crowns, oppCrowns int) clashroyale.Battle {
+	battle := watchBattle(at, "#OPP"+at.Format("150405"), crowns, oppCrowns, 0)
+	battle.Team[0].Cards = nil
+	for _, name := range cards {
+		battle.Team[0].Cards = append(battle.Team[0].Cards, clashroyale.Card{Name: name})
+	}
+	return battle
+}
+
+func TestRecordStoredDeckOutcomes(t *testing.T) {
+	seedCurationDecks(t)
+	hog := []string{"Fireball", "The Log", "Hog Rider", "Musketeer", "Cannon", "Ice Golem", "Ice Spirit", "Skeletons"}
+	unsaved := []string{"X-Bow", "Tesla", "Archers", "Knight", "Fireball", "The Log", "Skeletons", "Ice Spirit"}
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	battles := []clashroyale.Battle{
+		deckBattle(base, hog, 3, 0),
+		deckBattle(base.Add(5*time.Minute), hog, 1, 2),
+		deckBattle(base.Add(10*time.Minute), hog, 1, 0),
+		deckBattle(base.Add(15*time.Minute), unsaved, 1, 0),
+	}
+
+	recorded, err := recordStoredDeckOutcomes("abc", battles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recorded != 3 {
+		t.Fatalf("recorded %d results, want 3", recorded)
+	}
+	if recorded, err := recordStoredDeckOutcomes("#ABC", battles); err != nil || recorded != 0 {
+		t.Errorf("re-importing the same log recorded %d, %v; want 0", recorded, err)
+	}
+
+	output, err := runCurationCommand(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(output, "Record") || !strings.Contains(output, "2-1 (67%)") {
+		t.Errorf("list output missing the record:\n%s", output)
+	}
+	output, err = runCurationCommand(t, "--format", "detailed")
+	if err != nil || !strings.Contains(output, "Record: 2W 1L 0D (67% win rate) | Theoretical score: 8.00") {
+		t.Errorf("detailed output = %v\n%s", err, output)
+	}
+	output, err = runCurationCommand(t, "--format", "json")
+	if err != nil || !strings.Contains(output, `"wins": 2`) || strings.Count(output, `"record"`) != 1 {
+		t.Errorf("json output = %v\n%s", err, output)
+	}
+}
+
+func TestStoredDeckOutcomesSkipsIncompleteBattles(t *testing.T) {
+	at := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	twoVTwo := deckBattle(at, make([]string, 8), 1, 0)
+	twoVTwo.Team = append(twoVTwo.Team, twoVTwo.Team[0])
+	battles := []clashroyale.Battle{
+		watchBattle(at, "#ONE", 1, 0, 0), // one card in the team deck
+		twoVTwo,
+		deckBattle(at, []string{"A", "B", "C", "D", "E", "F", "G", "H"}, 0, 0),
+	}
+
+	outcomes := storedDeckOutcomes("#abc", battles)
+	if len(outcomes) != 1 {
+		t.Fatalf("got %d outcomes, want 1", len(outcomes))
+	}
+	if got := outcomes[0]; got.Result != "draw" || got.PlayerTag != "#ABC" || !strings.HasPrefix(got.BattleKey, "#ABC|") {
+		t.Errorf("outcome = %+v", got)
+	}
+}
+
+func TestBattleWatcherTracksSavedDecks(t *testing.T) {
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	var out bytes.Buffer
+	var tracked int
+	w := &battleWatcher{
+		tag: "#ABC",
+		out: &out,
+		trackDecks: func(battles []clashroyale.Battle) (int, error) {
+			tracked += len(battles)
+			return len(battles), nil
+		},
+	}
+	w.report(context.Background(), []clashroyale.Battle{watchBattle(base, "#ONE", 1, 0, 30)}, true)
+	if tracked != 1 || !strings.Contains(out.String(), "Recorded 1 result(s) for saved decks") {
+		t.Errorf("tracked %d, output %q", tracked, out.String())
+	}
+}
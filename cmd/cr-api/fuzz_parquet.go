@@ -0,0 +1,163 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/internal/exporter/parquet"
+	"github.com/klauer/clash-royale-api/go/pkg/fuzzstorage"
+)
+
+const fuzzOutputParquet = "parquet"
+
+// writeParquetToStdout runs write against stdout, refusing when stdout is a
+// terminal since Parquet is binary.
+func writeParquetToStdout(write func(io.Writer) error) error {
+	if info, err := os.Stdout.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		return errors.New("parquet output is binary: redirect stdout to a file or use --output-dir")
+	}
+	return write(os.Stdout)
+}
+
+var fuzzResultParquetColumns = []parquet.Column{
+	{Name: "rank", Type: parquet.Int32},
+	{Name: "name", Type: parquet.String},
+	{Name: "deck", Type: parquet.String},
+	{Name: "overall_score", Type: parquet.Double},
+	{Name: "contextual_score", Type: parquet.Double},
+	{Name: "ladder_score", Type: parquet.Double},
+	{Name: "normalized_score", Type: parquet.Double},
+	{Name: "deck_level_ratio", Type: parquet.Double},
+	{Name: "normalization_factor", Type: parquet.Double},
+	{Name: "attack_score", Type: parquet.Double},
+	{Name: "defense_score", Type: parquet.Double},
+	{Name: "synergy_score", Type: parquet.Double},
+	{Name: "versatility_score", Type: parquet.Double},
+	{Name: "avg_elixir", Type: parquet.Double},
+	{Name: "archetype", Type: parquet.String},
+	{Name: "archetype_confidence", Type: parquet.Double},
+	{Name: "hybrid_primary", Type: parquet.String},
+	{Name: "hybrid_secondary", Type: parquet.String},
+	{Name: "evaluated_at", Type: parquet.Timestamp},
+	{Name: "deck_link", Type: parquet.String},
+	{Name: "variants", Type: parquet.Int32},
+}
+
+// formatResultsParquetImpl writes fuzz results as a Parquet table, one row
+// per deck with the same fields as the JSON output.
+func formatResultsParquetImpl(w io.Writer, results []FuzzingResult) error {
+	pw, err := parquet.NewWriter(w, fuzzResultParquetColumns)
+	if err != nil {
+		return err
+	}
+	for i, result := range results {
+		if err := pw.WriteRow(
+			i+1,
+			result.Name,
+			strings.Join(result.Deck, ", "),
+			result.OverallScore,
+			result.ContextualScore,
+			result.LadderScore,
+			result.NormalizedScore,
+			result.DeckLevelRatio,
+			result.NormalizationFactor,
+			result.AttackScore,
+			result.DefenseScore,
+			result.SynergyScore,
+			result.VersatilityScore,
+			result.AvgElixir,
+			result.Archetype,
+			result.ArchetypeConfidence,
+			result.HybridPrimary,
+			result.HybridSecondary,
+			result.EvaluatedAt,
+			result.DeckLink,
+			result.Variants,
+		); err != nil {
+			return err
+		}
+	}
+	return pw.Close()
+}
+
+// formatListResultsParquet writes stored decks as a Parquet table. When
+// decks were re-evaluated for a player, stored_* columns hold the original
+// scores next to the player-specific ones, null when unknown.
+func formatListResultsParquet(w io.Writer, decks []fuzzstorage.DeckEntry, theoreticalByID map[int]fuzzstorage.DeckEntry) error {
+	columns := []parquet.Column{
+		{Name: "rank", Type: parquet.Int32},
+		{Name: "id", Type: parquet.Int64},
+		{Name: "name", Type: parquet.String},
+		{Name: "deck", Type: parquet.String},
+		{Name: "overall_score", Type: parquet.Double},
+		{Name: "attack_score", Type: parquet.Double},
+		{Name: "defense_score", Type: parquet.Double},
+		{Name: "synergy_score", Type: parquet.Double},
+		{Name: "versatility_score", Type: parquet.Double},
+		{Name: "avg_elixir", Type: parquet.Double},
+		{Name: "archetype", Type: parquet.String},
+		{Name: "archetype_confidence", Type: parquet.Double},
+		{Name: "hybrid_primary", Type: parquet.String},
+		{Name: "hybrid_secondary", Type: parquet.String},
+		{Name: "evaluated_at", Type: parquet.Timestamp},
+		{Name: "run_id", Type: parquet.String},
+		{Name: "tags", Type: parquet.String},
+		{Name: "favorite", Type: parquet.Boolean},
+		{Name: "notes", Type: parquet.String},
+		{Name: "wins", Type: parquet.Int32},
+		{Name: "losses", Type: parquet.Int32},
+		{Name: "draws", Type: parquet.Int32},
+	}
+	if theoreticalByID != nil {
+		columns = append(columns,
+			parquet.Column{Name: "stored_overall_score", Type: parquet.Double, Optional: true},
+			parquet.Column{Name: "stored_attack_score", Type: parquet.Double, Optional: true},
+			parquet.Column{Name: "stored_defense_score", Type: parquet.Double, Optional: true},
+			parquet.Column{Name: "stored_synergy_score", Type: parquet.Double, Optional: true},
+		)
+	}
+
+	pw, err := parquet.NewWriter(w, columns)
+	if err != nil {
+		return err
+	}
+	for i, deck := range decks {
+		row := []any{
+			i + 1,
+			deck.ID,
+			storedDeckName(deck),
+			strings.Join(deck.Cards, ", "),
+			deck.OverallScore,
+			deck.AttackScore,
+			deck.DefenseScore,
+			deck.SynergyScore,
+			deck.VersatilityScore,
+			deck.AvgElixir,
+			deck.Archetype,
+			deck.ArchetypeConf,
+			deck.HybridPrimary,
+			deck.HybridSecondary,
+			deck.EvaluatedAt,
+			deck.RunID,
+			strings.Join(deck.Tags, ";"),
+			deck.Favorite,
+			deck.Notes,
+			deck.Record.Wins,
+			deck.Record.Losses,
+			deck.Record.Draws,
+		}
+		if theoreticalByID != nil {
+			if stored, ok := theoreticalByID[deck.ID]; ok {
+				row = append(row, stored.OverallScore, stored.AttackScore, stored.DefenseScore, stored.SynergyScore)
+			} else {
+				row = append(row, nil, nil, nil, nil)
+			}
+		}
+		if err := pw.WriteRow(row...); err != nil {
+			return err
+		}
+	}
+	return pw.Close()
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/fuzzstorage"
+	"github.com/urfave/cli/v3"
+)
+
+// addDeckFuzzPruneCommand adds the subcommand that prunes fuzz storage
+func addDeckFuzzPruneCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "prune",
+		Usage: "Delete saved decks by rank, score, age, or near-duplicates",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "keep-per-archetype",
+				Usage: "Delete decks outside the best N of their archetype (0 = off)",
+			},
+			&cli.Float64Flag{
+				Name:  "min-score",
+				Usage: "Delete decks with an overall score below this",
+			},
+			&cli.StringFlag{
+				Name:  "older-than",
+				Usage: "Delete decks evaluated before a date (2006-01-02) or an age ago (30d, 12h)",
+			},
+			&cli.BoolFlag{
+				Name:  "dedupe",
+				Usage: "Delete decks sharing 7 of 8 cards with a better saved deck",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "List the decks that would be deleted without deleting them",
+			},
+			&cli.BoolFlag{Name: "confirm", Aliases: []string{"y"}, Usage: "Skip confirmation prompt"},
+		},
+		Action: deckFuzzPruneCommand,
+	}
+}
+
+// addDeckFuzzDeleteCommand adds the subcommand that deletes saved decks
+func addDeckFuzzDeleteCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "delete",
+		Usage:     "Delete saved decks",
+		ArgsUsage: "<deck id or name>...",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "confirm", Aliases: []string{"y"}, Usage: "Skip confirmation prompt"},
+		},
+		Action: deckFuzzDeleteCommand,
+	}
+}
+
+func deckFuzzPruneCommand(ctx context.Context, cmd *cli.Command) error {
+	policy, err := buildPrunePolicy(cmd, time.Now())
+	if err != nil {
+		return err
+	}
+
+	storage, err := fuzzstorage.NewStorage("")
+	if err != nil {
+		return fmt.Errorf("failed to open storage: %w", err)
+	}
+	defer closeFile(storage)
+
+	candidates, err := storage.PruneCandidates(policy)
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		printf("No decks matched the prune rules\n")
+		return nil
+	}
+	if cmd.Bool("dry-run") {
+		printPruneCandidates(candidates)
+		printf("Would delete %d deck(s)\n", len(candidates))
+		return nil
+	}
+
+	if !cmd.Bool("confirm") {
+		confirmed, err := confirmStorageAction(fmt.Sprintf("Delete %d saved deck(s)? (y/N): ", len(candidates)))
+		if err != nil {
+			return fmt.Errorf("failed to read confirmation: %w", err)
+		}
+		if !confirmed {
+			printf("Operation canceled\n")
+			return nil
+		}
+	}
+
+	ids := make([]int, len(candidates))
+	for i, candidate := range candidates {
+		ids[i] = candidate.Deck.ID
+	}
+	deleted, err := storage.DeleteDecks(ids)
+	if err != nil {
+		return err
+	}
+	printf("Deleted %d deck(s)%s\n", deleted, pruneReasonSummary(candidates))
+	return nil
+}
+
+func deckFuzzDeleteCommand(ctx context.Context, cmd *cli.Command) error {
+	refs := cmd.Args().Slice()
+	if len(refs) == 0 {
+		return errors.New("usage: cr-api deck fuzz delete <deck id or name>...")
+	}
+	storage, _, err := openCurationStorage("")
+	if err != nil {
+		return err
+	}
+	defer closeFile(storage)
+
+	entries := make([]fuzzstorage.DeckEntry, 0, len(refs))
+	ids := make([]int, 0, len(refs))
+	for _, ref := range refs {
+		entry, err := storage.FindDeck(ref)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, *entry)
+		ids = append(ids, entry.ID)
+	}
+
+	if !cmd.Bool("confirm") {
+		names := make([]string, len(entries))
+		for i, entry := range entries {
+			names[i] = describeStoredDeck(entry)
+		}
+		confirmed, err := confirmStorageAction(fmt.Sprintf("Delete %s? (y/N): ", strings.Join(names, ", ")))
+		if err != nil {
+			return fmt.Errorf("failed to read confirmation: %w", err)
+		}
+		if !confirmed {
+			printf("Operation canceled\n")
+			return nil
+		}
+	}
+
+	deleted, err := storage.DeleteDecks(ids)
+	if err != nil {
+		return err
+	}
+	printf("Deleted %d deck(s)\n", deleted)
+	return nil
+}
+
+// buildPrunePolicy reads the prune flags; at least one rule is required
+func buildPrunePolicy(cmd *cli.Command, now time.Time) (fuzzstorage.PrunePolicy, error) {
+	policy := fuzzstorage.PrunePolicy{
+		KeepPerArchetype: cmd.Int("keep-per-archetype"),
+		MinScore:         cmd.Float64("min-score"),
+		Dedupe:           cmd.Bool("dedupe"),
+	}
+	if policy.KeepPerArchetype < 0 {
+		return policy, fmt.Errorf("--keep-per-archetype must be >= 0")
+	}
+	if policy.MinScore < 0 || policy.MinScore > 10 {
+		return policy, fmt.Errorf("--min-score must be between 0 and 10")
+	}
+	if value := strings.TrimSpace(cmd.String("older-than")); value != "" {
+		cutoff, err := parsePruneCutoff(value, now)
+		if err != nil {
+			return policy, err
+		}
+		policy.OlderThan = cutoff
+	}
+	if !policy.Enabled() {
+		return policy, fmt.Errorf("at least one rule required (--keep-per-archetype, --min-score, --older-than, or --dedupe)")
+	}
+	return policy, nil
+}
+
+// parsePruneCutoff reads --older-than as a date, an RFC 3339 time, a number
+// of days ("30d"), or a Go duration ("12h") before now
+func parsePruneCutoff(value string, now time.Time) (time.Time, error) {
+	return parseTimeFlag("older-than", value, now)
+}
+
+// parseTimeFlag reads a time flag as a date, an RFC 3339 time, a number of
+// days ("30d"), or a Go duration ("12h") before now
+func parseTimeFlag(flag, value string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if age, err := time.ParseDuration(value); err == nil && age >= 0 {
+		return now.Add(-age), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --%s %q: use a date (2006-01-02) or an age (30d, 12h)", flag, value)
+}
+
+func printPruneCandidates(candidates []fuzzstorage.PruneCandidate) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fprintf(w, "ID\tName\tArchetype\tScore\tEvaluated\tReason\n")
+	for _, candidate := range candidates {
+		deck := candidate.Deck
+		reason := candidate.Reason
+		if reason == fuzzstorage.PruneReasonDuplicate {
+			reason = fmt.Sprintf("%s of %d", reason, candidate.DuplicateOf)
+		}
+		fprintf(w, "%d\t%s\t%s\t%.2f\t%s\t%s\n",
+			deck.ID, storedDeckName(deck), storedArchetypeLabel(deck), deck.OverallScore,
+			deck.EvaluatedAt.Local().Format("2006-01-02"), reason)
+	}
+	flushWriter(w)
+}
+
+// pruneReasonSummary counts candidates per reason, e.g. " (duplicate: 3, old: 12)"
+func pruneReasonSummary(candidates []fuzzstorage.PruneCandidate) string {
+	reasons := []string{
+		fuzzstorage.PruneReasonOld,
+		fuzzstorage.PruneReasonLowScore,
+		fuzzstorage.PruneReasonArchetypeRank,
+		fuzzstorage.PruneReasonDuplicate,
+	}
+	counts := make(map[string]int)
+	for _, candidate := range candidates {
+		counts[candidate.Reason]++
+	}
+	var parts []string
+	for _, reason := range reasons {
+		if counts[reason] > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %d", reason, counts[reason]))
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/fuzzstorage"
+)
+
+func runPruneCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	root := addDeckFuzzListCommand()
+	root.Name = "fuzz"
+	root.Commands = append(root.Commands, addDeckFuzzPruneCommand(), addDeckFuzzDeleteCommand(), addDeckFuzzFavoriteCommand())
+	return captureStdout(t, func() error {
+		return root.Run(context.Background(), append([]string{"fuzz"}, args...))
+	})
+}
+
+func TestParsePruneCutoff(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := map[string]time.Time{
+		"30d":                  now.AddDate(0, 0, -30),
+		"12h":                  now.Add(-12 * time.Hour),
+		"2026-01-01T00:00:00Z": time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		"2026-01-01":           time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local),
+	}
+	for value, want := range tests {
+		if got, err := parsePruneCutoff(value, now); err != nil || !got.Equal(want) {
+			t.Errorf("parsePruneCutoff(%q) = %v, %v, want %v", value, got, err, want)
+		}
+	}
+	for _, value := range []string{"soon", "-3d", "01/02/2026"} {
+		if _, err := parsePruneCutoff(value, now); err == nil {
+			t.Errorf("parsePruneCutoff(%q) should fail", value)
+		}
+	}
+}
+
+func TestDeckFuzzPruneCommand(t *testing.T) {
+	seedCurationDecks(t)
+
+	if _, err := runPruneCommand(t, "prune", "--dry-run"); err == nil || !strings.Contains(err.Error(), "at least one rule") {
+		t.Errorf("prune without rules error = %v", err)
+	}
+
+	output, err := runPruneCommand(t, "prune", "--min-score", "7.5", "--dry-run")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(output, "low_score") || !strings.Contains(output, "Would delete 1 deck(s)") {
+		t.Errorf("dry-run output =\n%s", output)
+	}
+
+	output, err = runPruneCommand(t, "prune", "--min-score", "7.5", "-y")
+	if err != nil || !strings.Contains(output, "Deleted 1 deck(s) (low_score: 1)") {
+		t.Errorf("prune output = %q, %v", output, err)
+	}
+	if output, err = runPruneCommand(t, "delete", "-y", "1"); err != nil || output != "Deleted 1 deck(s)\n" {
+		t.Errorf("delete output = %q, %v", output, err)
+	}
+
+	storage, err := fuzzstorage.NewStorage("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer storage.Close()
+	if count, _ := storage.Count(); count != 0 {
+		t.Errorf("Count() = %d, want 0", count)
+	}
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/klauer/clash-royale-api/go/pkg/deckhash"
+	"github.com/klauer/clash-royale-api/go/pkg/meta"
+	"github.com/urfave/cli/v3"
+)
+
+// deckLinkPattern matches copy-deck links and bare "ID;ID;..." lists
+var deckLinkPattern = regexp.MustCompile(`(?i)deck=|^\d+(;\d+){7}$`)
+
+// gaSeedSubstitutions is how many substitutes are considered per unowned card
+const gaSeedSubstitutions = 3
+
+// seedFileDeck is a deck read from a seed file and where it came from
+type seedFileDeck struct {
+	Cards []string
+	Line  int
+}
+
+// readGASeedFile reads decks from a --ga-seed-file. A .json file is read as a
+// meta snapshot (see `cr-api meta`) and seeds its top decks. Any other file
+// holds one deck per line, either a copy-deck link or 8 card names separated
+// by dashes or commas; blank lines and lines starting with # are skipped.
+// decodeLink is only called when the file contains links.
+func readGASeedFile(path string, decodeLink func(string) ([]string, error)) ([]seedFileDeck, error) {
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		snapshot, err := meta.ReadSnapshotFile(path)
+		if err != nil {
+			return nil, err
+		}
+		decks := make([]seedFileDeck, 0, len(snapshot.TopDecks))
+		for i, d := range snapshot.TopDecks {
+			if len(d.Cards) != deckCardCount {
+				return nil, fmt.Errorf("%s: top deck %d has %d cards, want %d", path, i+1, len(d.Cards), deckCardCount)
+			}
+			decks = append(decks, seedFileDeck{Cards: slices.Clone(d.Cards), Line: i + 1})
+		}
+		return decks, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seed file: %w", err)
+	}
+	var decks []seedFileDeck
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var cards []string
+		if deckLinkPattern.MatchString(line) {
+			cards, err = decodeLink(line)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", path, lineNum, err)
+			}
+		} else {
+			cards = parseDeckString(strings.ReplaceAll(line, ",", "-"))
+			if len(cards) != deckCardCount {
+				return nil, fmt.Errorf("%s:%d: deck must contain exactly %d cards, got %d", path, lineNum, deckCardCount, len(cards))
+			}
+		}
+		decks = append(decks, seedFileDeck{Cards: cards, Line: lineNum})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read seed file: %w", err)
+	}
+	return decks, nil
+}
+
+// loadGASeedDecks reads --ga-seed-file and fits its decks to the GA card pool.
+// Copy-deck links are decoded with the cached card database, fetching it when
+// it is missing.
+func loadGASeedDecks(
+	ctx context.Context,
+	cmd *cli.Command,
+	path string,
+	player *clashroyale.Player,
+	pool []*deck.CardCandidate,
+	verbose bool,
+) ([][]string, error) {
+	var index *deck.CardIDIndex
+	decodeLink := func(link string) ([]string, error) {
+		if index == nil {
+			cards, err := loadStaticCards(ctx, cmd.String("data-dir"), cmd.String("api-token"), verbose)
+			if err != nil {
+				return nil, err
+			}
+			index = deck.NewCardIDIndex(cards)
+		}
+		return deck.DecodeDeckLink(index, link)
+	}
+	seeds, err := readGASeedFile(path, decodeLink)
+	if err != nil {
+		return nil, err
+	}
+
+	adapted := adaptSeedDecks(seeds, player, pool)
+	if verbose {
+		fprintf(os.Stderr, "Seed file: %d decks read, %d usable, %d cards substituted\n",
+			len(seeds), len(adapted.Decks), len(adapted.Substitutions))
+		for _, sub := range adapted.Substitutions {
+			fprintf(os.Stderr, "  %s -> %s (%s)\n", sub.Card, sub.Substitute, sub.Reason)
+		}
+	}
+	if len(adapted.Dropped) > 0 {
+		entries := make([]string, len(adapted.Dropped))
+		for i, entry := range adapted.Dropped {
+			entries[i] = strconv.Itoa(entry)
+		}
+		fprintf(os.Stderr, "Warning: skipped %d seed decks with cards that have no substitute in your collection (entries %s)\n",
+			len(adapted.Dropped), strings.Join(entries, ", "))
+	}
+	return adapted.Decks, nil
+}
+
+// seedAdaptation records how seed decks were fitted to the card pool
+type seedAdaptation struct {
+	Decks         [][]string
+	Substitutions []evaluation.Substitution
+	// Dropped lists seed file lines whose unowned cards had no substitute
+	Dropped []int
+}
+
+// adaptSeedDecks replaces cards outside the GA card pool with the closest
+// pooled cards by role, elixir, and synergy, so every seed deck can enter the
+// initial population. Decks that cannot be completed are dropped.
+func adaptSeedDecks(seeds []seedFileDeck, player *clashroyale.Player, pool []*deck.CardCandidate) seedAdaptation {
+	pooled := make(map[string]bool, len(pool))
+	byLower := make(map[string]string, len(pool))
+	for _, c := range pool {
+		pooled[c.Name] = true
+		byLower[strings.ToLower(c.Name)] = c.Name
+	}
+	// Substitutes must come from the pool, so excluded cards count as unowned
+	poolPlayer := *player
+	poolPlayer.Cards = nil
+	for _, card := range player.Cards {
+		if pooled[card.Name] {
+			poolPlayer.Cards = append(poolPlayer.Cards, card)
+		}
+	}
+	playerContext := evaluation.NewPlayerContextFromPlayer(&poolPlayer)
+	synergyDB := deck.NewSynergyDatabase()
+	opts := evaluation.SubstitutionOptions{MaxPerCard: gaSeedSubstitutions}
+
+	var result seedAdaptation
+	seen := make(map[string]bool)
+	for _, seed := range seeds {
+		cards := make([]string, len(seed.Cards))
+		for i, name := range seed.Cards {
+			// Hand-written card lists may not match the API's capitalization
+			if canonical, ok := byLower[strings.ToLower(name)]; ok {
+				name = canonical
+			}
+			cards[i] = name
+		}
+		var swaps []evaluation.Substitution
+		for _, sub := range evaluation.SuggestSubstitutions(convertDeckToCandidates(cards, &poolPlayer), synergyDB, playerContext, opts) {
+			i := slices.Index(cards, sub.Card)
+			if i < 0 || slices.Contains(cards, sub.Substitute) {
+				continue
+			}
+			cards[i] = sub.Substitute
+			swaps = append(swaps, sub)
+		}
+		if slices.ContainsFunc(cards, func(name string) bool { return !pooled[name] }) {
+			result.Dropped = append(result.Dropped, seed.Line)
+			continue
+		}
+		key := deckhash.CanonicalDeckKey(cards)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result.Decks = append(result.Decks, cards)
+		result.Substitutions = append(result.Substitutions, swaps...)
+	}
+	return result
+}
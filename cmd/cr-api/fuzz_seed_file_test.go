@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/internal/storage"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/meta"
+)
+
+func TestReadGASeedFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "seeds.txt")
+	content := "# exported meta decks\n" +
+		"Hog Rider-Musketeer-Fireball-The Log-Ice Spirit-Skeletons-Cannon-Ice Golem\n" +
+		"\n" +
+		"https://link.clashroyale.com/deck/en?deck=1;2;3;4;5;6;7;8\n" +
+		"Golem, Night Witch, Baby Dragon, Lumberjack, Tornado, Lightning, Barbarian Barrel, Mega Minion\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded []string
+	decks, err := readGASeedFile(path, func(link string) ([]string, error) {
+		decoded = append(decoded, link)
+		return []string{"A", "B", "C", "D", "E", "F", "G", "H"}, nil
+	})
+	if err != nil {
+		t.Fatalf("readGASeedFile() error = %v", err)
+	}
+	if len(decks) != 3 {
+		t.Fatalf("read %d decks, want 3", len(decks))
+	}
+	if len(decoded) != 1 {
+		t.Fatalf("decoded %d links, want 1", len(decoded))
+	}
+	if decks[0].Line != 2 || decks[1].Line != 4 || decks[2].Line != 5 {
+		t.Errorf("lines = %d, %d, %d; want 2, 4, 5", decks[0].Line, decks[1].Line, decks[2].Line)
+	}
+	if decks[2].Cards[1] != "Night Witch" {
+		t.Errorf("comma-separated deck parsed as %v", decks[2].Cards)
+	}
+
+	bad := filepath.Join(dir, "bad.txt")
+	if err := os.WriteFile(bad, []byte("Hog Rider-Musketeer\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readGASeedFile(bad, nil); err == nil {
+		t.Error("expected an error for a short deck")
+	}
+}
+
+func TestReadGASeedFileMetaSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "2026-W42.json")
+	snapshot := meta.Snapshot{Week: "2026-W42", TopDecks: []meta.DeckUsageStat{
+		{Cards: []string{"A", "B", "C", "D", "E", "F", "G", "H"}, Uses: 10},
+	}}
+	if err := storage.WriteJSON(path, snapshot); err != nil {
+		t.Fatal(err)
+	}
+	decks, err := readGASeedFile(path, nil)
+	if err != nil {
+		t.Fatalf("readGASeedFile() error = %v", err)
+	}
+	if len(decks) != 1 || len(decks[0].Cards) != 8 {
+		t.Fatalf("readGASeedFile() = %+v, want one 8-card deck", decks)
+	}
+}
+
+func TestAdaptSeedDecks(t *testing.T) {
+	owned := []string{"Hog Rider", "Musketeer", "Fireball", "The Log", "Ice Spirit", "Skeletons", "Cannon", "Ice Golem", "Inferno Tower", "Valkyrie"}
+	player := &clashroyale.Player{Tag: "#TEST", Arena: clashroyale.Arena{ID: 54000015}}
+	for _, name := range owned {
+		player.Cards = append(player.Cards, clashroyale.Card{Name: name, Level: 11, MaxLevel: 14, Rarity: "Common"})
+	}
+	pool, err := buildGeneticCandidates(player, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seeds := []seedFileDeck{
+		// Tesla is not owned and should be replaced by an owned building
+		{Line: 1, Cards: []string{"hog rider", "Musketeer", "Fireball", "The Log", "Ice Spirit", "Skeletons", "Cannon", "Tesla"}},
+		// The same deck again is only seeded once
+		{Line: 2, Cards: []string{"Hog Rider", "Musketeer", "Fireball", "The Log", "Ice Spirit", "Skeletons", "Cannon", "Tesla"}},
+	}
+	adapted := adaptSeedDecks(seeds, player, pool)
+	if len(adapted.Decks) != 1 {
+		t.Fatalf("adapted %d decks, want 1: %+v", len(adapted.Decks), adapted)
+	}
+	got := adapted.Decks[0]
+	if slices.Contains(got, "Tesla") || !slices.Contains(got, "Hog Rider") {
+		t.Errorf("adapted deck = %v, want Tesla substituted and names canonicalized", got)
+	}
+	for _, name := range got {
+		if !slices.Contains(owned, name) {
+			t.Errorf("adapted deck holds unowned card %q", name)
+		}
+	}
+	if len(adapted.Substitutions) != 1 || adapted.Substitutions[0].Card != "Tesla" {
+		t.Errorf("substitutions = %+v, want one for Tesla", adapted.Substitutions)
+	}
+
+	// With every owned card already in the deck there is nothing to swap in
+	full := slices.Clone(owned[:8])
+	full[7] = "Mega Knight"
+	small := &clashroyale.Player{Tag: "#TEST", Arena: player.Arena, Cards: player.Cards[:8]}
+	smallPool, err := buildGeneticCandidates(small, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adapted = adaptSeedDecks([]seedFileDeck{{Line: 7, Cards: full}}, small, smallPool)
+	if len(adapted.Decks) != 0 || fmt.Sprint(adapted.Dropped) != "[7]" {
+		t.Errorf("adaptSeedDecks() = %+v, want line 7 dropped", adapted)
+	}
+}
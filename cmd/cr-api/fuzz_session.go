@@ -0,0 +1,281 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/storage"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deckhash"
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	fuzzSessionVersion = 1
+	fuzzSessionSuffix  = ".session.json"
+
+	fuzzSessionRunning     = "running"
+	fuzzSessionInterrupted = "interrupted"
+	fuzzSessionComplete    = "complete"
+
+	// fuzzSessionCheckpointInterval is how often a running session rewrites
+	// its manifest, bounding the work lost if the process is killed
+	fuzzSessionCheckpointInterval = 30 * time.Second
+)
+
+// fuzzSessionSecretFlags hold credentials and webhook URLs, which are left
+// out of the manifest and must be passed again (or set in the environment)
+// when resuming
+var fuzzSessionSecretFlags = map[string]bool{
+	"worker-token":   true,
+	"notify-discord": true,
+	"notify-webhook": true,
+}
+
+// fuzzSessionProgress counts the work a session has done across all its runs
+type fuzzSessionProgress struct {
+	// Target is the --count the session was started with
+	Target          int `json:"target"`
+	Generated       int `json:"generated"`
+	Evaluated       int `json:"evaluated"`
+	PassedScore     int `json:"passed_score"`
+	PassedArchetype int `json:"passed_archetype"`
+}
+
+// fuzzSession is the manifest of a resumable random-mode fuzz run. It is
+// written to <output-dir>/<name>.session.json when the run starts, every
+// fuzzSessionCheckpointInterval while it runs, and when it stops.
+type fuzzSession struct {
+	Version   int       `json:"version"`
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Args are the deck fuzz flags the session was started with
+	Args      []string `json:"args"`
+	PlayerTag string   `json:"player_tag,omitempty"`
+	Seed      int64    `json:"seed"`
+	Workers   int      `json:"workers"`
+	// RNG is the position of each generation worker's random stream
+	RNG      []deck.RNGPosition  `json:"rng"`
+	Progress fuzzSessionProgress `json:"progress"`
+	// Explored holds the hashes of every deck evaluated so far, which a
+	// resumed run does not evaluate again
+	Explored []string `json:"explored"`
+	// Results are the best decks kept so far
+	Results []FuzzingResult `json:"results"`
+
+	path     string
+	base     fuzzSessionProgress
+	explored *fuzzExplored
+	mu       sync.Mutex
+}
+
+// newFuzzSession starts a session for the current deck fuzz invocation.
+func newFuzzSession(cmd *cli.Command, name, outputDir, playerTag string, seed int64, workers, target int) (*fuzzSession, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return nil, fmt.Errorf("invalid --session name %q", name)
+	}
+	now := time.Now()
+	session := &fuzzSession{
+		Version:   fuzzSessionVersion,
+		Name:      name,
+		Status:    fuzzSessionRunning,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Args:      fuzzSessionArgs(cmd),
+		PlayerTag: playerTag,
+		Seed:      seed,
+		Workers:   workers,
+		Progress:  fuzzSessionProgress{Target: target},
+		path:      filepath.Join(outputDir, name+fuzzSessionSuffix),
+		explored:  newFuzzExplored(nil),
+	}
+	return session, session.save()
+}
+
+// fuzzSessionArgs rebuilds the flags set on cmd, except secrets, so a
+// resumed run is configured the same way.
+func fuzzSessionArgs(cmd *cli.Command) []string {
+	var args []string
+	for _, flag := range cmd.Flags {
+		name := flag.Names()[0]
+		if !cmd.IsSet(name) || fuzzSessionSecretFlags[name] {
+			continue
+		}
+		switch value := cmd.Value(name).(type) {
+		case []string:
+			for _, item := range value {
+				args = append(args, fmt.Sprintf("--%s=%s", name, item))
+			}
+		default:
+			args = append(args, fmt.Sprintf("--%s=%v", name, value))
+		}
+	}
+	return args
+}
+
+// loadFuzzSession reads the manifest at ref, or at ref + ".session.json".
+func loadFuzzSession(ref string) (*fuzzSession, error) {
+	path := ref
+	if !strings.HasSuffix(path, fuzzSessionSuffix) && !storage.FileExists(path) {
+		path += fuzzSessionSuffix
+	}
+	session := &fuzzSession{}
+	if err := storage.ReadJSON(path, session); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("no fuzz session at %s", ref)
+		}
+		return nil, err
+	}
+	if session.Version != fuzzSessionVersion {
+		return nil, fmt.Errorf("unsupported fuzz session version %d in %s", session.Version, path)
+	}
+	if len(session.RNG) == 0 {
+		return nil, fmt.Errorf("fuzz session %s has no recorded RNG state to resume from", path)
+	}
+	session.path = path
+	session.base = session.Progress
+	session.explored = newFuzzExplored(session.Explored)
+	return session, nil
+}
+
+// Remaining returns how many decks the session has left to generate.
+func (s *fuzzSession) Remaining() int {
+	return max(s.Progress.Target-s.Progress.Generated, 0)
+}
+
+// Resumed reports whether the session continues an earlier run.
+func (s *fuzzSession) Resumed() bool {
+	return len(s.RNG) > 0
+}
+
+// Checkpoint records the progress of the current run, whose fuzzer has
+// streamed decks and kept results, and rewrites the manifest.
+func (s *fuzzSession) Checkpoint(fuzzer *deck.DeckFuzzer, results []FuzzingResult, stats fuzzStreamStats, status string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if positions := fuzzer.RNGPositions(); positions != nil {
+		s.RNG = positions
+	}
+	s.Progress.Generated = s.base.Generated + fuzzer.Streamed()
+	s.Progress.Evaluated = s.base.Evaluated + stats.Evaluated
+	s.Progress.PassedScore = s.base.PassedScore + stats.PassedScore
+	s.Progress.PassedArchetype = s.base.PassedArchetype + stats.PassedArchetype
+	s.Explored = s.explored.Hashes()
+	s.Results = results
+	s.Status = status
+	s.UpdatedAt = time.Now()
+	return s.save()
+}
+
+// Totals adds the counts of earlier runs to stats of the current run.
+func (s *fuzzSession) Totals(stats fuzzStreamStats) fuzzStreamStats {
+	return fuzzStreamStats{
+		Evaluated:       s.base.Evaluated + stats.Evaluated,
+		PassedScore:     s.base.PassedScore + stats.PassedScore,
+		PassedArchetype: s.base.PassedArchetype + stats.PassedArchetype,
+	}
+}
+
+// save writes the manifest through a temporary file so an interrupted write
+// never leaves a truncated manifest behind.
+func (s *fuzzSession) save() error {
+	tmp := s.path + ".tmp"
+	if err := storage.WriteJSON(tmp, s); err != nil {
+		return fmt.Errorf("failed to write fuzz session: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to write fuzz session: %w", err)
+	}
+	return nil
+}
+
+// fuzzExplored is the set of deck hashes a session has evaluated. It is
+// safe for concurrent use.
+type fuzzExplored struct {
+	mu     sync.Mutex
+	hashes map[string]struct{}
+}
+
+func newFuzzExplored(hashes []string) *fuzzExplored {
+	explored := &fuzzExplored{hashes: make(map[string]struct{}, len(hashes))}
+	for _, hash := range hashes {
+		explored.hashes[hash] = struct{}{}
+	}
+	return explored
+}
+
+// Seen reports whether cards were already evaluated.
+func (e *fuzzExplored) Seen(cards []string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, ok := e.hashes[deckhash.DeckHash(cards)]
+	return ok
+}
+
+// Add records cards as evaluated.
+func (e *fuzzExplored) Add(cards []string) {
+	hash := deckhash.DeckHash(cards)
+	e.mu.Lock()
+	e.hashes[hash] = struct{}{}
+	e.mu.Unlock()
+}
+
+// Hashes returns the explored hashes in sorted order.
+func (e *fuzzExplored) Hashes() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	hashes := make([]string, 0, len(e.hashes))
+	for hash := range e.hashes {
+		hashes = append(hashes, hash)
+	}
+	slices.Sort(hashes)
+	return hashes
+}
+
+// addDeckFuzzResumeCommand adds the subcommand that continues a fuzz session
+func addDeckFuzzResumeCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "resume",
+		Usage:     "Continue an interrupted fuzz session from its manifest",
+		ArgsUsage: "<session manifest>",
+		Action:    deckFuzzResumeCommand,
+	}
+}
+
+func deckFuzzResumeCommand(ctx context.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() != 1 {
+		return errors.New("usage: cr-api deck fuzz resume <session manifest>")
+	}
+	session, err := loadFuzzSession(cmd.Args().First())
+	if err != nil {
+		return err
+	}
+	if session.Status == fuzzSessionComplete || session.Remaining() == 0 {
+		return fmt.Errorf("fuzz session %s is already complete (%d of %d decks generated)",
+			session.Name, session.Progress.Generated, session.Progress.Target)
+	}
+	fprintf(os.Stderr, "Resuming fuzz session %s: %d of %d decks generated, %d evaluated\n",
+		session.Name, session.Progress.Generated, session.Progress.Target, session.Progress.Evaluated)
+
+	// The recorded flags are parsed by a fresh fuzz command; the global
+	// flags it reads are carried over from this invocation.
+	fuzz := addDeckFuzzCommand()
+	fuzz.Commands = nil
+	fuzz.Flags = append(fuzz.Flags,
+		&cli.StringFlag{Name: "api-token", Value: cmd.String("api-token")},
+		&cli.StringFlag{Name: "data-dir", Value: cmd.String("data-dir")},
+	)
+	fuzz.Action = func(ctx context.Context, fuzzCmd *cli.Command) error {
+		return runDeckFuzz(ctx, fuzzCmd, session)
+	}
+	return fuzz.Run(ctx, append([]string{"fuzz"}, session.Args...))
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/internal/storage"
+	"github.com/urfave/cli/v3"
+)
+
+func TestDeckFuzzSessionResume(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	analysisPath := writeFuzzAnalysisFixture(t)
+	outputDir := filepath.Join(t.TempDir(), "out")
+
+	runFuzz := func(args ...string) error {
+		_, err := captureStdout(t, func() error {
+			return addDeckFuzzCommand().Run(context.Background(), append([]string{"fuzz"}, args...))
+		})
+		return err
+	}
+	runResume := func(ref string) error {
+		_, err := captureStdout(t, func() error {
+			return addDeckFuzzResumeCommand().Run(context.Background(), []string{"resume", ref})
+		})
+		return err
+	}
+
+	if err := runFuzz("--from-analysis", "--analysis-file", analysisPath, "--tag", "2PYQ98",
+		"--count", "40", "--workers", "2", "--eval-cache-size", "0", "--format", "json",
+		"--session", "nightly", "--output-dir", outputDir); err != nil {
+		t.Fatal(err)
+	}
+
+	manifestPath := filepath.Join(outputDir, "nightly"+fuzzSessionSuffix)
+	first, err := loadFuzzSession(manifestPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Status != fuzzSessionComplete || first.Progress.Generated != 40 || first.Workers != 2 || len(first.RNG) != 2 {
+		t.Fatalf("manifest = %+v, want a complete 2-worker session of 40 decks", first)
+	}
+	// Decks generated twice are evaluated twice but explored once
+	if first.Seed == 0 || len(first.Explored) == 0 || len(first.Explored) > first.Progress.Evaluated || len(first.Results) == 0 {
+		t.Errorf("manifest seed %d, %d explored, %d evaluated, %d results", first.Seed, len(first.Explored), first.Progress.Evaluated, len(first.Results))
+	}
+	if args := strings.Join(first.Args, " "); !strings.Contains(args, "--count=40") || !strings.Contains(args, "--session=nightly") {
+		t.Errorf("recorded args = %q, want --count=40 and --session=nightly", args)
+	}
+	if err := runResume(manifestPath); err == nil || !strings.Contains(err.Error(), "already complete") {
+		t.Errorf("resuming a complete session: err = %v", err)
+	}
+
+	// Pretend the run was interrupted after 40 of 100 decks
+	first.Progress.Target = 100
+	first.Status = fuzzSessionInterrupted
+	if err := storage.WriteJSON(manifestPath, first); err != nil {
+		t.Fatal(err)
+	}
+	if err := runResume(filepath.Join(outputDir, "nightly")); err != nil {
+		t.Fatal(err)
+	}
+
+	resumed, err := loadFuzzSession(manifestPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resumed.Status != fuzzSessionComplete || resumed.Progress.Generated != 100 || resumed.Seed != first.Seed {
+		t.Fatalf("resumed manifest = %+v, want 100 decks generated from the same seed", resumed.Progress)
+	}
+	for i, position := range resumed.RNG {
+		if position.Seed != first.RNG[i].Seed || position.Draws <= first.RNG[i].Draws {
+			t.Errorf("RNG stream %d = %+v, want it continued from %+v", i, position, first.RNG[i])
+		}
+	}
+	for _, hash := range first.Explored {
+		if _, found := slices.BinarySearch(resumed.Explored, hash); !found {
+			t.Fatalf("explored deck %s was dropped on resume", hash)
+		}
+	}
+	if len(resumed.Explored) > resumed.Progress.Evaluated || len(resumed.Explored) <= len(first.Explored) {
+		t.Errorf("resumed session evaluated %d decks (%d explored), first run %d",
+			resumed.Progress.Evaluated, len(resumed.Explored), first.Progress.Evaluated)
+	}
+}
+
+func TestFuzzSessionArgsOmitSecrets(t *testing.T) {
+	var args []string
+	cmd := &cli.Command{
+		Name:  "fuzz",
+		Flags: append(notifyFlags(), &cli.IntFlag{Name: "count"}, &cli.StringSliceFlag{Name: "include-cards"}),
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			args = fuzzSessionArgs(cmd)
+			return nil
+		},
+	}
+	if err := cmd.Run(context.Background(), []string{"fuzz", "--count", "500",
+		"--include-cards", "Hog Rider", "--include-cards", "The Log",
+		"--notify-webhook", "https://example.com/hook?token=secret"}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"--count=500", "--include-cards=Hog Rider", "--include-cards=The Log"}
+	if !slices.Equal(args, want) {
+		t.Errorf("fuzzSessionArgs() = %q, want %q", args, want)
+	}
+}
+
+func TestDeckFuzzSessionRequiresRandomModeAndOutputDir(t *testing.T) {
+	for _, args := range [][]string{
+		{"--session", "s"},
+		{"--session", "s", "--output-dir", t.TempDir(), "--mode", "genetic"},
+	} {
+		err := addDeckFuzzCommand().Run(context.Background(), append([]string{"fuzz", "--tag", "2PYQ98"}, args...))
+		if err == nil || !strings.Contains(err.Error(), "--session") {
+			t.Errorf("%v: err = %v, want a --session error", args, err)
+		}
+	}
+}
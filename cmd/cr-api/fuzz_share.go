@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/klauer/clash-royale-api/go/pkg/fuzzstorage"
+	"github.com/urfave/cli/v3"
+)
+
+// addDeckFuzzExportCommand adds the subcommand that writes saved decks to a
+// file others can import
+func addDeckFuzzExportCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "export",
+		Usage: "Export saved decks with their scores, tags, and notes to share",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "output",
+				Aliases:  []string{"o"},
+				Required: true,
+				Usage:    "Export file path; a .gz suffix compresses it (e.g. decks.json.gz)",
+			},
+			&cli.IntFlag{
+				Name:  "top",
+				Value: 0,
+				Usage: "Maximum number of decks to export (0 = all)",
+			},
+			&cli.StringFlag{
+				Name:  "archetype",
+				Usage: "Filter by archetype",
+			},
+			&cli.Float64Flag{
+				Name:  "min-score",
+				Usage: "Minimum overall score",
+			},
+			&cli.Float64Flag{
+				Name:  "max-score",
+				Usage: "Maximum overall score",
+			},
+			&cli.Float64Flag{
+				Name:  "min-elixir",
+				Usage: "Minimum average elixir",
+			},
+			&cli.Float64Flag{
+				Name:  "max-elixir",
+				Usage: "Maximum average elixir",
+			},
+			deckTagFilterFlag(),
+			favoritesFilterFlag(),
+			withCardsFilterFlag(),
+			withoutCardsFilterFlag(),
+			winConditionFilterFlag(),
+		},
+		Action: deckFuzzExportCommand,
+	}
+}
+
+// addDeckFuzzImportCommand adds the subcommand that merges an export into
+// fuzz storage
+func addDeckFuzzImportCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "import",
+		Usage: "Import shared decks, re-scored for you, and merge them into saved decks",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "input",
+				Aliases:  []string{"i"},
+				Required: true,
+				Usage:    "File written by 'deck fuzz export' (plain or gzip-compressed)",
+			},
+			playerTagFlagWithUsage(false, "Player tag (without #) to re-score imported decks with your card levels"),
+			&cli.StringFlag{
+				Name:  "on-conflict",
+				Value: string(fuzzstorage.ConflictHigherScore),
+				Usage: "When a deck is already saved, keep the copy with the higher score (score) or the newer evaluation (newer)",
+			},
+			&cli.BoolFlag{
+				Name:  "keep-scores",
+				Usage: "Keep the exported scores instead of re-evaluating imported decks",
+			},
+			&cli.IntFlag{
+				Name:  "workers",
+				Value: 1,
+				Usage: "Number of parallel workers for re-evaluation",
+			},
+			&cli.BoolFlag{
+				Name:    "verbose",
+				Aliases: []string{"v"},
+				Usage:   "Show detailed progress information",
+			},
+		},
+		Action: deckFuzzImportCommand,
+	}
+}
+
+func deckFuzzExportCommand(_ context.Context, cmd *cli.Command) error {
+	queryOpts, err := buildFuzzQueryOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	storage, err := fuzzstorage.NewStorage("")
+	if err != nil {
+		return fmt.Errorf("failed to open storage: %w", err)
+	}
+	defer closeFile(storage)
+
+	entries, err := storage.Query(queryOpts)
+	if err != nil {
+		return fmt.Errorf("failed to query decks: %w", err)
+	}
+
+	output := cmd.String("output")
+	if err := fuzzstorage.WriteExchange(output, fuzzstorage.NewExchange(entries, time.Now())); err != nil {
+		return err
+	}
+	printf("Exported %d saved decks to %s\n", len(entries), output)
+	return nil
+}
+
+func deckFuzzImportCommand(ctx context.Context, cmd *cli.Command) error {
+	policy, err := fuzzstorage.ParseConflictPolicy(cmd.String("on-conflict"))
+	if err != nil {
+		return err
+	}
+	input := cmd.String("input")
+	exchange, err := fuzzstorage.ReadExchange(input)
+	if err != nil {
+		return err
+	}
+	entries := exchange.Entries()
+	if len(entries) == 0 {
+		printf("No decks found in %s\n", input)
+		return nil
+	}
+
+	playerTag := cmd.String("tag")
+	verbose := cmd.Bool("verbose")
+	if !cmd.Bool("keep-scores") {
+		// Exported scores reflect the exporter's card levels and scoring
+		// version, so score the decks again before comparing them with ours
+		var player *clashroyale.Player
+		var playerContext *evaluation.PlayerContext
+		if playerTag != "" {
+			player, playerContext, err = loadFuzzPlayerContext(ctx, cmd, playerTag, verbose)
+			if err != nil {
+				return err
+			}
+		}
+		entries = reevaluateStoredDecks(entries, player, playerTag, playerContext, resolveFuzzWorkers(cmd, true, verbose), verbose)
+	}
+
+	storage, err := fuzzstorage.NewStorage("")
+	if err != nil {
+		return fmt.Errorf("failed to open storage: %w", err)
+	}
+	defer closeFile(storage)
+
+	result, err := storage.MergeDecks(entries, policy)
+	if err != nil {
+		return fmt.Errorf("failed to import decks: %w", err)
+	}
+
+	if verbose {
+		fprintf(os.Stderr, "Database: %s\n", storage.GetDBPath())
+	}
+	printf("Imported %d decks from %s: %d added, %d replaced, %d kept local copy\n",
+		len(entries), input, result.Added, result.Replaced, result.Kept)
+	return nil
+}
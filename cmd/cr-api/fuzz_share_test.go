@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/klauer/clash-royale-api/go/pkg/fuzzstorage"
+	"github.com/urfave/cli/v3"
+)
+
+func runShareCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	root := &cli.Command{Name: "fuzz", Commands: []*cli.Command{addDeckFuzzExportCommand(), addDeckFuzzImportCommand()}}
+	return captureStdout(t, func() error {
+		return root.Run(context.Background(), append([]string{"fuzz"}, args...))
+	})
+}
+
+func TestDeckFuzzExportImport(t *testing.T) {
+	ids := seedCurationDecks(t)
+	if _, err := runCurationCommand(t, "tag", "1", "ladder"); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "decks.json.gz")
+
+	output, err := runShareCommand(t, "export", "--output", path, "--deck-tag", "ladder")
+	if err != nil || !strings.Contains(output, "Exported 1 saved decks") {
+		t.Fatalf("export output = %q, %v", output, err)
+	}
+
+	// Import into a fresh home, re-scoring with the current scoring version
+	t.Setenv("HOME", t.TempDir())
+	output, err = runShareCommand(t, "import", "--input", path)
+	if err != nil || !strings.Contains(output, "1 added, 0 replaced, 0 kept local copy") {
+		t.Fatalf("import output = %q, %v", output, err)
+	}
+	storage, err := fuzzstorage.NewStorage("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer storage.Close()
+	imported, err := storage.GetDeck(ids[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(imported.Tags, []string{"ladder"}) || imported.EvaluationVersion != evaluation.CurrentScoringVersion {
+		t.Errorf("imported deck = %+v", imported)
+	}
+
+	output, err = runShareCommand(t, "import", "--input", path, "--keep-scores", "--on-conflict", "score")
+	if err != nil || !strings.Contains(output, "0 added") {
+		t.Errorf("second import output = %q, %v", output, err)
+	}
+	if _, err := runShareCommand(t, "import", "--input", path, "--on-conflict", "oldest"); err == nil {
+		t.Error("expected an error for an unknown conflict policy")
+	}
+}
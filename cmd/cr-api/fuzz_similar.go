@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/fuzzstorage"
+	"github.com/urfave/cli/v3"
+)
+
+// addDeckFuzzSimilarCommand adds the subcommand that finds saved decks like
+// a given one
+func addDeckFuzzSimilarCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "similar",
+		Usage:     "Find saved decks like a saved deck or a deck string, by card embedding similarity",
+		ArgsUsage: "[deck id or name]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "deck",
+				Usage: "Deck to compare against (8 cards separated by dashes) instead of a saved deck",
+			},
+			&cli.IntFlag{
+				Name:  "top",
+				Value: 10,
+				Usage: "Number of similar decks to display",
+			},
+			&cli.Float64Flag{
+				Name:  "min-similarity",
+				Value: 0.8,
+				Usage: "Minimum deck similarity (0-1)",
+			},
+			&cli.StringFlag{
+				Name:  "archetype",
+				Usage: "Only compare against decks of this archetype",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: "summary",
+				Usage: "Output format: summary, json",
+			},
+		},
+		Action: deckFuzzSimilarCommand,
+	}
+}
+
+// similarDeckJSON is a similar saved deck in JSON output
+type similarDeckJSON struct {
+	ID           int      `json:"id"`
+	Name         string   `json:"name,omitempty"`
+	Cards        []string `json:"cards"`
+	OverallScore float64  `json:"overall_score"`
+	Archetype    string   `json:"archetype,omitempty"`
+	Similarity   float64  `json:"similarity"`
+	Added        []string `json:"added"`
+	Removed      []string `json:"removed"`
+}
+
+func deckFuzzSimilarCommand(ctx context.Context, cmd *cli.Command) error {
+	deckStr := cmd.String("deck")
+	if (cmd.Args().Len() == 1) == (deckStr != "") || cmd.Args().Len() > 1 {
+		return errors.New("usage: cr-api deck fuzz similar <deck id or name> | --deck <cards>")
+	}
+	format := strings.ToLower(cmd.String("format"))
+	if format != "summary" && format != "json" {
+		return fmt.Errorf("invalid format %q (must be summary or json)", format)
+	}
+	minSimilarity := cmd.Float64("min-similarity")
+	if minSimilarity < 0 || minSimilarity > 1 {
+		return fmt.Errorf("--min-similarity must be between 0 and 1")
+	}
+
+	storage, entry, err := openCurationStorage(cmd.Args().First())
+	if err != nil {
+		return err
+	}
+	defer closeFile(storage)
+
+	var cards []string
+	if entry != nil {
+		cards = entry.Cards
+	} else if cards, err = parseDeckStringWithLabel(deckStr, "deck"); err != nil {
+		return err
+	}
+
+	similar, err := storage.SimilarDecks(cards, deck.DefaultCardEmbedding().DeckSimilarity, minSimilarity, fuzzstorage.QueryOptions{
+		Archetype: cmd.String("archetype"),
+		Limit:     cmd.Int("top"),
+	})
+	if err != nil {
+		return err
+	}
+
+	results := make([]similarDeckJSON, len(similar))
+	for i, s := range similar {
+		results[i] = similarDeckJSON{
+			ID:           s.ID,
+			Name:         s.Name,
+			Cards:        s.Cards,
+			OverallScore: s.OverallScore,
+			Archetype:    s.Archetype,
+			Similarity:   s.Similarity,
+			Added:        cardsNotIn(s.Cards, cards),
+			Removed:      cardsNotIn(cards, s.Cards),
+		}
+	}
+	if format == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(results)
+	}
+
+	printf("Decks like %s\n", strings.Join(cards, " - "))
+	if len(results) == 0 {
+		printf("No saved decks with similarity %.2f or higher\n", minSimilarity)
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fprintf(w, "ID\tName\tScore\tSimilarity\tChanges\n")
+	for _, r := range results {
+		fprintf(w, "%d\t%s\t%.2f\t%.2f\t%s\n", r.ID, r.Name, r.OverallScore, r.Similarity, describeDeckChanges(r.Added, r.Removed))
+	}
+	flushWriter(w)
+	return nil
+}
+
+// cardsNotIn returns the cards of a that are not in b
+func cardsNotIn(a, b []string) []string {
+	missing := []string{}
+	for _, card := range a {
+		if !slices.Contains(b, card) {
+			missing = append(missing, card)
+		}
+	}
+	return missing
+}
+
+// describeDeckChanges summarizes a swap, e.g. "+Zap -The Log"
+func describeDeckChanges(added, removed []string) string {
+	parts := make([]string, 0, len(added)+len(removed))
+	for _, card := range added {
+		parts = append(parts, "+"+card)
+	}
+	for _, card := range removed {
+		parts = append(parts, "-"+card)
+	}
+	return strings.Join(parts, " ")
+}
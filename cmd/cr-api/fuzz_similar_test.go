@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/fuzzstorage"
+)
+
+func TestDeckFuzzSimilarCommand(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	hogLog := []string{"Hog Rider", "Musketeer", "Cannon", "Ice Golem", "Ice Spirit", "Skeletons", "Fireball", "The Log"}
+	hogZap := []string{"Hog Rider", "Musketeer", "Cannon", "Ice Golem", "Ice Spirit", "Skeletons", "Fireball", "Zap"}
+	golem := []string{"Golem", "Night Witch", "Baby Dragon", "Lumberjack", "Tornado", "Lightning", "Barbarian Barrel", "Mega Minion"}
+	results := []FuzzingResult{
+		{Name: "Hog Log", Deck: hogLog, OverallScore: 9, Archetype: "cycle", EvaluatedAt: time.Now()},
+		{Name: "Hog Zap", Deck: hogZap, OverallScore: 8, Archetype: "cycle", EvaluatedAt: time.Now()},
+		{Name: "Golem", Deck: golem, OverallScore: 7, Archetype: "beatdown", EvaluatedAt: time.Now()},
+	}
+	if err := saveTopDecksToStorage(results, fuzzstorage.RetentionPolicy{}, nil, false); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) (string, error) {
+		return captureStdout(t, func() error {
+			return addDeckFuzzSimilarCommand().Run(context.Background(), append([]string{"similar"}, args...))
+		})
+	}
+
+	output, err := run("Hog Log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(output, "Hog Zap") || !strings.Contains(output, "+Zap -The Log") || strings.Contains(output, "7.00") {
+		t.Errorf("similar output should list only Hog Zap:\n%s", output)
+	}
+
+	output, err = run("--deck", strings.Join(hogZap, "-"), "--min-similarity", "0", "--format", "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decks []similarDeckJSON
+	if err := json.Unmarshal([]byte(output), &decks); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, output)
+	}
+	if len(decks) != 2 || decks[0].Name != "Hog Log" || decks[1].Name != "Golem" || decks[0].Similarity <= decks[1].Similarity {
+		t.Errorf("similar decks = %+v, want Hog Log then Golem", decks)
+	}
+
+	if _, err := run(); err == nil {
+		t.Error("expected a usage error without a deck")
+	}
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"container/heap"
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/metrics"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/klauer/clash-royale-api/go/pkg/leaderboard"
+	"github.com/schollz/progressbar/v3"
+)
+
+const (
+	minFuzzPoolSize = 100
+	fuzzPoolPerTop  = 10
+)
+
+// fuzzPoolSize is how many of the best decks a streaming run keeps. It is
+// larger than --top so deduplication and archetype or elixir balancing still
+// have decks to choose from.
+func fuzzPoolSize(top int) int {
+	return max(top*fuzzPoolPerTop, minFuzzPoolSize)
+}
+
+// fuzzTopK keeps the best unique results seen so far in a bounded min-heap,
+// so memory stays proportional to its capacity however many decks are offered.
+type fuzzTopK struct {
+	capacity int
+	heap     fuzzResultHeap
+	keys     map[string]struct{}
+}
+
+func newFuzzTopK(capacity int, sortBy string) *fuzzTopK {
+	return &fuzzTopK{
+		capacity: max(capacity, 1),
+		heap:     fuzzResultHeap{better: fuzzResultBetter(sortBy)},
+		keys:     make(map[string]struct{}),
+	}
+}
+
+// Offer adds result if it is among the best seen so far and not a duplicate.
+func (t *fuzzTopK) Offer(result FuzzingResult) {
+	key := deckKeyForResult(result)
+	if _, ok := t.keys[key]; ok {
+		return
+	}
+	if t.heap.Len() < t.capacity {
+		heap.Push(&t.heap, fuzzHeapEntry{result: result, key: key})
+		t.keys[key] = struct{}{}
+		return
+	}
+	worst := t.heap.entries[0]
+	if !t.heap.better(result, worst.result) {
+		return
+	}
+	delete(t.keys, worst.key)
+	t.heap.entries[0] = fuzzHeapEntry{result: result, key: key}
+	t.keys[key] = struct{}{}
+	heap.Fix(&t.heap, 0)
+}
+
+// Len returns the number of results kept.
+func (t *fuzzTopK) Len() int {
+	return t.heap.Len()
+}
+
+// Results returns the kept results in no particular order.
+func (t *fuzzTopK) Results() []FuzzingResult {
+	results := make([]FuzzingResult, 0, t.heap.Len())
+	for _, entry := range t.heap.entries {
+		results = append(results, entry.result)
+	}
+	return results
+}
+
+type fuzzHeapEntry struct {
+	result FuzzingResult
+	key    string
+}
+
+// fuzzResultHeap orders entries worst first so the root is the next to evict.
+type fuzzResultHeap struct {
+	entries []fuzzHeapEntry
+	better  func(a, b FuzzingResult) bool
+}
+
+func (h fuzzResultHeap) Len() int { return len(h.entries) }
+func (h fuzzResultHeap) Less(i, j int) bool {
+	return h.better(h.entries[j].result, h.entries[i].result)
+}
+func (h fuzzResultHeap) Swap(i, j int) { h.entries[i], h.entries[j] = h.entries[j], h.entries[i] }
+func (h *fuzzResultHeap) Push(x any)   { h.entries = append(h.entries, x.(fuzzHeapEntry)) }
+func (h *fuzzResultHeap) Pop() any {
+	last := h.entries[len(h.entries)-1]
+	h.entries = h.entries[:len(h.entries)-1]
+	return last
+}
+
+// fuzzStreamOptions configure streamFuzzResults.
+type fuzzStreamOptions struct {
+	workers    int
+	keep       int
+	sortBy     string
+	minOverall float64
+	minSynergy float64
+	archetypes []string
+	// accept, when set, drops decks breaking the --constraints rules before
+	// they are evaluated or saved. Generated decks are already filtered by
+	// the fuzzer; this catches seed decks, mutations and variations.
+	accept func([]string) bool
+	// groupBy adds a pool of groupKeep decks per group key, so rarer
+	// archetypes or elixir buckets survive for the balancing passes.
+	groupBy   []func(FuzzingResult) string
+	groupKeep int
+	storage   *leaderboard.Storage
+	// evalOpts carry the meta snapshot and game mode decks are scored with.
+	evalOpts evaluation.EvaluateOptions
+	// total sizes the verbose progress bar; 0 disables it.
+	total int
+	// explored, when set, skips decks it has seen and records each deck
+	// evaluated, so a resumed session does not repeat earlier work.
+	explored *fuzzExplored
+	// prior are results kept by an earlier run of the session, which the
+	// pools start from.
+	prior []FuzzingResult
+	// checkpoint, when set, receives the kept results and counts every
+	// checkpointEvery.
+	checkpoint      func([]FuzzingResult, fuzzStreamStats)
+	checkpointEvery time.Duration
+}
+
+// fuzzStreamStats counts decks seen by a streaming run.
+type fuzzStreamStats struct {
+	Evaluated       int
+	PassedScore     int
+	PassedArchetype int
+}
+
+// streamFuzzResults evaluates decks as they arrive, drops decks failing the
+// score and archetype filters, and keeps only the best unique decks. Apart
+// from the explored set of a session, nothing proportional to the number of
+// decks is held in memory. On cancellation it returns what was collected so
+// far along with the context error.
+func streamFuzzResults(
+	ctx context.Context,
+	decks <-chan []string,
+	player *clashroyale.Player,
+	playerTag string,
+	opts fuzzStreamOptions,
+) ([]FuzzingResult, fuzzStreamStats, error) {
+	var playerContext *evaluation.PlayerContext
+	if playerTag != "" && player != nil {
+		playerContext = evaluation.NewPlayerContextFromPlayer(player)
+	}
+
+	workers := max(opts.workers, 1)
+	results := make(chan FuzzingResult, workers*2)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Go(func() {
+			// Each worker gets its own synergy database to avoid concurrent access
+			synergyDB := deck.NewSynergyDatabase()
+			for deckCards := range decks {
+				if ctx.Err() != nil {
+					return
+				}
+				if opts.explored != nil && opts.explored.Seen(deckCards) {
+					continue
+				}
+				if opts.accept != nil && !opts.accept(deckCards) {
+					continue
+				}
+				result := evaluateSingleDeck(deckCards, player, playerTag, synergyDB, playerContext, opts.evalOpts)
+				select {
+				case <-ctx.Done():
+					return
+				case results <- result:
+				}
+			}
+		})
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var bar *progressbar.ProgressBar
+	if opts.total > 0 {
+		bar = progressbar.NewOptions(opts.total,
+			progressbar.OptionSetWriter(os.Stderr),
+			progressbar.OptionShowCount(),
+			progressbar.OptionShowIts(),
+			progressbar.OptionSetItsString("decks"),
+			progressbar.OptionOnCompletion(func() {
+				fprintln(os.Stderr)
+			}),
+		)
+	}
+
+	archetypeSet := make(map[string]bool, len(opts.archetypes))
+	for _, arch := range opts.archetypes {
+		archetypeSet[arch] = true
+	}
+	pool := newFuzzTopK(opts.keep, opts.sortBy)
+	groups := make([]map[string]*fuzzTopK, len(opts.groupBy))
+	for i := range groups {
+		groups[i] = make(map[string]*fuzzTopK)
+	}
+
+	keep := func(result FuzzingResult) {
+		pool.Offer(result)
+		for i, groupKey := range opts.groupBy {
+			key := groupKey(result)
+			group, ok := groups[i][key]
+			if !ok {
+				group = newFuzzTopK(opts.groupKeep, opts.sortBy)
+				groups[i][key] = group
+			}
+			group.Offer(result)
+		}
+	}
+	kept := func() []FuzzingResult {
+		all := pool.Results()
+		for _, byKey := range groups {
+			for _, group := range byKey {
+				all = append(all, group.Results()...)
+			}
+		}
+		all = deduplicateResults(all)
+		sortFuzzingResultsImpl(all, opts.sortBy)
+		return all
+	}
+	for _, result := range opts.prior {
+		keep(result)
+	}
+
+	var stats fuzzStreamStats
+	started := time.Now()
+	lastCheckpoint := started
+	for result := range results {
+		if opts.checkpoint != nil && time.Since(lastCheckpoint) >= opts.checkpointEvery {
+			opts.checkpoint(kept(), stats)
+			lastCheckpoint = time.Now()
+		}
+		if opts.explored != nil {
+			opts.explored.Add(result.Deck)
+		}
+		stats.Evaluated++
+		metrics.DecksEvaluated.Inc()
+		if elapsed := time.Since(started).Seconds(); elapsed > 0 {
+			metrics.DecksPerSecond.Set(float64(stats.Evaluated) / elapsed)
+		}
+		if bar != nil {
+			_ = bar.Add(1)
+		}
+		// Storage is not safe for concurrent writes, so save from this goroutine.
+		if opts.storage != nil {
+			saveDeckToStorage(result, playerTag, opts.storage)
+		}
+		if result.OverallScore < opts.minOverall || result.SynergyScore < opts.minSynergy {
+			continue
+		}
+		stats.PassedScore++
+		if len(archetypeSet) > 0 && !archetypeSet[result.Archetype] {
+			continue
+		}
+		stats.PassedArchetype++
+		keep(result)
+	}
+
+	return kept(), stats, ctx.Err()
+}
+
+// resultArchetype groups results by detected archetype.
+func resultArchetype(result FuzzingResult) string {
+	return result.Archetype
+}
+
+// resultElixirBucket groups results by average elixir bucket.
+func resultElixirBucket(result FuzzingResult) string {
+	return getElixirBucket(result.AvgElixir)
+}
+
+// chainDecks sends the decks in front, then every deck from rest, on one
+// channel. It stops early when ctx is done.
+func chainDecks(ctx context.Context, front [][]string, rest <-chan []string) <-chan []string {
+	out := make(chan []string)
+	go func() {
+		defer close(out)
+		for _, deckCards := range front {
+			select {
+			case <-ctx.Done():
+				return
+			case out <- deckCards:
+			}
+		}
+		for deckCards := range rest {
+			select {
+			case <-ctx.Done():
+				return
+			case out <- deckCards:
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+func TestFuzzTopKKeepsBestUniqueResults(t *testing.T) {
+	topK := newFuzzTopK(3, "overall")
+	for i := range 20 {
+		topK.Offer(FuzzingResult{
+			Deck:         []string{fmt.Sprintf("Card %d", i%10), "B", "C", "D", "E", "F", "G", "H"},
+			OverallScore: float64(i % 10),
+		})
+	}
+
+	if topK.Len() != 3 {
+		t.Fatalf("kept %d results, want 3", topK.Len())
+	}
+	results := topK.Results()
+	sortFuzzingResultsImpl(results, "overall")
+	for i, want := range []float64{9, 8, 7} {
+		if results[i].OverallScore != want {
+			t.Errorf("result %d score = %v, want %v", i, results[i].OverallScore, want)
+		}
+	}
+}
+
+func TestFuzzTopKHonorsSortBy(t *testing.T) {
+	topK := newFuzzTopK(2, "elixir")
+	for i, elixir := range []float64{4.1, 2.6, 3.3, 5.0} {
+		topK.Offer(FuzzingResult{Deck: []string{fmt.Sprintf("Card %d", i)}, AvgElixir: elixir})
+	}
+	results := topK.Results()
+	sortFuzzingResultsImpl(results, "elixir")
+	if len(results) != 2 || results[0].AvgElixir != 2.6 || results[1].AvgElixir != 3.3 {
+		t.Errorf("expected the two lowest-elixir decks, got %+v", results)
+	}
+}
+
+func TestStreamFuzzResults(t *testing.T) {
+	player := newStreamTestPlayer()
+	fuzzer, err := deck.NewDeckFuzzer(player, &deck.FuzzingConfig{Count: 200, Workers: 2, Seed: 11})
+	if err != nil {
+		t.Fatalf("NewDeckFuzzer() error = %v", err)
+	}
+
+	ctx := context.Background()
+	results, stats, err := streamFuzzResults(ctx, fuzzer.StreamDecks(ctx), player, player.Tag, fuzzStreamOptions{
+		workers:   2,
+		keep:      5,
+		sortBy:    "overall",
+		groupBy:   []func(FuzzingResult) string{resultElixirBucket},
+		groupKeep: 1,
+	})
+	if err != nil {
+		t.Fatalf("streamFuzzResults() error = %v", err)
+	}
+	if stats.Evaluated != fuzzer.GetStats().Success {
+		t.Errorf("evaluated %d decks, fuzzer generated %d", stats.Evaluated, fuzzer.GetStats().Success)
+	}
+	if stats.PassedScore != stats.Evaluated || stats.PassedArchetype != stats.Evaluated {
+		t.Errorf("expected every deck to pass empty filters, got %+v", stats)
+	}
+	if len(results) < 5 || len(results) > 5+3 {
+		t.Errorf("kept %d results, want the pool of 5 plus at most one per elixir bucket (3 buckets)", len(results))
+	}
+	for i := 1; i < len(results); i++ {
+		if results[i].OverallScore > results[i-1].OverallScore {
+			t.Fatalf("results not sorted by overall score at %d", i)
+		}
+	}
+	if len(deduplicateResults(results)) != len(results) {
+		t.Error("expected kept results to be unique")
+	}
+}
+
+func TestStreamFuzzResultsAppliesFilters(t *testing.T) {
+	player := newStreamTestPlayer()
+	fuzzer, err := deck.NewDeckFuzzer(player, &deck.FuzzingConfig{Count: 50, Seed: 5})
+	if err != nil {
+		t.Fatalf("NewDeckFuzzer() error = %v", err)
+	}
+
+	ctx := context.Background()
+	results, stats, err := streamFuzzResults(ctx, fuzzer.StreamDecks(ctx), player, player.Tag, fuzzStreamOptions{
+		keep:       10,
+		minOverall: 11,
+	})
+	if err != nil {
+		t.Fatalf("streamFuzzResults() error = %v", err)
+	}
+	if stats.Evaluated == 0 || stats.PassedScore != 0 || len(results) != 0 {
+		t.Errorf("expected an impossible score filter to drop every deck, got %d results and %+v", len(results), stats)
+	}
+}
+
+func TestStreamFuzzResultsRejectsBeforeEvaluating(t *testing.T) {
+	player := newStreamTestPlayer()
+	kept := []string{"Hog Rider", "Fireball", "Cannon", "Archers", "Knight", "Skeletons", "Musketeer", "Ice Spirit"}
+	rejected := []string{"Hog Rider", "Fireball", "Zap", "Archers", "Knight", "Skeletons", "Musketeer", "Ice Spirit"}
+	decks := make(chan []string, 2)
+	decks <- kept
+	decks <- rejected
+	close(decks)
+
+	results, stats, err := streamFuzzResults(context.Background(), decks, player, player.Tag, fuzzStreamOptions{
+		keep:   10,
+		accept: func(deckCards []string) bool { return !slices.Contains(deckCards, "Zap") },
+	})
+	if err != nil {
+		t.Fatalf("streamFuzzResults() error = %v", err)
+	}
+	if stats.Evaluated != 1 || len(results) != 1 || !slices.Equal(results[0].Deck, kept) {
+		t.Errorf("expected only the accepted deck to be evaluated, got %d results and %+v", len(results), stats)
+	}
+}
+
+func newStreamTestPlayer() *clashroyale.Player {
+	cards := []struct {
+		name   string
+		rarity string
+		elixir int
+	}{
+		{"Hog Rider", "Rare", 4}, {"Fireball", "Rare", 4}, {"Zap", "Common", 2},
+		{"Cannon", "Common", 3}, {"Archers", "Common", 3}, {"Knight", "Common", 3},
+		{"Skeletons", "Common", 1}, {"Valkyrie", "Rare", 4}, {"Baby Dragon", "Epic", 4},
+		{"Musketeer", "Rare", 4}, {"Ice Spirit", "Common", 1}, {"Giant", "Rare", 5},
+		{"The Log", "Legendary", 2}, {"Tesla", "Common", 4}, {"Minion Horde", "Common", 5},
+		{"Poison", "Epic", 4},
+	}
+	player := &clashroyale.Player{Name: "Tester", Tag: "#TAG123"}
+	for _, card := range cards {
+		player.Cards = append(player.Cards, clashroyale.Card{
+			Name: card.name, Level: 11, MaxLevel: 14, Rarity: card.rarity, ElixirCost: card.elixir,
+		})
+	}
+	return player
+}
@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	jsonstore "github.com/klauer/clash-royale-api/go/internal/storage"
 	"github.com/klauer/clash-royale-api/go/pkg/leaderboard"
@@ -84,6 +86,30 @@ func addLeaderboardCommands() *cli.Command {
 						Name:  "exclude",
 						Usage: "Exclude decks containing ANY of these cards (comma-separated)",
 					},
+					&cli.StringSliceFlag{
+						Name:  "score-band",
+						Usage: "Category score range as field=min..max, e.g. attack=8.. or defense=6..9 (repeatable)",
+					},
+					&cli.StringFlag{
+						Name:  "search",
+						Usage: `Full-text search over cards, archetype, and strategy (e.g. "hog rider", lava*, archetype:cycle)`,
+					},
+					&cli.StringFlag{
+						Name:  "player",
+						Usage: "Only decks evaluated for this player tag",
+					},
+					&cli.StringFlag{
+						Name:  "since",
+						Usage: "Only decks evaluated since a date (2006-01-02) or age (7d, 12h)",
+					},
+					&cli.StringFlag{
+						Name:  "until",
+						Usage: "Only decks evaluated before a date (2006-01-02) or age (7d, 12h)",
+					},
+					&cli.StringFlag{
+						Name:  "evaluation-version",
+						Usage: "Only decks scored by this evaluation version",
+					},
 					&cli.IntFlag{
 						Name:    "limit",
 						Aliases: []string{"n"},
@@ -95,6 +121,10 @@ func addLeaderboardCommands() *cli.Command {
 						Value: 0,
 						Usage: "Number of results to skip (for pagination)",
 					},
+					&cli.StringFlag{
+						Name:  "cursor",
+						Usage: "Continue from the cursor printed after the previous page",
+					},
 					&cli.StringFlag{
 						Name:  "sort-by",
 						Value: "overall_score",
@@ -228,27 +258,16 @@ func leaderboardFilterCommand(ctx context.Context, cmd *cli.Command) error {
 	}
 	defer closeFile(storage)
 
-	// Build query options
-	opts := leaderboard.QueryOptions{
-		Limit:           cmd.Int("limit"),
-		Offset:          cmd.Int("offset"),
-		Archetype:       cmd.String("archetype"),
-		Strategy:        cmd.String("strategy"),
-		MinScore:        cmd.Float64("min-score"),
-		MaxScore:        cmd.Float64("max-score"),
-		MinAvgElixir:    cmd.Float64("min-elixir"),
-		MaxAvgElixir:    cmd.Float64("max-elixir"),
-		SortBy:          cmd.String("sort-by"),
-		SortOrder:       cmd.String("order"),
-		RequireAllCards: cmd.StringSlice("require-all"),
-		RequireAnyCards: cmd.StringSlice("require-any"),
-		ExcludeCards:    cmd.StringSlice("exclude"),
+	opts, err := buildLeaderboardQueryOptions(cmd, time.Now())
+	if err != nil {
+		return err
 	}
 
-	decks, err := storage.Query(opts)
+	page, err := storage.QueryPage(opts)
 	if err != nil {
 		return fmt.Errorf("failed to query decks: %w", err)
 	}
+	decks := page.Entries
 
 	if len(decks) == 0 {
 		printf("No decks found matching the filters\n")
@@ -286,9 +305,100 @@ func leaderboardFilterCommand(ctx context.Context, cmd *cli.Command) error {
 		printf("%s\n", output)
 	}
 
+	// Keep machine-readable stdout clean; the page summary goes to stderr
+	summary := os.Stdout
+	if outputPath == "" && (format == "json" || format == "csv") {
+		summary = os.Stderr
+	}
+	fprintf(summary, "Showing %d of %d matching decks\n", len(decks), page.Total)
+	if page.NextCursor != "" {
+		fprintf(summary, "Next page: --cursor %s\n", page.NextCursor)
+	}
+
 	return nil
 }
 
+// buildLeaderboardQueryOptions turns the leaderboard filter flags into query
+// options
+func buildLeaderboardQueryOptions(cmd *cli.Command, now time.Time) (leaderboard.QueryOptions, error) {
+	opts := leaderboard.QueryOptions{
+		Limit:             cmd.Int("limit"),
+		Offset:            cmd.Int("offset"),
+		Archetype:         cmd.String("archetype"),
+		Strategy:          cmd.String("strategy"),
+		MinScore:          cmd.Float64("min-score"),
+		MaxScore:          cmd.Float64("max-score"),
+		MinAvgElixir:      cmd.Float64("min-elixir"),
+		MaxAvgElixir:      cmd.Float64("max-elixir"),
+		SortBy:            cmd.String("sort-by"),
+		SortOrder:         cmd.String("order"),
+		RequireAllCards:   cmd.StringSlice("require-all"),
+		RequireAnyCards:   cmd.StringSlice("require-any"),
+		ExcludeCards:      cmd.StringSlice("exclude"),
+		PlayerTag:         cmd.String("player"),
+		EvaluationVersion: cmd.String("evaluation-version"),
+		Search:            cmd.String("search"),
+		Cursor:            cmd.String("cursor"),
+	}
+	if opts.Cursor != "" && opts.Offset > 0 {
+		return opts, fmt.Errorf("--cursor and --offset cannot be combined")
+	}
+
+	known := knownCardNames()
+	var err error
+	for _, cards := range []*[]string{&opts.RequireAllCards, &opts.RequireAnyCards, &opts.ExcludeCards} {
+		if *cards, err = resolveQueryCards(*cards, known); err != nil {
+			return opts, err
+		}
+	}
+	if value := cmd.String("since"); value != "" {
+		if opts.EvaluatedAfter, err = parseTimeFlag("since", value, now); err != nil {
+			return opts, err
+		}
+	}
+	if value := cmd.String("until"); value != "" {
+		if opts.EvaluatedBefore, err = parseTimeFlag("until", value, now); err != nil {
+			return opts, err
+		}
+	}
+	for _, value := range cmd.StringSlice("score-band") {
+		band, err := parseScoreBand(value)
+		if err != nil {
+			return opts, err
+		}
+		opts.ScoreBands = append(opts.ScoreBands, band)
+	}
+	return opts, nil
+}
+
+// parseScoreBand reads a --score-band value such as "attack=8..", "defense=6..9",
+// or "synergy=..5"
+func parseScoreBand(value string) (leaderboard.ScoreBand, error) {
+	invalid := fmt.Errorf("invalid --score-band %q: use field=min..max, e.g. attack=8..", value)
+	field, bounds, ok := strings.Cut(value, "=")
+	if !ok || strings.TrimSpace(field) == "" {
+		return leaderboard.ScoreBand{}, invalid
+	}
+	low, high, ok := strings.Cut(bounds, "..")
+	if !ok {
+		return leaderboard.ScoreBand{}, invalid
+	}
+	band := leaderboard.ScoreBand{Field: strings.TrimSpace(field)}
+	for _, bound := range []struct {
+		text   string
+		target *float64
+	}{{low, &band.Min}, {high, &band.Max}} {
+		if text := strings.TrimSpace(bound.text); text != "" {
+			score, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return leaderboard.ScoreBand{}, invalid
+			}
+			*bound.target = score
+		}
+	}
+	return band, nil
+}
+
 func leaderboardExportCommand(ctx context.Context, cmd *cli.Command) error {
 	playerTag := cmd.String("tag")
 	format := cmd.String("format")
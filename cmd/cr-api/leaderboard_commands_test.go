@@ -0,0 +1,25 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/leaderboard"
+)
+
+func TestParseScoreBand(t *testing.T) {
+	for value, want := range map[string]leaderboard.ScoreBand{
+		"attack=8..":      {Field: "attack", Min: 8},
+		"defense=6..9":    {Field: "defense", Min: 6, Max: 9},
+		" synergy = ..5 ": {Field: "synergy", Max: 5},
+	} {
+		got, err := parseScoreBand(value)
+		if err != nil || got != want {
+			t.Errorf("parseScoreBand(%q) = %+v, %v; want %+v", value, got, err, want)
+		}
+	}
+	for _, value := range []string{"attack", "attack=8", "=1..2", "attack=high.."} {
+		if _, err := parseScoreBand(value); err == nil {
+			t.Errorf("parseScoreBand(%q) should fail", value)
+		}
+	}
+}
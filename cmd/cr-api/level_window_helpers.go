@@ -0,0 +1,340 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/urfave/cli/v3"
+)
+
+const levelWindowFlagName = "level-window"
+
+// levelWindowMaxSwaps bounds how many upgraded cards the budget comparison
+// swaps into the best playable deck, keeping the search to a few hundred
+// evaluations
+const levelWindowMaxSwaps = 2
+
+// levelWindowFlag enables budget mode: only cards within K levels of the
+// player's ladder average are used, and the result is compared with the best
+// deck reachable by upgrading.
+func levelWindowFlag() cli.Flag {
+	return &cli.IntFlag{
+		Name:  levelWindowFlagName,
+		Usage: "Budget mode: only use cards within K levels of the player's ladder average (their 8 best-leveled cards), and compare with the best deck after upgrades",
+	}
+}
+
+// levelWindow returns the --level-window around the collection's ladder
+// average, or nil when the flag is not set.
+func levelWindow(cmd *cli.Command, collection []deck.CardCandidate) (*deck.LevelWindow, error) {
+	if !cmd.IsSet(levelWindowFlagName) {
+		return nil, nil
+	}
+	levels := cmd.Int(levelWindowFlagName)
+	if levels < 0 {
+		return nil, fmt.Errorf("--%s must be 0 or more levels", levelWindowFlagName)
+	}
+	window := deck.NewLevelWindow(levels, collection)
+	if window == nil {
+		return nil, fmt.Errorf("--%s needs the player's card levels", levelWindowFlagName)
+	}
+	return window, nil
+}
+
+// analysisCandidates returns the analysis' collection as candidates, sorted
+// by name
+func analysisCandidates(cardAnalysis deck.CardAnalysis) []deck.CardCandidate {
+	cards := make([]deck.CardCandidate, 0, len(cardAnalysis.CardLevels))
+	for _, name := range slices.Sorted(maps.Keys(cardAnalysis.CardLevels)) {
+		level := cardAnalysis.CardLevels[name]
+		cards = append(cards, deck.CardCandidate{Name: name, Level: level.Level, MaxLevel: level.MaxLevel, Rarity: level.Rarity})
+	}
+	return cards
+}
+
+// playerCandidates returns the player's collection as candidates
+func playerCandidates(player *clashroyale.Player) []deck.CardCandidate {
+	cards := make([]deck.CardCandidate, 0, len(player.Cards))
+	for _, card := range player.Cards {
+		cards = append(cards, deck.CardCandidate{Name: card.Name, Level: card.Level, MaxLevel: card.MaxLevel, Rarity: card.Rarity})
+	}
+	return cards
+}
+
+// applyLevelWindowToAnalysis removes cards below the window from the
+// analysis used by the deck builder.
+func applyLevelWindowToAnalysis(window *deck.LevelWindow, cardAnalysis *deck.CardAnalysis, verbose bool) {
+	if window == nil {
+		return
+	}
+	var removed []string
+	for _, card := range analysisCandidates(*cardAnalysis) {
+		if !window.Allows(card) {
+			removed = append(removed, card.Name)
+		}
+	}
+	if len(removed) > 0 {
+		cardLevels := maps.Clone(cardAnalysis.CardLevels)
+		for _, name := range removed {
+			delete(cardLevels, name)
+		}
+		cardAnalysis.CardLevels = cardLevels
+	}
+	reportLevelWindow(window, len(cardAnalysis.CardLevels), removed, verbose)
+}
+
+// applyLevelWindowToPlayer removes cards below the window from the
+// collection and returns their names, so callers can also exclude them from
+// seed decks loaded from storage.
+func applyLevelWindowToPlayer(window *deck.LevelWindow, player *clashroyale.Player, verbose bool) []string {
+	if window == nil || player == nil {
+		return nil
+	}
+	var removed []string
+	player.Cards = slices.DeleteFunc(player.Cards, func(card clashroyale.Card) bool {
+		if window.Allows(deck.CardCandidate{Level: card.Level, MaxLevel: card.MaxLevel, Rarity: card.Rarity}) {
+			return false
+		}
+		removed = append(removed, card.Name)
+		return true
+	})
+	slices.Sort(removed)
+	reportLevelWindow(window, len(player.Cards), removed, verbose)
+	return removed
+}
+
+// validateIncludedCardsInWindow rejects --include-cards below the window
+func validateIncludedCardsInWindow(window *deck.LevelWindow, includeCards []string, player *clashroyale.Player) error {
+	if window == nil {
+		return nil
+	}
+	var below []string
+	for _, card := range player.Cards {
+		if slices.ContainsFunc(includeCards, func(name string) bool { return strings.TrimSpace(name) == card.Name }) &&
+			!window.Allows(deck.CardCandidate{Level: card.Level, MaxLevel: card.MaxLevel, Rarity: card.Rarity}) {
+			below = append(below, card.Name)
+		}
+	}
+	if len(below) > 0 {
+		return fmt.Errorf("included cards are below level %d (--%s %d): %s",
+			window.Floor(), levelWindowFlagName, window.Levels, strings.Join(below, ", "))
+	}
+	return nil
+}
+
+// raiseAnalysis returns a copy of the analysis with every card below the
+// window leveled up to its floor
+func raiseAnalysis(window *deck.LevelWindow, cardAnalysis deck.CardAnalysis) deck.CardAnalysis {
+	cardLevels := maps.Clone(cardAnalysis.CardLevels)
+	for name, level := range cardLevels {
+		card := deck.CardCandidate{Level: level.Level, MaxLevel: level.MaxLevel, Rarity: level.Rarity}
+		level.Level = window.Raise([]deck.CardCandidate{card})[0].Level
+		cardLevels[name] = level
+	}
+	cardAnalysis.CardLevels = cardLevels
+	return cardAnalysis
+}
+
+// raisePlayer returns a copy of the player with every card below the window
+// leveled up to its floor
+func raisePlayer(window *deck.LevelWindow, player *clashroyale.Player) *clashroyale.Player {
+	raised := *player
+	raised.Cards = slices.Clone(player.Cards)
+	for i, card := range raised.Cards {
+		candidate := deck.CardCandidate{Level: card.Level, MaxLevel: card.MaxLevel, Rarity: card.Rarity}
+		raised.Cards[i].Level = window.Raise([]deck.CardCandidate{candidate})[0].Level
+	}
+	return &raised
+}
+
+// deckUpgrades lists the upgrades a deck's cards need to enter the window,
+// in deck order
+func deckUpgrades(window *deck.LevelWindow, collection []deck.CardCandidate, cards []string) []deck.LevelUpgrade {
+	var deckCards []deck.CardCandidate
+	for _, name := range cards {
+		if i := slices.IndexFunc(collection, func(card deck.CardCandidate) bool { return card.Name == name }); i >= 0 {
+			deckCards = append(deckCards, collection[i])
+		}
+	}
+	return window.Upgrades(deckCards)
+}
+
+// levelWindowVariant is one side of the playable-now / after-upgrades
+// comparison
+type levelWindowVariant struct {
+	Cards    []string
+	Score    float64
+	Upgrades []deck.LevelUpgrade
+}
+
+// levelWindowUpgradeDeck looks for a stronger deck reachable by upgrading:
+// starting from the best playable deck, it swaps in the cards below the
+// window (scored as if upgraded) while each swap raises the score, up to
+// levelWindowMaxSwaps swaps.
+func levelWindowUpgradeDeck(deckCards, below []string, score func([]string) float64) ([]string, float64) {
+	best, bestScore := slices.Clone(deckCards), score(deckCards)
+	for range levelWindowMaxSwaps {
+		var swapped []string
+		swappedScore := bestScore
+		for _, card := range below {
+			if slices.Contains(best, card) {
+				continue
+			}
+			for i := range best {
+				candidate := slices.Clone(best)
+				candidate[i] = card
+				if s := score(candidate); s > swappedScore {
+					swapped, swappedScore = candidate, s
+				}
+			}
+		}
+		if swapped == nil {
+			break
+		}
+		best, bestScore = swapped, swappedScore
+	}
+	return best, bestScore
+}
+
+// printLevelWindowComparison shows the playable-now deck next to the best
+// deck after upgrades. Cards the decks share line up on the same rows.
+func printLevelWindowComparison(out io.Writer, window *deck.LevelWindow, now, after levelWindowVariant) {
+	unit := "levels"
+	if window.Levels == 1 {
+		unit = "level"
+	}
+	fprintf(out, "\nBudget mode: ladder average %.2f, cards at level %d+ (within %d %s)\n",
+		window.Average, window.Floor(), window.Levels, unit)
+	if slices.Equal(slices.Sorted(slices.Values(now.Cards)), slices.Sorted(slices.Values(after.Cards))) {
+		fprintf(out, "The best deck is already playable now (score %.2f)\n", now.Score)
+		return
+	}
+
+	upgraded := make(map[string]deck.LevelUpgrade, len(after.Upgrades))
+	for _, upgrade := range after.Upgrades {
+		upgraded[upgrade.Card] = upgrade
+	}
+	var shared, nowOnly []string
+	for _, card := range now.Cards {
+		if slices.Contains(after.Cards, card) {
+			shared = append(shared, card)
+		} else {
+			nowOnly = append(nowOnly, card)
+		}
+	}
+	afterOnly := slices.DeleteFunc(slices.Clone(after.Cards), func(card string) bool {
+		return slices.Contains(shared, card)
+	})
+
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fprintf(w, "\tPlayable now\tAfter %d upgrades\n", deck.UpgradeLevels(after.Upgrades))
+	fprintf(w, "Score\t%.2f\t%.2f (%+.2f)\n", now.Score, after.Score, after.Score-now.Score)
+	for _, card := range shared {
+		fprintf(w, "\t%s\t%s\n", card, card)
+	}
+	for i := range max(len(nowOnly), len(afterOnly)) {
+		left, right := "", ""
+		if i < len(nowOnly) {
+			left = nowOnly[i]
+		}
+		if i < len(afterOnly) {
+			right = afterOnly[i]
+			if upgrade, ok := upgraded[right]; ok {
+				right = fmt.Sprintf("%s (%d→%d)", right, upgrade.From, upgrade.To)
+			}
+		}
+		fprintf(w, "\t%s\t%s\n", left, right)
+	}
+	flushWriter(w)
+}
+
+func reportLevelWindow(window *deck.LevelWindow, kept int, removed []string, verbose bool) {
+	fprintf(os.Stderr, "Budget mode: ladder average %.2f, %d cards at level %d+ available, %d below the window\n",
+		window.Average, kept, window.Floor(), len(removed))
+	if verbose && len(removed) > 0 {
+		fprintf(os.Stderr, "  Below the window: %s\n", strings.Join(removed, ", "))
+	}
+}
+
+// displayLevelWindowComparison swaps cards below the window into the
+// playable-now deck while that raises its score, and prints the two decks
+// side by side. collection holds the player's current levels; score rates a
+// deck as it would play once its cards are upgraded into the window.
+func displayLevelWindowComparison(
+	out io.Writer,
+	window *deck.LevelWindow,
+	collection []deck.CardCandidate,
+	nowCards []string,
+	score func([]string) float64,
+) {
+	var below []string
+	for _, card := range collection {
+		if !window.Allows(card) {
+			below = append(below, card.Name)
+		}
+	}
+	afterCards, afterScore := levelWindowUpgradeDeck(nowCards, below, score)
+	now := levelWindowVariant{Cards: nowCards, Score: score(nowCards)}
+	after := levelWindowVariant{
+		Cards:    afterCards,
+		Score:    afterScore,
+		Upgrades: deckUpgrades(window, collection, afterCards),
+	}
+	printLevelWindowComparison(out, window, now, after)
+}
+
+// displayLevelWindowBuild compares a deck built within the window with the
+// best deck after upgrades
+func displayLevelWindowBuild(window *deck.LevelWindow, fullAnalysis deck.CardAnalysis, nowDeck *deck.DeckRecommendation) {
+	raised := raiseAnalysis(window, fullAnalysis)
+	playerContext := evaluation.NewPlayerContextFromAnalysis(raised)
+	synergyDB := deck.NewSynergyDatabase()
+	score := func(cards []string) float64 {
+		details := make([]deck.CardDetail, 0, len(cards))
+		for _, name := range cards {
+			level := raised.CardLevels[name]
+			details = append(details, deck.CardDetail{
+				Name:              name,
+				Level:             level.Level,
+				MaxLevel:          level.MaxLevel,
+				Rarity:            level.Rarity,
+				Elixir:            level.Elixir,
+				EvolutionLevel:    level.EvolutionLevel,
+				MaxEvolutionLevel: level.MaxEvolutionLevel,
+			})
+		}
+		candidates := (&deck.DeckRecommendation{DeckDetail: details}).Candidates()
+		return evaluation.Evaluate(candidates, synergyDB, playerContext).OverallScore
+	}
+	displayLevelWindowComparison(os.Stdout, window, analysisCandidates(fullAnalysis), nowDeck.Deck, score)
+}
+
+// displayLevelWindowFuzz compares the best playable fuzz deck with the best
+// deck after upgrades
+func displayLevelWindowFuzz(
+	out io.Writer,
+	window *deck.LevelWindow,
+	fullPlayer *clashroyale.Player,
+	nowCards []string,
+	withContext bool,
+	evalOpts evaluation.EvaluateOptions,
+) {
+	raised := raisePlayer(window, fullPlayer)
+	var playerContext *evaluation.PlayerContext
+	if withContext {
+		playerContext = evaluation.NewPlayerContextFromPlayer(raised)
+	}
+	synergyDB := deck.NewSynergyDatabase()
+	score := func(cards []string) float64 {
+		return evaluation.EvaluateWithOptions(convertDeckToCandidates(cards, raised), synergyDB, playerContext, evalOpts).OverallScore
+	}
+	displayLevelWindowComparison(out, window, playerCandidates(fullPlayer), nowCards, score)
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/urfave/cli/v3"
+)
+
+// runWithLevelWindowFlag runs fn inside a command that has --level-window
+func runWithLevelWindowFlag(t *testing.T, fn func(cmd *cli.Command), args ...string) {
+	t.Helper()
+	cmd := &cli.Command{
+		Name:  "test",
+		Flags: []cli.Flag{levelWindowFlag()},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			fn(cmd)
+			return nil
+		},
+	}
+	if err := cmd.Run(context.Background(), append([]string{"test"}, args...)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// levelWindowTestPlayer has eight commons at 14, a ladder average of 14
+func levelWindowTestPlayer() *clashroyale.Player {
+	player := &clashroyale.Player{}
+	for _, name := range []string{"Knight", "Archers", "Skeletons", "Ice Spirit", "Zap", "Bats", "Goblins", "Spear Goblins"} {
+		player.Cards = append(player.Cards, clashroyale.Card{Name: name, Level: 14, MaxLevel: 16, Rarity: "Common"})
+	}
+	return player
+}
+
+func TestLevelWindowFlag(t *testing.T) {
+	player := levelWindowTestPlayer()
+	runWithLevelWindowFlag(t, func(cmd *cli.Command) {
+		if window, err := levelWindow(cmd, playerCandidates(player)); window != nil || err != nil {
+			t.Errorf("unset flag: window %+v, err %v", window, err)
+		}
+	})
+	runWithLevelWindowFlag(t, func(cmd *cli.Command) {
+		window, err := levelWindow(cmd, playerCandidates(player))
+		if err != nil || window == nil || window.Floor() != 14 {
+			t.Errorf("--level-window 0: window %+v, err %v; want floor 14", window, err)
+		}
+	}, "--level-window", "0")
+	runWithLevelWindowFlag(t, func(cmd *cli.Command) {
+		if _, err := levelWindow(cmd, playerCandidates(player)); err == nil {
+			t.Error("a negative window should be rejected")
+		}
+	}, "--level-window", "-1")
+}
+
+func TestApplyLevelWindowToPlayer(t *testing.T) {
+	player := levelWindowTestPlayer()
+	player.Cards = append(player.Cards,
+		clashroyale.Card{Name: "Hog Rider", Level: 10, MaxLevel: 14, Rarity: "Rare"},  // standard 12
+		clashroyale.Card{Name: "Baby Dragon", Level: 5, MaxLevel: 11, Rarity: "Epic"}, // standard 10
+	)
+	window := deck.NewLevelWindow(2, playerCandidates(player))
+
+	if err := validateIncludedCardsInWindow(window, []string{" Baby Dragon"}, player); err == nil || !strings.Contains(err.Error(), "Baby Dragon") {
+		t.Errorf("err = %v, want Baby Dragon rejected", err)
+	}
+	if err := validateIncludedCardsInWindow(window, []string{"Hog Rider"}, player); err != nil {
+		t.Errorf("Hog Rider is in the window: %v", err)
+	}
+
+	removed := applyLevelWindowToPlayer(window, player, false)
+	if !slices.Equal(removed, []string{"Baby Dragon"}) || len(player.Cards) != 9 {
+		t.Errorf("removed %v, %d cards left; want only Baby Dragon removed", removed, len(player.Cards))
+	}
+	if removed := applyLevelWindowToPlayer(nil, player, false); removed != nil {
+		t.Errorf("nil window removed %v", removed)
+	}
+}
+
+func TestApplyLevelWindowToAnalysis(t *testing.T) {
+	levels := map[string]deck.CardLevelData{
+		"Knight":      {Level: 14, MaxLevel: 16, Rarity: "Common"},
+		"Archers":     {Level: 14, MaxLevel: 16, Rarity: "Common"},
+		"Baby Dragon": {Level: 5, MaxLevel: 11, Rarity: "Epic"},
+	}
+	cardAnalysis := deck.CardAnalysis{CardLevels: levels}
+	window := deck.NewLevelWindow(1, analysisCandidates(cardAnalysis))
+
+	raised := raiseAnalysis(window, cardAnalysis)
+	// Ladder average 12.67 leaves a floor of 12: Baby Dragon goes from
+	// standard level 10 to 12
+	if raised.CardLevels["Baby Dragon"].Level != 7 || levels["Baby Dragon"].Level != 5 {
+		t.Errorf("raised Baby Dragon to %d (original %d), want 7 (5)", raised.CardLevels["Baby Dragon"].Level, levels["Baby Dragon"].Level)
+	}
+
+	applyLevelWindowToAnalysis(window, &cardAnalysis, false)
+	if _, ok := cardAnalysis.CardLevels["Baby Dragon"]; ok || len(cardAnalysis.CardLevels) != 2 {
+		t.Errorf("card levels = %v, want Baby Dragon removed", cardAnalysis.CardLevels)
+	}
+	if len(levels) != 3 {
+		t.Error("the caller's card levels should not be modified")
+	}
+}
+
+func TestLevelWindowUpgradeDeck(t *testing.T) {
+	// Each card scores its value; swaps stop at levelWindowMaxSwaps
+	values := map[string]float64{"A": 1, "B": 2, "C": 3, "X": 5, "Y": 4, "Z": 6, "W": 0}
+	score := func(cards []string) float64 {
+		total := 0.0
+		for _, card := range cards {
+			total += values[card]
+		}
+		return total
+	}
+
+	cards, best := levelWindowUpgradeDeck([]string{"A", "B", "C"}, []string{"X", "Y", "Z", "W"}, score)
+	if !slices.Equal(cards, []string{"Z", "X", "C"}) || best != 14 {
+		t.Errorf("upgrade deck = %v (%.0f), want [Z X C] (14)", cards, best)
+	}
+
+	cards, best = levelWindowUpgradeDeck([]string{"A", "B"}, []string{"W"}, score)
+	if !slices.Equal(cards, []string{"A", "B"}) || best != 3 {
+		t.Errorf("no improving swap: got %v (%.0f)", cards, best)
+	}
+}
+
+func TestPrintLevelWindowComparison(t *testing.T) {
+	window := &deck.LevelWindow{Levels: 2, Average: 13.5}
+	now := levelWindowVariant{Cards: []string{"Knight", "Archers", "Zap"}, Score: 70}
+	after := levelWindowVariant{
+		Cards:    []string{"Knight", "Baby Dragon", "Zap"},
+		Score:    74.5,
+		Upgrades: []deck.LevelUpgrade{{Card: "Baby Dragon", From: 10, To: 12}},
+	}
+
+	var out bytes.Buffer
+	printLevelWindowComparison(&out, window, now, after)
+	text := out.String()
+	for _, want := range []string{"level 12+ (within 2 levels)", "Playable now", "After 2 upgrades", "+4.50", "Baby Dragon (10→12)"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("comparison missing %q:\n%s", want, text)
+		}
+	}
+	if line := text[strings.Index(text, "Archers"):]; !strings.Contains(line[:strings.Index(line, "\n")], "Baby Dragon") {
+		t.Errorf("the swapped cards should share a row:\n%s", text)
+	}
+
+	out.Reset()
+	printLevelWindowComparison(&out, window, now, levelWindowVariant{Cards: []string{"Zap", "Knight", "Archers"}, Score: 70})
+	if !strings.Contains(out.String(), "already playable now") {
+		t.Errorf("same deck output:\n%s", out.String())
+	}
+}
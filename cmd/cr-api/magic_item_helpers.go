@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/pkg/analysis"
+	"github.com/urfave/cli/v3"
+)
+
+// magicItemFlags describe the wild cards and books on hand. The API does not
+// report inventory, so the counts come from the player.
+func magicItemFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:  "wild-cards",
+			Usage: "Wild cards on hand as rarity=count (e.g. rare=120,epic=8); recommends which cards to spend them on",
+		},
+		&cli.StringSliceFlag{
+			Name:  "books",
+			Usage: "Books on hand as kind=count, kinds cards, epics, legendaries, champions (e.g. cards=1,legendaries=1)",
+		},
+	}
+}
+
+// parseMagicItems reads --wild-cards and --books
+func parseMagicItems(wildCards, books []string) (analysis.MagicItems, error) {
+	items := analysis.MagicItems{}
+	for _, entry := range wildCards {
+		name, count, err := parseItemCount(entry)
+		if err != nil {
+			return items, fmt.Errorf("invalid --wild-cards entry %q: %w", entry, err)
+		}
+		rarity := config.NormalizeRarity(name)
+		if !slices.Contains(config.GetAllRarities(), rarity) {
+			return items, fmt.Errorf("invalid --wild-cards entry %q: unknown rarity %q", entry, name)
+		}
+		if items.WildCards == nil {
+			items.WildCards = make(map[string]int)
+		}
+		items.WildCards[rarity] += count
+	}
+	for _, entry := range books {
+		name, count, err := parseItemCount(entry)
+		if err != nil {
+			return items, fmt.Errorf("invalid --books entry %q: %w", entry, err)
+		}
+		kind := analysis.NormalizeBookKind(name)
+		if kind == "" {
+			return items, fmt.Errorf("invalid --books entry %q: unknown book %q (use cards, epics, legendaries, or champions)", entry, name)
+		}
+		if items.Books == nil {
+			items.Books = make(map[string]int)
+		}
+		items.Books[kind] += count
+	}
+	return items, nil
+}
+
+func parseItemCount(entry string) (string, int, error) {
+	name, value, ok := strings.Cut(entry, "=")
+	if !ok || strings.TrimSpace(name) == "" {
+		return "", 0, fmt.Errorf("expected name=count")
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || count < 0 {
+		return "", 0, fmt.Errorf("count must be a non-negative integer")
+	}
+	return strings.TrimSpace(name), count, nil
+}
+
+func displayMagicItemAdvice(uses []analysis.MagicItemUse) {
+	printf("\nMagic Item Advice:\n")
+	printf("══════════════════\n")
+	if len(uses) == 0 {
+		printf("No upgrade priority can use these items yet; save them for now.\n")
+		return
+	}
+	for _, use := range uses {
+		item := "your " + use.Item
+		if !strings.HasPrefix(use.Item, "Book") {
+			item = fmt.Sprintf("%d %s", use.Quantity, use.Item)
+		}
+		printf("• Use %s on %s (Lv%d → Lv%d): %s\n",
+			item, use.CardName, use.CurrentLevel, use.CurrentLevel+1, use.Reason)
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/analysis"
+)
+
+func TestParseMagicItems(t *testing.T) {
+	items, err := parseMagicItems([]string{"rare=120", "Epic=8", "rare=30"}, []string{"cards=1", "legendary=2"})
+	if err != nil {
+		t.Fatalf("parseMagicItems() error = %v", err)
+	}
+	if items.WildCards["Rare"] != 150 || items.WildCards["Epic"] != 8 {
+		t.Errorf("wild cards = %v", items.WildCards)
+	}
+	if items.Books[analysis.BookOfCards] != 1 || items.Books[analysis.BookOfLegendaries] != 2 {
+		t.Errorf("books = %v", items.Books)
+	}
+	if items.IsEmpty() {
+		t.Error("expected items")
+	}
+
+	empty, err := parseMagicItems(nil, nil)
+	if err != nil || !empty.IsEmpty() {
+		t.Errorf("parseMagicItems(nil, nil) = %+v, %v", empty, err)
+	}
+}
+
+func TestParseMagicItemsErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		wild  []string
+		books []string
+	}{
+		{"missing count", []string{"rare"}, nil},
+		{"negative count", []string{"rare=-1"}, nil},
+		{"unknown rarity", []string{"mythic=3"}, nil},
+		{"unknown book", nil, []string{"spells=1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseMagicItems(tt.wild, tt.books); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -5,14 +5,21 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/klauer/clash-royale-api/go/internal/datapath"
 	"github.com/klauer/clash-royale-api/go/internal/exporter/csv"
+	"github.com/klauer/clash-royale-api/go/internal/exporter/report"
+	"github.com/klauer/clash-royale-api/go/internal/exporter/xlsx"
+	"github.com/klauer/clash-royale-api/go/internal/schema"
+	"github.com/klauer/clash-royale-api/go/internal/sqlstore"
 	"github.com/klauer/clash-royale-api/go/internal/storage"
 	"github.com/klauer/clash-royale-api/go/pkg/analysis"
 	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
 	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/whatif"
 	"github.com/urfave/cli/v3"
 )
 
@@ -43,6 +50,12 @@ func main() {
 				Usage:   "Clash Royale API token",
 				Sources: cli.EnvVars("CLASH_ROYALE_API_TOKEN"),
 			},
+			&cli.StringFlag{
+				Name:    "api-base",
+				Usage:   "API base URL, or royaleapi for the RoyaleAPI proxy (proxy.royaleapi.dev) when your IP changes",
+				Value:   "official",
+				Sources: cli.EnvVars("CR_API_BASE_URL"),
+			},
 			&cli.StringFlag{
 				Name:    "data-dir",
 				Aliases: []string{"d"},
@@ -55,7 +68,61 @@ func main() {
 				Aliases: []string{"v"},
 				Usage:   "Enable verbose logging",
 			},
+			&cli.BoolFlag{
+				Name:    offlineFlagName,
+				Usage:   "Never call the API: serve cached responses and saved player profiles of any age and report how old they are",
+				Sources: cli.EnvVars("CR_API_OFFLINE"),
+			},
+			&cli.StringFlag{
+				Name:    vcrFlagName,
+				Usage:   "record: save every API response to --vcr-dir; replay: answer requests from those recordings without a token or the API",
+				Sources: cli.EnvVars("CR_API_VCR"),
+			},
+			&cli.StringFlag{
+				Name:    vcrDirFlagName,
+				Usage:   "Directory of recorded API responses for --vcr (default: <data-dir>/cassettes)",
+				Sources: cli.EnvVars("CR_API_VCR_DIR"),
+			},
+			&cli.DurationFlag{
+				Name:  "cache-max-age",
+				Value: time.Hour,
+				Usage: "Serve API responses stored by `cache warm` when younger than this (0 disables)",
+			},
+			&cli.StringFlag{
+				Name:    "storage",
+				Value:   storageBackendJSON,
+				Usage:   "Storage backend: json, or sqlite to also record saves in <data-dir>/cr-api.db",
+				Sources: cli.EnvVars("CR_API_STORAGE"),
+			},
+			&cli.StringFlag{
+				Name:    synergyFileFlagName,
+				Usage:   "Synergy overrides JSON applied on top of the built-in pairs (default: <data-dir>/synergy_overrides.json)",
+				Sources: cli.EnvVars("CR_API_SYNERGY_FILE"),
+			},
+			&cli.StringFlag{
+				Name:    learnedSynergyFlagName,
+				Usage:   "Learned synergy overlay from `deck synergy learn` (default: <data-dir>/synergy_learned.json)",
+				Sources: cli.EnvVars("CR_API_LEARNED_SYNERGY"),
+			},
+			&cli.StringFlag{
+				Name:    customArchetypesFlagName,
+				Usage:   "Custom archetypes JSON with keyword weights, detected alongside the built-in archetypes (default: <data-dir>/custom_archetypes.json)",
+				Sources: cli.EnvVars("CR_API_CUSTOM_ARCHETYPES"),
+			},
+			&cli.StringFlag{
+				Name:    scorerPluginsFlagName,
+				Usage:   "Scorer plugins JSON: external scoring commands blended into every deck evaluation at their weights (off unless set)",
+				Sources: cli.EnvVars("CR_API_SCORER_PLUGINS"),
+			},
+			&cli.FloatFlag{
+				Name:    synergyBlendFlagName,
+				Value:   deck.DefaultSynergyBlend,
+				Usage:   "Weight of learned synergy adjustments (0 ignores the learned overlay, 1 applies them fully)",
+				Sources: cli.EnvVars("CR_API_SYNERGY_BLEND"),
+			},
 		},
+		Before: configureInvocation,
+		After:  finishInvocation,
 		Commands: []*cli.Command{
 			addArchetypeCommands(),
 			addDeckCommands(),
@@ -67,19 +134,73 @@ func main() {
 			addOnboardCommand(),
 			addReviewCommand(),
 			addCompareCommands(),
+			addComparePlayersCommand(),
+			addScrimCommand(),
 			addPlayerCommand(),
 			addCardsCommand(),
 			addAnalyzeCommand(),
 			addPlaystyleCommand(),
+			addProfileCommands(),
+			addServeCommand(),
+			addCacheCommands(),
+			addMetaCommands(),
+			addDBCommands(),
+			addSyncCommands(),
+			addSeasonCommands(),
+			addCollectionCommand(),
+			addClanCommands(),
+			addReportBugCommand(),
+			addValidateCommand(),
+			addBenchCommand(),
+			addSchemaCommand(),
+			addConfigCommands(),
+			addAuthCommands(),
+			addTUICommand(),
+			addWatchCommand(),
 		},
 	}
+	applyConfigFile(cmd)
+	applyStoredToken(cmd)
 
 	if err := cmd.Run(context.Background(), os.Args); err != nil {
 		fprintf(os.Stderr, "Error: %v\n", err)
+		if hint := apiErrorHint(err); hint != "" {
+			fprintf(os.Stderr, "%s\n", hint)
+		}
 		os.Exit(1)
 	}
 }
 
+// configureInvocation applies the global flags that set up shared state.
+func configureInvocation(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+	ctx, err := configureAPICache(ctx, cmd)
+	if err != nil {
+		return ctx, err
+	}
+	if ctx, err = configureVCR(ctx, cmd); err != nil {
+		return ctx, err
+	}
+	if ctx, err = configureAPIBase(ctx, cmd); err != nil {
+		return ctx, err
+	}
+	if ctx, err = configureSynergyOverrides(ctx, cmd); err != nil {
+		return ctx, err
+	}
+	if ctx, err = configureRoleOverrides(ctx, cmd); err != nil {
+		return ctx, err
+	}
+	if ctx, err = configureCustomArchetypes(ctx, cmd); err != nil {
+		return ctx, err
+	}
+	return configureScorerPlugins(ctx, cmd)
+}
+
+// finishInvocation releases the shared state configureInvocation set up.
+func finishInvocation(ctx context.Context, cmd *cli.Command) error {
+	stopScorerPlugins(ctx, cmd)
+	return reportOfflineDataAge(ctx, cmd)
+}
+
 func playerCommand(ctx context.Context, cmd *cli.Command) error {
 	tag := cmd.String("tag")
 	showChests := cmd.Bool("chests")
@@ -129,6 +250,12 @@ func playerCommand(ctx context.Context, cmd *cli.Command) error {
 		} else {
 			printf("Player data saved to: %s/players/%s.json\n", dataDir, player.Tag)
 		}
+		if err := recordInSQLite(cmd, func(db *sqlstore.Store) error {
+			_, err := db.RecordPlayer(player, time.Now(), "player --save")
+			return err
+		}); err != nil {
+			printf("Warning: Failed to record player in database: %v\n", err)
+		}
 	}
 
 	// Export to CSV if requested
@@ -178,6 +305,15 @@ func displayPlayerInfo(p *clashroyale.Player) {
 	printf("\nCard Collection:\n")
 	printf("Total Cards: %d\n", len(p.Cards))
 	printf("Star Points: %d\n", p.StarPoints)
+	if len(p.SupportCards) > 0 {
+		printf("Tower Troops: %s\n", strings.Join(p.TowerTroopNames(), ", "))
+	}
+	if current := p.CurrentTowerTroop(); current != "" {
+		printf("Current Tower Troop: %s\n", current)
+	}
+	if len(p.Badges) > 0 {
+		printf("Badges: %d (%d card masteries)\n", len(p.Badges), len(analysis.ParseCardMasteries(p)))
+	}
 }
 
 func displayUpcomingChests(chests *clashroyale.ChestCycle) {
@@ -287,6 +423,10 @@ func analyzeCommand(ctx context.Context, cmd *cli.Command) error {
 		PrioritizeWinCons: cmd.Bool("prioritize-win-cons"),
 		TopN:              cmd.Int("top-n"),
 	}
+	magicItems, err := parseMagicItems(cmd.StringSlice("wild-cards"), cmd.StringSlice("books"))
+	if err != nil {
+		return err
+	}
 
 	client, err := requireAPIClient(cmd, apiClientOptions{})
 	if err != nil {
@@ -303,6 +443,20 @@ func analyzeCommand(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("failed to get player: %w", err)
 	}
 
+	if cmd.Bool("masteries") {
+		displayMasteries(player, cmd.Float64("mastery-min-progress"), cmd.Int("top-n"))
+		return nil
+	}
+
+	if cmd.Bool("value-ranking") {
+		values, decks, err := runValueRanking(convertPlayerToCardLevels(player), cmd.Int("value-decks"), cmd.String("data-dir"), verbose)
+		if err != nil {
+			return err
+		}
+		displayValueRanking(values, decks, player.Name, player.Tag, cmd.Int("top-n"))
+		return nil
+	}
+
 	if verbose {
 		printf("Player: %s (%s)\n", player.Name, player.Tag)
 		printf("Analyzing %d cards...\n", len(player.Cards))
@@ -314,8 +468,15 @@ func analyzeCommand(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("failed to analyze card collection: %w", err)
 	}
 
+	if !magicItems.IsEmpty() {
+		cardAnalysis.MagicItemAdvice = analysis.AdviseMagicItems(cardAnalysis.UpgradePriority, magicItems)
+	}
+
 	// Display analysis results
 	displayAnalysis(cardAnalysis)
+	if !magicItems.IsEmpty() {
+		displayMagicItemAdvice(cardAnalysis.MagicItemAdvice)
+	}
 
 	// Save analysis if requested
 	if saveData {
@@ -334,6 +495,12 @@ func analyzeCommand(ctx context.Context, cmd *cli.Command) error {
 		} else {
 			printf("Analysis saved to: %s\n", analysisPath)
 		}
+		if err := recordInSQLite(cmd, func(db *sqlstore.Store) error {
+			_, err := db.RecordAnalysis(cardAnalysis.PlayerTag, cardAnalysis.AnalysisTime, cardAnalysis, analysisPath)
+			return err
+		}); err != nil {
+			printf("Warning: Failed to record analysis in database: %v\n", err)
+		}
 	}
 
 	// Export to CSV if requested
@@ -350,7 +517,16 @@ func analyzeCommand(ctx context.Context, cmd *cli.Command) error {
 		}
 	}
 
-	return nil
+	if path := cmd.String("export-xlsx"); path != "" {
+		if err := xlsx.WriteAnalysis(path, cardAnalysis); err != nil {
+			return fmt.Errorf("failed to export analysis workbook: %w", err)
+		}
+		fprintf(os.Stderr, "Workbook saved to: %s\n", path)
+	}
+
+	return exportReportsIfRequested(cmd, func() *report.Report {
+		return report.FromCardAnalysis(cardAnalysis)
+	})
 }
 
 func displayAnalysisHeader(a *analysis.CardAnalysis) {
@@ -459,7 +635,11 @@ func saveAnalysisData(dataDir string, a *analysis.CardAnalysis) error {
 		return fmt.Errorf("failed to sanitize player tag %q: %w", a.PlayerTag, err)
 	}
 
-	if err := storage.WriteJSON(filename, a); err != nil {
+	doc, err := schema.Stamp(a)
+	if err != nil {
+		return fmt.Errorf("failed to encode analysis: %w", err)
+	}
+	if err := storage.WriteJSON(filename, doc); err != nil {
 		return fmt.Errorf("failed to write analysis file: %w", err)
 	}
 
@@ -508,7 +688,7 @@ func playstyleCommand(ctx context.Context, cmd *cli.Command) error {
 		if verbose {
 			printf("\nGenerating deck recommendations...\n")
 		}
-		recommendations, err = analysis.RecommendDecks(playstyleAnalysis, dataDir)
+		recommendations, err = analysis.RecommendDecks(playstyleAnalysis, dataDir, arenaCardFilter(cmd, player.Arena.ID))
 		if err != nil {
 			printf("Warning: Failed to generate deck recommendations: %v\n", err)
 		} else {
@@ -530,7 +710,9 @@ func playstyleCommand(ctx context.Context, cmd *cli.Command) error {
 		}
 	}
 
-	return nil
+	return exportReportsIfRequested(cmd, func() *report.Report {
+		return report.FromPlaystyle(playstyleAnalysis, recommendations)
+	})
 }
 
 func displayPlaystyleAnalysis(p *analysis.PlaystyleAnalysis) {
@@ -646,11 +828,14 @@ func displayDeckRecommendations(r *analysis.DeckRecommendationResult) {
 	}
 }
 
+// playstyleArtifact is the saved playstyle document
+type playstyleArtifact struct {
+	PlaystyleAnalysis   *analysis.PlaystyleAnalysis        `json:"playstyle_analysis"`
+	DeckRecommendations *analysis.DeckRecommendationResult `json:"deck_recommendations,omitempty"`
+}
+
 func savePlaystyleData(dataDir string, p *analysis.PlaystyleAnalysis, r *analysis.DeckRecommendationResult) error {
-	saveData := struct {
-		PlaystyleAnalysis   *analysis.PlaystyleAnalysis        `json:"playstyle_analysis"`
-		DeckRecommendations *analysis.DeckRecommendationResult `json:"deck_recommendations,omitempty"`
-	}{
+	saveData := playstyleArtifact{
 		PlaystyleAnalysis: p,
 	}
 
@@ -658,7 +843,11 @@ func savePlaystyleData(dataDir string, p *analysis.PlaystyleAnalysis, r *analysi
 		saveData.DeckRecommendations = r
 	}
 
-	_, err := saveTaggedJSONArtifact(dataDir, p.PlayerTag, saveData, taggedJSONArtifactOptions{
+	doc, err := schema.Stamp(saveData)
+	if err != nil {
+		return fmt.Errorf("failed to encode playstyle: %w", err)
+	}
+	_, err = saveTaggedJSONArtifact(dataDir, p.PlayerTag, doc, taggedJSONArtifactOptions{
 		subdir:   storage.AnalysisDir,
 		fileStem: playstyleArtifactName,
 	})
@@ -704,6 +893,10 @@ func addCardsCommand() *cli.Command {
 				Usage: "Export card database to CSV",
 			},
 		},
+		Commands: []*cli.Command{
+			addCardRolesCommand(),
+			addCardSyncCommand(),
+		},
 		Action: cardsCommand,
 	}
 }
@@ -713,7 +906,7 @@ func addAnalyzeCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "analyze",
 		Usage: "Analyze player card collection and upgrade priorities",
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			playerTagFlag(true),
 			&cli.BoolFlag{
 				Name:  "include-max-level",
@@ -750,7 +943,29 @@ func addAnalyzeCommand() *cli.Command {
 				Name:  "export-csv",
 				Usage: "Export analysis to CSV",
 			},
-		},
+			&cli.StringFlag{
+				Name:  "export-xlsx",
+				Usage: "Write the analysis to an Excel workbook (summary, rarity breakdown, upgrade priorities, card levels)",
+			},
+			&cli.BoolFlag{
+				Name:  "masteries",
+				Usage: "Show card mastery tasks close to completion and their rewards instead of upgrade priorities",
+			},
+			&cli.Float64Flag{
+				Name:  "mastery-min-progress",
+				Value: defaultMasteryMinProgress,
+				Usage: "Minimum progress percent for a mastery task to be listed (with --masteries)",
+			},
+			&cli.BoolFlag{
+				Name:  "value-ranking",
+				Usage: "Rank each card's next upgrade by best-deck score gained per gold instead of upgrade priorities",
+			},
+			&cli.IntFlag{
+				Name:  "value-decks",
+				Value: whatif.DefaultBudgetDecks,
+				Usage: "Number of best decks to score upgrades against (with --value-ranking)",
+			},
+		}, append(magicItemFlags(), reportExportFlags()...)...),
 		Action: analyzeCommand,
 	}
 }
@@ -760,17 +975,18 @@ func addPlaystyleCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "playstyle",
 		Usage: "Analyze player's playstyle and recommend decks",
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			playerTagFlag(true),
 			&cli.BoolFlag{
 				Name:  "recommend-decks",
 				Usage: "Include deck recommendations based on playstyle",
 			},
+			ignoreArenaFlag(),
 			&cli.BoolFlag{
 				Name:  "save",
 				Usage: "Save analysis to JSON file",
 			},
-		},
+		}, reportExportFlags()...),
 		Action: playstyleCommand,
 	}
 }
@@ -0,0 +1,375 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/klauer/clash-royale-api/go/pkg/meta"
+	"github.com/urfave/cli/v3"
+)
+
+// addMetaCommands adds the meta snapshot and trend commands to the CLI
+func addMetaCommands() *cli.Command {
+	return &cli.Command{
+		Name:  "meta",
+		Usage: "Track card and archetype usage over time with weekly meta snapshots",
+		Commands: []*cli.Command{
+			{
+				Name:  "snapshot",
+				Usage: "Aggregate battle logs into this week's meta snapshot",
+				Flags: []cli.Flag{
+					tagsFlag(&cli.StringSliceFlag{
+						Name:  "clan",
+						Usage: "Clan tag whose members' battle logs are sampled (repeatable)",
+					}),
+					tagsFlag(&cli.StringSliceFlag{
+						Name:  "tag",
+						Usage: "Player tag whose battle log is sampled (repeatable)",
+					}),
+					&cli.StringFlag{
+						Name:  "tags-file",
+						Usage: "File with one player tag per line (blank lines and // comments ignored)",
+					},
+					&cli.StringFlag{
+						Name:  "week",
+						Usage: "ISO week to store the snapshot under, e.g. 2026-W42 (default: current week)",
+					},
+					&cli.StringFlag{
+						Name:  "source",
+						Usage: "Free-form label describing the sampled players",
+					},
+					bulkWorkersFlag(),
+				},
+				Action: metaSnapshotCommand,
+			},
+			{
+				Name:  "scrape",
+				Usage: "Sample top-ladder players' battle logs into this week's meta snapshot",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "location",
+						Value: clashroyale.GlobalLocation,
+						Usage: "Leaderboard location ID (e.g. global or 57000249)",
+					},
+					&cli.IntFlag{
+						Name:  "top",
+						Value: 100,
+						Usage: "Number of top Path of Legends players to sample",
+					},
+					&cli.DurationFlag{
+						Name:  "delay",
+						Usage: "Minimum gap between battle log requests, on top of the client rate limit",
+					},
+					&cli.DurationFlag{
+						Name:  "every",
+						Usage: "Repeat the scrape at this interval until interrupted (0 = run once)",
+					},
+					&cli.StringFlag{
+						Name:  "week",
+						Usage: "ISO week to store the snapshot under, e.g. 2026-W42 (default: current week)",
+					},
+				},
+				Action: metaScrapeCommand,
+			},
+			{
+				Name:  "trends",
+				Usage: "Report rising and falling cards and archetypes over the last N snapshots",
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:  "last",
+						Value: 4,
+						Usage: "Number of most recent snapshots to compare",
+					},
+					&cli.IntFlag{
+						Name:  "top",
+						Value: 10,
+						Usage: "Rising/falling entries to show per section",
+					},
+					&cli.FloatFlag{
+						Name:  "threshold",
+						Value: meta.DefaultTrendOptions().Threshold * 100,
+						Usage: "Usage change in percentage points needed to count as rising or falling",
+					},
+					&cli.IntFlag{
+						Name:  "min-uses",
+						Value: meta.DefaultTrendOptions().MinUses,
+						Usage: "Ignore cards and archetypes never used this many times in a snapshot",
+					},
+					&cli.StringFlag{
+						Name:  "format",
+						Value: batchFormatHuman,
+						Usage: "Output format: human, json",
+					},
+					&cli.StringFlag{
+						Name:  "output",
+						Usage: "Output file path (optional, prints to stdout if not specified)",
+					},
+				},
+				Action: metaTrendsCommand,
+			},
+		},
+	}
+}
+
+func metaSnapshotCommand(ctx context.Context, cmd *cli.Command) error {
+	dataDir := cmd.String("data-dir")
+	verbose := cmd.Bool("verbose")
+
+	week := cmd.String("week")
+	if week == "" {
+		week = meta.WeekOf(time.Now())
+	}
+
+	tags := cmd.StringSlice("tag")
+	if path := cmd.String("tags-file"); path != "" {
+		fileTags, err := readTagsFile(path)
+		if err != nil {
+			return err
+		}
+		tags = append(tags, fileTags...)
+	}
+	clans := cmd.StringSlice("clan")
+	if len(tags) == 0 && len(clans) == 0 {
+		return fmt.Errorf("provide at least one --clan, --tag, or --tags-file")
+	}
+
+	client, err := requireAPIClient(cmd, apiClientOptions{})
+	if err != nil {
+		return err
+	}
+	for _, clan := range clans {
+		members, err := client.GetClanMembersWithContext(ctx, clan)
+		if err != nil {
+			return fmt.Errorf("failed to get members for clan %s: %w", clan, err)
+		}
+		printf("Clan %s: %d members\n", clashroyale.NormalizeTag(clan), len(members.Items))
+		for _, member := range members.Items {
+			tags = append(tags, member.Tag)
+		}
+	}
+	tags = uniqueNormalizedTags(tags)
+
+	builder := meta.NewBuilder(classifyMetaArchetype)
+	opts := clashroyale.BulkOptions{Workers: cmd.Int("workers")}
+	if verbose {
+		opts.OnResult = func(done, total int, tag string, err error) {
+			if err != nil {
+				fprintf(os.Stderr, "[%d/%d] %s: %v\n", done, total, tag, err)
+				return
+			}
+			printf("[%d/%d] %s: fetched\n", done, total, tag)
+		}
+	}
+	results, err := client.GetBattleLogs(ctx, tags, opts)
+	if err != nil {
+		return err
+	}
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+			continue
+		}
+		builder.AddBattleLog(result.Tag, result.Battles)
+	}
+
+	if verbose {
+		printTokenStats(os.Stderr, client)
+	}
+
+	snapshot := builder.Build(week, time.Now().UTC())
+	snapshot.Source = cmd.String("source")
+	if snapshot.Decks == 0 {
+		return fmt.Errorf("no 1v1 battles found in %d battle logs (%d failed)", len(tags), failed)
+	}
+
+	path, err := meta.SaveSnapshot(dataDir, snapshot)
+	if err != nil {
+		return err
+	}
+	printf("Meta snapshot %s: %d players, %d battles, %d decks", snapshot.Week, snapshot.Players, snapshot.Battles, snapshot.Decks)
+	if failed > 0 {
+		printf(" (%d battle logs failed)", failed)
+	}
+	printf("\nSaved to %s\n", path)
+	return nil
+}
+
+func metaScrapeCommand(ctx context.Context, cmd *cli.Command) error {
+	client, err := requireAPIClient(cmd, apiClientOptions{})
+	if err != nil {
+		return err
+	}
+	every := cmd.Duration("every")
+	if every < 0 {
+		return fmt.Errorf("--every must not be negative")
+	}
+
+	for {
+		if err := runMetaScrape(ctx, cmd, client); err != nil {
+			return err
+		}
+		if every == 0 {
+			return nil
+		}
+		printf("Next scrape at %s\n", time.Now().Add(every).Format(time.Kitchen))
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(every):
+		}
+	}
+}
+
+func runMetaScrape(ctx context.Context, cmd *cli.Command, client *clashroyale.Client) error {
+	verbose := cmd.Bool("verbose")
+	week := cmd.String("week")
+	if week == "" {
+		week = meta.WeekOf(time.Now())
+	}
+
+	opts := meta.ScrapeOptions{
+		Location: cmd.String("location"),
+		Top:      cmd.Int("top"),
+		Delay:    cmd.Duration("delay"),
+	}
+	if verbose {
+		opts.OnPlayer = func(index, total int, tag string, battles int, err error) {
+			if err != nil {
+				fprintf(os.Stderr, "[%d/%d] %s: %v\n", index, total, tag, err)
+				return
+			}
+			printf("[%d/%d] %s: %d ladder battles\n", index, total, tag, battles)
+		}
+	}
+
+	builder := meta.NewBuilder(classifyMetaArchetype)
+	result, err := meta.ScrapeLadder(ctx, client, builder, opts)
+	if err != nil {
+		return err
+	}
+
+	if verbose {
+		printTokenStats(os.Stderr, client)
+	}
+
+	snapshot := builder.Build(week, time.Now().UTC())
+	snapshot.Source = fmt.Sprintf("ladder:%s:top%d", result.Location, result.Players)
+	if snapshot.Decks == 0 {
+		return fmt.Errorf("no ladder battles found in %d battle logs (%d failed)", result.Players, result.Failed)
+	}
+
+	path, err := meta.SaveSnapshot(cmd.String("data-dir"), snapshot)
+	if err != nil {
+		return err
+	}
+	printf("Meta snapshot %s from %s top %d: %d battles, %d decks", snapshot.Week, result.Location, result.Players, snapshot.Battles, snapshot.Decks)
+	if result.Failed > 0 {
+		printf(" (%d battle logs failed)", result.Failed)
+	}
+	printf("\nSaved to %s\n", path)
+	return nil
+}
+
+// classifyMetaArchetype names a deck's primary archetype for meta snapshots.
+func classifyMetaArchetype(cards []string) string {
+	return string(evaluation.DetectArchetype(convertToCardCandidates(cards)).Primary)
+}
+
+// uniqueNormalizedTags normalizes tags and drops blanks and duplicates, keeping order.
+func uniqueNormalizedTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	unique := make([]string, 0, len(tags))
+	for _, raw := range tags {
+		tag := clashroyale.NormalizeTag(raw)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		unique = append(unique, tag)
+	}
+	return unique
+}
+
+func metaTrendsCommand(ctx context.Context, cmd *cli.Command) error {
+	_ = ctx
+	format := strings.ToLower(strings.TrimSpace(cmd.String("format")))
+	if format != batchFormatHuman && format != batchFormatJSON {
+		return fmt.Errorf("unknown format: %s (supported: human, json)", format)
+	}
+	last := cmd.Int("last")
+	if last < 2 {
+		return fmt.Errorf("--last must be at least 2")
+	}
+
+	snapshots, err := meta.LoadRecent(cmd.String("data-dir"), last)
+	if err != nil {
+		return err
+	}
+	if len(snapshots) < 2 {
+		return fmt.Errorf("need at least 2 meta snapshots to compare, found %d (run `cr-api meta snapshot` weekly)", len(snapshots))
+	}
+
+	report := meta.ComputeTrends(snapshots, meta.TrendOptions{
+		Threshold: cmd.Float("threshold") / 100,
+		MinUses:   cmd.Int("min-uses"),
+	})
+
+	var formatted string
+	if format == batchFormatJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		formatted = string(data) + "\n"
+	} else {
+		formatted = formatMetaTrendsHuman(report, cmd.Int("top"))
+	}
+
+	return writeTextOutput(formatted, cmd.String("output"), textOutputOptions{
+		saveMessage: "Meta trends saved to",
+		verboseOnly: true,
+		verbose:     cmd.Bool("verbose"),
+	})
+}
+
+func formatMetaTrendsHuman(report meta.TrendReport, top int) string {
+	var buf bytes.Buffer
+
+	fprintf(&buf, "\nMeta Trends: %s → %s (%d snapshots)\n", report.Weeks[0], report.Weeks[len(report.Weeks)-1], len(report.Weeks))
+	fprintf(&buf, "============\n")
+
+	writeMetaTrendSection(&buf, "Rising Cards", meta.Rising(report.Cards, top))
+	writeMetaTrendSection(&buf, "Falling Cards", meta.Falling(report.Cards, top))
+	writeMetaTrendSection(&buf, "Rising Archetypes", meta.Rising(report.Archetypes, top))
+	writeMetaTrendSection(&buf, "Falling Archetypes", meta.Falling(report.Archetypes, top))
+	return buf.String()
+}
+
+func writeMetaTrendSection(buf *bytes.Buffer, title string, trends []meta.Trend) {
+	fprintf(buf, "\n%s\n", title)
+	if len(trends) == 0 {
+		fprintf(buf, "  (none)\n")
+		return
+	}
+	w := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+	fprintf(w, "  Name\tUsage\tChange\tWin Rate\tWR Change\n")
+	for _, trend := range trends {
+		fprintf(w, "  %s\t%.1f%%\t%+.1f pts\t%.1f%%\t%+.1f pts\n",
+			trend.Name,
+			trend.LastUsage*100,
+			trend.UsageDelta*100,
+			trend.LastWinRate*100,
+			trend.WinRateDelta*100,
+		)
+	}
+	flushWriter(w)
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/meta"
+)
+
+func TestUniqueNormalizedTags(t *testing.T) {
+	got := uniqueNormalizedTags([]string{"abc", "#ABC", " ", "#def"})
+	if len(got) != 2 || got[0] != "#ABC" || got[1] != "#DEF" {
+		t.Errorf("uniqueNormalizedTags() = %v", got)
+	}
+}
+
+func TestFormatMetaTrendsHuman(t *testing.T) {
+	report := meta.ComputeTrends([]*meta.Snapshot{
+		{Week: "2026-W41", Cards: []meta.UsageStat{{Name: "Hog Rider", Uses: 20, UsageRate: 0.20}, {Name: "Golem", Uses: 10, UsageRate: 0.10}}},
+		{Week: "2026-W42", Cards: []meta.UsageStat{{Name: "Hog Rider", Uses: 30, UsageRate: 0.30}}},
+	}, meta.DefaultTrendOptions())
+
+	out := formatMetaTrendsHuman(report, 5)
+	for _, want := range []string{"2026-W41 → 2026-W42", "Rising Cards", "Hog Rider", "+10.0 pts", "Falling Cards", "Golem", "Rising Archetypes\n  (none)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/metrics"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/genetic"
+	"github.com/urfave/cli/v3"
+)
+
+// metricsAddrFlag lets long-running commands expose Prometheus metrics
+func metricsAddrFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "metrics-addr",
+		Usage: "Serve Prometheus metrics at http://<addr>/metrics while running (e.g. 127.0.0.1:9090)",
+	}
+}
+
+// startMetricsServer serves /metrics on --metrics-addr until ctx is done. It
+// binds before returning so a busy port fails the command up front.
+func startMetricsServer(ctx context.Context, cmd *cli.Command) error {
+	addr := cmd.String("metrics-addr")
+	if addr == "" {
+		return nil
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to serve metrics: %w", err)
+	}
+	go func() {
+		if err := metrics.Serve(ctx, ln); err != nil {
+			fprintf(os.Stderr, "Warning: metrics server stopped: %v\n", err)
+		}
+	}()
+	fprintf(os.Stderr, "Serving metrics on http://%s/metrics\n", ln.Addr())
+	return nil
+}
+
+// recordAPIRequest is the request observer attached to every API client.
+func recordAPIRequest(status int, duration time.Duration) {
+	label := "error"
+	if status > 0 {
+		label = strconv.Itoa(status)
+	}
+	metrics.APIRequests.Inc(label)
+	if status == http.StatusTooManyRequests {
+		metrics.APIRateLimited.Inc()
+	}
+	metrics.APIRequestSeconds.Observe(duration.Seconds())
+}
+
+// countGAGenerations wraps a GA progress callback so every generation is
+// counted, whether or not progress is printed.
+func countGAGenerations(next func(genetic.GeneticProgress)) func(genetic.GeneticProgress) {
+	return func(progress genetic.GeneticProgress) {
+		metrics.GAGenerations.Inc()
+		if next != nil {
+			next(progress)
+		}
+	}
+}
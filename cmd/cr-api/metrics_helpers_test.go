@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/metrics"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/genetic"
+	"github.com/urfave/cli/v3"
+)
+
+func TestRecordAPIRequest(t *testing.T) {
+	before200, beforeErr := metrics.APIRequests.Value("200"), metrics.APIRequests.Value("error")
+	beforeLimited, beforeObserved := metrics.APIRateLimited.Value(), metrics.APIRequestSeconds.Count()
+
+	recordAPIRequest(200, 50*time.Millisecond)
+	recordAPIRequest(429, time.Millisecond)
+	recordAPIRequest(0, time.Second)
+
+	if got := metrics.APIRequests.Value("200") - before200; got != 1 {
+		t.Errorf("200 requests = %d", got)
+	}
+	if got := metrics.APIRequests.Value("error") - beforeErr; got != 1 {
+		t.Errorf("failed requests = %d", got)
+	}
+	if got := metrics.APIRateLimited.Value() - beforeLimited; got != 1 {
+		t.Errorf("rate-limited = %d", got)
+	}
+	if got := metrics.APIRequestSeconds.Count() - beforeObserved; got != 3 {
+		t.Errorf("latency observations = %d", got)
+	}
+}
+
+func TestCountGAGenerations(t *testing.T) {
+	before := metrics.GAGenerations.Value()
+	var printed int
+	progress := countGAGenerations(func(genetic.GeneticProgress) { printed++ })
+	progress(genetic.GeneticProgress{Generation: 1})
+	progress(genetic.GeneticProgress{Generation: 2})
+	countGAGenerations(nil)(genetic.GeneticProgress{Generation: 3})
+
+	if got := metrics.GAGenerations.Value() - before; got != 3 || printed != 2 {
+		t.Errorf("counted %d generations, forwarded %d", got, printed)
+	}
+}
+
+func TestStartMetricsServer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Reserve a free port, then hand it to the command
+	probe, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := probe.Addr().String()
+	closeFile(probe)
+
+	cmd := &cli.Command{
+		Name:  "probe",
+		Flags: []cli.Flag{metricsAddrFlag()},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return startMetricsServer(ctx, cmd)
+		},
+	}
+	if err := cmd.Run(ctx, []string{"probe", "--metrics-addr", addr}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := http.Get("http://" + addr + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeFile(resp.Body)
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), "cr_api_fuzz_decks_evaluated_total") {
+		t.Errorf("metrics body:\n%s", body)
+	}
+
+	if err := cmd.Run(ctx, []string{"probe", "--metrics-addr", addr}); err == nil {
+		t.Error("a busy port should fail the command")
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/klauer/clash-royale-api/go/internal/notify"
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	notifyDiscordEnvVar = "CR_API_DISCORD_WEBHOOK"
+	notifyWebhookEnvVar = "CR_API_NOTIFY_WEBHOOK"
+)
+
+// notifyFlags returns the webhook targets shared by commands that post events
+func notifyFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:    "notify-discord",
+			Usage:   "Discord webhook URL to post events to (repeatable)",
+			Sources: cli.EnvVars(notifyDiscordEnvVar),
+		},
+		&cli.StringSliceFlag{
+			Name:    "notify-webhook",
+			Usage:   "HTTP URL that receives events as JSON POSTs (repeatable)",
+			Sources: cli.EnvVars(notifyWebhookEnvVar),
+		},
+	}
+}
+
+// notifierFromCommand returns the configured notifier, or nil when no
+// target is set.
+func notifierFromCommand(cmd *cli.Command) notify.Notifier {
+	return notify.New(cmd.StringSlice("notify-discord"), cmd.StringSlice("notify-webhook"))
+}
+
+// sendNotification delivers an event if a notifier is configured. Delivery
+// failures are warnings; they never fail the command.
+func sendNotification(ctx context.Context, notifier notify.Notifier, event notify.Event) {
+	if notifier == nil {
+		return
+	}
+	if err := notifier.Notify(ctx, event); err != nil {
+		fprintf(os.Stderr, "Warning: failed to send notification: %v\n", err)
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/notify"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/fuzzstorage"
+)
+
+type recordingNotifier struct {
+	events []notify.Event
+	err    error
+}
+
+func (r *recordingNotifier) Notify(_ context.Context, event notify.Event) error {
+	r.events = append(r.events, event)
+	return r.err
+}
+
+func TestWatchNotifiesLossStreaks(t *testing.T) {
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	notifier := &recordingNotifier{}
+	var log []clashroyale.Battle
+	w := &battleWatcher{
+		tag:         "#ABC",
+		out:         &strings.Builder{},
+		notifier:    notifier,
+		streakAlert: 3,
+		fetch:       func(context.Context) ([]clashroyale.Battle, error) { return log, nil },
+	}
+	ctx := context.Background()
+	if err := w.start(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// A win resets the streak; three straight losses to beatdown follow
+	results := [][2]int{{0, 1}, {0, 1}, {1, 0}, {0, 1}, {0, 2}, {0, 3}}
+	for i, crowns := range results {
+		log = append([]clashroyale.Battle{watchBattle(base.Add(time.Duration(i)*time.Minute), "#OPP", crowns[0], crowns[1], 0)}, log...)
+		if err := w.poll(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(notifier.events) != 1 {
+		t.Fatalf("sent %d notifications, want 1: %+v", len(notifier.events), notifier.events)
+	}
+	event := notifier.events[0]
+	if event.Kind != notify.KindLossStreak || event.Message != "#ABC lost 3 in a row to beatdown" {
+		t.Errorf("event = %+v", event)
+	}
+}
+
+func TestSendNotificationWarnsOnFailure(t *testing.T) {
+	sendNotification(context.Background(), nil, notify.Event{})
+	failing := &recordingNotifier{err: errors.New("boom")}
+	sendNotification(context.Background(), failing, notify.Event{Title: "x"})
+	if len(failing.events) != 1 {
+		t.Errorf("events = %v", failing.events)
+	}
+}
+
+func TestNotifyFuzzPersonalBest(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	storage, err := fuzzstorage.NewStorage("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := storage.SaveTopDecks([]fuzzstorage.DeckEntry{{Cards: []string{"Golem"}, OverallScore: 8.0, EvaluatedAt: time.Now()}}); err != nil {
+		t.Fatal(err)
+	}
+	closeFile(storage)
+
+	notifier := &recordingNotifier{}
+	notifyFuzzPersonalBest(context.Background(), notifier, []FuzzingResult{{Deck: []string{"Knight"}, OverallScore: 7.5}}, "#ABC")
+	if len(notifier.events) != 0 {
+		t.Fatalf("a lower score should not notify: %+v", notifier.events)
+	}
+
+	results := []FuzzingResult{
+		{Deck: []string{"Knight"}, OverallScore: 7.5},
+		{Deck: []string{"Hog Rider", "The Log"}, OverallScore: 8.9, Archetype: "cycle"},
+	}
+	notifyFuzzPersonalBest(context.Background(), notifier, results, "#ABC")
+	if len(notifier.events) != 1 {
+		t.Fatalf("events = %+v", notifier.events)
+	}
+	event := notifier.events[0]
+	if event.Title != "New personal best deck found (score 8.9)" || !strings.Contains(event.Message, "Hog Rider, The Log") {
+		t.Errorf("event = %+v", event)
+	}
+}
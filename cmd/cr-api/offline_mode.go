@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/apicache"
+	"github.com/urfave/cli/v3"
+)
+
+const offlineFlagName = "offline"
+
+// apiOfflineCache is set by the global --offline flag. Clients created by
+// the CLI then serve every request from it and never reach the API.
+var apiOfflineCache *apicache.Offline
+
+// requireOnline rejects --offline for commands that only make sense with
+// live data.
+func requireOnline(cmd *cli.Command, name string) error {
+	if cmd.Bool(offlineFlagName) {
+		return fmt.Errorf("%s needs live API data and cannot run with --offline", name)
+	}
+	return nil
+}
+
+// reportOfflineDataAge notes on stderr how old the cached data behind an
+// offline run was, so stale output is never mistaken for live results.
+func reportOfflineDataAge(_ context.Context, _ *cli.Command) error {
+	if apiOfflineCache == nil {
+		return nil
+	}
+	if note := offlineDataAgeNote(apiOfflineCache, time.Now()); note != "" {
+		fprintf(os.Stderr, "%s\n", note)
+	}
+	return nil
+}
+
+func offlineDataAgeNote(cache *apicache.Offline, now time.Time) string {
+	served, oldest, newest := cache.Served()
+	if served == 0 {
+		return ""
+	}
+	responses := "response"
+	if served != 1 {
+		responses += "s"
+	}
+	note := fmt.Sprintf("Offline: %d cached %s, data from %s (%s old)",
+		served, responses, oldest.Local().Format("2006-01-02 15:04"), formatDataAge(now.Sub(oldest)))
+	if newest.Sub(oldest) >= time.Minute {
+		note += fmt.Sprintf(" to %s (%s old)", newest.Local().Format("2006-01-02 15:04"), formatDataAge(now.Sub(newest)))
+	}
+	return note
+}
+
+// formatDataAge renders an age in the largest two units that matter
+func formatDataAge(age time.Duration) string {
+	switch {
+	case age < time.Minute:
+		return "<1m"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh %dm", int(age.Hours()), int(age.Minutes())%60)
+	default:
+		days := int(age.Hours()) / 24
+		return fmt.Sprintf("%dd %dh", days, int(age.Hours())%24)
+	}
+}
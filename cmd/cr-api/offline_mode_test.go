@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/apicache"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/urfave/cli/v3"
+)
+
+func TestOfflineClientServesCacheWithoutToken(t *testing.T) {
+	dataDir := t.TempDir()
+	store := apicache.New(dataDir, time.Hour)
+	if err := store.Put(clashroyale.PlayerEndpoint("#ABC"), []byte(`{"tag":"#ABC","name":"Cached"}`)); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(apiTokenEnvVar, "")
+	t.Cleanup(func() { apiOfflineCache, apiResponseCache = nil, nil })
+
+	root := &cli.Command{
+		Name: "cr-api",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "data-dir"},
+			&cli.BoolFlag{Name: offlineFlagName},
+			&cli.DurationFlag{Name: "cache-max-age", Value: time.Hour},
+		},
+		Before: configureAPICache,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			client, err := requireAPIClient(cmd, apiClientOptions{})
+			if err != nil {
+				return err
+			}
+			player, err := client.GetPlayerWithContext(ctx, "#ABC")
+			if err != nil || player.Name != "Cached" {
+				t.Errorf("GetPlayer() = %+v, %v; want the cached player", player, err)
+			}
+			if _, err := client.GetPlayerWithContext(ctx, "#MISSING"); !errors.Is(err, clashroyale.ErrOffline) {
+				t.Errorf("GetPlayer(uncached) error = %v, want ErrOffline", err)
+			}
+			return nil
+		},
+	}
+	if err := root.Run(context.Background(), []string{"cr-api", "--offline", "--data-dir", dataDir}); err != nil {
+		t.Fatal(err)
+	}
+
+	note := offlineDataAgeNote(apiOfflineCache, time.Now())
+	if !strings.HasPrefix(note, "Offline: 1 cached response") || !strings.Contains(note, "old)") {
+		t.Errorf("note = %q", note)
+	}
+}
+
+func TestOfflineDataAgeNote(t *testing.T) {
+	if note := offlineDataAgeNote(apicache.NewOffline(t.TempDir()), time.Now()); note != "" {
+		t.Errorf("note with nothing served = %q, want empty", note)
+	}
+	tests := []struct {
+		age  time.Duration
+		want string
+	}{
+		{age: 30 * time.Second, want: "<1m"},
+		{age: 45 * time.Minute, want: "45m"},
+		{age: 5*time.Hour + 7*time.Minute, want: "5h 7m"},
+		{age: 50 * time.Hour, want: "2d 2h"},
+	}
+	for _, tt := range tests {
+		if got := formatDataAge(tt.age); got != tt.want {
+			t.Errorf("formatDataAge(%v) = %q, want %q", tt.age, got, tt.want)
+		}
+	}
+}
+
+func TestRequireOnline(t *testing.T) {
+	root := &cli.Command{
+		Name:  "cr-api",
+		Flags: []cli.Flag{&cli.BoolFlag{Name: offlineFlagName}},
+		Commands: []*cli.Command{{
+			Name:   "watch",
+			Action: func(_ context.Context, cmd *cli.Command) error { return requireOnline(cmd, "watch") },
+		}},
+	}
+	if err := root.Run(context.Background(), []string{"cr-api", "--offline", "watch"}); err == nil || !strings.Contains(err.Error(), "--offline") {
+		t.Errorf("watch --offline error = %v", err)
+	}
+}
@@ -1,6 +1,12 @@
 package main
 
-import "github.com/urfave/cli/v3"
+import (
+	"context"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/urfave/cli/v3"
+)
 
 const defaultPlayerTagFlagUsage = "Player tag (without #)"
 
@@ -13,10 +19,59 @@ func playerTagFlagWithUsage(required bool, usage string) *cli.StringFlag {
 		usage = defaultPlayerTagFlagUsage
 	}
 
-	return &cli.StringFlag{
+	return tagFlag(&cli.StringFlag{
 		Name:     "tag",
 		Aliases:  []string{"p"},
 		Usage:    usage,
 		Required: required,
+	})
+}
+
+// clanTagFlag is the required --tag flag of the clan commands
+func clanTagFlag() *cli.StringFlag {
+	return tagFlag(&cli.StringFlag{
+		Name:     "tag",
+		Aliases:  []string{"c"},
+		Usage:    "Clan tag (without #)",
+		Required: true,
+	})
+}
+
+// tagFlag makes flag check its value with clashroyale.ParseTag, so a bad
+// tag fails before any request with the suggested fix, and rewrites a good
+// one to its canonical #TAG form.
+func tagFlag(flag *cli.StringFlag) *cli.StringFlag {
+	flag.Validator = validateTagFlag
+	flag.Action = func(_ context.Context, cmd *cli.Command, value string) error {
+		tag, err := clashroyale.ParseTag(value)
+		if err != nil || tag == value {
+			return nil
+		}
+		return cmd.Set(flag.Name, tag)
+	}
+	return flag
+}
+
+// tagsFlag makes a repeatable tag flag check every value. Commands still
+// normalize the values they read, e.g. with uniqueNormalizedTags.
+func tagsFlag(flag *cli.StringSliceFlag) *cli.StringSliceFlag {
+	flag.Validator = func(values []string) error {
+		for _, value := range values {
+			if err := validateTagFlag(value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return flag
+}
+
+// validateTagFlag accepts an empty value, which commands report as a
+// missing tag themselves
+func validateTagFlag(value string) error {
+	if strings.TrimSpace(value) == "" {
+		return nil
 	}
+	_, err := clashroyale.ParseTag(value)
+	return err
 }
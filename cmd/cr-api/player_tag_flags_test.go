@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+)
+
+func TestTagFlagNormalizesAndSuggests(t *testing.T) {
+	run := func(args ...string) (string, error) {
+		var got string
+		cmd := &cli.Command{
+			Name:  "probe",
+			Flags: []cli.Flag{playerTagFlag(true)},
+			Action: func(_ context.Context, cmd *cli.Command) error {
+				got = cmd.String("tag")
+				return nil
+			},
+		}
+		err := cmd.Run(context.Background(), append([]string{"probe"}, args...))
+		return got, err
+	}
+
+	got, err := run("--tag", " 2pp0 ")
+	if err != nil || got != "#2PP0" {
+		t.Errorf("--tag ' 2pp0 ' = %q, %v; want #2PP0", got, err)
+	}
+	if _, err := run("-p", "#2PPO"); err == nil || !strings.Contains(err.Error(), "did you mean #2PP0?") {
+		t.Errorf("--tag #2PPO error = %v, want a suggestion", err)
+	}
+}
+
+func TestTagsFlagValidatesEveryValue(t *testing.T) {
+	cmd := &cli.Command{
+		Name:   "probe",
+		Flags:  []cli.Flag{tagsFlag(&cli.StringSliceFlag{Name: "tags"})},
+		Action: func(context.Context, *cli.Command) error { return nil },
+	}
+	err := cmd.Run(context.Background(), []string{"probe", "--tags", "2PP,9YL", "--tags", "ABC"})
+	if err == nil || !strings.Contains(err.Error(), `"ABC"`) {
+		t.Errorf("error = %v, want the bad tag named", err)
+	}
+}
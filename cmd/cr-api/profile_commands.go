@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/klauer/clash-royale-api/go/internal/publicprofile"
+	"github.com/klauer/clash-royale-api/go/internal/storage"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/leaderboard"
+	"github.com/urfave/cli/v3"
+)
+
+// addProfileCommands adds the public profile opt-in commands to the CLI
+func addProfileCommands() *cli.Command {
+	return &cli.Command{
+		Name:  "profile",
+		Usage: "Manage opted-in public profiles served by `serve --public`",
+		Commands: []*cli.Command{
+			{
+				Name:  "publish",
+				Usage: "Publish (or refresh) a sanitized public profile snapshot for a player",
+				Flags: []cli.Flag{
+					playerTagFlag(true),
+					&cli.IntFlag{
+						Name:  "top-decks",
+						Value: 5,
+						Usage: "Number of best decks from the player's leaderboard to publish",
+					},
+				},
+				Action: profilePublishCommand,
+			},
+			{
+				Name:   "unpublish",
+				Usage:  "Remove a player's public profile (opt out)",
+				Flags:  []cli.Flag{playerTagFlag(true)},
+				Action: profileUnpublishCommand,
+			},
+			{
+				Name:   "list",
+				Usage:  "List players with published public profiles",
+				Action: profileListCommand,
+			},
+		},
+	}
+}
+
+func profilePublishCommand(ctx context.Context, cmd *cli.Command) error {
+	tag := cmd.String("tag")
+	topDecks := cmd.Int("top-decks")
+	dataDir := cmd.String("data-dir")
+	verbose := cmd.Bool("verbose")
+
+	client, err := requireAPIClient(cmd, apiClientOptions{})
+	if err != nil {
+		return err
+	}
+
+	player, err := client.GetPlayerWithContext(ctx, tag)
+	if err != nil {
+		return fmt.Errorf("failed to get player: %w", err)
+	}
+
+	var battles []clashroyale.Battle
+	if battleLog, err := client.GetPlayerBattleLogWithContext(ctx, tag); err != nil {
+		fprintf(os.Stderr, "Warning: failed to fetch battle log for trophy chart: %v\n", err)
+	} else {
+		battles = *battleLog
+	}
+
+	var allCards []clashroyale.Card
+	var cached clashroyale.CardList
+	if cardsPath := storage.NewPathBuilder(dataDir).GetStaticCardsPath(); storage.FileExists(cardsPath) {
+		if err := storage.ReadJSON(cardsPath, &cached); err == nil {
+			allCards = cached.Items
+		}
+	}
+
+	previous, err := publicprofile.Load(dataDir, tag)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to load existing public profile: %w", err)
+	}
+
+	profile, err := publicprofile.Build(publicprofile.BuildInput{
+		Player:    player,
+		Battles:   battles,
+		BestDecks: loadPublicBestDecks(tag, topDecks, verbose),
+		AllCards:  allCards,
+		Previous:  previous,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build public profile: %w", err)
+	}
+
+	path, err := publicprofile.Save(dataDir, profile)
+	if err != nil {
+		return err
+	}
+
+	printf("Published public profile for %s (%s)\n", profile.Name, profile.Tag)
+	printf("Snapshot: %s\n", path)
+	printf("Serve it with: cr-api serve --public (GET /public/profiles/%s)\n", profile.Tag[1:])
+	return nil
+}
+
+// loadPublicBestDecks returns the top leaderboard decks for a player, or nil
+// when no leaderboard exists yet.
+func loadPublicBestDecks(tag string, limit int, verbose bool) []publicprofile.Deck {
+	if limit <= 0 {
+		return nil
+	}
+
+	store, err := leaderboard.NewStorage(tag)
+	if err != nil {
+		if verbose {
+			fprintf(os.Stderr, "Warning: leaderboard unavailable: %v\n", err)
+		}
+		return nil
+	}
+	defer closeFile(store)
+
+	entries, err := store.GetTopN(limit)
+	if err != nil {
+		if verbose {
+			fprintf(os.Stderr, "Warning: failed to read leaderboard: %v\n", err)
+		}
+		return nil
+	}
+
+	decks := make([]publicprofile.Deck, 0, len(entries))
+	for _, entry := range entries {
+		decks = append(decks, publicprofile.Deck{
+			Cards:        entry.Cards,
+			OverallScore: entry.OverallScore,
+			Archetype:    entry.Archetype,
+			AvgElixir:    entry.AvgElixir,
+		})
+	}
+	return decks
+}
+
+func profileUnpublishCommand(ctx context.Context, cmd *cli.Command) error {
+	_ = ctx
+	tag := cmd.String("tag")
+	if err := publicprofile.Remove(cmd.String("data-dir"), tag); err != nil {
+		return fmt.Errorf("failed to remove public profile: %w", err)
+	}
+	printf("Public profile for %s removed\n", tag)
+	return nil
+}
+
+func profileListCommand(ctx context.Context, cmd *cli.Command) error {
+	_ = ctx
+	tags, err := publicprofile.ListTags(cmd.String("data-dir"))
+	if err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		printf("No public profiles published.\n")
+		return nil
+	}
+	for _, tag := range tags {
+		printf("%s\n", tag)
+	}
+	return nil
+}
@@ -0,0 +1,382 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	redactedValue = "[REDACTED]"
+	// maxCapturedOutput bounds the command output kept in a bundle; the tail
+	// is kept since failures are usually reported last.
+	maxCapturedOutput = 2 << 20
+)
+
+// bugReportEnvPrefixes selects the environment variables copied into a bundle.
+var bugReportEnvPrefixes = []string{"CLASH_ROYALE_", "CR_API_", "DATA_DIR", "DEFAULT_PLAYER_TAG", "REQUEST_DELAY", "MAX_RETRIES", "CSV_DIR", "COMBAT_STATS_", "UNLOCKED_EVOLUTIONS", "EVOLUTION_", "FUZZ_", "API_BASE_URL", "EXPORT_FORMAT"}
+
+// sensitiveNameMarkers flag environment variables and flags whose values are redacted.
+var sensitiveNameMarkers = []string{"TOKEN", "SECRET", "PASSWORD", "KEY", "AUTH"}
+
+// sensitiveFlags take a secret as their value.
+var sensitiveFlags = map[string]bool{"--api-token": true, "-t": true, "--auth-token": true}
+
+// bugReport is report.json inside the bundle.
+type bugReport struct {
+	CreatedAt   time.Time         `json:"created_at"`
+	Version     string            `json:"version"`
+	Commit      string            `json:"commit"`
+	BuildTime   string            `json:"build_time"`
+	GoVersion   string            `json:"go_version"`
+	OS          string            `json:"os"`
+	Arch        string            `json:"arch"`
+	CPUs        int               `json:"cpus"`
+	CommandLine []string          `json:"command_line,omitempty"`
+	Rerun       *bugReportRerun   `json:"rerun,omitempty"`
+	Config      map[string]string `json:"config"`
+	Environment map[string]string `json:"environment"`
+	DataDir     bugReportDataDir  `json:"data_dir"`
+	Notes       string            `json:"notes,omitempty"`
+}
+
+// bugReportRerun records the result of re-running the failing command.
+type bugReportRerun struct {
+	ExitCode  int     `json:"exit_code"`
+	Error     string  `json:"error,omitempty"`
+	Duration  string  `json:"duration"`
+	Seconds   float64 `json:"seconds"`
+	Truncated bool    `json:"output_truncated,omitempty"`
+}
+
+// bugReportDataDir summarizes the data directory without including its contents.
+type bugReportDataDir struct {
+	Path    string         `json:"path"`
+	Exists  bool           `json:"exists"`
+	Entries map[string]int `json:"entries,omitempty"` // Top-level entry -> file count
+}
+
+// addReportBugCommand adds the bug-report bundle command
+func addReportBugCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "report-bug",
+		Usage:     "Bundle version info, redacted config, and a re-run of the failing command into a zip for issue reports",
+		ArgsUsage: "[-- <failing cr-api arguments...>]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "Bundle path (default: cr-api-bug-<timestamp>.zip)",
+			},
+			&cli.BoolFlag{
+				Name:  "no-run",
+				Usage: "Record the failing command line without re-running it",
+			},
+			&cli.DurationFlag{
+				Name:  "timeout",
+				Value: 5 * time.Minute,
+				Usage: "Maximum time to let the failing command run",
+			},
+			&cli.StringFlag{
+				Name:  "cassette",
+				Usage: "Recorded API cassette to include in the bundle",
+			},
+			&cli.StringFlag{
+				Name:  "notes",
+				Usage: "Short description of what went wrong",
+			},
+		},
+		Action: reportBugCommand,
+	}
+}
+
+func reportBugCommand(ctx context.Context, cmd *cli.Command) error {
+	args := cmd.Args().Slice()
+	if len(args) > 0 && (args[0] == "cr-api" || filepath.Base(args[0]) == "cr-api") {
+		args = args[1:]
+	}
+	secrets := collectSecrets(cmd.String("api-token"))
+
+	report := bugReport{
+		CreatedAt:   time.Now().UTC(),
+		Version:     version,
+		Commit:      commit,
+		BuildTime:   buildTime,
+		GoVersion:   runtime.Version(),
+		OS:          runtime.GOOS,
+		Arch:        runtime.GOARCH,
+		CPUs:        runtime.NumCPU(),
+		CommandLine: redactArgs(args),
+		Config: map[string]string{
+			"data-dir":      cmd.String("data-dir"),
+			"cache-max-age": cmd.Duration("cache-max-age").String(),
+			"storage":       cmd.String("storage"),
+			"verbose":       fmt.Sprint(cmd.Bool("verbose")),
+			"api-token":     presence(cmd.String("api-token")),
+			"api-base":      cmd.String("api-base"),
+		},
+		Environment: redactedEnvironment(os.Environ()),
+		DataDir:     summarizeDataDir(cmd.String("data-dir")),
+		Notes:       cmd.String("notes"),
+	}
+
+	var output []byte
+	if len(args) > 0 && !cmd.Bool("no-run") {
+		printf("Re-running: cr-api %s\n", strings.Join(report.CommandLine, " "))
+		var rerun bugReportRerun
+		output, rerun = rerunForBugReport(ctx, args, cmd.Duration("timeout"))
+		report.Rerun = &rerun
+		printf("Exit code %d after %s\n", rerun.ExitCode, rerun.Duration)
+	}
+
+	files := map[string][]byte{}
+	reportJSON, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	files["report.json"] = reportJSON
+	if output != nil {
+		files["output.log"] = redactSecrets(output, secrets)
+	}
+	if path := cmd.String("cassette"); path != "" {
+		cassette, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read cassette: %w", err)
+		}
+		files[filepath.Join("cassette", filepath.Base(path))] = redactSecrets(cassette, secrets)
+	}
+
+	bundlePath := cmd.String("output")
+	if bundlePath == "" {
+		bundlePath = fmt.Sprintf("cr-api-bug-%s.zip", report.CreatedAt.Format(artifactTimestampLayout))
+	}
+	if err := writeBugBundle(bundlePath, files); err != nil {
+		return err
+	}
+
+	printf("Bug report written to %s\n", bundlePath)
+	printf("Review it before attaching to an issue; tokens are redacted but command output is included as-is.\n")
+	return nil
+}
+
+// rerunForBugReport runs cr-api again with the given arguments and captures its
+// combined output.
+func rerunForBugReport(ctx context.Context, args []string, timeout time.Duration) ([]byte, bugReportRerun) {
+	var rerun bugReportRerun
+	executable, err := os.Executable()
+	if err != nil {
+		rerun.ExitCode = -1
+		rerun.Error = fmt.Sprintf("cannot locate cr-api executable: %v", err)
+		return nil, rerun
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	output := &tailBuffer{limit: maxCapturedOutput}
+	child := exec.CommandContext(ctx, executable, args...)
+	child.Stdout = output
+	child.Stderr = output
+
+	start := time.Now()
+	err = child.Run()
+	elapsed := time.Since(start)
+
+	rerun.Duration = elapsed.Round(time.Millisecond).String()
+	rerun.Seconds = elapsed.Seconds()
+	rerun.Truncated = output.truncated
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		rerun.ExitCode = exitErr.ExitCode()
+	case err != nil:
+		rerun.ExitCode = -1
+		rerun.Error = err.Error()
+	}
+	if ctx.Err() != nil {
+		rerun.Error = fmt.Sprintf("stopped after %s: %v", timeout, ctx.Err())
+	}
+	return output.Bytes(), rerun
+}
+
+// tailBuffer keeps the last limit bytes written to it.
+type tailBuffer struct {
+	bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	n, err := b.Buffer.Write(p)
+	if over := b.Len() - b.limit; over > 0 {
+		b.Next(over)
+		b.truncated = true
+	}
+	return n, err
+}
+
+// redactArgs hides the values of secret-bearing flags.
+func redactArgs(args []string) []string {
+	redacted := make([]string, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name, _, hasValue := strings.Cut(arg, "=")
+		switch {
+		case hasValue && sensitiveFlags[name]:
+			redacted[i] = name + "=" + redactedValue
+		case sensitiveFlags[arg] && i+1 < len(args):
+			redacted[i] = arg
+			redacted[i+1] = redactedValue
+			i++
+		default:
+			redacted[i] = arg
+		}
+	}
+	return redacted
+}
+
+// redactedEnvironment keeps cr-api related variables, hiding secret values.
+func redactedEnvironment(environ []string) map[string]string {
+	env := make(map[string]string)
+	for _, entry := range environ {
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok || !hasAnyPrefix(name, bugReportEnvPrefixes) {
+			continue
+		}
+		if isSensitiveName(name) {
+			value = presence(value)
+		}
+		env[name] = value
+	}
+	return env
+}
+
+// collectSecrets returns every secret value that must not appear in the bundle.
+func collectSecrets(apiToken string) []string {
+	var secrets []string
+	if apiToken != "" {
+		secrets = append(secrets, apiToken)
+	}
+	for _, entry := range os.Environ() {
+		name, value, ok := strings.Cut(entry, "=")
+		if ok && len(value) >= 8 && isSensitiveName(name) {
+			secrets = append(secrets, value)
+		}
+	}
+	// A rotation pool lists several tokens; each may show up on its own
+	for _, value := range slices.Clone(secrets) {
+		for _, token := range splitAPITokens(value) {
+			if token = strings.TrimSpace(token); len(token) >= 8 && token != value {
+				secrets = append(secrets, token)
+			}
+		}
+	}
+	// Replace longer secrets first so overlapping values are fully hidden.
+	sort.Slice(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+	return secrets
+}
+
+func redactSecrets(data []byte, secrets []string) []byte {
+	for _, secret := range secrets {
+		data = bytes.ReplaceAll(data, []byte(secret), []byte(redactedValue))
+	}
+	return data
+}
+
+func isSensitiveName(name string) bool {
+	upper := strings.ToUpper(name)
+	for _, marker := range sensitiveNameMarkers {
+		if strings.Contains(upper, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAnyPrefix(name string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// presence reports whether a secret is set without revealing it.
+func presence(value string) string {
+	if value == "" {
+		return "(not set)"
+	}
+	return redactedValue
+}
+
+func summarizeDataDir(dataDir string) bugReportDataDir {
+	summary := bugReportDataDir{Path: dataDir}
+	entries, err := os.ReadDir(dataDir)
+	if err != nil {
+		return summary
+	}
+	summary.Exists = true
+	summary.Entries = make(map[string]int, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			summary.Entries[entry.Name()] = 1
+			continue
+		}
+		count := 0
+		_ = filepath.WalkDir(filepath.Join(dataDir, entry.Name()), func(_ string, d os.DirEntry, err error) error {
+			if err == nil && !d.IsDir() {
+				count++
+			}
+			return nil
+		})
+		summary.Entries[entry.Name()+"/"] = count
+	}
+	return summary
+}
+
+func writeBugBundle(path string, files map[string][]byte) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle: %w", err)
+	}
+	defer closeFile(f)
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	archive := zip.NewWriter(f)
+	for _, name := range names {
+		w, err := archive.Create(filepath.ToSlash(name))
+		if err != nil {
+			return fmt.Errorf("failed to add %s to bundle: %w", name, err)
+		}
+		if _, err := io.Copy(w, bytes.NewReader(files[name])); err != nil {
+			return fmt.Errorf("failed to write %s to bundle: %w", name, err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to finalize bundle: %w", err)
+	}
+	return nil
+}
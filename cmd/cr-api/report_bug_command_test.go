@@ -0,0 +1,78 @@
+package main
+
+import (
+	"archive/zip"
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRedactArgs(t *testing.T) {
+	got := redactArgs([]string{"--api-token", "secret", "player", "--tag", "#ABC", "-t", "other", "--auth-token=xyz"})
+	want := []string{"--api-token", redactedValue, "player", "--tag", "#ABC", "-t", redactedValue, "--auth-token=" + redactedValue}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("redactArgs() = %v, want %v", got, want)
+	}
+}
+
+func TestRedactedEnvironment(t *testing.T) {
+	env := redactedEnvironment([]string{
+		"CLASH_ROYALE_API_TOKEN=abc123",
+		"CR_API_SERVE_TOKEN=",
+		"CR_API_STORAGE=sqlite",
+		"DATA_DIR=/tmp/data",
+		"HOME=/home/me",
+		"AWS_SECRET_ACCESS_KEY=nope",
+	})
+	want := map[string]string{
+		"CLASH_ROYALE_API_TOKEN": redactedValue,
+		"CR_API_SERVE_TOKEN":     "(not set)",
+		"CR_API_STORAGE":         "sqlite",
+		"DATA_DIR":               "/tmp/data",
+	}
+	if !reflect.DeepEqual(env, want) {
+		t.Errorf("redactedEnvironment() = %v, want %v", env, want)
+	}
+}
+
+func TestTailBufferKeepsEnd(t *testing.T) {
+	buf := &tailBuffer{limit: 5}
+	_, _ = buf.Write([]byte("hello "))
+	_, _ = buf.Write([]byte("world"))
+	if got := buf.String(); got != "world" || !buf.truncated {
+		t.Errorf("tail = %q truncated=%v", got, buf.truncated)
+	}
+}
+
+func TestWriteBugBundle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bug.zip")
+	output := redactSecrets([]byte("token=supersecret failed"), []string{"supersecret"})
+	if err := writeBugBundle(path, map[string][]byte{"report.json": []byte("{}"), "output.log": output}); err != nil {
+		t.Fatal(err)
+	}
+
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = archive.Close() }()
+
+	contents := map[string]string{}
+	for _, file := range archive.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(rc)
+		_ = rc.Close()
+		contents[file.Name] = string(data)
+	}
+	if len(contents) != 2 || contents["report.json"] != "{}" {
+		t.Errorf("bundle contents = %v", contents)
+	}
+	if strings.Contains(contents["output.log"], "supersecret") {
+		t.Errorf("secret leaked into output.log: %q", contents["output.log"])
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"os"
+
+	"github.com/klauer/clash-royale-api/go/internal/exporter/report"
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	exportHTMLFlagName     = "export-html"
+	exportMarkdownFlagName = "export-markdown"
+)
+
+func reportExportFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{Name: exportHTMLFlagName, Usage: "Write a self-contained HTML report with tables and charts to this file"},
+		&cli.StringFlag{Name: exportMarkdownFlagName, Usage: "Write a Markdown report to this file"},
+	}
+}
+
+// exportReportsIfRequested writes the report built by build to the
+// --export-html and --export-markdown paths. build runs only when one of the
+// flags is set. Status goes to stderr so machine-readable stdout stays clean.
+func exportReportsIfRequested(cmd *cli.Command, build func() *report.Report) error {
+	targets := []struct {
+		flag   string
+		format report.Format
+	}{
+		{exportHTMLFlagName, report.FormatHTML},
+		{exportMarkdownFlagName, report.FormatMarkdown},
+	}
+
+	var r *report.Report
+	for _, target := range targets {
+		path := cmd.String(target.flag)
+		if path == "" {
+			continue
+		}
+		if r == nil {
+			r = build()
+		}
+		if err := report.WriteFile(path, target.format, r); err != nil {
+			return err
+		}
+		fprintf(os.Stderr, "Report saved to: %s\n", path)
+	}
+	return nil
+}
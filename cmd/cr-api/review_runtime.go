@@ -149,18 +149,19 @@ func runDeckDiff(dataDir string, result *onlinePlayerAnalysisResult) (*evaluatio
 }
 
 func makeCandidate(name, rarity string, level, maxLevel, elixir, evolutionLevel, maxEvolutionLevel int) deck.CardCandidate {
-	return deck.CardCandidate{
+	candidate := deck.CardCandidate{
 		Name:              name,
 		Level:             level,
 		MaxLevel:          maxLevel,
 		Rarity:            rarity,
 		Elixir:            elixir,
 		Role:              inferRole(name),
-		Stats:             inferStats(name),
 		HasEvolution:      evolutionLevel > 0,
 		EvolutionLevel:    evolutionLevel,
 		MaxEvolutionLevel: maxEvolutionLevel,
 	}
+	candidate.Stats = inferStats(name, candidate.StandardLevel())
+	return candidate
 }
 
 func playerDeckToCandidates(cards []clashroyale.Card) []deck.CardCandidate {
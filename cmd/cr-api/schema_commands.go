@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/klauer/clash-royale-api/go/internal/schema"
+	"github.com/klauer/clash-royale-api/go/pkg/analysis"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/urfave/cli/v3"
+)
+
+// artifactTypes maps each versioned document kind to the type it decodes into
+var artifactTypes = map[schema.Kind]any{
+	schema.KindAnalysis:  analysis.CardAnalysis{},
+	schema.KindPlaystyle: playstyleArtifact{},
+	schema.KindDeck:      deck.DeckRecommendation{},
+}
+
+// artifactSchema returns the JSON Schema for a document kind.
+func artifactSchema(kind schema.Kind) map[string]any {
+	return schema.Generate(kind, artifactTypes[kind])
+}
+
+// marshalSchema renders a schema the way published schema files are written
+func marshalSchema(s map[string]any) ([]byte, error) {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// addValidateCommand adds the validate command for saved documents
+func addValidateCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "validate",
+		Usage:     "Check a saved analysis, playstyle, or deck file against its JSON Schema",
+		ArgsUsage: "<file>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "kind",
+				Usage: "Document kind: analysis, playstyle, deck (detected from the fields by default)",
+			},
+		},
+		Action: validateCommand,
+	}
+}
+
+// addSchemaCommand adds the schema command that publishes the JSON Schemas
+func addSchemaCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "schema",
+		Usage: "Print or write the JSON Schemas for saved analysis, playstyle, and deck files",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "kind",
+				Value: string(schema.KindAnalysis),
+				Usage: "Document kind to print: analysis, playstyle, deck",
+			},
+			&cli.StringFlag{
+				Name:  "output-dir",
+				Usage: "Write every schema to <dir>/<kind>.schema.json instead of printing one",
+			},
+		},
+		Action: schemaCommand,
+	}
+}
+
+func validateCommand(ctx context.Context, cmd *cli.Command) error {
+	_ = ctx
+	path := cmd.Args().First()
+	if path == "" {
+		return errors.New("usage: cr-api validate <file>")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var kind schema.Kind
+	if name := cmd.String("kind"); name != "" {
+		kind, err = schema.ParseKind(name)
+	} else {
+		kind, err = schema.DetectKind(data)
+		if err != nil {
+			err = fmt.Errorf("%w; pass --kind", err)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	version, err := schema.Version(data)
+	if err != nil {
+		return err
+	}
+	migrated, err := schema.Migrate(kind, data)
+	if err != nil {
+		return err
+	}
+	violations, err := schema.Validate(artifactSchema(kind), migrated)
+	if err != nil {
+		return err
+	}
+
+	if version < schema.CurrentVersion {
+		printf("%s: %s document, schema_version %d (migrated to %d on load)\n", path, kind, version, schema.CurrentVersion)
+	} else {
+		printf("%s: %s document, schema_version %d\n", path, kind, version)
+	}
+	if len(violations) > 0 {
+		for _, v := range violations {
+			printf("  ✗ %s\n", v)
+		}
+		return fmt.Errorf("%s is not a valid %s document (%d problems)", path, kind, len(violations))
+	}
+	printf("  ✓ valid\n")
+	return nil
+}
+
+func schemaCommand(ctx context.Context, cmd *cli.Command) error {
+	_ = ctx
+	if dir := cmd.String("output-dir"); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create schema directory: %w", err)
+		}
+		for _, kind := range schema.Kinds {
+			data, err := marshalSchema(artifactSchema(kind))
+			if err != nil {
+				return err
+			}
+			path := filepath.Join(dir, string(kind)+".schema.json")
+			if err := os.WriteFile(path, data, 0o644); err != nil {
+				return fmt.Errorf("failed to write schema: %w", err)
+			}
+			printf("Wrote %s\n", path)
+		}
+		return nil
+	}
+
+	kind, err := schema.ParseKind(cmd.String("kind"))
+	if err != nil {
+		return err
+	}
+	data, err := marshalSchema(artifactSchema(kind))
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/schema"
+	"github.com/klauer/clash-royale-api/go/pkg/analysis"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/urfave/cli/v3"
+)
+
+// TestPublishedSchemasUpToDate keeps docs/schemas in sync with the structs.
+// Regenerate with: cr-api schema --output-dir docs/schemas
+func TestPublishedSchemasUpToDate(t *testing.T) {
+	for _, kind := range schema.Kinds {
+		want, err := marshalSchema(artifactSchema(kind))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := os.ReadFile(filepath.Join("..", "..", "docs", "schemas", string(kind)+".schema.json"))
+		if err != nil {
+			t.Fatalf("published %s schema: %v", kind, err)
+		}
+		if string(got) != string(want) {
+			t.Errorf("docs/schemas/%s.schema.json is stale; run `cr-api schema --output-dir docs/schemas`", kind)
+		}
+	}
+}
+
+func runValidate(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	return captureStdout(t, func() error {
+		cmd := &cli.Command{Name: "cr-api", Commands: []*cli.Command{addValidateCommand()}}
+		return cmd.Run(context.Background(), append([]string{"cr-api", "validate"}, args...))
+	})
+}
+
+func TestSavedArtifactsValidate(t *testing.T) {
+	dataDir := t.TempDir()
+
+	cardAnalysis := &analysis.CardAnalysis{
+		PlayerTag:    "#2PQ0R",
+		PlayerName:   "Player",
+		AnalysisTime: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		TotalCards:   1,
+		CardLevels:   map[string]analysis.CardLevelInfo{"Knight": {Name: "Knight", Level: 14, MaxLevel: 16, Rarity: "Common"}},
+	}
+	if err := saveAnalysisData(dataDir, cardAnalysis); err != nil {
+		t.Fatalf("saveAnalysisData() error = %v", err)
+	}
+	if err := savePlaystyleData(dataDir, &analysis.PlaystyleAnalysis{PlayerTag: "#2PQ0R"}, nil); err != nil {
+		t.Fatalf("savePlaystyleData() error = %v", err)
+	}
+	deckPath, err := deck.NewBuilder(dataDir).SaveDeck(&deck.DeckRecommendation{
+		Deck:       []string{"Knight"},
+		DeckDetail: []deck.CardDetail{{Name: "Knight", Level: 14, MaxLevel: 16, Rarity: "Common", Elixir: 3}},
+	}, "", "#2PQ0R")
+	if err != nil {
+		t.Fatalf("SaveDeck() error = %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dataDir, "analysis", "*.json"))
+	if err != nil || len(files) != 2 {
+		t.Fatalf("analysis files = %v, %v", files, err)
+	}
+	for _, path := range append(files, deckPath) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v, err := schema.Version(data); err != nil || v != schema.CurrentVersion {
+			t.Errorf("%s: schema_version = %d, %v", filepath.Base(path), v, err)
+		}
+		out, err := runValidate(t, path)
+		if err != nil || !strings.Contains(out, "✓ valid") {
+			t.Errorf("validate %s: %v\n%s", filepath.Base(path), err, out)
+		}
+	}
+
+	loaded, err := deck.NewBuilder(dataDir).LoadDeckFromFile(deckPath)
+	if err != nil || loaded.Deck[0] != "Knight" {
+		t.Errorf("LoadDeckFromFile() = %+v, %v", loaded, err)
+	}
+}
+
+func TestValidateCommandReportsProblems(t *testing.T) {
+	dir := t.TempDir()
+	legacy := filepath.Join(dir, "deck.json")
+	if err := os.WriteFile(legacy, []byte(`{"deck":["Knight"],"deck_detail":[{"name":"Knight","level":"14"}],"average_elixir":3,"notes":null}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runValidate(t, legacy)
+	if err == nil {
+		t.Fatal("expected validation to fail")
+	}
+	for _, want := range []string{
+		"deck document, schema_version 0 (migrated to 1 on load)",
+		"$.deck_detail[0].level: expected integer, got string",
+		`$.deck_detail[0]: missing required field "max_level"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	unknown := filepath.Join(dir, "other.json")
+	if err := os.WriteFile(unknown, []byte(`{"name":"x"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runValidate(t, unknown); err == nil || !strings.Contains(err.Error(), "--kind") {
+		t.Errorf("expected a --kind hint, got %v", err)
+	}
+	if _, err := runValidate(t, "--kind", "analysis", unknown); err == nil {
+		t.Error("expected missing analysis fields to fail validation")
+	}
+}
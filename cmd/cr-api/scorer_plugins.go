@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/klauer/clash-royale-api/go/internal/storage"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/urfave/cli/v3"
+)
+
+const scorerPluginsFlagName = "scorer-plugins"
+
+// configureScorerPlugins registers the scorer plugins named by
+// --scorer-plugins (or its env var or config file key) so every evaluation
+// blends them in. Plugins run commands, so nothing is loaded without that
+// opt-in, not even a file in the data directory, which `sync pull` writes.
+// A named file must exist; a file that fails to load only produces a
+// warning.
+func configureScorerPlugins(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+	evaluation.ResetScorerPlugins()
+	path := strings.TrimSpace(cmd.String(scorerPluginsFlagName))
+	if path == "" {
+		return ctx, nil
+	}
+	if !storage.FileExists(path) {
+		return ctx, fmt.Errorf("scorer plugins file not found: %s", path)
+	}
+	file, err := evaluation.ReadScorerPlugins(path)
+	if err == nil {
+		err = file.Register()
+	}
+	if err != nil {
+		fprintf(os.Stderr, "Warning: ignoring scorer plugins: %v\n", err)
+	}
+	return ctx, nil
+}
+
+// stopScorerPlugins stops plugin processes when the command finishes
+func stopScorerPlugins(context.Context, *cli.Command) {
+	evaluation.ResetScorerPlugins()
+}
+
+// addDeckPluginsCommand adds the command that lists scorer plugins and
+// tries them on a deck
+func addDeckPluginsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "plugins",
+		Usage: "List the scorer plugins from --scorer-plugins and optionally score a deck with each",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "deck",
+				Usage: `Deck to score with each plugin, as 8 cards separated by dashes or commas (e.g. "Hog Rider-Musketeer-...")`,
+			},
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "Output in JSON format",
+			},
+		},
+		Action: deckPluginsCommand,
+	}
+}
+
+// scorerPluginEntry is one registered scorer plugin, with its score for the
+// --deck deck when one was given
+type scorerPluginEntry struct {
+	Name    string   `json:"name"`
+	Weight  float64  `json:"weight"`
+	Command string   `json:"command"`
+	Score   *float64 `json:"score,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+func deckPluginsCommand(ctx context.Context, cmd *cli.Command) error {
+	var candidates []deck.CardCandidate
+	if deckStr := cmd.String("deck"); deckStr != "" {
+		cardNames, err := parseDeckStringWithLabel(deckStr, "--deck")
+		if err != nil {
+			return err
+		}
+		candidates = convertDeckToCandidates(cardNames, nil)
+	}
+
+	plugins := evaluation.ScorerPlugins()
+	entries := make([]scorerPluginEntry, 0, len(plugins))
+	for _, registered := range plugins {
+		entry := scorerPluginEntry{
+			Name:    registered.Plugin.Name(),
+			Weight:  registered.Weight,
+			Command: fmt.Sprint(registered.Plugin),
+		}
+		if candidates != nil {
+			if score, err := registered.Plugin.Score(candidates); err != nil {
+				entry.Error = err.Error()
+			} else {
+				entry.Score = &score
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	if cmd.Bool("json") {
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode scorer plugins: %w", err)
+		}
+		printf("%s\n", data)
+		return nil
+	}
+
+	if len(entries) == 0 {
+		printf("No scorer plugins configured (set --scorer-plugins)\n")
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fprintf(w, "Plugin\tWeight\tCommand\tScore\n")
+	fprintf(w, "------\t------\t-------\t-----\n")
+	for _, entry := range entries {
+		score := "-"
+		switch {
+		case entry.Error != "":
+			score = "error: " + entry.Error
+		case entry.Score != nil:
+			score = fmt.Sprintf("%.2f", *entry.Score)
+		}
+		fprintf(w, "%s\t%.0f%%\t%s\t%s\n", entry.Name, entry.Weight*100, entry.Command, score)
+	}
+	flushWriter(w)
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/urfave/cli/v3"
+)
+
+func TestConfigureScorerPlugins(t *testing.T) {
+	t.Cleanup(evaluation.ResetScorerPlugins)
+	dataDir := t.TempDir()
+	plugins := `{"plugins":[{"name":"neural","command":["neural-scorer","--model","deck.onnx"],"weight":0.25,"timeout":"2s"}]}`
+	pluginsPath := filepath.Join(dataDir, "scorer_plugins.json")
+	if err := os.WriteFile(pluginsPath, []byte(plugins), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) error {
+		root := &cli.Command{
+			Name: "cr-api",
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "data-dir"},
+				&cli.StringFlag{Name: scorerPluginsFlagName},
+			},
+			Before: configureScorerPlugins,
+			Action: func(context.Context, *cli.Command) error { return nil },
+		}
+		return root.Run(context.Background(), append([]string{"cr-api"}, args...))
+	}
+
+	// A file in the data dir is never loaded without the opt-in
+	if err := run("--data-dir", dataDir); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(evaluation.ScorerPlugins()); got != 0 {
+		t.Fatalf("%d plugins loaded without --scorer-plugins", got)
+	}
+
+	if err := run("--data-dir", dataDir, "--"+scorerPluginsFlagName, pluginsPath); err != nil {
+		t.Fatalf("configureScorerPlugins() error = %v", err)
+	}
+	registered := evaluation.ScorerPlugins()
+	if len(registered) != 1 || registered[0].Plugin.Name() != "neural" || registered[0].Weight != 0.25 {
+		t.Fatalf("ScorerPlugins() = %+v", registered)
+	}
+
+	// The next invocation without the flag drops them again
+	if err := run("--data-dir", dataDir); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(evaluation.ScorerPlugins()); got != 0 {
+		t.Errorf("%d plugins still registered", got)
+	}
+
+	err := run("--data-dir", dataDir, "--"+scorerPluginsFlagName, filepath.Join(dataDir, "missing.json"))
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("missing --scorer-plugins error = %v, want not found", err)
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/klauer/clash-royale-api/go/pkg/analysis"
+	"github.com/klauer/clash-royale-api/go/pkg/archetypes"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/klauer/clash-royale-api/go/pkg/scrim"
+	"github.com/urfave/cli/v3"
+)
+
+// addScrimCommand adds the friendly-battle scrim planner to the CLI
+func addScrimCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "scrim",
+		Usage: "Suggest balanced friendly-battle matchups between two players: similar deck scores, contrasting archetypes",
+		Flags: []cli.Flag{
+			playerTagFlag(true),
+			&cli.StringFlag{
+				Name:     "opponent",
+				Usage:    "Tag of the player to scrim against",
+				Required: true,
+			},
+			&cli.IntFlag{
+				Name:  "top",
+				Value: 3,
+				Usage: "Number of matchups to suggest, each deck used once (0 = every pairing)",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: batchFormatHuman,
+				Usage: "Output format: human, json",
+			},
+		},
+		Action: scrimCommand,
+	}
+}
+
+func scrimCommand(ctx context.Context, cmd *cli.Command) error {
+	format := strings.ToLower(strings.TrimSpace(cmd.String("format")))
+	if format != batchFormatHuman && format != batchFormatJSON {
+		return fmt.Errorf("unknown format: %s (supported: human, json)", format)
+	}
+
+	client, err := requireAPIClient(cmd, apiClientOptions{})
+	if err != nil {
+		return err
+	}
+	builder := archetypes.NewArchetypeBuilder(cmd.String("data-dir"))
+
+	home, err := scrimSide(ctx, client, builder, cmd.String("tag"))
+	if err != nil {
+		return err
+	}
+	away, err := scrimSide(ctx, client, builder, cmd.String("opponent"))
+	if err != nil {
+		return err
+	}
+
+	plan := scrim.Pair(home, away, cmd.Int("top"))
+
+	if format == batchFormatJSON {
+		data, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		printf("%s\n", data)
+		return nil
+	}
+	printf("%s", formatScrimPlan(plan))
+	return nil
+}
+
+// scrimSide builds one deck per archetype from the player's collection and
+// scores each with the evaluation engine. Archetypes the collection cannot
+// fill are skipped.
+func scrimSide(
+	ctx context.Context,
+	client *clashroyale.Client,
+	builder *archetypes.ArchetypeBuilder,
+	tag string,
+) (scrim.Side, error) {
+	player, err := client.GetPlayerWithContext(ctx, tag)
+	if err != nil {
+		return scrim.Side{}, fmt.Errorf("failed to get player %s: %w", tag, err)
+	}
+	cardAnalysis, err := analysis.AnalyzeCardCollection(player, analysis.DefaultAnalysisOptions())
+	if err != nil {
+		return scrim.Side{}, fmt.Errorf("failed to analyze card collection for %s: %w", tag, err)
+	}
+	deckAnalysis := convertToDeckCardAnalysis(cardAnalysis, player)
+	synergyDB := deck.NewSynergyDatabase()
+	playerContext := evaluation.NewPlayerContextFromPlayer(player)
+
+	side := scrim.Side{Tag: player.Tag, Name: player.Name}
+	for _, archetype := range archetypes.GetAllArchetypes() {
+		recommendation, err := builder.BuildForArchetype(archetype, deckAnalysis)
+		if err != nil || len(recommendation.Deck) != 8 {
+			continue
+		}
+		result := evaluation.Evaluate(recommendation.Candidates(), synergyDB, playerContext)
+		side.Decks = append(side.Decks, scrim.Deck{
+			Archetype: archetype,
+			Cards:     recommendation.Deck,
+			AvgElixir: recommendation.AvgElixir,
+			Score:     result.OverallScore,
+		})
+	}
+	if len(side.Decks) == 0 {
+		return scrim.Side{}, fmt.Errorf("could not build any decks from %s's collection", tag)
+	}
+	return side, nil
+}
+
+func formatScrimPlan(plan *scrim.Plan) string {
+	var buf bytes.Buffer
+	fprintf(&buf, "\nScrim Plan: %s (%s) vs %s (%s)\n", plan.Home.Name, plan.Home.Tag, plan.Away.Name, plan.Away.Tag)
+	fprintf(&buf, "==============================\n")
+
+	if len(plan.Matchups) == 0 {
+		fprintf(&buf, "\nNo matchups: both players can only build the same archetypes.\n")
+		return buf.String()
+	}
+
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fprintf(w, "\n#\t%s\tScore\t%s\tScore\tGap\tStyle\n", plan.Home.Name, plan.Away.Name)
+	for i, m := range plan.Matchups {
+		style := "-"
+		if m.Contrast {
+			style = "clash"
+		}
+		fprintf(w, "%d\t%s\t%.2f\t%s\t%.2f\t%.2f\t%s\n", i+1,
+			formatArchetypeName(m.Home.Archetype), m.Home.Score,
+			formatArchetypeName(m.Away.Archetype), m.Away.Score,
+			m.ScoreGap, style)
+	}
+	flushWriter(w)
+
+	for i, m := range plan.Matchups {
+		fprintf(&buf, "\nMatchup %d: %s vs %s\n", i+1, formatArchetypeName(m.Home.Archetype), formatArchetypeName(m.Away.Archetype))
+		fprintf(&buf, "  %s (%.1f avg elixir): %s\n", plan.Home.Name, m.Home.AvgElixir, strings.Join(m.Home.Cards, ", "))
+		fprintf(&buf, "  %s (%.1f avg elixir): %s\n", plan.Away.Name, m.Away.AvgElixir, strings.Join(m.Away.Cards, ", "))
+	}
+	return buf.String()
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/mulligan"
+	"github.com/klauer/clash-royale-api/go/pkg/scrim"
+)
+
+func TestFormatScrimPlan(t *testing.T) {
+	plan := &scrim.Plan{
+		Home: scrim.Side{Tag: "#AAA", Name: "Alice"},
+		Away: scrim.Side{Tag: "#BBB", Name: "Bob"},
+		Matchups: []scrim.Matchup{{
+			Home:     scrim.Deck{Archetype: mulligan.ArchetypeBeatdown, Cards: []string{"Golem", "Night Witch"}, AvgElixir: 4.1, Score: 7.2},
+			Away:     scrim.Deck{Archetype: mulligan.ArchetypeBridgeSpam, Cards: []string{"Battle Ram", "Bandit"}, AvgElixir: 3.5, Score: 7.0},
+			ScoreGap: 0.2,
+		}},
+	}
+	out := formatScrimPlan(plan)
+	for _, want := range []string{"Alice (#AAA) vs Bob (#BBB)", "Beatdown", "Bridge Spam", "0.20", "Golem, Night Witch", "Battle Ram, Bandit"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	plan.Matchups = nil
+	if out := formatScrimPlan(plan); !strings.Contains(out, "No matchups") {
+		t.Errorf("expected empty plan message:\n%s", out)
+	}
+}
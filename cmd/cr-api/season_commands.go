@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/season"
+	"github.com/urfave/cli/v3"
+)
+
+// addSeasonCommands adds the season tracking commands to the CLI
+func addSeasonCommands() *cli.Command {
+	return &cli.Command{
+		Name:  "season",
+		Usage: "Season tracking and trophy-road progress from recorded history",
+		Commands: []*cli.Command{
+			{
+				Name:  "report",
+				Usage: "Report per-season peak trophies, net gain, arena promotions, and the current season projection",
+				Flags: []cli.Flag{
+					playerTagFlag(true),
+					&cli.IntFlag{
+						Name:  "seasons",
+						Value: 3,
+						Usage: "Number of most recent seasons to show (0 = all)",
+					},
+					&cli.StringFlag{
+						Name:  "format",
+						Value: batchFormatHuman,
+						Usage: "Output format: human, json",
+					},
+				},
+				Action: seasonReportCommand,
+			},
+		},
+	}
+}
+
+func seasonReportCommand(ctx context.Context, cmd *cli.Command) error {
+	_ = ctx
+	format := strings.ToLower(strings.TrimSpace(cmd.String("format")))
+	if format != batchFormatHuman && format != batchFormatJSON {
+		return fmt.Errorf("unknown format: %s (supported: human, json)", format)
+	}
+
+	db, err := openHistoryDB(cmd)
+	if err != nil {
+		return err
+	}
+	defer closeFile(db)
+
+	tag := cmd.String("tag")
+	history, err := db.TrophyProgression(tag, time.Time{})
+	if err != nil {
+		return err
+	}
+	if len(history) == 0 {
+		return fmt.Errorf("no trophy history for %s (run `cr-api db sync --tag %s` regularly or `cr-api db migrate`)", tag, tag)
+	}
+
+	points := make([]season.Point, len(history))
+	for i, point := range history {
+		points[i] = season.Point{Time: point.Time, Trophies: point.Trophies, Arena: point.Arena}
+	}
+	stats := season.Report(points, time.Now())
+	if limit := cmd.Int("seasons"); limit > 0 && len(stats) > limit {
+		stats = stats[:limit]
+	}
+
+	if format == batchFormatJSON {
+		data, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		printf("%s\n", data)
+		return nil
+	}
+	printf("%s", formatSeasonReport(tag, stats))
+	return nil
+}
+
+func formatSeasonReport(tag string, stats []season.Stats) string {
+	var buf bytes.Buffer
+	fprintf(&buf, "\nSeason Report for %s\n", tag)
+	fprintf(&buf, "====================\n")
+
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fprintf(w, "Season\tStart\tEnd\tPeak\tLow\tNet\tSamples\n")
+	for _, s := range stats {
+		fprintf(w, "%s\t%d\t%d\t%d\t%d\t%+d\t%d\n",
+			s.ID, s.StartTrophies, s.EndTrophies, s.PeakTrophies, s.LowTrophies, s.NetGain, s.Samples)
+	}
+	flushWriter(w)
+
+	for _, s := range stats {
+		if len(s.Promotions) == 0 && s.Projection == nil {
+			continue
+		}
+		fprintf(&buf, "\n%s (%s → %s)\n", s.ID, s.Start.Format("2006-01-02"), s.End.Format("2006-01-02"))
+		fprintf(&buf, "  Peak %d on %s\n", s.PeakTrophies, s.PeakTime.Local().Format("2006-01-02"))
+		for _, promotion := range s.Promotions {
+			fprintf(&buf, "  Promoted to %s on %s at %d trophies\n",
+				promotion.Arena, promotion.Time.Local().Format("2006-01-02"), promotion.Trophies)
+		}
+		if p := s.Projection; p != nil {
+			fprintf(&buf, "  Projected end: %d trophies (%+.1f/day, %.1f days left)", p.EndTrophies, p.TrophiesPerDay, p.DaysRemaining)
+			if p.Arena != "" {
+				fprintf(&buf, ", %s", p.Arena)
+			}
+			fprintf(&buf, "\n")
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/klauer/clash-royale-api/go/internal/datapath"
+	"github.com/klauer/clash-royale-api/go/internal/server"
+	"github.com/klauer/clash-royale-api/go/pkg/analysis"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/klauer/clash-royale-api/go/pkg/fuzzstorage"
+	"github.com/klauer/clash-royale-api/go/pkg/leaderboard"
+)
+
+// fuzzProgressInterval is how many evaluated decks pass between progress updates.
+const fuzzProgressInterval = 100
+
+// serveBackend implements server.Backend with the same code paths the CLI
+// commands use, so API responses match `cr-api` output.
+type serveBackend struct {
+	apiToken string
+	dataDir  string
+}
+
+// playerAnalysisResponse is returned by GET /api/v1/players/{tag}/analysis
+type playerAnalysisResponse struct {
+	PlayerTag    string                 `json:"player_tag"`
+	PlayerName   string                 `json:"player_name"`
+	Trophies     int                    `json:"trophies"`
+	ExpLevel     int                    `json:"exp_level"`
+	CardAnalysis *analysis.CardAnalysis `json:"card_analysis"`
+}
+
+// fuzzJobResult is the result payload of a completed API fuzz job
+type fuzzJobResult struct {
+	PlayerTag  string          `json:"player_tag"`
+	PlayerName string          `json:"player_name"`
+	Generated  int             `json:"generated"`
+	Unique     int             `json:"unique"`
+	Results    []FuzzingResult `json:"results"`
+}
+
+func (b *serveBackend) requireToken() error {
+	if b.apiToken == "" {
+		return fmt.Errorf("%w: API token required (set CLASH_ROYALE_API_TOKEN or use --api-token)", server.ErrUnavailable)
+	}
+	return nil
+}
+
+func (b *serveBackend) fetchPlayer(ctx context.Context, tag string) (*clashroyale.Player, error) {
+	if err := b.requireToken(); err != nil {
+		return nil, err
+	}
+	client, err := requireAPIClientFromToken(b.apiToken, apiClientOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", server.ErrUnavailable, err)
+	}
+	player, err := client.GetPlayerWithContext(ctx, tag)
+	if err != nil {
+		return nil, serveAPIError(err)
+	}
+	return player, nil
+}
+
+func (b *serveBackend) AnalyzePlayer(ctx context.Context, tag string) (any, error) {
+	player, err := b.fetchPlayer(ctx, tag)
+	if err != nil {
+		return nil, err
+	}
+	cardAnalysis, err := analysis.AnalyzeCardCollection(player, analysis.DefaultAnalysisOptions())
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze card collection: %w", err)
+	}
+	return playerAnalysisResponse{
+		PlayerTag:    player.Tag,
+		PlayerName:   player.Name,
+		Trophies:     player.Trophies,
+		ExpLevel:     player.ExpLevel,
+		CardAnalysis: cardAnalysis,
+	}, nil
+}
+
+func (b *serveBackend) EvaluateDeck(ctx context.Context, req server.EvaluateRequest) (any, error) {
+	mode, err := evaluation.ParseEvaluationMode(req.Mode)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", server.ErrInvalidRequest, err)
+	}
+
+	synergyDB := deck.NewSynergyDatabase()
+	playerContext := fetchPlayerContextIfNeeded(ctx, req.Tag, b.apiToken, 0, false)
+
+	if len(req.PartnerDeck) > 0 {
+		if req.Mode != "" && mode != evaluation.Mode2v2 {
+			return nil, fmt.Errorf("%w: partner_deck requires mode 2v2", server.ErrInvalidRequest)
+		}
+		partnerContext := fetchPlayerContextIfNeeded(ctx, req.PartnerTag, b.apiToken, 0, false)
+		result := evaluation.EvaluatePair(
+			convertToCardCandidates(req.Deck),
+			convertToCardCandidates(req.PartnerDeck),
+			synergyDB,
+			playerContext,
+			partnerContext,
+		)
+		return result, nil
+	}
+
+	result := evaluation.EvaluateWithMode(convertToCardCandidates(req.Deck), synergyDB, playerContext, mode)
+	if url := newDeckLinkEncoder(b.dataDir).Link(result.Deck); url != "" {
+		result.DeckLink = &evaluation.DeckLink{URL: url, Valid: true}
+	}
+	return result, nil
+}
+
+func (b *serveBackend) RunFuzz(ctx context.Context, req server.FuzzRequest, progress func(done, total int)) (any, error) {
+	player, err := b.fetchPlayer(ctx, req.Tag)
+	if err != nil {
+		return nil, err
+	}
+
+	fuzzer, err := deck.NewDeckFuzzer(player, &deck.FuzzingConfig{
+		Count:        req.Count,
+		Workers:      1,
+		Seed:         req.Seed,
+		IncludeCards: req.IncludeCards,
+		ExcludeCards: req.ExcludeCards,
+		MinAvgElixir: req.MinAvgElixir,
+		MaxAvgElixir: req.MaxAvgElixir,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", server.ErrInvalidRequest, err)
+	}
+
+	decks, err := fuzzer.GenerateDecksWithContext(ctx, req.Count)
+	if err != nil {
+		return nil, err
+	}
+	if len(decks) == 0 {
+		return nil, fmt.Errorf("no decks were successfully generated")
+	}
+
+	playerContext := evaluation.NewPlayerContextFromPlayer(player)
+	synergyDB := deck.NewSynergyDatabase()
+	results := make([]FuzzingResult, 0, len(decks))
+	for i, deckCards := range decks {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		results = append(results, evaluateSingleDeck(deckCards, player, player.Tag, synergyDB, playerContext, evaluation.EvaluateOptions{}))
+		if (i+1)%fuzzProgressInterval == 0 || i+1 == len(decks) {
+			progress(i+1, len(decks))
+		}
+	}
+
+	sortFuzzingResultsImpl(results, "overall")
+	unique := deduplicateResults(results)
+	top := getTopResultsImpl(unique, req.Top)
+	links := newDeckLinkEncoder(b.dataDir)
+	for i := range top {
+		top[i].DeckLink = links.Link(top[i].Deck)
+	}
+
+	if req.Save {
+		if err := saveTopDecksToStorage(top, fuzzstorage.DefaultRetentionPolicy(), nil, false); err != nil {
+			return nil, err
+		}
+	}
+
+	return fuzzJobResult{
+		PlayerTag:  player.Tag,
+		PlayerName: player.Name,
+		Generated:  len(decks),
+		Unique:     len(unique),
+		Results:    top,
+	}, nil
+}
+
+func (b *serveBackend) QueryDecks(_ context.Context, tag string, query server.DeckQuery) (any, error) {
+	dbPath, err := datapath.LeaderboardDBPath(tag)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(dbPath); errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: no saved decks for %s", server.ErrNotFound, tag)
+	}
+
+	storage, err := leaderboard.NewStorage(tag)
+	if err != nil {
+		return nil, err
+	}
+	defer closeFile(storage)
+
+	opts := leaderboard.DefaultQueryOptions()
+	opts.Archetype = query.Archetype
+	opts.MinScore = query.MinScore
+	opts.MaxScore = query.MaxScore
+	opts.RequireAllCards = query.Cards
+	opts.Limit = query.Limit
+	opts.Offset = query.Offset
+	if query.SortBy != "" {
+		opts.SortBy = query.SortBy
+	}
+
+	entries, err := storage.Query(opts)
+	if err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		entries = []leaderboard.DeckEntry{}
+	}
+	return entries, nil
+}
+
+// serveAPIError maps Clash Royale API failures onto server error classes.
+func serveAPIError(err error) error {
+	switch {
+	case errors.Is(err, clashroyale.ErrInvalidTag):
+		return fmt.Errorf("%w: %v", server.ErrInvalidRequest, err)
+	case errors.Is(err, clashroyale.ErrNotFound):
+		return fmt.Errorf("%w: %v", server.ErrNotFound, err)
+	case errors.Is(err, clashroyale.ErrMaintenance), errors.Is(err, clashroyale.ErrRateLimited):
+		return fmt.Errorf("%w: %v", server.ErrUnavailable, err)
+	case errors.Is(err, context.Canceled):
+		return err
+	}
+	return fmt.Errorf("%w: %v", server.ErrUpstream, err)
+}
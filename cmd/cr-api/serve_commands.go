@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/klauer/clash-royale-api/go/internal/server"
+	"github.com/klauer/clash-royale-api/go/internal/sqlstore"
+	"github.com/urfave/cli/v3"
+)
+
+// addServeCommand adds the HTTP server command
+func addServeCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "serve",
+		Usage: "Run the cr-api HTTP server (REST API, GraphQL, and/or public profiles)",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "addr",
+				Value: "127.0.0.1:8080",
+				Usage: "Listen address",
+			},
+			&cli.BoolFlag{
+				Name:  "api",
+				Usage: "Expose the REST API (player analysis, deck evaluation, fuzz jobs, saved deck queries)",
+			},
+			&cli.BoolFlag{
+				Name:  "graphql",
+				Usage: "Expose a read-only GraphQL endpoint at /api/v1/graphql over stored players, snapshots, battles, analyses, saved decks, and deck evaluations",
+			},
+			&cli.StringFlag{
+				Name:    "auth-token",
+				Sources: cli.EnvVars("CR_API_SERVE_TOKEN"),
+				Usage:   "Bearer token required for REST API and GraphQL requests",
+			},
+			&cli.IntFlag{
+				Name:  "max-jobs",
+				Value: 1,
+				Usage: "Maximum number of fuzz jobs running at once",
+			},
+			&cli.IntFlag{
+				Name:  "max-queued-jobs",
+				Value: 10,
+				Usage: "Maximum number of fuzz jobs waiting for a free slot; further submissions get 503",
+			},
+			&cli.BoolFlag{
+				Name:  "public",
+				Usage: "Expose unauthenticated, read-only public profiles for opted-in players (see `cr-api profile publish`)",
+			},
+			&cli.IntFlag{
+				Name:  "public-rate-limit",
+				Value: 30,
+				Usage: "Maximum public requests per client IP per minute",
+			},
+			&cli.BoolFlag{
+				Name:  "metrics",
+				Usage: "Expose Prometheus metrics at /metrics (API calls, rate-limit hits, fuzz throughput, evaluation latency, GA generations)",
+			},
+		},
+		Action: serveCommand,
+	}
+}
+
+func serveCommand(ctx context.Context, cmd *cli.Command) error {
+	dataDir := cmd.String("data-dir")
+	opts := server.Options{
+		Addr:              cmd.String("addr"),
+		DataDir:           dataDir,
+		Public:            cmd.Bool("public"),
+		PublicRateLimit:   cmd.Int("public-rate-limit"),
+		API:               cmd.Bool("api"),
+		AuthToken:         cmd.String("auth-token"),
+		MaxConcurrentJobs: cmd.Int("max-jobs"),
+		MaxQueuedJobs:     cmd.Int("max-queued-jobs"),
+		Metrics:           cmd.Bool("metrics"),
+	}
+	if opts.API {
+		opts.Backend = &serveBackend{apiToken: cmd.String("api-token"), dataDir: dataDir}
+	}
+	if cmd.Bool("graphql") {
+		db, err := sqlstore.Open(sqlstore.DefaultPath(dataDir))
+		if err != nil {
+			return err
+		}
+		defer closeFile(db)
+		opts.GraphQL = newGraphQLSchema(db)
+	}
+	if (opts.API || opts.GraphQL != nil) && opts.AuthToken == "" && !isLoopbackAddr(opts.Addr) {
+		fprintf(os.Stderr, "Warning: REST API/GraphQL is listening on %s without --auth-token\n", opts.Addr)
+	}
+
+	srv, err := server.New(opts)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	printf("Serving on http://%s\n", srv.Addr())
+	if err := srv.ListenAndServe(ctx); err != nil {
+		return fmt.Errorf("server error: %w", err)
+	}
+	return nil
+}
+
+// isLoopbackAddr reports whether a listen address only accepts local connections.
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
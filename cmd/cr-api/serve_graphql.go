@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/graphql"
+	"github.com/klauer/clash-royale-api/go/internal/server"
+	"github.com/klauer/clash-royale-api/go/internal/sqlstore"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/leaderboard"
+)
+
+// Default list sizes for GraphQL relations without a limit argument
+const (
+	graphQLDefaultBattles     = 25
+	graphQLDefaultAnalyses    = 5
+	graphQLDefaultDecks       = 10
+	graphQLDefaultEvaluations = 10
+)
+
+// graphQLMaxLimit caps every relation's limit, like the REST deck query
+const graphQLMaxLimit = 500
+
+// newGraphQLSchema exposes the SQLite store and the deck leaderboards to
+// `serve --graphql`. Players are the root; their snapshots, battles,
+// analyses, saved decks, and deck evaluations hang off them. Fields of
+// those records use the same JSON names as the stored data.
+func newGraphQLSchema(db *sqlstore.Store) *graphql.Schema {
+	record := func(name string) *graphql.Object {
+		return &graphql.Object{Name: name}
+	}
+	snapshot, battle, analysis, savedDeck, evaluation := record("Snapshot"), record("Battle"),
+		record("Analysis"), record("Deck"), record("Evaluation")
+
+	player := &graphql.Object{Name: "Player", Fields: map[string]*graphql.Field{
+		"profile": {
+			Resolve: func(_ context.Context, source any, _ map[string]any) (any, error) {
+				summary := source.(sqlstore.PlayerSummary)
+				snapshots, err := db.PlayerSnapshots(summary.Tag, summary.LastFetchedAt, 1)
+				if err != nil || len(snapshots) == 0 {
+					return nil, err
+				}
+				return snapshots[len(snapshots)-1].Player, nil
+			},
+		},
+		"snapshots": {
+			Type: snapshot,
+			Args: []string{"since", "limit"},
+			Resolve: func(_ context.Context, source any, args map[string]any) (any, error) {
+				since, err := graphQLSinceArg(args)
+				if err != nil {
+					return nil, err
+				}
+				limit, err := graphQLLimitArg(args, graphQLMaxLimit)
+				if err != nil {
+					return nil, err
+				}
+				return db.PlayerSnapshots(source.(sqlstore.PlayerSummary).Tag, since, limit)
+			},
+		},
+		"trophyProgression": {
+			Args: []string{"since"},
+			Resolve: func(_ context.Context, source any, args map[string]any) (any, error) {
+				since, err := graphQLSinceArg(args)
+				if err != nil {
+					return nil, err
+				}
+				return db.TrophyProgression(source.(sqlstore.PlayerSummary).Tag, since)
+			},
+		},
+		"battles": {
+			Type: battle,
+			Args: []string{"limit"},
+			Resolve: func(_ context.Context, source any, args map[string]any) (any, error) {
+				limit, err := graphQLLimitArg(args, graphQLDefaultBattles)
+				if err != nil {
+					return nil, err
+				}
+				return db.PlayerBattles(source.(sqlstore.PlayerSummary).Tag, limit)
+			},
+		},
+		"analyses": {
+			Type: analysis,
+			Args: []string{"limit"},
+			Resolve: func(_ context.Context, source any, args map[string]any) (any, error) {
+				limit, err := graphQLLimitArg(args, graphQLDefaultAnalyses)
+				if err != nil {
+					return nil, err
+				}
+				return db.Analyses(source.(sqlstore.PlayerSummary).Tag, limit)
+			},
+		},
+		"decks": {
+			Type: savedDeck,
+			Args: []string{"limit"},
+			Resolve: func(_ context.Context, source any, args map[string]any) (any, error) {
+				limit, err := graphQLLimitArg(args, graphQLDefaultDecks)
+				if err != nil {
+					return nil, err
+				}
+				return db.Decks(source.(sqlstore.PlayerSummary).Tag, limit)
+			},
+		},
+		"evaluations": {
+			Type: evaluation,
+			Args: []string{"archetype", "minScore", "maxScore", "cards", "sortBy", "limit"},
+			Resolve: func(ctx context.Context, source any, args map[string]any) (any, error) {
+				query, err := graphQLDeckQuery(args)
+				if err != nil {
+					return nil, err
+				}
+				entries, err := (&serveBackend{}).QueryDecks(ctx, source.(sqlstore.PlayerSummary).Tag, query)
+				if errors.Is(err, server.ErrNotFound) {
+					return []leaderboard.DeckEntry{}, nil
+				}
+				return entries, err
+			},
+		},
+	}}
+
+	return &graphql.Schema{Query: &graphql.Object{Name: "Query", Fields: map[string]*graphql.Field{
+		"players": {
+			Type: player,
+			Args: []string{"limit"},
+			Resolve: func(_ context.Context, _ any, args map[string]any) (any, error) {
+				limit, err := graphQLLimitArg(args, graphQLMaxLimit)
+				if err != nil {
+					return nil, err
+				}
+				return db.Players("", limit)
+			},
+		},
+		"player": {
+			Type:     player,
+			Args:     []string{"tag"},
+			Required: []string{"tag"},
+			Resolve: func(_ context.Context, _ any, args map[string]any) (any, error) {
+				raw, err := graphql.StringArg(args, "tag", "")
+				if err != nil {
+					return nil, err
+				}
+				tag, err := clashroyale.ParseTag(raw)
+				if err != nil {
+					return nil, err
+				}
+				players, err := db.Players(tag, 1)
+				if err != nil || len(players) == 0 {
+					return nil, err
+				}
+				return players[0], nil
+			},
+		},
+	}}}
+}
+
+// graphQLSinceArg reads a since argument, a date or an age like --since
+func graphQLSinceArg(args map[string]any) (time.Time, error) {
+	value, err := graphql.StringArg(args, "since", "")
+	if err != nil || value == "" {
+		return time.Time{}, err
+	}
+	return parseTimeFlag("since", value, time.Now())
+}
+
+// graphQLDeckQuery maps evaluations arguments onto a leaderboard query
+func graphQLDeckQuery(args map[string]any) (server.DeckQuery, error) {
+	query := server.DeckQuery{}
+	var err error
+	if query.Archetype, err = graphql.StringArg(args, "archetype", ""); err != nil {
+		return query, err
+	}
+	if query.MinScore, err = graphql.FloatArg(args, "minScore", 0); err != nil {
+		return query, err
+	}
+	if query.MaxScore, err = graphql.FloatArg(args, "maxScore", 0); err != nil {
+		return query, err
+	}
+	if query.Cards, err = graphql.StringListArg(args, "cards"); err != nil {
+		return query, err
+	}
+	if query.SortBy, err = graphql.StringArg(args, "sortBy", ""); err != nil {
+		return query, err
+	}
+	if query.Limit, err = graphQLLimitArg(args, graphQLDefaultEvaluations); err != nil {
+		return query, err
+	}
+	return query, nil
+}
+
+// graphQLLimitArg returns the limit argument, or fallback when it was not
+// given. Like the REST deck query, limits outside 1..graphQLMaxLimit are an
+// error, so no relation returns an unbounded list.
+func graphQLLimitArg(args map[string]any, fallback int) (int, error) {
+	limit, err := graphql.IntArg(args, "limit", fallback)
+	if err != nil {
+		return 0, err
+	}
+	if limit < 1 || limit > graphQLMaxLimit {
+		return 0, fmt.Errorf("limit must be between 1 and %d", graphQLMaxLimit)
+	}
+	return limit, nil
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/graphql"
+	"github.com/klauer/clash-royale-api/go/internal/sqlstore"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+func TestGraphQLSchemaResolvesStoredData(t *testing.T) {
+	db, err := sqlstore.Open(filepath.Join(t.TempDir(), sqlstore.DBFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeFile(db)
+
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	player := &clashroyale.Player{
+		Tag: "#2PP", Name: "Stored", Trophies: 7100,
+		Cards: []clashroyale.Card{{Name: "Hog Rider", Level: 12}},
+	}
+	if _, err := db.RecordPlayer(player, base, "test"); err != nil {
+		t.Fatal(err)
+	}
+	battle := clashroyale.Battle{
+		Type:     "PvP",
+		UTCDate:  base,
+		Team:     []clashroyale.BattleTeam{{Tag: "#2PP", Crowns: 3, Cards: []clashroyale.Card{{Name: "Hog Rider"}}}},
+		Opponent: []clashroyale.BattleTeam{{Tag: "#9YL", Cards: []clashroyale.Card{{Name: "Golem"}}}},
+	}
+	if _, err := db.RecordBattles("#2PP", []clashroyale.Battle{battle}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.RecordAnalysis("#2PP", base, map[string]any{"total_cards": 1, "summary": map[string]int{"max_level_cards": 0}}, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	schema := newGraphQLSchema(db)
+	resp := schema.Execute(context.Background(), graphql.Request{
+		Query: `query($tag: String!) {
+			players { tag }
+			player(tag: $tag) {
+				name
+				trophies
+				profile { cards { name level } }
+				battles(limit: 5) { opponent_tag crowns deck }
+				analyses { analyzed_at data { total_cards summary { max_level_cards } } }
+				decks { cards }
+			}
+			unknown: player(tag: "9yl") { name }
+		}`,
+		Variables: map[string]any{"tag": "2pp"},
+	})
+	if len(resp.Errors) != 0 {
+		t.Fatalf("errors = %+v", resp.Errors)
+	}
+	data, err := json.Marshal(resp.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"players":[{"tag":"#2PP"}]`,
+		`"name":"Stored","trophies":7100`,
+		`"profile":{"cards":[{"name":"Hog Rider","level":12}]}`,
+		`"battles":[{"opponent_tag":"#9YL","crowns":3,"deck":["Hog Rider"]}]`,
+		`"data":{"total_cards":1,"summary":{"max_level_cards":0}}`,
+		`"decks":[]`,
+		`"unknown":null`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("data missing %s\n%s", want, data)
+		}
+	}
+
+	resp = schema.Execute(context.Background(), graphql.Request{Query: `{ player(tag: "#2PPO") { name } }`})
+	if len(resp.Errors) != 1 || !strings.Contains(resp.Errors[0].Message, "did you mean #2PP0?") {
+		t.Errorf("bad tag errors = %+v", resp.Errors)
+	}
+}
+
+func TestGraphQLLimitArgIsBounded(t *testing.T) {
+	tests := []struct {
+		args map[string]any
+		want int
+	}{
+		{map[string]any{}, 20},
+		{map[string]any{"limit": 7}, 7},
+		{map[string]any{"limit": graphQLMaxLimit}, graphQLMaxLimit},
+	}
+	for _, tt := range tests {
+		got, err := graphQLLimitArg(tt.args, 20)
+		if err != nil || got != tt.want {
+			t.Errorf("graphQLLimitArg(%v) = %d, %v; want %d", tt.args, got, err, tt.want)
+		}
+	}
+	for _, limit := range []any{0, -1, graphQLMaxLimit + 1, "many"} {
+		if _, err := graphQLLimitArg(map[string]any{"limit": limit}, 20); err == nil {
+			t.Errorf("limit %v should fail", limit)
+		}
+	}
+}
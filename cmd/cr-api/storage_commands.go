@@ -4,14 +4,18 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"text/tabwriter"
 	"time"
 
+	"github.com/klauer/clash-royale-api/go/internal/datapath"
 	"github.com/klauer/clash-royale-api/go/internal/playertag"
+	"github.com/klauer/clash-royale-api/go/pkg/fuzzstorage"
 	"github.com/klauer/clash-royale-api/go/pkg/leaderboard"
 	"github.com/urfave/cli/v3"
 )
@@ -83,6 +87,22 @@ func addStorageCommands() *cli.Command {
 				},
 				Action: storageVacuumCommand,
 			},
+			{
+				Name:  "maintain",
+				Usage: "Remove duplicate decks, rebuild indexes, and vacuum the deck databases",
+				Flags: []cli.Flag{
+					playerTagFlagWithUsage(false, "Only maintain this player's leaderboard (default: every leaderboard)"),
+					&cli.BoolFlag{Name: "fuzz", Value: true, Usage: "Also maintain the deck fuzz database"},
+					&cli.StringFlag{Name: "fuzz-storage", Usage: "Path to the deck fuzz database (default: ~/.cr-api/fuzz_top_decks.db)"},
+					&cli.BoolFlag{Name: "dry-run", Usage: "Count duplicate decks and orphaned index rows without changing anything"},
+					&cli.StringFlag{
+						Name:  "format",
+						Value: storageFormatSummary,
+						Usage: "Output format: summary, json",
+					},
+				},
+				Action: storageMaintainCommand,
+			},
 			{
 				Name:  "export",
 				Usage: "Export storage decks for backup",
@@ -384,6 +404,137 @@ func storageVacuumCommand(ctx context.Context, cmd *cli.Command) error {
 	return nil
 }
 
+// storageMaintainEntry is one database's maintenance report
+type storageMaintainEntry struct {
+	Database string `json:"database"`
+	leaderboard.MaintenanceReport
+}
+
+func storageMaintainCommand(ctx context.Context, cmd *cli.Command) error {
+	format := strings.ToLower(cmd.String("format"))
+	if format != storageFormatSummary && format != storageFormatJSON {
+		return fmt.Errorf("invalid format %q (valid: summary, json)", format)
+	}
+	dryRun := cmd.Bool("dry-run")
+
+	tags, err := maintainLeaderboardTags(cmd.String("tag"))
+	if err != nil {
+		return err
+	}
+	var entries []storageMaintainEntry
+	for _, tag := range tags {
+		storage, err := leaderboard.NewStorage(tag)
+		if err != nil {
+			return fmt.Errorf("failed to open storage: %w", err)
+		}
+		report, err := storage.Maintain(dryRun)
+		closeFile(storage)
+		if err != nil {
+			return fmt.Errorf("failed to maintain leaderboard #%s: %w", tag, err)
+		}
+		entries = append(entries, storageMaintainEntry{Database: "leaderboard #" + tag, MaintenanceReport: report})
+	}
+
+	if cmd.Bool("fuzz") {
+		entry, ok, err := maintainFuzzStorage(cmd.String("fuzz-storage"), dryRun)
+		if err != nil {
+			return err
+		}
+		if ok {
+			entries = append(entries, entry)
+		}
+	}
+
+	if format == storageFormatJSON {
+		if entries == nil {
+			entries = []storageMaintainEntry{}
+		}
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		printf("%s\n", string(data))
+		return nil
+	}
+	printStorageMaintainSummary(entries, dryRun)
+	return nil
+}
+
+// maintainLeaderboardTags returns the player tag to maintain, or the tags of
+// every saved leaderboard when tag is empty
+func maintainLeaderboardTags(tag string) ([]string, error) {
+	if tag != "" {
+		sanitized, err := playertag.Sanitize(tag)
+		if err != nil {
+			return nil, err
+		}
+		return []string{sanitized}, nil
+	}
+	dir, err := datapath.LeaderboardsDir()
+	if err != nil {
+		return nil, err
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.db"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list leaderboards: %w", err)
+	}
+	tags := make([]string, 0, len(paths))
+	for _, path := range paths {
+		tags = append(tags, strings.TrimSuffix(filepath.Base(path), ".db"))
+	}
+	return tags, nil
+}
+
+// maintainFuzzStorage maintains the deck fuzz database; ok is false when it
+// does not exist yet
+func maintainFuzzStorage(path string, dryRun bool) (entry storageMaintainEntry, ok bool, err error) {
+	if path == "" {
+		if path, err = fuzzstorage.DefaultDBPath(); err != nil {
+			return entry, false, err
+		}
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return entry, false, nil
+	}
+	storage, err := fuzzstorage.NewStorage(path)
+	if err != nil {
+		return entry, false, fmt.Errorf("failed to open fuzz storage: %w", err)
+	}
+	defer closeFile(storage)
+	report, err := storage.Maintain(dryRun)
+	if err != nil {
+		return entry, false, fmt.Errorf("failed to maintain fuzz storage: %w", err)
+	}
+	return storageMaintainEntry{Database: "deck fuzz", MaintenanceReport: report}, true, nil
+}
+
+func printStorageMaintainSummary(entries []storageMaintainEntry, dryRun bool) {
+	if len(entries) == 0 {
+		printf("No deck databases found\n")
+		return
+	}
+	duplicates, orphans := "Duplicates Removed", "Orphans Removed"
+	if dryRun {
+		duplicates, orphans = "Duplicates", "Orphans"
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fprintf(w, "Database\tBefore\tAfter\tFreed\t%s\t%s\tRelinked\n", duplicates, orphans)
+	var before, after int64
+	for _, e := range entries {
+		fprintf(w, "%s\t%s\t%s\t%s\t%d\t%d\t%d\n", e.Database,
+			humanReadableBytes(e.SizeBeforeBytes), humanReadableBytes(e.SizeAfterBytes), humanReadableBytes(e.FreedBytes()),
+			e.DuplicatesRemoved, e.OrphansRemoved, e.RelinkedRows)
+		before += e.SizeBeforeBytes
+		after += e.SizeAfterBytes
+	}
+	flushWriter(w)
+	if dryRun {
+		printf("\nDry run: nothing was changed. Run without --dry-run to remove them, rebuild indexes, and vacuum.\n")
+		return
+	}
+	printf("\nMaintenance complete. Total size: %s -> %s (freed %s)\n", humanReadableBytes(before), humanReadableBytes(after), humanReadableBytes(max(before-after, 0)))
+}
+
 func storageExportCommand(ctx context.Context, cmd *cli.Command) error {
 	format := strings.ToLower(cmd.String("format"))
 	if format != storageFormatJSON {
@@ -2,8 +2,11 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"strings"
 	"testing"
 
+	"github.com/klauer/clash-royale-api/go/pkg/leaderboard"
 	"github.com/urfave/cli/v3"
 )
 
@@ -74,3 +77,44 @@ func TestBuildCleanupOptions(t *testing.T) {
 		}
 	})
 }
+
+func TestStorageMaintainCommand(t *testing.T) {
+	seedCurationDecks(t)
+	lb, err := leaderboard.NewStorage("#2CGJ89")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closeFile(lb)
+
+	var root *cli.Command
+	for _, sub := range addStorageCommands().Commands {
+		if sub.Name == "maintain" {
+			root = sub
+		}
+	}
+	output, err := captureStdout(t, func() error {
+		return root.Run(context.Background(), []string{"maintain"})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"leaderboard #2CGJ89", "deck fuzz", "Maintenance complete"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+
+	output, err = captureStdout(t, func() error {
+		return root.Run(context.Background(), []string{"maintain", "--tag", "2CGJ89", "--fuzz=false", "--dry-run", "--format", "json"})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entries []storageMaintainEntry
+	if err := json.Unmarshal([]byte(output), &entries); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, output)
+	}
+	if len(entries) != 1 || entries[0].Database != "leaderboard #2CGJ89" || !entries[0].DryRun {
+		t.Errorf("entries = %+v", entries)
+	}
+}
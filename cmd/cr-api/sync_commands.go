@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/klauer/clash-royale-api/go/internal/datapath"
+	"github.com/klauer/clash-royale-api/go/internal/datasync"
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	syncFormatSummary = "summary"
+	syncFormatJSON    = "json"
+)
+
+// addSyncCommands adds the sync command group that copies the data
+// directory to and from a remote store
+func addSyncCommands() *cli.Command {
+	return &cli.Command{
+		Name:  "sync",
+		Usage: "Sync ~/.cr-api with S3-compatible storage or WebDAV across machines",
+		Commands: []*cli.Command{
+			{
+				Name:   "push",
+				Usage:  "Upload files that are new or newer than the remote copy",
+				Flags:  syncFlags(),
+				Action: syncCommand(datasync.Push),
+			},
+			{
+				Name:   "pull",
+				Usage:  "Download files that are new or newer than the local copy",
+				Flags:  syncFlags(),
+				Action: syncCommand(datasync.Pull),
+			},
+		},
+	}
+}
+
+func syncFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "sync-remote",
+			Usage:   "Remote location: s3://bucket/prefix, or an http(s):// WebDAV folder URL",
+			Sources: cli.EnvVars("CR_API_SYNC_REMOTE"),
+		},
+		&cli.StringFlag{
+			Name:  "dir",
+			Usage: "Local directory to sync (default: ~/.cr-api)",
+		},
+		&cli.StringSliceFlag{
+			Name:  "exclude",
+			Usage: "Glob of files to skip, matched against the file name or its path in the directory (repeatable)",
+		},
+		&cli.BoolFlag{
+			Name:  "force",
+			Usage: "Overwrite files whose destination copy is newer",
+		},
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Show what would be copied without copying",
+		},
+		&cli.StringFlag{
+			Name:  "format",
+			Value: syncFormatSummary,
+			Usage: "Output format: summary, json",
+		},
+		&cli.StringFlag{
+			Name:    "sync-user",
+			Usage:   "WebDAV username",
+			Sources: cli.EnvVars("CR_API_SYNC_USER"),
+		},
+		&cli.StringFlag{
+			Name:    "sync-password",
+			Usage:   "WebDAV password or app token",
+			Sources: cli.EnvVars("CR_API_SYNC_PASSWORD"),
+		},
+		&cli.StringFlag{
+			Name:    "s3-endpoint",
+			Usage:   "S3 service URL for MinIO, R2, B2, ... (default: AWS for --s3-region)",
+			Sources: cli.EnvVars("CR_API_S3_ENDPOINT", "AWS_ENDPOINT_URL_S3"),
+		},
+		&cli.StringFlag{
+			Name:    "s3-region",
+			Value:   "us-east-1",
+			Usage:   "S3 signing region",
+			Sources: cli.EnvVars("AWS_REGION"),
+		},
+		&cli.StringFlag{
+			Name:    "s3-access-key",
+			Usage:   "S3 access key ID",
+			Sources: cli.EnvVars("AWS_ACCESS_KEY_ID"),
+		},
+		&cli.StringFlag{
+			Name:    "s3-secret-key",
+			Usage:   "S3 secret access key",
+			Sources: cli.EnvVars("AWS_SECRET_ACCESS_KEY"),
+		},
+	}
+}
+
+func syncCommand(direction datasync.Direction) cli.ActionFunc {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		format := strings.ToLower(cmd.String("format"))
+		if format != syncFormatSummary && format != syncFormatJSON {
+			return fmt.Errorf("invalid format %q (supported: summary, json)", format)
+		}
+		backend, err := newSyncBackend(cmd)
+		if err != nil {
+			return err
+		}
+		dir := cmd.String("dir")
+		if dir == "" {
+			if dir, err = datapath.AppDir(); err != nil {
+				return err
+			}
+		}
+
+		report, err := datasync.Sync(ctx, backend, direction, datasync.Options{
+			Dir:     dir,
+			Exclude: cmd.StringSlice("exclude"),
+			Force:   cmd.Bool("force"),
+			DryRun:  cmd.Bool("dry-run"),
+		})
+		if err != nil {
+			return err
+		}
+
+		if format == syncFormatJSON {
+			data, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format JSON: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+		printSyncSummary(report)
+		return nil
+	}
+}
+
+// newSyncBackend picks the backend from the --sync-remote scheme
+func newSyncBackend(cmd *cli.Command) (datasync.Backend, error) {
+	remote := strings.TrimSpace(cmd.String("sync-remote"))
+	if remote == "" {
+		return nil, fmt.Errorf("--sync-remote is required (or set sync-remote in the config file)")
+	}
+	u, err := url.Parse(remote)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --sync-remote %q: %w", remote, err)
+	}
+
+	switch u.Scheme {
+	case "s3":
+		if u.Host == "" {
+			return nil, fmt.Errorf("invalid --sync-remote %q: missing bucket (s3://bucket/prefix)", remote)
+		}
+		if cmd.String("s3-access-key") == "" || cmd.String("s3-secret-key") == "" {
+			return nil, fmt.Errorf("S3 sync needs --s3-access-key and --s3-secret-key (or AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY)")
+		}
+		region := cmd.String("s3-region")
+		endpoint := cmd.String("s3-endpoint")
+		if endpoint == "" {
+			endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", region)
+		}
+		return &datasync.S3{
+			Endpoint:  endpoint,
+			Region:    region,
+			Bucket:    u.Host,
+			Prefix:    strings.Trim(u.Path, "/"),
+			AccessKey: cmd.String("s3-access-key"),
+			SecretKey: cmd.String("s3-secret-key"),
+		}, nil
+	case "http", "https":
+		return &datasync.WebDAV{
+			URL:      remote,
+			Username: cmd.String("sync-user"),
+			Password: cmd.String("sync-password"),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported --sync-remote %q (use s3://bucket/prefix or an http(s):// WebDAV URL)", remote)
+	}
+}
+
+func printSyncSummary(report datasync.Report) {
+	transferred, conflicts := report.Transferred(), report.Conflicts()
+	if len(report.Actions) > 0 {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fprintf(w, "Action\tFile\tSize\tReason\n")
+		for _, a := range report.Actions {
+			fprintf(w, "%s\t%s\t%s\t%s\n", a.Op, a.Path, humanReadableBytes(a.Size), a.Reason)
+		}
+		flushWriter(w)
+		printf("\n")
+	}
+
+	var total int64
+	for _, a := range transferred {
+		total += a.Size
+	}
+	verb := map[datasync.Direction]string{datasync.Push: "Pushed", datasync.Pull: "Pulled"}[report.Direction]
+	preposition := map[datasync.Direction]string{datasync.Push: "to", datasync.Pull: "from"}[report.Direction]
+	if report.DryRun {
+		verb = "Would " + strings.ToLower(strings.TrimSuffix(verb, "ed"))
+	}
+	printf("%s %d files (%s) %s %s; %d unchanged\n", verb, len(transferred), humanReadableBytes(total), preposition, report.Remote, report.Unchanged)
+	if len(conflicts) > 0 {
+		printf("%d files skipped because the destination copy is newer; rerun with --force to overwrite them\n", len(conflicts))
+	}
+}
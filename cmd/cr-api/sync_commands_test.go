@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+)
+
+func runSyncCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	root := &cli.Command{Name: "cr-api", Commands: []*cli.Command{addSyncCommands()}}
+	return captureStdout(t, func() error {
+		return root.Run(context.Background(), append([]string{"cr-api", "sync"}, args...))
+	})
+}
+
+func TestSyncPushPullCommands(t *testing.T) {
+	var mu sync.Mutex
+	files := map[string][]byte{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodGet:
+			data, ok := files[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write(data)
+		case http.MethodPut:
+			files[r.URL.Path], _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer server.Close()
+
+	laptop, desktop := t.TempDir(), t.TempDir()
+	if err := os.WriteFile(filepath.Join(laptop, "fuzz_top_decks.db"), []byte("decks"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	remote := server.URL + "/cr-api"
+
+	output, err := runSyncCommand(t, "push", "--sync-remote", remote, "--dir", laptop, "--dry-run")
+	if err != nil || !strings.Contains(output, "Would push 1 files") || len(files) != 0 {
+		t.Fatalf("dry-run push output = %q, %v", output, err)
+	}
+	output, err = runSyncCommand(t, "push", "--sync-remote", remote, "--dir", laptop)
+	if err != nil || !strings.Contains(output, "Pushed 1 files") {
+		t.Fatalf("push output = %q, %v", output, err)
+	}
+	output, err = runSyncCommand(t, "pull", "--sync-remote", remote, "--dir", desktop)
+	if err != nil || !strings.Contains(output, "Pulled 1 files") {
+		t.Fatalf("pull output = %q, %v", output, err)
+	}
+	if data, err := os.ReadFile(filepath.Join(desktop, "fuzz_top_decks.db")); err != nil || string(data) != "decks" {
+		t.Errorf("pulled file = %q, %v", data, err)
+	}
+}
+
+func TestNewSyncBackendValidatesRemote(t *testing.T) {
+	for _, args := range [][]string{
+		{"push"},
+		{"push", "--sync-remote", "ftp://example.com/data"},
+		{"push", "--sync-remote", "s3://bucket/cr-api"},
+	} {
+		t.Setenv("AWS_ACCESS_KEY_ID", "")
+		t.Setenv("CR_API_SYNC_REMOTE", "")
+		if _, err := runSyncCommand(t, append(args, "--dir", t.TempDir())...); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/internal/tui"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/klauer/clash-royale-api/go/pkg/fuzzstorage"
+	"github.com/urfave/cli/v3"
+)
+
+// addTUICommand adds the interactive browser command
+func addTUICommand() *cli.Command {
+	return &cli.Command{
+		Name:  "tui",
+		Usage: "Browse player info, cards, saved decks, and the fuzz leaderboard interactively",
+		Flags: []cli.Flag{
+			playerTagFlag(true),
+			&cli.IntFlag{
+				Name:  "leaderboard-limit",
+				Value: 100,
+				Usage: "Number of top fuzz decks to list on the Leaderboard tab",
+			},
+		},
+		Action: tuiCommand,
+	}
+}
+
+func tuiCommand(ctx context.Context, cmd *cli.Command) error {
+	tag := cmd.String("tag")
+	dataDir := cmd.String("data-dir")
+
+	client, err := requireAPIClient(cmd, apiClientOptions{})
+	if err != nil {
+		return err
+	}
+	player, err := client.GetPlayerWithContext(ctx, tag)
+	if err != nil {
+		return fmt.Errorf("failed to get player: %w", err)
+	}
+
+	savedDecks, err := loadSavedDecks(dataDir)
+	if err != nil {
+		fprintf(os.Stderr, "Warning: failed to load saved decks: %v\n", err)
+	}
+	leaderboard, err := loadFuzzLeaderboard(cmd.Int("leaderboard-limit"))
+	if err != nil {
+		fprintf(os.Stderr, "Warning: failed to load fuzz leaderboard: %v\n", err)
+	}
+
+	return tui.Run(ctx, tui.Source{
+		Player:      player,
+		SavedDecks:  savedDecks,
+		Leaderboard: leaderboard,
+		Evaluate:    evaluateDeckForPlayer(deck.NewSynergyDatabase()),
+		Reload: func(ctx context.Context) (*clashroyale.Player, error) {
+			return client.GetPlayerWithContext(ctx, tag)
+		},
+	})
+}
+
+// evaluateDeckForPlayer scores decks with the player's card levels, the
+// same way `deck fuzz` scores generated decks.
+func evaluateDeckForPlayer(synergyDB *deck.SynergyDatabase) func(*clashroyale.Player, []string) evaluation.EvaluationResult {
+	return func(player *clashroyale.Player, cards []string) evaluation.EvaluationResult {
+		var playerContext *evaluation.PlayerContext
+		if player != nil {
+			playerContext = evaluation.NewPlayerContextFromPlayer(player)
+		}
+		return evaluation.EvaluateWithOptions(convertDeckToCandidates(cards, player), synergyDB, playerContext, evaluation.EvaluateOptions{})
+	}
+}
+
+// loadSavedDecks reads the decks saved by `deck build --save`, newest first.
+func loadSavedDecks(dataDir string) ([]tui.SavedDeck, error) {
+	paths, err := filepath.Glob(filepath.Join(dataDir, "decks", "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	builder := deck.NewBuilder(dataDir)
+	decks := make([]tui.SavedDeck, 0, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		rec, err := builder.LoadDeckFromFile(path)
+		if err != nil || len(rec.Deck) == 0 {
+			// Skip files from other tools and ones that predate the format
+			continue
+		}
+		decks = append(decks, tui.SavedDeck{
+			Name:      strings.TrimSuffix(filepath.Base(path), ".json"),
+			Cards:     rec.Deck,
+			AvgElixir: rec.AvgElixir,
+			SavedAt:   info.ModTime(),
+		})
+	}
+	slices.SortStableFunc(decks, func(a, b tui.SavedDeck) int {
+		return b.SavedAt.Compare(a.SavedAt)
+	})
+	return decks, nil
+}
+
+// loadFuzzLeaderboard returns the top decks from the fuzz storage database.
+func loadFuzzLeaderboard(limit int) ([]fuzzstorage.DeckEntry, error) {
+	storage, err := fuzzstorage.NewStorage("")
+	if err != nil {
+		return nil, err
+	}
+	defer closeFile(storage)
+	return storage.GetTopN(limit)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+func TestLoadSavedDecks(t *testing.T) {
+	dataDir := t.TempDir()
+	builder := deck.NewBuilder(dataDir)
+	older, err := builder.SaveDeck(&deck.DeckRecommendation{Deck: []string{"Knight"}, AvgElixir: 3}, "", "#0L9")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := builder.SaveDeck(&deck.DeckRecommendation{Deck: []string{"Hog Rider", "The Log"}, AvgElixir: 3}, "", "#2UV"); err != nil {
+		t.Fatal(err)
+	}
+	past := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(older, past, past); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "decks", "notes.json"), []byte(`{"name":"x"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	decks, err := loadSavedDecks(dataDir)
+	if err != nil {
+		t.Fatalf("loadSavedDecks() error = %v", err)
+	}
+	if len(decks) != 2 {
+		t.Fatalf("loaded %d decks, want 2 (non-deck JSON skipped): %+v", len(decks), decks)
+	}
+	if decks[0].Cards[0] != "Hog Rider" || decks[1].Cards[0] != "Knight" {
+		t.Errorf("decks should be newest first: %+v", decks)
+	}
+
+	if decks, err := loadSavedDecks(filepath.Join(dataDir, "missing")); err != nil || len(decks) != 0 {
+		t.Errorf("missing directory = %v, %v", decks, err)
+	}
+}
+
+func TestEvaluateDeckForPlayerUsesPlayerLevels(t *testing.T) {
+	evaluate := evaluateDeckForPlayer(deck.NewSynergyDatabase())
+	cards := []string{"Knight", "Archers", "Fireball", "Hog Rider", "The Log", "Musketeer", "Ice Spirit", "Cannon"}
+
+	collection := make([]clashroyale.Card, 0, len(cards))
+	for _, name := range cards {
+		collection = append(collection, clashroyale.Card{Name: name, Level: 5, MaxLevel: 16, Rarity: "Common"})
+	}
+	low := evaluate(&clashroyale.Player{Tag: "#LOW", Cards: collection}, cards)
+	for i := range collection {
+		collection[i].Level = 16
+	}
+	high := evaluate(&clashroyale.Player{Tag: "#HIGH", Cards: collection}, cards)
+
+	if len(low.Deck) != len(cards) {
+		t.Fatalf("evaluated deck = %v", low.Deck)
+	}
+	if high.OverallScore <= low.OverallScore {
+		t.Errorf("maxed cards scored %.2f, underleveled %.2f; levels should matter", high.OverallScore, low.OverallScore)
+	}
+}
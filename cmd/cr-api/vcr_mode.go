@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	vcrFlagName    = "vcr"
+	vcrDirFlagName = "vcr-dir"
+)
+
+// apiVCRMode and apiVCRDir are set by the global --vcr and --vcr-dir
+// flags. Every client the CLI creates then records its responses to, or
+// replays them from, apiVCRDir.
+var (
+	apiVCRMode clashroyale.VCRMode
+	apiVCRDir  string
+)
+
+// configureVCR resolves --vcr for this invocation. The response cache is
+// bypassed in both modes, so recordings hold every response and replays
+// show exactly what was recorded.
+func configureVCR(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+	apiVCRMode, apiVCRDir = "", ""
+	value := cmd.String(vcrFlagName)
+	if value == "" {
+		return ctx, nil
+	}
+	mode, err := clashroyale.ParseVCRMode(value)
+	if err != nil {
+		return ctx, err
+	}
+	if cmd.Bool(offlineFlagName) {
+		return ctx, errors.New("--vcr and --offline cannot be combined; --vcr replay already works without the API")
+	}
+	apiVCRMode = mode
+	apiVCRDir = cmd.String(vcrDirFlagName)
+	if apiVCRDir == "" {
+		apiVCRDir = filepath.Join(cmd.String("data-dir"), "cassettes")
+	}
+	apiResponseCache = nil
+	return ctx, nil
+}
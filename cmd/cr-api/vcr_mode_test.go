@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+)
+
+func TestVCRRecordsAndReplaysWithoutToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"tag":"#2PP","name":"Recorded"}`)
+	}))
+	dataDir := t.TempDir()
+	t.Setenv(apiTokenEnvVar, "")
+	t.Cleanup(func() { apiVCRMode, apiVCRDir, apiBaseURL = "", "", "" })
+
+	run := func(args ...string) (string, error) {
+		var name string
+		root := &cli.Command{
+			Name: "cr-api",
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "api-token"},
+				&cli.StringFlag{Name: "api-base"},
+				&cli.StringFlag{Name: "data-dir", Value: dataDir},
+				&cli.BoolFlag{Name: offlineFlagName},
+				&cli.StringFlag{Name: vcrFlagName},
+				&cli.StringFlag{Name: vcrDirFlagName},
+			},
+			Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+				if ctx, err := configureVCR(ctx, cmd); err != nil {
+					return ctx, err
+				}
+				return configureAPIBase(ctx, cmd)
+			},
+			Action: func(ctx context.Context, cmd *cli.Command) error {
+				client, err := requireAPIClient(cmd, apiClientOptions{})
+				if err != nil {
+					return err
+				}
+				player, err := client.GetPlayerWithContext(ctx, "#2PP")
+				if err != nil {
+					return err
+				}
+				name = player.Name
+				return nil
+			},
+		}
+		err := root.Run(context.Background(), append([]string{"cr-api"}, args...))
+		return name, err
+	}
+
+	if _, err := run("--vcr", "record", "--api-token", "token", "--api-base", server.URL+"/v1"); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	if apiVCRDir != filepath.Join(dataDir, "cassettes") {
+		t.Errorf("default VCR dir = %q", apiVCRDir)
+	}
+	server.Close()
+
+	name, err := run("--vcr", "replay")
+	if err != nil || name != "Recorded" {
+		t.Errorf("replay = %q, %v; want the recorded player", name, err)
+	}
+
+	if _, err := run("--vcr", "replay", "--offline"); err == nil || !strings.Contains(err.Error(), "cannot be combined") {
+		t.Errorf("--vcr with --offline error = %v", err)
+	}
+}
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/notify"
+	"github.com/klauer/clash-royale-api/go/internal/sqlstore"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/urfave/cli/v3"
+)
+
+// minWatchInterval keeps polling well inside the API rate limits.
+const minWatchInterval = 10 * time.Second
+
+// addWatchCommand adds the live battle tracking command
+func addWatchCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "watch",
+		Usage: "Poll a player's battle log and print new battles as they are played",
+		Flags: slices.Concat([]cli.Flag{
+			playerTagFlag(true),
+			&cli.DurationFlag{
+				Name:  "interval",
+				Value: time.Minute,
+				Usage: "Time between battle log polls (minimum 10s)",
+			},
+			&cli.IntFlag{
+				Name:  "recent",
+				Value: 0,
+				Usage: "Also print this many of the most recent battles on startup",
+			},
+			&cli.BoolFlag{
+				Name:  "record",
+				Value: true,
+				Usage: "Append new 1v1 battles to the history database (<data-dir>/cr-api.db)",
+			},
+			&cli.IntFlag{
+				Name:  "notify-loss-streak",
+				Value: 3,
+				Usage: "Post a notification after this many losses in a row (0 = never)",
+			},
+			trackDecksFlag(),
+			metricsAddrFlag(),
+		}, notifyFlags()),
+		Action: watchCommand,
+	}
+}
+
+func watchCommand(ctx context.Context, cmd *cli.Command) error {
+	tag := cmd.String("tag")
+	interval := cmd.Duration("interval")
+	if interval < minWatchInterval {
+		return fmt.Errorf("--interval must be at least %s", minWatchInterval)
+	}
+
+	if err := requireOnline(cmd, "watch"); err != nil {
+		return err
+	}
+
+	// Bypass the response cache: a cached battle log would hide new battles
+	token, err := requireAPIToken(cmd, apiClientOptions{})
+	if err != nil {
+		return err
+	}
+	client := newAPIClient(token)
+
+	w := &battleWatcher{
+		tag:         tag,
+		out:         os.Stdout,
+		notifier:    notifierFromCommand(cmd),
+		streakAlert: cmd.Int("notify-loss-streak"),
+		fetch: func(ctx context.Context) ([]clashroyale.Battle, error) {
+			log, err := client.GetPlayerBattleLogWithContext(ctx, tag)
+			if err != nil {
+				return nil, err
+			}
+			return *log, nil
+		},
+	}
+	if cmd.Bool("record") {
+		db, err := openHistoryDB(cmd)
+		if err != nil {
+			return err
+		}
+		defer closeFile(db)
+		w.record = func(battles []clashroyale.Battle) (int, error) {
+			return db.RecordBattles(tag, battles)
+		}
+		printf("Recording new battles in %s\n", sqlstore.DefaultPath(cmd.String("data-dir")))
+	}
+	if cmd.Bool("track-decks") {
+		w.trackDecks = func(battles []clashroyale.Battle) (int, error) {
+			return recordStoredDeckOutcomes(tag, battles)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	if err := startMetricsServer(ctx, cmd); err != nil {
+		return err
+	}
+
+	if err := w.start(ctx, cmd.Int("recent")); err != nil {
+		return fmt.Errorf("failed to get battle log: %w", err)
+	}
+	printf("Watching %s every %s (Ctrl+C to stop)\n", tag, interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			w.printSummary()
+			return nil
+		case <-ticker.C:
+			if err := w.poll(ctx); err != nil && ctx.Err() == nil {
+				fprintf(os.Stderr, "Warning: poll failed, retrying in %s: %v\n", interval, err)
+			}
+		}
+	}
+}
+
+// battleWatcher tracks which battles of a player's log have been seen and
+// reports the new ones on each poll.
+type battleWatcher struct {
+	tag    string
+	out    io.Writer
+	fetch  func(ctx context.Context) ([]clashroyale.Battle, error)
+	record func(battles []clashroyale.Battle) (int, error)
+	// trackDecks records the results of battles played with saved decks
+	trackDecks func(battles []clashroyale.Battle) (int, error)
+
+	// notifier receives a loss streak event every streakAlert losses in a row
+	notifier    notify.Notifier
+	streakAlert int
+
+	seen                map[string]bool
+	wins, losses, draws int
+	trophyDelta         int
+	// lossStreak holds the opponent archetypes of the current losing run
+	lossStreak []string
+}
+
+// start takes the initial snapshot of the battle log so only battles played
+// afterwards are reported. The newest recent battles are printed (and
+// recorded) as a catch-up.
+func (w *battleWatcher) start(ctx context.Context, recent int) error {
+	battles, err := w.fetch(ctx)
+	if err != nil {
+		return err
+	}
+	w.seen = make(map[string]bool, len(battles))
+	for _, battle := range battles {
+		w.seen[watchBattleKey(battle)] = true
+	}
+	// The API lists the newest battle first
+	shown := battles[:min(max(recent, 0), len(battles))]
+	if len(shown) > 0 {
+		w.report(ctx, slices.Clone(shown), false)
+	}
+	return nil
+}
+
+// poll fetches the battle log and reports battles not seen before.
+func (w *battleWatcher) poll(ctx context.Context) error {
+	battles, err := w.fetch(ctx)
+	if err != nil {
+		return err
+	}
+	var fresh []clashroyale.Battle
+	for _, battle := range battles {
+		key := watchBattleKey(battle)
+		if w.seen[key] {
+			continue
+		}
+		w.seen[key] = true
+		fresh = append(fresh, battle)
+	}
+	if len(fresh) > 0 {
+		w.report(ctx, fresh, true)
+	}
+	return nil
+}
+
+// report prints battles oldest first and records them. Only live battles
+// count towards the session summary.
+func (w *battleWatcher) report(ctx context.Context, battles []clashroyale.Battle, live bool) {
+	slices.Reverse(battles)
+	for _, battle := range battles {
+		fprintf(w.out, "%s\n", formatWatchBattle(battle))
+		if !live {
+			continue
+		}
+		switch battleResult(battle) {
+		case "Win":
+			w.wins++
+			w.lossStreak = nil
+		case "Loss":
+			w.losses++
+			w.lossStreak = append(w.lossStreak, opponentArchetype(battle))
+			if w.streakAlert > 0 && len(w.lossStreak)%w.streakAlert == 0 {
+				sendNotification(ctx, w.notifier, w.lossStreakEvent())
+			}
+		default:
+			w.draws++
+			w.lossStreak = nil
+		}
+		if len(battle.Team) > 0 {
+			w.trophyDelta += battle.Team[0].TrophyChange
+		}
+	}
+	if w.record != nil {
+		if _, err := w.record(battles); err != nil {
+			fprintf(os.Stderr, "Warning: failed to record battles: %v\n", err)
+		}
+	}
+	if w.trackDecks != nil {
+		if n, err := w.trackDecks(battles); err != nil {
+			fprintf(os.Stderr, "Warning: failed to record saved deck results: %v\n", err)
+		} else if n > 0 {
+			fprintf(w.out, "    Recorded %d result(s) for saved decks\n", n)
+		}
+	}
+}
+
+// lossStreakEvent describes the current losing run, naming the archetype
+// behind most of the losses when there is one.
+func (w *battleWatcher) lossStreakEvent() notify.Event {
+	counts := make(map[string]int)
+	for _, archetype := range w.lossStreak {
+		if archetype != "" {
+			counts[archetype]++
+		}
+	}
+	var top string
+	for archetype, n := range counts {
+		if n > counts[top] || (n == counts[top] && archetype < top) {
+			top = archetype
+		}
+	}
+
+	n := len(w.lossStreak)
+	message := fmt.Sprintf("%s lost %d in a row", w.tag, n)
+	switch {
+	case counts[top] == n:
+		message += " to " + top
+	case counts[top]*2 > n:
+		message += fmt.Sprintf(", %d of them to %s", counts[top], top)
+	}
+	return notify.Event{
+		Kind:    notify.KindLossStreak,
+		Title:   fmt.Sprintf("Lost %d in a row", n),
+		Message: message,
+		Time:    time.Now().UTC(),
+		Fields: map[string]any{
+			"player_tag":           w.tag,
+			"streak":               n,
+			"opponent_archetypes":  slices.Clone(w.lossStreak),
+			"session_trophy_delta": w.trophyDelta,
+		},
+	}
+}
+
+func (w *battleWatcher) printSummary() {
+	fprintf(w.out, "\nSession: %dW %dL %dD, %+d trophies\n", w.wins, w.losses, w.draws, w.trophyDelta)
+}
+
+// watchBattleKey identifies a battle the same way the history database does.
+func watchBattleKey(battle clashroyale.Battle) string {
+	key := battle.UTCDate.UTC().Format(time.RFC3339)
+	if len(battle.Opponent) > 0 {
+		key += "|" + battle.Opponent[0].Tag
+	}
+	return key
+}
+
+// battleResult compares crowns from the watched player's side.
+func battleResult(battle clashroyale.Battle) string {
+	if len(battle.Team) == 0 || len(battle.Opponent) == 0 {
+		return "Draw"
+	}
+	switch team, opp := battle.Team[0].Crowns, battle.Opponent[0].Crowns; {
+	case team > opp:
+		return "Win"
+	case team < opp:
+		return "Loss"
+	default:
+		return "Draw"
+	}
+}
+
+// formatWatchBattle renders one battle as a result line followed by the
+// opponent's deck.
+func formatWatchBattle(battle clashroyale.Battle) string {
+	mode := battle.GameMode.Name
+	if mode == "" {
+		mode = battle.Type
+	}
+	line := fmt.Sprintf("%s  %-4s", battle.UTCDate.Local().Format("2006-01-02 15:04"), battleResult(battle))
+	if len(battle.Team) == 0 || len(battle.Opponent) == 0 {
+		return line + "  " + mode
+	}
+
+	team, opp := battle.Team[0], battle.Opponent[0]
+	line += fmt.Sprintf("  %d-%d", team.Crowns, opp.Crowns)
+	if team.TrophyChange != 0 {
+		line += fmt.Sprintf("  %+d 🏆 (%d)", team.TrophyChange, team.StartingTrophies+team.TrophyChange)
+	}
+	line += fmt.Sprintf("  vs %s (%s)  %s", opp.Name, opp.Tag, mode)
+
+	cards := opponentCards(battle)
+	if len(cards) == 0 {
+		return line
+	}
+	return fmt.Sprintf("%s\n    Opponent deck (%s): %s", line, classifyMetaArchetype(cards), strings.Join(cards, ", "))
+}
+
+func opponentCards(battle clashroyale.Battle) []string {
+	if len(battle.Opponent) == 0 {
+		return nil
+	}
+	cards := make([]string, 0, len(battle.Opponent[0].Cards))
+	for _, card := range battle.Opponent[0].Cards {
+		cards = append(cards, card.Name)
+	}
+	return cards
+}
+
+// opponentArchetype detects the opponent's deck archetype, or "" when the
+// battle log has no deck for them.
+func opponentArchetype(battle clashroyale.Battle) string {
+	cards := opponentCards(battle)
+	if len(cards) == 0 {
+		return ""
+	}
+	return classifyMetaArchetype(cards)
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+func watchBattle(at time.Time, opponent string, crowns, oppCrowns, change int) clashroyale.Battle {
+	return clashroyale.Battle{
+		Type:     "PvP",
+		UTCDate:  at,
+		GameMode: clashroyale.GameMode{Name: "Ladder"},
+		Team: []clashroyale.BattleTeam{{
+			Tag: "#ABC", Crowns: crowns, StartingTrophies: 7000, TrophyChange: change,
+			Cards: []clashroyale.Card{{Name: "Hog Rider"}},
+		}},
+		Opponent: []clashroyale.BattleTeam{{
+			Tag: opponent, Name: "Rival", Crowns: oppCrowns,
+			Cards: []clashroyale.Card{{Name: "Golem"}, {Name: "Night Witch"}, {Name: "Baby Dragon"}, {Name: "Lightning"}},
+		}},
+	}
+}
+
+func TestBattleWatcherReportsOnlyNewBattles(t *testing.T) {
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	old := watchBattle(base, "#OLD", 1, 0, 30)
+	log := []clashroyale.Battle{old}
+
+	var out bytes.Buffer
+	var recorded []string
+	w := &battleWatcher{
+		tag: "#ABC",
+		out: &out,
+		fetch: func(context.Context) ([]clashroyale.Battle, error) {
+			return log, nil
+		},
+		record: func(battles []clashroyale.Battle) (int, error) {
+			for _, b := range battles {
+				recorded = append(recorded, b.Opponent[0].Tag)
+			}
+			return len(battles), nil
+		},
+	}
+	ctx := context.Background()
+	if err := w.start(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if out.Len() != 0 || len(recorded) != 0 {
+		t.Fatalf("start should only snapshot the log, printed %q, recorded %v", out.String(), recorded)
+	}
+
+	// Newest first, as the API returns them
+	log = []clashroyale.Battle{
+		watchBattle(base.Add(10*time.Minute), "#TWO", 0, 3, -28),
+		watchBattle(base.Add(5*time.Minute), "#ONE", 2, 1, 31),
+		old,
+	}
+	if err := w.poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(recorded, ","); got != "#ONE,#TWO" {
+		t.Errorf("recorded %s, want each new battle once, oldest first", got)
+	}
+	text := out.String()
+	if first, second := strings.Index(text, "(#ONE)"), strings.Index(text, "(#TWO)"); first < 0 || second < first {
+		t.Errorf("battles should print oldest first:\n%s", text)
+	}
+
+	out.Reset()
+	w.printSummary()
+	if got := strings.TrimSpace(out.String()); got != "Session: 1W 1L 0D, +3 trophies" {
+		t.Errorf("summary = %q", got)
+	}
+}
+
+func TestBattleWatcherRecentCatchUp(t *testing.T) {
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	log := []clashroyale.Battle{
+		watchBattle(base.Add(2*time.Minute), "#C", 1, 1, 0),
+		watchBattle(base.Add(time.Minute), "#B", 1, 0, 30),
+		watchBattle(base, "#A", 1, 0, 30),
+	}
+	var out bytes.Buffer
+	w := &battleWatcher{out: &out, fetch: func(context.Context) ([]clashroyale.Battle, error) { return log, nil }}
+	if err := w.start(context.Background(), 2); err != nil {
+		t.Fatal(err)
+	}
+	text := out.String()
+	if strings.Contains(text, "(#A)") || strings.Index(text, "(#B)") > strings.Index(text, "(#C)") {
+		t.Errorf("catch-up should show the 2 newest battles oldest first:\n%s", text)
+	}
+	if log[0].Opponent[0].Tag != "#C" {
+		t.Error("start must not reorder the fetched log")
+	}
+	if w.wins+w.losses+w.draws != 0 {
+		t.Error("catch-up battles should not count towards the session")
+	}
+}
+
+func TestFormatWatchBattle(t *testing.T) {
+	got := formatWatchBattle(watchBattle(time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC), "#OPP", 3, 1, 32))
+	for _, want := range []string{"Win", "3-1", "+32 🏆 (7032)", "vs Rival (#OPP)", "Ladder", "Opponent deck (beatdown): Golem, Night Witch"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("formatWatchBattle() missing %q:\n%s", want, got)
+		}
+	}
+
+	friendly := watchBattle(time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC), "#OPP", 0, 1, 0)
+	if got := formatWatchBattle(friendly); !strings.Contains(got, "Loss") || strings.Contains(got, "🏆") {
+		t.Errorf("no-trophy battle = %q", got)
+	}
+}
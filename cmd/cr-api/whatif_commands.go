@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 
+	"github.com/klauer/clash-royale-api/go/internal/schema"
 	"github.com/klauer/clash-royale-api/go/pkg/analysis"
 	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
 	"github.com/klauer/clash-royale-api/go/pkg/deck"
@@ -14,7 +16,10 @@ import (
 	"github.com/urfave/cli/v3"
 )
 
-const whatIfScenarioArtifactStem = "scenario"
+const (
+	whatIfScenarioArtifactStem = "scenario"
+	whatIfBudgetArtifactStem   = "budget"
+)
 
 // addWhatIfCommands adds what-if analysis commands to the CLI
 func addWhatIfCommands() *cli.Command {
@@ -25,10 +30,18 @@ func addWhatIfCommands() *cli.Command {
 		Flags: []cli.Flag{
 			playerTagFlag(true),
 			&cli.StringSliceFlag{
-				Name:     "upgrade",
-				Aliases:  []string{"u"},
-				Usage:    "Card upgrades to simulate (format: CardName:ToLevel or CardName:FromLevel:ToLevel)",
-				Required: true,
+				Name:    "upgrade",
+				Aliases: []string{"u"},
+				Usage:   "Card upgrades to simulate (format: CardName:ToLevel or CardName:FromLevel:ToLevel)",
+			},
+			&cli.IntFlag{
+				Name:  "gold",
+				Usage: "Plan the upgrades that best improve your top decks within this gold budget (instead of --upgrade)",
+			},
+			&cli.IntFlag{
+				Name:  "decks",
+				Value: whatif.DefaultBudgetDecks,
+				Usage: "With --gold, number of best decks to improve",
 			},
 			&cli.StringFlag{
 				Name:  "from-analysis",
@@ -67,6 +80,16 @@ func whatIfCommand(ctx context.Context, cmd *cli.Command) error {
 	apiToken := cmd.String("api-token")
 	verbose := cmd.Bool("verbose")
 	dataDir := cmd.String("data-dir")
+	gold := cmd.Int("gold")
+
+	switch {
+	case gold > 0 && len(upgradesSpec) > 0:
+		return fmt.Errorf("--gold and --upgrade cannot be combined")
+	case gold < 0:
+		return fmt.Errorf("--gold must be positive")
+	case gold == 0 && len(upgradesSpec) == 0:
+		return fmt.Errorf("provide --upgrade to simulate specific upgrades or --gold to plan a budget")
+	}
 
 	// Load card levels and player info
 	cardLevels, playerName, err := loadCardLevelsForWhatIf(ctx, fromAnalysis, tag, apiToken, verbose)
@@ -74,6 +97,14 @@ func whatIfCommand(ctx context.Context, cmd *cli.Command) error {
 		return err
 	}
 
+	if gold > 0 {
+		plan, err := runWhatIfBudget(cardLevels, gold, cmd.Int("decks"), dataDir, verbose)
+		if err != nil {
+			return err
+		}
+		return outputWhatIfBudget(plan, playerName, tag, jsonOutput, showDecks, saveData, dataDir)
+	}
+
 	// Parse upgrade specifications
 	upgrades, err := parseUpgradeSpecs(upgradesSpec, verbose)
 	if err != nil {
@@ -200,6 +231,113 @@ func outputWhatIfResults(scenario *whatif.WhatIfScenario, jsonOutput, showDecks,
 	return nil
 }
 
+// runWhatIfBudget builds a deck for every strategy from the current levels
+// and plans the upgrades that raise the best ones most within the budget.
+func runWhatIfBudget(cardLevels map[string]deck.CardLevelData, gold, topDecks int, dataDir string, verbose bool) (*whatif.BudgetPlan, error) {
+	decks, err := buildStrategyDecks(cardLevels, dataDir, verbose)
+	if err != nil {
+		return nil, err
+	}
+	if verbose {
+		printf("Planning %d gold across the best %d of %d candidate decks...\n", gold, topDecks, len(decks))
+	}
+
+	plan, err := whatif.OptimizeBudget(cardLevels, decks, whatif.BudgetOptions{Gold: gold, TopDecks: topDecks}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to plan gold budget: %w", err)
+	}
+	return plan, nil
+}
+
+// buildStrategyDecks builds one deck per strategy from the card levels,
+// skipping strategies the collection cannot fill
+func buildStrategyDecks(cardLevels map[string]deck.CardLevelData, dataDir string, verbose bool) ([][]string, error) {
+	decks := make([][]string, 0, len(getAllDeckStrategies()))
+	for _, strategy := range getAllDeckStrategies() {
+		builder := deck.NewBuilder(dataDir)
+		if err := builder.SetStrategy(strategy); err != nil {
+			return nil, fmt.Errorf("invalid strategy '%s': %w", strategy, err)
+		}
+		rec, err := builder.BuildDeckFromAnalysis(deck.CardAnalysis{CardLevels: cardLevels})
+		if err != nil {
+			if verbose {
+				printf("Skipping %s deck: %v\n", strategy, err)
+			}
+			continue
+		}
+		decks = append(decks, rec.Deck)
+	}
+	return decks, nil
+}
+
+// outputWhatIfBudget prints or saves a gold budget plan
+func outputWhatIfBudget(plan *whatif.BudgetPlan, playerName, tag string, jsonOutput, showDecks, saveData bool, dataDir string) error {
+	if jsonOutput {
+		data, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal budget plan: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	displayWhatIfBudget(plan, playerName, tag, showDecks)
+
+	if saveData {
+		filename, err := saveTimestampedJSONArtifact(dataDir, plan, timestampedJSONArtifactOptions{
+			subdir:   "whatif",
+			fileStem: whatIfBudgetArtifactStem,
+		})
+		if err != nil {
+			printf("Warning: Failed to save budget plan: %v\n", err)
+		} else {
+			printf("\nBudget plan saved to: %s\n", filename)
+		}
+	}
+	return nil
+}
+
+func displayWhatIfBudget(plan *whatif.BudgetPlan, playerName, tag string, showDecks bool) {
+	printf("\n")
+	printf("============================================================================\n")
+	printf("                        GOLD BUDGET PLAN                                    \n")
+	printf("============================================================================\n\n")
+
+	if playerName != "" {
+		printf("Upgrade plan for %s (%s)\n", playerName, tag)
+	}
+	printf("Budget: %d gold, spent %d (%d left)\n\n", plan.Budget, plan.Spent, plan.Remaining())
+
+	printf("Upgrades\n")
+	printf("--------\n")
+	if len(plan.Upgrades) == 0 {
+		printf("No affordable upgrade improves these decks.\n\n")
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fprintf(w, "Card\tFrom\tTo\tGold\tScore Gain\n")
+		fprintf(w, "----\t----\t--\t----\t----------\n")
+		for _, u := range plan.Upgrades {
+			fprintf(w, "%s\t%d\t%d\t%d\t%+.3f\n", u.CardName, u.FromLevel, u.ToLevel, u.GoldCost, u.ScoreGain)
+		}
+		flushWriter(w)
+		printf("\n")
+	}
+
+	printf("Projected Deck Scores\n")
+	printf("---------------------\n")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fprintf(w, "#\tBefore\tAfter\tChange\tCards\n")
+	for i, d := range plan.Decks {
+		cards := formatCardList(d.Cards)
+		if showDecks {
+			cards = strings.Join(d.Cards, ", ")
+		}
+		fprintf(w, "%d\t%.2f\t%.2f\t%+.2f\t%s\n", i+1, d.Before, d.After, d.After-d.Before, cards)
+	}
+	flushWriter(w)
+	printf("\nTotal score gain: %+.3f\n", plan.TotalGain)
+}
+
 func displayWhatIfScenario(scenario *whatif.WhatIfScenario, showDecks bool) {
 	printf("\n")
 	printf("============================================================================\n")
@@ -343,7 +481,7 @@ func loadCardAnalysis(path string) (*analysis.CardAnalysis, error) {
 	}
 
 	var cardAnalysis analysis.CardAnalysis
-	if err := json.Unmarshal(data, &cardAnalysis); err != nil {
+	if err := schema.Unmarshal(schema.KindAnalysis, data, &cardAnalysis); err != nil {
 		return nil, fmt.Errorf("failed to parse analysis JSON: %w", err)
 	}
 
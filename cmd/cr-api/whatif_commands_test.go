@@ -0,0 +1,76 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+func budgetTestCardLevels() map[string]deck.CardLevelData {
+	cards := []struct {
+		name   string
+		rarity string
+		elixir int
+	}{
+		{"Knight", "Common", 3}, {"Archers", "Common", 3}, {"Fireball", "Rare", 4}, {"Hog Rider", "Rare", 4},
+		{"The Log", "Legendary", 2}, {"Musketeer", "Rare", 4}, {"Ice Spirit", "Common", 1}, {"Cannon", "Common", 3},
+		{"Giant", "Rare", 5}, {"Zap", "Common", 2}, {"Minions", "Common", 3}, {"Valkyrie", "Rare", 4},
+		{"Baby Dragon", "Epic", 4}, {"Skeletons", "Common", 1}, {"Mini P.E.K.K.A", "Rare", 4}, {"Arrows", "Common", 3},
+		{"Golem", "Epic", 8}, {"Night Witch", "Legendary", 4}, {"Lightning", "Epic", 6}, {"Bats", "Common", 2},
+	}
+	levels := make(map[string]deck.CardLevelData, len(cards))
+	for _, c := range cards {
+		switch c.rarity {
+		case "Common":
+			levels[c.name] = deck.CardLevelData{Level: 11, MaxLevel: 16, Rarity: c.rarity, Elixir: c.elixir}
+		case "Rare":
+			levels[c.name] = deck.CardLevelData{Level: 8, MaxLevel: 14, Rarity: c.rarity, Elixir: c.elixir}
+		case "Epic":
+			levels[c.name] = deck.CardLevelData{Level: 5, MaxLevel: 11, Rarity: c.rarity, Elixir: c.elixir}
+		default:
+			levels[c.name] = deck.CardLevelData{Level: 3, MaxLevel: 8, Rarity: c.rarity, Elixir: c.elixir}
+		}
+	}
+	return levels
+}
+
+func TestRunWhatIfBudget(t *testing.T) {
+	plan, err := runWhatIfBudget(budgetTestCardLevels(), 60000, 2, t.TempDir(), false)
+	if err != nil {
+		t.Fatalf("runWhatIfBudget() error = %v", err)
+	}
+	if len(plan.Decks) == 0 || len(plan.Decks) > 2 {
+		t.Fatalf("planned for %d decks, want 1-2", len(plan.Decks))
+	}
+	if plan.Spent > plan.Budget {
+		t.Errorf("spent %d of a %d budget", plan.Spent, plan.Budget)
+	}
+	if len(plan.Upgrades) == 0 || plan.TotalGain <= 0 {
+		t.Errorf("expected upgrades that raise the decks: %+v", plan)
+	}
+	spent := 0
+	for _, u := range plan.Upgrades {
+		spent += u.GoldCost
+	}
+	if spent != plan.Spent {
+		t.Errorf("upgrade costs sum to %d, plan spent %d", spent, plan.Spent)
+	}
+}
+
+func TestRunValueRanking(t *testing.T) {
+	values, decks, err := runValueRanking(budgetTestCardLevels(), 2, t.TempDir(), false)
+	if err != nil {
+		t.Fatalf("runValueRanking() error = %v", err)
+	}
+	if len(decks) == 0 || len(decks) > 2 {
+		t.Fatalf("ranked against %d decks, want 1-2", len(decks))
+	}
+	if len(values) == 0 {
+		t.Fatal("expected upgrades that raise the decks")
+	}
+	for i := 1; i < len(values); i++ {
+		if values[i].GainPer1000Gold > values[i-1].GainPer1000Gold {
+			t.Errorf("values not ordered by gain per gold: %+v", values)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+//go:build integration
+
+package main
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+// TestAPISchemaMatchesModels fetches live responses and fails on every
+// field the API sends that the models in pkg/clashroyale/types.go lack,
+// so a schema change on Supercell's side is caught here instead of being
+// silently dropped at decode time.
+//
+// This test requires CLASH_ROYALE_API_TOKEN and DEFAULT_PLAYER_TAG; the
+// clan endpoints are checked when the player is in a clan.
+func TestAPISchemaMatchesModels(t *testing.T) {
+	token := os.Getenv("CLASH_ROYALE_API_TOKEN")
+	if token == "" {
+		t.Skip("CLASH_ROYALE_API_TOKEN not set, skipping integration test")
+	}
+	playerTag := os.Getenv("DEFAULT_PLAYER_TAG")
+	if playerTag == "" {
+		t.Skip("DEFAULT_PLAYER_TAG not set, skipping integration test")
+	}
+
+	client := clashroyale.NewClient(token)
+	client.SetUnknownFieldHandler(func(endpoint string, fields []string) {
+		t.Errorf("%s: fields missing from the models: %s", endpoint, strings.Join(fields, ", "))
+	})
+	ctx := context.Background()
+
+	player, err := client.GetPlayerWithContext(ctx, playerTag)
+	if err != nil {
+		t.Fatalf("GetPlayer: %v", err)
+	}
+	if _, err := client.GetPlayerBattleLogWithContext(ctx, playerTag); err != nil {
+		t.Errorf("GetPlayerBattleLog: %v", err)
+	}
+	if _, err := client.GetPlayerUpcomingChestsWithContext(ctx, playerTag); err != nil {
+		t.Errorf("GetPlayerUpcomingChests: %v", err)
+	}
+	if _, err := client.GetCardsWithContext(ctx); err != nil {
+		t.Errorf("GetCards: %v", err)
+	}
+	if player.Clan == nil {
+		return
+	}
+	if _, err := client.GetClanWithContext(ctx, player.Clan.Tag); err != nil {
+		t.Errorf("GetClan: %v", err)
+	}
+	if _, err := client.GetCurrentRiverRaceWithContext(ctx, player.Clan.Tag); err != nil {
+		t.Errorf("GetCurrentRiverRace: %v", err)
+	}
+}
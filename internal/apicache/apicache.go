@@ -0,0 +1,121 @@
+// Package apicache persists Clash Royale API responses on disk so analysis
+// commands can run from previously warmed data instead of the live API.
+package apicache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/storage"
+)
+
+// Entry is the on-disk envelope for a cached response.
+type Entry struct {
+	Endpoint  string          `json:"endpoint"`
+	FetchedAt time.Time       `json:"fetched_at"`
+	Body      json.RawMessage `json:"body"`
+}
+
+// Store is a file-backed clashroyale.ResponseCache.
+//
+// Entries older than MaxAge are ignored by Get. A read-only store serves
+// existing entries but discards Put calls, which keeps ordinary commands from
+// silently refreshing the cache behind the user's back.
+type Store struct {
+	dir      string
+	maxAge   time.Duration
+	readOnly bool
+	now      func() time.Time
+}
+
+// New creates a writable store rooted at dataDir's API cache directory.
+func New(dataDir string, maxAge time.Duration) *Store {
+	return &Store{
+		dir:    storage.NewPathBuilder(dataDir).GetAPICacheDir(),
+		maxAge: maxAge,
+		now:    time.Now,
+	}
+}
+
+// NewReadOnly creates a store that serves fresh entries but never writes.
+func NewReadOnly(dataDir string, maxAge time.Duration) *Store {
+	s := New(dataDir, maxAge)
+	s.readOnly = true
+	return s
+}
+
+// Dir returns the directory holding cache entries.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Get returns the cached body for endpoint when present and fresh.
+func (s *Store) Get(endpoint string) ([]byte, bool) {
+	entry, err := s.Lookup(endpoint)
+	if err != nil || !s.fresh(entry) {
+		return nil, false
+	}
+	return entry.Body, true
+}
+
+// Fresh reports whether endpoint has an entry within the store's max age.
+func (s *Store) Fresh(endpoint string) bool {
+	entry, err := s.Lookup(endpoint)
+	return err == nil && s.fresh(entry)
+}
+
+// Lookup returns the raw entry for endpoint regardless of age. It returns an
+// error wrapping os.ErrNotExist when the endpoint has never been cached.
+func (s *Store) Lookup(endpoint string) (*Entry, error) {
+	var entry Entry
+	if err := storage.ReadJSON(s.path(endpoint), &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// Put stores body for endpoint unless the store is read-only.
+func (s *Store) Put(endpoint string, body []byte) error {
+	if s.readOnly {
+		return nil
+	}
+	entry := Entry{
+		Endpoint:  endpoint,
+		FetchedAt: s.now().UTC(),
+		Body:      json.RawMessage(body),
+	}
+	if err := storage.WriteJSON(s.path(endpoint), entry); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return nil
+}
+
+// Clear removes every cached response and returns the number of entries deleted.
+func (s *Store) Clear() (int, error) {
+	files, err := storage.ListJSONFiles(s.dir)
+	if err != nil {
+		return 0, err
+	}
+	for _, file := range files {
+		if err := storage.DeleteFile(file); err != nil {
+			return 0, err
+		}
+	}
+	return len(files), nil
+}
+
+func (s *Store) fresh(entry *Entry) bool {
+	if s.maxAge <= 0 {
+		return false
+	}
+	return s.now().Sub(entry.FetchedAt) <= s.maxAge
+}
+
+func (s *Store) path(endpoint string) string {
+	sum := sha256.Sum256([]byte(endpoint))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:12])+".json")
+}
@@ -0,0 +1,65 @@
+package apicache
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestStorePutGetRespectsMaxAge(t *testing.T) {
+	store := New(t.TempDir(), time.Hour)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	if _, ok := store.Get("/players/%23ABC"); ok {
+		t.Fatal("expected miss before Put")
+	}
+	if err := store.Put("/players/%23ABC", []byte(`{"tag":"#ABC"}`)); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	body, ok := store.Get("/players/%23ABC")
+	if !ok {
+		t.Fatal("expected hit after Put")
+	}
+	var player struct {
+		Tag string `json:"tag"`
+	}
+	if err := json.Unmarshal(body, &player); err != nil || player.Tag != "#ABC" {
+		t.Fatalf("Get() body = %s, %v", body, err)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if store.Fresh("/players/%23ABC") {
+		t.Error("entry older than max age should not be fresh")
+	}
+	if _, err := store.Lookup("/players/%23ABC"); err != nil {
+		t.Errorf("Lookup() should return stale entries, got %v", err)
+	}
+}
+
+func TestReadOnlyStoreDiscardsWrites(t *testing.T) {
+	dir := t.TempDir()
+	if err := NewReadOnly(dir, time.Hour).Put("/cards", []byte(`{}`)); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if _, ok := New(dir, time.Hour).Get("/cards"); ok {
+		t.Error("read-only store must not persist entries")
+	}
+}
+
+func TestClear(t *testing.T) {
+	store := New(t.TempDir(), time.Hour)
+	for _, endpoint := range []string{"/a", "/b"} {
+		if err := store.Put(endpoint, []byte(`{}`)); err != nil {
+			t.Fatalf("Put() error = %v", err)
+		}
+	}
+	removed, err := store.Clear()
+	if err != nil || removed != 2 {
+		t.Fatalf("Clear() = %d, %v; want 2, nil", removed, err)
+	}
+	if store.Fresh("/a") {
+		t.Error("entry should be gone after Clear")
+	}
+}
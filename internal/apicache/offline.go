@@ -0,0 +1,103 @@
+package apicache
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/storage"
+)
+
+// Offline serves cached responses for the global --offline flag. Entries
+// are used whatever their age, player profiles saved with `player --save`
+// stand in for missing /players/{tag} entries, and every hit is recorded so
+// the command can report how old its data was.
+type Offline struct {
+	store      *Store
+	playersDir string
+
+	mu     sync.Mutex
+	served int
+	oldest time.Time
+	newest time.Time
+}
+
+// NewOffline returns an offline source for dataDir.
+func NewOffline(dataDir string) *Offline {
+	return &Offline{
+		store:      New(dataDir, 0),
+		playersDir: storage.NewPathBuilder(dataDir).GetPlayersDir(),
+	}
+}
+
+// Get returns the newest cached body for endpoint.
+func (o *Offline) Get(endpoint string) ([]byte, bool) {
+	var body []byte
+	var fetchedAt time.Time
+	if entry, err := o.store.Lookup(endpoint); err == nil {
+		body, fetchedAt = entry.Body, entry.FetchedAt
+	}
+	if saved, savedAt, ok := o.savedPlayer(endpoint); ok && savedAt.After(fetchedAt) {
+		body, fetchedAt = saved, savedAt
+	}
+	if body == nil {
+		return nil, false
+	}
+	o.record(fetchedAt)
+	return body, true
+}
+
+// Put discards writes; offline mode never fetches anything new.
+func (o *Offline) Put(string, []byte) error {
+	return nil
+}
+
+// Served reports how many responses came from the cache and the fetch
+// times of the oldest and newest of them.
+func (o *Offline) Served() (count int, oldest, newest time.Time) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.served, o.oldest, o.newest
+}
+
+func (o *Offline) record(fetchedAt time.Time) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.served++
+	if o.oldest.IsZero() || fetchedAt.Before(o.oldest) {
+		o.oldest = fetchedAt
+	}
+	if fetchedAt.After(o.newest) {
+		o.newest = fetchedAt
+	}
+}
+
+// savedPlayer reads players/{tag}.json for a /players/{tag} endpoint. The
+// player command names the file after the tag with its '#'; the storage
+// layout drops it, so both are tried.
+func (o *Offline) savedPlayer(endpoint string) ([]byte, time.Time, bool) {
+	escaped, ok := strings.CutPrefix(endpoint, "/players/")
+	if !ok || strings.Contains(escaped, "/") {
+		return nil, time.Time{}, false
+	}
+	tag, err := url.PathUnescape(escaped)
+	if err != nil || tag == "" {
+		return nil, time.Time{}, false
+	}
+	for _, name := range []string{tag, strings.TrimPrefix(tag, "#")} {
+		path := filepath.Join(o.playersDir, name+".json")
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		return data, info.ModTime().UTC(), true
+	}
+	return nil, time.Time{}, false
+}
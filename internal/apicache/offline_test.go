@@ -0,0 +1,78 @@
+package apicache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestOfflineServesEntriesOfAnyAge(t *testing.T) {
+	dir := t.TempDir()
+	store := New(dir, time.Hour)
+	fetched := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return fetched }
+	if err := store.Put("/cards", []byte(`{"items":[]}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	offline := NewOffline(dir)
+	if _, ok := offline.Get("/cards"); !ok {
+		t.Fatal("offline cache should serve a months-old entry")
+	}
+	if _, ok := offline.Get("/players/%23MISSING"); ok {
+		t.Error("expected a miss for an uncached endpoint")
+	}
+	if err := offline.Put("/cards", []byte(`{}`)); err != nil {
+		t.Fatal(err)
+	}
+	served, oldest, newest := offline.Served()
+	if served != 1 || !oldest.Equal(fetched) || !newest.Equal(fetched) {
+		t.Errorf("Served() = %d, %v, %v; want one response fetched at %v", served, oldest, newest, fetched)
+	}
+}
+
+func TestOfflineFallsBackToSavedPlayer(t *testing.T) {
+	dir := t.TempDir()
+	store := New(dir, time.Hour)
+	cachedAt := time.Now().Add(-48 * time.Hour).UTC()
+	store.now = func() time.Time { return cachedAt }
+	if err := store.Put("/players/%23ABC", []byte(`{"tag":"#ABC","name":"cached"}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	// `player --save` names the file after the tag with its '#'
+	playersDir := filepath.Join(dir, "players")
+	if err := os.MkdirAll(playersDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(playersDir, "#ABC.json"), []byte(`{"tag":"#ABC","name":"saved"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	offline := NewOffline(dir)
+	body, ok := offline.Get("/players/%23ABC")
+	if !ok {
+		t.Fatal("expected a hit")
+	}
+	var player struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(body, &player); err != nil || player.Name != "saved" {
+		t.Errorf("Get() = %s, %v; want the newer saved profile", body, err)
+	}
+	if _, ok := offline.Get("/players/%23ABC/battlelog"); ok {
+		t.Error("saved profiles only stand in for the player endpoint")
+	}
+
+	// An older saved profile loses to a newer cache entry
+	old := time.Now().Add(-72 * time.Hour)
+	if err := os.Chtimes(filepath.Join(playersDir, "#ABC.json"), old, old); err != nil {
+		t.Fatal(err)
+	}
+	body, _ = offline.Get("/players/%23ABC")
+	if err := json.Unmarshal(body, &player); err != nil || player.Name != "cached" {
+		t.Errorf("Get() = %s, %v; want the newer cache entry", body, err)
+	}
+}
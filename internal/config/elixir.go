@@ -2,7 +2,10 @@ package config
 
 // Elixir-related constants for deck building and scoring
 
-import "slices"
+import (
+	"maps"
+	"slices"
+)
 
 const (
 	// ElixirOptimal is the optimal elixir cost for balanced deck composition
@@ -216,6 +219,13 @@ func GetCardElixir(cardName string, apiElixir int) int {
 	return 4
 }
 
+// LookupCardElixir returns a card's cost from the static fallback mapping.
+// Returns (cost, true) when known and (0, false) when the card is missing.
+func LookupCardElixir(cardName string) (int, bool) {
+	cost, ok := fallbackElixir[cardName]
+	return cost, ok
+}
+
 // GetCardRole returns the role for a given card name.
 // Returns empty CardRole ("") if the card is not found in any role group.
 // For evolution-aware role classification, use GetCardRoleWithEvolution.
@@ -224,11 +234,18 @@ func GetCardRole(cardName string) CardRole {
 }
 
 // GetCardRoleWithEvolution returns the role for a given card name, considering evolution level.
-// When evolutionLevel > 0, checks evolutionRoleOverrides first before roleGroups.
+// User role overrides (SetRoleOverrides) win; otherwise when evolutionLevel > 0,
+// checks evolutionRoleOverrides first before roleGroups.
 // Returns empty CardRole ("") if the card is not found in any role group.
 func GetCardRoleWithEvolution(cardName string, evolutionLevel int) CardRole {
+	if role, exists := lookupRoleOverride(cardName); exists {
+		return role
+	}
 	if canonicalName, exists := roleAliases[cardName]; exists {
 		cardName = canonicalName
+		if role, exists := lookupRoleOverride(cardName); exists {
+			return role
+		}
 	}
 
 	// Check evolution overrides first if evolved
@@ -238,22 +255,30 @@ func GetCardRoleWithEvolution(cardName string, evolutionLevel int) CardRole {
 		}
 	}
 
-	// Check standard role groups
-	for role, cards := range roleGroups {
-		if slices.Contains(cards, cardName) {
-			return role
-		}
-	}
-	return ""
+	return BuiltinCardRole(cardName)
 }
 
-// GetRoleCards returns the list of cards for a given role.
-// Returns nil if the role doesn't exist.
+// GetRoleCards returns the list of cards for a given role, with user role
+// overrides applied. Returns nil if the role doesn't exist.
 func GetRoleCards(role CardRole) []string {
-	if cards, exists := roleGroups[role]; exists {
+	cards, exists := roleGroups[role]
+	if !exists {
+		return nil
+	}
+	overrides := RoleOverrides()
+	if len(overrides) == 0 {
 		return cards
 	}
-	return nil
+	cards = slices.DeleteFunc(slices.Clone(cards), func(name string) bool {
+		override, ok := overrides[name]
+		return ok && override != role
+	})
+	for _, name := range slices.Sorted(maps.Keys(overrides)) {
+		if overrides[name] == role && !slices.Contains(cards, name) {
+			cards = append(cards, name)
+		}
+	}
+	return cards
 }
 
 // GetRoleDescription returns a human-readable description for a card role.
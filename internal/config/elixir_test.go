@@ -25,3 +25,12 @@ func TestGetRoleDescription(t *testing.T) {
 		})
 	}
 }
+
+func TestLookupCardElixir(t *testing.T) {
+	if cost, ok := LookupCardElixir("Hog Rider"); !ok || cost != 4 {
+		t.Errorf("LookupCardElixir(Hog Rider) = %d, %v; want 4, true", cost, ok)
+	}
+	if _, ok := LookupCardElixir("Brand New Card"); ok {
+		t.Error("LookupCardElixir should report unknown cards")
+	}
+}
@@ -0,0 +1,227 @@
+package config
+
+// User configuration file (~/.cr-api/config.yaml) support
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/internal/datapath"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// FileName is the config file name inside the app directory
+	FileName = "config.yaml"
+
+	// FileEnvVar overrides the config file location
+	FileEnvVar = "CR_API_CONFIG"
+)
+
+// FilePath returns the config file location: $CR_API_CONFIG when set,
+// otherwise ~/.cr-api/config.yaml.
+func FilePath() string {
+	if path := os.Getenv(FileEnvVar); path != "" {
+		return path
+	}
+	return datapath.AppPathOrFallback(FileName)
+}
+
+// File is a flat YAML mapping of long flag names to values, for example
+//
+//	api-token: eyJ0eXAi...
+//	tag: "#ABC123"
+//	workers: 8
+//
+// Lists are accepted for repeatable flags and read back comma-joined. The
+// document is kept as a YAML node tree so Set and Unset preserve comments
+// and key order when the file is saved.
+type File struct {
+	Path string
+	doc  yaml.Node
+	// preamble holds a comment-only file, such as a fresh `config init`
+	// template, which the YAML node tree would otherwise drop
+	preamble []byte
+}
+
+// LoadFile reads a config file. A missing file yields an empty config that
+// Save will create.
+func LoadFile(path string) (*File, error) {
+	f := &File{Path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &f.doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if f.doc.Kind == 0 || len(f.doc.Content) == 0 {
+		// Empty or comment-only file
+		f.preamble = data
+		return f, nil
+	}
+
+	root := f.root()
+	if root == nil {
+		return nil, fmt.Errorf("%s: expected a mapping of flag names to values", path)
+	}
+	for i := 0; i < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		if !isScalar(value) && !isScalarList(value) {
+			return nil, fmt.Errorf("%s:%d: %s must be a value or a list of values", path, value.Line, key.Value)
+		}
+	}
+	return f, nil
+}
+
+// Keys returns the configured keys in file order.
+func (f *File) Keys() []string {
+	root := f.root()
+	if root == nil {
+		return nil
+	}
+	keys := make([]string, 0, len(root.Content)/2)
+	for i := 0; i < len(root.Content); i += 2 {
+		keys = append(keys, root.Content[i].Value)
+	}
+	return keys
+}
+
+// Lookup returns the value for key as flag text. Lists are comma-joined and
+// null values count as unset.
+func (f *File) Lookup(key string) (string, bool) {
+	value := f.value(key)
+	if value == nil || value.Tag == "!!null" {
+		return "", false
+	}
+	if value.Kind == yaml.SequenceNode {
+		items := make([]string, 0, len(value.Content))
+		for _, item := range value.Content {
+			items = append(items, item.Value)
+		}
+		return strings.Join(items, ","), true
+	}
+	return value.Value, true
+}
+
+// Set stores value for key, replacing any existing value in place.
+func (f *File) Set(key, value string) {
+	// Untagged so the value reads back with its natural type (workers: 8);
+	// the encoder quotes text such as "#ABC123" that plain YAML can't hold
+	node := &yaml.Node{Kind: yaml.ScalarNode, Value: value}
+
+	if existing := f.value(key); existing != nil {
+		// Keep the comments attached to the old value
+		existing.Kind, existing.Tag, existing.Style = node.Kind, node.Tag, node.Style
+		existing.Value, existing.Content = node.Value, nil
+		return
+	}
+	root := f.ensureRoot()
+	root.Content = append(root.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Value: key},
+		node,
+	)
+}
+
+// Unset removes key and reports whether it was present.
+func (f *File) Unset(key string) bool {
+	root := f.root()
+	if root == nil {
+		return false
+	}
+	for i := 0; i < len(root.Content); i += 2 {
+		if root.Content[i].Value == key {
+			root.Content = append(root.Content[:i], root.Content[i+2:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Save writes the config back to Path. The file may hold an API token, so
+// it is only readable by the owner.
+func (f *File) Save() error {
+	f.ensureRoot()
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&f.doc); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	data := buf.Bytes()
+	if len(f.preamble) > 0 {
+		data = slices.Concat(bytes.TrimRight(f.preamble, "\n"), []byte("\n\n"), data)
+	}
+	return WriteFile(f.Path, data)
+}
+
+// WriteFile writes raw config contents to path, creating its directory.
+func WriteFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+func (f *File) root() *yaml.Node {
+	if f.doc.Kind != yaml.DocumentNode || len(f.doc.Content) == 0 {
+		return nil
+	}
+	if root := f.doc.Content[0]; root.Kind == yaml.MappingNode {
+		return root
+	}
+	return nil
+}
+
+func (f *File) ensureRoot() *yaml.Node {
+	if root := f.root(); root != nil {
+		return root
+	}
+	root := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	// Keep any leading comments from an otherwise empty file
+	f.doc = yaml.Node{Kind: yaml.DocumentNode, HeadComment: f.doc.HeadComment, Content: []*yaml.Node{root}}
+	return root
+}
+
+func (f *File) value(key string) *yaml.Node {
+	root := f.root()
+	if root == nil {
+		return nil
+	}
+	for i := 0; i < len(root.Content); i += 2 {
+		if root.Content[i].Value == key {
+			return root.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func isScalar(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode
+}
+
+func isScalarList(n *yaml.Node) bool {
+	if n.Kind != yaml.SequenceNode {
+		return false
+	}
+	for _, item := range n.Content {
+		if !isScalar(item) {
+			return false
+		}
+	}
+	return true
+}
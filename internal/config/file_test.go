@@ -0,0 +1,153 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadFileMissing(t *testing.T) {
+	f, err := LoadFile(filepath.Join(t.TempDir(), "config.yaml"))
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	if keys := f.Keys(); len(keys) != 0 {
+		t.Errorf("Keys() = %v, want none", keys)
+	}
+	if _, ok := f.Lookup("tag"); ok {
+		t.Error("Lookup() found a key in an empty config")
+	}
+}
+
+func TestLookup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	contents := `tag: "#ABC123"
+workers: 8
+verbose: true
+include-cards:
+  - Hog Rider
+  - Fireball
+synergy-file: ~
+`
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+
+	want := map[string]string{
+		"tag":           "#ABC123",
+		"workers":       "8",
+		"verbose":       "true",
+		"include-cards": "Hog Rider,Fireball",
+	}
+	for key, value := range want {
+		if got, ok := f.Lookup(key); !ok || got != value {
+			t.Errorf("Lookup(%q) = %q, %v; want %q", key, got, ok, value)
+		}
+	}
+	if _, ok := f.Lookup("synergy-file"); ok {
+		t.Error("null values must count as unset")
+	}
+	if got := strings.Join(f.Keys(), ","); got != "tag,workers,verbose,include-cards,synergy-file" {
+		t.Errorf("Keys() = %s", got)
+	}
+}
+
+func TestLoadFileRejectsNesting(t *testing.T) {
+	dir := t.TempDir()
+	cases := map[string]string{
+		"list.yaml":   "- tag\n",
+		"nested.yaml": "deck:\n  workers: 4\n",
+		"broken.yaml": "tag: [\n",
+	}
+	for name, contents := range cases {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadFile(path); err == nil {
+			t.Errorf("LoadFile(%s) expected an error", name)
+		}
+	}
+}
+
+func TestSetAndSavePreserveComments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	contents := "# My settings\n\n# Main account\ntag: \"#OLD\" # primary\nworkers: 4\n"
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Set("tag", "#NEW")
+	f.Set("ga-mutation-rate", "0.2")
+	if !f.Unset("workers") || f.Unset("workers") {
+		t.Error("Unset() should report whether the key was present")
+	}
+	if err := f.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# My settings\n\n# Main account\ntag: '#NEW' # primary\nga-mutation-rate: 0.2\n"
+	if string(data) != want {
+		t.Errorf("saved config =\n%s\nwant\n%s", data, want)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("config permissions = %o, want 600", perm)
+	}
+
+	reloaded, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := reloaded.Lookup("tag"); got != "#NEW" {
+		t.Errorf("reloaded tag = %q", got)
+	}
+}
+
+func TestSaveKeepsCommentOnlyTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "config.yaml")
+	if err := WriteFile(path, []byte("# template\n# workers: 4\n")); err != nil {
+		t.Fatal(err)
+	}
+	f, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Set("workers", "8")
+	if err := f.Save(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "# template\n# workers: 4\n\nworkers: 8\n"; string(data) != want {
+		t.Errorf("saved config = %q, want %q", data, want)
+	}
+}
+
+func TestFilePath(t *testing.T) {
+	t.Setenv(FileEnvVar, "/tmp/custom.yaml")
+	if got := FilePath(); got != "/tmp/custom.yaml" {
+		t.Errorf("FilePath() = %q", got)
+	}
+	t.Setenv(FileEnvVar, "")
+	if got := FilePath(); filepath.Base(got) != FileName {
+		t.Errorf("FilePath() = %q", got)
+	}
+}
@@ -0,0 +1,147 @@
+package config
+
+// User card role overrides, layered over the built-in role groups
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// RoleOverridesFile is the default name of the user role overrides file in
+// the data directory
+const RoleOverridesFile = "card_roles.json"
+
+// roleOrder is the fixed order roles are listed and looked up in
+var roleOrder = []CardRole{
+	RoleWinCondition, RoleBuilding, RoleSpellBig, RoleSpellSmall, RoleSupport, RoleCycle,
+}
+
+var roleOverrides struct {
+	mu    sync.RWMutex
+	roles map[string]CardRole
+}
+
+// roleOverridesData is the on-disk format of the overrides file
+type roleOverridesData struct {
+	Roles map[string]CardRole `json:"roles"`
+}
+
+// AllCardRoles returns every card role in display order
+func AllCardRoles() []CardRole {
+	return slices.Clone(roleOrder)
+}
+
+// ParseCardRole parses a role name. It accepts the role values themselves
+// ("win_conditions") and their singular, dashed, or spaced forms ("win
+// condition", "spell-big").
+func ParseCardRole(value string) (CardRole, error) {
+	key := strings.ToLower(strings.TrimSpace(value))
+	key = strings.NewReplacer("-", "_", " ", "_").Replace(key)
+	switch key {
+	case "win_condition", "wincon":
+		return RoleWinCondition, nil
+	case "building":
+		return RoleBuilding, nil
+	case "spell_big", "big_spell":
+		return RoleSpellBig, nil
+	case "spell_small", "small_spell":
+		return RoleSpellSmall, nil
+	}
+	for _, role := range roleOrder {
+		if string(role) == key {
+			return role, nil
+		}
+	}
+	names := make([]string, len(roleOrder))
+	for i, role := range roleOrder {
+		names[i] = string(role)
+	}
+	return "", fmt.Errorf("unknown card role: %s (supported: %s)", value, strings.Join(names, ", "))
+}
+
+// SetRoleOverrides replaces the user role overrides. They take precedence
+// over the built-in roles, including evolution overrides. nil clears them.
+func SetRoleOverrides(overrides map[string]CardRole) {
+	roleOverrides.mu.Lock()
+	defer roleOverrides.mu.Unlock()
+	roleOverrides.roles = maps.Clone(overrides)
+}
+
+// RoleOverrides returns a copy of the user role overrides
+func RoleOverrides() map[string]CardRole {
+	roleOverrides.mu.RLock()
+	defer roleOverrides.mu.RUnlock()
+	return maps.Clone(roleOverrides.roles)
+}
+
+func lookupRoleOverride(cardName string) (CardRole, bool) {
+	roleOverrides.mu.RLock()
+	defer roleOverrides.mu.RUnlock()
+	role, ok := roleOverrides.roles[cardName]
+	return role, ok
+}
+
+// BuiltinCardRole returns a card's role from the built-in role groups,
+// ignoring user overrides and evolutions
+func BuiltinCardRole(cardName string) CardRole {
+	if canonicalName, exists := roleAliases[cardName]; exists {
+		cardName = canonicalName
+	}
+	for _, role := range roleOrder {
+		if slices.Contains(roleGroups[role], cardName) {
+			return role
+		}
+	}
+	return ""
+}
+
+// BuiltinRoleCards returns every card in the built-in role groups with its
+// role. Cards listed under two roles keep the first in AllCardRoles order.
+func BuiltinRoleCards() map[string]CardRole {
+	cards := make(map[string]CardRole)
+	for _, role := range slices.Backward(roleOrder) {
+		for _, name := range roleGroups[role] {
+			cards[name] = role
+		}
+	}
+	return cards
+}
+
+// LoadRoleOverrides reads a role overrides file. Every role must be valid.
+func LoadRoleOverrides(path string) (map[string]CardRole, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read role overrides: %w", err)
+	}
+	var file roleOverridesData
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse role overrides %s: %w", path, err)
+	}
+	overrides := make(map[string]CardRole, len(file.Roles))
+	for name, role := range file.Roles {
+		parsed, err := ParseCardRole(string(role))
+		if err != nil {
+			return nil, fmt.Errorf("role overrides %s: %s: %w", path, name, err)
+		}
+		overrides[strings.TrimSpace(name)] = parsed
+	}
+	return overrides, nil
+}
+
+// SaveRoleOverrides writes a role overrides file, creating its directory
+func SaveRoleOverrides(path string, overrides map[string]CardRole) error {
+	file := roleOverridesData{Roles: overrides}
+	if file.Roles == nil {
+		file.Roles = map[string]CardRole{}
+	}
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode role overrides: %w", err)
+	}
+	return WriteFile(path, append(data, '\n'))
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestRoleOverridesTakePrecedence(t *testing.T) {
+	t.Cleanup(func() { SetRoleOverrides(nil) })
+
+	SetRoleOverrides(map[string]CardRole{
+		"Knight":       RoleCycle,
+		"Goblin Drill": RoleWinCondition,
+		"Log":          RoleSpellBig,
+	})
+
+	if got := GetCardRole("Knight"); got != RoleCycle {
+		t.Errorf("GetCardRole(Knight) = %q, want cycle override", got)
+	}
+	if got := GetCardRoleWithEvolution("Knight", 1); got != RoleCycle {
+		t.Errorf("evolved Knight = %q, user override should beat the evolution role", got)
+	}
+	if got := GetCardRole("Goblin Drill"); got != RoleWinCondition {
+		t.Errorf("GetCardRole(Goblin Drill) = %q, want new card from override", got)
+	}
+	if got := GetCardRole("The Log"); got != RoleSpellBig {
+		t.Errorf("GetCardRole(The Log) = %q, override on the alias should apply", got)
+	}
+	if got := BuiltinCardRole("Knight"); got != RoleSupport {
+		t.Errorf("BuiltinCardRole(Knight) = %q, want support", got)
+	}
+
+	support := GetRoleCards(RoleSupport)
+	if slices.Contains(support, "Knight") {
+		t.Error("GetRoleCards(support) still lists the overridden Knight")
+	}
+	if !slices.Contains(GetRoleCards(RoleCycle), "Knight") || !slices.Contains(GetRoleCards(RoleWinCondition), "Goblin Drill") {
+		t.Error("GetRoleCards does not list overridden cards under their new role")
+	}
+
+	SetRoleOverrides(nil)
+	if got := GetCardRole("Knight"); got != RoleSupport {
+		t.Errorf("after clearing overrides GetCardRole(Knight) = %q", got)
+	}
+}
+
+func TestParseCardRole(t *testing.T) {
+	tests := map[string]CardRole{
+		"win_conditions": RoleWinCondition,
+		"Win Condition":  RoleWinCondition,
+		"building":       RoleBuilding,
+		"spell-big":      RoleSpellBig,
+		"small spell":    RoleSpellSmall,
+		"cycle":          RoleCycle,
+	}
+	for input, want := range tests {
+		if got, err := ParseCardRole(input); err != nil || got != want {
+			t.Errorf("ParseCardRole(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseCardRole("tank"); err == nil {
+		t.Error("expected an error for an unknown role")
+	}
+}
+
+func TestRoleOverridesFileRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", RoleOverridesFile)
+	if err := SaveRoleOverrides(path, map[string]CardRole{"Goblin Drill": RoleWinCondition}); err != nil {
+		t.Fatalf("SaveRoleOverrides() error = %v", err)
+	}
+	loaded, err := LoadRoleOverrides(path)
+	if err != nil {
+		t.Fatalf("LoadRoleOverrides() error = %v", err)
+	}
+	if loaded["Goblin Drill"] != RoleWinCondition || len(loaded) != 1 {
+		t.Errorf("loaded = %v", loaded)
+	}
+
+	bad := filepath.Join(t.TempDir(), "bad.json")
+	if err := os.WriteFile(bad, []byte(`{"roles":{"Knight":"tank"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadRoleOverrides(bad); err == nil {
+		t.Error("expected an error for an invalid role")
+	}
+}
+
+func TestBuiltinRoleCardsIsDeterministic(t *testing.T) {
+	cards := BuiltinRoleCards()
+	// Heal Spirit is listed as both a small spell and a cycle card
+	if got := cards["Heal Spirit"]; got != RoleSpellSmall {
+		t.Errorf("Heal Spirit = %q, want the first listed role", got)
+	}
+	if got := BuiltinCardRole("Heal Spirit"); got != RoleSpellSmall {
+		t.Errorf("BuiltinCardRole(Heal Spirit) = %q", got)
+	}
+}
@@ -0,0 +1,210 @@
+// Package credentials keeps the Clash Royale API token out of environment
+// variables and shell history. The token is stored in the OS keychain when
+// one is available, or in a passphrase-encrypted file, and a small marker
+// file in the app directory records which store holds it so commands only
+// consult a store after `auth login`.
+package credentials
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/klauer/clash-royale-api/go/internal/datapath"
+)
+
+const (
+	// MarkerFileName records which store holds the token
+	MarkerFileName = "auth.json"
+	// EncryptedFileName holds the token for StoreFile
+	EncryptedFileName = "credentials.enc"
+
+	// PassphraseEnvVar supplies the encrypted file passphrase without a
+	// prompt, for scripts
+	PassphraseEnvVar = "CR_API_TOKEN_PASSPHRASE"
+)
+
+// StoreKind names where the token is kept
+type StoreKind string
+
+const (
+	StoreKeychain StoreKind = "keychain"
+	StoreFile     StoreKind = "file"
+)
+
+// ErrNotStored is returned when no token has been saved
+var ErrNotStored = errors.New("no stored API token (run 'cr-api auth login')")
+
+// ParseStoreKind parses a store name; empty picks the keychain when the OS
+// has one and the encrypted file otherwise.
+func ParseStoreKind(name string) (StoreKind, error) {
+	switch kind := StoreKind(name); kind {
+	case "":
+		if osKeychain().Available() {
+			return StoreKeychain, nil
+		}
+		return StoreFile, nil
+	case StoreKeychain, StoreFile:
+		return kind, nil
+	default:
+		return "", fmt.Errorf("unknown credential store: %s (supported: keychain, file)", name)
+	}
+}
+
+// marker is the content of auth.json
+type marker struct {
+	Store StoreKind `json:"store"`
+}
+
+// appDir holds the marker and encrypted file
+var appDir = datapath.AppDir
+
+// osKeychain returns the keychain Save, Load, and Delete use; tests replace
+// it so they never touch the real keychain
+var osKeychain = NewKeychain
+
+func path(name string) (string, error) {
+	dir, err := appDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// StoredKind returns the store recorded by the last login, or
+// ErrNotStored.
+func StoredKind() (StoreKind, error) {
+	p, err := path(MarkerFileName)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ErrNotStored
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", p, err)
+	}
+	var m marker
+	if err := json.Unmarshal(data, &m); err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", p, err)
+	}
+	if m.Store != StoreKeychain && m.Store != StoreFile {
+		return "", fmt.Errorf("invalid store %q in %s", m.Store, p)
+	}
+	return m.Store, nil
+}
+
+func writeMarker(kind StoreKind) error {
+	p, err := path(MarkerFileName)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(p), err)
+	}
+	data, err := json.Marshal(marker{Store: kind})
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(p, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", p, err)
+	}
+	return nil
+}
+
+// Save stores token in the keychain, or in the encrypted file under
+// passphrase, and records the choice. A token left in the previously used
+// store is removed.
+func Save(kind StoreKind, token, passphrase string) error {
+	previous, _ := StoredKind()
+	switch kind {
+	case StoreKeychain:
+		if err := osKeychain().Set(token); err != nil {
+			return err
+		}
+		if err := removeEncryptedFile(); err != nil {
+			return err
+		}
+	case StoreFile:
+		file, err := DefaultEncryptedFile()
+		if err != nil {
+			return err
+		}
+		if err := file.Write(token, passphrase); err != nil {
+			return err
+		}
+		if previous == StoreKeychain {
+			if err := osKeychain().Delete(); err != nil && !errors.Is(err, ErrNotStored) {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unknown credential store: %s", kind)
+	}
+	return writeMarker(kind)
+}
+
+// Load returns the stored token. passphrase is called for the encrypted
+// file only.
+func Load(passphrase func() (string, error)) (string, StoreKind, error) {
+	kind, err := StoredKind()
+	if err != nil {
+		return "", "", err
+	}
+	if kind == StoreKeychain {
+		token, err := osKeychain().Get()
+		return token, kind, err
+	}
+	file, err := DefaultEncryptedFile()
+	if err != nil {
+		return "", kind, err
+	}
+	secret, err := passphrase()
+	if err != nil {
+		return "", kind, err
+	}
+	token, err := file.Read(secret)
+	return token, kind, err
+}
+
+// Delete removes the stored token and the marker. The keychain is only
+// touched when the marker records it. It reports whether anything was
+// removed.
+func Delete() (bool, error) {
+	removed := false
+	if kind, _ := StoredKind(); kind == StoreKeychain {
+		switch err := osKeychain().Delete(); {
+		case err == nil:
+			removed = true
+		case !errors.Is(err, ErrNotStored):
+			return removed, err
+		}
+	}
+	for _, name := range []string{EncryptedFileName, MarkerFileName} {
+		p, err := path(name)
+		if err != nil {
+			return removed, err
+		}
+		switch err := os.Remove(p); {
+		case err == nil:
+			removed = true
+		case !errors.Is(err, os.ErrNotExist):
+			return removed, fmt.Errorf("failed to remove %s: %w", p, err)
+		}
+	}
+	return removed, nil
+}
+
+func removeEncryptedFile() error {
+	p, err := path(EncryptedFileName)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove %s: %w", p, err)
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package credentials
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fakeKeychain returns a keychain for goos backed by a map, recording the
+// commands it runs
+func fakeKeychain(goos string, installed bool) (*Keychain, *[]string) {
+	var commands []string
+	stored := map[string]string{}
+	k := &Keychain{Service: keychainService, Account: keychainAccount, goos: goos}
+	k.lookPath = func(string) bool { return installed }
+	k.run = func(stdin, name string, args ...string) (string, error) {
+		commands = append(commands, name+" "+strings.Join(args, " "))
+		notFound := &errToolFailed{code: 1}
+		if goos == "darwin" {
+			notFound.code = 44
+		}
+		switch args[0] {
+		case "add-generic-password":
+			if args[len(args)-1] != "-w" {
+				return "", &errToolFailed{code: 2, stderr: "password passed as an argument"}
+			}
+			first, retyped, _ := strings.Cut(strings.TrimSuffix(stdin, "\n"), "\n")
+			if first != retyped {
+				return "", &errToolFailed{code: 1, stderr: "passwords don't match"}
+			}
+			stored["token"] = first
+		case "store":
+			stored["token"] = stdin
+		case "find-generic-password", "lookup":
+			token, ok := stored["token"]
+			if !ok {
+				return "", notFound
+			}
+			return token + "\n", nil
+		case "delete-generic-password", "clear":
+			if _, ok := stored["token"]; !ok && goos == "darwin" {
+				return "", notFound
+			}
+			delete(stored, "token")
+		}
+		return "", nil
+	}
+	return k, &commands
+}
+
+func useTempAppDir(t *testing.T, keychain *Keychain) string {
+	t.Helper()
+	dir := t.TempDir()
+	oldDir, oldKeychain := appDir, osKeychain
+	appDir = func() (string, error) { return dir, nil }
+	osKeychain = func() *Keychain { return keychain }
+	t.Cleanup(func() { appDir, osKeychain = oldDir, oldKeychain })
+	return dir
+}
+
+func TestKeychainCommands(t *testing.T) {
+	for _, goos := range []string{"darwin", "linux"} {
+		k, commands := fakeKeychain(goos, true)
+		if _, err := k.Get(); !errors.Is(err, ErrNotStored) {
+			t.Errorf("%s: Get() before Set error = %v, want ErrNotStored", goos, err)
+		}
+		if err := k.Set("secret-token"); err != nil {
+			t.Fatalf("%s: Set() error = %v", goos, err)
+		}
+		if token, err := k.Get(); err != nil || token != "secret-token" {
+			t.Errorf("%s: Get() = %q, %v", goos, token, err)
+		}
+		if err := k.Delete(); err != nil {
+			t.Errorf("%s: Delete() error = %v", goos, err)
+		}
+		if err := k.Delete(); !errors.Is(err, ErrNotStored) {
+			t.Errorf("%s: second Delete() error = %v, want ErrNotStored", goos, err)
+		}
+		tool := map[string]string{"darwin": "security", "linux": "secret-tool"}[goos]
+		if !slices.ContainsFunc(*commands, func(c string) bool { return strings.HasPrefix(c, tool+" ") }) {
+			t.Errorf("%s: commands = %v, want %s", goos, *commands, tool)
+		}
+	}
+	// Both tools read the token from stdin, keeping it out of the process list
+	for _, goos := range []string{"darwin", "linux"} {
+		k, commands := fakeKeychain(goos, true)
+		if err := k.Set("secret-token"); err != nil {
+			t.Fatal(err)
+		}
+		for _, c := range *commands {
+			if strings.Contains(c, "secret-token") {
+				t.Errorf("%s: command line contains the token: %s", goos, c)
+			}
+		}
+	}
+
+	windows, _ := fakeKeychain("windows", true)
+	if windows.Available() || windows.Set("x") == nil {
+		t.Error("windows has no supported keychain tool")
+	}
+}
+
+func TestEncryptedFileRoundTrip(t *testing.T) {
+	file := &EncryptedFile{Path: filepath.Join(t.TempDir(), EncryptedFileName), Iterations: 1000}
+	if err := file.Write("secret-token", "correct horse"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(file.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret-token") {
+		t.Error("encrypted file contains the plain token")
+	}
+	if info, err := os.Stat(file.Path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("file mode = %v, %v, want 0600", info.Mode().Perm(), err)
+	}
+
+	if token, err := file.Read("correct horse"); err != nil || token != "secret-token" {
+		t.Errorf("Read() = %q, %v", token, err)
+	}
+	if _, err := file.Read("wrong"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("Read(wrong) error = %v, want ErrWrongPassphrase", err)
+	}
+	if err := file.Write("x", ""); err == nil {
+		t.Error("expected an error for an empty passphrase")
+	}
+}
+
+func TestSaveLoadDelete(t *testing.T) {
+	keychain, _ := fakeKeychain("linux", true)
+	dir := useTempAppDir(t, keychain)
+
+	if _, _, err := Load(nil); !errors.Is(err, ErrNotStored) {
+		t.Fatalf("Load() before login error = %v, want ErrNotStored", err)
+	}
+	if kind, err := ParseStoreKind(""); err != nil || kind != StoreKeychain {
+		t.Errorf("ParseStoreKind(\"\") = %q, %v, want keychain", kind, err)
+	}
+
+	if err := Save(StoreKeychain, "keychain-token", ""); err != nil {
+		t.Fatal(err)
+	}
+	token, kind, err := Load(nil)
+	if err != nil || token != "keychain-token" || kind != StoreKeychain {
+		t.Errorf("Load() = %q, %q, %v", token, kind, err)
+	}
+
+	// Switching to the file store clears the keychain entry
+	if err := Save(StoreFile, "file-token", "pass"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := keychain.Get(); !errors.Is(err, ErrNotStored) {
+		t.Errorf("keychain still holds a token after switching stores: %v", err)
+	}
+	token, kind, err = Load(func() (string, error) { return "pass", nil })
+	if err != nil || token != "file-token" || kind != StoreFile {
+		t.Errorf("Load() = %q, %q, %v", token, kind, err)
+	}
+
+	removed, err := Delete()
+	if err != nil || !removed {
+		t.Fatalf("Delete() = %v, %v", removed, err)
+	}
+	for _, name := range []string{MarkerFileName, EncryptedFileName} {
+		if _, err := os.Stat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+			t.Errorf("%s still exists after Delete()", name)
+		}
+	}
+	if removed, err := Delete(); err != nil || removed {
+		t.Errorf("second Delete() = %v, %v, want false", removed, err)
+	}
+}
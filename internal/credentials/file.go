@@ -0,0 +1,136 @@
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	encryptedFileVersion = 1
+	// defaultIterations follows the OWASP recommendation for PBKDF2-SHA256
+	defaultIterations = 600_000
+	saltSize          = 16
+	keySize           = 32
+)
+
+// ErrWrongPassphrase is returned when the encrypted file cannot be opened
+// with the given passphrase
+var ErrWrongPassphrase = errors.New("wrong passphrase for the stored API token")
+
+// EncryptedFile keeps the token encrypted with AES-256-GCM under a key
+// derived from a passphrase with PBKDF2-SHA256.
+type EncryptedFile struct {
+	Path string
+	// Iterations is the PBKDF2 work factor for new files; 0 uses the
+	// default. Existing files record their own.
+	Iterations int
+}
+
+type encryptedFileData struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// DefaultEncryptedFile returns the encrypted file in the app directory
+func DefaultEncryptedFile() (*EncryptedFile, error) {
+	p, err := path(EncryptedFileName)
+	if err != nil {
+		return nil, err
+	}
+	return &EncryptedFile{Path: p}, nil
+}
+
+// Write encrypts token under passphrase and saves it, readable only by the
+// current user.
+func (f *EncryptedFile) Write(token, passphrase string) error {
+	if passphrase == "" {
+		return errors.New("passphrase is empty")
+	}
+	data := encryptedFileData{
+		Version:    encryptedFileVersion,
+		KDF:        "pbkdf2-sha256",
+		Iterations: f.Iterations,
+		Salt:       make([]byte, saltSize),
+	}
+	if data.Iterations <= 0 {
+		data.Iterations = defaultIterations
+	}
+	if _, err := rand.Read(data.Salt); err != nil {
+		return fmt.Errorf("failed to generate salt: %w", err)
+	}
+	aead, err := newAEAD(passphrase, data.Salt, data.Iterations)
+	if err != nil {
+		return err
+	}
+	data.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(data.Nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	data.Ciphertext = aead.Seal(nil, data.Nonce, []byte(token), nil)
+
+	encoded, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(f.Path), 0o700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(f.Path), err)
+	}
+	if err := os.WriteFile(f.Path, append(encoded, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", f.Path, err)
+	}
+	return nil
+}
+
+// Read decrypts the token with passphrase
+func (f *EncryptedFile) Read(passphrase string) (string, error) {
+	encoded, err := os.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ErrNotStored
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", f.Path, err)
+	}
+	var data encryptedFileData
+	if err := json.Unmarshal(encoded, &data); err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", f.Path, err)
+	}
+	if data.Version != encryptedFileVersion || data.KDF != "pbkdf2-sha256" || data.Iterations <= 0 {
+		return "", fmt.Errorf("unsupported credential file %s (version %d, kdf %q)", f.Path, data.Version, data.KDF)
+	}
+	aead, err := newAEAD(passphrase, data.Salt, data.Iterations)
+	if err != nil {
+		return "", err
+	}
+	if len(data.Nonce) != aead.NonceSize() {
+		return "", fmt.Errorf("corrupt credential file %s", f.Path)
+	}
+	token, err := aead.Open(nil, data.Nonce, data.Ciphertext, nil)
+	if err != nil {
+		return "", ErrWrongPassphrase
+	}
+	return string(token), nil
+}
+
+func newAEAD(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,180 @@
+package credentials
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+const (
+	keychainService = "cr-api"
+	keychainAccount = "api-token"
+)
+
+// Keychain stores the token with the OS secret store through its command
+// line tool: `security` (macOS Keychain) or `secret-tool` (libsecret: GNOME
+// Keyring, KWallet). Other systems use the encrypted file instead.
+type Keychain struct {
+	Service string
+	Account string
+	goos    string
+	// run executes a tool with stdin and returns its stdout; tests fake it
+	run func(stdin string, name string, args ...string) (string, error)
+	// lookPath reports whether a tool is installed; tests fake it
+	lookPath func(name string) bool
+}
+
+// NewKeychain returns the keychain for the current OS
+func NewKeychain() *Keychain {
+	return &Keychain{
+		Service:  keychainService,
+		Account:  keychainAccount,
+		goos:     runtime.GOOS,
+		run:      runTool,
+		lookPath: func(name string) bool { _, err := exec.LookPath(name); return err == nil },
+	}
+}
+
+// errToolFailed marks a tool that ran and exited non-zero
+type errToolFailed struct {
+	code   int
+	stderr string
+}
+
+func (e *errToolFailed) Error() string {
+	return fmt.Sprintf("exit status %d: %s", e.code, e.stderr)
+}
+
+func runTool(stdin string, name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return "", &errToolFailed{code: exitErr.ExitCode(), stderr: strings.TrimSpace(stderr.String())}
+	}
+	return stdout.String(), err
+}
+
+// tool returns the secret store tool for the OS, or "" when there is none
+func (k *Keychain) tool() string {
+	switch k.goos {
+	case "darwin":
+		return "security"
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return "secret-tool"
+	default:
+		return ""
+	}
+}
+
+// Available reports whether the OS keychain tool is installed
+func (k *Keychain) Available() bool {
+	tool := k.tool()
+	return tool != "" && k.lookPath(tool)
+}
+
+// Name describes the keychain for messages
+func (k *Keychain) Name() string {
+	if k.goos == "darwin" {
+		return "macOS Keychain"
+	}
+	return "Secret Service keyring (secret-tool)"
+}
+
+func (k *Keychain) requireAvailable() error {
+	if k.Available() {
+		return nil
+	}
+	if k.tool() == "" {
+		return fmt.Errorf("no supported OS keychain on %s; use the encrypted file store (--store file)", k.goos)
+	}
+	return fmt.Errorf("%s not found; install it or use the encrypted file store (--store file)", k.tool())
+}
+
+// Set saves token, replacing any stored token
+func (k *Keychain) Set(token string) error {
+	if err := k.requireAvailable(); err != nil {
+		return err
+	}
+	var err error
+	if k.goos == "darwin" {
+		// A trailing -w without a value makes security prompt for the
+		// password, entered twice, so the token stays off the command line
+		_, err = k.run(token+"\n"+token+"\n", "security", "add-generic-password", "-U", "-s", k.Service, "-a", k.Account, "-w")
+	} else {
+		_, err = k.run(token, "secret-tool", "store", "--label=cr-api API token", "service", k.Service, "account", k.Account)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save token in %s: %w", k.Name(), err)
+	}
+	return nil
+}
+
+// Get returns the stored token, or ErrNotStored
+func (k *Keychain) Get() (string, error) {
+	if err := k.requireAvailable(); err != nil {
+		return "", err
+	}
+	var out string
+	var err error
+	if k.goos == "darwin" {
+		out, err = k.run("", "security", "find-generic-password", "-s", k.Service, "-a", k.Account, "-w")
+	} else {
+		out, err = k.run("", "secret-tool", "lookup", "service", k.Service, "account", k.Account)
+	}
+	if k.notFound(err) {
+		return "", ErrNotStored
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read token from %s: %w", k.Name(), err)
+	}
+	token := strings.TrimSpace(out)
+	if token == "" {
+		return "", ErrNotStored
+	}
+	return token, nil
+}
+
+// Delete removes the stored token, or returns ErrNotStored
+func (k *Keychain) Delete() error {
+	if err := k.requireAvailable(); err != nil {
+		return err
+	}
+	var err error
+	if k.goos == "darwin" {
+		_, err = k.run("", "security", "delete-generic-password", "-s", k.Service, "-a", k.Account)
+	} else {
+		// secret-tool clear succeeds whether or not anything matched
+		if _, getErr := k.Get(); errors.Is(getErr, ErrNotStored) {
+			return ErrNotStored
+		}
+		_, err = k.run("", "secret-tool", "clear", "service", k.Service, "account", k.Account)
+	}
+	if k.notFound(err) {
+		return ErrNotStored
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove token from %s: %w", k.Name(), err)
+	}
+	return nil
+}
+
+// notFound reports whether err is the tool's "no such item" exit: 44 for
+// security, 1 with no output for secret-tool lookup
+func (k *Keychain) notFound(err error) bool {
+	var failed *errToolFailed
+	if !errors.As(err, &failed) {
+		return false
+	}
+	if k.goos == "darwin" {
+		return failed.code == 44
+	}
+	return failed.code == 1
+}
@@ -0,0 +1,390 @@
+// Package datasync copies the cr-api data directory to and from a remote
+// store (S3-compatible object storage or WebDAV) so analyses, snapshots, and
+// deck databases follow a user across machines.
+//
+// The remote holds every file under files/ and a manifest.json recording
+// each file's size, SHA-256, and modification time. Push and pull compare a
+// scan of the local directory against the manifest: unchanged files are
+// skipped, and a changed file is copied only when the source copy is newer.
+// A destination copy that is newer is a conflict and is left alone unless
+// forced. Sync never deletes files on either side.
+package datasync
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/closeutil"
+)
+
+// ManifestVersion is the manifest format written by a push
+const ManifestVersion = 1
+
+const (
+	manifestKey = "manifest.json"
+	filesPrefix = "files/"
+	tempPattern = ".cr-api-sync-*"
+)
+
+// DefaultExcludes are never synced: the config file and stored credentials
+// hold API tokens and sync credentials, scorer plugin files name commands
+// to run, and SQLite sidecar files only make sense next to the database
+// that wrote them.
+var DefaultExcludes = []string{"config.yaml", "auth.json", "credentials.enc", "scorer_plugins.json", "*.db-wal", "*.db-shm", "*.db-journal", tempPattern}
+
+// ErrNotFound is returned by a Backend for a missing key
+var ErrNotFound = errors.New("remote file not found")
+
+// Backend stores whole files by slash-separated key.
+type Backend interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Put(ctx context.Context, key string, data []byte) error
+	// String names the remote in messages, without credentials
+	String() string
+}
+
+// FileState is one synced file as recorded in a manifest
+type FileState struct {
+	Size    int64     `json:"size"`
+	SHA256  string    `json:"sha256"`
+	ModTime time.Time `json:"mod_time"`
+}
+
+// Manifest maps slash-separated paths relative to the data directory to
+// their state
+type Manifest struct {
+	Version   int                  `json:"version"`
+	UpdatedAt time.Time            `json:"updated_at"`
+	Files     map[string]FileState `json:"files"`
+}
+
+// Direction is the way files are copied
+type Direction string
+
+const (
+	Push Direction = "push"
+	Pull Direction = "pull"
+)
+
+// Action kinds reported in Action.Op
+const (
+	OpUpload   = "upload"
+	OpDownload = "download"
+	OpConflict = "conflict"
+)
+
+// Action is what a sync does, or would do, with one file
+type Action struct {
+	Path   string `json:"path"`
+	Op     string `json:"op"`
+	Reason string `json:"reason"`
+	Size   int64  `json:"size"`
+}
+
+// Options controls a Sync
+type Options struct {
+	// Dir is the local data directory
+	Dir string
+	// Exclude adds glob patterns to DefaultExcludes; a pattern matches a
+	// file's base name or its path relative to Dir
+	Exclude []string
+	// Force copies files even when the destination copy is newer
+	Force bool
+	// DryRun plans the sync without copying anything
+	DryRun bool
+}
+
+// Report lists the actions of a sync and how many files were already in
+// step
+type Report struct {
+	Direction Direction `json:"direction"`
+	Remote    string    `json:"remote"`
+	DryRun    bool      `json:"dry_run"`
+	Actions   []Action  `json:"actions"`
+	Unchanged int       `json:"unchanged"`
+}
+
+// Transferred returns the actions that copy a file
+func (r Report) Transferred() []Action {
+	return slices.DeleteFunc(slices.Clone(r.Actions), func(a Action) bool { return a.Op == OpConflict })
+}
+
+// Conflicts returns the files left alone because the destination is newer
+func (r Report) Conflicts() []Action {
+	return slices.DeleteFunc(slices.Clone(r.Actions), func(a Action) bool { return a.Op != OpConflict })
+}
+
+// Scan records the state of every regular file under dir, skipping the
+// excluded ones. Modification times are truncated to whole seconds, which
+// every file system can store.
+func Scan(dir string, exclude []string) (Manifest, error) {
+	patterns := excludePatterns(exclude)
+	manifest := Manifest{Version: ManifestVersion, Files: map[string]FileState{}}
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if excluded(rel, patterns) {
+			return nil
+		}
+		state, err := fileState(p)
+		if err != nil {
+			return err
+		}
+		manifest.Files[rel] = state
+		return nil
+	})
+	if err != nil {
+		return Manifest{}, fmt.Errorf("failed to scan %s: %w", dir, err)
+	}
+	return manifest, nil
+}
+
+func excludePatterns(extra []string) []string {
+	return append(slices.Clone(DefaultExcludes), extra...)
+}
+
+func excluded(rel string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, path.Base(rel)); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, rel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func fileState(p string) (FileState, error) {
+	file, err := os.Open(p)
+	if err != nil {
+		return FileState{}, err
+	}
+	defer closeutil.WithLog("datasync", file, "data file")
+	info, err := file.Stat()
+	if err != nil {
+		return FileState{}, err
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return FileState{}, err
+	}
+	return FileState{
+		Size:    info.Size(),
+		SHA256:  hex.EncodeToString(hash.Sum(nil)),
+		ModTime: info.ModTime().UTC().Truncate(time.Second),
+	}, nil
+}
+
+// Plan returns the actions that bring the destination in step with the
+// source for direction, sorted by path. A file differing on both sides is
+// copied when the source copy is newer, or always when force is set. Paths
+// that could leave the data directory (see ValidPath) are skipped.
+func Plan(local, remote Manifest, direction Direction, force bool) (actions []Action, unchanged int) {
+	source, dest, op := local, remote, OpUpload
+	if direction == Pull {
+		source, dest, op = remote, local, OpDownload
+	}
+	for p, src := range source.Files {
+		if !ValidPath(p) {
+			continue
+		}
+		dst, exists := dest.Files[p]
+		switch {
+		case !exists:
+			actions = append(actions, Action{Path: p, Op: op, Reason: "new", Size: src.Size})
+		case src.SHA256 == dst.SHA256:
+			unchanged++
+		case src.ModTime.After(dst.ModTime):
+			actions = append(actions, Action{Path: p, Op: op, Reason: "newer", Size: src.Size})
+		case force:
+			actions = append(actions, Action{Path: p, Op: op, Reason: "forced", Size: src.Size})
+		default:
+			reason := "destination is newer"
+			if src.ModTime.Equal(dst.ModTime) {
+				reason = "both changed at the same time"
+			}
+			actions = append(actions, Action{Path: p, Op: OpConflict, Reason: reason, Size: src.Size})
+		}
+	}
+	slices.SortFunc(actions, func(a, b Action) int { return cmp.Compare(a.Path, b.Path) })
+	return actions, unchanged
+}
+
+// Sync runs a push or pull between opts.Dir and backend.
+func Sync(ctx context.Context, backend Backend, direction Direction, opts Options) (Report, error) {
+	report := Report{Direction: direction, Remote: backend.String(), DryRun: opts.DryRun}
+	if direction != Push && direction != Pull {
+		return report, fmt.Errorf("unknown sync direction: %s", direction)
+	}
+	if direction == Push {
+		if _, err := os.Stat(opts.Dir); err != nil {
+			return report, fmt.Errorf("failed to read data directory: %w", err)
+		}
+	} else if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		return report, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	local, err := Scan(opts.Dir, opts.Exclude)
+	if err != nil {
+		return report, err
+	}
+	remote, err := loadManifest(ctx, backend)
+	if err != nil {
+		return report, err
+	}
+	// Files this machine excludes are left alone on the remote too
+	patterns := excludePatterns(opts.Exclude)
+	planned := remote
+	planned.Files = maps.Clone(remote.Files)
+	maps.DeleteFunc(planned.Files, func(p string, _ FileState) bool { return excluded(p, patterns) })
+	report.Actions, report.Unchanged = Plan(local, planned, direction, opts.Force)
+	if opts.DryRun {
+		return report, nil
+	}
+
+	if direction == Push {
+		return report, push(ctx, backend, opts.Dir, local, remote, report.Transferred())
+	}
+	return report, pull(ctx, backend, opts.Dir, remote, report.Transferred())
+}
+
+func loadManifest(ctx context.Context, backend Backend) (Manifest, error) {
+	data, err := backend.Get(ctx, manifestKey)
+	if errors.Is(err, ErrNotFound) {
+		return Manifest{Version: ManifestVersion, Files: map[string]FileState{}}, nil
+	}
+	if err != nil {
+		return Manifest{}, fmt.Errorf("failed to read remote manifest: %w", err)
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return Manifest{}, fmt.Errorf("failed to parse remote manifest: %w", err)
+	}
+	if manifest.Version > ManifestVersion {
+		return Manifest{}, fmt.Errorf("remote manifest version %d is newer than this cr-api supports (%d)", manifest.Version, ManifestVersion)
+	}
+	if manifest.Files == nil {
+		manifest.Files = map[string]FileState{}
+	}
+	for p := range manifest.Files {
+		if !ValidPath(p) {
+			return Manifest{}, fmt.Errorf("remote manifest lists unsafe path %q", p)
+		}
+	}
+	return manifest, nil
+}
+
+// ValidPath reports whether a manifest path stays inside the data
+// directory: relative, slash-separated, already clean, and without ".."
+// elements or backslashes.
+func ValidPath(p string) bool {
+	if p == "" || path.Clean(p) != p || strings.Contains(p, `\`) {
+		return false
+	}
+	if slices.Contains(strings.Split(p, "/"), "..") {
+		return false
+	}
+	return filepath.IsLocal(filepath.FromSlash(p))
+}
+
+// push uploads files and then the manifest. The manifest is written even
+// when an upload fails, so the files that made it are recorded.
+func push(ctx context.Context, backend Backend, dir string, local, remote Manifest, uploads []Action) error {
+	var uploadErr error
+	uploaded := 0
+	for _, action := range uploads {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(action.Path)))
+		if err == nil {
+			err = backend.Put(ctx, filesPrefix+action.Path, data)
+		}
+		if err != nil {
+			uploadErr = fmt.Errorf("failed to upload %s: %w", action.Path, err)
+			break
+		}
+		remote.Files[action.Path] = local.Files[action.Path]
+		uploaded++
+	}
+	if uploaded == 0 {
+		return uploadErr
+	}
+
+	remote.Version = ManifestVersion
+	remote.UpdatedAt = time.Now().UTC()
+	data, err := json.MarshalIndent(remote, "", "  ")
+	if err != nil {
+		return errors.Join(uploadErr, fmt.Errorf("failed to encode manifest: %w", err))
+	}
+	if err := backend.Put(ctx, manifestKey, data); err != nil {
+		return errors.Join(uploadErr, fmt.Errorf("failed to write remote manifest: %w", err))
+	}
+	return uploadErr
+}
+
+// pull downloads files through a temporary file in the destination
+// directory, so an interrupted pull never leaves a partial file, and stamps
+// each with its remote modification time.
+func pull(ctx context.Context, backend Backend, dir string, remote Manifest, downloads []Action) error {
+	for _, action := range downloads {
+		if !ValidPath(action.Path) {
+			return fmt.Errorf("refusing to download unsafe path %q", action.Path)
+		}
+		state := remote.Files[action.Path]
+		data, err := backend.Get(ctx, filesPrefix+action.Path)
+		if err != nil {
+			return fmt.Errorf("failed to download %s: %w", action.Path, err)
+		}
+		if sum := sha256.Sum256(data); hex.EncodeToString(sum[:]) != state.SHA256 {
+			return fmt.Errorf("failed to download %s: content does not match the remote manifest", action.Path)
+		}
+		if err := writeFileAtomic(filepath.Join(dir, filepath.FromSlash(action.Path)), data, state.ModTime); err != nil {
+			return fmt.Errorf("failed to write %s: %w", action.Path, err)
+		}
+	}
+	return nil
+}
+
+func writeFileAtomic(target string, data []byte, modTime time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(target), tempPattern)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chtimes(tmp.Name(), modTime, modTime); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), target)
+}
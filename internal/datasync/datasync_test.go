@@ -0,0 +1,223 @@
+package datasync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryBackend is an in-process Backend
+type memoryBackend struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+func newMemoryBackend() *memoryBackend {
+	return &memoryBackend{files: map[string][]byte{}}
+}
+
+func (m *memoryBackend) Get(_ context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.files[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return data, nil
+}
+
+func (m *memoryBackend) Put(_ context.Context, key string, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.files[key] = append([]byte(nil), data...)
+	return nil
+}
+
+func (m *memoryBackend) String() string { return "memory" }
+
+func writeFile(t *testing.T, dir, rel, content string, modTime time.Time) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, dir, rel string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(rel)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestSyncPushThenPull(t *testing.T) {
+	ctx := context.Background()
+	backend := newMemoryBackend()
+	laptop, desktop := t.TempDir(), t.TempDir()
+	start := time.Now().Add(-time.Hour)
+
+	writeFile(t, laptop, "fuzz_top_decks.db", "decks v1", start)
+	writeFile(t, laptop, "leaderboards/ABC.db", "leaderboard", start)
+	writeFile(t, laptop, "config.yaml", "api-token: secret", start)
+	writeFile(t, laptop, "scorer_plugins.json", `{"plugins":[]}`, start)
+	writeFile(t, laptop, "fuzz_top_decks.db-wal", "wal", start)
+
+	report, err := Sync(ctx, backend, Push, Options{Dir: laptop})
+	if err != nil {
+		t.Fatalf("push error = %v", err)
+	}
+	if got := len(report.Transferred()); got != 2 {
+		t.Fatalf("push transferred %d files, want 2: %+v", got, report.Actions)
+	}
+	for _, name := range []string{"config.yaml", "scorer_plugins.json"} {
+		if _, err := backend.Get(ctx, filesPrefix+name); err != ErrNotFound {
+			t.Errorf("%s should never be pushed", name)
+		}
+	}
+
+	report, err = Sync(ctx, backend, Pull, Options{Dir: desktop})
+	if err != nil {
+		t.Fatalf("pull error = %v", err)
+	}
+	if got := len(report.Transferred()); got != 2 || readFile(t, desktop, "leaderboards/ABC.db") != "leaderboard" {
+		t.Fatalf("pull = %+v", report)
+	}
+	info, err := os.Stat(filepath.Join(desktop, "fuzz_top_decks.db"))
+	if err != nil || !info.ModTime().Equal(start.Truncate(time.Second)) {
+		t.Errorf("pulled file mod time = %v, want %v", info.ModTime(), start.Truncate(time.Second))
+	}
+
+	// Nothing changed, so a second pull is a no-op
+	report, err = Sync(ctx, backend, Pull, Options{Dir: desktop})
+	if err != nil || len(report.Actions) != 0 || report.Unchanged != 2 {
+		t.Errorf("second pull = %+v, %v", report, err)
+	}
+
+	// The desktop edits the deck database and pushes it back
+	writeFile(t, desktop, "fuzz_top_decks.db", "decks v2", start.Add(10*time.Minute))
+	if _, err := Sync(ctx, backend, Push, Options{Dir: desktop}); err != nil {
+		t.Fatal(err)
+	}
+	report, err = Sync(ctx, backend, Pull, Options{Dir: laptop})
+	if err != nil || len(report.Transferred()) != 1 || readFile(t, laptop, "fuzz_top_decks.db") != "decks v2" {
+		t.Errorf("laptop pull = %+v, %v", report, err)
+	}
+}
+
+func TestSyncConflictsKeepNewerCopy(t *testing.T) {
+	ctx := context.Background()
+	backend := newMemoryBackend()
+	laptop, desktop := t.TempDir(), t.TempDir()
+	start := time.Now().Add(-time.Hour)
+
+	writeFile(t, laptop, "notes.json", "laptop", start.Add(5*time.Minute))
+	writeFile(t, desktop, "notes.json", "desktop", start)
+	if _, err := Sync(ctx, backend, Push, Options{Dir: laptop}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The desktop copy is older than the remote one
+	report, err := Sync(ctx, backend, Push, Options{Dir: desktop})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conflicts := report.Conflicts(); len(conflicts) != 1 || conflicts[0].Path != "notes.json" {
+		t.Fatalf("push conflicts = %+v", report.Actions)
+	}
+
+	// Pulling brings the newer remote copy down
+	report, err = Sync(ctx, backend, Pull, Options{Dir: desktop, DryRun: true})
+	if err != nil || len(report.Transferred()) != 1 || readFile(t, desktop, "notes.json") != "desktop" {
+		t.Fatalf("dry-run pull = %+v, %v", report, err)
+	}
+
+	// --force pushes the older copy anyway
+	report, err = Sync(ctx, backend, Push, Options{Dir: desktop, Force: true})
+	if err != nil || len(report.Transferred()) != 1 || report.Actions[0].Reason != "forced" {
+		t.Fatalf("forced push = %+v, %v", report, err)
+	}
+	if data, _ := backend.Get(ctx, filesPrefix+"notes.json"); string(data) != "desktop" {
+		t.Errorf("remote notes.json = %q, want desktop", data)
+	}
+
+	// Now the laptop copy is newer than the remote, so pulling conflicts
+	report, err = Sync(ctx, backend, Pull, Options{Dir: laptop})
+	if err != nil || len(report.Conflicts()) != 1 || readFile(t, laptop, "notes.json") != "laptop" {
+		t.Errorf("laptop pull = %+v, %v", report, err)
+	}
+}
+
+func TestScanExcludes(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	writeFile(t, dir, "discover/ABC.log", "log", now)
+	writeFile(t, dir, "discover/ABC.json", "{}", now)
+	writeFile(t, dir, "cache/x.json", "{}", now)
+
+	manifest, err := Scan(dir, []string{"*.log", "cache/*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest.Files) != 1 {
+		t.Fatalf("Scan() files = %v, want only discover/ABC.json", manifest.Files)
+	}
+	if _, ok := manifest.Files["discover/ABC.json"]; !ok {
+		t.Errorf("Scan() files = %v", manifest.Files)
+	}
+}
+
+func TestPullRejectsPathsOutsideDataDir(t *testing.T) {
+	ctx := context.Background()
+	backend := newMemoryBackend()
+	root := t.TempDir()
+	dataDir := filepath.Join(root, "data")
+
+	payload := []byte("planted")
+	sum := sha256.Sum256(payload)
+	manifest := Manifest{Version: ManifestVersion, Files: map[string]FileState{
+		"../escaped.txt": {Size: int64(len(payload)), SHA256: hex.EncodeToString(sum[:]), ModTime: time.Now()},
+	}}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = backend.Put(ctx, manifestKey, data)
+	_ = backend.Put(ctx, filesPrefix+"../escaped.txt", payload)
+
+	if _, err := Sync(ctx, backend, Pull, Options{Dir: dataDir}); err == nil || !strings.Contains(err.Error(), "unsafe path") {
+		t.Fatalf("pull error = %v, want unsafe path", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "escaped.txt")); !os.IsNotExist(err) {
+		t.Errorf("traversal entry was written outside the data directory: %v", err)
+	}
+
+	actions, _ := Plan(Manifest{}, manifest, Pull, true)
+	if len(actions) != 0 {
+		t.Errorf("Plan() = %+v, want unsafe paths skipped", actions)
+	}
+
+	for p, want := range map[string]bool{
+		"fuzz_top_decks.db": true, "leaderboards/ABC.db": true,
+		"../x": false, "a/../../x": false, "/etc/passwd": false, "a//b": false,
+		"./a": false, `a\..\x`: false, "": false, "..": false,
+	} {
+		if got := ValidPath(p); got != want {
+			t.Errorf("ValidPath(%q) = %v, want %v", p, got, want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package datasync
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/closeutil"
+)
+
+// defaultTimeout bounds a single remote request; data files can be large
+const defaultTimeout = 5 * time.Minute
+
+// maxErrorBody caps how much of a rejected response is quoted in errors
+const maxErrorBody = 512
+
+// doRequest sends req and returns the response body, mapping 404 to
+// ErrNotFound
+func doRequest(client *http.Client, req *http.Request, remote string) ([]byte, error) {
+	req.Header.Set("User-Agent", "cr-api")
+	if client == nil {
+		client = &http.Client{Timeout: defaultTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request to %s failed: %w", remote, err)
+	}
+	defer closeutil.WithLog("datasync", resp.Body, "sync response")
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return nil, &statusError{Status: resp.StatusCode, Message: fmt.Sprintf("%s %s rejected by %s: %s %s",
+			req.Method, req.URL.Path, remote, resp.Status, strings.TrimSpace(string(detail)))}
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response from %s: %w", remote, err)
+	}
+	return data, nil
+}
+
+// statusError is a rejected request, kept typed so callers can react to
+// particular status codes
+type statusError struct {
+	Status  int
+	Message string
+}
+
+func (e *statusError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,88 @@
+package datasync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// WebDAV stores files under a collection on a WebDAV server (Nextcloud,
+// ownCloud, Apache mod_dav, rclone serve webdav, ...).
+type WebDAV struct {
+	// URL is the collection that holds the synced files; credentials in
+	// the URL are used when Username is empty
+	URL      string
+	Username string
+	Password string
+	Client   *http.Client
+}
+
+func (w *WebDAV) String() string {
+	u, err := url.Parse(w.URL)
+	if err != nil {
+		return "webdav"
+	}
+	u.User = nil
+	return u.String()
+}
+
+func (w *WebDAV) Get(ctx context.Context, key string) ([]byte, error) {
+	return w.do(ctx, http.MethodGet, key, nil)
+}
+
+// Put uploads data, creating missing parent collections when the server
+// answers 409 Conflict
+func (w *WebDAV) Put(ctx context.Context, key string, data []byte) error {
+	_, err := w.do(ctx, http.MethodPut, key, data)
+	var status *statusError
+	if !errors.As(err, &status) || status.Status != http.StatusConflict {
+		return err
+	}
+	if err := w.makeCollections(ctx, key); err != nil {
+		return err
+	}
+	_, err = w.do(ctx, http.MethodPut, key, data)
+	return err
+}
+
+// makeCollections creates each parent collection of key in turn; 405 Method
+// Not Allowed means the collection already exists
+func (w *WebDAV) makeCollections(ctx context.Context, key string) error {
+	parts := strings.Split(key, "/")
+	for i := 1; i < len(parts); i++ {
+		_, err := w.do(ctx, "MKCOL", strings.Join(parts[:i], "/")+"/", nil)
+		var status *statusError
+		if err != nil && !(errors.As(err, &status) && status.Status == http.StatusMethodNotAllowed) {
+			return fmt.Errorf("failed to create remote folder: %w", err)
+		}
+	}
+	return nil
+}
+
+func (w *WebDAV) do(ctx context.Context, method, key string, body []byte) ([]byte, error) {
+	base, err := url.Parse(strings.TrimSuffix(w.URL, "/"))
+	if err != nil || base.Host == "" {
+		return nil, fmt.Errorf("invalid WebDAV URL %q", w.URL)
+	}
+	username, password := w.Username, w.Password
+	if base.User != nil && username == "" {
+		username = base.User.Username()
+		password, _ = base.User.Password()
+	}
+	base.User = nil
+	base.Path += "/" + key
+
+	req, err := http.NewRequestWithContext(ctx, method, base.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create WebDAV request: %w", err)
+	}
+	req.ContentLength = int64(len(body))
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+	return doRequest(w.Client, req, w.String())
+}
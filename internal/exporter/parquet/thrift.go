@@ -0,0 +1,111 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// Thrift compact protocol type codes
+const (
+	compactI32    = 5
+	compactI64    = 6
+	compactBinary = 8
+	compactList   = 9
+	compactStruct = 12
+)
+
+// compactWriter encodes the subset of the Thrift compact protocol needed for
+// Parquet page headers and file metadata: i32, i64, binary, lists, and
+// nested structs.
+type compactWriter struct {
+	buf bytes.Buffer
+	// lastField holds the previous field ID of each open struct, since
+	// field headers are delta-encoded against it
+	lastField []int16
+}
+
+func (c *compactWriter) beginStruct() {
+	c.lastField = append(c.lastField, 0)
+}
+
+func (c *compactWriter) endStruct() {
+	c.buf.WriteByte(0)
+	c.lastField = c.lastField[:len(c.lastField)-1]
+}
+
+func (c *compactWriter) fieldHeader(id int16, typ byte) {
+	last := &c.lastField[len(c.lastField)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		c.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		c.buf.WriteByte(typ)
+		c.varint(zigzag(int64(id)))
+	}
+	*last = id
+}
+
+func (c *compactWriter) varint(v uint64) {
+	c.buf.Write(binary.AppendUvarint(nil, v))
+}
+
+func zigzag(v int64) uint64 {
+	return uint64(v<<1) ^ uint64(v>>63)
+}
+
+func (c *compactWriter) i32Field(id int16, v int32) {
+	c.fieldHeader(id, compactI32)
+	c.varint(zigzag(int64(v)))
+}
+
+func (c *compactWriter) i64Field(id int16, v int64) {
+	c.fieldHeader(id, compactI64)
+	c.varint(zigzag(v))
+}
+
+func (c *compactWriter) stringField(id int16, v string) {
+	c.fieldHeader(id, compactBinary)
+	c.varint(uint64(len(v)))
+	c.buf.WriteString(v)
+}
+
+func (c *compactWriter) structField(id int16, body func()) {
+	c.fieldHeader(id, compactStruct)
+	c.beginStruct()
+	body()
+	c.endStruct()
+}
+
+func (c *compactWriter) listHeader(id int16, elemType byte, size int) {
+	c.fieldHeader(id, compactList)
+	if size < 15 {
+		c.buf.WriteByte(byte(size)<<4 | elemType)
+		return
+	}
+	c.buf.WriteByte(0xf0 | elemType)
+	c.varint(uint64(size))
+}
+
+func (c *compactWriter) i32ListField(id int16, values ...int32) {
+	c.listHeader(id, compactI32, len(values))
+	for _, v := range values {
+		c.varint(zigzag(int64(v)))
+	}
+}
+
+func (c *compactWriter) stringListField(id int16, values ...string) {
+	c.listHeader(id, compactBinary, len(values))
+	for _, v := range values {
+		c.varint(uint64(len(v)))
+		c.buf.WriteString(v)
+	}
+}
+
+// structListField writes a list of n structs, calling body(i) for each.
+func (c *compactWriter) structListField(id int16, n int, body func(i int)) {
+	c.listHeader(id, compactStruct, n)
+	for i := range n {
+		c.beginStruct()
+		body(i)
+		c.endStruct()
+	}
+}
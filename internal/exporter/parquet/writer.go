@@ -0,0 +1,454 @@
+// Package parquet writes flat tables as Apache Parquet files so bulk results
+// can be loaded straight into DuckDB, pandas, or Spark.
+//
+// The writer covers only what flat exports need: required and optional
+// (nullable) columns of booleans, integers, doubles, strings, and
+// timestamps, stored with PLAIN encoding and no compression. Rows are
+// buffered and flushed as one row group every RowGroupSize rows, so memory
+// stays bounded no matter how many rows are written.
+//
+// It is written here rather than taken from a Parquet library because the
+// maintained Go implementations are built around nested schemas, reflection,
+// and compression codecs, and would add far more code and dependencies than
+// this write-only subset of the format. Readers only see standard Parquet.
+package parquet
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// Type is a column's logical type.
+type Type int
+
+const (
+	Boolean Type = iota
+	Int32
+	Int64
+	Double
+	String
+	// Timestamp is stored as milliseconds since the Unix epoch (UTC).
+	Timestamp
+)
+
+func (t Type) String() string {
+	switch t {
+	case Boolean:
+		return "boolean"
+	case Int32:
+		return "int32"
+	case Int64:
+		return "int64"
+	case Double:
+		return "double"
+	case String:
+		return "string"
+	case Timestamp:
+		return "timestamp"
+	default:
+		return fmt.Sprintf("Type(%d)", int(t))
+	}
+}
+
+// Parquet physical types and converted types from the format spec
+const (
+	physicalBoolean   = 0
+	physicalInt32     = 1
+	physicalInt64     = 2
+	physicalDouble    = 5
+	physicalByteArray = 6
+
+	convertedUTF8            = 0
+	convertedTimestampMillis = 9
+
+	repetitionRequired = 0
+	repetitionOptional = 1
+	encodingPlain      = 0
+	encodingRLE        = 3
+	codecUncompressed  = 0
+	pageTypeData       = 0
+)
+
+func (t Type) physical() int32 {
+	switch t {
+	case Boolean:
+		return physicalBoolean
+	case Int32:
+		return physicalInt32
+	case Int64, Timestamp:
+		return physicalInt64
+	case Double:
+		return physicalDouble
+	default:
+		return physicalByteArray
+	}
+}
+
+// converted returns the converted type annotation, or -1 for none.
+func (t Type) converted() int32 {
+	switch t {
+	case String:
+		return convertedUTF8
+	case Timestamp:
+		return convertedTimestampMillis
+	default:
+		return -1
+	}
+}
+
+// Column describes one column of the table. An Optional column accepts nil
+// for a null value.
+type Column struct {
+	Name     string
+	Type     Type
+	Optional bool
+}
+
+func (c Column) repetition() int32 {
+	if c.Optional {
+		return repetitionOptional
+	}
+	return repetitionRequired
+}
+
+// DefaultRowGroupSize is the number of rows buffered per row group
+const DefaultRowGroupSize = 100_000
+
+// createdBy is recorded in the file footer
+const createdBy = "clash-royale-api"
+
+var magic = []byte("PAR1")
+
+// Writer streams rows into a Parquet file. Call Close to write the footer;
+// the file is unreadable without it.
+type Writer struct {
+	// RowGroupSize is the number of rows per row group. Change it before
+	// the first WriteRow call.
+	RowGroupSize int
+
+	out       io.Writer
+	offset    int64
+	columns   []Column
+	buffers   []columnBuffer
+	rows      int
+	totalRows int64
+	rowGroups []rowGroup
+	closed    bool
+}
+
+type columnBuffer struct {
+	data  []byte
+	bools []bool
+	// defined holds the definition level of each row of an optional
+	// column: false for null
+	defined []bool
+}
+
+type rowGroup struct {
+	numRows   int64
+	totalSize int64
+	chunks    []columnChunk
+}
+
+type columnChunk struct {
+	offset int64
+	size   int64
+}
+
+// NewWriter starts a Parquet file on out with the given columns.
+func NewWriter(out io.Writer, columns []Column) (*Writer, error) {
+	if len(columns) == 0 {
+		return nil, errors.New("parquet: at least one column is required")
+	}
+	seen := make(map[string]bool, len(columns))
+	for _, col := range columns {
+		if col.Name == "" {
+			return nil, errors.New("parquet: column name is required")
+		}
+		if seen[col.Name] {
+			return nil, fmt.Errorf("parquet: duplicate column %q", col.Name)
+		}
+		if col.Type < Boolean || col.Type > Timestamp {
+			return nil, fmt.Errorf("parquet: column %q has unknown type %v", col.Name, col.Type)
+		}
+		seen[col.Name] = true
+	}
+
+	w := &Writer{
+		RowGroupSize: DefaultRowGroupSize,
+		out:          out,
+		columns:      append([]Column(nil), columns...),
+		buffers:      make([]columnBuffer, len(columns)),
+	}
+	if err := w.write(magic); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *Writer) write(p []byte) error {
+	n, err := w.out.Write(p)
+	w.offset += int64(n)
+	if err != nil {
+		return fmt.Errorf("parquet: write failed: %w", err)
+	}
+	return nil
+}
+
+// WriteRow appends one row. Values must match the column types in order:
+// bool, int32 or int, int64 or int, float64, string, and time.Time, or nil
+// in an optional column.
+func (w *Writer) WriteRow(values ...any) error {
+	if w.closed {
+		return errors.New("parquet: write after close")
+	}
+	if len(values) != len(w.columns) {
+		return fmt.Errorf("parquet: row has %d values, want %d", len(values), len(w.columns))
+	}
+	// Check every value before buffering any so a bad row leaves the
+	// columns aligned
+	for i, v := range values {
+		if err := checkValue(w.columns[i], v); err != nil {
+			return err
+		}
+	}
+	for i, v := range values {
+		w.buffers[i].append(w.columns[i], v)
+	}
+
+	w.rows++
+	if w.rows >= max(w.RowGroupSize, 1) {
+		return w.flushRowGroup()
+	}
+	return nil
+}
+
+func checkValue(col Column, v any) error {
+	if v == nil && col.Optional {
+		return nil
+	}
+	ok := false
+	switch col.Type {
+	case Boolean:
+		_, ok = v.(bool)
+	case Int32:
+		switch n := v.(type) {
+		case int32:
+			ok = true
+		case int:
+			if n < math.MinInt32 || n > math.MaxInt32 {
+				return fmt.Errorf("parquet: column %q: %d overflows int32", col.Name, n)
+			}
+			ok = true
+		}
+	case Int64:
+		switch v.(type) {
+		case int64, int:
+			ok = true
+		}
+	case Double:
+		_, ok = v.(float64)
+	case String:
+		_, ok = v.(string)
+	case Timestamp:
+		_, ok = v.(time.Time)
+	}
+	if !ok {
+		return fmt.Errorf("parquet: column %q: expected %v, got %T", col.Name, col.Type, v)
+	}
+	return nil
+}
+
+// append PLAIN-encodes a value that has passed checkValue. A null is only
+// recorded as a definition level.
+func (b *columnBuffer) append(col Column, v any) {
+	if col.Optional {
+		b.defined = append(b.defined, v != nil)
+		if v == nil {
+			return
+		}
+	}
+	switch col.Type {
+	case Boolean:
+		b.bools = append(b.bools, v.(bool))
+	case Int32:
+		var n int32
+		switch x := v.(type) {
+		case int32:
+			n = x
+		case int:
+			n = int32(x)
+		}
+		b.data = binary.LittleEndian.AppendUint32(b.data, uint32(n))
+	case Int64:
+		var n int64
+		switch x := v.(type) {
+		case int64:
+			n = x
+		case int:
+			n = int64(x)
+		}
+		b.data = binary.LittleEndian.AppendUint64(b.data, uint64(n))
+	case Double:
+		b.data = binary.LittleEndian.AppendUint64(b.data, math.Float64bits(v.(float64)))
+	case String:
+		s := v.(string)
+		b.data = binary.LittleEndian.AppendUint32(b.data, uint32(len(s)))
+		b.data = append(b.data, s...)
+	case Timestamp:
+		b.data = binary.LittleEndian.AppendUint64(b.data, uint64(v.(time.Time).UnixMilli()))
+	}
+}
+
+// pageData returns the encoded page body: for an optional column, its
+// definition levels followed by the values of the rows that are not null.
+// PLAIN booleans are bit-packed, least significant bit first.
+func (b *columnBuffer) pageData(col Column) []byte {
+	var page []byte
+	if col.Optional {
+		levels := bitPacked(b.defined)
+		page = binary.LittleEndian.AppendUint32(page, uint32(len(levels)))
+		page = append(page, levels...)
+	}
+	if col.Type != Boolean {
+		return append(page, b.data...)
+	}
+	return append(page, bitPack(b.bools)...)
+}
+
+// bitPack packs bools eight to a byte, least significant bit first
+func bitPack(values []bool) []byte {
+	packed := make([]byte, (len(values)+7)/8)
+	for i, v := range values {
+		if v {
+			packed[i/8] |= 1 << (i % 8)
+		}
+	}
+	return packed
+}
+
+// bitPacked encodes levels of bit width 1 as a single bit-packed run of the
+// RLE/bit-packing hybrid encoding. The run covers whole groups of eight, and
+// readers ignore the padding past the page's value count.
+func bitPacked(levels []bool) []byte {
+	groups := (len(levels) + 7) / 8
+	run := binary.AppendUvarint(nil, uint64(groups)<<1|1)
+	return append(run, bitPack(levels)...)
+}
+
+func (b *columnBuffer) reset() {
+	b.data = b.data[:0]
+	b.bools = b.bools[:0]
+	b.defined = b.defined[:0]
+}
+
+// flushRowGroup writes the buffered rows as one row group with a single
+// data page per column. Columns are flat, so there are no repetition levels,
+// and only optional columns carry definition levels.
+func (w *Writer) flushRowGroup() error {
+	if w.rows == 0 {
+		return nil
+	}
+	group := rowGroup{numRows: int64(w.rows), chunks: make([]columnChunk, len(w.columns))}
+	for i, col := range w.columns {
+		data := w.buffers[i].pageData(col)
+
+		var header compactWriter
+		header.beginStruct()
+		header.i32Field(1, pageTypeData)
+		header.i32Field(2, int32(len(data)))
+		header.i32Field(3, int32(len(data)))
+		header.structField(5, func() {
+			header.i32Field(1, int32(w.rows))
+			header.i32Field(2, encodingPlain)
+			header.i32Field(3, encodingRLE)
+			header.i32Field(4, encodingRLE)
+		})
+		header.endStruct()
+
+		chunk := columnChunk{offset: w.offset, size: int64(header.buf.Len() + len(data))}
+		if err := w.write(header.buf.Bytes()); err != nil {
+			return err
+		}
+		if err := w.write(data); err != nil {
+			return err
+		}
+		group.chunks[i] = chunk
+		group.totalSize += chunk.size
+		w.buffers[i].reset()
+	}
+	w.rowGroups = append(w.rowGroups, group)
+	w.totalRows += group.numRows
+	w.rows = 0
+	return nil
+}
+
+// Close flushes buffered rows and writes the file footer. It does not close
+// the underlying writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	if err := w.flushRowGroup(); err != nil {
+		return err
+	}
+	w.closed = true
+
+	footer := w.fileMetadata()
+	if err := w.write(footer); err != nil {
+		return err
+	}
+	if err := w.write(binary.LittleEndian.AppendUint32(nil, uint32(len(footer)))); err != nil {
+		return err
+	}
+	return w.write(magic)
+}
+
+// fileMetadata encodes the FileMetaData footer struct.
+func (w *Writer) fileMetadata() []byte {
+	var c compactWriter
+	c.beginStruct()
+	c.i32Field(1, 1)
+	c.structListField(2, len(w.columns)+1, func(i int) {
+		if i == 0 {
+			c.stringField(4, "schema")
+			c.i32Field(5, int32(len(w.columns)))
+			return
+		}
+		col := w.columns[i-1]
+		c.i32Field(1, col.Type.physical())
+		c.i32Field(3, col.repetition())
+		c.stringField(4, col.Name)
+		if converted := col.Type.converted(); converted >= 0 {
+			c.i32Field(6, converted)
+		}
+	})
+	c.i64Field(3, w.totalRows)
+	c.structListField(4, len(w.rowGroups), func(g int) {
+		group := w.rowGroups[g]
+		c.structListField(1, len(group.chunks), func(i int) {
+			chunk := group.chunks[i]
+			col := w.columns[i]
+			c.i64Field(2, chunk.offset)
+			c.structField(3, func() {
+				c.i32Field(1, col.Type.physical())
+				c.i32ListField(2, encodingPlain)
+				c.stringListField(3, col.Name)
+				c.i32Field(4, codecUncompressed)
+				c.i64Field(5, group.numRows)
+				c.i64Field(6, chunk.size)
+				c.i64Field(7, chunk.size)
+				c.i64Field(9, chunk.offset)
+			})
+		})
+		c.i64Field(2, group.totalSize)
+		c.i64Field(3, group.numRows)
+	})
+	c.stringField(6, createdBy)
+	c.endStruct()
+	return c.buf.Bytes()
+}
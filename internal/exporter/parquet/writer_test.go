@@ -0,0 +1,298 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// compactReader decodes Thrift compact structs into maps keyed by field ID so
+// tests can inspect the footer and page headers without a Parquet library.
+type compactReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *compactReader) byte() byte {
+	b := r.data[r.pos]
+	r.pos++
+	return b
+}
+
+func (r *compactReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	r.pos += n
+	return v
+}
+
+func (r *compactReader) int() int64 {
+	u := r.uvarint()
+	return int64(u>>1) ^ -int64(u&1)
+}
+
+func (r *compactReader) value(typ byte) any {
+	switch typ {
+	case compactI32, compactI64:
+		return r.int()
+	case compactBinary:
+		n := int(r.uvarint())
+		s := string(r.data[r.pos : r.pos+n])
+		r.pos += n
+		return s
+	case compactList:
+		header := r.byte()
+		size := int(header >> 4)
+		if size == 15 {
+			size = int(r.uvarint())
+		}
+		list := make([]any, size)
+		for i := range list {
+			list[i] = r.value(header & 0x0f)
+		}
+		return list
+	case compactStruct:
+		return r.structure()
+	default:
+		panic("unexpected compact type")
+	}
+}
+
+func (r *compactReader) structure() map[int16]any {
+	fields := map[int16]any{}
+	var last int16
+	for {
+		header := r.byte()
+		if header == 0 {
+			return fields
+		}
+		id := last + int16(header>>4)
+		if header>>4 == 0 {
+			id = int16(r.int())
+		}
+		fields[id] = r.value(header & 0x0f)
+		last = id
+	}
+}
+
+func readFooter(t *testing.T, file []byte) map[int16]any {
+	t.Helper()
+	if !bytes.HasPrefix(file, magic) || !bytes.HasSuffix(file, magic) {
+		t.Fatal("missing PAR1 magic")
+	}
+	size := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+	r := &compactReader{data: file[len(file)-8-size : len(file)-8]}
+	return r.structure()
+}
+
+// readPage returns the number of values and the body of the data page at
+// offset.
+func readPage(t *testing.T, file []byte, offset int64) (int64, []byte) {
+	t.Helper()
+	r := &compactReader{data: file, pos: int(offset)}
+	header := r.structure()
+	size := int(header[3].(int64))
+	dataHeader := header[5].(map[int16]any)
+	return dataHeader[1].(int64), file[r.pos : r.pos+size]
+}
+
+var testColumns = []Column{
+	{Name: "deck", Type: String},
+	{Name: "score", Type: Double},
+	{Name: "rank", Type: Int32},
+	{Name: "id", Type: Int64},
+	{Name: "saved", Type: Boolean},
+	{Name: "evaluated_at", Type: Timestamp},
+}
+
+func TestWriterRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, testColumns)
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+	w.RowGroupSize = 2
+
+	evaluated := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	rows := [][]any{
+		{"Hog Rider-Fireball", 8.5, 1, int64(100), true, evaluated},
+		{"Golem-Night Witch", 7.25, int32(2), 200, false, evaluated},
+		{"X-Bow-Tesla", 6.0, 3, int64(300), true, evaluated.Add(time.Second)},
+	}
+	for _, row := range rows {
+		if err := w.WriteRow(row...); err != nil {
+			t.Fatalf("WriteRow() error = %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	file := buf.Bytes()
+	footer := readFooter(t, file)
+	if got := footer[3].(int64); got != 3 {
+		t.Errorf("num_rows = %d, want 3", got)
+	}
+	if got := footer[6].(string); got != createdBy {
+		t.Errorf("created_by = %q", got)
+	}
+
+	schema := footer[2].([]any)
+	if len(schema) != len(testColumns)+1 || schema[0].(map[int16]any)[5].(int64) != int64(len(testColumns)) {
+		t.Fatalf("schema = %v", schema)
+	}
+	for i, col := range testColumns {
+		element := schema[i+1].(map[int16]any)
+		if element[4] != col.Name || element[1].(int64) != int64(col.Type.physical()) {
+			t.Errorf("schema[%d] = %v, want %s", i+1, element, col.Name)
+		}
+	}
+	if converted := schema[6].(map[int16]any)[6].(int64); converted != convertedTimestampMillis {
+		t.Errorf("timestamp converted type = %d", converted)
+	}
+
+	groups := footer[4].([]any)
+	if len(groups) != 2 {
+		t.Fatalf("row groups = %d, want 2", len(groups))
+	}
+
+	var decks []string
+	var scores []float64
+	var flags []bool
+	for _, g := range groups {
+		chunks := g.(map[int16]any)[1].([]any)
+		meta := func(i int) map[int16]any { return chunks[i].(map[int16]any)[3].(map[int16]any) }
+
+		n, data := readPage(t, file, meta(0)[9].(int64))
+		for range n {
+			size := int(binary.LittleEndian.Uint32(data))
+			decks = append(decks, string(data[4:4+size]))
+			data = data[4+size:]
+		}
+		n, data = readPage(t, file, meta(1)[9].(int64))
+		for i := range n {
+			scores = append(scores, math.Float64frombits(binary.LittleEndian.Uint64(data[i*8:])))
+		}
+		n, data = readPage(t, file, meta(4)[9].(int64))
+		for i := range n {
+			flags = append(flags, data[i/8]&(1<<(i%8)) != 0)
+		}
+		if got := meta(5)[3].([]any)[0]; got != "evaluated_at" {
+			t.Errorf("path_in_schema = %v", got)
+		}
+	}
+
+	if len(decks) != 3 || decks[0] != "Hog Rider-Fireball" || decks[2] != "X-Bow-Tesla" {
+		t.Errorf("decks = %v", decks)
+	}
+	if len(scores) != 3 || scores[1] != 7.25 {
+		t.Errorf("scores = %v", scores)
+	}
+	if len(flags) != 3 || !flags[0] || flags[1] || !flags[2] {
+		t.Errorf("flags = %v", flags)
+	}
+}
+
+func TestWriterOptionalColumns(t *testing.T) {
+	var buf bytes.Buffer
+	columns := []Column{{Name: "stored_score", Type: Double, Optional: true}, {Name: "favorite", Type: Boolean, Optional: true}}
+	w, err := NewWriter(&buf, columns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range [][]any{{8.5, true}, {nil, nil}, {6.0, false}} {
+		if err := w.WriteRow(row...); err != nil {
+			t.Fatalf("WriteRow(%v) error = %v", row, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file := buf.Bytes()
+	footer := readFooter(t, file)
+	for i, element := range footer[2].([]any)[1:] {
+		if got := element.(map[int16]any)[3].(int64); got != repetitionOptional {
+			t.Errorf("column %d repetition = %d, want optional", i, got)
+		}
+	}
+	chunks := footer[4].([]any)[0].(map[int16]any)[1].([]any)
+	offset := func(i int) int64 { return chunks[i].(map[int16]any)[3].(map[int16]any)[9].(int64) }
+
+	// Definition levels: a 4-byte length, then one bit-packed run of one
+	// group (header 0x03) marking rows 0 and 2 as defined
+	n, data := readPage(t, file, offset(0))
+	if n != 3 || binary.LittleEndian.Uint32(data) != 2 || data[4] != 0x03 || data[5] != 0b101 {
+		t.Fatalf("score page: %d values, levels % x", n, data[:min(len(data), 6)])
+	}
+	values := data[6:]
+	if len(values) != 16 || math.Float64frombits(binary.LittleEndian.Uint64(values)) != 8.5 ||
+		math.Float64frombits(binary.LittleEndian.Uint64(values[8:])) != 6.0 {
+		t.Errorf("score values = % x, want only the two non-null doubles", values)
+	}
+
+	_, data = readPage(t, file, offset(1))
+	if data[5] != 0b101 || len(data) != 7 || data[6] != 0b01 {
+		t.Errorf("favorite page = % x, want levels 0b101 and values true, false", data)
+	}
+}
+
+func TestWriterEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, testColumns[:1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	footer := readFooter(t, buf.Bytes())
+	if footer[3].(int64) != 0 {
+		t.Errorf("num_rows = %v, want 0", footer[3])
+	}
+	if _, ok := footer[4]; !ok {
+		t.Error("row_groups must be present even when empty")
+	}
+}
+
+func TestWriterRejectsBadInput(t *testing.T) {
+	if _, err := NewWriter(&bytes.Buffer{}, nil); err == nil {
+		t.Error("expected an error without columns")
+	}
+	if _, err := NewWriter(&bytes.Buffer{}, []Column{{Name: "a"}, {Name: "a"}}); err == nil {
+		t.Error("expected an error for duplicate columns")
+	}
+
+	w, err := NewWriter(&bytes.Buffer{}, testColumns[:3])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteRow("deck", 1.0); err == nil {
+		t.Error("expected an error for a short row")
+	}
+	if err := w.WriteRow("deck", 1, 1); err == nil {
+		t.Error("expected an error for an int in a double column")
+	}
+	if err := w.WriteRow("deck", 1.0, math.MaxInt32+1); err == nil {
+		t.Error("expected an error for int32 overflow")
+	}
+	if err := w.WriteRow("deck", nil, 1); err == nil {
+		t.Error("expected an error for nil in a required column")
+	}
+	// Rejected rows must not leave partial values behind
+	if w.buffers[0].data != nil {
+		t.Errorf("rejected rows were buffered: %q", w.buffers[0].data)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestWriterPropagatesWriteErrors(t *testing.T) {
+	if _, err := NewWriter(failingWriter{}, testColumns); err == nil {
+		t.Error("expected the write error from NewWriter")
+	}
+}
@@ -0,0 +1,296 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/pkg/analysis"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+)
+
+// rarityOrder lists rarities from most to least common
+var rarityOrder = []string{"Common", "Rare", "Epic", "Legendary", "Champion"}
+
+// rarityColors are chart colors matching the in-game rarity frames
+var rarityColors = map[string]string{
+	"Common":    "#8fa6bb",
+	"Rare":      "#f0922b",
+	"Epic":      "#a24fd6",
+	"Legendary": "#3fbfb3",
+	"Champion":  "#d8b330",
+}
+
+// FromCardAnalysis builds a collection report: summary, rarity charts, and
+// upgrade priorities.
+func FromCardAnalysis(a *analysis.CardAnalysis) *Report {
+	summary := a.Summary
+	r := &Report{
+		Title:       "Card Collection Analysis",
+		Subtitle:    playerSubtitle(a.PlayerName, a.PlayerTag),
+		GeneratedAt: a.AnalysisTime,
+	}
+
+	r.Sections = append(r.Sections, Section{
+		Title: "Summary",
+		Table: &Table{
+			Headers: []string{"Metric", "Value"},
+			Rows: [][]string{
+				{"Total cards", fmt.Sprint(summary.TotalCards)},
+				{"Max level cards", fmt.Sprintf("%d (%.1f%%)", summary.MaxLevelCards, summary.CompletionPercent)},
+				{"Average level", fmt.Sprintf("%.2f", summary.AvgCardLevel)},
+				{"Ready to upgrade", fmt.Sprint(summary.UpgradableCards)},
+				{"Overall progress", fmt.Sprintf("%.1f%%", summary.AvgLevelRatio*100)},
+			},
+		},
+	})
+
+	if len(a.RarityBreakdown) > 0 {
+		progress := &BarChart{Max: 100}
+		table := &Table{Headers: []string{"Rarity", "Cards", "Max Level", "Avg Level", "Ready", "Near Max"}}
+		for _, rarity := range rarityOrder {
+			stats, ok := a.RarityBreakdown[rarity]
+			if !ok {
+				continue
+			}
+			progress.Bars = append(progress.Bars, Bar{
+				Label: rarity,
+				Value: stats.AvgLevelRatio * 100,
+				Text:  fmt.Sprintf("%.1f%%", stats.AvgLevelRatio*100),
+				Color: rarityColors[rarity],
+			})
+			table.Rows = append(table.Rows, []string{
+				rarity,
+				fmt.Sprint(stats.TotalCards),
+				fmt.Sprint(stats.MaxLevelCards),
+				fmt.Sprintf("%.1f", stats.AvgLevel),
+				fmt.Sprint(stats.CardsReadyUpgrade),
+				fmt.Sprint(stats.CardsNearMax),
+			})
+		}
+		r.Sections = append(r.Sections, Section{
+			Title:      "Rarity Breakdown",
+			Paragraphs: []string{"Average level progress toward max, by rarity."},
+			Chart:      progress,
+			Table:      table,
+		})
+	}
+
+	upgrades := Section{Title: "Upgrade Priorities"}
+	if len(a.UpgradePriority) == 0 {
+		upgrades.Paragraphs = []string{"No upgrade priorities found."}
+	} else {
+		upgrades.Chart = &BarChart{Max: 100}
+		upgrades.Table = &Table{Headers: []string{"Card", "Rarity", "Level", "Owned", "Needed", "Score", "Priority", "Reasons"}}
+		for _, p := range a.UpgradePriority {
+			upgrades.Chart.Bars = append(upgrades.Chart.Bars, Bar{
+				Label: p.CardName,
+				Value: p.PriorityScore,
+				Text:  fmt.Sprintf("%.1f", p.PriorityScore),
+				Color: rarityColors[p.Rarity],
+			})
+			upgrades.Table.Rows = append(upgrades.Table.Rows, []string{
+				p.CardName,
+				p.Rarity,
+				fmt.Sprintf("%d/%d", p.CurrentLevel, p.MaxLevel),
+				fmt.Sprint(p.CardsOwned),
+				fmt.Sprint(p.CardsNeeded),
+				fmt.Sprintf("%.1f", p.PriorityScore),
+				p.Priority,
+				strings.Join(p.Reasons, "; "),
+			})
+		}
+	}
+	r.Sections = append(r.Sections, upgrades)
+	return r
+}
+
+// FromPlaystyle builds a playstyle report, including deck recommendations
+// when recommendations is non-nil.
+func FromPlaystyle(p *analysis.PlaystyleAnalysis, recommendations *analysis.DeckRecommendationResult) *Report {
+	r := &Report{
+		Title:       "Playstyle Analysis",
+		Subtitle:    playerSubtitle(p.PlayerName, p.PlayerTag),
+		GeneratedAt: p.AnalysisTime,
+	}
+
+	r.Sections = append(r.Sections, Section{
+		Title: "Overall Statistics",
+		Table: &Table{
+			Headers: []string{"Metric", "Value"},
+			Rows: [][]string{
+				{"Total battles", fmt.Sprint(p.TotalBattles)},
+				{"Record", fmt.Sprintf("%dW - %dL", p.Wins, p.Losses)},
+				{"Win rate", fmt.Sprintf("%.1f%%", p.WinRate)},
+				{"Three-crown wins", fmt.Sprintf("%d (%.1f%% of wins)", p.ThreeCrownWins, p.ThreeCrownRate)},
+			},
+		},
+		Chart: &BarChart{Max: 100, Bars: []Bar{
+			{Label: "Win rate", Value: p.WinRate, Text: fmt.Sprintf("%.1f%%", p.WinRate), Color: "#3d9a5b"},
+			{Label: "Three-crown rate", Value: p.ThreeCrownRate, Text: fmt.Sprintf("%.1f%%", p.ThreeCrownRate), Color: "#d8b330"},
+		}},
+	})
+
+	profile := [][]string{
+		{"Aggression", p.AggressionLevel},
+		{"Consistency", p.Consistency},
+		{"Deck style", p.DeckStyle},
+		{"Elixir distribution", p.DeckElixirDistribution},
+	}
+	if p.CurrentWinCondition != "" {
+		profile = append(profile,
+			[]string{"Win condition", p.CurrentWinCondition},
+			[]string{"Average elixir", fmt.Sprintf("%.1f", p.CurrentDeckAvgElixir)},
+		)
+	}
+	r.Sections = append(r.Sections, Section{
+		Title:   "Playstyle Profile",
+		Table:   &Table{Headers: []string{"Trait", "Value"}, Rows: profile},
+		Bullets: p.PlaystyleTraits,
+	})
+
+	if len(p.CurrentDeckCards) > 0 {
+		r.Sections = append(r.Sections, Section{
+			Title:      "Current Deck",
+			Paragraphs: []string{strings.Join(p.CurrentDeckCards, ", ")},
+		})
+	}
+
+	if recommendations != nil && recommendations.Recommended != nil {
+		r.Sections = append(r.Sections, recommendationSection(recommendations))
+	}
+	return r
+}
+
+func recommendationSection(result *analysis.DeckRecommendationResult) Section {
+	top := result.Recommended
+	section := Section{
+		Title: "Recommended Deck",
+		Paragraphs: []string{fmt.Sprintf("%s: %s win condition, %.1f average elixir, %d/100 match (%s).",
+			top.Deck.DeckName, top.Deck.WinCondition, top.Deck.AverageElixir, top.Score, top.Compatibility)},
+		Bullets: top.Reasons,
+		Chart:   &BarChart{Max: 100},
+	}
+	for _, rec := range result.AllScores {
+		section.Chart.Bars = append(section.Chart.Bars, Bar{
+			Label: rec.Deck.DeckName,
+			Value: float64(rec.Score),
+			Text:  fmt.Sprintf("%d/100", rec.Score),
+		})
+	}
+	if len(top.Deck.DeckDetail) > 0 {
+		section.Table = &Table{Headers: []string{"Card", "Level", "Elixir"}}
+		for _, card := range top.Deck.DeckDetail {
+			section.Table.Rows = append(section.Table.Rows, []string{
+				card.Name,
+				fmt.Sprintf("%d/%d", card.Level, card.MaxLevel),
+				fmt.Sprint(card.Elixir),
+			})
+		}
+	}
+	return section
+}
+
+// FromEvaluation builds a deck evaluation report: category score chart,
+// critical flaws, and every analysis section.
+func FromEvaluation(result *evaluation.EvaluationResult) *Report {
+	r := &Report{
+		Title:    "Deck Evaluation",
+		Subtitle: strings.Join(result.Deck, ", "),
+	}
+
+	overview := []string{fmt.Sprintf("Overall score %.1f/10 (%s). Archetype: %s (%.0f%% confidence). Average elixir %.1f.",
+		result.OverallScore, result.OverallRating, result.DetectedArchetype, result.ArchetypeConfidence*100, result.AvgElixir)}
+	if result.DeckLink != nil && result.DeckLink.URL != "" {
+		overview = append(overview, "Copy deck: "+result.DeckLink.URL)
+	}
+
+	type category struct {
+		name  string
+		score evaluation.CategoryScore
+	}
+	categories := []category{
+		{"Attack", result.Attack},
+		{"Defense", result.Defense},
+		{"Synergy", result.Synergy},
+		{"Versatility", result.Versatility},
+		{"F2P Friendly", result.F2PFriendly},
+		{"Playability", result.Playability},
+	}
+	if result.MetaCounter != nil {
+		categories = append(categories, category{"Meta Counter", *result.MetaCounter})
+	}
+	chart := &BarChart{Max: 10}
+	table := &Table{Headers: []string{"Category", "Score", "Rating", "Assessment"}}
+	for _, c := range categories {
+		chart.Bars = append(chart.Bars, Bar{
+			Label: c.name,
+			Value: c.score.Score,
+			Text:  fmt.Sprintf("%.1f/10", c.score.Score),
+			Color: scoreColor(c.score.Score),
+		})
+		table.Rows = append(table.Rows, []string{c.name, fmt.Sprintf("%.1f", c.score.Score), string(c.score.Rating), c.score.Assessment})
+	}
+	r.Sections = append(r.Sections, Section{Title: "Scores", Paragraphs: overview, Chart: chart, Table: table})
+
+	if len(result.CriticalFlaws) > 0 {
+		flaws := Section{Title: "Critical Flaws", Table: &Table{Headers: []string{"Flaw", "Penalty"}}}
+		for _, flaw := range result.CriticalFlaws {
+			flaws.Table.Rows = append(flaws.Table.Rows, []string{flaw.Description, fmt.Sprintf("-%.1f", flaw.Penalty)})
+		}
+		r.Sections = append(r.Sections, flaws)
+	}
+
+	sections := []evaluation.AnalysisSection{
+		result.AttackAnalysis,
+		result.DefenseAnalysis,
+		result.BaitAnalysis,
+		result.CycleAnalysis,
+		result.LadderAnalysis,
+		result.EvolutionAnalysis,
+		result.DamageRaceAnalysis,
+	}
+	if result.MetaAnalysis != nil {
+		sections = append(sections, *result.MetaAnalysis)
+	}
+	for _, s := range sections {
+		if s.Title == "" {
+			continue
+		}
+		section := Section{Title: fmt.Sprintf("%s (%.1f/10)", s.Title, s.Score), Bullets: s.Details}
+		if s.Summary != "" {
+			section.Paragraphs = []string{s.Summary}
+		}
+		r.Sections = append(r.Sections, section)
+	}
+
+	if missing := result.MissingCardsAnalysis; missing != nil && len(missing.MissingCards) > 0 {
+		section := Section{Title: "Missing Cards", Table: &Table{Headers: []string{"Card", "Suggested replacements"}}}
+		for _, card := range missing.MissingCards {
+			section.Table.Rows = append(section.Table.Rows, []string{
+				card.Name,
+				strings.Join(missing.SuggestedReplacements[card.Name], ", "),
+			})
+		}
+		r.Sections = append(r.Sections, section)
+	}
+	return r
+}
+
+func playerSubtitle(name, tag string) string {
+	if name == "" {
+		return tag
+	}
+	return fmt.Sprintf("%s (%s)", name, tag)
+}
+
+// scoreColor shades a 0-10 score from red through amber to green.
+func scoreColor(score float64) string {
+	switch {
+	case score >= 7:
+		return "#3d9a5b"
+	case score >= 5:
+		return "#d8a030"
+	default:
+		return "#c94a4a"
+	}
+}
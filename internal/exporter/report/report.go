@@ -0,0 +1,250 @@
+// Package report renders analysis results as shareable documents: a
+// self-contained HTML page (inline styles, no external assets) or Markdown.
+//
+// A Report is a format-neutral list of sections holding text, bar charts,
+// and tables. Builders in this package turn card analysis, playstyle, and
+// deck evaluation results into Reports; WriteHTML and WriteMarkdown render
+// them.
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Format is a report output format.
+type Format string
+
+const (
+	FormatHTML     Format = "html"
+	FormatMarkdown Format = "markdown"
+)
+
+// Report is a titled document of sections.
+type Report struct {
+	Title       string
+	Subtitle    string
+	GeneratedAt time.Time
+	Sections    []Section
+}
+
+// Section is one headed block of a report. Every part is optional and
+// rendered in field order.
+type Section struct {
+	Title      string
+	Paragraphs []string
+	Bullets    []string
+	Chart      *BarChart
+	Table      *Table
+}
+
+// Table is a simple grid with a header row.
+type Table struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// BarChart is a horizontal bar chart. Bars are scaled against Max.
+type BarChart struct {
+	Max  float64
+	Bars []Bar
+}
+
+// Bar is one chart row. Label is shown next to the bar; Text (for example
+// "7.5/10") next to its end. Color is a CSS color for HTML output.
+type Bar struct {
+	Label string
+	Value float64
+	Text  string
+	Color string
+}
+
+// percent returns the bar's width as a percentage of the chart maximum.
+func (c *BarChart) percent(b Bar) float64 {
+	if c.Max <= 0 {
+		return 0
+	}
+	return min(max(b.Value/c.Max*100, 0), 100)
+}
+
+// Write renders the report in the given format.
+func Write(w io.Writer, format Format, r *Report) error {
+	switch format {
+	case FormatHTML:
+		return WriteHTML(w, r)
+	case FormatMarkdown:
+		return WriteMarkdown(w, r)
+	default:
+		return fmt.Errorf("unsupported report format %q", format)
+	}
+}
+
+// WriteFile renders the report to path, creating parent directories.
+func WriteFile(path string, format Format, r *Report) error {
+	var buf bytes.Buffer
+	if err := Write(&buf, format, r); err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create report directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"percent": func(c *BarChart, b Bar) string { return fmt.Sprintf("%.1f%%", c.percent(b)) },
+	"color": func(b Bar) template.CSS {
+		if b.Color == "" {
+			return "#4a7bd0"
+		}
+		return template.CSS(b.Color)
+	},
+	"timestamp": func(t time.Time) string { return t.Format("2006-01-02 15:04:05") },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem auto; max-width: 960px; padding: 0 1rem; color: #1f2430; background: #f7f8fb; }
+h1 { margin-bottom: 0.2rem; }
+.subtitle, .generated { color: #5a6275; margin: 0.2rem 0; }
+section { background: #fff; border-radius: 8px; box-shadow: 0 1px 3px rgba(0,0,0,0.08); margin: 1.5rem 0; padding: 1rem 1.5rem; }
+table { border-collapse: collapse; width: 100%; margin: 0.5rem 0; font-size: 0.92rem; }
+th, td { border-bottom: 1px solid #e3e6ee; padding: 0.4rem 0.6rem; text-align: left; }
+th { background: #eef1f7; }
+.chart { margin: 0.5rem 0; }
+.bar-row { display: flex; align-items: center; margin: 0.3rem 0; }
+.bar-label { width: 9rem; flex-shrink: 0; }
+.bar-track { flex-grow: 1; background: #eef1f7; border-radius: 4px; height: 1.1rem; }
+.bar-fill { height: 100%; border-radius: 4px; }
+.bar-text { width: 6rem; flex-shrink: 0; text-align: right; font-variant-numeric: tabular-nums; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{- if .Subtitle}}
+<p class="subtitle">{{.Subtitle}}</p>
+{{- end}}
+{{- if not .GeneratedAt.IsZero}}
+<p class="generated">Generated {{timestamp .GeneratedAt}}</p>
+{{- end}}
+{{- range .Sections}}
+<section>
+<h2>{{.Title}}</h2>
+{{- range .Paragraphs}}
+<p>{{.}}</p>
+{{- end}}
+{{- if .Bullets}}
+<ul>
+{{- range .Bullets}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- with $chart := .Chart}}
+<div class="chart">
+{{- range .Bars}}
+<div class="bar-row"><span class="bar-label">{{.Label}}</span><div class="bar-track"><div class="bar-fill" style="width: {{percent $chart .}}; background: {{color .}}"></div></div><span class="bar-text">{{.Text}}</span></div>
+{{- end}}
+</div>
+{{- end}}
+{{- with .Table}}
+<table>
+<thead><tr>{{range .Headers}}<th>{{.}}</th>{{end}}</tr></thead>
+<tbody>
+{{- range .Rows}}
+<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+</section>
+{{- end}}
+</body>
+</html>
+`))
+
+// WriteHTML renders the report as a single self-contained HTML page.
+func WriteHTML(w io.Writer, r *Report) error {
+	if err := htmlTemplate.Execute(w, r); err != nil {
+		return fmt.Errorf("failed to render HTML report: %w", err)
+	}
+	return nil
+}
+
+// markdownBarWidth is the length of a full bar in Markdown charts
+const markdownBarWidth = 20
+
+// WriteMarkdown renders the report as Markdown. Charts become text bars.
+func WriteMarkdown(w io.Writer, r *Report) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", r.Title)
+	if r.Subtitle != "" {
+		fmt.Fprintf(&b, "%s\n\n", r.Subtitle)
+	}
+	if !r.GeneratedAt.IsZero() {
+		fmt.Fprintf(&b, "_Generated %s_\n\n", r.GeneratedAt.Format("2006-01-02 15:04:05"))
+	}
+
+	for _, section := range r.Sections {
+		fmt.Fprintf(&b, "## %s\n\n", section.Title)
+		for _, p := range section.Paragraphs {
+			fmt.Fprintf(&b, "%s\n\n", p)
+		}
+		if len(section.Bullets) > 0 {
+			for _, bullet := range section.Bullets {
+				fmt.Fprintf(&b, "- %s\n", bullet)
+			}
+			b.WriteString("\n")
+		}
+		if chart := section.Chart; chart != nil && len(chart.Bars) > 0 {
+			b.WriteString("```text\n")
+			labelWidth := 0
+			for _, bar := range chart.Bars {
+				labelWidth = max(labelWidth, len([]rune(bar.Label)))
+			}
+			for _, bar := range chart.Bars {
+				filled := int(chart.percent(bar)/100*markdownBarWidth + 0.5)
+				fmt.Fprintf(&b, "%-*s %s%s %s\n", labelWidth, bar.Label,
+					strings.Repeat("█", filled), strings.Repeat("░", markdownBarWidth-filled), bar.Text)
+			}
+			b.WriteString("```\n\n")
+		}
+		if table := section.Table; table != nil && len(table.Headers) > 0 {
+			writeMarkdownRow(&b, table.Headers)
+			separator := make([]string, len(table.Headers))
+			for i := range separator {
+				separator[i] = "---"
+			}
+			writeMarkdownRow(&b, separator)
+			for _, row := range table.Rows {
+				writeMarkdownRow(&b, row)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeMarkdownRow(b *strings.Builder, cells []string) {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = strings.ReplaceAll(cell, "|", `\|`)
+	}
+	fmt.Fprintf(b, "| %s |\n", strings.Join(escaped, " | "))
+}
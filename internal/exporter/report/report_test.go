@@ -0,0 +1,149 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/analysis"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+)
+
+func sampleReport() *Report {
+	return &Report{
+		Title:       "Sample <Report>",
+		Subtitle:    "Player (#ABC)",
+		GeneratedAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		Sections: []Section{{
+			Title:      "Scores",
+			Paragraphs: []string{"Overall 7.5"},
+			Bullets:    []string{"Hog Rider | Fireball"},
+			Chart: &BarChart{Max: 10, Bars: []Bar{
+				{Label: "Attack", Value: 7.5, Text: "7.5/10", Color: "#3d9a5b"},
+				{Label: "Defense", Value: 12, Text: "12/10"},
+			}},
+			Table: &Table{Headers: []string{"Card", "Note"}, Rows: [][]string{{"P.E.K.K.A", "a|b"}}},
+		}},
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteHTML(&buf, sampleReport()); err != nil {
+		t.Fatalf("WriteHTML() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"<title>Sample &lt;Report&gt;</title>",
+		"Generated 2026-10-01 12:00:00",
+		`style="width: 75.0%; background: #3d9a5b"`,
+		`style="width: 100.0%; background: #4a7bd0"`,
+		"<li>Hog Rider | Fireball</li>",
+		"<td>P.E.K.K.A</td><td>a|b</td>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("HTML missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "<link") || strings.Contains(out, "<script src") {
+		t.Error("HTML report should not reference external assets")
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteMarkdown(&buf, sampleReport()); err != nil {
+		t.Fatalf("WriteMarkdown() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"# Sample <Report>\n\nPlayer (#ABC)\n\n_Generated 2026-10-01 12:00:00_",
+		"## Scores",
+		"- Hog Rider | Fireball",
+		"Attack  " + strings.Repeat("█", 15) + strings.Repeat("░", 5) + " 7.5/10",
+		"Defense " + strings.Repeat("█", 20) + " 12/10",
+		"| Card | Note |\n| --- | --- |\n| P.E.K.K.A | a\\|b |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Markdown missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reports", "out.md")
+	if err := WriteFile(path, FormatMarkdown, sampleReport()); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || !strings.HasPrefix(string(data), "# Sample") {
+		t.Errorf("written report = %q, %v", data, err)
+	}
+	if err := WriteFile(path, Format("pdf"), sampleReport()); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func sectionTitles(r *Report) []string {
+	titles := make([]string, len(r.Sections))
+	for i, s := range r.Sections {
+		titles[i] = s.Title
+	}
+	return titles
+}
+
+func TestFromCardAnalysis(t *testing.T) {
+	a := &analysis.CardAnalysis{
+		PlayerTag:  "#ABC",
+		PlayerName: "Player",
+		Summary:    analysis.CollectionSummary{TotalCards: 100, MaxLevelCards: 10, CompletionPercent: 10},
+		RarityBreakdown: map[string]analysis.RarityStats{
+			"Epic":   {TotalCards: 20, AvgLevelRatio: 0.5},
+			"Common": {TotalCards: 30, AvgLevelRatio: 0.8},
+		},
+		UpgradePriority: []analysis.UpgradePriority{
+			{CardName: "Hog Rider", Rarity: "Rare", CurrentLevel: 12, MaxLevel: 16, PriorityScore: 80, Priority: "high", Reasons: []string{"Win condition", "Close to upgrade"}},
+		},
+	}
+
+	r := FromCardAnalysis(a)
+	if got := strings.Join(sectionTitles(r), ","); got != "Summary,Rarity Breakdown,Upgrade Priorities" {
+		t.Fatalf("sections = %s", got)
+	}
+	rarity := r.Sections[1].Chart.Bars
+	if len(rarity) != 2 || rarity[0].Label != "Common" || rarity[0].Value != 80 || rarity[1].Color != rarityColors["Epic"] {
+		t.Errorf("rarity bars = %+v, want Common then Epic", rarity)
+	}
+	row := r.Sections[2].Table.Rows[0]
+	if row[2] != "12/16" || row[7] != "Win condition; Close to upgrade" {
+		t.Errorf("upgrade row = %v", row)
+	}
+}
+
+func TestFromEvaluation(t *testing.T) {
+	result := &evaluation.EvaluationResult{
+		Deck:           []string{"Hog Rider", "Fireball"},
+		OverallScore:   7.2,
+		OverallRating:  evaluation.RatingGreat,
+		Attack:         evaluation.CategoryScore{Score: 8},
+		Defense:        evaluation.CategoryScore{Score: 4},
+		MetaCounter:    &evaluation.CategoryScore{Score: 6},
+		AttackAnalysis: evaluation.AnalysisSection{Title: "Attack Analysis", Score: 8, Details: []string{"Hog Rider pressure"}},
+		CriticalFlaws:  []evaluation.CriticalFlaw{{Description: "No building", Penalty: 0.5}},
+	}
+
+	r := FromEvaluation(result)
+	if got := strings.Join(sectionTitles(r), ","); got != "Scores,Critical Flaws,Attack Analysis (8.0/10)" {
+		t.Fatalf("sections = %s", got)
+	}
+	bars := r.Sections[0].Chart.Bars
+	if len(bars) != 7 || bars[6].Label != "Meta Counter" || bars[0].Color != scoreColor(8) || bars[1].Color != scoreColor(4) {
+		t.Errorf("score bars = %+v", bars)
+	}
+	if got := r.Sections[1].Table.Rows[0][1]; got != "-0.5" {
+		t.Errorf("flaw penalty = %q, want -0.5", got)
+	}
+}
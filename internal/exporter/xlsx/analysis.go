@@ -0,0 +1,283 @@
+// Package xlsx writes analysis results as Excel workbooks, one sheet per
+// table, so spreadsheet users get a single file instead of a folder of CSVs.
+package xlsx
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/pkg/analysis"
+	"github.com/xuri/excelize/v2"
+)
+
+// Sheet names in the analysis workbook
+const (
+	SheetSummary           = "Summary"
+	SheetRarityBreakdown   = "Rarity Breakdown"
+	SheetUpgradePriorities = "Upgrade Priorities"
+	SheetCardLevels        = "Card Levels"
+)
+
+// rarityOrder lists rarities from most to least common
+var rarityOrder = []string{"Common", "Rare", "Epic", "Legendary", "Champion"}
+
+// sheet is one table of the workbook. Cells keep their Go types so numbers
+// stay numeric in Excel.
+type sheet struct {
+	name    string
+	headers []string
+	rows    [][]any
+	// widths are column widths in characters; missing entries use the default
+	widths []float64
+}
+
+// WriteAnalysis writes a card analysis workbook to path with summary, rarity
+// breakdown, upgrade priority, and card level sheets. Parent directories are
+// created as needed.
+func WriteAnalysis(path string, a *analysis.CardAnalysis) error {
+	return writeWorkbook(path, []sheet{
+		summarySheet(a),
+		raritySheet(a),
+		upgradeSheet(a),
+		cardLevelsSheet(a),
+	})
+}
+
+func summarySheet(a *analysis.CardAnalysis) sheet {
+	s := a.Summary
+	return sheet{
+		name:    SheetSummary,
+		headers: []string{"Metric", "Value"},
+		widths:  []float64{24, 24},
+		rows: [][]any{
+			{"Player Tag", a.PlayerTag},
+			{"Player Name", a.PlayerName},
+			{"Analysis Time", a.AnalysisTime.Format("2006-01-02 15:04:05")},
+			{"Total Cards", s.TotalCards},
+			{"Max Level Cards", s.MaxLevelCards},
+			{"Upgradable Cards", s.UpgradableCards},
+			{"Average Card Level", round(s.AvgCardLevel, 2)},
+			{"Completion %", round(s.CompletionPercent, 1)},
+			{"Average Level Ratio", round(s.AvgLevelRatio, 3)},
+		},
+	}
+}
+
+func raritySheet(a *analysis.CardAnalysis) sheet {
+	s := sheet{
+		name: SheetRarityBreakdown,
+		headers: []string{
+			"Rarity", "Total Cards", "Max Level Cards", "Average Level",
+			"Average Level Ratio", "Cards Near Max", "Cards Ready to Upgrade",
+		},
+		widths: []float64{12, 12, 16, 14, 20, 16, 22},
+	}
+	for _, rarity := range sortedRarities(a.RarityBreakdown) {
+		stats := a.RarityBreakdown[rarity]
+		s.rows = append(s.rows, []any{
+			rarity,
+			stats.TotalCards,
+			stats.MaxLevelCards,
+			round(stats.AvgLevel, 1),
+			round(stats.AvgLevelRatio, 3),
+			stats.CardsNearMax,
+			stats.CardsReadyUpgrade,
+		})
+	}
+	return s
+}
+
+func upgradeSheet(a *analysis.CardAnalysis) sheet {
+	s := sheet{
+		name: SheetUpgradePriorities,
+		headers: []string{
+			"Card Name", "Rarity", "Current Level", "Max Level", "Cards Owned", "Cards Needed",
+			"Priority", "Priority Score", "Ready to Upgrade", "Completion %", "Reasons",
+		},
+		widths: []float64{20, 12, 14, 10, 12, 13, 10, 14, 17, 13, 60},
+	}
+	for _, p := range a.UpgradePriority {
+		s.rows = append(s.rows, []any{
+			p.CardName,
+			p.Rarity,
+			p.CurrentLevel,
+			p.MaxLevel,
+			p.CardsOwned,
+			p.CardsNeeded,
+			p.Priority,
+			round(p.PriorityScore, 1),
+			p.IsReadyToUpgrade(),
+			round(p.PercentageComplete(), 1),
+			strings.Join(p.Reasons, "; "),
+		})
+	}
+	return s
+}
+
+func cardLevelsSheet(a *analysis.CardAnalysis) sheet {
+	s := sheet{
+		name: SheetCardLevels,
+		headers: []string{
+			"Card Name", "Card ID", "Rarity", "Elixir Cost", "Current Level", "Max Level",
+			"Evolution Level", "Max Evolution Level", "Level Ratio", "Cards Owned",
+			"Cards Needed for Next", "Progress to Next %", "Is Max Level",
+		},
+		widths: []float64{20, 10, 12, 11, 14, 10, 15, 19, 11, 12, 21, 18, 12},
+	}
+
+	names := make([]string, 0, len(a.CardLevels))
+	for name := range a.CardLevels {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		card := a.CardLevels[name]
+		progress := 100.0
+		if !card.IsMaxLevel {
+			progress = card.ProgressToNext()
+		}
+		s.rows = append(s.rows, []any{
+			card.Name,
+			card.ID,
+			card.Rarity,
+			card.Elixir,
+			card.Level,
+			card.MaxLevel,
+			card.EvolutionLevel,
+			card.MaxEvolutionLevel,
+			round(card.LevelRatio(), 3),
+			card.CardCount,
+			card.CardsToNext,
+			round(progress, 1),
+			card.IsMaxLevel,
+		})
+	}
+	return s
+}
+
+// sortedRarities returns the breakdown's rarities in rarityOrder, followed by
+// any unknown rarities alphabetically.
+func sortedRarities(breakdown map[string]analysis.RarityStats) []string {
+	var rarities, extra []string
+	for _, rarity := range rarityOrder {
+		if _, ok := breakdown[rarity]; ok {
+			rarities = append(rarities, rarity)
+		}
+	}
+	for rarity := range breakdown {
+		if !slices.Contains(rarityOrder, rarity) {
+			extra = append(extra, rarity)
+		}
+	}
+	slices.Sort(extra)
+	return append(rarities, extra...)
+}
+
+// writeWorkbook writes sheets in order, each with a bold frozen header row
+// and an autofilter over its data.
+func writeWorkbook(path string, sheets []sheet) (returnErr error) {
+	f := excelize.NewFile()
+	defer func() {
+		if err := f.Close(); err != nil && returnErr == nil {
+			returnErr = err
+		}
+	}()
+
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"#DDE3EE"}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create header style: %w", err)
+	}
+
+	defaultSheet := f.GetSheetName(0)
+	for i, s := range sheets {
+		if i == 0 {
+			if err := f.SetSheetName(defaultSheet, s.name); err != nil {
+				return fmt.Errorf("failed to rename sheet: %w", err)
+			}
+		} else if _, err := f.NewSheet(s.name); err != nil {
+			return fmt.Errorf("failed to add sheet %q: %w", s.name, err)
+		}
+		if err := writeSheet(f, s, headerStyle); err != nil {
+			return fmt.Errorf("failed to write sheet %q: %w", s.name, err)
+		}
+	}
+	f.SetActiveSheet(0)
+
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create workbook directory: %w", err)
+		}
+	}
+	if err := f.SaveAs(path); err != nil {
+		return fmt.Errorf("failed to save workbook: %w", err)
+	}
+	return nil
+}
+
+func writeSheet(f *excelize.File, s sheet, headerStyle int) error {
+	headers := make([]any, len(s.headers))
+	for i, h := range s.headers {
+		headers[i] = h
+	}
+	if err := f.SetSheetRow(s.name, "A1", &headers); err != nil {
+		return err
+	}
+	for i, row := range s.rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+2)
+		if err != nil {
+			return err
+		}
+		if err := f.SetSheetRow(s.name, cell, &row); err != nil {
+			return err
+		}
+	}
+
+	lastHeader, err := excelize.CoordinatesToCellName(len(s.headers), 1)
+	if err != nil {
+		return err
+	}
+	if err := f.SetCellStyle(s.name, "A1", lastHeader, headerStyle); err != nil {
+		return err
+	}
+	if err := f.SetPanes(s.name, &excelize.Panes{
+		Freeze:      true,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	}); err != nil {
+		return err
+	}
+	if len(s.rows) > 0 {
+		lastCell, err := excelize.CoordinatesToCellName(len(s.headers), len(s.rows)+1)
+		if err != nil {
+			return err
+		}
+		if err := f.AutoFilter(s.name, "A1:"+lastCell, nil); err != nil {
+			return err
+		}
+	}
+	for i, width := range s.widths {
+		col, err := excelize.ColumnNumberToName(i + 1)
+		if err != nil {
+			return err
+		}
+		if err := f.SetColWidth(s.name, col, col, width); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// round trims float noise so cells show the same precision as the CSV export.
+func round(v float64, places int) float64 {
+	scale := math.Pow10(places)
+	return math.Round(v*scale) / scale
+}
@@ -0,0 +1,77 @@
+package xlsx
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/analysis"
+	"github.com/xuri/excelize/v2"
+)
+
+func sampleAnalysis() *analysis.CardAnalysis {
+	return &analysis.CardAnalysis{
+		PlayerTag:    "#ABC",
+		PlayerName:   "Player",
+		AnalysisTime: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		CardLevels: map[string]analysis.CardLevelInfo{
+			"Knight":    {Name: "Knight", ID: 26000000, Level: 14, MaxLevel: 16, Rarity: "Common", Elixir: 3, CardCount: 100, CardsToNext: 500},
+			"Hog Rider": {Name: "Hog Rider", ID: 26000021, Level: 16, MaxLevel: 16, Rarity: "Rare", Elixir: 4, IsMaxLevel: true},
+		},
+		RarityBreakdown: map[string]analysis.RarityStats{
+			"Rare":    {Rarity: "Rare", TotalCards: 20, AvgLevelRatio: 0.5},
+			"Common":  {Rarity: "Common", TotalCards: 30, AvgLevelRatio: 0.8123},
+			"Mystery": {Rarity: "Mystery", TotalCards: 1},
+		},
+		UpgradePriority: []analysis.UpgradePriority{
+			{CardName: "Knight", Rarity: "Common", CurrentLevel: 14, MaxLevel: 16, CardsOwned: 100, CardsRequired: 600, CardsNeeded: 500, Priority: "high", PriorityScore: 81.25, Reasons: []string{"Win condition", "Close to upgrade"}},
+		},
+		Summary: analysis.CollectionSummary{TotalCards: 2, MaxLevelCards: 1, AvgCardLevel: 15, CompletionPercent: 50},
+	}
+}
+
+func TestWriteAnalysis(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "exports", "analysis.xlsx")
+	if err := WriteAnalysis(path, sampleAnalysis()); err != nil {
+		t.Fatalf("WriteAnalysis() error = %v", err)
+	}
+
+	f, err := excelize.OpenFile(path)
+	if err != nil {
+		t.Fatalf("workbook does not open: %v", err)
+	}
+	defer f.Close()
+
+	want := []string{SheetSummary, SheetRarityBreakdown, SheetUpgradePriorities, SheetCardLevels}
+	if got := f.GetSheetList(); !slices.Equal(got, want) {
+		t.Fatalf("sheets = %v, want %v", got, want)
+	}
+
+	cells := []struct {
+		sheet, cell, want string
+	}{
+		{SheetSummary, "B2", "#ABC"},
+		{SheetSummary, "B5", "2"},
+		{SheetRarityBreakdown, "A2", "Common"},
+		{SheetRarityBreakdown, "E2", "0.812"},
+		{SheetRarityBreakdown, "A4", "Mystery"},
+		{SheetUpgradePriorities, "H2", "81.3"},
+		{SheetUpgradePriorities, "K2", "Win condition; Close to upgrade"},
+		{SheetCardLevels, "A2", "Hog Rider"},
+		{SheetCardLevels, "L2", "100"},
+		{SheetCardLevels, "M3", "FALSE"},
+	}
+	for _, c := range cells {
+		got, err := f.GetCellValue(c.sheet, c.cell)
+		if err != nil || got != c.want {
+			t.Errorf("%s!%s = %q, %v; want %q", c.sheet, c.cell, got, err, c.want)
+		}
+	}
+
+	// Numbers are stored as numbers so spreadsheets can sort and chart them
+	typ, err := f.GetCellType(SheetCardLevels, "E3")
+	if err != nil || typ == excelize.CellTypeSharedString || typ == excelize.CellTypeInlineString {
+		t.Errorf("Card Levels!E3 type = %v, %v; want a number", typ, err)
+	}
+}
@@ -0,0 +1,274 @@
+package fuzzdist
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/closeutil"
+)
+
+const (
+	defaultMaxAttempts = 3
+	// maxConsecutiveFailures drops an endpoint that keeps failing so its
+	// batches go to healthy workers.
+	maxConsecutiveFailures = 3
+	// busyBackoff is how long an endpoint waits after a 503 before taking
+	// another batch.
+	busyBackoff = 2 * time.Second
+)
+
+// Coordinator fans batches out to worker endpoints and collects the results.
+type Coordinator struct {
+	// Endpoints are worker base URLs such as "http://10.0.0.5:8091".
+	Endpoints []string
+	// AuthToken is sent as a bearer token when set.
+	AuthToken string
+	// Client defaults to an http.Client without a timeout; batches are
+	// bounded by the context instead.
+	Client *http.Client
+	// MaxAttempts is the number of times a batch is tried before the run
+	// fails. Defaults to 3.
+	MaxAttempts int
+	// Progress is called after each completed batch.
+	Progress func(done, total int, resp *BatchResponse)
+	// Warn receives non-fatal problems such as a failed attempt.
+	Warn func(format string, args ...any)
+}
+
+// Plan splits total decks into batch sizes of at most batchSize.
+func Plan(total, batchSize int) []int {
+	if total <= 0 {
+		return nil
+	}
+	if batchSize <= 0 || batchSize > total {
+		batchSize = total
+	}
+	batches := make([]int, 0, (total+batchSize-1)/batchSize)
+	for remaining := total; remaining > 0; remaining -= batchSize {
+		batches = append(batches, min(batchSize, remaining))
+	}
+	return batches
+}
+
+// Health queries every endpoint's health and returns the reachable ones.
+// Unreachable endpoints are reported through Warn.
+func (c *Coordinator) Health(ctx context.Context) map[string]Health {
+	healthy := make(map[string]Health)
+	for _, endpoint := range c.Endpoints {
+		var health Health
+		if err := c.do(ctx, http.MethodGet, endpoint, HealthPath, nil, &health); err != nil {
+			c.warn("worker %s unavailable: %v", endpoint, err)
+			continue
+		}
+		healthy[endpoint] = health
+	}
+	return healthy
+}
+
+// Run splits total decks into batches of batchSize and runs them on the
+// workers. template supplies the player and configuration; each batch gets
+// its own count and seed (base seed + batch index) so batches explore
+// different decks and a seeded run is reproducible. Responses are returned in
+// batch order.
+func (c *Coordinator) Run(ctx context.Context, template BatchRequest, total, batchSize int) ([]*BatchResponse, error) {
+	if len(c.Endpoints) == 0 {
+		return nil, fmt.Errorf("no worker endpoints configured")
+	}
+	plan := Plan(total, batchSize)
+	if len(plan) == 0 {
+		return nil, fmt.Errorf("nothing to do: total must be positive")
+	}
+	maxAttempts := c.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = defaultMaxAttempts
+	}
+	baseSeed := template.Config.Seed
+	if baseSeed == 0 {
+		baseSeed = time.Now().UnixNano()
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	queue := make(chan int, len(plan))
+	for i := range plan {
+		queue <- i
+	}
+
+	var (
+		mu        sync.Mutex
+		responses = make([]*BatchResponse, len(plan))
+		attempts  = make([]int, len(plan))
+		done      int
+		allDone   = make(chan struct{})
+		wg        sync.WaitGroup
+	)
+
+	for _, endpoint := range c.Endpoints {
+		wg.Go(func() {
+			failures := 0
+			for {
+				var batch int
+				select {
+				case <-ctx.Done():
+					return
+				case <-allDone:
+					return
+				case batch = <-queue:
+				}
+
+				req := template
+				req.BatchID = batch
+				req.Config.Count = plan[batch]
+				req.Config.Seed = baseSeed + int64(batch)
+
+				var resp BatchResponse
+				err := c.do(ctx, http.MethodPost, endpoint, BatchPath, req, &resp)
+				if err == nil {
+					failures = 0
+					mu.Lock()
+					responses[batch] = &resp
+					done++
+					finished := done
+					if finished == len(plan) {
+						close(allDone)
+					}
+					mu.Unlock()
+					if c.Progress != nil {
+						c.Progress(finished, len(plan), &resp)
+					}
+					continue
+				}
+				if ctx.Err() != nil {
+					return
+				}
+
+				var statusErr *statusError
+				if errors.As(err, &statusErr) && statusErr.status == http.StatusBadRequest {
+					cancel(fmt.Errorf("batch %d rejected by %s: %w", batch, endpoint, err))
+					return
+				}
+
+				// A busy worker is not a failure; put the batch back and wait.
+				if errors.As(err, &statusErr) && statusErr.status == http.StatusServiceUnavailable {
+					queue <- batch
+					sleepContext(ctx, busyBackoff)
+					continue
+				}
+
+				mu.Lock()
+				attempts[batch]++
+				exhausted := attempts[batch] >= maxAttempts
+				mu.Unlock()
+				if exhausted {
+					cancel(fmt.Errorf("batch %d failed after %d attempts: %w", batch, maxAttempts, err))
+					return
+				}
+				queue <- batch
+				c.warn("worker %s: batch %d failed, retrying: %v", endpoint, batch, err)
+				failures++
+				if failures >= maxConsecutiveFailures {
+					c.warn("worker %s dropped after %d consecutive failures", endpoint, failures)
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	if err := context.Cause(ctx); err != nil {
+		return compact(responses), err
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if done < len(plan) {
+		return compact(responses), fmt.Errorf("all workers failed with %d of %d batches remaining", len(plan)-done, len(plan))
+	}
+	return responses, nil
+}
+
+func (c *Coordinator) do(ctx context.Context, method, endpoint, path string, body, dst any) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(endpoint, "/")+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.AuthToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.AuthToken)
+	}
+
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer closeutil.WithLog("fuzzdist", resp.Body, "response body")
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		_ = json.NewDecoder(io.LimitReader(resp.Body, 1<<16)).Decode(&apiErr)
+		return &statusError{status: resp.StatusCode, message: apiErr.Error}
+	}
+	return json.NewDecoder(resp.Body).Decode(dst)
+}
+
+func (c *Coordinator) warn(format string, args ...any) {
+	if c.Warn != nil {
+		c.Warn(format, args...)
+	}
+}
+
+// statusError is a non-200 worker response.
+type statusError struct {
+	status  int
+	message string
+}
+
+func (e *statusError) Error() string {
+	if e.message == "" {
+		return http.StatusText(e.status)
+	}
+	return fmt.Sprintf("%s: %s", http.StatusText(e.status), e.message)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+func compact(responses []*BatchResponse) []*BatchResponse {
+	out := make([]*BatchResponse, 0, len(responses))
+	for _, resp := range responses {
+		if resp != nil {
+			out = append(out, resp)
+		}
+	}
+	return out
+}
@@ -0,0 +1,273 @@
+// Package fuzzdist distributes deck fuzzing across machines. A coordinator
+// splits a run into seeded batches and sends them over HTTP to workers
+// (`cr-api deck fuzz worker`). Each worker generates and evaluates its batch
+// locally and returns only the best decks, so large runs scale with the number
+// of hosts while network traffic stays small.
+//
+// The package only handles transport. cmd/cr-api implements Runner with the
+// same generation and evaluation code as a local fuzz run.
+package fuzzdist
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+// Protocol paths served by a worker.
+const (
+	BatchPath  = "/fuzzdist/v1/batch"
+	HealthPath = "/fuzzdist/v1/health"
+)
+
+// maxBatchRequestBytes bounds a batch request; a full player profile is well
+// under 1MB.
+const maxBatchRequestBytes = 8 << 20
+
+// shutdownTimeout bounds how long a stopping worker waits for running batches.
+const shutdownTimeout = 30 * time.Second
+
+// Default bounds on what a worker accepts in one batch. Requests outside them
+// get 400, so a coordinator cannot make a worker allocate without limit.
+const (
+	DefaultMaxBatchCount   = 1000000
+	DefaultMaxBatchKeep    = 10000
+	DefaultMaxBatchWorkers = 256
+)
+
+// ErrInvalidBatch is returned by a Runner for requests it cannot run. Workers
+// answer it with 400, and the coordinator does not retry it.
+var ErrInvalidBatch = errors.New("invalid batch")
+
+// BatchRequest asks a worker to generate and evaluate one batch of decks.
+type BatchRequest struct {
+	BatchID   int                 `json:"batch_id"`
+	Player    *clashroyale.Player `json:"player"`
+	PlayerTag string              `json:"player_tag,omitempty"`
+	// Config is the fuzzing configuration; Count and Seed are set per batch.
+	Config deck.FuzzingConfig `json:"config"`
+	// Keep is the number of best decks the worker returns.
+	Keep   int    `json:"keep"`
+	SortBy string `json:"sort_by,omitempty"`
+	// Scoring is the coordinator's scoring fingerprint (see
+	// WorkerOptions.Scoring). Workers that score differently refuse the batch.
+	Scoring string `json:"scoring,omitempty"`
+}
+
+// BatchResponse is a worker's result for one batch.
+type BatchResponse struct {
+	BatchID   int    `json:"batch_id"`
+	Worker    string `json:"worker"`
+	Generated int    `json:"generated"`
+	Evaluated int    `json:"evaluated"`
+	// Results is a JSON array of evaluated decks, best first. Its element
+	// type is owned by the Runner implementation.
+	Results    json.RawMessage `json:"results"`
+	DurationMS int64           `json:"duration_ms"`
+}
+
+// Health is the body of GET HealthPath.
+type Health struct {
+	Worker  string `json:"worker"`
+	CPUs    int    `json:"cpus"`
+	Version string `json:"version,omitempty"`
+	Scoring string `json:"scoring,omitempty"`
+	Busy    int    `json:"busy"`
+}
+
+// Runner executes one batch on a worker.
+type Runner interface {
+	RunBatch(ctx context.Context, req BatchRequest) (*BatchResponse, error)
+}
+
+// WorkerOptions configures NewWorkerHandler.
+type WorkerOptions struct {
+	// AuthToken, when set, must be sent as a bearer token.
+	AuthToken string
+	// MaxConcurrent is the number of batches run at once; further requests get
+	// 503 so the coordinator retries them elsewhere. Defaults to 1, since each
+	// batch already uses every CPU.
+	MaxConcurrent int
+	// MaxBatchCount caps Config.Count (default DefaultMaxBatchCount).
+	MaxBatchCount int
+	// MaxKeep caps Keep (default DefaultMaxBatchKeep).
+	MaxKeep int
+	// MaxWorkers caps Config.Workers (default DefaultMaxBatchWorkers).
+	MaxWorkers int
+	// Version is reported by the health endpoint.
+	Version string
+	// Scoring, when set, returns the fingerprint of how this worker scores
+	// decks. It is reported by the health endpoint, and a batch whose
+	// Scoring differs gets 400, so results scored differently from the
+	// coordinator are never merged.
+	Scoring func() string
+}
+
+// NewWorkerHandler serves the worker side of the protocol.
+func NewWorkerHandler(runner Runner, opts WorkerOptions) http.Handler {
+	if opts.MaxConcurrent < 1 {
+		opts.MaxConcurrent = 1
+	}
+	if opts.MaxBatchCount < 1 {
+		opts.MaxBatchCount = DefaultMaxBatchCount
+	}
+	if opts.MaxKeep < 1 {
+		opts.MaxKeep = DefaultMaxBatchKeep
+	}
+	if opts.MaxWorkers < 1 {
+		opts.MaxWorkers = DefaultMaxBatchWorkers
+	}
+	w := &worker{runner: runner, opts: opts, slots: make(chan struct{}, opts.MaxConcurrent)}
+	w.name, _ = os.Hostname()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+BatchPath, w.requireAuth(w.handleBatch))
+	mux.HandleFunc("GET "+HealthPath, w.requireAuth(w.handleHealth))
+	return mux
+}
+
+type worker struct {
+	runner Runner
+	opts   WorkerOptions
+	slots  chan struct{}
+	name   string
+}
+
+func (w *worker) requireAuth(next http.HandlerFunc) http.HandlerFunc {
+	if w.opts.AuthToken == "" {
+		return next
+	}
+	want := []byte("Bearer " + w.opts.AuthToken)
+	return func(rw http.ResponseWriter, r *http.Request) {
+		got := []byte(r.Header.Get("Authorization"))
+		if subtle.ConstantTimeCompare(got, want) != 1 {
+			rw.Header().Set("WWW-Authenticate", `Bearer realm="cr-api-fuzz-worker"`)
+			writeError(rw, http.StatusUnauthorized, "missing or invalid bearer token")
+			return
+		}
+		next(rw, r)
+	}
+}
+
+func (w *worker) handleHealth(rw http.ResponseWriter, _ *http.Request) {
+	writeJSON(rw, http.StatusOK, Health{
+		Worker:  w.name,
+		CPUs:    runtime.NumCPU(),
+		Version: w.opts.Version,
+		Scoring: w.scoring(),
+		Busy:    len(w.slots),
+	})
+}
+
+func (w *worker) handleBatch(rw http.ResponseWriter, r *http.Request) {
+	select {
+	case w.slots <- struct{}{}:
+		defer func() { <-w.slots }()
+	default:
+		writeError(rw, http.StatusServiceUnavailable, "worker busy")
+		return
+	}
+
+	var req BatchRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(rw, r.Body, maxBatchRequestBytes))
+	if err := decoder.Decode(&req); err != nil {
+		writeError(rw, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return
+	}
+	if err := w.checkBounds(req); err != nil {
+		writeError(rw, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	start := time.Now()
+	resp, err := w.runner.RunBatch(r.Context(), req)
+	switch {
+	case errors.Is(err, ErrInvalidBatch):
+		writeError(rw, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		log.Printf("fuzzdist: batch %d failed: %v", req.BatchID, err)
+		writeError(rw, http.StatusInternalServerError, err.Error())
+		return
+	}
+	resp.BatchID = req.BatchID
+	resp.Worker = w.name
+	resp.DurationMS = time.Since(start).Milliseconds()
+	writeJSON(rw, http.StatusOK, resp)
+}
+
+// scoring returns the worker's scoring fingerprint, or "" when unchecked
+func (w *worker) scoring() string {
+	if w.opts.Scoring == nil {
+		return ""
+	}
+	return w.opts.Scoring()
+}
+
+// checkBounds rejects batches this worker will not run
+func (w *worker) checkBounds(req BatchRequest) error {
+	if scoring := w.scoring(); scoring != "" && req.Scoring != scoring {
+		return fmt.Errorf("batch scoring %q does not match this worker's %q; run the same cr-api version with the same custom archetypes, scorer plugins, and synergy files",
+			req.Scoring, scoring)
+	}
+	switch {
+	case req.Player == nil || req.Config.Count < 1:
+		return errors.New("batch needs a player and a positive config count")
+	case req.Config.Count > w.opts.MaxBatchCount:
+		return fmt.Errorf("batch count %d exceeds this worker's limit of %d", req.Config.Count, w.opts.MaxBatchCount)
+	case req.Keep < 0 || req.Keep > w.opts.MaxKeep:
+		return fmt.Errorf("keep must be between 0 and %d, got %d", w.opts.MaxKeep, req.Keep)
+	case req.Config.Workers < 0 || req.Config.Workers > w.opts.MaxWorkers:
+		return fmt.Errorf("workers must be between 0 and %d, got %d", w.opts.MaxWorkers, req.Config.Workers)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(payload); err != nil {
+		log.Printf("fuzzdist: failed to encode response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+// ListenAndServe serves handler on addr until ctx is cancelled, then shuts
+// down, letting in-flight batches finish for up to shutdownTimeout.
+func ListenAndServe(ctx context.Context, addr string, handler http.Handler) error {
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return httpServer.Shutdown(shutdownCtx)
+	}
+}
@@ -0,0 +1,240 @@
+package fuzzdist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+type fakeRunner struct {
+	mu    sync.Mutex
+	seeds []int64
+	err   error
+}
+
+func (r *fakeRunner) RunBatch(_ context.Context, req BatchRequest) (*BatchResponse, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	r.mu.Lock()
+	r.seeds = append(r.seeds, req.Config.Seed)
+	r.mu.Unlock()
+	payload, _ := json.Marshal([]int{req.BatchID})
+	return &BatchResponse{Generated: req.Config.Count, Evaluated: req.Config.Count, Results: payload}, nil
+}
+
+func newTestWorker(t *testing.T, runner Runner, token string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(NewWorkerHandler(runner, WorkerOptions{AuthToken: token, Version: "test"}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func testTemplate() BatchRequest {
+	return BatchRequest{
+		Player: &clashroyale.Player{Tag: "#TEST"},
+		Config: deck.FuzzingConfig{Seed: 100},
+		Keep:   5,
+	}
+}
+
+func TestPlan(t *testing.T) {
+	tests := []struct {
+		total, size int
+		want        []int
+	}{
+		{10, 4, []int{4, 4, 2}},
+		{10, 10, []int{10}},
+		{10, 0, []int{10}},
+		{3, 100, []int{3}},
+		{0, 5, nil},
+	}
+	for _, tt := range tests {
+		if got := Plan(tt.total, tt.size); !slices.Equal(got, tt.want) {
+			t.Errorf("Plan(%d, %d) = %v, want %v", tt.total, tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestCoordinatorRunMergesBatchesInOrder(t *testing.T) {
+	runnerA, runnerB := &fakeRunner{}, &fakeRunner{}
+	a := newTestWorker(t, runnerA, "secret")
+	b := newTestWorker(t, runnerB, "secret")
+
+	c := &Coordinator{Endpoints: []string{a.URL, b.URL}, AuthToken: "secret"}
+	responses, err := c.Run(context.Background(), testTemplate(), 25, 10)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(responses) != 3 {
+		t.Fatalf("got %d responses, want 3", len(responses))
+	}
+	generated := 0
+	for i, resp := range responses {
+		if resp.BatchID != i {
+			t.Errorf("response %d has batch id %d", i, resp.BatchID)
+		}
+		generated += resp.Generated
+	}
+	if generated != 25 {
+		t.Errorf("generated = %d, want 25", generated)
+	}
+
+	seeds := slices.Concat(runnerA.seeds, runnerB.seeds)
+	slices.Sort(seeds)
+	if !slices.Equal(seeds, []int64{100, 101, 102}) {
+		t.Errorf("batch seeds = %v, want base seed + batch index", seeds)
+	}
+}
+
+func TestCoordinatorRetriesOnHealthyWorker(t *testing.T) {
+	bad := newTestWorker(t, &fakeRunner{err: errors.New("disk full")}, "")
+	good := newTestWorker(t, &fakeRunner{}, "")
+
+	c := &Coordinator{Endpoints: []string{bad.URL, good.URL}, MaxAttempts: 10}
+	responses, err := c.Run(context.Background(), testTemplate(), 40, 10)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(responses) != 4 {
+		t.Fatalf("got %d responses, want 4", len(responses))
+	}
+}
+
+func TestCoordinatorStopsOnInvalidBatch(t *testing.T) {
+	srv := newTestWorker(t, &fakeRunner{err: fmt.Errorf("%w: no cards", ErrInvalidBatch)}, "")
+
+	c := &Coordinator{Endpoints: []string{srv.URL}}
+	_, err := c.Run(context.Background(), testTemplate(), 10, 5)
+	if err == nil || !strings.Contains(err.Error(), "no cards") {
+		t.Fatalf("expected rejection error, got %v", err)
+	}
+}
+
+func TestCoordinatorFailsWhenAllWorkersFail(t *testing.T) {
+	srv := newTestWorker(t, &fakeRunner{err: errors.New("boom")}, "")
+
+	c := &Coordinator{Endpoints: []string{srv.URL}, MaxAttempts: 2}
+	if _, err := c.Run(context.Background(), testTemplate(), 10, 5); err == nil {
+		t.Fatal("expected error when every attempt fails")
+	}
+}
+
+func TestWorkerRequiresToken(t *testing.T) {
+	srv := newTestWorker(t, &fakeRunner{}, "secret")
+
+	c := &Coordinator{Endpoints: []string{srv.URL}, AuthToken: "wrong"}
+	if healthy := c.Health(context.Background()); len(healthy) != 0 {
+		t.Errorf("expected wrong token to be rejected, got %v", healthy)
+	}
+	c.AuthToken = "secret"
+	healthy := c.Health(context.Background())
+	if got := healthy[srv.URL]; got.Version != "test" || got.CPUs < 1 {
+		t.Errorf("unexpected health %+v", got)
+	}
+}
+
+func TestWorkerBusyReturns503(t *testing.T) {
+	gate := make(chan struct{})
+	started := make(chan struct{})
+	runner := runnerFunc(func(ctx context.Context, req BatchRequest) (*BatchResponse, error) {
+		close(started)
+		<-gate
+		return &BatchResponse{Results: json.RawMessage("[]")}, nil
+	})
+	srv := newTestWorker(t, runner, "")
+
+	body := `{"player":{"tag":"#TEST"},"config":{"count":1}}`
+	errCh := make(chan error, 1)
+	go func() {
+		resp, err := http.Post(srv.URL+BatchPath, "application/json", strings.NewReader(body))
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		errCh <- err
+	}()
+	<-started
+
+	resp, err := http.Post(srv.URL+BatchPath, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503 while busy", resp.StatusCode)
+	}
+	close(gate)
+	if err := <-errCh; err != nil {
+		t.Fatalf("first POST error = %v", err)
+	}
+}
+
+func TestWorkerRejectsOutOfRangeBatches(t *testing.T) {
+	runner := &fakeRunner{}
+	srv := httptest.NewServer(NewWorkerHandler(runner, WorkerOptions{MaxBatchCount: 1000}))
+	t.Cleanup(srv.Close)
+
+	tests := map[string]int{
+		`{"player":{"tag":"#TEST"},"config":{"count":1000},"keep":10}`:     http.StatusOK,
+		`{"player":{"tag":"#TEST"},"config":{"count":0}}`:                  http.StatusBadRequest,
+		`{"player":{"tag":"#TEST"},"config":{"count":1001}}`:               http.StatusBadRequest,
+		`{"player":{"tag":"#TEST"},"config":{"count":1},"keep":-1}`:        http.StatusBadRequest,
+		`{"player":{"tag":"#TEST"},"config":{"count":1},"keep":10001}`:     http.StatusBadRequest,
+		`{"player":{"tag":"#TEST"},"config":{"count":1,"Workers":-1}}`:     http.StatusBadRequest,
+		`{"player":{"tag":"#TEST"},"config":{"count":1,"Workers":100000}}`: http.StatusBadRequest,
+	}
+	for body, want := range tests {
+		resp, err := http.Post(srv.URL+BatchPath, "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("POST error = %v", err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("%s: status = %d, want %d", body, resp.StatusCode, want)
+		}
+	}
+	if len(runner.seeds) != 1 {
+		t.Errorf("runner ran %d batches, want only the one in range", len(runner.seeds))
+	}
+}
+
+func TestWorkerRejectsOtherScoring(t *testing.T) {
+	runner := &fakeRunner{}
+	srv := httptest.NewServer(NewWorkerHandler(runner, WorkerOptions{Scoring: func() string { return "1.3.0/abc" }}))
+	t.Cleanup(srv.Close)
+
+	coordinator := &Coordinator{Endpoints: []string{srv.URL}}
+	if health := coordinator.Health(context.Background()); health[srv.URL].Scoring != "1.3.0/abc" {
+		t.Errorf("health = %+v, want the worker's scoring", health)
+	}
+
+	template := testTemplate()
+	template.Scoring = "1.3.0/def"
+	_, err := coordinator.Run(context.Background(), template, 10, 5)
+	if err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Errorf("Run() error = %v, want a scoring mismatch", err)
+	}
+	template.Scoring = "1.3.0/abc"
+	if _, err := coordinator.Run(context.Background(), template, 10, 5); err != nil {
+		t.Errorf("Run() with matching scoring error = %v", err)
+	}
+	if len(runner.seeds) != 2 {
+		t.Errorf("runner ran %d batches, want only the 2 with matching scoring", len(runner.seeds))
+	}
+}
+
+type runnerFunc func(ctx context.Context, req BatchRequest) (*BatchResponse, error)
+
+func (f runnerFunc) RunBatch(ctx context.Context, req BatchRequest) (*BatchResponse, error) {
+	return f(ctx, req)
+}
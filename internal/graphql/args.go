@@ -0,0 +1,64 @@
+package graphql
+
+import "fmt"
+
+// StringArg returns a string argument, or fallback when it was not given
+func StringArg(args map[string]any, name, fallback string) (string, error) {
+	v, ok := args[name]
+	if !ok {
+		return fallback, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("argument %q must be a string", name)
+	}
+	return s, nil
+}
+
+// IntArg returns an integer argument, or fallback when it was not given
+func IntArg(args map[string]any, name string, fallback int) (int, error) {
+	v, ok := args[name]
+	if !ok {
+		return fallback, nil
+	}
+	n, ok := v.(int)
+	if !ok {
+		return 0, fmt.Errorf("argument %q must be an integer", name)
+	}
+	return n, nil
+}
+
+// FloatArg returns a numeric argument, or fallback when it was not given
+func FloatArg(args map[string]any, name string, fallback float64) (float64, error) {
+	switch v := args[name].(type) {
+	case nil:
+		return fallback, nil
+	case int:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	}
+	return 0, fmt.Errorf("argument %q must be a number", name)
+}
+
+// StringListArg returns a list of strings; a single string is accepted as
+// a list of one, as GraphQL input coercion allows
+func StringListArg(args map[string]any, name string) ([]string, error) {
+	switch v := args[name].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []any:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("argument %q must be a list of strings", name)
+			}
+			list = append(list, s)
+		}
+		return list, nil
+	}
+	return nil, fmt.Errorf("argument %q must be a list of strings", name)
+}
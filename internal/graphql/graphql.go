@@ -0,0 +1,455 @@
+// Package graphql is a small, read-only GraphQL executor for `cr-api serve`.
+//
+// It supports query operations with variables, aliases, arguments, nested
+// selections, and __typename. Fragments, directives, mutations, and
+// introspection are rejected with an error. Fields of an Object that have
+// no resolver are read from the JSON encoding of the value being resolved,
+// so stored data can be queried with nested selections without declaring
+// every type; selecting nothing from such a field returns it whole. Each
+// request has a budget of fields it may resolve.
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+)
+
+// Resolver computes a field from the value of its parent object. args
+// holds the field's arguments with variables substituted: integers are
+// int, other numbers float64, and lists []any.
+type Resolver func(ctx context.Context, source any, args map[string]any) (any, error)
+
+// Object is a type whose fields can be selected
+type Object struct {
+	Name   string
+	Fields map[string]*Field
+}
+
+// Field is a field with a resolver
+type Field struct {
+	// Type is the object type of the result, or of each element when the
+	// resolver returns a slice. Nil results are selected from their JSON.
+	Type *Object
+	// Args lists the accepted argument names; any other is an error
+	Args []string
+	// Required lists arguments that must be given
+	Required []string
+	Resolve  Resolver
+}
+
+// DefaultMaxFields is the field budget of a Schema that does not set one
+const DefaultMaxFields = 50000
+
+// Schema is the set of root query fields
+type Schema struct {
+	Query *Object
+	// MaxFields caps the fields one request may resolve, counting every
+	// list element's fields, so aliases and nested lists cannot multiply a
+	// query's cost without bound. A request over the budget gets no data.
+	// 0 means DefaultMaxFields.
+	MaxFields int
+}
+
+// Request is a GraphQL request as sent over HTTP
+type Request struct {
+	Query         string         `json:"query"`
+	Variables     map[string]any `json:"variables,omitempty"`
+	OperationName string         `json:"operationName,omitempty"`
+}
+
+// Response is a GraphQL result. Data is absent when the request could not
+// be executed at all; field errors leave the field null and are listed in
+// Errors alongside the rest of the data.
+type Response struct {
+	Data   any     `json:"data,omitempty"`
+	Errors []Error `json:"errors,omitempty"`
+}
+
+// Error is one entry of Response.Errors
+type Error struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
+
+// Execute runs req against the schema
+func (s *Schema) Execute(ctx context.Context, req Request) Response {
+	doc, err := parse(req.Query)
+	if err != nil {
+		return Response{Errors: []Error{{Message: err.Error()}}}
+	}
+	op, err := doc.operation(req.OperationName)
+	if err != nil {
+		return Response{Errors: []Error{{Message: err.Error()}}}
+	}
+	variables, err := op.coerceVariables(req.Variables)
+	if err != nil {
+		return Response{Errors: []Error{{Message: err.Error()}}}
+	}
+
+	budget := s.MaxFields
+	if budget <= 0 {
+		budget = DefaultMaxFields
+	}
+	e := &executor{variables: variables, budget: budget}
+	data := e.executeObject(ctx, s.Query, nil, op.selections, nil)
+	if e.overBudget {
+		return Response{Errors: []Error{{Message: fmt.Sprintf("query selects more than %d fields; lower the limits or remove aliases", budget)}}}
+	}
+	return Response{Data: data, Errors: e.errors}
+}
+
+func (d *document) operation(name string) (*operation, error) {
+	if name == "" {
+		if len(d.operations) > 1 {
+			return nil, fmt.Errorf("operationName is required when the query has several operations")
+		}
+		return d.operations[0], nil
+	}
+	for _, op := range d.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown operation %q", name)
+}
+
+func (op *operation) coerceVariables(given map[string]any) (map[string]any, error) {
+	variables := map[string]any{}
+	for _, def := range op.variables {
+		v, ok := given[def.name]
+		switch {
+		case ok && v != nil:
+			variables[def.name] = normalizeJSONValue(v)
+		case def.hasDefault:
+			resolved, err := def.defaultValue.resolve(nil)
+			if err != nil {
+				return nil, err
+			}
+			variables[def.name] = resolved
+		case def.required:
+			return nil, fmt.Errorf("variable $%s is required", def.name)
+		default:
+			variables[def.name] = nil
+		}
+	}
+	return variables, nil
+}
+
+// normalizeJSONValue turns whole float64 numbers decoded from JSON into
+// int, so variables look like literals to the resolvers
+func normalizeJSONValue(v any) any {
+	switch v := v.(type) {
+	case float64:
+		if v == float64(int(v)) {
+			return int(v)
+		}
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = normalizeJSONValue(item)
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, item := range v {
+			out[key] = normalizeJSONValue(item)
+		}
+		return out
+	}
+	return v
+}
+
+func (v value) resolve(variables map[string]any) (any, error) {
+	switch v.kind {
+	case valueNull:
+		return nil, nil
+	case valueInt:
+		n, err := strconv.Atoi(v.raw)
+		if err != nil {
+			return nil, fmt.Errorf("integer %s out of range", v.raw)
+		}
+		return n, nil
+	case valueFloat:
+		return strconv.ParseFloat(v.raw, 64)
+	case valueString, valueEnum:
+		return v.raw, nil
+	case valueBool:
+		return v.raw == "true", nil
+	case valueVariable:
+		resolved, ok := variables[v.variable]
+		if !ok {
+			return nil, fmt.Errorf("variable $%s is not defined by the operation", v.variable)
+		}
+		return resolved, nil
+	case valueList:
+		list := make([]any, 0, len(v.list))
+		for _, item := range v.list {
+			resolved, err := item.resolve(variables)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, resolved)
+		}
+		return list, nil
+	case valueObject:
+		object := map[string]any{}
+		for _, field := range v.object {
+			resolved, err := field.value.resolve(variables)
+			if err != nil {
+				return nil, err
+			}
+			object[field.name] = resolved
+		}
+		return object, nil
+	}
+	return nil, fmt.Errorf("unsupported value")
+}
+
+type executor struct {
+	variables  map[string]any
+	errors     []Error
+	budget     int
+	overBudget bool
+}
+
+// spend counts one field against the budget and reports whether it may be
+// resolved; once the budget is gone every later field is skipped
+func (e *executor) spend() bool {
+	if e.budget <= 0 {
+		e.overBudget = true
+		return false
+	}
+	e.budget--
+	return true
+}
+
+func (e *executor) fail(path []any, err error) {
+	e.errors = append(e.errors, Error{Message: err.Error(), Path: append([]any(nil), path...)})
+}
+
+func (e *executor) executeObject(ctx context.Context, obj *Object, source any, selections []*selection, path []any) *orderedObject {
+	result := &orderedObject{}
+	var fallback map[string]any
+	for _, sel := range selections {
+		if !e.spend() {
+			return result
+		}
+		fieldPath := append(path[:len(path):len(path)], sel.responseKey())
+		if sel.name == "__typename" {
+			result.set(sel.responseKey(), obj.Name)
+			continue
+		}
+		field, ok := obj.Fields[sel.name]
+		if !ok {
+			if source == nil {
+				e.fail(fieldPath, fmt.Errorf("cannot query field %q on type %s", sel.name, obj.Name))
+				result.set(sel.responseKey(), nil)
+				continue
+			}
+			if fallback == nil {
+				var err error
+				if fallback, err = jsonObject(source); err != nil {
+					e.fail(fieldPath, err)
+					result.set(sel.responseKey(), nil)
+					continue
+				}
+			}
+			if len(sel.arguments) > 0 {
+				e.fail(fieldPath, fmt.Errorf("field %q on type %s takes no arguments", sel.name, obj.Name))
+				result.set(sel.responseKey(), nil)
+				continue
+			}
+			result.set(sel.responseKey(), e.selectJSON(fallback[sel.name], sel, fieldPath))
+			continue
+		}
+
+		args, err := e.arguments(obj, field, sel)
+		if err != nil {
+			e.fail(fieldPath, err)
+			result.set(sel.responseKey(), nil)
+			continue
+		}
+		resolved, err := field.Resolve(ctx, source, args)
+		if err != nil {
+			e.fail(fieldPath, err)
+			result.set(sel.responseKey(), nil)
+			continue
+		}
+		result.set(sel.responseKey(), e.complete(ctx, field, resolved, sel, fieldPath))
+	}
+	return result
+}
+
+func (e *executor) arguments(obj *Object, field *Field, sel *selection) (map[string]any, error) {
+	args := map[string]any{}
+	for _, arg := range sel.arguments {
+		if !slices.Contains(field.Args, arg.name) {
+			return nil, fmt.Errorf("unknown argument %q on field %s.%s", arg.name, obj.Name, sel.name)
+		}
+		resolved, err := arg.value.resolve(e.variables)
+		if err != nil {
+			return nil, err
+		}
+		if resolved != nil {
+			args[arg.name] = resolved
+		}
+	}
+	for _, name := range field.Required {
+		if _, ok := args[name]; !ok {
+			return nil, fmt.Errorf("argument %q of field %s.%s is required", name, obj.Name, sel.name)
+		}
+	}
+	return args, nil
+}
+
+// complete shapes a resolved value for the selection
+func (e *executor) complete(ctx context.Context, field *Field, resolved any, sel *selection, path []any) any {
+	if isNil(resolved) {
+		return nil
+	}
+	if field.Type == nil {
+		generic, err := toJSONValue(resolved)
+		if err != nil {
+			e.fail(path, err)
+			return nil
+		}
+		return e.selectJSON(generic, sel, path)
+	}
+	if len(sel.selections) == 0 {
+		e.fail(path, fmt.Errorf("field %q of type %s must have a selection of subfields", sel.name, field.Type.Name))
+		return nil
+	}
+
+	rv := reflect.ValueOf(resolved)
+	if rv.Kind() != reflect.Slice {
+		return e.executeObject(ctx, field.Type, resolved, sel.selections, path)
+	}
+	list := make([]any, rv.Len())
+	for i := range list {
+		item := rv.Index(i).Interface()
+		if isNil(item) {
+			continue
+		}
+		list[i] = e.executeObject(ctx, field.Type, item, sel.selections, append(path[:len(path):len(path)], i))
+	}
+	return list
+}
+
+// selectJSON applies a selection to decoded JSON
+func (e *executor) selectJSON(v any, sel *selection, path []any) any {
+	if len(sel.selections) == 0 || v == nil {
+		return v
+	}
+	switch v := v.(type) {
+	case []any:
+		list := make([]any, len(v))
+		for i, item := range v {
+			list[i] = e.selectJSON(item, sel, append(path[:len(path):len(path)], i))
+		}
+		return list
+	case map[string]any:
+		result := &orderedObject{}
+		for _, child := range sel.selections {
+			if !e.spend() {
+				return result
+			}
+			childPath := append(path[:len(path):len(path)], child.responseKey())
+			switch {
+			case child.name == "__typename":
+				result.set(child.responseKey(), "JSON")
+			case len(child.arguments) > 0:
+				e.fail(childPath, fmt.Errorf("field %q takes no arguments", child.name))
+				result.set(child.responseKey(), nil)
+			default:
+				result.set(child.responseKey(), e.selectJSON(v[child.name], child, childPath))
+			}
+		}
+		return result
+	}
+	e.fail(path, fmt.Errorf("field %q is a scalar and cannot have a selection", sel.name))
+	return nil
+}
+
+func jsonObject(source any) (map[string]any, error) {
+	generic, err := toJSONValue(source)
+	if err != nil {
+		return nil, err
+	}
+	object, ok := generic.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("value is not an object")
+	}
+	return object, nil
+}
+
+func toJSONValue(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode value: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var generic any
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, fmt.Errorf("failed to decode value: %w", err)
+	}
+	return generic, nil
+}
+
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// orderedObject keeps result fields in selection order, as GraphQL
+// requires; a repeated key keeps its first position
+type orderedObject struct {
+	keys   []string
+	values map[string]any
+}
+
+func (o *orderedObject) set(key string, v any) {
+	if o.values == nil {
+		o.values = map[string]any{}
+	}
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = v
+}
+
+// MarshalJSON writes the fields in order
+func (o *orderedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		value, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
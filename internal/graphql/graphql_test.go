@@ -0,0 +1,200 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type testPlayer struct {
+	Tag   string     `json:"tag"`
+	Name  string     `json:"name"`
+	Cards []testCard `json:"cards"`
+}
+
+type testCard struct {
+	Name  string `json:"name"`
+	Level int    `json:"level"`
+}
+
+func testSchema() *Schema {
+	players := map[string]*testPlayer{
+		"#2PP": {Tag: "#2PP", Name: "Alpha", Cards: []testCard{{"Knight", 14}, {"Zap", 13}}},
+		"#9YL": {Tag: "#9YL", Name: "Beta"},
+	}
+	battle := &Object{Name: "Battle"}
+	player := &Object{Name: "Player", Fields: map[string]*Field{
+		"battles": {
+			Type: battle,
+			Args: []string{"limit"},
+			Resolve: func(_ context.Context, source any, args map[string]any) (any, error) {
+				limit, err := IntArg(args, "limit", 10)
+				if err != nil {
+					return nil, err
+				}
+				all := []map[string]any{{"crowns": 3, "opponent": "#QQ"}, {"crowns": 1, "opponent": "#RR"}}
+				return all[:min(limit, len(all))], nil
+			},
+		},
+		"broken": {Resolve: func(context.Context, any, map[string]any) (any, error) {
+			return nil, errors.New("storage unavailable")
+		}},
+	}}
+	return &Schema{Query: &Object{Name: "Query", Fields: map[string]*Field{
+		"player": {
+			Type:     player,
+			Args:     []string{"tag"},
+			Required: []string{"tag"},
+			Resolve: func(_ context.Context, _ any, args map[string]any) (any, error) {
+				tag, err := StringArg(args, "tag", "")
+				if err != nil {
+					return nil, err
+				}
+				return players[tag], nil
+			},
+		},
+	}}}
+}
+
+func execute(t *testing.T, query string, variables map[string]any) (string, []Error) {
+	t.Helper()
+	resp := testSchema().Execute(context.Background(), Request{Query: query, Variables: variables})
+	data, err := json.Marshal(resp.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data), resp.Errors
+}
+
+func TestExecuteNestedSelections(t *testing.T) {
+	got, errs := execute(t, `
+		query Lookup($tag: String!, $n: Int = 1) {
+			player(tag: $tag) {
+				__typename
+				name
+				deck: cards { name }
+				battles(limit: $n) { crowns }
+			}
+			missing: player(tag: "#QQQ") { name }
+		}`, map[string]any{"tag": "#2PP"})
+	if len(errs) != 0 {
+		t.Fatalf("errors = %+v", errs)
+	}
+	want := `{"player":{"__typename":"Player","name":"Alpha","deck":[{"name":"Knight"},{"name":"Zap"}],"battles":[{"crowns":3}]},"missing":null}`
+	if got != want {
+		t.Errorf("data = %s\nwant   %s", got, want)
+	}
+}
+
+func TestExecuteWholeJSONValue(t *testing.T) {
+	got, errs := execute(t, `{ player(tag: "#2PP") { cards } }`, nil)
+	if len(errs) != 0 || got != `{"player":{"cards":[{"level":14,"name":"Knight"},{"level":13,"name":"Zap"}]}}` {
+		t.Errorf("data = %s, errors = %+v", got, errs)
+	}
+}
+
+func TestExecuteFieldErrorsArePartial(t *testing.T) {
+	got, errs := execute(t, `{ player(tag: "#9YL") { name broken battles(limit: "x") { crowns } } }`, nil)
+	if got != `{"player":{"name":"Beta","broken":null,"battles":null}}` {
+		t.Errorf("data = %s", got)
+	}
+	if len(errs) != 2 || errs[0].Message != "storage unavailable" || errs[1].Path[1] != "battles" {
+		t.Errorf("errors = %+v", errs)
+	}
+}
+
+func TestExecuteRejectsInvalidRequests(t *testing.T) {
+	tests := map[string]string{
+		`{ player { name } }`:                        `argument "tag" of field Query.player is required`,
+		`{ player(tag: "#2PP", mode: 1) { name } }`:  `unknown argument "mode"`,
+		`{ player(tag: "#2PP") }`:                    "must have a selection of subfields",
+		`{ nope }`:                                   `cannot query field "nope" on type Query`,
+		`{ player(tag: $tag) { name } }`:             "variable $tag is not defined",
+		`{ player(tag: "#2PP") { name(x: 1) } }`:     "takes no arguments",
+		`{ player(tag: "#2PP") { name { first } } }`: "is a scalar",
+	}
+	for query, want := range tests {
+		_, errs := execute(t, query, nil)
+		if len(errs) != 1 || !strings.Contains(errs[0].Message, want) {
+			t.Errorf("%s: errors = %+v, want %q", query, errs, want)
+		}
+	}
+
+	for query, want := range map[string]string{
+		`mutation { player }`:                                   "read-only",
+		`{ ...F } fragment F on Query { player }`:               "fragments are not supported",
+		`{ player(tag: "#2PP") @skip(if: true) { name }`:        "directives are not supported",
+		`{ player(tag: "#2PP" { name } }`:                       "syntax error at line 1",
+		`query Q($tag: String!) { player(tag: $tag) { name } }`: "variable $tag is required",
+	} {
+		resp := testSchema().Execute(context.Background(), Request{Query: query})
+		if resp.Data != nil || len(resp.Errors) != 1 || !strings.Contains(resp.Errors[0].Message, want) {
+			t.Errorf("%s: response = %+v, want %q", query, resp, want)
+		}
+	}
+}
+
+func TestExecuteEnforcesFieldBudget(t *testing.T) {
+	schema := testSchema()
+	schema.MaxFields = 10
+	// 3 fields plus 2 per battle fit within the budget
+	resp := schema.Execute(context.Background(), Request{Query: `{ player(tag: "#2PP") { name battles { crowns opponent } } }`})
+	if resp.Data == nil || len(resp.Errors) != 0 {
+		t.Errorf("query within budget: response = %+v", resp)
+	}
+
+	// Aliases repeat the same fields and each counts
+	query := "{" + strings.Repeat(` p: player(tag: "#2PP") { name battles { crowns opponent } }`, 3) + " }"
+	resp = schema.Execute(context.Background(), Request{Query: query})
+	if resp.Data != nil || len(resp.Errors) != 1 || !strings.Contains(resp.Errors[0].Message, "more than 10 fields") {
+		t.Errorf("query over budget: response = %+v", resp)
+	}
+}
+
+func TestParseRejectsDeepNesting(t *testing.T) {
+	deep := map[string]string{
+		"selection sets": strings.Repeat("{ a ", maxNestingDepth+1) + strings.Repeat("}", maxNestingDepth+1),
+		"list values":    `{ a(x: ` + strings.Repeat("[", maxNestingDepth+1) + strings.Repeat("]", maxNestingDepth+1) + `) }`,
+		"object values":  `{ a(x: ` + strings.Repeat("{y: ", maxNestingDepth+1) + "1" + strings.Repeat("}", maxNestingDepth+1) + `) }`,
+		"list types":     `query($v: ` + strings.Repeat("[", maxNestingDepth+1) + "Int" + strings.Repeat("]", maxNestingDepth+1) + `) { a }`,
+	}
+	for name, query := range deep {
+		if _, err := parse(query); err == nil || !strings.Contains(err.Error(), "levels deep") {
+			t.Errorf("%s: error = %v, want a nesting error", name, err)
+		}
+	}
+
+	// One level less parses: the list is inside the top-level selection set
+	limit := `{ a(x: ` + strings.Repeat("[", maxNestingDepth-1) + strings.Repeat("]", maxNestingDepth-1) + `) }`
+	if _, err := parse(limit); err != nil {
+		t.Errorf("query at the nesting limit: error = %v", err)
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	schema := testSchema()
+
+	body := `{"query":"query($t: String!) { player(tag: $t) { name } }","variables":{"t":"#2PP"}}`
+	rec := httptest.NewRecorder()
+	schema.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body)))
+	if rec.Code != http.StatusOK || strings.TrimSpace(rec.Body.String()) != `{"data":{"player":{"name":"Alpha"}}}` {
+		t.Errorf("POST = %d %s", rec.Code, rec.Body)
+	}
+
+	rec = httptest.NewRecorder()
+	schema.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/graphql?query="+url.QueryEscape(`{ player(tag: "#9YL") { name } }`), nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"Beta"`) {
+		t.Errorf("GET = %d %s", rec.Code, rec.Body)
+	}
+
+	rec = httptest.NewRecorder()
+	schema.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"{"}`)))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("syntax error status = %d", rec.Code)
+	}
+}
@@ -0,0 +1,54 @@
+package graphql
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+const maxRequestBytes = 1 << 20
+
+// ServeHTTP answers GET requests with query, variables, and operationName
+// URL parameters and POST requests with a JSON Request body. Requests that
+// cannot be executed get 400; everything else gets 200 with any field
+// errors in the body, as GraphQL over HTTP expects.
+func (s *Schema) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req Request
+	switch r.Method {
+	case http.MethodGet:
+		values := r.URL.Query()
+		req.Query = values.Get("query")
+		req.OperationName = values.Get("operationName")
+		if raw := values.Get("variables"); raw != "" {
+			if err := json.Unmarshal([]byte(raw), &req.Variables); err != nil {
+				writeResponse(w, http.StatusBadRequest, Response{Errors: []Error{{Message: "invalid variables: " + err.Error()}}})
+				return
+			}
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes)).Decode(&req); err != nil {
+			writeResponse(w, http.StatusBadRequest, Response{Errors: []Error{{Message: "invalid JSON body: " + err.Error()}}})
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		writeResponse(w, http.StatusMethodNotAllowed, Response{Errors: []Error{{Message: "use GET or POST"}}})
+		return
+	}
+	if req.Query == "" {
+		writeResponse(w, http.StatusBadRequest, Response{Errors: []Error{{Message: "query is required"}}})
+		return
+	}
+
+	resp := s.Execute(r.Context(), req)
+	status := http.StatusOK
+	if resp.Data == nil {
+		status = http.StatusBadRequest
+	}
+	writeResponse(w, status, resp)
+}
+
+func writeResponse(w http.ResponseWriter, status int, resp Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,534 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// document is a parsed query document
+type document struct {
+	operations []*operation
+}
+
+type operation struct {
+	name       string
+	variables  []variableDefinition
+	selections []*selection
+}
+
+type variableDefinition struct {
+	name         string
+	required     bool
+	defaultValue value
+	hasDefault   bool
+}
+
+type selection struct {
+	alias      string
+	name       string
+	arguments  []argument
+	selections []*selection
+}
+
+// responseKey is the name the field appears under in the result
+func (s *selection) responseKey() string {
+	if s.alias != "" {
+		return s.alias
+	}
+	return s.name
+}
+
+type argument struct {
+	name  string
+	value value
+}
+
+// value is a literal or variable reference in the query
+type value struct {
+	kind     valueKind
+	raw      string
+	list     []value
+	object   []argument
+	variable string
+}
+
+type valueKind int
+
+const (
+	valueNull valueKind = iota
+	valueInt
+	valueFloat
+	valueString
+	valueBool
+	valueEnum
+	valueList
+	valueObject
+	valueVariable
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	line int
+}
+
+// parse reads a query document
+func parse(source string) (*document, error) {
+	p := &parser{source: source, line: 1}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	doc := &document{}
+	for p.tok.kind != tokenEOF {
+		op, err := p.parseOperation()
+		if err != nil {
+			return nil, err
+		}
+		doc.operations = append(doc.operations, op)
+	}
+	if len(doc.operations) == 0 {
+		return nil, fmt.Errorf("query contains no operation")
+	}
+	return doc, nil
+}
+
+// maxNestingDepth bounds how deeply selection sets, list and object values,
+// and list types may nest, so a hostile query cannot exhaust the stack
+const maxNestingDepth = 15
+
+type parser struct {
+	source string
+	pos    int
+	line   int
+	tok    token
+	depth  int
+}
+
+// nest enters one level of nesting; call the returned func on the way out
+func (p *parser) nest() (func(), error) {
+	if p.depth >= maxNestingDepth {
+		return nil, p.errorf("query nests more than %d levels deep", maxNestingDepth)
+	}
+	p.depth++
+	return func() { p.depth-- }, nil
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("syntax error at line %d: %s", p.tok.line, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) parseOperation() (*operation, error) {
+	op := &operation{}
+	if p.tok.kind == tokenName {
+		switch p.tok.text {
+		case "query":
+		case "mutation", "subscription":
+			return nil, fmt.Errorf("%s operations are not supported; the API is read-only", p.tok.text)
+		case "fragment":
+			return nil, fmt.Errorf("fragments are not supported")
+		default:
+			return nil, p.errorf("unexpected %q", p.tok.text)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind == tokenName {
+			op.name = p.tok.text
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+		if p.isPunct("(") {
+			vars, err := p.parseVariableDefinitions()
+			if err != nil {
+				return nil, err
+			}
+			op.variables = vars
+		}
+	}
+	if err := p.rejectDirectives(); err != nil {
+		return nil, err
+	}
+	selections, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.selections = selections
+	return op, nil
+}
+
+func (p *parser) parseVariableDefinitions() ([]variableDefinition, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var defs []variableDefinition
+	for !p.isPunct(")") {
+		if err := p.expect("$"); err != nil {
+			return nil, err
+		}
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		required, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		def := variableDefinition{name: name, required: required}
+		if p.isPunct("=") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if def.defaultValue, err = p.parseValue(true); err != nil {
+				return nil, err
+			}
+			def.hasDefault = true
+		}
+		defs = append(defs, def)
+	}
+	return defs, p.advance()
+}
+
+// parseType skips a type reference, reporting whether it is non-null.
+// Argument types are checked by the resolvers, not the parser.
+func (p *parser) parseType() (bool, error) {
+	if p.isPunct("[") {
+		leave, err := p.nest()
+		if err != nil {
+			return false, err
+		}
+		defer leave()
+		if err := p.advance(); err != nil {
+			return false, err
+		}
+		if _, err := p.parseType(); err != nil {
+			return false, err
+		}
+		if err := p.expect("]"); err != nil {
+			return false, err
+		}
+	} else if _, err := p.expectName(); err != nil {
+		return false, err
+	}
+	if p.isPunct("!") {
+		return true, p.advance()
+	}
+	return false, nil
+}
+
+func (p *parser) parseSelectionSet() ([]*selection, error) {
+	leave, err := p.nest()
+	if err != nil {
+		return nil, err
+	}
+	defer leave()
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var selections []*selection
+	for !p.isPunct("}") {
+		if p.isPunct("...") {
+			return nil, fmt.Errorf("fragments are not supported")
+		}
+		sel, err := p.parseField()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, sel)
+	}
+	if len(selections) == 0 {
+		return nil, p.errorf("empty selection set")
+	}
+	return selections, p.advance()
+}
+
+func (p *parser) parseField() (*selection, error) {
+	sel := &selection{}
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	if p.isPunct(":") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		sel.alias = name
+		if name, err = p.expectName(); err != nil {
+			return nil, err
+		}
+	}
+	sel.name = name
+
+	if p.isPunct("(") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		for !p.isPunct(")") {
+			arg, err := p.parseArgument(false)
+			if err != nil {
+				return nil, err
+			}
+			sel.arguments = append(sel.arguments, arg)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.rejectDirectives(); err != nil {
+		return nil, err
+	}
+	if p.isPunct("{") {
+		if sel.selections, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return sel, nil
+}
+
+func (p *parser) parseArgument(constant bool) (argument, error) {
+	name, err := p.expectName()
+	if err != nil {
+		return argument{}, err
+	}
+	if err := p.expect(":"); err != nil {
+		return argument{}, err
+	}
+	v, err := p.parseValue(constant)
+	return argument{name: name, value: v}, err
+}
+
+func (p *parser) parseValue(constant bool) (value, error) {
+	tok := p.tok
+	switch {
+	case tok.kind == tokenPunct && tok.text == "$":
+		if constant {
+			return value{}, p.errorf("variables are not allowed here")
+		}
+		if err := p.advance(); err != nil {
+			return value{}, err
+		}
+		name, err := p.expectName()
+		return value{kind: valueVariable, variable: name}, err
+	case tok.kind == tokenPunct && tok.text == "[":
+		leave, err := p.nest()
+		if err != nil {
+			return value{}, err
+		}
+		defer leave()
+		if err := p.advance(); err != nil {
+			return value{}, err
+		}
+		list := value{kind: valueList}
+		for !p.isPunct("]") {
+			item, err := p.parseValue(constant)
+			if err != nil {
+				return value{}, err
+			}
+			list.list = append(list.list, item)
+		}
+		return list, p.advance()
+	case tok.kind == tokenPunct && tok.text == "{":
+		leave, err := p.nest()
+		if err != nil {
+			return value{}, err
+		}
+		defer leave()
+		if err := p.advance(); err != nil {
+			return value{}, err
+		}
+		object := value{kind: valueObject}
+		for !p.isPunct("}") {
+			field, err := p.parseArgument(constant)
+			if err != nil {
+				return value{}, err
+			}
+			object.object = append(object.object, field)
+		}
+		return object, p.advance()
+	case tok.kind == tokenInt:
+		return value{kind: valueInt, raw: tok.text}, p.advance()
+	case tok.kind == tokenFloat:
+		return value{kind: valueFloat, raw: tok.text}, p.advance()
+	case tok.kind == tokenString:
+		return value{kind: valueString, raw: tok.text}, p.advance()
+	case tok.kind == tokenName:
+		v := value{kind: valueEnum, raw: tok.text}
+		switch tok.text {
+		case "true", "false":
+			v.kind = valueBool
+		case "null":
+			v.kind = valueNull
+		}
+		return v, p.advance()
+	}
+	return value{}, p.errorf("expected a value, got %q", tok.text)
+}
+
+func (p *parser) rejectDirectives() error {
+	if p.isPunct("@") {
+		return fmt.Errorf("directives are not supported")
+	}
+	return nil
+}
+
+func (p *parser) isPunct(text string) bool {
+	return p.tok.kind == tokenPunct && p.tok.text == text
+}
+
+func (p *parser) expect(text string) error {
+	if !p.isPunct(text) {
+		if p.tok.kind == tokenEOF {
+			return p.errorf("expected %q, got end of query", text)
+		}
+		return p.errorf("expected %q, got %q", text, p.tok.text)
+	}
+	return p.advance()
+}
+
+func (p *parser) expectName() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", p.errorf("expected a name, got %q", p.tok.text)
+	}
+	name := p.tok.text
+	return name, p.advance()
+}
+
+// advance reads the next token, skipping whitespace, commas, and comments
+func (p *parser) advance() error {
+	for p.pos < len(p.source) {
+		c := p.source[p.pos]
+		switch {
+		case c == '\n':
+			p.line++
+			p.pos++
+		case c == ' ' || c == '\t' || c == '\r' || c == ',':
+			p.pos++
+		case c == '#':
+			for p.pos < len(p.source) && p.source[p.pos] != '\n' {
+				p.pos++
+			}
+		default:
+			return p.lexToken()
+		}
+	}
+	p.tok = token{kind: tokenEOF, line: p.line}
+	return nil
+}
+
+func (p *parser) lexToken() error {
+	start := p.pos
+	c := p.source[p.pos]
+	p.tok = token{line: p.line}
+	switch {
+	case strings.HasPrefix(p.source[p.pos:], "..."):
+		p.pos += 3
+		p.tok.kind, p.tok.text = tokenPunct, "..."
+	case strings.ContainsRune("!$()[]{}:=@|", rune(c)):
+		p.pos++
+		p.tok.kind, p.tok.text = tokenPunct, string(c)
+	case c == '_' || isLetter(c):
+		for p.pos < len(p.source) && (p.source[p.pos] == '_' || isLetter(p.source[p.pos]) || isDigit(p.source[p.pos])) {
+			p.pos++
+		}
+		p.tok.kind, p.tok.text = tokenName, p.source[start:p.pos]
+	case c == '-' || isDigit(c):
+		return p.lexNumber()
+	case c == '"':
+		return p.lexString()
+	default:
+		r, _ := utf8.DecodeRuneInString(p.source[p.pos:])
+		return fmt.Errorf("syntax error at line %d: unexpected character %q", p.line, r)
+	}
+	return nil
+}
+
+func (p *parser) lexNumber() error {
+	start := p.pos
+	if p.source[p.pos] == '-' {
+		p.pos++
+	}
+	digits := func() {
+		for p.pos < len(p.source) && isDigit(p.source[p.pos]) {
+			p.pos++
+		}
+	}
+	digits()
+	kind := tokenInt
+	if p.pos < len(p.source) && p.source[p.pos] == '.' {
+		kind = tokenFloat
+		p.pos++
+		digits()
+	}
+	if p.pos < len(p.source) && (p.source[p.pos] == 'e' || p.source[p.pos] == 'E') {
+		kind = tokenFloat
+		p.pos++
+		if p.pos < len(p.source) && (p.source[p.pos] == '+' || p.source[p.pos] == '-') {
+			p.pos++
+		}
+		digits()
+	}
+	text := p.source[start:p.pos]
+	if _, err := strconv.ParseFloat(text, 64); err != nil {
+		return fmt.Errorf("syntax error at line %d: invalid number %q", p.line, text)
+	}
+	p.tok.kind, p.tok.text = kind, text
+	return nil
+}
+
+func (p *parser) lexString() error {
+	if strings.HasPrefix(p.source[p.pos:], `"""`) {
+		end := strings.Index(p.source[p.pos+3:], `"""`)
+		if end < 0 {
+			return fmt.Errorf("syntax error at line %d: unterminated block string", p.line)
+		}
+		text := p.source[p.pos+3 : p.pos+3+end]
+		p.line += strings.Count(text, "\n")
+		p.pos += end + 6
+		p.tok.kind, p.tok.text = tokenString, strings.TrimSpace(text)
+		return nil
+	}
+	end := p.pos + 1
+	for end < len(p.source) && p.source[end] != '"' {
+		if p.source[end] == '\n' {
+			break
+		}
+		if p.source[end] == '\\' {
+			end++
+		}
+		end++
+	}
+	if end >= len(p.source) || p.source[end] != '"' {
+		return fmt.Errorf("syntax error at line %d: unterminated string", p.line)
+	}
+	text, err := strconv.Unquote(p.source[p.pos : end+1])
+	if err != nil {
+		return fmt.Errorf("syntax error at line %d: invalid string: %v", p.line, err)
+	}
+	p.pos = end + 1
+	p.tok.kind, p.tok.text = tokenString, text
+	return nil
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
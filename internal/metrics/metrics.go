@@ -0,0 +1,304 @@
+// Package metrics keeps process-wide counters, gauges, and histograms and
+// serves them in the Prometheus text exposition format, so long-running
+// `serve`, `watch`, and `deck fuzz` processes can be scraped.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Metrics recorded by the CLI
+var (
+	APIRequests = NewCounterVec("cr_api_api_requests_total",
+		"Clash Royale API HTTP attempts by response status (\"error\" for network failures).", "status")
+	APIRateLimited = NewCounter("cr_api_api_rate_limited_total",
+		"Clash Royale API responses rejected with 429 Too Many Requests.")
+	APIRequestSeconds = NewHistogram("cr_api_api_request_duration_seconds",
+		"Latency of Clash Royale API HTTP attempts.", []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30})
+
+	DecksEvaluated = NewCounter("cr_api_fuzz_decks_evaluated_total",
+		"Decks generated and evaluated by fuzzing.")
+	DecksPerSecond = NewGauge("cr_api_fuzz_decks_per_second",
+		"Evaluation throughput of the current fuzz run.")
+	EvaluationSeconds = NewHistogram("cr_api_deck_evaluation_duration_seconds",
+		"Latency of a single deck evaluation.", []float64{.00005, .0001, .00025, .0005, .001, .0025, .005, .01, .05})
+	GAGenerations = NewCounter("cr_api_ga_generations_total",
+		"Genetic algorithm generations completed.")
+)
+
+// metric is one registered metric family
+type metric interface {
+	name() string
+	write(w io.Writer)
+}
+
+var (
+	registryMu sync.Mutex
+	registry   []metric
+)
+
+func register(m metric) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for _, existing := range registry {
+		if existing.name() == m.name() {
+			panic("metrics: duplicate metric " + m.name())
+		}
+	}
+	registry = append(registry, m)
+}
+
+// WriteText writes every registered metric in the Prometheus text format,
+// sorted by name.
+func WriteText(w io.Writer) {
+	registryMu.Lock()
+	metrics := slices.Clone(registry)
+	registryMu.Unlock()
+	slices.SortFunc(metrics, func(a, b metric) int { return strings.Compare(a.name(), b.name()) })
+	for _, m := range metrics {
+		m.write(w)
+	}
+}
+
+// Handler serves the registered metrics.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		WriteText(w)
+	})
+}
+
+// Serve exposes /metrics on ln until ctx is cancelled.
+func Serve(ctx context.Context, ln net.Listener) error {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", Handler())
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Serve(ln)
+	}()
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
+
+func writeHeader(w io.Writer, name, help, kind string) {
+	_, _ = fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, escapeHelp(help), name, kind)
+}
+
+func writeSample(w io.Writer, name, labels string, value float64) {
+	if labels != "" {
+		labels = "{" + labels + "}"
+	}
+	_, _ = fmt.Fprintf(w, "%s%s %s\n", name, labels, formatFloat(value))
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+func escapeHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
+
+func escapeLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`).Replace(s)
+}
+
+// atomicFloat is a float64 updated without locks
+type atomicFloat struct {
+	bits atomic.Uint64
+}
+
+func (f *atomicFloat) Load() float64 {
+	return math.Float64frombits(f.bits.Load())
+}
+
+func (f *atomicFloat) Store(v float64) {
+	f.bits.Store(math.Float64bits(v))
+}
+
+func (f *atomicFloat) Add(delta float64) {
+	for {
+		old := f.bits.Load()
+		if f.bits.CompareAndSwap(old, math.Float64bits(math.Float64frombits(old)+delta)) {
+			return
+		}
+	}
+}
+
+// Counter is a monotonically increasing count.
+type Counter struct {
+	metricName, help string
+	value            atomic.Uint64
+}
+
+// NewCounter registers a counter.
+func NewCounter(name, help string) *Counter {
+	c := &Counter{metricName: name, help: help}
+	register(c)
+	return c
+}
+
+func (c *Counter) Inc()          { c.value.Add(1) }
+func (c *Counter) Add(n uint64)  { c.value.Add(n) }
+func (c *Counter) Value() uint64 { return c.value.Load() }
+func (c *Counter) name() string  { return c.metricName }
+func (c *Counter) write(w io.Writer) {
+	writeHeader(w, c.metricName, c.help, "counter")
+	writeSample(w, c.metricName, "", float64(c.Value()))
+}
+
+// CounterVec is a counter partitioned by one label.
+type CounterVec struct {
+	metricName, help, label string
+
+	mu     sync.Mutex
+	values map[string]*atomic.Uint64
+}
+
+// NewCounterVec registers a counter with one label.
+func NewCounterVec(name, help, label string) *CounterVec {
+	c := &CounterVec{metricName: name, help: help, label: label, values: make(map[string]*atomic.Uint64)}
+	register(c)
+	return c
+}
+
+// Inc increments the counter for a label value.
+func (c *CounterVec) Inc(value string) {
+	c.mu.Lock()
+	v, ok := c.values[value]
+	if !ok {
+		v = new(atomic.Uint64)
+		c.values[value] = v
+	}
+	c.mu.Unlock()
+	v.Add(1)
+}
+
+// Value returns the count for a label value.
+func (c *CounterVec) Value(value string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if v, ok := c.values[value]; ok {
+		return v.Load()
+	}
+	return 0
+}
+
+func (c *CounterVec) name() string { return c.metricName }
+
+func (c *CounterVec) write(w io.Writer) {
+	c.mu.Lock()
+	keys := make([]string, 0, len(c.values))
+	for key := range c.values {
+		keys = append(keys, key)
+	}
+	c.mu.Unlock()
+	slices.Sort(keys)
+
+	writeHeader(w, c.metricName, c.help, "counter")
+	for _, key := range keys {
+		writeSample(w, c.metricName, fmt.Sprintf(`%s="%s"`, c.label, escapeLabel(key)), float64(c.Value(key)))
+	}
+}
+
+// Gauge is a value that can go up and down.
+type Gauge struct {
+	metricName, help string
+	value            atomicFloat
+}
+
+// NewGauge registers a gauge.
+func NewGauge(name, help string) *Gauge {
+	g := &Gauge{metricName: name, help: help}
+	register(g)
+	return g
+}
+
+func (g *Gauge) Set(v float64)  { g.value.Store(v) }
+func (g *Gauge) Value() float64 { return g.value.Load() }
+func (g *Gauge) name() string   { return g.metricName }
+func (g *Gauge) write(w io.Writer) {
+	writeHeader(w, g.metricName, g.help, "gauge")
+	writeSample(w, g.metricName, "", g.Value())
+}
+
+// Histogram counts observations in cumulative buckets.
+type Histogram struct {
+	metricName, help string
+	bounds           []float64
+	buckets          []atomic.Uint64
+	count            atomic.Uint64
+	sum              atomicFloat
+}
+
+// NewHistogram registers a histogram with ascending bucket upper bounds.
+func NewHistogram(name, help string, bounds []float64) *Histogram {
+	if !slices.IsSorted(bounds) {
+		panic("metrics: histogram buckets must be sorted: " + name)
+	}
+	h := &Histogram{metricName: name, help: help, bounds: bounds, buckets: make([]atomic.Uint64, len(bounds))}
+	register(h)
+	return h
+}
+
+// Observe records one value.
+func (h *Histogram) Observe(v float64) {
+	if i, _ := slices.BinarySearch(h.bounds, v); i < len(h.bounds) {
+		h.buckets[i].Add(1)
+	}
+	h.count.Add(1)
+	h.sum.Add(v)
+}
+
+// ObserveSince records the seconds elapsed since start.
+func (h *Histogram) ObserveSince(start time.Time) {
+	h.Observe(time.Since(start).Seconds())
+}
+
+// Count returns the number of observations.
+func (h *Histogram) Count() uint64 { return h.count.Load() }
+
+func (h *Histogram) name() string { return h.metricName }
+
+func (h *Histogram) write(w io.Writer) {
+	writeHeader(w, h.metricName, h.help, "histogram")
+	var cumulative uint64
+	for i, bound := range h.bounds {
+		cumulative += h.buckets[i].Load()
+		writeSample(w, h.metricName+"_bucket", `le="`+formatFloat(bound)+`"`, float64(cumulative))
+	}
+	count := h.count.Load()
+	writeSample(w, h.metricName+"_bucket", `le="+Inf"`, float64(count))
+	writeSample(w, h.metricName+"_sum", "", h.sum.Load())
+	writeSample(w, h.metricName+"_count", "", float64(count))
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func scrape(t *testing.T) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	body, _ := io.ReadAll(rec.Body)
+	return string(body)
+}
+
+func TestHistogramExposition(t *testing.T) {
+	h := NewHistogram("test_latency_seconds", "Test latency.", []float64{0.1, 1})
+	for _, v := range []float64{0.05, 0.1, 0.5, 3} {
+		h.Observe(v)
+	}
+	got := scrape(t)
+	want := `# HELP test_latency_seconds Test latency.
+# TYPE test_latency_seconds histogram
+test_latency_seconds_bucket{le="0.1"} 2
+test_latency_seconds_bucket{le="1"} 3
+test_latency_seconds_bucket{le="+Inf"} 4
+test_latency_seconds_sum 3.65
+test_latency_seconds_count 4
+`
+	if !strings.Contains(got, want) {
+		t.Errorf("exposition missing histogram:\n%s", got)
+	}
+}
+
+func TestCountersAndGauges(t *testing.T) {
+	c := NewCounter("test_events_total", "Test events.")
+	c.Inc()
+	c.Add(2)
+	v := NewCounterVec("test_requests_total", "Test requests.", "status")
+	v.Inc("200")
+	v.Inc("200")
+	v.Inc(`a"b`)
+	g := NewGauge("test_rate", "Test rate.")
+	g.Set(12.5)
+
+	got := scrape(t)
+	for _, want := range []string{
+		"# TYPE test_events_total counter\ntest_events_total 3\n",
+		`test_requests_total{status="200"} 2`,
+		`test_requests_total{status="a\"b"} 1`,
+		"# TYPE test_rate gauge\ntest_rate 12.5\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("exposition missing %q:\n%s", want, got)
+		}
+	}
+	// Families are sorted by name
+	if strings.Index(got, "test_events_total") > strings.Index(got, "test_rate") {
+		t.Error("metrics should be written in name order")
+	}
+}
+
+func TestDuplicateRegistrationPanics(t *testing.T) {
+	NewCounter("test_duplicate_total", "First.")
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a name twice should panic")
+		}
+	}()
+	NewGauge("test_duplicate_total", "Second.")
+}
@@ -0,0 +1,187 @@
+// Package notify posts cr-api events, such as a new best fuzz deck or a loss
+// streak in watch mode, to Discord and generic HTTP webhooks.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/closeutil"
+)
+
+// Event kinds sent by the CLI
+const (
+	KindPersonalBest = "personal_best"
+	KindLossStreak   = "loss_streak"
+)
+
+// defaultTimeout bounds a single webhook delivery
+const defaultTimeout = 10 * time.Second
+
+// maxErrorBody caps how much of a rejected response is quoted in errors
+const maxErrorBody = 512
+
+// Event is one notification. Fields carries machine-readable details for
+// generic webhooks; chat targets only show Title and Message.
+type Event struct {
+	Kind    string         `json:"kind"`
+	Title   string         `json:"title"`
+	Message string         `json:"message"`
+	Time    time.Time      `json:"time"`
+	Fields  map[string]any `json:"fields,omitempty"`
+}
+
+// Notifier delivers events to one or more targets.
+type Notifier interface {
+	Notify(ctx context.Context, event Event) error
+}
+
+// New returns a notifier for the given Discord and generic webhook URLs, or
+// nil when no URL is set.
+func New(discordURLs, webhookURLs []string) Notifier {
+	var targets Multi
+	for _, url := range discordURLs {
+		if url = strings.TrimSpace(url); url != "" {
+			targets = append(targets, &Discord{URL: url})
+		}
+	}
+	for _, url := range webhookURLs {
+		if url = strings.TrimSpace(url); url != "" {
+			targets = append(targets, &Webhook{URL: url})
+		}
+	}
+	switch len(targets) {
+	case 0:
+		return nil
+	case 1:
+		return targets[0]
+	default:
+		return targets
+	}
+}
+
+// Multi sends each event to every target and reports all failures.
+type Multi []Notifier
+
+func (m Multi) Notify(ctx context.Context, event Event) error {
+	var errs []error
+	for _, target := range m {
+		if err := target.Notify(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Webhook posts the event as JSON to an arbitrary URL.
+type Webhook struct {
+	URL    string
+	Client *http.Client
+}
+
+func (w *Webhook) Notify(ctx context.Context, event Event) error {
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+	return post(ctx, w.Client, w.URL, event)
+}
+
+// Discord posts the event as an embed to a Discord channel webhook.
+type Discord struct {
+	URL    string
+	Client *http.Client
+}
+
+// Discord rejects embed titles and descriptions over these lengths
+const (
+	discordTitleLimit       = 256
+	discordDescriptionLimit = 4096
+)
+
+type discordPayload struct {
+	Username string         `json:"username"`
+	Embeds   []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Timestamp   string `json:"timestamp"`
+	Color       int    `json:"color,omitempty"`
+}
+
+// kindColors tints embeds by event kind
+var kindColors = map[string]int{
+	KindPersonalBest: 0x2ecc71,
+	KindLossStreak:   0xe74c3c,
+}
+
+func (d *Discord) Notify(ctx context.Context, event Event) error {
+	at := event.Time
+	if at.IsZero() {
+		at = time.Now()
+	}
+	payload := discordPayload{
+		Username: "cr-api",
+		Embeds: []discordEmbed{{
+			Title:       truncate(event.Title, discordTitleLimit),
+			Description: truncate(event.Message, discordDescriptionLimit),
+			Timestamp:   at.UTC().Format(time.RFC3339),
+			Color:       kindColors[event.Kind],
+		}},
+	}
+	return post(ctx, d.Client, d.URL, payload)
+}
+
+func post(ctx context.Context, client *http.Client, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "cr-api")
+
+	if client == nil {
+		client = &http.Client{Timeout: defaultTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send notification to %s: %w", redact(url), err)
+	}
+	defer closeutil.WithLog("notify", resp.Body, "webhook response")
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return fmt.Errorf("notification to %s rejected: %s %s", redact(url), resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// redact drops the path of a webhook URL from messages; Discord webhook
+// paths embed the secret token.
+func redact(url string) string {
+	scheme, rest, ok := strings.Cut(url, "://")
+	if !ok {
+		return "webhook"
+	}
+	host, _, _ := strings.Cut(rest, "/")
+	return scheme + "://" + host
+}
+
+func truncate(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit-1]) + "…"
+}
@@ -0,0 +1,103 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func recordingServer(t *testing.T, status int) (*httptest.Server, *[]map[string]any) {
+	t.Helper()
+	var received []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		received = append(received, body)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("nope"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &received
+}
+
+var testEvent = Event{
+	Kind:    KindPersonalBest,
+	Title:   "New personal best deck",
+	Message: "Score 8.90: Hog Rider, The Log",
+	Time:    time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+	Fields:  map[string]any{"score": 8.9},
+}
+
+func TestDiscordPostsEmbed(t *testing.T) {
+	srv, received := recordingServer(t, http.StatusNoContent)
+	if err := (&Discord{URL: srv.URL}).Notify(context.Background(), testEvent); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if len(*received) != 1 {
+		t.Fatalf("received %d posts", len(*received))
+	}
+	embeds, _ := (*received)[0]["embeds"].([]any)
+	if len(embeds) != 1 {
+		t.Fatalf("payload = %v", (*received)[0])
+	}
+	embed := embeds[0].(map[string]any)
+	if embed["title"] != testEvent.Title || embed["description"] != testEvent.Message || embed["timestamp"] != "2026-10-01T12:00:00Z" {
+		t.Errorf("embed = %v", embed)
+	}
+}
+
+func TestWebhookPostsEvent(t *testing.T) {
+	srv, received := recordingServer(t, http.StatusOK)
+	if err := (&Webhook{URL: srv.URL}).Notify(context.Background(), testEvent); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	got := (*received)[0]
+	if got["kind"] != KindPersonalBest || got["fields"].(map[string]any)["score"] != 8.9 {
+		t.Errorf("payload = %v", got)
+	}
+}
+
+func TestRejectedNotificationHidesWebhookPath(t *testing.T) {
+	srv, _ := recordingServer(t, http.StatusUnauthorized)
+	err := (&Discord{URL: srv.URL + "/api/webhooks/123/secret-token"}).Notify(context.Background(), testEvent)
+	if err == nil {
+		t.Fatal("expected an error for a 401 response")
+	}
+	if strings.Contains(err.Error(), "secret-token") || !strings.Contains(err.Error(), "401") {
+		t.Errorf("error = %v", err)
+	}
+}
+
+func TestNewFansOutToEveryTarget(t *testing.T) {
+	if New(nil, []string{" "}) != nil {
+		t.Error("New() without URLs should return nil")
+	}
+	discord, discordGot := recordingServer(t, http.StatusNoContent)
+	hook, hookGot := recordingServer(t, http.StatusInternalServerError)
+
+	err := New([]string{discord.URL}, []string{hook.URL}).Notify(context.Background(), testEvent)
+	if len(*discordGot) != 1 || len(*hookGot) != 1 {
+		t.Errorf("posts: discord %d, webhook %d", len(*discordGot), len(*hookGot))
+	}
+	if err == nil || !strings.Contains(err.Error(), "500") {
+		t.Errorf("a failing target should be reported, got %v", err)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	if got := truncate("héllo", 4); got != "hél…" {
+		t.Errorf("truncate() = %q", got)
+	}
+	if got := truncate("ok", 4); got != "ok" {
+		t.Errorf("truncate() = %q", got)
+	}
+}
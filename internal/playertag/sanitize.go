@@ -1,24 +1,20 @@
 package playertag
 
 import (
-	"fmt"
-	"regexp"
 	"strings"
-)
 
-var pattern = regexp.MustCompile(`^[A-Za-z0-9]+$`)
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
 
 // Sanitize validates and canonicalizes a player tag for storage and display.
-// It trims whitespace, removes leading '#', enforces alnum chars, and uppercases.
+// It checks the tag with clashroyale.ParseTag and returns it uppercased,
+// without the leading '#'.
 func Sanitize(playerTag string) (string, error) {
-	tag := strings.TrimPrefix(strings.TrimSpace(playerTag), "#")
-	if tag == "" {
-		return "", fmt.Errorf("player tag is required")
-	}
-	if !pattern.MatchString(tag) {
-		return "", fmt.Errorf("invalid player tag: must contain only letters and digits")
+	tag, err := clashroyale.ParseTag(playerTag)
+	if err != nil {
+		return "", err
 	}
-	return strings.ToUpper(tag), nil
+	return strings.TrimPrefix(tag, "#"), nil
 }
 
 // Display validates and canonicalizes a player tag for user-facing output.
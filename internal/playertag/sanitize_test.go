@@ -1,15 +1,21 @@
 package playertag
 
-import "testing"
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
 
 func TestSanitize(t *testing.T) {
 	t.Run("normalizes and uppercases", func(t *testing.T) {
-		got, err := Sanitize(" #abc123 ")
+		got, err := Sanitize(" #2pq0r ")
 		if err != nil {
 			t.Fatalf("Sanitize returned error: %v", err)
 		}
-		if got != "ABC123" {
-			t.Fatalf("Sanitize = %q, want %q", got, "ABC123")
+		if got != "2PQ0R" {
+			t.Fatalf("Sanitize = %q, want %q", got, "2PQ0R")
 		}
 	})
 
@@ -18,14 +24,21 @@ func TestSanitize(t *testing.T) {
 			t.Fatal("expected error for invalid player tag")
 		}
 	})
+
+	t.Run("rejects characters outside the tag alphabet", func(t *testing.T) {
+		_, err := Sanitize("#2PQOR")
+		if !errors.Is(err, clashroyale.ErrInvalidTag) || !strings.Contains(err.Error(), "did you mean #2PQ0R?") {
+			t.Fatalf("Sanitize error = %v, want the letter O hint", err)
+		}
+	})
 }
 
 func TestDisplay(t *testing.T) {
-	got, err := Display(" abc123 ")
+	got, err := Display(" 2pq0r ")
 	if err != nil {
 		t.Fatalf("Display returned error: %v", err)
 	}
-	if got != "#ABC123" {
-		t.Fatalf("Display = %q, want %q", got, "#ABC123")
+	if got != "#2PQ0R" {
+		t.Fatalf("Display = %q, want %q", got, "#2PQ0R")
 	}
 }
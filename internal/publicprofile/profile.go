@@ -0,0 +1,255 @@
+// Package publicprofile builds and persists the sanitized, opt-in player
+// profiles that `cr-api serve --public` exposes without authentication.
+//
+// A profile only exists on disk after the player explicitly publishes it, so
+// the presence of the snapshot file is the opt-in record. Snapshots carry a
+// deliberately small subset of player data: best decks, a trophy chart, and
+// collection completion. Clan membership, donations, and raw card counts are
+// never included.
+package publicprofile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/playertag"
+	"github.com/klauer/clash-royale-api/go/internal/storage"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+// maxTrophyPoints bounds the trophy chart so snapshots stay small.
+const maxTrophyPoints = 500
+
+// Profile is the sanitized public view of a player.
+type Profile struct {
+	Tag          string               `json:"tag"`
+	Name         string               `json:"name"`
+	ExpLevel     int                  `json:"exp_level"`
+	Trophies     int                  `json:"trophies"`
+	BestTrophies int                  `json:"best_trophies"`
+	Arena        string               `json:"arena"`
+	Wins         int                  `json:"wins"`
+	Losses       int                  `json:"losses"`
+	BestDecks    []Deck               `json:"best_decks"`
+	TrophyChart  []TrophyPoint        `json:"trophy_chart"`
+	Collection   CollectionCompletion `json:"collection"`
+	PublishedAt  time.Time            `json:"published_at"`
+}
+
+// Deck is a publicly shared deck with its evaluation summary.
+type Deck struct {
+	Cards        []string `json:"cards"`
+	OverallScore float64  `json:"overall_score,omitempty"`
+	Archetype    string   `json:"archetype,omitempty"`
+	AvgElixir    float64  `json:"avg_elixir,omitempty"`
+}
+
+// TrophyPoint is a single sample on the trophy chart.
+type TrophyPoint struct {
+	Time     time.Time `json:"time"`
+	Trophies int       `json:"trophies"`
+}
+
+// RarityCompletion reports owned vs total cards for a rarity.
+type RarityCompletion struct {
+	Owned int `json:"owned"`
+	Total int `json:"total,omitempty"`
+}
+
+// CollectionCompletion summarizes how complete a player's collection is.
+type CollectionCompletion struct {
+	OwnedCards        int                         `json:"owned_cards"`
+	TotalCards        int                         `json:"total_cards,omitempty"`
+	CompletionPercent float64                     `json:"completion_percent,omitempty"`
+	MaxLevelCards     int                         `json:"max_level_cards"`
+	ByRarity          map[string]RarityCompletion `json:"by_rarity"`
+}
+
+// BuildInput collects the data sources used to assemble a profile.
+type BuildInput struct {
+	Player *clashroyale.Player
+	// Battles is the player's recent battle log, used for the trophy chart.
+	Battles []clashroyale.Battle
+	// BestDecks are pre-ranked decks to publish; when empty the player's
+	// current deck is used.
+	BestDecks []Deck
+	// AllCards is the full card database, used to compute completion totals.
+	AllCards []clashroyale.Card
+	// Previous is an earlier snapshot whose trophy chart is carried forward.
+	Previous *Profile
+	Now      time.Time
+}
+
+// Build assembles a sanitized profile from the given inputs.
+func Build(in BuildInput) (*Profile, error) {
+	if in.Player == nil {
+		return nil, fmt.Errorf("player is required")
+	}
+	displayTag, err := playertag.Display(in.Player.Tag)
+	if err != nil {
+		return nil, err
+	}
+	now := in.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	profile := &Profile{
+		Tag:          displayTag,
+		Name:         in.Player.Name,
+		ExpLevel:     in.Player.ExpLevel,
+		Trophies:     in.Player.Trophies,
+		BestTrophies: in.Player.BestTrophies,
+		Arena:        in.Player.Arena.Name,
+		Wins:         in.Player.Wins,
+		Losses:       in.Player.Losses,
+		BestDecks:    in.BestDecks,
+		Collection:   buildCollection(in.Player.Cards, in.AllCards),
+		PublishedAt:  now.UTC(),
+	}
+
+	if len(profile.BestDecks) == 0 && len(in.Player.CurrentDeck) > 0 {
+		cards := make([]string, 0, len(in.Player.CurrentDeck))
+		for _, card := range in.Player.CurrentDeck {
+			cards = append(cards, card.Name)
+		}
+		profile.BestDecks = []Deck{{Cards: cards}}
+	}
+	if profile.BestDecks == nil {
+		profile.BestDecks = []Deck{}
+	}
+
+	var previous []TrophyPoint
+	if in.Previous != nil {
+		previous = in.Previous.TrophyChart
+	}
+	profile.TrophyChart = buildTrophyChart(previous, in.Player, in.Battles, now)
+
+	return profile, nil
+}
+
+func buildCollection(owned, all []clashroyale.Card) CollectionCompletion {
+	completion := CollectionCompletion{
+		OwnedCards: len(owned),
+		ByRarity:   make(map[string]RarityCompletion),
+	}
+
+	for _, card := range owned {
+		rarity := card.Rarity
+		entry := completion.ByRarity[rarity]
+		entry.Owned++
+		completion.ByRarity[rarity] = entry
+		if card.MaxLevel > 0 && card.Level >= card.MaxLevel {
+			completion.MaxLevelCards++
+		}
+	}
+
+	if len(all) > 0 {
+		completion.TotalCards = len(all)
+		for _, card := range all {
+			entry := completion.ByRarity[card.Rarity]
+			entry.Total++
+			completion.ByRarity[card.Rarity] = entry
+		}
+		completion.CompletionPercent = float64(completion.OwnedCards) / float64(completion.TotalCards) * 100
+	}
+
+	return completion
+}
+
+// buildTrophyChart merges previously published points with points derived
+// from ladder battles and the player's current trophy count.
+func buildTrophyChart(previous []TrophyPoint, player *clashroyale.Player, battles []clashroyale.Battle, now time.Time) []TrophyPoint {
+	byTime := make(map[int64]TrophyPoint, len(previous)+len(battles)+1)
+	for _, point := range previous {
+		byTime[point.Time.Unix()] = point
+	}
+
+	sanitized, err := playertag.Sanitize(player.Tag)
+	if err == nil {
+		for _, battle := range battles {
+			for _, member := range battle.Team {
+				memberTag, tagErr := playertag.Sanitize(member.Tag)
+				if tagErr != nil || memberTag != sanitized || member.StartingTrophies == 0 {
+					continue
+				}
+				point := TrophyPoint{
+					Time:     battle.UTCDate.UTC(),
+					Trophies: member.StartingTrophies + member.TrophyChange,
+				}
+				byTime[point.Time.Unix()] = point
+			}
+		}
+	}
+
+	current := TrophyPoint{Time: now.UTC(), Trophies: player.Trophies}
+	byTime[current.Time.Unix()] = current
+
+	chart := make([]TrophyPoint, 0, len(byTime))
+	for _, point := range byTime {
+		chart = append(chart, point)
+	}
+	sort.Slice(chart, func(i, j int) bool {
+		return chart[i].Time.Before(chart[j].Time)
+	})
+	if len(chart) > maxTrophyPoints {
+		chart = chart[len(chart)-maxTrophyPoints:]
+	}
+	return chart
+}
+
+// Save writes the profile snapshot, opting the player in.
+func Save(dataDir string, profile *Profile) (string, error) {
+	path, err := storage.NewPathBuilder(dataDir).GetPublicProfilePath(profile.Tag)
+	if err != nil {
+		return "", err
+	}
+	if err := storage.WriteJSON(path, profile); err != nil {
+		return "", fmt.Errorf("failed to write public profile: %w", err)
+	}
+	return path, nil
+}
+
+// Load reads a published profile. It returns os.ErrNotExist (wrapped) when the
+// player has not opted in.
+func Load(dataDir, tag string) (*Profile, error) {
+	path, err := storage.NewPathBuilder(dataDir).GetPublicProfilePath(tag)
+	if err != nil {
+		return nil, err
+	}
+	if !storage.FileExists(path) {
+		return nil, fmt.Errorf("public profile for %s: %w", tag, os.ErrNotExist)
+	}
+	var profile Profile
+	if err := storage.ReadJSON(path, &profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// Remove deletes a published profile, opting the player out.
+func Remove(dataDir, tag string) error {
+	path, err := storage.NewPathBuilder(dataDir).GetPublicProfilePath(tag)
+	if err != nil {
+		return err
+	}
+	return storage.DeleteFile(path)
+}
+
+// ListTags returns the tags of all published profiles.
+func ListTags(dataDir string) ([]string, error) {
+	files, err := storage.ListJSONFiles(storage.NewPathBuilder(dataDir).GetPublicProfilesDir())
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]string, 0, len(files))
+	for _, file := range files {
+		tags = append(tags, "#"+strings.TrimSuffix(filepath.Base(file), ".json"))
+	}
+	sort.Strings(tags)
+	return tags, nil
+}
@@ -0,0 +1,122 @@
+package publicprofile
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+func testPlayer() *clashroyale.Player {
+	return &clashroyale.Player{
+		Tag:          "#2PQ0R",
+		Name:         "Tester",
+		Trophies:     6100,
+		BestTrophies: 6400,
+		Arena:        clashroyale.Arena{Name: "Legendary Arena"},
+		Clan:         &clashroyale.Clan{Tag: "#CLAN", Name: "Secret Clan"},
+		Cards: []clashroyale.Card{
+			{Name: "Knight", Rarity: "Common", Level: 14, MaxLevel: 14, Count: 5000},
+			{Name: "Hog Rider", Rarity: "Rare", Level: 12, MaxLevel: 14},
+		},
+		CurrentDeck: []clashroyale.Card{{Name: "Knight"}, {Name: "Hog Rider"}},
+	}
+}
+
+func TestBuildSanitizesAndSummarizes(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	battles := []clashroyale.Battle{
+		{
+			UTCDate: now.Add(-time.Hour),
+			Team:    []clashroyale.BattleTeam{{Tag: "#2PQ0R", StartingTrophies: 6070, TrophyChange: 30}},
+		},
+		{
+			UTCDate: now.Add(-2 * time.Hour),
+			Team:    []clashroyale.BattleTeam{{Tag: "#OTHER", StartingTrophies: 5000, TrophyChange: 30}},
+		},
+	}
+	allCards := []clashroyale.Card{
+		{Name: "Knight", Rarity: "Common"},
+		{Name: "Archers", Rarity: "Common"},
+		{Name: "Hog Rider", Rarity: "Rare"},
+		{Name: "Sparky", Rarity: "Legendary"},
+	}
+
+	profile, err := Build(BuildInput{Player: testPlayer(), Battles: battles, AllCards: allCards, Now: now})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if profile.Tag != "#2PQ0R" {
+		t.Errorf("Tag = %q, want #2PQ0R", profile.Tag)
+	}
+	if len(profile.BestDecks) != 1 || len(profile.BestDecks[0].Cards) != 2 {
+		t.Errorf("expected current deck fallback, got %+v", profile.BestDecks)
+	}
+	if len(profile.TrophyChart) != 2 {
+		t.Fatalf("expected 2 trophy points (own battle + current), got %d", len(profile.TrophyChart))
+	}
+	if profile.TrophyChart[0].Trophies != 6100 || !profile.TrophyChart[0].Time.Before(profile.TrophyChart[1].Time) {
+		t.Errorf("unexpected trophy chart ordering: %+v", profile.TrophyChart)
+	}
+	if profile.Collection.TotalCards != 4 || profile.Collection.OwnedCards != 2 {
+		t.Errorf("unexpected collection totals: %+v", profile.Collection)
+	}
+	if profile.Collection.CompletionPercent != 50 {
+		t.Errorf("CompletionPercent = %.1f, want 50", profile.Collection.CompletionPercent)
+	}
+	if profile.Collection.MaxLevelCards != 1 {
+		t.Errorf("MaxLevelCards = %d, want 1", profile.Collection.MaxLevelCards)
+	}
+}
+
+func TestBuildCarriesTrophyHistoryForward(t *testing.T) {
+	earlier := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	previous := &Profile{TrophyChart: []TrophyPoint{{Time: earlier, Trophies: 5800}}}
+
+	profile, err := Build(BuildInput{Player: testPlayer(), Previous: previous, Now: earlier.AddDate(0, 1, 0)})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if len(profile.TrophyChart) != 2 || profile.TrophyChart[0].Trophies != 5800 {
+		t.Errorf("expected previous point to be preserved, got %+v", profile.TrophyChart)
+	}
+}
+
+func TestSaveLoadRemove(t *testing.T) {
+	dataDir := t.TempDir()
+
+	if _, err := Load(dataDir, "2PQ0R"); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected ErrNotExist before publishing, got %v", err)
+	}
+
+	profile, err := Build(BuildInput{Player: testPlayer()})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if _, err := Save(dataDir, profile); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(dataDir, "#2pq0r")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.Name != "Tester" {
+		t.Errorf("loaded name = %q", loaded.Name)
+	}
+
+	tags, err := ListTags(dataDir)
+	if err != nil || len(tags) != 1 || tags[0] != "#2PQ0R" {
+		t.Errorf("ListTags() = %v, %v", tags, err)
+	}
+
+	if err := Remove(dataDir, "2PQ0R"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, err := Load(dataDir, "2PQ0R"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected ErrNotExist after removal, got %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON Schema dialect of generated schemas
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// Generate builds the JSON Schema for saved documents of the given kind,
+// decoded into values of v's type. Properties follow encoding/json rules:
+// json tag names, omitempty fields are optional, nil slices, maps, and
+// pointers may be null, and time.Time is an RFC 3339 string. The document's
+// schema_version is required and must equal CurrentVersion.
+func Generate(kind Kind, v any) map[string]any {
+	s := typeSchema(reflect.TypeOf(v), map[reflect.Type]bool{})
+	s["$schema"] = Draft
+	s["title"] = "cr-api " + string(kind) + " document"
+
+	properties, _ := s["properties"].(map[string]any)
+	if properties == nil {
+		properties = map[string]any{}
+		s["properties"] = properties
+	}
+	properties[VersionField] = map[string]any{"const": CurrentVersion}
+	required, _ := s["required"].([]any)
+	s["required"] = append([]any{VersionField}, required...)
+	return s
+}
+
+// typeSchema returns the schema for t. seen guards against recursive types,
+// which are left unconstrained.
+func typeSchema(t reflect.Type, seen map[reflect.Type]bool) map[string]any {
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(typeSchema(t.Elem(), seen))
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// []byte encodes as a base64 string
+			return nullable(map[string]any{"type": "string"})
+		}
+		return nullable(map[string]any{"type": "array", "items": typeSchema(t.Elem(), seen)})
+	case reflect.Array:
+		return map[string]any{"type": "array", "items": typeSchema(t.Elem(), seen)}
+	case reflect.Map:
+		return nullable(map[string]any{"type": "object", "additionalProperties": typeSchema(t.Elem(), seen)})
+	case reflect.Struct:
+		if seen[t] {
+			return map[string]any{}
+		}
+		seen[t] = true
+		defer delete(seen, t)
+
+		properties := map[string]any{}
+		var required []any
+		addStructFields(t, seen, properties, &required)
+		s := map[string]any{"type": "object", "properties": properties}
+		if len(required) > 0 {
+			s["required"] = required
+		}
+		return s
+	default:
+		// Interfaces and anything else can hold any value
+		return map[string]any{}
+	}
+}
+
+// addStructFields adds t's encoded fields, flattening embedded structs the
+// way encoding/json does.
+func addStructFields(t reflect.Type, seen map[reflect.Type]bool, properties map[string]any, required *[]any) {
+	for field := range t.Fields() {
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				addStructFields(embedded, seen, properties, required)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = typeSchema(field.Type, seen)
+		if !hasOption(options, "omitempty") && !hasOption(options, "omitzero") {
+			*required = append(*required, name)
+		}
+	}
+}
+
+func hasOption(options, option string) bool {
+	for o := range strings.SplitSeq(options, ",") {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// nullable widens a schema's type to also accept null.
+func nullable(s map[string]any) map[string]any {
+	if typ, ok := s["type"].(string); ok {
+		s["type"] = []any{typ, "null"}
+	}
+	return s
+}
@@ -0,0 +1,163 @@
+// Package schema versions the JSON documents cr-api saves to disk (card
+// analyses, playstyle analyses, and saved decks).
+//
+// Saved documents carry a top-level schema_version field. Stamp adds it when
+// writing, and Migrate upgrades older documents to CurrentVersion when
+// reading. Generate derives a JSON Schema from the Go type a document is
+// decoded into, and Validate checks a document against such a schema.
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Kind identifies a saved document type.
+type Kind string
+
+const (
+	KindAnalysis  Kind = "analysis"
+	KindPlaystyle Kind = "playstyle"
+	KindDeck      Kind = "deck"
+)
+
+// Kinds lists every versioned document kind.
+var Kinds = []Kind{KindAnalysis, KindPlaystyle, KindDeck}
+
+// ParseKind validates a kind name.
+func ParseKind(name string) (Kind, error) {
+	for _, kind := range Kinds {
+		if string(kind) == name {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("unknown document kind %q (want analysis, playstyle, or deck)", name)
+}
+
+// VersionField is the top-level field holding a document's schema version
+const VersionField = "schema_version"
+
+// CurrentVersion is the schema version written by this build. Documents
+// without a version field are version 0, the unversioned format that
+// predates schema_version.
+const CurrentVersion = 1
+
+// migration upgrades a decoded document by one version in place
+type migration func(doc map[string]any) error
+
+// migrations[kind][v] upgrades a document of that kind from version v to
+// v+1. Every kind needs CurrentVersion entries.
+var migrations = map[Kind][]migration{
+	// Version 1 only introduced schema_version; the fields are unchanged
+	KindAnalysis:  {noChange},
+	KindPlaystyle: {noChange},
+	KindDeck:      {noChange},
+}
+
+func noChange(map[string]any) error { return nil }
+
+// Stamp encodes v, which must encode as a JSON object, with schema_version
+// set to CurrentVersion as its first field.
+func Stamp(v any) (json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("versioned documents must be JSON objects: %w", err)
+	}
+	if _, ok := doc[VersionField]; ok {
+		return nil, fmt.Errorf("document already has a %s field", VersionField)
+	}
+
+	stamped := fmt.Appendf(nil, `{%q:%d`, VersionField, CurrentVersion)
+	if len(doc) > 0 {
+		stamped = append(stamped, ',')
+	}
+	// data[0] is the opening brace
+	return append(stamped, data[1:]...), nil
+}
+
+// Version returns the schema version of a raw document, 0 if it has none.
+func Version(data []byte) (int, error) {
+	var header map[string]json.RawMessage
+	if err := json.Unmarshal(data, &header); err != nil {
+		return 0, fmt.Errorf("document is not a JSON object: %w", err)
+	}
+	raw, ok := header[VersionField]
+	if !ok {
+		return 0, nil
+	}
+	var version int
+	if err := json.Unmarshal(raw, &version); err != nil || version < 0 {
+		return 0, fmt.Errorf("invalid %s %s", VersionField, raw)
+	}
+	return version, nil
+}
+
+// ErrNewerVersion is returned for documents written by a newer cr-api.
+var ErrNewerVersion = errors.New("document was written by a newer version of cr-api")
+
+// Migrate upgrades a raw document of the given kind to CurrentVersion.
+// Current documents are returned unchanged.
+func Migrate(kind Kind, data []byte) ([]byte, error) {
+	version, err := Version(data)
+	if err != nil {
+		return nil, err
+	}
+	if version == CurrentVersion {
+		return data, nil
+	}
+	if version > CurrentVersion {
+		return nil, fmt.Errorf("%w: %s %d, this build reads up to %d", ErrNewerVersion, VersionField, version, CurrentVersion)
+	}
+	steps, ok := migrations[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown document kind %q", kind)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("document is not a JSON object: %w", err)
+	}
+	for v := version; v < CurrentVersion; v++ {
+		if err := steps[v](doc); err != nil {
+			return nil, fmt.Errorf("failed to migrate %s document from version %d: %w", kind, v, err)
+		}
+	}
+	doc[VersionField] = CurrentVersion
+	return json.Marshal(doc)
+}
+
+// Unmarshal migrates a raw document to CurrentVersion and decodes it into v.
+func Unmarshal(kind Kind, data []byte, v any) error {
+	migrated, err := Migrate(kind, data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(migrated, v)
+}
+
+// DetectKind guesses a document's kind from its top-level fields.
+func DetectKind(data []byte) (Kind, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return "", fmt.Errorf("document is not a JSON object: %w", err)
+	}
+	has := func(name string) bool {
+		_, ok := fields[name]
+		return ok
+	}
+	switch {
+	case has("playstyle_analysis"):
+		return KindPlaystyle, nil
+	case has("deck_detail"):
+		return KindDeck, nil
+	case has("card_levels") && has("upgrade_priority"):
+		return KindAnalysis, nil
+	default:
+		return "", errors.New("cannot tell the document kind from its fields")
+	}
+}
@@ -0,0 +1,170 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type sampleCard struct {
+	Level int    `json:"level"`
+	Note  string `json:"note,omitempty"`
+}
+
+type sampleBase struct {
+	PlayerTag string `json:"player_tag"`
+}
+
+type sampleDoc struct {
+	sampleBase
+	Cards     map[string]sampleCard `json:"cards"`
+	Deck      []string              `json:"deck"`
+	Score     float64               `json:"score"`
+	SavedAt   time.Time             `json:"saved_at"`
+	Extra     *sampleCard           `json:"extra,omitempty"`
+	Ignored   string                `json:"-"`
+	unexposed int
+}
+
+func TestStamp(t *testing.T) {
+	data, err := Stamp(sampleCard{Level: 11})
+	if err != nil {
+		t.Fatalf("Stamp() error = %v", err)
+	}
+	if got := string(data); got != `{"schema_version":1,"level":11}` {
+		t.Errorf("Stamp() = %s", got)
+	}
+
+	if data, err := Stamp(struct{}{}); err != nil || string(data) != `{"schema_version":1}` {
+		t.Errorf("Stamp(empty) = %s, %v", data, err)
+	}
+	if _, err := Stamp([]string{"Knight"}); err == nil {
+		t.Error("expected an error for a non-object document")
+	}
+	if _, err := Stamp(map[string]int{VersionField: 2}); err == nil {
+		t.Error("expected an error when the version is already set")
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	legacy := []byte(`{"card_levels":{},"upgrade_priority":[]}`)
+	migrated, err := Migrate(KindAnalysis, legacy)
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if v, err := Version(migrated); err != nil || v != CurrentVersion {
+		t.Errorf("migrated version = %d, %v; want %d", v, err, CurrentVersion)
+	}
+
+	current := []byte(`{"schema_version":1,"deck":[]}`)
+	if got, err := Migrate(KindDeck, current); err != nil || string(got) != string(current) {
+		t.Errorf("current documents must pass through unchanged: %s, %v", got, err)
+	}
+
+	if _, err := Migrate(KindDeck, []byte(`{"schema_version":99}`)); !errors.Is(err, ErrNewerVersion) {
+		t.Errorf("Migrate(newer) error = %v, want ErrNewerVersion", err)
+	}
+	if _, err := Migrate(KindDeck, []byte(`{"schema_version":"one"}`)); err == nil {
+		t.Error("expected an error for a non-integer version")
+	}
+
+	var card sampleCard
+	if err := Unmarshal(KindDeck, []byte(`{"level":9}`), &card); err != nil || card.Level != 9 {
+		t.Errorf("Unmarshal() = %+v, %v", card, err)
+	}
+}
+
+func TestMigrationsCoverEveryVersion(t *testing.T) {
+	for _, kind := range Kinds {
+		if got := len(migrations[kind]); got != CurrentVersion {
+			t.Errorf("%s has %d migrations, want %d", kind, got, CurrentVersion)
+		}
+	}
+}
+
+func TestDetectKind(t *testing.T) {
+	cases := map[string]Kind{
+		`{"playstyle_analysis":{}}`:                KindPlaystyle,
+		`{"deck":[],"deck_detail":[]}`:             KindDeck,
+		`{"card_levels":{},"upgrade_priority":[]}`: KindAnalysis,
+	}
+	for doc, want := range cases {
+		if got, err := DetectKind([]byte(doc)); err != nil || got != want {
+			t.Errorf("DetectKind(%s) = %q, %v; want %q", doc, got, err, want)
+		}
+	}
+	if _, err := DetectKind([]byte(`{"name":"x"}`)); err == nil {
+		t.Error("expected an error for an unrecognized document")
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	s := Generate(KindDeck, sampleDoc{})
+	if s["$schema"] != Draft || s["type"] != "object" {
+		t.Fatalf("schema header = %v", s)
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(data)
+	for _, want := range []string{
+		`"player_tag":{"type":"string"}`,
+		`"saved_at":{"format":"date-time","type":"string"}`,
+		`"deck":{"items":{"type":"string"},"type":["array","null"]}`,
+		`"schema_version":{"const":1}`,
+		`"required":["schema_version","player_tag","cards","deck","score","saved_at"]`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("schema missing %s:\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{"Ignored", "unexposed"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("schema should not include %s", unwanted)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	s := Generate(KindDeck, sampleDoc{})
+	doc := sampleDoc{
+		sampleBase: sampleBase{PlayerTag: "#ABC"},
+		Cards:      map[string]sampleCard{"Knight": {Level: 14}},
+		Score:      8,
+		SavedAt:    time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+	}
+	valid, err := Stamp(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs, err := Validate(s, valid); err != nil || len(errs) != 0 {
+		t.Fatalf("Validate(valid) = %v, %v", errs, err)
+	}
+
+	invalid := `{"schema_version":1,"player_tag":7,"cards":{"Knight":{"level":1.5}},"deck":["Knight",3],"saved_at":"x"}`
+	errs, err := Validate(s, []byte(invalid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.Error())
+	}
+	want := []string{
+		`$: missing required field "score"`,
+		`$.cards.Knight.level: expected integer, got number`,
+		`$.deck[1]: expected string, got integer`,
+		`$.player_tag: expected string, got integer`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Validate(invalid) =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if _, err := Validate(s, []byte("{")); err == nil {
+		t.Error("expected an error for malformed JSON")
+	}
+}
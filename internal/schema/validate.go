@@ -0,0 +1,140 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ValidationError describes one schema violation at a JSON path such as
+// $.card_levels.Knight.level.
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// Validate checks a raw JSON document against a schema from Generate. It
+// supports the keywords Generate emits: type, const, properties, required,
+// items, and additionalProperties. Violations are returned sorted by path.
+func Validate(s map[string]any, data []byte) ([]ValidationError, error) {
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	var errs []ValidationError
+	validateValue(s, doc, "$", &errs)
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Path < errs[j].Path })
+	return errs, nil
+}
+
+func validateValue(s map[string]any, v any, path string, errs *[]ValidationError) {
+	report := func(format string, args ...any) {
+		*errs = append(*errs, ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if want, ok := s["const"]; ok && !equalJSON(want, v) {
+		report("must be %v, got %v", want, jsonText(v))
+		return
+	}
+	if types := schemaTypes(s["type"]); len(types) > 0 {
+		got := jsonType(v)
+		if !slices.Contains(types, got) && !(got == "integer" && slices.Contains(types, "number")) {
+			report("expected %s, got %s", strings.Join(types, " or "), got)
+			return
+		}
+	}
+
+	switch value := v.(type) {
+	case map[string]any:
+		if properties, ok := s["properties"].(map[string]any); ok {
+			for name, prop := range properties {
+				if field, present := value[name]; present {
+					validateValue(prop.(map[string]any), field, path+"."+name, errs)
+				}
+			}
+		}
+		if required, ok := s["required"].([]any); ok {
+			for _, name := range required {
+				if _, present := value[name.(string)]; !present {
+					report("missing required field %q", name)
+				}
+			}
+		}
+		if additional, ok := s["additionalProperties"].(map[string]any); ok {
+			properties, _ := s["properties"].(map[string]any)
+			for name, field := range value {
+				if _, declared := properties[name]; !declared {
+					validateValue(additional, field, path+"."+name, errs)
+				}
+			}
+		}
+	case []any:
+		if items, ok := s["items"].(map[string]any); ok {
+			for i, item := range value {
+				validateValue(items, item, fmt.Sprintf("%s[%d]", path, i), errs)
+			}
+		}
+	}
+}
+
+func schemaTypes(t any) []string {
+	switch typ := t.(type) {
+	case string:
+		return []string{typ}
+	case []any:
+		types := make([]string, 0, len(typ))
+		for _, item := range typ {
+			types = append(types, fmt.Sprint(item))
+		}
+		return types
+	case []string:
+		return typ
+	default:
+		return nil
+	}
+}
+
+// jsonType names the JSON Schema type of a decoded value. Whole numbers are
+// integers.
+func jsonType(v any) string {
+	switch value := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if value == math.Trunc(value) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+// equalJSON compares values after a JSON round trip so Go ints in schemas
+// match decoded float64s.
+func equalJSON(a, b any) bool {
+	return jsonText(a) == jsonText(b)
+}
+
+func jsonText(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
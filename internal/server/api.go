@@ -0,0 +1,301 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/internal/playertag"
+)
+
+const (
+	maxRequestBodyBytes = 1 << 20
+	deckSize            = 8
+	defaultFuzzCount    = 1000
+	maxFuzzCount        = 50000
+	defaultFuzzTop      = 10
+	maxDeckQueryLimit   = 500
+	// jobQueueRetryAfter is the Retry-After, in seconds, sent when the fuzz
+	// job queue is full
+	jobQueueRetryAfter = 30
+)
+
+// Errors returned by a Backend are mapped to HTTP status codes. Wrap them with
+// fmt.Errorf("%w: ...") to attach detail.
+var (
+	// ErrInvalidRequest maps to 400 Bad Request.
+	ErrInvalidRequest = errors.New("invalid request")
+	// ErrNotFound maps to 404 Not Found.
+	ErrNotFound = errors.New("not found")
+	// ErrUnavailable maps to 503 Service Unavailable (e.g. no API token configured).
+	ErrUnavailable = errors.New("unavailable")
+	// ErrUpstream maps to 502 Bad Gateway (the Clash Royale API failed).
+	ErrUpstream = errors.New("upstream error")
+)
+
+// Backend performs the analysis behind the REST API. cmd/cr-api implements it
+// with the same code paths the CLI commands use so responses match CLI output.
+type Backend interface {
+	// AnalyzePlayer returns the card collection analysis for a player.
+	AnalyzePlayer(ctx context.Context, tag string) (any, error)
+	// EvaluateDeck evaluates a deck, or a deck pair when PartnerDeck is set.
+	EvaluateDeck(ctx context.Context, req EvaluateRequest) (any, error)
+	// RunFuzz generates and evaluates decks for a player. progress is called
+	// periodically with the number of evaluated decks.
+	RunFuzz(ctx context.Context, req FuzzRequest, progress func(done, total int)) (any, error)
+	// QueryDecks returns saved decks from a player's deck leaderboard.
+	QueryDecks(ctx context.Context, tag string, query DeckQuery) (any, error)
+}
+
+// EvaluateRequest is the body of POST /api/v1/decks/evaluate.
+type EvaluateRequest struct {
+	Deck        []string `json:"deck"`
+	Tag         string   `json:"tag,omitempty"`
+	Mode        string   `json:"mode,omitempty"`
+	PartnerDeck []string `json:"partner_deck,omitempty"`
+	PartnerTag  string   `json:"partner_tag,omitempty"`
+}
+
+// FuzzRequest is the body of POST /api/v1/fuzz/jobs.
+type FuzzRequest struct {
+	Tag          string   `json:"tag"`
+	Count        int      `json:"count,omitempty"`
+	Top          int      `json:"top,omitempty"`
+	Seed         int64    `json:"seed,omitempty"`
+	IncludeCards []string `json:"include_cards,omitempty"`
+	ExcludeCards []string `json:"exclude_cards,omitempty"`
+	MinAvgElixir float64  `json:"min_avg_elixir,omitempty"`
+	MaxAvgElixir float64  `json:"max_avg_elixir,omitempty"`
+	Save         bool     `json:"save,omitempty"`
+}
+
+// DeckQuery filters GET /api/v1/players/{tag}/decks.
+type DeckQuery struct {
+	Archetype string
+	MinScore  float64
+	MaxScore  float64
+	Cards     []string
+	SortBy    string
+	Limit     int
+	Offset    int
+}
+
+// registerAPIRoutes mounts the REST API. When AuthToken is set every API
+// request must carry it as a bearer token.
+func (s *Server) registerAPIRoutes() {
+	s.mux.HandleFunc("GET /api/v1/players/{tag}/analysis", s.requireAuth(s.handlePlayerAnalysis))
+	s.mux.HandleFunc("GET /api/v1/players/{tag}/decks", s.requireAuth(s.handleDeckQuery))
+	s.mux.HandleFunc("POST /api/v1/decks/evaluate", s.requireAuth(s.handleEvaluate))
+	s.mux.HandleFunc("POST /api/v1/fuzz/jobs", s.requireAuth(s.handleFuzzSubmit))
+	s.mux.HandleFunc("GET /api/v1/fuzz/jobs", s.requireAuth(s.handleFuzzList))
+	s.mux.HandleFunc("GET /api/v1/fuzz/jobs/{id}", s.requireAuth(s.handleFuzzGet))
+	s.mux.HandleFunc("DELETE /api/v1/fuzz/jobs/{id}", s.requireAuth(s.handleFuzzCancel))
+}
+
+func (s *Server) requireAuth(next http.HandlerFunc) http.HandlerFunc {
+	if s.opts.AuthToken == "" {
+		return next
+	}
+	want := []byte("Bearer " + s.opts.AuthToken)
+	return func(w http.ResponseWriter, r *http.Request) {
+		got := []byte(r.Header.Get("Authorization"))
+		if subtle.ConstantTimeCompare(got, want) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="cr-api"`)
+			writeError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *Server) handlePlayerAnalysis(w http.ResponseWriter, r *http.Request) {
+	tag, ok := pathTag(w, r)
+	if !ok {
+		return
+	}
+	result, err := s.opts.Backend.AnalyzePlayer(r.Context(), tag)
+	if err != nil {
+		writeBackendError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleDeckQuery(w http.ResponseWriter, r *http.Request) {
+	tag, ok := pathTag(w, r)
+	if !ok {
+		return
+	}
+	query, err := parseDeckQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	result, err := s.opts.Backend.QueryDecks(r.Context(), tag, query)
+	if err != nil {
+		writeBackendError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleEvaluate(w http.ResponseWriter, r *http.Request) {
+	var req EvaluateRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if len(req.Deck) != deckSize {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("deck must contain exactly %d cards, got %d", deckSize, len(req.Deck)))
+		return
+	}
+	if len(req.PartnerDeck) > 0 && len(req.PartnerDeck) != deckSize {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("partner_deck must contain exactly %d cards, got %d", deckSize, len(req.PartnerDeck)))
+		return
+	}
+
+	result, err := s.opts.Backend.EvaluateDeck(r.Context(), req)
+	if err != nil {
+		writeBackendError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleFuzzSubmit(w http.ResponseWriter, r *http.Request) {
+	var req FuzzRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if strings.TrimSpace(req.Tag) == "" {
+		writeError(w, http.StatusBadRequest, "tag is required")
+		return
+	}
+	if req.Count == 0 {
+		req.Count = defaultFuzzCount
+	}
+	if req.Count < 0 || req.Count > maxFuzzCount {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("count must be between 1 and %d", maxFuzzCount))
+		return
+	}
+	if req.Top == 0 {
+		req.Top = defaultFuzzTop
+	}
+	if req.Top < 0 || req.Top > maxDeckQueryLimit {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("top must be between 1 and %d", maxDeckQueryLimit))
+		return
+	}
+
+	job, err := s.jobs.submit(req)
+	if err != nil {
+		w.Header().Set("Retry-After", fmt.Sprintf("%d", jobQueueRetryAfter))
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	w.Header().Set("Location", "/api/v1/fuzz/jobs/"+job.ID)
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+func (s *Server) handleFuzzList(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, s.jobs.list())
+}
+
+func (s *Server) handleFuzzGet(w http.ResponseWriter, r *http.Request) {
+	job, ok := s.jobs.get(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "fuzz job not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, job)
+}
+
+func (s *Server) handleFuzzCancel(w http.ResponseWriter, r *http.Request) {
+	job, ok := s.jobs.cancel(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "fuzz job not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, job)
+}
+
+func pathTag(w http.ResponseWriter, r *http.Request) (string, bool) {
+	tag, err := playertag.Sanitize(r.PathValue("tag"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return "", false
+	}
+	return tag, true
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, dst any) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dst); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func parseDeckQuery(r *http.Request) (DeckQuery, error) {
+	values := r.URL.Query()
+	query := DeckQuery{
+		Archetype: values.Get("archetype"),
+		SortBy:    values.Get("sort"),
+		Limit:     10,
+	}
+	if cards := values.Get("cards"); cards != "" {
+		for _, card := range strings.Split(cards, ",") {
+			if card = strings.TrimSpace(card); card != "" {
+				query.Cards = append(query.Cards, card)
+			}
+		}
+	}
+
+	var err error
+	if query.MinScore, err = parseFloatParam(values.Get("min_score")); err != nil {
+		return query, fmt.Errorf("invalid min_score: %w", err)
+	}
+	if query.MaxScore, err = parseFloatParam(values.Get("max_score")); err != nil {
+		return query, fmt.Errorf("invalid max_score: %w", err)
+	}
+	if raw := values.Get("limit"); raw != "" {
+		if query.Limit, err = strconv.Atoi(raw); err != nil || query.Limit < 1 || query.Limit > maxDeckQueryLimit {
+			return query, fmt.Errorf("limit must be between 1 and %d", maxDeckQueryLimit)
+		}
+	}
+	if raw := values.Get("offset"); raw != "" {
+		if query.Offset, err = strconv.Atoi(raw); err != nil || query.Offset < 0 {
+			return query, fmt.Errorf("offset must be a non-negative integer")
+		}
+	}
+	return query, nil
+}
+
+func parseFloatParam(raw string) (float64, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(raw, 64)
+}
+
+func writeBackendError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidRequest):
+		writeError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, ErrNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrUnavailable):
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+	case errors.Is(err, ErrUpstream):
+		writeError(w, http.StatusBadGateway, err.Error())
+	case errors.Is(err, context.Canceled):
+		writeError(w, http.StatusServiceUnavailable, "request cancelled")
+	default:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,277 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/graphql"
+)
+
+type fakeBackend struct {
+	lastQuery DeckQuery
+	fuzzGate  chan struct{}
+}
+
+func (b *fakeBackend) AnalyzePlayer(_ context.Context, tag string) (any, error) {
+	if tag == "999999" {
+		return nil, fmt.Errorf("%w: player %s", ErrNotFound, tag)
+	}
+	return map[string]string{"tag": tag}, nil
+}
+
+func (b *fakeBackend) EvaluateDeck(_ context.Context, req EvaluateRequest) (any, error) {
+	if req.Mode == "3v3" {
+		return nil, fmt.Errorf("%w: unknown mode", ErrInvalidRequest)
+	}
+	return map[string]any{"deck": req.Deck, "score": 7.5}, nil
+}
+
+func (b *fakeBackend) RunFuzz(ctx context.Context, req FuzzRequest, progress func(done, total int)) (any, error) {
+	if b.fuzzGate != nil {
+		select {
+		case <-b.fuzzGate:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	progress(req.Count, req.Count)
+	return []string{"deck"}, nil
+}
+
+func (b *fakeBackend) QueryDecks(_ context.Context, tag string, query DeckQuery) (any, error) {
+	b.lastQuery = query
+	return []string{tag}, nil
+}
+
+func newAPITestServer(t *testing.T, backend *fakeBackend, token string) *Server {
+	t.Helper()
+	srv, err := New(Options{DataDir: t.TempDir(), API: true, Backend: backend, AuthToken: token})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func doRequest(srv *Server, method, path, body, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNewRequiresBackendForAPI(t *testing.T) {
+	if _, err := New(Options{DataDir: t.TempDir(), API: true}); err == nil {
+		t.Error("expected error when API is enabled without a backend")
+	}
+}
+
+func TestAPIEndpoints(t *testing.T) {
+	backend := &fakeBackend{}
+	srv := newAPITestServer(t, backend, "")
+
+	deck := `["Hog Rider","Musketeer","Cannon","Ice Spirit","Skeletons","The Log","Fireball","Ice Golem"]`
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{"analysis", "GET", "/api/v1/players/2pq0r/analysis", "", http.StatusOK},
+		{"analysis not found", "GET", "/api/v1/players/999999/analysis", "", http.StatusNotFound},
+		{"analysis bad tag", "GET", "/api/v1/players/bad-tag!/analysis", "", http.StatusBadRequest},
+		{"analysis tag outside alphabet", "GET", "/api/v1/players/ABC123/analysis", "", http.StatusBadRequest},
+		{"evaluate", "POST", "/api/v1/decks/evaluate", `{"deck":` + deck + `}`, http.StatusOK},
+		{"evaluate short deck", "POST", "/api/v1/decks/evaluate", `{"deck":["Knight"]}`, http.StatusBadRequest},
+		{"evaluate bad mode", "POST", "/api/v1/decks/evaluate", `{"deck":` + deck + `,"mode":"3v3"}`, http.StatusBadRequest},
+		{"evaluate unknown field", "POST", "/api/v1/decks/evaluate", `{"cards":[]}`, http.StatusBadRequest},
+		{"decks", "GET", "/api/v1/players/2PQ0R/decks?archetype=cycle&min_score=7&limit=5&cards=Hog%20Rider,Fireball", "", http.StatusOK},
+		{"decks bad limit", "GET", "/api/v1/players/2PQ0R/decks?limit=0", "", http.StatusBadRequest},
+		{"fuzz missing tag", "POST", "/api/v1/fuzz/jobs", `{}`, http.StatusBadRequest},
+		{"fuzz count too large", "POST", "/api/v1/fuzz/jobs", `{"tag":"2PQ0R","count":1000000}`, http.StatusBadRequest},
+		{"fuzz top too large", "POST", "/api/v1/fuzz/jobs", `{"tag":"2PQ0R","top":501}`, http.StatusBadRequest},
+		{"fuzz unknown job", "GET", "/api/v1/fuzz/jobs/fuzz-999", "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := doRequest(srv, tt.method, tt.path, tt.body, "")
+			if rec.Code != tt.status {
+				t.Errorf("%s %s status = %d, want %d (body %s)", tt.method, tt.path, rec.Code, tt.status, rec.Body.String())
+			}
+		})
+	}
+
+	if backend.lastQuery.Archetype != "cycle" || backend.lastQuery.MinScore != 7 || backend.lastQuery.Limit != 5 || len(backend.lastQuery.Cards) != 2 {
+		t.Errorf("deck query not parsed: %+v", backend.lastQuery)
+	}
+}
+
+func TestAPIAuthToken(t *testing.T) {
+	srv := newAPITestServer(t, &fakeBackend{}, "secret")
+
+	if rec := doRequest(srv, "GET", "/api/v1/players/2PQ0R/analysis", "", ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("without token status = %d, want 401", rec.Code)
+	}
+	if rec := doRequest(srv, "GET", "/api/v1/players/2PQ0R/analysis", "", "wrong"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("wrong token status = %d, want 401", rec.Code)
+	}
+	if rec := doRequest(srv, "GET", "/api/v1/players/2PQ0R/analysis", "", "secret"); rec.Code != http.StatusOK {
+		t.Errorf("valid token status = %d, want 200", rec.Code)
+	}
+	if rec := doRequest(srv, "GET", "/healthz", "", ""); rec.Code != http.StatusOK {
+		t.Errorf("healthz should not require a token, status = %d", rec.Code)
+	}
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	srv := newAPITestServer(t, &fakeBackend{}, "secret")
+	if rec := doRequest(srv, "GET", "/metrics", "", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("metrics without the option status = %d, want 404", rec.Code)
+	}
+
+	srv, err := New(Options{DataDir: t.TempDir(), API: true, Backend: &fakeBackend{}, AuthToken: "secret", Metrics: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := doRequest(srv, "GET", "/metrics", "", "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "# TYPE cr_api_api_requests_total counter") {
+		t.Errorf("metrics status = %d, body:\n%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestFuzzJobLifecycle(t *testing.T) {
+	backend := &fakeBackend{fuzzGate: make(chan struct{})}
+	srv := newAPITestServer(t, backend, "")
+
+	rec := doRequest(srv, "POST", "/api/v1/fuzz/jobs", `{"tag":"2PQ0R","count":200}`, "")
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("submit status = %d, body %s", rec.Code, rec.Body.String())
+	}
+	var job FuzzJob
+	if err := json.Unmarshal(rec.Body.Bytes(), &job); err != nil {
+		t.Fatal(err)
+	}
+	if job.ID == "" || job.Request.Top != defaultFuzzTop {
+		t.Fatalf("unexpected job: %+v", job)
+	}
+	if loc := rec.Header().Get("Location"); loc != "/api/v1/fuzz/jobs/"+job.ID {
+		t.Errorf("Location = %q", loc)
+	}
+
+	close(backend.fuzzGate)
+	job = waitForJob(t, srv, job.ID)
+	if job.Status != JobCompleted || job.Progress.Done != 200 || job.Result == nil {
+		t.Errorf("unexpected finished job: %+v", job)
+	}
+
+	rec = doRequest(srv, "GET", "/api/v1/fuzz/jobs", "", "")
+	var jobs []FuzzJob
+	if err := json.Unmarshal(rec.Body.Bytes(), &jobs); err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || jobs[0].Result != nil {
+		t.Errorf("list should contain one job summary without results: %+v", jobs)
+	}
+}
+
+func TestFuzzJobCancel(t *testing.T) {
+	backend := &fakeBackend{fuzzGate: make(chan struct{})}
+	srv := newAPITestServer(t, backend, "")
+
+	rec := doRequest(srv, "POST", "/api/v1/fuzz/jobs", `{"tag":"2PQ0R"}`, "")
+	var job FuzzJob
+	if err := json.Unmarshal(rec.Body.Bytes(), &job); err != nil {
+		t.Fatal(err)
+	}
+
+	if rec := doRequest(srv, "DELETE", "/api/v1/fuzz/jobs/"+job.ID, "", ""); rec.Code != http.StatusOK {
+		t.Fatalf("cancel status = %d", rec.Code)
+	}
+	if job = waitForJob(t, srv, job.ID); job.Status != JobCancelled {
+		t.Errorf("status = %s, want cancelled", job.Status)
+	}
+}
+
+func TestFuzzJobQueueLimit(t *testing.T) {
+	backend := &fakeBackend{fuzzGate: make(chan struct{})}
+	srv, err := New(Options{DataDir: t.TempDir(), API: true, Backend: backend, MaxConcurrentJobs: 1, MaxQueuedJobs: 1})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(srv.Close)
+
+	var first FuzzJob
+	for i := range 2 {
+		rec := doRequest(srv, "POST", "/api/v1/fuzz/jobs", `{"tag":"2PQ0R"}`, "")
+		if rec.Code != http.StatusAccepted {
+			t.Fatalf("submit %d status = %d, body %s", i+1, rec.Code, rec.Body.String())
+		}
+		if i == 0 {
+			if err := json.Unmarshal(rec.Body.Bytes(), &first); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	rec := doRequest(srv, "POST", "/api/v1/fuzz/jobs", `{"tag":"2PQ0R"}`, "")
+	if rec.Code != http.StatusServiceUnavailable || rec.Header().Get("Retry-After") == "" {
+		t.Fatalf("submit beyond the queue: status = %d, Retry-After = %q", rec.Code, rec.Header().Get("Retry-After"))
+	}
+
+	// A finished job frees its place
+	doRequest(srv, "DELETE", "/api/v1/fuzz/jobs/"+first.ID, "", "")
+	waitForJob(t, srv, first.ID)
+	if rec := doRequest(srv, "POST", "/api/v1/fuzz/jobs", `{"tag":"2PQ0R"}`, ""); rec.Code != http.StatusAccepted {
+		t.Errorf("submit after a job finished: status = %d", rec.Code)
+	}
+}
+
+func waitForJob(t *testing.T, srv *Server, id string) FuzzJob {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		job, ok := srv.jobs.get(id)
+		if !ok {
+			t.Fatalf("job %s disappeared", id)
+		}
+		if job.Status.finished() {
+			return job
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("job %s did not finish", id)
+	return FuzzJob{}
+}
+
+func TestGraphQLEndpoint(t *testing.T) {
+	schema := &graphql.Schema{Query: &graphql.Object{Name: "Query", Fields: map[string]*graphql.Field{
+		"ping": {Resolve: func(context.Context, any, map[string]any) (any, error) { return "pong", nil }},
+	}}}
+	srv, err := New(Options{DataDir: t.TempDir(), GraphQL: schema, AuthToken: "secret"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(srv.Close)
+
+	body := `{"query":"{ ping }"}`
+	if rec := doRequest(srv, "POST", "/api/v1/graphql", body, ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("without token status = %d, want 401", rec.Code)
+	}
+	rec := doRequest(srv, "POST", "/api/v1/graphql", body, "secret")
+	if rec.Code != http.StatusOK || strings.TrimSpace(rec.Body.String()) != `{"data":{"ping":"pong"}}` {
+		t.Errorf("graphql = %d %s", rec.Code, rec.Body)
+	}
+	if rec := doRequest(srv, "GET", "/api/v1/players/2PQ0R/analysis", "", "secret"); rec.Code != http.StatusNotFound {
+		t.Errorf("REST API without --api status = %d, want 404", rec.Code)
+	}
+}
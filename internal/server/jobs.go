@@ -0,0 +1,249 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxConcurrentJobs = 1
+	defaultMaxQueuedJobs     = 10
+	maxRetainedJobs          = 50
+)
+
+// errJobQueueFull is returned by submit when every slot is running and the
+// queue holds as many jobs as it may.
+var errJobQueueFull = errors.New("fuzz job queue is full")
+
+// JobStatus is the lifecycle state of a fuzz job.
+type JobStatus string
+
+const (
+	JobQueued    JobStatus = "queued"
+	JobRunning   JobStatus = "running"
+	JobCompleted JobStatus = "completed"
+	JobFailed    JobStatus = "failed"
+	JobCancelled JobStatus = "cancelled"
+)
+
+func (s JobStatus) finished() bool {
+	return s == JobCompleted || s == JobFailed || s == JobCancelled
+}
+
+// JobProgress reports how many decks a job has evaluated.
+type JobProgress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// FuzzJob is a snapshot of a submitted fuzz job.
+type FuzzJob struct {
+	ID         string      `json:"id"`
+	Status     JobStatus   `json:"status"`
+	Request    FuzzRequest `json:"request"`
+	Progress   JobProgress `json:"progress"`
+	CreatedAt  time.Time   `json:"created_at"`
+	StartedAt  *time.Time  `json:"started_at,omitempty"`
+	FinishedAt *time.Time  `json:"finished_at,omitempty"`
+	Error      string      `json:"error,omitempty"`
+	Result     any         `json:"result,omitempty"`
+}
+
+// jobManager runs fuzz jobs in the background, at most slots at a time,
+// accepts at most maxPending unfinished jobs, and keeps the most recent
+// finished jobs around for polling.
+type jobManager struct {
+	backend    Backend
+	ctx        context.Context
+	stop       context.CancelFunc
+	slots      chan struct{}
+	maxPending int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	nextID  int
+	jobs    map[string]*FuzzJob
+	cancels map[string]context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+func newJobManager(backend Backend, maxConcurrent, maxQueued int) *jobManager {
+	if maxConcurrent <= 0 {
+		maxConcurrent = defaultMaxConcurrentJobs
+	}
+	if maxQueued <= 0 {
+		maxQueued = defaultMaxQueuedJobs
+	}
+	ctx, stop := context.WithCancel(context.Background())
+	return &jobManager{
+		backend:    backend,
+		ctx:        ctx,
+		stop:       stop,
+		slots:      make(chan struct{}, maxConcurrent),
+		maxPending: maxConcurrent + maxQueued,
+		now:        time.Now,
+		jobs:       make(map[string]*FuzzJob),
+		cancels:    make(map[string]context.CancelFunc),
+	}
+}
+
+// submit queues a job, or returns errJobQueueFull when maxPending jobs are
+// already queued or running.
+func (m *jobManager) submit(req FuzzRequest) (FuzzJob, error) {
+	m.mu.Lock()
+	// cancels holds an entry for every job that has not finished
+	if len(m.cancels) >= m.maxPending {
+		m.mu.Unlock()
+		return FuzzJob{}, errJobQueueFull
+	}
+	m.nextID++
+	job := &FuzzJob{
+		ID:        fmt.Sprintf("fuzz-%d", m.nextID),
+		Status:    JobQueued,
+		Request:   req,
+		Progress:  JobProgress{Total: req.Count},
+		CreatedAt: m.now(),
+	}
+	ctx, cancel := context.WithCancel(m.ctx)
+	m.jobs[job.ID] = job
+	m.cancels[job.ID] = cancel
+	m.evictFinishedLocked()
+	snapshot := *job
+	m.mu.Unlock()
+
+	m.wg.Add(1)
+	go m.run(ctx, job.ID, req)
+	return snapshot, nil
+}
+
+func (m *jobManager) run(ctx context.Context, id string, req FuzzRequest) {
+	defer m.wg.Done()
+
+	select {
+	case m.slots <- struct{}{}:
+		defer func() { <-m.slots }()
+	case <-ctx.Done():
+		m.finish(id, nil, ctx.Err())
+		return
+	}
+
+	m.update(id, func(job *FuzzJob) {
+		started := m.now()
+		job.Status = JobRunning
+		job.StartedAt = &started
+	})
+
+	result, err := m.backend.RunFuzz(ctx, req, func(done, total int) {
+		m.update(id, func(job *FuzzJob) {
+			job.Progress = JobProgress{Done: done, Total: total}
+		})
+	})
+	m.finish(id, result, err)
+}
+
+func (m *jobManager) finish(id string, result any, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if job, ok := m.jobs[id]; ok {
+		finished := m.now()
+		job.FinishedAt = &finished
+		switch {
+		case errors.Is(err, context.Canceled):
+			job.Status = JobCancelled
+		case err != nil:
+			job.Status = JobFailed
+			job.Error = err.Error()
+		default:
+			job.Status = JobCompleted
+			job.Result = result
+		}
+	}
+	// Drop the cancel func together with the status change, so a job that
+	// reads as finished no longer counts toward maxPending
+	if cancel, ok := m.cancels[id]; ok {
+		cancel()
+		delete(m.cancels, id)
+	}
+}
+
+func (m *jobManager) update(id string, fn func(job *FuzzJob)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if job, ok := m.jobs[id]; ok {
+		fn(job)
+	}
+}
+
+func (m *jobManager) get(id string) (FuzzJob, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.jobs[id]
+	if !ok {
+		return FuzzJob{}, false
+	}
+	return *job, true
+}
+
+// list returns job summaries, newest first, without result payloads.
+func (m *jobManager) list() []FuzzJob {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	jobs := make([]FuzzJob, 0, len(m.jobs))
+	for _, job := range m.jobs {
+		summary := *job
+		summary.Result = nil
+		jobs = append(jobs, summary)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].CreatedAt.After(jobs[j].CreatedAt) ||
+			(jobs[i].CreatedAt.Equal(jobs[j].CreatedAt) && jobs[i].ID > jobs[j].ID)
+	})
+	return jobs
+}
+
+func (m *jobManager) cancel(id string) (FuzzJob, bool) {
+	m.mu.Lock()
+	job, ok := m.jobs[id]
+	if !ok {
+		m.mu.Unlock()
+		return FuzzJob{}, false
+	}
+	if cancel, running := m.cancels[id]; running {
+		cancel()
+	}
+	snapshot := *job
+	m.mu.Unlock()
+	return snapshot, true
+}
+
+// shutdown cancels every job and waits for them to exit.
+func (m *jobManager) shutdown() {
+	m.stop()
+	m.wg.Wait()
+}
+
+// evictFinishedLocked drops the oldest finished jobs beyond maxRetainedJobs.
+func (m *jobManager) evictFinishedLocked() {
+	if len(m.jobs) <= maxRetainedJobs {
+		return
+	}
+	var finished []*FuzzJob
+	for _, job := range m.jobs {
+		if job.Status.finished() {
+			finished = append(finished, job)
+		}
+	}
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].CreatedAt.Before(finished[j].CreatedAt)
+	})
+	for _, job := range finished {
+		if len(m.jobs) <= maxRetainedJobs {
+			return
+		}
+		delete(m.jobs, job.ID)
+	}
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"os"
+
+	"github.com/klauer/clash-royale-api/go/internal/playertag"
+	"github.com/klauer/clash-royale-api/go/internal/publicprofile"
+)
+
+// registerPublicRoutes mounts the read-only public profile endpoints. Only
+// players who ran `cr-api profile publish` have a snapshot to serve; every
+// other tag returns 404 so the endpoint cannot be used to enumerate players.
+func (s *Server) registerPublicRoutes(limiter *rateLimiter) {
+	s.mux.HandleFunc("GET /public/profiles/{tag}", limiter.middleware(s.handlePublicProfile))
+	s.mux.HandleFunc("GET /public/profiles/{tag}/decks", limiter.middleware(s.handlePublicDecks))
+	s.mux.HandleFunc("GET /public/profiles/{tag}/trophies", limiter.middleware(s.handlePublicTrophies))
+	s.mux.HandleFunc("GET /public/profiles/{tag}/collection", limiter.middleware(s.handlePublicCollection))
+}
+
+func (s *Server) loadPublicProfile(w http.ResponseWriter, r *http.Request) (*publicprofile.Profile, bool) {
+	tag, err := playertag.Sanitize(r.PathValue("tag"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+
+	profile, err := publicprofile.Load(s.opts.DataDir, tag)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			writeError(w, http.StatusNotFound, "no public profile for this tag")
+			return nil, false
+		}
+		writeError(w, http.StatusInternalServerError, "failed to load profile")
+		return nil, false
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	return profile, true
+}
+
+func (s *Server) handlePublicProfile(w http.ResponseWriter, r *http.Request) {
+	if profile, ok := s.loadPublicProfile(w, r); ok {
+		writeJSON(w, http.StatusOK, profile)
+	}
+}
+
+func (s *Server) handlePublicDecks(w http.ResponseWriter, r *http.Request) {
+	if profile, ok := s.loadPublicProfile(w, r); ok {
+		writeJSON(w, http.StatusOK, profile.BestDecks)
+	}
+}
+
+func (s *Server) handlePublicTrophies(w http.ResponseWriter, r *http.Request) {
+	if profile, ok := s.loadPublicProfile(w, r); ok {
+		writeJSON(w, http.StatusOK, profile.TrophyChart)
+	}
+}
+
+func (s *Server) handlePublicCollection(w http.ResponseWriter, r *http.Request) {
+	if profile, ok := s.loadPublicProfile(w, r); ok {
+		writeJSON(w, http.StatusOK, profile.Collection)
+	}
+}
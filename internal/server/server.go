@@ -0,0 +1,224 @@
+// Package server implements the HTTP server behind `cr-api serve`.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/graphql"
+	"github.com/klauer/clash-royale-api/go/internal/metrics"
+)
+
+const (
+	defaultAddr            = "127.0.0.1:8080"
+	defaultPublicRateLimit = 30
+	shutdownTimeout        = 5 * time.Second
+)
+
+// Options configures the server.
+type Options struct {
+	// Addr is the listen address (default 127.0.0.1:8080).
+	Addr string
+	// DataDir is the data directory that backs all endpoints.
+	DataDir string
+	// Public enables the unauthenticated, read-only public profile endpoints.
+	Public bool
+	// PublicRateLimit is the number of public requests allowed per client IP
+	// per minute (default 30).
+	PublicRateLimit int
+
+	// API enables the REST API under /api/v1 (requires Backend).
+	API bool
+	// Backend performs player analysis, deck evaluation, fuzzing, and deck queries.
+	Backend Backend
+	// AuthToken, when set, is required as a bearer token on every API request.
+	AuthToken string
+	// MaxConcurrentJobs caps how many fuzz jobs run at once (default 1).
+	MaxConcurrentJobs int
+	// MaxQueuedJobs caps how many fuzz jobs wait for a free slot (default
+	// 10); submissions beyond it get 503.
+	MaxQueuedJobs int
+
+	// GraphQL, when set, is served at /api/v1/graphql behind AuthToken like
+	// the REST API.
+	GraphQL *graphql.Schema
+
+	// Metrics exposes Prometheus metrics at /metrics, unauthenticated like
+	// /healthz.
+	Metrics bool
+}
+
+// Server serves the cr-api HTTP endpoints.
+type Server struct {
+	opts Options
+	mux  *http.ServeMux
+	jobs *jobManager
+}
+
+// New creates a server and registers the enabled routes.
+func New(opts Options) (*Server, error) {
+	if opts.Addr == "" {
+		opts.Addr = defaultAddr
+	}
+	if opts.PublicRateLimit <= 0 {
+		opts.PublicRateLimit = defaultPublicRateLimit
+	}
+	if !opts.Public && !opts.API && opts.GraphQL == nil {
+		return nil, fmt.Errorf("no endpoints enabled; enable the REST API, GraphQL, or --public profiles")
+	}
+	if opts.API && opts.Backend == nil {
+		return nil, fmt.Errorf("REST API requires a backend")
+	}
+
+	s := &Server{opts: opts, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	if opts.Metrics {
+		s.mux.Handle("GET /metrics", metrics.Handler())
+	}
+	if opts.Public {
+		s.registerPublicRoutes(newRateLimiter(opts.PublicRateLimit, time.Minute))
+	}
+	if opts.API {
+		s.jobs = newJobManager(opts.Backend, opts.MaxConcurrentJobs, opts.MaxQueuedJobs)
+		s.registerAPIRoutes()
+	}
+	if opts.GraphQL != nil {
+		s.mux.Handle("/api/v1/graphql", s.requireAuth(opts.GraphQL.ServeHTTP))
+	}
+	return s, nil
+}
+
+// Addr returns the configured listen address.
+func (s *Server) Addr() string {
+	return s.opts.Addr
+}
+
+// Handler returns the root HTTP handler.
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+// ListenAndServe serves until ctx is cancelled, then shuts down gracefully.
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	httpServer := &http.Server{
+		Addr:              s.opts.Addr,
+		Handler:           s.mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		err := httpServer.Shutdown(shutdownCtx)
+		s.Close()
+		return err
+	}
+}
+
+// Close cancels any running fuzz jobs and waits for them to stop.
+func (s *Server) Close() {
+	if s.jobs != nil {
+		s.jobs.shutdown()
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(payload); err != nil {
+		log.Printf("server: failed to encode response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+// rateLimiter is a fixed-window, per-client-IP request limiter.
+type rateLimiter struct {
+	limit  int
+	window time.Duration
+	now    func() time.Time
+
+	mu      sync.Mutex
+	clients map[string]*clientWindow
+}
+
+type clientWindow struct {
+	start time.Time
+	count int
+}
+
+func newRateLimiter(limit int, window time.Duration) *rateLimiter {
+	return &rateLimiter{
+		limit:   limit,
+		window:  window,
+		now:     time.Now,
+		clients: make(map[string]*clientWindow),
+	}
+}
+
+// allow records a request for key and reports whether it is within the limit,
+// along with the time until the current window resets.
+func (rl *rateLimiter) allow(key string) (bool, time.Duration) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := rl.now()
+	entry, ok := rl.clients[key]
+	if !ok || now.Sub(entry.start) >= rl.window {
+		entry = &clientWindow{start: now}
+		rl.clients[key] = entry
+		rl.evictExpired(now)
+	}
+	entry.count++
+	return entry.count <= rl.limit, rl.window - now.Sub(entry.start)
+}
+
+func (rl *rateLimiter) evictExpired(now time.Time) {
+	for key, entry := range rl.clients {
+		if now.Sub(entry.start) >= rl.window {
+			delete(rl.clients, key)
+		}
+	}
+}
+
+func (rl *rateLimiter) middleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		allowed, reset := rl.allow(clientIP(r))
+		if !allowed {
+			w.Header().Set("Retry-After", fmt.Sprintf("%d", int(reset.Seconds())+1))
+			writeError(w, http.StatusTooManyRequests, "rate limit exceeded")
+			return
+		}
+		next(w, r)
+	}
+}
+
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/publicprofile"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+func newPublicTestServer(t *testing.T, limit int) (*Server, string) {
+	t.Helper()
+	dataDir := t.TempDir()
+
+	profile, err := publicprofile.Build(publicprofile.BuildInput{
+		Player: &clashroyale.Player{
+			Tag:         "#2PQ0R",
+			Name:        "Tester",
+			Trophies:    6000,
+			Clan:        &clashroyale.Clan{Name: "Secret Clan"},
+			CurrentDeck: []clashroyale.Card{{Name: "Knight"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if _, err := publicprofile.Save(dataDir, profile); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	srv, err := New(Options{DataDir: dataDir, Public: true, PublicRateLimit: limit})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return srv, dataDir
+}
+
+func TestPublicProfileEndpoints(t *testing.T) {
+	srv, _ := newPublicTestServer(t, 100)
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/public/profiles/2PQ0R", http.StatusOK},
+		{"/public/profiles/2pq0r/decks", http.StatusOK},
+		{"/public/profiles/2PQ0R/trophies", http.StatusOK},
+		{"/public/profiles/2PQ0R/collection", http.StatusOK},
+		{"/public/profiles/999999", http.StatusNotFound},
+		{"/public/profiles/bad-tag!", http.StatusBadRequest},
+		{"/public/profiles/2PQOR", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if rec.Code != tt.status {
+			t.Errorf("GET %s status = %d, want %d", tt.path, rec.Code, tt.status)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/public/profiles/2PQ0R", nil))
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if _, leaked := body["clan"]; leaked {
+		t.Error("public profile must not expose clan data")
+	}
+}
+
+func TestPublicEndpointsAreRateLimited(t *testing.T) {
+	srv, _ := newPublicTestServer(t, 2)
+
+	var last *httptest.ResponseRecorder
+	for range 3 {
+		last = httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/public/profiles/2PQ0R", nil)
+		req.RemoteAddr = "203.0.113.5:1234"
+		srv.Handler().ServeHTTP(last, req)
+	}
+	if last.Code != http.StatusTooManyRequests {
+		t.Errorf("third request status = %d, want 429", last.Code)
+	}
+	if last.Header().Get("Retry-After") == "" {
+		t.Error("expected Retry-After header on rate-limited response")
+	}
+
+	other := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/public/profiles/2PQ0R", nil)
+	req.RemoteAddr = "203.0.113.6:1234"
+	srv.Handler().ServeHTTP(other, req)
+	if other.Code != http.StatusOK {
+		t.Errorf("other client status = %d, want 200", other.Code)
+	}
+}
+
+func TestRateLimiterWindowResets(t *testing.T) {
+	limiter := newRateLimiter(1, time.Minute)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+
+	if ok, _ := limiter.allow("a"); !ok {
+		t.Fatal("first request should be allowed")
+	}
+	if ok, _ := limiter.allow("a"); ok {
+		t.Fatal("second request in window should be rejected")
+	}
+	now = now.Add(time.Minute)
+	if ok, _ := limiter.allow("a"); !ok {
+		t.Fatal("request after window reset should be allowed")
+	}
+}
+
+func TestNewRequiresEnabledEndpoints(t *testing.T) {
+	if _, err := New(Options{DataDir: t.TempDir()}); err == nil {
+		t.Error("expected error when no endpoints are enabled")
+	}
+}
@@ -0,0 +1,198 @@
+package sqlstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/apicache"
+	"github.com/klauer/clash-royale-api/go/internal/storage"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+// timestampedFilePattern matches files named by PathBuilder:
+// YYYYMMDD_HHMMSS_{kind}_{TAG}.json
+var timestampedFilePattern = regexp.MustCompile(`^(\d{8}_\d{6})_(analysis|deck)_([0-9A-Za-z]+)\.json$`)
+
+// MigrationReport counts what a JSON import added and skipped.
+type MigrationReport struct {
+	Players  int      `json:"players"`
+	Analyses int      `json:"analyses"`
+	Battles  int      `json:"battles"`
+	Decks    int      `json:"decks"`
+	Skipped  int      `json:"skipped"` // Already imported
+	Errors   []string `json:"errors,omitempty"`
+}
+
+// ImportJSON imports the JSON files under dataDir: player profiles, analyses,
+// saved deck recommendations, and player/battle log responses from the API
+// cache. Rows already present are skipped, so it is safe to run repeatedly.
+func (s *Store) ImportJSON(dataDir string) (MigrationReport, error) {
+	var report MigrationReport
+	pb := storage.NewPathBuilder(dataDir)
+
+	if err := s.importPlayerFiles(pb.GetPlayersDir(), &report); err != nil {
+		return report, err
+	}
+	if err := s.importTimestampedFiles(pb.GetAnalysisDir(), "analysis", &report); err != nil {
+		return report, err
+	}
+	if err := s.importTimestampedFiles(pb.GetDecksDir(), "deck", &report); err != nil {
+		return report, err
+	}
+	if err := s.importAPICache(pb.GetAPICacheDir(), &report); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+func (s *Store) importPlayerFiles(dir string, report *MigrationReport) error {
+	files, err := storage.ListJSONFiles(dir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		var player clashroyale.Player
+		if err := storage.ReadJSON(file, &player); err != nil || player.Tag == "" {
+			report.addError(file, err)
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			report.addError(file, err)
+			continue
+		}
+		added, err := s.RecordPlayer(&player, info.ModTime(), file)
+		if err != nil {
+			return err
+		}
+		report.count(added, &report.Players)
+	}
+	return nil
+}
+
+func (s *Store) importTimestampedFiles(dir, kind string, report *MigrationReport) error {
+	files, err := storage.ListJSONFiles(dir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		match := timestampedFilePattern.FindStringSubmatch(filepath.Base(file))
+		if match == nil || match[2] != kind {
+			continue
+		}
+		savedAt, err := time.ParseInLocation("20060102_150405", match[1], time.Local)
+		if err != nil {
+			report.addError(file, err)
+			continue
+		}
+		tag := "#" + strings.ToUpper(match[3])
+
+		var payload json.RawMessage
+		if err := storage.ReadJSON(file, &payload); err != nil {
+			report.addError(file, err)
+			continue
+		}
+
+		var added bool
+		switch kind {
+		case "analysis":
+			added, err = s.RecordAnalysis(tag, savedAt, payload, file)
+			if err != nil {
+				return err
+			}
+			report.count(added, &report.Analyses)
+		case "deck":
+			var deck struct {
+				Deck []string `json:"deck"`
+			}
+			if err := json.Unmarshal(payload, &deck); err != nil || len(deck.Deck) == 0 {
+				report.addError(file, err)
+				continue
+			}
+			added, err = s.RecordDeck(tag, savedAt, deck.Deck, payload, file)
+			if err != nil {
+				return err
+			}
+			report.count(added, &report.Decks)
+		}
+	}
+	return nil
+}
+
+func (s *Store) importAPICache(dir string, report *MigrationReport) error {
+	files, err := storage.ListJSONFiles(dir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		var entry apicache.Entry
+		if err := storage.ReadJSON(file, &entry); err != nil {
+			report.addError(file, err)
+			continue
+		}
+		tag, resource, ok := parsePlayerEndpoint(entry.Endpoint)
+		if !ok {
+			continue
+		}
+
+		switch resource {
+		case "":
+			var player clashroyale.Player
+			if err := json.Unmarshal(entry.Body, &player); err != nil {
+				report.addError(file, err)
+				continue
+			}
+			added, err := s.RecordPlayer(&player, entry.FetchedAt, "cache:"+entry.Endpoint)
+			if err != nil {
+				return err
+			}
+			report.count(added, &report.Players)
+		case "battlelog":
+			var battles clashroyale.BattleLogResponse
+			if err := json.Unmarshal(entry.Body, &battles); err != nil {
+				report.addError(file, err)
+				continue
+			}
+			added, err := s.RecordBattles(tag, battles)
+			if err != nil {
+				return err
+			}
+			report.Battles += added
+		}
+	}
+	return nil
+}
+
+// parsePlayerEndpoint splits "/players/%23TAG[/resource]" into tag and resource.
+func parsePlayerEndpoint(endpoint string) (tag, resource string, ok bool) {
+	rest, found := strings.CutPrefix(endpoint, "/players/")
+	if !found {
+		return "", "", false
+	}
+	escapedTag, resource, _ := strings.Cut(rest, "/")
+	tag = strings.Replace(escapedTag, "%23", "#", 1)
+	if tag == "" {
+		return "", "", false
+	}
+	return tag, resource, true
+}
+
+func (r *MigrationReport) count(added bool, counter *int) {
+	if added {
+		*counter++
+	} else {
+		r.Skipped++
+	}
+}
+
+func (r *MigrationReport) addError(file string, err error) {
+	if err == nil {
+		err = fmt.Errorf("unrecognized content")
+	}
+	r.Errors = append(r.Errors, fmt.Sprintf("%s: %v", file, err))
+}
@@ -0,0 +1,620 @@
+// Package sqlstore is the optional SQLite storage backend. It keeps a history
+// of player snapshots, analyses, battles, and saved decks in one database so
+// questions spanning many saves (e.g. trophy progression) can be answered with
+// a query instead of scanning JSON files.
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/closeutil"
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	_ "github.com/mattn/go-sqlite3" // SQLite driver
+)
+
+// DBFileName is the database file created inside the data directory.
+const DBFileName = "cr-api.db"
+
+// schemaVersion is bumped whenever schema changes need a migration step.
+const schemaVersion = 2
+
+// Store is a SQLite database holding players, analyses, battles, and decks.
+type Store struct {
+	db   *sql.DB
+	path string
+}
+
+// DefaultPath returns the database path for a data directory.
+func DefaultPath(dataDir string) string {
+	return filepath.Join(dataDir, DBFileName)
+}
+
+// Open opens (creating if needed) the database at path.
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create database directory: %w", err)
+	}
+	db, err := sql.Open("sqlite3", path+"?_foreign_keys=on&_busy_timeout=5000")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	store := &Store{db: db, path: path}
+	if err := store.initSchema(); err != nil {
+		closeutil.WithLog("sqlstore", db, "database")
+		return nil, fmt.Errorf("failed to initialize schema: %w", err)
+	}
+	return store, nil
+}
+
+// Close closes the database connection.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Path returns the database file path.
+func (s *Store) Path() string {
+	return s.path
+}
+
+func (s *Store) initSchema() error {
+	schema := `
+	CREATE TABLE IF NOT EXISTS schema_version (
+		version INTEGER NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS players (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		tag TEXT NOT NULL,
+		name TEXT NOT NULL,
+		exp_level INTEGER NOT NULL,
+		trophies INTEGER NOT NULL,
+		best_trophies INTEGER NOT NULL,
+		wins INTEGER NOT NULL,
+		losses INTEGER NOT NULL,
+		arena TEXT,
+		fetched_at DATETIME NOT NULL,
+		source TEXT,
+		data TEXT NOT NULL,
+		UNIQUE(tag, fetched_at)
+	);
+	CREATE INDEX IF NOT EXISTS idx_players_tag_time ON players(tag, fetched_at);
+
+	CREATE TABLE IF NOT EXISTS analyses (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		player_tag TEXT NOT NULL,
+		analyzed_at DATETIME NOT NULL,
+		source TEXT,
+		data TEXT NOT NULL,
+		UNIQUE(player_tag, analyzed_at)
+	);
+	CREATE INDEX IF NOT EXISTS idx_analyses_tag_time ON analyses(player_tag, analyzed_at);
+
+	CREATE TABLE IF NOT EXISTS battles (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		player_tag TEXT NOT NULL,
+		battle_time DATETIME NOT NULL,
+		battle_type TEXT,
+		game_mode TEXT,
+		opponent_tag TEXT NOT NULL,
+		crowns INTEGER NOT NULL,
+		opponent_crowns INTEGER NOT NULL,
+		starting_trophies INTEGER NOT NULL,
+		trophy_change INTEGER NOT NULL,
+		deck TEXT NOT NULL,
+		opponent_deck TEXT NOT NULL,
+		deck_level REAL NOT NULL DEFAULT 0,
+		opponent_deck_level REAL NOT NULL DEFAULT 0,
+		opponent_starting_trophies INTEGER NOT NULL DEFAULT 0,
+		UNIQUE(player_tag, battle_time, opponent_tag)
+	);
+	CREATE INDEX IF NOT EXISTS idx_battles_tag_time ON battles(player_tag, battle_time);
+
+	CREATE TABLE IF NOT EXISTS decks (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		player_tag TEXT NOT NULL,
+		saved_at DATETIME NOT NULL,
+		cards TEXT NOT NULL,
+		source TEXT,
+		data TEXT NOT NULL,
+		UNIQUE(player_tag, saved_at, cards)
+	);
+	CREATE INDEX IF NOT EXISTS idx_decks_tag_time ON decks(player_tag, saved_at);
+	`
+	if _, err := s.db.Exec(schema); err != nil {
+		return err
+	}
+
+	var version int
+	err := s.db.QueryRow("SELECT version FROM schema_version LIMIT 1").Scan(&version)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		_, err = s.db.Exec("INSERT INTO schema_version (version) VALUES (?)", schemaVersion)
+		return err
+	case err != nil:
+		return err
+	case version > schemaVersion:
+		return fmt.Errorf("database schema version %d is newer than supported version %d", version, schemaVersion)
+	case version < 2:
+		return s.migrateBattleLevels()
+	}
+	return nil
+}
+
+// migrateBattleLevels adds the deck level and opponent trophy columns of
+// schema version 2. Battles recorded before them read as 0 (unknown).
+func (s *Store) migrateBattleLevels() error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+	for _, column := range []string{
+		"deck_level REAL NOT NULL DEFAULT 0",
+		"opponent_deck_level REAL NOT NULL DEFAULT 0",
+		"opponent_starting_trophies INTEGER NOT NULL DEFAULT 0",
+	} {
+		if _, err := tx.Exec("ALTER TABLE battles ADD COLUMN " + column); err != nil {
+			return fmt.Errorf("failed to migrate battles: %w", err)
+		}
+	}
+	if _, err := tx.Exec("UPDATE schema_version SET version = 2"); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RecordPlayer stores a player snapshot. Recording the same player and
+// timestamp twice is a no-op; it reports whether a row was inserted.
+func (s *Store) RecordPlayer(player *clashroyale.Player, fetchedAt time.Time, source string) (bool, error) {
+	data, err := json.Marshal(player)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode player: %w", err)
+	}
+	result, err := s.db.Exec(`
+		INSERT OR IGNORE INTO players
+			(tag, name, exp_level, trophies, best_trophies, wins, losses, arena, fetched_at, source, data)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		clashroyale.NormalizeTag(player.Tag), player.Name, player.ExpLevel, player.Trophies, player.BestTrophies,
+		player.Wins, player.Losses, player.Arena.Name, fetchedAt.UTC(), source, string(data),
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to record player: %w", err)
+	}
+	return inserted(result)
+}
+
+// RecordAnalysis stores an analysis result serialized as JSON.
+func (s *Store) RecordAnalysis(playerTag string, analyzedAt time.Time, analysis any, source string) (bool, error) {
+	data, err := json.Marshal(analysis)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode analysis: %w", err)
+	}
+	result, err := s.db.Exec(`
+		INSERT OR IGNORE INTO analyses (player_tag, analyzed_at, source, data)
+		VALUES (?, ?, ?, ?)`,
+		clashroyale.NormalizeTag(playerTag), analyzedAt.UTC(), source, string(data),
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to record analysis: %w", err)
+	}
+	return inserted(result)
+}
+
+// RecordBattles stores 1v1 battles from a player's battle log and returns how
+// many were new. Battle logs overlap between fetches, so duplicates are skipped.
+func (s *Store) RecordBattles(playerTag string, battles []clashroyale.Battle) (int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.Prepare(`
+		INSERT OR IGNORE INTO battles
+			(player_tag, battle_time, battle_type, game_mode, opponent_tag, crowns, opponent_crowns,
+			 starting_trophies, trophy_change, deck, opponent_deck,
+			 deck_level, opponent_deck_level, opponent_starting_trophies)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return 0, err
+	}
+	defer closeutil.WithLog("sqlstore", stmt, "statement")
+
+	tag := clashroyale.NormalizeTag(playerTag)
+	added := 0
+	for _, battle := range battles {
+		if len(battle.Team) != 1 || len(battle.Opponent) != 1 {
+			continue
+		}
+		team, opponent := battle.Team[0], battle.Opponent[0]
+		deck, err := json.Marshal(cardNames(team.Cards))
+		if err != nil {
+			return 0, err
+		}
+		opponentDeck, err := json.Marshal(cardNames(opponent.Cards))
+		if err != nil {
+			return 0, err
+		}
+		result, err := stmt.Exec(
+			tag, battle.UTCDate.UTC(), battle.Type, battle.GameMode.Name, opponent.Tag,
+			team.Crowns, opponent.Crowns, team.StartingTrophies, team.TrophyChange,
+			string(deck), string(opponentDeck),
+			averageLevel(team.Cards), averageLevel(opponent.Cards), opponent.StartingTrophies,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("failed to record battle: %w", err)
+		}
+		if ok, err := inserted(result); err != nil {
+			return 0, err
+		} else if ok {
+			added++
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return added, nil
+}
+
+// RecordDeck stores a saved deck and its full payload.
+func (s *Store) RecordDeck(playerTag string, savedAt time.Time, cards []string, deck any, source string) (bool, error) {
+	cardsJSON, err := json.Marshal(cards)
+	if err != nil {
+		return false, err
+	}
+	data, err := json.Marshal(deck)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode deck: %w", err)
+	}
+	result, err := s.db.Exec(`
+		INSERT OR IGNORE INTO decks (player_tag, saved_at, cards, source, data)
+		VALUES (?, ?, ?, ?, ?)`,
+		clashroyale.NormalizeTag(playerTag), savedAt.UTC(), string(cardsJSON), source, string(data),
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to record deck: %w", err)
+	}
+	return inserted(result)
+}
+
+// TrophyPoint is the player's trophy count at a point in time.
+type TrophyPoint struct {
+	Time     time.Time `json:"time"`
+	Trophies int       `json:"trophies"`
+	Source   string    `json:"source"`          // "snapshot" or "battle"
+	Arena    string    `json:"arena,omitempty"` // Only recorded on snapshots
+}
+
+// TrophyProgression merges player snapshots and ladder battle results into a
+// chronological trophy history.
+func (s *Store) TrophyProgression(playerTag string, since time.Time) ([]TrophyPoint, error) {
+	tag := clashroyale.NormalizeTag(playerTag)
+	rows, err := s.db.Query(`
+		SELECT fetched_at, trophies, 'snapshot', COALESCE(arena, '') FROM players
+		WHERE tag = ? AND fetched_at >= ?
+		UNION ALL
+		SELECT battle_time, starting_trophies + trophy_change, 'battle', '' FROM battles
+		WHERE player_tag = ? AND battle_time >= ? AND starting_trophies > 0
+		ORDER BY 1`,
+		tag, since.UTC(), tag, since.UTC(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query trophy progression: %w", err)
+	}
+	defer closeutil.WithLog("sqlstore", rows, "rows")
+
+	var points []TrophyPoint
+	for rows.Next() {
+		var point TrophyPoint
+		if err := rows.Scan(&point.Time, &point.Trophies, &point.Source, &point.Arena); err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+	}
+	return points, rows.Err()
+}
+
+// PlayerSnapshot is a recorded player profile and when it was fetched.
+type PlayerSnapshot struct {
+	FetchedAt time.Time           `json:"fetched_at"`
+	Player    *clashroyale.Player `json:"player"`
+}
+
+// PlayerSnapshots returns the most recent recorded snapshots of a player
+// since the given time (zero for all history), oldest first; a limit of 0
+// returns all of them.
+func (s *Store) PlayerSnapshots(playerTag string, since time.Time, limit int) ([]PlayerSnapshot, error) {
+	rows, err := s.db.Query(`
+		SELECT fetched_at, data FROM (
+			SELECT id, fetched_at, data FROM players
+			WHERE tag = ? AND fetched_at >= ?
+			ORDER BY fetched_at DESC, id DESC LIMIT ?
+		)
+		ORDER BY fetched_at, id`,
+		clashroyale.NormalizeTag(playerTag), since.UTC(), sqlLimit(limit),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query player snapshots: %w", err)
+	}
+	defer closeutil.WithLog("sqlstore", rows, "rows")
+
+	var snapshots []PlayerSnapshot
+	for rows.Next() {
+		var snapshot PlayerSnapshot
+		var data string
+		if err := rows.Scan(&snapshot.FetchedAt, &data); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(data), &snapshot.Player); err != nil {
+			return nil, fmt.Errorf("failed to decode player snapshot: %w", err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, rows.Err()
+}
+
+// StoredBattle is a recorded 1v1 battle, seen from the recording player's side.
+type StoredBattle struct {
+	PlayerTag      string    `json:"player_tag"`
+	OpponentTag    string    `json:"opponent_tag"`
+	Time           time.Time `json:"time"`
+	Type           string    `json:"type"`
+	GameMode       string    `json:"game_mode"`
+	Crowns         int       `json:"crowns"`
+	OpponentCrowns int       `json:"opponent_crowns"`
+	Deck           []string  `json:"deck"`
+	OpponentDeck   []string  `json:"opponent_deck"`
+	// DeckLevel and OpponentDeckLevel are average card levels on the shared
+	// 1-16 scale; they and OpponentStartingTrophies are 0 for battles
+	// recorded before they were tracked
+	DeckLevel                float64 `json:"deck_level"`
+	OpponentDeckLevel        float64 `json:"opponent_deck_level"`
+	StartingTrophies         int     `json:"starting_trophies"`
+	OpponentStartingTrophies int     `json:"opponent_starting_trophies"`
+}
+
+// Battles returns every recorded battle since the given time (zero for all
+// history), oldest first.
+func (s *Store) Battles(since time.Time) ([]StoredBattle, error) {
+	return s.queryBattles(`WHERE battle_time >= ? ORDER BY battle_time, id`, since.UTC())
+}
+
+// PlayerBattles returns a player's most recent battles, newest first; a
+// limit of 0 returns all of them.
+func (s *Store) PlayerBattles(playerTag string, limit int) ([]StoredBattle, error) {
+	return s.queryBattles(`WHERE player_tag = ? ORDER BY battle_time DESC, id DESC LIMIT ?`,
+		clashroyale.NormalizeTag(playerTag), sqlLimit(limit))
+}
+
+func (s *Store) queryBattles(where string, args ...any) ([]StoredBattle, error) {
+	rows, err := s.db.Query(`
+		SELECT player_tag, opponent_tag, battle_time, COALESCE(battle_type, ''), COALESCE(game_mode, ''),
+			crowns, opponent_crowns, deck, opponent_deck,
+			deck_level, opponent_deck_level, starting_trophies, opponent_starting_trophies
+		FROM battles `+where,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query battles: %w", err)
+	}
+	defer closeutil.WithLog("sqlstore", rows, "rows")
+
+	var battles []StoredBattle
+	for rows.Next() {
+		var battle StoredBattle
+		var deck, opponentDeck string
+		if err := rows.Scan(&battle.PlayerTag, &battle.OpponentTag, &battle.Time, &battle.Type, &battle.GameMode,
+			&battle.Crowns, &battle.OpponentCrowns, &deck, &opponentDeck,
+			&battle.DeckLevel, &battle.OpponentDeckLevel, &battle.StartingTrophies, &battle.OpponentStartingTrophies); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(deck), &battle.Deck); err != nil {
+			return nil, fmt.Errorf("failed to decode battle deck: %w", err)
+		}
+		if err := json.Unmarshal([]byte(opponentDeck), &battle.OpponentDeck); err != nil {
+			return nil, fmt.Errorf("failed to decode battle deck: %w", err)
+		}
+		battles = append(battles, battle)
+	}
+	return battles, rows.Err()
+}
+
+// PlayerSummary describes a recorded player from their latest snapshot.
+type PlayerSummary struct {
+	Tag           string    `json:"tag"`
+	Name          string    `json:"name"`
+	ExpLevel      int       `json:"exp_level"`
+	Trophies      int       `json:"trophies"`
+	BestTrophies  int       `json:"best_trophies"`
+	Arena         string    `json:"arena,omitempty"`
+	LastFetchedAt time.Time `json:"last_fetched_at"`
+	Snapshots     int       `json:"snapshots"`
+}
+
+// Players returns players with at least one snapshot, by tag. An empty
+// tag lists all players; otherwise only that one is returned. A limit of 0
+// returns all of them.
+func (s *Store) Players(playerTag string, limit int) ([]PlayerSummary, error) {
+	filter, args := "", []any{}
+	if playerTag != "" {
+		filter, args = "AND p.tag = ?", append(args, clashroyale.NormalizeTag(playerTag))
+	}
+	args = append(args, sqlLimit(limit))
+	rows, err := s.db.Query(`
+		SELECT p.tag, p.name, p.exp_level, p.trophies, p.best_trophies, COALESCE(p.arena, ''), p.fetched_at,
+			(SELECT COUNT(*) FROM players c WHERE c.tag = p.tag)
+		FROM players p
+		WHERE p.id = (SELECT l.id FROM players l WHERE l.tag = p.tag ORDER BY l.fetched_at DESC, l.id DESC LIMIT 1) `+filter+`
+		ORDER BY p.tag LIMIT ?`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query players: %w", err)
+	}
+	defer closeutil.WithLog("sqlstore", rows, "rows")
+
+	players := []PlayerSummary{}
+	for rows.Next() {
+		var player PlayerSummary
+		if err := rows.Scan(&player.Tag, &player.Name, &player.ExpLevel, &player.Trophies, &player.BestTrophies,
+			&player.Arena, &player.LastFetchedAt, &player.Snapshots); err != nil {
+			return nil, err
+		}
+		players = append(players, player)
+	}
+	return players, rows.Err()
+}
+
+// StoredAnalysis is a recorded card collection analysis.
+type StoredAnalysis struct {
+	PlayerTag  string          `json:"player_tag"`
+	AnalyzedAt time.Time       `json:"analyzed_at"`
+	Source     string          `json:"source,omitempty"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// Analyses returns a player's most recent analyses, newest first; a limit
+// of 0 returns all of them.
+func (s *Store) Analyses(playerTag string, limit int) ([]StoredAnalysis, error) {
+	rows, err := s.db.Query(`
+		SELECT player_tag, analyzed_at, COALESCE(source, ''), data FROM analyses
+		WHERE player_tag = ?
+		ORDER BY analyzed_at DESC, id DESC LIMIT ?`,
+		clashroyale.NormalizeTag(playerTag), sqlLimit(limit),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query analyses: %w", err)
+	}
+	defer closeutil.WithLog("sqlstore", rows, "rows")
+
+	analyses := []StoredAnalysis{}
+	for rows.Next() {
+		var analysis StoredAnalysis
+		var data string
+		if err := rows.Scan(&analysis.PlayerTag, &analysis.AnalyzedAt, &analysis.Source, &data); err != nil {
+			return nil, err
+		}
+		analysis.Data = json.RawMessage(data)
+		analyses = append(analyses, analysis)
+	}
+	return analyses, rows.Err()
+}
+
+// StoredDeck is a recorded saved deck.
+type StoredDeck struct {
+	PlayerTag string          `json:"player_tag"`
+	SavedAt   time.Time       `json:"saved_at"`
+	Cards     []string        `json:"cards"`
+	Source    string          `json:"source,omitempty"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Decks returns a player's most recently saved decks, newest first; a
+// limit of 0 returns all of them.
+func (s *Store) Decks(playerTag string, limit int) ([]StoredDeck, error) {
+	rows, err := s.db.Query(`
+		SELECT player_tag, saved_at, cards, COALESCE(source, ''), data FROM decks
+		WHERE player_tag = ?
+		ORDER BY saved_at DESC, id DESC LIMIT ?`,
+		clashroyale.NormalizeTag(playerTag), sqlLimit(limit),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query decks: %w", err)
+	}
+	defer closeutil.WithLog("sqlstore", rows, "rows")
+
+	decks := []StoredDeck{}
+	for rows.Next() {
+		var deck StoredDeck
+		var cards, data string
+		if err := rows.Scan(&deck.PlayerTag, &deck.SavedAt, &cards, &deck.Source, &data); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(cards), &deck.Cards); err != nil {
+			return nil, fmt.Errorf("failed to decode deck cards: %w", err)
+		}
+		deck.Data = json.RawMessage(data)
+		decks = append(decks, deck)
+	}
+	return decks, rows.Err()
+}
+
+// sqlLimit maps a limit of 0 or less to SQLite's "no limit"
+func sqlLimit(limit int) int {
+	if limit <= 0 {
+		return -1
+	}
+	return limit
+}
+
+// Counts is the number of rows in each table.
+type Counts struct {
+	Players  int `json:"players"`
+	Analyses int `json:"analyses"`
+	Battles  int `json:"battles"`
+	Decks    int `json:"decks"`
+}
+
+// Counts returns row counts for every table.
+func (s *Store) Counts() (Counts, error) {
+	var counts Counts
+	targets := []struct {
+		table string
+		dest  *int
+	}{
+		{"players", &counts.Players},
+		{"analyses", &counts.Analyses},
+		{"battles", &counts.Battles},
+		{"decks", &counts.Decks},
+	}
+	for _, target := range targets {
+		// Table names come from the fixed list above.
+		if err := s.db.QueryRow("SELECT COUNT(*) FROM " + target.table).Scan(target.dest); err != nil {
+			return counts, fmt.Errorf("failed to count %s: %w", target.table, err)
+		}
+	}
+	return counts, nil
+}
+
+func inserted(result sql.Result) (bool, error) {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// averageLevel returns the mean card level on the shared 1-16 scale, or 0
+// for an empty deck. The API reports levels relative to each rarity's
+// starting level, so those are shifted up.
+func averageLevel(cards []clashroyale.Card) float64 {
+	if len(cards) == 0 {
+		return 0
+	}
+	total := 0
+	for _, card := range cards {
+		level := card.Level
+		if offset := config.GetStartingLevel(card.Rarity) - 1; offset > 0 && card.MaxLevel > 0 &&
+			card.MaxLevel+offset <= config.GetMaxLevel(card.Rarity) {
+			level += offset
+		}
+		total += level
+	}
+	return float64(total) / float64(len(cards))
+}
+
+func cardNames(cards []clashroyale.Card) []string {
+	names := make([]string, 0, len(cards))
+	for _, card := range cards {
+		names = append(names, card.Name)
+	}
+	return names
+}
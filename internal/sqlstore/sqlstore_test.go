@@ -0,0 +1,322 @@
+package sqlstore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/apicache"
+	"github.com/klauer/clash-royale-api/go/internal/storage"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	store, err := Open(filepath.Join(t.TempDir(), DBFileName))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	return store
+}
+
+func ladderBattle(at time.Time, opponent string, starting, change int) clashroyale.Battle {
+	return clashroyale.Battle{
+		Type:     "PvP",
+		UTCDate:  at,
+		Team:     []clashroyale.BattleTeam{{Tag: "#ABC", StartingTrophies: starting, TrophyChange: change, Cards: []clashroyale.Card{{Name: "Hog Rider"}}}},
+		Opponent: []clashroyale.BattleTeam{{Tag: opponent, Cards: []clashroyale.Card{{Name: "Golem"}}}},
+	}
+}
+
+func TestRecordAndTrophyProgression(t *testing.T) {
+	store := openTestStore(t)
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	player := &clashroyale.Player{Tag: "#ABC", Name: "Tester", Trophies: 7000}
+	if added, err := store.RecordPlayer(player, base, "test"); err != nil || !added {
+		t.Fatalf("RecordPlayer() = %v, %v", added, err)
+	}
+	if added, _ := store.RecordPlayer(player, base, "test"); added {
+		t.Error("recording the same snapshot twice should be a no-op")
+	}
+
+	battles := []clashroyale.Battle{
+		ladderBattle(base.Add(time.Hour), "#OPP1", 7000, 30),
+		ladderBattle(base.Add(2*time.Hour), "#OPP2", 7030, -28),
+		{UTCDate: base, Team: []clashroyale.BattleTeam{{}, {}}, Opponent: []clashroyale.BattleTeam{{}, {}}},
+	}
+	if added, err := store.RecordBattles("ABC", battles); err != nil || added != 2 {
+		t.Fatalf("RecordBattles() = %d, %v; want 2 (2v2 skipped)", added, err)
+	}
+	if added, _ := store.RecordBattles("#ABC", battles[:1]); added != 0 {
+		t.Errorf("overlapping battle log added %d rows, want 0", added)
+	}
+
+	points, err := store.TrophyProgression("#abc", time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []int{7000, 7030, 7002}
+	if len(points) != len(want) {
+		t.Fatalf("points = %+v", points)
+	}
+	for i, point := range points {
+		if point.Trophies != want[i] {
+			t.Errorf("point %d trophies = %d, want %d", i, point.Trophies, want[i])
+		}
+	}
+	if points[0].Source != "snapshot" || !points[1].Time.Equal(base.Add(time.Hour)) {
+		t.Errorf("unexpected first points: %+v", points[:2])
+	}
+
+	recent, err := store.TrophyProgression("#ABC", base.Add(90*time.Minute))
+	if err != nil || len(recent) != 1 {
+		t.Errorf("TrophyProgression(since) = %+v, %v", recent, err)
+	}
+}
+
+func TestPlayerSnapshots(t *testing.T) {
+	store := openTestStore(t)
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	later := &clashroyale.Player{Tag: "#ABC", Cards: []clashroyale.Card{{Name: "Knight"}, {Name: "Zap"}}}
+	earlier := &clashroyale.Player{Tag: "#ABC", Cards: []clashroyale.Card{{Name: "Knight"}}}
+	other := &clashroyale.Player{Tag: "#XYZ"}
+	for _, rec := range []struct {
+		player *clashroyale.Player
+		at     time.Time
+	}{{later, base.Add(24 * time.Hour)}, {earlier, base}, {other, base}} {
+		if _, err := store.RecordPlayer(rec.player, rec.at, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	snapshots, err := store.PlayerSnapshots("abc", time.Time{}, 0)
+	if err != nil {
+		t.Fatalf("PlayerSnapshots() error = %v", err)
+	}
+	if len(snapshots) != 2 {
+		t.Fatalf("snapshots = %+v, want 2 for #ABC", snapshots)
+	}
+	if !snapshots[0].FetchedAt.Equal(base) || len(snapshots[0].Player.Cards) != 1 || len(snapshots[1].Player.Cards) != 2 {
+		t.Errorf("snapshots not oldest first: %+v", snapshots)
+	}
+
+	recent, err := store.PlayerSnapshots("#ABC", base.Add(time.Hour), 0)
+	if err != nil || len(recent) != 1 {
+		t.Errorf("PlayerSnapshots(since) = %+v, %v", recent, err)
+	}
+
+	latest, err := store.PlayerSnapshots("#ABC", time.Time{}, 1)
+	if err != nil || len(latest) != 1 || len(latest[0].Player.Cards) != 2 {
+		t.Errorf("PlayerSnapshots(limit 1) = %+v, %v; want the newest", latest, err)
+	}
+}
+
+func TestBattles(t *testing.T) {
+	store := openTestStore(t)
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	win := ladderBattle(base.Add(time.Hour), "#OPP1", 7000, 30)
+	win.Team[0].Crowns = 2
+	// A level 14 common and a level 9 (standard 14) epic against level 11s
+	win.Team[0].Cards = []clashroyale.Card{{Name: "Hog Rider", Level: 14, MaxLevel: 16}, {Name: "Poison", Level: 9, MaxLevel: 11, Rarity: "Epic"}}
+	win.Opponent[0].Cards[0].Level = 11
+	win.Opponent[0].StartingTrophies = 7100
+	if _, err := store.RecordBattles("#ABC", []clashroyale.Battle{win, ladderBattle(base, "#OPP2", 7030, -28)}); err != nil {
+		t.Fatal(err)
+	}
+
+	battles, err := store.Battles(time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(battles) != 2 || battles[0].OpponentTag != "#OPP2" {
+		t.Fatalf("Battles() = %+v, want 2 battles oldest first", battles)
+	}
+	got := battles[1]
+	if got.PlayerTag != "#ABC" || got.Type != "PvP" || got.Crowns != 2 ||
+		len(got.Deck) != 2 || got.Deck[0] != "Hog Rider" || got.OpponentDeck[0] != "Golem" {
+		t.Errorf("battle = %+v", got)
+	}
+	if got.DeckLevel != 14 || got.OpponentDeckLevel != 11 || got.StartingTrophies != 7000 || got.OpponentStartingTrophies != 7100 {
+		t.Errorf("levels %.1f vs %.1f, trophies %d vs %d", got.DeckLevel, got.OpponentDeckLevel,
+			got.StartingTrophies, got.OpponentStartingTrophies)
+	}
+
+	recent, err := store.Battles(base.Add(30 * time.Minute))
+	if err != nil || len(recent) != 1 {
+		t.Errorf("Battles(since) = %+v, %v", recent, err)
+	}
+}
+
+func TestReadPlayersAnalysesAndDecks(t *testing.T) {
+	store := openTestStore(t)
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	for i, trophies := range []int{7000, 7100} {
+		player := &clashroyale.Player{Tag: "#ABC", Name: "Tester", Trophies: trophies}
+		if _, err := store.RecordPlayer(player, base.Add(time.Duration(i)*time.Hour), "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := store.RecordPlayer(&clashroyale.Player{Tag: "#XYZ", Name: "Other"}, base, "test"); err != nil {
+		t.Fatal(err)
+	}
+	for i := range 3 {
+		at := base.Add(time.Duration(i) * time.Hour)
+		if _, err := store.RecordAnalysis("#ABC", at, map[string]int{"total_cards": 100 + i}, "test"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := store.RecordDeck("#ABC", at, []string{"Hog Rider", "Zap"}, map[string]int{"rank": i}, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	battles := []clashroyale.Battle{ladderBattle(base, "#OPP1", 7000, 30), ladderBattle(base.Add(time.Hour), "#OPP2", 7030, -28)}
+	if _, err := store.RecordBattles("#ABC", battles); err != nil {
+		t.Fatal(err)
+	}
+
+	players, err := store.Players("", 0)
+	if err != nil || len(players) != 2 {
+		t.Fatalf("Players() = %+v, %v", players, err)
+	}
+	if got := players[0]; got.Tag != "#ABC" || got.Trophies != 7100 || got.Snapshots != 2 || !got.LastFetchedAt.Equal(base.Add(time.Hour)) {
+		t.Errorf("latest #ABC summary = %+v", got)
+	}
+	if one, err := store.Players("xyz", 0); err != nil || len(one) != 1 || one[0].Name != "Other" {
+		t.Errorf("Players(xyz) = %+v, %v", one, err)
+	}
+	if first, err := store.Players("", 1); err != nil || len(first) != 1 || first[0].Tag != "#ABC" {
+		t.Errorf("Players(limit 1) = %+v, %v", first, err)
+	}
+
+	analyses, err := store.Analyses("abc", 2)
+	if err != nil || len(analyses) != 2 || string(analyses[0].Data) != `{"total_cards":102}` {
+		t.Errorf("Analyses() = %+v, %v; want the 2 newest", analyses, err)
+	}
+	decks, err := store.Decks("#ABC", 0)
+	if err != nil || len(decks) != 3 || decks[0].Cards[0] != "Hog Rider" || string(decks[2].Data) != `{"rank":0}` {
+		t.Errorf("Decks() = %+v, %v", decks, err)
+	}
+	recent, err := store.PlayerBattles("#ABC", 1)
+	if err != nil || len(recent) != 1 || recent[0].OpponentTag != "#OPP2" {
+		t.Errorf("PlayerBattles() = %+v, %v; want the newest battle", recent, err)
+	}
+}
+
+func TestImportJSON(t *testing.T) {
+	dataDir := t.TempDir()
+	pb := storage.NewPathBuilder(dataDir)
+
+	playerPath := filepath.Join(pb.GetPlayersDir(), "ABC.json")
+	if err := storage.WriteJSON(playerPath, clashroyale.Player{Tag: "#ABC", Trophies: 6500}); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.WriteJSON(filepath.Join(pb.GetAnalysisDir(), "20261001_120000_analysis_ABC.json"), map[string]any{"player_tag": "#ABC"}); err != nil {
+		t.Fatal(err)
+	}
+	deckFile := filepath.Join(pb.GetDecksDir(), "20261002_120000_deck_ABC.json")
+	if err := storage.WriteJSON(deckFile, map[string]any{"deck": []string{"Hog Rider", "Fireball"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.WriteJSON(filepath.Join(pb.GetDecksDir(), "notes.json"), map[string]any{}); err != nil {
+		t.Fatal(err)
+	}
+
+	cache := apicache.New(dataDir, time.Hour)
+	battleLog := `[{"type":"PvP","utcDate":"2026-10-03T10:00:00Z","team":[{"tag":"#ABC","startingTrophies":6500,"trophyChange":30}],"opponent":[{"tag":"#OPP"}]}]`
+	if err := cache.Put(clashroyale.PlayerBattleLogEndpoint("#ABC"), []byte(battleLog)); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.Put(clashroyale.PlayerEndpoint("#ABC"), []byte(`{"tag":"#ABC","trophies":6530}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.Put("/clans/%23XYZ/members", []byte(`{"items":[]}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	store := openTestStore(t)
+	report, err := store.ImportJSON(dataDir)
+	if err != nil {
+		t.Fatalf("ImportJSON() error = %v", err)
+	}
+	if report.Players != 2 || report.Analyses != 1 || report.Decks != 1 || report.Battles != 1 || len(report.Errors) != 0 {
+		t.Errorf("first import report = %+v", report)
+	}
+
+	again, err := store.ImportJSON(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Players+again.Analyses+again.Decks+again.Battles != 0 || again.Skipped != 4 {
+		t.Errorf("second import should skip everything, got %+v", again)
+	}
+
+	counts, err := store.Counts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counts != (Counts{Players: 2, Analyses: 1, Battles: 1, Decks: 1}) {
+		t.Errorf("counts = %+v", counts)
+	}
+}
+
+func TestOpenRejectsNewerSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DBFileName)
+	store, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.db.Exec("UPDATE schema_version SET version = ?", schemaVersion+1); err != nil {
+		t.Fatal(err)
+	}
+	_ = store.Close()
+
+	if _, err := Open(path); err == nil {
+		t.Error("expected error opening a database from a newer version")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("database file missing: %v", err)
+	}
+}
+
+func TestOpenMigratesBattleLevels(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DBFileName)
+	store, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Recreate a version 1 battles table
+	for _, stmt := range []string{
+		"DROP TABLE battles",
+		`CREATE TABLE battles (
+			id INTEGER PRIMARY KEY AUTOINCREMENT, player_tag TEXT NOT NULL, battle_time DATETIME NOT NULL,
+			battle_type TEXT, game_mode TEXT, opponent_tag TEXT NOT NULL, crowns INTEGER NOT NULL,
+			opponent_crowns INTEGER NOT NULL, starting_trophies INTEGER NOT NULL, trophy_change INTEGER NOT NULL,
+			deck TEXT NOT NULL, opponent_deck TEXT NOT NULL, UNIQUE(player_tag, battle_time, opponent_tag))`,
+		`INSERT INTO battles (player_tag, battle_time, opponent_tag, crowns, opponent_crowns, starting_trophies,
+			trophy_change, deck, opponent_deck) VALUES ('#ABC', '2026-10-01 12:00:00', '#OPP', 1, 0, 7000, 30, '[]', '[]')`,
+		"UPDATE schema_version SET version = 1",
+	} {
+		if _, err := store.db.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_ = store.Close()
+
+	store, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	battles, err := store.Battles(time.Time{})
+	if err != nil || len(battles) != 1 || battles[0].DeckLevel != 0 {
+		t.Fatalf("Battles() after migration = %+v, %v", battles, err)
+	}
+	var version int
+	if err := store.db.QueryRow("SELECT version FROM schema_version").Scan(&version); err != nil || version != schemaVersion {
+		t.Errorf("schema version = %d, %v; want %d", version, err, schemaVersion)
+	}
+}
@@ -25,6 +25,10 @@ const (
 	CSVAnalysisSubdir   = "analysis"
 	CSVBattlesSubdir    = "battles"
 	CSVArchetypesSubdir = "archetypes"
+	PublicProfilesDir   = "public_profiles"
+	APICacheDir         = "cache/api"
+	EvaluationCacheFile = "cache/evaluations.json"
+	MetaSnapshotsDir    = "meta/snapshots"
 )
 
 // PathBuilder constructs standardized file paths for data storage
@@ -50,6 +54,11 @@ func (pb *PathBuilder) GetStaticCardsPath() string {
 	return filepath.Join(pb.GetStaticDir(), "cards.json")
 }
 
+// GetCardArtDir returns the directory caching downloaded card art.
+func (pb *PathBuilder) GetCardArtDir() string {
+	return filepath.Join(pb.GetStaticDir(), "card_art")
+}
+
 // GetPlayersDir returns the players data directory path
 func (pb *PathBuilder) GetPlayersDir() string {
 	return filepath.Join(pb.BaseDir, PlayersDir)
@@ -70,6 +79,42 @@ func (pb *PathBuilder) GetEventDecksDir() string {
 	return filepath.Join(pb.BaseDir, EventDecksDir)
 }
 
+// GetPublicProfilesDir returns the directory holding opted-in public profile snapshots
+func (pb *PathBuilder) GetPublicProfilesDir() string {
+	return filepath.Join(pb.BaseDir, PublicProfilesDir)
+}
+
+// GetPublicProfilePath returns the file path for a published public profile
+// Format: data/public_profiles/{playerTag}.json
+func (pb *PathBuilder) GetPublicProfilePath(playerTag string) (string, error) {
+	sanitized, err := playertag.Sanitize(playerTag)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(pb.GetPublicProfilesDir(), fmt.Sprintf("%s.json", sanitized)), nil
+}
+
+// GetAPICacheDir returns the directory holding cached API responses
+func (pb *PathBuilder) GetAPICacheDir() string {
+	return filepath.Join(pb.BaseDir, APICacheDir)
+}
+
+// GetEvaluationCachePath returns the file holding cached deck evaluations
+func (pb *PathBuilder) GetEvaluationCachePath() string {
+	return filepath.Join(pb.BaseDir, EvaluationCacheFile)
+}
+
+// GetMetaSnapshotsDir returns the directory holding weekly meta snapshots
+func (pb *PathBuilder) GetMetaSnapshotsDir() string {
+	return filepath.Join(pb.BaseDir, MetaSnapshotsDir)
+}
+
+// GetMetaSnapshotPath returns the file path for a weekly meta snapshot
+// Format: data/meta/snapshots/{week}.json
+func (pb *PathBuilder) GetMetaSnapshotPath(week string) string {
+	return filepath.Join(pb.GetMetaSnapshotsDir(), fmt.Sprintf("%s.json", week))
+}
+
 // GetEvolutionShardsPath returns the path to the evolution shard inventory file.
 func (pb *PathBuilder) GetEvolutionShardsPath() string {
 	return filepath.Join(pb.BaseDir, "evolution_shards.json")
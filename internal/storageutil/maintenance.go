@@ -0,0 +1,108 @@
+package storageutil
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+)
+
+// MaintenanceReport summarizes a maintenance pass over one database.
+type MaintenanceReport struct {
+	DBPath          string `json:"db_path"`
+	SizeBeforeBytes int64  `json:"size_before_bytes"`
+	SizeAfterBytes  int64  `json:"size_after_bytes"`
+	// DuplicatesRemoved counts decks deleted because another row holds the
+	// same cards
+	DuplicatesRemoved int `json:"duplicates_removed"`
+	// OrphansRemoved counts index rows whose deck no longer exists
+	OrphansRemoved int64 `json:"orphans_removed"`
+	// RelinkedRows counts rows of other tables that referred to a deck by an
+	// old hash and now use its canonical hash, or were dropped because the
+	// canonical hash already had them
+	RelinkedRows int64 `json:"relinked_rows,omitempty"`
+	// DryRun reports counts without changing the database
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// FreedBytes returns how much smaller the database file became.
+func (r MaintenanceReport) FreedBytes() int64 {
+	return max(r.SizeBeforeBytes-r.SizeAfterBytes, 0)
+}
+
+// Querier is implemented by both *sql.DB and *sql.Tx, so maintenance steps
+// can run inside one transaction.
+type Querier interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// OrphanCheck names an index table whose KeyColumn refers to the id of a
+// deck in DeckTable.
+type OrphanCheck struct {
+	Table     string
+	KeyColumn string
+	DeckTable string
+}
+
+// FileSize returns the size of a database file, or 0 when it cannot be read.
+func FileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// CountDeckHashDuplicates returns how many rows ApplyDeckHashMigration would
+// delete.
+func CountDeckHashDuplicates(records []DeckHashMigrationRow, winners map[string]DeckHashMigrationRow) int {
+	duplicates := 0
+	for _, row := range records {
+		if row.Valid && winners[row.Canonical].ID != row.ID {
+			duplicates++
+		}
+	}
+	return duplicates
+}
+
+// RemoveOrphans deletes index rows whose deck no longer exists, or with
+// dryRun only counts them.
+func RemoveOrphans(db Querier, checks []OrphanCheck, dryRun bool) (int64, error) {
+	var total int64
+	for _, check := range checks {
+		where := fmt.Sprintf("%s NOT IN (SELECT id FROM %s)", check.KeyColumn, check.DeckTable)
+		if dryRun {
+			var count int64
+			if err := db.QueryRow("SELECT COUNT(*) FROM " + check.Table + " WHERE " + where).Scan(&count); err != nil {
+				return 0, fmt.Errorf("failed to count orphaned %s rows: %w", check.Table, err)
+			}
+			total += count
+			continue
+		}
+		result, err := db.Exec("DELETE FROM " + check.Table + " WHERE " + where)
+		if err != nil {
+			return 0, fmt.Errorf("failed to remove orphaned %s rows: %w", check.Table, err)
+		}
+		deleted, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to read orphaned %s row count: %w", check.Table, err)
+		}
+		total += deleted
+	}
+	return total, nil
+}
+
+// Compact rebuilds every index, runs any extra statements (such as a
+// full-text index optimize), refreshes the query planner statistics, and
+// vacuums the database.
+func Compact(db *sql.DB, extra ...string) error {
+	statements := append([]string{"REINDEX"}, extra...)
+	statements = append(statements, "ANALYZE", "VACUUM")
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			return fmt.Errorf("failed to run %s: %w", statement, err)
+		}
+	}
+	return nil
+}
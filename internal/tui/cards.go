@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+// cardSort is a sort order for the card collection tab
+type cardSort int
+
+const (
+	sortByLevel cardSort = iota
+	sortByName
+	sortByRarity
+	sortByElixir
+	sortByCount
+	cardSortCount
+)
+
+var cardSortNames = [...]string{"level", "name", "rarity", "elixir", "count"}
+
+func (s cardSort) String() string {
+	return cardSortNames[s]
+}
+
+// next cycles to the following sort order.
+func (s cardSort) next() cardSort {
+	return (s + 1) % cardSortCount
+}
+
+// standardLevel places a card on the shared 1-16 level scale so cards of
+// different rarities sort together.
+func standardLevel(card clashroyale.Card) int {
+	candidate := deck.CardCandidate{Level: card.Level, MaxLevel: card.MaxLevel, Rarity: card.Rarity}
+	return candidate.StandardLevel()
+}
+
+// compareCards orders two cards by s. Ties fall back to name so the order
+// is stable while the user cycles sorts.
+func compareCards(a, b clashroyale.Card, s cardSort) int {
+	var c int
+	switch s {
+	case sortByLevel:
+		c = cmp.Compare(standardLevel(a), standardLevel(b))
+	case sortByRarity:
+		c = cmp.Compare(config.GetRarityPriorityScore(a.Rarity), config.GetRarityPriorityScore(b.Rarity))
+	case sortByElixir:
+		c = cmp.Compare(a.ElixirCost, b.ElixirCost)
+	case sortByCount:
+		c = cmp.Compare(a.Count, b.Count)
+	}
+	if c != 0 {
+		return c
+	}
+	return strings.Compare(a.Name, b.Name)
+}
+
+// visibleCards filters cards by a case-insensitive substring of the name,
+// rarity, or type and sorts them. Level, rarity, and count sort highest
+// first unless reversed; name and elixir sort lowest first.
+func visibleCards(cards []clashroyale.Card, filter string, s cardSort, reverse bool) []clashroyale.Card {
+	filter = strings.ToLower(strings.TrimSpace(filter))
+	out := make([]clashroyale.Card, 0, len(cards))
+	for _, card := range cards {
+		if filter == "" ||
+			strings.Contains(strings.ToLower(card.Name), filter) ||
+			strings.Contains(strings.ToLower(card.Rarity), filter) ||
+			strings.Contains(strings.ToLower(card.Type), filter) {
+			out = append(out, card)
+		}
+	}
+
+	descending := s == sortByLevel || s == sortByRarity || s == sortByCount
+	if reverse {
+		descending = !descending
+	}
+	slices.SortStableFunc(out, func(a, b clashroyale.Card) int {
+		if descending {
+			return compareCards(b, a, s)
+		}
+		return compareCards(a, b, s)
+	})
+	return out
+}
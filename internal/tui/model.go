@@ -0,0 +1,315 @@
+// Package tui implements the interactive terminal browser behind `cr-api tui`:
+// player info, the card collection, saved decks, and the fuzz leaderboard,
+// with an evaluation panel that scores the selected deck against the player.
+package tui
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/klauer/clash-royale-api/go/pkg/fuzzstorage"
+)
+
+// Tab identifies one of the browser's views
+type Tab int
+
+const (
+	TabPlayer Tab = iota
+	TabCards
+	TabDecks
+	TabLeaderboard
+	tabCount
+)
+
+var tabNames = [...]string{"Player", "Cards", "Decks", "Leaderboard"}
+
+func (t Tab) String() string {
+	return tabNames[t]
+}
+
+// SavedDeck is a deck listed on the Decks tab
+type SavedDeck struct {
+	Name      string
+	Cards     []string
+	AvgElixir float64
+	SavedAt   time.Time
+}
+
+// Source supplies the data the browser shows and the actions it triggers.
+type Source struct {
+	Player      *clashroyale.Player
+	SavedDecks  []SavedDeck
+	Leaderboard []fuzzstorage.DeckEntry
+
+	// Evaluate scores a deck with the player's card levels
+	Evaluate func(player *clashroyale.Player, cards []string) evaluation.EvaluationResult
+
+	// Reload fetches the player again; nil disables the reload key
+	Reload func(ctx context.Context) (*clashroyale.Player, error)
+}
+
+// deckRow is one selectable deck on the Decks or Leaderboard tab
+type deckRow struct {
+	label string
+	cards []string
+	entry *fuzzstorage.DeckEntry
+	saved *SavedDeck
+}
+
+type evaluatedMsg struct {
+	label  string
+	cards  []string
+	result evaluation.EvaluationResult
+}
+
+type reloadedMsg struct {
+	player *clashroyale.Player
+	err    error
+}
+
+// Model is the bubbletea model for the browser.
+type Model struct {
+	ctx    context.Context
+	src    Source
+	player *clashroyale.Player
+
+	tab    Tab
+	cursor [tabCount]int
+	width  int
+	height int
+
+	cardSort    cardSort
+	cardReverse bool
+	filter      string
+	filtering   bool
+	cards       []clashroyale.Card
+
+	evalLabel  string
+	evalCards  []string
+	eval       *evaluation.EvaluationResult
+	evaluating bool
+	reloading  bool
+	status     string
+}
+
+// New returns a browser model over src.
+func New(ctx context.Context, src Source) Model {
+	m := Model{ctx: ctx, src: src, player: src.Player, width: 100, height: 30}
+	m.refreshCards()
+	return m
+}
+
+// Run shows the browser until the user quits or ctx is cancelled.
+func Run(ctx context.Context, src Source) error {
+	_, err := tea.NewProgram(New(ctx, src), tea.WithAltScreen(), tea.WithContext(ctx)).Run()
+	return err
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+	case evaluatedMsg:
+		m.evaluating = false
+		m.evalLabel, m.evalCards = msg.label, msg.cards
+		m.eval = &msg.result
+		m.status = ""
+		return m, nil
+	case reloadedMsg:
+		m.reloading = false
+		if msg.err != nil {
+			m.status = "Reload failed: " + msg.err.Error()
+			return m, nil
+		}
+		m.player = msg.player
+		m.refreshCards()
+		m.status = "Reloaded " + m.player.Name
+		if m.evalCards != nil {
+			// Re-score the open evaluation against the refreshed levels
+			cmd := m.evaluate(m.evalLabel, m.evalCards)
+			return m, cmd
+		}
+		return m, nil
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.filtering {
+		switch msg.Type {
+		case tea.KeyEnter:
+			m.filtering = false
+		case tea.KeyEsc:
+			m.filtering = false
+			m.filter = ""
+		case tea.KeyBackspace:
+			if r := []rune(m.filter); len(r) > 0 {
+				m.filter = string(r[:len(r)-1])
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			m.filter += string(msg.Runes)
+		case tea.KeyCtrlC:
+			return m, tea.Quit
+		}
+		m.refreshCards()
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "tab", "right", "l":
+		m.tab = (m.tab + 1) % tabCount
+	case "shift+tab", "left", "h":
+		m.tab = (m.tab + tabCount - 1) % tabCount
+	case "1", "2", "3", "4":
+		m.tab = Tab(msg.String()[0] - '1')
+	case "up", "k":
+		m.moveCursor(-1)
+	case "down", "j":
+		m.moveCursor(1)
+	case "pgup":
+		m.moveCursor(-m.listHeight())
+	case "pgdown":
+		m.moveCursor(m.listHeight())
+	case "home", "g":
+		m.cursor[m.tab] = 0
+	case "end", "G":
+		m.moveCursor(m.rowCount())
+	case "s":
+		if m.tab == TabCards {
+			m.cardSort = m.cardSort.next()
+			m.refreshCards()
+		}
+	case "o":
+		if m.tab == TabCards {
+			m.cardReverse = !m.cardReverse
+			m.refreshCards()
+		}
+	case "/":
+		if m.tab == TabCards {
+			m.filtering = true
+		}
+	case "esc":
+		if m.tab == TabCards && m.filter != "" {
+			m.filter = ""
+			m.refreshCards()
+		} else {
+			m.eval, m.evalCards, m.evalLabel = nil, nil, ""
+		}
+	case "enter", "e":
+		if row, ok := m.selectedDeck(); ok && !m.evaluating {
+			cmd := m.evaluate(row.label, row.cards)
+			return m, cmd
+		}
+	case "r":
+		if m.src.Reload != nil && m.player != nil && !m.reloading {
+			m.reloading = true
+			m.status = "Reloading " + m.player.Tag + "…"
+			return m, m.reload()
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) evaluate(label string, cards []string) tea.Cmd {
+	if m.src.Evaluate == nil {
+		m.status = "Evaluation is not available"
+		return nil
+	}
+	m.evaluating = true
+	m.status = "Evaluating " + label + "…"
+	evaluate, player := m.src.Evaluate, m.player
+	return func() tea.Msg {
+		return evaluatedMsg{label: label, cards: cards, result: evaluate(player, cards)}
+	}
+}
+
+func (m Model) reload() tea.Cmd {
+	ctx, reload := m.ctx, m.src.Reload
+	return func() tea.Msg {
+		player, err := reload(ctx)
+		return reloadedMsg{player: player, err: err}
+	}
+}
+
+func (m *Model) refreshCards() {
+	var cards []clashroyale.Card
+	if m.player != nil {
+		cards = m.player.Cards
+	}
+	m.cards = visibleCards(cards, m.filter, m.cardSort, m.cardReverse)
+	m.clampCursor()
+}
+
+func (m *Model) moveCursor(delta int) {
+	m.cursor[m.tab] += delta
+	m.clampCursor()
+}
+
+func (m *Model) clampCursor() {
+	for tab := range tabCount {
+		n := m.rowCountFor(tab)
+		m.cursor[tab] = max(0, min(m.cursor[tab], n-1))
+	}
+}
+
+func (m Model) rowCount() int {
+	return m.rowCountFor(m.tab)
+}
+
+func (m Model) rowCountFor(tab Tab) int {
+	switch tab {
+	case TabCards:
+		return len(m.cards)
+	case TabDecks, TabLeaderboard:
+		return len(m.deckRows(tab))
+	default:
+		return 0
+	}
+}
+
+// deckRows lists the decks on tab. The Decks tab starts with the player's
+// in-game deck, followed by saved decks.
+func (m Model) deckRows(tab Tab) []deckRow {
+	var rows []deckRow
+	switch tab {
+	case TabDecks:
+		if m.player != nil && len(m.player.CurrentDeck) > 0 {
+			cards := make([]string, 0, len(m.player.CurrentDeck))
+			for _, card := range m.player.CurrentDeck {
+				cards = append(cards, card.Name)
+			}
+			rows = append(rows, deckRow{label: "Current in-game deck", cards: cards})
+		}
+		for i := range m.src.SavedDecks {
+			saved := &m.src.SavedDecks[i]
+			rows = append(rows, deckRow{label: saved.Name, cards: saved.Cards, saved: saved})
+		}
+	case TabLeaderboard:
+		for i := range m.src.Leaderboard {
+			entry := &m.src.Leaderboard[i]
+			rows = append(rows, deckRow{label: "Leaderboard #" + strconv.Itoa(i+1), cards: entry.Cards, entry: entry})
+		}
+	}
+	return rows
+}
+
+func (m Model) selectedDeck() (deckRow, bool) {
+	rows := m.deckRows(m.tab)
+	if len(rows) == 0 {
+		return deckRow{}, false
+	}
+	return rows[m.cursor[m.tab]], true
+}
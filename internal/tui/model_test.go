@@ -0,0 +1,186 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/klauer/clash-royale-api/go/pkg/fuzzstorage"
+)
+
+func testPlayer() *clashroyale.Player {
+	return &clashroyale.Player{
+		Tag:      "#ABC123",
+		Name:     "Tester",
+		Trophies: 7000,
+		Cards: []clashroyale.Card{
+			{Name: "Knight", Level: 14, MaxLevel: 16, Rarity: "Common", ElixirCost: 3, Count: 50, Type: "Troop"},
+			{Name: "Fireball", Level: 10, MaxLevel: 14, Rarity: "Rare", ElixirCost: 4, Count: 10, Type: "Spell"},
+			{Name: "Hog Rider", Level: 12, MaxLevel: 14, Rarity: "Rare", ElixirCost: 4, Count: 5, Type: "Troop"},
+			{Name: "The Log", Level: 5, MaxLevel: 6, Rarity: "Legendary", ElixirCost: 2, Count: 1, Type: "Spell"},
+		},
+		CurrentDeck: []clashroyale.Card{{Name: "Knight"}, {Name: "Fireball"}},
+	}
+}
+
+func press(t *testing.T, m Model, keys ...string) Model {
+	t.Helper()
+	for _, key := range keys {
+		var msg tea.KeyMsg
+		switch key {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "backspace":
+			msg = tea.KeyMsg{Type: tea.KeyBackspace}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		case "tab":
+			msg = tea.KeyMsg{Type: tea.KeyTab}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		}
+		next, cmd := m.Update(msg)
+		m = next.(Model)
+		// Deliver async results the way the program loop would
+		for cmd != nil {
+			result := cmd()
+			if _, quit := result.(tea.QuitMsg); quit || result == nil {
+				break
+			}
+			next, cmd = m.Update(result)
+			m = next.(Model)
+		}
+	}
+	return m
+}
+
+func cardNames(m Model) []string {
+	names := make([]string, 0, len(m.cards))
+	for _, card := range m.cards {
+		names = append(names, card.Name)
+	}
+	return names
+}
+
+func TestCardsSortAndFilter(t *testing.T) {
+	m := press(t, New(context.Background(), Source{Player: testPlayer()}), "2")
+	if m.tab != TabCards {
+		t.Fatalf("tab = %v, want Cards", m.tab)
+	}
+	// The Log (5/6 Legendary) is level 13 on the shared scale
+	if got := strings.Join(cardNames(m), ","); got != "Knight,Hog Rider,The Log,Fireball" {
+		t.Errorf("level order = %s", got)
+	}
+
+	m = press(t, m, "s")
+	if got := strings.Join(cardNames(m), ","); got != "Fireball,Hog Rider,Knight,The Log" {
+		t.Errorf("name order = %s", got)
+	}
+	m = press(t, m, "o")
+	if got := cardNames(m)[0]; got != "The Log" {
+		t.Errorf("reversed name order starts with %s", got)
+	}
+
+	m = press(t, m, "/", "s", "p", "e", "l", "l", "enter")
+	if got := strings.Join(cardNames(m), ","); got != "The Log,Fireball" {
+		t.Errorf("filtered cards = %s", got)
+	}
+	if view := m.View(); !strings.Contains(view, "Filter: spell") || !strings.Contains(view, "(2 of 4 cards)") {
+		t.Errorf("view missing filter summary:\n%s", view)
+	}
+	m = press(t, m, "esc")
+	if len(m.cards) != 4 {
+		t.Errorf("esc should clear the filter, got %d cards", len(m.cards))
+	}
+}
+
+func TestEvaluatePanelAndReload(t *testing.T) {
+	var evaluatedWith []string
+	src := Source{
+		Player: testPlayer(),
+		SavedDecks: []SavedDeck{
+			{Name: "saved-one", Cards: []string{"Hog Rider", "The Log"}, AvgElixir: 3},
+		},
+		Leaderboard: []fuzzstorage.DeckEntry{{Cards: []string{"Golem"}, OverallScore: 8.5, Archetype: "beatdown"}},
+		Evaluate: func(player *clashroyale.Player, cards []string) evaluation.EvaluationResult {
+			evaluatedWith = append(evaluatedWith, player.Name)
+			return evaluation.EvaluationResult{Deck: cards, OverallScore: 7.25, OverallRating: "Great"}
+		},
+		Reload: func(context.Context) (*clashroyale.Player, error) {
+			p := testPlayer()
+			p.Name = "Reloaded"
+			return p, nil
+		},
+	}
+
+	m := press(t, New(context.Background(), src), "3", "down", "enter")
+	if m.eval == nil || m.evalLabel != "saved-one" {
+		t.Fatalf("evaluation = %+v, label %q", m.eval, m.evalLabel)
+	}
+	view := m.View()
+	for _, want := range []string{"Evaluation: saved-one", "Overall 7.25 (Great)", "Hog Rider, The Log"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+
+	m = press(t, m, "r")
+	if m.player.Name != "Reloaded" {
+		t.Errorf("player after reload = %s", m.player.Name)
+	}
+	if got := strings.Join(evaluatedWith, ","); got != "Tester,Reloaded" {
+		t.Errorf("evaluations ran against %s; reload should re-score the open deck", got)
+	}
+
+	m = press(t, m, "4", "enter")
+	if m.evalLabel != "Leaderboard #1" || strings.Join(m.evalCards, ",") != "Golem" {
+		t.Errorf("leaderboard evaluation = %q %v", m.evalLabel, m.evalCards)
+	}
+	m = press(t, m, "esc")
+	if m.eval != nil {
+		t.Error("esc should close the evaluation panel")
+	}
+}
+
+func TestReloadFailureKeepsPlayer(t *testing.T) {
+	src := Source{
+		Player: testPlayer(),
+		Reload: func(context.Context) (*clashroyale.Player, error) { return nil, errors.New("rate limited") },
+	}
+	m := press(t, New(context.Background(), src), "r")
+	if m.player == nil || m.player.Name != "Tester" {
+		t.Errorf("player = %+v", m.player)
+	}
+	if !strings.Contains(m.View(), "Reload failed: rate limited") {
+		t.Errorf("view missing reload error:\n%s", m.View())
+	}
+}
+
+func TestCursorStaysInRange(t *testing.T) {
+	m := New(context.Background(), Source{Player: testPlayer()})
+	next, _ := m.Update(tea.WindowSizeMsg{Width: 60, Height: 8})
+	m = press(t, next.(Model), "2", "G")
+	if m.cursor[TabCards] != 3 {
+		t.Errorf("cursor at end = %d, want 3", m.cursor[TabCards])
+	}
+	m = press(t, m, "/", "k", "n", "i", "g", "h", "t", "enter")
+	if m.cursor[TabCards] != 0 {
+		t.Errorf("cursor after filtering = %d, want 0", m.cursor[TabCards])
+	}
+	for line := range strings.SplitSeq(m.View(), "\n") {
+		if w := len([]rune(line)); w > 60 {
+			t.Errorf("line wider than the terminal (%d): %q", w, line)
+		}
+	}
+
+	empty := press(t, New(context.Background(), Source{}), "3", "down", "enter", "4")
+	if !strings.Contains(empty.View(), "leaderboard is empty") {
+		t.Errorf("empty leaderboard view:\n%s", empty.View())
+	}
+}
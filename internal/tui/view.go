@@ -0,0 +1,262 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+)
+
+var (
+	titleStyle     = lipgloss.NewStyle().Bold(true)
+	activeTabStyle = lipgloss.NewStyle().Bold(true).Reverse(true).Padding(0, 1)
+	tabStyle       = lipgloss.NewStyle().Padding(0, 1)
+	selectedStyle  = lipgloss.NewStyle().Reverse(true)
+	headerStyle    = lipgloss.NewStyle().Bold(true).Underline(true)
+	dimStyle       = lipgloss.NewStyle().Faint(true)
+	panelStyle     = lipgloss.NewStyle().Border(lipgloss.NormalBorder(), true, false, false, false)
+)
+
+// chromeLines counts the title, tab bar, and help lines around the body.
+const chromeLines = 4
+
+func (m Model) View() string {
+	var b strings.Builder
+	b.WriteString(m.titleLine() + "\n")
+	b.WriteString(m.tabBar() + "\n\n")
+
+	panel := m.evalPanel()
+	var body string
+	switch m.tab {
+	case TabPlayer:
+		body = m.playerView()
+	case TabCards:
+		body = m.cardsView()
+	case TabDecks, TabLeaderboard:
+		body = m.decksView()
+	}
+	b.WriteString(body)
+	if panel != "" {
+		b.WriteString("\n" + panel)
+	}
+	b.WriteString("\n" + m.helpLine())
+	return lipgloss.NewStyle().MaxWidth(m.width).Render(b.String())
+}
+
+func (m Model) titleLine() string {
+	title := titleStyle.Render("cr-api")
+	if m.player != nil {
+		title += fmt.Sprintf("  %s (%s)  🏆 %d", m.player.Name, m.player.Tag, m.player.Trophies)
+	}
+	if m.status != "" {
+		title += "  " + dimStyle.Render(m.status)
+	}
+	return title
+}
+
+func (m Model) tabBar() string {
+	tabs := make([]string, 0, tabCount)
+	for tab := range tabCount {
+		label := fmt.Sprintf("%d %s", tab+1, tab)
+		if tab == m.tab {
+			tabs = append(tabs, activeTabStyle.Render(label))
+		} else {
+			tabs = append(tabs, tabStyle.Render(label))
+		}
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
+}
+
+// listHeight is the number of list rows that fit beside the other sections.
+func (m Model) listHeight() int {
+	used := chromeLines + 2 // list header and summary line
+	if panel := m.evalPanel(); panel != "" {
+		used += lipgloss.Height(panel) + 1
+	}
+	return max(3, m.height-used)
+}
+
+// window returns the [start, end) slice of n rows to draw so the cursor
+// stays visible.
+func (m Model) window(n int) (int, int) {
+	height := m.listHeight()
+	start := max(0, min(m.cursor[m.tab]-height/2, n-height))
+	return start, min(n, start+height)
+}
+
+func (m Model) playerView() string {
+	p := m.player
+	if p == nil {
+		return "No player loaded."
+	}
+	lines := []string{
+		fmt.Sprintf("Name:      %s (%s)", p.Name, p.Tag),
+		fmt.Sprintf("Level:     %d", p.ExpLevel),
+		fmt.Sprintf("Trophies:  %d (best %d)", p.Trophies, p.BestTrophies),
+		fmt.Sprintf("Arena:     %s", p.Arena.Name),
+	}
+	if p.League.Name != "" {
+		lines = append(lines, fmt.Sprintf("League:    %s", p.League.Name))
+	}
+	if p.Clan != nil {
+		lines = append(lines, fmt.Sprintf("Clan:      %s (%s), %s", p.Clan.Name, p.Clan.Tag, p.Role))
+	}
+	if games := p.Wins + p.Losses; games > 0 {
+		lines = append(lines, fmt.Sprintf("Record:    %d W / %d L (%.1f%%), %d three-crown",
+			p.Wins, p.Losses, float64(p.Wins)/float64(games)*100, p.ThreeCrownWins))
+	}
+	lines = append(lines, fmt.Sprintf("Cards:     %d collected, %d star points", len(p.Cards), p.StarPoints))
+	if troop := p.CurrentTowerTroop(); troop != "" {
+		lines = append(lines, fmt.Sprintf("Tower:     %s", troop))
+	}
+	if len(p.CurrentDeck) > 0 {
+		names := make([]string, 0, len(p.CurrentDeck))
+		for _, card := range p.CurrentDeck {
+			names = append(names, card.Name)
+		}
+		lines = append(lines, "", "Current deck: "+strings.Join(names, ", "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m Model) cardsView() string {
+	var b strings.Builder
+	order := "↓"
+	descending := m.cardSort == sortByLevel || m.cardSort == sortByRarity || m.cardSort == sortByCount
+	if descending == m.cardReverse {
+		order = "↑"
+	}
+	summary := fmt.Sprintf("Sort: %s %s", m.cardSort, order)
+	switch {
+	case m.filtering:
+		summary += "  Filter: " + m.filter + "▌"
+	case m.filter != "":
+		summary += "  Filter: " + m.filter
+	}
+	total := 0
+	if m.player != nil {
+		total = len(m.player.Cards)
+	}
+	summary += fmt.Sprintf("  (%d of %d cards)", len(m.cards), total)
+	b.WriteString(dimStyle.Render(summary) + "\n")
+
+	b.WriteString(headerStyle.Render(fmt.Sprintf("%-22s %-10s %6s %6s %6s  %s", "Card", "Rarity", "Level", "Elixir", "Count", "Evo")))
+	start, end := m.window(len(m.cards))
+	for i := start; i < end; i++ {
+		card := m.cards[i]
+		evo := deck.FormatEvolutionBadge(card.EvolutionLevel)
+		line := fmt.Sprintf("%-22s %-10s %6s %6d %6d  %s",
+			card.Name, card.Rarity, fmt.Sprintf("%d/%d", card.Level, card.MaxLevel), card.ElixirCost, card.Count, evo)
+		b.WriteString("\n" + m.row(i, line))
+	}
+	if len(m.cards) == 0 {
+		b.WriteString("\n" + dimStyle.Render("No cards match."))
+	}
+	return b.String()
+}
+
+func (m Model) decksView() string {
+	rows := m.deckRows(m.tab)
+	var b strings.Builder
+	if m.tab == TabDecks {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("%d decks", len(rows))) + "\n")
+		b.WriteString(headerStyle.Render(fmt.Sprintf("%-30s %6s  %s", "Deck", "Elixir", "Cards")))
+	} else {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("%d top fuzz decks", len(rows))) + "\n")
+		b.WriteString(headerStyle.Render(fmt.Sprintf("%-4s %6s %-14s %6s  %s", "#", "Score", "Archetype", "Elixir", "Cards")))
+	}
+
+	start, end := m.window(len(rows))
+	for i := start; i < end; i++ {
+		row := rows[i]
+		var line string
+		switch {
+		case row.entry != nil:
+			line = fmt.Sprintf("%-4d %6.2f %-14s %6.2f  %s",
+				i+1, row.entry.OverallScore, row.entry.Archetype, row.entry.AvgElixir, strings.Join(row.cards, ", "))
+		case row.saved != nil && row.saved.AvgElixir > 0:
+			line = fmt.Sprintf("%-30s %6.2f  %s", row.label, row.saved.AvgElixir, strings.Join(row.cards, ", "))
+		default:
+			line = fmt.Sprintf("%-30s %6s  %s", row.label, "", strings.Join(row.cards, ", "))
+		}
+		b.WriteString("\n" + m.row(i, line))
+	}
+	if len(rows) == 0 {
+		if m.tab == TabDecks {
+			b.WriteString("\n" + dimStyle.Render("No saved decks. Save one with `cr-api deck build --save`."))
+		} else {
+			b.WriteString("\n" + dimStyle.Render("The leaderboard is empty. Populate it with `cr-api deck fuzz --save-top`."))
+		}
+	}
+	return b.String()
+}
+
+func (m Model) row(i int, line string) string {
+	if i == m.cursor[m.tab] {
+		return selectedStyle.Render(line)
+	}
+	return line
+}
+
+// evalPanel renders the most recent evaluation, or nothing when none is
+// open.
+func (m Model) evalPanel() string {
+	if m.eval == nil {
+		if m.evaluating {
+			return panelStyle.Render("Evaluating…")
+		}
+		return ""
+	}
+	r := m.eval
+	target := "default card levels"
+	if m.player != nil {
+		target = m.player.Name + "'s card levels"
+	}
+	lines := []string{
+		titleStyle.Render(fmt.Sprintf("Evaluation: %s", m.evalLabel)) + dimStyle.Render("  with "+target),
+		fmt.Sprintf("Overall %.2f (%s)  Archetype %s (%.0f%%)  Avg elixir %.2f",
+			r.OverallScore, r.OverallRating, r.DetectedArchetype, r.ArchetypeConfidence*100, r.AvgElixir),
+		categoryLine(r),
+		dimStyle.Render(strings.Join(r.Deck, ", ")),
+	}
+	return panelStyle.Render(strings.Join(lines, "\n"))
+}
+
+func categoryLine(r *evaluation.EvaluationResult) string {
+	categories := []struct {
+		name  string
+		score evaluation.CategoryScore
+	}{
+		{"Attack", r.Attack},
+		{"Defense", r.Defense},
+		{"Synergy", r.Synergy},
+		{"Versatility", r.Versatility},
+		{"F2P", r.F2PFriendly},
+		{"Playability", r.Playability},
+	}
+	parts := make([]string, 0, len(categories))
+	for _, c := range categories {
+		parts = append(parts, fmt.Sprintf("%s %.1f", c.name, c.score.Score))
+	}
+	return strings.Join(parts, "  ")
+}
+
+func (m Model) helpLine() string {
+	help := "tab/1-4 switch · ↑↓ move · q quit"
+	switch m.tab {
+	case TabCards:
+		if m.filtering {
+			help = "type to filter · enter keep · esc clear"
+		} else {
+			help = "s sort · o reverse · / filter · " + help
+		}
+	case TabDecks, TabLeaderboard:
+		help = "enter evaluate · esc close · " + help
+	}
+	if m.src.Reload != nil {
+		help = "r reload player · " + help
+	}
+	return dimStyle.Render(help)
+}
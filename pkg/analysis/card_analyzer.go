@@ -40,6 +40,8 @@ func AnalyzeCardCollection(player *clashroyale.Player, options AnalysisOptions)
 		CardLevels:      cardLevels,
 		RarityBreakdown: rarityBreakdown,
 		UpgradePriority: upgradePriorities,
+		TowerTroops:     player.TowerTroopNames(),
+		ArenaID:         player.Arena.ID,
 	}
 
 	// Populate max level cards list
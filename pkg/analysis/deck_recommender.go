@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 
 	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
@@ -32,8 +33,13 @@ type DeckRecommendationResult struct {
 	AnalysisTime string                `json:"analysis_time"`
 }
 
-// RecommendDecks analyzes playstyle and recommends the best deck
-func RecommendDecks(playstyle *PlaystyleAnalysis, dataDir string) (*DeckRecommendationResult, error) {
+// CardFilter reports whether a card may appear in recommended decks
+type CardFilter func(cardName string) bool
+
+// RecommendDecks analyzes playstyle and recommends the best deck. Decks with
+// a card that allowed rejects, such as one the player's arena has not
+// unlocked, are never recommended; a nil filter allows every card.
+func RecommendDecks(playstyle *PlaystyleAnalysis, dataDir string, allowed CardFilter) (*DeckRecommendationResult, error) {
 	if playstyle == nil {
 		return nil, fmt.Errorf("playstyle analysis cannot be nil")
 	}
@@ -44,6 +50,16 @@ func RecommendDecks(playstyle *PlaystyleAnalysis, dataDir string) (*DeckRecommen
 		// If no decks found, create some example decks
 		decks = createExampleDecks()
 	}
+	if allowed != nil {
+		decks = slices.DeleteFunc(decks, func(deck *DeckAnalysis) bool {
+			return slices.ContainsFunc(deck.Cards, func(card clashroyale.Card) bool {
+				return !allowed(card.Name)
+			})
+		})
+	}
+	if len(decks) == 0 {
+		return nil, fmt.Errorf("no decks available with the allowed cards")
+	}
 
 	// Score each deck based on playstyle
 	deckScores := make([]*DeckRecommendation, 0, len(decks))
@@ -94,3 +94,28 @@ func TestClassifyDeckStyle(t *testing.T) {
 		}
 	}
 }
+
+func TestRecommendDecks_FiltersCards(t *testing.T) {
+	playstyle := &PlaystyleAnalysis{PlayerTag: "#TEST"}
+	noInferno := func(name string) bool { return name != "Inferno Dragon" }
+
+	result, err := RecommendDecks(playstyle, t.TempDir(), noInferno)
+	if err != nil {
+		t.Fatalf("RecommendDecks() error = %v", err)
+	}
+	for _, rec := range result.AllScores {
+		for _, card := range rec.Deck.Cards {
+			if card.Name == "Inferno Dragon" {
+				t.Errorf("%s recommended with a filtered card", rec.Deck.DeckName)
+			}
+		}
+	}
+	all, err := RecommendDecks(playstyle, t.TempDir(), nil)
+	if err != nil || len(all.AllScores) <= len(result.AllScores) {
+		t.Errorf("unfiltered recommendations = %d, filtered %d; want the filter to drop decks", len(all.AllScores), len(result.AllScores))
+	}
+
+	if _, err := RecommendDecks(playstyle, t.TempDir(), func(string) bool { return false }); err == nil {
+		t.Error("expected an error when the filter rejects every deck")
+	}
+}
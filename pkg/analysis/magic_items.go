@@ -0,0 +1,182 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+)
+
+// Book kinds. A book completes the card requirement of one card's next
+// level; each kind only works on some rarities.
+const (
+	BookOfCards       = "cards"
+	BookOfEpics       = "epics"
+	BookOfLegendaries = "legendaries"
+	BookOfChampions   = "champions"
+)
+
+// bookOrder spends the narrowest books first so the Book of Cards is still
+// free for a card that only it can reach
+var bookOrder = []string{BookOfChampions, BookOfLegendaries, BookOfEpics, BookOfCards}
+
+// bookRarities lists the rarities each book kind can upgrade
+var bookRarities = map[string][]string{
+	BookOfCards:       {"Common", "Rare", "Epic"},
+	BookOfEpics:       {"Epic"},
+	BookOfLegendaries: {"Legendary"},
+	BookOfChampions:   {"Champion"},
+}
+
+// MagicItems is a player's stock of wild cards and books. The official API
+// does not report inventory, so it is entered by hand.
+type MagicItems struct {
+	WildCards map[string]int `json:"wild_cards,omitempty"` // by rarity
+	Books     map[string]int `json:"books,omitempty"`      // by book kind
+}
+
+// IsEmpty reports whether there is nothing to spend
+func (m MagicItems) IsEmpty() bool {
+	for _, n := range m.WildCards {
+		if n > 0 {
+			return false
+		}
+	}
+	for _, n := range m.Books {
+		if n > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// MagicItemUse recommends spending a magic item on one card
+type MagicItemUse struct {
+	Item          string  `json:"item"`     // display name, e.g. "Book of Cards"
+	Quantity      int     `json:"quantity"` // books used or wild cards spent
+	CardName      string  `json:"card_name"`
+	Rarity        string  `json:"rarity"`
+	CurrentLevel  int     `json:"current_level"`
+	CardsCovered  int     `json:"cards_covered"`
+	PriorityScore float64 `json:"priority_score"`
+	Reason        string  `json:"reason"`
+}
+
+// BookName returns the in-game name of a book kind
+func BookName(kind string) string {
+	switch kind {
+	case BookOfCards:
+		return "Book of Cards"
+	case BookOfEpics:
+		return "Book of Epics"
+	case BookOfLegendaries:
+		return "Book of Legendaries"
+	case BookOfChampions:
+		return "Book of Champions"
+	default:
+		return "Book of " + kind
+	}
+}
+
+// NormalizeBookKind maps user input such as "Book of Legendaries" or
+// "legendary" to a book kind, or returns "" when it is not a known book
+func NormalizeBookKind(kind string) string {
+	k := strings.ToLower(strings.TrimSpace(kind))
+	k = strings.TrimPrefix(k, "book of ")
+	switch k {
+	case "card", "cards":
+		return BookOfCards
+	case "epic", "epics":
+		return BookOfEpics
+	case "legendary", "legendaries":
+		return BookOfLegendaries
+	case "champion", "champions":
+		return BookOfChampions
+	default:
+		return ""
+	}
+}
+
+// AdviseMagicItems recommends where to spend wild cards and books, working
+// down the upgrade priorities. Each book goes to the highest priority card of
+// a rarity it accepts that still needs cards, preferring cards whose gap the
+// wild cards on hand cannot close. Wild cards then finish the remaining
+// priorities in order when they cover the whole gap; partial top-ups are not
+// recommended because they do not change the card's level.
+func AdviseMagicItems(priorities []UpgradePriority, items MagicItems) []MagicItemUse {
+	pending := make([]UpgradePriority, 0, len(priorities))
+	for _, p := range priorities {
+		if p.CardsNeeded > 0 && p.CurrentLevel < p.MaxLevel {
+			p.Rarity = config.NormalizeRarity(p.Rarity)
+			pending = append(pending, p)
+		}
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].PriorityScore > pending[j].PriorityScore
+	})
+
+	wild := make(map[string]int, len(items.WildCards))
+	for rarity, n := range items.WildCards {
+		wild[config.NormalizeRarity(rarity)] += n
+	}
+
+	var uses []MagicItemUse
+	done := make(map[string]bool)
+
+	for _, kind := range bookOrder {
+		for range items.Books[kind] {
+			idx := pickBookTarget(pending, done, bookRarities[kind], wild)
+			if idx < 0 {
+				break
+			}
+			p := pending[idx]
+			done[p.CardName] = true
+			uses = append(uses, magicItemUse(BookName(kind), 1, p,
+				fmt.Sprintf("covers all %d cards still needed for level %d", p.CardsNeeded, p.CurrentLevel+1)))
+		}
+	}
+
+	for _, p := range pending {
+		if done[p.CardName] || wild[p.Rarity] < p.CardsNeeded {
+			continue
+		}
+		wild[p.Rarity] -= p.CardsNeeded
+		done[p.CardName] = true
+		uses = append(uses, magicItemUse(fmt.Sprintf("%s Wild Cards", p.Rarity), p.CardsNeeded, p,
+			fmt.Sprintf("finishes the upgrade to level %d", p.CurrentLevel+1)))
+	}
+
+	return uses
+}
+
+// pickBookTarget returns the index of the best pending card for a book that
+// accepts rarities, or -1 when none fits
+func pickBookTarget(pending []UpgradePriority, done map[string]bool, rarities []string, wild map[string]int) int {
+	fallback := -1
+	for i, p := range pending {
+		if done[p.CardName] || !contains(rarities, p.Rarity) {
+			continue
+		}
+		if wild[p.Rarity] < p.CardsNeeded {
+			return i
+		}
+		if fallback < 0 {
+			fallback = i
+		}
+	}
+	return fallback
+}
+
+func magicItemUse(item string, quantity int, p UpgradePriority, reason string) MagicItemUse {
+	return MagicItemUse{
+		Item:          item,
+		Quantity:      quantity,
+		CardName:      p.CardName,
+		Rarity:        p.Rarity,
+		CurrentLevel:  p.CurrentLevel,
+		CardsCovered:  p.CardsNeeded,
+		PriorityScore: p.PriorityScore,
+		Reason:        fmt.Sprintf("%s (%s priority, score %.1f)", reason, p.Priority, p.PriorityScore),
+	}
+}
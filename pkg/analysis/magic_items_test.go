@@ -0,0 +1,82 @@
+package analysis
+
+import "testing"
+
+func magicItemPriorities() []UpgradePriority {
+	return []UpgradePriority{
+		{CardName: "Musketeer", Rarity: "rare", CurrentLevel: 10, MaxLevel: 16, CardsNeeded: 300, PriorityScore: 85, Priority: "high"},
+		{CardName: "Knight", Rarity: "common", CurrentLevel: 11, MaxLevel: 16, CardsNeeded: 400, PriorityScore: 70, Priority: "high"},
+		{CardName: "Fireball", Rarity: "rare", CurrentLevel: 11, MaxLevel: 16, CardsNeeded: 50, PriorityScore: 60, Priority: "medium"},
+		{CardName: "The Log", Rarity: "legendary", CurrentLevel: 13, MaxLevel: 16, CardsNeeded: 10, PriorityScore: 55, Priority: "medium"},
+		{CardName: "Zap", Rarity: "common", CurrentLevel: 12, MaxLevel: 16, CardsNeeded: 0, PriorityScore: 90, Priority: "high"},
+	}
+}
+
+func TestAdviseMagicItemsBooksThenWildCards(t *testing.T) {
+	items := MagicItems{
+		WildCards: map[string]int{"Rare": 60},
+		Books:     map[string]int{BookOfCards: 1, BookOfLegendaries: 1},
+	}
+	uses := AdviseMagicItems(magicItemPriorities(), items)
+
+	want := []struct {
+		item string
+		card string
+		qty  int
+	}{
+		{"Book of Legendaries", "The Log", 1},
+		// Rare wild cards cannot close Musketeer's 300 card gap
+		{"Book of Cards", "Musketeer", 1},
+		{"Rare Wild Cards", "Fireball", 50},
+	}
+	if len(uses) != len(want) {
+		t.Fatalf("uses = %+v", uses)
+	}
+	for i, w := range want {
+		if uses[i].Item != w.item || uses[i].CardName != w.card || uses[i].Quantity != w.qty {
+			t.Errorf("uses[%d] = %+v, want %s on %s", i, uses[i], w.item, w.card)
+		}
+		if uses[i].Reason == "" {
+			t.Errorf("uses[%d] has no reason", i)
+		}
+	}
+}
+
+func TestAdviseMagicItemsBookSkipsGapWildCardsCanClose(t *testing.T) {
+	items := MagicItems{
+		WildCards: map[string]int{"rare": 300},
+		Books:     map[string]int{BookOfCards: 1},
+	}
+	uses := AdviseMagicItems(magicItemPriorities(), items)
+	if len(uses) != 2 {
+		t.Fatalf("uses = %+v", uses)
+	}
+	if uses[0].Item != "Book of Cards" || uses[0].CardName != "Knight" {
+		t.Errorf("book used on %s, want Knight", uses[0].CardName)
+	}
+	if uses[1].Item != "Rare Wild Cards" || uses[1].CardName != "Musketeer" || uses[1].Quantity != 300 {
+		t.Errorf("wild cards = %+v, want 300 on Musketeer", uses[1])
+	}
+}
+
+func TestAdviseMagicItemsNothingUsable(t *testing.T) {
+	items := MagicItems{Books: map[string]int{BookOfChampions: 2}, WildCards: map[string]int{"Epic": 5}}
+	if uses := AdviseMagicItems(magicItemPriorities(), items); len(uses) != 0 {
+		t.Errorf("uses = %+v, want none", uses)
+	}
+}
+
+func TestNormalizeBookKind(t *testing.T) {
+	tests := map[string]string{
+		"cards":               BookOfCards,
+		"Book of Legendaries": BookOfLegendaries,
+		"epic":                BookOfEpics,
+		" Champions ":         BookOfChampions,
+		"books":               "",
+	}
+	for in, want := range tests {
+		if got := NormalizeBookKind(in); got != want {
+			t.Errorf("NormalizeBookKind(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package analysis
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+// MasteryReward is the approximate reward for finishing a mastery level
+type MasteryReward struct {
+	Gold int `json:"gold"`
+	Gems int `json:"gems"`
+}
+
+// masteryRewards lists the reward for reaching each mastery level (index 0 is
+// level 1). Values approximate the in-game reward track and are used to rank
+// tasks, not to promise exact payouts.
+var masteryRewards = []MasteryReward{
+	{Gold: 500},
+	{Gold: 1000},
+	{Gold: 1500, Gems: 10},
+	{Gold: 2000},
+	{Gold: 3000, Gems: 20},
+	{Gold: 4000},
+	{Gold: 5000, Gems: 30},
+	{Gold: 7500},
+	{Gold: 10000, Gems: 50},
+	{Gold: 15000, Gems: 100},
+}
+
+// gemGoldValue converts gems to gold when ranking rewards, matching the
+// shop's typical 1 gem ≈ 20 gold exchange rate
+const gemGoldValue = 20
+
+// CardMastery is a player's progress toward the next level of a card mastery
+type CardMastery struct {
+	Card            string        `json:"card"`
+	Badge           string        `json:"badge"`
+	Level           int           `json:"level"`
+	MaxLevel        int           `json:"max_level"`
+	Progress        int           `json:"progress"`
+	Target          int           `json:"target"`
+	Remaining       int           `json:"remaining"`
+	PercentComplete float64       `json:"percent_complete"`
+	NextReward      MasteryReward `json:"next_reward"`
+}
+
+// IsComplete reports whether every mastery level is finished
+func (m CardMastery) IsComplete() bool {
+	return m.MaxLevel > 0 && m.Level >= m.MaxLevel
+}
+
+// RewardValue is the next reward expressed in gold
+func (m CardMastery) RewardValue() int {
+	return m.NextReward.Gold + m.NextReward.Gems*gemGoldValue
+}
+
+// MasteryRewardForLevel returns the reward for reaching level, or a zero
+// reward for levels outside the known track
+func MasteryRewardForLevel(level int) MasteryReward {
+	if level < 1 || level > len(masteryRewards) {
+		return MasteryReward{}
+	}
+	return masteryRewards[level-1]
+}
+
+// ParseCardMasteries extracts card mastery progress from the player's badges.
+// Badge names like "MasteryHogRider" are matched to card names in the
+// player's collection, falling back to splitting the CamelCase name.
+func ParseCardMasteries(player *clashroyale.Player) []CardMastery {
+	if player == nil {
+		return nil
+	}
+
+	cardNames := make(map[string]string, len(player.Cards))
+	for _, card := range player.Cards {
+		cardNames[masteryKey(card.Name)] = card.Name
+	}
+
+	var masteries []CardMastery
+	for _, badge := range player.Badges {
+		if !badge.IsMastery() {
+			continue
+		}
+		badgeCard := strings.TrimPrefix(badge.Name, clashroyale.MasteryBadgePrefix)
+		cardName, ok := cardNames[masteryKey(badgeCard)]
+		if !ok {
+			cardName = splitCamelCase(badgeCard)
+		}
+
+		mastery := CardMastery{
+			Card:     cardName,
+			Badge:    badge.Name,
+			Level:    badge.Level,
+			MaxLevel: badge.MaxLevel,
+			Progress: badge.Progress,
+			Target:   badge.Target,
+		}
+		if !mastery.IsComplete() {
+			mastery.NextReward = MasteryRewardForLevel(badge.Level + 1)
+			if badge.Target > 0 {
+				mastery.Remaining = max(badge.Target-badge.Progress, 0)
+				mastery.PercentComplete = min(float64(badge.Progress)/float64(badge.Target)*100, 100)
+			}
+		}
+		masteries = append(masteries, mastery)
+	}
+	return masteries
+}
+
+// MasteriesCloseToCompletion returns unfinished masteries at or above
+// minPercent progress, closest to completion first with ties broken by reward
+// value. topN <= 0 returns all of them.
+func MasteriesCloseToCompletion(masteries []CardMastery, minPercent float64, topN int) []CardMastery {
+	var nearDone []CardMastery
+	for _, mastery := range masteries {
+		if mastery.IsComplete() || mastery.Target == 0 || mastery.PercentComplete < minPercent {
+			continue
+		}
+		nearDone = append(nearDone, mastery)
+	}
+
+	sort.SliceStable(nearDone, func(i, j int) bool {
+		if nearDone[i].PercentComplete != nearDone[j].PercentComplete {
+			return nearDone[i].PercentComplete > nearDone[j].PercentComplete
+		}
+		if nearDone[i].RewardValue() != nearDone[j].RewardValue() {
+			return nearDone[i].RewardValue() > nearDone[j].RewardValue()
+		}
+		return nearDone[i].Card < nearDone[j].Card
+	})
+
+	if topN > 0 && len(nearDone) > topN {
+		nearDone = nearDone[:topN]
+	}
+	return nearDone
+}
+
+// masteryKey normalizes a card or badge name for matching
+func masteryKey(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// splitCamelCase turns "HogRider" into "Hog Rider"
+func splitCamelCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if i > 0 && unicode.IsUpper(r) {
+			b.WriteRune(' ')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,75 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+func masteryTestPlayer() *clashroyale.Player {
+	return &clashroyale.Player{
+		Tag:   "#ABC",
+		Cards: []clashroyale.Card{{Name: "Hog Rider"}, {Name: "Mini P.E.K.K.A"}, {Name: "The Log"}},
+		Badges: []clashroyale.Badge{
+			{Name: "MasteryHogRider", Level: 4, MaxLevel: 10, Progress: 90, Target: 100},
+			{Name: "MasteryMiniPekka", Level: 1, MaxLevel: 10, Progress: 30, Target: 100},
+			{Name: "MasteryTheLog", Level: 10, MaxLevel: 10, Progress: 500, Target: 500},
+			{Name: "MasteryGoblinGiant", Level: 8, MaxLevel: 10, Progress: 45, Target: 50},
+			{Name: "Classic12Wins", Progress: 3},
+		},
+	}
+}
+
+func TestParseCardMasteries(t *testing.T) {
+	masteries := ParseCardMasteries(masteryTestPlayer())
+	if len(masteries) != 4 {
+		t.Fatalf("got %d masteries, want 4 (non-mastery badges skipped)", len(masteries))
+	}
+
+	byCard := make(map[string]CardMastery)
+	for _, mastery := range masteries {
+		byCard[mastery.Card] = mastery
+	}
+	hog, ok := byCard["Hog Rider"]
+	if !ok || hog.Remaining != 10 || hog.PercentComplete != 90 {
+		t.Errorf("unexpected Hog Rider mastery: %+v", hog)
+	}
+	if hog.NextReward != MasteryRewardForLevel(5) {
+		t.Errorf("Hog Rider next reward = %+v, want level 5 reward", hog.NextReward)
+	}
+	if _, ok := byCard["Mini P.E.K.K.A"]; !ok {
+		t.Error("expected MasteryMiniPekka to match the collection's Mini P.E.K.K.A")
+	}
+	if _, ok := byCard["Goblin Giant"]; !ok {
+		t.Error("expected an unowned card name to be split from CamelCase")
+	}
+	if log := byCard["The Log"]; !log.IsComplete() || log.NextReward != (MasteryReward{}) {
+		t.Errorf("expected a completed mastery with no next reward, got %+v", log)
+	}
+}
+
+func TestMasteriesCloseToCompletion(t *testing.T) {
+	masteries := ParseCardMasteries(masteryTestPlayer())
+
+	nearDone := MasteriesCloseToCompletion(masteries, 50, 0)
+	if len(nearDone) != 2 {
+		t.Fatalf("got %d masteries, want 2", len(nearDone))
+	}
+	// Both are at 90%; Goblin Giant's level 9 reward is worth more
+	if nearDone[0].Card != "Goblin Giant" || nearDone[1].Card != "Hog Rider" {
+		t.Errorf("unexpected order: %s, %s", nearDone[0].Card, nearDone[1].Card)
+	}
+
+	if got := MasteriesCloseToCompletion(masteries, 0, 1); len(got) != 1 {
+		t.Errorf("topN=1 returned %d masteries", len(got))
+	}
+}
+
+func TestMasteryRewardForLevel(t *testing.T) {
+	if MasteryRewardForLevel(0) != (MasteryReward{}) || MasteryRewardForLevel(11) != (MasteryReward{}) {
+		t.Error("expected zero rewards outside the mastery track")
+	}
+	if MasteryRewardForLevel(10).Gold <= MasteryRewardForLevel(1).Gold {
+		t.Error("expected higher levels to reward more gold")
+	}
+}
@@ -0,0 +1,265 @@
+// Package predict estimates the chance of winning a battle with a logistic
+// regression model trained on locally recorded battles. The model uses three
+// features: the card level gap, the trophy gap, and how the two deck
+// archetypes have fared against each other.
+package predict
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/storage"
+)
+
+// ModelFile is the default name of the trained model in the data directory.
+const ModelFile = "win_model.json"
+
+// trophyScale converts a trophy gap into the trophy feature: one unit per
+// 100 trophies
+const trophyScale = 100.0
+
+// Features describe a battle from one player's side.
+type Features struct {
+	// LevelDelta is the player's average card level minus the opponent's on
+	// the shared 1-16 scale; 0 when unknown
+	LevelDelta float64 `json:"level_delta"`
+	// TrophyDelta is the player's starting trophies minus the opponent's; 0
+	// when unknown
+	TrophyDelta       float64 `json:"trophy_delta"`
+	Archetype         string  `json:"archetype"`
+	OpponentArchetype string  `json:"opponent_archetype"`
+}
+
+// Mirror returns the features from the opponent's side.
+func (f Features) Mirror() Features {
+	return Features{
+		LevelDelta:        -f.LevelDelta,
+		TrophyDelta:       -f.TrophyDelta,
+		Archetype:         f.OpponentArchetype,
+		OpponentArchetype: f.Archetype,
+	}
+}
+
+// Example is a recorded battle and whether the player won it.
+type Example struct {
+	Features
+	Won bool
+}
+
+// Weights are the model coefficients. The model has no intercept: with
+// every example also trained from the opponent's side, predictions for the
+// two sides of a battle add up to 1.
+type Weights struct {
+	LevelDelta float64 `json:"level_delta"`
+	// TrophyDelta is per 100 trophies
+	TrophyDelta float64 `json:"trophy_delta"`
+	// Matchup scales the log-odds of the archetype matchup's win rate
+	Matchup float64 `json:"matchup"`
+}
+
+// MatchupRecord is how decks of one archetype fared against another.
+type MatchupRecord struct {
+	Archetype string `json:"archetype"`
+	Opponent  string `json:"opponent"`
+	Wins      int    `json:"wins"`
+	Games     int    `json:"games"`
+}
+
+// TrainOptions controls Train.
+type TrainOptions struct {
+	// Iterations of batch gradient descent
+	Iterations   int
+	LearningRate float64
+	// L2 is the ridge penalty on the weights
+	L2 float64
+	// PriorGames shrinks matchup win rates toward 50%, as if every matchup
+	// had this many extra games split evenly
+	PriorGames float64
+}
+
+// DefaultTrainOptions returns the default training settings.
+func DefaultTrainOptions() TrainOptions {
+	return TrainOptions{Iterations: 500, LearningRate: 0.5, L2: 0.01, PriorGames: 10}
+}
+
+// Model is a trained win prediction model.
+type Model struct {
+	Version    int             `json:"version"`
+	TrainedAt  time.Time       `json:"trained_at"`
+	Battles    int             `json:"battles"`
+	PriorGames float64         `json:"prior_games"`
+	Weights    Weights         `json:"weights"`
+	Matchups   []MatchupRecord `json:"matchups"`
+	// Accuracy and LogLoss are measured on the training battles
+	Accuracy float64 `json:"accuracy"`
+	LogLoss  float64 `json:"log_loss"`
+
+	index map[[2]string]MatchupRecord
+}
+
+// Train fits a model to examples. Each example is also trained from the
+// opponent's side. A battle's own result is left out of its matchup
+// feature so the model cannot learn to trust matchups seen only once.
+func Train(examples []Example, opts TrainOptions) (*Model, error) {
+	if len(examples) == 0 {
+		return nil, errors.New("no battles to train on")
+	}
+	if opts.Iterations <= 0 || opts.LearningRate <= 0 {
+		return nil, errors.New("iterations and learning rate must be positive")
+	}
+
+	model := &Model{Version: 1, TrainedAt: time.Now().UTC(), Battles: len(examples), PriorGames: opts.PriorGames}
+	model.index = make(map[[2]string]MatchupRecord)
+	tally := func(a, b string, won bool) {
+		key := [2]string{a, b}
+		record := model.index[key]
+		record.Archetype, record.Opponent = a, b
+		record.Games++
+		if won {
+			record.Wins++
+		}
+		model.index[key] = record
+	}
+	for _, example := range examples {
+		tally(example.Archetype, example.OpponentArchetype, example.Won)
+		tally(example.OpponentArchetype, example.Archetype, !example.Won)
+	}
+
+	type row struct {
+		x [3]float64
+		y float64
+	}
+	rows := make([]row, 0, 2*len(examples))
+	for _, example := range examples {
+		for _, side := range []Example{example, {Features: example.Mirror(), Won: !example.Won}} {
+			rows = append(rows, row{
+				x: [3]float64{
+					side.LevelDelta,
+					side.TrophyDelta / trophyScale,
+					model.matchupLogit(side.Archetype, side.OpponentArchetype, &side.Won),
+				},
+				y: boolToFloat(side.Won),
+			})
+		}
+	}
+
+	var w [3]float64
+	n := float64(len(rows))
+	for range opts.Iterations {
+		var grad [3]float64
+		for _, r := range rows {
+			diff := sigmoid(dot(w, r.x)) - r.y
+			for j := range grad {
+				grad[j] += diff * r.x[j]
+			}
+		}
+		for j := range w {
+			w[j] -= opts.LearningRate * (grad[j]/n + opts.L2*w[j])
+		}
+	}
+	model.Weights = Weights{LevelDelta: w[0], TrophyDelta: w[1], Matchup: w[2]}
+
+	correct, loss := 0.0, 0.0
+	for _, r := range rows {
+		p := sigmoid(dot(w, r.x))
+		if (p > 0.5) == (r.y == 1) {
+			correct++
+		} else if p == 0.5 {
+			correct += 0.5
+		}
+		p = math.Min(math.Max(p, 1e-12), 1-1e-12)
+		loss -= r.y*math.Log(p) + (1-r.y)*math.Log(1-p)
+	}
+	model.Accuracy = correct / n
+	model.LogLoss = loss / n
+
+	model.Matchups = slices.SortedFunc(maps.Values(model.index), func(a, b MatchupRecord) int {
+		return cmp.Or(strings.Compare(a.Archetype, b.Archetype), strings.Compare(a.Opponent, b.Opponent))
+	})
+	return model, nil
+}
+
+// Predict returns the probability that the player described by f wins.
+func (m *Model) Predict(f Features) float64 {
+	x := [3]float64{f.LevelDelta, f.TrophyDelta / trophyScale, m.matchupLogit(f.Archetype, f.OpponentArchetype, nil)}
+	return sigmoid(dot([3]float64{m.Weights.LevelDelta, m.Weights.TrophyDelta, m.Weights.Matchup}, x))
+}
+
+// Matchup returns the recorded games of archetype against opponent.
+func (m *Model) Matchup(archetype, opponent string) MatchupRecord {
+	record, ok := m.matchupIndex()[[2]string{archetype, opponent}]
+	if !ok {
+		return MatchupRecord{Archetype: archetype, Opponent: opponent}
+	}
+	return record
+}
+
+// matchupLogit returns the log-odds of the shrunk matchup win rate. When
+// exclude is set, that result is first taken out of the record.
+func (m *Model) matchupLogit(archetype, opponent string, exclude *bool) float64 {
+	record := m.Matchup(archetype, opponent)
+	wins, games := float64(record.Wins), float64(record.Games)
+	if exclude != nil && games > 0 {
+		games--
+		if *exclude {
+			wins--
+		}
+	}
+	rate := (wins + m.PriorGames/2) / (games + m.PriorGames)
+	if games+m.PriorGames == 0 {
+		rate = 0.5
+	}
+	rate = math.Min(math.Max(rate, 0.01), 0.99)
+	return math.Log(rate / (1 - rate))
+}
+
+func (m *Model) matchupIndex() map[[2]string]MatchupRecord {
+	if m.index == nil {
+		m.index = make(map[[2]string]MatchupRecord, len(m.Matchups))
+		for _, record := range m.Matchups {
+			m.index[[2]string{record.Archetype, record.Opponent}] = record
+		}
+	}
+	return m.index
+}
+
+// Load reads a model file written by Save.
+func Load(path string) (*Model, error) {
+	var model Model
+	if err := storage.ReadJSON(path, &model); err != nil {
+		return nil, fmt.Errorf("failed to read win model: %w", err)
+	}
+	if model.Version != 1 {
+		return nil, fmt.Errorf("unsupported win model version %d", model.Version)
+	}
+	return &model, nil
+}
+
+// Save writes the model as JSON.
+func (m *Model) Save(path string) error {
+	if err := storage.WriteJSON(path, m); err != nil {
+		return fmt.Errorf("failed to write win model: %w", err)
+	}
+	return nil
+}
+
+func sigmoid(z float64) float64 {
+	return 1 / (1 + math.Exp(-z))
+}
+
+func dot(a, b [3]float64) float64 {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
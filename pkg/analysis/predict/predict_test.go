@@ -0,0 +1,122 @@
+package predict
+
+import (
+	"math"
+	"math/rand/v2"
+	"path/filepath"
+	"testing"
+)
+
+// syntheticBattles generates battles where the side with higher card
+// levels usually wins and Beatdown beats Siege
+func syntheticBattles(n int) []Example {
+	rng := rand.New(rand.NewPCG(1, 2))
+	examples := make([]Example, 0, n)
+	for range n {
+		f := Features{
+			LevelDelta:        rng.Float64()*4 - 2,
+			TrophyDelta:       rng.Float64()*400 - 200,
+			Archetype:         "Beatdown",
+			OpponentArchetype: "Siege",
+		}
+		if rng.IntN(2) == 0 {
+			f = f.Mirror()
+		}
+		logit := 1.5 * f.LevelDelta
+		if f.Archetype == "Beatdown" {
+			logit += 0.8
+		} else {
+			logit -= 0.8
+		}
+		examples = append(examples, Example{Features: f, Won: rng.Float64() < 1/(1+math.Exp(-logit))})
+	}
+	return examples
+}
+
+func TestTrainLearnsLevelAndMatchup(t *testing.T) {
+	model, err := Train(syntheticBattles(2000), DefaultTrainOptions())
+	if err != nil {
+		t.Fatalf("Train() error = %v", err)
+	}
+	if model.Weights.LevelDelta < 0.5 {
+		t.Errorf("LevelDelta weight = %.2f, want clearly positive", model.Weights.LevelDelta)
+	}
+	if model.Weights.Matchup <= 0 {
+		t.Errorf("Matchup weight = %.2f, want positive", model.Weights.Matchup)
+	}
+	if model.Accuracy < 0.6 {
+		t.Errorf("Accuracy = %.2f, want at least 0.6", model.Accuracy)
+	}
+
+	even := Features{Archetype: "Beatdown", OpponentArchetype: "Siege"}
+	if p := model.Predict(even); p <= 0.5 {
+		t.Errorf("Predict(Beatdown vs Siege) = %.2f, want above 0.5", p)
+	}
+	ahead := even
+	ahead.LevelDelta = 1
+	if model.Predict(ahead) <= model.Predict(even) {
+		t.Error("higher card levels should raise the win probability")
+	}
+
+	record := model.Matchup("Beatdown", "Siege")
+	reverse := model.Matchup("Siege", "Beatdown")
+	if record.Games != 2000 || reverse.Games != 2000 || record.Wins+reverse.Wins != 2000 {
+		t.Errorf("matchup records = %+v / %+v, want both directions of 2000 games", record, reverse)
+	}
+}
+
+func TestPredictIsSymmetric(t *testing.T) {
+	model, err := Train(syntheticBattles(500), DefaultTrainOptions())
+	if err != nil {
+		t.Fatalf("Train() error = %v", err)
+	}
+	f := Features{LevelDelta: 0.7, TrophyDelta: 120, Archetype: "Siege", OpponentArchetype: "Beatdown"}
+	if sum := model.Predict(f) + model.Predict(f.Mirror()); math.Abs(sum-1) > 1e-9 {
+		t.Errorf("Predict(f) + Predict(mirror) = %v, want 1", sum)
+	}
+}
+
+func TestPredictUnknownMatchup(t *testing.T) {
+	model, err := Train(syntheticBattles(200), DefaultTrainOptions())
+	if err != nil {
+		t.Fatalf("Train() error = %v", err)
+	}
+	if p := model.Predict(Features{Archetype: "Cycle", OpponentArchetype: "Bait"}); math.Abs(p-0.5) > 1e-9 {
+		t.Errorf("Predict(unknown matchup, even levels) = %v, want 0.5", p)
+	}
+}
+
+func TestTrainErrors(t *testing.T) {
+	if _, err := Train(nil, DefaultTrainOptions()); err == nil {
+		t.Error("Train(nil) should fail")
+	}
+	if _, err := Train(syntheticBattles(10), TrainOptions{}); err == nil {
+		t.Error("Train() with zero iterations should fail")
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	model, err := Train(syntheticBattles(300), DefaultTrainOptions())
+	if err != nil {
+		t.Fatalf("Train() error = %v", err)
+	}
+	path := filepath.Join(t.TempDir(), ModelFile)
+	if err := model.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	f := Features{LevelDelta: -0.4, TrophyDelta: 50, Archetype: "Beatdown", OpponentArchetype: "Siege"}
+	if got, want := loaded.Predict(f), model.Predict(f); math.Abs(got-want) > 1e-12 {
+		t.Errorf("loaded Predict() = %v, want %v", got, want)
+	}
+	if loaded.Battles != 300 {
+		t.Errorf("Battles = %d, want 300", loaded.Battles)
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Load() of a missing file should fail")
+	}
+}
@@ -21,6 +21,11 @@ type CardAnalysis struct {
 	RarityBreakdown map[string]RarityStats   `json:"rarity_breakdown"`
 	UpgradePriority []UpgradePriority        `json:"upgrade_priority"`
 	Summary         CollectionSummary        `json:"summary"`
+	TowerTroops     []string                 `json:"tower_troops,omitempty"`
+	ArenaID         int                      `json:"arena_id,omitempty"`
+	// MagicItemAdvice says where to spend wild cards and books, when the
+	// player's inventory was given
+	MagicItemAdvice []MagicItemUse `json:"magic_item_advice,omitempty"`
 }
 
 // CardLevelInfo provides detailed information about a single card's level and upgrade status
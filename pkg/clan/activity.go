@@ -0,0 +1,188 @@
+// Package clan aggregates clan member data — donations, activity, river race
+// participation and trophy trends — into per-member reports for clan leaders.
+package clan
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+// Suggestions attached to members whose activity stands out.
+const (
+	SuggestPromote = "promote"
+	SuggestKick    = "kick"
+)
+
+// ActivityOptions controls the thresholds behind promote/kick suggestions.
+type ActivityOptions struct {
+	// InactiveDays marks a member as a kick candidate when last seen longer ago
+	InactiveDays float64
+	// PromoteDonations is the weekly donation count for a promote suggestion
+	PromoteDonations int
+	// PromoteWarDecks is the river race decks used for a promote suggestion
+	PromoteWarDecks int
+}
+
+// DefaultActivityOptions returns the default suggestion thresholds.
+func DefaultActivityOptions() ActivityOptions {
+	return ActivityOptions{
+		InactiveDays:     7,
+		PromoteDonations: 300,
+		PromoteWarDecks:  12,
+	}
+}
+
+// TrophyTrend is the trophy change for a member over the trend window.
+type TrophyTrend struct {
+	Change  int `json:"change"`
+	Samples int `json:"samples"`
+}
+
+// MemberActivity is one member's activity summary.
+type MemberActivity struct {
+	Tag               string       `json:"tag"`
+	Name              string       `json:"name"`
+	Role              string       `json:"role"`
+	Trophies          int          `json:"trophies"`
+	Donations         int          `json:"donations"`
+	DonationsReceived int          `json:"donations_received"`
+	DonationRatio     float64      `json:"donation_ratio"`
+	LastSeen          time.Time    `json:"last_seen"`
+	DaysInactive      float64      `json:"days_inactive"`
+	WarFame           int          `json:"war_fame"`
+	WarDecksUsed      int          `json:"war_decks_used"`
+	TrophyTrend       *TrophyTrend `json:"trophy_trend,omitempty"`
+	Suggestion        string       `json:"suggestion,omitempty"`
+}
+
+// BuildActivity joins the member list with river race participation and
+// trophy trends (keyed by normalized tag, optional) into activity rows in
+// member-list order.
+func BuildActivity(
+	members []clashroyale.Member,
+	race *clashroyale.CurrentRiverRace,
+	trends map[string]TrophyTrend,
+	now time.Time,
+	opts ActivityOptions,
+) []MemberActivity {
+	participants := make(map[string]clashroyale.RiverRaceParticipant)
+	if race != nil {
+		for _, participant := range race.Clan.Participants {
+			participants[participant.Tag] = participant
+		}
+	}
+
+	rows := make([]MemberActivity, 0, len(members))
+	for _, member := range members {
+		participant := participants[member.Tag]
+		row := MemberActivity{
+			Tag:               member.Tag,
+			Name:              member.Name,
+			Role:              member.Role,
+			Trophies:          member.Trophies,
+			Donations:         member.Donations,
+			DonationsReceived: member.DonationsReceived,
+			DonationRatio:     donationRatio(member.Donations, member.DonationsReceived),
+			WarFame:           participant.Fame,
+			WarDecksUsed:      participant.DecksUsed,
+		}
+		if lastSeen := member.LastSeenTime(); !lastSeen.IsZero() {
+			row.LastSeen = lastSeen
+			row.DaysInactive = max(now.Sub(lastSeen).Hours()/24, 0)
+		}
+		if trend, ok := trends[clashroyale.NormalizeTag(member.Tag)]; ok {
+			row.TrophyTrend = &trend
+		}
+		row.Suggestion = suggest(row, opts)
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// donationRatio is donations given per donation received; members who only
+// give report the donations themselves.
+func donationRatio(given, received int) float64 {
+	if received == 0 {
+		return float64(given)
+	}
+	return float64(given) / float64(received)
+}
+
+// suggest flags long-inactive members and war-skipping non-donors as kick
+// candidates, and active, generous war participants who are still plain
+// members as promote candidates.
+func suggest(row MemberActivity, opts ActivityOptions) string {
+	if !row.LastSeen.IsZero() && row.DaysInactive >= opts.InactiveDays {
+		return SuggestKick
+	}
+	if row.Donations == 0 && row.WarDecksUsed == 0 {
+		return SuggestKick
+	}
+	if strings.EqualFold(row.Role, "member") &&
+		row.Donations >= opts.PromoteDonations && row.WarDecksUsed >= opts.PromoteWarDecks {
+		return SuggestPromote
+	}
+	return ""
+}
+
+// SortKeys lists the columns SortActivity accepts.
+var SortKeys = []string{"donations", "received", "ratio", "last-seen", "trophies", "trend", "fame", "war-decks", "name", "role"}
+
+var roleRank = map[string]int{"leader": 0, "coLeader": 1, "elder": 2, "member": 3}
+
+// SortActivity sorts rows in place by key. Numeric columns sort highest first
+// and last-seen sorts most recent first; reverse flips the order. Ties keep
+// member-list order.
+func SortActivity(rows []MemberActivity, key string, reverse bool) error {
+	var less func(a, b MemberActivity) bool
+	switch key {
+	case "donations":
+		less = func(a, b MemberActivity) bool { return a.Donations > b.Donations }
+	case "received":
+		less = func(a, b MemberActivity) bool { return a.DonationsReceived > b.DonationsReceived }
+	case "ratio":
+		less = func(a, b MemberActivity) bool { return a.DonationRatio > b.DonationRatio }
+	case "last-seen":
+		less = func(a, b MemberActivity) bool { return a.LastSeen.After(b.LastSeen) }
+	case "trophies":
+		less = func(a, b MemberActivity) bool { return a.Trophies > b.Trophies }
+	case "trend":
+		less = func(a, b MemberActivity) bool { return trendChange(a) > trendChange(b) }
+	case "fame":
+		less = func(a, b MemberActivity) bool { return a.WarFame > b.WarFame }
+	case "war-decks":
+		less = func(a, b MemberActivity) bool { return a.WarDecksUsed > b.WarDecksUsed }
+	case "name":
+		less = func(a, b MemberActivity) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) }
+	case "role":
+		less = func(a, b MemberActivity) bool { return rankOfRole(a.Role) < rankOfRole(b.Role) }
+	default:
+		return fmt.Errorf("unknown sort key %q (supported: %s)", key, strings.Join(SortKeys, ", "))
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if reverse {
+			return less(rows[j], rows[i])
+		}
+		return less(rows[i], rows[j])
+	})
+	return nil
+}
+
+func trendChange(row MemberActivity) int {
+	if row.TrophyTrend == nil {
+		return 0
+	}
+	return row.TrophyTrend.Change
+}
+
+func rankOfRole(role string) int {
+	if rank, ok := roleRank[role]; ok {
+		return rank
+	}
+	return len(roleRank)
+}
@@ -0,0 +1,88 @@
+package clan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+func testMembers() []clashroyale.Member {
+	return []clashroyale.Member{
+		{Tag: "#A", Name: "Alpha", Role: "leader", Trophies: 7000, Donations: 120, DonationsReceived: 60, LastSeen: "20261017T100000.000Z"},
+		{Tag: "#B", Name: "bravo", Role: "member", Trophies: 6500, Donations: 400, DonationsReceived: 0, LastSeen: "20261017T110000.000Z"},
+		{Tag: "#C", Name: "Charlie", Role: "elder", Trophies: 6800, Donations: 0, DonationsReceived: 40, LastSeen: "20261001T110000.000Z"},
+		{Tag: "#D", Name: "Delta", Role: "member", Trophies: 5000, LastSeen: "bad"},
+	}
+}
+
+func TestBuildActivity(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	race := &clashroyale.CurrentRiverRace{Clan: clashroyale.RiverRaceClan{
+		Participants: []clashroyale.RiverRaceParticipant{
+			{Tag: "#A", Fame: 1600, DecksUsed: 8},
+			{Tag: "#B", Fame: 2400, DecksUsed: 16},
+			{Tag: "#C", Fame: 200, DecksUsed: 2},
+		},
+	}}
+	trends := map[string]TrophyTrend{"#A": {Change: 120, Samples: 5}}
+
+	rows := BuildActivity(testMembers(), race, trends, now, DefaultActivityOptions())
+	if len(rows) != 4 {
+		t.Fatalf("got %d rows, want 4", len(rows))
+	}
+
+	alpha := rows[0]
+	if alpha.DonationRatio != 2 || alpha.WarFame != 1600 || alpha.WarDecksUsed != 8 {
+		t.Errorf("alpha = %+v", alpha)
+	}
+	if alpha.DaysInactive < 0.08 || alpha.DaysInactive > 0.09 {
+		t.Errorf("alpha days inactive = %.3f, want ~2h", alpha.DaysInactive)
+	}
+	if alpha.TrophyTrend == nil || alpha.TrophyTrend.Change != 120 {
+		t.Errorf("alpha trend = %+v, want +120", alpha.TrophyTrend)
+	}
+
+	wantSuggestions := []string{"", SuggestPromote, SuggestKick, SuggestKick}
+	for i, want := range wantSuggestions {
+		if rows[i].Suggestion != want {
+			t.Errorf("%s suggestion = %q, want %q", rows[i].Name, rows[i].Suggestion, want)
+		}
+	}
+	if !rows[3].LastSeen.IsZero() || rows[3].DaysInactive != 0 {
+		t.Errorf("malformed last seen should be left empty: %+v", rows[3])
+	}
+}
+
+func TestSortActivity(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	rows := BuildActivity(testMembers(), nil, nil, now, DefaultActivityOptions())
+
+	tests := []struct {
+		key     string
+		reverse bool
+		want    []string
+	}{
+		{"donations", false, []string{"bravo", "Alpha", "Charlie", "Delta"}},
+		{"donations", true, []string{"Charlie", "Delta", "Alpha", "bravo"}},
+		{"trophies", false, []string{"Alpha", "Charlie", "bravo", "Delta"}},
+		{"last-seen", false, []string{"bravo", "Alpha", "Charlie", "Delta"}},
+		{"name", false, []string{"Alpha", "bravo", "Charlie", "Delta"}},
+		{"role", false, []string{"Alpha", "Charlie", "bravo", "Delta"}},
+	}
+	for _, tt := range tests {
+		sorted := append([]MemberActivity(nil), rows...)
+		if err := SortActivity(sorted, tt.key, tt.reverse); err != nil {
+			t.Fatalf("SortActivity(%s) error = %v", tt.key, err)
+		}
+		for i, name := range tt.want {
+			if sorted[i].Name != name {
+				t.Errorf("SortActivity(%s, reverse=%v)[%d] = %s, want %s", tt.key, tt.reverse, i, sorted[i].Name, name)
+			}
+		}
+	}
+
+	if err := SortActivity(rows, "karma", false); err == nil {
+		t.Error("expected error for unknown sort key")
+	}
+}
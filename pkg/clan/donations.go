@@ -0,0 +1,147 @@
+package clan
+
+import (
+	"sort"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/pkg/analysis"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+// donatableRarities are the rarities clanmates can request and donate.
+var donatableRarities = map[string]bool{"Common": true, "Rare": true}
+
+// CardNeed is how many copies one member still needs for a card's next level.
+type CardNeed struct {
+	Tag    string `json:"tag"`
+	Name   string `json:"name"`
+	Level  int    `json:"level"`
+	Needed int    `json:"needed"`
+	InDeck bool   `json:"in_deck"`
+}
+
+// CardDemand is every member who plays a card and still needs copies of it,
+// neediest first.
+type CardDemand struct {
+	Card        string     `json:"card"`
+	Rarity      string     `json:"rarity"`
+	TotalNeeded int        `json:"total_needed"`
+	Members     []CardNeed `json:"members"`
+}
+
+// RequestSuggestion is the card a member should request next.
+type RequestSuggestion struct {
+	Tag    string `json:"tag"`
+	Name   string `json:"name"`
+	Card   string `json:"card"`
+	Rarity string `json:"rarity"`
+	Needed int    `json:"needed"`
+	InDeck bool   `json:"in_deck"`
+}
+
+// DonationGuide routes the clan's common and rare donations.
+type DonationGuide struct {
+	Members  int                 `json:"members"`
+	Requests []RequestSuggestion `json:"requests"`
+	Demand   []CardDemand        `json:"demand"`
+	// Unrequested lists commons and rares owned in the clan that no member
+	// plays and still needs, so copies of them can be donated freely
+	Unrequested []string `json:"unrequested"`
+}
+
+// BuildDonationGuide works out which commons and rares each member needs.
+// Demand counts only cards in a member's current deck, since those are the
+// ones worth requesting; each member's request suggestion prefers a deck
+// card and falls back to the collection card needing the most copies.
+// Members whose profiles are nil are skipped.
+func BuildDonationGuide(players []*clashroyale.Player) *DonationGuide {
+	guide := &DonationGuide{}
+	demand := make(map[string]*CardDemand)
+	owned := make(map[string]bool)
+
+	for _, player := range players {
+		if player == nil {
+			continue
+		}
+		guide.Members++
+		inDeck := make(map[string]bool, len(player.CurrentDeck))
+		for _, card := range player.CurrentDeck {
+			inDeck[card.Name] = true
+		}
+
+		var best *RequestSuggestion
+		for _, card := range player.Cards {
+			rarity := config.NormalizeRarity(card.Rarity)
+			if !donatableRarities[rarity] {
+				continue
+			}
+			owned[card.Name] = true
+			needed := cardsStillNeeded(card, rarity)
+			if needed <= 0 {
+				continue
+			}
+			need := CardNeed{Tag: player.Tag, Name: player.Name, Level: card.Level, Needed: needed, InDeck: inDeck[card.Name]}
+
+			if need.InDeck {
+				d, ok := demand[card.Name]
+				if !ok {
+					d = &CardDemand{Card: card.Name, Rarity: rarity}
+					demand[card.Name] = d
+				}
+				d.Members = append(d.Members, need)
+				d.TotalNeeded += needed
+			}
+
+			candidate := RequestSuggestion{Tag: player.Tag, Name: player.Name, Card: card.Name, Rarity: rarity, Needed: needed, InDeck: need.InDeck}
+			if best == nil || betterRequest(candidate, *best) {
+				best = &candidate
+			}
+		}
+		if best != nil {
+			guide.Requests = append(guide.Requests, *best)
+		}
+	}
+
+	for name, d := range demand {
+		sort.SliceStable(d.Members, func(i, j int) bool {
+			return d.Members[i].Needed > d.Members[j].Needed
+		})
+		guide.Demand = append(guide.Demand, *d)
+		delete(owned, name)
+	}
+	sort.Slice(guide.Demand, func(i, j int) bool {
+		a, b := guide.Demand[i], guide.Demand[j]
+		if len(a.Members) != len(b.Members) {
+			return len(a.Members) > len(b.Members)
+		}
+		if a.TotalNeeded != b.TotalNeeded {
+			return a.TotalNeeded > b.TotalNeeded
+		}
+		return a.Card < b.Card
+	})
+
+	for name := range owned {
+		guide.Unrequested = append(guide.Unrequested, name)
+	}
+	sort.Strings(guide.Unrequested)
+	return guide
+}
+
+// cardsStillNeeded is the copies a card lacks for its next level, or 0 when
+// it is maxed or ready to upgrade
+func cardsStillNeeded(card clashroyale.Card, rarity string) int {
+	// The API reports levels relative to the rarity's starting level
+	level := card.Level + config.GetStartingLevel(rarity) - 1
+	return analysis.CalculateCardsNeeded(level, rarity) - card.Count
+}
+
+// betterRequest prefers deck cards, then the card needing more copies
+func betterRequest(a, b RequestSuggestion) bool {
+	if a.InDeck != b.InDeck {
+		return a.InDeck
+	}
+	if a.Needed != b.Needed {
+		return a.Needed > b.Needed
+	}
+	return a.Card < b.Card
+}
@@ -0,0 +1,70 @@
+package clan
+
+import (
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+func TestBuildDonationGuide(t *testing.T) {
+	// Common level 11 needs 2000 copies; Rare API level 8 (level 10) needs 300
+	alice := &clashroyale.Player{
+		Tag: "#AAA", Name: "Alice",
+		CurrentDeck: []clashroyale.Card{{Name: "Knight"}, {Name: "Musketeer"}},
+		Cards: []clashroyale.Card{
+			{Name: "Knight", Rarity: "common", Level: 11, Count: 500},
+			{Name: "Musketeer", Rarity: "rare", Level: 8, Count: 250},
+			{Name: "Archers", Rarity: "common", Level: 11, Count: 0},
+			{Name: "Golem", Rarity: "epic", Level: 5, Count: 0},
+		},
+	}
+	bob := &clashroyale.Player{
+		Tag: "#BBB", Name: "Bob",
+		CurrentDeck: []clashroyale.Card{{Name: "Knight"}},
+		Cards: []clashroyale.Card{
+			{Name: "Knight", Rarity: "common", Level: 11, Count: 1900},
+			// Ready to upgrade, so not needed
+			{Name: "Zap", Rarity: "common", Level: 11, Count: 2500},
+		},
+	}
+	guide := BuildDonationGuide([]*clashroyale.Player{alice, nil, bob})
+
+	if guide.Members != 2 {
+		t.Errorf("members = %d, want 2", guide.Members)
+	}
+
+	if len(guide.Demand) != 2 {
+		t.Fatalf("demand = %+v", guide.Demand)
+	}
+	knight := guide.Demand[0]
+	if knight.Card != "Knight" || len(knight.Members) != 2 || knight.TotalNeeded != 1600 {
+		t.Errorf("knight demand = %+v", knight)
+	}
+	if knight.Members[0].Tag != "#AAA" || knight.Members[0].Needed != 1500 {
+		t.Errorf("neediest knight member = %+v, want Alice needing 1500", knight.Members[0])
+	}
+	if m := guide.Demand[1]; m.Card != "Musketeer" || m.Rarity != "Rare" || m.TotalNeeded != 50 {
+		t.Errorf("musketeer demand = %+v", m)
+	}
+
+	// Alice's deck cards beat Archers despite Archers needing more copies
+	if len(guide.Requests) != 2 {
+		t.Fatalf("requests = %+v", guide.Requests)
+	}
+	if r := guide.Requests[0]; r.Tag != "#AAA" || r.Card != "Knight" || !r.InDeck {
+		t.Errorf("alice request = %+v", r)
+	}
+	if r := guide.Requests[1]; r.Tag != "#BBB" || r.Card != "Knight" || r.Needed != 100 {
+		t.Errorf("bob request = %+v", r)
+	}
+
+	want := []string{"Archers", "Zap"}
+	if len(guide.Unrequested) != len(want) {
+		t.Fatalf("unrequested = %v, want %v", guide.Unrequested, want)
+	}
+	for i, name := range want {
+		if guide.Unrequested[i] != name {
+			t.Errorf("unrequested = %v, want %v", guide.Unrequested, want)
+		}
+	}
+}
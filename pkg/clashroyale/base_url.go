@@ -0,0 +1,64 @@
+package clashroyale
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	// DefaultBaseURL is the official Clash Royale API
+	DefaultBaseURL = "https://api.clashroyale.com/v1"
+
+	// RoyaleAPIProxyBaseURL is the RoyaleAPI proxy. It forwards requests
+	// unchanged, with the same bearer token, from RoyaleAPIProxyIP, so a
+	// token that allows that address works from any machine.
+	RoyaleAPIProxyBaseURL = "https://proxy.royaleapi.dev/v1"
+
+	// RoyaleAPIProxyIP is the address to allow when creating a token for
+	// the proxy on the developer portal
+	RoyaleAPIProxyIP = "45.79.218.79"
+)
+
+// ResolveBaseURL maps an --api-base value to a base URL: empty or
+// "official" is the official API, "royaleapi" (or "proxy") is the RoyaleAPI
+// proxy, and anything else must be an http(s) URL.
+func ResolveBaseURL(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	switch strings.ToLower(value) {
+	case "", "official":
+		return DefaultBaseURL, nil
+	case "royaleapi", "proxy":
+		return RoyaleAPIProxyBaseURL, nil
+	}
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("invalid API base %q: use official, royaleapi, or an http(s) URL such as %s", value, RoyaleAPIProxyBaseURL)
+	}
+	return strings.TrimRight(value, "/"), nil
+}
+
+// SetBaseURL points the client at another API host, such as the RoyaleAPI
+// proxy. Endpoints are appended to it, so it includes the /v1 prefix.
+func (c *Client) SetBaseURL(baseURL string) {
+	c.baseURL = strings.TrimRight(baseURL, "/")
+}
+
+// BaseURL returns the API base URL requests are sent to
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// usesRoyaleAPIProxy reports whether requests go through the RoyaleAPI proxy
+func (c *Client) usesRoyaleAPIProxy() bool {
+	return strings.HasPrefix(c.baseURL, "https://proxy.royaleapi.dev")
+}
+
+// proxyAccessError explains a 403 from the RoyaleAPI proxy, which almost
+// always means the token does not allow the proxy's address.
+func (c *Client) proxyAccessError(apiErr APIError) error {
+	if apiErr.StatusCode != 403 || !c.usesRoyaleAPIProxy() {
+		return apiErr
+	}
+	return fmt.Errorf("%w (the RoyaleAPI proxy needs a token that allows IP %s)", apiErr, RoyaleAPIProxyIP)
+}
@@ -0,0 +1,137 @@
+package clashroyale
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultBulkWorkers is the number of concurrent requests GetPlayers and
+// GetBattleLogs use when BulkOptions.Workers is unset. The client's rate
+// limit still applies, so more workers mainly hide request latency, or
+// spread load across a token pool.
+const DefaultBulkWorkers = 4
+
+// BulkOptions controls a bulk fetch.
+type BulkOptions struct {
+	// Workers is the number of concurrent requests (default
+	// DefaultBulkWorkers)
+	Workers int
+	// OnResult, when set, is called after each tag finishes, one call at a
+	// time. done counts finished tags.
+	OnResult func(done, total int, tag string, err error)
+}
+
+// PlayerResult is one tag's outcome from GetPlayers.
+type PlayerResult struct {
+	Tag    string
+	Player *Player
+	Err    error
+}
+
+// BattleLogResult is one tag's outcome from GetBattleLogs.
+type BattleLogResult struct {
+	Tag     string
+	Battles BattleLogResponse
+	Err     error
+}
+
+// GetPlayers fetches many players concurrently. Results are in the order of
+// tags and carry their own errors, so one bad tag does not fail the rest.
+// The returned error is only set when ctx ends; tags that were not fetched
+// then carry the context error.
+func (c *Client) GetPlayers(ctx context.Context, tags []string, opts BulkOptions) ([]PlayerResult, error) {
+	return getPlayers(ctx, tags, opts, c.GetPlayerWithContext)
+}
+
+// GetBattleLogs fetches many battle logs concurrently, with the same
+// ordering and error handling as GetPlayers.
+func (c *Client) GetBattleLogs(ctx context.Context, tags []string, opts BulkOptions) ([]BattleLogResult, error) {
+	return getBattleLogs(ctx, tags, opts, c.GetPlayerBattleLogWithContext)
+}
+
+func getPlayers(
+	ctx context.Context,
+	tags []string,
+	opts BulkOptions,
+	fetch func(context.Context, string) (*Player, error),
+) ([]PlayerResult, error) {
+	players, errs, err := fetchBulk(ctx, tags, opts, fetch)
+	results := make([]PlayerResult, len(tags))
+	for i, tag := range tags {
+		results[i] = PlayerResult{Tag: tag, Player: players[i], Err: errs[i]}
+	}
+	return results, err
+}
+
+func getBattleLogs(
+	ctx context.Context,
+	tags []string,
+	opts BulkOptions,
+	fetch func(context.Context, string) (*BattleLogResponse, error),
+) ([]BattleLogResult, error) {
+	logs, errs, err := fetchBulk(ctx, tags, opts, fetch)
+	results := make([]BattleLogResult, len(tags))
+	for i, tag := range tags {
+		results[i] = BattleLogResult{Tag: tag, Err: errs[i]}
+		if logs[i] != nil {
+			results[i].Battles = *logs[i]
+		}
+	}
+	return results, err
+}
+
+// fetchBulk runs fetch for every tag on a worker pool and returns values and
+// errors indexed like tags.
+func fetchBulk[T any](
+	ctx context.Context,
+	tags []string,
+	opts BulkOptions,
+	fetch func(context.Context, string) (*T, error),
+) ([]*T, []error, error) {
+	values := make([]*T, len(tags))
+	errs := make([]error, len(tags))
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = DefaultBulkWorkers
+	}
+	workers = min(workers, len(tags))
+
+	jobs := make(chan int)
+	var mu sync.Mutex
+	done := 0
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Go(func() {
+			for i := range jobs {
+				value, err := fetch(ctx, tags[i])
+				values[i], errs[i] = value, err
+				if opts.OnResult != nil {
+					mu.Lock()
+					done++
+					opts.OnResult(done, len(tags), tags[i], err)
+					mu.Unlock()
+				}
+			}
+		})
+	}
+
+	next := 0
+feed:
+	for ; next < len(tags); next++ {
+		select {
+		case jobs <- next:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		for i := next; i < len(tags); i++ {
+			errs[i] = err
+		}
+		return values, errs, err
+	}
+	return values, errs, nil
+}
@@ -0,0 +1,138 @@
+package clashroyale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/ratelimit"
+)
+
+// bulkServer answers /players/{tag} and /players/{tag}/battlelog, returning
+// 404 for tags starting with "#BAD", and records the most requests it saw
+// in flight at once
+func bulkServer(t *testing.T) (*Client, *int32) {
+	t.Helper()
+	var inFlight, peak int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(&peak)
+			if current <= seen || atomic.CompareAndSwapInt32(&peak, seen, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		path := strings.TrimPrefix(r.URL.Path, "/players/")
+		tag, battleLog := strings.CutSuffix(path, "/battlelog")
+		if strings.HasPrefix(tag, "#BAD") {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"reason":"notFound"}`)
+			return
+		}
+		if battleLog {
+			fmt.Fprintf(w, `[{"type":"PvP","team":[{"tag":%q}]}]`, tag)
+			return
+		}
+		fmt.Fprintf(w, `{"tag":%q,"name":"player %s"}`, tag, tag)
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient("token")
+	client.baseURL = server.URL
+	client.rateLimiter = ratelimit.NewUnlimited()
+	return client, &peak
+}
+
+func TestGetPlayers(t *testing.T) {
+	client, peak := bulkServer(t)
+	tags := []string{"#AAA", "#BAD1", "#CCC", "#DDD", "#EEE", "#FFF"}
+
+	var mu sync.Mutex
+	var calls []int
+	results, err := client.GetPlayers(context.Background(), tags, BulkOptions{
+		Workers: 3,
+		OnResult: func(done, total int, _ string, _ error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if total != len(tags) {
+				t.Errorf("OnResult total = %d, want %d", total, len(tags))
+			}
+			calls = append(calls, done)
+		},
+	})
+	if err != nil {
+		t.Fatalf("GetPlayers() error = %v", err)
+	}
+	if len(results) != len(tags) {
+		t.Fatalf("got %d results, want %d", len(results), len(tags))
+	}
+	for i, result := range results {
+		if result.Tag != tags[i] {
+			t.Errorf("results[%d].Tag = %s, want input order", i, result.Tag)
+		}
+		if tags[i] == "#BAD1" {
+			var apiErr APIError
+			if !errors.As(result.Err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+				t.Errorf("bad tag error = %v, want a 404 APIError", result.Err)
+			}
+			continue
+		}
+		if result.Err != nil || result.Player == nil || result.Player.Tag != tags[i] {
+			t.Errorf("results[%d] = %+v", i, result)
+		}
+	}
+	if len(calls) != len(tags) || calls[len(calls)-1] != len(tags) {
+		t.Errorf("OnResult done counts = %v", calls)
+	}
+	if got := atomic.LoadInt32(peak); got < 2 || got > 3 {
+		t.Errorf("peak concurrency = %d, want 2-3 with three workers", got)
+	}
+}
+
+func TestGetBattleLogs(t *testing.T) {
+	client, _ := bulkServer(t)
+	results, err := client.GetBattleLogs(context.Background(), []string{"#AAA", "#BAD"}, BulkOptions{})
+	if err != nil {
+		t.Fatalf("GetBattleLogs() error = %v", err)
+	}
+	if results[0].Err != nil || len(results[0].Battles) != 1 || results[0].Battles[0].Type != "PvP" {
+		t.Errorf("results[0] = %+v", results[0])
+	}
+	if results[1].Err == nil || results[1].Battles != nil {
+		t.Errorf("results[1] = %+v, want an error and no battles", results[1])
+	}
+}
+
+func TestGetPlayersCanceled(t *testing.T) {
+	client, _ := bulkServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := client.GetPlayers(ctx, []string{"#AAA", "#BBB"}, BulkOptions{Workers: 1})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("GetPlayers() error = %v, want context.Canceled", err)
+	}
+	for _, result := range results {
+		if result.Err == nil {
+			t.Errorf("result %s has no error after cancel", result.Tag)
+		}
+	}
+}
+
+func TestGetPlayersEmpty(t *testing.T) {
+	client, _ := bulkServer(t)
+	results, err := client.GetPlayers(context.Background(), nil, BulkOptions{})
+	if err != nil || len(results) != 0 {
+		t.Errorf("GetPlayers(nil) = %v, %v", results, err)
+	}
+}
@@ -0,0 +1,24 @@
+package clashroyale
+
+// ResponseCache stores raw API response bodies keyed by request endpoint.
+//
+// When a cache is attached to a Client, GET endpoints consult it before
+// hitting the network and successful responses are offered back via Put.
+// Implementations decide freshness and whether writes are persisted.
+type ResponseCache interface {
+	// Get returns the cached body for endpoint and whether it was usable.
+	Get(endpoint string) ([]byte, bool)
+	// Put records a successful response body for endpoint.
+	Put(endpoint string, body []byte) error
+}
+
+// SetCache attaches a response cache to the client. Passing nil disables caching.
+func (c *Client) SetCache(cache ResponseCache) {
+	c.cache = cache
+}
+
+// RequestCount returns the number of requests sent to the API by this client,
+// excluding responses served from the cache. Retries are not counted separately.
+func (c *Client) RequestCount() int64 {
+	return c.requests.Load()
+}
@@ -3,9 +3,11 @@ package clashroyale
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/klauer/clash-royale-api/go/internal/closeutil"
@@ -18,6 +20,24 @@ type Client struct {
 	apiToken    string
 	rateLimiter ratelimit.Limiter
 	baseURL     string
+	cache       ResponseCache
+	requests    atomic.Int64
+	observer    RequestObserver
+	// pool rotates across several tokens; nil for a single token
+	pool *tokenPool
+	// offline fails every request that the cache cannot answer
+	offline bool
+	// unknownFields is told about response fields the models lack
+	unknownFields UnknownFieldHandler
+}
+
+// RequestObserver is called after every HTTP attempt, including retries.
+// status is 0 when the request failed before a response arrived.
+type RequestObserver func(status int, duration time.Duration)
+
+// SetRequestObserver installs a hook for request metrics. Passing nil removes it.
+func (c *Client) SetRequestObserver(observer RequestObserver) {
+	c.observer = observer
 }
 
 // NewClient creates a new Clash Royale API client
@@ -30,7 +50,7 @@ func NewClient(apiToken string) *Client {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		baseURL: "https://api.clashroyale.com/v1",
+		baseURL: DefaultBaseURL,
 	}
 }
 
@@ -60,18 +80,36 @@ func (c *Client) NewRequest(ctx context.Context, method, endpoint string) (*http
 	return req, nil
 }
 
+// ErrOffline is returned for requests an offline client cannot serve from
+// its cache
+var ErrOffline = errors.New("offline: no cached response")
+
+// SetOffline stops the client from contacting the API. Requests are served
+// only by the attached cache; anything else fails with ErrOffline.
+func (c *Client) SetOffline(offline bool) {
+	c.offline = offline
+}
+
 // Do performs an HTTP request with retry logic and rate limiting
 func (c *Client) Do(req *http.Request) (*http.Response, error) {
-	// Rate limit the request
-	c.rateLimiter.Take()
+	if c.offline {
+		return nil, fmt.Errorf("%w for %s", ErrOffline, req.URL.Path)
+	}
+	// Rate limit the request; pooled tokens are limited one by one
+	if c.pool == nil {
+		c.rateLimiter.Take()
+	}
+	c.requests.Add(1)
 
 	var resp *http.Response
 	var err error
 	var lastRetryErr error
+	// rotated skips the backoff when a 429 moved the retry to another token
+	rotated := false
 
 	// Simple retry loop
 	for attempt := range 3 {
-		if attempt > 0 {
+		if attempt > 0 && !rotated {
 			select {
 			case <-req.Context().Done():
 				return nil, req.Context().Err()
@@ -79,20 +117,36 @@ func (c *Client) Do(req *http.Request) (*http.Response, error) {
 			}
 		}
 
-		// Clone the request for each attempt
-		reqClone := req.Clone(req.Context())
-		resp, err = c.httpClient.Do(reqClone)
+		rotated = false
+		var token *pooledToken
+		if c.pool != nil {
+			resp, token, err = c.sendPooled(req)
+			if err != nil && (errors.Is(err, ErrNoActiveTokens) || req.Context().Err() != nil) {
+				return nil, err
+			}
+		} else {
+			// Clone the request for each attempt
+			resp, err = c.send(req.Clone(req.Context()))
+		}
+		if errors.Is(err, ErrNotRecorded) {
+			return nil, err
+		}
 		if err != nil {
 			continue // Network error, retry
 		}
 
 		// Check for rate limit (429) or server errors (5xx) - retry these
 		if resp.StatusCode == 429 || (resp.StatusCode >= 500 && resp.StatusCode < 600) {
-			lastRetryErr = fmt.Errorf("retryable response status %d", resp.StatusCode)
 			if resp.StatusCode == 429 {
 				delay := retryAfterDelay(resp, attempt)
-				closeutil.WithLog("clashroyale", resp.Body, "response body")
+				lastRetryErr = &RateLimitError{APIError: parseAPIError(resp), RetryAfter: delay}
 				resp = nil
+				if token != nil {
+					// Only this token backs off; the next attempt uses another
+					c.pool.throttle(token, delay)
+					rotated = true
+					continue
+				}
 				select {
 				case <-req.Context().Done():
 					return nil, req.Context().Err()
@@ -100,14 +154,18 @@ func (c *Client) Do(req *http.Request) (*http.Response, error) {
 				}
 				continue
 			}
-			closeutil.WithLog("clashroyale", resp.Body, "response body")
+			apiErr := parseAPIError(resp)
 			resp = nil
+			if apiErr.inMaintenance() {
+				return nil, apiErr
+			}
+			lastRetryErr = apiErr
 			continue
 		}
 
 		// Check for client errors (4xx except 429) - don't retry these
 		if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != 429 {
-			return nil, parseAPIError(resp)
+			return nil, c.proxyAccessError(parseAPIError(resp))
 		}
 
 		// Success or other status code
@@ -124,6 +182,20 @@ func (c *Client) Do(req *http.Request) (*http.Response, error) {
 	return nil, fmt.Errorf("max retries exceeded")
 }
 
+// send performs one HTTP attempt and reports it to the observer
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	started := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if c.observer != nil {
+		status := 0
+		if err == nil {
+			status = resp.StatusCode
+		}
+		c.observer(status, time.Since(started))
+	}
+	return resp, err
+}
+
 func retryAfterDelay(resp *http.Response, attempt int) time.Duration {
 	retryAfter := resp.Header.Get("Retry-After")
 	if retryAfter == "" {
@@ -3,9 +3,11 @@ package clashroyale
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
@@ -44,6 +46,7 @@ func TestNormalizeTag(t *testing.T) {
 		{"2P0LYQ", "#2P0LYQ"},
 		{"#2P0LYQ", "#2P0LYQ"},
 		{"ABCDEF1234", "#ABCDEF1234"},
+		{" 2p0lyq ", "#2P0LYQ"},
 	}
 
 	for _, test := range tests {
@@ -222,6 +225,10 @@ func TestClient_Do_RateLimitRetry(t *testing.T) {
 
 	client := NewClient("test_token")
 	client.baseURL = server.URL
+	var observed []int
+	client.SetRequestObserver(func(status int, _ time.Duration) {
+		observed = append(observed, status)
+	})
 
 	req, err := client.NewRequest(context.Background(), "GET", "/test")
 	if err != nil {
@@ -245,6 +252,9 @@ func TestClient_Do_RateLimitRetry(t *testing.T) {
 	if requestCount != 3 {
 		t.Errorf("Do() made %d requests, want 3", requestCount)
 	}
+	if fmt.Sprint(observed) != "[429 429 200]" {
+		t.Errorf("observer saw %v, want every attempt", observed)
+	}
 
 	// Should have taken some time due to retries (at least 2 seconds)
 	if duration < 2*time.Second {
@@ -632,6 +642,82 @@ func TestGetLocations_Success(t *testing.T) {
 	}
 }
 
+func TestGetCurrentRiverRace_Success(t *testing.T) {
+	mockRace := CurrentRiverRace{
+		State:       "full",
+		PeriodType:  RiverRacePeriodWarDay,
+		PeriodIndex: 3,
+		Clan: RiverRaceClan{
+			Tag:  "#CLAN1",
+			Name: "Test Clan",
+			Fame: 1200,
+			Participants: []RiverRaceParticipant{
+				{Tag: "#P1", Name: "Done", DecksUsedToday: 4, DecksUsed: 12},
+				{Tag: "#P2", Name: "Idle", DecksUsedToday: 1, DecksUsed: 5},
+			},
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/clans/#CLAN1/currentriverrace" {
+			t.Errorf("Expected current river race path, got: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(mockRace)
+	}))
+	defer server.Close()
+
+	client := NewClient("test_token")
+	client.baseURL = server.URL
+
+	race, err := client.GetCurrentRiverRace("CLAN1")
+	if err != nil {
+		t.Fatalf("GetCurrentRiverRace() error = %v", err)
+	}
+
+	if !race.IsBattleDay() {
+		t.Errorf("IsBattleDay() = false for period type %q", race.PeriodType)
+	}
+	if len(race.Clan.Participants) != 2 {
+		t.Fatalf("GetCurrentRiverRace() returned %d participants, want 2", len(race.Clan.Participants))
+	}
+	if got := race.Clan.Participants[0].UnusedDecksToday(); got != 0 {
+		t.Errorf("UnusedDecksToday() for finished member = %d, want 0", got)
+	}
+	if got := race.Clan.Participants[1].UnusedDecksToday(); got != 3 {
+		t.Errorf("UnusedDecksToday() for idle member = %d, want 3", got)
+	}
+}
+
+func TestGetPathOfLegendRankings_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/locations/global/pathoflegend/players" {
+			t.Errorf("Expected rankings path, got: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("limit"); got != "2" {
+			t.Errorf("Expected limit=2, got: %q", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"items":[{"tag":"#AAA","name":"Top","eloRating":3100,"rank":1},{"tag":"#BBB","name":"Second","eloRating":3050,"rank":2}]}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("test_token")
+	client.baseURL = server.URL
+
+	rankings, err := client.GetPathOfLegendRankings(GlobalLocation, 2)
+	if err != nil {
+		t.Fatalf("GetPathOfLegendRankings() error = %v", err)
+	}
+	if len(rankings.Items) != 2 || rankings.Items[0].Tag != "#AAA" || rankings.Items[0].EloRating != 3100 {
+		t.Errorf("GetPathOfLegendRankings() = %+v", rankings.Items)
+	}
+}
+
 func TestJSONDecodeError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -677,3 +763,114 @@ func BenchmarkNewRequest(b *testing.B) {
 		_, _ = client.NewRequest(ctx, "GET", "/players/test123")
 	}
 }
+
+type memoryCache struct {
+	entries map[string][]byte
+}
+
+func (m *memoryCache) Get(endpoint string) ([]byte, bool) {
+	body, ok := m.entries[endpoint]
+	return body, ok
+}
+
+func (m *memoryCache) Put(endpoint string, body []byte) error {
+	m.entries[endpoint] = body
+	return nil
+}
+
+func TestClient_ResponseCache(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"tag": "#ABC", "name": "Live"}`)
+	}))
+	defer server.Close()
+
+	cache := &memoryCache{entries: map[string][]byte{
+		PlayerEndpoint("CACHED"): []byte(`{"tag": "#CACHED", "name": "Cached"}`),
+	}}
+	client := NewClient("test_token")
+	client.baseURL = server.URL
+	client.SetCache(cache)
+
+	player, err := client.GetPlayer("CACHED")
+	if err != nil {
+		t.Fatalf("GetPlayer() error = %v", err)
+	}
+	if player.Name != "Cached" || hits != 0 || client.RequestCount() != 0 {
+		t.Errorf("expected cached response without API call, got %q (hits=%d)", player.Name, hits)
+	}
+
+	if _, err := client.GetPlayer("ABC"); err != nil {
+		t.Fatalf("GetPlayer() error = %v", err)
+	}
+	if hits != 1 || client.RequestCount() != 1 {
+		t.Errorf("expected one API call, hits=%d count=%d", hits, client.RequestCount())
+	}
+	if _, ok := cache.entries[PlayerEndpoint("ABC")]; !ok {
+		t.Error("expected live response to be offered to the cache")
+	}
+}
+
+func TestResolveBaseURL(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "", want: DefaultBaseURL},
+		{input: "official", want: DefaultBaseURL},
+		{input: "RoyaleAPI", want: RoyaleAPIProxyBaseURL},
+		{input: "proxy", want: RoyaleAPIProxyBaseURL},
+		{input: "http://localhost:8080/v1/", want: "http://localhost:8080/v1"},
+		{input: "proxy.royaleapi.dev", wantErr: true},
+		{input: "ftp://example.com", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ResolveBaseURL(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ResolveBaseURL(%q) = %q, %v; want %q, error %v", tt.input, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestClient_ProxyAccessDeniedHint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"reason":"accessDenied.invalidIp","message":"Invalid authorization"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("test_token")
+	client.SetBaseURL(server.URL)
+	if _, err := client.GetPlayer("#ABC"); err == nil || strings.Contains(err.Error(), RoyaleAPIProxyIP) {
+		t.Errorf("official API error = %v, want no proxy hint", err)
+	}
+
+	// Pretend the test server is the proxy
+	client.baseURL = "https://proxy.royaleapi.dev/v1"
+	client.httpClient.Transport = rewriteTransport{target: server.URL}
+	_, err := client.GetPlayer("#ABC")
+	if err == nil || !strings.Contains(err.Error(), RoyaleAPIProxyIP) {
+		t.Fatalf("proxy error = %v, want the proxy IP hint", err)
+	}
+	var apiErr APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Errorf("proxy error = %v, want the 403 APIError", err)
+	}
+}
+
+// rewriteTransport sends every request to target
+type rewriteTransport struct {
+	target string
+}
+
+func (rt rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	u, err := url.Parse(rt.target)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.Scheme, req.URL.Host = u.Scheme, u.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
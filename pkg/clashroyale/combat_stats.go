@@ -1,19 +1,32 @@
 package clashroyale
 
 import (
+	_ "embed"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/klauer/clash-royale-api/go/internal/closeutil"
 )
 
+//go:embed cards_stats.json
+var defaultStatsJSON []byte
+
+// DefaultStatsLevel is the level the bundled stats are recorded at.
+const DefaultStatsLevel = 11
+
+// statsLevelGrowth is how much hitpoints and damage grow per card level when
+// a registry has no multiplier for a level.
+const statsLevelGrowth = 1.1
+
 // CombatStats represents the combat statistics of a card at a specific level (Standard Level 11)
 type CombatStats struct {
-	Hitpoints       int     `json:"hitpoints,omitempty"`
+	Hitpoints       int     `json:"hitpoints,omitempty"` // Per unit for cards that spawn several
 	Damage          int     `json:"damage,omitempty"`
 	DamagePerSecond int     `json:"damagePerSecond,omitempty"`
 	HitSpeed        float64 `json:"hitSpeed,omitempty"`
@@ -25,12 +38,43 @@ type CombatStats struct {
 	SpawnCount      int     `json:"spawnCount,omitempty"`
 	DeathDamage     int     `json:"deathDamage,omitempty"`
 	DashDamage      int     `json:"dashDamage,omitempty"`
+	Flying          bool    `json:"flying,omitempty"` // Air unit, out of reach of ground-only attacks
 }
 
 // CardStatsRegistry represents the collection of all card stats
 type CardStatsRegistry struct {
+	Version     int    `json:"version,omitempty"`
+	Description string `json:"description,omitempty"`
+	// StandardLevel is the card level Stats are recorded at (default 11)
+	StandardLevel int `json:"standardLevel,omitempty"`
+	// LevelMultipliers scales hitpoints and damage from StandardLevel to
+	// another level. Missing levels grow 10% per level.
+	LevelMultipliers map[int]float64 `json:"levelMultipliers,omitempty"`
 	// Map of Card Name -> CombatStats
 	Stats map[string]CombatStats `json:"stats"`
+	// Levels holds exact stats for specific levels: Card Name -> Level -> CombatStats
+	Levels map[string]map[int]CombatStats `json:"levels,omitempty"`
+
+	// fallback answers lookups for cards this registry does not list
+	fallback *CardStatsRegistry
+}
+
+var (
+	defaultStatsOnce sync.Once
+	defaultStats     *CardStatsRegistry
+)
+
+// DefaultStats returns the bundled combat stats dataset. The registry is
+// shared and must not be modified.
+func DefaultStats() *CardStatsRegistry {
+	defaultStatsOnce.Do(func() {
+		registry, err := parseStats(defaultStatsJSON)
+		if err != nil {
+			panic(fmt.Sprintf("clashroyale: bundled cards_stats.json is invalid: %v", err))
+		}
+		defaultStats = registry
+	})
+	return defaultStats
 }
 
 // LoadStats loads combat stats from a JSON file
@@ -46,11 +90,33 @@ func LoadStats(filepath string) (*CardStatsRegistry, error) {
 		return nil, fmt.Errorf("failed to read stats file: %w", err)
 	}
 
+	return parseStats(bytes)
+}
+
+// LoadStatsWithDefaults loads a combat stats file layered over the bundled
+// dataset: cards the file lists use its stats, every other card uses the
+// bundled ones. A missing file returns the bundled dataset. An unreadable
+// file returns the bundled dataset along with the error.
+func LoadStatsWithDefaults(filepath string) (*CardStatsRegistry, error) {
+	if _, err := os.Stat(filepath); errors.Is(err, os.ErrNotExist) {
+		return DefaultStats(), nil
+	}
+	registry, err := LoadStats(filepath)
+	if err != nil {
+		return DefaultStats(), err
+	}
+	registry.fallback = DefaultStats()
+	return registry, nil
+}
+
+func parseStats(data []byte) (*CardStatsRegistry, error) {
 	var registry CardStatsRegistry
-	if err := json.Unmarshal(bytes, &registry); err != nil {
+	if err := json.Unmarshal(data, &registry); err != nil {
 		return nil, fmt.Errorf("failed to parse stats file: %w", err)
 	}
-
+	if registry.StandardLevel <= 0 {
+		registry.StandardLevel = DefaultStatsLevel
+	}
 	return &registry, nil
 }
 
@@ -59,9 +125,58 @@ func (r *CardStatsRegistry) GetStats(cardName string) *CombatStats {
 	if stats, ok := r.Stats[cardName]; ok {
 		return &stats
 	}
+	if r.fallback != nil {
+		return r.fallback.GetStats(cardName)
+	}
 	return nil
 }
 
+// StatsAtLevel returns the stats for a card at a card level. Exact per-level
+// stats are used when listed; otherwise hitpoints and damage are scaled from
+// the standard level. A level of 0 or less returns the standard-level stats.
+func (r *CardStatsRegistry) StatsAtLevel(cardName string, level int) *CombatStats {
+	if exact, ok := r.Levels[cardName][level]; ok {
+		return &exact
+	}
+	stats, ok := r.Stats[cardName]
+	if !ok {
+		if r.fallback != nil {
+			return r.fallback.StatsAtLevel(cardName, level)
+		}
+		return nil
+	}
+	if level <= 0 || level == r.standardLevel() {
+		return &stats
+	}
+
+	multiplier := r.levelMultiplier(level)
+	scale := func(v int) int { return int(math.Round(float64(v) * multiplier)) }
+	stats.Hitpoints = scale(stats.Hitpoints)
+	stats.Damage = scale(stats.Damage)
+	stats.DamagePerSecond = scale(stats.DamagePerSecond)
+	stats.DeathDamage = scale(stats.DeathDamage)
+	stats.DashDamage = scale(stats.DashDamage)
+	return &stats
+}
+
+func (r *CardStatsRegistry) standardLevel() int {
+	if r.StandardLevel > 0 {
+		return r.StandardLevel
+	}
+	return DefaultStatsLevel
+}
+
+// levelMultiplier returns the hitpoint and damage scale from the standard level to level.
+func (r *CardStatsRegistry) levelMultiplier(level int) float64 {
+	if m, ok := r.LevelMultipliers[level]; ok && m > 0 {
+		return m
+	}
+	if r.fallback != nil && r.fallback.standardLevel() == r.standardLevel() {
+		return r.fallback.levelMultiplier(level)
+	}
+	return math.Pow(statsLevelGrowth, float64(level-r.standardLevel()))
+}
+
 // DPSPerElixir calculates damage efficiency for elixir cost
 func (cs *CombatStats) DPSPerElixir(elixir int) float64 {
 	if elixir <= 0 {
@@ -3,6 +3,7 @@ package clashroyale
 import (
 	"math"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -57,42 +58,103 @@ func TestLoadStats(t *testing.T) {
 	}
 }
 
-func TestLoadRealStatsFile(t *testing.T) {
-	// Try to locate the real data file relative to this test file
-	// This assumes the test is run from the project root or the package directory
-	// We'll try a few common paths
-	paths := []string{
-		"../../data/static/cards_stats.json", // From package dir (go pkg structure)
-		"data/static/cards_stats.json",       // From project root
-		"../../../data/cards_stats.json",     // Legacy path
-		"data/cards_stats.json",              // Legacy path
-	}
-
-	var foundPath string
-	for _, p := range paths {
-		if _, err := os.Stat(p); err == nil {
-			foundPath = p
-			break
+func TestDefaultStats(t *testing.T) {
+	registry := DefaultStats()
+	if registry.StandardLevel != DefaultStatsLevel {
+		t.Errorf("StandardLevel = %d, want %d", registry.StandardLevel, DefaultStatsLevel)
+	}
+	if len(registry.Stats) < 100 {
+		t.Errorf("bundled stats cover %d cards, want at least 100", len(registry.Stats))
+	}
+
+	knight := registry.GetStats("Knight")
+	if knight == nil {
+		t.Fatal("Expected to find Knight stats in bundled data")
+	}
+	if knight.Hitpoints <= 0 || knight.DamagePerSecond <= 0 || knight.Targets == "" {
+		t.Errorf("Knight stats incomplete: %+v", knight)
+	}
+}
+
+func TestStatsAtLevel(t *testing.T) {
+	registry, err := parseStats([]byte(`{
+		"standardLevel": 11,
+		"levelMultipliers": {"14": 1.5},
+		"stats": {
+			"Knight": {"hitpoints": 1000, "damage": 100, "damagePerSecond": 80, "hitSpeed": 1.2, "targets": "Ground"}
+		},
+		"levels": {
+			"Knight": {"16": {"hitpoints": 5000, "targets": "Ground"}}
 		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
 	}
 
-	if foundPath == "" {
-		t.Skip("Could not find data/cards_stats.json, skipping integration test")
+	tests := []struct {
+		name  string
+		level int
+		hp    int
+		dps   int
+	}{
+		{"standard level", 11, 1000, 80},
+		{"unknown level", 0, 1000, 80},
+		{"multiplier table", 14, 1500, 120},
+		{"default growth", 12, 1100, 88},
+		{"exact level stats", 16, 5000, 0},
 	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := registry.StatsAtLevel("Knight", tt.level)
+			if stats == nil {
+				t.Fatal("expected Knight stats")
+			}
+			if stats.Hitpoints != tt.hp || stats.DamagePerSecond != tt.dps {
+				t.Errorf("level %d: hp=%d dps=%d, want %d and %d", tt.level, stats.Hitpoints, stats.DamagePerSecond, tt.hp, tt.dps)
+			}
+			if stats.Targets != "Ground" {
+				t.Errorf("level %d: targets = %q, want unscaled Ground", tt.level, stats.Targets)
+			}
+		})
+	}
+
+	if registry.Stats["Knight"].Hitpoints != 1000 {
+		t.Error("StatsAtLevel modified the registry")
+	}
+	if registry.StatsAtLevel("Missing", 14) != nil {
+		t.Error("Expected nil for a missing card")
+	}
+}
+
+func TestLoadStatsWithDefaults(t *testing.T) {
+	dir := t.TempDir()
 
-	registry, err := LoadStats(foundPath)
+	registry, err := LoadStatsWithDefaults(filepath.Join(dir, "missing.json"))
+	if err != nil || registry != DefaultStats() {
+		t.Fatalf("missing file: registry=%p err=%v, want the bundled stats", registry, err)
+	}
+
+	path := filepath.Join(dir, "cards_stats.json")
+	if err := os.WriteFile(path, []byte(`{"stats": {"Knight": {"hitpoints": 1, "targets": "Air"}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	registry, err = LoadStatsWithDefaults(path)
 	if err != nil {
-		t.Fatalf("Failed to load real stats file: %v", err)
+		t.Fatal(err)
+	}
+	if got := registry.GetStats("Knight"); got == nil || got.Hitpoints != 1 {
+		t.Errorf("Knight = %+v, want the file's stats", got)
+	}
+	if got := registry.StatsAtLevel("Archers", 14); got == nil || *got != *DefaultStats().StatsAtLevel("Archers", 14) {
+		t.Errorf("Archers = %+v, want bundled stats", got)
 	}
 
-	// Check for a known card
-	knight := registry.GetStats("Knight")
-	if knight == nil {
-		t.Error("Expected to find Knight stats in real data file")
-	} else {
-		if knight.Hitpoints <= 0 {
-			t.Error("Knight should have positive hitpoints")
-		}
+	if err := os.WriteFile(path, []byte(`{"stats": [`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	registry, err = LoadStatsWithDefaults(path)
+	if err == nil || registry != DefaultStats() {
+		t.Errorf("broken file: err=%v, want an error and the bundled stats", err)
 	}
 }
 
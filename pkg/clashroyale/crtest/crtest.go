@@ -0,0 +1,72 @@
+// Package crtest provides canned Clash Royale API data and a seeded
+// clashroyale.MockClient, so analysis code can be tested without a token
+// or network access.
+//
+// Every function decodes its fixture afresh, so callers may modify the
+// returned values freely.
+package crtest
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+//go:embed fixtures/*.json
+var fixtures embed.FS
+
+const (
+	// PlayerTag is the tag of the fixture player
+	PlayerTag = "#2PYQ98LG"
+	// ClanTag is the tag of the fixture player's clan
+	ClanTag = "#2QCUJ8RP"
+)
+
+// Player returns a mid-ladder player with a Hog Rider deck and a mix of
+// card levels across every rarity
+func Player() *clashroyale.Player {
+	return load[clashroyale.Player]("player.json")
+}
+
+// Cards returns the card list as served by /cards, covering every card the
+// fixture player owns
+func Cards() *clashroyale.CardList {
+	return load[clashroyale.CardList]("cards.json")
+}
+
+// BattleLog returns three ladder battles of the fixture player: two wins
+// and a loss
+func BattleLog() clashroyale.BattleLogResponse {
+	return *load[clashroyale.BattleLogResponse]("battlelog.json")
+}
+
+// Clan returns the fixture player's clan with its member list filled in
+func Clan() *clashroyale.Clan {
+	return load[clashroyale.Clan]("clan.json")
+}
+
+// NewMockClient returns a mock serving the fixture player, battle log,
+// clan, and card list
+func NewMockClient() *clashroyale.MockClient {
+	mock := clashroyale.NewMockClient()
+	mock.AddPlayer(Player())
+	mock.BattleLogs[PlayerTag] = BattleLog()
+	clan := Clan()
+	mock.AddClan(clan, clan.MemberList)
+	mock.Cards = Cards()
+	return mock
+}
+
+func load[T any](name string) *T {
+	data, err := fixtures.ReadFile("fixtures/" + name)
+	if err != nil {
+		panic(fmt.Sprintf("crtest: %v", err))
+	}
+	value := new(T)
+	if err := json.Unmarshal(data, value); err != nil {
+		panic(fmt.Sprintf("crtest: decode %s: %v", name, err))
+	}
+	return value
+}
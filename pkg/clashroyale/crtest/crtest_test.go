@@ -0,0 +1,73 @@
+package crtest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/analysis"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale/crtest"
+)
+
+func TestFixturesAreConsistent(t *testing.T) {
+	player := crtest.Player()
+	if player.Tag != crtest.PlayerTag || player.Clan == nil || player.Clan.Tag != crtest.ClanTag {
+		t.Fatalf("player tag/clan = %s/%+v", player.Tag, player.Clan)
+	}
+	for _, tag := range []string{crtest.PlayerTag, crtest.ClanTag} {
+		if _, err := clashroyale.ParseTag(tag); err != nil {
+			t.Errorf("fixture tag %s: %v", tag, err)
+		}
+	}
+	if len(player.CurrentDeck) != 8 {
+		t.Errorf("current deck has %d cards", len(player.CurrentDeck))
+	}
+
+	known := map[int]bool{}
+	for _, card := range crtest.Cards().Items {
+		known[card.ID] = true
+	}
+	for _, card := range player.Cards {
+		if !known[card.ID] {
+			t.Errorf("%s (%d) is missing from Cards()", card.Name, card.ID)
+		}
+		if card.Level < 1 || card.Level > card.MaxLevel {
+			t.Errorf("%s level %d outside 1..%d", card.Name, card.Level, card.MaxLevel)
+		}
+	}
+
+	for i, battle := range crtest.BattleLog() {
+		if battle.UTCDate.IsZero() || len(battle.Team) != 1 || battle.Team[0].Tag != crtest.PlayerTag {
+			t.Errorf("battle %d: unexpected %+v", i, battle)
+		}
+	}
+	if clan := crtest.Clan(); clan.Members != len(clan.MemberList) {
+		t.Errorf("clan members = %d, list has %d", clan.Members, len(clan.MemberList))
+	}
+}
+
+func TestFixturesReturnCopies(t *testing.T) {
+	crtest.Player().Name = "changed"
+	if got := crtest.Player().Name; got == "changed" {
+		t.Error("Player() returned a shared value")
+	}
+}
+
+func TestAnalyzeFixturePlayer(t *testing.T) {
+	mock := crtest.NewMockClient()
+	player, err := mock.GetPlayerWithContext(context.Background(), "2pyq98lg")
+	if err != nil {
+		t.Fatalf("GetPlayerWithContext() error = %v", err)
+	}
+
+	result, err := analysis.AnalyzeCardCollection(player, analysis.DefaultAnalysisOptions())
+	if err != nil {
+		t.Fatalf("AnalyzeCardCollection() error = %v", err)
+	}
+	if result.TotalCards != len(player.Cards) {
+		t.Errorf("TotalCards = %d, want %d", result.TotalCards, len(player.Cards))
+	}
+	if len(result.UpgradePriority) == 0 {
+		t.Error("expected upgrade priorities for the fixture collection")
+	}
+}
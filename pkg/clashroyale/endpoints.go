@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"log"
 	"net/http"
 	"net/url"
 
@@ -13,6 +15,15 @@ import (
 // makeAPIRequest is a generic helper to reduce duplication across API endpoints.
 // It handles the common pattern of: create request, execute, check status, decode JSON.
 func makeAPIRequest[T any](ctx context.Context, c *Client, endpoint, errorMsg string) (*T, error) {
+	if c.cache != nil {
+		if body, ok := c.cache.Get(endpoint); ok {
+			var cached T
+			if err := json.Unmarshal(body, &cached); err == nil {
+				return &cached, nil
+			}
+		}
+	}
+
 	req, err := c.NewRequest(ctx, "GET", endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -31,14 +42,79 @@ func makeAPIRequest[T any](ctx context.Context, c *Client, endpoint, errorMsg st
 		}
 	}
 
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
 	var result T
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := json.Unmarshal(body, &result); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
+	if c.unknownFields != nil {
+		if fields, err := CheckUnknownFields(body, &result); err == nil && len(fields) > 0 {
+			c.unknownFields(endpoint, fields)
+		}
+	}
+
+	if c.cache != nil {
+		if err := c.cache.Put(endpoint, body); err != nil {
+			log.Printf("clashroyale: failed to cache %s: %v", endpoint, err)
+		}
+	}
 
 	return &result, nil
 }
 
+// tagAPIRequest is makeAPIRequest for player and clan endpoints; a tag that
+// cannot be valid fails with ErrInvalidTag before any request is made.
+func tagAPIRequest[T any](ctx context.Context, c *Client, tag, endpoint, errorMsg string) (*T, error) {
+	if err := ValidateTag(tag); err != nil {
+		return nil, err
+	}
+	return makeAPIRequest[T](ctx, c, endpoint, errorMsg)
+}
+
+// PlayerEndpoint returns the API path for a player profile.
+func PlayerEndpoint(tag string) string {
+	return fmt.Sprintf("/players/%s", url.PathEscape(NormalizeTag(tag)))
+}
+
+// PlayerUpcomingChestsEndpoint returns the API path for a player's upcoming chests.
+func PlayerUpcomingChestsEndpoint(tag string) string {
+	return PlayerEndpoint(tag) + "/upcomingchests"
+}
+
+// PlayerBattleLogEndpoint returns the API path for a player's battle log.
+func PlayerBattleLogEndpoint(tag string) string {
+	return PlayerEndpoint(tag) + "/battlelog"
+}
+
+// ClanEndpoint returns the API path for a clan profile.
+func ClanEndpoint(tag string) string {
+	return fmt.Sprintf("/clans/%s", url.PathEscape(NormalizeTag(tag)))
+}
+
+// ClanMembersEndpoint returns the API path for a clan's member list.
+func ClanMembersEndpoint(tag string) string {
+	return ClanEndpoint(tag) + "/members"
+}
+
+// ClanCurrentRiverRaceEndpoint returns the API path for a clan's current river race.
+func ClanCurrentRiverRaceEndpoint(tag string) string {
+	return ClanEndpoint(tag) + "/currentriverrace"
+}
+
+// PathOfLegendRankingsEndpoint returns the API path for a location's Path of
+// Legends leaderboard. limit <= 0 uses the API default page size.
+func PathOfLegendRankingsEndpoint(location string, limit int) string {
+	endpoint := fmt.Sprintf("/locations/%s/pathoflegend/players", url.PathEscape(location))
+	if limit > 0 {
+		endpoint += fmt.Sprintf("?limit=%d", limit)
+	}
+	return endpoint
+}
+
 // GetPlayer retrieves player information for the given tag
 func (c *Client) GetPlayer(tag string) (*Player, error) {
 	return c.GetPlayerWithContext(context.Background(), tag)
@@ -46,9 +122,7 @@ func (c *Client) GetPlayer(tag string) (*Player, error) {
 
 // GetPlayerWithContext retrieves player information for the given tag with caller context.
 func (c *Client) GetPlayerWithContext(ctx context.Context, tag string) (*Player, error) {
-	normalizedTag := NormalizeTag(tag)
-	endpoint := fmt.Sprintf("/players/%s", url.PathEscape(normalizedTag))
-	return makeAPIRequest[Player](ctx, c, endpoint, fmt.Sprintf("Failed to get player %s", tag))
+	return tagAPIRequest[Player](ctx, c, tag, PlayerEndpoint(tag), fmt.Sprintf("Failed to get player %s", tag))
 }
 
 // GetPlayerUpcomingChests retrieves the upcoming chest cycle for a player
@@ -58,9 +132,7 @@ func (c *Client) GetPlayerUpcomingChests(tag string) (*ChestCycle, error) {
 
 // GetPlayerUpcomingChestsWithContext retrieves upcoming chest cycle with caller context.
 func (c *Client) GetPlayerUpcomingChestsWithContext(ctx context.Context, tag string) (*ChestCycle, error) {
-	normalizedTag := NormalizeTag(tag)
-	endpoint := fmt.Sprintf("/players/%s/upcomingchests", url.PathEscape(normalizedTag))
-	return makeAPIRequest[ChestCycle](ctx, c, endpoint, fmt.Sprintf("Failed to get upcoming chests for player %s", tag))
+	return tagAPIRequest[ChestCycle](ctx, c, tag, PlayerUpcomingChestsEndpoint(tag), fmt.Sprintf("Failed to get upcoming chests for player %s", tag))
 }
 
 // GetPlayerBattleLog retrieves the battle log for a player
@@ -70,9 +142,7 @@ func (c *Client) GetPlayerBattleLog(tag string) (*BattleLogResponse, error) {
 
 // GetPlayerBattleLogWithContext retrieves battle log with caller context.
 func (c *Client) GetPlayerBattleLogWithContext(ctx context.Context, tag string) (*BattleLogResponse, error) {
-	normalizedTag := NormalizeTag(tag)
-	endpoint := fmt.Sprintf("/players/%s/battlelog", url.PathEscape(normalizedTag))
-	return makeAPIRequest[BattleLogResponse](ctx, c, endpoint, fmt.Sprintf("Failed to get battle log for player %s", tag))
+	return tagAPIRequest[BattleLogResponse](ctx, c, tag, PlayerBattleLogEndpoint(tag), fmt.Sprintf("Failed to get battle log for player %s", tag))
 }
 
 // GetCards retrieves the full list of cards
@@ -94,3 +164,43 @@ func (c *Client) GetLocations() (*LocationList, error) {
 func (c *Client) GetLocationsWithContext(ctx context.Context) (*LocationList, error) {
 	return makeAPIRequest[LocationList](ctx, c, "/locations", "Failed to get locations")
 }
+
+// GetClanMembers retrieves the member list for the given clan tag
+func (c *Client) GetClanMembers(tag string) (*ClanMemberList, error) {
+	return c.GetClanMembersWithContext(context.Background(), tag)
+}
+
+// GetClanMembersWithContext retrieves the clan member list with caller context.
+func (c *Client) GetClanMembersWithContext(ctx context.Context, tag string) (*ClanMemberList, error) {
+	return tagAPIRequest[ClanMemberList](ctx, c, tag, ClanMembersEndpoint(tag), fmt.Sprintf("Failed to get members for clan %s", tag))
+}
+
+// GetClan retrieves clan information for the given clan tag
+func (c *Client) GetClan(tag string) (*Clan, error) {
+	return c.GetClanWithContext(context.Background(), tag)
+}
+
+// GetClanWithContext retrieves clan information with caller context.
+func (c *Client) GetClanWithContext(ctx context.Context, tag string) (*Clan, error) {
+	return tagAPIRequest[Clan](ctx, c, tag, ClanEndpoint(tag), fmt.Sprintf("Failed to get clan %s", tag))
+}
+
+// GetCurrentRiverRace retrieves the river race the clan is currently in
+func (c *Client) GetCurrentRiverRace(tag string) (*CurrentRiverRace, error) {
+	return c.GetCurrentRiverRaceWithContext(context.Background(), tag)
+}
+
+// GetCurrentRiverRaceWithContext retrieves the current river race with caller context.
+func (c *Client) GetCurrentRiverRaceWithContext(ctx context.Context, tag string) (*CurrentRiverRace, error) {
+	return tagAPIRequest[CurrentRiverRace](ctx, c, tag, ClanCurrentRiverRaceEndpoint(tag), fmt.Sprintf("Failed to get current river race for clan %s", tag))
+}
+
+// GetPathOfLegendRankings retrieves the top players of a location's Path of Legends leaderboard
+func (c *Client) GetPathOfLegendRankings(location string, limit int) (*PlayerRankingList, error) {
+	return c.GetPathOfLegendRankingsWithContext(context.Background(), location, limit)
+}
+
+// GetPathOfLegendRankingsWithContext retrieves Path of Legends rankings with caller context.
+func (c *Client) GetPathOfLegendRankingsWithContext(ctx context.Context, location string, limit int) (*PlayerRankingList, error) {
+	return makeAPIRequest[PlayerRankingList](ctx, c, PathOfLegendRankingsEndpoint(location, limit), fmt.Sprintf("Failed to get Path of Legends rankings for %s", location))
+}
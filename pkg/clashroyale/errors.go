@@ -0,0 +1,63 @@
+package clashroyale
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Sentinels for the API failures callers usually branch on. Match them with
+// errors.Is; the underlying APIError stays reachable with errors.As.
+var (
+	// ErrNotFound means no player, clan or location has the requested ID
+	ErrNotFound = errors.New("not found")
+	// ErrRateLimited means the API kept answering 429; the error is a
+	// *RateLimitError carrying the suggested wait
+	ErrRateLimited = errors.New("rate limited")
+	// ErrMaintenance means the API is down for maintenance (503)
+	ErrMaintenance = errors.New("API in maintenance")
+	// ErrAccessDenied means the API rejected the token (403), usually
+	// because it does not allow this machine's IP address
+	ErrAccessDenied = errors.New("access denied")
+	// ErrInvalidTag means a tag cannot be a player or clan tag, so no
+	// request was sent
+	ErrInvalidTag = errors.New("invalid tag")
+)
+
+// Is maps the response status onto the package sentinels
+func (e APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrMaintenance:
+		return e.StatusCode == http.StatusServiceUnavailable
+	case ErrAccessDenied:
+		return e.StatusCode == http.StatusForbidden
+	}
+	return false
+}
+
+// inMaintenance reports whether a 503 is the API's maintenance answer,
+// which lasts far longer than a retry
+func (e APIError) inMaintenance() bool {
+	return e.StatusCode == http.StatusServiceUnavailable && e.Reason == "inMaintenance"
+}
+
+// RateLimitError is returned once 429 responses use up every retry
+type RateLimitError struct {
+	APIError
+	// RetryAfter is the wait the API asked for in its last response
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%s (retry after %s)", e.APIError.Error(), e.RetryAfter)
+}
+
+// Unwrap exposes the APIError to errors.As
+func (e *RateLimitError) Unwrap() error {
+	return e.APIError
+}
@@ -0,0 +1,93 @@
+package clashroyale
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAPIErrorIs(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusServiceUnavailable, ErrMaintenance},
+		{http.StatusForbidden, ErrAccessDenied},
+	}
+	for _, tt := range tests {
+		err := fmt.Errorf("wrapped: %w", APIError{StatusCode: tt.status})
+		if !errors.Is(err, tt.want) {
+			t.Errorf("status %d: errors.Is(%v) = false", tt.status, tt.want)
+		}
+		if errors.Is(APIError{StatusCode: http.StatusBadRequest}, tt.want) {
+			t.Errorf("400 matched %v", tt.want)
+		}
+	}
+}
+
+func TestGetPlayerInvalidTagSkipsRequest(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer server.Close()
+
+	client := NewClient("test_token")
+	client.baseURL = server.URL
+	if _, err := client.GetPlayer("#2PP0O"); !errors.Is(err, ErrInvalidTag) {
+		t.Errorf("GetPlayer() error = %v, want ErrInvalidTag", err)
+	}
+	if requests.Load() != 0 {
+		t.Errorf("made %d requests for an invalid tag", requests.Load())
+	}
+}
+
+func TestClient_Do_MaintenanceNotRetried(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, `{"reason":"inMaintenance","message":"Maintenance break"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("test_token")
+	client.baseURL = server.URL
+	_, err := client.GetPlayer("#2PP")
+	if !errors.Is(err, ErrMaintenance) {
+		t.Fatalf("GetPlayer() error = %v, want ErrMaintenance", err)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("made %d requests, want maintenance to skip retries", requests.Load())
+	}
+}
+
+func TestClient_Do_RateLimitExhausted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"reason":"requestThrottled","message":"Request was throttled"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("test_token")
+	client.baseURL = server.URL
+	_, err := client.GetPlayer("#2PP")
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("GetPlayer() error = %v, want ErrRateLimited", err)
+	}
+	var rateErr *RateLimitError
+	if !errors.As(err, &rateErr) || rateErr.RetryAfter != time.Second || rateErr.Reason != "requestThrottled" {
+		t.Errorf("GetPlayer() error = %#v, want a RateLimitError with a 1s RetryAfter", err)
+	}
+	var apiErr APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("GetPlayer() error = %v, want the 429 APIError", err)
+	}
+}
@@ -0,0 +1,253 @@
+// Command genmodels generates the clashroyale response models in types.go
+// from swagger.yaml, a locally maintained schema in Swagger 2.0 definitions
+// form. Definitions and their properties keep the order of the document.
+//
+// Usage:
+//
+//	go run ./internal/genmodels -spec swagger.yaml -out types.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const refPrefix = "#/definitions/"
+
+// document is the part of a Swagger 2.0 document the generator reads
+type document struct {
+	Swagger     string    `yaml:"swagger"`
+	Definitions namedList `yaml:"definitions"`
+}
+
+// schema is a definition or property schema
+type schema struct {
+	Type        string    `yaml:"type"`
+	Format      string    `yaml:"format"`
+	Ref         string    `yaml:"$ref"`
+	Description string    `yaml:"description"`
+	Items       *schema   `yaml:"items"`
+	Properties  namedList `yaml:"properties"`
+	GoName      string    `yaml:"x-go-name"`
+	GoPointer   bool      `yaml:"x-go-pointer"`
+	OmitEmpty   bool      `yaml:"x-omitempty"`
+}
+
+type named struct {
+	Name   string
+	Schema schema
+}
+
+// namedList is a YAML mapping of schemas that keeps its key order
+type namedList []named
+
+func (l *namedList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: expected a mapping", node.Line)
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		var entry named
+		entry.Name = node.Content[i].Value
+		if err := node.Content[i+1].Decode(&entry.Schema); err != nil {
+			return fmt.Errorf("%s: %w", entry.Name, err)
+		}
+		*l = append(*l, entry)
+	}
+	return nil
+}
+
+func main() {
+	specPath := flag.String("spec", "swagger.yaml", "Swagger 2.0 document to read")
+	outPath := flag.String("out", "types.go", "Go file to write")
+	pkg := flag.String("package", "clashroyale", "package name of the generated file")
+	flag.Parse()
+
+	src, err := generateFile(*specPath, *pkg)
+	if err == nil {
+		err = os.WriteFile(*outPath, src, 0o644)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "genmodels: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// generateFile reads the document at specPath and returns the formatted Go
+// source of its models
+func generateFile(specPath, pkg string) ([]byte, error) {
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec: %w", err)
+	}
+	var doc document
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", specPath, err)
+	}
+	return generate(&doc, filepath.Base(specPath), pkg)
+}
+
+// generate returns the Go source of doc's models. specName names the
+// document in the generated file's header.
+func generate(doc *document, specName, pkg string) ([]byte, error) {
+	if doc.Swagger != "2.0" {
+		return nil, fmt.Errorf("unsupported swagger version %q, want 2.0", doc.Swagger)
+	}
+	known := make(map[string]bool, len(doc.Definitions))
+	for _, def := range doc.Definitions {
+		if known[def.Name] {
+			return nil, fmt.Errorf("definition %s is defined more than once", def.Name)
+		}
+		known[def.Name] = true
+	}
+
+	g := &generator{known: known}
+	for _, def := range doc.Definitions {
+		if err := g.definition(def); err != nil {
+			return nil, fmt.Errorf("%s: %w", def.Name, err)
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by genmodels from %s; DO NOT EDIT.\n\n", specName)
+	out.WriteString("// This file holds the response models, field for field as the API sends\n")
+	out.WriteString("// them. Edit " + specName + " and run go generate instead of changing it;\n")
+	out.WriteString("// methods and derived values belong in types_ext.go. CheckUnknownFields\n")
+	out.WriteString("// reports fields the API sends that these structs lack.\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", pkg)
+	if g.usesTime {
+		out.WriteString("import \"time\"\n\n")
+	}
+	out.Write(g.body.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated code does not parse: %w", err)
+	}
+	return src, nil
+}
+
+type generator struct {
+	known    map[string]bool
+	usesTime bool
+	body     bytes.Buffer
+}
+
+func (g *generator) definition(def named) error {
+	g.comment(def.Schema.Description)
+	switch def.Schema.Type {
+	case "array":
+		elem, err := g.goType(def.Schema)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&g.body, "type %s %s\n\n", def.Name, elem)
+		return nil
+	case "object", "":
+		fmt.Fprintf(&g.body, "type %s struct {\n", def.Name)
+		for _, prop := range def.Schema.Properties {
+			if err := g.field(prop); err != nil {
+				return fmt.Errorf("%s: %w", prop.Name, err)
+			}
+		}
+		g.body.WriteString("}\n\n")
+		return nil
+	default:
+		return fmt.Errorf("unsupported definition type %q", def.Schema.Type)
+	}
+}
+
+func (g *generator) field(prop named) error {
+	goType, err := g.goType(prop.Schema)
+	if err != nil {
+		return err
+	}
+	if prop.Schema.GoPointer {
+		if prop.Schema.Ref == "" {
+			return fmt.Errorf("x-go-pointer needs a $ref")
+		}
+		goType = "*" + goType
+	}
+	name := prop.Schema.GoName
+	if name == "" {
+		name = fieldName(prop.Name)
+	}
+	tag := prop.Name
+	if prop.Schema.OmitEmpty {
+		tag += ",omitempty"
+	}
+	fmt.Fprintf(&g.body, "\t%s %s `json:%q`", name, goType, tag)
+	if desc := strings.TrimSpace(prop.Schema.Description); desc != "" {
+		if strings.Contains(desc, "\n") {
+			return fmt.Errorf("property descriptions must be one line")
+		}
+		g.body.WriteString(" // " + desc)
+	}
+	g.body.WriteString("\n")
+	return nil
+}
+
+// goType returns the Go type of a schema
+func (g *generator) goType(s schema) (string, error) {
+	if s.Ref != "" {
+		name, ok := strings.CutPrefix(s.Ref, refPrefix)
+		if !ok || !g.known[name] {
+			return "", fmt.Errorf("unknown $ref %q", s.Ref)
+		}
+		return name, nil
+	}
+	switch s.Type {
+	case "string":
+		if s.Format == "date-time" {
+			g.usesTime = true
+			return "time.Time", nil
+		}
+		return "string", nil
+	case "integer":
+		if s.Format == "int64" {
+			return "int64", nil
+		}
+		return "int", nil
+	case "number":
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	case "array":
+		if s.Items == nil {
+			return "", fmt.Errorf("array without items")
+		}
+		elem, err := g.goType(*s.Items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elem, nil
+	default:
+		return "", fmt.Errorf("unsupported type %q; inline objects need their own definition", s.Type)
+	}
+}
+
+func (g *generator) comment(text string) {
+	for line := range strings.SplitSeq(strings.TrimSpace(text), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			g.body.WriteString("// " + line + "\n")
+		}
+	}
+}
+
+// fieldName exports a JSON property name, spelling a trailing "Id" as "ID"
+func fieldName(jsonName string) string {
+	if jsonName == "" {
+		return ""
+	}
+	name := strings.ToUpper(jsonName[:1]) + jsonName[1:]
+	if base, ok := strings.CutSuffix(name, "Id"); ok {
+		name = base + "ID"
+	}
+	return name
+}
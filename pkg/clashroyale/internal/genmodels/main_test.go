@@ -0,0 +1,85 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestTypesAreUpToDate(t *testing.T) {
+	want, err := generateFile("../../swagger.yaml", "clashroyale")
+	if err != nil {
+		t.Fatalf("generateFile() error = %v", err)
+	}
+	got, err := os.ReadFile("../../types.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Error("types.go differs from swagger.yaml; run go generate ./pkg/clashroyale")
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	spec := `
+swagger: "2.0"
+definitions:
+  Zebra:
+    description: Zebra comes first because the document lists it first
+    type: object
+    properties:
+      stripeId: {type: integer}
+      seenAt: {type: string, format: date-time, x-omitempty: true}
+      herd: {$ref: "#/definitions/Herd", x-go-pointer: true}
+      url: {type: string, x-go-name: URL, description: Photo link}
+  Herd:
+    type: array
+    items: {$ref: "#/definitions/Zebra"}
+`
+	var doc document
+	if err := yaml.Unmarshal([]byte(spec), &doc); err != nil {
+		t.Fatal(err)
+	}
+	src, err := generate(&doc, "spec.yaml", "zoo")
+	if err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+	// Compare with runs of spaces collapsed, ignoring gofmt's alignment
+	out := strings.Join(strings.Fields(string(src)), " ")
+	for _, want := range []string{
+		"// Code generated by genmodels from spec.yaml; DO NOT EDIT.",
+		`import "time"`,
+		"StripeID int `json:\"stripeId\"`",
+		"SeenAt time.Time `json:\"seenAt,omitempty\"`",
+		"Herd *Herd `json:\"herd\"`",
+		"URL string `json:\"url\"` // Photo link",
+		"type Herd []Zebra",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code lacks %q:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "type Zebra") > strings.Index(out, "type Herd") {
+		t.Error("definitions should keep the document order")
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := map[string]string{
+		`swagger: "3.0"`: "unsupported swagger version",
+		`{swagger: "2.0", definitions: {A: {properties: {b: {$ref: "#/definitions/Missing"}}}}}`:    "unknown $ref",
+		`{swagger: "2.0", definitions: {A: {properties: {b: {type: string, x-go-pointer: true}}}}}`: "x-go-pointer needs a $ref",
+		`{swagger: "2.0", definitions: {A: {properties: {b: {type: object}}}}}`:                     "inline objects",
+	}
+	for spec, want := range tests {
+		var doc document
+		if err := yaml.Unmarshal([]byte(spec), &doc); err != nil {
+			t.Fatalf("%s: %v", spec, err)
+		}
+		if _, err := generate(&doc, "spec.yaml", "zoo"); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: error = %v, want %q", spec, err, want)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package clashroyale
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// API is the read side of the Clash Royale API that Client implements.
+// Code that accepts an API instead of a *Client can be tested with
+// MockClient, or with the fixtures in the crtest package.
+type API interface {
+	GetPlayerWithContext(ctx context.Context, tag string) (*Player, error)
+	GetPlayerUpcomingChestsWithContext(ctx context.Context, tag string) (*ChestCycle, error)
+	GetPlayerBattleLogWithContext(ctx context.Context, tag string) (*BattleLogResponse, error)
+	GetPlayers(ctx context.Context, tags []string, opts BulkOptions) ([]PlayerResult, error)
+	GetBattleLogs(ctx context.Context, tags []string, opts BulkOptions) ([]BattleLogResult, error)
+	GetCardsWithContext(ctx context.Context) (*CardList, error)
+	GetLocationsWithContext(ctx context.Context) (*LocationList, error)
+	GetClanWithContext(ctx context.Context, tag string) (*Clan, error)
+	GetClanMembersWithContext(ctx context.Context, tag string) (*ClanMemberList, error)
+	GetCurrentRiverRaceWithContext(ctx context.Context, tag string) (*CurrentRiverRace, error)
+	GetPathOfLegendRankingsWithContext(ctx context.Context, location string, limit int) (*PlayerRankingList, error)
+}
+
+var (
+	_ API = (*Client)(nil)
+	_ API = (*MockClient)(nil)
+)
+
+// MockClient is an in-memory API for tests. Responses are looked up by
+// normalized tag (or location ID for rankings); anything missing fails
+// with a 404 APIError, so errors.Is(err, ErrNotFound) holds just as with
+// the real API. Tags are validated like Client does. Fill the maps before
+// sharing the mock between goroutines; returned values are the stored
+// pointers, not copies.
+type MockClient struct {
+	Players     map[string]*Player
+	Chests      map[string]*ChestCycle
+	BattleLogs  map[string]BattleLogResponse
+	Clans       map[string]*Clan
+	ClanMembers map[string]*ClanMemberList
+	RiverRaces  map[string]*CurrentRiverRace
+	Rankings    map[string]*PlayerRankingList
+	Cards       *CardList
+	Locations   *LocationList
+	// Errors fails requests for the endpoints it holds, such as
+	// PlayerEndpoint("#2PP"), with the given error
+	Errors map[string]error
+
+	mu    sync.Mutex
+	calls []string
+}
+
+// NewMockClient returns a MockClient with empty maps
+func NewMockClient() *MockClient {
+	return &MockClient{
+		Players:     map[string]*Player{},
+		Chests:      map[string]*ChestCycle{},
+		BattleLogs:  map[string]BattleLogResponse{},
+		Clans:       map[string]*Clan{},
+		ClanMembers: map[string]*ClanMemberList{},
+		RiverRaces:  map[string]*CurrentRiverRace{},
+		Rankings:    map[string]*PlayerRankingList{},
+		Errors:      map[string]error{},
+	}
+}
+
+// AddPlayer stores player under its tag
+func (m *MockClient) AddPlayer(player *Player) {
+	m.Players[NormalizeTag(player.Tag)] = player
+}
+
+// AddClan stores clan and a member list for it
+func (m *MockClient) AddClan(clan *Clan, members []Member) {
+	tag := NormalizeTag(clan.Tag)
+	m.Clans[tag] = clan
+	m.ClanMembers[tag] = &ClanMemberList{Items: members}
+}
+
+// Calls returns the endpoints requested so far, in order
+func (m *MockClient) Calls() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.calls...)
+}
+
+// record logs endpoint and returns the error the request should fail with
+func (m *MockClient) record(ctx context.Context, endpoint string) error {
+	m.mu.Lock()
+	m.calls = append(m.calls, endpoint)
+	m.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.Errors[endpoint]
+}
+
+// mockLookup serves key from values for endpoint
+func mockLookup[T any](ctx context.Context, m *MockClient, endpoint string, values map[string]T, key string) (T, error) {
+	var zero T
+	if err := m.record(ctx, endpoint); err != nil {
+		return zero, err
+	}
+	value, ok := values[key]
+	if !ok {
+		return zero, mockNotFound(endpoint)
+	}
+	return value, nil
+}
+
+// mockTagLookup is mockLookup for tag endpoints, validating the tag first
+func mockTagLookup[T any](ctx context.Context, m *MockClient, tag, endpoint string, values map[string]T) (T, error) {
+	if err := ValidateTag(tag); err != nil {
+		var zero T
+		return zero, err
+	}
+	return mockLookup(ctx, m, endpoint, values, NormalizeTag(tag))
+}
+
+func mockNotFound(endpoint string) APIError {
+	return APIError{
+		StatusCode: http.StatusNotFound,
+		Reason:     "notFound",
+		Message:    fmt.Sprintf("mock has no response for %s", endpoint),
+	}
+}
+
+// GetPlayerWithContext returns the stored player
+func (m *MockClient) GetPlayerWithContext(ctx context.Context, tag string) (*Player, error) {
+	return mockTagLookup(ctx, m, tag, PlayerEndpoint(tag), m.Players)
+}
+
+// GetPlayerUpcomingChestsWithContext returns the stored chest cycle
+func (m *MockClient) GetPlayerUpcomingChestsWithContext(ctx context.Context, tag string) (*ChestCycle, error) {
+	return mockTagLookup(ctx, m, tag, PlayerUpcomingChestsEndpoint(tag), m.Chests)
+}
+
+// GetPlayerBattleLogWithContext returns the stored battle log
+func (m *MockClient) GetPlayerBattleLogWithContext(ctx context.Context, tag string) (*BattleLogResponse, error) {
+	battles, err := mockTagLookup(ctx, m, tag, PlayerBattleLogEndpoint(tag), m.BattleLogs)
+	if err != nil {
+		return nil, err
+	}
+	return &battles, nil
+}
+
+// GetPlayers fetches stored players like Client.GetPlayers
+func (m *MockClient) GetPlayers(ctx context.Context, tags []string, opts BulkOptions) ([]PlayerResult, error) {
+	return getPlayers(ctx, tags, opts, m.GetPlayerWithContext)
+}
+
+// GetBattleLogs fetches stored battle logs like Client.GetBattleLogs
+func (m *MockClient) GetBattleLogs(ctx context.Context, tags []string, opts BulkOptions) ([]BattleLogResult, error) {
+	return getBattleLogs(ctx, tags, opts, m.GetPlayerBattleLogWithContext)
+}
+
+// GetCardsWithContext returns the stored card list
+func (m *MockClient) GetCardsWithContext(ctx context.Context) (*CardList, error) {
+	return mockSingle(ctx, m, "/cards", m.Cards)
+}
+
+// GetLocationsWithContext returns the stored location list
+func (m *MockClient) GetLocationsWithContext(ctx context.Context) (*LocationList, error) {
+	return mockSingle(ctx, m, "/locations", m.Locations)
+}
+
+// GetClanWithContext returns the stored clan
+func (m *MockClient) GetClanWithContext(ctx context.Context, tag string) (*Clan, error) {
+	return mockTagLookup(ctx, m, tag, ClanEndpoint(tag), m.Clans)
+}
+
+// GetClanMembersWithContext returns the stored member list
+func (m *MockClient) GetClanMembersWithContext(ctx context.Context, tag string) (*ClanMemberList, error) {
+	return mockTagLookup(ctx, m, tag, ClanMembersEndpoint(tag), m.ClanMembers)
+}
+
+// GetCurrentRiverRaceWithContext returns the stored river race
+func (m *MockClient) GetCurrentRiverRaceWithContext(ctx context.Context, tag string) (*CurrentRiverRace, error) {
+	return mockTagLookup(ctx, m, tag, ClanCurrentRiverRaceEndpoint(tag), m.RiverRaces)
+}
+
+// GetPathOfLegendRankingsWithContext returns the stored rankings for
+// location, cut to limit entries
+func (m *MockClient) GetPathOfLegendRankingsWithContext(ctx context.Context, location string, limit int) (*PlayerRankingList, error) {
+	rankings, err := mockLookup(ctx, m, PathOfLegendRankingsEndpoint(location, limit), m.Rankings, location)
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(rankings.Items) > limit {
+		return &PlayerRankingList{Items: rankings.Items[:limit], Paging: rankings.Paging}, nil
+	}
+	return rankings, nil
+}
+
+// mockSingle serves an endpoint without parameters
+func mockSingle[T any](ctx context.Context, m *MockClient, endpoint string, value *T) (*T, error) {
+	if err := m.record(ctx, endpoint); err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, mockNotFound(endpoint)
+	}
+	return value, nil
+}
@@ -0,0 +1,70 @@
+package clashroyale
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestMockClientServesStoredData(t *testing.T) {
+	mock := NewMockClient()
+	mock.AddPlayer(&Player{Tag: "#2PP", Name: "Stored"})
+	mock.BattleLogs["#2PP"] = BattleLogResponse{{Type: "PvP"}}
+	mock.AddClan(&Clan{Tag: "#9YL"}, []Member{{Tag: "#2PP"}})
+	ctx := context.Background()
+
+	player, err := mock.GetPlayerWithContext(ctx, "2pp")
+	if err != nil || player.Name != "Stored" {
+		t.Fatalf("GetPlayerWithContext() = %+v, %v", player, err)
+	}
+	members, err := mock.GetClanMembersWithContext(ctx, "#9YL")
+	if err != nil || len(members.Items) != 1 {
+		t.Fatalf("GetClanMembersWithContext() = %+v, %v", members, err)
+	}
+	if _, err := mock.GetPlayerWithContext(ctx, "#QQQ"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing player error = %v, want ErrNotFound", err)
+	}
+	if _, err := mock.GetCardsWithContext(ctx); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing cards error = %v, want ErrNotFound", err)
+	}
+	if _, err := mock.GetPlayerWithContext(ctx, "#2P"); !errors.Is(err, ErrInvalidTag) {
+		t.Errorf("bad tag error = %v, want ErrInvalidTag", err)
+	}
+
+	want := []string{PlayerEndpoint("#2PP"), ClanMembersEndpoint("#9YL"), PlayerEndpoint("#QQQ"), "/cards"}
+	if got := mock.Calls(); !slices.Equal(got, want) {
+		t.Errorf("Calls() = %v, want %v", got, want)
+	}
+
+	results, err := mock.GetBattleLogs(ctx, []string{"#2PP", "#QQQ"}, BulkOptions{})
+	if err != nil || len(results[0].Battles) != 1 || !errors.Is(results[1].Err, ErrNotFound) {
+		t.Errorf("GetBattleLogs() = %+v, %v", results, err)
+	}
+}
+
+func TestMockClientErrors(t *testing.T) {
+	mock := NewMockClient()
+	mock.AddPlayer(&Player{Tag: "#2PP"})
+	mock.Errors[PlayerEndpoint("#2PP")] = &RateLimitError{APIError: APIError{StatusCode: 429}}
+
+	if _, err := mock.GetPlayerWithContext(context.Background(), "#2PP"); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("error = %v, want ErrRateLimited", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := mock.GetPlayerWithContext(ctx, "#2PP"); !errors.Is(err, context.Canceled) {
+		t.Errorf("canceled error = %v, want context.Canceled", err)
+	}
+}
+
+func TestMockClientRankingsLimit(t *testing.T) {
+	mock := NewMockClient()
+	mock.Rankings["global"] = &PlayerRankingList{Items: make([]PlayerRanking, 5)}
+
+	rankings, err := mock.GetPathOfLegendRankingsWithContext(context.Background(), "global", 2)
+	if err != nil || len(rankings.Items) != 2 {
+		t.Errorf("rankings = %+v, %v; want 2 items", rankings, err)
+	}
+}
@@ -0,0 +1,127 @@
+package clashroyale
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// UnknownFieldHandler receives the JSON fields of a response that the
+// target model has no field for, as paths such as "cards[].newField"
+type UnknownFieldHandler func(endpoint string, fields []string)
+
+// SetUnknownFieldHandler makes the client compare every API response with
+// the model it decodes into and report fields the model lacks, which is
+// how schema changes on Supercell's side show up first. Passing nil turns
+// the check off. Responses served from the cache are not checked.
+func (c *Client) SetUnknownFieldHandler(handler UnknownFieldHandler) {
+	c.unknownFields = handler
+}
+
+var jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+
+// CheckUnknownFields decodes data as generic JSON and returns, sorted, the
+// paths of fields that json.Unmarshal would silently drop when decoding
+// into v. Arrays are written as "[]" and map values as ".*". Types with
+// their own UnmarshalJSON, such as time.Time, are not looked into.
+func CheckUnknownFields(data []byte, v any) ([]string, error) {
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	seen := map[string]bool{}
+	collectUnknownFields(reflect.TypeOf(v), raw, "", seen)
+	fields := make([]string, 0, len(seen))
+	for field := range seen {
+		fields = append(fields, field)
+	}
+	slices.Sort(fields)
+	return fields, nil
+}
+
+func collectUnknownFields(t reflect.Type, raw any, path string, seen map[string]bool) {
+	if t == nil {
+		return
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := raw.(map[string]any)
+		if !ok {
+			return
+		}
+		fields := jsonFields(t)
+		for key, value := range object {
+			fieldPath := key
+			if path != "" {
+				fieldPath = path + "." + key
+			}
+			fieldType, ok := fields[strings.ToLower(key)]
+			if !ok {
+				seen[fieldPath] = true
+				continue
+			}
+			collectUnknownFields(fieldType, value, fieldPath, seen)
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := raw.([]any)
+		if !ok {
+			return
+		}
+		for _, item := range items {
+			collectUnknownFields(t.Elem(), item, path+"[]", seen)
+		}
+	case reflect.Map:
+		object, ok := raw.(map[string]any)
+		if !ok {
+			return
+		}
+		for _, value := range object {
+			collectUnknownFields(t.Elem(), value, path+".*", seen)
+		}
+	}
+}
+
+// jsonFields maps the lowercased JSON names of t's fields, including those
+// of embedded structs, to their types; encoding/json matches names without
+// regard to case
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for field := range t.Fields() {
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for key, fieldType := range jsonFields(embedded) {
+					if _, ok := fields[key]; !ok {
+						fields[key] = fieldType
+					}
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = field.Type
+	}
+	return fields
+}
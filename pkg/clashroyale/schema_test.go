@@ -0,0 +1,62 @@
+package clashroyale
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"go.uber.org/ratelimit"
+)
+
+func TestCheckUnknownFields(t *testing.T) {
+	data := []byte(`{
+		"tag": "#2PP",
+		"NAME": "case differs",
+		"createdAt": "2026-01-02T03:04:05Z",
+		"heroLevel": 3,
+		"clan": {"tag": "#9YL", "banner": {"id": 1}},
+		"cards": [{"id": 1, "evolutionTier": 2}, {"id": 2, "evolutionTier": 1, "shards": 5}],
+		"badges": [{"name": "Classic12Wins", "iconUrls": {"large": "x", "small": "y"}}]
+	}`)
+
+	got, err := CheckUnknownFields(data, &Player{})
+	if err != nil {
+		t.Fatalf("CheckUnknownFields() error = %v", err)
+	}
+	want := []string{"badges[].iconUrls.small", "cards[].evolutionTier", "cards[].shards", "clan.banner", "heroLevel"}
+	if !slices.Equal(got, want) {
+		t.Errorf("CheckUnknownFields() = %v, want %v", got, want)
+	}
+
+	if got, _ := CheckUnknownFields([]byte(`[{"type":"PvP","mystery":true}]`), &BattleLogResponse{}); !slices.Equal(got, []string{"[].mystery"}) {
+		t.Errorf("battle log unknown fields = %v", got)
+	}
+	if _, err := CheckUnknownFields([]byte(`{`), &Player{}); err == nil {
+		t.Error("expected error for malformed JSON")
+	}
+}
+
+func TestUnknownFieldHandler(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"items":[{"id":1,"name":"Knight","rarity":"Common","newStat":9}]}`)
+	}))
+	defer server.Close()
+
+	client := NewClient("token")
+	client.baseURL = server.URL
+	client.rateLimiter = ratelimit.NewUnlimited()
+	var reported []string
+	client.SetUnknownFieldHandler(func(endpoint string, fields []string) {
+		reported = append(reported, endpoint+" "+fmt.Sprint(fields))
+	})
+
+	if _, err := client.GetCardsWithContext(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"/cards [items[].newStat]"}; !slices.Equal(reported, want) {
+		t.Errorf("reported = %v, want %v", reported, want)
+	}
+}
@@ -0,0 +1,82 @@
+package clashroyale
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// TagAlphabet is every character Supercell uses in player and clan tags
+const TagAlphabet = "0289PYLQGRJCUV"
+
+// NormalizeTag trims and uppercases tag and gives it a leading # for API
+// requests. It does not validate; see ParseTag.
+func NormalizeTag(tag string) string {
+	tag = strings.ToUpper(strings.TrimSpace(tag))
+	if len(tag) > 0 && tag[0] != '#' {
+		return "#" + tag
+	}
+	return tag
+}
+
+// ParseTag normalizes a tag typed or pasted by a user and checks it against
+// TagAlphabet. A tag that only fails because of a letter O is rejected with
+// the corrected tag as a suggestion.
+func ParseTag(tag string) (string, error) {
+	normalized := NormalizeTag(tag)
+	if err := validateTagShape(normalized); err != nil {
+		return "", err
+	}
+	body := strings.TrimPrefix(normalized, "#")
+	invalid := invalidTagChars(body)
+	if len(invalid) == 0 {
+		return normalized, nil
+	}
+	fixed := "#" + strings.ReplaceAll(body, "O", "0")
+	if len(invalidTagChars(fixed[1:])) == 0 {
+		return "", fmt.Errorf("%w %q: did you mean %s? Tags use the digit 0, never the letter O", ErrInvalidTag, tag, fixed)
+	}
+	return "", fmt.Errorf("%w %q: tags only use %s, not %s", ErrInvalidTag, tag, TagAlphabet, strings.Join(invalid, ", "))
+}
+
+// invalidTagChars lists the distinct characters of body outside TagAlphabet
+func invalidTagChars(body string) []string {
+	var invalid []string
+	for _, r := range body {
+		if !strings.ContainsRune(TagAlphabet, r) && !slices.Contains(invalid, string(r)) {
+			invalid = append(invalid, string(r))
+		}
+	}
+	return invalid
+}
+
+// ValidateTag checks that tag, with or without its '#', could be a player
+// or clan tag: 3 to 15 letters and digits. It is looser than ParseTag so the
+// client accepts any tag the API might; a letter O is still rejected with a
+// hint, since tags only ever use the digit zero.
+func ValidateTag(tag string) error {
+	if err := validateTagShape(tag); err != nil {
+		return err
+	}
+	if strings.ContainsAny(tag, "Oo") {
+		return fmt.Errorf("%w %q: tags use the digit 0, never the letter O", ErrInvalidTag, tag)
+	}
+	return nil
+}
+
+// validateTagShape checks the length and characters of tag
+func validateTagShape(tag string) error {
+	body := strings.TrimPrefix(strings.TrimSpace(tag), "#")
+	if body == "" {
+		return fmt.Errorf("%w: tag is empty", ErrInvalidTag)
+	}
+	for _, r := range body {
+		if (r < '0' || r > '9') && (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') {
+			return fmt.Errorf("%w %q: tags only contain letters and digits", ErrInvalidTag, tag)
+		}
+	}
+	if len(body) < 3 || len(body) > 15 {
+		return fmt.Errorf("%w %q: tags are 3 to 15 characters long", ErrInvalidTag, tag)
+	}
+	return nil
+}
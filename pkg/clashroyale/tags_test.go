@@ -0,0 +1,63 @@
+package clashroyale
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseTag(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr string
+	}{
+		{input: "2pp", want: "#2PP"},
+		{input: " #9yl2qcv ", want: "#9YL2QCV"},
+		{input: "#2PPO", wantErr: "did you mean #2PP0?"},
+		{input: "lqgoo", wantErr: "did you mean #LQG00?"},
+		{input: "#ABC123", wantErr: "not A, B, 1, 3"},
+		{input: "#2OX", wantErr: "not O, X"},
+		{input: "#2P", wantErr: "3 to 15 characters"},
+		{input: "", wantErr: "empty"},
+	}
+	for _, tt := range tests {
+		got, err := ParseTag(tt.input)
+		if tt.wantErr == "" {
+			if err != nil || got != tt.want {
+				t.Errorf("ParseTag(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+			}
+			continue
+		}
+		if !errors.Is(err, ErrInvalidTag) || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("ParseTag(%q) error = %v, want ErrInvalidTag mentioning %q", tt.input, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateTag(t *testing.T) {
+	tests := []struct {
+		tag   string
+		valid bool
+	}{
+		{"#2PP", true},
+		{"2PP", true},
+		{" #9yl2qcv ", true},
+		{"", false},
+		{"#", false},
+		{"#2P", false},
+		{"#2", false},
+		{"#2PP-9YL", false},
+		{"#2PPO", false},
+		{"#2222222222222222", false},
+	}
+	for _, tt := range tests {
+		err := ValidateTag(tt.tag)
+		if (err == nil) != tt.valid {
+			t.Errorf("ValidateTag(%q) = %v, want valid %v", tt.tag, err, tt.valid)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidTag) {
+			t.Errorf("ValidateTag(%q) = %v, want ErrInvalidTag", tt.tag, err)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package clashroyale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/ratelimit"
+)
+
+// ErrNoActiveTokens is returned when every token in a pool has been
+// rejected by the API
+var ErrNoActiveTokens = errors.New("no usable API tokens left")
+
+// TokenStats reports how one pooled token has been used
+type TokenStats struct {
+	// Token is the token masked to its last four characters
+	Token string `json:"token"`
+	// Requests counts HTTP attempts sent with the token, including retries
+	Requests int64 `json:"requests"`
+	// Throttled counts 429 responses for the token
+	Throttled int64 `json:"throttled"`
+	// Removed is set once the API answered 403 for the token
+	Removed bool   `json:"removed"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// pooledToken is one token with its own rate limit and accounting
+type pooledToken struct {
+	value         string
+	limiter       ratelimit.Limiter
+	requests      int64
+	throttled     int64
+	removed       bool
+	reason        string
+	cooldownUntil time.Time
+}
+
+// tokenPool rotates requests across several tokens round-robin. Each
+// token is rate limited on its own, a 429 pauses only the token that got
+// it, and a 403 (token revoked or not allowed from this IP) removes it.
+type tokenPool struct {
+	mu     sync.Mutex
+	tokens []*pooledToken
+	next   int
+	now    func() time.Time
+}
+
+func newTokenPool(tokens []string) *tokenPool {
+	pool := &tokenPool{now: time.Now}
+	for _, token := range tokens {
+		pool.tokens = append(pool.tokens, &pooledToken{
+			value:   token,
+			limiter: ratelimit.New(1, ratelimit.Per(time.Second)),
+		})
+	}
+	return pool
+}
+
+// NewClientWithTokens creates a client that rotates across tokens. Blank
+// and repeated tokens are dropped; with a single token it is the same as
+// NewClient.
+func NewClientWithTokens(tokens []string) *Client {
+	seen := map[string]bool{}
+	var unique []string
+	for _, token := range tokens {
+		token = strings.TrimSpace(token)
+		if token == "" || seen[token] {
+			continue
+		}
+		seen[token] = true
+		unique = append(unique, token)
+	}
+	if len(unique) <= 1 {
+		return NewClient(strings.Join(unique, ""))
+	}
+	client := NewClient(unique[0])
+	client.pool = newTokenPool(unique)
+	return client
+}
+
+// unlimit lifts every token's rate limit
+func (p *tokenPool) unlimit() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, token := range p.tokens {
+		token.limiter = ratelimit.NewUnlimited()
+	}
+}
+
+// acquire returns the next active token that is not cooling down after a
+// 429, waiting for its rate limit. When every active token is cooling
+// down it waits for the first one to recover.
+func (p *tokenPool) acquire(ctx context.Context) (*pooledToken, error) {
+	for {
+		p.mu.Lock()
+		token, wait, err := p.pickLocked()
+		// unlimit swaps limiters under the lock, so read this one before
+		// releasing it
+		var limiter ratelimit.Limiter
+		if token != nil {
+			limiter = token.limiter
+		}
+		p.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		if token != nil {
+			limiter.Take()
+			p.mu.Lock()
+			token.requests++
+			p.mu.Unlock()
+			return token, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (p *tokenPool) pickLocked() (*pooledToken, time.Duration, error) {
+	now := p.now()
+	var wait time.Duration
+	active := 0
+	for i := range p.tokens {
+		index := (p.next + i) % len(p.tokens)
+		token := p.tokens[index]
+		if token.removed {
+			continue
+		}
+		active++
+		if remaining := token.cooldownUntil.Sub(now); remaining > 0 {
+			if wait == 0 || remaining < wait {
+				wait = remaining
+			}
+			continue
+		}
+		p.next = index + 1
+		return token, 0, nil
+	}
+	if active == 0 {
+		return nil, 0, p.exhaustedErrorLocked()
+	}
+	return nil, wait, nil
+}
+
+func (p *tokenPool) exhaustedErrorLocked() error {
+	last := ""
+	for _, token := range p.tokens {
+		if token.reason != "" {
+			last = token.reason
+		}
+	}
+	if last == "" {
+		return ErrNoActiveTokens
+	}
+	return fmt.Errorf("%w (last: %s)", ErrNoActiveTokens, last)
+}
+
+// throttle pauses token for delay after a 429
+func (p *tokenPool) throttle(token *pooledToken, delay time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	token.throttled++
+	token.cooldownUntil = p.now().Add(delay)
+}
+
+// remove drops token from the rotation and reports whether any remain
+func (p *tokenPool) remove(token *pooledToken, reason string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	token.removed = true
+	token.reason = reason
+	for _, t := range p.tokens {
+		if !t.removed {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *tokenPool) stats() []TokenStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats := make([]TokenStats, 0, len(p.tokens))
+	for _, token := range p.tokens {
+		stats = append(stats, TokenStats{
+			Token:     maskToken(token.value),
+			Requests:  token.requests,
+			Throttled: token.throttled,
+			Removed:   token.removed,
+			Reason:    token.reason,
+		})
+	}
+	return stats
+}
+
+// TokenStats returns per-token usage for a client created with several
+// tokens, in the order they were given. It is nil for a single token.
+func (c *Client) TokenStats() []TokenStats {
+	if c.pool == nil {
+		return nil
+	}
+	return c.pool.stats()
+}
+
+func maskToken(token string) string {
+	if len(token) <= 4 {
+		return strings.Repeat("*", len(token))
+	}
+	return "..." + token[len(token)-4:]
+}
+
+// sendPooled sends one attempt with the next pooled token. A token the API
+// rejects with 403 is removed and the attempt moves on to the next token;
+// once none are left the last 403 is returned.
+func (c *Client) sendPooled(req *http.Request) (*http.Response, *pooledToken, error) {
+	for {
+		token, err := c.pool.acquire(req.Context())
+		if err != nil {
+			return nil, nil, err
+		}
+		reqClone := req.Clone(req.Context())
+		reqClone.Header.Set("Authorization", "Bearer "+token.value)
+		resp, err := c.send(reqClone)
+		if err != nil || resp.StatusCode != http.StatusForbidden {
+			return resp, token, err
+		}
+		apiErr := parseAPIError(resp)
+		if !c.pool.remove(token, apiErr.Error()) {
+			return nil, nil, fmt.Errorf("%w: %w", ErrNoActiveTokens, c.proxyAccessError(apiErr))
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package clashroyale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// tokenServer answers with the status handler returns for each bearer
+// token and records which tokens were used
+func tokenServer(t *testing.T, handler func(token string) int) (*httptest.Server, *[]string) {
+	t.Helper()
+	var mu sync.Mutex
+	var used []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		mu.Lock()
+		used = append(used, token)
+		mu.Unlock()
+		status := handler(token)
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "60")
+		}
+		w.WriteHeader(status)
+		if status == http.StatusForbidden {
+			fmt.Fprint(w, `{"reason":"accessDenied","message":"Invalid authorization"}`)
+			return
+		}
+		fmt.Fprint(w, `{}`)
+	}))
+	t.Cleanup(server.Close)
+	return server, &used
+}
+
+func doPooled(t *testing.T, client *Client) error {
+	t.Helper()
+	req, err := client.NewRequest(context.Background(), http.MethodGet, "/test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Do(req)
+	if err == nil {
+		resp.Body.Close()
+	}
+	return err
+}
+
+func TestNewClientWithTokens(t *testing.T) {
+	single := NewClientWithTokens([]string{" token-a ", "", "token-a"})
+	if single.pool != nil || single.apiToken != "token-a" || single.TokenStats() != nil {
+		t.Errorf("one distinct token should make a plain client, got pool %v token %q", single.pool, single.apiToken)
+	}
+	pooled := NewClientWithTokens([]string{"token-a", "token-b", "token-a"})
+	if pooled.pool == nil || len(pooled.TokenStats()) != 2 {
+		t.Fatalf("TokenStats() = %+v, want two tokens", pooled.TokenStats())
+	}
+}
+
+func TestTokenPoolRotates(t *testing.T) {
+	server, used := tokenServer(t, func(string) int { return http.StatusOK })
+	client := NewClientWithTokens([]string{"token-aaaa", "token-bbbb", "token-cccc"})
+	client.baseURL = server.URL
+
+	start := time.Now()
+	for range 3 {
+		if err := doPooled(t, client); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Each token has its own one-per-second limit, so three requests on
+	// three tokens do not wait
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("three requests on three tokens took %v", elapsed)
+	}
+	if got := strings.Join(*used, ","); got != "token-aaaa,token-bbbb,token-cccc" {
+		t.Errorf("tokens used = %s", got)
+	}
+	for _, stats := range client.TokenStats() {
+		if stats.Requests != 1 || stats.Removed || strings.Contains(stats.Token, "token") {
+			t.Errorf("stats = %+v, want one request and a masked token", stats)
+		}
+	}
+	if client.RequestCount() != 3 {
+		t.Errorf("RequestCount() = %d, want 3", client.RequestCount())
+	}
+}
+
+func TestTokenPoolRemovesForbiddenTokens(t *testing.T) {
+	server, used := tokenServer(t, func(token string) int {
+		if token == "revoked" {
+			return http.StatusForbidden
+		}
+		return http.StatusOK
+	})
+	client := NewClientWithTokens([]string{"revoked", "good-token"})
+	client.baseURL = server.URL
+
+	for range 2 {
+		if err := doPooled(t, client); err != nil {
+			t.Fatalf("Do() error = %v, want the request to move to the good token", err)
+		}
+	}
+	if got := strings.Join(*used, ","); got != "revoked,good-token,good-token" {
+		t.Errorf("tokens used = %s, want the revoked token tried once", got)
+	}
+	stats := client.TokenStats()
+	if !stats[0].Removed || !strings.Contains(stats[0].Reason, "accessDenied") || stats[1].Removed {
+		t.Errorf("stats = %+v", stats)
+	}
+}
+
+func TestTokenPoolExhausted(t *testing.T) {
+	server, _ := tokenServer(t, func(string) int { return http.StatusForbidden })
+	client := NewClientWithTokens([]string{"token-a", "token-b"})
+	client.baseURL = server.URL
+
+	err := doPooled(t, client)
+	if !errors.Is(err, ErrNoActiveTokens) {
+		t.Fatalf("Do() error = %v, want ErrNoActiveTokens", err)
+	}
+	var apiErr APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Errorf("Do() error = %v, want the 403 APIError", err)
+	}
+	if err := doPooled(t, client); !errors.Is(err, ErrNoActiveTokens) {
+		t.Errorf("second Do() error = %v, want ErrNoActiveTokens", err)
+	}
+}
+
+func TestTokenPoolThrottledTokenBacksOff(t *testing.T) {
+	server, used := tokenServer(t, func(token string) int {
+		if token == "busy" {
+			return http.StatusTooManyRequests
+		}
+		return http.StatusOK
+	})
+	client := NewClientWithTokens([]string{"busy", "idle"})
+	client.baseURL = server.URL
+
+	start := time.Now()
+	if err := doPooled(t, client); err != nil {
+		t.Fatal(err)
+	}
+	// The 60s Retry-After only pauses the busy token
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Do() took %v, want an immediate retry on the idle token", elapsed)
+	}
+	if got := strings.Join(*used, ","); got != "busy,idle" {
+		t.Errorf("tokens used = %s", got)
+	}
+	if stats := client.TokenStats(); stats[0].Throttled != 1 || stats[0].Removed {
+		t.Errorf("stats = %+v, want the busy token throttled once", stats)
+	}
+}
+
+// Under -race this catches acquire reading a limiter that unlimit swaps
+func TestTokenPoolUnlimitDuringAcquire(t *testing.T) {
+	pool := newTokenPool([]string{"token-a", "token-b"})
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() {
+			for range 100 {
+				if _, err := pool.acquire(context.Background()); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		})
+	}
+	wg.Go(func() {
+		for range 100 {
+			pool.unlimit()
+		}
+	})
+	wg.Wait()
+}
@@ -1,48 +1,47 @@
-package clashroyale
+// Code generated by genmodels from swagger.yaml; DO NOT EDIT.
 
-import (
-	"fmt"
-	"time"
-)
+// This file holds the response models, field for field as the API sends
+// them. Edit swagger.yaml and run go generate instead of changing it;
+// methods and derived values belong in types_ext.go. CheckUnknownFields
+// reports fields the API sends that these structs lack.
 
-// NormalizeTag ensures the tag has a leading # for API requests
-func NormalizeTag(tag string) string {
-	if len(tag) > 0 && tag[0] != '#' {
-		return "#" + tag
-	}
-	return tag
-}
+package clashroyale
+
+import "time"
 
 // Player represents a player profile
 type Player struct {
-	Tag                   string    `json:"tag"`
-	Name                  string    `json:"name"`
-	NameSet               bool      `json:"nameSet"`
-	ExpLevel              int       `json:"expLevel"`
-	ExpPoints             int       `json:"expPoints"`
-	Trophies              int       `json:"trophies"`
-	BestTrophies          int       `json:"bestTrophies"`
-	Wins                  int       `json:"wins"`
-	Losses                int       `json:"losses"`
-	BattleCount           int       `json:"battleCount"`
-	ThreeCrownWins        int       `json:"threeCrownWins"`
-	ChallengeWins         int       `json:"challengeWins"`
-	ChallengeMaxWins      int       `json:"challengeMaxWins"`
-	TournamentWins        int       `json:"tournamentWins"`
-	TournamentBattleCount int       `json:"tournamentBattleCount"`
-	Role                  string    `json:"role"`
-	Clan                  *Clan     `json:"clan,omitempty"`
-	Arena                 Arena     `json:"arena"`
-	League                League    `json:"league"`
-	CurrentDeck           []Card    `json:"currentDeck,omitempty"`
-	Cards                 []Card    `json:"cards"`
-	StarPoints            int       `json:"starPoints"`
-	Donations             int       `json:"donations"`
-	TotalDonations        int       `json:"totalDonations"`
-	ChallengeCardsWon     int       `json:"challengeCardsWon"`
-	Level                 int       `json:"level"`
-	Experience            int       `json:"experience"`
-	CreatedAt             time.Time `json:"createdAt"`
+	Tag                     string    `json:"tag"`
+	Name                    string    `json:"name"`
+	NameSet                 bool      `json:"nameSet"`
+	ExpLevel                int       `json:"expLevel"`
+	ExpPoints               int       `json:"expPoints"`
+	Trophies                int       `json:"trophies"`
+	BestTrophies            int       `json:"bestTrophies"`
+	Wins                    int       `json:"wins"`
+	Losses                  int       `json:"losses"`
+	BattleCount             int       `json:"battleCount"`
+	ThreeCrownWins          int       `json:"threeCrownWins"`
+	ChallengeWins           int       `json:"challengeWins"`
+	ChallengeMaxWins        int       `json:"challengeMaxWins"`
+	TournamentWins          int       `json:"tournamentWins"`
+	TournamentBattleCount   int       `json:"tournamentBattleCount"`
+	Role                    string    `json:"role"`
+	Clan                    *Clan     `json:"clan,omitempty"`
+	Arena                   Arena     `json:"arena"`
+	League                  League    `json:"league"`
+	CurrentDeck             []Card    `json:"currentDeck,omitempty"`
+	Cards                   []Card    `json:"cards"`
+	SupportCards            []Card    `json:"supportCards,omitempty"`            // Tower troops owned
+	CurrentDeckSupportCards []Card    `json:"currentDeckSupportCards,omitempty"` // Tower troop equipped with CurrentDeck
+	Badges                  []Badge   `json:"badges,omitempty"`
+	StarPoints              int       `json:"starPoints"`
+	Donations               int       `json:"donations"`
+	TotalDonations          int       `json:"totalDonations"`
+	ChallengeCardsWon       int       `json:"challengeCardsWon"`
+	Level                   int       `json:"level"`
+	Experience              int       `json:"experience"`
+	CreatedAt               time.Time `json:"createdAt"`
 }
 
 // Clan represents player's clan information
@@ -83,6 +82,44 @@ type Member struct {
 	PreviousClanRank  int    `json:"previousClanRank"`
 }
 
+// ClanMemberList represents the paginated member list of a clan
+type ClanMemberList struct {
+	Items  []Member `json:"items"`
+	Paging Paging   `json:"paging"`
+}
+
+// CurrentRiverRace represents a clan's river race in progress
+type CurrentRiverRace struct {
+	State        string          `json:"state"`
+	Clan         RiverRaceClan   `json:"clan"`
+	Clans        []RiverRaceClan `json:"clans"`
+	SectionIndex int             `json:"sectionIndex"`
+	PeriodIndex  int             `json:"periodIndex"`
+	PeriodType   string          `json:"periodType"`
+}
+
+// RiverRaceClan represents one clan's standing in a river race
+type RiverRaceClan struct {
+	Tag          string                 `json:"tag"`
+	Name         string                 `json:"name"`
+	Fame         int                    `json:"fame"`
+	RepairPoints int                    `json:"repairPoints"`
+	PeriodPoints int                    `json:"periodPoints"`
+	ClanScore    int                    `json:"clanScore"`
+	Participants []RiverRaceParticipant `json:"participants"`
+}
+
+// RiverRaceParticipant represents a player's river race contribution
+type RiverRaceParticipant struct {
+	Tag            string `json:"tag"`
+	Name           string `json:"name"`
+	Fame           int    `json:"fame"`
+	RepairPoints   int    `json:"repairPoints"`
+	BoatAttacks    int    `json:"boatAttacks"`
+	DecksUsed      int    `json:"decksUsed"`
+	DecksUsedToday int    `json:"decksUsedToday"`
+}
+
 // Arena represents an arena
 type Arena struct {
 	ID          int    `json:"id"`
@@ -103,6 +140,18 @@ type League struct {
 type IconUrls struct {
 	Medium          string `json:"medium,omitempty"`
 	EvolutionMedium string `json:"evolutionMedium,omitempty"`
+	Large           string `json:"large,omitempty"`
+}
+
+// Badge represents a player badge. Leveled badges such as card masteries
+// report Level/MaxLevel and Progress toward Target for the next level.
+type Badge struct {
+	Name     string   `json:"name"`
+	Level    int      `json:"level,omitempty"`
+	MaxLevel int      `json:"maxLevel,omitempty"`
+	Progress int      `json:"progress"`
+	Target   int      `json:"target,omitempty"`
+	IconUrls IconUrls `json:"iconUrls"`
 }
 
 // Paging represents cursor-based pagination info
@@ -133,30 +182,6 @@ type Card struct {
 	StarLevel         int      `json:"starLevel,omitempty"`
 }
 
-// Validate checks if the card data is valid
-func (c *Card) Validate() error {
-	// Check for negative values first (basic data integrity)
-	if c.EvolutionLevel < 0 {
-		return fmt.Errorf("evolution level cannot be negative: %d", c.EvolutionLevel)
-	}
-
-	if c.MaxEvolutionLevel < 0 {
-		return fmt.Errorf("max evolution level cannot be negative: %d", c.MaxEvolutionLevel)
-	}
-
-	if c.StarLevel < 0 {
-		return fmt.Errorf("star level cannot be negative: %d", c.StarLevel)
-	}
-
-	// Check logical constraints
-	if c.EvolutionLevel > c.MaxEvolutionLevel {
-		return fmt.Errorf("evolution level %d cannot be greater than max evolution level %d",
-			c.EvolutionLevel, c.MaxEvolutionLevel)
-	}
-
-	return nil
-}
-
 // Chest represents an upcoming chest
 type Chest struct {
 	Name     string   `json:"name"`
@@ -190,6 +215,7 @@ type BattleTeam struct {
 	Crowns           int    `json:"crowns"`
 	Clan             *Clan  `json:"clan,omitempty"`
 	Cards            []Card `json:"cards,omitempty"`
+	SupportCards     []Card `json:"supportCards,omitempty"`
 }
 
 // GameMode represents a game mode
@@ -222,3 +248,22 @@ type LocationList struct {
 	Items  []Location `json:"items"`
 	Paging Paging     `json:"paging"`
 }
+
+// PlayerRanking represents a player's entry in a location leaderboard
+type PlayerRanking struct {
+	Tag          string `json:"tag"`
+	Name         string `json:"name"`
+	ExpLevel     int    `json:"expLevel"`
+	Trophies     int    `json:"trophies,omitempty"`
+	EloRating    int    `json:"eloRating,omitempty"`
+	Rank         int    `json:"rank"`
+	PreviousRank int    `json:"previousRank"`
+	Clan         *Clan  `json:"clan,omitempty"`
+	Arena        *Arena `json:"arena,omitempty"`
+}
+
+// PlayerRankingList represents the response for player ranking endpoints
+type PlayerRankingList struct {
+	Items  []PlayerRanking `json:"items"`
+	Paging Paging          `json:"paging"`
+}
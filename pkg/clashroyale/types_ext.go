@@ -0,0 +1,104 @@
+// Methods, derived values, and game rules for the response models in
+// types.go, which is generated from swagger.yaml.
+
+package clashroyale
+
+//go:generate go run ./internal/genmodels -spec swagger.yaml -out types.go
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// River race period types reported by the current river race endpoint
+const (
+	RiverRacePeriodTraining  = "training"
+	RiverRacePeriodWarDay    = "warDay"
+	RiverRacePeriodColosseum = "colosseum"
+)
+
+// GlobalLocation is the location ID for worldwide rankings
+const GlobalLocation = "global"
+
+// TowerTroopNames returns the names of the tower troops the player owns
+func (p *Player) TowerTroopNames() []string {
+	names := make([]string, 0, len(p.SupportCards))
+	for _, card := range p.SupportCards {
+		names = append(names, card.Name)
+	}
+	return names
+}
+
+// CurrentTowerTroop returns the tower troop equipped with the player's
+// current deck, or "" when the API did not report one
+func (p *Player) CurrentTowerTroop() string {
+	if len(p.CurrentDeckSupportCards) == 0 {
+		return ""
+	}
+	return p.CurrentDeckSupportCards[0].Name
+}
+
+// APITimeLayout is the timestamp layout used by fields such as lastSeen
+const APITimeLayout = "20060102T150405.000Z"
+
+// ParseAPITime parses a timestamp in the API's compact format
+func ParseAPITime(value string) (time.Time, error) {
+	return time.Parse(APITimeLayout, value)
+}
+
+// LastSeenTime parses the member's last-seen timestamp, returning the zero
+// time when it is missing or malformed
+func (m Member) LastSeenTime() time.Time {
+	t, err := ParseAPITime(m.LastSeen)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// WarDecksPerDay is the number of war decks each member can play per battle day
+const WarDecksPerDay = 4
+
+// IsBattleDay reports whether members can attack during the current period
+func (r *CurrentRiverRace) IsBattleDay() bool {
+	return r.PeriodType != "" && r.PeriodType != RiverRacePeriodTraining
+}
+
+// UnusedDecksToday returns how many war decks the participant can still play today
+func (p RiverRaceParticipant) UnusedDecksToday() int {
+	return max(WarDecksPerDay-p.DecksUsedToday, 0)
+}
+
+// MasteryBadgePrefix starts the name of every card mastery badge, e.g.
+// "MasteryHogRider"
+const MasteryBadgePrefix = "Mastery"
+
+// IsMastery reports whether the badge tracks card mastery
+func (b Badge) IsMastery() bool {
+	return strings.HasPrefix(b.Name, MasteryBadgePrefix) && len(b.Name) > len(MasteryBadgePrefix)
+}
+
+// Validate checks if the card data is valid
+func (c *Card) Validate() error {
+	// Check for negative values first (basic data integrity)
+	if c.EvolutionLevel < 0 {
+		return fmt.Errorf("evolution level cannot be negative: %d", c.EvolutionLevel)
+	}
+
+	if c.MaxEvolutionLevel < 0 {
+		return fmt.Errorf("max evolution level cannot be negative: %d", c.MaxEvolutionLevel)
+	}
+
+	if c.StarLevel < 0 {
+		return fmt.Errorf("star level cannot be negative: %d", c.StarLevel)
+	}
+
+	// Check logical constraints
+	if c.EvolutionLevel > c.MaxEvolutionLevel {
+		return fmt.Errorf("evolution level %d cannot be greater than max evolution level %d",
+			c.EvolutionLevel, c.MaxEvolutionLevel)
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package clashroyale
 
 import (
+	"encoding/json"
 	"testing"
 )
 
@@ -335,3 +336,38 @@ func TestCard_Validate_NegativeValues(t *testing.T) {
 		})
 	}
 }
+
+func TestPlayer_TowerTroops(t *testing.T) {
+	data := []byte(`{
+		"tag": "#ABC",
+		"supportCards": [{"name": "Tower Princess"}, {"name": "Cannoneer"}],
+		"currentDeckSupportCards": [{"name": "Cannoneer"}]
+	}`)
+	var player Player
+	if err := json.Unmarshal(data, &player); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	if got := player.TowerTroopNames(); len(got) != 2 || got[1] != "Cannoneer" {
+		t.Errorf("TowerTroopNames() = %v", got)
+	}
+	if got := player.CurrentTowerTroop(); got != "Cannoneer" {
+		t.Errorf("CurrentTowerTroop() = %q, want Cannoneer", got)
+	}
+	if got := (&Player{}).CurrentTowerTroop(); got != "" {
+		t.Errorf("CurrentTowerTroop() without support cards = %q, want empty", got)
+	}
+}
+
+func TestBadge_IsMastery(t *testing.T) {
+	tests := map[string]bool{
+		"MasteryHogRider": true,
+		"Mastery":         false,
+		"Classic12Wins":   false,
+	}
+	for name, want := range tests {
+		if got := (Badge{Name: name}).IsMastery(); got != want {
+			t.Errorf("Badge{%q}.IsMastery() = %v, want %v", name, got, want)
+		}
+	}
+}
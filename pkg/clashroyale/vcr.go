@@ -0,0 +1,233 @@
+package clashroyale
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.uber.org/ratelimit"
+)
+
+// VCRMode selects whether a VCRTransport records or replays
+type VCRMode string
+
+const (
+	// VCRRecord sends requests to the API and saves every response
+	VCRRecord VCRMode = "record"
+	// VCRReplay serves saved responses and never contacts the API
+	VCRReplay VCRMode = "replay"
+)
+
+// ParseVCRMode validates a --vcr value
+func ParseVCRMode(value string) (VCRMode, error) {
+	switch mode := VCRMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case VCRRecord, VCRReplay:
+		return mode, nil
+	}
+	return "", fmt.Errorf("invalid VCR mode %q: use record or replay", value)
+}
+
+// ErrNotRecorded is returned in replay mode for a request that has no
+// saved response
+var ErrNotRecorded = errors.New("vcr: no recorded response")
+
+// Recording is one saved response, stored as a JSON file in the cassette
+// directory. The Authorization header is never saved, so recordings can be
+// shared and checked in.
+type Recording struct {
+	Method     string          `json:"method"`
+	Path       string          `json:"path"`
+	RecordedAt time.Time       `json:"recorded_at"`
+	StatusCode int             `json:"status_code"`
+	Header     http.Header     `json:"header,omitempty"`
+	Body       json.RawMessage `json:"body,omitempty"`
+	// Text holds a body that is not JSON
+	Text string `json:"text,omitempty"`
+}
+
+// vcrHeaders are the response headers worth replaying
+var vcrHeaders = []string{"Content-Type", "Retry-After"}
+
+// VCRTransport is an http.RoundTripper that records API responses to Dir,
+// one file per request, and replays them later. Requests are matched on
+// method, path below the /v1 prefix, and query, so a recording made
+// through one API base replays through any other. Recording the same
+// request again overwrites the older response.
+type VCRTransport struct {
+	Dir  string
+	Mode VCRMode
+	// Next sends recorded requests; nil uses http.DefaultTransport
+	Next http.RoundTripper
+}
+
+// NewVCRTransport returns a transport that records to or replays from dir
+func NewVCRTransport(dir string, mode VCRMode) *VCRTransport {
+	return &VCRTransport{Dir: dir, Mode: mode}
+}
+
+// RoundTrip records or replays req
+func (t *VCRTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := vcrRequestPath(req)
+	file := filepath.Join(t.Dir, vcrFileName(req.Method, path))
+	if t.Mode == VCRReplay {
+		return t.replay(req, file, path)
+	}
+
+	next := t.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("vcr: failed to read response: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	rec := Recording{
+		Method:     req.Method,
+		Path:       path,
+		RecordedAt: time.Now().UTC(),
+		StatusCode: resp.StatusCode,
+		Header:     http.Header{},
+	}
+	for _, name := range vcrHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			rec.Header.Set(name, value)
+		}
+	}
+	if json.Valid(body) {
+		rec.Body = body
+	} else {
+		rec.Text = string(body)
+	}
+	if err := writeRecording(file, rec); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (t *VCRTransport) replay(req *http.Request, file, path string) (*http.Response, error) {
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w for %s %s in %s", ErrNotRecorded, req.Method, path, t.Dir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("vcr: failed to read recording: %w", err)
+	}
+	var rec Recording
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("vcr: failed to decode %s: %w", file, err)
+	}
+
+	body := []byte(rec.Text)
+	if len(rec.Body) > 0 {
+		body = rec.Body
+	}
+	header := rec.Header
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.StatusCode, http.StatusText(rec.StatusCode)),
+		StatusCode:    rec.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// ReadRecordings returns every response saved in dir, in file name order
+func ReadRecordings(dir string) ([]Recording, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	recordings := make([]Recording, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var rec Recording
+		if err := json.Unmarshal(data, &rec); err != nil {
+			return nil, fmt.Errorf("vcr: failed to decode %s: %w", file, err)
+		}
+		recordings = append(recordings, rec)
+	}
+	return recordings, nil
+}
+
+// SetVCR routes the client's requests through a VCRTransport on dir. In
+// replay mode the rate limit is lifted, since nothing reaches the API.
+func (c *Client) SetVCR(dir string, mode VCRMode) {
+	transport := NewVCRTransport(dir, mode)
+	transport.Next = c.httpClient.Transport
+	c.httpClient.Transport = transport
+	if mode == VCRReplay {
+		c.rateLimiter = ratelimit.NewUnlimited()
+		if c.pool != nil {
+			c.pool.unlimit()
+		}
+	}
+}
+
+// vcrRequestPath is the request path below the /v1 prefix, with its query
+func vcrRequestPath(req *http.Request) string {
+	path := req.URL.EscapedPath()
+	if i := strings.Index(path, "/v1/"); i >= 0 {
+		path = path[i+len("/v1"):]
+	}
+	if req.URL.RawQuery != "" {
+		path += "?" + req.URL.RawQuery
+	}
+	return path
+}
+
+// vcrFileName keeps a readable prefix of the path and a hash that makes
+// it unique
+func vcrFileName(method, path string) string {
+	sum := sha256.Sum256([]byte(method + " " + path))
+	slug := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		}
+		return '_'
+	}, strings.TrimPrefix(strings.ReplaceAll(path, "%23", ""), "/"))
+	if len(slug) > 60 {
+		slug = slug[:60]
+	}
+	return fmt.Sprintf("%s_%s.json", slug, hex.EncodeToString(sum[:6]))
+}
+
+func writeRecording(file string, rec Recording) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return fmt.Errorf("vcr: failed to create cassette directory: %w", err)
+	}
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return fmt.Errorf("vcr: failed to encode recording: %w", err)
+	}
+	if err := os.WriteFile(file, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("vcr: failed to write recording: %w", err)
+	}
+	return nil
+}
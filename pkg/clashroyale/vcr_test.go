@@ -0,0 +1,106 @@
+package clashroyale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/ratelimit"
+)
+
+func TestVCRRecordThenReplay(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/players/#QQQ" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"reason":"notFound"}`)
+			return
+		}
+		fmt.Fprint(w, `{"tag":"#2PP","name":"Recorded"}`)
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	recorder := NewClient("secret-token")
+	recorder.SetBaseURL(server.URL + "/v1")
+	recorder.rateLimiter = ratelimit.NewUnlimited()
+	recorder.SetVCR(dir, VCRRecord)
+	if _, err := recorder.GetPlayerWithContext(ctx, "#2PP"); err != nil {
+		t.Fatalf("record GetPlayer error = %v", err)
+	}
+	if _, err := recorder.GetPlayerWithContext(ctx, "#QQQ"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("record missing player error = %v", err)
+	}
+
+	recordings, err := ReadRecordings(dir)
+	if err != nil || len(recordings) != 2 {
+		t.Fatalf("ReadRecordings() = %d, %v; want 2", len(recordings), err)
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	for _, file := range files {
+		data, _ := os.ReadFile(file)
+		if strings.Contains(string(data), "secret-token") {
+			t.Errorf("%s contains the API token", file)
+		}
+	}
+
+	// Replay against another base, with the server gone
+	server.Close()
+	before := atomic.LoadInt32(&hits)
+	replayer := NewClient("")
+	replayer.SetVCR(dir, VCRReplay)
+	player, err := replayer.GetPlayerWithContext(ctx, "2pp")
+	if err != nil || player.Name != "Recorded" {
+		t.Fatalf("replay GetPlayer = %+v, %v", player, err)
+	}
+	if _, err := replayer.GetPlayerWithContext(ctx, "#QQQ"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("replay missing player error = %v, want ErrNotFound", err)
+	}
+	if atomic.LoadInt32(&hits) != before {
+		t.Error("replay reached the server")
+	}
+}
+
+func TestVCRReplayUnrecorded(t *testing.T) {
+	client := NewClient("")
+	client.SetVCR(t.TempDir(), VCRReplay)
+
+	started := time.Now()
+	_, err := client.GetClanWithContext(context.Background(), "#9YL")
+	if !errors.Is(err, ErrNotRecorded) || !strings.Contains(err.Error(), "/clans/%239YL") {
+		t.Errorf("error = %v, want ErrNotRecorded naming the path", err)
+	}
+	if elapsed := time.Since(started); elapsed > 500*time.Millisecond {
+		t.Errorf("unrecorded request took %v; it should not be retried", elapsed)
+	}
+}
+
+func TestParseVCRMode(t *testing.T) {
+	if mode, err := ParseVCRMode(" Replay "); err != nil || mode != VCRReplay {
+		t.Errorf("ParseVCRMode(Replay) = %q, %v", mode, err)
+	}
+	if _, err := ParseVCRMode("rewind"); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+}
+
+func TestVCRFileName(t *testing.T) {
+	name := vcrFileName("GET", "/players/%232PP/battlelog")
+	if !strings.HasPrefix(name, "players_2PP_battlelog_") || !strings.HasSuffix(name, ".json") {
+		t.Errorf("vcrFileName() = %q", name)
+	}
+	if name == vcrFileName("GET", "/players/%232PP") {
+		t.Error("different paths share a file name")
+	}
+}
@@ -0,0 +1,222 @@
+// Package collection reports how complete a player's card collection is:
+// owned versus total cards per rarity, the cards still missing and where
+// they unlock, duplicate cards beyond max level, and when the collection
+// should complete at the player's recent rate of new cards.
+package collection
+
+import (
+	"sort"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+)
+
+// minProjectionSpan is the shortest stretch of history a projection is fitted to.
+const minProjectionSpan = 24 * time.Hour
+
+// starPointsPerCard approximates the star points each duplicate card beyond
+// max level converts to. Values are used to size the overflow, not to
+// promise exact payouts.
+var starPointsPerCard = map[string]int{
+	"Common":    1,
+	"Rare":      10,
+	"Epic":      100,
+	"Legendary": 1000,
+	"Champion":  1000,
+}
+
+// Snapshot is the number of distinct cards owned at a point in time.
+type Snapshot struct {
+	Time  time.Time `json:"time"`
+	Owned int       `json:"owned"`
+}
+
+// RarityCompletion is owned versus total cards of one rarity.
+type RarityCompletion struct {
+	Rarity  string  `json:"rarity"`
+	Owned   int     `json:"owned"`
+	Total   int     `json:"total"`
+	Percent float64 `json:"percent"`
+}
+
+// MissingCard is a card the player does not own and the arena it unlocks in.
+type MissingCard struct {
+	Name        string `json:"name"`
+	Rarity      string `json:"rarity"`
+	UnlockArena int    `json:"unlock_arena"`
+	ArenaName   string `json:"arena_name"`
+	// Unlocked reports whether the player's arena already offers the card
+	Unlocked bool `json:"unlocked"`
+}
+
+// Overflow is a card with more copies than it needs to reach max level.
+type Overflow struct {
+	Name       string `json:"name"`
+	Rarity     string `json:"rarity"`
+	ExtraCards int    `json:"extra_cards"`
+	StarPoints int    `json:"star_points"`
+}
+
+// Projection estimates when the collection completes.
+type Projection struct {
+	CardsPerDay   float64   `json:"cards_per_day"`
+	DaysRemaining float64   `json:"days_remaining"`
+	CompletesAt   time.Time `json:"completes_at"`
+	// Since is the start of the history the rate was measured over
+	Since time.Time `json:"since"`
+}
+
+// Report summarizes a player's collection completion.
+type Report struct {
+	PlayerTag        string             `json:"player_tag"`
+	PlayerName       string             `json:"player_name,omitempty"`
+	Owned            int                `json:"owned"`
+	Total            int                `json:"total"`
+	Percent          float64            `json:"percent"`
+	Rarities         []RarityCompletion `json:"rarities"`
+	Missing          []MissingCard      `json:"missing,omitempty"`
+	Overflow         []Overflow         `json:"overflow,omitempty"`
+	OverflowPoints   int                `json:"overflow_star_points"`
+	StarPoints       int                `json:"star_points"`
+	Projection       *Projection        `json:"projection,omitempty"`
+	ProjectionReason string             `json:"projection_reason,omitempty"`
+}
+
+// Build compares the player's cards with the full card list. history is the
+// owned count from earlier snapshots; together with the current collection it
+// sets the rate used for the completion projection.
+func Build(player *clashroyale.Player, allCards []clashroyale.Card, history []Snapshot, now time.Time) *Report {
+	report := &Report{
+		PlayerTag:  player.Tag,
+		PlayerName: player.Name,
+		StarPoints: player.StarPoints,
+	}
+
+	owned := make(map[string]clashroyale.Card, len(player.Cards))
+	for _, card := range player.Cards {
+		owned[card.Name] = card
+	}
+
+	arena := evaluation.ArenaNumber(player.Arena.ID)
+	byRarity := make(map[string]*RarityCompletion)
+	for _, card := range allCards {
+		rarity := config.NormalizeRarity(card.Rarity)
+		stats, ok := byRarity[rarity]
+		if !ok {
+			stats = &RarityCompletion{Rarity: rarity}
+			byRarity[rarity] = stats
+		}
+		stats.Total++
+		report.Total++
+
+		if _, ok := owned[card.Name]; ok {
+			stats.Owned++
+			report.Owned++
+			continue
+		}
+		unlock := evaluation.CardUnlockArena(card.Name)
+		report.Missing = append(report.Missing, MissingCard{
+			Name:        card.Name,
+			Rarity:      rarity,
+			UnlockArena: unlock,
+			ArenaName:   evaluation.ArenaName(unlock),
+			Unlocked:    evaluation.IsCardUnlockedAt(card.Name, arena),
+		})
+	}
+	report.Percent = percent(report.Owned, report.Total)
+
+	for _, rarity := range config.GetAllRarities() {
+		if stats, ok := byRarity[rarity]; ok {
+			stats.Percent = percent(stats.Owned, stats.Total)
+			report.Rarities = append(report.Rarities, *stats)
+			delete(byRarity, rarity)
+		}
+	}
+	for _, stats := range byRarity {
+		stats.Percent = percent(stats.Owned, stats.Total)
+		report.Rarities = append(report.Rarities, *stats)
+	}
+
+	sort.SliceStable(report.Missing, func(i, j int) bool {
+		a, b := report.Missing[i], report.Missing[j]
+		if a.UnlockArena != b.UnlockArena {
+			return a.UnlockArena < b.UnlockArena
+		}
+		return a.Name < b.Name
+	})
+
+	for _, card := range player.Cards {
+		if o, ok := overflow(card); ok {
+			report.Overflow = append(report.Overflow, o)
+			report.OverflowPoints += o.StarPoints
+		}
+	}
+	sort.SliceStable(report.Overflow, func(i, j int) bool {
+		return report.Overflow[i].StarPoints > report.Overflow[j].StarPoints
+	})
+
+	report.Projection, report.ProjectionReason = project(report, len(player.Cards), history, now)
+	return report
+}
+
+// overflow returns the copies of card beyond what it needs to reach max level
+func overflow(card clashroyale.Card) (Overflow, bool) {
+	rarity := config.NormalizeRarity(card.Rarity)
+	// The API reports levels relative to the rarity's starting level
+	level := card.Level + config.GetStartingLevel(rarity) - 1
+	extra := card.Count - config.CalculateTotalCardsToMax(level, rarity)
+	if extra <= 0 {
+		return Overflow{}, false
+	}
+	return Overflow{
+		Name:       card.Name,
+		Rarity:     rarity,
+		ExtraCards: extra,
+		StarPoints: extra * starPointsPerCard[rarity],
+	}, true
+}
+
+// project fits the rate of new cards from the oldest snapshot to the current
+// owned count. It returns a reason instead when the collection is complete or
+// the history is too short or flat to project from.
+func project(report *Report, current int, history []Snapshot, now time.Time) (*Projection, string) {
+	remaining := report.Total - report.Owned
+	if remaining <= 0 {
+		return nil, "collection complete"
+	}
+	if len(history) == 0 {
+		return nil, "no recorded history"
+	}
+	oldest := history[0]
+	for _, s := range history[1:] {
+		if s.Time.Before(oldest.Time) {
+			oldest = s
+		}
+	}
+	span := now.Sub(oldest.Time)
+	if span < minProjectionSpan {
+		return nil, "history covers less than a day"
+	}
+	gained := current - oldest.Owned
+	if gained <= 0 {
+		return nil, "no new cards since " + oldest.Time.Format("2006-01-02")
+	}
+
+	perDay := float64(gained) / span.Hours() * 24
+	days := float64(remaining) / perDay
+	return &Projection{
+		CardsPerDay:   perDay,
+		DaysRemaining: days,
+		CompletesAt:   now.Add(time.Duration(days * float64(24*time.Hour))),
+		Since:         oldest.Time,
+	}, ""
+}
+
+func percent(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total) * 100
+}
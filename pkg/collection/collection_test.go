@@ -0,0 +1,137 @@
+package collection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+func testCatalog() []clashroyale.Card {
+	return []clashroyale.Card{
+		{Name: "Knight", Rarity: "common"},
+		{Name: "Archers", Rarity: "common"},
+		{Name: "Musketeer", Rarity: "rare"},
+		{Name: "Golem", Rarity: "epic"},
+		{Name: "The Log", Rarity: "legendary"},
+		{Name: "Miner", Rarity: "legendary"},
+	}
+}
+
+func testPlayer() *clashroyale.Player {
+	return &clashroyale.Player{
+		Tag:        "#ABC",
+		Name:       "Tester",
+		Arena:      clashroyale.Arena{ID: 54000005},
+		StarPoints: 1200,
+		Cards: []clashroyale.Card{
+			// Max level with 30 spare copies
+			{Name: "Knight", Rarity: "common", Level: 16, MaxLevel: 16, Count: 30},
+			// Still needs cards to max
+			{Name: "Archers", Rarity: "common", Level: 10, MaxLevel: 16, Count: 100},
+			{Name: "Golem", Rarity: "epic", Level: 11, MaxLevel: 11, Count: 2},
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	report := Build(testPlayer(), testCatalog(), nil, now)
+
+	if report.Owned != 3 || report.Total != 6 || report.Percent != 50 {
+		t.Errorf("owned %d/%d (%.1f%%), want 3/6 (50%%)", report.Owned, report.Total, report.Percent)
+	}
+
+	wantRarities := []RarityCompletion{
+		{Rarity: "Common", Owned: 2, Total: 2, Percent: 100},
+		{Rarity: "Rare", Owned: 0, Total: 1, Percent: 0},
+		{Rarity: "Epic", Owned: 1, Total: 1, Percent: 100},
+		{Rarity: "Legendary", Owned: 0, Total: 2, Percent: 0},
+	}
+	if len(report.Rarities) != len(wantRarities) {
+		t.Fatalf("rarities = %+v", report.Rarities)
+	}
+	for i, want := range wantRarities {
+		if report.Rarities[i] != want {
+			t.Errorf("rarities[%d] = %+v, want %+v", i, report.Rarities[i], want)
+		}
+	}
+
+	// Missing cards sorted by unlock arena; the player is in arena 5
+	if len(report.Missing) != 3 {
+		t.Fatalf("missing = %+v", report.Missing)
+	}
+	if m := report.Missing[0]; m.Name != "Musketeer" || m.UnlockArena != 0 || !m.Unlocked {
+		t.Errorf("missing[0] = %+v, want an unlocked Musketeer", m)
+	}
+	if m := report.Missing[1]; m.Name != "Miner" || m.UnlockArena != 6 || m.Unlocked || m.ArenaName == "" {
+		t.Errorf("missing[1] = %+v, want Miner locked until arena 6", m)
+	}
+
+	if len(report.Overflow) != 2 {
+		t.Fatalf("overflow = %+v", report.Overflow)
+	}
+	if o := report.Overflow[0]; o.Name != "Golem" || o.ExtraCards != 2 || o.StarPoints != 200 {
+		t.Errorf("overflow[0] = %+v, want 2 Golem copies worth 200", o)
+	}
+	if o := report.Overflow[1]; o.Name != "Knight" || o.ExtraCards != 30 || o.StarPoints != 30 {
+		t.Errorf("overflow[1] = %+v, want 30 Knight copies worth 30", o)
+	}
+	if report.OverflowPoints != 230 || report.StarPoints != 1200 {
+		t.Errorf("overflow points %d, star points %d", report.OverflowPoints, report.StarPoints)
+	}
+
+	if report.Projection != nil || report.ProjectionReason == "" {
+		t.Errorf("expected no projection without history, got %+v", report.Projection)
+	}
+}
+
+func TestBuildProjection(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	history := []Snapshot{
+		{Time: now.AddDate(0, 0, -5), Owned: 2},
+		{Time: now.AddDate(0, 0, -10), Owned: 1},
+	}
+	report := Build(testPlayer(), testCatalog(), history, now)
+
+	// 2 new cards in 10 days, 3 still missing
+	p := report.Projection
+	if p == nil {
+		t.Fatalf("expected a projection, reason %q", report.ProjectionReason)
+	}
+	if p.CardsPerDay != 0.2 || p.DaysRemaining != 15 || !p.Since.Equal(history[1].Time) {
+		t.Errorf("projection = %+v", p)
+	}
+	if want := now.AddDate(0, 0, 15); !p.CompletesAt.Equal(want) {
+		t.Errorf("completes at %s, want %s", p.CompletesAt, want)
+	}
+}
+
+func TestBuildProjectionReasons(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		history []Snapshot
+	}{
+		{"too short", []Snapshot{{Time: now.Add(-time.Hour), Owned: 1}}},
+		{"no progress", []Snapshot{{Time: now.AddDate(0, 0, -7), Owned: 3}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Build(testPlayer(), testCatalog(), tt.history, now)
+			if report.Projection != nil || report.ProjectionReason == "" {
+				t.Errorf("projection = %+v, reason %q", report.Projection, report.ProjectionReason)
+			}
+		})
+	}
+
+	player := testPlayer()
+	player.Cards = append(player.Cards,
+		clashroyale.Card{Name: "Musketeer", Rarity: "rare", Level: 1, MaxLevel: 14},
+		clashroyale.Card{Name: "The Log", Rarity: "legendary", Level: 1, MaxLevel: 8},
+		clashroyale.Card{Name: "Miner", Rarity: "legendary", Level: 1, MaxLevel: 8},
+	)
+	if report := Build(player, testCatalog(), nil, now); report.ProjectionReason != "collection complete" {
+		t.Errorf("complete collection reason = %q", report.ProjectionReason)
+	}
+}
@@ -3,6 +3,7 @@ package deck
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"sort"
 	"strings"
 	"testing"
@@ -670,3 +671,19 @@ func BenchmarkThroughput(b *testing.B) {
 
 	b.ReportMetric(rate, "decks/sec")
 }
+
+// BenchmarkDeckFuzzer_GenerateRandomDeck benchmarks role-composed random decks,
+// the generation step of `deck fuzz`
+func BenchmarkDeckFuzzer_GenerateRandomDeck(b *testing.B) {
+	fuzzer, err := NewDeckFuzzer(newParallelTestPlayer(), &FuzzingConfig{Seed: 12345})
+	if err != nil {
+		b.Fatal(err)
+	}
+	rng := rand.New(rand.NewSource(12345))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = fuzzer.GenerateRandomDeckWithRng(rng)
+	}
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "decks/sec")
+}
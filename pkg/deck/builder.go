@@ -2,7 +2,6 @@
 package deck
 
 import (
-	"encoding/json"
 	"fmt"
 	"math"
 	"os"
@@ -14,6 +13,7 @@ import (
 
 	"github.com/klauer/clash-royale-api/go/internal/config"
 	"github.com/klauer/clash-royale-api/go/internal/playertag"
+	"github.com/klauer/clash-royale-api/go/internal/schema"
 	"github.com/klauer/clash-royale-api/go/internal/storage"
 	"github.com/klauer/clash-royale-api/go/internal/util"
 	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
@@ -43,6 +43,7 @@ type Builder struct {
 	includeCards               []string                  // Cards to force into the deck
 	excludeCards               []string                  // Cards to exclude from consideration
 	fuzzIntegration            *FuzzIntegration          // Fuzz stats integration for data-driven card scoring
+	counterMatrix              *CounterMatrix            // Lazily loaded for tower troop recommendations
 }
 
 // NewBuilder creates a new deck builder instance
@@ -72,14 +73,9 @@ func NewBuilder(dataDir string) *Builder {
 		synergyCache:               make(map[string]float64),
 	}
 
-	// Try to load combat stats
+	// Load combat stats, layering any data-dir overrides over the bundled dataset
 	statsPath := filepath.Join(dataDir, "cards_stats.json")
-	if stats, err := clashroyale.LoadStats(statsPath); err == nil {
-		builder.statsRegistry = stats
-	} else {
-		// Log error if needed, for now just silent failure or maybe print to stderr
-		// fmt.Fprintf(os.Stderr, "Warning: Failed to load card stats from %s: %v\n", statsPath, err)
-	}
+	builder.statsRegistry, _ = clashroyale.LoadStatsWithDefaults(statsPath)
 
 	// Initialize level curve framework
 	levelCurvePath := "config/card_level_curves.json"
@@ -119,6 +115,8 @@ type CardAnalysis struct {
 	AnalysisTime string                   `json:"analysis_time,omitempty"`
 	PlayerName   string                   `json:"player_name,omitempty"`
 	PlayerTag    string                   `json:"player_tag,omitempty"`
+	TowerTroops  []string                 `json:"tower_troops,omitempty"` // Tower troops the player owns
+	ArenaID      int                      `json:"arena_id,omitempty"`     // API arena ID; limits candidates to unlocked cards
 }
 
 // CardLevelData represents card level and metadata from analysis
@@ -171,10 +169,26 @@ func (b *Builder) BuildDeckFromAnalysis(analysis CardAnalysis) (*DeckRecommendat
 
 	recommendation := b.buildRecommendationDetails(deck, analysis.AnalysisTime, evolutionSlots, notes)
 	b.finalizeRecommendation(recommendation)
+	b.recommendTowerTroop(recommendation, analysis.TowerTroops)
 
 	return recommendation, nil
 }
 
+// recommendTowerTroop picks the owned tower troop that best covers the deck's
+// defensive gaps. Analyses without tower troop data leave the slot empty.
+func (b *Builder) recommendTowerTroop(recommendation *DeckRecommendation, owned []string) {
+	if len(owned) == 0 {
+		return
+	}
+	if b.counterMatrix == nil {
+		b.counterMatrix = LoadCounterMatrix(b.dataDir, "")
+	}
+
+	choice := RecommendTowerTroop(b.counterMatrix, recommendation.Deck, owned)
+	recommendation.TowerTroop = choice.Name
+	recommendation.AddNote(fmt.Sprintf("Tower troop: %s (%s)", choice.Name, choice.Reason))
+}
+
 // filterExcludedCards removes excluded cards from the candidate pool
 func (b *Builder) filterExcludedCards(candidates []*CardCandidate) []*CardCandidate {
 	if len(b.excludeCards) == 0 {
@@ -494,7 +508,7 @@ func (b *Builder) LoadAnalysis(analysisPath string) (*CardAnalysis, error) {
 	}
 
 	var analysis CardAnalysis
-	if err := json.Unmarshal(data, &analysis); err != nil {
+	if err := schema.Unmarshal(schema.KindAnalysis, data, &analysis); err != nil {
 		return nil, fmt.Errorf("failed to parse analysis JSON: %w", err)
 	}
 
@@ -550,7 +564,11 @@ func (b *Builder) SaveDeck(deckData *DeckRecommendation, outputDir, playerTag st
 	filename := fmt.Sprintf("%s_deck_%s.json", timestamp, cleanTag)
 	path := filepath.Join(outputDir, filename)
 
-	if err := storage.WriteJSON(path, deckData); err != nil {
+	doc, err := schema.Stamp(deckData)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode deck: %w", err)
+	}
+	if err := storage.WriteJSON(path, doc); err != nil {
 		return "", fmt.Errorf("failed to write deck file: %w", err)
 	}
 
@@ -588,11 +606,6 @@ func (b *Builder) buildCandidate(name string, data CardLevelData) *CardCandidate
 	hasEvolution := data.MaxEvolutionLevel > 0 && b.unlockedEvolutions[name]
 	evoPriority := b.getEvolutionPriority(role)
 
-	var stats *clashroyale.CombatStats
-	if b.statsRegistry != nil {
-		stats = b.statsRegistry.GetStats(name)
-	}
-
 	// Create candidate first (score will be calculated next)
 	candidate := &CardCandidate{
 		Name:              name,
@@ -606,8 +619,8 @@ func (b *Builder) buildCandidate(name string, data CardLevelData) *CardCandidate
 		EvolutionPriority: evoPriority,
 		EvolutionLevel:    data.EvolutionLevel,
 		MaxEvolutionLevel: data.MaxEvolutionLevel,
-		Stats:             stats,
 	}
+	candidate.Stats = b.getStatsForCard(candidate)
 
 	// Calculate score using strategy-aware scoring
 	score := ScoreCardWithStrategy(candidate, role, b.strategyConfig, b.levelCurve)
@@ -1012,7 +1025,7 @@ func (b *Builder) LoadDeckFromFile(deckPath string) (*DeckRecommendation, error)
 	}
 
 	var recommendation DeckRecommendation
-	if err := json.Unmarshal(data, &recommendation); err != nil {
+	if err := schema.Unmarshal(schema.KindDeck, data, &recommendation); err != nil {
 		return nil, fmt.Errorf("failed to parse deck JSON: %w", err)
 	}
 
@@ -1176,18 +1189,19 @@ func (b *Builder) getUpgradeCandidates(deck *DeckRecommendation, cardLevels map[
 
 		// Calculate current and upgraded scores
 		role := b.inferRole(card.Name)
+		current := &CardCandidate{
+			Name:              card.Name,
+			Level:             card.Level,
+			MaxLevel:          card.MaxLevel,
+			Rarity:            card.Rarity,
+			Elixir:            card.Elixir,
+			Role:              role,
+			EvolutionLevel:    card.EvolutionLevel,
+			MaxEvolutionLevel: card.MaxEvolutionLevel,
+		}
+		current.Stats = b.getStatsForCard(current)
 		currentScore := ScoreCardWithStrategy(
-			&CardCandidate{
-				Name:              card.Name,
-				Level:             card.Level,
-				MaxLevel:          card.MaxLevel,
-				Rarity:            card.Rarity,
-				Elixir:            card.Elixir,
-				Role:              role,
-				EvolutionLevel:    card.EvolutionLevel,
-				MaxEvolutionLevel: card.MaxEvolutionLevel,
-				Stats:             b.getStatsForCard(card.Name),
-			},
+			current,
 			role,
 			b.strategyConfig,
 			b.levelCurve,
@@ -1195,18 +1209,11 @@ func (b *Builder) getUpgradeCandidates(deck *DeckRecommendation, cardLevels map[
 
 		targetLevel := min(card.Level+1, card.MaxLevel)
 
+		upgraded := *current
+		upgraded.Level = targetLevel
+		upgraded.Stats = b.getStatsForCard(&upgraded)
 		upgradedScore := ScoreCardWithStrategy(
-			&CardCandidate{
-				Name:              card.Name,
-				Level:             targetLevel,
-				MaxLevel:          card.MaxLevel,
-				Rarity:            card.Rarity,
-				Elixir:            card.Elixir,
-				Role:              role,
-				EvolutionLevel:    card.EvolutionLevel,
-				MaxEvolutionLevel: card.MaxEvolutionLevel,
-				Stats:             b.getStatsForCard(card.Name),
-			},
+			&upgraded,
 			role,
 			b.strategyConfig,
 			b.levelCurve,
@@ -1254,12 +1261,9 @@ func (b *Builder) getUpgradeCandidates(deck *DeckRecommendation, cardLevels map[
 	return candidates
 }
 
-// getStatsForCard returns combat stats for a card if available
-func (b *Builder) getStatsForCard(cardName string) *clashroyale.CombatStats {
-	if b.statsRegistry != nil {
-		return b.statsRegistry.GetStats(cardName)
-	}
-	return nil
+// getStatsForCard returns combat stats for a card at its level if available
+func (b *Builder) getStatsForCard(card *CardCandidate) *clashroyale.CombatStats {
+	return CombatStatsFor(b.statsRegistry, card.Name, card.StandardLevel())
 }
 
 // getGoldCost returns the gold needed to upgrade a card from its current level
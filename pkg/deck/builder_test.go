@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
 )
 
 func TestBuilder_BuildDeckFromAnalysis(t *testing.T) {
@@ -172,7 +174,7 @@ func TestBuilder_LoadAndSaveDeck(t *testing.T) {
 	}
 
 	// Test saving deck
-	deckPath, err := builder.SaveDeck(deck, tempDir, "#TEST123")
+	deckPath, err := builder.SaveDeck(deck, tempDir, "#2PP0Q")
 	if err != nil {
 		t.Fatalf("Failed to save deck: %v", err)
 	}
@@ -183,7 +185,7 @@ func TestBuilder_LoadAndSaveDeck(t *testing.T) {
 	}
 
 	// Verify filename format
-	expectedPattern := "*_deck_TEST123.json"
+	expectedPattern := "*_deck_2PP0Q.json"
 	matched, _ := filepath.Match(expectedPattern, filepath.Base(deckPath))
 	if !matched {
 		t.Errorf("Deck filename doesn't match expected pattern: %s", filepath.Base(deckPath))
@@ -807,6 +809,50 @@ func TestCardCandidate_LevelRatio(t *testing.T) {
 	}
 }
 
+func TestCardCandidate_StandardLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		card     CardCandidate
+		expected int
+	}{
+		{"standard scale", CardCandidate{Level: 14, MaxLevel: 16, Rarity: "Epic"}, 14},
+		{"legendary relative to its max", CardCandidate{Level: 6, MaxLevel: 8, Rarity: "Legendary"}, 14},
+		{"max level", CardCandidate{Level: 6, MaxLevel: 6, Rarity: "Champion"}, 16},
+		{"unknown max level", CardCandidate{Level: 11, Rarity: "Rare"}, 11},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.card.StandardLevel(); got != tt.expected {
+				t.Errorf("StandardLevel() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestApplyCombatStats(t *testing.T) {
+	cards := []CardCandidate{
+		{Name: "Knight", Level: 11, MaxLevel: 16, Rarity: "Common"},
+		{Name: "Knight", Level: 14, MaxLevel: 16, Rarity: "Common"},
+		{Name: "Archers", Level: 14, MaxLevel: 16, Stats: &clashroyale.CombatStats{Hitpoints: 1}},
+		{Name: "Not A Card", Level: 11, MaxLevel: 16},
+	}
+	ApplyCombatStats(cards, nil)
+
+	if cards[0].Stats == nil || cards[0].Stats.Targets != "Ground" || cards[0].Stats.DamagePerSecond == 0 {
+		t.Fatalf("Knight stats = %+v, want bundled stats", cards[0].Stats)
+	}
+	if cards[1].Stats.Hitpoints <= cards[0].Stats.Hitpoints {
+		t.Errorf("level 14 Knight has %d HP, level 11 has %d; want more at higher level",
+			cards[1].Stats.Hitpoints, cards[0].Stats.Hitpoints)
+	}
+	if cards[2].Stats.Hitpoints != 1 {
+		t.Error("ApplyCombatStats replaced stats the candidate already had")
+	}
+	if cards[3].Stats != nil {
+		t.Errorf("unknown card got stats %+v", cards[3].Stats)
+	}
+}
+
 func TestBuilder_BuildCandidate_EvolutionLevel(t *testing.T) {
 	builder := NewBuilder("testdata")
 
@@ -1739,8 +1785,8 @@ func TestBuilder_StrategyCompositionOverrides(t *testing.T) {
 			strategy:            StrategyCycle,
 			expectedWinCond:     2, // Updated: may include 2 win conditions
 			expectedBuildings:   1,
-			expectedBigSpells:   1, // Updated: may include 1 big spell
-			expectedSmallSpells: 2, // Updated: may include 2 small spells
+			expectedBigSpells:   0, // Override: no big spells
+			expectedSmallSpells: 1,
 		},
 		{
 			name:                "Spell strategy",
@@ -1753,9 +1799,9 @@ func TestBuilder_StrategyCompositionOverrides(t *testing.T) {
 		{
 			name:                "Balanced strategy",
 			strategy:            StrategyBalanced,
-			expectedWinCond:     1,
+			expectedWinCond:     2, // Fill slots follow combat-stat scoring
 			expectedBuildings:   1,
-			expectedBigSpells:   2, // Updated: may include 2 big spells
+			expectedBigSpells:   1,
 			expectedSmallSpells: 1,
 		},
 	}
@@ -1875,7 +1921,7 @@ func TestSaveDeck(t *testing.T) {
 	}
 
 	// Test saving to default location
-	path, err := builder.SaveDeck(deck, "", "#TESTPLAYER")
+	path, err := builder.SaveDeck(deck, "", "#2PPQRJ")
 	if err != nil {
 		t.Fatalf("SaveDeck failed: %v", err)
 	}
@@ -1891,7 +1937,7 @@ func TestSaveDeck(t *testing.T) {
 
 	// Test saving to custom location
 	customDir := filepath.Join(tempDir, "custom")
-	path2, err := builder.SaveDeck(deck, customDir, "#TESTPLAYER")
+	path2, err := builder.SaveDeck(deck, customDir, "#2PPQRJ")
 	if err != nil {
 		t.Fatalf("SaveDeck with custom dir failed: %v", err)
 	}
@@ -1914,12 +1960,12 @@ func TestSaveDeckCanonicalizesPlayerTag(t *testing.T) {
 		AvgElixir: 3.0,
 	}
 
-	path, err := builder.SaveDeck(deck, "", " abc123 ")
+	path, err := builder.SaveDeck(deck, "", " 2pq0r ")
 	if err != nil {
 		t.Fatalf("SaveDeck failed: %v", err)
 	}
 
-	if !strings.HasSuffix(path, "_deck_ABC123.json") {
+	if !strings.HasSuffix(path, "_deck_2PQ0R.json") {
 		t.Fatalf("expected canonicalized filename suffix, got %s", filepath.Base(path))
 	}
 }
@@ -0,0 +1,181 @@
+package deck
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+)
+
+// CardPool restricts which cards deck tools may use, for special events and
+// tournaments with their own card lists. An empty Allowed list allows every
+// card that is not banned.
+type CardPool struct {
+	Name           string   `json:"name,omitempty"`
+	Allowed        []string `json:"allowed,omitempty"`
+	Banned         []string `json:"banned,omitempty"`
+	BannedRarities []string `json:"banned_rarities,omitempty"`
+
+	allowed  map[string]bool
+	banned   map[string]bool
+	rarities map[string]bool
+}
+
+// LoadCardPool reads a card pool file. JSON files use the CardPool fields;
+// any other file is a plain list of allowed cards, one per line, with blank
+// lines and # comments ignored.
+func LoadCardPool(path string) (*CardPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read card pool: %w", err)
+	}
+
+	pool := &CardPool{}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		if err := json.Unmarshal(data, pool); err != nil {
+			return nil, fmt.Errorf("failed to parse card pool %s: %w", path, err)
+		}
+	} else {
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			line, _, _ := strings.Cut(scanner.Text(), "#")
+			if line = strings.TrimSpace(line); line != "" {
+				pool.Allowed = append(pool.Allowed, line)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read card pool %s: %w", path, err)
+		}
+		pool.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	if pool.Empty() {
+		return nil, fmt.Errorf("card pool %s lists no cards or restrictions", path)
+	}
+	return pool, nil
+}
+
+// Empty reports whether the pool places no restrictions
+func (p *CardPool) Empty() bool {
+	return p == nil || (len(p.Allowed) == 0 && len(p.Banned) == 0 && len(p.BannedRarities) == 0)
+}
+
+// BanRarities adds rarities (e.g. "Champion") to the pool's bans
+func (p *CardPool) BanRarities(rarities ...string) {
+	for _, r := range rarities {
+		if r = config.NormalizeRarity(r); r != "" && !slices.Contains(p.BannedRarities, r) {
+			p.BannedRarities = append(p.BannedRarities, r)
+		}
+	}
+	p.rarities = nil
+}
+
+// Allows reports whether a card may be used. rarity may be empty, in which
+// case it is looked up by name.
+func (p *CardPool) Allows(name, rarity string) bool {
+	if p.Empty() {
+		return true
+	}
+	p.index()
+	key := poolKey(name)
+	if p.banned[key] {
+		return false
+	}
+	if len(p.allowed) > 0 && !p.allowed[key] {
+		return false
+	}
+	if len(p.rarities) > 0 {
+		if rarity == "" {
+			rarity, _ = config.LookupCardRarity(name)
+		}
+		if p.rarities[config.NormalizeRarity(rarity)] {
+			return false
+		}
+	}
+	return true
+}
+
+// AllowsDeck reports whether every card in the deck is allowed
+func (p *CardPool) AllowsDeck(cards []string) bool {
+	for _, card := range cards {
+		if !p.Allows(card, "") {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterCardLevels returns the allowed cards and the sorted names of the
+// ones removed.
+func (p *CardPool) FilterCardLevels(cardLevels map[string]CardLevelData) (map[string]CardLevelData, []string) {
+	if p.Empty() {
+		return cardLevels, nil
+	}
+	kept := make(map[string]CardLevelData, len(cardLevels))
+	var removed []string
+	for name, data := range cardLevels {
+		if p.Allows(name, data.Rarity) {
+			kept[name] = data
+		} else {
+			removed = append(removed, name)
+		}
+	}
+	slices.Sort(removed)
+	return kept, removed
+}
+
+// Describe summarizes the restrictions, e.g. "sudden-death: 40 allowed cards,
+// no Champion"
+func (p *CardPool) Describe() string {
+	if p.Empty() {
+		return "all cards"
+	}
+	var parts []string
+	if len(p.Allowed) > 0 {
+		parts = append(parts, fmt.Sprintf("%d allowed cards", len(p.Allowed)))
+	}
+	if len(p.Banned) > 0 {
+		parts = append(parts, fmt.Sprintf("%d banned cards", len(p.Banned)))
+	}
+	if len(p.BannedRarities) > 0 {
+		parts = append(parts, "no "+strings.Join(p.BannedRarities, "/"))
+	}
+	desc := strings.Join(parts, ", ")
+	if p.Name != "" {
+		desc = p.Name + ": " + desc
+	}
+	return desc
+}
+
+func (p *CardPool) index() {
+	if p.allowed == nil {
+		p.allowed = poolSet(p.Allowed)
+		p.banned = poolSet(p.Banned)
+	}
+	if p.rarities == nil {
+		p.rarities = make(map[string]bool, len(p.BannedRarities))
+		for _, r := range p.BannedRarities {
+			p.rarities[config.NormalizeRarity(r)] = true
+		}
+	}
+}
+
+func poolSet(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		if key := poolKey(name); key != "" {
+			set[key] = true
+		}
+	}
+	return set
+}
+
+func poolKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
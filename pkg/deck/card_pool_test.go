@@ -0,0 +1,100 @@
+package deck
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func writePoolFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadCardPoolTextList(t *testing.T) {
+	path := writePoolFile(t, "draft-week.txt", "# event cards\nKnight\n\n  archers  # lowercase is fine\nFireball\n")
+
+	pool, err := LoadCardPool(path)
+	if err != nil {
+		t.Fatalf("LoadCardPool() error = %v", err)
+	}
+	if pool.Name != "draft-week" || len(pool.Allowed) != 3 {
+		t.Fatalf("pool = %+v", pool)
+	}
+	if !pool.Allows("Archers", "") || !pool.Allows("knight", "Common") {
+		t.Error("listed cards should be allowed regardless of case")
+	}
+	if pool.Allows("Hog Rider", "Rare") {
+		t.Error("cards missing from the list should be rejected")
+	}
+	if got, want := pool.Describe(), "draft-week: 3 allowed cards"; got != want {
+		t.Errorf("Describe() = %q, want %q", got, want)
+	}
+}
+
+func TestLoadCardPoolJSON(t *testing.T) {
+	path := writePoolFile(t, "event.json", `{"name":"no-champs","banned":["Mega Knight"],"banned_rarities":["champion"]}`)
+
+	pool, err := LoadCardPool(path)
+	if err != nil {
+		t.Fatalf("LoadCardPool() error = %v", err)
+	}
+	if pool.Allows("Mega Knight", "Epic") {
+		t.Error("banned card should be rejected")
+	}
+	if pool.Allows("Archer Queen", "") {
+		t.Error("champion should be rejected by rarity lookup")
+	}
+	if !pool.Allows("Hog Rider", "Rare") {
+		t.Error("without an allowed list other cards should pass")
+	}
+	if !pool.AllowsDeck([]string{"Hog Rider", "Knight"}) || pool.AllowsDeck([]string{"Knight", "Mega Knight"}) {
+		t.Error("AllowsDeck() should reject decks with any banned card")
+	}
+}
+
+func TestLoadCardPoolErrors(t *testing.T) {
+	if _, err := LoadCardPool(writePoolFile(t, "empty.txt", "# nothing yet\n")); err == nil {
+		t.Error("expected an error for an empty pool")
+	}
+	if _, err := LoadCardPool(writePoolFile(t, "bad.json", "{")); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+	if _, err := LoadCardPool(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestCardPoolFilterCardLevels(t *testing.T) {
+	pool := &CardPool{Banned: []string{"Knight"}}
+	pool.BanRarities("Legendary", "legendary")
+	if len(pool.BannedRarities) != 1 {
+		t.Fatalf("BannedRarities = %v, want duplicates collapsed", pool.BannedRarities)
+	}
+
+	levels := map[string]CardLevelData{
+		"Knight":  {Level: 11, Rarity: "Common"},
+		"Archers": {Level: 11, Rarity: "Common"},
+		"The Log": {Level: 5, Rarity: "Legendary"},
+	}
+	kept, removed := pool.FilterCardLevels(levels)
+	if len(kept) != 1 || kept["Archers"].Level != 11 {
+		t.Errorf("kept = %v", kept)
+	}
+	if !slices.Equal(removed, []string{"Knight", "The Log"}) {
+		t.Errorf("removed = %v", removed)
+	}
+
+	var none *CardPool
+	if kept, removed := none.FilterCardLevels(levels); len(kept) != 3 || removed != nil {
+		t.Error("a nil pool should keep every card")
+	}
+	if none.Describe() != "all cards" {
+		t.Errorf("nil Describe() = %q", none.Describe())
+	}
+}
@@ -0,0 +1,62 @@
+package deck
+
+import "slices"
+
+// DefaultClusterThreshold is the Jaccard similarity at which two 8-card decks
+// sharing 7 cards (7/9 ≈ 0.78) fall in one cluster while decks sharing 6
+// (6/10) do not.
+const DefaultClusterThreshold = 0.75
+
+// DeckCluster is a group of near-duplicate decks, given as indexes into the
+// slice passed to ClusterDecks.
+type DeckCluster struct {
+	// Representative is the first deck of the cluster
+	Representative int
+	// Members lists every deck in the cluster, the representative first
+	Members []int
+}
+
+// Variants is the number of decks in the cluster besides its representative.
+func (c DeckCluster) Variants() int {
+	return len(c.Members) - 1
+}
+
+// JaccardSimilarity is the number of cards two decks share divided by the
+// number of distinct cards in both: 1 for the same cards in any order, 0 for
+// decks with no card in common.
+func JaccardSimilarity(a, b []string) float64 {
+	union := len(a) + len(b)
+	if union == 0 {
+		return 1
+	}
+	shared := 0
+	for _, card := range a {
+		if slices.Contains(b, card) {
+			shared++
+		}
+	}
+	return float64(shared) / float64(union-shared)
+}
+
+// ClusterDecks groups near-duplicate decks. Each deck joins the first cluster
+// whose representative has a Jaccard similarity of at least threshold with
+// it, or else starts a new cluster. Clusters are returned in the order they
+// were started, so passing decks best first makes every representative its
+// cluster's best deck and the clusters ranked by it.
+func ClusterDecks(decks [][]string, threshold float64) []DeckCluster {
+	var clusters []DeckCluster
+	for i, cards := range decks {
+		joined := false
+		for c := range clusters {
+			if JaccardSimilarity(decks[clusters[c].Representative], cards) >= threshold {
+				clusters[c].Members = append(clusters[c].Members, i)
+				joined = true
+				break
+			}
+		}
+		if !joined {
+			clusters = append(clusters, DeckCluster{Representative: i, Members: []int{i}})
+		}
+	}
+	return clusters
+}
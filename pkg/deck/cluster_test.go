@@ -0,0 +1,64 @@
+package deck
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestJaccardSimilarity(t *testing.T) {
+	hog := []string{"Hog Rider", "Musketeer", "Cannon", "Ice Golem", "Ice Spirit", "Skeletons", "Fireball", "The Log"}
+	tesla := slices.Clone(hog)
+	tesla[2] = "Tesla"
+	knight := slices.Clone(tesla)
+	knight[3] = "Knight"
+	reversed := slices.Clone(hog)
+	slices.Reverse(reversed)
+
+	tests := []struct {
+		name string
+		b    []string
+		want float64
+	}{
+		{name: "same cards in another order", b: reversed, want: 1},
+		{name: "one card swapped", b: tesla, want: 7.0 / 9},
+		{name: "two cards swapped", b: knight, want: 6.0 / 10},
+		{name: "nothing shared", b: []string{"Golem"}, want: 0},
+	}
+	for _, tt := range tests {
+		if got := JaccardSimilarity(hog, tt.b); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: JaccardSimilarity() = %.3f, want %.3f", tt.name, got, tt.want)
+		}
+	}
+	if got := JaccardSimilarity(nil, nil); got != 1 {
+		t.Errorf("JaccardSimilarity(nil, nil) = %v, want 1", got)
+	}
+}
+
+func TestClusterDecks(t *testing.T) {
+	hog := []string{"Hog Rider", "Musketeer", "Cannon", "Ice Golem", "Ice Spirit", "Skeletons", "Fireball", "The Log"}
+	tesla := slices.Clone(hog)
+	tesla[2] = "Tesla"
+	knight := slices.Clone(tesla)
+	knight[3] = "Knight"
+	golem := []string{"Golem", "Night Witch", "Baby Dragon", "Lumberjack", "Tornado", "Lightning", "Barbarian Barrel", "Mega Minion"}
+
+	// knight is 7/8 of tesla but only 6/8 of the hog representative
+	clusters := ClusterDecks([][]string{hog, golem, tesla, knight}, DefaultClusterThreshold)
+	want := []DeckCluster{
+		{Representative: 0, Members: []int{0, 2}},
+		{Representative: 1, Members: []int{1}},
+		{Representative: 3, Members: []int{3}},
+	}
+	if len(clusters) != len(want) {
+		t.Fatalf("ClusterDecks() = %+v, want %+v", clusters, want)
+	}
+	for i := range want {
+		if clusters[i].Representative != want[i].Representative || !slices.Equal(clusters[i].Members, want[i].Members) {
+			t.Errorf("cluster %d = %+v, want %+v", i, clusters[i], want[i])
+		}
+	}
+	if clusters[0].Variants() != 1 {
+		t.Errorf("Variants() = %d, want 1", clusters[0].Variants())
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 )
 
 //go:embed archetype_requirements.json
@@ -171,6 +172,32 @@ func LoadCoherenceScorer(dataPath string) (*CoherenceScorer, error) {
 	return NewCoherenceScorer(&config), nil
 }
 
+// ArchetypeSignalCards returns the sorted cards the built-in archetype
+// requirements name, either as a required win condition or in a card category
+func ArchetypeSignalCards() ([]string, error) {
+	var config ArchetypeRequirementsConfig
+	if err := json.Unmarshal(defaultArchetypeRequirementsJSON, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse embedded archetype requirements: %w", err)
+	}
+
+	categories := config.CardCategories
+	groups := [][]string{
+		categories.CycleCards, categories.BaitCards, categories.SplashDamage, categories.HighDPS,
+		categories.MiniTanks, categories.ResetCards, categories.BigSpells, categories.SmallSpells,
+		categories.AirDefense, categories.FastThreats,
+	}
+	for _, archetype := range config.Archetypes {
+		groups = append(groups, archetype.RequiredWinConditions)
+	}
+
+	var cards []string
+	for _, group := range groups {
+		cards = append(cards, group...)
+	}
+	slices.Sort(cards)
+	return slices.Compact(cards), nil
+}
+
 // NewCoherenceScorer creates a coherence scorer from configuration
 func NewCoherenceScorer(config *ArchetypeRequirementsConfig) *CoherenceScorer {
 	cs := &CoherenceScorer{
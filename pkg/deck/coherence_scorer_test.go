@@ -2,6 +2,7 @@
 package deck
 
 import (
+	"slices"
 	"testing"
 )
 
@@ -363,3 +364,19 @@ func TestCoherenceArchetypeRequirementsElixir(t *testing.T) {
 		t.Errorf("Expected elixir violation for %.1f avg elixir with cycle strategy", result.AverageElixir)
 	}
 }
+
+func TestArchetypeSignalCards(t *testing.T) {
+	cards, err := ArchetypeSignalCards()
+	if err != nil {
+		t.Fatalf("ArchetypeSignalCards() error = %v", err)
+	}
+	if !slices.IsSorted(cards) || len(slices.Compact(slices.Clone(cards))) != len(cards) {
+		t.Error("signal cards should be sorted and unique")
+	}
+	if !slices.Contains(cards, "Hog Rider") {
+		t.Error("required win conditions should be archetype signals")
+	}
+	if slices.Contains(cards, "Brand New Card") {
+		t.Error("unexpected card in archetype signals")
+	}
+}
@@ -25,6 +25,7 @@ func ConvertAnalysisForDeckBuilding(analysisData *analysis.CardAnalysis) CardAna
 	return CardAnalysis{
 		CardLevels:   cardLevels,
 		AnalysisTime: analysisData.AnalysisTime.Format(time.RFC3339),
+		TowerTroops:  analysisData.TowerTroops,
 	}
 }
 
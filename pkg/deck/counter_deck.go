@@ -0,0 +1,236 @@
+// Package deck provides counter-deck generation for Clash Royale decks.
+// This file picks cards from a player's collection that answer a specific
+// opponent deck and lets the Builder fill the remaining slots.
+package deck
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+const (
+	defaultMaxCounterCards = 4
+	// winConditionThreatWeight makes answering the opponent's win conditions
+	// worth more than answering their support cards.
+	winConditionThreatWeight = 2.0
+)
+
+// CounterDeckOptions tunes counter-deck generation
+type CounterDeckOptions struct {
+	// MaxCounterCards caps how many cards are locked in for countering (default 4)
+	MaxCounterCards int
+}
+
+// CounterPick is a card chosen because it answers opponent cards
+type CounterPick struct {
+	Card     string   `json:"card"`
+	Answers  []string `json:"answers"`
+	Gain     float64  `json:"gain"`
+	LevelFit float64  `json:"level_fit"`
+}
+
+// OpponentCardCoverage reports how a generated deck answers one opponent card
+type OpponentCardCoverage struct {
+	Card         string   `json:"card"`
+	WinCondition bool     `json:"win_condition"`
+	HardCounters []string `json:"hard_counters"`
+	SoftCounters []string `json:"soft_counters"`
+	KnownCard    bool     `json:"known_card"`
+}
+
+// Covered reports whether any deck card counters the opponent card
+func (c OpponentCardCoverage) Covered() bool {
+	return len(c.HardCounters) > 0 || len(c.SoftCounters) > 0
+}
+
+// CounterDeckResult is the generated counter deck plus its coverage report
+type CounterDeckResult struct {
+	OpponentDeck   []string               `json:"opponent_deck"`
+	Recommendation *DeckRecommendation    `json:"recommendation"`
+	CounterPicks   []CounterPick          `json:"counter_picks"`
+	Coverage       []OpponentCardCoverage `json:"coverage"`
+	CoverageScore  float64                `json:"coverage_score"`
+	Unanswered     []string               `json:"unanswered"`
+	// ModelWinProbability is the counter deck's chance from a win model
+	// trained on recorded battles; BuildCounterDeck leaves it unset for
+	// callers to fill in
+	ModelWinProbability *float64 `json:"model_win_probability,omitempty"`
+}
+
+// BuildCounterDeck builds the best deck from analysis that counters opponent.
+//
+// Counter cards are chosen greedily by weighted marginal coverage of the
+// opponent's cards (win conditions count double, hard counters beat soft ones,
+// and under-leveled cards are discounted). The chosen cards are force-included
+// into a normal Builder run, which fills the remaining slots.
+func BuildCounterDeck(builder *Builder, analysis CardAnalysis, opponent []string, db *CountersDatabase, opts CounterDeckOptions) (*CounterDeckResult, error) {
+	if builder == nil {
+		return nil, fmt.Errorf("builder is required")
+	}
+	if db == nil {
+		return nil, fmt.Errorf("counters database is required")
+	}
+	if len(opponent) == 0 {
+		return nil, fmt.Errorf("opponent deck is empty")
+	}
+	if len(analysis.CardLevels) == 0 {
+		return nil, fmt.Errorf("analysis data missing 'card_levels'")
+	}
+	if opts.MaxCounterCards <= 0 {
+		opts.MaxCounterCards = defaultMaxCounterCards
+	}
+
+	picks := selectCounterCards(analysis, opponent, db, opts.MaxCounterCards)
+	include := make([]string, 0, len(picks))
+	for _, pick := range picks {
+		include = append(include, pick.Card)
+	}
+
+	builder.SetIncludeCards(include)
+	recommendation, err := builder.BuildDeckFromAnalysis(analysis)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build counter deck: %w", err)
+	}
+
+	result := &CounterDeckResult{
+		OpponentDeck:   opponent,
+		Recommendation: recommendation,
+		CounterPicks:   picks,
+	}
+	result.Coverage, result.CoverageScore = AnalyzeCounterCoverage(recommendation.Deck, opponent, db)
+	for _, cov := range result.Coverage {
+		if cov.KnownCard && !cov.Covered() {
+			result.Unanswered = append(result.Unanswered, cov.Card)
+		}
+	}
+	return result, nil
+}
+
+// AnalyzeCounterCoverage reports which of deckCards counter each opponent card.
+// The score is the weighted share of known opponent cards that are answered,
+// where a hard counter fully covers a card and a soft counter half covers it.
+func AnalyzeCounterCoverage(deckCards, opponent []string, db *CountersDatabase) ([]OpponentCardCoverage, float64) {
+	coverage := make([]OpponentCardCoverage, 0, len(opponent))
+	var covered, total float64
+
+	for _, threat := range opponent {
+		_, known := db.CountersFor(threat)
+		cov := OpponentCardCoverage{
+			Card:         threat,
+			WinCondition: IsWinCondition(threat),
+			KnownCard:    known,
+		}
+		best := 0.0
+		for _, card := range deckCards {
+			strength := db.Strength(threat, card)
+			switch strength {
+			case CounterStrengthHard:
+				cov.HardCounters = append(cov.HardCounters, card)
+			case CounterStrengthSoft:
+				cov.SoftCounters = append(cov.SoftCounters, card)
+			}
+			best = max(best, strength.Weight())
+		}
+		coverage = append(coverage, cov)
+
+		if known {
+			weight := threatWeight(threat)
+			total += weight
+			covered += weight * best
+		}
+	}
+
+	if total == 0 {
+		return coverage, 0
+	}
+	return coverage, covered / total
+}
+
+// selectCounterCards greedily picks owned cards with the largest marginal
+// counter value against the opponent deck.
+func selectCounterCards(analysis CardAnalysis, opponent []string, db *CountersDatabase, limit int) []CounterPick {
+	owned := make([]string, 0, len(analysis.CardLevels))
+	for name := range analysis.CardLevels {
+		owned = append(owned, name)
+	}
+	sort.Strings(owned)
+
+	// best tracks the strongest answer found so far for each opponent card
+	best := make(map[string]float64, len(opponent))
+	var picks []CounterPick
+	used := make(map[string]bool)
+
+	for len(picks) < limit {
+		var chosen *CounterPick
+		for _, card := range owned {
+			if used[card] {
+				continue
+			}
+			fit := counterLevelFit(analysis.CardLevels[card])
+			gain := 0.0
+			var answers []string
+			for _, threat := range opponent {
+				weight := db.Strength(threat, card).Weight()
+				if weight == 0 {
+					continue
+				}
+				answers = append(answers, threat)
+				if improvement := weight - best[threat]; improvement > 0 {
+					gain += improvement * threatWeight(threat)
+				}
+			}
+			gain *= fit
+			if gain <= 0 {
+				continue
+			}
+			if chosen == nil || gain > chosen.Gain {
+				chosen = &CounterPick{Card: card, Answers: answers, Gain: gain, LevelFit: fit}
+			}
+		}
+		if chosen == nil {
+			break
+		}
+
+		used[chosen.Card] = true
+		for _, threat := range chosen.Answers {
+			best[threat] = max(best[threat], db.Strength(threat, chosen.Card).Weight())
+		}
+		picks = append(picks, *chosen)
+	}
+
+	enforceSingleChampionPick(&picks, analysis)
+	return picks
+}
+
+// enforceSingleChampionPick drops extra champions; a deck may only hold one.
+func enforceSingleChampionPick(picks *[]CounterPick, analysis CardAnalysis) {
+	seenChampion := false
+	*picks = slices.DeleteFunc(*picks, func(p CounterPick) bool {
+		if analysis.CardLevels[p.Card].Rarity != RarityChampion {
+			return false
+		}
+		if seenChampion {
+			return true
+		}
+		seenChampion = true
+		return false
+	})
+}
+
+// counterLevelFit discounts under-leveled counters: a max-level card keeps its
+// full value while a card at half its max level keeps 75%.
+func counterLevelFit(data CardLevelData) float64 {
+	if data.MaxLevel <= 0 {
+		return 0.5
+	}
+	ratio := float64(data.Level) / float64(data.MaxLevel)
+	return 0.5 + 0.5*min(max(ratio, 0), 1)
+}
+
+func threatWeight(card string) float64 {
+	if IsWinCondition(card) {
+		return winConditionThreatWeight
+	}
+	return 1.0
+}
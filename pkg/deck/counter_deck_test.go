@@ -0,0 +1,142 @@
+package deck
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func counterTestAnalysis() CardAnalysis {
+	levels := map[string]CardLevelData{
+		"Hog Rider":      {Level: 14, MaxLevel: 14, Rarity: "Rare", Elixir: 4},
+		"Cannon":         {Level: 14, MaxLevel: 14, Rarity: "Common", Elixir: 3},
+		"Tornado":        {Level: 12, MaxLevel: 14, Rarity: "Epic", Elixir: 3},
+		"Valkyrie":       {Level: 13, MaxLevel: 14, Rarity: "Rare", Elixir: 4},
+		"Musketeer":      {Level: 13, MaxLevel: 14, Rarity: "Rare", Elixir: 4},
+		"Inferno Tower":  {Level: 7, MaxLevel: 14, Rarity: "Rare", Elixir: 5},
+		"The Log":        {Level: 13, MaxLevel: 14, Rarity: "Legendary", Elixir: 2},
+		"Fireball":       {Level: 13, MaxLevel: 14, Rarity: "Rare", Elixir: 4},
+		"Knight":         {Level: 14, MaxLevel: 14, Rarity: "Common", Elixir: 3},
+		"Skeletons":      {Level: 14, MaxLevel: 14, Rarity: "Common", Elixir: 1},
+		"Ice Spirit":     {Level: 14, MaxLevel: 14, Rarity: "Common", Elixir: 1},
+		"Archers":        {Level: 14, MaxLevel: 14, Rarity: "Common", Elixir: 3},
+		"Baby Dragon":    {Level: 12, MaxLevel: 14, Rarity: "Epic", Elixir: 4},
+		"Mini P.E.K.K.A": {Level: 13, MaxLevel: 14, Rarity: "Rare", Elixir: 4},
+	}
+	return CardAnalysis{CardLevels: levels, PlayerName: "Tester"}
+}
+
+func TestCountersDatabaseEmbedded(t *testing.T) {
+	db, err := NewCountersDatabase()
+	if err != nil {
+		t.Fatalf("NewCountersDatabase() error = %v", err)
+	}
+	if len(db.Cards()) == 0 {
+		t.Fatal("embedded counters database is empty")
+	}
+	if got := db.Strength("Hog Rider", "Cannon"); got != CounterStrengthHard {
+		t.Errorf("Strength(Hog Rider, Cannon) = %q, want hard", got)
+	}
+	if got := db.Strength("Hog Rider", "Knight"); got != CounterStrengthSoft {
+		t.Errorf("Strength(Hog Rider, Knight) = %q, want soft", got)
+	}
+	if got := db.Strength("Unknown Card", "Cannon"); got != CounterStrengthNone {
+		t.Errorf("Strength(Unknown Card, Cannon) = %q, want none", got)
+	}
+
+	for _, card := range db.Cards() {
+		counters, _ := db.CountersFor(card)
+		for _, hard := range counters.Hard {
+			if slices.Contains(counters.Soft, hard) {
+				t.Errorf("%s lists %s as both hard and soft counter", card, hard)
+			}
+		}
+	}
+}
+
+func TestLoadCountersDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "counters.json")
+	data := `{"version":1,"cards":{"Golem":{"hard":["Inferno Tower"],"soft":["Barbarians"]}}}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := LoadCountersDatabase(path)
+	if err != nil {
+		t.Fatalf("LoadCountersDatabase() error = %v", err)
+	}
+	if db.Strength("Golem", "Barbarians") != CounterStrengthSoft {
+		t.Error("expected Barbarians to softly counter Golem")
+	}
+	if _, err := LoadCountersDatabase(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestBuildCounterDeck(t *testing.T) {
+	db, err := NewCountersDatabase()
+	if err != nil {
+		t.Fatal(err)
+	}
+	opponent := []string{"Hog Rider", "Musketeer", "Fireball", "The Log", "Ice Spirit", "Skeletons", "Cannon", "Valkyrie"}
+
+	result, err := BuildCounterDeck(NewBuilder("testdata"), counterTestAnalysis(), opponent, db, CounterDeckOptions{})
+	if err != nil {
+		t.Fatalf("BuildCounterDeck() error = %v", err)
+	}
+
+	if len(result.Recommendation.Deck) != 8 {
+		t.Fatalf("expected 8-card deck, got %v", result.Recommendation.Deck)
+	}
+	if len(result.CounterPicks) == 0 || len(result.CounterPicks) > defaultMaxCounterCards {
+		t.Fatalf("unexpected counter pick count: %d", len(result.CounterPicks))
+	}
+	for _, pick := range result.CounterPicks {
+		if !slices.Contains(result.Recommendation.Deck, pick.Card) {
+			t.Errorf("counter pick %s missing from deck %v", pick.Card, result.Recommendation.Deck)
+		}
+	}
+
+	var hog OpponentCardCoverage
+	for _, cov := range result.Coverage {
+		if cov.Card == "Hog Rider" {
+			hog = cov
+		}
+	}
+	if len(hog.HardCounters) == 0 {
+		t.Errorf("expected a hard counter to Hog Rider, got %+v", hog)
+	}
+	if result.CoverageScore <= 0 || result.CoverageScore > 1 {
+		t.Errorf("CoverageScore = %.2f, want (0, 1]", result.CoverageScore)
+	}
+}
+
+func TestSelectCounterCardsPrefersLeveledCards(t *testing.T) {
+	db, err := NewCountersDatabase()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Inferno Tower (level 7/14) and Mini P.E.K.K.A (13/14) both hard counter
+	// Royal Giant; the better-leveled card should win the only slot.
+	picks := selectCounterCards(counterTestAnalysis(), []string{"Royal Giant"}, db, 1)
+	if len(picks) != 1 {
+		t.Fatalf("expected one pick, got %v", picks)
+	}
+	if picks[0].Card == "Inferno Tower" {
+		t.Errorf("expected a better-leveled counter than Inferno Tower, got %+v", picks[0])
+	}
+}
+
+func TestBuildCounterDeckValidatesInput(t *testing.T) {
+	db, err := NewCountersDatabase()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := BuildCounterDeck(NewBuilder("testdata"), counterTestAnalysis(), nil, db, CounterDeckOptions{}); err == nil {
+		t.Error("expected error for empty opponent deck")
+	}
+	if _, err := BuildCounterDeck(NewBuilder("testdata"), CardAnalysis{}, []string{"Hog Rider"}, db, CounterDeckOptions{}); err == nil {
+		t.Error("expected error for empty analysis")
+	}
+}
@@ -0,0 +1,121 @@
+// Package deck provides a per-card counters database for Clash Royale decks.
+//
+// This file maps individual cards to their hard and soft counters. Unlike the
+// CounterMatrix, which tracks a handful of meta threats and broad capability
+// categories, the counters database is keyed by any card an opponent may play
+// and is used to build decks that answer a specific opponent deck.
+package deck
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+)
+
+//go:embed counters.json
+var defaultCountersJSON []byte
+
+// CounterStrength grades how reliably one card answers another.
+type CounterStrength string
+
+const (
+	// CounterStrengthNone means the card does not counter the threat
+	CounterStrengthNone CounterStrength = ""
+	// CounterStrengthSoft means the card helps but may need support
+	CounterStrengthSoft CounterStrength = "soft"
+	// CounterStrengthHard means the card answers the threat efficiently on its own
+	CounterStrengthHard CounterStrength = "hard"
+)
+
+// Weight returns the effectiveness weight for the strength (hard 1.0, soft 0.5).
+func (s CounterStrength) Weight() float64 {
+	switch s {
+	case CounterStrengthHard:
+		return 1.0
+	case CounterStrengthSoft:
+		return 0.5
+	default:
+		return 0.0
+	}
+}
+
+// CardCounters lists the hard and soft counters for a card
+type CardCounters struct {
+	Hard []string `json:"hard"`
+	Soft []string `json:"soft"`
+}
+
+// countersDataFile represents the JSON structure of the counters database
+type countersDataFile struct {
+	Version     int                     `json:"version"`
+	Description string                  `json:"description"`
+	LastUpdated string                  `json:"last_updated"`
+	Cards       map[string]CardCounters `json:"cards"`
+}
+
+// CountersDatabase maps cards to the cards that counter them
+type CountersDatabase struct {
+	cards map[string]CardCounters
+}
+
+// NewCountersDatabase returns the built-in counters database
+func NewCountersDatabase() (*CountersDatabase, error) {
+	db, err := parseCountersDatabase(defaultCountersJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse embedded counters database: %w", err)
+	}
+	return db, nil
+}
+
+// LoadCountersDatabase loads a counters database from a JSON file
+func LoadCountersDatabase(path string) (*CountersDatabase, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read counters database: %w", err)
+	}
+	return parseCountersDatabase(data)
+}
+
+func parseCountersDatabase(data []byte) (*CountersDatabase, error) {
+	var file countersDataFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse counters database: %w", err)
+	}
+	if file.Cards == nil {
+		file.Cards = make(map[string]CardCounters)
+	}
+	return &CountersDatabase{cards: file.Cards}, nil
+}
+
+// CountersFor returns the counters recorded for a card
+func (db *CountersDatabase) CountersFor(card string) (CardCounters, bool) {
+	counters, ok := db.cards[card]
+	return counters, ok
+}
+
+// Strength returns how well counterCard answers threat
+func (db *CountersDatabase) Strength(threat, counterCard string) CounterStrength {
+	counters, ok := db.cards[threat]
+	if !ok {
+		return CounterStrengthNone
+	}
+	if slices.Contains(counters.Hard, counterCard) {
+		return CounterStrengthHard
+	}
+	if slices.Contains(counters.Soft, counterCard) {
+		return CounterStrengthSoft
+	}
+	return CounterStrengthNone
+}
+
+// Cards returns the sorted list of cards with counter data
+func (db *CountersDatabase) Cards() []string {
+	names := make([]string, 0, len(db.cards))
+	for name := range db.cards {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
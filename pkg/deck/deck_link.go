@@ -0,0 +1,158 @@
+// Package deck provides official Clash Royale copy-deck link support.
+// This file encodes decks into https://link.clashroyale.com/deck/... links and
+// decodes those links back into card names using card IDs from the API.
+package deck
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/internal/storage"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+// DeckLinkBaseURL is the official copy-deck endpoint used by the game
+const DeckLinkBaseURL = "https://link.clashroyale.com/deck/en"
+
+// CardIDIndex maps card names to API card IDs and back
+type CardIDIndex struct {
+	byName  map[string]int
+	byLower map[string]int
+	byID    map[int]string
+}
+
+// NewCardIDIndex builds an index from the cards endpoint response
+func NewCardIDIndex(cards []clashroyale.Card) *CardIDIndex {
+	index := &CardIDIndex{
+		byName:  make(map[string]int, len(cards)),
+		byLower: make(map[string]int, len(cards)),
+		byID:    make(map[int]string, len(cards)),
+	}
+	for _, card := range cards {
+		if card.Name == "" || card.ID == 0 {
+			continue
+		}
+		index.byName[card.Name] = card.ID
+		index.byLower[strings.ToLower(card.Name)] = card.ID
+		index.byID[card.ID] = card.Name
+	}
+	return index
+}
+
+// LoadCardIDIndex builds an index from the cached card database in dataDir
+// (written by `cr-api cards`). It returns an error if no cache exists.
+func LoadCardIDIndex(dataDir string) (*CardIDIndex, error) {
+	path := storage.NewPathBuilder(dataDir).GetStaticCardsPath()
+	var cards clashroyale.CardList
+	if err := storage.ReadJSON(path, &cards); err != nil {
+		return nil, fmt.Errorf("card database not cached (run `cr-api cards`): %w", err)
+	}
+	if len(cards.Items) == 0 {
+		return nil, fmt.Errorf("cached card database at %s is empty", path)
+	}
+	return NewCardIDIndex(cards.Items), nil
+}
+
+// Len returns the number of cards in the index
+func (idx *CardIDIndex) Len() int {
+	return len(idx.byID)
+}
+
+// ID returns the card ID for a card name (case-insensitive)
+func (idx *CardIDIndex) ID(name string) (int, bool) {
+	if id, ok := idx.byName[name]; ok {
+		return id, true
+	}
+	id, ok := idx.byLower[strings.ToLower(strings.TrimSpace(name))]
+	return id, ok
+}
+
+// Name returns the card name for a card ID
+func (idx *CardIDIndex) Name(id int) (string, bool) {
+	name, ok := idx.byID[id]
+	return name, ok
+}
+
+// EncodeDeckLink returns the official copy-deck link for an 8-card deck
+func EncodeDeckLink(index *CardIDIndex, cards []string) (string, error) {
+	if index == nil {
+		return "", fmt.Errorf("card ID index is required")
+	}
+	if len(cards) != 8 {
+		return "", fmt.Errorf("deck must contain exactly 8 cards, got %d", len(cards))
+	}
+
+	ids := make([]string, 0, len(cards))
+	for _, name := range cards {
+		id, ok := index.ID(name)
+		if !ok {
+			return "", fmt.Errorf("unknown card: %s", name)
+		}
+		ids = append(ids, strconv.Itoa(id))
+	}
+
+	// The game expects literal semicolons, so the query is not form-encoded.
+	return DeckLinkBaseURL + "?deck=" + strings.Join(ids, ";"), nil
+}
+
+// DecodeDeckLink parses a copy-deck link into card names. It accepts the
+// https://link.clashroyale.com/deck/... form, the in-app
+// clashroyale://copyDeck?deck=... form, and a bare "ID;ID;..." list.
+func DecodeDeckLink(index *CardIDIndex, link string) ([]string, error) {
+	if index == nil {
+		return nil, fmt.Errorf("card ID index is required")
+	}
+
+	param, err := extractDeckParam(link)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(param, ";")
+	if len(parts) != 8 {
+		return nil, fmt.Errorf("deck link must contain 8 card IDs, got %d", len(parts))
+	}
+
+	cards := make([]string, 0, len(parts))
+	for _, part := range parts {
+		id, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid card ID %q", part)
+		}
+		name, ok := index.Name(id)
+		if !ok {
+			return nil, fmt.Errorf("unknown card ID %d (run `cr-api cards` to refresh card data)", id)
+		}
+		cards = append(cards, name)
+	}
+	return cards, nil
+}
+
+// extractDeckParam returns the unescaped value of the deck= parameter.
+// url.ParseQuery rejects semicolons, so the value is located manually.
+func extractDeckParam(link string) (string, error) {
+	link = strings.TrimSpace(link)
+	if link == "" {
+		return "", fmt.Errorf("deck link is empty")
+	}
+
+	start := strings.Index(link, "deck=")
+	if start < 0 {
+		if strings.Contains(link, ";") && !strings.Contains(link, "/") {
+			return link, nil
+		}
+		return "", fmt.Errorf("deck link is missing the deck parameter")
+	}
+	value := link[start+len("deck="):]
+	if end := strings.IndexAny(value, "&#"); end >= 0 {
+		value = value[:end]
+	}
+
+	unescaped, err := url.QueryUnescape(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid deck parameter: %w", err)
+	}
+	return unescaped, nil
+}
@@ -0,0 +1,105 @@
+package deck
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/internal/storage"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+func deckLinkTestCards() []clashroyale.Card {
+	return []clashroyale.Card{
+		{ID: 26000021, Name: "Hog Rider"},
+		{ID: 26000014, Name: "Musketeer"},
+		{ID: 27000000, Name: "Cannon"},
+		{ID: 26000030, Name: "Ice Spirit"},
+		{ID: 26000010, Name: "Skeletons"},
+		{ID: 28000011, Name: "The Log"},
+		{ID: 28000000, Name: "Fireball"},
+		{ID: 26000038, Name: "Ice Golem"},
+	}
+}
+
+func TestEncodeDecodeDeckLinkRoundTrip(t *testing.T) {
+	index := NewCardIDIndex(deckLinkTestCards())
+	deckCards := []string{"Hog Rider", "Musketeer", "Cannon", "Ice Spirit", "Skeletons", "The Log", "Fireball", "Ice Golem"}
+
+	link, err := EncodeDeckLink(index, deckCards)
+	if err != nil {
+		t.Fatalf("EncodeDeckLink() error = %v", err)
+	}
+	want := DeckLinkBaseURL + "?deck=26000021;26000014;27000000;26000030;26000010;28000011;28000000;26000038"
+	if link != want {
+		t.Errorf("EncodeDeckLink() = %s, want %s", link, want)
+	}
+
+	decoded, err := DecodeDeckLink(index, link)
+	if err != nil {
+		t.Fatalf("DecodeDeckLink() error = %v", err)
+	}
+	if !slices.Equal(decoded, deckCards) {
+		t.Errorf("DecodeDeckLink() = %v, want %v", decoded, deckCards)
+	}
+}
+
+func TestDecodeDeckLinkFormats(t *testing.T) {
+	index := NewCardIDIndex(deckLinkTestCards())
+	ids := "26000021;26000014;27000000;26000030;26000010;28000011;28000000;26000038"
+
+	links := []string{
+		"https://link.clashroyale.com/deck/en?deck=" + ids,
+		"https://link.clashroyale.com/deck/en?deck=26000021%3B26000014%3B27000000%3B26000030%3B26000010%3B28000011%3B28000000%3B26000038",
+		"https://link.clashroyale.com/en/?clashroyale://copyDeck?deck=" + ids + "&l=Royals&tt=159000000",
+		ids,
+	}
+	for _, link := range links {
+		cards, err := DecodeDeckLink(index, link)
+		if err != nil {
+			t.Errorf("DecodeDeckLink(%q) error = %v", link, err)
+			continue
+		}
+		if cards[0] != "Hog Rider" || cards[7] != "Ice Golem" {
+			t.Errorf("DecodeDeckLink(%q) = %v", link, cards)
+		}
+	}
+}
+
+func TestDeckLinkErrors(t *testing.T) {
+	index := NewCardIDIndex(deckLinkTestCards())
+
+	if _, err := EncodeDeckLink(index, []string{"Hog Rider"}); err == nil {
+		t.Error("expected error for short deck")
+	}
+	if _, err := EncodeDeckLink(index, []string{"Hog Rider", "Musketeer", "Cannon", "Ice Spirit", "Skeletons", "The Log", "Fireball", "Unknown"}); err == nil {
+		t.Error("expected error for unknown card")
+	}
+	if _, err := DecodeDeckLink(index, "https://link.clashroyale.com/deck/en"); err == nil {
+		t.Error("expected error for missing deck parameter")
+	}
+	if _, err := DecodeDeckLink(index, "https://link.clashroyale.com/deck/en?deck=1;2;3"); err == nil {
+		t.Error("expected error for wrong card count")
+	}
+	if _, err := DecodeDeckLink(index, "1;2;3;4;5;6;7;8"); err == nil {
+		t.Error("expected error for unknown card IDs")
+	}
+}
+
+func TestLoadCardIDIndex(t *testing.T) {
+	dataDir := t.TempDir()
+	if _, err := LoadCardIDIndex(dataDir); err == nil {
+		t.Error("expected error without cached cards")
+	}
+
+	path := storage.NewPathBuilder(dataDir).GetStaticCardsPath()
+	if err := storage.WriteJSON(path, clashroyale.CardList{Items: deckLinkTestCards()}); err != nil {
+		t.Fatal(err)
+	}
+	index, err := LoadCardIDIndex(dataDir)
+	if err != nil {
+		t.Fatalf("LoadCardIDIndex() error = %v", err)
+	}
+	if id, ok := index.ID("hog rider"); !ok || id != 26000021 {
+		t.Errorf("ID(hog rider) = %d, %v", id, ok)
+	}
+}
@@ -0,0 +1,131 @@
+package deck
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+)
+
+// deckNameNicknames are the short names players use for common win
+// conditions in deck names ("Hog 2.6", "LavaLoon", "RG")
+var deckNameNicknames = map[string]string{
+	"Balloon":          "Loon",
+	"Battle Ram":       "Ram",
+	"Electro Giant":    "E-Giant",
+	"Elixir Golem":     "E-Golem",
+	"Giant Skeleton":   "Giant Skelly",
+	"Goblin Barrel":    "Barrel",
+	"Goblin Drill":     "Drill",
+	"Hog Rider":        "Hog",
+	"Lava Hound":       "Lava",
+	"Mega Knight":      "MK",
+	"P.E.K.K.A":        "PEKKA",
+	"Royal Giant":      "RG",
+	"Skeleton Barrel":  "Skelly Barrel",
+	"Three Musketeers": "3M",
+}
+
+// GenerateDeckName names a deck the way players do: its win conditions, average
+// elixir, and archetype, e.g. "Evo Hog 2.6 Cycle". An evolved win condition
+// gets an "Evo" prefix; otherwise the most expensive evolved card is appended
+// ("Golem 4.3 Beatdown + Evo Valkyrie"). archetype may be empty or name both
+// halves of a hybrid ("cycle/miner"). The name depends only on the cards and
+// archetype, not on their order.
+func GenerateDeckName(cards []CardCandidate, archetype string) string {
+	if len(cards) == 0 {
+		return "Empty Deck"
+	}
+
+	ordered := slices.Clone(cards)
+	slices.SortFunc(ordered, func(a, b CardCandidate) int {
+		if costA, costB := deckNameElixir(a), deckNameElixir(b); costA != costB {
+			return costB - costA
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	var winConditions []CardCandidate
+	for _, card := range ordered {
+		if deckNameRole(card) == RoleWinCondition {
+			winConditions = append(winConditions, card)
+		}
+	}
+	if len(winConditions) == 0 {
+		// Without a win condition the most expensive card carries the deck
+		winConditions = ordered[:1]
+	}
+	winConditions = winConditions[:min(2, len(winConditions))]
+
+	parts := make([]string, 0, 6)
+	evolvedWinCondition := false
+	for _, card := range winConditions {
+		if card.EvolutionLevel > 0 && !evolvedWinCondition {
+			parts = append(parts, "Evo")
+			evolvedWinCondition = true
+		}
+		parts = append(parts, deckNameNickname(card.Name))
+	}
+
+	total := 0
+	for _, card := range cards {
+		total += deckNameElixir(card)
+	}
+	parts = append(parts, fmt.Sprintf("%.1f", float64(total)/float64(len(cards))))
+
+	if label := deckNameArchetype(archetype, winConditions); label != "" {
+		parts = append(parts, label)
+	}
+
+	name := strings.Join(parts, " ")
+	if !evolvedWinCondition {
+		for _, card := range ordered {
+			if card.EvolutionLevel > 0 {
+				name += " + Evo " + card.Name
+				break
+			}
+		}
+	}
+	return name
+}
+
+func deckNameRole(card CardCandidate) CardRole {
+	if card.Role != nil && *card.Role != "" {
+		return *card.Role
+	}
+	return config.GetCardRoleWithEvolution(card.Name, card.EvolutionLevel)
+}
+
+func deckNameElixir(card CardCandidate) int {
+	return config.GetCardElixir(card.Name, card.Elixir)
+}
+
+func deckNameNickname(name string) string {
+	if nickname, ok := deckNameNicknames[name]; ok {
+		return nickname
+	}
+	return name
+}
+
+// deckNameArchetype title-cases the archetype, dropping "unknown" and halves
+// that only repeat a win condition ("Graveyard 3.8 Graveyard")
+func deckNameArchetype(archetype string, winConditions []CardCandidate) string {
+	var words []string
+	for part := range strings.SplitSeq(strings.ToLower(strings.TrimSpace(archetype)), "/") {
+		part = strings.TrimSpace(part)
+		if part == "" || part == "unknown" {
+			continue
+		}
+		repeated := false
+		for _, card := range winConditions {
+			if strings.EqualFold(part, card.Name) || strings.EqualFold(part, deckNameNickname(card.Name)) {
+				repeated = true
+			}
+		}
+		if !repeated {
+			words = append(words, strings.ToUpper(part[:1])+part[1:])
+		}
+	}
+	return strings.Join(words, "/")
+}
@@ -0,0 +1,73 @@
+package deck
+
+import (
+	"slices"
+	"testing"
+)
+
+func namedDeck(names ...string) []CardCandidate {
+	cards := make([]CardCandidate, len(names))
+	for i, name := range names {
+		cards[i] = CardCandidate{Name: name}
+	}
+	return cards
+}
+
+func costedDeck(costs map[string]int) []CardCandidate {
+	cards := make([]CardCandidate, 0, len(costs))
+	for name, cost := range costs {
+		cards = append(cards, CardCandidate{Name: name, Elixir: cost})
+	}
+	return cards
+}
+
+func TestGenerateDeckName(t *testing.T) {
+	hogCycle := namedDeck("Hog Rider", "Musketeer", "Cannon", "Ice Golem", "Ice Spirit", "Skeletons", "Fireball", "The Log")
+	evoHog := slices.Clone(hogCycle)
+	evoHog[0].EvolutionLevel = 1
+	evoCannon := slices.Clone(hogCycle)
+	evoCannon[2].EvolutionLevel = 1
+
+	tests := []struct {
+		name      string
+		cards     []CardCandidate
+		archetype string
+		want      string
+	}{
+		{name: "hog cycle", cards: hogCycle, archetype: "cycle", want: "Hog 2.6 Cycle"},
+		{name: "evolved win condition", cards: evoHog, archetype: "cycle", want: "Evo Hog 2.6 Cycle"},
+		{name: "other evolved card", cards: evoCannon, archetype: "cycle", want: "Hog 2.6 Cycle + Evo Cannon"},
+		{name: "unknown archetype", cards: hogCycle, archetype: "unknown", want: "Hog 2.6"},
+		{
+			name:      "two win conditions",
+			cards:     costedDeck(map[string]int{"Lava Hound": 7, "Balloon": 5, "Mega Minion": 3, "Minions": 3, "Tombstone": 3, "Fireball": 4, "Zap": 2, "Skeleton Dragons": 4}),
+			archetype: "beatdown",
+			want:      "Lava Loon 3.9 Beatdown",
+		},
+		{
+			name:      "hybrid halves",
+			cards:     namedDeck("Hog Rider", "Miner", "Musketeer", "Ice Spirit", "Skeletons", "Cannon", "Fireball", "The Log"),
+			archetype: "cycle/miner",
+			want:      "Hog Miner 2.9 Cycle",
+		},
+		{name: "no win condition", cards: namedDeck("Knight", "Fireball", "Skeletons"), archetype: "", want: "Fireball 2.7"},
+		{name: "empty", cards: nil, want: "Empty Deck"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GenerateDeckName(tt.cards, tt.archetype); got != tt.want {
+				t.Errorf("GenerateDeckName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateDeckNameIgnoresCardOrder(t *testing.T) {
+	cards := namedDeck("Golem", "Night Witch", "Baby Dragon", "Lumberjack", "Tornado", "Lightning", "Barbarian Barrel", "Mega Minion")
+	want := GenerateDeckName(cards, "beatdown")
+	reversed := slices.Clone(cards)
+	slices.Reverse(reversed)
+	if got := GenerateDeckName(reversed, "beatdown"); got != want {
+		t.Errorf("reversed deck named %q, want %q", got, want)
+	}
+}
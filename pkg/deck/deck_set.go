@@ -0,0 +1,464 @@
+package deck
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+)
+
+const (
+	// DefaultDeckSetBalance is the weight of the weakest deck in the set
+	// objective when DeckSetOptions.Balance is unset
+	DefaultDeckSetBalance = 0.25
+	// DefaultDeckSetPasses bounds the local search when DeckSetOptions.MaxPasses is unset
+	DefaultDeckSetPasses = 50
+
+	// Composition penalties on the 0-10 deck score scale
+	deckSetNoWinConditionPenalty = 1.5
+	deckSetNoSpellPenalty        = 1.0
+
+	deckSetImprovementEpsilon = 1e-9
+)
+
+// deckSetSlotRoles is the role each snake-draft round fills
+var deckSetSlotRoles = []CardRole{
+	RoleWinCondition, RoleSpellBig, RoleSpellSmall, RoleBuilding,
+	RoleSupport, RoleSupport, RoleCycle, RoleCycle,
+}
+
+// DeckSetScorer rates one 8-card deck on a 0-10 scale
+type DeckSetScorer func(cards []CardCandidate) float64
+
+// DeckSetOptions configures BuildDeckSet
+type DeckSetOptions struct {
+	// Count is the number of decks; no card appears in more than one
+	Count int
+
+	// Balance (0-1) blends the weakest deck's score into the objective, so
+	// a set of even decks beats one great deck and a weak one. Zero uses
+	// DefaultDeckSetBalance; a negative value optimizes the average alone.
+	Balance float64
+
+	// MaxPasses bounds the improvement passes (default DefaultDeckSetPasses)
+	MaxPasses int
+
+	// Score rates decks; nil uses the V2 scorer for the builder's strategy
+	Score DeckSetScorer
+
+	// Duel biases the set toward decks that cover each other's weaknesses:
+	// repeated archetypes and gaps shared by several decks are penalized
+	Duel bool
+
+	// Classify names a deck's archetype for duel mode; nil skips the
+	// archetype spread and only weighs shared weaknesses
+	Classify DeckArchetypeClassifier
+}
+
+// DeckSet is a group of decks with no shared cards
+type DeckSet struct {
+	Decks  []*DeckRecommendation `json:"decks"`
+	Scores []float64             `json:"scores"`
+	// Objective is the balanced set score that was optimized
+	Objective float64 `json:"objective"`
+	Average   float64 `json:"average"`
+	Weakest   float64 `json:"weakest"`
+	// Passes is how many improvement passes ran before no move helped
+	Passes int `json:"passes"`
+
+	// Duel mode fields: each deck's archetype (in deck order), the gaps
+	// left open in more than one deck, and the faced-archetype check
+	Duel             bool             `json:"duel,omitempty"`
+	Archetypes       []string         `json:"archetypes,omitempty"`
+	SharedWeaknesses []string         `json:"shared_weaknesses,omitempty"`
+	Coverage         *DeckSetCoverage `json:"coverage,omitempty"`
+}
+
+// deckSetState is the search state: each deck's cards and current score
+type deckSetState struct {
+	decks    [][]*CardCandidate
+	scores   []float64
+	profiles []duelProfile // nil outside duel mode
+	duel     *deckSetDuel
+	used     map[string]bool
+	locked   map[string]bool
+	score    func([]*CardCandidate) float64
+	balance  float64
+	limit    int // champions per deck
+}
+
+// BuildDeckSet builds opts.Count decks from the collection at once with no
+// card in more than one deck, for Classic Challenges and war. Decks are
+// seeded by a snake draft over the core roles, then improved by swapping
+// cards between decks and bringing in unused cards until no single move
+// raises the set objective. Included cards are placed round-robin and stay
+// in their deck; excluded cards are never used. In duel mode the objective
+// also favors distinct archetypes and decks that cover each other's gaps.
+func (b *Builder) BuildDeckSet(analysis CardAnalysis, opts DeckSetOptions) (*DeckSet, error) {
+	if opts.Count < 1 {
+		return nil, fmt.Errorf("deck set count must be at least 1, got %d", opts.Count)
+	}
+	if len(analysis.CardLevels) == 0 {
+		return nil, fmt.Errorf("analysis data missing 'card_levels'")
+	}
+
+	b.clearSynergyCache()
+	if !b.unlockedEvolutionsExplicit {
+		b.unlockedEvolutions = make(map[string]bool)
+		for name, data := range analysis.CardLevels {
+			if data.EvolutionLevel > 0 {
+				b.unlockedEvolutions[name] = true
+			}
+		}
+	}
+
+	candidates := b.filterExcludedCards(b.buildCandidates(analysis.CardLevels))
+	if need := opts.Count * 8; len(candidates) < need {
+		return nil, fmt.Errorf("%d decks need %d distinct cards, only %d available", opts.Count, need, len(candidates))
+	}
+	// Highest card score first; names break ties so results are repeatable
+	slices.SortFunc(candidates, func(a, c *CardCandidate) int {
+		if r := cmp.Compare(c.Score, a.Score); r != 0 {
+			return r
+		}
+		return cmp.Compare(a.Name, c.Name)
+	})
+
+	state := b.newDeckSetState(opts)
+	if err := state.placeIncluded(b.includeCards, candidates); err != nil {
+		return nil, err
+	}
+	state.snakeDraft(candidates)
+	for i, cards := range state.decks {
+		if len(cards) < 8 {
+			return nil, fmt.Errorf("could not fill deck %d within the champion limit", i+1)
+		}
+		state.scores[i] = state.score(cards)
+		if state.duel != nil {
+			state.profiles[i] = state.duel.profile(cards)
+		}
+	}
+
+	maxPasses := opts.MaxPasses
+	if maxPasses <= 0 {
+		maxPasses = DefaultDeckSetPasses
+	}
+	passes := 0
+	for passes < maxPasses && state.improve(candidates) {
+		passes++
+	}
+
+	return b.finishDeckSet(state, analysis.AnalysisTime, passes), nil
+}
+
+func (b *Builder) newDeckSetState(opts DeckSetOptions) *deckSetState {
+	scorer := opts.Score
+	if scorer == nil {
+		synergyDB := b.synergyDB
+		if synergyDB == nil {
+			synergyDB = NewSynergyDatabase()
+		}
+		strategy := b.strategy
+		if strategy == "" {
+			strategy = StrategyBalanced
+		}
+		scorer = func(cards []CardCandidate) float64 {
+			return ScoreDeckV2Simple(cards, strategy, synergyDB) * 10
+		}
+	}
+
+	balance := opts.Balance
+	switch {
+	case balance == 0:
+		balance = DefaultDeckSetBalance
+	case balance < 0:
+		balance = 0
+	case balance > 1:
+		balance = 1
+	}
+
+	limit := b.championLimit
+	if limit <= 0 {
+		limit = 1
+	}
+
+	state := &deckSetState{
+		decks:  make([][]*CardCandidate, opts.Count),
+		scores: make([]float64, opts.Count),
+		used:   make(map[string]bool),
+		locked: make(map[string]bool),
+		score: func(deck []*CardCandidate) float64 {
+			cards := make([]CardCandidate, len(deck))
+			for i, card := range deck {
+				cards[i] = *card
+			}
+			return scorer(cards) - deckSetCompositionPenalty(deck)
+		},
+		balance: balance,
+		limit:   limit,
+	}
+	if opts.Duel {
+		state.duel = b.newDeckSetDuel(opts.Classify)
+		state.profiles = make([]duelProfile, opts.Count)
+	}
+	return state
+}
+
+// placeIncluded spreads the included cards across the decks round-robin
+func (s *deckSetState) placeIncluded(includeCards []string, candidates []*CardCandidate) error {
+	for i, name := range includeCards {
+		idx := slices.IndexFunc(candidates, func(c *CardCandidate) bool { return c.Name == name })
+		if idx < 0 || s.used[name] {
+			continue
+		}
+		card := candidates[idx]
+		d := i % len(s.decks)
+		if len(s.decks[d]) >= 8 || !s.fitsChampionLimit(s.decks[d], card, nil) {
+			return fmt.Errorf("included card %s does not fit in deck %d", name, d+1)
+		}
+		s.decks[d] = append(s.decks[d], card)
+		s.used[name] = true
+		s.locked[name] = true
+	}
+	return nil
+}
+
+// snakeDraft fills each deck one role at a time, reversing the pick order
+// every round so no deck always picks first
+func (s *deckSetState) snakeDraft(candidates []*CardCandidate) {
+	for round, role := range deckSetSlotRoles {
+		order := make([]int, len(s.decks))
+		for i := range order {
+			order[i] = i
+		}
+		if round%2 == 1 {
+			slices.Reverse(order)
+		}
+		for _, d := range order {
+			if len(s.decks[d]) >= 8 {
+				continue
+			}
+			if card := s.pick(candidates, s.decks[d], &role); card != nil {
+				s.add(d, card)
+			} else if card := s.pick(candidates, s.decks[d], nil); card != nil {
+				s.add(d, card)
+			}
+		}
+	}
+	// Included cards can leave a deck short of a full draft
+	for d := range s.decks {
+		for len(s.decks[d]) < 8 {
+			card := s.pick(candidates, s.decks[d], nil)
+			if card == nil {
+				break
+			}
+			s.add(d, card)
+		}
+	}
+}
+
+// pick returns the best unused candidate, optionally of one role, that fits
+// the deck's champion limit
+func (s *deckSetState) pick(candidates, deck []*CardCandidate, role *CardRole) *CardCandidate {
+	for _, card := range candidates {
+		if s.used[card.Name] || (role != nil && (card.Role == nil || *card.Role != *role)) {
+			continue
+		}
+		if s.fitsChampionLimit(deck, card, nil) {
+			return card
+		}
+	}
+	return nil
+}
+
+func (s *deckSetState) add(d int, card *CardCandidate) {
+	s.decks[d] = append(s.decks[d], card)
+	s.used[card.Name] = true
+}
+
+// fitsChampionLimit reports whether deck stays within the limit after
+// adding card in place of removed (which may be nil)
+func (s *deckSetState) fitsChampionLimit(deck []*CardCandidate, card, removed *CardCandidate) bool {
+	if card.Rarity != RarityChampion {
+		return true
+	}
+	count := 1
+	for _, c := range deck {
+		if c.Rarity == RarityChampion && c != removed {
+			count++
+		}
+	}
+	return count <= s.limit
+}
+
+// objective is the balanced set score for per-deck scores, less the duel
+// penalty for the decks' profiles in duel mode
+func (s *deckSetState) objective(scores []float64, profiles []duelProfile) float64 {
+	total, weakest := 0.0, math.MaxFloat64
+	for _, score := range scores {
+		total += score
+		weakest = min(weakest, score)
+	}
+	avg := total / float64(len(scores))
+	objective := avg*(1-s.balance) + weakest*s.balance
+	if s.duel != nil {
+		objective -= s.duel.penalty(profiles)
+	}
+	return objective
+}
+
+// deckSetMove is a candidate change: replace a card with an unused one, or
+// swap cards between two decks
+type deckSetMove struct {
+	deckA, slotA int
+	deckB, slotB int // deckB < 0 means replace with card
+	card         *CardCandidate
+	scoreA       float64
+	scoreB       float64
+	profileA     duelProfile
+	profileB     duelProfile
+}
+
+// improve applies the single move that raises the objective the most and
+// reports whether one was found
+func (s *deckSetState) improve(candidates []*CardCandidate) bool {
+	current := s.objective(s.scores, s.profiles)
+	best, bestObjective := deckSetMove{}, current+deckSetImprovementEpsilon
+	found := false
+	trial := slices.Clone(s.scores)
+	trialProfiles := slices.Clone(s.profiles)
+	consider := func(move deckSetMove) {
+		copy(trial, s.scores)
+		copy(trialProfiles, s.profiles)
+		trial[move.deckA] = move.scoreA
+		if move.deckB >= 0 {
+			trial[move.deckB] = move.scoreB
+		}
+		if s.duel != nil {
+			trialProfiles[move.deckA] = move.profileA
+			if move.deckB >= 0 {
+				trialProfiles[move.deckB] = move.profileB
+			}
+		}
+		if obj := s.objective(trial, trialProfiles); obj > bestObjective {
+			best, bestObjective, found = move, obj, true
+		}
+	}
+
+	for d, deck := range s.decks {
+		for slot, out := range deck {
+			if s.locked[out.Name] {
+				continue
+			}
+			// Bring in an unused card
+			for _, card := range candidates {
+				if s.used[card.Name] || !s.fitsChampionLimit(deck, card, out) {
+					continue
+				}
+				move := deckSetMove{deckA: d, slotA: slot, deckB: -1, card: card}
+				move.scoreA, move.profileA = s.scoreWith(d, slot, card)
+				consider(move)
+			}
+			// Trade with a later deck
+			for e := d + 1; e < len(s.decks); e++ {
+				for other, in := range s.decks[e] {
+					if s.locked[in.Name] || !s.fitsChampionLimit(deck, in, out) || !s.fitsChampionLimit(s.decks[e], out, in) {
+						continue
+					}
+					move := deckSetMove{deckA: d, slotA: slot, deckB: e, slotB: other}
+					move.scoreA, move.profileA = s.scoreWith(d, slot, in)
+					move.scoreB, move.profileB = s.scoreWith(e, other, out)
+					consider(move)
+				}
+			}
+		}
+	}
+	if !found {
+		return false
+	}
+
+	if best.deckB < 0 {
+		delete(s.used, s.decks[best.deckA][best.slotA].Name)
+		s.used[best.card.Name] = true
+		s.decks[best.deckA][best.slotA] = best.card
+	} else {
+		a, b := s.decks[best.deckA][best.slotA], s.decks[best.deckB][best.slotB]
+		s.decks[best.deckA][best.slotA], s.decks[best.deckB][best.slotB] = b, a
+		s.scores[best.deckB] = best.scoreB
+		if s.duel != nil {
+			s.profiles[best.deckB] = best.profileB
+		}
+	}
+	s.scores[best.deckA] = best.scoreA
+	if s.duel != nil {
+		s.profiles[best.deckA] = best.profileA
+	}
+	return true
+}
+
+// scoreWith scores deck d with the card in slot replaced, along with its
+// duel profile in duel mode
+func (s *deckSetState) scoreWith(d, slot int, card *CardCandidate) (float64, duelProfile) {
+	deck := slices.Clone(s.decks[d])
+	deck[slot] = card
+	if s.duel == nil {
+		return s.score(deck), duelProfile{}
+	}
+	return s.score(deck), s.duel.profile(deck)
+}
+
+// deckSetCompositionPenalty keeps every deck playable: each needs a win
+// condition and a spell
+func deckSetCompositionPenalty(deck []*CardCandidate) float64 {
+	hasWinCondition, hasSpell := false, false
+	for _, card := range deck {
+		if card.Role == nil {
+			continue
+		}
+		switch *card.Role {
+		case RoleWinCondition:
+			hasWinCondition = true
+		case RoleSpellBig, RoleSpellSmall:
+			hasSpell = true
+		}
+	}
+	penalty := 0.0
+	if !hasWinCondition {
+		penalty += deckSetNoWinConditionPenalty
+	}
+	if !hasSpell {
+		penalty += deckSetNoSpellPenalty
+	}
+	return penalty
+}
+
+// finishDeckSet turns the search state into recommendations, best deck first
+func (b *Builder) finishDeckSet(state *deckSetState, analysisTime string, passes int) *DeckSet {
+	order := make([]int, len(state.decks))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(i, j int) int {
+		return cmp.Compare(state.scores[j], state.scores[i])
+	})
+
+	set := &DeckSet{Passes: passes, Objective: state.objective(state.scores, state.profiles), Weakest: math.MaxFloat64}
+	for _, i := range order {
+		cards := state.decks[i]
+		recommendation := b.buildRecommendationDetails(cards, analysisTime, b.selectEvolutionSlots(cards), []string{})
+		b.finalizeRecommendation(recommendation)
+		set.Decks = append(set.Decks, recommendation)
+		set.Scores = append(set.Scores, state.scores[i])
+		if state.duel != nil {
+			set.Archetypes = append(set.Archetypes, state.profiles[i].archetype)
+		}
+		set.Average += state.scores[i]
+		set.Weakest = min(set.Weakest, state.scores[i])
+	}
+	set.Average /= float64(len(set.Scores))
+	if state.duel != nil {
+		set.Duel = true
+		set.SharedWeaknesses = sharedDuelGaps(state.profiles)
+	}
+	return set
+}
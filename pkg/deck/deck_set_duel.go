@@ -0,0 +1,243 @@
+package deck
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+const (
+	// DuelArchetypePenalty is taken from the set objective for each deck
+	// that repeats an archetype already in the set
+	DuelArchetypePenalty = 0.5
+	// DuelSharedGapPenalty is taken for each extra deck that leaves the
+	// same weakness open, so the decks cover for each other
+	DuelSharedGapPenalty = 0.3
+	// DuelCoverageThreshold is the counter coverage (0-1) a faced archetype
+	// needs from its best deck to count as covered
+	DuelCoverageThreshold = 0.5
+)
+
+// DeckArchetypeClassifier names the archetype of an 8-card deck
+type DeckArchetypeClassifier func(cards []CardCandidate) string
+
+// duelGap is a bit set of weaknesses a deck leaves open
+type duelGap uint8
+
+const (
+	duelGapAirDefense duelGap = 1 << iota
+	duelGapSwarmClear
+	duelGapTankKillers
+	duelGapSmallSpell
+	duelGapBigSpell
+)
+
+var duelGapNames = []struct {
+	gap  duelGap
+	name string
+}{
+	{duelGapAirDefense, "air defense"},
+	{duelGapSwarmClear, "swarm clear"},
+	{duelGapTankKillers, "tank killers"},
+	{duelGapSmallSpell, "small spell"},
+	{duelGapBigSpell, "big spell"},
+}
+
+// duelProfile is what duel mode compares between decks
+type duelProfile struct {
+	archetype string
+	gaps      duelGap
+}
+
+type deckSetDuel struct {
+	classify DeckArchetypeClassifier
+	matrix   *CounterMatrix
+}
+
+func (b *Builder) newDeckSetDuel(classify DeckArchetypeClassifier) *deckSetDuel {
+	if b.counterMatrix == nil {
+		b.counterMatrix = LoadCounterMatrix(b.dataDir, "")
+	}
+	return &deckSetDuel{classify: classify, matrix: b.counterMatrix}
+}
+
+// profile classifies the deck and finds the defensive and spell gaps it
+// leaves open
+func (d *deckSetDuel) profile(deck []*CardCandidate) duelProfile {
+	names := make([]string, len(deck))
+	var smallSpell, bigSpell bool
+	for i, card := range deck {
+		names[i] = card.Name
+		if card.Role != nil {
+			smallSpell = smallSpell || *card.Role == RoleSpellSmall
+			bigSpell = bigSpell || *card.Role == RoleSpellBig
+		}
+	}
+
+	var p duelProfile
+	needs := AnalyzeTowerTroopNeeds(d.matrix, names)
+	if needs.AirDefense {
+		p.gaps |= duelGapAirDefense
+	}
+	if needs.SwarmClear {
+		p.gaps |= duelGapSwarmClear
+	}
+	if needs.TankKillers {
+		p.gaps |= duelGapTankKillers
+	}
+	if !smallSpell {
+		p.gaps |= duelGapSmallSpell
+	}
+	if !bigSpell {
+		p.gaps |= duelGapBigSpell
+	}
+
+	if d.classify != nil {
+		cards := make([]CardCandidate, len(deck))
+		for i, card := range deck {
+			cards[i] = *card
+		}
+		p.archetype = d.classify(cards)
+	}
+	return p
+}
+
+// penalty charges for repeated archetypes and for weaknesses left open in
+// more than one deck
+func (d *deckSetDuel) penalty(profiles []duelProfile) float64 {
+	archetypes := make(map[string]int, len(profiles))
+	for _, p := range profiles {
+		if p.archetype != "" {
+			archetypes[p.archetype]++
+		}
+	}
+	penalty := 0.0
+	for _, count := range archetypes {
+		penalty += float64(count-1) * DuelArchetypePenalty
+	}
+	for _, g := range duelGapNames {
+		if count := duelGapCount(profiles, g.gap); count > 1 {
+			penalty += float64(count-1) * DuelSharedGapPenalty
+		}
+	}
+	return penalty
+}
+
+func duelGapCount(profiles []duelProfile, gap duelGap) int {
+	count := 0
+	for _, p := range profiles {
+		if p.gaps&gap != 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// sharedDuelGaps names the weaknesses left open in more than one deck
+func sharedDuelGaps(profiles []duelProfile) []string {
+	var shared []string
+	for _, g := range duelGapNames {
+		if count := duelGapCount(profiles, g.gap); count > 1 {
+			shared = append(shared, fmt.Sprintf("%s (%d decks)", g.name, count))
+		}
+	}
+	return shared
+}
+
+// FacedDeck is an opponent deck from the battle log
+type FacedDeck struct {
+	Archetype string   `json:"archetype"`
+	Cards     []string `json:"cards"`
+}
+
+// ArchetypeCoverage reports how well the set answers one faced archetype
+type ArchetypeCoverage struct {
+	Archetype string `json:"archetype"`
+	Faced     int    `json:"faced"`
+	// BestDeck is the 1-based deck in the set with the highest average
+	// counter coverage against this archetype's decks
+	BestDeck int     `json:"best_deck"`
+	Coverage float64 `json:"coverage"`
+	Covered  bool    `json:"covered"`
+}
+
+// DeckSetCoverage checks a deck set against recently faced opponents
+type DeckSetCoverage struct {
+	Battles    int                 `json:"battles"`
+	Archetypes []ArchetypeCoverage `json:"archetypes"`
+	// Score is the average best coverage, weighted by how often each
+	// archetype was faced
+	Score     float64  `json:"score"`
+	Uncovered []string `json:"uncovered,omitempty"`
+}
+
+// ValidateCoverage groups faced decks by archetype and finds, for each, the
+// deck in the set that counters them best. Archetypes whose best deck stays
+// under DuelCoverageThreshold are listed as uncovered. The result is also
+// stored on the set.
+func (s *DeckSet) ValidateCoverage(faced []FacedDeck, db *CountersDatabase) (*DeckSetCoverage, error) {
+	if db == nil {
+		return nil, fmt.Errorf("counters database is required")
+	}
+	if len(s.Decks) == 0 {
+		return nil, fmt.Errorf("deck set is empty")
+	}
+
+	type tally struct {
+		faced int
+		sums  []float64 // coverage per set deck
+	}
+	tallies := make(map[string]*tally)
+	coverage := &DeckSetCoverage{}
+	for _, opponent := range faced {
+		if len(opponent.Cards) == 0 {
+			continue
+		}
+		archetype := opponent.Archetype
+		if archetype == "" {
+			archetype = "unknown"
+		}
+		t := tallies[archetype]
+		if t == nil {
+			t = &tally{sums: make([]float64, len(s.Decks))}
+			tallies[archetype] = t
+		}
+		t.faced++
+		coverage.Battles++
+		for i, rec := range s.Decks {
+			_, score := AnalyzeCounterCoverage(rec.Deck, opponent.Cards, db)
+			t.sums[i] += score
+		}
+	}
+
+	weighted := 0.0
+	for archetype, t := range tallies {
+		entry := ArchetypeCoverage{Archetype: archetype, Faced: t.faced, BestDeck: 1}
+		for i, sum := range t.sums {
+			if avg := sum / float64(t.faced); avg > entry.Coverage {
+				entry.Coverage, entry.BestDeck = avg, i+1
+			}
+		}
+		entry.Covered = entry.Coverage >= DuelCoverageThreshold
+		coverage.Archetypes = append(coverage.Archetypes, entry)
+		weighted += entry.Coverage * float64(t.faced)
+	}
+	// Most faced first; names break ties
+	slices.SortFunc(coverage.Archetypes, func(a, b ArchetypeCoverage) int {
+		if r := cmp.Compare(b.Faced, a.Faced); r != 0 {
+			return r
+		}
+		return cmp.Compare(a.Archetype, b.Archetype)
+	})
+	for _, entry := range coverage.Archetypes {
+		if !entry.Covered {
+			coverage.Uncovered = append(coverage.Uncovered, entry.Archetype)
+		}
+	}
+	if coverage.Battles > 0 {
+		coverage.Score = weighted / float64(coverage.Battles)
+	}
+
+	s.Coverage = coverage
+	return coverage, nil
+}
@@ -0,0 +1,140 @@
+package deck
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// elixirClassifier labels decks by average elixir, standing in for the
+// evaluation package's archetype detection
+func elixirClassifier(cards []CardCandidate) string {
+	total := 0
+	for _, card := range cards {
+		total += card.Elixir
+	}
+	if float64(total)/float64(len(cards)) >= 4 {
+		return "beatdown"
+	}
+	return "cycle"
+}
+
+func TestBuildDeckSetDuelMode(t *testing.T) {
+	builder := NewBuilder("testdata")
+	set, err := builder.BuildDeckSet(deckSetTestAnalysis(), DeckSetOptions{Count: 2, Duel: true, Classify: elixirClassifier})
+	if err != nil {
+		t.Fatalf("BuildDeckSet() error = %v", err)
+	}
+	if !set.Duel || len(set.Archetypes) != 2 {
+		t.Fatalf("duel set = %+v, want two archetypes", set)
+	}
+	if set.Archetypes[0] == set.Archetypes[1] {
+		t.Errorf("both decks are %s; duel mode should spread archetypes", set.Archetypes[0])
+	}
+
+	seen := make(map[string]bool)
+	for _, rec := range set.Decks {
+		for _, name := range rec.Deck {
+			if seen[name] {
+				t.Errorf("%s is in more than one deck", name)
+			}
+			seen[name] = true
+		}
+	}
+
+	plain, err := NewBuilder("testdata").BuildDeckSet(deckSetTestAnalysis(), DeckSetOptions{Count: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plain.Duel || plain.Archetypes != nil {
+		t.Errorf("non-duel set reported duel fields: %+v", plain)
+	}
+}
+
+func TestDuelPenalty(t *testing.T) {
+	duel := &deckSetDuel{}
+	distinct := []duelProfile{{archetype: "cycle"}, {archetype: "beatdown", gaps: duelGapBigSpell}}
+	if got := duel.penalty(distinct); got != 0 {
+		t.Errorf("penalty for distinct decks = %.2f, want 0", got)
+	}
+
+	repeated := []duelProfile{
+		{archetype: "cycle", gaps: duelGapBigSpell | duelGapAirDefense},
+		{archetype: "cycle", gaps: duelGapBigSpell},
+		{archetype: "cycle", gaps: duelGapBigSpell},
+	}
+	want := 2*DuelArchetypePenalty + 2*DuelSharedGapPenalty
+	if got := duel.penalty(repeated); got != want {
+		t.Errorf("penalty = %.2f, want %.2f", got, want)
+	}
+	if got := sharedDuelGaps(repeated); len(got) != 1 || got[0] != "big spell (3 decks)" {
+		t.Errorf("sharedDuelGaps() = %v", got)
+	}
+}
+
+func TestDuelProfileFindsSpellGaps(t *testing.T) {
+	builder := NewBuilder("testdata")
+	duel := builder.newDeckSetDuel(nil)
+	small, support := RoleSpellSmall, RoleSupport
+	deck := []*CardCandidate{{Name: "Zap", Role: &small}, {Name: "Knight", Role: &support}}
+
+	p := duel.profile(deck)
+	if p.gaps&duelGapSmallSpell != 0 {
+		t.Error("deck with Zap reported a small spell gap")
+	}
+	if p.gaps&duelGapBigSpell == 0 {
+		t.Error("deck without a big spell should report the gap")
+	}
+	if p.archetype != "" {
+		t.Errorf("archetype = %q without a classifier", p.archetype)
+	}
+}
+
+func TestDeckSetValidateCoverage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "counters.json")
+	data := `{"version":1,"cards":{
+		"Hog Rider":{"hard":["Cannon"],"soft":["Knight"]},
+		"Golem":{"hard":["Inferno Tower"]},
+		"Graveyard":{"hard":["Poison"]}}}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	db, err := LoadCountersDatabase(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	set := &DeckSet{Decks: []*DeckRecommendation{
+		{Deck: []string{"Knight", "Arrows"}},
+		{Deck: []string{"Cannon", "Inferno Tower"}},
+	}}
+	faced := []FacedDeck{
+		{Archetype: "cycle", Cards: []string{"Hog Rider"}},
+		{Archetype: "cycle", Cards: []string{"Hog Rider"}},
+		{Archetype: "beatdown", Cards: []string{"Golem"}},
+		{Archetype: "graveyard", Cards: []string{"Graveyard"}},
+		{Archetype: "cycle"}, // no cards: skipped
+	}
+
+	coverage, err := set.ValidateCoverage(faced, db)
+	if err != nil {
+		t.Fatalf("ValidateCoverage() error = %v", err)
+	}
+	if set.Coverage != coverage || coverage.Battles != 4 || len(coverage.Archetypes) != 3 {
+		t.Fatalf("coverage = %+v", coverage)
+	}
+	cycle := coverage.Archetypes[0]
+	if cycle.Archetype != "cycle" || cycle.Faced != 2 || cycle.BestDeck != 2 || cycle.Coverage != 1 || !cycle.Covered {
+		t.Errorf("cycle coverage = %+v, want deck 2 with a hard counter", cycle)
+	}
+	if len(coverage.Uncovered) != 1 || coverage.Uncovered[0] != "graveyard" {
+		t.Errorf("uncovered = %v, want [graveyard]", coverage.Uncovered)
+	}
+	if coverage.Score != 0.75 {
+		t.Errorf("score = %.2f, want 0.75", coverage.Score)
+	}
+
+	if _, err := set.ValidateCoverage(faced, nil); err == nil {
+		t.Error("expected an error without a counters database")
+	}
+}
@@ -0,0 +1,132 @@
+package deck
+
+import (
+	"strings"
+	"testing"
+)
+
+func deckSetTestAnalysis() CardAnalysis {
+	cards := map[string]struct {
+		rarity string
+		elixir int
+	}{
+		"Hog Rider": {"Rare", 4}, "Giant": {"Rare", 5}, "Balloon": {"Epic", 5}, "Golem": {"Epic", 8},
+		"Royal Giant": {"Common", 6}, "Miner": {"Legendary", 3},
+		"Fireball": {"Rare", 4}, "Poison": {"Epic", 4}, "Lightning": {"Epic", 6}, "Rocket": {"Rare", 6},
+		"Zap": {"Common", 2}, "The Log": {"Legendary", 2}, "Arrows": {"Common", 3}, "Barbarian Barrel": {"Epic", 2},
+		"Cannon": {"Common", 3}, "Tesla": {"Common", 4}, "Inferno Tower": {"Rare", 5}, "Bomb Tower": {"Rare", 4},
+		"Musketeer": {"Rare", 4}, "Valkyrie": {"Rare", 4}, "Baby Dragon": {"Epic", 4}, "Mega Minion": {"Rare", 3},
+		"Knight": {"Common", 3}, "Archers": {"Common", 3}, "Minions": {"Common", 3}, "Wizard": {"Rare", 5},
+		"Night Witch": {"Legendary", 4}, "Electro Wizard": {"Legendary", 4}, "Lumberjack": {"Legendary", 4},
+		"Skeletons": {"Common", 1}, "Ice Spirit": {"Common", 1}, "Bats": {"Common", 2}, "Fire Spirit": {"Common", 1},
+		"Ice Golem": {"Rare", 2}, "Goblins": {"Common", 2}, "Spear Goblins": {"Common", 2},
+		"Archer Queen": {"Champion", 5}, "Golden Knight": {"Champion", 4}, "Skeleton King": {"Champion", 4},
+	}
+	levels := make(map[string]CardLevelData, len(cards))
+	for name, card := range cards {
+		levels[name] = CardLevelData{Level: 11, MaxLevel: 14, Rarity: card.rarity, Elixir: card.elixir}
+	}
+	return CardAnalysis{CardLevels: levels}
+}
+
+func TestBuildDeckSetHasNoOverlap(t *testing.T) {
+	builder := NewBuilder("testdata")
+	set, err := builder.BuildDeckSet(deckSetTestAnalysis(), DeckSetOptions{Count: 4})
+	if err != nil {
+		t.Fatalf("BuildDeckSet() error = %v", err)
+	}
+	if len(set.Decks) != 4 || len(set.Scores) != 4 {
+		t.Fatalf("got %d decks and %d scores, want 4", len(set.Decks), len(set.Scores))
+	}
+
+	seen := make(map[string]int)
+	for i, rec := range set.Decks {
+		if len(rec.Deck) != 8 {
+			t.Fatalf("deck %d has %d cards", i+1, len(rec.Deck))
+		}
+		champions, winConditions := 0, 0
+		for j, name := range rec.Deck {
+			if prev, dup := seen[name]; dup {
+				t.Errorf("%s is in deck %d and deck %d", name, prev+1, i+1)
+			}
+			seen[name] = i
+			if rec.DeckDetail[j].Rarity == RarityChampion {
+				champions++
+			}
+			if rec.DeckDetail[j].Role == string(RoleWinCondition) {
+				winConditions++
+			}
+		}
+		if champions > 1 {
+			t.Errorf("deck %d has %d champions", i+1, champions)
+		}
+		if winConditions == 0 {
+			t.Errorf("deck %d has no win condition: %v", i+1, rec.Deck)
+		}
+		if i > 0 && set.Scores[i] > set.Scores[i-1] {
+			t.Errorf("decks should be ordered best first: %v", set.Scores)
+		}
+	}
+	if set.Weakest != set.Scores[3] || set.Weakest > set.Average {
+		t.Errorf("weakest %.2f, average %.2f, scores %v", set.Weakest, set.Average, set.Scores)
+	}
+}
+
+func TestBuildDeckSetImprovesOnTheDraft(t *testing.T) {
+	analysis := deckSetTestAnalysis()
+	drafted, err := NewBuilder("testdata").BuildDeckSet(analysis, DeckSetOptions{Count: 2, MaxPasses: -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A negative MaxPasses still runs the default number of passes
+	if drafted.Passes == 0 {
+		t.Fatal("expected the local search to find at least one improving move")
+	}
+
+	oneStep, err := NewBuilder("testdata").BuildDeckSet(analysis, DeckSetOptions{Count: 2, MaxPasses: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oneStep.Passes != 1 || drafted.Objective < oneStep.Objective {
+		t.Errorf("full search objective %.3f after %d passes, one pass %.3f", drafted.Objective, drafted.Passes, oneStep.Objective)
+	}
+}
+
+func TestBuildDeckSetKeepsIncludedCardsAndSkipsExcluded(t *testing.T) {
+	builder := NewBuilder("testdata")
+	builder.SetIncludeCards([]string{"Golem", "Miner"})
+	builder.SetExcludeCards([]string{"Hog Rider"})
+
+	set, err := builder.BuildDeckSet(deckSetTestAnalysis(), DeckSetOptions{Count: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	all := strings.Join(append(set.Decks[0].Deck, set.Decks[1].Deck...), ",")
+	if !strings.Contains(all, "Golem") || !strings.Contains(all, "Miner") {
+		t.Errorf("included cards missing: %s", all)
+	}
+	if strings.Contains(all, "Hog Rider") {
+		t.Errorf("excluded card used: %s", all)
+	}
+	for _, rec := range set.Decks {
+		hasGolem, hasMiner := false, false
+		for _, name := range rec.Deck {
+			hasGolem = hasGolem || name == "Golem"
+			hasMiner = hasMiner || name == "Miner"
+		}
+		if hasGolem && hasMiner {
+			t.Errorf("included cards should be spread across decks: %v", rec.Deck)
+		}
+	}
+}
+
+func TestBuildDeckSetErrors(t *testing.T) {
+	builder := NewBuilder("testdata")
+	if _, err := builder.BuildDeckSet(deckSetTestAnalysis(), DeckSetOptions{Count: 0}); err == nil {
+		t.Error("expected an error for a zero count")
+	}
+	if _, err := builder.BuildDeckSet(deckSetTestAnalysis(), DeckSetOptions{Count: 5}); err == nil ||
+		!strings.Contains(err.Error(), "40 distinct cards") {
+		t.Errorf("expected a collection size error, got %v", err)
+	}
+}
@@ -68,6 +68,7 @@ func NewDeckSpaceCalculator(player *clashroyale.Player) (*DeckSpaceCalculator, e
 			EvolutionLevel:    card.EvolutionLevel,
 			MaxEvolutionLevel: card.MaxEvolutionLevel,
 		}
+		candidate.Stats = CombatStatsFor(nil, card.Name, candidate.StandardLevel())
 
 		calc.cards = append(calc.cards, candidate)
 		calc.cardsByRole[role] = append(calc.cardsByRole[role], candidate)
@@ -0,0 +1,113 @@
+package deckimage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/jpeg" // card art is PNG, but accept JPEG icons too
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/closeutil"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+// maxArtBytes caps a single card art download
+const maxArtBytes = 5 << 20
+
+// ArtCache downloads card art and keeps it in a local directory so each card
+// is fetched once.
+type ArtCache struct {
+	Dir    string
+	Client *http.Client
+}
+
+// NewArtCache creates a cache that stores card art in dir.
+func NewArtCache(dir string) *ArtCache {
+	return &ArtCache{
+		Dir:    dir,
+		Client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Path returns the cache file for a card's art.
+func (c *ArtCache) Path(card clashroyale.Card) string {
+	return filepath.Join(c.Dir, fmt.Sprintf("%d.png", card.ID))
+}
+
+// Load returns a card's art, downloading it from the card's medium icon URL
+// when it is not cached yet.
+func (c *ArtCache) Load(ctx context.Context, card clashroyale.Card) (image.Image, error) {
+	path := c.Path(card)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		data, err = c.download(ctx, card)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.store(path, data); err != nil {
+			return nil, err
+		}
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode card art %s: %w", path, err)
+	}
+	return img, nil
+}
+
+func (c *ArtCache) download(ctx context.Context, card clashroyale.Card) ([]byte, error) {
+	url := card.IconUrls.Medium
+	if url == "" {
+		return nil, fmt.Errorf("no icon URL in the card database")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create art request: %w", err)
+	}
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download card art: %w", err)
+	}
+	defer closeutil.WithLog("deckimage", resp.Body, "card art response")
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download card art: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxArtBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read card art: %w", err)
+	}
+	if len(data) > maxArtBytes {
+		return nil, fmt.Errorf("card art larger than %d bytes", maxArtBytes)
+	}
+	if _, _, err := image.DecodeConfig(bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("downloaded card art is not an image: %w", err)
+	}
+	return data, nil
+}
+
+// store writes art through a temporary file so an interrupted write never
+// leaves a truncated image in the cache.
+func (c *ArtCache) store(path string, data []byte) error {
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create art cache: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to cache card art: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to cache card art: %w", err)
+	}
+	return nil
+}
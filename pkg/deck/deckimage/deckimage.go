@@ -0,0 +1,311 @@
+// Package deckimage renders a deck as a shareable image: a 4x2 grid of card
+// art with each card's elixir cost, written as PNG or SVG.
+//
+// Card art comes from the iconUrls of the cards endpoint and is cached
+// locally by ArtCache, so each card is downloaded once. Cards without art
+// are drawn as placeholders colored by rarity.
+package deckimage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+// Format is an output image format.
+type Format string
+
+const (
+	FormatPNG Format = "png"
+	FormatSVG Format = "svg"
+)
+
+// Grid layout in pixels
+const (
+	columns     = 4
+	tileWidth   = 150
+	tileHeight  = 180
+	padding     = 10
+	labelHeight = 24 // SVG only; PNG has no font to draw names with
+)
+
+var (
+	backgroundColor  = color.RGBA{0x1b, 0x24, 0x38, 0xff}
+	elixirColor      = color.RGBA{0xc0, 0x3a, 0xd6, 0xff}
+	elixirTextColor  = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	placeholderColor = color.RGBA{0x5a, 0x65, 0x78, 0xff}
+	rarityColors     = map[string]color.RGBA{
+		"common":    {0x9d, 0xb4, 0xc8, 0xff},
+		"rare":      {0xf5, 0x9e, 0x2e, 0xff},
+		"epic":      {0xa8, 0x4c, 0xd8, 0xff},
+		"legendary": {0x4f, 0xd1, 0xc5, 0xff},
+		"champion":  {0xe8, 0xc1, 0x3c, 0xff},
+	}
+)
+
+// Tile is one card in the grid. Art is optional.
+type Tile struct {
+	Name   string
+	Rarity string
+	Elixir int
+	Art    image.Image
+}
+
+// FormatForPath picks the image format from a file extension.
+func FormatForPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".png":
+		return FormatPNG, nil
+	case ".svg":
+		return FormatSVG, nil
+	default:
+		return "", fmt.Errorf("unsupported image extension %q (use .png or .svg)", filepath.Ext(path))
+	}
+}
+
+// BuildTiles matches deck card names against the card database and loads
+// their art through art (which may be nil to skip art). Every name gets a
+// tile; the returned error joins the cards that fell back to a placeholder.
+func BuildTiles(ctx context.Context, names []string, cards []clashroyale.Card, art *ArtCache) ([]Tile, error) {
+	byName := make(map[string]clashroyale.Card, len(cards))
+	for _, card := range cards {
+		byName[strings.ToLower(card.Name)] = card
+	}
+
+	tiles := make([]Tile, 0, len(names))
+	var errs []error
+	for _, name := range names {
+		card, ok := byName[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			tiles = append(tiles, Tile{Name: name})
+			errs = append(errs, fmt.Errorf("%s: not in the card database", name))
+			continue
+		}
+		tile := Tile{Name: card.Name, Rarity: card.Rarity, Elixir: card.ElixirCost}
+		if art != nil {
+			img, err := art.Load(ctx, card)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", card.Name, err))
+			}
+			tile.Art = img
+		}
+		tiles = append(tiles, tile)
+	}
+	return tiles, errors.Join(errs...)
+}
+
+// Render writes tiles as an image in the given format.
+func Render(w io.Writer, format Format, tiles []Tile) error {
+	if len(tiles) == 0 {
+		return fmt.Errorf("no cards to render")
+	}
+	switch format {
+	case FormatPNG:
+		return png.Encode(w, renderRaster(tiles))
+	case FormatSVG:
+		return renderSVG(w, tiles)
+	default:
+		return fmt.Errorf("unsupported image format %q", format)
+	}
+}
+
+// WriteFile renders tiles to path, picking the format from its extension.
+func WriteFile(path string, tiles []Tile) error {
+	format, err := FormatForPath(path)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := Render(&buf, format, tiles); err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create image directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write deck image: %w", err)
+	}
+	return nil
+}
+
+func gridSize(count, rowExtra int) (width, height int) {
+	rows := (count + columns - 1) / columns
+	cols := min(count, columns)
+	return padding + cols*(tileWidth+padding), padding + rows*(tileHeight+rowExtra+padding)
+}
+
+func tileOrigin(i, rowExtra int) image.Point {
+	return image.Pt(
+		padding+(i%columns)*(tileWidth+padding),
+		padding+(i/columns)*(tileHeight+rowExtra+padding),
+	)
+}
+
+func tileColor(tile Tile) color.RGBA {
+	if c, ok := rarityColors[strings.ToLower(tile.Rarity)]; ok {
+		return c
+	}
+	return placeholderColor
+}
+
+func renderRaster(tiles []Tile) *image.RGBA {
+	width, height := gridSize(len(tiles), 0)
+	canvas := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(canvas, canvas.Bounds(), image.NewUniform(backgroundColor), image.Point{}, draw.Src)
+
+	for i, tile := range tiles {
+		origin := tileOrigin(i, 0)
+		rect := image.Rectangle{Min: origin, Max: origin.Add(image.Pt(tileWidth, tileHeight))}
+		if tile.Art != nil {
+			drawScaled(canvas, fitRect(rect, tile.Art.Bounds()), tile.Art)
+		} else {
+			draw.Draw(canvas, rect, image.NewUniform(tileColor(tile)), image.Point{}, draw.Src)
+		}
+		if tile.Elixir > 0 {
+			drawElixir(canvas, origin.Add(image.Pt(18, 18)), tile.Elixir)
+		}
+	}
+	return canvas
+}
+
+// fitRect returns the largest rectangle with src's aspect ratio centered in
+// dst.
+func fitRect(dst, src image.Rectangle) image.Rectangle {
+	sw, sh := src.Dx(), src.Dy()
+	if sw <= 0 || sh <= 0 {
+		return dst
+	}
+	w, h := dst.Dx(), dst.Dy()
+	if w*sh > h*sw {
+		w = h * sw / sh
+	} else {
+		h = w * sh / sw
+	}
+	origin := dst.Min.Add(image.Pt((dst.Dx()-w)/2, (dst.Dy()-h)/2))
+	return image.Rectangle{Min: origin, Max: origin.Add(image.Pt(w, h))}
+}
+
+// drawScaled draws src into dst scaled to rect, averaging the source pixels
+// under each destination pixel and blending over what is already there.
+func drawScaled(dst *image.RGBA, rect image.Rectangle, src image.Image) {
+	sb := src.Bounds()
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		sy0 := sb.Min.Y + (y-rect.Min.Y)*sb.Dy()/rect.Dy()
+		sy1 := max(sb.Min.Y+(y-rect.Min.Y+1)*sb.Dy()/rect.Dy(), sy0+1)
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			sx0 := sb.Min.X + (x-rect.Min.X)*sb.Dx()/rect.Dx()
+			sx1 := max(sb.Min.X+(x-rect.Min.X+1)*sb.Dx()/rect.Dx(), sx0+1)
+
+			var r, g, b, a, n uint32
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, b, a, n = r+pr, g+pg, b+pb, a+pa, n+1
+				}
+			}
+			// Source values are alpha-premultiplied, so "over" is src + dst*(1-alpha)
+			r, g, b, a = r/n, g/n, b/n, a/n
+			under := dst.RGBAAt(x, y)
+			dst.SetRGBA(x, y, color.RGBA{
+				R: blendOver(r, under.R, a),
+				G: blendOver(g, under.G, a),
+				B: blendOver(b, under.B, a),
+				A: blendOver(a, under.A, a),
+			})
+		}
+	}
+}
+
+// blendOver composites a 16-bit premultiplied source channel with coverage
+// alpha over an 8-bit destination channel.
+func blendOver(src uint32, under uint8, alpha uint32) uint8 {
+	return uint8((src + uint32(under)*0x101*(0xffff-alpha)/0xffff) >> 8)
+}
+
+// digitGlyphs are 3x5 bitmaps for 0-9, one row per entry, high bit left.
+var digitGlyphs = [10][5]uint8{
+	{7, 5, 5, 5, 7}, {2, 6, 2, 2, 7}, {7, 1, 7, 4, 7}, {7, 1, 7, 1, 7}, {5, 5, 7, 1, 1},
+	{7, 4, 7, 1, 7}, {7, 4, 7, 5, 7}, {7, 1, 1, 1, 1}, {7, 5, 7, 5, 7}, {7, 5, 7, 1, 7},
+}
+
+// drawElixir draws an elixir drop with the cost centered at center.
+func drawElixir(dst *image.RGBA, center image.Point, cost int) {
+	const radius, scale = 14, 3
+	for y := -radius; y <= radius; y++ {
+		for x := -radius; x <= radius; x++ {
+			if x*x+y*y <= radius*radius {
+				dst.SetRGBA(center.X+x, center.Y+y, elixirColor)
+			}
+		}
+	}
+
+	digits := fmt.Sprint(cost)
+	width := len(digits)*4*scale - scale
+	left := center.X - width/2
+	top := center.Y - 5*scale/2
+	for i, d := range digits {
+		glyph := digitGlyphs[d-'0']
+		for row, bits := range glyph {
+			for col := range 3 {
+				if bits&(4>>col) == 0 {
+					continue
+				}
+				x0 := left + (i*4+col)*scale
+				y0 := top + row*scale
+				draw.Draw(dst, image.Rect(x0, y0, x0+scale, y0+scale), image.NewUniform(elixirTextColor), image.Point{}, draw.Src)
+			}
+		}
+	}
+}
+
+func renderSVG(w io.Writer, tiles []Tile) error {
+	width, height := gridSize(len(tiles), labelHeight)
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", width, height, width, height)
+	fmt.Fprintf(&b, `  <rect width="100%%" height="100%%" fill="%s"/>`+"\n", hexColor(backgroundColor))
+
+	for i, tile := range tiles {
+		origin := tileOrigin(i, labelHeight)
+		if tile.Art != nil {
+			var buf bytes.Buffer
+			if err := png.Encode(&buf, tile.Art); err != nil {
+				return fmt.Errorf("failed to encode art for %s: %w", tile.Name, err)
+			}
+			fmt.Fprintf(&b, `  <image x="%d" y="%d" width="%d" height="%d" href="data:image/png;base64,%s"/>`+"\n",
+				origin.X, origin.Y, tileWidth, tileHeight, base64.StdEncoding.EncodeToString(buf.Bytes()))
+		} else {
+			fmt.Fprintf(&b, `  <rect x="%d" y="%d" width="%d" height="%d" rx="8" fill="%s"/>`+"\n",
+				origin.X, origin.Y, tileWidth, tileHeight, hexColor(tileColor(tile)))
+		}
+		if tile.Elixir > 0 {
+			fmt.Fprintf(&b, `  <circle cx="%d" cy="%d" r="14" fill="%s"/>`+"\n", origin.X+18, origin.Y+18, hexColor(elixirColor))
+			fmt.Fprintf(&b, `  <text x="%d" y="%d" fill="#ffffff" font-family="sans-serif" font-size="16" font-weight="bold" text-anchor="middle">%d</text>`+"\n",
+				origin.X+18, origin.Y+24, tile.Elixir)
+		}
+		fmt.Fprintf(&b, `  <text x="%d" y="%d" fill="#ffffff" font-family="sans-serif" font-size="14" text-anchor="middle">%s</text>`+"\n",
+			origin.X+tileWidth/2, origin.Y+tileHeight+17, html.EscapeString(tile.Name))
+	}
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,149 @@
+package deckimage
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+func solidImage(width, height int, c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestFormatForPath(t *testing.T) {
+	for path, want := range map[string]Format{"deck.png": FormatPNG, "out/Deck.SVG": FormatSVG} {
+		if got, err := FormatForPath(path); err != nil || got != want {
+			t.Errorf("FormatForPath(%q) = %q, %v; want %q", path, got, err, want)
+		}
+	}
+	if _, err := FormatForPath("deck.jpg"); err == nil {
+		t.Error("expected an error for .jpg")
+	}
+}
+
+func TestRenderPNG(t *testing.T) {
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	tiles := make([]Tile, 8)
+	for i := range tiles {
+		tiles[i] = Tile{Name: "Card", Rarity: "rare", Elixir: i + 1}
+	}
+	tiles[0].Art = solidImage(275, 330, red)
+
+	var buf bytes.Buffer
+	if err := Render(&buf, FormatPNG, tiles); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("output is not a PNG: %v", err)
+	}
+	if got := img.Bounds().Size(); got != image.Pt(650, 390) {
+		t.Errorf("size = %v, want 650x390 for a 4x2 grid", got)
+	}
+
+	// Tile centers: art in the first tile, the rare placeholder in the second
+	if got := color.RGBAModel.Convert(img.At(85, 140)); got != red {
+		t.Errorf("first tile = %v, want the card art", got)
+	}
+	if got := color.RGBAModel.Convert(img.At(245, 140)); got != rarityColors["rare"] {
+		t.Errorf("second tile = %v, want the rare placeholder", got)
+	}
+	if got := color.RGBAModel.Convert(img.At(10+18-12, 10+18)); got != elixirColor {
+		t.Errorf("elixir drop = %v, want %v", got, elixirColor)
+	}
+}
+
+func TestRenderSVG(t *testing.T) {
+	tiles := []Tile{
+		{Name: "P.E.K.K.A", Rarity: "epic", Elixir: 7, Art: solidImage(4, 4, color.RGBA{0, 0, 0xff, 0xff})},
+		{Name: "Goblins & Co", Rarity: "common", Elixir: 2},
+	}
+	var buf bytes.Buffer
+	if err := Render(&buf, FormatSVG, tiles); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`width="330" height="224"`,
+		`href="data:image/png;base64,`,
+		`fill="#9db4c8"`,
+		">7</text>",
+		">P.E.K.K.A</text>",
+		">Goblins &amp; Co</text>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("SVG missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestArtCache(t *testing.T) {
+	var art bytes.Buffer
+	if err := png.Encode(&art, solidImage(2, 2, color.RGBA{0, 0xff, 0, 0xff})); err != nil {
+		t.Fatal(err)
+	}
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if r.URL.Path != "/knight.png" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(art.Bytes())
+	}))
+	defer server.Close()
+
+	cache := NewArtCache(filepath.Join(t.TempDir(), "card_art"))
+	cards := []clashroyale.Card{
+		{ID: 26000000, Name: "Knight", Rarity: "common", ElixirCost: 3, IconUrls: clashroyale.IconUrls{Medium: server.URL + "/knight.png"}},
+		{ID: 26000001, Name: "Archers", Rarity: "common", ElixirCost: 3, IconUrls: clashroyale.IconUrls{Medium: server.URL + "/missing.png"}},
+	}
+
+	for range 2 {
+		tiles, err := BuildTiles(context.Background(), []string{"knight", "Archers", "Mystery"}, cards, cache)
+		if len(tiles) != 3 || tiles[0].Name != "Knight" || tiles[0].Art == nil || tiles[1].Art != nil {
+			t.Fatalf("tiles = %+v", tiles)
+		}
+		if err == nil || !strings.Contains(err.Error(), "Archers") || !strings.Contains(err.Error(), "Mystery: not in the card database") {
+			t.Errorf("error = %v, want Archers and Mystery reported", err)
+		}
+	}
+	if _, err := os.Stat(cache.Path(cards[0])); err != nil {
+		t.Errorf("Knight art not cached: %v", err)
+	}
+	// Knight is downloaded once; the missing Archers art is retried
+	if got := hits.Load(); got != 3 {
+		t.Errorf("server hits = %d, want 3", got)
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "images", "deck.svg")
+	if err := WriteFile(path, []Tile{{Name: "Knight"}}); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || !bytes.HasPrefix(data, []byte("<svg")) {
+		t.Errorf("written file = %q, %v", data, err)
+	}
+	if err := WriteFile(filepath.Join(t.TempDir(), "deck.gif"), []Tile{{Name: "Knight"}}); err == nil {
+		t.Error("expected an error for .gif")
+	}
+}
@@ -72,7 +72,7 @@ func createTestDiscoveryRunner(t *testing.T, evaluator *mockDeckEvaluator) (*Dis
 	os.Setenv("HOME", tmpDir)
 
 	// Create storage
-	storage, err := leaderboard.NewStorage("#TEST123")
+	storage, err := leaderboard.NewStorage("#2PP0Q")
 	if err != nil {
 		os.RemoveAll(tmpDir)
 		os.Setenv("HOME", originalHome)
@@ -89,7 +89,7 @@ func createTestDiscoveryRunner(t *testing.T, evaluator *mockDeckEvaluator) (*Dis
 		},
 		Storage:   storage,
 		Evaluator: evaluator,
-		PlayerTag: "#TEST123",
+		PlayerTag: "#2PP0Q",
 	}
 
 	runner, err := NewDiscoveryRunner(config)
@@ -128,7 +128,7 @@ func TestNewDiscoveryRunner(t *testing.T) {
 				},
 				Storage:   nil, // Will be set in test
 				Evaluator: &mockDeckEvaluator{score: 8.0},
-				PlayerTag: "#TEST123",
+				PlayerTag: "#2PP0Q",
 			},
 			setupStorage: true,
 			wantErr:      false,
@@ -141,7 +141,7 @@ func TestNewDiscoveryRunner(t *testing.T) {
 					Candidates: createTestCandidates(20),
 				},
 				Evaluator: nil,
-				PlayerTag: "#TEST123",
+				PlayerTag: "#2PP0Q",
 			},
 			wantErr:     true,
 			errContains: "evaluator is required",
@@ -155,7 +155,7 @@ func TestNewDiscoveryRunner(t *testing.T) {
 				},
 				Evaluator: &mockDeckEvaluator{score: 8.0},
 				Storage:   nil,
-				PlayerTag: "#TEST123",
+				PlayerTag: "#2PP0Q",
 			},
 			wantErr:     true,
 			errContains: "storage is required",
@@ -186,7 +186,7 @@ func TestNewDiscoveryRunner(t *testing.T) {
 				defer os.Setenv("HOME", originalHome)
 				defer os.RemoveAll(tmpDir)
 
-				storage, err := leaderboard.NewStorage("#TEST123")
+				storage, err := leaderboard.NewStorage("#2PP0Q")
 				if err != nil {
 					t.Fatalf("failed to create storage: %v", err)
 				}
@@ -341,7 +341,7 @@ func TestDiscoveryRunner_Run_Exhaustion(t *testing.T) {
 	defer os.Setenv("HOME", originalHome)
 	defer os.RemoveAll(tmpDir)
 
-	storage, err := leaderboard.NewStorage("#TEST123")
+	storage, err := leaderboard.NewStorage("#2PP0Q")
 	if err != nil {
 		t.Fatalf("failed to create storage: %v", err)
 	}
@@ -356,7 +356,7 @@ func TestDiscoveryRunner_Run_Exhaustion(t *testing.T) {
 		},
 		Storage:   storage,
 		Evaluator: evaluator,
-		PlayerTag: "#TEST123",
+		PlayerTag: "#2PP0Q",
 	}
 
 	runner, err := NewDiscoveryRunner(config)
@@ -466,7 +466,7 @@ func TestDiscoveryRunner_SaveAndResume(t *testing.T) {
 
 	// Second run: create a new runner with the same player tag
 	// It should find and use the existing checkpoint
-	storage2, err := leaderboard.NewStorage("#TEST123")
+	storage2, err := leaderboard.NewStorage("#2PP0Q")
 	if err != nil {
 		t.Fatalf("failed to create storage: %v", err)
 	}
@@ -486,7 +486,7 @@ func TestDiscoveryRunner_SaveAndResume(t *testing.T) {
 		},
 		Storage:   storage2,
 		Evaluator: evaluator2,
-		PlayerTag: "#TEST123",
+		PlayerTag: "#2PP0Q",
 	}
 
 	runner2, err := NewDiscoveryRunner(config2)
@@ -564,8 +564,8 @@ func TestDiscoveryRunner_CheckpointPersistence(t *testing.T) {
 		t.Error("expected generator checkpoint to be present")
 	}
 
-	if checkpoint.PlayerTag != "TEST123" {
-		t.Errorf("expected player tag TEST123, got %s", checkpoint.PlayerTag)
+	if checkpoint.PlayerTag != "2PP0Q" {
+		t.Errorf("expected player tag 2PP0Q, got %s", checkpoint.PlayerTag)
 	}
 }
 
@@ -717,7 +717,7 @@ func TestDiscoveryRunner_GetStatusSummary(t *testing.T) {
 		t.Error("expected summary to contain 'Discovery Status'")
 	}
 
-	if !containsString(summary, "TEST123") {
+	if !containsString(summary, "2PP0Q") {
 		t.Error("expected summary to contain player tag")
 	}
 
@@ -0,0 +1,288 @@
+package deck
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+// Embedding feature block weights. Each block is scaled to unit length, so
+// the similarity of two cards is the weighted mean of the per-block cosine
+// similarities.
+const (
+	embeddingRoleWeight   = 0.35
+	embeddingElixirWeight = 0.2
+	embeddingCombatWeight = 0.3
+	embeddingUsageWeight  = 0.15
+
+	// embeddingMaxElixir is the highest cost with its own elixir bin
+	embeddingMaxElixir = 10
+
+	// embeddingCombatFeatures is the length of the combat block
+	embeddingCombatFeatures = 10
+)
+
+// SimilarCard is a card and its similarity (0-1) to another card.
+type SimilarCard struct {
+	Name       string  `json:"name"`
+	Similarity float64 `json:"similarity"`
+}
+
+// CardEmbedding maps each card to a vector capturing what it does in a deck,
+// so cards that can stand in for each other lie close together. The vector
+// concatenates four feature blocks:
+//   - role: one-hot over the card roles
+//   - elixir: the cost spread over neighboring bins, so a 3 and a 4 elixir
+//     card are closer than a 3 and a 6
+//   - combat: targeting, air/splash/swarm/range traits, and log-scaled
+//     hitpoints and damage from the combat stats dataset
+//   - usage: the card's synergy partners, so cards played with the same
+//     cards are close
+type CardEmbedding struct {
+	names   []string
+	index   map[string]int
+	vectors [][]float64
+}
+
+var (
+	defaultEmbeddingOnce sync.Once
+	defaultEmbedding     *CardEmbedding
+)
+
+// DefaultCardEmbedding returns the embedding built from the bundled combat
+// stats and built-in synergy pairs. It is shared and must not be modified.
+func DefaultCardEmbedding() *CardEmbedding {
+	defaultEmbeddingOnce.Do(func() {
+		defaultEmbedding = NewCardEmbedding(nil, defaultSynergyPairs())
+	})
+	return defaultEmbedding
+}
+
+// SimilarCards returns the k cards most similar to name in the default
+// embedding, most similar first, or nil for an unknown card.
+func SimilarCards(name string, k int) []SimilarCard {
+	return DefaultCardEmbedding().SimilarCards(name, k)
+}
+
+// NewCardEmbedding builds an embedding for every card in the combat stats
+// registry, the role table, and the synergy pairs. A nil registry uses the
+// bundled dataset.
+func NewCardEmbedding(registry *clashroyale.CardStatsRegistry, pairs []SynergyPair) *CardEmbedding {
+	if registry == nil {
+		registry = clashroyale.DefaultStats()
+	}
+
+	cards := make(map[string]bool)
+	for name := range registry.Stats {
+		cards[name] = true
+	}
+	for name := range config.BuiltinRoleCards() {
+		cards[name] = true
+	}
+	partners := make(map[string]map[string]float64)
+	var partnerNames []string
+	for _, pair := range pairs {
+		for _, p := range [2][2]string{{pair.Card1, pair.Card2}, {pair.Card2, pair.Card1}} {
+			if partners[p[0]] == nil {
+				partners[p[0]] = make(map[string]float64)
+				partnerNames = append(partnerNames, p[0])
+			}
+			partners[p[0]][p[1]] = max(partners[p[0]][p[1]], pair.Score)
+		}
+	}
+	for _, name := range partnerNames {
+		cards[name] = true
+	}
+	partnerIndex := make(map[string]int, len(partnerNames))
+	for i, name := range partnerNames {
+		partnerIndex[name] = i
+	}
+
+	e := &CardEmbedding{index: make(map[string]int, len(cards))}
+	e.names = make([]string, 0, len(cards))
+	for name := range cards {
+		e.names = append(e.names, name)
+	}
+	slices.Sort(e.names)
+
+	roles := config.AllCardRoles()
+	for i, name := range e.names {
+		e.index[name] = i
+
+		role := make([]float64, len(roles))
+		if j := slices.Index(roles, config.GetCardRole(name)); j >= 0 {
+			role[j] = 1
+		}
+
+		usage := make([]float64, len(partnerNames))
+		for partner, score := range partners[name] {
+			usage[partnerIndex[partner]] = score
+		}
+
+		e.vectors = append(e.vectors, joinEmbeddingBlocks(
+			[]float64{embeddingRoleWeight, embeddingElixirWeight, embeddingCombatWeight, embeddingUsageWeight},
+			role, elixirFeatures(config.GetCardElixir(name, 0)), combatFeatures(registry.GetStats(name)), usage,
+		))
+	}
+	return e
+}
+
+// elixirFeatures spreads a cost over the elixir bins with a Gaussian bump
+func elixirFeatures(elixir int) []float64 {
+	features := make([]float64, embeddingMaxElixir)
+	if elixir <= 0 {
+		return features
+	}
+	for bin := range features {
+		gap := float64(bin + 1 - elixir)
+		features[bin] = math.Exp(-gap * gap / 2)
+	}
+	return features
+}
+
+// combatFeatures describes how a card fights; spells have no hitpoints
+func combatFeatures(stats *clashroyale.CombatStats) []float64 {
+	if stats == nil {
+		return make([]float64, embeddingCombatFeatures)
+	}
+	flag := func(b bool) float64 {
+		if b {
+			return 1
+		}
+		return 0
+	}
+	logScale := func(value, ceiling int) float64 {
+		return min(math.Log1p(float64(max(value, 0)))/math.Log1p(float64(ceiling)), 1)
+	}
+	targets := strings.ToLower(stats.Targets)
+	troop := stats.Hitpoints > 0
+	damage := stats.DamagePerSecond
+	if damage == 0 {
+		damage = stats.Damage
+	}
+	speed := map[string]float64{"slow": 0.25, "medium": 0.5, "fast": 0.75, "very fast": 1}[strings.ToLower(stats.Speed)]
+	if !troop || stats.Lifetime > 0 {
+		speed = 0
+	}
+	return []float64{
+		flag(strings.Contains(targets, "air")),
+		flag(targets == "buildings"),
+		flag(stats.Flying),
+		flag(stats.Radius > 0),
+		flag(stats.SpawnCount > 1),
+		flag(troop && stats.Range >= 4),
+		flag(!troop),
+		logScale(stats.Hitpoints, 5000),
+		logScale(damage, 2000),
+		speed,
+	}
+}
+
+// joinEmbeddingBlocks scales each block to unit length times the square root
+// of its weight, concatenates them, and normalizes the result
+func joinEmbeddingBlocks(weights []float64, blocks ...[]float64) []float64 {
+	var vector []float64
+	for i, block := range blocks {
+		norm := math.Sqrt(dot(block, block))
+		for _, v := range block {
+			if norm > 0 {
+				v *= math.Sqrt(weights[i]) / norm
+			}
+			vector = append(vector, v)
+		}
+	}
+	if norm := math.Sqrt(dot(vector, vector)); norm > 0 {
+		for i := range vector {
+			vector[i] /= norm
+		}
+	}
+	return vector
+}
+
+func dot(a, b []float64) float64 {
+	total := 0.0
+	for i := range a {
+		total += a[i] * b[i]
+	}
+	return total
+}
+
+// Cards returns the embedded card names in alphabetical order.
+func (e *CardEmbedding) Cards() []string {
+	return slices.Clone(e.names)
+}
+
+// Vector returns a copy of the card's unit-length vector.
+func (e *CardEmbedding) Vector(name string) ([]float64, bool) {
+	i, ok := e.index[name]
+	if !ok {
+		return nil, false
+	}
+	return slices.Clone(e.vectors[i]), true
+}
+
+// Similarity returns the cosine similarity (0-1) of two cards: 1 for the
+// same card and 0 when either card is unknown.
+func (e *CardEmbedding) Similarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	i, ok := e.index[a]
+	if !ok {
+		return 0
+	}
+	j, ok := e.index[b]
+	if !ok {
+		return 0
+	}
+	return max(dot(e.vectors[i], e.vectors[j]), 0)
+}
+
+// SimilarCards returns the k cards most similar to name, most similar first,
+// or nil for an unknown card. Ties are broken by name.
+func (e *CardEmbedding) SimilarCards(name string, k int) []SimilarCard {
+	if _, ok := e.index[name]; !ok || k <= 0 {
+		return nil
+	}
+	similar := make([]SimilarCard, 0, len(e.names)-1)
+	for _, other := range e.names {
+		if other != name {
+			similar = append(similar, SimilarCard{Name: other, Similarity: e.Similarity(name, other)})
+		}
+	}
+	slices.SortFunc(similar, func(a, b SimilarCard) int {
+		if c := cmp.Compare(b.Similarity, a.Similarity); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return similar[:min(k, len(similar))]
+}
+
+// DeckSimilarity compares two decks card by card: each card is matched to
+// its most similar card in the other deck, and the matches are averaged in
+// both directions. Identical decks score 1; unlike JaccardSimilarity, decks
+// that swap Zap for The Log still score close to 1.
+func (e *CardEmbedding) DeckSimilarity(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	return (e.bestMatchMean(a, b) + e.bestMatchMean(b, a)) / 2
+}
+
+func (e *CardEmbedding) bestMatchMean(from, to []string) float64 {
+	total := 0.0
+	for _, card := range from {
+		best := 0.0
+		for _, other := range to {
+			best = max(best, e.Similarity(card, other))
+		}
+		total += best
+	}
+	return total / float64(len(from))
+}
@@ -0,0 +1,78 @@
+package deck
+
+import (
+	"slices"
+	"testing"
+)
+
+func similarNames(similar []SimilarCard) []string {
+	names := make([]string, len(similar))
+	for i, s := range similar {
+		names[i] = s.Name
+	}
+	return names
+}
+
+func TestSimilarCardsFindsStandIns(t *testing.T) {
+	tests := map[string]string{
+		"Zap":           "The Log",
+		"Ice Spirit":    "Electro Spirit",
+		"Inferno Tower": "Tesla",
+		"Minions":       "Mega Minion",
+	}
+	for card, want := range tests {
+		got := SimilarCards(card, 5)
+		if len(got) != 5 {
+			t.Fatalf("SimilarCards(%q, 5) returned %d cards", card, len(got))
+		}
+		if !slices.Contains(similarNames(got), want) {
+			t.Errorf("SimilarCards(%q) = %v, want %s among them", card, similarNames(got), want)
+		}
+		for i := 1; i < len(got); i++ {
+			if got[i].Similarity > got[i-1].Similarity {
+				t.Errorf("SimilarCards(%q) not sorted: %v", card, got)
+			}
+		}
+	}
+	if got := SimilarCards("Not A Card", 3); got != nil {
+		t.Errorf("SimilarCards(unknown) = %v, want nil", got)
+	}
+}
+
+func TestCardEmbeddingSimilarity(t *testing.T) {
+	e := DefaultCardEmbedding()
+	if got := e.Similarity("Hog Rider", "Hog Rider"); got != 1 {
+		t.Errorf("Similarity(same card) = %.2f, want 1", got)
+	}
+	if e.Similarity("Zap", "The Log") <= e.Similarity("Zap", "Golem") {
+		t.Error("Zap should be closer to The Log than to Golem")
+	}
+	if got := e.Similarity("Zap", "Not A Card"); got != 0 {
+		t.Errorf("Similarity(unknown) = %.2f, want 0", got)
+	}
+	vector, ok := e.Vector("Fireball")
+	if !ok || len(vector) == 0 {
+		t.Fatal("Vector(Fireball) missing")
+	}
+	if norm := dot(vector, vector); norm < 0.999 || norm > 1.001 {
+		t.Errorf("Vector(Fireball) has squared norm %.3f, want 1", norm)
+	}
+}
+
+func TestDeckSimilarity(t *testing.T) {
+	e := DefaultCardEmbedding()
+	hog := []string{"Hog Rider", "Musketeer", "Cannon", "Ice Golem", "Ice Spirit", "Skeletons", "The Log", "Fireball"}
+	hogZap := []string{"Hog Rider", "Musketeer", "Cannon", "Ice Golem", "Ice Spirit", "Skeletons", "Zap", "Fireball"}
+	golem := []string{"Golem", "Night Witch", "Baby Dragon", "Lightning", "Lumberjack", "Tornado", "Mega Minion", "Skeletons"}
+
+	if got := e.DeckSimilarity(hog, hog); got < 0.999 {
+		t.Errorf("DeckSimilarity(same deck) = %.3f, want 1", got)
+	}
+	near, far := e.DeckSimilarity(hog, hogZap), e.DeckSimilarity(hog, golem)
+	if near <= far || near < 0.95 {
+		t.Errorf("DeckSimilarity: one-card swap %.3f, different archetype %.3f", near, far)
+	}
+	if got := e.DeckSimilarity(hog, nil); got != 0 {
+		t.Errorf("DeckSimilarity(empty) = %.3f, want 0", got)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/klauer/clash-royale-api/go/internal/config"
 	"github.com/klauer/clash-royale-api/go/pkg/deck"
 )
 
@@ -199,14 +200,16 @@ func findReplacements(
 	return replacements
 }
 
-// getSimilarCards returns cards similar to the given card (same role or elixir cost)
+// similarCardsFallback is how many card embedding neighbors stand in for
+// cards without curated alternatives
+const similarCardsFallback = 3
+
+// getSimilarCards returns cards similar to the given card: the curated
+// alternatives when the card has them, otherwise its nearest neighbors in the
+// card embedding
 func getSimilarCards(card deck.CardCandidate) []deck.CardCandidate {
-	// This is a simplified implementation
-	// In a full implementation, this would query a card database
 	similar := make([]deck.CardCandidate, 0)
 
-	// For now, we'll return a hardcoded set of common alternatives
-	// This should be replaced with actual card database lookup
 	commonAlternatives := map[string][]string{
 		"Knight":         {"Valkyrie", "Ice Golem", "Dark Prince"},
 		"Hog Rider":      {"Ram Rider", "Battle Ram", "Royal Hogs"},
@@ -216,6 +219,8 @@ func getSimilarCards(card deck.CardCandidate) []deck.CardCandidate {
 		"Mega Minion":    {"Minions", "Bats", "Minion Horde"},
 		"Ice Spirit":     {"Fire Spirit", "Heal Spirit", "Electro Spirit"},
 		"Tesla":          {"Cannon", "Inferno Tower", "Bomb Tower"},
+		"Inferno Tower":  {"Inferno Dragon", "Tesla", "Cannon"},
+		"Poison":         {"Fireball", "Earthquake", "Tornado"},
 		"Prince":         {"Dark Prince", "Mini P.E.K.K.A", "Valkyrie"},
 		"Goblin Gang":    {"Skeleton Army", "Guards", "Rascals"},
 		"Balloon":        {"Lava Hound", "Giant", "Golem"},
@@ -225,9 +230,18 @@ func getSimilarCards(card deck.CardCandidate) []deck.CardCandidate {
 		"Electro Wizard": {"Ice Wizard", "Witch", "Mother Witch"},
 	}
 
-	// Get alternatives for this card
 	altNames, exists := commonAlternatives[card.Name]
 	if !exists {
+		for _, neighbor := range deck.SimilarCards(card.Name, similarCardsFallback) {
+			role := deck.CardRole(config.GetCardRole(neighbor.Name))
+			similar = append(similar, deck.CardCandidate{
+				Name:     neighbor.Name,
+				Level:    card.Level,
+				MaxLevel: card.MaxLevel,
+				Elixir:   inferElixirForCard(neighbor.Name),
+				Role:     &role,
+			})
+		}
 		return similar
 	}
 
@@ -376,6 +390,9 @@ func inferElixirForCard(name string) int {
 		"Electro Wizard": 4,
 		"Ice Wizard":     3,
 		"Mother Witch":   4,
+		"Inferno Dragon": 4,
+		"Earthquake":     3,
+		"Tornado":        3,
 	}
 
 	if cost, exists := elixirCosts[name]; exists {
@@ -408,6 +408,13 @@ func TestGetSimilarCards(t *testing.T) {
 			maxCount:    3,
 			expectNames: []string{"Ram Rider", "Battle Ram", "Royal Hogs"},
 		},
+		{
+			name:        "Uncurated card falls back to embedding neighbors",
+			card:        makeCard("Electro Spirit", deck.RoleCycle, 11, 11, "Common", 1),
+			minCount:    3,
+			maxCount:    3,
+			expectNames: []string{"Ice Spirit"},
+		},
 		{
 			name:     "Unknown card has no alternatives",
 			card:     makeCard("Unknown Card", deck.RoleSupport, 11, 11, "Common", 3),
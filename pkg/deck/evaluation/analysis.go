@@ -2,6 +2,7 @@ package evaluation
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -607,6 +608,21 @@ func calculateCycleScore(avgElixir float64, lowCostCount, shortestCycle int) flo
 	return score
 }
 
+// describeCycleTimes lists the simulated cycle time in each elixir phase
+func describeCycleTimes(sim ElixirSimResult) string {
+	parts := make([]string, 0, len(sim.Phases))
+	for _, phase := range sim.Phases {
+		if phase.Cycles == 0 {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("~%.0fs at %s elixir", phase.CycleSeconds, phase.Phase))
+	}
+	if len(parts) == 0 {
+		return "never within a game"
+	}
+	return strings.Join(parts, ", ")
+}
+
 // BuildCycleAnalysis creates detailed cycle analysis
 func BuildCycleAnalysis(deckCards []deck.CardCandidate) AnalysisSection {
 	// Calculate cycle metrics
@@ -650,14 +666,13 @@ func BuildCycleAnalysis(deckCards []deck.CardCandidate) AnalysisSection {
 	details = append(details, fmt.Sprintf("Shortest 4-card cycle: %d elixir (%s)",
 		shortestCycle, cycleAssessment))
 
-	// Rotation estimate (find win condition) using helper
-	winConditions := filterByRole(deckCards, deck.RoleWinCondition)
-	if len(winConditions) > 0 {
-		winCondition := winConditions[0].Name
-		rotationTime := int(avgElixir * 3.5) // Rough estimate
-		details = append(details, fmt.Sprintf("Rotation estimate: Can return to %s in ~%d seconds",
-			winCondition, rotationTime))
+	// Rotation and elixir float from simulated games
+	sim := SimulateElixirCurve(deckCards, ElixirSimOptions{})
+	if sim.WinCondition != "" {
+		details = append(details, fmt.Sprintf("Rotation: returns to %s every %s (%d simulated games)",
+			sim.WinCondition, describeCycleTimes(sim), sim.Runs))
 	}
+	details = append(details, fmt.Sprintf("Average elixir float: %.1f", sim.AvgFloat))
 
 	// Elixir curve distribution
 	curveStr := ""
@@ -703,30 +718,18 @@ func BuildCycleAnalysis(deckCards []deck.CardCandidate) AnalysisSection {
 
 // isLevelIndependent determines if card is effective when underleveled
 func isLevelIndependent(card deck.CardCandidate) bool {
-	// Small spells (utility-based)
-	smallSpells := map[string]bool{
-		"Log": true, "Zap": true, "Arrows": true, "Snowball": true,
-		"Barbarian Barrel": true, "Giant Snowball": true,
-	}
-
-	// Cycle cards (cheap utility)
-	cycleCards := map[string]bool{
-		"Skeletons": true, "Ice Spirit": true, "Ice Golem": true,
-		"Heal Spirit": true, "Electro Spirit": true, "Fire Spirit": true,
-	}
-
-	// Defensive buildings (utility)
-	buildings := map[string]bool{
-		"Tesla": true, "Cannon": true, "Bomb Tower": true,
-	}
-
-	// Reset cards (utility effect)
-	resetCards := map[string]bool{
-		"Electro Wizard": true, "Electro Spirit": true,
-	}
+	return levelIndependentCards[card.Name]
+}
 
-	return smallSpells[card.Name] || cycleCards[card.Name] ||
-		buildings[card.Name] || resetCards[card.Name]
+// levelIndependentCards are small spells, cheap cycle cards, defensive
+// buildings, and reset cards, whose value is their utility
+var levelIndependentCards = map[string]bool{
+	"Log": true, "Zap": true, "Arrows": true, "Snowball": true,
+	"Barbarian Barrel": true, "Giant Snowball": true,
+	"Skeletons": true, "Ice Spirit": true, "Ice Golem": true,
+	"Heal Spirit": true, "Electro Spirit": true, "Fire Spirit": true,
+	"Tesla": true, "Cannon": true, "Bomb Tower": true,
+	"Electro Wizard": true,
 }
 
 // calculateLadderScore combines F2P factors with level-independence (0-10)
@@ -1280,20 +1283,39 @@ func BuildEvolutionAnalysis(deckCards []deck.CardCandidate, playerContext *Playe
 //
 //nolint:gocognit,gocyclo // Domain penalty matrix is explicit to keep balancing transparent.
 func applyCriticalFlawPenalties(baseScore float64, deckCards []deck.CardCandidate) float64 {
-	score := baseScore
+	score := baseScore - criticalFlawPenalty(deckCards)
+
+	// Ensure score doesn't go below 0
+	if score < 0 {
+		score = 0
+	}
+
+	return score
+}
+
+// criticalFlawPenalty returns the total overall-score penalty for critical
+// compositional flaws (no win condition, no spells, no anti-air)
+func criticalFlawPenalty(deckCards []deck.CardCandidate) float64 {
+	penalty := 0.0
+	for _, flaw := range CriticalFlaws(deckCards) {
+		penalty += flaw.Penalty
+	}
+	return penalty
+}
+
+// CriticalFlaws lists the compositional flaws that are penalized directly on
+// the overall score, with the ladder penalty for each.
+func CriticalFlaws(deckCards []deck.CardCandidate) []CriticalFlaw {
+	var flaws []CriticalFlaw
 
 	// Check for critical attack flaws
 	winConditionCount := 0
 	spellCount := 0
-	bigSpellCount := 0
 	for _, card := range deckCards {
 		if card.Role != nil {
 			if *card.Role == deck.RoleWinCondition {
 				winConditionCount++
 			}
-			if *card.Role == deck.RoleSpellBig {
-				bigSpellCount++
-			}
 			if *card.Role == deck.RoleSpellBig || *card.Role == deck.RoleSpellSmall {
 				spellCount++
 			}
@@ -1302,12 +1324,20 @@ func applyCriticalFlawPenalties(baseScore float64, deckCards []deck.CardCandidat
 
 	// Penalty for no win condition: -2.0 points (critical flaw)
 	if winConditionCount == 0 {
-		score -= 2.0
+		flaws = append(flaws, CriticalFlaw{
+			Code:        FlawNoWinCondition,
+			Description: "No win condition to pressure towers",
+			Penalty:     2.0,
+		})
 	}
 
 	// Penalty for no spells: -1.5 points (severe limitation)
 	if spellCount == 0 {
-		score -= 1.5
+		flaws = append(flaws, CriticalFlaw{
+			Code:        FlawNoSpells,
+			Description: "No spells to clear swarms or finish towers",
+			Penalty:     1.5,
+		})
 	}
 
 	// Check for critical defense flaws
@@ -1320,15 +1350,52 @@ func applyCriticalFlawPenalties(baseScore float64, deckCards []deck.CardCandidat
 
 	// Penalty for no anti-air: -2.0 points (critical vulnerability)
 	if antiAirCount == 0 {
-		score -= 2.0
+		flaws = append(flaws, CriticalFlaw{
+			Code:        FlawNoAntiAir,
+			Description: "No cards that can target air units",
+			Penalty:     2.0,
+		})
 	}
 
-	// Ensure score doesn't go below 0
-	if score < 0 {
-		score = 0
+	return flaws
+}
+
+// resolveTowerTroop picks the explicitly requested tower troop, then the one
+// equipped in playerContext, then Tower Princess.
+func resolveTowerTroop(towerTroop string, playerContext *PlayerContext) deck.TowerTroopProfile {
+	if profile, ok := deck.LookupTowerTroop(towerTroop); ok {
+		return profile
+	}
+	if playerContext != nil {
+		if profile, ok := deck.LookupTowerTroop(playerContext.TowerTroop); ok {
+			return profile
+		}
 	}
+	profile, _ := deck.LookupTowerTroop(deck.DefaultTowerTroop)
+	return profile
+}
 
-	return score
+// addTowerTroopDefenseDetails notes the tower troop's effect on defense and
+// suggests a better-fitting owned troop when there is one.
+func addTowerTroopDefenseDetails(section *AnalysisSection, defenseScore CategoryScore, profile deck.TowerTroopProfile, adjustment float64, deckNames []string, playerContext *PlayerContext) {
+	section.Score = defenseScore.Score
+	section.Rating = defenseScore.Rating
+
+	if adjustment != 0 {
+		section.Details = append(section.Details, fmt.Sprintf("Tower troop: %s (%+.1f defense) - %s",
+			profile.Name, adjustment, profile.Description))
+	} else {
+		section.Details = append(section.Details, fmt.Sprintf("Tower troop: %s", profile.Name))
+	}
+
+	var owned []string
+	if playerContext != nil {
+		owned = playerContext.TowerTroops
+	}
+	best := deck.RecommendTowerTroop(getCounterMatrix(), deckNames, owned)
+	if best.Name != profile.Name && best.Adjustment > adjustment {
+		section.Details = append(section.Details, fmt.Sprintf("💡 Consider %s as tower troop: %s", best.Name, best.Reason))
+	}
 }
 
 // Evaluate performs comprehensive deck evaluation with all scoring and analysis
@@ -1337,6 +1404,74 @@ func applyCriticalFlawPenalties(baseScore float64, deckCards []deck.CardCandidat
 // - Arena-specific card availability
 // - Evolution unlock status
 func Evaluate(deckCards []deck.CardCandidate, synergyDB *deck.SynergyDatabase, playerContext *PlayerContext) EvaluationResult {
+	return EvaluateWithMode(deckCards, synergyDB, playerContext, ModeLadder)
+}
+
+// EvaluateWithMode performs deck evaluation using the scoring profile for mode.
+// ModeLadder matches Evaluate; Mode2v2 relaxes role-redundancy and
+// critical-flaw penalties because a partner deck covers some gaps.
+func EvaluateWithMode(deckCards []deck.CardCandidate, synergyDB *deck.SynergyDatabase, playerContext *PlayerContext, mode EvaluationMode) EvaluationResult {
+	return EvaluateWithTowerTroop(deckCards, synergyDB, playerContext, mode, "")
+}
+
+// EvaluateWithTowerTroop performs deck evaluation with the deck defended by
+// towerTroop. An empty towerTroop uses the troop equipped in playerContext,
+// falling back to Tower Princess, which leaves defense unchanged.
+func EvaluateWithTowerTroop(deckCards []deck.CardCandidate, synergyDB *deck.SynergyDatabase, playerContext *PlayerContext, mode EvaluationMode, towerTroop string) EvaluationResult {
+	return EvaluateWithOptions(deckCards, synergyDB, playerContext, EvaluateOptions{Mode: mode, TowerTroop: towerTroop})
+}
+
+// EvaluateOptions are the optional inputs to EvaluateWithOptions. The zero
+// value matches Evaluate.
+type EvaluateOptions struct {
+	// Mode is the scoring profile; empty means ModeLadder
+	Mode EvaluationMode
+
+	// TowerTroop overrides the tower troop (see EvaluateWithTowerTroop)
+	TowerTroop string
+
+	// Meta, when set, scores how well the deck answers the meta's popular
+	// win conditions and folds that into the overall score
+	Meta *MetaContext
+
+	// GameMode applies an event preset (see LookupGameMode); empty means
+	// GameModeStandard
+	GameMode GameMode
+
+	// TrophyBand shifts weights and meta assumptions for a stretch of the
+	// trophy road; empty uses the player context's band, if any. Ignored
+	// for event game modes.
+	TrophyBand TrophyBand
+}
+
+// WithCombatStats returns deckCards with bundled combat stats filled in for
+// cards that have none, copying the slice only when something is missing.
+func WithCombatStats(deckCards []deck.CardCandidate) []deck.CardCandidate {
+	for _, card := range deckCards {
+		if card.Stats == nil {
+			filled := slices.Clone(deckCards)
+			deck.ApplyCombatStats(filled, nil)
+			return filled
+		}
+	}
+	return deckCards
+}
+
+// EvaluateWithOptions performs deck evaluation with every optional input.
+func EvaluateWithOptions(deckCards []deck.CardCandidate, synergyDB *deck.SynergyDatabase, playerContext *PlayerContext, opts EvaluateOptions) EvaluationResult {
+	mode := opts.Mode
+	if mode == "" {
+		mode = ModeLadder
+	}
+	towerTroop := opts.TowerTroop
+	gameMode := LookupGameMode(opts.GameMode)
+	weights := gameMode.Weights
+	trophyBand, hasTrophyBand := resolveTrophyBand(playerContext, opts)
+	if hasTrophyBand {
+		weights = trophyBand.Weights
+	}
+	deckCards = WithCombatStats(deckCards)
+
 	// Extract deck card names
 	deckNames := make([]string, len(deckCards))
 	for i, card := range deckCards {
@@ -1351,35 +1486,51 @@ func Evaluate(deckCards []deck.CardCandidate, synergyDB *deck.SynergyDatabase, p
 	defenseScore := ScoreDefense(deckCards)
 	synergyScore := ScoreSynergy(deckCards, synergyDB)
 	versatilityScore := ScoreVersatility(deckCards)
+	if mode == Mode2v2 {
+		versatilityScore = ScoreVersatility2v2(deckCards)
+	}
 	f2pScore := ScoreF2P(deckCards)
 	playabilityScore := ScorePlayability(deckCards, playerContext)
 
+	// Tower troops shift defense depending on which gaps they cover
+	towerTroopProfile := resolveTowerTroop(towerTroop, playerContext)
+	towerTroopNeeds := deck.AnalyzeTowerTroopNeeds(getCounterMatrix(), deckNames)
+	towerTroopAdjustment := towerTroopProfile.DefenseAdjustment(towerTroopNeeds)
+	if towerTroopAdjustment != 0 {
+		defenseScore.Score = clampScoreToTen(defenseScore.Score + towerTroopAdjustment)
+		defenseScore.Rating = ScoreToRating(defenseScore.Score)
+		defenseScore.Stars = ScoreToStars(defenseScore.Score)
+	}
+
 	// Phase 2: Archetype Detection
 	archetypeResult := DetectArchetype(deckCards)
 
 	// Phase 3: Build Analysis Sections
 	defenseAnalysis := BuildDefenseAnalysis(deckCards)
+	addTowerTroopDefenseDetails(&defenseAnalysis, defenseScore, towerTroopProfile, towerTroopAdjustment, deckNames, playerContext)
 	attackAnalysis := BuildAttackAnalysis(deckCards)
 	baitAnalysis := BuildBaitAnalysis(deckCards)
 	cycleAnalysis := BuildCycleAnalysis(deckCards)
+	if gameMode.IgnoreCycleSpeed {
+		cycleAnalysis.Details = append(cycleAnalysis.Details, fmt.Sprintf("Cycle speed is not scored in %s", gameMode.Name))
+	}
 	ladderAnalysis := BuildLadderAnalysis(deckCards, playerContext)
 	evolutionAnalysis := BuildEvolutionAnalysis(deckCards, playerContext)
+	damageRaceAnalysis := BuildDamageRaceAnalysis(deckCards, playerContext)
+	openingHandAnalysis := BuildOpeningHandAnalysis(deckCards)
 
 	// Phase 4: Calculate Overall Score (weighted average)
 	// Weights: Attack 23%, Defense 22%, Synergy 21%, Versatility 14%, F2P 10%, Playability 10%
-	// Balanced emphasis on attack/defense/synergy fundamentals
+	// Balanced emphasis on attack/defense/synergy fundamentals; game modes
+	// and trophy bands bring their own weights.
 	// Critical flaws are separately penalized via applyCriticalFlawPenalties
-	baseOverallScore := (attackScore.Score * overallWeightAttack) +
-		(defenseScore.Score * overallWeightDefense) +
-		(synergyScore.Score * overallWeightSynergy) +
-		(versatilityScore.Score * overallWeightVersatility) +
-		(f2pScore.Score * overallWeightF2P) +
-		(playabilityScore.Score * overallWeightPlayability)
+	baseOverallScore := weights.Base(attackScore.Score, defenseScore.Score, synergyScore.Score,
+		versatilityScore.Score, f2pScore.Score, playabilityScore.Score)
 
 	// When player context is available, replace Playability with ladder viability at the same weight.
 	contextualScore := baseOverallScore
 	if playerContext != nil {
-		contextualScore = baseOverallScore - (playabilityScore.Score * overallWeightPlayability) + (ladderAnalysis.Score * overallWeightPlayability)
+		contextualScore = baseOverallScore - (playabilityScore.Score * weights.Playability) + (ladderAnalysis.Score * weights.Playability)
 	}
 
 	levelRatio := 1.0
@@ -1398,9 +1549,44 @@ func Evaluate(deckCards []deck.CardCandidate, synergyDB *deck.SynergyDatabase, p
 
 	overallScore = clampScoreToTen(overallScore)
 
+	// Event presets reward or ignore cycle speed and prefer an elixir band
+	gameModeAdjustment := 0.0
+	if !gameMode.Standard() {
+		overallScore, gameModeAdjustment = gameMode.applyGameMode(overallScore, cycleAnalysis.Score, avgElixir)
+	}
+
+	// Answering the meta's popular win conditions takes a fixed share of the
+	// score; without a snapshot, a trophy band assumes its own meta
+	metaCtx := opts.Meta
+	if metaCtx == nil && hasTrophyBand {
+		metaCtx = trophyBand.MetaContext()
+	}
+	var metaCounterScore *CategoryScore
+	var metaAnalysis *AnalysisSection
+	if metaCtx != nil {
+		metaCounterScore, metaAnalysis = ScoreMetaCounter(deckNames, metaCtx)
+		if metaAnalysis != nil && opts.Meta == nil {
+			metaAnalysis.Details = append([]string{"Assumed meta: " + metaCtx.Source}, metaAnalysis.Details...)
+		}
+		if metaCounterScore != nil {
+			overallScore = clampScoreToTen(overallScore*(1-metaCounterWeight) + metaCounterScore.Score*metaCounterWeight)
+		}
+	}
+
+	// Registered scorer plugins take their configured share of the score
+	overallScore, pluginScores := applyScorerPlugins(deckCards, overallScore)
+
 	// Apply penalties for critical compositional flaws
 	// These are severe enough to warrant direct overall score penalties
-	overallScore = applyCriticalFlawPenalties(overallScore, deckCards)
+	criticalFlaws := CriticalFlaws(deckCards)
+	if mode == Mode2v2 {
+		overallScore = clampScoreToTen(overallScore - criticalFlawPenalty(deckCards)*twoVTwoFlawPenaltyScale)
+		for i := range criticalFlaws {
+			criticalFlaws[i].Penalty *= twoVTwoFlawPenaltyScale
+		}
+	} else {
+		overallScore = applyCriticalFlawPenalties(overallScore, deckCards)
+	}
 
 	overallRating := ScoreToRating(overallScore)
 
@@ -1446,6 +1632,7 @@ func Evaluate(deckCards []deck.CardCandidate, synergyDB *deck.SynergyDatabase, p
 
 			// Penalty: -2 points per locked card, -1 point per unlocked but missing card
 			penalty := float64(lockedCount)*2.0 + float64(missingCardsAnalysis.MissingCount-lockedCount)*1.0
+			missingCardsAnalysis.ScorePenalty = penalty
 			overallScore -= penalty
 
 			// Ensure score doesn't go below 0
@@ -1473,6 +1660,7 @@ func Evaluate(deckCards []deck.CardCandidate, synergyDB *deck.SynergyDatabase, p
 	return EvaluationResult{
 		Deck:      deckNames,
 		AvgElixir: avgElixir,
+		Mode:      mode,
 
 		Attack:      attackScore,
 		Defense:     defenseScore,
@@ -1486,16 +1674,41 @@ func Evaluate(deckCards []deck.CardCandidate, synergyDB *deck.SynergyDatabase, p
 
 		DetectedArchetype:   archetypeResult.Primary,
 		ArchetypeConfidence: archetypeResult.PrimaryConfidence,
+		HybridPrimary:       archetypeResult.HybridPrimary(),
+		HybridSecondary:     archetypeResult.HybridSecondary(),
+
+		DefenseAnalysis:     defenseAnalysis,
+		AttackAnalysis:      attackAnalysis,
+		BaitAnalysis:        baitAnalysis,
+		CycleAnalysis:       cycleAnalysis,
+		LadderAnalysis:      ladderAnalysis,
+		EvolutionAnalysis:   evolutionAnalysis,
+		DamageRaceAnalysis:  damageRaceAnalysis,
+		OpeningHandAnalysis: openingHandAnalysis,
+
+		TowerTroop: towerTroopProfile.Name,
+
+		GameMode:           resultGameMode(gameMode),
+		GameModeAdjustment: gameModeAdjustment,
+		TrophyBand:         trophyBand.Band,
 
-		DefenseAnalysis:   defenseAnalysis,
-		AttackAnalysis:    attackAnalysis,
-		BaitAnalysis:      baitAnalysis,
-		CycleAnalysis:     cycleAnalysis,
-		LadderAnalysis:    ladderAnalysis,
-		EvolutionAnalysis: evolutionAnalysis,
+		MetaCounter:  metaCounterScore,
+		MetaAnalysis: metaAnalysis,
+
+		PluginScores: pluginScores,
 
 		SynergyMatrix:        synergyMatrix,
+		CriticalFlaws:        criticalFlaws,
 		MissingCardsAnalysis: missingCardsAnalysis,
 		OverallBreakdown:     overallBreakdown,
 	}
 }
+
+// resultGameMode records non-standard game modes on the result, keeping
+// standard results unchanged
+func resultGameMode(preset GameModePreset) GameMode {
+	if preset.Standard() {
+		return ""
+	}
+	return preset.Mode
+}
@@ -49,3 +49,46 @@ func TestBuildDefenseAnalysis_ShowsResetRetargetTools(t *testing.T) {
 		t.Fatalf("did not expect reset/retarget warning when deck has reset tools: %v", result.Details)
 	}
 }
+
+func TestEvaluateWithTowerTroopAdjustsDefense(t *testing.T) {
+	// Swarm-heavy deck with no tank killers: Cannoneer should help defense
+	deckCards := []deck.CardCandidate{
+		makeCard("Giant", deck.RoleWinCondition, 11, 11, "Rare", 5),
+		makeCard("Wizard", deck.RoleSupport, 11, 11, "Rare", 5),
+		makeCard("Baby Dragon", deck.RoleSupport, 11, 11, "Epic", 4),
+		makeCard("Musketeer", deck.RoleSupport, 11, 11, "Rare", 4),
+		makeCard("Archers", deck.RoleSupport, 11, 11, "Common", 3),
+		makeCard("Fireball", deck.RoleSpellBig, 11, 11, "Rare", 4),
+		makeCard("Zap", deck.RoleSpellSmall, 11, 11, "Common", 2),
+		makeCard("Valkyrie", deck.RoleSupport, 11, 11, "Rare", 4),
+	}
+
+	baseline := Evaluate(deckCards, nil, nil)
+	if baseline.TowerTroop != deck.TowerTroopPrincess {
+		t.Errorf("default TowerTroop = %q, want %q", baseline.TowerTroop, deck.TowerTroopPrincess)
+	}
+
+	profile, _ := deck.LookupTowerTroop(deck.TowerTroopCannoneer)
+	want := profile.DefenseAdjustment(deck.AnalyzeTowerTroopNeeds(getCounterMatrix(), baseline.Deck))
+	withCannoneer := EvaluateWithTowerTroop(deckCards, nil, nil, ModeLadder, deck.TowerTroopCannoneer)
+	got := withCannoneer.Defense.Score - baseline.Defense.Score
+	if diff := got - want; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("defense changed by %.2f, want %.2f", got, want)
+	}
+	if withCannoneer.DefenseAnalysis.Score != withCannoneer.Defense.Score {
+		t.Error("expected the defense analysis to report the adjusted score")
+	}
+	if !strings.Contains(strings.Join(withCannoneer.DefenseAnalysis.Details, " "), "Tower troop: Cannoneer") {
+		t.Errorf("expected tower troop detail, got %v", withCannoneer.DefenseAnalysis.Details)
+	}
+
+	// The troop equipped in player context applies when none is requested
+	playerContext := &PlayerContext{
+		Collection:         map[string]CardLevelInfo{},
+		UnlockedEvolutions: map[string]bool{},
+		TowerTroop:         deck.TowerTroopCannoneer,
+	}
+	if result := Evaluate(deckCards, nil, playerContext); result.TowerTroop != deck.TowerTroopCannoneer {
+		t.Errorf("TowerTroop from context = %q, want Cannoneer", result.TowerTroop)
+	}
+}
@@ -22,52 +22,87 @@ type ArchetypeDetectionResult struct {
 
 	// IsHybrid indicates if this deck has multiple distinct archetypes
 	IsHybrid bool
+
+	// Dominant is the best-scoring archetype. It differs from Primary when
+	// Primary is hybrid (Dominant and Secondary are the hybrid's halves) or
+	// unknown because confidence is too low.
+	Dominant Archetype
+}
+
+// HybridPrimary returns the stronger half of a hybrid deck, or "" when the
+// deck is not a hybrid
+func (r ArchetypeDetectionResult) HybridPrimary() Archetype {
+	if !r.IsHybrid {
+		return ""
+	}
+	return r.Dominant
 }
 
-// DetectArchetype analyzes a deck and returns the detected archetype with confidence scoring
+// HybridSecondary returns the weaker half of a hybrid deck, or "" when the
+// deck is not a hybrid
+func (r ArchetypeDetectionResult) HybridSecondary() Archetype {
+	if !r.IsHybrid {
+		return ""
+	}
+	return r.Secondary
+}
+
+// DetectArchetype analyzes a deck and returns the detected archetype with confidence scoring.
+// Custom archetypes added with RegisterArchetypeScorer compete with the built-in ones.
 func DetectArchetype(deckCards []deck.CardCandidate) ArchetypeDetectionResult {
+	return DetectArchetypeWithThresholds(deckCards, DefaultHybridThresholds)
+}
+
+// DetectArchetypeWithThresholds is DetectArchetype with explicit hybrid
+// thresholds, for calibration.
+func DetectArchetypeWithThresholds(deckCards []deck.CardCandidate, thresholds HybridThresholds) ArchetypeDetectionResult {
 	if len(deckCards) == 0 {
 		return ArchetypeDetectionResult{
 			Primary:           ArchetypeUnknown,
 			PrimaryConfidence: 0.0,
+			Dominant:          ArchetypeUnknown,
 		}
 	}
+	return rankArchetypes(deckCards).classify(thresholds)
+}
 
-	// Calculate scores for each archetype
-	archetypeScores := make(map[Archetype]float64)
+// archetypeRanking is a deck's two best-fitting archetypes with their raw
+// 0-10 scores
+type archetypeRanking struct {
+	primary, secondary           Archetype
+	primaryScore, secondaryScore float64
+}
 
-	archetypeScores[ArchetypeBeatdown] = scoreBeatdown(deckCards)
-	archetypeScores[ArchetypeControl] = scoreControl(deckCards)
-	archetypeScores[ArchetypeCycle] = scoreCycle(deckCards)
-	archetypeScores[ArchetypeBridge] = scoreBridgeSpam(deckCards)
-	archetypeScores[ArchetypeSiege] = scoreSiege(deckCards)
-	archetypeScores[ArchetypeBait] = scoreBait(deckCards)
-	archetypeScores[ArchetypeGraveyard] = scoreGraveyard(deckCards)
-	archetypeScores[ArchetypeMiner] = scoreMiner(deckCards)
+func rankArchetypes(deckCards []deck.CardCandidate) archetypeRanking {
+	// Calculate scores for each registered archetype
+	scorers := ArchetypeScorers()
+	archetypeScores := make(map[Archetype]float64, len(scorers))
+	for _, scorer := range scorers {
+		archetypeScores[scorer.Archetype()] = scorer.Score(deckCards)
+	}
 
 	// Find top 2 archetypes
-	primary, primaryScore := findTopArchetype(archetypeScores)
-	delete(archetypeScores, primary) // Remove primary to find secondary
-	secondary, secondaryScore := findTopArchetype(archetypeScores)
+	var ranking archetypeRanking
+	ranking.primary, ranking.primaryScore = findTopArchetype(archetypeScores)
+	delete(archetypeScores, ranking.primary) // Remove primary to find secondary
+	ranking.secondary, ranking.secondaryScore = findTopArchetype(archetypeScores)
+	return ranking
+}
 
+// classify turns a ranking into a detection result under thresholds
+func (r archetypeRanking) classify(thresholds HybridThresholds) ArchetypeDetectionResult {
 	// Normalize scores to confidence (0.0-1.0)
 	// A score > 7.0 is considered high confidence
-	primaryConfidence := normalizeConfidence(primaryScore)
-	secondaryConfidence := normalizeConfidence(secondaryScore)
-
-	// Determine if hybrid: secondary archetype must have >70% of primary score
-	// and both must have high confidence (0.7 threshold)
-	// Also require significant score gap to avoid marking similar archetypes as hybrid
-	scoreGap := primaryScore - secondaryScore
-	isHybrid := secondaryConfidence > 0.7 && primaryConfidence > 0.7 &&
-		secondaryScore > 0.7*primaryScore && scoreGap < 2.0
+	primaryConfidence := normalizeConfidence(r.primaryScore)
+	secondaryConfidence := normalizeConfidence(r.secondaryScore)
 
 	result := ArchetypeDetectionResult{
-		Primary:             primary,
+		Primary:             r.primary,
+		Dominant:            r.primary,
 		PrimaryConfidence:   primaryConfidence,
-		Secondary:           secondary,
+		Secondary:           r.secondary,
 		SecondaryConfidence: secondaryConfidence,
-		IsHybrid:            isHybrid,
+		IsHybrid:            thresholds.isHybrid(r, primaryConfidence, secondaryConfidence),
 	}
 
 	// If primary confidence is too low, mark as unknown
@@ -76,12 +111,6 @@ func DetectArchetype(deckCards []deck.CardCandidate) ArchetypeDetectionResult {
 		result.IsHybrid = false
 	}
 
-	// Mark as hybrid archetype only if truly hybrid (not related archetypes)
-	// Skip hybrid marking for closely related archetype pairs
-	if isHybrid && areRelatedArchetypes(primary, secondary) {
-		result.IsHybrid = false // Related archetypes, not true hybrid
-	}
-
 	if result.IsHybrid {
 		result.Primary = ArchetypeHybrid
 	}
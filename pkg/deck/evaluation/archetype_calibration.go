@@ -0,0 +1,206 @@
+package evaluation
+
+// HybridThresholds decide when a deck's top two archetypes are close enough
+// to call it a hybrid.
+type HybridThresholds struct {
+	// MinConfidence is the confidence both archetypes must exceed
+	MinConfidence float64 `json:"min_confidence"`
+	// MinScoreRatio is the fraction of the primary score the secondary score
+	// must exceed
+	MinScoreRatio float64 `json:"min_score_ratio"`
+	// MaxScoreGap is the raw score difference (0-10 scale) the two archetypes
+	// must stay under
+	MaxScoreGap float64 `json:"max_score_gap"`
+	// AllowRelated counts closely related pairs (siege and control, miner and
+	// cycle, ...) as hybrids
+	AllowRelated bool `json:"allow_related"`
+}
+
+// DefaultHybridThresholds are fit to ArchetypeCorpus with
+// CalibrateHybridThresholds: no labeled pure deck is a hybrid under them.
+var DefaultHybridThresholds = HybridThresholds{
+	MinConfidence: 0.85,
+	MinScoreRatio: 0.95,
+	MaxScoreGap:   0.5,
+	AllowRelated:  true,
+}
+
+func (t HybridThresholds) isHybrid(r archetypeRanking, primaryConfidence, secondaryConfidence float64) bool {
+	if r.secondary == ArchetypeUnknown {
+		return false
+	}
+	if primaryConfidence <= t.MinConfidence || secondaryConfidence <= t.MinConfidence {
+		return false
+	}
+	if r.secondaryScore <= t.MinScoreRatio*r.primaryScore || r.primaryScore-r.secondaryScore >= t.MaxScoreGap {
+		return false
+	}
+	return t.AllowRelated || !areRelatedArchetypes(r.primary, r.secondary)
+}
+
+// HybridCalibration measures hybrid detection against labeled decks: a true
+// positive is a labeled hybrid detected as a hybrid of one of its archetypes.
+type HybridCalibration struct {
+	Thresholds     HybridThresholds `json:"thresholds"`
+	Decks          int              `json:"decks"`
+	TruePositives  int              `json:"true_positives"`
+	FalsePositives int              `json:"false_positives"`
+	FalseNegatives int              `json:"false_negatives"`
+	Precision      float64          `json:"precision"`
+	Recall         float64          `json:"recall"`
+	F1             float64          `json:"f1"`
+	// PureAccuracy is the fraction of non-hybrid decks detected as their
+	// labeled archetype and not as a hybrid
+	PureAccuracy float64 `json:"pure_accuracy"`
+
+	pure, pureCorrect int
+}
+
+type rankedLabeledDeck struct {
+	label   LabeledDeck
+	ranking archetypeRanking
+}
+
+func rankLabeledDecks(decks []LabeledDeck) []rankedLabeledDeck {
+	ranked := make([]rankedLabeledDeck, 0, len(decks))
+	for _, labeled := range decks {
+		cards := labeled.Candidates()
+		if len(cards) == 0 {
+			continue
+		}
+		ranked = append(ranked, rankedLabeledDeck{label: labeled, ranking: rankArchetypes(cards)})
+	}
+	return ranked
+}
+
+// EvaluateHybridThresholds measures hybrid detection on decks under thresholds
+func EvaluateHybridThresholds(decks []LabeledDeck, thresholds HybridThresholds) HybridCalibration {
+	return scoreHybridThresholds(rankLabeledDecks(decks), thresholds)
+}
+
+func scoreHybridThresholds(decks []rankedLabeledDeck, thresholds HybridThresholds) HybridCalibration {
+	calibration := HybridCalibration{Thresholds: thresholds, Decks: len(decks)}
+	for _, ranked := range decks {
+		result := ranked.ranking.classify(thresholds)
+		label := ranked.label
+		if label.Hybrid {
+			if result.IsHybrid && labeledArchetypeMatches(label, result.Dominant, result.Secondary) {
+				calibration.TruePositives++
+			} else {
+				calibration.FalseNegatives++
+			}
+			continue
+		}
+
+		calibration.pure++
+		if result.IsHybrid {
+			calibration.FalsePositives++
+		}
+		if result.Primary == label.Archetype {
+			calibration.pureCorrect++
+		}
+	}
+	calibration.computeRates()
+	return calibration
+}
+
+// computeRates derives precision, recall, F1 and pure accuracy from the counts
+func (c *HybridCalibration) computeRates() {
+	c.Precision, c.Recall, c.F1, c.PureAccuracy = 0, 0, 0, 0
+	if tp := float64(c.TruePositives); tp > 0 {
+		c.Precision = tp / float64(c.TruePositives+c.FalsePositives)
+		c.Recall = tp / float64(c.TruePositives+c.FalseNegatives)
+		c.F1 = 2 * c.Precision * c.Recall / (c.Precision + c.Recall)
+	}
+	if c.pure > 0 {
+		c.PureAccuracy = float64(c.pureCorrect) / float64(c.pure)
+	}
+}
+
+func labeledArchetypeMatches(label LabeledDeck, archetypes ...Archetype) bool {
+	for _, archetype := range archetypes {
+		if archetype == label.Archetype || (label.Secondary != "" && archetype == label.Secondary) {
+			return true
+		}
+	}
+	return false
+}
+
+// CalibrateHybridThresholds searches a grid of thresholds for the one that
+// reports the fewest pure decks as hybrids and, among those, has the best
+// hybrid F1 on decks. A missed hybrid still reports its dominant archetype,
+// while a false one hides a pure deck's archetype, so false positives weigh
+// more. Ties go to pure deck accuracy and then to strictness, so a corpus
+// without hybrids keeps hybrids rare.
+func CalibrateHybridThresholds(decks []LabeledDeck) HybridCalibration {
+	return calibrateRanked(rankLabeledDecks(decks))
+}
+
+// CrossValidateHybridThresholds estimates how CalibrateHybridThresholds does
+// on decks it was not fit to. Deck i goes to fold i%folds; each fold is
+// scored with thresholds fit to the other folds, and the counts of every
+// fold are summed. The result has no Thresholds, since each fold has its own.
+func CrossValidateHybridThresholds(decks []LabeledDeck, folds int) HybridCalibration {
+	ranked := rankLabeledDecks(decks)
+	folds = max(min(folds, len(ranked)), 2)
+	total := HybridCalibration{Decks: len(ranked)}
+	for fold := range folds {
+		var train, held []rankedLabeledDeck
+		for i, labeled := range ranked {
+			if i%folds == fold {
+				held = append(held, labeled)
+			} else {
+				train = append(train, labeled)
+			}
+		}
+		scored := scoreHybridThresholds(held, calibrateRanked(train).Thresholds)
+		total.TruePositives += scored.TruePositives
+		total.FalsePositives += scored.FalsePositives
+		total.FalseNegatives += scored.FalseNegatives
+		total.pure += scored.pure
+		total.pureCorrect += scored.pureCorrect
+	}
+	total.computeRates()
+	return total
+}
+
+func calibrateRanked(ranked []rankedLabeledDeck) HybridCalibration {
+	var best HybridCalibration
+	first := true
+	// Strictest first: later candidates must be strictly better to win
+	for _, allowRelated := range []bool{false, true} {
+		for confidence := 0.90; confidence >= 0.295; confidence -= 0.05 {
+			for ratio := 0.95; ratio >= 0.495; ratio -= 0.05 {
+				for gap := 0.5; gap <= 5.001; gap += 0.25 {
+					candidate := scoreHybridThresholds(ranked, HybridThresholds{
+						MinConfidence: roundThreshold(confidence),
+						MinScoreRatio: roundThreshold(ratio),
+						MaxScoreGap:   roundThreshold(gap),
+						AllowRelated:  allowRelated,
+					})
+					if first || candidate.betterThan(best) {
+						best, first = candidate, false
+					}
+				}
+			}
+		}
+	}
+	return best
+}
+
+// betterThan orders calibrations by fewer false positives, then higher F1,
+// then higher pure deck accuracy
+func (c HybridCalibration) betterThan(other HybridCalibration) bool {
+	if c.FalsePositives != other.FalsePositives {
+		return c.FalsePositives < other.FalsePositives
+	}
+	if c.F1 != other.F1 {
+		return c.F1 > other.F1
+	}
+	return c.PureAccuracy > other.PureAccuracy
+}
+
+// roundThreshold drops floating point drift from the grid steps
+func roundThreshold(value float64) float64 {
+	return float64(int(value*100+0.5)) / 100
+}
@@ -0,0 +1,148 @@
+package evaluation
+
+import (
+	"testing"
+)
+
+func TestArchetypeCorpus(t *testing.T) {
+	corpus := ArchetypeCorpus()
+	if len(corpus) < 50 {
+		t.Fatalf("ArchetypeCorpus() has %d decks, want at least 50", len(corpus))
+	}
+	hybrids := 0
+	for _, labeled := range corpus {
+		if labeled.Name == "" || len(labeled.Cards) == 0 || labeled.Archetype == "" {
+			t.Errorf("incomplete labeled deck: %+v", labeled)
+		}
+		if labeled.Hybrid {
+			hybrids++
+			if labeled.Secondary == "" {
+				t.Errorf("hybrid deck %q has no secondary archetype", labeled.Name)
+			}
+		}
+	}
+	if hybrids == 0 {
+		t.Error("corpus has no labeled hybrid decks")
+	}
+
+	// Callers get a copy
+	corpus[0].Cards[0].Name = "changed"
+	if ArchetypeCorpus()[0].Cards[0].Name == "changed" {
+		t.Error("ArchetypeCorpus() returned shared cards")
+	}
+}
+
+func TestDefaultHybridThresholdsAreCalibrated(t *testing.T) {
+	corpus := ArchetypeCorpus()
+	fitted := CalibrateHybridThresholds(corpus)
+	if fitted.Thresholds != DefaultHybridThresholds {
+		t.Errorf("DefaultHybridThresholds = %+v, but the corpus fits %+v (F1 %.3f); update the defaults",
+			DefaultHybridThresholds, fitted.Thresholds, fitted.F1)
+	}
+
+	legacy := EvaluateHybridThresholds(corpus, HybridThresholds{MinConfidence: 0.7, MinScoreRatio: 0.7, MaxScoreGap: 2.0})
+	current := EvaluateHybridThresholds(corpus, DefaultHybridThresholds)
+	if current.F1 <= legacy.F1 {
+		t.Errorf("calibrated hybrid F1 %.3f is not better than the legacy thresholds' %.3f", current.F1, legacy.F1)
+	}
+	if current.FalsePositives != 0 {
+		t.Errorf("DefaultHybridThresholds report %d labeled pure decks as hybrids", current.FalsePositives)
+	}
+	if current.PureAccuracy < legacy.PureAccuracy {
+		t.Errorf("calibrated pure accuracy %.3f is below the legacy thresholds' %.3f", current.PureAccuracy, legacy.PureAccuracy)
+	}
+	t.Logf("hybrid F1 %.3f (legacy %.3f), precision %.3f, recall %.3f, pure accuracy %.3f",
+		current.F1, legacy.F1, current.Precision, current.Recall, current.PureAccuracy)
+}
+
+func TestCrossValidateHybridThresholds(t *testing.T) {
+	corpus := ArchetypeCorpus()
+	hybrids := 0
+	for _, labeled := range corpus {
+		if labeled.Hybrid {
+			hybrids++
+		}
+	}
+
+	// Every deck is held out exactly once
+	for _, folds := range []int{1, 5, len(corpus), 2 * len(corpus)} {
+		cv := CrossValidateHybridThresholds(corpus, folds)
+		if cv.TruePositives+cv.FalseNegatives != hybrids || cv.pure != len(corpus)-hybrids {
+			t.Errorf("%d folds: scored %d hybrids and %d pure decks, want %d and %d",
+				folds, cv.TruePositives+cv.FalseNegatives, cv.pure, hybrids, len(corpus)-hybrids)
+		}
+	}
+
+	cv := CrossValidateHybridThresholds(corpus, 5)
+	fitted := CalibrateHybridThresholds(corpus)
+	if cv.F1 > fitted.F1 {
+		t.Errorf("held-out F1 %.3f beats the in-sample F1 %.3f", cv.F1, fitted.F1)
+	}
+	t.Logf("5-fold hybrid F1 %.3f (TP/FP/FN %d/%d/%d), pure accuracy %.3f",
+		cv.F1, cv.TruePositives, cv.FalsePositives, cv.FalseNegatives, cv.PureAccuracy)
+}
+
+func TestEvaluateHybridThresholds(t *testing.T) {
+	decks := []LabeledDeck{
+		{
+			Name: "Graveyard X-Bow", Archetype: ArchetypeSiege, Secondary: ArchetypeGraveyard, Hybrid: true,
+			Cards: []LabeledCard{
+				{Name: "Graveyard", Elixir: 5}, {Name: "X-Bow", Elixir: 6},
+				{Name: "Tesla", Elixir: 4, Role: "buildings"}, {Name: "Ice Wizard", Elixir: 3},
+				{Name: "Poison", Elixir: 4}, {Name: "Tornado", Elixir: 3},
+				{Name: "Knight", Elixir: 3}, {Name: "Skeletons", Elixir: 1},
+			},
+		},
+		{
+			Name: "Log Bait", Archetype: ArchetypeBait,
+			Cards: []LabeledCard{
+				{Name: "Goblin Barrel", Elixir: 3}, {Name: "Princess", Elixir: 3},
+				{Name: "Goblin Gang", Elixir: 3}, {Name: "Knight", Elixir: 3},
+				{Name: "Ice Spirit", Elixir: 1}, {Name: "Inferno Tower", Elixir: 5},
+				{Name: "Rocket", Elixir: 6}, {Name: "The Log", Elixir: 2},
+			},
+		},
+	}
+
+	loose := EvaluateHybridThresholds(decks, HybridThresholds{MinConfidence: 0.5, MinScoreRatio: 0.5, MaxScoreGap: 5, AllowRelated: true})
+	if loose.TruePositives != 1 || loose.FalsePositives != 0 || loose.F1 != 1 || loose.PureAccuracy != 1 {
+		t.Errorf("loose thresholds = %+v, want one true positive and F1 1", loose)
+	}
+
+	// Nothing is a hybrid when the confidence bar cannot be cleared
+	strict := EvaluateHybridThresholds(decks, HybridThresholds{MinConfidence: 1, MinScoreRatio: 0.5, MaxScoreGap: 5})
+	if strict.TruePositives != 0 || strict.FalseNegatives != 1 || strict.F1 != 0 {
+		t.Errorf("strict thresholds = %+v, want one false negative", strict)
+	}
+}
+
+func TestEvaluationReportsHybridHalves(t *testing.T) {
+	for _, labeled := range ArchetypeCorpus() {
+		cards := labeled.Candidates()
+		detected := DetectArchetype(cards)
+		result := SummarizeEvaluation(Evaluate(cards, nil, nil))
+		if !detected.IsHybrid {
+			if result.HybridPrimary != "" || result.HybridSecondary != "" {
+				t.Errorf("%s: non-hybrid deck reports halves %s/%s", labeled.Name, result.HybridPrimary, result.HybridSecondary)
+			}
+			continue
+		}
+		if result.Archetype != ArchetypeHybrid || result.HybridPrimary != detected.Dominant || result.HybridSecondary != detected.Secondary {
+			t.Errorf("%s: cached archetype %s (%s/%s), want hybrid (%s/%s)",
+				labeled.Name, result.Archetype, result.HybridPrimary, result.HybridSecondary, detected.Dominant, detected.Secondary)
+		}
+	}
+}
+
+func TestParseArchetypeCorpus(t *testing.T) {
+	decks, err := ParseArchetypeCorpus(ArchetypeCorpusJSON())
+	if err != nil || len(decks) != len(ArchetypeCorpus()) {
+		t.Fatalf("ParseArchetypeCorpus(built-in) = %d decks, %v", len(decks), err)
+	}
+	if _, err := ParseArchetypeCorpus([]byte(`{"decks":[{"name":"x","archetype":"cycle","hybrid":true,"cards":[{"name":"Knight","elixir":3}]}]}`)); err == nil {
+		t.Error("a hybrid deck without a secondary archetype should fail")
+	}
+	if _, err := ParseArchetypeCorpus([]byte(`{"decks":[{"name":"x","archetype":"cycle"}]}`)); err == nil {
+		t.Error("a deck without cards should fail")
+	}
+}
@@ -0,0 +1,94 @@
+package evaluation
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+//go:embed archetype_corpus.json
+var archetypeCorpusJSON []byte
+
+// LabeledDeck is a deck whose archetype was classified by hand. Hybrid decks
+// name both archetypes.
+type LabeledDeck struct {
+	Name      string        `json:"name"`
+	Archetype Archetype     `json:"archetype"`
+	Secondary Archetype     `json:"secondary,omitempty"`
+	Hybrid    bool          `json:"hybrid,omitempty"`
+	Cards     []LabeledCard `json:"cards"`
+}
+
+// LabeledCard is one card of a LabeledDeck
+type LabeledCard struct {
+	Name   string        `json:"name"`
+	Elixir int           `json:"elixir"`
+	Role   deck.CardRole `json:"role,omitempty"`
+}
+
+// Candidates returns the deck's cards for DetectArchetype
+func (d LabeledDeck) Candidates() []deck.CardCandidate {
+	cards := make([]deck.CardCandidate, len(d.Cards))
+	for i, card := range d.Cards {
+		cards[i] = deck.CardCandidate{Name: card.Name, Elixir: card.Elixir}
+		if card.Role != "" {
+			cards[i].Role = new(card.Role)
+		}
+	}
+	return cards
+}
+
+type archetypeCorpusFile struct {
+	Version     int           `json:"version"`
+	Description string        `json:"description,omitempty"`
+	Decks       []LabeledDeck `json:"decks"`
+}
+
+var (
+	archetypeCorpusOnce sync.Once
+	archetypeCorpus     []LabeledDeck
+)
+
+// ArchetypeCorpus returns a copy of the built-in labeled deck corpus used to
+// measure archetype detection and calibrate DefaultHybridThresholds.
+func ArchetypeCorpus() []LabeledDeck {
+	archetypeCorpusOnce.Do(func() {
+		decks, err := ParseArchetypeCorpus(archetypeCorpusJSON)
+		if err != nil {
+			panic(fmt.Sprintf("embedded archetype_corpus.json is invalid: %v", err))
+		}
+		archetypeCorpus = decks
+	})
+	decks := slices.Clone(archetypeCorpus)
+	for i := range decks {
+		decks[i].Cards = slices.Clone(decks[i].Cards)
+	}
+	return decks
+}
+
+// ArchetypeCorpusJSON returns the built-in corpus in the format
+// ParseArchetypeCorpus reads, as a starting point for a custom corpus.
+func ArchetypeCorpusJSON() []byte {
+	return slices.Clone(archetypeCorpusJSON)
+}
+
+// ParseArchetypeCorpus parses a labeled deck corpus
+func ParseArchetypeCorpus(data []byte) ([]LabeledDeck, error) {
+	var file archetypeCorpusFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse archetype corpus: %w", err)
+	}
+	for i, labeled := range file.Decks {
+		if labeled.Archetype == "" || len(labeled.Cards) == 0 {
+			return nil, fmt.Errorf("archetype corpus deck %d (%s) needs an archetype and cards", i+1, labeled.Name)
+		}
+		if labeled.Hybrid && labeled.Secondary == "" {
+			return nil, fmt.Errorf("archetype corpus deck %d (%s) is hybrid but has no secondary archetype", i+1, labeled.Name)
+		}
+	}
+	return file.Decks, nil
+}
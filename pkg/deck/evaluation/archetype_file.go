@@ -0,0 +1,147 @@
+package evaluation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+// CustomArchetypesFile is the default name of the custom archetypes file in
+// the data directory
+const CustomArchetypesFile = "custom_archetypes.json"
+
+// CustomArchetypeFile is the JSON format of a custom archetypes file
+type CustomArchetypeFile struct {
+	Archetypes []KeywordArchetype `json:"archetypes"`
+}
+
+// KeywordArchetype is a custom archetype scored from keyword weights: each
+// card in the deck adds the weight of its name and of its role. The total is
+// capped to 0-10, halved when the deck's average elixir is outside the
+// optional range, and 0 when a required card is missing.
+type KeywordArchetype struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Keywords maps card names to the points they add
+	Keywords map[string]float64 `json:"keywords,omitempty"`
+	// Roles maps card roles (see `cards roles list`) to the points each card
+	// with that role adds
+	Roles     map[config.CardRole]float64 `json:"roles,omitempty"`
+	Required  []string                    `json:"required,omitempty"`
+	MinElixir float64                     `json:"min_elixir,omitempty"`
+	MaxElixir float64                     `json:"max_elixir,omitempty"`
+}
+
+// Archetype returns the archetype name
+func (k *KeywordArchetype) Archetype() Archetype {
+	return Archetype(k.Name)
+}
+
+// Score scores a deck's fit for the archetype (0-10 scale)
+func (k *KeywordArchetype) Score(deckCards []deck.CardCandidate) float64 {
+	for _, required := range k.Required {
+		if !hasCard(deckCards, required) {
+			return 0.0
+		}
+	}
+
+	score := 0.0
+	for _, card := range deckCards {
+		score += k.Keywords[card.Name]
+		if len(k.Roles) > 0 {
+			score += k.Roles[config.GetCardRoleWithEvolution(card.Name, card.EvolutionLevel)]
+		}
+	}
+	score = min(10.0, max(0.0, score))
+
+	avgElixir := calculateAvgElixir(deckCards)
+	if (k.MinElixir > 0 && avgElixir < k.MinElixir) || (k.MaxElixir > 0 && avgElixir > k.MaxElixir) {
+		score *= 0.5
+	}
+	return score
+}
+
+func hasCard(deckCards []deck.CardCandidate, name string) bool {
+	for _, card := range deckCards {
+		if card.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// validate normalizes role names and checks that the archetype can score
+func (k *KeywordArchetype) validate() error {
+	k.Name = strings.TrimSpace(k.Name)
+	if k.Name == "" {
+		return fmt.Errorf("archetype has no name")
+	}
+	if IsBuiltinArchetype(Archetype(k.Name)) {
+		return fmt.Errorf("%s: name is used by a built-in archetype", k.Name)
+	}
+	if len(k.Keywords) == 0 && len(k.Roles) == 0 {
+		return fmt.Errorf("%s: needs keywords or roles", k.Name)
+	}
+	if k.MinElixir < 0 || k.MaxElixir < 0 || (k.MaxElixir > 0 && k.MinElixir > k.MaxElixir) {
+		return fmt.Errorf("%s: invalid elixir range %.1f-%.1f", k.Name, k.MinElixir, k.MaxElixir)
+	}
+	roles := make(map[config.CardRole]float64, len(k.Roles))
+	for role, weight := range k.Roles {
+		parsed, err := config.ParseCardRole(string(role))
+		if err != nil {
+			return fmt.Errorf("%s: %w", k.Name, err)
+		}
+		roles[parsed] += weight
+	}
+	k.Roles = roles
+	return nil
+}
+
+// ParseCustomArchetypes parses and validates custom archetypes JSON
+func ParseCustomArchetypes(data []byte) (*CustomArchetypeFile, error) {
+	var file CustomArchetypeFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse custom archetypes file: %w", err)
+	}
+	seen := make(map[string]bool, len(file.Archetypes))
+	for i := range file.Archetypes {
+		archetype := &file.Archetypes[i]
+		if err := archetype.validate(); err != nil {
+			return nil, err
+		}
+		if seen[archetype.Name] {
+			return nil, fmt.Errorf("%s: defined more than once", archetype.Name)
+		}
+		seen[archetype.Name] = true
+	}
+	return &file, nil
+}
+
+// ReadCustomArchetypes reads and parses a custom archetypes JSON file
+func ReadCustomArchetypes(path string) (*CustomArchetypeFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read custom archetypes file: %w", err)
+	}
+	file, err := ParseCustomArchetypes(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return file, nil
+}
+
+// Register replaces the custom archetype scorers with the file's archetypes
+func (f *CustomArchetypeFile) Register() error {
+	ResetArchetypeScorers()
+	for i := range f.Archetypes {
+		if err := RegisterArchetypeScorer(&f.Archetypes[i]); err != nil {
+			ResetArchetypeScorers()
+			return err
+		}
+	}
+	return nil
+}
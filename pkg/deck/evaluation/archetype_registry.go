@@ -0,0 +1,112 @@
+package evaluation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+// ArchetypeScorer scores how well a deck fits one archetype on a 0-10 scale.
+// DetectArchetype runs every registered scorer and picks the top two.
+type ArchetypeScorer interface {
+	Archetype() Archetype
+	Score(deckCards []deck.CardCandidate) float64
+}
+
+// archetypeScorerFunc adapts a built-in score function to ArchetypeScorer
+type archetypeScorerFunc struct {
+	archetype Archetype
+	score     func([]deck.CardCandidate) float64
+}
+
+func (s archetypeScorerFunc) Archetype() Archetype { return s.archetype }
+
+func (s archetypeScorerFunc) Score(deckCards []deck.CardCandidate) float64 {
+	return s.score(deckCards)
+}
+
+// builtinArchetypeScorers are always registered, ahead of any custom scorer
+var builtinArchetypeScorers = []ArchetypeScorer{
+	archetypeScorerFunc{ArchetypeBeatdown, scoreBeatdown},
+	archetypeScorerFunc{ArchetypeControl, scoreControl},
+	archetypeScorerFunc{ArchetypeCycle, scoreCycle},
+	archetypeScorerFunc{ArchetypeBridge, scoreBridgeSpam},
+	archetypeScorerFunc{ArchetypeSiege, scoreSiege},
+	archetypeScorerFunc{ArchetypeBait, scoreBait},
+	archetypeScorerFunc{ArchetypeGraveyard, scoreGraveyard},
+	archetypeScorerFunc{ArchetypeMiner, scoreMiner},
+}
+
+var archetypeRegistry struct {
+	mu     sync.RWMutex
+	custom []ArchetypeScorer
+	// fingerprint describes the custom scorers for evaluation cache keys
+	fingerprint string
+}
+
+// RegisterArchetypeScorer adds a custom archetype to DetectArchetype. The
+// archetype name must be new: built-in archetypes, hybrid, and unknown cannot
+// be replaced.
+func RegisterArchetypeScorer(scorer ArchetypeScorer) error {
+	if scorer == nil {
+		return fmt.Errorf("archetype scorer is nil")
+	}
+	name := scorer.Archetype()
+	if strings.TrimSpace(string(name)) == "" {
+		return fmt.Errorf("archetype scorer has no name")
+	}
+	if name == ArchetypeHybrid || name == ArchetypeUnknown {
+		return fmt.Errorf("archetype %q is reserved", name)
+	}
+
+	archetypeRegistry.mu.Lock()
+	defer archetypeRegistry.mu.Unlock()
+	for _, existing := range slices.Concat(builtinArchetypeScorers, archetypeRegistry.custom) {
+		if existing.Archetype() == name {
+			return fmt.Errorf("archetype %q is already registered", name)
+		}
+	}
+	archetypeRegistry.custom = append(archetypeRegistry.custom, scorer)
+	archetypeRegistry.fingerprint += fmt.Sprintf("%s=%+v;", name, scorer)
+	return nil
+}
+
+// ResetArchetypeScorers removes every custom archetype, leaving the built-in
+// ones.
+func ResetArchetypeScorers() {
+	archetypeRegistry.mu.Lock()
+	defer archetypeRegistry.mu.Unlock()
+	archetypeRegistry.custom = nil
+	archetypeRegistry.fingerprint = ""
+}
+
+// ArchetypeScorers returns the registered scorers, built-in ones first.
+func ArchetypeScorers() []ArchetypeScorer {
+	archetypeRegistry.mu.RLock()
+	defer archetypeRegistry.mu.RUnlock()
+	return slices.Concat(builtinArchetypeScorers, archetypeRegistry.custom)
+}
+
+// IsBuiltinArchetype reports whether an archetype is one DetectArchetype
+// knows without a custom scorer.
+func IsBuiltinArchetype(archetype Archetype) bool {
+	if archetype == ArchetypeHybrid || archetype == ArchetypeUnknown {
+		return true
+	}
+	for _, scorer := range builtinArchetypeScorers {
+		if scorer.Archetype() == archetype {
+			return true
+		}
+	}
+	return false
+}
+
+// customArchetypeFingerprint changes whenever the custom scorers do
+func customArchetypeFingerprint() string {
+	archetypeRegistry.mu.RLock()
+	defer archetypeRegistry.mu.RUnlock()
+	return archetypeRegistry.fingerprint
+}
@@ -0,0 +1,148 @@
+package evaluation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+const recruitsArchetypesJSON = `{
+  "archetypes": [
+    {
+      "name": "recruits-split-lane",
+      "description": "Royal Recruits with split-lane pressure",
+      "required": ["Royal Recruits"],
+      "keywords": {"Royal Recruits": 5, "Flying Machine": 2, "Royal Hogs": 3, "Zappies": 1},
+      "roles": {"spell small": 0.5},
+      "min_elixir": 3.4,
+      "max_elixir": 4.6
+    }
+  ]
+}`
+
+func recruitsDeck() []deck.CardCandidate {
+	return []deck.CardCandidate{
+		makeCard("Royal Recruits", deck.RoleSupport, 11, 14, "Common", 7),
+		makeCard("Royal Hogs", deck.RoleWinCondition, 11, 14, "Common", 5),
+		makeCard("Flying Machine", deck.RoleSupport, 11, 14, "Common", 4),
+		makeCard("Zappies", deck.RoleSupport, 11, 14, "Common", 4),
+		makeCard("Goblin Cage", deck.RoleBuilding, 11, 14, "Common", 4),
+		makeCard("Fireball", deck.RoleSpellBig, 11, 14, "Common", 4),
+		makeCard("Arrows", deck.RoleSpellSmall, 11, 14, "Common", 3),
+		makeCard("Barbarian Barrel", deck.RoleSpellSmall, 11, 14, "Common", 2),
+	}
+}
+
+func registerTestArchetypes(t *testing.T, data string) {
+	t.Helper()
+	file, err := ParseCustomArchetypes([]byte(data))
+	if err != nil {
+		t.Fatalf("ParseCustomArchetypes() error = %v", err)
+	}
+	if err := file.Register(); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	t.Cleanup(ResetArchetypeScorers)
+}
+
+func TestKeywordArchetypeDetection(t *testing.T) {
+	before := DetectArchetype(recruitsDeck())
+	if before.Primary == "recruits-split-lane" {
+		t.Fatal("custom archetype detected before it was registered")
+	}
+
+	registerTestArchetypes(t, recruitsArchetypesJSON)
+	result := DetectArchetype(recruitsDeck())
+	if result.Primary != "recruits-split-lane" {
+		t.Errorf("Primary = %s, want recruits-split-lane", result.Primary)
+	}
+
+	// Without the required card the archetype scores nothing
+	withoutRecruits := recruitsDeck()
+	withoutRecruits[0] = makeCard("Knight", deck.RoleSupport, 11, 14, "Common", 3)
+	if got := DetectArchetype(withoutRecruits); got.Primary == "recruits-split-lane" {
+		t.Errorf("Primary = %s without Royal Recruits", got.Primary)
+	}
+}
+
+func TestKeywordArchetypeScore(t *testing.T) {
+	file, err := ParseCustomArchetypes([]byte(recruitsArchetypesJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	archetype := &file.Archetypes[0]
+
+	// 5 + 3 + 2 + 1 from keywords, 0.5 each for Arrows and Barbarian Barrel
+	if got := archetype.Score(recruitsDeck()); got != 10.0 {
+		t.Errorf("Score() = %.2f, want 10 (capped)", got)
+	}
+
+	cheap := recruitsDeck()
+	for i := range cheap {
+		cheap[i].Elixir = 2
+	}
+	if got := archetype.Score(cheap); got != 5.0 {
+		t.Errorf("Score() outside the elixir range = %.2f, want 5", got)
+	}
+}
+
+func TestParseCustomArchetypesErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{name: "missing name", data: `{"archetypes":[{"keywords":{"Knight":1}}]}`, want: "no name"},
+		{name: "built-in name", data: `{"archetypes":[{"name":"cycle","keywords":{"Knight":1}}]}`, want: "built-in"},
+		{name: "no weights", data: `{"archetypes":[{"name":"empty"}]}`, want: "needs keywords or roles"},
+		{name: "bad role", data: `{"archetypes":[{"name":"x","roles":{"tank":1}}]}`, want: "unknown card role"},
+		{name: "bad elixir", data: `{"archetypes":[{"name":"x","keywords":{"Knight":1},"min_elixir":5,"max_elixir":3}]}`, want: "elixir range"},
+		{name: "duplicate", data: `{"archetypes":[{"name":"x","keywords":{"Knight":1}},{"name":"x","keywords":{"Knight":1}}]}`, want: "more than once"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCustomArchetypes([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseCustomArchetypes() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterArchetypeScorer(t *testing.T) {
+	t.Cleanup(ResetArchetypeScorers)
+
+	if err := RegisterArchetypeScorer(archetypeScorerFunc{ArchetypeCycle, scoreCycle}); err == nil {
+		t.Error("registering a built-in archetype again should fail")
+	}
+	if err := RegisterArchetypeScorer(archetypeScorerFunc{ArchetypeHybrid, scoreCycle}); err == nil {
+		t.Error("registering hybrid should fail")
+	}
+
+	custom := &KeywordArchetype{Name: "custom", Keywords: map[string]float64{"Knight": 1}}
+	if err := RegisterArchetypeScorer(custom); err != nil {
+		t.Fatalf("RegisterArchetypeScorer() error = %v", err)
+	}
+	if err := RegisterArchetypeScorer(custom); err == nil {
+		t.Error("registering the same archetype twice should fail")
+	}
+	scorers := ArchetypeScorers()
+	if len(scorers) != len(builtinArchetypeScorers)+1 || scorers[len(scorers)-1].Archetype() != "custom" {
+		t.Errorf("ArchetypeScorers() = %d scorers, want built-ins then custom", len(scorers))
+	}
+
+	ResetArchetypeScorers()
+	if got := len(ArchetypeScorers()); got != len(builtinArchetypeScorers) {
+		t.Errorf("after reset, %d scorers, want %d", got, len(builtinArchetypeScorers))
+	}
+}
+
+func TestEvaluationCacheKeyIncludesCustomArchetypes(t *testing.T) {
+	cards := recruitsDeck()
+	before := EvaluationCacheKey(cards, "")
+	registerTestArchetypes(t, recruitsArchetypesJSON)
+	if EvaluationCacheKey(cards, "") == before {
+		t.Error("cache key should change when custom archetypes are registered")
+	}
+}
@@ -0,0 +1,316 @@
+package evaluation
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+// DefaultEvaluationCacheSize is the number of evaluations kept in memory and
+// on disk by default.
+const DefaultEvaluationCacheSize = 50000
+
+// CachedEvaluation holds the scores of one evaluation, without the detailed
+// analysis sections, so that many can be kept in memory and on disk.
+type CachedEvaluation struct {
+	OverallScore        float64   `json:"overall"`
+	ContextualScore     float64   `json:"contextual,omitempty"`
+	LadderScore         float64   `json:"ladder,omitempty"`
+	NormalizedScore     float64   `json:"normalized,omitempty"`
+	DeckLevelRatio      float64   `json:"level_ratio,omitempty"`
+	NormalizationFactor float64   `json:"normalization,omitempty"`
+	Attack              float64   `json:"attack,omitempty"`
+	Defense             float64   `json:"defense,omitempty"`
+	Synergy             float64   `json:"synergy,omitempty"`
+	Versatility         float64   `json:"versatility,omitempty"`
+	AvgElixir           float64   `json:"elixir,omitempty"`
+	Archetype           Archetype `json:"archetype,omitempty"`
+	ArchetypeConfidence float64   `json:"archetype_confidence,omitempty"`
+	HybridPrimary       Archetype `json:"hybrid_primary,omitempty"`
+	HybridSecondary     Archetype `json:"hybrid_secondary,omitempty"`
+}
+
+// SummarizeEvaluation keeps the scores of result worth caching. Without an
+// overall breakdown the contextual and normalized scores equal the overall
+// score and the level ratio and normalization factor are 1.
+func SummarizeEvaluation(result EvaluationResult) CachedEvaluation {
+	cached := CachedEvaluation{
+		OverallScore:        result.OverallScore,
+		ContextualScore:     result.OverallScore,
+		NormalizedScore:     result.OverallScore,
+		DeckLevelRatio:      1,
+		NormalizationFactor: 1,
+		Attack:              result.Attack.Score,
+		Defense:             result.Defense.Score,
+		Synergy:             result.Synergy.Score,
+		Versatility:         result.Versatility.Score,
+		AvgElixir:           result.AvgElixir,
+		Archetype:           result.DetectedArchetype,
+		ArchetypeConfidence: result.ArchetypeConfidence,
+		HybridPrimary:       result.HybridPrimary,
+		HybridSecondary:     result.HybridSecondary,
+	}
+	if breakdown := result.OverallBreakdown; breakdown != nil {
+		cached.ContextualScore = breakdown.ContextualScore
+		cached.LadderScore = breakdown.LadderScore
+		cached.NormalizedScore = breakdown.NormalizedScore
+		cached.DeckLevelRatio = breakdown.DeckLevelRatio
+		cached.NormalizationFactor = breakdown.NormalizationFactor
+	}
+	return cached
+}
+
+// EvaluationCacheKey hashes a deck's sorted card names with their level,
+// evolution level, and role, the scoring version, and variant. Callers put
+// everything else that changes the score (see EvaluationVariant) in variant.
+// Custom archetype scorers, scorer plugins (with the files a subprocess
+// plugin runs), and the synergy overrides and learned overlay (see
+// deck.SynergyFingerprint) are added to the key automatically. Results
+// where a plugin failed (see EvaluationResult.PluginFailed) must not be
+// stored under it.
+func EvaluationCacheKey(cards []deck.CardCandidate, variant string) string {
+	parts := make([]string, len(cards))
+	for i, card := range cards {
+		parts[i] = card.Name + "@" + strconv.Itoa(card.Level) + "/" + strconv.Itoa(card.MaxLevel) + "/" + strconv.Itoa(card.EvolutionLevel)
+		if card.Role != nil {
+			parts[i] += "/" + string(*card.Role)
+		}
+	}
+	slices.Sort(parts)
+	if custom := customArchetypeFingerprint(); custom != "" {
+		variant += " archetypes=" + custom
+	}
+	if plugins := scorerPluginFingerprint(); plugins != "" {
+		variant += " plugins=" + plugins
+	}
+	if synergy := deck.SynergyFingerprint(); synergy != "" {
+		variant += " synergy=" + synergy
+	}
+	sum := sha256.Sum256([]byte(CurrentScoringVersion + "\n" + variant + "\n" + strings.Join(parts, "|")))
+	return hex.EncodeToString(sum[:])
+}
+
+// ScoringFingerprint identifies everything besides the deck and the player
+// that decides a score: the scoring version, custom archetype scorers, scorer
+// plugins, and the synergy overrides and learned overlay. Two processes with
+// the same fingerprint score a deck alike, so distributed fuzz workers
+// compare theirs with the coordinator's.
+func ScoringFingerprint() string {
+	sum := sha256.Sum256([]byte(customArchetypeFingerprint() + "\n" + scorerPluginFingerprint() + "\n" + deck.SynergyFingerprint()))
+	return CurrentScoringVersion + "/" + hex.EncodeToString(sum[:8])
+}
+
+// EvaluationVariant describes the player context and options an evaluation
+// ran with, for use in EvaluationCacheKey. It reports false for options the
+// key cannot capture (a meta context), whose results must not be cached.
+func EvaluationVariant(playerContext *PlayerContext, opts EvaluateOptions) (string, bool) {
+	if opts.Meta != nil {
+		return "", false
+	}
+	band, _ := resolveTrophyBand(playerContext, opts)
+	variant := fmt.Sprintf("mode=%s tower=%s game=%s band=%s", opts.Mode, opts.TowerTroop, opts.GameMode, band.Band)
+	if playerContext == nil {
+		return variant + " player=none", true
+	}
+	evolutions := make([]string, 0, len(playerContext.UnlockedEvolutions))
+	for card, unlocked := range playerContext.UnlockedEvolutions {
+		if unlocked {
+			evolutions = append(evolutions, card)
+		}
+	}
+	slices.Sort(evolutions)
+	return fmt.Sprintf("%s player=%s arena=%d tower_troop=%s evolutions=%s", variant,
+		playerContext.PlayerTag, playerContext.ArenaID, playerContext.TowerTroop, strings.Join(evolutions, ",")), true
+}
+
+// EvaluationCacheStats counts cache lookups.
+type EvaluationCacheStats struct {
+	Hits    int64
+	Misses  int64
+	Entries int
+	// Loaded is the number of entries read from disk when the cache opened
+	Loaded int
+}
+
+// HitRate is the fraction of lookups that hit, 0 before any lookup.
+func (s EvaluationCacheStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// EvaluationCache is a least-recently-used cache of evaluations, optionally
+// persisted to a JSON file between runs. It is safe for concurrent use, and
+// a nil cache never hits and ignores stores.
+type EvaluationCache struct {
+	mu       sync.Mutex
+	capacity int
+	path     string
+	order    *list.List // front is most recently used
+	entries  map[string]*list.Element
+	stats    EvaluationCacheStats
+	dirty    bool
+}
+
+type evaluationCacheEntry struct {
+	Key   string           `json:"key"`
+	Value CachedEvaluation `json:"value"`
+}
+
+type evaluationCacheFile struct {
+	ScoringVersion string                 `json:"scoring_version"`
+	Entries        []evaluationCacheEntry `json:"entries"`
+}
+
+// NewEvaluationCache returns an in-memory cache holding up to capacity
+// evaluations (DefaultEvaluationCacheSize when capacity is not positive).
+func NewEvaluationCache(capacity int) *EvaluationCache {
+	if capacity <= 0 {
+		capacity = DefaultEvaluationCacheSize
+	}
+	return &EvaluationCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// OpenEvaluationCache returns a cache backed by path, loading the entries a
+// previous run saved there. A missing file or one written by another scoring
+// version starts the cache empty.
+func OpenEvaluationCache(path string, capacity int) (*EvaluationCache, error) {
+	cache := NewEvaluationCache(capacity)
+	cache.path = path
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cache, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read evaluation cache: %w", err)
+	}
+	var file evaluationCacheFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse evaluation cache %s: %w", path, err)
+	}
+	if file.ScoringVersion != CurrentScoringVersion {
+		cache.dirty = true
+		return cache, nil
+	}
+	// Entries are saved most recent first; pushing each to the back keeps
+	// that order and stops at capacity
+	for _, entry := range file.Entries {
+		if cache.order.Len() >= cache.capacity {
+			break
+		}
+		if _, ok := cache.entries[entry.Key]; ok {
+			continue
+		}
+		cache.entries[entry.Key] = cache.order.PushBack(&evaluationCacheEntry{Key: entry.Key, Value: entry.Value})
+	}
+	cache.stats.Loaded = cache.order.Len()
+	return cache, nil
+}
+
+// Get returns the cached evaluation for key and marks it recently used.
+func (c *EvaluationCache) Get(key string) (CachedEvaluation, bool) {
+	if c == nil {
+		return CachedEvaluation{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		c.stats.Misses++
+		return CachedEvaluation{}, false
+	}
+	c.stats.Hits++
+	c.order.MoveToFront(element)
+	return element.Value.(*evaluationCacheEntry).Value, true
+}
+
+// Put stores an evaluation, evicting the least recently used one when full.
+func (c *EvaluationCache) Put(key string, value CachedEvaluation) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dirty = true
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*evaluationCacheEntry).Value = value
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&evaluationCacheEntry{Key: key, Value: value})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*evaluationCacheEntry).Key)
+	}
+}
+
+// Stats returns the lookup counts so far.
+func (c *EvaluationCache) Stats() EvaluationCacheStats {
+	if c == nil {
+		return EvaluationCacheStats{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Entries = c.order.Len()
+	return stats
+}
+
+// Save writes the cache to its file, most recently used first. It does
+// nothing for an in-memory cache or when nothing changed since opening.
+func (c *EvaluationCache) Save() error {
+	if c == nil || c.path == "" {
+		return nil
+	}
+	c.mu.Lock()
+	if !c.dirty {
+		c.mu.Unlock()
+		return nil
+	}
+	file := evaluationCacheFile{
+		ScoringVersion: CurrentScoringVersion,
+		Entries:        make([]evaluationCacheEntry, 0, c.order.Len()),
+	}
+	for element := c.order.Front(); element != nil; element = element.Next() {
+		file.Entries = append(file.Entries, *element.Value.(*evaluationCacheEntry))
+	}
+	c.dirty = false
+	c.mu.Unlock()
+
+	data, err := json.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("failed to encode evaluation cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create evaluation cache directory: %w", err)
+	}
+	// Write then rename so an interrupted save never leaves a torn file
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write evaluation cache: %w", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return fmt.Errorf("failed to replace evaluation cache: %w", err)
+	}
+	return nil
+}
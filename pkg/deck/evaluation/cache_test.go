@@ -0,0 +1,153 @@
+package evaluation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+func TestEvaluationCacheKey(t *testing.T) {
+	deckA := []deck.CardCandidate{{Name: "Knight", Level: 14, MaxLevel: 16}, {Name: "Hog Rider", Level: 13, MaxLevel: 16}}
+	reordered := []deck.CardCandidate{deckA[1], deckA[0]}
+	upgraded := []deck.CardCandidate{{Name: "Knight", Level: 15, MaxLevel: 16}, deckA[1]}
+	evolved := []deck.CardCandidate{{Name: "Knight", Level: 14, MaxLevel: 16, EvolutionLevel: 1}, deckA[1]}
+
+	key := EvaluationCacheKey(deckA, "ladder")
+	if got := EvaluationCacheKey(reordered, "ladder"); got != key {
+		t.Error("card order changed the key")
+	}
+	for name, other := range map[string]string{
+		"level":     EvaluationCacheKey(upgraded, "ladder"),
+		"evolution": EvaluationCacheKey(evolved, "ladder"),
+		"variant":   EvaluationCacheKey(deckA, "2v2"),
+	} {
+		if other == key {
+			t.Errorf("%s change kept the same key", name)
+		}
+	}
+}
+
+func TestEvaluationCacheKeyIncludesSynergyFiles(t *testing.T) {
+	cards := []deck.CardCandidate{{Name: "Giant", Level: 14, MaxLevel: 16}, {Name: "Witch", Level: 14, MaxLevel: 16}}
+	before := EvaluationCacheKey(cards, "ladder")
+
+	path := filepath.Join(t.TempDir(), deck.SynergyOverridesFile)
+	if err := os.WriteFile(path, []byte(`{"version":1,"pairs":[{"card1":"Giant","card2":"Witch","score":0.1}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	deck.SetSynergyOverridesPath(path)
+	t.Cleanup(func() { deck.SetSynergyOverridesPath("") })
+	if EvaluationCacheKey(cards, "ladder") == before {
+		t.Error("cache key should change when a synergy overrides file is in effect")
+	}
+}
+
+func TestEvaluationVariant(t *testing.T) {
+	if _, ok := EvaluationVariant(nil, EvaluateOptions{Meta: &MetaContext{}}); ok {
+		t.Error("meta context evaluations should not be cacheable")
+	}
+	ladder, _ := EvaluationVariant(nil, EvaluateOptions{})
+	twoVTwo, _ := EvaluationVariant(nil, EvaluateOptions{Mode: Mode2v2})
+	if ladder == twoVTwo {
+		t.Error("mode should change the variant")
+	}
+	withPlayer, ok := EvaluationVariant(&PlayerContext{PlayerTag: "#ABC", UnlockedEvolutions: map[string]bool{"Knight": true}}, EvaluateOptions{})
+	if !ok || withPlayer == ladder {
+		t.Errorf("player context variant = %q, %v", withPlayer, ok)
+	}
+}
+
+func TestEvaluationCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewEvaluationCache(2)
+	cache.Put("a", CachedEvaluation{OverallScore: 1})
+	cache.Put("b", CachedEvaluation{OverallScore: 2})
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatal("expected a to be cached")
+	}
+	cache.Put("c", CachedEvaluation{OverallScore: 3})
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("b was least recently used and should have been evicted")
+	}
+	if got, ok := cache.Get("c"); !ok || got.OverallScore != 3 {
+		t.Errorf("Get(c) = %+v, %v", got, ok)
+	}
+	stats := cache.Stats()
+	if stats.Hits != 2 || stats.Misses != 1 || stats.Entries != 2 {
+		t.Errorf("stats = %+v", stats)
+	}
+	if rate := stats.HitRate(); rate < 0.66 || rate > 0.67 {
+		t.Errorf("hit rate = %v, want 2/3", rate)
+	}
+}
+
+func TestEvaluationCachePersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "evaluations.json")
+	cache, err := OpenEvaluationCache(path, 10)
+	if err != nil {
+		t.Fatalf("OpenEvaluationCache() error = %v", err)
+	}
+	cache.Put("old", CachedEvaluation{OverallScore: 5})
+	cache.Put("new", CachedEvaluation{OverallScore: 7, Archetype: ArchetypeCycle})
+	if err := cache.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	// A smaller reopened cache keeps the most recently used entries
+	reopened, err := OpenEvaluationCache(path, 1)
+	if err != nil {
+		t.Fatalf("reopen error = %v", err)
+	}
+	if got, ok := reopened.Get("new"); !ok || got.OverallScore != 7 || got.Archetype != ArchetypeCycle {
+		t.Errorf("Get(new) = %+v, %v", got, ok)
+	}
+	if _, ok := reopened.Get("old"); ok {
+		t.Error("old should not fit in a cache of one")
+	}
+	if reopened.Stats().Loaded != 1 {
+		t.Errorf("loaded = %d, want 1", reopened.Stats().Loaded)
+	}
+
+	stale := `{"scoring_version":"0.1.0","entries":[{"key":"new","value":{"overall":1}}]}`
+	if err := os.WriteFile(path, []byte(stale), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err = OpenEvaluationCache(path, 10)
+	if err != nil {
+		t.Fatalf("reopen stale error = %v", err)
+	}
+	if _, ok := reopened.Get("new"); ok {
+		t.Error("entries from another scoring version should be dropped")
+	}
+}
+
+func TestNilEvaluationCache(t *testing.T) {
+	var cache *EvaluationCache
+	cache.Put("a", CachedEvaluation{OverallScore: 1})
+	if _, ok := cache.Get("a"); ok {
+		t.Error("nil cache should never hit")
+	}
+	if err := cache.Save(); err != nil {
+		t.Errorf("Save() on nil cache = %v", err)
+	}
+}
+
+func TestSummarizeEvaluation(t *testing.T) {
+	result := EvaluationResult{
+		OverallScore: 7.5,
+		Attack:       CategoryScore{Score: 8},
+		AvgElixir:    3.1,
+	}
+	cached := SummarizeEvaluation(result)
+	if cached.ContextualScore != 7.5 || cached.DeckLevelRatio != 1 || cached.Attack != 8 {
+		t.Errorf("summary without breakdown = %+v", cached)
+	}
+
+	result.OverallBreakdown = &OverallScoreBreakdown{ContextualScore: 6, LadderScore: 7, NormalizedScore: 8, DeckLevelRatio: 0.9, NormalizationFactor: 1.1}
+	cached = SummarizeEvaluation(result)
+	if cached.ContextualScore != 6 || cached.LadderScore != 7 || cached.DeckLevelRatio != 0.9 {
+		t.Errorf("summary with breakdown = %+v", cached)
+	}
+}
@@ -22,6 +22,15 @@ type PlayerContext struct {
 	// Player metadata
 	PlayerTag  string
 	PlayerName string
+
+	// Tower troops: the one equipped with the current deck and all owned
+	TowerTroop  string
+	TowerTroops []string
+
+	// Trophies and the trophy band they fall in; the band shifts scoring
+	// weights and meta assumptions (see TrophyBandPreset)
+	Trophies   int
+	TrophyBand TrophyBand
 }
 
 // CardLevelInfo stores level information for a single card
@@ -43,12 +52,16 @@ func NewPlayerContextFromPlayer(player *clashroyale.Player) *PlayerContext {
 
 	ctx := &PlayerContext{
 		Arena:              &player.Arena,
-		ArenaID:            player.Arena.ID,
+		ArenaID:            ArenaNumber(player.Arena.ID),
 		ArenaName:          player.Arena.Name,
 		Collection:         make(map[string]CardLevelInfo),
 		UnlockedEvolutions: make(map[string]bool),
 		PlayerTag:          player.Tag,
 		PlayerName:         player.Name,
+		TowerTroop:         player.CurrentTowerTroop(),
+		TowerTroops:        player.TowerTroopNames(),
+		Trophies:           player.Trophies,
+		TrophyBand:         TrophyBandFor(player.Trophies),
 	}
 
 	// Build card collection map
@@ -71,6 +84,35 @@ func NewPlayerContextFromPlayer(player *clashroyale.Player) *PlayerContext {
 	return ctx
 }
 
+// NewPlayerContextFromAnalysis builds a PlayerContext from a saved card
+// analysis. Analyses carry no trophies, so no trophy band is inferred.
+func NewPlayerContextFromAnalysis(analysis deck.CardAnalysis) *PlayerContext {
+	ctx := &PlayerContext{
+		ArenaID:            ArenaNumber(analysis.ArenaID),
+		Collection:         make(map[string]CardLevelInfo, len(analysis.CardLevels)),
+		UnlockedEvolutions: make(map[string]bool),
+		PlayerTag:          analysis.PlayerTag,
+		PlayerName:         analysis.PlayerName,
+		TowerTroops:        analysis.TowerTroops,
+	}
+	if ctx.ArenaID > 0 {
+		ctx.ArenaName = ArenaName(ctx.ArenaID)
+	}
+	for name, data := range analysis.CardLevels {
+		ctx.Collection[name] = CardLevelInfo{
+			Level:             data.Level,
+			MaxLevel:          data.MaxLevel,
+			EvolutionLevel:    data.EvolutionLevel,
+			MaxEvolutionLevel: data.MaxEvolutionLevel,
+			Rarity:            data.Rarity,
+		}
+		if data.EvolutionLevel > 0 {
+			ctx.UnlockedEvolutions[name] = true
+		}
+	}
+	return ctx
+}
+
 // GetCardLevel returns the level of a card in the player's collection
 // Returns 0 if the card is not in the collection
 func (ctx *PlayerContext) GetCardLevel(cardName string) int {
@@ -121,16 +163,44 @@ func (ctx *PlayerContext) GetAverageLevel(cardNames []string) float64 {
 // Returns true if the player's arena level is >= the card's unlock arena
 // Returns true if ArenaID is 0 (no arena restrictions - training mode)
 func (ctx *PlayerContext) IsCardUnlockedInArena(cardName string) bool {
-	// No arena restrictions if ArenaID is 0
-	if ctx.ArenaID == 0 {
+	if ctx == nil {
 		return true
 	}
+	return IsCardUnlockedAt(cardName, ctx.ArenaID)
+}
+
+// apiArenaIDBase is the offset of arena IDs in API responses, where Training
+// Camp is 54000000 and Goblin Stadium 54000001
+const apiArenaIDBase = 54000000
 
-	// Get the arena where this card unlocks
-	unlockArena := getCardUnlockArena(cardName)
+// ArenaNumber converts an API arena ID such as 54000008 to its arena number
+// (8). Arena numbers pass through unchanged.
+func ArenaNumber(arenaID int) int {
+	if arenaID >= apiArenaIDBase {
+		return arenaID - apiArenaIDBase
+	}
+	return arenaID
+}
 
-	// Card is unlocked if player has reached or passed the unlock arena
-	return ctx.ArenaID >= unlockArena
+// IsCardUnlockedAt reports whether a card is unlocked by arena number arena.
+// Arena 0 means no restriction.
+func IsCardUnlockedAt(cardName string, arena int) bool {
+	if arena == 0 {
+		return true
+	}
+	return arena >= CardUnlockArena(cardName)
+}
+
+// LockedCards returns the cards in names not yet unlocked at the player's
+// arena, in their original order
+func (ctx *PlayerContext) LockedCards(names []string) []string {
+	var locked []string
+	for _, name := range names {
+		if !ctx.IsCardUnlockedInArena(name) {
+			locked = append(locked, name)
+		}
+	}
+	return locked
 }
 
 // CalculateUpgradeGap calculates how many levels a deck is below max
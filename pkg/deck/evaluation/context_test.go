@@ -321,6 +321,32 @@ func TestPlayerContext_IsCardUnlockedInArena(t *testing.T) {
 	}
 }
 
+func TestArenaNumber(t *testing.T) {
+	tests := map[int]int{0: 0, 8: 8, 54000000: 0, 54000008: 8, 54000031: 31}
+	for id, want := range tests {
+		if got := ArenaNumber(id); got != want {
+			t.Errorf("ArenaNumber(%d) = %d, want %d", id, got, want)
+		}
+	}
+}
+
+func TestPlayerContext_LockedCards(t *testing.T) {
+	ctx := makeTestPlayerContext()
+	locked := ctx.LockedCards([]string{"Royal Ghost", "Giant", "Electro Wizard", "Mega Knight"})
+	if !slices.Equal(locked, []string{"Royal Ghost", "Mega Knight"}) {
+		t.Errorf("LockedCards = %v, want Royal Ghost and Mega Knight", locked)
+	}
+	var none *PlayerContext
+	if locked := none.LockedCards([]string{"Royal Ghost"}); locked != nil {
+		t.Errorf("nil context LockedCards = %v, want none", locked)
+	}
+
+	fromAPI := NewPlayerContextFromPlayer(&clashroyale.Player{Arena: clashroyale.Arena{ID: 54000008}})
+	if fromAPI.ArenaID != 8 || fromAPI.IsCardUnlockedInArena("Royal Ghost") {
+		t.Errorf("API arena ID normalized to %d, want 8 with Royal Ghost locked", fromAPI.ArenaID)
+	}
+}
+
 func TestPlayerContext_CalculateUpgradeGap(t *testing.T) {
 	tests := []struct {
 		name        string
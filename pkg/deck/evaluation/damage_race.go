@@ -0,0 +1,233 @@
+package evaluation
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+// ============================================================================
+// Damage Race Analysis
+// ============================================================================
+
+const (
+	// damageRaceReferenceLevel is the level the crown tower damage and tower HP
+	// tables below are expressed at (tournament standard).
+	damageRaceReferenceLevel = 11
+	// damageRaceMaxDisplayLevel normalizes per-rarity API levels onto one scale.
+	damageRaceMaxDisplayLevel = 14
+	// damageRaceLevelGrowth approximates the per-level stat increase for both
+	// spells and towers.
+	damageRaceLevelGrowth = 1.10
+
+	// princessTowerHPReference is princess tower HP at the reference level.
+	princessTowerHPReference = 3052
+
+	// Overtime runs at double elixir: one elixir every 1.4 seconds.
+	overtimeSecondsPerElixir = 1.4
+	overtimeWindowSeconds    = 120.0
+
+	// spellRotationPlays is how many cards must be played before a card returns
+	// to hand: the card itself plus the four cards queued behind it.
+	spellRotationPlays = 5
+
+	// overtimeTiebreakerThreshold is the share of a princess tower a deck must be
+	// able to chip with spells alone during overtime to hold its own in a
+	// tiebreaker.
+	overtimeTiebreakerThreshold = 0.35
+)
+
+// spellTowerDamage is approximate crown tower damage at the reference level
+// for spells that are regularly cycled into towers.
+var spellTowerDamage = map[string]float64{
+	"Rocket":           484,
+	"Lightning":        335,
+	"Fireball":         207,
+	"Poison":           184,
+	"Earthquake":       159,
+	"Royal Delivery":   103,
+	"Arrows":           93,
+	"The Log":          58,
+	"Zap":              58,
+	"Giant Snowball":   58,
+	"Barbarian Barrel": 48,
+}
+
+// DamageRaceSpell is one tower-damage spell in a deck, scaled to its level
+type DamageRaceSpell struct {
+	Name        string
+	Elixir      int
+	TowerDamage float64
+}
+
+// DamageRaceMetrics summarizes how fast a deck can chip towers with spells alone
+type DamageRaceMetrics struct {
+	Spells []DamageRaceSpell
+	// RotationElixir is the elixir spent per spell rotation (all spells plus the
+	// cheapest cards needed to cycle back to them)
+	RotationElixir int
+	// RotationDamage is crown tower damage dealt per rotation
+	RotationDamage float64
+	// RotationSeconds is the time needed to afford one rotation in overtime
+	RotationSeconds float64
+	// DamagePerSecond is sustained spell-only tower damage during overtime
+	DamagePerSecond float64
+	// TowerHP is princess tower HP at the assumed tower level
+	TowerHP float64
+	// OvertimeDamage is spell damage achievable across the overtime window
+	OvertimeDamage float64
+	// SecondsToTower is the time needed to take a full princess tower, or 0
+	// when the deck has no tower-damage spells
+	SecondsToTower float64
+}
+
+// OvertimeTowerShare returns the share of a princess tower chipped during overtime
+func (m DamageRaceMetrics) OvertimeTowerShare() float64 {
+	if m.TowerHP <= 0 {
+		return 0
+	}
+	return m.OvertimeDamage / m.TowerHP
+}
+
+// LosesTiebreakers reports whether the deck's spell chip is too slow to win
+// overtime damage races.
+func (m DamageRaceMetrics) LosesTiebreakers() bool {
+	return m.OvertimeTowerShare() < overtimeTiebreakerThreshold
+}
+
+// CalculateDamageRaceMetrics computes spell-cycle tower damage for a deck.
+// Opponent towers are assumed to match the deck's highest card level, which
+// approximates the player's king level.
+func CalculateDamageRaceMetrics(deckCards []deck.CardCandidate, playerContext *PlayerContext) DamageRaceMetrics {
+	towerLevel := 0
+	levels := make([]int, len(deckCards))
+	for i, card := range deckCards {
+		levels[i] = damageRaceDisplayLevel(card, playerContext)
+		towerLevel = max(towerLevel, levels[i])
+	}
+
+	metrics := DamageRaceMetrics{
+		TowerHP: princessTowerHPReference * damageRaceLevelScale(towerLevel),
+	}
+
+	var others []int
+	for i, card := range deckCards {
+		base, isSpell := spellTowerDamage[card.Name]
+		if !isSpell {
+			others = append(others, card.Elixir)
+			continue
+		}
+		metrics.Spells = append(metrics.Spells, DamageRaceSpell{
+			Name:        card.Name,
+			Elixir:      card.Elixir,
+			TowerDamage: base * damageRaceLevelScale(levels[i]),
+		})
+	}
+	if len(metrics.Spells) == 0 {
+		return metrics
+	}
+
+	// Strongest spells first so a deck with more spells than a rotation can
+	// hold keeps the ones that matter.
+	sort.SliceStable(metrics.Spells, func(i, j int) bool {
+		return metrics.Spells[i].TowerDamage > metrics.Spells[j].TowerDamage
+	})
+	if len(metrics.Spells) > spellRotationPlays {
+		metrics.Spells = metrics.Spells[:spellRotationPlays]
+	}
+
+	for _, spell := range metrics.Spells {
+		metrics.RotationElixir += spell.Elixir
+		metrics.RotationDamage += spell.TowerDamage
+	}
+	sort.Ints(others)
+	for i := 0; i < spellRotationPlays-len(metrics.Spells) && i < len(others); i++ {
+		metrics.RotationElixir += others[i]
+	}
+
+	metrics.RotationSeconds = float64(metrics.RotationElixir) * overtimeSecondsPerElixir
+	if metrics.RotationSeconds > 0 {
+		metrics.DamagePerSecond = metrics.RotationDamage / metrics.RotationSeconds
+	}
+	metrics.OvertimeDamage = metrics.DamagePerSecond * overtimeWindowSeconds
+	if metrics.DamagePerSecond > 0 {
+		metrics.SecondsToTower = metrics.TowerHP / metrics.DamagePerSecond
+	}
+	return metrics
+}
+
+// BuildDamageRaceAnalysis evaluates whether a deck can win overtime damage
+// races by cycling spells into a tower.
+func BuildDamageRaceAnalysis(deckCards []deck.CardCandidate, playerContext *PlayerContext) AnalysisSection {
+	metrics := CalculateDamageRaceMetrics(deckCards, playerContext)
+	share := metrics.OvertimeTowerShare()
+	score := clampScoreToTen(share * 10.0)
+
+	details := []string{}
+	if len(metrics.Spells) == 0 {
+		details = append(details, "No tower-damage spells: overtime chip relies entirely on troops connecting")
+		details = append(details, "⚠️ Likely to lose overtime tiebreakers against spell cycle decks")
+		return AnalysisSection{
+			Title:   "Damage Race Analysis",
+			Summary: "No spell chip damage - loses overtime damage races",
+			Details: details,
+			Score:   0,
+			Rating:  ScoreToRating(0),
+		}
+	}
+
+	spellParts := make([]string, 0, len(metrics.Spells))
+	for _, spell := range metrics.Spells {
+		spellParts = append(spellParts, fmt.Sprintf("%s (%.0f)", spell.Name, spell.TowerDamage))
+	}
+	details = append(details, fmt.Sprintf("Tower damage spells: %s", strings.Join(spellParts, ", ")))
+	details = append(details, fmt.Sprintf("Spell rotation: %d elixir for %.0f tower damage (~%.0fs in double elixir)",
+		metrics.RotationElixir, metrics.RotationDamage, metrics.RotationSeconds))
+	details = append(details, fmt.Sprintf("Spell cycle DPS: %.1f vs princess tower HP %.0f",
+		metrics.DamagePerSecond, metrics.TowerHP))
+	details = append(details, fmt.Sprintf("Overtime chip: %.0f damage in %.0fs (%.0f%% of a princess tower)",
+		metrics.OvertimeDamage, overtimeWindowSeconds, share*100))
+	details = append(details, fmt.Sprintf("Time to take a full princess tower with spells alone: ~%.0fs",
+		metrics.SecondsToTower))
+	if metrics.LosesTiebreakers() {
+		details = append(details, "⚠️ Likely to lose overtime tiebreakers: spell chip is too slow to close out a damage race")
+	}
+
+	summary := "Moderate spell chip - can contest close overtime races"
+	switch {
+	case share >= 1.0:
+		summary = "Can spell cycle a full princess tower in overtime"
+	case metrics.LosesTiebreakers():
+		summary = "Weak spell chip - loses most overtime damage races"
+	}
+
+	return AnalysisSection{
+		Title:   "Damage Race Analysis",
+		Summary: summary,
+		Details: details,
+		Score:   score,
+		Rating:  ScoreToRating(score),
+	}
+}
+
+// damageRaceDisplayLevel returns a card's level on the shared display scale,
+// preferring collection data from the player context when available.
+func damageRaceDisplayLevel(card deck.CardCandidate, playerContext *PlayerContext) int {
+	level, maxLevel := card.Level, card.MaxLevel
+	if playerContext != nil {
+		if info, ok := playerContext.Collection[card.Name]; ok && info.MaxLevel > 0 {
+			level, maxLevel = info.Level, info.MaxLevel
+		}
+	}
+	if maxLevel <= 0 || level <= 0 {
+		return damageRaceReferenceLevel
+	}
+	return level + (damageRaceMaxDisplayLevel - maxLevel)
+}
+
+func damageRaceLevelScale(level int) float64 {
+	return math.Pow(damageRaceLevelGrowth, float64(level-damageRaceReferenceLevel))
+}
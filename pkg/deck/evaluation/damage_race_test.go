@@ -0,0 +1,106 @@
+package evaluation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+func rocketCycleDeck(rocketLevel int) []deck.CardCandidate {
+	return []deck.CardCandidate{
+		makeCard("Rocket", deck.RoleSpellBig, rocketLevel, 14, "Common", 6),
+		makeCard("The Log", deck.RoleSpellSmall, 14, 14, "Common", 2),
+		makeCard("Ice Spirit", deck.RoleCycle, 14, 14, "Common", 1),
+		makeCard("Skeletons", deck.RoleCycle, 14, 14, "Common", 1),
+		makeCard("Ice Golem", deck.RoleCycle, 14, 14, "Rare", 2),
+		makeCard("Cannon", deck.RoleBuilding, 14, 14, "Common", 3),
+		makeCard("Musketeer", deck.RoleSupport, 14, 14, "Rare", 4),
+		makeCard("Hog Rider", deck.RoleWinCondition, 14, 14, "Rare", 4),
+	}
+}
+
+func TestCalculateDamageRaceMetricsRocketCycle(t *testing.T) {
+	metrics := CalculateDamageRaceMetrics(rocketCycleDeck(14), nil)
+
+	if len(metrics.Spells) != 2 || metrics.Spells[0].Name != "Rocket" {
+		t.Fatalf("expected Rocket first of 2 spells, got %+v", metrics.Spells)
+	}
+	// Rocket + Log + the three cheapest other cards (1 + 1 + 2)
+	if metrics.RotationElixir != 12 {
+		t.Errorf("RotationElixir = %d, want 12", metrics.RotationElixir)
+	}
+	if metrics.LosesTiebreakers() {
+		t.Errorf("rocket cycle should not lose tiebreakers (share %.2f)", metrics.OvertimeTowerShare())
+	}
+	if metrics.OvertimeTowerShare() < 1.0 {
+		t.Errorf("rocket cycle should chip a full tower in overtime, got share %.2f", metrics.OvertimeTowerShare())
+	}
+}
+
+func TestCalculateDamageRaceMetricsUnderleveledSpell(t *testing.T) {
+	full := CalculateDamageRaceMetrics(rocketCycleDeck(14), nil)
+	under := CalculateDamageRaceMetrics(rocketCycleDeck(11), nil)
+
+	if under.RotationDamage >= full.RotationDamage {
+		t.Errorf("under-leveled rocket should deal less damage: %.0f >= %.0f", under.RotationDamage, full.RotationDamage)
+	}
+	if under.TowerHP != full.TowerHP {
+		t.Errorf("tower HP should follow the highest card level: %.0f != %.0f", under.TowerHP, full.TowerHP)
+	}
+}
+
+func TestBuildDamageRaceAnalysisNoSpells(t *testing.T) {
+	cards := []deck.CardCandidate{
+		makeCard("Golem", deck.RoleWinCondition, 11, 11, "Epic", 8),
+		makeCard("Night Witch", deck.RoleSupport, 11, 11, "Legendary", 4),
+		makeCard("Baby Dragon", deck.RoleSupport, 11, 11, "Epic", 4),
+		makeCard("Mega Minion", deck.RoleSupport, 11, 11, "Rare", 3),
+		makeCard("Lumberjack", deck.RoleSupport, 11, 11, "Legendary", 4),
+		makeCard("Dark Prince", deck.RoleSupport, 11, 11, "Epic", 4),
+		makeCard("Skeletons", deck.RoleCycle, 11, 11, "Common", 1),
+		makeCard("Tornado", deck.RoleSpellBig, 11, 11, "Epic", 3),
+	}
+
+	section := BuildDamageRaceAnalysis(cards, nil)
+	if section.Score != 0 {
+		t.Errorf("Score = %.2f, want 0", section.Score)
+	}
+	if !strings.Contains(strings.Join(section.Details, "\n"), "lose overtime tiebreakers") {
+		t.Errorf("expected tiebreaker warning, got %v", section.Details)
+	}
+}
+
+func TestBuildDamageRaceAnalysisFlagsSlowChip(t *testing.T) {
+	cards := []deck.CardCandidate{
+		makeCard("Golem", deck.RoleWinCondition, 11, 11, "Epic", 8),
+		makeCard("Night Witch", deck.RoleSupport, 11, 11, "Legendary", 4),
+		makeCard("Baby Dragon", deck.RoleSupport, 11, 11, "Epic", 4),
+		makeCard("Mega Minion", deck.RoleSupport, 11, 11, "Rare", 3),
+		makeCard("Lumberjack", deck.RoleSupport, 11, 11, "Legendary", 4),
+		makeCard("Dark Prince", deck.RoleSupport, 11, 11, "Epic", 4),
+		makeCard("Tornado", deck.RoleSpellBig, 11, 11, "Epic", 3),
+		makeCard("Zap", deck.RoleSpellSmall, 11, 11, "Common", 2),
+	}
+
+	section := BuildDamageRaceAnalysis(cards, nil)
+	if section.Title != "Damage Race Analysis" {
+		t.Errorf("Title = %q", section.Title)
+	}
+	if section.Score >= 3.5 {
+		t.Errorf("Zap-only chip should score low, got %.2f", section.Score)
+	}
+	if !strings.Contains(section.Summary, "Weak spell chip") {
+		t.Errorf("Summary = %q", section.Summary)
+	}
+}
+
+func TestEvaluateIncludesDamageRaceAnalysis(t *testing.T) {
+	result := Evaluate(rocketCycleDeck(14), nil, nil)
+	if result.DamageRaceAnalysis.Title == "" {
+		t.Fatal("expected damage race analysis in evaluation result")
+	}
+	if result.DamageRaceAnalysis.Score < 9.0 {
+		t.Errorf("rocket cycle damage race score = %.2f, want >= 9", result.DamageRaceAnalysis.Score)
+	}
+}
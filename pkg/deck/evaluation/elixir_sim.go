@@ -0,0 +1,281 @@
+package evaluation
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+	"slices"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+// ============================================================================
+// Elixir Curve Simulation
+// ============================================================================
+
+const (
+	// secondsPerElixir is the single elixir generation rate
+	secondsPerElixir = 2.8
+
+	// startingElixir and maxElixir bound each player's elixir bar
+	startingElixir = 5.0
+	maxElixir      = 10.0
+
+	// defaultElixirSimRuns keeps the simulation cheap enough to run on
+	// every evaluation
+	defaultElixirSimRuns = 20
+)
+
+// ElixirPhase is a stretch of the game with one elixir generation rate
+type ElixirPhase struct {
+	Name       string  `json:"name"`
+	Multiplier float64 `json:"multiplier"`
+	// Start and End are seconds from the start of the game
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
+
+// DefaultElixirPhases is a full game: two minutes of single elixir, the
+// final regulation minute and first overtime minute at double elixir, and
+// the last overtime minute at triple elixir.
+var DefaultElixirPhases = []ElixirPhase{
+	{Name: "single", Multiplier: 1, Start: 0, End: 120},
+	{Name: "double", Multiplier: 2, Start: 120, End: 240},
+	{Name: "triple", Multiplier: 3, Start: 240, End: 300},
+}
+
+// ElixirSimOptions configures SimulateElixirCurve
+type ElixirSimOptions struct {
+	// Runs is the number of simulated games (default 20)
+	Runs int
+	// Seed fixes the random stream; 0 derives it from the deck so the same
+	// deck always simulates the same way
+	Seed uint64
+	// Phases overrides DefaultElixirPhases
+	Phases []ElixirPhase
+}
+
+// ElixirPhaseStats are the simulation results for one elixir phase
+type ElixirPhaseStats struct {
+	Phase      string  `json:"phase"`
+	Multiplier float64 `json:"multiplier"`
+	// CycleSeconds is the average time between win condition plays that
+	// start in this phase; 0 when none were observed
+	CycleSeconds float64 `json:"cycle_seconds"`
+	// AvgFloat is the time-weighted average of unspent elixir
+	AvgFloat float64 `json:"avg_float"`
+	Cycles   int     `json:"cycles"`
+}
+
+// ElixirSimResult summarizes simulated games of a deck
+type ElixirSimResult struct {
+	Runs         int    `json:"runs"`
+	WinCondition string `json:"win_condition,omitempty"`
+	// FirstPlaySeconds is the average time until the win condition is
+	// first played
+	FirstPlaySeconds float64            `json:"first_play_seconds"`
+	AvgFloat         float64            `json:"avg_float"`
+	Phases           []ElixirPhaseStats `json:"phases"`
+}
+
+// Phase returns the stats of the named phase
+func (r ElixirSimResult) Phase(name string) (ElixirPhaseStats, bool) {
+	i := slices.IndexFunc(r.Phases, func(p ElixirPhaseStats) bool { return p.Phase == name })
+	if i < 0 {
+		return ElixirPhaseStats{}, false
+	}
+	return r.Phases[i], true
+}
+
+// SimulateElixirCurve plays Monte Carlo games with deckCards to measure how
+// long the deck takes to cycle back to its win condition and how much
+// elixir it floats. Each game deals a shuffled deck into a four-card hand,
+// then repeatedly plays the win condition when it is in hand, or else a
+// random card from hand, as soon as it is affordable. Played cards go to
+// the back of the queue. No opponent is modeled.
+func SimulateElixirCurve(deckCards []deck.CardCandidate, opts ElixirSimOptions) ElixirSimResult {
+	runs := opts.Runs
+	if runs <= 0 {
+		runs = defaultElixirSimRuns
+	}
+	phases := opts.Phases
+	if len(phases) == 0 {
+		phases = DefaultElixirPhases
+	}
+	seed := opts.Seed
+	if seed == 0 {
+		seed = elixirSimSeed(deckCards)
+	}
+
+	result := ElixirSimResult{Runs: runs, Phases: make([]ElixirPhaseStats, len(phases))}
+	for i, phase := range phases {
+		result.Phases[i] = ElixirPhaseStats{Phase: phase.Name, Multiplier: phase.Multiplier}
+	}
+	if len(deckCards) <= openingHandSize {
+		return result
+	}
+
+	// Shuffle from name order so card order doesn't change the results
+	cards := slices.SortedFunc(slices.Values(deckCards), func(a, b deck.CardCandidate) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	winCondition := -1
+	if i := slices.IndexFunc(deckCards, func(c deck.CardCandidate) bool {
+		return hasRole(c, deck.RoleWinCondition)
+	}); i >= 0 {
+		result.WinCondition = deckCards[i].Name
+		winCondition = slices.IndexFunc(cards, func(c deck.CardCandidate) bool { return c.Name == result.WinCondition })
+	}
+
+	sim := elixirSim{
+		cards:        cards,
+		phases:       phases,
+		winCondition: winCondition,
+		rng:          rand.New(rand.NewPCG(seed, uint64(len(deckCards)))),
+		cycleTotals:  make([]float64, len(phases)),
+		floatTotals:  make([]float64, len(phases)),
+		cycleCounts:  make([]int, len(phases)),
+		order:        make([]int, len(cards)),
+	}
+	for i := range sim.order {
+		sim.order[i] = i
+	}
+	firstPlays := 0
+	firstPlayTotal := 0.0
+	for range runs {
+		if first, ok := sim.playGame(); ok {
+			firstPlayTotal += first
+			firstPlays++
+		}
+	}
+
+	gameLength := phases[len(phases)-1].End - phases[0].Start
+	floatTotal := 0.0
+	for i, phase := range phases {
+		stats := &result.Phases[i]
+		stats.Cycles = sim.cycleCounts[i]
+		if stats.Cycles > 0 {
+			stats.CycleSeconds = sim.cycleTotals[i] / float64(stats.Cycles)
+		}
+		if length := phase.End - phase.Start; length > 0 {
+			stats.AvgFloat = sim.floatTotals[i] / (length * float64(runs))
+		}
+		floatTotal += sim.floatTotals[i]
+	}
+	if gameLength > 0 {
+		result.AvgFloat = floatTotal / (gameLength * float64(runs))
+	}
+	if firstPlays > 0 {
+		result.FirstPlaySeconds = firstPlayTotal / float64(firstPlays)
+	}
+	return result
+}
+
+// elixirSim accumulates per-phase totals across simulated games
+type elixirSim struct {
+	cards        []deck.CardCandidate
+	phases       []ElixirPhase
+	winCondition int
+	rng          *rand.Rand
+	order        []int
+
+	cycleTotals []float64
+	floatTotals []float64
+	cycleCounts []int
+}
+
+// playGame simulates one game and returns when the win condition was first
+// played, if it was
+func (s *elixirSim) playGame() (float64, bool) {
+	// The first four cards are the hand; the rest is a queue read from head
+	s.rng.Shuffle(len(s.order), func(i, j int) { s.order[i], s.order[j] = s.order[j], s.order[i] })
+	hand, queue := s.order[:openingHandSize], s.order[openingHandSize:]
+	head := 0
+
+	now := s.phases[0].Start
+	elixir := startingElixir
+	lastWinCondition := -1.0
+	firstPlay, played := 0.0, false
+
+	for {
+		slot := slices.Index(hand, s.winCondition)
+		if slot < 0 {
+			slot = s.rng.IntN(len(hand))
+		}
+		card := hand[slot]
+		cost := min(float64(max(s.cards[card].Elixir, 1)), maxElixir)
+
+		var ok bool
+		if now, elixir, ok = s.waitFor(now, elixir, cost); !ok {
+			return firstPlay, played
+		}
+		elixir -= cost
+
+		if card == s.winCondition {
+			if lastWinCondition >= 0 {
+				phase := s.phaseAt(lastWinCondition)
+				s.cycleTotals[phase] += now - lastWinCondition
+				s.cycleCounts[phase]++
+			} else {
+				firstPlay, played = now, true
+			}
+			lastWinCondition = now
+		}
+
+		hand[slot] = queue[head]
+		queue[head] = card
+		head = (head + 1) % len(queue)
+	}
+}
+
+// waitFor advances time until elixir reaches cost, which must be at most
+// maxElixir, adding the elixir held along the way to the float totals. It
+// reports false when the game ends first.
+func (s *elixirSim) waitFor(now, elixir, cost float64) (float64, float64, bool) {
+	for i, phase := range s.phases {
+		if now >= phase.End {
+			continue
+		}
+		rate := phase.Multiplier / secondsPerElixir
+		remaining := phase.End - now
+		if elixir >= cost {
+			return now, elixir, true
+		}
+
+		// Costs never exceed the cap, so elixir climbs linearly until the
+		// card is affordable or the phase ends
+		toCost := (cost - elixir) / rate
+		if toCost <= remaining {
+			s.floatTotals[i] += elixir*toCost + rate*toCost*toCost/2
+			return now + toCost, cost, true
+		}
+		s.floatTotals[i] += elixir*remaining + rate*remaining*remaining/2
+		elixir += rate * remaining
+		now = phase.End
+	}
+	return now, elixir, false
+}
+
+func (s *elixirSim) phaseAt(t float64) int {
+	for i, phase := range s.phases {
+		if t < phase.End {
+			return i
+		}
+	}
+	return len(s.phases) - 1
+}
+
+// elixirSimSeed derives a stable seed from the deck's card names
+func elixirSimSeed(deckCards []deck.CardCandidate) uint64 {
+	names := make([]string, len(deckCards))
+	for i, card := range deckCards {
+		names[i] = card.Name
+	}
+	slices.Sort(names)
+	h := fnv.New64a()
+	for _, name := range names {
+		h.Write([]byte(name))
+		h.Write([]byte{0})
+	}
+	return h.Sum64()
+}
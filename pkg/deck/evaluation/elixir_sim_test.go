@@ -0,0 +1,127 @@
+package evaluation
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+func golemBeatdownDeck() []deck.CardCandidate {
+	return []deck.CardCandidate{
+		makeCard("Golem", deck.RoleWinCondition, 11, 14, "Epic", 8),
+		makeCard("Night Witch", deck.RoleSupport, 11, 14, "Legendary", 4),
+		makeCard("Baby Dragon", deck.RoleSupport, 11, 14, "Epic", 4),
+		makeCard("Mega Minion", deck.RoleSupport, 11, 14, "Rare", 3),
+		makeCard("Lumberjack", deck.RoleSupport, 11, 14, "Legendary", 4),
+		makeCard("Electro Wizard", deck.RoleSupport, 11, 14, "Legendary", 4),
+		makeCard("Lightning", deck.RoleSpellBig, 11, 14, "Epic", 6),
+		makeCard("Tornado", deck.RoleSpellSmall, 11, 14, "Epic", 3),
+	}
+}
+
+func TestSimulateElixirCurveCycleTimes(t *testing.T) {
+	hog := SimulateElixirCurve(hogFireballDeck(), ElixirSimOptions{})
+	golem := SimulateElixirCurve(golemBeatdownDeck(), ElixirSimOptions{})
+
+	if hog.WinCondition != "Hog Rider" || golem.WinCondition != "Golem" {
+		t.Fatalf("win conditions = %q, %q", hog.WinCondition, golem.WinCondition)
+	}
+	if hog.Runs != defaultElixirSimRuns || len(hog.Phases) != len(DefaultElixirPhases) {
+		t.Fatalf("got %d runs and %d phases", hog.Runs, len(hog.Phases))
+	}
+	for i, phase := range hog.Phases {
+		if phase.Cycles == 0 || golem.Phases[i].Cycles == 0 {
+			t.Fatalf("%s: no cycles observed", phase.Phase)
+		}
+		if phase.CycleSeconds >= golem.Phases[i].CycleSeconds {
+			t.Errorf("%s: hog cycles in %.1fs, golem in %.1fs", phase.Phase, phase.CycleSeconds, golem.Phases[i].CycleSeconds)
+		}
+		if i > 0 && phase.CycleSeconds >= hog.Phases[i-1].CycleSeconds {
+			t.Errorf("%s cycle (%.1fs) not faster than %s (%.1fs)",
+				phase.Phase, phase.CycleSeconds, hog.Phases[i-1].Phase, hog.Phases[i-1].CycleSeconds)
+		}
+	}
+	if hog.AvgFloat <= 0 || hog.AvgFloat >= golem.AvgFloat || golem.AvgFloat > maxElixir {
+		t.Errorf("avg float hog %.2f, golem %.2f", hog.AvgFloat, golem.AvgFloat)
+	}
+	if hog.FirstPlaySeconds <= 0 || hog.FirstPlaySeconds >= golem.FirstPlaySeconds {
+		t.Errorf("first play hog %.1fs, golem %.1fs", hog.FirstPlaySeconds, golem.FirstPlaySeconds)
+	}
+}
+
+func TestSimulateElixirCurveIsStable(t *testing.T) {
+	cards := hogFireballDeck()
+	first := SimulateElixirCurve(cards, ElixirSimOptions{})
+
+	slices.Reverse(cards)
+	reversed := SimulateElixirCurve(cards, ElixirSimOptions{})
+	if !slices.Equal(first.Phases, reversed.Phases) || first.AvgFloat != reversed.AvgFloat {
+		t.Errorf("card order changed the results: %+v vs %+v", first.Phases, reversed.Phases)
+	}
+
+	reseeded := SimulateElixirCurve(cards, ElixirSimOptions{Seed: 7})
+	if slices.Equal(first.Phases, reseeded.Phases) {
+		t.Error("expected a different seed to change the results")
+	}
+}
+
+func TestSimulateElixirCurveWithoutWinCondition(t *testing.T) {
+	cards := hogFireballDeck()[1:]
+	cards = append(cards, makeCard("Knight", deck.RoleSupport, 11, 14, "Common", 3))
+
+	result := SimulateElixirCurve(cards, ElixirSimOptions{Runs: 5})
+	if result.WinCondition != "" || result.FirstPlaySeconds != 0 {
+		t.Errorf("result = %+v, want no win condition", result)
+	}
+	for _, phase := range result.Phases {
+		if phase.Cycles != 0 {
+			t.Errorf("%s: %d cycles without a win condition", phase.Phase, phase.Cycles)
+		}
+	}
+	if result.AvgFloat <= 0 {
+		t.Error("expected elixir float without a win condition")
+	}
+}
+
+func TestElixirSimWaitFor(t *testing.T) {
+	sim := elixirSim{
+		phases:      []ElixirPhase{{Name: "single", Multiplier: 1, Start: 0, End: 28}, {Name: "double", Multiplier: 2, Start: 28, End: 56}},
+		floatTotals: make([]float64, 2),
+	}
+
+	// Filling from 0 to 10 at single elixir takes the whole first phase
+	now, elixir, ok := sim.waitFor(0, 0, 10)
+	if !ok || now != 28 || elixir != 10 {
+		t.Fatalf("waitFor() = %v, %v, %v; want 28, 10, true", now, elixir, ok)
+	}
+	if math.Abs(sim.floatTotals[0]-140) > 1e-9 {
+		t.Errorf("float total = %v, want 140", sim.floatTotals[0])
+	}
+
+	// Double elixir fills twice as fast
+	if now, _, ok := sim.waitFor(28, 0, 10); !ok || now != 42 {
+		t.Errorf("waitFor() at double elixir = %v, %v; want 42, true", now, ok)
+	}
+
+	// The game ends 6 seconds in, short of the card's cost
+	sim.floatTotals[1] = 0
+	if _, _, ok := sim.waitFor(50, 0, 10); ok {
+		t.Error("expected the game to end before reaching 10 elixir")
+	}
+	if want := (2 / secondsPerElixir) * 36 / 2; math.Abs(sim.floatTotals[1]-want) > 1e-9 {
+		t.Errorf("float total = %v, want %v", sim.floatTotals[1], want)
+	}
+}
+
+func TestBuildCycleAnalysisUsesSimulation(t *testing.T) {
+	section := BuildCycleAnalysis(hogFireballDeck())
+	details := strings.Join(section.Details, "\n")
+	for _, want := range []string{"Rotation: returns to Hog Rider every ~", "at double elixir", "Average elixir float:"} {
+		if !strings.Contains(details, want) {
+			t.Errorf("details missing %q:\n%s", want, details)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package evaluation
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+// evolutionPlanBenefitWeight scales the evolution score an unlock adds across
+// the top decks (0-10 per deck) against the recommender's 0-100 score
+const evolutionPlanBenefitWeight = 2.0
+
+// EvolutionPlanDeck is one of the decks an evolution plan is measured against
+type EvolutionPlanDeck struct {
+	Label string   `json:"label"`
+	Cards []string `json:"cards"`
+	Score float64  `json:"score"`
+}
+
+// EvolutionDeckGain is how much unlocking an evolution raises one deck's
+// evolution analysis score
+type EvolutionDeckGain struct {
+	Deck int     `json:"deck"` // index into EvolutionPlan.Decks
+	Gain float64 `json:"gain"`
+}
+
+// EvolutionPlanStep is one evolution to unlock, in recommended spending order
+type EvolutionPlanStep struct {
+	deck.EvolutionRecommendation
+	TargetLevel   int                 `json:"target_level"`
+	ShardsMissing int                 `json:"shards_missing"`
+	Ready         bool                `json:"ready"`
+	DeckBenefit   float64             `json:"deck_benefit"`
+	DeckGains     []EvolutionDeckGain `json:"deck_gains,omitempty"`
+	Priority      float64             `json:"priority"`
+}
+
+// EvolutionPlan lists the evolutions a player can work toward and the order
+// to spend shards on them
+type EvolutionPlan struct {
+	Decks         []EvolutionPlanDeck `json:"decks"`
+	Steps         []EvolutionPlanStep `json:"steps"`
+	Ready         int                 `json:"ready"`
+	ShardsMissing int                 `json:"shards_missing"`
+}
+
+// PlanEvolutions ranks every evolution the player has not unlocked yet.
+// candidates is the player's collection; decks are the player's top decks,
+// whose cards must be in candidates. An evolution's priority is its
+// recommender score plus the evolution analysis score it adds to the decks
+// (BuildEvolutionAnalysis). Evolutions the player already has the shards for
+// come first.
+func PlanEvolutions(candidates []deck.CardCandidate, decks []EvolutionPlanDeck, recommender *deck.EvolutionRecommender) *EvolutionPlan {
+	byName := make(map[string]deck.CardCandidate, len(candidates))
+	pending := make([]deck.CardCandidate, 0, len(candidates))
+	for _, card := range candidates {
+		byName[card.Name] = card
+		if card.MaxEvolutionLevel > card.EvolutionLevel {
+			pending = append(pending, card)
+		}
+	}
+
+	deckCards := make([][]deck.CardCandidate, len(decks))
+	for i, d := range decks {
+		for _, name := range d.Cards {
+			if card, ok := byName[name]; ok {
+				deckCards[i] = append(deckCards[i], card)
+			}
+		}
+	}
+
+	plan := &EvolutionPlan{Decks: decks, Steps: []EvolutionPlanStep{}}
+	for _, rec := range recommender.Recommend(pending, 0) {
+		card := byName[rec.CardName]
+		step := EvolutionPlanStep{
+			EvolutionRecommendation: rec,
+			TargetLevel:             card.EvolutionLevel + 1,
+			ShardsMissing:           max(rec.ShardsNeeded-rec.CurrentShards, 0),
+		}
+		step.Ready = step.ShardsMissing == 0
+		for i, cards := range deckCards {
+			if gain := evolutionGain(cards, card.Name); gain > 0 {
+				step.DeckGains = append(step.DeckGains, EvolutionDeckGain{Deck: i, Gain: gain})
+				step.DeckBenefit += gain
+			}
+		}
+		step.Priority = rec.RecommendationScore + step.DeckBenefit*evolutionPlanBenefitWeight
+		plan.Steps = append(plan.Steps, step)
+	}
+
+	slices.SortStableFunc(plan.Steps, func(a, b EvolutionPlanStep) int {
+		if a.Ready != b.Ready {
+			if a.Ready {
+				return -1
+			}
+			return 1
+		}
+		if c := cmp.Compare(b.Priority, a.Priority); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.CardName, b.CardName)
+	})
+	for _, step := range plan.Steps {
+		if step.Ready {
+			plan.Ready++
+		}
+		plan.ShardsMissing += step.ShardsMissing
+	}
+	return plan
+}
+
+// evolutionGain is the evolution analysis score a deck gains when the named
+// card goes up one evolution level; 0 when the card is not in the deck
+func evolutionGain(cards []deck.CardCandidate, name string) float64 {
+	i := slices.IndexFunc(cards, func(c deck.CardCandidate) bool { return c.Name == name })
+	if i < 0 {
+		return 0
+	}
+	before := BuildEvolutionAnalysis(cards, nil).Score
+	evolved := slices.Clone(cards)
+	evolved[i].EvolutionLevel++
+	return max(BuildEvolutionAnalysis(evolved, nil).Score-before, 0)
+}
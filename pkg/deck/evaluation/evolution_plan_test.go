@@ -0,0 +1,52 @@
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+func TestPlanEvolutions(t *testing.T) {
+	candidates := []deck.CardCandidate{
+		{Name: "Knight", Level: 14, MaxLevel: 16, MaxEvolutionLevel: 1},
+		{Name: "Archers", Level: 10, MaxLevel: 16, MaxEvolutionLevel: 1},
+		{Name: "Bats", Level: 14, MaxLevel: 16, MaxEvolutionLevel: 1},
+		{Name: "Valkyrie", Level: 14, MaxLevel: 16, EvolutionLevel: 1, MaxEvolutionLevel: 1},
+		{Name: "Musketeer", Level: 14, MaxLevel: 16, MaxEvolutionLevel: 1},
+		{Name: "Hog Rider", Level: 14, MaxLevel: 16},
+	}
+	deck.ClassifyAllCandidates(candidates)
+	decks := []EvolutionPlanDeck{
+		{Label: "balanced", Cards: []string{"Knight", "Valkyrie", "Hog Rider"}},
+		{Label: "cycle", Cards: []string{"Knight", "Bats", "Hog Rider"}},
+	}
+	recommender := deck.NewEvolutionRecommender(map[string]int{"Archers": 10, "Knight": 4}, []string{"Musketeer"})
+
+	plan := PlanEvolutions(candidates, decks, recommender)
+
+	names := make([]string, len(plan.Steps))
+	for i, step := range plan.Steps {
+		names[i] = step.CardName
+	}
+	// Valkyrie is fully evolved, Musketeer is already unlocked, Hog Rider has no evolution
+	if len(plan.Steps) != 3 {
+		t.Fatalf("steps = %v, want Archers, Knight, Bats", names)
+	}
+	if names[0] != "Archers" || !plan.Steps[0].Ready || plan.Steps[0].ShardsMissing != 0 {
+		t.Errorf("first step = %+v, want the ready Archers evolution", plan.Steps[0])
+	}
+	if names[1] != "Knight" {
+		t.Errorf("order = %v, Knight is in both decks and should come before Bats", names)
+	}
+
+	knight := plan.Steps[1]
+	if len(knight.DeckGains) != 2 || knight.DeckBenefit <= 0 || knight.ShardsMissing != 6 || knight.TargetLevel != 1 {
+		t.Errorf("Knight step = %+v", knight)
+	}
+	if plan.Steps[0].DeckBenefit != 0 {
+		t.Errorf("Archers is in no deck but has benefit %.2f", plan.Steps[0].DeckBenefit)
+	}
+	if plan.Ready != 1 || plan.ShardsMissing != 6+10 {
+		t.Errorf("plan totals = ready %d, missing %d", plan.Ready, plan.ShardsMissing)
+	}
+}
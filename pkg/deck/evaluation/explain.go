@@ -0,0 +1,579 @@
+package evaluation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/interactions"
+)
+
+// ScoreContribution is one category's share of the base overall score
+type ScoreContribution struct {
+	Category string  `json:"category"`
+	Score    float64 `json:"score"`
+	Weight   float64 `json:"weight"`
+	Weighted float64 `json:"weighted"`
+	Rating   Rating  `json:"rating"`
+	Note     string  `json:"note,omitempty"`
+}
+
+// SynergyGrid is the pairwise synergy score (0.0-1.0) for every card pair in
+// a deck. Scores[i][j] is the synergy between Cards[i] and Cards[j].
+type SynergyGrid struct {
+	Cards  []string    `json:"cards"`
+	Scores [][]float64 `json:"scores"`
+}
+
+// Explanation breaks an EvaluationResult down into the pieces that produced
+// its overall score, for "why this score" reports
+type Explanation struct {
+	Deck                []string       `json:"deck"`
+	Mode                EvaluationMode `json:"mode"`
+	GameMode            GameMode       `json:"game_mode,omitempty"`
+	TrophyBand          TrophyBand     `json:"trophy_band,omitempty"`
+	AvgElixir           float64        `json:"average_elixir"`
+	Archetype           Archetype      `json:"archetype"`
+	ArchetypeConfidence float64        `json:"archetype_confidence"`
+
+	OverallScore  float64 `json:"overall_score"`
+	OverallRating Rating  `json:"overall_rating"`
+
+	// BaseScore is the weighted sum of Contributions before adjustments
+	BaseScore     float64             `json:"base_score"`
+	Contributions []ScoreContribution `json:"contributions"`
+
+	// Breakdown is present when player context adjusted the score
+	Breakdown          *OverallScoreBreakdown `json:"breakdown,omitempty"`
+	CriticalFlaws      []CriticalFlaw         `json:"critical_flaws,omitempty"`
+	MissingCardPenalty float64                `json:"missing_card_penalty,omitempty"`
+
+	// MetaCounter is present when the deck was scored against a MetaContext
+	MetaCounter *CategoryScore `json:"meta_counter,omitempty"`
+
+	// PluginScores are the registered scorer plugins' results
+	PluginScores []PluginScore `json:"plugin_scores,omitempty"`
+
+	// GameModeAdjustment is the overall score change from the game mode
+	GameModeAdjustment float64 `json:"game_mode_adjustment,omitempty"`
+
+	Sections      []AnalysisSection     `json:"sections"`
+	SynergyMatrix SynergyMatrix         `json:"synergy_matrix"`
+	SynergyGrid   *SynergyGrid          `json:"synergy_grid,omitempty"`
+	MissingCards  *MissingCardsAnalysis `json:"missing_cards,omitempty"`
+
+	// Interactions are the deck's spells checked against common spell
+	// targets; set by callers via SpellInteractions
+	Interactions []interactions.Check `json:"interactions,omitempty"`
+
+	// OpeningHands covers every starting hand; set by callers via
+	// AnalyzeOpeningHands
+	OpeningHands *OpeningHandReport `json:"opening_hands,omitempty"`
+}
+
+// Explain builds an Explanation for result. synergyDB is optional and adds
+// the full pairwise synergy grid.
+func Explain(result *EvaluationResult, synergyDB *deck.SynergyDatabase) Explanation {
+	playabilityNote := ""
+	if result.OverallBreakdown != nil {
+		playabilityNote = "replaced by ladder viability when player context is available"
+	}
+
+	weights := result.overallWeights()
+	contributions := []ScoreContribution{
+		newContribution("Attack", result.Attack, weights.Attack, ""),
+		newContribution("Defense", result.Defense, weights.Defense, ""),
+		newContribution("Synergy", result.Synergy, weights.Synergy, ""),
+		newContribution("Versatility", result.Versatility, weights.Versatility, ""),
+		newContribution("F2P Friendly", result.F2PFriendly, weights.F2P, ""),
+		newContribution("Playability", result.Playability, weights.Playability, playabilityNote),
+	}
+	baseScore := 0.0
+	for _, contribution := range contributions {
+		baseScore += contribution.Weighted
+	}
+
+	sections := []AnalysisSection{
+		result.AttackAnalysis,
+		result.DefenseAnalysis,
+		result.BaitAnalysis,
+		result.CycleAnalysis,
+		result.LadderAnalysis,
+		result.EvolutionAnalysis,
+		result.DamageRaceAnalysis,
+		result.OpeningHandAnalysis,
+	}
+	if result.MetaAnalysis != nil {
+		sections = append(sections, *result.MetaAnalysis)
+	}
+	nonEmpty := sections[:0]
+	for _, section := range sections {
+		if section.Title != "" {
+			nonEmpty = append(nonEmpty, section)
+		}
+	}
+
+	explanation := Explanation{
+		Deck:                result.Deck,
+		Mode:                result.Mode,
+		GameMode:            result.GameMode,
+		TrophyBand:          result.TrophyBand,
+		AvgElixir:           result.AvgElixir,
+		Archetype:           result.DetectedArchetype,
+		ArchetypeConfidence: result.ArchetypeConfidence,
+		OverallScore:        result.OverallScore,
+		OverallRating:       result.OverallRating,
+		BaseScore:           baseScore,
+		Contributions:       contributions,
+		Breakdown:           result.OverallBreakdown,
+		CriticalFlaws:       result.CriticalFlaws,
+		Sections:            nonEmpty,
+		SynergyMatrix:       result.SynergyMatrix,
+		MissingCards:        result.MissingCardsAnalysis,
+		MetaCounter:         result.MetaCounter,
+		PluginScores:        result.PluginScores,
+		GameModeAdjustment:  result.GameModeAdjustment,
+	}
+	if result.MissingCardsAnalysis != nil {
+		explanation.MissingCardPenalty = result.MissingCardsAnalysis.ScorePenalty
+	}
+	if synergyDB != nil {
+		explanation.SynergyGrid = buildSynergyGrid(result.Deck, synergyDB)
+	}
+	return explanation
+}
+
+func newContribution(name string, score CategoryScore, weight float64, note string) ScoreContribution {
+	return ScoreContribution{
+		Category: name,
+		Score:    score.Score,
+		Weight:   weight,
+		Weighted: score.Score * weight,
+		Rating:   score.Rating,
+		Note:     note,
+	}
+}
+
+func buildSynergyGrid(cards []string, synergyDB *deck.SynergyDatabase) *SynergyGrid {
+	scores := make([][]float64, len(cards))
+	for i := range cards {
+		scores[i] = make([]float64, len(cards))
+		for j := range cards {
+			if i != j {
+				scores[i][j] = synergyDB.GetSynergy(cards[i], cards[j])
+			}
+		}
+	}
+	return &SynergyGrid{Cards: cards, Scores: scores}
+}
+
+// FormatExplainText renders an Explanation as a plain-text report
+func FormatExplainText(e *Explanation) string {
+	var out strings.Builder
+
+	out.WriteString("WHY THIS SCORE\n")
+	out.WriteString("══════════════\n\n")
+	out.WriteString(fmt.Sprintf("Deck: %s\n", strings.Join(e.Deck, ", ")))
+	out.WriteString(fmt.Sprintf("Archetype: %s (%.0f%% confidence), avg elixir %.2f\n",
+		e.Archetype, e.ArchetypeConfidence*100, e.AvgElixir))
+	if e.GameMode != "" {
+		out.WriteString(fmt.Sprintf("Game mode: %s\n", LookupGameMode(e.GameMode).Name))
+	}
+	if band, ok := LookupTrophyBand(e.TrophyBand); ok {
+		out.WriteString(fmt.Sprintf("Trophy band: %s (%s)\n", band.Name, band.Range()))
+	}
+	out.WriteString(fmt.Sprintf("Overall: %.2f/10 (%s)\n\n", e.OverallScore, e.OverallRating))
+
+	out.WriteString("Score Build-Up\n")
+	out.WriteString("──────────────\n")
+	for _, c := range e.Contributions {
+		out.WriteString(fmt.Sprintf("  %-13s %5.2f × %.2f = %5.2f", c.Category, c.Score, c.Weight, c.Weighted))
+		if c.Note != "" {
+			out.WriteString("  (" + c.Note + ")")
+		}
+		out.WriteString("\n")
+	}
+	out.WriteString(fmt.Sprintf("  %-13s %24.2f\n", "Base score", e.BaseScore))
+	for _, line := range adjustmentLines(e) {
+		out.WriteString("  " + line + "\n")
+	}
+	out.WriteString(fmt.Sprintf("  %-13s %24.2f\n\n", "Final score", e.OverallScore))
+
+	out.WriteString("Critical Flaws\n")
+	out.WriteString("──────────────\n")
+	if len(e.CriticalFlaws) == 0 {
+		out.WriteString("  None\n")
+	}
+	for _, flaw := range e.CriticalFlaws {
+		out.WriteString(fmt.Sprintf("  -%.2f  %s\n", flaw.Penalty, flaw.Description))
+	}
+	out.WriteString("\n")
+
+	for _, section := range e.Sections {
+		out.WriteString(fmt.Sprintf("%s (%.1f/10, %s)\n", section.Title, section.Score, section.Rating))
+		out.WriteString(strings.Repeat("─", len([]rune(section.Title))) + "\n")
+		if section.Summary != "" {
+			out.WriteString("  " + section.Summary + "\n")
+		}
+		for _, detail := range section.Details {
+			out.WriteString("  • " + detail + "\n")
+		}
+		out.WriteString("\n")
+	}
+
+	if e.OpeningHands != nil && len(e.OpeningHands.Hands) > 0 {
+		out.WriteString("Opening Hands\n")
+		out.WriteString("─────────────\n")
+		for _, line := range openingHandSummaryLines(e.OpeningHands) {
+			out.WriteString("  " + line + "\n")
+		}
+		worst := worstOpeningHands(e.OpeningHands)
+		width := len("Worst hands")
+		for _, hand := range worst {
+			width = max(width, len(strings.Join(hand.Cards, ", ")))
+		}
+		out.WriteString(fmt.Sprintf("\n  %-*s %5s %-9s %s\n", width, "Worst hands", "Score", "Anti-air", "Safe plays"))
+		for _, hand := range worst {
+			out.WriteString(fmt.Sprintf("  %-*s %5.0f %-9s %s\n", width, strings.Join(hand.Cards, ", "), hand.Score,
+				yesNo(hand.HasAntiAir), joinOrDash(hand.SafePlays)))
+		}
+		out.WriteString("\n")
+	}
+
+	if len(e.Interactions) > 0 {
+		out.WriteString("Spell Interactions\n")
+		out.WriteString("──────────────────\n")
+		for _, line := range interactionLines(e.Interactions) {
+			out.WriteString("  " + line + "\n")
+		}
+		out.WriteString("\n")
+	}
+
+	out.WriteString("Synergy\n")
+	out.WriteString("───────\n")
+	out.WriteString(fmt.Sprintf("  %d/%d pairs, %.0f%% card coverage\n",
+		e.SynergyMatrix.PairCount, e.SynergyMatrix.MaxPossiblePairs, e.SynergyMatrix.SynergyCoverage))
+	for _, pair := range e.SynergyMatrix.Pairs {
+		out.WriteString(fmt.Sprintf("  %s + %s (%.0f%%): %s\n", pair.Card1, pair.Card2, pair.Score*100, pair.Description))
+	}
+	if e.SynergyGrid != nil {
+		out.WriteString("\n")
+		out.WriteString(formatSynergyGridText(e.SynergyGrid))
+	}
+	out.WriteString("\n")
+
+	if e.MissingCards != nil {
+		out.WriteString(FormatMissingCardsReport(e.MissingCards))
+	}
+
+	return out.String()
+}
+
+// FormatExplainMarkdown renders an Explanation as a Markdown report
+func FormatExplainMarkdown(e *Explanation) string {
+	var out strings.Builder
+
+	out.WriteString("# Why this score\n\n")
+	out.WriteString(fmt.Sprintf("**Deck:** %s  \n", strings.Join(e.Deck, ", ")))
+	out.WriteString(fmt.Sprintf("**Archetype:** %s (%.0f%% confidence)  \n", e.Archetype, e.ArchetypeConfidence*100))
+	out.WriteString(fmt.Sprintf("**Average elixir:** %.2f  \n", e.AvgElixir))
+	if e.GameMode != "" {
+		out.WriteString(fmt.Sprintf("**Game mode:** %s  \n", LookupGameMode(e.GameMode).Name))
+	}
+	if band, ok := LookupTrophyBand(e.TrophyBand); ok {
+		out.WriteString(fmt.Sprintf("**Trophy band:** %s (%s)  \n", band.Name, band.Range()))
+	}
+	out.WriteString(fmt.Sprintf("**Overall:** %.2f/10 (%s)\n\n", e.OverallScore, e.OverallRating))
+
+	out.WriteString("## Score build-up\n\n")
+	out.WriteString("| Category | Score | Weight | Contribution | Rating |\n")
+	out.WriteString("|---|---:|---:|---:|---|\n")
+	for _, c := range e.Contributions {
+		rating := string(c.Rating)
+		if c.Note != "" {
+			rating += " (" + c.Note + ")"
+		}
+		out.WriteString(fmt.Sprintf("| %s | %.2f | %.2f | %.2f | %s |\n", c.Category, c.Score, c.Weight, c.Weighted, rating))
+	}
+	out.WriteString(fmt.Sprintf("| **Base score** | | | **%.2f** | |\n\n", e.BaseScore))
+	if lines := adjustmentLines(e); len(lines) > 0 {
+		for _, line := range lines {
+			out.WriteString("- " + line + "\n")
+		}
+		out.WriteString("\n")
+	}
+	out.WriteString(fmt.Sprintf("**Final score:** %.2f\n\n", e.OverallScore))
+
+	out.WriteString("## Critical flaws\n\n")
+	if len(e.CriticalFlaws) == 0 {
+		out.WriteString("None.\n\n")
+	} else {
+		for _, flaw := range e.CriticalFlaws {
+			out.WriteString(fmt.Sprintf("- **-%.2f** %s\n", flaw.Penalty, flaw.Description))
+		}
+		out.WriteString("\n")
+	}
+
+	out.WriteString("## Analysis\n\n")
+	for _, section := range e.Sections {
+		out.WriteString(fmt.Sprintf("### %s (%.1f/10, %s)\n\n", section.Title, section.Score, section.Rating))
+		if section.Summary != "" {
+			out.WriteString(section.Summary + "\n\n")
+		}
+		for _, detail := range section.Details {
+			out.WriteString("- " + detail + "\n")
+		}
+		if len(section.Details) > 0 {
+			out.WriteString("\n")
+		}
+	}
+
+	if e.OpeningHands != nil && len(e.OpeningHands.Hands) > 0 {
+		out.WriteString("## Opening hands\n\n")
+		for _, line := range openingHandSummaryLines(e.OpeningHands) {
+			out.WriteString("- " + line + "\n")
+		}
+		out.WriteString("\n| Worst hands | Score | Anti-air | Safe plays |\n")
+		out.WriteString("|---|---:|---|---|\n")
+		for _, hand := range worstOpeningHands(e.OpeningHands) {
+			out.WriteString(fmt.Sprintf("| %s | %.0f | %s | %s |\n", strings.Join(hand.Cards, ", "), hand.Score,
+				yesNo(hand.HasAntiAir), joinOrDash(hand.SafePlays)))
+		}
+		out.WriteString("\n")
+	}
+
+	if len(e.Interactions) > 0 {
+		out.WriteString("## Spell interactions\n\n")
+		for _, line := range interactionLines(e.Interactions) {
+			out.WriteString("- " + line + "\n")
+		}
+		out.WriteString("\n")
+	}
+
+	out.WriteString("## Synergy\n\n")
+	out.WriteString(fmt.Sprintf("%d/%d pairs, %.0f%% card coverage.\n\n",
+		e.SynergyMatrix.PairCount, e.SynergyMatrix.MaxPossiblePairs, e.SynergyMatrix.SynergyCoverage))
+	for _, pair := range e.SynergyMatrix.Pairs {
+		out.WriteString(fmt.Sprintf("- **%s + %s** (%.0f%%): %s\n", pair.Card1, pair.Card2, pair.Score*100, pair.Description))
+	}
+	if len(e.SynergyMatrix.Pairs) > 0 {
+		out.WriteString("\n")
+	}
+	if e.SynergyGrid != nil {
+		out.WriteString("| |")
+		for _, card := range e.SynergyGrid.Cards {
+			out.WriteString(" " + card + " |")
+		}
+		out.WriteString("\n|---|" + strings.Repeat("---:|", len(e.SynergyGrid.Cards)) + "\n")
+		for i, card := range e.SynergyGrid.Cards {
+			out.WriteString("| **" + card + "** |")
+			for j := range e.SynergyGrid.Cards {
+				out.WriteString(" " + gridCell(e.SynergyGrid, i, j) + " |")
+			}
+			out.WriteString("\n")
+		}
+		out.WriteString("\n")
+	}
+
+	if e.MissingCards != nil {
+		out.WriteString("## Missing cards\n\n")
+		if e.MissingCards.IsPlayable {
+			out.WriteString("All cards are available in the collection.\n")
+		}
+		for _, missing := range e.MissingCards.MissingCards {
+			status := "unlocked"
+			if missing.IsLocked {
+				status = "locked"
+			}
+			out.WriteString(fmt.Sprintf("- **%s** (%s, %s, unlocks in %s)", missing.Name, missing.Rarity, status, missing.UnlockArenaName))
+			if len(missing.AlternativeCards) > 0 {
+				out.WriteString(" — alternatives: " + strings.Join(missing.AlternativeCards, ", "))
+			}
+			out.WriteString("\n")
+		}
+	}
+
+	return out.String()
+}
+
+// interactionLines summarizes each spell's kills and misses, followed by the
+// outcomes a level gap changes
+func interactionLines(checks []interactions.Check) []string {
+	var (
+		lines []string
+		notes []string
+		order []interactions.Card
+	)
+	kills := make(map[interactions.Card][]string)
+	survives := make(map[interactions.Card][]string)
+	targetLevel := make(map[interactions.Card]int)
+	for _, check := range checks {
+		if _, seen := targetLevel[check.Attacker]; !seen {
+			order = append(order, check.Attacker)
+			targetLevel[check.Attacker] = check.Target.Level
+		}
+		switch {
+		case !check.Reachable:
+		case check.Kills:
+			kills[check.Attacker] = append(kills[check.Attacker], check.Target.Name)
+		default:
+			survives[check.Attacker] = append(survives[check.Attacker],
+				fmt.Sprintf("%s (%d HP left)", check.Target.Name, check.Remaining))
+		}
+		if note := check.Note(); note != "" {
+			notes = append(notes, "⚠ "+note)
+		}
+	}
+
+	for _, spell := range order {
+		line := spell.String() + ": "
+		if targetLevel[spell] > 0 {
+			line = fmt.Sprintf("%s vs level %d troops: ", spell, targetLevel[spell])
+		}
+		if len(kills[spell]) > 0 {
+			line += "kills " + strings.Join(kills[spell], ", ")
+		} else {
+			line += "kills none of the common targets"
+		}
+		if len(survives[spell]) > 0 {
+			line += "; survives: " + strings.Join(survives[spell], ", ")
+		}
+		lines = append(lines, line)
+	}
+	return append(lines, notes...)
+}
+
+// adjustmentLines describes how the base score became the final score
+func adjustmentLines(e *Explanation) []string {
+	var lines []string
+	if b := e.Breakdown; b != nil {
+		lines = append(lines,
+			fmt.Sprintf("Contextual score %.2f (playability swapped for ladder viability %.2f)", b.ContextualScore, b.LadderScore),
+			fmt.Sprintf("Level normalization ×%.2f at deck level ratio %.2f gives %.2f", b.NormalizationFactor, b.DeckLevelRatio, b.NormalizedScore),
+			fmt.Sprintf("Blend 75%% contextual + 15%% ladder + 10%% normalized = %.2f",
+				clampScoreToTen(b.ContextualScore*0.75+b.LadderScore*0.15+b.NormalizedScore*0.10)),
+		)
+	}
+	if e.GameMode != "" {
+		lines = append(lines, gameModeAdjustmentLine(LookupGameMode(e.GameMode), e.GameModeAdjustment))
+	}
+	if band, ok := LookupTrophyBand(e.TrophyBand); ok {
+		lines = append(lines, fmt.Sprintf("%s trophy band weights (%s)", band.Name, band.Description))
+	}
+	if m := e.MetaCounter; m != nil {
+		lines = append(lines, fmt.Sprintf("Meta answers %.2f blended in at %.0f%% weight", m.Score, metaCounterWeight*100))
+	}
+	for _, plugin := range e.PluginScores {
+		if plugin.Score == nil {
+			lines = append(lines, fmt.Sprintf("%s plugin failed, not blended in: %s", plugin.Name, plugin.Error))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s plugin %.2f blended in at %.0f%% weight", plugin.Name, plugin.Score.Score, plugin.Weight*100))
+	}
+	flawTotal := 0.0
+	for _, flaw := range e.CriticalFlaws {
+		flawTotal += flaw.Penalty
+	}
+	if flawTotal > 0 {
+		lines = append(lines, fmt.Sprintf("Critical flaw penalty -%.2f", flawTotal))
+	}
+	if e.MissingCardPenalty > 0 {
+		lines = append(lines, fmt.Sprintf("Missing card penalty -%.2f", e.MissingCardPenalty))
+	}
+	return lines
+}
+
+// gameModeAdjustmentLine describes what a game mode preset changed
+func gameModeAdjustmentLine(preset GameModePreset, adjustment float64) string {
+	var rules []string
+	if preset.IgnoreCycleSpeed {
+		rules = append(rules, "cycle speed ignored")
+	} else if preset.CycleWeight > 0 {
+		rules = append(rules, fmt.Sprintf("cycle speed at %.0f%% weight", preset.CycleWeight*100))
+	}
+	if preset.ElixirPenalty > 0 {
+		rules = append(rules, "elixir band "+formatElixirBand(preset.MinElixir, preset.MaxElixir))
+	}
+	line := fmt.Sprintf("%s preset %+.2f", preset.Name, adjustment)
+	if len(rules) > 0 {
+		line += " (" + strings.Join(rules, ", ") + ")"
+	}
+	return line
+}
+
+func formatElixirBand(minElixir, maxElixir float64) string {
+	switch {
+	case minElixir > 0 && maxElixir > 0:
+		return fmt.Sprintf("%.1f-%.1f", minElixir, maxElixir)
+	case minElixir > 0:
+		return fmt.Sprintf(">= %.1f", minElixir)
+	default:
+		return fmt.Sprintf("<= %.1f", maxElixir)
+	}
+}
+
+func formatSynergyGridText(grid *SynergyGrid) string {
+	var out strings.Builder
+	out.WriteString("  Pair grid (synergy %, rows and columns in deck order):\n")
+	out.WriteString(fmt.Sprintf("  %-16s", ""))
+	for i := range grid.Cards {
+		out.WriteString(fmt.Sprintf("%5d", i+1))
+	}
+	out.WriteString("\n")
+	for i, card := range grid.Cards {
+		out.WriteString(fmt.Sprintf("  %d %-14s", i+1, truncateCardName(card, 14)))
+		for j := range grid.Cards {
+			out.WriteString(fmt.Sprintf("%5s", gridCell(grid, i, j)))
+		}
+		out.WriteString("\n")
+	}
+	return out.String()
+}
+
+func gridCell(grid *SynergyGrid, i, j int) string {
+	switch {
+	case i == j:
+		return "—"
+	case grid.Scores[i][j] == 0:
+		return "·"
+	default:
+		return fmt.Sprintf("%.0f", grid.Scores[i][j]*100)
+	}
+}
+
+func truncateCardName(name string, width int) string {
+	runes := []rune(name)
+	if len(runes) <= width {
+		return name
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+// openingHandTableRows is the number of worst hands listed by explain reports
+const openingHandTableRows = 5
+
+func openingHandSummaryLines(r *OpeningHandReport) []string {
+	return []string{
+		fmt.Sprintf("%d starting hands, average hand score %.1f/10", len(r.Hands), r.AverageScore),
+		fmt.Sprintf("Safe first play: %d hands (%.0f%%)", r.SafeHands, r.SafeShare()*100),
+		fmt.Sprintf("No anti-air: %d hands (%.0f%%)", r.NoAntiAirHands, r.NoAntiAirShare()*100),
+	}
+}
+
+func worstOpeningHands(r *OpeningHandReport) []OpeningHand {
+	return r.Hands[:min(openingHandTableRows, len(r.Hands))]
+}
+
+func yesNo(ok bool) string {
+	if ok {
+		return "yes"
+	}
+	return "no"
+}
+
+func joinOrDash(names []string) string {
+	if len(names) == 0 {
+		return "-"
+	}
+	return strings.Join(names, ", ")
+}
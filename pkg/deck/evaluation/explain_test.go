@@ -0,0 +1,93 @@
+package evaluation
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+func TestExplainReportsScoreBuildUp(t *testing.T) {
+	synergyDB := deck.NewSynergyDatabase()
+	cards := []deck.CardCandidate{
+		makeCard("Hog Rider", deck.RoleWinCondition, 11, 14, "Rare", 4),
+		makeCard("Fireball", deck.RoleSpellBig, 11, 14, "Rare", 4),
+		makeCard("Zap", deck.RoleSpellSmall, 11, 14, "Common", 2),
+		makeCard("Cannon", deck.RoleBuilding, 11, 14, "Common", 3),
+		makeCard("Musketeer", deck.RoleSupport, 11, 14, "Rare", 4),
+		makeCard("Ice Spirit", deck.RoleCycle, 11, 14, "Common", 1),
+		makeCard("Skeletons", deck.RoleCycle, 11, 14, "Common", 1),
+		makeCard("Valkyrie", deck.RoleSupport, 11, 14, "Rare", 4),
+	}
+	result := Evaluate(cards, synergyDB, nil)
+
+	explanation := Explain(&result, synergyDB)
+
+	if len(explanation.Contributions) != 6 {
+		t.Fatalf("got %d contributions, want 6", len(explanation.Contributions))
+	}
+	sum := 0.0
+	for _, c := range explanation.Contributions {
+		sum += c.Weighted
+	}
+	if diff := sum - explanation.BaseScore; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("contributions sum to %v, base score is %v", sum, explanation.BaseScore)
+	}
+	if len(explanation.Sections) == 0 {
+		t.Error("expected analysis sections")
+	}
+	if explanation.SynergyGrid == nil || len(explanation.SynergyGrid.Scores) != 8 {
+		t.Fatal("expected an 8x8 synergy grid")
+	}
+	if explanation.SynergyGrid.Scores[0][0] != 0 {
+		t.Error("expected no self-synergy on the diagonal")
+	}
+
+	text := FormatExplainText(&explanation)
+	for _, want := range []string{"WHY THIS SCORE", "Score Build-Up", "Critical Flaws", "Synergy", "Hog Rider"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("text report missing %q", want)
+		}
+	}
+	markdown := FormatExplainMarkdown(&explanation)
+	for _, want := range []string{"# Why this score", "| Category |", "## Critical flaws", "## Analysis"} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("markdown report missing %q", want)
+		}
+	}
+	if _, err := json.Marshal(explanation); err != nil {
+		t.Errorf("json.Marshal() error = %v", err)
+	}
+}
+
+func TestExplainListsCriticalFlaws(t *testing.T) {
+	cards := []deck.CardCandidate{
+		makeCard("Knight", deck.RoleCycle, 11, 14, "Common", 3),
+		makeCard("Valkyrie", deck.RoleCycle, 11, 14, "Rare", 4),
+		makeCard("Mini P.E.K.K.A", deck.RoleCycle, 11, 14, "Rare", 4),
+		makeCard("Skeletons", deck.RoleCycle, 11, 14, "Common", 1),
+		makeCard("Ice Golem", deck.RoleCycle, 11, 14, "Rare", 2),
+		makeCard("Guards", deck.RoleCycle, 11, 14, "Epic", 3),
+		makeCard("Bandit", deck.RoleCycle, 11, 14, "Legendary", 3),
+		makeCard("Lumberjack", deck.RoleCycle, 11, 14, "Legendary", 4),
+	}
+	result := Evaluate(cards, nil, nil)
+	explanation := Explain(&result, nil)
+
+	codes := make(map[CriticalFlawCode]bool)
+	for _, flaw := range explanation.CriticalFlaws {
+		codes[flaw.Code] = true
+	}
+	for _, want := range []CriticalFlawCode{FlawNoWinCondition, FlawNoSpells, FlawNoAntiAir} {
+		if !codes[want] {
+			t.Errorf("expected critical flaw %s, got %+v", want, explanation.CriticalFlaws)
+		}
+	}
+	if explanation.SynergyGrid != nil {
+		t.Error("expected no synergy grid without a synergy database")
+	}
+	if !strings.Contains(FormatExplainText(&explanation), "No cards that can target air units") {
+		t.Error("text report missing the anti-air flaw")
+	}
+}
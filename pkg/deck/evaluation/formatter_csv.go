@@ -17,7 +17,14 @@ func FormatCSV(result *EvaluationResult) string {
 	output.WriteString(fmt.Sprintf("Overall Score,%.2f\n", result.OverallScore))
 	output.WriteString(fmt.Sprintf("Overall Rating,%s\n", result.OverallRating))
 	output.WriteString(fmt.Sprintf("Archetype,%s\n", result.DetectedArchetype))
-	output.WriteString(fmt.Sprintf("Archetype Confidence,%.2f\n\n", result.ArchetypeConfidence*100))
+	output.WriteString(fmt.Sprintf("Archetype Confidence,%.2f\n", result.ArchetypeConfidence*100))
+	if result.TowerTroop != "" {
+		output.WriteString(fmt.Sprintf("Tower Troop,%s\n", result.TowerTroop))
+	}
+	if result.TrophyBand != TrophyBandNone {
+		output.WriteString(fmt.Sprintf("Trophy Band,%s\n", result.TrophyBand))
+	}
+	output.WriteString("\n")
 
 	// Section 2: Category Scores
 	output.WriteString("# CATEGORY SCORES\n")
@@ -37,6 +44,9 @@ func FormatCSV(result *EvaluationResult) string {
 	output.WriteString(formatAnalysisSectionCSV(result.BaitAnalysis))
 	output.WriteString(formatAnalysisSectionCSV(result.CycleAnalysis))
 	output.WriteString(formatAnalysisSectionCSV(result.LadderAnalysis))
+	if result.DamageRaceAnalysis.Title != "" {
+		output.WriteString(formatAnalysisSectionCSV(result.DamageRaceAnalysis))
+	}
 	output.WriteString("\n")
 
 	// Section 4: Synergy Matrix
@@ -30,6 +30,7 @@ func FormatDetailed(result *EvaluationResult) string {
 
 	// Missing cards analysis with unlock requirements
 	output.WriteString(formatDetailedMissingCards(result))
+	output.WriteString(formatSubstitutionSection(result))
 
 	// Counter analysis with comprehensive breakdowns
 	output.WriteString(formatDetailedCounterAnalysis(result))
@@ -56,7 +57,14 @@ func formatDetailedHeader(result *EvaluationResult) string {
 	header.WriteString("═══════════════════════════════════════════════════════════════════════\n\n")
 	header.WriteString("Cards: " + strings.Join(result.Deck, " • ") + "\n")
 	header.WriteString(fmt.Sprintf("Card Count: %d\n", len(result.Deck)))
-	header.WriteString(fmt.Sprintf("Average Elixir Cost: %.2f\n\n", result.AvgElixir))
+	header.WriteString(fmt.Sprintf("Average Elixir Cost: %.2f\n", result.AvgElixir))
+	if result.TowerTroop != "" {
+		header.WriteString(fmt.Sprintf("Tower Troop: %s\n", result.TowerTroop))
+	}
+	if band, ok := LookupTrophyBand(result.TrophyBand); ok {
+		header.WriteString(fmt.Sprintf("Trophy Band: %s (%s)\n", band.Name, band.Range()))
+	}
+	header.WriteString("\n")
 
 	header.WriteString("Archetype Detection:\n")
 	header.WriteString(fmt.Sprintf("  Detected: %s\n", strings.Title(string(result.DetectedArchetype))))
@@ -162,6 +170,11 @@ func formatDetailedAnalysisSections(result *EvaluationResult) string {
 		result.LadderAnalysis,
 	}
 
+	// Add damage race analysis if present
+	if result.DamageRaceAnalysis.Title != "" {
+		sections = append(sections, result.DamageRaceAnalysis)
+	}
+
 	// Add evolution analysis if present
 	if result.EvolutionAnalysis.Title != "" {
 		sections = append(sections, result.EvolutionAnalysis)
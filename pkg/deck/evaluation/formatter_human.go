@@ -19,6 +19,7 @@ func FormatHuman(result *EvaluationResult) string {
 	output.WriteString(formatRecommendations(result))
 	output.WriteString(formatAlternativeSuggestions(result))
 	output.WriteString(formatMissingCards(result))
+	output.WriteString(formatSubstitutionSection(result))
 	output.WriteString(formatCopyDeckLink(result))
 	output.WriteString(formatFooter(result))
 
@@ -40,9 +41,19 @@ func formatHeader(result *EvaluationResult) string {
 
 	// Basic stats
 	header.WriteString(fmt.Sprintf("📊 Average Elixir: %.2f\n", result.AvgElixir))
-	header.WriteString(fmt.Sprintf("🎯 Archetype: %s (%.0f%% confidence)\n\n",
+	header.WriteString(fmt.Sprintf("🎯 Archetype: %s (%.0f%% confidence)\n",
 		strings.Title(string(result.DetectedArchetype)),
 		result.ArchetypeConfidence*100))
+	if result.TowerTroop != "" {
+		header.WriteString(fmt.Sprintf("🏰 Tower Troop: %s\n", result.TowerTroop))
+	}
+	if band, ok := LookupTrophyBand(result.TrophyBand); ok {
+		header.WriteString(fmt.Sprintf("🏆 Trophy Band: %s (%s)\n", band.Name, band.Range()))
+	}
+	if result.Mode == Mode2v2 {
+		header.WriteString("👥 Mode: 2v2 (role redundancy and critical flaws penalized less)\n")
+	}
+	header.WriteString("\n")
 
 	// Overall score with large visual display
 	header.WriteString("═══════════════════════════════════════════════════════════════════════\n")
@@ -105,6 +116,9 @@ func formatDetailedAnalysis(result *EvaluationResult) string {
 		result.CycleAnalysis,
 		result.LadderAnalysis,
 	}
+	if result.DamageRaceAnalysis.Title != "" {
+		sections = append(sections, result.DamageRaceAnalysis)
+	}
 
 	for _, section := range sections {
 		analysis.WriteString(formatAnalysisSection(section))
@@ -278,6 +292,23 @@ func formatAlternativeSuggestions(result *EvaluationResult) string {
 	return alts.String()
 }
 
+// formatSubstitutionSection lists owned stand-ins for missing and
+// underleveled cards
+func formatSubstitutionSection(result *EvaluationResult) string {
+	if len(result.Substitutions) == 0 {
+		return ""
+	}
+
+	var subs strings.Builder
+	subs.WriteString("═══════════════════════════════════════════════════════════════════════\n")
+	subs.WriteString("                       CARD SUBSTITUTIONS\n")
+	subs.WriteString("═══════════════════════════════════════════════════════════════════════\n\n")
+	subs.WriteString("Closest owned cards by role, elixir, and synergy (score before → after):\n")
+	subs.WriteString(FormatSubstitutions(result.Substitutions))
+	subs.WriteString("\n")
+	return subs.String()
+}
+
 // formatMissingCards formats missing cards analysis
 func formatMissingCards(result *EvaluationResult) string {
 	if result.MissingCardsAnalysis == nil {
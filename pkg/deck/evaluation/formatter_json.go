@@ -34,11 +34,12 @@ func FormatJSON(result *EvaluationResult) (string, error) {
 				"f2p_friendly": categoryScoreToMap(result.F2PFriendly),
 			},
 			"detailed_analysis": map[string]any{
-				"defense": analysisSectionToMap(result.DefenseAnalysis),
-				"attack":  analysisSectionToMap(result.AttackAnalysis),
-				"bait":    analysisSectionToMap(result.BaitAnalysis),
-				"cycle":   analysisSectionToMap(result.CycleAnalysis),
-				"ladder":  analysisSectionToMap(result.LadderAnalysis),
+				"defense":     analysisSectionToMap(result.DefenseAnalysis),
+				"attack":      analysisSectionToMap(result.AttackAnalysis),
+				"bait":        analysisSectionToMap(result.BaitAnalysis),
+				"cycle":       analysisSectionToMap(result.CycleAnalysis),
+				"ladder":      analysisSectionToMap(result.LadderAnalysis),
+				"damage_race": analysisSectionToMap(result.DamageRaceAnalysis),
 			},
 			"synergy_matrix":  synergyMatrixToMap(result.SynergyMatrix),
 			"recommendations": generateRecommendationsJSON(result),
@@ -0,0 +1,80 @@
+package evaluation
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// FormatPairHuman formats a 2v2 pair evaluation as a human-readable report
+func FormatPairHuman(result *PairEvaluationResult) string {
+	var output strings.Builder
+
+	output.WriteString("╔═══════════════════════════════════════════════════════════════════════╗\n")
+	output.WriteString("║                     2v2 TEAM EVALUATION REPORT                        ║\n")
+	output.WriteString("╚═══════════════════════════════════════════════════════════════════════╝\n\n")
+
+	for _, entry := range []struct {
+		label  string
+		result *EvaluationResult
+	}{
+		{"Deck A", &result.DeckA},
+		{"Deck B", &result.DeckB},
+	} {
+		output.WriteString(fmt.Sprintf("🃏 %s: %s\n", entry.label, strings.Join(entry.result.Deck, " • ")))
+		output.WriteString(fmt.Sprintf("   Score: %.1f/10 - %s | Archetype: %s | Avg Elixir: %.2f\n\n",
+			entry.result.OverallScore, entry.result.OverallRating,
+			entry.result.DetectedArchetype, entry.result.AvgElixir))
+	}
+
+	output.WriteString("═══════════════════════════════════════════════════════════════════════\n")
+	output.WriteString(fmt.Sprintf("                  TEAM SCORE: %.1f/10 - %s\n", result.TeamScore, result.TeamRating))
+	output.WriteString("═══════════════════════════════════════════════════════════════════════\n\n")
+
+	output.WriteString(fmt.Sprintf("🔗 Combo Potential: %.1f/10\n", result.ComboScore))
+	if len(result.CrossSynergies) == 0 {
+		output.WriteString("   No known cross-deck synergies\n")
+	}
+	for i, pair := range result.CrossSynergies {
+		if i >= 5 {
+			break
+		}
+		output.WriteString(fmt.Sprintf("   • %s + %s (%.2f) - %s\n", pair.Card1, pair.Card2, pair.Score, pair.Description))
+	}
+	output.WriteString("\n")
+
+	cov := result.Coverage
+	output.WriteString(fmt.Sprintf("🛡️ Team Coverage: %.1f/10\n", result.CoverageScore))
+	output.WriteString(fmt.Sprintf("   Win conditions: %d | Anti-air: %d | Spells: %d (%d big) | Buildings: %d | Elixir gap: %.1f\n",
+		cov.WinConditions, cov.AntiAir, cov.Spells, cov.BigSpells, cov.Buildings, cov.ElixirGap))
+	if len(result.SharedCards) > 0 {
+		output.WriteString(fmt.Sprintf("   Shared cards: %s\n", strings.Join(result.SharedCards, ", ")))
+	}
+	output.WriteString("\n")
+
+	if len(result.Notes) > 0 {
+		output.WriteString("📝 Notes:\n")
+		for _, note := range result.Notes {
+			output.WriteString("   • " + note + "\n")
+		}
+	}
+
+	return output.String()
+}
+
+// FormatPairJSON formats a 2v2 pair evaluation as JSON with metadata
+func FormatPairJSON(result *PairEvaluationResult) (string, error) {
+	output := map[string]any{
+		"version":   "1.0.0",
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+		"mode":      Mode2v2,
+		"pair":      result,
+	}
+
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,216 @@
+package evaluation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GameMode selects an event or challenge rule set that changes what scores
+// well. It is independent of EvaluationMode, so a 2v2 Triple Elixir deck can
+// use both.
+type GameMode string
+
+const (
+	// GameModeStandard is regular ladder play and leaves scoring unchanged
+	GameModeStandard GameMode = "standard"
+	// GameModeDoubleElixir is the double elixir challenge
+	GameModeDoubleElixir GameMode = "double-elixir"
+	// GameModeTripleElixir is triple elixir, where elixir is rarely the limit
+	GameModeTripleElixir GameMode = "triple-elixir"
+	// GameModeRage is the rage challenge, where everything moves faster
+	GameModeRage GameMode = "rage"
+	// GameModeSuddenDeath is sudden death, where one tower ends the game
+	GameModeSuddenDeath GameMode = "sudden-death"
+)
+
+// OverallWeights are the category weights of the base overall score
+type OverallWeights struct {
+	Attack      float64 `json:"attack"`
+	Defense     float64 `json:"defense"`
+	Synergy     float64 `json:"synergy"`
+	Versatility float64 `json:"versatility"`
+	F2P         float64 `json:"f2p"`
+	Playability float64 `json:"playability"`
+}
+
+// GameModePreset is the weight profile and rule toggles for a GameMode
+type GameModePreset struct {
+	Mode        GameMode       `json:"mode"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Weights     OverallWeights `json:"weights"`
+
+	// IgnoreCycleSpeed drops cycle speed from scoring entirely, for modes
+	// with so much elixir that cycling a cheap deck buys nothing
+	IgnoreCycleSpeed bool `json:"ignore_cycle_speed,omitempty"`
+
+	// CycleWeight is the share of the overall score taken by the cycle
+	// analysis score
+	CycleWeight float64 `json:"cycle_weight,omitempty"`
+
+	// MinElixir and MaxElixir bound the preferred average elixir (0 means
+	// unbounded); ElixirPenalty is subtracted per elixir outside the band
+	MinElixir     float64 `json:"min_elixir,omitempty"`
+	MaxElixir     float64 `json:"max_elixir,omitempty"`
+	ElixirPenalty float64 `json:"elixir_penalty,omitempty"`
+}
+
+// defaultOverallWeights are the standard ladder weights
+var defaultOverallWeights = OverallWeights{
+	Attack:      overallWeightAttack,
+	Defense:     overallWeightDefense,
+	Synergy:     overallWeightSynergy,
+	Versatility: overallWeightVersatility,
+	F2P:         overallWeightF2P,
+	Playability: overallWeightPlayability,
+}
+
+var gameModePresets = []GameModePreset{
+	{
+		Mode:        GameModeStandard,
+		Name:        "Standard",
+		Description: "Regular ladder scoring",
+		Weights:     defaultOverallWeights,
+	},
+	{
+		Mode:        GameModeDoubleElixir,
+		Name:        "Double Elixir",
+		Description: "Heavier pushes are affordable; synergy matters more and very cheap decks lose value",
+		Weights: OverallWeights{
+			Attack: 0.25, Defense: 0.22, Synergy: 0.23, Versatility: 0.14, F2P: 0.08, Playability: 0.08,
+		},
+		MinElixir:     3.0,
+		MaxElixir:     4.8,
+		ElixirPenalty: 0.5,
+	},
+	{
+		Mode:        GameModeTripleElixir,
+		Name:        "Triple Elixir",
+		Description: "Cycle speed is ignored; stacked synergy and raw power win",
+		Weights: OverallWeights{
+			Attack: 0.27, Defense: 0.22, Synergy: 0.27, Versatility: 0.14, F2P: 0.05, Playability: 0.05,
+		},
+		IgnoreCycleSpeed: true,
+		MinElixir:        3.6,
+		ElixirPenalty:    0.75,
+	},
+	{
+		Mode:        GameModeRage,
+		Name:        "Rage",
+		Description: "Faster troops and elixir reward quick cycles and offense",
+		Weights: OverallWeights{
+			Attack: 0.28, Defense: 0.20, Synergy: 0.20, Versatility: 0.12, F2P: 0.10, Playability: 0.10,
+		},
+		CycleWeight:   0.12,
+		MaxElixir:     4.0,
+		ElixirPenalty: 0.75,
+	},
+	{
+		Mode:        GameModeSuddenDeath,
+		Name:        "Sudden Death",
+		Description: "The first tower wins, so attack and a quick cycle count for more",
+		Weights: OverallWeights{
+			Attack: 0.30, Defense: 0.22, Synergy: 0.20, Versatility: 0.12, F2P: 0.08, Playability: 0.08,
+		},
+		CycleWeight:   0.08,
+		MaxElixir:     4.2,
+		ElixirPenalty: 0.5,
+	},
+}
+
+// GameModePresets returns every built-in preset, standard first
+func GameModePresets() []GameModePreset {
+	presets := make([]GameModePreset, len(gameModePresets))
+	copy(presets, gameModePresets)
+	return presets
+}
+
+// LookupGameMode returns the preset for mode; empty and unknown modes get
+// the standard preset.
+func LookupGameMode(mode GameMode) GameModePreset {
+	for _, preset := range gameModePresets {
+		if preset.Mode == mode {
+			return preset
+		}
+	}
+	return gameModePresets[0]
+}
+
+// ParseGameMode parses a game mode name such as "triple-elixir", "Triple
+// Elixir", or "rage". Empty means GameModeStandard.
+func ParseGameMode(value string) (GameMode, error) {
+	key := strings.ToLower(strings.TrimSpace(value))
+	key = strings.NewReplacer("_", "-", " ", "-").Replace(key)
+	switch key {
+	case "", "standard", "ladder":
+		return GameModeStandard, nil
+	case "double", "2x":
+		return GameModeDoubleElixir, nil
+	case "triple", "3x":
+		return GameModeTripleElixir, nil
+	}
+	for _, preset := range gameModePresets {
+		if string(preset.Mode) == key {
+			return preset.Mode, nil
+		}
+	}
+	return "", fmt.Errorf("unknown game mode: %s (supported: %s)", value, strings.Join(GameModeNames(), ", "))
+}
+
+// GameModeNames lists the preset names accepted by ParseGameMode
+func GameModeNames() []string {
+	names := make([]string, len(gameModePresets))
+	for i, preset := range gameModePresets {
+		names[i] = string(preset.Mode)
+	}
+	return names
+}
+
+// Standard reports whether the preset leaves scoring unchanged
+func (p GameModePreset) Standard() bool {
+	return p.Mode == GameModeStandard
+}
+
+// Base returns the weighted base score for the category scores
+func (w OverallWeights) Base(attack, defense, synergy, versatility, f2p, playability float64) float64 {
+	return attack*w.Attack +
+		defense*w.Defense +
+		synergy*w.Synergy +
+		versatility*w.Versatility +
+		f2p*w.F2P +
+		playability*w.Playability
+}
+
+// ElixirAdjustment returns the (non-positive) score change for an average
+// elixir outside the preset's band
+func (p GameModePreset) ElixirAdjustment(avgElixir float64) float64 {
+	if p.ElixirPenalty <= 0 {
+		return 0
+	}
+	switch {
+	case p.MinElixir > 0 && avgElixir < p.MinElixir:
+		return -(p.MinElixir - avgElixir) * p.ElixirPenalty
+	case p.MaxElixir > 0 && avgElixir > p.MaxElixir:
+		return -(avgElixir - p.MaxElixir) * p.ElixirPenalty
+	}
+	return 0
+}
+
+// cycleWeight returns the share of the overall score given to cycle speed
+func (p GameModePreset) cycleWeight() float64 {
+	if p.IgnoreCycleSpeed {
+		return 0
+	}
+	return p.CycleWeight
+}
+
+// applyGameMode folds the preset's cycle weight and elixir band into score
+// and returns the result with the total change it made.
+func (p GameModePreset) applyGameMode(score, cycleScore, avgElixir float64) (float64, float64) {
+	adjusted := score
+	if w := p.cycleWeight(); w > 0 {
+		adjusted = adjusted*(1-w) + cycleScore*w
+	}
+	adjusted = clampScoreToTen(adjusted + p.ElixirAdjustment(avgElixir))
+	return adjusted, adjusted - score
+}
@@ -0,0 +1,127 @@
+package evaluation
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+func gameModeTestDeck(names ...string) []deck.CardCandidate {
+	cards := make([]deck.CardCandidate, len(names))
+	for i, name := range names {
+		cards[i] = createTestCardCandidate(name)
+	}
+	return cards
+}
+
+var (
+	cheapCycleDeck = []string{"Hog Rider", "Ice Spirit", "Skeletons", "The Log", "Cannon", "Musketeer", "Fireball", "Ice Golem"}
+	heavyBeatdown  = []string{"Golem", "Night Witch", "Baby Dragon", "Lightning", "Tornado", "Mega Minion", "Lumberjack", "Electro Wizard"}
+)
+
+func TestParseGameMode(t *testing.T) {
+	tests := map[string]GameMode{
+		"":              GameModeStandard,
+		"Ladder":        GameModeStandard,
+		"triple":        GameModeTripleElixir,
+		"Triple Elixir": GameModeTripleElixir,
+		"double_elixir": GameModeDoubleElixir,
+		"RAGE":          GameModeRage,
+		"sudden-death":  GameModeSuddenDeath,
+	}
+	for input, want := range tests {
+		got, err := ParseGameMode(input)
+		if err != nil || got != want {
+			t.Errorf("ParseGameMode(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseGameMode("mirror"); err == nil || !strings.Contains(err.Error(), "triple-elixir") {
+		t.Errorf("unknown mode error = %v, want supported modes listed", err)
+	}
+}
+
+func TestGameModePresetWeightsSumToOne(t *testing.T) {
+	for _, preset := range GameModePresets() {
+		w := preset.Weights
+		sum := w.Attack + w.Defense + w.Synergy + w.Versatility + w.F2P + w.Playability
+		if math.Abs(sum-1) > 1e-9 {
+			t.Errorf("%s weights sum to %.3f", preset.Mode, sum)
+		}
+	}
+	if LookupGameMode("unknown").Mode != GameModeStandard {
+		t.Error("unknown modes should fall back to the standard preset")
+	}
+}
+
+func TestGameModeElixirAdjustment(t *testing.T) {
+	triple := LookupGameMode(GameModeTripleElixir)
+	if got := triple.ElixirAdjustment(2.6); math.Abs(got+0.75) > 1e-9 {
+		t.Errorf("triple elixir at 2.6 = %.3f, want -0.75", got)
+	}
+	if got := triple.ElixirAdjustment(5.5); got != 0 {
+		t.Errorf("triple elixir has no upper bound, got %.3f", got)
+	}
+	rage := LookupGameMode(GameModeRage)
+	if got := rage.ElixirAdjustment(4.5); math.Abs(got+0.375) > 1e-9 {
+		t.Errorf("rage at 4.5 = %.3f, want -0.375", got)
+	}
+	if got := LookupGameMode(GameModeStandard).ElixirAdjustment(6); got != 0 {
+		t.Errorf("standard should never adjust, got %.3f", got)
+	}
+}
+
+func TestEvaluateStandardGameModeMatchesEvaluate(t *testing.T) {
+	cards := gameModeTestDeck(cheapCycleDeck...)
+	synergyDB := deck.NewSynergyDatabase()
+
+	base := Evaluate(cards, synergyDB, nil)
+	standard := EvaluateWithOptions(cards, synergyDB, nil, EvaluateOptions{GameMode: GameModeStandard})
+	if standard.OverallScore != base.OverallScore || standard.GameMode != "" || standard.GameModeAdjustment != 0 {
+		t.Errorf("standard = %.3f (%q), Evaluate = %.3f", standard.OverallScore, standard.GameMode, base.OverallScore)
+	}
+}
+
+func TestEvaluateGameModesShiftScores(t *testing.T) {
+	synergyDB := deck.NewSynergyDatabase()
+	cycle := gameModeTestDeck(cheapCycleDeck...)
+	heavy := gameModeTestDeck(heavyBeatdown...)
+
+	gap := func(mode GameMode) float64 {
+		opts := EvaluateOptions{GameMode: mode}
+		return EvaluateWithOptions(cycle, synergyDB, nil, opts).OverallScore -
+			EvaluateWithOptions(heavy, synergyDB, nil, opts).OverallScore
+	}
+	standardGap := gap(GameModeStandard)
+	if tripleGap := gap(GameModeTripleElixir); tripleGap >= standardGap {
+		t.Errorf("triple elixir should favor the heavy deck more: gap %.2f vs standard %.2f", tripleGap, standardGap)
+	}
+	if rageGap := gap(GameModeRage); rageGap <= standardGap {
+		t.Errorf("rage should favor the cycle deck more: gap %.2f vs standard %.2f", rageGap, standardGap)
+	}
+
+	triple := EvaluateWithOptions(cycle, synergyDB, nil, EvaluateOptions{GameMode: GameModeTripleElixir})
+	if triple.GameMode != GameModeTripleElixir || triple.GameModeAdjustment >= 0 {
+		t.Errorf("triple result mode %q adjustment %.2f", triple.GameMode, triple.GameModeAdjustment)
+	}
+	if !strings.Contains(strings.Join(triple.CycleAnalysis.Details, "\n"), "not scored in Triple Elixir") {
+		t.Errorf("cycle analysis should note that cycle speed is ignored: %v", triple.CycleAnalysis.Details)
+	}
+}
+
+func TestExplainUsesGameModeWeights(t *testing.T) {
+	cards := gameModeTestDeck(heavyBeatdown...)
+	result := EvaluateWithOptions(cards, nil, nil, EvaluateOptions{GameMode: GameModeSuddenDeath})
+	explanation := Explain(&result, nil)
+
+	if explanation.GameMode != GameModeSuddenDeath || explanation.Contributions[0].Weight != 0.30 {
+		t.Errorf("explanation mode %q, attack weight %.2f", explanation.GameMode, explanation.Contributions[0].Weight)
+	}
+	text := FormatExplainText(&explanation)
+	for _, want := range []string{"Game mode: Sudden Death", "Sudden Death preset", "cycle speed at 8% weight", "elixir band <= 4.2"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("explain text missing %q:\n%s", want, text)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package evaluation
+
+import (
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/interactions"
+)
+
+// SpellInteractions checks the deck's damage spells against the troops most
+// often answered with a spell. Spells use the player's card levels when
+// playerContext has them; targets are at opponentLevel, or at each spell's
+// level when opponentLevel is 0.
+func SpellInteractions(deckCards []deck.CardCandidate, playerContext *PlayerContext, opponentLevel int) []interactions.Check {
+	calc := interactions.NewCalculator(nil)
+
+	var checks []interactions.Check
+	for _, card := range deckCards {
+		if !isSpellCard(card) || !calc.CanDamage(card.Name) {
+			continue
+		}
+		spell := interactions.Card{Name: card.Name, Level: spellLevel(card, playerContext)}
+		for _, name := range interactions.CommonSpellTargets {
+			target := interactions.Card{Name: name, Level: opponentLevel}
+			if opponentLevel <= 0 {
+				target.Level = spell.Level
+			}
+			if check, err := calc.Check(spell, target); err == nil {
+				checks = append(checks, check)
+			}
+		}
+	}
+	return checks
+}
+
+// spellLevel returns a deck card's standard level, preferring the player's
+// collection level.
+func spellLevel(card deck.CardCandidate, playerContext *PlayerContext) int {
+	if playerContext != nil {
+		if info, ok := playerContext.Collection[card.Name]; ok {
+			owned := deck.CardCandidate{Level: info.Level, MaxLevel: info.MaxLevel, Rarity: info.Rarity}
+			return owned.StandardLevel()
+		}
+	}
+	return card.StandardLevel()
+}
@@ -0,0 +1,52 @@
+package evaluation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+func TestSpellInteractions(t *testing.T) {
+	cards := []deck.CardCandidate{
+		makeCard("Hog Rider", deck.RoleWinCondition, 11, 16, "Rare", 4),
+		makeCard("Fireball", deck.RoleSpellBig, 11, 16, "Rare", 4),
+		makeCard("The Log", deck.RoleSpellSmall, 11, 16, "Legendary", 2),
+		makeCard("Musketeer", deck.RoleSupport, 11, 16, "Rare", 4),
+	}
+
+	checks := SpellInteractions(cards, nil, 0)
+	if len(checks) == 0 {
+		t.Fatal("expected spell interaction checks")
+	}
+	for _, check := range checks {
+		if check.Attacker.Name != "Fireball" && check.Attacker.Name != "The Log" {
+			t.Errorf("unexpected attacker %s", check.Attacker.Name)
+		}
+		if check.LevelSensitive() {
+			t.Errorf("even levels should not be level sensitive: %s", check.Note())
+		}
+	}
+
+	// The player's Fireball is level 9 (API level 7 for a Rare)
+	playerContext := &PlayerContext{Collection: map[string]CardLevelInfo{
+		"Fireball": {Level: 7, MaxLevel: 14, Rarity: "Rare"},
+	}}
+	var notes []string
+	for _, check := range SpellInteractions(cards, playerContext, 11) {
+		if check.Attacker.Name == "Fireball" && check.Attacker.Level != 9 {
+			t.Fatalf("Fireball level = %d, want 9 from the collection", check.Attacker.Level)
+		}
+		if note := check.Note(); note != "" {
+			notes = append(notes, note)
+		}
+	}
+	if !strings.Contains(strings.Join(notes, "\n"), "Fireball (level 9) no longer one-shots Musketeer (level 11)") {
+		t.Errorf("expected a lost Musketeer one-shot, got:\n%s", strings.Join(notes, "\n"))
+	}
+
+	explanation := Explanation{Interactions: checks}
+	if text := FormatExplainText(&explanation); !strings.Contains(text, "Fireball (level 11) vs level 11 troops: kills") {
+		t.Errorf("text report missing spell interactions:\n%s", text)
+	}
+}
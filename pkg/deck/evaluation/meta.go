@@ -12,8 +12,9 @@ import (
 
 // MetaAnalyzer provides meta-aware deck evaluation using event tracking data
 type MetaAnalyzer struct {
-	eventData *events.EventAnalysis
-	options   MetaAnalysisOptions
+	eventData  *events.EventAnalysis
+	options    MetaAnalysisOptions
+	cardTrends map[string]string
 }
 
 // MetaAnalysisOptions configures meta-aware analysis behavior
@@ -50,6 +51,13 @@ func NewMetaAnalyzer(eventData *events.EventAnalysis, options MetaAnalysisOption
 	}
 }
 
+// SetCardTrends supplies historical card trends ("rising", "falling",
+// "stable"), e.g. from meta snapshot comparisons. Cards with a historical trend
+// use it instead of the win-rate heuristic.
+func (ma *MetaAnalyzer) SetCardTrends(trends map[string]string) {
+	ma.cardTrends = trends
+}
+
 // MetaAdjustment represents a score adjustment based on meta data
 type MetaAdjustment struct {
 	BaseScore       float64  `json:"base_score"`        // Original score
@@ -171,10 +179,13 @@ func (ma *MetaAnalyzer) analyzeCardMeta(deckCards []string) []CardMetaInfo {
 		// Determine meta tier based on win rate
 		metaTier := calculateMetaTier(winRate)
 
-		// Determine trend (simplified - would need historical data)
+		// Prefer historical trends; otherwise approximate from win rate
 		trend := "stable"
 		isTrending := false
-		if winRate > 0.55 {
+		if historical, ok := ma.cardTrends[cardName]; ok {
+			trend = historical
+			isTrending = historical == "rising"
+		} else if winRate > 0.55 {
 			trend = "rising"
 			isTrending = true
 		} else if winRate < 0.45 {
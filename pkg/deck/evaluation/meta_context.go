@@ -0,0 +1,178 @@
+package evaluation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/meta"
+)
+
+const (
+	// metaCounterWeight is the share of the overall score given to answering
+	// the meta when a MetaContext is supplied
+	metaCounterWeight = 0.10
+
+	// metaMaxThreats is how many of the most-used win conditions are checked
+	metaMaxThreats = 8
+)
+
+var (
+	countersDBOnce sync.Once
+	countersDB     *deck.CountersDatabase
+)
+
+func getCountersDatabase() *deck.CountersDatabase {
+	countersDBOnce.Do(func() {
+		countersDB, _ = deck.NewCountersDatabase()
+	})
+	return countersDB
+}
+
+// MetaContext is the meta a deck is scored against: how often cards are
+// played and which exact decks are most common.
+type MetaContext struct {
+	// Source describes where the data came from (e.g. a snapshot week)
+	Source string `json:"source,omitempty"`
+
+	// CardUsage maps card names to the share of meta decks using them (0-1)
+	CardUsage map[string]float64 `json:"card_usage"`
+
+	// TopDecks are the most common exact decks
+	TopDecks []meta.DeckUsageStat `json:"top_decks,omitempty"`
+}
+
+// NewMetaContext builds a MetaContext from a meta snapshot. When the snapshot
+// has no card stats, usage is derived from its top decks.
+func NewMetaContext(snapshot *meta.Snapshot) *MetaContext {
+	metaCtx := &MetaContext{
+		Source:    snapshot.Week,
+		CardUsage: make(map[string]float64, len(snapshot.Cards)),
+		TopDecks:  snapshot.TopDecks,
+	}
+	for _, stat := range snapshot.Cards {
+		metaCtx.CardUsage[stat.Name] = stat.UsageRate
+	}
+	if len(metaCtx.CardUsage) > 0 {
+		return metaCtx
+	}
+
+	totalUses := 0
+	for _, topDeck := range snapshot.TopDecks {
+		totalUses += topDeck.Uses
+	}
+	if totalUses == 0 {
+		return metaCtx
+	}
+	for _, topDeck := range snapshot.TopDecks {
+		for _, card := range topDeck.Cards {
+			metaCtx.CardUsage[card] += float64(topDeck.Uses) / float64(totalUses)
+		}
+	}
+	return metaCtx
+}
+
+// LoadMetaContext reads a meta snapshot file (as written by `meta snapshot`
+// or `meta scrape`) into a MetaContext.
+func LoadMetaContext(path string) (*MetaContext, error) {
+	snapshot, err := meta.ReadSnapshotFile(path)
+	if err != nil {
+		return nil, err
+	}
+	metaCtx := NewMetaContext(snapshot)
+	if len(metaCtx.CardUsage) == 0 {
+		return nil, fmt.Errorf("meta snapshot %s has no card usage", path)
+	}
+	return metaCtx, nil
+}
+
+// MetaThreat is a popular win condition and how the deck answers it.
+type MetaThreat struct {
+	Card      string   `json:"card"`
+	UsageRate float64  `json:"usage_rate"`
+	Answers   []string `json:"answers,omitempty"`
+	// Coverage is 1.0 for a hard counter or two soft counters, 0.5 for one soft counter
+	Coverage float64 `json:"coverage"`
+}
+
+// Threats returns the most-used win conditions with counter data, most used first.
+func (m *MetaContext) Threats() []MetaThreat {
+	counters := getCountersDatabase()
+	if m == nil || counters == nil {
+		return nil
+	}
+
+	var threats []MetaThreat
+	for card, usage := range m.CardUsage {
+		if usage <= 0 || !deck.IsWinCondition(card) {
+			continue
+		}
+		if _, ok := counters.CountersFor(card); !ok {
+			continue
+		}
+		threats = append(threats, MetaThreat{Card: card, UsageRate: usage})
+	}
+	sort.Slice(threats, func(i, j int) bool {
+		if threats[i].UsageRate != threats[j].UsageRate {
+			return threats[i].UsageRate > threats[j].UsageRate
+		}
+		return threats[i].Card < threats[j].Card
+	})
+	if len(threats) > metaMaxThreats {
+		threats = threats[:metaMaxThreats]
+	}
+	return threats
+}
+
+// ScoreMetaCounter scores how well deckNames answers the meta's popular win
+// conditions, weighting each threat by its usage. It returns nil when the
+// context has no known threats.
+func ScoreMetaCounter(deckNames []string, metaCtx *MetaContext) (*CategoryScore, *AnalysisSection) {
+	threats := metaCtx.Threats()
+	if len(threats) == 0 {
+		return nil, nil
+	}
+	counters := getCountersDatabase()
+
+	weighted, totalUsage := 0.0, 0.0
+	section := &AnalysisSection{Title: "Meta Answers"}
+	var unanswered []string
+	for i := range threats {
+		threat := &threats[i]
+		for _, card := range deckNames {
+			if strength := counters.Strength(threat.Card, card); strength != deck.CounterStrengthNone {
+				threat.Answers = append(threat.Answers, fmt.Sprintf("%s (%s)", card, strength))
+				threat.Coverage += strength.Weight()
+			}
+		}
+		threat.Coverage = min(threat.Coverage, 1.0)
+		weighted += threat.UsageRate * threat.Coverage
+		totalUsage += threat.UsageRate
+
+		if len(threat.Answers) == 0 {
+			unanswered = append(unanswered, threat.Card)
+			section.Details = append(section.Details, fmt.Sprintf("⚠️ %s (%.0f%% of decks): no answer", threat.Card, threat.UsageRate*100))
+			continue
+		}
+		section.Details = append(section.Details, fmt.Sprintf("%s (%.0f%% of decks): %s",
+			threat.Card, threat.UsageRate*100, strings.Join(threat.Answers, ", ")))
+	}
+
+	score := clampScoreToTen(weighted / totalUsage * 10)
+	section.Score = score
+	section.Rating = ScoreToRating(score)
+	if len(unanswered) == 0 {
+		section.Summary = fmt.Sprintf("Answers all %d popular win conditions", len(threats))
+	} else {
+		section.Summary = fmt.Sprintf("No answer to %s", strings.Join(unanswered, ", "))
+	}
+
+	return &CategoryScore{
+		Score:      score,
+		Rating:     section.Rating,
+		Assessment: section.Summary,
+		Stars:      ScoreToStars(score),
+	}, section
+}
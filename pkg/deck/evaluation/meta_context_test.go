@@ -0,0 +1,100 @@
+package evaluation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/meta"
+)
+
+func hogMetaContext() *MetaContext {
+	return &MetaContext{
+		Source: "2026-W42",
+		CardUsage: map[string]float64{
+			"Hog Rider":   0.40,
+			"Royal Giant": 0.20,
+			"The Log":     0.50,
+		},
+	}
+}
+
+func TestNewMetaContextFallsBackToTopDecks(t *testing.T) {
+	snapshot := &meta.Snapshot{
+		Week: "2026-W42",
+		TopDecks: []meta.DeckUsageStat{
+			{Cards: []string{"Hog Rider", "The Log"}, Uses: 3},
+			{Cards: []string{"Golem", "The Log"}, Uses: 1},
+		},
+	}
+
+	metaCtx := NewMetaContext(snapshot)
+	if metaCtx.Source != "2026-W42" {
+		t.Errorf("Source = %q, want 2026-W42", metaCtx.Source)
+	}
+	if got := metaCtx.CardUsage["The Log"]; got != 1.0 {
+		t.Errorf("The Log usage = %.2f, want 1.00", got)
+	}
+	if got := metaCtx.CardUsage["Hog Rider"]; got != 0.75 {
+		t.Errorf("Hog Rider usage = %.2f, want 0.75", got)
+	}
+}
+
+func TestMetaContextThreats(t *testing.T) {
+	threats := hogMetaContext().Threats()
+	if len(threats) != 2 {
+		t.Fatalf("got %d threats, want 2 (spells are not win conditions): %+v", len(threats), threats)
+	}
+	if threats[0].Card != "Hog Rider" || threats[1].Card != "Royal Giant" {
+		t.Errorf("threats = %s, %s; want Hog Rider, Royal Giant", threats[0].Card, threats[1].Card)
+	}
+}
+
+func TestScoreMetaCounter(t *testing.T) {
+	metaCtx := hogMetaContext()
+
+	answered, section := ScoreMetaCounter([]string{"Cannon", "Knight", "Archers"}, metaCtx)
+	if answered == nil || section == nil {
+		t.Fatal("expected a score for a meta with known threats")
+	}
+	if answered.Score != 10 {
+		t.Errorf("Cannon deck score = %.2f, want 10 (hard counter to both threats)", answered.Score)
+	}
+
+	unanswered, section := ScoreMetaCounter([]string{"Archers", "Zap"}, metaCtx)
+	if unanswered.Score != 0 {
+		t.Errorf("no-answer deck score = %.2f, want 0", unanswered.Score)
+	}
+	if !strings.Contains(section.Summary, "Hog Rider") {
+		t.Errorf("summary %q should name the unanswered Hog Rider", section.Summary)
+	}
+
+	if score, _ := ScoreMetaCounter([]string{"Cannon"}, nil); score != nil {
+		t.Error("expected nil score without a meta context")
+	}
+}
+
+func TestEvaluateWithOptionsMeta(t *testing.T) {
+	synergyDB := deck.NewSynergyDatabase()
+	cards := createDeckFromFixture([]string{
+		"Hog Rider", "Musketeer", "Valkyrie", "Cannon",
+		"Fireball", "The Log", "Ice Spirit", "Skeletons",
+	})
+
+	plain := Evaluate(cards, synergyDB, nil)
+	noMeta := EvaluateWithOptions(cards, synergyDB, nil, EvaluateOptions{})
+	if plain.OverallScore != noMeta.OverallScore {
+		t.Errorf("nil meta changed the score: %.3f vs %.3f", plain.OverallScore, noMeta.OverallScore)
+	}
+	if noMeta.MetaCounter != nil {
+		t.Error("MetaCounter should be nil without a meta context")
+	}
+
+	withMeta := EvaluateWithOptions(cards, synergyDB, nil, EvaluateOptions{Meta: hogMetaContext()})
+	if withMeta.MetaCounter == nil || withMeta.MetaAnalysis == nil {
+		t.Fatal("expected meta counter score and analysis")
+	}
+	if withMeta.MetaCounter.Score != 10 {
+		t.Errorf("MetaCounter = %.2f, want 10", withMeta.MetaCounter.Score)
+	}
+}
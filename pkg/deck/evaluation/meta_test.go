@@ -530,6 +530,19 @@ func TestAnalyzeCardMeta(t *testing.T) {
 	}
 }
 
+func TestAnalyzeCardMetaUsesHistoricalTrends(t *testing.T) {
+	analyzer := NewMetaAnalyzer(createMockEventAnalysis(100, 0.55), DefaultMetaAnalysisOptions())
+	analyzer.SetCardTrends(map[string]string{"Hog Rider": "falling", "Log": "rising"})
+
+	result := analyzer.analyzeCardMeta([]string{"Hog Rider", "Log"})
+	if result[0].Trend != "falling" || result[0].IsTrending {
+		t.Errorf("Hog Rider trend = %s (trending %v), want falling", result[0].Trend, result[0].IsTrending)
+	}
+	if result[1].Trend != "rising" || !result[1].IsTrending {
+		t.Errorf("Log trend = %s (trending %v), want rising", result[1].Trend, result[1].IsTrending)
+	}
+}
+
 func TestCountTrendingCards(t *testing.T) {
 	eventData := createMockEventAnalysis(100, 0.55)
 	analyzer := NewMetaAnalyzer(eventData, DefaultMetaAnalysisOptions())
@@ -185,6 +185,9 @@ type MissingCardsAnalysis struct {
 
 	// SuggestedReplacements maps missing cards to suggested alternatives
 	SuggestedReplacements map[string][]string `json:"suggested_replacements,omitempty"`
+
+	// ScorePenalty is the overall-score penalty applied for missing cards
+	ScorePenalty float64 `json:"score_penalty,omitempty"`
 }
 
 // IdentifyMissingCardsWithContext analyzes which cards in a deck are missing from player's collection
@@ -271,7 +274,7 @@ func identifyMissingCardsInternal(
 		}
 
 		// Card is missing - get details
-		unlockArena := getCardUnlockArena(card.Name)
+		unlockArena := CardUnlockArena(card.Name)
 
 		// Use PlayerContext for arena-aware validation
 		isLocked := !playerContext.IsCardUnlockedInArena(card.Name)
@@ -280,7 +283,7 @@ func identifyMissingCardsInternal(
 			Name:            card.Name,
 			Rarity:          card.Rarity,
 			UnlockArena:     unlockArena,
-			UnlockArenaName: getArenaName(unlockArena),
+			UnlockArenaName: ArenaName(unlockArena),
 			IsLocked:        isLocked,
 		}
 
@@ -326,8 +329,9 @@ func findOwnedAlternatives(
 	return alternatives
 }
 
-// getCardUnlockArena returns the arena where a card unlocks
-func getCardUnlockArena(cardName string) int {
+// CardUnlockArena returns the arena number where a card unlocks. Unknown cards
+// are treated as available from the start.
+func CardUnlockArena(cardName string) int {
 	if arena, exists := cardUnlockArenas[cardName]; exists {
 		return arena
 	}
@@ -335,8 +339,8 @@ func getCardUnlockArena(cardName string) int {
 	return 0
 }
 
-// getArenaName returns the name for an arena number
-func getArenaName(arenaNum int) string {
+// ArenaName returns the name for an arena number
+func ArenaName(arenaNum int) string {
 	arenaNames := map[int]string{
 		0:  "Training Camp",
 		1:  "Goblin Stadium",
@@ -171,7 +171,7 @@ func TestIdentifyMissingCards(t *testing.T) {
 	}
 }
 
-func TestGetCardUnlockArena(t *testing.T) {
+func TestCardUnlockArena(t *testing.T) {
 	tests := []struct {
 		name          string
 		cardName      string
@@ -271,16 +271,16 @@ func TestGetCardUnlockArena(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := getCardUnlockArena(tt.cardName)
+			result := CardUnlockArena(tt.cardName)
 			if result != tt.expectedArena {
-				t.Errorf("getCardUnlockArena(%q) = %d, want %d",
+				t.Errorf("CardUnlockArena(%q) = %d, want %d",
 					tt.cardName, result, tt.expectedArena)
 			}
 		})
 	}
 }
 
-func TestGetArenaName(t *testing.T) {
+func TestArenaName(t *testing.T) {
 	tests := []struct {
 		name     string
 		arenaNum int
@@ -308,9 +308,9 @@ func TestGetArenaName(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := getArenaName(tt.arenaNum)
+			result := ArenaName(tt.arenaNum)
 			if result != tt.expected {
-				t.Errorf("getArenaName(%d) = %q, want %q", tt.arenaNum, result, tt.expected)
+				t.Errorf("ArenaName(%d) = %q, want %q", tt.arenaNum, result, tt.expected)
 			}
 		})
 	}
@@ -0,0 +1,249 @@
+package evaluation
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+// ============================================================================
+// Opening Hand Analysis
+// ============================================================================
+
+const (
+	// openingHandSize is the number of cards dealt into the starting hand
+	openingHandSize = 4
+
+	// safeOpeningElixir is the most a troop may cost to be played first in
+	// the back without handing the opponent a punish
+	safeOpeningElixir = 3
+
+	// backTankElixir is the least a win condition must cost to be a tank
+	// that beatdown decks open with behind the king tower
+	backTankElixir = 7
+
+	// Hand score points, adding up to 10
+	openingSafePlayPoints    = 4.0
+	openingAntiAirPoints     = 3.0
+	openingWinConditionPoint = 2.0
+	openingLowElixirPoint    = 1.0
+
+	// openingLowElixirAverage is the highest average cost of a hand that
+	// still leaves room to react after the first play
+	openingLowElixirAverage = 4.0
+)
+
+// OpeningHand is one possible starting hand
+type OpeningHand struct {
+	Cards []string `json:"cards"`
+	// SafePlays are the hand's cards that can be played first
+	SafePlays       []string `json:"safe_plays"`
+	HasAntiAir      bool     `json:"has_anti_air"`
+	HasWinCondition bool     `json:"has_win_condition"`
+	AvgElixir       float64  `json:"avg_elixir"`
+	// Score is 0-10: 4 for a safe first play, 3 for anti-air, 2 for a win
+	// condition, and 1 for an average cost of at most 4 elixir
+	Score float64 `json:"score"`
+}
+
+// OpeningHandReport covers every starting hand a deck can be dealt
+type OpeningHandReport struct {
+	// Hands holds all C(8,4) hands, worst first
+	Hands          []OpeningHand `json:"hands"`
+	SafeHands      int           `json:"safe_hands"`
+	NoAntiAirHands int           `json:"no_anti_air_hands"`
+	// AverageScore is the mean hand score, the score of the analysis section
+	AverageScore float64 `json:"average_score"`
+}
+
+// SafeShare returns the share of hands with a safe first play (0.0-1.0)
+func (r OpeningHandReport) SafeShare() float64 {
+	if len(r.Hands) == 0 {
+		return 0
+	}
+	return float64(r.SafeHands) / float64(len(r.Hands))
+}
+
+// NoAntiAirShare returns the share of hands without anti-air (0.0-1.0)
+func (r OpeningHandReport) NoAntiAirShare() float64 {
+	if len(r.Hands) == 0 {
+		return 0
+	}
+	return float64(r.NoAntiAirHands) / float64(len(r.Hands))
+}
+
+// Worst returns the worst starting hand
+func (r OpeningHandReport) Worst() (OpeningHand, bool) {
+	if len(r.Hands) == 0 {
+		return OpeningHand{}, false
+	}
+	return r.Hands[0], true
+}
+
+// AnalyzeOpeningHands scores every four-card starting hand of deckCards.
+// Safe first plays are troops costing at most 3 elixir, and win conditions
+// costing 7 or more that open behind the king tower. Spells and buildings
+// never are. Anti-air means a troop or building that targets air.
+func AnalyzeOpeningHands(deckCards []deck.CardCandidate) OpeningHandReport {
+	report := OpeningHandReport{Hands: []OpeningHand{}}
+	if len(deckCards) < openingHandSize {
+		return report
+	}
+
+	total := 0.0
+	for indices := range handCombinations(len(deckCards), openingHandSize) {
+		hand := scoreOpeningHand(deckCards, indices)
+		if len(hand.SafePlays) > 0 {
+			report.SafeHands++
+		}
+		if !hand.HasAntiAir {
+			report.NoAntiAirHands++
+		}
+		total += hand.Score
+		report.Hands = append(report.Hands, hand)
+	}
+	report.AverageScore = total / float64(len(report.Hands))
+
+	// Worst first; equal scores put the more expensive hand first
+	slices.SortStableFunc(report.Hands, func(a, b OpeningHand) int {
+		return cmp.Or(cmp.Compare(a.Score, b.Score), cmp.Compare(b.AvgElixir, a.AvgElixir))
+	})
+	return report
+}
+
+func scoreOpeningHand(deckCards []deck.CardCandidate, indices []int) OpeningHand {
+	hand := OpeningHand{Cards: make([]string, 0, len(indices)), SafePlays: []string{}}
+	elixir := 0
+	for _, i := range indices {
+		card := deckCards[i]
+		hand.Cards = append(hand.Cards, card.Name)
+		elixir += card.Elixir
+		if isSafeOpeningPlay(card) {
+			hand.SafePlays = append(hand.SafePlays, card.Name)
+		}
+		if canTargetAir(card) && !isSpellCard(card) {
+			hand.HasAntiAir = true
+		}
+		if hasRole(card, deck.RoleWinCondition) {
+			hand.HasWinCondition = true
+		}
+	}
+	hand.AvgElixir = float64(elixir) / float64(len(indices))
+
+	if len(hand.SafePlays) > 0 {
+		hand.Score += openingSafePlayPoints
+	}
+	if hand.HasAntiAir {
+		hand.Score += openingAntiAirPoints
+	}
+	if hand.HasWinCondition {
+		hand.Score += openingWinConditionPoint
+	}
+	if hand.AvgElixir <= openingLowElixirAverage {
+		hand.Score += openingLowElixirPoint
+	}
+	return hand
+}
+
+func isSafeOpeningPlay(card deck.CardCandidate) bool {
+	if isSpellCard(card) || hasRole(card, deck.RoleBuilding) {
+		return false
+	}
+	if hasRole(card, deck.RoleWinCondition) {
+		return card.Elixir >= backTankElixir || card.Elixir <= safeOpeningElixir
+	}
+	return card.Elixir > 0 && card.Elixir <= safeOpeningElixir
+}
+
+// handCombinations yields every k-element index combination of n in
+// lexicographic order. The yielded slice is reused between iterations.
+func handCombinations(n, k int) func(yield func([]int) bool) {
+	return func(yield func([]int) bool) {
+		indices := make([]int, k)
+		for i := range indices {
+			indices[i] = i
+		}
+		for {
+			if !yield(indices) {
+				return
+			}
+			i := k - 1
+			for i >= 0 && indices[i] == n-k+i {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			indices[i]++
+			for j := i + 1; j < k; j++ {
+				indices[j] = indices[j-1] + 1
+			}
+		}
+	}
+}
+
+// BuildOpeningHandAnalysis summarizes how often a deck's starting hand
+// offers a safe first play and anti-air, and names the worst hand.
+func BuildOpeningHandAnalysis(deckCards []deck.CardCandidate) AnalysisSection {
+	report := AnalyzeOpeningHands(deckCards)
+	worst, ok := report.Worst()
+	if !ok {
+		return AnalysisSection{
+			Title:   "Opening Hand Analysis",
+			Summary: "Not enough cards to deal a starting hand",
+			Details: []string{},
+			Score:   0,
+			Rating:  ScoreToRating(0),
+		}
+	}
+
+	details := []string{
+		fmt.Sprintf("Safe first play in %d of %d starting hands (%.0f%%)",
+			report.SafeHands, len(report.Hands), report.SafeShare()*100),
+		fmt.Sprintf("No anti-air in %d of %d starting hands (%.0f%%)",
+			report.NoAntiAirHands, len(report.Hands), report.NoAntiAirShare()*100),
+		fmt.Sprintf("Worst starting hand: %s (%.0f/10, %s)",
+			strings.Join(worst.Cards, ", "), worst.Score, describeOpeningHand(worst)),
+	}
+	if report.SafeShare() < 0.5 {
+		details = append(details, "⚠️ Most starting hands force an awkward first play; consider a cheaper cycle card")
+	}
+
+	summary := "Reliable starting hands"
+	switch {
+	case report.AverageScore < 6:
+		summary = "Awkward starting hands are common"
+	case report.AverageScore < 8:
+		summary = "Most starting hands are playable"
+	}
+
+	score := clampScoreToTen(report.AverageScore)
+	return AnalysisSection{
+		Title:   "Opening Hand Analysis",
+		Summary: summary,
+		Details: details,
+		Score:   score,
+		Rating:  ScoreToRating(score),
+	}
+}
+
+// describeOpeningHand lists what a hand lacks, or its safe plays
+func describeOpeningHand(hand OpeningHand) string {
+	var missing []string
+	if len(hand.SafePlays) == 0 {
+		missing = append(missing, "no safe play")
+	}
+	if !hand.HasAntiAir {
+		missing = append(missing, "no anti-air")
+	}
+	if !hand.HasWinCondition {
+		missing = append(missing, "no win condition")
+	}
+	if len(missing) == 0 {
+		return "safe plays: " + strings.Join(hand.SafePlays, ", ")
+	}
+	return strings.Join(missing, ", ")
+}
@@ -0,0 +1,104 @@
+package evaluation
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+func hogFireballDeck() []deck.CardCandidate {
+	return []deck.CardCandidate{
+		makeCard("Hog Rider", deck.RoleWinCondition, 11, 14, "Rare", 4),
+		makeCard("Fireball", deck.RoleSpellBig, 11, 14, "Rare", 4),
+		makeCard("Zap", deck.RoleSpellSmall, 11, 14, "Common", 2),
+		makeCard("Cannon", deck.RoleBuilding, 11, 14, "Common", 3),
+		makeCard("Musketeer", deck.RoleSupport, 11, 14, "Rare", 4),
+		makeCard("Ice Spirit", deck.RoleCycle, 11, 14, "Common", 1),
+		makeCard("Skeletons", deck.RoleCycle, 11, 14, "Common", 1),
+		makeCard("Valkyrie", deck.RoleSupport, 11, 14, "Rare", 4),
+	}
+}
+
+func TestAnalyzeOpeningHands(t *testing.T) {
+	report := AnalyzeOpeningHands(hogFireballDeck())
+
+	if len(report.Hands) != 70 {
+		t.Fatalf("got %d hands, want C(8,4) = 70", len(report.Hands))
+	}
+	// Only Ice Spirit and Skeletons are safe, and only Musketeer and Valkyrie
+	// hit air, so C(6,4) = 15 hands miss each
+	if report.SafeHands != 55 {
+		t.Errorf("SafeHands = %d, want 55", report.SafeHands)
+	}
+	if report.NoAntiAirHands != 15 {
+		t.Errorf("NoAntiAirHands = %d, want 15", report.NoAntiAirHands)
+	}
+
+	worst, ok := report.Worst()
+	if !ok {
+		t.Fatal("expected a worst hand")
+	}
+	if want := []string{"Hog Rider", "Fireball", "Zap", "Cannon"}; !slices.Equal(worst.Cards, want) || worst.Score != 3 {
+		t.Errorf("worst hand = %v (%.0f), want %v (3)", worst.Cards, worst.Score, want)
+	}
+	for i := 1; i < len(report.Hands); i++ {
+		if report.Hands[i].Score < report.Hands[i-1].Score {
+			t.Fatalf("hands not sorted worst first at %d", i)
+		}
+	}
+}
+
+func TestIsSafeOpeningPlay(t *testing.T) {
+	tests := []struct {
+		card deck.CardCandidate
+		want bool
+	}{
+		{makeCard("Knight", deck.RoleSupport, 11, 14, "Common", 3), true},
+		{makeCard("Musketeer", deck.RoleSupport, 11, 14, "Rare", 4), false},
+		{makeCard("Golem", deck.RoleWinCondition, 11, 14, "Epic", 8), true},
+		{makeCard("Hog Rider", deck.RoleWinCondition, 11, 14, "Rare", 4), false},
+		{makeCard("Cannon", deck.RoleBuilding, 11, 14, "Common", 3), false},
+		{makeCard("Zap", deck.RoleSpellSmall, 11, 14, "Common", 2), false},
+	}
+	for _, tt := range tests {
+		if got := isSafeOpeningPlay(tt.card); got != tt.want {
+			t.Errorf("isSafeOpeningPlay(%s) = %v, want %v", tt.card.Name, got, tt.want)
+		}
+	}
+}
+
+func TestHandCombinations(t *testing.T) {
+	var got [][]int
+	for indices := range handCombinations(4, 2) {
+		got = append(got, slices.Clone(indices))
+	}
+	want := [][]int{{0, 1}, {0, 2}, {0, 3}, {1, 2}, {1, 3}, {2, 3}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("handCombinations(4, 2) = %v, want %v", got, want)
+	}
+}
+
+func TestExplainRendersOpeningHands(t *testing.T) {
+	synergyDB := deck.NewSynergyDatabase()
+	cards := hogFireballDeck()
+	result := Evaluate(cards, synergyDB, nil)
+	if result.OpeningHandAnalysis.Title != "Opening Hand Analysis" {
+		t.Fatalf("OpeningHandAnalysis = %+v", result.OpeningHandAnalysis)
+	}
+
+	explanation := Explain(&result, synergyDB)
+	report := AnalyzeOpeningHands(cards)
+	explanation.OpeningHands = &report
+
+	text := FormatExplainText(&explanation)
+	for _, want := range []string{"Opening Hands", "Safe first play: 55 hands (79%)", "Hog Rider, Fireball, Zap, Cannon"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("text report missing %q", want)
+		}
+	}
+	if markdown := FormatExplainMarkdown(&explanation); !strings.Contains(markdown, "| Worst hands | Score | Anti-air | Safe plays |") {
+		t.Error("markdown report missing the opening hand table")
+	}
+}
@@ -0,0 +1,270 @@
+package evaluation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+// maxScorerPluginWeight caps the combined weight of the registered plugins,
+// so the built-in category scores always count
+const maxScorerPluginWeight = 0.9
+
+// ScorerPlugin is an external scoring module, such as a neural evaluator.
+// Score rates a deck on a 0-10 scale; EvaluateWithOptions blends it into the
+// overall score at the weight it was registered with.
+type ScorerPlugin interface {
+	Name() string
+	Score(deckCards []deck.CardCandidate) (float64, error)
+}
+
+// RegisteredScorerPlugin is a scorer plugin with its weight
+type RegisteredScorerPlugin struct {
+	Plugin ScorerPlugin
+	Weight float64
+}
+
+// PluginScore is one scorer plugin's result for a deck. A plugin that fails
+// has Error set, no Score, and does not change the overall score.
+type PluginScore struct {
+	Name   string         `json:"name"`
+	Weight float64        `json:"weight"`
+	Score  *CategoryScore `json:"score,omitempty"`
+	Error  string         `json:"error,omitempty"`
+}
+
+var scorerPluginRegistry struct {
+	mu      sync.RWMutex
+	plugins []RegisteredScorerPlugin
+}
+
+// scorerPluginFailures counts failed plugin scores; see ScorerPluginFailures
+var scorerPluginFailures atomic.Uint64
+
+// scorerPluginFingerprinter is implemented by plugins whose scores depend on
+// more than their configuration, such as a program that can be rebuilt.
+// Fingerprint is read each time a cache key is built.
+type scorerPluginFingerprinter interface {
+	Fingerprint() string
+}
+
+// RegisterScorerPlugin adds a scorer plugin to every evaluation. Names must
+// be unique, and the weights of all plugins must add up to at most 0.9.
+func RegisterScorerPlugin(plugin ScorerPlugin, weight float64) error {
+	if plugin == nil {
+		return fmt.Errorf("scorer plugin is nil")
+	}
+	name := plugin.Name()
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("scorer plugin has no name")
+	}
+	if weight <= 0 || math.IsNaN(weight) {
+		return fmt.Errorf("%s: weight must be positive, got %g", name, weight)
+	}
+
+	scorerPluginRegistry.mu.Lock()
+	defer scorerPluginRegistry.mu.Unlock()
+	total := weight
+	for _, existing := range scorerPluginRegistry.plugins {
+		if existing.Plugin.Name() == name {
+			return fmt.Errorf("scorer plugin %q is already registered", name)
+		}
+		total += existing.Weight
+	}
+	if total > maxScorerPluginWeight+1e-9 {
+		return fmt.Errorf("%s: scorer plugin weights add up to %.2f, more than %.2f", name, total, maxScorerPluginWeight)
+	}
+	scorerPluginRegistry.plugins = append(scorerPluginRegistry.plugins, RegisteredScorerPlugin{Plugin: plugin, Weight: weight})
+	return nil
+}
+
+// ResetScorerPlugins removes every scorer plugin, closing those that
+// implement io.Closer.
+func ResetScorerPlugins() {
+	scorerPluginRegistry.mu.Lock()
+	plugins := scorerPluginRegistry.plugins
+	scorerPluginRegistry.plugins = nil
+	scorerPluginRegistry.mu.Unlock()
+
+	for _, registered := range plugins {
+		if closer, ok := registered.Plugin.(io.Closer); ok {
+			_ = closer.Close()
+		}
+	}
+}
+
+// ScorerPlugins returns the registered scorer plugins in registration order.
+func ScorerPlugins() []RegisteredScorerPlugin {
+	scorerPluginRegistry.mu.RLock()
+	defer scorerPluginRegistry.mu.RUnlock()
+	return slices.Clone(scorerPluginRegistry.plugins)
+}
+
+// scorerPluginFingerprint changes whenever the scorer plugins do, including
+// when a subprocess plugin's program is rebuilt
+func scorerPluginFingerprint() string {
+	var fingerprint strings.Builder
+	for _, registered := range ScorerPlugins() {
+		identity := fmt.Sprintf("%+v", registered.Plugin)
+		if fingerprinter, ok := registered.Plugin.(scorerPluginFingerprinter); ok {
+			identity = fingerprinter.Fingerprint()
+		}
+		fmt.Fprintf(&fingerprint, "%s@%g=%s;", registered.Plugin.Name(), registered.Weight, identity)
+	}
+	return fingerprint.String()
+}
+
+// ScorerPluginFailures counts the plugin scores that failed or timed out
+// since the process started. Callers that cache a bare score compare it
+// before and after scoring, and skip caching when it moved.
+func ScorerPluginFailures() uint64 {
+	return scorerPluginFailures.Load()
+}
+
+// PluginFailed reports whether a scorer plugin failed for this evaluation.
+// Such a result is missing that plugin's score and must not be cached.
+func (r *EvaluationResult) PluginFailed() bool {
+	for _, plugin := range r.PluginScores {
+		if plugin.Error != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// applyScorerPlugins runs the registered plugins and blends the scores of
+// those that succeed into overallScore, each at its own weight
+func applyScorerPlugins(deckCards []deck.CardCandidate, overallScore float64) (float64, []PluginScore) {
+	plugins := ScorerPlugins()
+	if len(plugins) == 0 {
+		return overallScore, nil
+	}
+
+	results := make([]PluginScore, 0, len(plugins))
+	blended, totalWeight := 0.0, 0.0
+	for _, registered := range plugins {
+		result := PluginScore{Name: registered.Plugin.Name(), Weight: registered.Weight}
+		score, err := registered.Plugin.Score(deckCards)
+		if err == nil && (math.IsNaN(score) || score < 0 || score > 10) {
+			err = fmt.Errorf("score %g is outside 0-10", score)
+		}
+		if err != nil {
+			scorerPluginFailures.Add(1)
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+		result.Score = &CategoryScore{
+			Score:      score,
+			Rating:     ScoreToRating(score),
+			Assessment: fmt.Sprintf("Scored by the %s plugin", result.Name),
+			Stars:      ScoreToStars(score),
+		}
+		blended += score * registered.Weight
+		totalWeight += registered.Weight
+		results = append(results, result)
+	}
+	return clampScoreToTen(overallScore*(1-totalWeight) + blended), results
+}
+
+// ScorerPluginConfigFile is the JSON format of a scorer plugins file
+type ScorerPluginConfigFile struct {
+	Plugins []ScorerPluginConfig `json:"plugins"`
+}
+
+// ScorerPluginConfig describes a subprocess scorer plugin (see
+// SubprocessScorer) and its weight in the overall score
+type ScorerPluginConfig struct {
+	Name    string   `json:"name"`
+	Command []string `json:"command"`
+	Weight  float64  `json:"weight"`
+	// Timeout bounds each deck's scoring, as a duration like "2s"; empty
+	// means DefaultSubprocessScorerTimeout
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// ParseScorerPlugins parses and validates scorer plugins JSON
+func ParseScorerPlugins(data []byte) (*ScorerPluginConfigFile, error) {
+	var file ScorerPluginConfigFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse scorer plugins file: %w", err)
+	}
+	seen := make(map[string]bool, len(file.Plugins))
+	total := 0.0
+	for i := range file.Plugins {
+		plugin := &file.Plugins[i]
+		plugin.Name = strings.TrimSpace(plugin.Name)
+		if plugin.Name == "" {
+			return nil, fmt.Errorf("scorer plugin has no name")
+		}
+		if seen[plugin.Name] {
+			return nil, fmt.Errorf("%s: defined more than once", plugin.Name)
+		}
+		seen[plugin.Name] = true
+		if len(plugin.Command) == 0 || strings.TrimSpace(plugin.Command[0]) == "" {
+			return nil, fmt.Errorf("%s: needs a command", plugin.Name)
+		}
+		if plugin.Weight <= 0 {
+			return nil, fmt.Errorf("%s: weight must be positive", plugin.Name)
+		}
+		if _, err := plugin.timeout(); err != nil {
+			return nil, err
+		}
+		total += plugin.Weight
+	}
+	if total > maxScorerPluginWeight+1e-9 {
+		return nil, fmt.Errorf("scorer plugin weights add up to %.2f, more than %.2f", total, maxScorerPluginWeight)
+	}
+	return &file, nil
+}
+
+func (c *ScorerPluginConfig) timeout() (time.Duration, error) {
+	if c.Timeout == "" {
+		return DefaultSubprocessScorerTimeout, nil
+	}
+	timeout, err := time.ParseDuration(c.Timeout)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("%s: invalid timeout %q", c.Name, c.Timeout)
+	}
+	return timeout, nil
+}
+
+// ReadScorerPlugins reads and parses a scorer plugins JSON file
+func ReadScorerPlugins(path string) (*ScorerPluginConfigFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scorer plugins file: %w", err)
+	}
+	file, err := ParseScorerPlugins(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return file, nil
+}
+
+// Register replaces the scorer plugins with the file's plugins. Their
+// processes start on first use.
+func (f *ScorerPluginConfigFile) Register() error {
+	ResetScorerPlugins()
+	for i := range f.Plugins {
+		config := f.Plugins[i]
+		timeout, err := config.timeout()
+		if err == nil {
+			err = RegisterScorerPlugin(NewSubprocessScorer(config.Name, config.Command, timeout), config.Weight)
+		}
+		if err != nil {
+			ResetScorerPlugins()
+			return err
+		}
+	}
+	return nil
+}
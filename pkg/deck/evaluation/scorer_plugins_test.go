@@ -0,0 +1,270 @@
+package evaluation
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+type fixedScorerPlugin struct {
+	name  string
+	score float64
+	err   error
+}
+
+func (p fixedScorerPlugin) Name() string { return p.name }
+
+func (p fixedScorerPlugin) Score([]deck.CardCandidate) (float64, error) {
+	return p.score, p.err
+}
+
+func TestRegisterScorerPlugin(t *testing.T) {
+	t.Cleanup(ResetScorerPlugins)
+
+	if err := RegisterScorerPlugin(fixedScorerPlugin{name: "neural"}, 0); err == nil {
+		t.Error("a zero weight should fail")
+	}
+	if err := RegisterScorerPlugin(fixedScorerPlugin{name: " "}, 0.1); err == nil {
+		t.Error("a plugin without a name should fail")
+	}
+	if err := RegisterScorerPlugin(fixedScorerPlugin{name: "neural"}, 0.5); err != nil {
+		t.Fatalf("RegisterScorerPlugin() error = %v", err)
+	}
+	if err := RegisterScorerPlugin(fixedScorerPlugin{name: "neural"}, 0.1); err == nil {
+		t.Error("registering the same name twice should fail")
+	}
+	if err := RegisterScorerPlugin(fixedScorerPlugin{name: "heavy"}, 0.5); err == nil {
+		t.Error("weights above 0.9 in total should fail")
+	}
+	if got := ScorerPlugins(); len(got) != 1 || got[0].Weight != 0.5 {
+		t.Errorf("ScorerPlugins() = %+v", got)
+	}
+
+	ResetScorerPlugins()
+	if got := len(ScorerPlugins()); got != 0 {
+		t.Errorf("after reset, %d plugins", got)
+	}
+}
+
+func TestApplyScorerPluginsBlendsSuccessfulScores(t *testing.T) {
+	t.Cleanup(ResetScorerPlugins)
+	for _, registered := range []RegisteredScorerPlugin{
+		{fixedScorerPlugin{name: "neural", score: 10}, 0.2},
+		{fixedScorerPlugin{name: "offline", err: errors.New("model not loaded")}, 0.3},
+		{fixedScorerPlugin{name: "broken", score: 11}, 0.1},
+	} {
+		if err := RegisterScorerPlugin(registered.Plugin, registered.Weight); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	score, results := applyScorerPlugins(recruitsDeck(), 6)
+	if math.Abs(score-6.8) > 1e-9 {
+		t.Errorf("score = %v, want 6*0.8 + 10*0.2 = 6.8", score)
+	}
+	if len(results) != 3 || results[0].Score == nil || results[0].Score.Score != 10 {
+		t.Fatalf("results = %+v", results)
+	}
+	if results[1].Score != nil || results[1].Error != "model not loaded" {
+		t.Errorf("failed plugin = %+v", results[1])
+	}
+	if !strings.Contains(results[2].Error, "outside 0-10") {
+		t.Errorf("out of range plugin = %+v", results[2])
+	}
+}
+
+func TestEvaluateIncludesScorerPlugins(t *testing.T) {
+	cards := recruitsDeck()
+	baseline := Evaluate(cards, nil, nil)
+	before := EvaluationCacheKey(cards, "")
+
+	t.Cleanup(ResetScorerPlugins)
+	if err := RegisterScorerPlugin(fixedScorerPlugin{name: "neural", score: 10}, 0.5); err != nil {
+		t.Fatal(err)
+	}
+	result := Evaluate(cards, nil, nil)
+	if result.OverallScore <= baseline.OverallScore {
+		t.Errorf("overall = %.2f, want above %.2f with a perfect plugin score", result.OverallScore, baseline.OverallScore)
+	}
+	if len(result.PluginScores) != 1 || result.PluginScores[0].Name != "neural" {
+		t.Errorf("PluginScores = %+v", result.PluginScores)
+	}
+	if EvaluationCacheKey(cards, "") == before {
+		t.Error("cache key should change when scorer plugins are registered")
+	}
+
+	explanation := Explain(&result, nil)
+	if lines := adjustmentLines(&explanation); !strings.Contains(strings.Join(lines, "\n"), "neural plugin 10.00 blended in at 50% weight") {
+		t.Errorf("adjustment lines = %q", lines)
+	}
+}
+
+func TestScorerPluginFailuresAreCounted(t *testing.T) {
+	t.Cleanup(ResetScorerPlugins)
+	if err := RegisterScorerPlugin(fixedScorerPlugin{name: "offline", err: errors.New("model not loaded")}, 0.3); err != nil {
+		t.Fatal(err)
+	}
+
+	before := ScorerPluginFailures()
+	result := Evaluate(recruitsDeck(), nil, nil)
+	if !result.PluginFailed() {
+		t.Error("PluginFailed() = false with a failing plugin")
+	}
+	if got := ScorerPluginFailures() - before; got != 1 {
+		t.Errorf("ScorerPluginFailures() grew by %d, want 1", got)
+	}
+
+	ResetScorerPlugins()
+	if result := Evaluate(recruitsDeck(), nil, nil); result.PluginFailed() {
+		t.Error("PluginFailed() = true without plugins")
+	}
+}
+
+func TestSubprocessScorerFingerprintTracksFiles(t *testing.T) {
+	model := filepath.Join(t.TempDir(), "model.bin")
+	if err := os.WriteFile(model, []byte("v1"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	scorer := NewSubprocessScorer("neural", []string{os.Args[0], model}, 0)
+	before := scorer.Fingerprint()
+	if strings.Count(before, "[") != 2 {
+		t.Errorf("Fingerprint() = %q, want hashes of the program and model files", before)
+	}
+
+	if err := os.WriteFile(model, []byte("retrained"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if scorer.Fingerprint() == before {
+		t.Error("Fingerprint() should change when a file argument changes")
+	}
+
+	// The same files elsewhere give the same fingerprint
+	moved := filepath.Join(t.TempDir(), "model.bin")
+	if err := os.WriteFile(moved, []byte("retrained"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	copied := NewSubprocessScorer("neural", []string{os.Args[0], moved}, 0)
+	if got, want := strings.TrimPrefix(copied.Fingerprint(), copied.String()), strings.TrimPrefix(scorer.Fingerprint(), scorer.String()); got != want {
+		t.Errorf("file hashes %q differ from %q for identical files", got, want)
+	}
+}
+
+func TestScoringFingerprintTracksPlugins(t *testing.T) {
+	before := ScoringFingerprint()
+	if !strings.HasPrefix(before, CurrentScoringVersion+"/") {
+		t.Errorf("ScoringFingerprint() = %q, want the scoring version first", before)
+	}
+	t.Cleanup(ResetScorerPlugins)
+	if err := RegisterScorerPlugin(fixedScorerPlugin{name: "neural", score: 10}, 0.5); err != nil {
+		t.Fatal(err)
+	}
+	if ScoringFingerprint() == before {
+		t.Error("ScoringFingerprint() should change when scorer plugins are registered")
+	}
+}
+
+func TestParseScorerPluginsErrors(t *testing.T) {
+	tests := map[string]string{
+		`{"plugins":[{"command":["x"],"weight":0.1}]}`:                                                      "no name",
+		`{"plugins":[{"name":"a","weight":0.1}]}`:                                                           "needs a command",
+		`{"plugins":[{"name":"a","command":["x"]}]}`:                                                        "weight must be positive",
+		`{"plugins":[{"name":"a","command":["x"],"weight":0.1,"timeout":"soon"}]}`:                          "invalid timeout",
+		`{"plugins":[{"name":"a","command":["x"],"weight":0.5},{"name":"b","command":["y"],"weight":0.5}]}`: "add up to 1.00",
+		`{"plugins":[{"name":"a","command":["x"],"weight":0.1},{"name":"a","command":["y"],"weight":0.1}]}`: "defined more than once",
+	}
+	for data, want := range tests {
+		if _, err := ParseScorerPlugins([]byte(data)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: error = %v, want %q", data, err, want)
+		}
+	}
+}
+
+// TestScorerPluginHelperProcess is the subprocess scorer used by the tests
+// below. It scores a deck by its average elixir, and misbehaves when the
+// first card asks it to.
+func TestScorerPluginHelperProcess(t *testing.T) {
+	if os.Getenv("CR_API_TEST_SCORER_PLUGIN") != "1" {
+		return
+	}
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		var req SubprocessScoreRequest
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			fmt.Println(`{"error":"bad request"}`)
+			continue
+		}
+		switch req.Deck[0].Name {
+		case "Hang":
+			time.Sleep(time.Minute)
+		case "Garbage":
+			fmt.Println("not json")
+		case "Refuse":
+			fmt.Println(`{"error":"unknown card"}`)
+		default:
+			fmt.Printf(`{"score":%g}`+"\n", req.AvgElixir)
+		}
+	}
+	os.Exit(0)
+}
+
+func newHelperScorer(t *testing.T, timeout time.Duration) *SubprocessScorer {
+	t.Helper()
+	t.Setenv("CR_API_TEST_SCORER_PLUGIN", "1")
+	scorer := NewSubprocessScorer("helper", []string{os.Args[0], "-test.run=^TestScorerPluginHelperProcess$"}, timeout)
+	t.Cleanup(func() { _ = scorer.Close() })
+	return scorer
+}
+
+func TestSubprocessScorer(t *testing.T) {
+	scorer := newHelperScorer(t, 10*time.Second)
+	cards := recruitsDeck()
+
+	for range 2 {
+		score, err := scorer.Score(cards)
+		if err != nil {
+			t.Fatalf("Score() error = %v", err)
+		}
+		if want := calculateAvgElixir(cards); score != want {
+			t.Errorf("Score() = %v, want %v", score, want)
+		}
+	}
+
+	refused := []deck.CardCandidate{{Name: "Refuse"}}
+	if _, err := scorer.Score(refused); err == nil || !strings.Contains(err.Error(), "helper: unknown card") {
+		t.Errorf("refused deck error = %v", err)
+	}
+	garbage := []deck.CardCandidate{{Name: "Garbage"}}
+	if _, err := scorer.Score(garbage); err == nil || !strings.Contains(err.Error(), "invalid response") {
+		t.Errorf("garbage response error = %v", err)
+	}
+	if _, err := scorer.Score(cards); err != nil {
+		t.Errorf("Score() after a protocol error should restart the process, error = %v", err)
+	}
+}
+
+func TestSubprocessScorerTimeout(t *testing.T) {
+	scorer := newHelperScorer(t, 200*time.Millisecond)
+	hang := []deck.CardCandidate{{Name: "Hang"}}
+	if _, err := scorer.Score(hang); err == nil || !strings.Contains(err.Error(), "did not answer within") {
+		t.Errorf("hanging plugin error = %v", err)
+	}
+	if _, err := scorer.Score(recruitsDeck()); err != nil {
+		t.Errorf("Score() after a timeout should restart the process, error = %v", err)
+	}
+}
+
+func TestSubprocessScorerMissingCommand(t *testing.T) {
+	scorer := NewSubprocessScorer("missing", []string{"/nonexistent/cr-api-scorer"}, 0)
+	if _, err := scorer.Score(recruitsDeck()); err == nil || !strings.Contains(err.Error(), "failed to start missing") {
+		t.Errorf("error = %v", err)
+	}
+}
@@ -0,0 +1,264 @@
+package evaluation
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/closeutil"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+// DefaultSubprocessScorerTimeout bounds how long a subprocess scorer may take
+// to score one deck
+const DefaultSubprocessScorerTimeout = 5 * time.Second
+
+// SubprocessScorer is a ScorerPlugin run as a long-lived child process that
+// speaks JSON lines. For each deck it writes one SubprocessScoreRequest line
+// to the process's stdin and reads one SubprocessScoreResponse line from its
+// stdout. The process's stderr passes through. It starts on first use, is
+// killed when a deck times out or the protocol breaks, and is restarted for
+// the next deck.
+type SubprocessScorer struct {
+	name    string
+	command []string
+	timeout time.Duration
+
+	mu     sync.Mutex
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+
+	// hashMu guards hashes, the file hashes Fingerprint reuses
+	hashMu sync.Mutex
+	hashes map[string]subprocessFileHash
+}
+
+// SubprocessScoreRequest is the line sent to a subprocess scorer per deck
+type SubprocessScoreRequest struct {
+	Deck      []SubprocessCard `json:"deck"`
+	AvgElixir float64          `json:"avg_elixir"`
+}
+
+// SubprocessCard is one card of a SubprocessScoreRequest
+type SubprocessCard struct {
+	Name           string `json:"name"`
+	Level          int    `json:"level"`
+	MaxLevel       int    `json:"max_level"`
+	Rarity         string `json:"rarity,omitempty"`
+	Elixir         int    `json:"elixir"`
+	Role           string `json:"role,omitempty"`
+	EvolutionLevel int    `json:"evolution_level,omitempty"`
+}
+
+// SubprocessScoreResponse is the line a subprocess scorer answers with: a
+// 0-10 score, or an error message
+type SubprocessScoreResponse struct {
+	Score *float64 `json:"score,omitempty"`
+	Error string   `json:"error,omitempty"`
+}
+
+// NewSubprocessScorer returns a scorer that runs command (the program and its
+// arguments). A timeout of 0 means DefaultSubprocessScorerTimeout.
+func NewSubprocessScorer(name string, command []string, timeout time.Duration) *SubprocessScorer {
+	if timeout <= 0 {
+		timeout = DefaultSubprocessScorerTimeout
+	}
+	return &SubprocessScorer{name: name, command: command, timeout: timeout}
+}
+
+// Name returns the plugin name
+func (s *SubprocessScorer) Name() string { return s.name }
+
+// String returns the command line
+func (s *SubprocessScorer) String() string {
+	return strings.Join(s.command, " ")
+}
+
+// Fingerprint identifies the plugin in evaluation cache keys and scoring
+// fingerprints: the command line, plus a content hash of the program and of
+// every argument that names a file, such as a script or model weights. A
+// rebuilt program or retrained model therefore gets new cache keys, while
+// the same files on another machine give the same fingerprint. Hashes are
+// reused until a file's size or modification time changes.
+func (s *SubprocessScorer) Fingerprint() string {
+	var fingerprint strings.Builder
+	fingerprint.WriteString(s.String())
+	for i, arg := range s.command {
+		path := arg
+		if i == 0 {
+			resolved, err := exec.LookPath(arg)
+			if err != nil {
+				continue
+			}
+			path = resolved
+		}
+		if hash := s.fileHash(path); hash != "" {
+			fmt.Fprintf(&fingerprint, " [%s]", hash)
+		}
+	}
+	return fingerprint.String()
+}
+
+// subprocessFileHash is the content hash of a file at a size and modification time
+type subprocessFileHash struct {
+	size    int64
+	modTime time.Time
+	hash    string
+}
+
+// fileHash returns the SHA-256 of a regular file, or "" for anything else
+func (s *SubprocessScorer) fileHash(path string) string {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return ""
+	}
+	s.hashMu.Lock()
+	defer s.hashMu.Unlock()
+	if cached, ok := s.hashes[path]; ok && cached.size == info.Size() && cached.modTime.Equal(info.ModTime()) {
+		return cached.hash
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer closeutil.WithLog("evaluation", file, "plugin file")
+	sum := sha256.New()
+	if _, err := io.Copy(sum, file); err != nil {
+		return ""
+	}
+	hash := hex.EncodeToString(sum.Sum(nil))
+	if s.hashes == nil {
+		s.hashes = make(map[string]subprocessFileHash)
+	}
+	s.hashes[path] = subprocessFileHash{size: info.Size(), modTime: info.ModTime(), hash: hash}
+	return hash
+}
+
+// Score sends the deck to the process and waits for its score. Calls are
+// serialized.
+func (s *SubprocessScorer) Score(deckCards []deck.CardCandidate) (float64, error) {
+	line, err := json.Marshal(newSubprocessScoreRequest(deckCards))
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode deck: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.start(); err != nil {
+		return 0, err
+	}
+	if _, err := s.stdin.Write(append(line, '\n')); err != nil {
+		s.stop()
+		return 0, fmt.Errorf("failed to send deck to %s: %w", s.name, err)
+	}
+
+	type reply struct {
+		line []byte
+		err  error
+	}
+	replies := make(chan reply, 1)
+	stdout := s.stdout
+	go func() {
+		line, err := stdout.ReadBytes('\n')
+		replies <- reply{line, err}
+	}()
+
+	var got reply
+	select {
+	case got = <-replies:
+	case <-time.After(s.timeout):
+		s.stop()
+		return 0, fmt.Errorf("%s did not answer within %v", s.name, s.timeout)
+	}
+	if got.err != nil {
+		s.stop()
+		return 0, fmt.Errorf("failed to read score from %s: %w", s.name, got.err)
+	}
+
+	var resp SubprocessScoreResponse
+	if err := json.Unmarshal(got.line, &resp); err != nil {
+		s.stop()
+		return 0, fmt.Errorf("invalid response from %s: %w", s.name, err)
+	}
+	if resp.Error != "" {
+		return 0, fmt.Errorf("%s: %s", s.name, resp.Error)
+	}
+	if resp.Score == nil {
+		return 0, fmt.Errorf("%s answered without a score", s.name)
+	}
+	return *resp.Score, nil
+}
+
+// Close stops the process, if it is running
+func (s *SubprocessScorer) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stop()
+	return nil
+}
+
+// start launches the process unless it is already running
+func (s *SubprocessScorer) start() error {
+	if s.cmd != nil {
+		return nil
+	}
+	if len(s.command) == 0 {
+		return fmt.Errorf("%s: no command", s.name)
+	}
+	cmd := exec.Command(s.command[0], s.command[1:]...)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("failed to start %s: %w", s.name, err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to start %s: %w", s.name, err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start %s: %w", s.name, err)
+	}
+	s.cmd, s.stdin, s.stdout = cmd, stdin, bufio.NewReader(stdout)
+	return nil
+}
+
+// stop closes stdin, kills the process, and waits for it to exit
+func (s *SubprocessScorer) stop() {
+	if s.cmd == nil {
+		return
+	}
+	_ = s.stdin.Close()
+	_ = s.cmd.Process.Kill()
+	_ = s.cmd.Wait()
+	s.cmd, s.stdin, s.stdout = nil, nil, nil
+}
+
+func newSubprocessScoreRequest(deckCards []deck.CardCandidate) SubprocessScoreRequest {
+	req := SubprocessScoreRequest{
+		Deck:      make([]SubprocessCard, len(deckCards)),
+		AvgElixir: calculateAvgElixir(deckCards),
+	}
+	for i, card := range deckCards {
+		req.Deck[i] = SubprocessCard{
+			Name:           card.Name,
+			Level:          card.Level,
+			MaxLevel:       card.MaxLevel,
+			Rarity:         card.Rarity,
+			Elixir:         card.Elixir,
+			EvolutionLevel: card.EvolutionLevel,
+		}
+		if card.Role != nil {
+			req.Deck[i].Role = string(*card.Role)
+		}
+	}
+	return req
+}
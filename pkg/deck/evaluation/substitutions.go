@@ -0,0 +1,306 @@
+package evaluation
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+// SubstitutionReason says why a deck card needs a stand-in
+type SubstitutionReason string
+
+const (
+	// SubstitutionMissing marks a card the player does not own
+	SubstitutionMissing SubstitutionReason = "missing"
+	// SubstitutionUnderleveled marks an owned card too far below the rest of
+	// the deck to be worth leveling
+	SubstitutionUnderleveled SubstitutionReason = "underleveled"
+)
+
+// Substitution is an owned card suggested in place of a deck card the
+// player lacks or cannot afford to level
+type Substitution struct {
+	Card       string             `json:"card"`
+	Substitute string             `json:"substitute"`
+	Reason     SubstitutionReason `json:"reason"`
+
+	// Similarity (0-1) is how closely the substitute matches the card by
+	// role, elixir cost, card embedding, and synergy with the rest of the
+	// deck
+	Similarity float64 `json:"similarity"`
+
+	// ScoreBefore and ScoreAfter are the deck's overall scores without and
+	// with the swap
+	ScoreBefore float64 `json:"score_before"`
+	ScoreAfter  float64 `json:"score_after"`
+	ScoreDelta  float64 `json:"score_delta"`
+}
+
+// SubstitutionOptions configures SuggestSubstitutions
+type SubstitutionOptions struct {
+	// MaxPerCard limits the substitutes suggested for each card
+	MaxPerCard int
+
+	// LevelGap is how many more levels below max than the deck's other
+	// cards an owned card must be to count as underleveled; 0 only looks
+	// at missing cards
+	LevelGap int
+
+	// Evaluate is passed to EvaluateWithOptions when scoring swaps
+	Evaluate EvaluateOptions
+}
+
+// DefaultSubstitutionOptions suggests up to two substitutes per card and
+// treats cards three levels behind the deck as underleveled
+func DefaultSubstitutionOptions() SubstitutionOptions {
+	return SubstitutionOptions{MaxPerCard: 2, LevelGap: 3}
+}
+
+// Similarity weights; a card listed as a known alternative counts as a role
+// match even when the roles differ (e.g. Inferno Tower and Inferno Dragon)
+const (
+	substituteRoleWeight      = 0.4
+	substituteElixirWeight    = 0.2
+	substituteEmbeddingWeight = 0.25
+	substituteSynergyWeight   = 0.15
+
+	// substituteEmbeddingMatch is the card embedding similarity at which a
+	// card of another role and cost is still considered a stand-in
+	substituteEmbeddingMatch = 0.85
+)
+
+// SuggestSubstitutions finds owned stand-ins for deck cards the player lacks
+// or has badly underleveled, closest first, with the score impact of each
+// swap. It returns nil without a player collection.
+//
+// Substitutes are scored as far below max as the replaced card, less any
+// levels they are ahead by in the collection, so the score impact reflects
+// the deck's level scale.
+func SuggestSubstitutions(
+	deckCards []deck.CardCandidate,
+	synergyDB *deck.SynergyDatabase,
+	playerContext *PlayerContext,
+	opts SubstitutionOptions,
+) []Substitution {
+	if playerContext == nil || len(playerContext.Collection) == 0 || len(deckCards) == 0 {
+		return nil
+	}
+	if opts.MaxPerCard <= 0 {
+		opts.MaxPerCard = DefaultSubstitutionOptions().MaxPerCard
+	}
+	if synergyDB == nil {
+		synergyDB = deck.NewSynergyDatabase()
+	}
+
+	needs := substitutionNeeds(deckCards, playerContext, opts.LevelGap)
+	if len(needs) == 0 {
+		return nil
+	}
+
+	baseline := EvaluateWithOptions(deckCards, synergyDB, playerContext, opts.Evaluate).OverallScore
+	inDeck := make(map[string]bool, len(deckCards))
+	for _, card := range deckCards {
+		inDeck[card.Name] = true
+	}
+
+	var substitutions []Substitution
+	for i, original := range deckCards {
+		reason, ok := needs[i]
+		if !ok {
+			continue
+		}
+		rest := slices.Delete(slices.Clone(deckCards), i, i+1)
+		candidates := rankSubstitutes(original, rest, inDeck, synergyDB, playerContext, reason)
+		for _, candidate := range candidates[:min(opts.MaxPerCard, len(candidates))] {
+			swapped := slices.Clone(deckCards)
+			swapped[i] = candidate.card
+			after := EvaluateWithOptions(swapped, synergyDB, playerContext, opts.Evaluate).OverallScore
+			substitutions = append(substitutions, Substitution{
+				Card:        original.Name,
+				Substitute:  candidate.card.Name,
+				Reason:      reason,
+				Similarity:  math.Round(candidate.similarity*100) / 100,
+				ScoreBefore: baseline,
+				ScoreAfter:  after,
+				ScoreDelta:  after - baseline,
+			})
+		}
+	}
+	return substitutions
+}
+
+// substitutionNeeds maps the index of each deck card that needs a stand-in
+// to the reason
+func substitutionNeeds(deckCards []deck.CardCandidate, playerContext *PlayerContext, levelGap int) map[int]SubstitutionReason {
+	needs := make(map[int]SubstitutionReason)
+	var gaps []int
+	for i, card := range deckCards {
+		info, owned := playerContext.Collection[card.Name]
+		if !owned {
+			needs[i] = SubstitutionMissing
+			continue
+		}
+		gaps = append(gaps, levelsBelowMax(info))
+	}
+	if levelGap <= 0 || len(gaps) < 2 {
+		return needs
+	}
+
+	for i, card := range deckCards {
+		info, owned := playerContext.Collection[card.Name]
+		if !owned {
+			continue
+		}
+		// Compare against the median of the deck's other owned cards so
+		// one badly underleveled card does not hide another
+		gap := levelsBelowMax(info)
+		others := slices.Clone(gaps)
+		j := slices.Index(others, gap)
+		others = slices.Delete(others, j, j+1)
+		slices.Sort(others)
+		if gap-others[len(others)/2] >= levelGap {
+			needs[i] = SubstitutionUnderleveled
+		}
+	}
+	return needs
+}
+
+func levelsBelowMax(info CardLevelInfo) int {
+	return max(info.MaxLevel-info.Level, 0)
+}
+
+type rankedSubstitute struct {
+	card       deck.CardCandidate
+	similarity float64
+}
+
+// rankSubstitutes orders the owned cards that could replace original, most
+// similar first
+func rankSubstitutes(
+	original deck.CardCandidate,
+	rest []deck.CardCandidate,
+	inDeck map[string]bool,
+	synergyDB *deck.SynergyDatabase,
+	playerContext *PlayerContext,
+	reason SubstitutionReason,
+) []rankedSubstitute {
+	originalRole := substituteRole(original)
+	originalElixir := config.GetCardElixir(original.Name, original.Elixir)
+	originalGap := 0
+	if info, owned := playerContext.Collection[original.Name]; owned {
+		originalGap = levelsBelowMax(info)
+	}
+	known := make(map[string]bool)
+	for _, alt := range getSimilarCards(original) {
+		known[alt.Name] = true
+	}
+	embedding := deck.DefaultCardEmbedding()
+	restHasChampion := slices.ContainsFunc(rest, func(card deck.CardCandidate) bool {
+		return strings.EqualFold(card.Rarity, "Champion")
+	})
+
+	var ranked []rankedSubstitute
+	for name, info := range playerContext.Collection {
+		if inDeck[name] || !playerContext.IsCardUnlockedInArena(name) {
+			continue
+		}
+		if restHasChampion && strings.EqualFold(info.Rarity, "Champion") {
+			continue
+		}
+		// An underleveled card's stand-in has to be better leveled
+		gap := levelsBelowMax(info)
+		if reason == SubstitutionUnderleveled && gap >= originalGap {
+			continue
+		}
+
+		role := deck.CardRole(config.GetCardRole(name))
+		roleMatch := known[name] || (role != "" && role == originalRole)
+		elixir := config.GetCardElixir(name, 0)
+		elixirMatch := max(0, 1-math.Abs(float64(elixir-originalElixir))/4)
+		embeddingMatch := embedding.Similarity(original.Name, name)
+		if !roleMatch && elixirMatch < 0.75 && embeddingMatch < substituteEmbeddingMatch {
+			continue
+		}
+
+		similarity := substituteElixirWeight*elixirMatch +
+			substituteEmbeddingWeight*embeddingMatch +
+			substituteSynergyWeight*bestSynergy(name, rest, synergyDB)
+		if roleMatch {
+			similarity += substituteRoleWeight
+		}
+		ranked = append(ranked, rankedSubstitute{
+			card:       substituteCandidate(name, info, original, gap, originalGap, role, elixir),
+			similarity: similarity,
+		})
+	}
+
+	slices.SortFunc(ranked, func(a, b rankedSubstitute) int {
+		if c := cmp.Compare(b.similarity, a.similarity); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.card.Name, b.card.Name)
+	})
+	return ranked
+}
+
+func substituteRole(card deck.CardCandidate) deck.CardRole {
+	if card.Role != nil {
+		return *card.Role
+	}
+	return deck.CardRole(config.GetCardRole(card.Name))
+}
+
+// bestSynergy returns the card's strongest synergy with the rest of the deck
+func bestSynergy(name string, rest []deck.CardCandidate, synergyDB *deck.SynergyDatabase) float64 {
+	best := 0.0
+	for _, card := range rest {
+		best = max(best, synergyDB.GetSynergy(name, card.Name))
+	}
+	return best
+}
+
+func substituteCandidate(name string, info CardLevelInfo, original deck.CardCandidate, gap, originalGap int, role deck.CardRole, elixir int) deck.CardCandidate {
+	// Keep the replaced card's distance from max, less the levels the
+	// substitute is ahead by in the collection
+	maxLevel := info.MaxLevel
+	if maxLevel <= 0 {
+		maxLevel = original.MaxLevel
+	}
+	below := max(original.MaxLevel-original.Level, 0)
+	if originalGap > gap {
+		below = max(below-(originalGap-gap), 0)
+	}
+	candidate := deck.CardCandidate{
+		Name:              name,
+		Level:             max(maxLevel-below, 1),
+		MaxLevel:          maxLevel,
+		Rarity:            info.Rarity,
+		Elixir:            elixir,
+		EvolutionLevel:    info.EvolutionLevel,
+		MaxEvolutionLevel: info.MaxEvolutionLevel,
+	}
+	if role != "" {
+		candidate.Role = &role
+	}
+	candidate.Stats = deck.CombatStatsFor(nil, name, candidate.StandardLevel())
+	return candidate
+}
+
+// FormatSubstitutions renders substitutions as an indented list, one line per
+// suggested swap
+func FormatSubstitutions(substitutions []Substitution) string {
+	if len(substitutions) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for _, sub := range substitutions {
+		fmt.Fprintf(&b, "  %s → %s (%s, %.0f%% similar): %.2f → %.2f (%+.2f)\n",
+			sub.Card, sub.Substitute, sub.Reason, sub.Similarity*100, sub.ScoreBefore, sub.ScoreAfter, sub.ScoreDelta)
+	}
+	return b.String()
+}
@@ -0,0 +1,97 @@
+package evaluation
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+// substitutionTestContext owns every card in names at 14/16, plus extras
+func substitutionTestContext(names []string, extras map[string]CardLevelInfo) *PlayerContext {
+	ctx := &PlayerContext{Collection: make(map[string]CardLevelInfo)}
+	for _, name := range names {
+		ctx.Collection[name] = CardLevelInfo{Level: 14, MaxLevel: 16, Rarity: "Common"}
+	}
+	for name, info := range extras {
+		ctx.Collection[name] = info
+	}
+	return ctx
+}
+
+func substitutesFor(subs []Substitution, card string) []string {
+	var names []string
+	for _, sub := range subs {
+		if sub.Card == card {
+			names = append(names, sub.Substitute)
+		}
+	}
+	return names
+}
+
+func TestSuggestSubstitutionsForMissingCards(t *testing.T) {
+	cards := gameModeTestDeck("Hog Rider", "Ice Spirit", "Skeletons", "The Log", "Inferno Tower", "Musketeer", "Fireball", "Ice Golem")
+	owned := []string{"Hog Rider", "Ice Spirit", "Skeletons", "The Log", "Musketeer", "Ice Golem", "Knight", "Archers"}
+	ctx := substitutionTestContext(owned, map[string]CardLevelInfo{
+		"Poison":         {Level: 12, MaxLevel: 16, Rarity: "Epic"},
+		"Inferno Dragon": {Level: 12, MaxLevel: 16, Rarity: "Legendary"},
+	})
+
+	subs := SuggestSubstitutions(cards, deck.NewSynergyDatabase(), ctx, DefaultSubstitutionOptions())
+	if got := substitutesFor(subs, "Fireball"); len(got) == 0 || got[0] != "Poison" {
+		t.Errorf("Fireball substitutes = %v, want Poison first", got)
+	}
+	if got := substitutesFor(subs, "Inferno Tower"); !slices.Contains(got, "Inferno Dragon") {
+		t.Errorf("Inferno Tower substitutes = %v, want Inferno Dragon", got)
+	}
+	for _, sub := range subs {
+		if sub.Reason != SubstitutionMissing {
+			t.Errorf("%s → %s reason = %q, want missing", sub.Card, sub.Substitute, sub.Reason)
+		}
+		if sub.ScoreDelta != sub.ScoreAfter-sub.ScoreBefore || sub.Similarity <= 0 || sub.Similarity > 1 {
+			t.Errorf("%s → %s: delta %.2f, similarity %.2f", sub.Card, sub.Substitute, sub.ScoreDelta, sub.Similarity)
+		}
+	}
+	if len(substitutesFor(subs, "Fireball")) > DefaultSubstitutionOptions().MaxPerCard {
+		t.Errorf("more than MaxPerCard substitutes: %v", substitutesFor(subs, "Fireball"))
+	}
+
+	// Output order follows the deck, so repeated runs agree
+	again := SuggestSubstitutions(cards, deck.NewSynergyDatabase(), ctx, DefaultSubstitutionOptions())
+	if !slices.Equal(subs, again) {
+		t.Error("substitutions are not deterministic")
+	}
+
+	text := FormatSubstitutions(subs)
+	if !strings.Contains(text, "Fireball → Poison (missing") {
+		t.Errorf("formatted substitutions missing the Fireball swap:\n%s", text)
+	}
+}
+
+func TestSuggestSubstitutionsForUnderleveledCards(t *testing.T) {
+	cards := gameModeTestDeck(cheapCycleDeck...)
+	ctx := substitutionTestContext(cheapCycleDeck, map[string]CardLevelInfo{
+		"Musketeer": {Level: 9, MaxLevel: 16, Rarity: "Rare"},
+		"Hunter":    {Level: 11, MaxLevel: 14, Rarity: "Epic"},
+		"Wizard":    {Level: 8, MaxLevel: 16, Rarity: "Rare"},
+	})
+
+	subs := SuggestSubstitutions(cards, nil, ctx, DefaultSubstitutionOptions())
+	got := substitutesFor(subs, "Musketeer")
+	if !slices.Contains(got, "Hunter") || slices.Contains(got, "Wizard") {
+		t.Errorf("Musketeer substitutes = %v, want the better-leveled Hunter only", got)
+	}
+	if len(subs) != len(got) || subs[0].Reason != SubstitutionUnderleveled {
+		t.Errorf("substitutions = %+v, want only the underleveled Musketeer", subs)
+	}
+
+	opts := DefaultSubstitutionOptions()
+	opts.LevelGap = 0
+	if subs := SuggestSubstitutions(cards, nil, ctx, opts); subs != nil {
+		t.Errorf("LevelGap 0 substitutions = %+v, want none for an owned deck", subs)
+	}
+	if subs := SuggestSubstitutions(cards, nil, nil, DefaultSubstitutionOptions()); subs != nil {
+		t.Errorf("no player context: %+v", subs)
+	}
+}
@@ -0,0 +1,193 @@
+package evaluation
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// TrophyBand is a stretch of the trophy road whose meta differs enough to
+// change what scores well. A deck that is great at 9000 trophies can be bad
+// in mid-ladder, where Mega Knight and other punishing tanks dominate.
+type TrophyBand string
+
+const (
+	// TrophyBandNone leaves scoring unchanged
+	TrophyBandNone TrophyBand = ""
+	// TrophyBandLow is the early trophy road, below 3000
+	TrophyBandLow TrophyBand = "low"
+	// TrophyBandMid is mid-ladder, 3000-5999
+	TrophyBandMid TrophyBand = "mid"
+	// TrophyBandHigh is the late trophy road, 6000-8999
+	TrophyBandHigh TrophyBand = "high"
+	// TrophyBandTop is 9000 and up, where the competitive meta applies
+	TrophyBandTop TrophyBand = "top"
+)
+
+// TrophyBandPreset is the weight profile and assumed meta for a TrophyBand
+type TrophyBandPreset struct {
+	Band        TrophyBand     `json:"band"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Weights     OverallWeights `json:"weights"`
+
+	// MinTrophies and MaxTrophies bound the band; MaxTrophies is 0 for the
+	// open-ended top band
+	MinTrophies int `json:"min_trophies"`
+	MaxTrophies int `json:"max_trophies,omitempty"`
+
+	// Threats are the win conditions assumed popular in the band, with the
+	// share of decks playing them. They stand in for a meta snapshot when
+	// none is given.
+	Threats map[string]float64 `json:"threats"`
+}
+
+var trophyBandPresets = []TrophyBandPreset{
+	{
+		Band:        TrophyBandLow,
+		Name:        "Early Ladder",
+		Description: "Lower card levels and simple pushes; cheap, forgiving decks and solid defense win",
+		Weights: OverallWeights{
+			Attack: 0.24, Defense: 0.24, Synergy: 0.16, Versatility: 0.12, F2P: 0.14, Playability: 0.10,
+		},
+		MinTrophies: 0,
+		MaxTrophies: 2999,
+		Threats: map[string]float64{
+			"Giant": 0.28, "Battle Ram": 0.20, "Hog Rider": 0.18, "Balloon": 0.16, "P.E.K.K.A": 0.14, "Goblin Barrel": 0.12,
+		},
+	},
+	{
+		Band:        TrophyBandMid,
+		Name:        "Mid Ladder",
+		Description: "Mega Knight and heavy tank spam dominate; defense against big pushes matters most",
+		Weights: OverallWeights{
+			Attack: 0.22, Defense: 0.27, Synergy: 0.18, Versatility: 0.13, F2P: 0.10, Playability: 0.10,
+		},
+		MinTrophies: 3000,
+		MaxTrophies: 5999,
+		Threats: map[string]float64{
+			"Mega Knight": 0.32, "Royal Giant": 0.18, "Balloon": 0.16, "P.E.K.K.A": 0.14, "Hog Rider": 0.14,
+			"Goblin Giant": 0.10, "Goblin Barrel": 0.10,
+		},
+	},
+	{
+		Band:        TrophyBandHigh,
+		Name:        "High Ladder",
+		Description: "Standard ladder scoring against a broad meta",
+		Weights:     defaultOverallWeights,
+		MinTrophies: 6000,
+		MaxTrophies: 8999,
+		Threats: map[string]float64{
+			"Hog Rider": 0.20, "Mega Knight": 0.16, "Royal Giant": 0.14, "Goblin Barrel": 0.14, "Balloon": 0.12,
+			"Golem": 0.10, "Graveyard": 0.10, "Miner": 0.10,
+		},
+	},
+	{
+		Band:        TrophyBandTop,
+		Name:        "Top Ladder",
+		Description: "Skilled opponents punish gaps; synergy and versatility count for more than card levels",
+		Weights: OverallWeights{
+			Attack: 0.22, Defense: 0.22, Synergy: 0.23, Versatility: 0.17, F2P: 0.06, Playability: 0.10,
+		},
+		MinTrophies: 9000,
+		Threats: map[string]float64{
+			"Hog Rider": 0.22, "Goblin Barrel": 0.18, "Miner": 0.15, "Graveyard": 0.12, "Golem": 0.12,
+			"Lava Hound": 0.10, "X-Bow": 0.08, "Royal Giant": 0.08, "Mortar": 0.06,
+		},
+	},
+}
+
+// TrophyBandPresets returns every built-in band, lowest first
+func TrophyBandPresets() []TrophyBandPreset {
+	presets := make([]TrophyBandPreset, len(trophyBandPresets))
+	copy(presets, trophyBandPresets)
+	return presets
+}
+
+// LookupTrophyBand returns the preset for band. It reports false for
+// TrophyBandNone and unknown bands.
+func LookupTrophyBand(band TrophyBand) (TrophyBandPreset, bool) {
+	for _, preset := range trophyBandPresets {
+		if preset.Band == band {
+			return preset, true
+		}
+	}
+	return TrophyBandPreset{}, false
+}
+
+// TrophyBandFor returns the band containing trophies
+func TrophyBandFor(trophies int) TrophyBand {
+	for _, preset := range slices.Backward(trophyBandPresets) {
+		if trophies >= preset.MinTrophies {
+			return preset.Band
+		}
+	}
+	return TrophyBandLow
+}
+
+// ParseTrophyBand parses a band name such as "mid" or "Mid Ladder". Empty
+// and "none" mean TrophyBandNone.
+func ParseTrophyBand(value string) (TrophyBand, error) {
+	key := strings.ToLower(strings.TrimSpace(value))
+	key = strings.TrimSuffix(strings.NewReplacer("_", "-", " ", "-").Replace(key), "-ladder")
+	switch key {
+	case "", "none":
+		return TrophyBandNone, nil
+	case "early":
+		return TrophyBandLow, nil
+	}
+	for _, preset := range trophyBandPresets {
+		if string(preset.Band) == key {
+			return preset.Band, nil
+		}
+	}
+	return "", fmt.Errorf("unknown trophy band: %s (supported: none, %s)", value, strings.Join(TrophyBandNames(), ", "))
+}
+
+// TrophyBandNames lists the band names accepted by ParseTrophyBand
+func TrophyBandNames() []string {
+	names := make([]string, len(trophyBandPresets))
+	for i, preset := range trophyBandPresets {
+		names[i] = string(preset.Band)
+	}
+	return names
+}
+
+// Range describes the band's trophies, e.g. "3000-5999" or "9000+"
+func (p TrophyBandPreset) Range() string {
+	if p.MaxTrophies == 0 {
+		return fmt.Sprintf("%d+", p.MinTrophies)
+	}
+	return fmt.Sprintf("%d-%d", p.MinTrophies, p.MaxTrophies)
+}
+
+// MetaContext returns the band's assumed meta
+func (p TrophyBandPreset) MetaContext() *MetaContext {
+	return &MetaContext{
+		Source:    fmt.Sprintf("%s trophy band (%s)", p.Name, p.Range()),
+		CardUsage: maps.Clone(p.Threats),
+	}
+}
+
+// resolveTrophyBand picks the band for an evaluation: the explicit option,
+// else the band inferred from the player's trophies. Event game modes are
+// not played on the ladder, so they never use a band.
+func resolveTrophyBand(playerContext *PlayerContext, opts EvaluateOptions) (TrophyBandPreset, bool) {
+	if !LookupGameMode(opts.GameMode).Standard() {
+		return TrophyBandPreset{}, false
+	}
+	band := opts.TrophyBand
+	if band == TrophyBandNone && playerContext != nil {
+		band = playerContext.TrophyBand
+	}
+	return LookupTrophyBand(band)
+}
+
+// overallWeights returns the category weights the result was scored with
+func (r *EvaluationResult) overallWeights() OverallWeights {
+	if band, ok := LookupTrophyBand(r.TrophyBand); ok {
+		return band.Weights
+	}
+	return LookupGameMode(r.GameMode).Weights
+}
@@ -0,0 +1,143 @@
+package evaluation
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+func TestTrophyBandFor(t *testing.T) {
+	tests := map[int]TrophyBand{
+		-10:   TrophyBandLow,
+		0:     TrophyBandLow,
+		2999:  TrophyBandLow,
+		3000:  TrophyBandMid,
+		5999:  TrophyBandMid,
+		6000:  TrophyBandHigh,
+		9000:  TrophyBandTop,
+		12500: TrophyBandTop,
+	}
+	for trophies, want := range tests {
+		if got := TrophyBandFor(trophies); got != want {
+			t.Errorf("TrophyBandFor(%d) = %q, want %q", trophies, got, want)
+		}
+	}
+}
+
+func TestParseTrophyBand(t *testing.T) {
+	tests := map[string]TrophyBand{
+		"":           TrophyBandNone,
+		"none":       TrophyBandNone,
+		"MID":        TrophyBandMid,
+		"Mid Ladder": TrophyBandMid,
+		"early":      TrophyBandLow,
+		"top_ladder": TrophyBandTop,
+	}
+	for input, want := range tests {
+		got, err := ParseTrophyBand(input)
+		if err != nil || got != want {
+			t.Errorf("ParseTrophyBand(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseTrophyBand("legendary"); err == nil || !strings.Contains(err.Error(), "mid") {
+		t.Errorf("unknown band error = %v, want supported bands listed", err)
+	}
+}
+
+func TestTrophyBandPresets(t *testing.T) {
+	counters := getCountersDatabase()
+	for _, preset := range TrophyBandPresets() {
+		w := preset.Weights
+		if sum := w.Attack + w.Defense + w.Synergy + w.Versatility + w.F2P + w.Playability; math.Abs(sum-1) > 1e-9 {
+			t.Errorf("%s weights sum to %.3f", preset.Band, sum)
+		}
+		if got := len(preset.MetaContext().Threats()); got != min(len(preset.Threats), metaMaxThreats) {
+			t.Errorf("%s: %d of %d threats are scorable win conditions", preset.Band, got, len(preset.Threats))
+		}
+		for card := range preset.Threats {
+			if _, ok := counters.CountersFor(card); !ok || !deck.IsWinCondition(card) {
+				t.Errorf("%s threat %s is not a win condition with counter data", preset.Band, card)
+			}
+		}
+	}
+	if _, ok := LookupTrophyBand(TrophyBandNone); ok {
+		t.Error("TrophyBandNone should have no preset")
+	}
+}
+
+func TestEvaluateWithTrophyBand(t *testing.T) {
+	cards := gameModeTestDeck(cheapCycleDeck...)
+	synergyDB := deck.NewSynergyDatabase()
+
+	base := Evaluate(cards, synergyDB, nil)
+	if base.TrophyBand != TrophyBandNone || base.MetaCounter != nil {
+		t.Fatalf("no band: trophy band %q, meta counter %v; want neither", base.TrophyBand, base.MetaCounter)
+	}
+
+	mid := EvaluateWithOptions(cards, synergyDB, nil, EvaluateOptions{TrophyBand: TrophyBandMid})
+	if mid.TrophyBand != TrophyBandMid || mid.MetaCounter == nil || mid.MetaAnalysis == nil {
+		t.Fatalf("mid band: trophy band %q, meta counter %v", mid.TrophyBand, mid.MetaCounter)
+	}
+	if !strings.HasPrefix(mid.MetaAnalysis.Details[0], "Assumed meta: Mid Ladder trophy band (3000-5999)") {
+		t.Errorf("first meta detail = %q, want the assumed band meta", mid.MetaAnalysis.Details[0])
+	}
+	if mid.OverallScore == base.OverallScore {
+		t.Error("the mid band should change the overall score")
+	}
+
+	// The player's trophies pick the band when no option is given
+	fromPlayer := EvaluateWithOptions(cards, synergyDB, &PlayerContext{TrophyBand: TrophyBandTop}, EvaluateOptions{})
+	if fromPlayer.TrophyBand != TrophyBandTop {
+		t.Errorf("player context band = %q, want top", fromPlayer.TrophyBand)
+	}
+	override := EvaluateWithOptions(cards, synergyDB, &PlayerContext{TrophyBand: TrophyBandTop}, EvaluateOptions{TrophyBand: TrophyBandLow})
+	if override.TrophyBand != TrophyBandLow {
+		t.Errorf("explicit band = %q, want low", override.TrophyBand)
+	}
+
+	// A meta snapshot replaces the assumed meta
+	snapshot := &MetaContext{Source: "snapshot", CardUsage: map[string]float64{"Golem": 0.5}}
+	withSnapshot := EvaluateWithOptions(cards, synergyDB, nil, EvaluateOptions{TrophyBand: TrophyBandMid, Meta: snapshot})
+	if withSnapshot.MetaAnalysis == nil || strings.HasPrefix(withSnapshot.MetaAnalysis.Details[0], "Assumed meta") {
+		t.Errorf("snapshot meta analysis = %+v, want the snapshot's threats only", withSnapshot.MetaAnalysis)
+	}
+
+	// Event modes are not ladder play
+	triple := EvaluateWithOptions(cards, synergyDB, nil, EvaluateOptions{TrophyBand: TrophyBandMid, GameMode: GameModeTripleElixir})
+	if triple.TrophyBand != TrophyBandNone {
+		t.Errorf("triple elixir trophy band = %q, want none", triple.TrophyBand)
+	}
+}
+
+func TestExplainUsesTrophyBandWeights(t *testing.T) {
+	cards := gameModeTestDeck(heavyBeatdown...)
+	result := EvaluateWithOptions(cards, nil, nil, EvaluateOptions{TrophyBand: TrophyBandMid})
+	explanation := Explain(&result, nil)
+
+	band, _ := LookupTrophyBand(TrophyBandMid)
+	if explanation.Contributions[1].Weight != band.Weights.Defense {
+		t.Errorf("defense weight = %.2f, want the mid band's %.2f", explanation.Contributions[1].Weight, band.Weights.Defense)
+	}
+	text := FormatExplainText(&explanation)
+	for _, want := range []string{"Trophy band: Mid Ladder (3000-5999)", "Mid Ladder trophy band weights"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("explanation missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestNewPlayerContextInfersTrophyBand(t *testing.T) {
+	ctx := NewPlayerContextFromPlayer(&clashroyale.Player{Tag: "#ABC", Trophies: 4200})
+	if ctx.Trophies != 4200 || ctx.TrophyBand != TrophyBandMid {
+		t.Errorf("trophies %d, band %q; want 4200 and mid", ctx.Trophies, ctx.TrophyBand)
+	}
+
+	variant, _ := EvaluationVariant(ctx, EvaluateOptions{})
+	noBand, _ := EvaluationVariant(&PlayerContext{PlayerTag: "#ABC"}, EvaluateOptions{})
+	if variant == noBand || !strings.Contains(variant, "band=mid") {
+		t.Errorf("cache variant %q should record the band", variant)
+	}
+}
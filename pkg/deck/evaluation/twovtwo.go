@@ -0,0 +1,279 @@
+package evaluation
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+// ============================================================================
+// 2v2 Evaluation
+// ============================================================================
+
+// EvaluationMode selects the scoring profile used by EvaluateWithMode
+type EvaluationMode string
+
+const (
+	// ModeLadder is the standard 1v1 scoring profile
+	ModeLadder EvaluationMode = "1v1"
+	// Mode2v2 scores a deck for the 2v2 queue, where a partner covers some roles
+	Mode2v2 EvaluationMode = "2v2"
+)
+
+const (
+	// twoVTwoFlawPenaltyScale halves critical-flaw penalties in 2v2 because the
+	// partner deck can supply a missing win condition, spell, or anti-air.
+	twoVTwoFlawPenaltyScale = 0.5
+
+	// Team score weights for joint pair evaluation
+	pairWeightDecks    = 0.6
+	pairWeightCombo    = 0.2
+	pairWeightCoverage = 0.2
+
+	// pairComboTopPairs is how many cross-deck synergies count toward combo
+	// score; that many pairs at pairComboStrongPair earn full marks.
+	pairComboTopPairs   = 4
+	pairComboStrongPair = 0.8
+)
+
+// ParseEvaluationMode parses a mode name ("1v1", "ladder", "2v2")
+func ParseEvaluationMode(value string) (EvaluationMode, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "1v1", "ladder":
+		return ModeLadder, nil
+	case "2v2":
+		return Mode2v2, nil
+	default:
+		return "", fmt.Errorf("unknown evaluation mode: %s (supported: 1v1, 2v2)", value)
+	}
+}
+
+// scoreRoleDiversity2v2 is scoreRoleDiversity with lower thresholds: doubling
+// up on a role is fine when a partner brings the rest.
+func scoreRoleDiversity2v2(uniqueRoles int) float64 {
+	if uniqueRoles >= 4 {
+		return 10.0
+	} else if uniqueRoles >= 3 {
+		return 8.0
+	} else if uniqueRoles >= 2 {
+		return 5.5
+	}
+	return float64(uniqueRoles) * 2.5
+}
+
+// scoreTargetCoverage2v2 relaxes targeting requirements for 2v2
+func scoreTargetCoverage2v2(targetsAir, targetsGround int) float64 {
+	if targetsAir >= 2 && targetsGround >= 5 {
+		return 10.0
+	} else if targetsAir >= 1 && targetsGround >= 4 {
+		return 7.5
+	}
+	return (float64(targetsAir) + float64(targetsGround)) * 0.75
+}
+
+// ScoreVersatility2v2 calculates versatility for 2v2 play (0-10 scale).
+// Duplicate roles are penalized less than in ScoreVersatility.
+func ScoreVersatility2v2(deckCards []deck.CardCandidate) CategoryScore {
+	if len(deckCards) == 0 {
+		return CreateCategoryScore(0, "No cards in deck")
+	}
+
+	metrics := collectRoleStats(deckCards)
+
+	roleScore := scoreRoleDiversity2v2(len(metrics.roleCount))
+	elixirScore := scoreElixirVariety(len(metrics.elixirVariety))
+	targetScore := scoreTargetCoverage2v2(metrics.targetsAir, metrics.targetsGround)
+
+	score := clampScoreToTen((roleScore * 0.4) + (elixirScore * 0.3) + (targetScore * 0.3))
+	assessment := generateVersatilityAssessment(len(metrics.roleCount), len(metrics.elixirVariety), score)
+
+	return CreateCategoryScore(score, assessment+" (2v2 profile)")
+}
+
+// TeamCoverage summarizes what two 2v2 decks bring to the table together
+type TeamCoverage struct {
+	WinConditions int `json:"win_conditions"`
+	AntiAir       int `json:"anti_air"`
+	Spells        int `json:"spells"`
+	BigSpells     int `json:"big_spells"`
+	Buildings     int `json:"buildings"`
+	// ElixirGap is the absolute difference between the decks' average elixir
+	ElixirGap float64 `json:"elixir_gap"`
+}
+
+// PairEvaluationResult is the joint evaluation of two decks for the 2v2 queue
+type PairEvaluationResult struct {
+	DeckA EvaluationResult `json:"deck_a"`
+	DeckB EvaluationResult `json:"deck_b"`
+
+	// CrossSynergies are synergy pairs between a card in deck A and a card in deck B
+	CrossSynergies []deck.SynergyPair `json:"cross_synergies"`
+	// SharedCards appear in both decks and reduce the team's card variety
+	SharedCards []string `json:"shared_cards"`
+
+	Coverage      TeamCoverage `json:"coverage"`
+	ComboScore    float64      `json:"combo_score"`    // 0-10
+	CoverageScore float64      `json:"coverage_score"` // 0-10
+	TeamScore     float64      `json:"team_score"`     // 0-10
+	TeamRating    Rating       `json:"team_rating"`
+	Notes         []string     `json:"notes"`
+}
+
+// EvaluatePair evaluates two decks jointly for 2v2 play. Each deck is scored
+// with the 2v2 profile, then the team score blends both deck scores with
+// cross-deck combo potential and combined role coverage.
+func EvaluatePair(deckA, deckB []deck.CardCandidate, synergyDB *deck.SynergyDatabase, contextA, contextB *PlayerContext) PairEvaluationResult {
+	result := PairEvaluationResult{
+		DeckA: EvaluateWithMode(deckA, synergyDB, contextA, Mode2v2),
+		DeckB: EvaluateWithMode(deckB, synergyDB, contextB, Mode2v2),
+	}
+
+	result.SharedCards = sharedCardNames(deckA, deckB)
+	result.CrossSynergies = findCrossSynergies(deckA, deckB, synergyDB)
+	result.ComboScore = scorePairCombo(result.CrossSynergies, synergyDB != nil)
+
+	result.Coverage = collectTeamCoverage(deckA, deckB)
+	result.Coverage.ElixirGap = math.Abs(result.DeckA.AvgElixir - result.DeckB.AvgElixir)
+	result.CoverageScore, result.Notes = scoreTeamCoverage(result.Coverage, deckA, deckB, len(result.SharedCards))
+
+	avgDeckScore := (result.DeckA.OverallScore + result.DeckB.OverallScore) / 2
+	result.TeamScore = clampScoreToTen(avgDeckScore*pairWeightDecks +
+		result.ComboScore*pairWeightCombo +
+		result.CoverageScore*pairWeightCoverage)
+	result.TeamRating = ScoreToRating(result.TeamScore)
+
+	return result
+}
+
+// findCrossSynergies returns known synergies between the two decks, strongest first
+func findCrossSynergies(deckA, deckB []deck.CardCandidate, synergyDB *deck.SynergyDatabase) []deck.SynergyPair {
+	if synergyDB == nil {
+		return nil
+	}
+
+	var pairs []deck.SynergyPair
+	seen := make(map[string]bool)
+	for _, a := range deckA {
+		for _, b := range deckB {
+			if a.Name == b.Name {
+				continue
+			}
+			pair := synergyDB.GetSynergyPair(a.Name, b.Name)
+			if pair == nil {
+				continue
+			}
+			key := pair.Card1 + "|" + pair.Card2
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			pairs = append(pairs, *pair)
+		}
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].Score > pairs[j].Score
+	})
+	return pairs
+}
+
+// scorePairCombo converts the strongest cross-deck synergies into a 0-10 score
+func scorePairCombo(pairs []deck.SynergyPair, haveDB bool) float64 {
+	if !haveDB {
+		return 5.0
+	}
+	total := 0.0
+	for i, pair := range pairs {
+		if i >= pairComboTopPairs {
+			break
+		}
+		total += pair.Score
+	}
+	return clampScoreToTen(total / (pairComboTopPairs * pairComboStrongPair) * 10.0)
+}
+
+func collectTeamCoverage(decks ...[]deck.CardCandidate) TeamCoverage {
+	var coverage TeamCoverage
+	for _, cards := range decks {
+		for _, card := range cards {
+			switch {
+			case hasRole(card, deck.RoleWinCondition):
+				coverage.WinConditions++
+			case hasRole(card, deck.RoleBuilding):
+				coverage.Buildings++
+			case hasRole(card, deck.RoleSpellBig):
+				coverage.BigSpells++
+				coverage.Spells++
+			case hasRole(card, deck.RoleSpellSmall):
+				coverage.Spells++
+			}
+			if canTargetAir(card) {
+				coverage.AntiAir++
+			}
+		}
+	}
+	return coverage
+}
+
+// scoreTeamCoverage awards up to 2.5 points each for win conditions, anti-air,
+// spells, and complementary tempo, minus a small penalty per shared card.
+func scoreTeamCoverage(coverage TeamCoverage, deckA, deckB []deck.CardCandidate, sharedCount int) (float64, []string) {
+	var notes []string
+	score := 0.0
+
+	winA := len(filterByRole(deckA, deck.RoleWinCondition))
+	winB := len(filterByRole(deckB, deck.RoleWinCondition))
+	switch {
+	case winA > 0 && winB > 0:
+		score += 2.5
+		notes = append(notes, "Both decks bring a win condition for split pressure")
+	case winA > 0 || winB > 0:
+		score += 1.25
+		notes = append(notes, "Only one deck has a win condition - the other plays support")
+	default:
+		notes = append(notes, "⚠️ Team has no win condition")
+	}
+
+	score += math.Min(float64(coverage.AntiAir), 4) * 0.625
+	if coverage.AntiAir < 3 {
+		notes = append(notes, fmt.Sprintf("⚠️ Light team anti-air (%d cards) - vulnerable to air pushes", coverage.AntiAir))
+	}
+
+	score += math.Min(float64(coverage.Spells), 3) / 3 * 1.5
+	if coverage.BigSpells > 0 {
+		score += 1.0
+	} else {
+		notes = append(notes, "⚠️ No big spell across the team for clumped 2v2 pushes")
+	}
+
+	if coverage.ElixirGap >= 0.3 && coverage.ElixirGap <= 1.5 {
+		score += 2.5
+		notes = append(notes, fmt.Sprintf("Complementary tempo (%.1f elixir gap): one deck cycles while the other builds", coverage.ElixirGap))
+	} else {
+		score += 1.5
+	}
+
+	if sharedCount > 0 {
+		score -= float64(sharedCount) * 0.5
+		notes = append(notes, fmt.Sprintf("%d shared card(s) reduce the team's card variety", sharedCount))
+	}
+
+	return clampScoreToTen(score), notes
+}
+
+func sharedCardNames(deckA, deckB []deck.CardCandidate) []string {
+	inA := make(map[string]bool, len(deckA))
+	for _, card := range deckA {
+		inA[card.Name] = true
+	}
+	var shared []string
+	for _, card := range deckB {
+		if inA[card.Name] {
+			shared = append(shared, card.Name)
+		}
+	}
+	return shared
+}
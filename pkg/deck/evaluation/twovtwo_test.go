@@ -0,0 +1,147 @@
+package evaluation
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+func giantDoubleSupportDeck() []deck.CardCandidate {
+	return []deck.CardCandidate{
+		makeCard("Giant", deck.RoleWinCondition, 11, 11, "Rare", 5),
+		makeCard("Musketeer", deck.RoleSupport, 11, 11, "Rare", 4),
+		makeCard("Wizard", deck.RoleSupport, 11, 11, "Rare", 5),
+		makeCard("Mini P.E.K.K.A", deck.RoleSupport, 11, 11, "Rare", 4),
+		makeCard("Baby Dragon", deck.RoleSupport, 11, 11, "Epic", 4),
+		makeCard("Valkyrie", deck.RoleSupport, 11, 11, "Rare", 4),
+		makeCard("Fireball", deck.RoleSpellBig, 11, 11, "Rare", 4),
+		makeCard("Zap", deck.RoleSpellSmall, 11, 11, "Common", 2),
+	}
+}
+
+func hogCycleDeck() []deck.CardCandidate {
+	return []deck.CardCandidate{
+		makeCard("Hog Rider", deck.RoleWinCondition, 11, 11, "Rare", 4),
+		makeCard("Ice Spirit", deck.RoleCycle, 11, 11, "Common", 1),
+		makeCard("Skeletons", deck.RoleCycle, 11, 11, "Common", 1),
+		makeCard("Ice Golem", deck.RoleCycle, 11, 11, "Rare", 2),
+		makeCard("Cannon", deck.RoleBuilding, 11, 11, "Common", 3),
+		makeCard("Archers", deck.RoleSupport, 11, 11, "Common", 3),
+		makeCard("The Log", deck.RoleSpellSmall, 11, 11, "Legendary", 2),
+		makeCard("Poison", deck.RoleSpellBig, 11, 11, "Epic", 4),
+	}
+}
+
+func TestParseEvaluationMode(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    EvaluationMode
+		wantErr bool
+	}{
+		{"", ModeLadder, false},
+		{"1v1", ModeLadder, false},
+		{"Ladder", ModeLadder, false},
+		{"2V2", Mode2v2, false},
+		{"3v3", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseEvaluationMode(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseEvaluationMode(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseEvaluationMode(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestScoreVersatility2v2PenalizesRedundancyLess(t *testing.T) {
+	cards := giantDoubleSupportDeck()
+	ladder := ScoreVersatility(cards)
+	twoVTwo := ScoreVersatility2v2(cards)
+	if twoVTwo.Score <= ladder.Score {
+		t.Errorf("2v2 versatility %.2f should exceed 1v1 versatility %.2f for a role-heavy deck", twoVTwo.Score, ladder.Score)
+	}
+}
+
+func TestEvaluateWithMode2v2SoftensCriticalFlaws(t *testing.T) {
+	// No win condition: a critical flaw on ladder, a support deck in 2v2
+	cards := giantDoubleSupportDeck()
+	cards[0] = makeCard("Knight", deck.RoleSupport, 11, 11, "Common", 3)
+
+	ladder := EvaluateWithMode(cards, nil, nil, ModeLadder)
+	twoVTwo := EvaluateWithMode(cards, nil, nil, Mode2v2)
+
+	if twoVTwo.Mode != Mode2v2 || ladder.Mode != ModeLadder {
+		t.Fatalf("unexpected modes: %q, %q", ladder.Mode, twoVTwo.Mode)
+	}
+	if twoVTwo.OverallScore <= ladder.OverallScore {
+		t.Errorf("2v2 score %.2f should exceed ladder score %.2f when missing a win condition", twoVTwo.OverallScore, ladder.OverallScore)
+	}
+}
+
+func TestEvaluatePair(t *testing.T) {
+	synergyDB := deck.NewSynergyDatabase()
+	result := EvaluatePair(giantDoubleSupportDeck(), hogCycleDeck(), synergyDB, nil, nil)
+
+	if result.DeckA.Mode != Mode2v2 || result.DeckB.Mode != Mode2v2 {
+		t.Errorf("pair decks should be scored with the 2v2 profile")
+	}
+	if result.TeamScore <= 0 || result.TeamScore > 10 {
+		t.Errorf("TeamScore = %.2f, want (0, 10]", result.TeamScore)
+	}
+	if result.Coverage.WinConditions != 2 {
+		t.Errorf("WinConditions = %d, want 2", result.Coverage.WinConditions)
+	}
+	if len(result.SharedCards) != 0 {
+		t.Errorf("SharedCards = %v, want none", result.SharedCards)
+	}
+	for i := 1; i < len(result.CrossSynergies); i++ {
+		if result.CrossSynergies[i].Score > result.CrossSynergies[i-1].Score {
+			t.Fatalf("cross synergies not sorted by score: %+v", result.CrossSynergies)
+		}
+	}
+}
+
+func TestEvaluatePairPenalizesSharedCards(t *testing.T) {
+	synergyDB := deck.NewSynergyDatabase()
+	distinct := EvaluatePair(giantDoubleSupportDeck(), hogCycleDeck(), synergyDB, nil, nil)
+
+	overlapping := hogCycleDeck()
+	overlapping[5] = makeCard("Musketeer", deck.RoleSupport, 11, 11, "Rare", 4)
+	overlapping[7] = makeCard("Fireball", deck.RoleSpellBig, 11, 11, "Rare", 4)
+	shared := EvaluatePair(giantDoubleSupportDeck(), overlapping, synergyDB, nil, nil)
+
+	if len(shared.SharedCards) != 2 {
+		t.Fatalf("SharedCards = %v, want 2 cards", shared.SharedCards)
+	}
+	if shared.CoverageScore >= distinct.CoverageScore {
+		t.Errorf("shared cards should lower coverage: %.2f >= %.2f", shared.CoverageScore, distinct.CoverageScore)
+	}
+}
+
+func TestFormatPairOutputs(t *testing.T) {
+	result := EvaluatePair(giantDoubleSupportDeck(), hogCycleDeck(), deck.NewSynergyDatabase(), nil, nil)
+
+	human := FormatPairHuman(&result)
+	for _, want := range []string{"2v2 TEAM EVALUATION REPORT", "Deck A:", "Deck B:", "TEAM SCORE", "Team Coverage"} {
+		if !strings.Contains(human, want) {
+			t.Errorf("human output missing %q", want)
+		}
+	}
+
+	out, err := FormatPairJSON(&result)
+	if err != nil {
+		t.Fatalf("FormatPairJSON() error = %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal([]byte(out), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if decoded["mode"] != "2v2" {
+		t.Errorf("mode = %v, want 2v2", decoded["mode"])
+	}
+}
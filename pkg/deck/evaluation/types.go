@@ -108,6 +108,9 @@ type EvaluationResult struct {
 	Deck      []string `json:"deck"`
 	AvgElixir float64  `json:"average_elixir"`
 
+	// Mode is the scoring profile used (1v1 ladder or 2v2)
+	Mode EvaluationMode `json:"mode,omitempty"`
+
 	// Category scores (6 categories)
 	Attack      CategoryScore `json:"attack"`
 	Defense     CategoryScore `json:"defense"`
@@ -123,22 +126,53 @@ type EvaluationResult struct {
 	// Archetype detection
 	DetectedArchetype   Archetype `json:"detected_archetype"`
 	ArchetypeConfidence float64   `json:"archetype_confidence"` // 0.0-1.0
+	// HybridPrimary and HybridSecondary name the two archetypes of a hybrid
+	// deck; both are empty unless DetectedArchetype is hybrid
+	HybridPrimary   Archetype `json:"hybrid_primary,omitempty"`
+	HybridSecondary Archetype `json:"hybrid_secondary,omitempty"`
+
+	// TowerTroop is the tower troop the deck was scored with
+	TowerTroop string `json:"tower_troop,omitempty"`
+
+	// GameMode is the event preset the deck was scored for; empty means
+	// standard. GameModeAdjustment is the overall score change it made.
+	GameMode           GameMode `json:"game_mode,omitempty"`
+	GameModeAdjustment float64  `json:"game_mode_adjustment,omitempty"`
+
+	// TrophyBand is the trophy band whose weights and assumed meta the deck
+	// was scored with; empty when none applied
+	TrophyBand TrophyBand `json:"trophy_band,omitempty"`
+
+	// MetaCounter scores how well the deck answers popular win conditions.
+	// Present only when evaluated with a MetaContext.
+	MetaCounter  *CategoryScore   `json:"meta_counter,omitempty"`
+	MetaAnalysis *AnalysisSection `json:"meta_analysis,omitempty"`
+
+	// PluginScores are the results of registered scorer plugins, already
+	// blended into OverallScore
+	PluginScores []PluginScore `json:"plugin_scores,omitempty"`
 
 	// Detailed analysis sections
-	DefenseAnalysis   AnalysisSection `json:"defense_analysis"`
-	AttackAnalysis    AnalysisSection `json:"attack_analysis"`
-	BaitAnalysis      AnalysisSection `json:"bait_analysis"`
-	CycleAnalysis     AnalysisSection `json:"cycle_analysis"`
-	LadderAnalysis    AnalysisSection `json:"ladder_analysis"`
-	EvolutionAnalysis AnalysisSection `json:"evolution_analysis"`
+	DefenseAnalysis     AnalysisSection `json:"defense_analysis"`
+	AttackAnalysis      AnalysisSection `json:"attack_analysis"`
+	BaitAnalysis        AnalysisSection `json:"bait_analysis"`
+	CycleAnalysis       AnalysisSection `json:"cycle_analysis"`
+	LadderAnalysis      AnalysisSection `json:"ladder_analysis"`
+	EvolutionAnalysis   AnalysisSection `json:"evolution_analysis"`
+	DamageRaceAnalysis  AnalysisSection `json:"damage_race_analysis"`
+	OpeningHandAnalysis AnalysisSection `json:"opening_hand_analysis"`
 
 	// Synergy matrix
 	SynergyMatrix SynergyMatrix `json:"synergy_matrix"`
 
+	// CriticalFlaws are compositional flaws penalized on the overall score
+	CriticalFlaws []CriticalFlaw `json:"critical_flaws,omitempty"`
+
 	// Optional features (Task 2.4)
 	DeckLink               *DeckLink               `json:"deck_link,omitempty"`
 	AlternativeSuggestions *AlternativeSuggestions `json:"alternative_suggestions,omitempty"`
 	MissingCardsAnalysis   *MissingCardsAnalysis   `json:"missing_cards_analysis,omitempty"`
+	Substitutions          []Substitution          `json:"substitutions,omitempty"`
 	OverallBreakdown       *OverallScoreBreakdown  `json:"overall_breakdown,omitempty"`
 }
 
@@ -192,3 +226,22 @@ type SynergyMatrix struct {
 	// SynergyCoverage is percentage of cards with synergies
 	SynergyCoverage float64 `json:"synergy_coverage"` // 0.0-100.0
 }
+
+// CriticalFlawCode identifies a critical compositional flaw
+type CriticalFlawCode string
+
+const (
+	FlawNoWinCondition CriticalFlawCode = "no_win_condition"
+	FlawNoSpells       CriticalFlawCode = "no_spells"
+	FlawNoAntiAir      CriticalFlawCode = "no_anti_air"
+)
+
+// CriticalFlaw is a compositional flaw that makes a deck fundamentally
+// unviable and is penalized directly on the overall score
+type CriticalFlaw struct {
+	Code        CriticalFlawCode `json:"code"`
+	Description string           `json:"description"`
+
+	// Penalty is the number of overall-score points deducted
+	Penalty float64 `json:"penalty"`
+}
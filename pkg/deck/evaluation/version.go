@@ -0,0 +1,94 @@
+package evaluation
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ScoringVersion describes one release of the scoring algorithm. Bump
+// CurrentScoringVersion and append an entry whenever a change moves stored
+// scores, so `deck fuzz migrate` knows which decks to re-score.
+type ScoringVersion struct {
+	Version string
+	Changes string
+}
+
+// CurrentScoringVersion is the version recorded with every new score.
+const CurrentScoringVersion = "1.3.0"
+
+var scoringVersions = []ScoringVersion{
+	{Version: "1.0.0", Changes: "Category scoring (attack, defense, synergy, versatility, F2P, playability) with archetype detection"},
+	{Version: "1.1.0", Changes: "Tower troop defense, bundled per-level combat stats, and learned synergy adjustments"},
+	{Version: "1.2.0", Changes: "Hybrid archetype thresholds calibrated on the labeled deck corpus"},
+	{Version: "1.3.0", Changes: "Trophy band weights and assumed meta for evaluations with player context"},
+}
+
+// ScoringVersions returns the registered versions, oldest first.
+func ScoringVersions() []ScoringVersion {
+	return append([]ScoringVersion(nil), scoringVersions...)
+}
+
+// LookupScoringVersion returns the registry entry for version.
+func LookupScoringVersion(version string) (ScoringVersion, bool) {
+	for _, v := range scoringVersions {
+		if v.Version == version {
+			return v, true
+		}
+	}
+	return ScoringVersion{}, false
+}
+
+// NeedsRescore reports whether a score recorded with version is older than
+// CurrentScoringVersion. Blank and unparseable versions (e.g. "imported")
+// predate versioning and need a re-score; newer versions, written by a later
+// release, are left alone.
+func NeedsRescore(version string) bool {
+	return CompareScoringVersions(version, CurrentScoringVersion) < 0
+}
+
+// CompareScoringVersions orders dotted numeric versions ("1.0" == "1.0.0").
+// Unparseable versions sort before every valid one.
+func CompareScoringVersions(a, b string) int {
+	pa, okA := parseScoringVersion(a)
+	pb, okB := parseScoringVersion(b)
+	switch {
+	case !okA && !okB:
+		return 0
+	case !okA:
+		return -1
+	case !okB:
+		return 1
+	}
+	for i := range max(len(pa), len(pb)) {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func parseScoringVersion(version string) ([]int, bool) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if version == "" {
+		return nil, false
+	}
+	var parts []int
+	for field := range strings.SplitSeq(version, ".") {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return nil, false
+		}
+		parts = append(parts, n)
+	}
+	return parts, true
+}
@@ -0,0 +1,52 @@
+package evaluation
+
+import "testing"
+
+func TestCompareScoringVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.0", "1.1.0", -1},
+		{"1.1.0", "1.0.0", 1},
+		{"1.0", "1.0.0", 0},
+		{"v1.2.0", "1.10.0", -1},
+		{"imported", "1.0.0", -1},
+		{"", "imported", 0},
+	}
+	for _, tt := range tests {
+		if got := CompareScoringVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareScoringVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestNeedsRescore(t *testing.T) {
+	for version, want := range map[string]bool{
+		"":                    true,
+		"imported":            true,
+		"1.0":                 true,
+		"1.0.0":               true,
+		CurrentScoringVersion: false,
+		"99.0.0":              false,
+	} {
+		if got := NeedsRescore(version); got != want {
+			t.Errorf("NeedsRescore(%q) = %v, want %v", version, got, want)
+		}
+	}
+}
+
+func TestScoringVersionRegistry(t *testing.T) {
+	versions := ScoringVersions()
+	if len(versions) == 0 || versions[len(versions)-1].Version != CurrentScoringVersion {
+		t.Fatalf("the newest registered version must be CurrentScoringVersion: %+v", versions)
+	}
+	for i := 1; i < len(versions); i++ {
+		if CompareScoringVersions(versions[i-1].Version, versions[i].Version) >= 0 {
+			t.Errorf("versions out of order: %s before %s", versions[i-1].Version, versions[i].Version)
+		}
+	}
+	if _, ok := LookupScoringVersion("1.0.0"); !ok {
+		t.Error("1.0.0 should be registered")
+	}
+}
@@ -3,11 +3,15 @@
 package deck
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"maps"
 	"math/rand"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/klauer/clash-royale-api/go/internal/config"
@@ -38,6 +42,9 @@ type FuzzingConfig struct {
 	SynergyFirst bool
 	// EvolutionCentric enables evolution-centric generation (build decks around evolution cards)
 	EvolutionCentric bool
+	// RoleTemplate fills every deck slot from its role in the role composition
+	// (see generateRoleTemplateDeckAttemptWithRng)
+	RoleTemplate bool
 	// MinEvolutionCards is the minimum number of evolution-eligible cards in deck (default: 3)
 	MinEvolutionCards int
 	// MinEvoLevel is the minimum evolution level for cards to prioritize (default: 1)
@@ -55,20 +62,28 @@ type FuzzingConfig struct {
 	// EnsureArchetypes ensures generated decks cover all archetypes
 	// When true, the fuzzer will attempt to generate decks representing each archetype
 	EnsureArchetypes bool
+	// Deterministic makes StreamDecks generate the same decks for a given Seed
+	// and Count whatever the number of workers (see streamDecksDeterministic)
+	Deterministic bool
+	// Accept, when set, rejects generated decks it returns false for; they
+	// are regenerated instead of being handed out for evaluation
+	Accept func(deck []string) bool `json:"-"`
 }
 
 // FuzzingStats tracks metrics during deck generation
 type FuzzingStats struct {
-	mu              sync.Mutex
-	Generated       int
-	Success         int
-	Failed          int
-	SkippedElixir   int
-	SkippedInclude  int
-	SkippedExclude  int
-	SkippedScore    int
-	StartTime       time.Time
-	GenerationTimes []time.Duration
+	mu             sync.Mutex
+	Generated      int
+	Success        int
+	Failed         int
+	SkippedElixir  int
+	SkippedInclude int
+	SkippedExclude int
+	SkippedScore   int
+	// SkippedConstraint counts generated decks rejected by FuzzingConfig.Accept
+	SkippedConstraint int
+	StartTime         time.Time
+	GenerationTimes   []time.Duration
 }
 
 // FuzzedDeck represents a generated deck with its evaluation results
@@ -114,11 +129,22 @@ type DeckFuzzer struct {
 	config           *FuzzingConfig
 	composition      *RoleComposition
 	rng              *rand.Rand
+	rngSource        *countingSource
 	stats            *FuzzingStats
 	excludeMap       map[string]bool
 	includeMap       map[string]bool
+	includeCards     []string
+	cardRoles        map[string]config.CardRole
 	synergyDB        *SynergyDatabase
 	uniquenessScorer *UniquenessScorer
+
+	// streams are the random sources of the last StreamDecks call, resume
+	// the positions the next call continues from, and streamed the decks
+	// it has finished generating
+	streamsMu sync.Mutex
+	streams   []*countingSource
+	resume    []RNGPosition
+	streamed  atomic.Int64
 }
 
 // NewDeckFuzzer creates a new deck fuzzer from a player's card collection
@@ -181,12 +207,11 @@ func NewDeckFuzzer(player *clashroyale.Player, cfg *FuzzingConfig) (*DeckFuzzer,
 	}
 
 	// Initialize random number generator
-	var rng *rand.Rand
-	if cfg.Seed != 0 {
-		rng = rand.New(rand.NewSource(cfg.Seed))
-	} else {
-		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
 	}
+	rngSource := newCountingSource(seed, 0)
 
 	// Build exclude and include maps
 	excludeMap := make(map[string]bool)
@@ -201,6 +226,7 @@ func NewDeckFuzzer(player *clashroyale.Player, cfg *FuzzingConfig) (*DeckFuzzer,
 
 	// Convert player cards to candidates and categorize by role
 	cardsByRole := make(map[config.CardRole][]CardCandidate)
+	cardRoles := make(map[string]config.CardRole, len(player.Cards))
 	allCards := make([]CardCandidate, 0, len(player.Cards))
 
 	for _, card := range player.Cards {
@@ -212,6 +238,7 @@ func NewDeckFuzzer(player *clashroyale.Player, cfg *FuzzingConfig) (*DeckFuzzer,
 		}
 
 		role := config.GetCardRoleWithEvolution(cardName, card.EvolutionLevel)
+		cardRoles[cardName] = role
 
 		// Calculate level ratio manually
 		levelRatio := float64(card.Level) / float64(card.MaxLevel)
@@ -230,6 +257,7 @@ func NewDeckFuzzer(player *clashroyale.Player, cfg *FuzzingConfig) (*DeckFuzzer,
 			EvolutionLevel:    card.EvolutionLevel,
 			MaxEvolutionLevel: card.MaxEvolutionLevel,
 		}
+		candidate.Stats = CombatStatsFor(nil, cardName, candidate.StandardLevel())
 
 		allCards = append(allCards, candidate)
 
@@ -256,13 +284,16 @@ func NewDeckFuzzer(player *clashroyale.Player, cfg *FuzzingConfig) (*DeckFuzzer,
 		allCards:    allCards,
 		config:      cfg,
 		composition: DefaultRoleComposition(),
-		rng:         rng,
+		rng:         rand.New(rngSource),
+		rngSource:   rngSource,
 		stats: &FuzzingStats{
 			StartTime:       time.Now(),
 			GenerationTimes: make([]time.Duration, 0, cfg.Count),
 		},
 		excludeMap:       excludeMap,
 		includeMap:       includeMap,
+		includeCards:     slices.Sorted(maps.Keys(includeMap)),
+		cardRoles:        cardRoles,
 		synergyDB:        NewSynergyDatabase(),
 		uniquenessScorer: uniquenessScorer,
 	}
@@ -280,54 +311,34 @@ func (df *DeckFuzzer) GenerateRandomDeck() ([]string, error) {
 func (df *DeckFuzzer) GenerateRandomDeckWithRng(rng *rand.Rand) ([]string, error) {
 	const maxRetries = 100
 
-	// Use synergy-first generation if enabled
-	if df.config.SynergyFirst {
-		for range maxRetries {
-			deck, err := df.generateSynergyDeckAttemptWithRng(rng)
-			if err != nil {
-				df.recordFailure()
-				continue
-			}
-
-			df.recordSuccess()
-			return deck, nil
-		}
-
-		df.recordFailure()
-		return nil, fmt.Errorf("failed to generate valid synergy deck after %d attempts", maxRetries)
-	}
-
-	// Use evolution-centric generation if enabled
-	if df.config.EvolutionCentric {
-		for range maxRetries {
-			deck, err := df.generateEvolutionCentricDeckAttemptWithRng(rng)
-			if err != nil {
-				df.recordFailure()
-				continue
-			}
-
-			df.recordSuccess()
-			return deck, nil
-		}
-
-		df.recordFailure()
-		return nil, fmt.Errorf("failed to generate valid evolution deck after %d attempts", maxRetries)
+	// Standard role-based generation unless another strategy is enabled
+	attempt, kind := df.generateRandomDeckAttemptWithRng, "deck"
+	switch {
+	case df.config.SynergyFirst:
+		attempt, kind = df.generateSynergyDeckAttemptWithRng, "synergy deck"
+	case df.config.EvolutionCentric:
+		attempt, kind = df.generateEvolutionCentricDeckAttemptWithRng, "evolution deck"
+	case df.config.RoleTemplate:
+		attempt, kind = df.generateRoleTemplateDeckAttemptWithRng, "role-template deck"
 	}
 
-	// Standard role-based generation
 	for range maxRetries {
-		deck, err := df.generateRandomDeckAttemptWithRng(rng)
+		deck, err := attempt(rng)
 		if err != nil {
 			df.recordFailure()
 			continue
 		}
+		if df.config.Accept != nil && !df.config.Accept(deck) {
+			df.recordConstraintSkip()
+			continue
+		}
 
 		df.recordSuccess()
 		return deck, nil
 	}
 
 	df.recordFailure()
-	return nil, fmt.Errorf("failed to generate valid deck after %d attempts", maxRetries)
+	return nil, fmt.Errorf("failed to generate valid %s after %d attempts", kind, maxRetries)
 }
 
 // generateRandomDeckAttemptWithRng attempts to generate a single random valid deck using the provided RNG
@@ -336,7 +347,7 @@ func (df *DeckFuzzer) generateRandomDeckAttemptWithRng(rng *rand.Rand) ([]string
 	used := make(map[string]bool)
 
 	// 1. Add include cards first (force-add any --include-cards)
-	for cardName := range df.includeMap {
+	for _, cardName := range df.includeCards {
 		if !df.isCardAvailable(cardName) {
 			return nil, fmt.Errorf("included card not available: %s", cardName)
 		}
@@ -417,6 +428,78 @@ func (df *DeckFuzzer) generateRandomDeckAttemptWithRng(rng *rand.Rand) ([]string
 	return deck, nil
 }
 
+// generateRoleTemplateDeckAttemptWithRng attempts to generate a deck slot by
+// slot from the role composition using the provided RNG: one win condition,
+// building, big spell and small spell, two support and two cycle cards by
+// default. Include cards take a slot of their own role, or a support or cycle
+// slot when their role is full. A role without enough cards left gives its
+// slots to random cards of any role.
+func (df *DeckFuzzer) generateRoleTemplateDeckAttemptWithRng(rng *rand.Rand) ([]string, error) {
+	slots := map[config.CardRole]int{
+		config.RoleWinCondition: df.composition.WinConditions,
+		config.RoleBuilding:     df.composition.Buildings,
+		config.RoleSpellBig:     df.composition.BigSpells,
+		config.RoleSpellSmall:   df.composition.SmallSpells,
+		config.RoleSupport:      df.composition.Support,
+		config.RoleCycle:        df.composition.Cycle,
+	}
+	deck := make([]string, 0, 8)
+	used := make(map[string]bool)
+
+	for _, cardName := range df.includeCards {
+		if !df.isCardAvailable(cardName) {
+			return nil, fmt.Errorf("included card not available: %s", cardName)
+		}
+		deck = append(deck, cardName)
+		used[cardName] = true
+		for _, role := range []config.CardRole{df.cardRoles[cardName], config.RoleSupport, config.RoleCycle} {
+			if slots[role] > 0 {
+				slots[role]--
+				break
+			}
+		}
+	}
+
+	for _, role := range []config.CardRole{
+		config.RoleWinCondition,
+		config.RoleBuilding,
+		config.RoleSpellBig,
+		config.RoleSpellSmall,
+		config.RoleSupport,
+		config.RoleCycle,
+	} {
+		count := min(slots[role], 8-len(deck))
+		if count <= 0 {
+			continue
+		}
+		// selectRandomCardsWithRng marks its picks as used
+		cards := df.selectRandomCardsWithRng(rng, role, count, used)
+		deck = append(deck, cards...)
+		if len(cards) < count {
+			deck = append(deck, df.fillRemainingSlotsWithRng(rng, count-len(cards), used)...)
+			for _, card := range deck {
+				used[card] = true
+			}
+		}
+	}
+	// A custom composition may leave slots open
+	if len(deck) < 8 {
+		deck = append(deck, df.fillRemainingSlotsWithRng(rng, 8-len(deck), used)...)
+	}
+
+	if len(deck) != 8 {
+		return nil, fmt.Errorf("invalid deck size: %d", len(deck))
+	}
+
+	avgElixir := df.calculateAvgElixir(deck)
+	if avgElixir < df.config.MinAvgElixir || avgElixir > df.config.MaxAvgElixir {
+		df.stats.SkippedElixir++
+		return nil, fmt.Errorf("elixir out of range: %.2f", avgElixir)
+	}
+
+	return deck, nil
+}
+
 // selectRandomCardsWithRng selects random cards from a role using weighted sampling with the provided RNG
 func (df *DeckFuzzer) selectRandomCardsWithRng(rng *rand.Rand, role config.CardRole, count int, used map[string]bool) []string {
 	cards := df.cardsByRole[role]
@@ -581,7 +664,7 @@ func (df *DeckFuzzer) generateSynergyDeckAttemptWithRng(rng *rand.Rand) ([]strin
 	used := make(map[string]bool)
 
 	// Add include cards first
-	for cardName := range df.includeMap {
+	for _, cardName := range df.includeCards {
 		if !availableCards[cardName] {
 			return nil, fmt.Errorf("included card not available: %s", cardName)
 		}
@@ -664,7 +747,7 @@ func (df *DeckFuzzer) generateEvolutionCentricDeckAttemptWithRng(rng *rand.Rand)
 	used := make(map[string]bool)
 
 	// 1. Add include cards first
-	for cardName := range df.includeMap {
+	for _, cardName := range df.includeCards {
 		if !df.isCardAvailable(cardName) {
 			return nil, fmt.Errorf("included card not available: %s", cardName)
 		}
@@ -881,21 +964,30 @@ func (df *DeckFuzzer) recordFailure() {
 	df.stats.mu.Unlock()
 }
 
+// recordConstraintSkip records a generated deck rejected by config.Accept
+func (df *DeckFuzzer) recordConstraintSkip() {
+	df.stats.mu.Lock()
+	df.stats.Generated++
+	df.stats.SkippedConstraint++
+	df.stats.mu.Unlock()
+}
+
 // GetStats returns a copy of the current stats
 func (df *DeckFuzzer) GetStats() FuzzingStats {
 	df.stats.mu.Lock()
 	defer df.stats.mu.Unlock()
 
 	return FuzzingStats{
-		Generated:       df.stats.Generated,
-		Success:         df.stats.Success,
-		Failed:          df.stats.Failed,
-		SkippedElixir:   df.stats.SkippedElixir,
-		SkippedInclude:  df.stats.SkippedInclude,
-		SkippedExclude:  df.stats.SkippedExclude,
-		SkippedScore:    df.stats.SkippedScore,
-		StartTime:       df.stats.StartTime,
-		GenerationTimes: append([]time.Duration{}, df.stats.GenerationTimes...),
+		Generated:         df.stats.Generated,
+		Success:           df.stats.Success,
+		Failed:            df.stats.Failed,
+		SkippedElixir:     df.stats.SkippedElixir,
+		SkippedInclude:    df.stats.SkippedInclude,
+		SkippedExclude:    df.stats.SkippedExclude,
+		SkippedScore:      df.stats.SkippedScore,
+		SkippedConstraint: df.stats.SkippedConstraint,
+		StartTime:         df.stats.StartTime,
+		GenerationTimes:   append([]time.Duration{}, df.stats.GenerationTimes...),
 	}
 }
 
@@ -1014,6 +1106,183 @@ func (df *DeckFuzzer) GenerateDecksParallelWithContext(ctx context.Context) ([][
 	return decks, nil
 }
 
+// StreamDecks generates config.Count decks and sends each one on the returned
+// channel as soon as it is built, so callers can process decks without holding
+// the whole run in memory. Generation uses config.Workers goroutines seeded the
+// same way as GenerateDecksParallelWithContext. The channel is closed when all
+// decks have been generated or ctx is done.
+func (df *DeckFuzzer) StreamDecks(ctx context.Context) <-chan []string {
+	if df.config.Deterministic {
+		return df.streamDecksDeterministic(ctx)
+	}
+	workers := max(df.config.Workers, 1)
+	out := make(chan []string, workers)
+
+	var remaining atomic.Int64
+	remaining.Store(int64(df.config.Count))
+
+	df.streamsMu.Lock()
+	df.streams = make([]*countingSource, workers)
+	for w := range workers {
+		switch {
+		case w < len(df.resume):
+			df.streams[w] = newCountingSource(df.resume[w].Seed, df.resume[w].Draws)
+		case workers > 1:
+			df.streams[w] = newCountingSource(df.config.Seed+int64(w)*int64(workers), 0)
+		default:
+			df.streams[w] = df.rngSource
+		}
+	}
+	streams := df.streams
+	df.streamsMu.Unlock()
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Go(func() {
+			rng := rand.New(streams[w])
+			for remaining.Add(-1) >= 0 {
+				if ctx.Err() != nil {
+					return
+				}
+				deck, err := df.GenerateRandomDeckWithRng(rng)
+				df.streamed.Add(1)
+				if err != nil {
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case out <- deck:
+				}
+			}
+		})
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// deterministicChunkSize is how many decks each seed partition of a
+// deterministic run generates
+const deterministicChunkSize = 1000
+
+// streamDecksDeterministic is StreamDecks for Config.Deterministic. Count is
+// split into chunks of deterministicChunkSize decks, each generated from its
+// own seed derived from Config.Seed. Workers claim whole chunks, so the decks
+// generated depend only on the seed and Count, not on the number of workers
+// or how they are scheduled. Each deck lists its cards in canonical order, so
+// a deck generated more than once looks the same whichever copy is kept. The
+// order decks arrive in still varies.
+func (df *DeckFuzzer) streamDecksDeterministic(ctx context.Context) <-chan []string {
+	workers := max(df.config.Workers, 1)
+	out := make(chan []string, workers)
+	chunks := (df.config.Count + deterministicChunkSize - 1) / deterministicChunkSize
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Go(func() {
+			for chunk := int(next.Add(1)) - 1; chunk < chunks; chunk = int(next.Add(1)) - 1 {
+				rng := rand.New(rand.NewSource(partitionSeed(df.config.Seed, chunk)))
+				size := min(deterministicChunkSize, df.config.Count-chunk*deterministicChunkSize)
+				for range size {
+					if ctx.Err() != nil {
+						return
+					}
+					deck, err := df.GenerateRandomDeckWithRng(rng)
+					df.streamed.Add(1)
+					if err != nil {
+						continue
+					}
+					df.canonicalDeckOrder(deck)
+					select {
+					case <-ctx.Done():
+						return
+					case out <- deck:
+					}
+				}
+			}
+		})
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// fuzzRoleOrder ranks roles in the order decks are built
+var fuzzRoleOrder = map[config.CardRole]int{
+	config.RoleWinCondition: 0,
+	config.RoleBuilding:     1,
+	config.RoleSpellBig:     2,
+	config.RoleSpellSmall:   3,
+	config.RoleSupport:      4,
+	config.RoleCycle:        5,
+}
+
+// canonicalDeckOrder sorts cards by role, then by name, so a deck generated
+// twice with its cards in a different order is listed the same way.
+func (df *DeckFuzzer) canonicalDeckOrder(deck []string) {
+	rank := func(card string) int {
+		if order, ok := fuzzRoleOrder[df.cardRoles[card]]; ok {
+			return order
+		}
+		return len(fuzzRoleOrder)
+	}
+	slices.SortFunc(deck, func(a, b string) int {
+		return cmp.Or(cmp.Compare(rank(a), rank(b)), strings.Compare(a, b))
+	})
+}
+
+// partitionSeed derives the seed of partition i from seed with the SplitMix64
+// finalizer, so neighbouring seeds do not share partitions the way seed+i
+// would.
+func partitionSeed(seed int64, i int) int64 {
+	z := uint64(seed) + uint64(i+1)*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return int64(z ^ (z >> 31))
+}
+
+// RNGPositions returns the position of each worker's random stream in the
+// last StreamDecks call, or nil before the first. It is safe to call while
+// decks are streaming.
+func (df *DeckFuzzer) RNGPositions() []RNGPosition {
+	df.streamsMu.Lock()
+	defer df.streamsMu.Unlock()
+	if df.streams == nil {
+		return nil
+	}
+	positions := make([]RNGPosition, len(df.streams))
+	for i, stream := range df.streams {
+		positions[i] = stream.Position()
+	}
+	return positions
+}
+
+// ResumeFrom makes the next StreamDecks call continue the random streams at
+// positions, as saved by RNGPositions, instead of starting them from the
+// seed. Config.Workers must match the number of positions for the run to
+// pick up exactly where it stopped.
+func (df *DeckFuzzer) ResumeFrom(positions []RNGPosition) {
+	df.streamsMu.Lock()
+	defer df.streamsMu.Unlock()
+	df.resume = append([]RNGPosition(nil), positions...)
+}
+
+// Streamed returns how many of its Count decks StreamDecks has finished
+// generating, including attempts that failed.
+func (df *DeckFuzzer) Streamed() int {
+	return int(df.streamed.Load())
+}
+
 // GenerateDecksParallel generates decks using parallel workers.
 func (df *DeckFuzzer) GenerateDecksParallel() ([][]string, error) {
 	return df.GenerateDecksParallelWithContext(context.Background())
@@ -1,7 +1,9 @@
 package deck
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"testing"
 
 	"github.com/klauer/clash-royale-api/go/internal/config"
@@ -276,6 +278,40 @@ func TestGenerateRandomDeckWithExcludeCards(t *testing.T) {
 	}
 }
 
+func TestGenerateRandomDeckRegeneratesRejectedDecks(t *testing.T) {
+	player := &clashroyale.Player{Name: "TestPlayer", Tag: "#2PP"}
+	for _, name := range []string{
+		"Hog Rider", "Fireball", "Zap", "Cannon", "Archers", "Knight", "Skeletons", "Valkyrie",
+		"Baby Dragon", "Musketeer", "Ice Spirit", "Giant", "Log", "Tesla", "Minion Horde", "Poison",
+	} {
+		player.Cards = append(player.Cards, clashroyale.Card{Name: name, Level: 11, MaxLevel: 13, Rarity: "Common", ElixirCost: 3})
+	}
+
+	cfg := &FuzzingConfig{
+		Count:  20,
+		Seed:   7,
+		Accept: func(deck []string) bool { return !slices.Contains(deck, "Zap") },
+	}
+	fuzzer, err := NewDeckFuzzer(player, cfg)
+	if err != nil {
+		t.Fatalf("Failed to create fuzzer: %v", err)
+	}
+	for range 20 {
+		deck, err := fuzzer.GenerateRandomDeck()
+		if err != nil {
+			t.Fatalf("GenerateRandomDeck() error = %v", err)
+		}
+		if slices.Contains(deck, "Zap") {
+			t.Fatalf("GenerateRandomDeck() returned a rejected deck: %v", deck)
+		}
+	}
+	stats := fuzzer.GetStats()
+	if stats.SkippedConstraint == 0 || stats.Success != 20 {
+		t.Errorf("got %d successes and %d rejected decks, want 20 successes after some rejected decks",
+			stats.Success, stats.SkippedConstraint)
+	}
+}
+
 func TestGenerateRandomDeckWithElixirConstraints(t *testing.T) {
 	player := &clashroyale.Player{
 		Name: "TestPlayer",
@@ -395,6 +431,67 @@ func TestGenerateEvolutionCentricDeck(t *testing.T) {
 	}
 }
 
+func TestGenerateRoleTemplateDeck(t *testing.T) {
+	player := &clashroyale.Player{
+		Name: "TestPlayer",
+		Tag:  "#TEST123",
+		Cards: []clashroyale.Card{
+			{Name: "Hog Rider", Level: 8, MaxLevel: 13, Rarity: "Rare", ElixirCost: 4},
+			{Name: "Giant", Level: 7, MaxLevel: 11, Rarity: "Rare", ElixirCost: 5},
+			{Name: "Cannon", Level: 11, MaxLevel: 13, Rarity: "Common", ElixirCost: 3},
+			{Name: "Tesla", Level: 7, MaxLevel: 11, Rarity: "Common", ElixirCost: 3},
+			{Name: "Fireball", Level: 7, MaxLevel: 11, Rarity: "Rare", ElixirCost: 4},
+			{Name: "Poison", Level: 5, MaxLevel: 11, Rarity: "Epic", ElixirCost: 4},
+			{Name: "Zap", Level: 11, MaxLevel: 13, Rarity: "Common", ElixirCost: 2},
+			{Name: "Log", Level: 11, MaxLevel: 13, Rarity: "Legendary", ElixirCost: 2},
+			{Name: "Musketeer", Level: 8, MaxLevel: 13, Rarity: "Rare", ElixirCost: 4},
+			{Name: "Valkyrie", Level: 7, MaxLevel: 11, Rarity: "Rare", ElixirCost: 4},
+			{Name: "Archers", Level: 10, MaxLevel: 13, Rarity: "Common", ElixirCost: 3},
+			{Name: "Knight", Level: 11, MaxLevel: 13, Rarity: "Common", ElixirCost: 3},
+			{Name: "Skeletons", Level: 11, MaxLevel: 13, Rarity: "Common", ElixirCost: 1},
+			{Name: "Ice Spirit", Level: 11, MaxLevel: 13, Rarity: "Common", ElixirCost: 1},
+			{Name: "Bats", Level: 11, MaxLevel: 13, Rarity: "Common", ElixirCost: 2},
+		},
+	}
+
+	fuzzer, err := NewDeckFuzzer(player, &FuzzingConfig{
+		Count:        10,
+		Seed:         7,
+		RoleTemplate: true,
+		IncludeCards: []string{"Knight"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create fuzzer: %v", err)
+	}
+
+	want := map[config.CardRole]int{
+		config.RoleWinCondition: 1,
+		config.RoleBuilding:     1,
+		config.RoleSpellBig:     1,
+		config.RoleSpellSmall:   1,
+		config.RoleSupport:      2,
+		config.RoleCycle:        2,
+	}
+	for range 20 {
+		deck, err := fuzzer.GenerateRandomDeck()
+		if err != nil {
+			t.Fatalf("Failed to generate role-template deck: %v", err)
+		}
+		if len(deck) != 8 || !slices.Contains(deck, "Knight") {
+			t.Fatalf("deck = %v, want 8 cards including Knight", deck)
+		}
+		got := make(map[config.CardRole]int)
+		for _, card := range deck {
+			got[config.GetCardRole(card)]++
+		}
+		for role, count := range want {
+			if got[role] != count {
+				t.Errorf("deck %v has %d %s cards, want %d", deck, got[role], role, count)
+			}
+		}
+	}
+}
+
 func TestGenerateDecks(t *testing.T) {
 	player := &clashroyale.Player{
 		Name: "TestPlayer",
@@ -666,28 +763,7 @@ func TestFuzzingConfigValidation(t *testing.T) {
 }
 
 func TestGenerateDecksParallel(t *testing.T) {
-	player := &clashroyale.Player{
-		Name: "TestPlayer",
-		Tag:  "#TEST123",
-		Cards: []clashroyale.Card{
-			{Name: "Hog Rider", Level: 8, MaxLevel: 13, Rarity: "Rare", ElixirCost: 4},
-			{Name: "Fireball", Level: 7, MaxLevel: 11, Rarity: "Rare", ElixirCost: 4},
-			{Name: "Zap", Level: 11, MaxLevel: 13, Rarity: "Common", ElixirCost: 2},
-			{Name: "Cannon", Level: 11, MaxLevel: 13, Rarity: "Common", ElixirCost: 3},
-			{Name: "Archers", Level: 10, MaxLevel: 13, Rarity: "Common", ElixirCost: 3},
-			{Name: "Knight", Level: 11, MaxLevel: 13, Rarity: "Common", ElixirCost: 3},
-			{Name: "Skeletons", Level: 11, MaxLevel: 13, Rarity: "Common", ElixirCost: 1},
-			{Name: "Valkyrie", Level: 7, MaxLevel: 11, Rarity: "Rare", ElixirCost: 4},
-			{Name: "Baby Dragon", Level: 5, MaxLevel: 11, Rarity: "Epic", ElixirCost: 4},
-			{Name: "Musketeer", Level: 8, MaxLevel: 13, Rarity: "Rare", ElixirCost: 4},
-			{Name: "Ice Spirit", Level: 11, MaxLevel: 13, Rarity: "Common", ElixirCost: 1},
-			{Name: "Giant", Level: 7, MaxLevel: 11, Rarity: "Rare", ElixirCost: 5},
-			{Name: "Log", Level: 11, MaxLevel: 13, Rarity: "Legendary", ElixirCost: 2},
-			{Name: "Tesla", Level: 7, MaxLevel: 11, Rarity: "Common", ElixirCost: 3},
-			{Name: "Minion Horde", Level: 9, MaxLevel: 13, Rarity: "Common", ElixirCost: 5},
-			{Name: "Poison", Level: 5, MaxLevel: 11, Rarity: "Epic", ElixirCost: 4},
-		},
-	}
+	player := newParallelTestPlayer()
 
 	cfg := &FuzzingConfig{
 		Count:   50,
@@ -715,3 +791,144 @@ func TestGenerateDecksParallel(t *testing.T) {
 		}
 	}
 }
+
+func TestStreamDecks(t *testing.T) {
+	for _, workers := range []int{1, 4} {
+		fuzzer, err := NewDeckFuzzer(newParallelTestPlayer(), &FuzzingConfig{Count: 40, Workers: workers, Seed: 3})
+		if err != nil {
+			t.Fatalf("Failed to create fuzzer: %v", err)
+		}
+
+		received := 0
+		for deck := range fuzzer.StreamDecks(context.Background()) {
+			if len(deck) != 8 {
+				t.Errorf("workers=%d: deck has %d cards, expected 8", workers, len(deck))
+			}
+			received++
+		}
+		if stats := fuzzer.GetStats(); received != stats.Success {
+			t.Errorf("workers=%d: received %d decks, stats report %d successes", workers, received, stats.Success)
+		}
+		if received == 0 || received > 40 {
+			t.Errorf("workers=%d: received %d decks, want 1-40", workers, received)
+		}
+	}
+}
+
+func TestStreamDecksStopsOnCancel(t *testing.T) {
+	fuzzer, err := NewDeckFuzzer(newParallelTestPlayer(), &FuzzingConfig{Count: 1_000_000, Workers: 2})
+	if err != nil {
+		t.Fatalf("Failed to create fuzzer: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	received := 0
+	for range fuzzer.StreamDecks(ctx) {
+		received++
+		if received == 10 {
+			cancel()
+		}
+	}
+	cancel()
+	if received >= 1_000_000 {
+		t.Errorf("expected stream to stop early after cancel, got %d decks", received)
+	}
+}
+
+func TestStreamDecksResumesFromRNGPositions(t *testing.T) {
+	stream := func(count int, resume []RNGPosition) ([][]string, *DeckFuzzer) {
+		fuzzer, err := NewDeckFuzzer(newParallelTestPlayer(), &FuzzingConfig{Count: count, Workers: 1, Seed: 7})
+		if err != nil {
+			t.Fatalf("Failed to create fuzzer: %v", err)
+		}
+		if resume != nil {
+			fuzzer.ResumeFrom(resume)
+		}
+		var decks [][]string
+		for deck := range fuzzer.StreamDecks(context.Background()) {
+			decks = append(decks, deck)
+		}
+		return decks, fuzzer
+	}
+
+	full, _ := stream(30, nil)
+	first, stopped := stream(12, nil)
+	if stopped.Streamed() != 12 {
+		t.Errorf("Streamed() = %d, want 12", stopped.Streamed())
+	}
+	positions := stopped.RNGPositions()
+	if len(positions) != 1 || positions[0].Seed != 7 || positions[0].Draws == 0 {
+		t.Fatalf("RNGPositions() = %+v, want one advanced stream seeded 7", positions)
+	}
+	rest, _ := stream(18, positions)
+
+	resumed := append(first, rest...)
+	if len(resumed) != len(full) {
+		t.Fatalf("resumed run produced %d decks, full run %d", len(resumed), len(full))
+	}
+	for i := range full {
+		if !slices.Equal(resumed[i], full[i]) {
+			t.Fatalf("deck %d = %v after resuming, want %v", i, resumed[i], full[i])
+		}
+	}
+}
+
+func TestStreamDecksDeterministicIgnoresWorkerCount(t *testing.T) {
+	stream := func(workers int, seed int64) []string {
+		fuzzer, err := NewDeckFuzzer(newParallelTestPlayer(), &FuzzingConfig{
+			Count:         2500,
+			Workers:       workers,
+			Seed:          seed,
+			IncludeCards:  []string{"Hog Rider", "Zap"},
+			Deterministic: true,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create fuzzer: %v", err)
+		}
+		var decks []string
+		for deck := range fuzzer.StreamDecks(context.Background()) {
+			decks = append(decks, fmt.Sprint(deck))
+		}
+		if fuzzer.Streamed() != 2500 {
+			t.Errorf("workers=%d: Streamed() = %d, want 2500", workers, fuzzer.Streamed())
+		}
+		slices.Sort(decks)
+		return decks
+	}
+
+	single := stream(1, 42)
+	if len(single) == 0 {
+		t.Fatal("No decks generated")
+	}
+	if parallel := stream(4, 42); !slices.Equal(single, parallel) {
+		t.Error("4 workers generated different decks than 1 worker with the same seed")
+	}
+	if other := stream(4, 43); slices.Equal(single, other) {
+		t.Error("a different seed generated the same decks")
+	}
+}
+
+func newParallelTestPlayer() *clashroyale.Player {
+	return &clashroyale.Player{
+		Name: "TestPlayer",
+		Tag:  "#TEST123",
+		Cards: []clashroyale.Card{
+			{Name: "Hog Rider", Level: 8, MaxLevel: 13, Rarity: "Rare", ElixirCost: 4},
+			{Name: "Fireball", Level: 7, MaxLevel: 11, Rarity: "Rare", ElixirCost: 4},
+			{Name: "Zap", Level: 11, MaxLevel: 13, Rarity: "Common", ElixirCost: 2},
+			{Name: "Cannon", Level: 11, MaxLevel: 13, Rarity: "Common", ElixirCost: 3},
+			{Name: "Archers", Level: 10, MaxLevel: 13, Rarity: "Common", ElixirCost: 3},
+			{Name: "Knight", Level: 11, MaxLevel: 13, Rarity: "Common", ElixirCost: 3},
+			{Name: "Skeletons", Level: 11, MaxLevel: 13, Rarity: "Common", ElixirCost: 1},
+			{Name: "Valkyrie", Level: 7, MaxLevel: 11, Rarity: "Rare", ElixirCost: 4},
+			{Name: "Baby Dragon", Level: 5, MaxLevel: 11, Rarity: "Epic", ElixirCost: 4},
+			{Name: "Musketeer", Level: 8, MaxLevel: 13, Rarity: "Rare", ElixirCost: 4},
+			{Name: "Ice Spirit", Level: 11, MaxLevel: 13, Rarity: "Common", ElixirCost: 1},
+			{Name: "Giant", Level: 7, MaxLevel: 11, Rarity: "Rare", ElixirCost: 5},
+			{Name: "Log", Level: 11, MaxLevel: 13, Rarity: "Legendary", ElixirCost: 2},
+			{Name: "Tesla", Level: 7, MaxLevel: 11, Rarity: "Common", ElixirCost: 3},
+			{Name: "Minion Horde", Level: 9, MaxLevel: 13, Rarity: "Common", ElixirCost: 5},
+			{Name: "Poison", Level: 5, MaxLevel: 11, Rarity: "Epic", ElixirCost: 4},
+		},
+	}
+}
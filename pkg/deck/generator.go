@@ -461,6 +461,7 @@ func NewGeneratorConfigFromPlayer(player *clashroyale.Player, strategy Generator
 			EvolutionLevel:    card.EvolutionLevel,
 			MaxEvolutionLevel: card.MaxEvolutionLevel,
 		}
+		candidate.Stats = CombatStatsFor(nil, card.Name, candidate.StandardLevel())
 		candidates = append(candidates, candidate)
 	}
 
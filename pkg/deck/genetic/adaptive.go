@@ -0,0 +1,200 @@
+// Package genetic provides genetic algorithm-based deck optimization
+// using the eaopt library for evolutionary deck generation.
+package genetic
+
+import (
+	"math"
+	"sync"
+)
+
+// AdaptiveParams adjusts mutation pressure from observed progress instead of
+// a fixed schedule. Within a run it applies the 1/5 success rule over a
+// window of generations: when more than SuccessTarget of generations improve
+// the best fitness the search is exploiting well and mutation is lowered;
+// when fewer do, or population diversity drops below DiversityFloor
+// (premature convergence), mutation is raised. Between refinement rounds,
+// ObserveRound applies the same idea to rate, intensity, and elite count.
+//
+// It is safe for concurrent use; island populations read the rate in parallel.
+type AdaptiveParams struct {
+	// Current values, seeded from the base configuration.
+	MutationRate      float64
+	MutationIntensity float64
+	EliteCount        int
+
+	MinMutationRate      float64
+	MaxMutationRate      float64
+	MinMutationIntensity float64
+	MaxMutationIntensity float64
+	// MaxEliteFraction caps EliteCount as a fraction of the population.
+	MaxEliteFraction float64
+
+	// Decay multiplies mutation when improvement is steady; Boost multiplies
+	// it when the search stalls or converges.
+	Decay float64
+	Boost float64
+
+	// DiversityFloor is the mean pairwise deck distance (0-1, see
+	// PopulationDiversity) below which the population counts as converged.
+	DiversityFloor float64
+	// Window is the number of generations per 1/5-rule adjustment.
+	Window int
+	// SuccessTarget is the improving-generation ratio separating steady
+	// progress from stalling (0.2 for the classic 1/5 rule).
+	SuccessTarget float64
+
+	mu             sync.Mutex
+	populationSize int
+	successes      int
+	observed       int
+	bestSeen       float64
+	hasBest        bool
+	roundBest      float64
+	hasRoundBest   bool
+}
+
+// NewAdaptiveParams returns a controller starting from the configuration's
+// mutation rate, intensity, and elite count.
+func NewAdaptiveParams(config GeneticConfig) *AdaptiveParams {
+	return &AdaptiveParams{
+		MutationRate:         config.MutationRate,
+		MutationIntensity:    config.MutationIntensity,
+		EliteCount:           config.EliteCount,
+		MinMutationRate:      0.02,
+		MaxMutationRate:      0.5,
+		MinMutationIntensity: 0.1,
+		MaxMutationIntensity: 0.8,
+		MaxEliteFraction:     0.2,
+		Decay:                0.7,
+		Boost:                1.5,
+		DiversityFloor:       0.25,
+		Window:               10,
+		SuccessTarget:        0.2,
+		populationSize:       config.PopulationSize,
+	}
+}
+
+// Rate returns the current mutation rate.
+func (a *AdaptiveParams) Rate() float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.MutationRate
+}
+
+// ObserveGeneration records one generation's best fitness and population
+// diversity and adjusts the mutation rate once per Window generations, or
+// immediately when diversity collapses.
+func (a *AdaptiveParams) ObserveGeneration(best, diversity float64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.hasBest || best > a.bestSeen+1e-9 {
+		if a.hasBest {
+			a.successes++
+		}
+		a.bestSeen = best
+		a.hasBest = true
+	}
+	a.observed++
+
+	if diversity < a.DiversityFloor {
+		a.MutationRate = a.clampRate(a.MutationRate * a.Boost)
+		a.resetWindow()
+		return
+	}
+	if a.observed < max(a.Window, 1) {
+		return
+	}
+	if float64(a.successes)/float64(a.observed) > a.SuccessTarget {
+		a.MutationRate = a.clampRate(a.MutationRate * a.Decay)
+	} else {
+		a.MutationRate = a.clampRate(a.MutationRate * a.Boost)
+	}
+	a.resetWindow()
+}
+
+// ObserveRound updates the parameters for the next refinement round from the
+// round's best fitness and final diversity. Steady improvement narrows the
+// search (less mutation, more elites); a stalled or converged round widens it.
+func (a *AdaptiveParams) ObserveRound(best, diversity float64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	improved := !a.hasRoundBest || best > a.roundBest+1e-9
+	if improved {
+		a.roundBest = best
+		a.hasRoundBest = true
+	}
+
+	maxElite := max(int(float64(a.populationSize)*a.MaxEliteFraction), 1)
+	if improved && diversity >= a.DiversityFloor {
+		a.MutationRate = a.clampRate(a.MutationRate * a.Decay)
+		a.MutationIntensity = a.clampIntensity(a.MutationIntensity * a.Decay)
+		a.EliteCount = min(a.EliteCount+1, maxElite)
+	} else {
+		a.MutationRate = a.clampRate(a.MutationRate * a.Boost)
+		a.MutationIntensity = a.clampIntensity(a.MutationIntensity * a.Boost)
+		a.EliteCount = max(a.EliteCount-1, 1)
+	}
+	a.EliteCount = min(a.EliteCount, max(a.populationSize-1, 0))
+	a.resetWindow()
+	a.hasBest = false
+}
+
+// ApplyTo copies the current parameters into a configuration.
+func (a *AdaptiveParams) ApplyTo(config *GeneticConfig) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	config.MutationRate = a.MutationRate
+	config.MutationIntensity = a.MutationIntensity
+	config.EliteCount = a.EliteCount
+}
+
+func (a *AdaptiveParams) resetWindow() {
+	a.successes = 0
+	a.observed = 0
+}
+
+func (a *AdaptiveParams) clampRate(rate float64) float64 {
+	return math.Max(a.MinMutationRate, math.Min(a.MaxMutationRate, rate))
+}
+
+func (a *AdaptiveParams) clampIntensity(intensity float64) float64 {
+	return math.Max(a.MinMutationIntensity, math.Min(a.MaxMutationIntensity, intensity))
+}
+
+// PopulationDiversity is the mean pairwise distance between decks, where the
+// distance is the fraction of cards two decks do not share: 0 when every deck
+// is identical, 1 when no two decks share a card. It is one minus the
+// average pairwise card overlap.
+func PopulationDiversity(decks [][]string) float64 {
+	if len(decks) < 2 {
+		return 0
+	}
+	sets := make([]map[string]bool, len(decks))
+	for i, cards := range decks {
+		sets[i] = make(map[string]bool, len(cards))
+		for _, card := range cards {
+			sets[i][card] = true
+		}
+	}
+
+	total := 0.0
+	pairs := 0
+	for i := range sets {
+		for j := i + 1; j < len(sets); j++ {
+			shared := 0
+			for card := range sets[i] {
+				if sets[j][card] {
+					shared++
+				}
+			}
+			size := max(len(sets[i]), len(sets[j]))
+			if size > 0 {
+				total += 1 - float64(shared)/float64(size)
+			}
+			pairs++
+		}
+	}
+	return total / float64(pairs)
+}
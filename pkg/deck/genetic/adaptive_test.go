@@ -0,0 +1,141 @@
+// Package genetic provides genetic algorithm-based deck optimization
+// using the eaopt library for evolutionary deck generation.
+package genetic
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+func newTestAdaptive() *AdaptiveParams {
+	cfg := DefaultGeneticConfig()
+	cfg.MutationRate = 0.1
+	cfg.MutationIntensity = 0.3
+	cfg.EliteCount = 2
+	cfg.PopulationSize = 50
+	a := NewAdaptiveParams(cfg)
+	a.Window = 5
+	return a
+}
+
+func TestAdaptiveParamsLowersRateOnSteadyImprovement(t *testing.T) {
+	a := newTestAdaptive()
+	for i := range 5 {
+		a.ObserveGeneration(float64(i), 0.8)
+	}
+	if got := a.Rate(); math.Abs(got-0.07) > 1e-9 {
+		t.Errorf("rate after steady improvement = %v, want 0.07", got)
+	}
+}
+
+func TestAdaptiveParamsRaisesRateWhenStalled(t *testing.T) {
+	a := newTestAdaptive()
+	for range 5 {
+		a.ObserveGeneration(5, 0.8)
+	}
+	if got := a.Rate(); math.Abs(got-0.15) > 1e-9 {
+		t.Errorf("rate after stall = %v, want 0.15", got)
+	}
+}
+
+func TestAdaptiveParamsRaisesRateOnConvergence(t *testing.T) {
+	a := newTestAdaptive()
+	a.ObserveGeneration(1, 0.05)
+	if got := a.Rate(); math.Abs(got-0.15) > 1e-9 {
+		t.Errorf("rate after diversity collapse = %v, want 0.15", got)
+	}
+
+	for range 20 {
+		a.ObserveGeneration(1, 0.0)
+	}
+	if got := a.Rate(); got != a.MaxMutationRate {
+		t.Errorf("rate should be clamped to %v, got %v", a.MaxMutationRate, got)
+	}
+}
+
+func TestAdaptiveParamsObserveRound(t *testing.T) {
+	a := newTestAdaptive()
+
+	a.ObserveRound(6.0, 0.6)
+	var cfg GeneticConfig
+	a.ApplyTo(&cfg)
+	if math.Abs(cfg.MutationRate-0.07) > 1e-9 || math.Abs(cfg.MutationIntensity-0.21) > 1e-9 || cfg.EliteCount != 3 {
+		t.Errorf("after improving round got rate %v, intensity %v, elite %d", cfg.MutationRate, cfg.MutationIntensity, cfg.EliteCount)
+	}
+
+	// No improvement: widen the search again.
+	a.ObserveRound(5.5, 0.6)
+	a.ApplyTo(&cfg)
+	if math.Abs(cfg.MutationRate-0.105) > 1e-9 || cfg.EliteCount != 2 {
+		t.Errorf("after stalled round got rate %v, elite %d", cfg.MutationRate, cfg.EliteCount)
+	}
+
+	// Elite count is capped at MaxEliteFraction of the population.
+	for i := range 30 {
+		a.ObserveRound(10+float64(i), 0.9)
+	}
+	a.ApplyTo(&cfg)
+	if cfg.EliteCount != 10 {
+		t.Errorf("elite count = %d, want cap of 10", cfg.EliteCount)
+	}
+	if cfg.MutationRate != a.MinMutationRate || cfg.MutationIntensity != a.MinMutationIntensity {
+		t.Errorf("expected rate and intensity at their minimums, got %v, %v", cfg.MutationRate, cfg.MutationIntensity)
+	}
+}
+
+func TestPopulationDiversity(t *testing.T) {
+	same := []string{"A", "B", "C", "D", "E", "F", "G", "H"}
+	other := []string{"I", "J", "K", "L", "M", "N", "O", "P"}
+	half := []string{"A", "B", "C", "D", "M", "N", "O", "P"}
+
+	if got := PopulationDiversity([][]string{same, same, same}); got != 0 {
+		t.Errorf("identical decks diversity = %v, want 0", got)
+	}
+	if got := PopulationDiversity([][]string{same, other}); got != 1 {
+		t.Errorf("disjoint decks diversity = %v, want 1", got)
+	}
+	if got := PopulationDiversity([][]string{same, half}); got != 0.5 {
+		t.Errorf("half-shared decks diversity = %v, want 0.5", got)
+	}
+	if got := PopulationDiversity([][]string{same}); got != 0 {
+		t.Errorf("single deck diversity = %v, want 0", got)
+	}
+}
+
+func TestOptimizeWithAdaptiveMutation(t *testing.T) {
+	cfg := DefaultGeneticConfig()
+	cfg.PopulationSize = 20
+	cfg.Generations = 5
+	cfg.ConvergenceGenerations = 0
+	cfg.ParallelEvaluations = false
+
+	optimizer, err := NewGeneticOptimizer(createMockCandidates(16), deck.StrategyBalanced, &cfg)
+	if err != nil {
+		t.Fatalf("NewGeneticOptimizer() error = %v", err)
+	}
+	optimizer.RNG = rand.New(rand.NewSource(7))
+	optimizer.FitnessFunc = func(cards []deck.CardCandidate) (float64, error) {
+		total := 0.0
+		for _, card := range cards {
+			total += card.Score
+		}
+		return total, nil
+	}
+	optimizer.Adaptive = NewAdaptiveParams(cfg)
+
+	var last GeneticProgress
+	optimizer.Progress = func(p GeneticProgress) { last = p }
+	result, err := optimizer.Optimize()
+	if err != nil {
+		t.Fatalf("Optimize() error = %v", err)
+	}
+	if last.MutationRate <= 0 {
+		t.Errorf("expected progress to report the adaptive mutation rate, got %v", last.MutationRate)
+	}
+	if result.Diversity < 0 || result.Diversity > 1 {
+		t.Errorf("result diversity out of range: %v", result.Diversity)
+	}
+}
@@ -74,6 +74,19 @@ type GeneticConfig struct {
 	// Recommended: 1-3 individuals.
 	MigrationSize int
 
+	// MigrationTopology is the pattern migrants travel between islands.
+	// Empty means TopologyRing.
+	MigrationTopology IslandTopology
+
+	// IslandStrategies assigns each island an archetype or objective to
+	// evolve toward, round-robin. Empty means every island optimizes the
+	// same fitness.
+	IslandStrategies []IslandStrategy
+
+	// IslandStrategyWeight is the share (0-1) of an island's search fitness
+	// taken from its strategy score. 0 uses DefaultIslandStrategyWeight.
+	IslandStrategyWeight float64
+
 	// SeedPopulation is an optional initial population to start evolution.
 	// If provided, evolution begins from these decks instead of random initialization.
 	// Useful for resuming previous runs or warm-starting from known good decks.
@@ -82,6 +95,34 @@ type GeneticConfig struct {
 	// UseArchetypes indicates whether to enforce archetype constraints during evolution.
 	// When true, generated decks will respect archetype composition rules.
 	UseArchetypes bool
+
+	// MinEvolvedCards is the minimum number of evolved cards (the player owns
+	// the evolution) every deck must contain. 0 disables the constraint.
+	MinEvolvedCards int
+
+	// EvolutionSlotLimit caps the number of evolved cards in a deck, matching
+	// the in-game evolution slots. 0 disables the cap. Set it equal to
+	// MinEvolvedCards to require an exact count.
+	EvolutionSlotLimit int
+
+	// NoveltyWeight enables novelty search when positive: each deck's fitness
+	// gains NoveltyWeight times its DeckNovelty against the previous
+	// generation and NoveltyArchive (0-1, so 1.0 is worth up to one point of
+	// the 0-10 evaluation score). Ignored in multi-objective mode.
+	NoveltyWeight float64
+
+	// NoveltyNeighbors is the number of nearest decks novelty is measured
+	// against. 0 uses DefaultNoveltyNeighbors.
+	NoveltyNeighbors int
+
+	// NoveltyArchive holds previously found decks (e.g. from fuzz storage)
+	// that novelty search steers away from.
+	NoveltyArchive [][]string
+
+	// Objectives enables NSGA-II multi-objective optimization when it lists two
+	// or more objectives. The optimizer then returns a Pareto front instead of
+	// a single-fitness hall of fame. Island settings are ignored in this mode.
+	Objectives []Objective
 }
 
 // DefaultGeneticConfig returns a configuration with sensible defaults
@@ -102,8 +143,17 @@ func DefaultGeneticConfig() GeneticConfig {
 		IslandCount:            4,
 		MigrationInterval:      15,
 		MigrationSize:          2,
+		MigrationTopology:      TopologyRing,
+		IslandStrategies:       nil,
+		IslandStrategyWeight:   0,
 		SeedPopulation:         nil,
 		UseArchetypes:          false,
+		MinEvolvedCards:        0,
+		EvolutionSlotLimit:     0,
+		NoveltyWeight:          0,
+		NoveltyNeighbors:       0,
+		NoveltyArchive:         nil,
+		Objectives:             nil,
 	}
 }
 
@@ -168,7 +218,11 @@ func (p *envParser) parseBool(key string, setter func(bool)) {
 //	GA_CROSSOVER_RATE, GA_MUTATION_INTENSITY, GA_ELITE_COUNT,
 //	GA_TOURNAMENT_SIZE, GA_PARALLEL_EVALUATIONS, GA_CONVERGENCE_GENERATIONS,
 //	GA_TARGET_FITNESS, GA_ISLAND_MODEL, GA_ISLAND_COUNT,
-//	GA_MIGRATION_INTERVAL, GA_MIGRATION_SIZE, GA_USE_ARCHETYPES
+//	GA_MIGRATION_INTERVAL, GA_MIGRATION_SIZE, GA_ISLAND_TOPOLOGY,
+//	GA_ISLAND_STRATEGIES (comma-separated archetypes or objectives),
+//	GA_ISLAND_STRATEGY_WEIGHT, GA_USE_ARCHETYPES,
+//	GA_MIN_EVOLVED_CARDS, GA_EVOLUTION_SLOT_LIMIT, GA_NOVELTY_WEIGHT,
+//	GA_OBJECTIVES (comma-separated, e.g. "attack,defense,synergy,f2p")
 func LoadFromEnv() GeneticConfig {
 	config := DefaultGeneticConfig()
 	p := &envParser{config: &config}
@@ -187,7 +241,26 @@ func LoadFromEnv() GeneticConfig {
 	p.parsePositiveInt("GA_ISLAND_COUNT", func(v int) { config.IslandCount = v })
 	p.parsePositiveInt("GA_MIGRATION_INTERVAL", func(v int) { config.MigrationInterval = v })
 	p.parsePositiveInt("GA_MIGRATION_SIZE", func(v int) { config.MigrationSize = v })
+	if v := os.Getenv("GA_ISLAND_TOPOLOGY"); v != "" {
+		if topology, err := ParseIslandTopology(v); err == nil {
+			config.MigrationTopology = topology
+		}
+	}
+	if v := os.Getenv("GA_ISLAND_STRATEGIES"); v != "" {
+		if strategies, err := ParseIslandStrategies(v); err == nil {
+			config.IslandStrategies = strategies
+		}
+	}
+	p.parseFloat01("GA_ISLAND_STRATEGY_WEIGHT", func(v float64) { config.IslandStrategyWeight = v })
 	p.parseBool("GA_USE_ARCHETYPES", func(v bool) { config.UseArchetypes = v })
+	p.parseNonNegativeInt("GA_MIN_EVOLVED_CARDS", func(v int) { config.MinEvolvedCards = v })
+	p.parseNonNegativeInt("GA_EVOLUTION_SLOT_LIMIT", func(v int) { config.EvolutionSlotLimit = v })
+	p.parseNonNegativeFloat("GA_NOVELTY_WEIGHT", func(v float64) { config.NoveltyWeight = v })
+	if v := os.Getenv("GA_OBJECTIVES"); v != "" {
+		if objectives, err := ParseObjectives(v); err == nil {
+			config.Objectives = objectives
+		}
+	}
 
 	return config
 }
@@ -227,6 +300,32 @@ func (c *GeneticConfig) Validate() error {
 	if c.TargetFitness < 0 {
 		return fmt.Errorf("target_fitness must be non-negative, got %f", c.TargetFitness)
 	}
+	if c.MinEvolvedCards < 0 || c.MinEvolvedCards > 8 {
+		return fmt.Errorf("min_evolved_cards must be between 0 and 8, got %d", c.MinEvolvedCards)
+	}
+	if c.EvolutionSlotLimit < 0 || c.EvolutionSlotLimit > 8 {
+		return fmt.Errorf("evolution_slot_limit must be between 0 and 8, got %d", c.EvolutionSlotLimit)
+	}
+	if c.EvolutionSlotLimit > 0 && c.MinEvolvedCards > c.EvolutionSlotLimit {
+		return fmt.Errorf("min_evolved_cards (%d) must not exceed evolution_slot_limit (%d)", c.MinEvolvedCards, c.EvolutionSlotLimit)
+	}
+	if c.NoveltyWeight < 0 {
+		return fmt.Errorf("novelty_weight must be non-negative, got %f", c.NoveltyWeight)
+	}
+	if c.NoveltyNeighbors < 0 {
+		return fmt.Errorf("novelty_neighbors must be non-negative, got %d", c.NoveltyNeighbors)
+	}
+	for _, objective := range c.Objectives {
+		if !objective.valid() {
+			return fmt.Errorf("unknown objective %q", objective)
+		}
+	}
+	if len(c.Objectives) == 1 {
+		return fmt.Errorf("multi-objective mode needs at least 2 objectives, got %d", len(c.Objectives))
+	}
+	if c.MultiObjective() {
+		return nil
+	}
 	if c.IslandModel {
 		if c.IslandCount <= 1 {
 			return fmt.Errorf("island_count must be at least 2 when island_model is enabled, got %d", c.IslandCount)
@@ -241,10 +340,26 @@ func (c *GeneticConfig) Validate() error {
 			return fmt.Errorf("migration_size (%d) must be less than per-island population (%d)",
 				c.MigrationSize, c.PopulationSize/c.IslandCount)
 		}
+		if c.MigrationTopology != "" && !c.MigrationTopology.valid() {
+			return fmt.Errorf("unknown migration_topology %q", c.MigrationTopology)
+		}
+		if c.IslandStrategyWeight < 0 || c.IslandStrategyWeight > 1 {
+			return fmt.Errorf("island_strategy_weight must be between 0 and 1, got %f", c.IslandStrategyWeight)
+		}
+		for _, strategy := range c.IslandStrategies {
+			if err := strategy.validate(); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
 
+// MultiObjective reports whether the configuration selects NSGA-II mode.
+func (c *GeneticConfig) MultiObjective() bool {
+	return len(c.Objectives) >= 2
+}
+
 // String returns a human-readable representation of the configuration.
 func (c *GeneticConfig) String() string {
 	return fmt.Sprintf(
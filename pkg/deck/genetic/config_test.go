@@ -263,6 +263,48 @@ func TestGeneticConfigValidate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "exactly two evolved cards",
+			config: GeneticConfig{
+				PopulationSize:     100,
+				Generations:        100,
+				TournamentSize:     5,
+				MinEvolvedCards:    2,
+				EvolutionSlotLimit: 2,
+			},
+			wantErr: false,
+		},
+		{
+			name: "min evolved cards above slot limit",
+			config: GeneticConfig{
+				PopulationSize:     100,
+				Generations:        100,
+				TournamentSize:     5,
+				MinEvolvedCards:    3,
+				EvolutionSlotLimit: 2,
+			},
+			wantErr: true,
+		},
+		{
+			name: "evolution slot limit above deck size",
+			config: GeneticConfig{
+				PopulationSize:     100,
+				Generations:        100,
+				TournamentSize:     5,
+				EvolutionSlotLimit: 9,
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative novelty weight",
+			config: GeneticConfig{
+				PopulationSize: 100,
+				Generations:    100,
+				TournamentSize: 5,
+				NoveltyWeight:  -1,
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -36,11 +36,15 @@ func (g *DeckGenome) Crossover(other any) (any, error) {
 	}
 
 	offspring := &DeckGenome{
-		Cards:      g.repairDeck(cards, otherDeck),
-		config:     g.config,
-		candidates: g.candidates,
-		strategy:   g.strategy,
+		Cards:            g.repairDeck(cards, otherDeck),
+		config:           g.config,
+		candidates:       g.candidates,
+		strategy:         g.strategy,
+		fitnessEvaluator: g.fitnessEvaluator,
+		fitnessCache:     g.fitnessCache,
+		lineage:          g.lineage,
 	}
+	g.lineage.Record(offspring.Cards, deck.LineageCrossover, g.Cards, otherDeck.Cards)
 
 	return offspring, nil
 }
@@ -169,7 +173,8 @@ func (g *DeckGenome) repairDeck(cards []string, parents ...*DeckGenome) []string
 		}
 	}
 
-	return g.ensureWinCondition(repaired, used, candidateMap)
+	repaired = g.ensureWinCondition(repaired, used, candidateMap)
+	return g.enforceEvolutionConstraints(repaired)
 }
 
 func (g *DeckGenome) ensureWinCondition(cards []string, used map[string]bool, candidateMap map[string]*deck.CardCandidate) []string {
@@ -0,0 +1,128 @@
+// Package genetic provides genetic algorithm-based deck optimization
+// using the eaopt library for evolutionary deck generation.
+package genetic
+
+import (
+	"fmt"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+// hasEvolutionConstraints reports whether MinEvolvedCards or
+// EvolutionSlotLimit is set.
+func (c *GeneticConfig) hasEvolutionConstraints() bool {
+	return c != nil && (c.MinEvolvedCards > 0 || c.EvolutionSlotLimit > 0)
+}
+
+// isEvolved reports whether the player owns the card's evolution.
+func isEvolved(candidate *deck.CardCandidate) bool {
+	return candidate != nil && candidate.EvolutionLevel > 0
+}
+
+// validateEvolutionPool checks that the candidate pool can satisfy the
+// evolution constraints at all.
+func validateEvolutionPool(candidates []*deck.CardCandidate, config *GeneticConfig) error {
+	if !config.hasEvolutionConstraints() {
+		return nil
+	}
+	evolved := 0
+	for _, candidate := range candidates {
+		if isEvolved(candidate) {
+			evolved++
+		}
+	}
+	if evolved < config.MinEvolvedCards {
+		return fmt.Errorf("min_evolved_cards is %d but only %d candidate cards are evolved", config.MinEvolvedCards, evolved)
+	}
+	if config.EvolutionSlotLimit > 0 && len(candidates)-evolved < 8-config.EvolutionSlotLimit {
+		return fmt.Errorf("evolution_slot_limit %d needs %d cards that are not evolved, got %d",
+			config.EvolutionSlotLimit, 8-config.EvolutionSlotLimit, len(candidates)-evolved)
+	}
+	return nil
+}
+
+// enforceEvolutionConstraints swaps cards until the deck has at least
+// MinEvolvedCards and at most EvolutionSlotLimit evolved cards. Swaps prefer
+// a replacement with the same role and never remove the deck's only win
+// condition.
+func (g *DeckGenome) enforceEvolutionConstraints(cards []string) []string {
+	if !g.config.hasEvolutionConstraints() {
+		return cards
+	}
+	candidateMap := g.candidateMap()
+	used := make(map[string]bool, len(cards))
+	evolved := 0
+	for _, card := range cards {
+		used[card] = true
+		if isEvolved(candidateMap[card]) {
+			evolved++
+		}
+	}
+
+	for g.config.EvolutionSlotLimit > 0 && evolved > g.config.EvolutionSlotLimit {
+		if !g.swapForEvolution(cards, used, candidateMap, false) {
+			break
+		}
+		evolved--
+	}
+	for evolved < g.config.MinEvolvedCards {
+		if !g.swapForEvolution(cards, used, candidateMap, true) {
+			break
+		}
+		evolved++
+	}
+	return cards
+}
+
+// swapForEvolution replaces one card with an unused card whose evolution
+// state is wantEvolved, taking a card of the opposite state out. It returns
+// false when no swap is possible.
+func (g *DeckGenome) swapForEvolution(cards []string, used map[string]bool, candidateMap map[string]*deck.CardCandidate, wantEvolved bool) bool {
+	winConditions := 0
+	for _, card := range cards {
+		if g.isWinCondition(card, candidateMap) {
+			winConditions++
+		}
+	}
+
+	var positions []int
+	for i, card := range cards {
+		if isEvolved(candidateMap[card]) == wantEvolved {
+			continue
+		}
+		if winConditions == 1 && g.isWinCondition(card, candidateMap) {
+			continue
+		}
+		positions = append(positions, i)
+	}
+	if len(positions) == 0 {
+		return false
+	}
+	pos := positions[randomInt(len(positions))]
+	out := candidateMap[cards[pos]]
+
+	var sameRole, other []string
+	for _, candidate := range g.candidates {
+		if used[candidate.Name] || isEvolved(candidate) != wantEvolved {
+			continue
+		}
+		if out != nil && out.Role != nil && candidate.Role != nil && *out.Role == *candidate.Role {
+			sameRole = append(sameRole, candidate.Name)
+		} else {
+			other = append(other, candidate.Name)
+		}
+	}
+	options := sameRole
+	if len(options) == 0 {
+		options = other
+	}
+	if len(options) == 0 {
+		return false
+	}
+
+	replacement := options[randomInt(len(options))]
+	delete(used, cards[pos])
+	cards[pos] = replacement
+	used[replacement] = true
+	return true
+}
@@ -0,0 +1,126 @@
+package genetic
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+// createEvolvedCandidates marks the first evolved mock candidates as
+// evolved. Evolved cards score highest so an unconstrained GA favors them.
+func createEvolvedCandidates(count, evolved int) []*deck.CardCandidate {
+	candidates := createMockCandidates(count)
+	for i := range evolved {
+		candidates[i].EvolutionLevel = 1
+		candidates[i].MaxEvolutionLevel = 1
+		candidates[i].Score += 10
+	}
+	return candidates
+}
+
+func countEvolved(cards []string, candidates []*deck.CardCandidate) int {
+	evolved := 0
+	for _, candidate := range candidates {
+		for _, card := range cards {
+			if card == candidate.Name && isEvolved(candidate) {
+				evolved++
+			}
+		}
+	}
+	return evolved
+}
+
+func TestEnforceEvolutionConstraints(t *testing.T) {
+	candidates := createEvolvedCandidates(16, 5)
+	cfg := DefaultGeneticConfig()
+	cfg.MinEvolvedCards = 2
+	cfg.EvolutionSlotLimit = 2
+
+	tests := []struct {
+		name  string
+		cards []string
+	}{
+		{"too many evolved", []string{"Card0", "Card1", "Card2", "Card3", "Card4", "Card5", "Card6", "Card7"}},
+		{"none evolved", []string{"Card6", "Card7", "Card8", "Card9", "Card10", "Card11", "Card12", "Card13"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			genome, err := NewDeckGenomeFromCards(tt.cards, candidates, deck.StrategyBalanced, &cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cards := genome.enforceEvolutionConstraints(genome.Cards)
+			if got := countEvolved(cards, candidates); got != 2 {
+				t.Errorf("evolved cards = %d in %v, want exactly 2", got, cards)
+			}
+			seen := make(map[string]bool)
+			hasWinCondition := false
+			for _, card := range cards {
+				if seen[card] {
+					t.Errorf("duplicate card %s in %v", card, cards)
+				}
+				seen[card] = true
+				hasWinCondition = hasWinCondition || genome.isWinCondition(card, genome.candidateMap())
+			}
+			if !hasWinCondition {
+				t.Errorf("repair removed the win condition: %v", cards)
+			}
+		})
+	}
+}
+
+func TestOptimizeWithEvolutionConstraints(t *testing.T) {
+	candidates := createEvolvedCandidates(16, 5)
+	cfg := DefaultGeneticConfig()
+	cfg.PopulationSize = 20
+	cfg.Generations = 5
+	cfg.ConvergenceGenerations = 0
+	cfg.ParallelEvaluations = false
+	cfg.MinEvolvedCards = 2
+	cfg.EvolutionSlotLimit = 2
+	cfg.SeedPopulation = [][]string{{"Card0", "Card1", "Card2", "Card3", "Card4", "Card5", "Card6", "Card7"}}
+
+	optimizer, err := NewGeneticOptimizer(candidates, deck.StrategyBalanced, &cfg)
+	if err != nil {
+		t.Fatalf("NewGeneticOptimizer() error = %v", err)
+	}
+	optimizer.RNG = rand.New(rand.NewSource(3))
+	optimizer.FitnessFunc = func(cards []deck.CardCandidate) (float64, error) {
+		total := 0.0
+		for _, card := range cards {
+			total += card.Score
+		}
+		return total, nil
+	}
+
+	result, err := optimizer.Optimize()
+	if err != nil {
+		t.Fatalf("Optimize() error = %v", err)
+	}
+	if len(result.HallOfFame) == 0 {
+		t.Fatal("expected hall of fame decks")
+	}
+	for _, genome := range result.HallOfFame {
+		if got := countEvolved(genome.Cards, candidates); got != 2 {
+			t.Errorf("deck %v has %d evolved cards, want exactly 2", genome.Cards, got)
+		}
+	}
+}
+
+func TestNewGeneticOptimizerRejectsInfeasibleEvolutionConstraints(t *testing.T) {
+	cfg := DefaultGeneticConfig()
+	cfg.MinEvolvedCards = 3
+	_, err := NewGeneticOptimizer(createEvolvedCandidates(16, 2), deck.StrategyBalanced, &cfg)
+	if err == nil || !strings.Contains(err.Error(), "only 2 candidate cards are evolved") {
+		t.Errorf("error = %v, want a min_evolved_cards pool error", err)
+	}
+
+	cfg.MinEvolvedCards = 0
+	cfg.EvolutionSlotLimit = 1
+	_, err = NewGeneticOptimizer(createEvolvedCandidates(10, 4), deck.StrategyBalanced, &cfg)
+	if err == nil || !strings.Contains(err.Error(), "evolution_slot_limit") {
+		t.Errorf("error = %v, want an evolution_slot_limit pool error", err)
+	}
+}
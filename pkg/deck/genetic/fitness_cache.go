@@ -8,7 +8,16 @@ import (
 	"sync"
 )
 
-var fitnessCache sync.Map
+// fitnessCache remembers the fitness of each deck one optimizer run has
+// evaluated. Each run gets its own, so runs with different fitness functions
+// or card levels never share scores.
+type fitnessCache struct {
+	scores sync.Map
+}
+
+func newFitnessCache() *fitnessCache {
+	return &fitnessCache{}
+}
 
 func fitnessCacheKey(cards []string) string {
 	if len(cards) == 0 {
@@ -20,12 +29,13 @@ func fitnessCacheKey(cards []string) string {
 	return strings.Join(sorted, "|")
 }
 
-func getCachedFitness(cards []string) (float64, bool) {
+// get returns the deck's remembered fitness. A nil cache remembers nothing.
+func (c *fitnessCache) get(cards []string) (float64, bool) {
 	key := fitnessCacheKey(cards)
-	if key == "" {
+	if c == nil || key == "" {
 		return 0, false
 	}
-	if value, ok := fitnessCache.Load(key); ok {
+	if value, ok := c.scores.Load(key); ok {
 		if fitness, ok := value.(float64); ok {
 			return fitness, true
 		}
@@ -33,10 +43,10 @@ func getCachedFitness(cards []string) (float64, bool) {
 	return 0, false
 }
 
-func storeCachedFitness(cards []string, fitness float64) {
+func (c *fitnessCache) store(cards []string, fitness float64) {
 	key := fitnessCacheKey(cards)
-	if key == "" {
+	if c == nil || key == "" {
 		return
 	}
-	fitnessCache.Store(key, fitness)
+	c.scores.Store(key, fitness)
 }
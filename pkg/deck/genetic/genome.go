@@ -32,6 +32,14 @@ type DeckGenome struct {
 
 	// fitnessEvaluator overrides default Evaluate behavior when set.
 	fitnessEvaluator func([]deck.CardCandidate) (float64, error)
+
+	// fitnessCache, when set, holds the scores of decks the optimizer run
+	// already evaluated.
+	fitnessCache *fitnessCache
+
+	// lineage, when set, records the parents of decks crossover and
+	// mutation produce.
+	lineage *deck.LineageRecorder
 }
 
 // NewDeckGenome creates a new random deck genome from the available candidates.
@@ -87,7 +95,8 @@ func NewDeckGenomeFromCards(cardNames []string, candidates []*deck.CardCandidate
 }
 
 // initializeRandomDeck creates a random valid deck from the candidate pool.
-// It ensures the deck has at least one win condition and respects role diversity.
+// It ensures the deck has at least one win condition and respects role
+// diversity and the evolution constraints.
 func (g *DeckGenome) initializeRandomDeck() error {
 	// Group candidates by role for balanced selection
 	byRole := make(map[deck.CardRole][]*deck.CardCandidate)
@@ -134,7 +143,7 @@ func (g *DeckGenome) initializeRandomDeck() error {
 		return fmt.Errorf("failed to select 8 unique cards, got %d", len(cards))
 	}
 
-	g.Cards = cards
+	g.Cards = g.enforceEvolutionConstraints(cards)
 	return nil
 }
 
@@ -166,7 +175,7 @@ func (g *DeckGenome) Evaluate() (float64, error) {
 		return 0, fmt.Errorf("failed to resolve all cards: got %d, want 8", len(deckCards))
 	}
 
-	if cached, ok := getCachedFitness(g.Cards); ok {
+	if cached, ok := g.fitnessCache.get(g.Cards); ok {
 		g.Fitness = cached
 		return g.Fitness, nil
 	}
@@ -177,7 +186,7 @@ func (g *DeckGenome) Evaluate() (float64, error) {
 			return 0, err
 		}
 		g.Fitness = fitness
-		storeCachedFitness(g.Cards, g.Fitness)
+		g.fitnessCache.store(g.Cards, g.Fitness)
 		return g.Fitness, nil
 	}
 
@@ -189,11 +198,20 @@ func (g *DeckGenome) Evaluate() (float64, error) {
 
 	// Use OverallScore (0-10 scale) as fitness
 	g.Fitness = result.OverallScore
-	storeCachedFitness(g.Cards, g.Fitness)
+	g.fitnessCache.store(g.Cards, g.Fitness)
 
 	return g.Fitness, nil
 }
 
+// defaultFitnessFunc scores decks like Evaluate without a fitness evaluator,
+// sharing one synergy database across a run.
+func defaultFitnessFunc() func([]deck.CardCandidate) (float64, error) {
+	synergyDB := deck.NewSynergyDatabase()
+	return func(deckCards []deck.CardCandidate) (float64, error) {
+		return evaluation.Evaluate(deckCards, synergyDB, nil).OverallScore, nil
+	}
+}
+
 // Clone creates a deep copy of this genome.
 //
 // This method implements the eaopt.Genome interface requirement.
@@ -208,6 +226,8 @@ func (g *DeckGenome) Clone() any {
 		candidates:       g.candidates,
 		strategy:         g.strategy,
 		fitnessEvaluator: g.fitnessEvaluator,
+		fitnessCache:     g.fitnessCache,
+		lineage:          g.lineage,
 	}
 }
 
@@ -0,0 +1,329 @@
+// Package genetic provides genetic algorithm-based deck optimization
+// using the eaopt library for evolutionary deck generation.
+package genetic
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+
+	"github.com/MaxHalford/eaopt"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+)
+
+// DefaultIslandStrategyWeight is the share of an island's fitness that comes
+// from its strategy score when GeneticConfig.IslandStrategyWeight is 0.
+const DefaultIslandStrategyWeight = 0.5
+
+// IslandTopology names the pattern migrants travel between islands.
+type IslandTopology string
+
+// Supported island topologies.
+const (
+	// TopologyRing exchanges migrants between consecutive islands.
+	TopologyRing IslandTopology = "ring"
+	// TopologyFullyConnected exchanges migrants between every pair of islands.
+	TopologyFullyConnected IslandTopology = "fully-connected"
+	// TopologyRandom exchanges migrants between each island and one other
+	// island picked at random every migration.
+	TopologyRandom IslandTopology = "random"
+)
+
+// AllIslandTopologies lists every supported topology.
+var AllIslandTopologies = []IslandTopology{TopologyRing, TopologyFullyConnected, TopologyRandom}
+
+// ParseIslandTopology parses a topology name. An empty string is the ring
+// topology.
+func ParseIslandTopology(value string) (IslandTopology, error) {
+	value = strings.TrimSpace(strings.ToLower(value))
+	switch value {
+	case "":
+		return TopologyRing, nil
+	case "full", "fully_connected", "complete":
+		return TopologyFullyConnected, nil
+	}
+	topology := IslandTopology(value)
+	if !topology.valid() {
+		return "", fmt.Errorf("unknown island topology %q (supported: ring, fully-connected, random)", value)
+	}
+	return topology, nil
+}
+
+func (t IslandTopology) valid() bool {
+	for _, known := range AllIslandTopologies {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// IslandStrategy is the goal one island evolves toward: fitting an archetype,
+// or scoring well on one evaluation objective. Exactly one field is set.
+type IslandStrategy struct {
+	Archetype evaluation.Archetype
+	Objective Objective
+}
+
+// String returns the archetype or objective name.
+func (s IslandStrategy) String() string {
+	if s.Archetype != "" {
+		return string(s.Archetype)
+	}
+	return string(s.Objective)
+}
+
+// ParseIslandStrategies parses a comma-separated list of archetypes and
+// objectives such as "cycle,beatdown,defense". Objectives are checked first,
+// then the registered archetype scorers. An empty string yields nil.
+func ParseIslandStrategies(value string) ([]IslandStrategy, error) {
+	value = strings.TrimSpace(strings.ToLower(value))
+	if value == "" {
+		return nil, nil
+	}
+
+	var strategies []IslandStrategy
+	for part := range strings.SplitSeq(value, ",") {
+		name := strings.TrimSpace(part)
+		if objective := Objective(name); objective.valid() {
+			strategies = append(strategies, IslandStrategy{Objective: objective})
+			continue
+		}
+		if archetypeScorer(evaluation.Archetype(name)) != nil {
+			strategies = append(strategies, IslandStrategy{Archetype: evaluation.Archetype(name)})
+			continue
+		}
+		return nil, fmt.Errorf("unknown island strategy %q (use an archetype or one of: attack, defense, synergy, f2p)", part)
+	}
+	return strategies, nil
+}
+
+func (s IslandStrategy) validate() error {
+	switch {
+	case s.Archetype != "" && s.Objective != "":
+		return fmt.Errorf("island strategy sets both archetype %q and objective %q", s.Archetype, s.Objective)
+	case s.Objective != "":
+		if !s.Objective.valid() {
+			return fmt.Errorf("unknown objective %q", s.Objective)
+		}
+	case s.Archetype != "":
+		if archetypeScorer(s.Archetype) == nil {
+			return fmt.Errorf("unknown archetype %q", s.Archetype)
+		}
+	default:
+		return errors.New("island strategy needs an archetype or objective")
+	}
+	return nil
+}
+
+func archetypeScorer(archetype evaluation.Archetype) evaluation.ArchetypeScorer {
+	for _, scorer := range evaluation.ArchetypeScorers() {
+		if scorer.Archetype() == archetype {
+			return scorer
+		}
+	}
+	return nil
+}
+
+// IslandProgress is one island's population statistics for a generation.
+// Fitness values are evaluation fitness, so islands with different strategies
+// can be compared.
+type IslandProgress struct {
+	Island      int
+	Strategy    string
+	BestFitness float64
+	AvgFitness  float64
+	Diversity   float64
+}
+
+// islandScorer blends an island's strategy score into the search fitness.
+// Strategy scores are cached per deck because islands share decks through
+// migration and crossover.
+type islandScorer struct {
+	strategy  IslandStrategy
+	weight    float64
+	synergyDB *deck.SynergyDatabase
+	cache     sync.Map
+}
+
+// newIslandScorers returns one scorer per island, assigning the configured
+// strategies round-robin, or nil when no strategies are configured.
+func newIslandScorers(config *GeneticConfig, islands int) []*islandScorer {
+	if config == nil || len(config.IslandStrategies) == 0 || islands <= 1 {
+		return nil
+	}
+	weight := config.IslandStrategyWeight
+	if weight <= 0 {
+		weight = DefaultIslandStrategyWeight
+	}
+	synergyDB := deck.NewSynergyDatabase()
+	byStrategy := make(map[IslandStrategy]*islandScorer)
+	scorers := make([]*islandScorer, islands)
+	for i := range scorers {
+		strategy := config.IslandStrategies[i%len(config.IslandStrategies)]
+		scorer, ok := byStrategy[strategy]
+		if !ok {
+			scorer = &islandScorer{strategy: strategy, weight: weight, synergyDB: synergyDB}
+			byStrategy[strategy] = scorer
+		}
+		scorers[i] = scorer
+	}
+	return scorers
+}
+
+// fitness blends the deck's evaluation fitness with its strategy score.
+func (s *islandScorer) fitness(genome *DeckGenome, base float64) float64 {
+	if s == nil {
+		return base
+	}
+	return (1-s.weight)*base + s.weight*s.score(genome)
+}
+
+// score is the deck's 0-10 fit for the island's archetype or objective.
+func (s *islandScorer) score(genome *DeckGenome) float64 {
+	key := fitnessCacheKey(genome.Cards)
+	if cached, ok := s.cache.Load(key); ok {
+		return cached.(float64)
+	}
+	deckCards := genome.getCardCandidates()
+	var score float64
+	if s.strategy.Archetype != "" {
+		if scorer := archetypeScorer(s.strategy.Archetype); scorer != nil {
+			score = scorer.Score(deckCards)
+		}
+	} else {
+		result := evaluation.Evaluate(deckCards, s.synergyDB, nil)
+		score = ObjectiveScores(result, []Objective{s.strategy.Objective})[0]
+	}
+	s.cache.Store(key, score)
+	return score
+}
+
+// withIslands attaches each island's scorer to the genomes a factory creates.
+// eaopt fills the islands one after another, popSize genomes each.
+func withIslands(factory func(rng *rand.Rand) eaopt.Genome, scorers []*islandScorer, popSize uint) func(rng *rand.Rand) eaopt.Genome {
+	created := 0
+	return func(rng *rand.Rand) eaopt.Genome {
+		genome := factory(rng)
+		if wrapped, ok := genome.(*eaoptDeckGenome); ok {
+			wrapped.island = scorers[(created/int(popSize))%len(scorers)]
+		}
+		created++
+		return genome
+	}
+}
+
+// islandMigrator exchanges random individuals between islands following a
+// topology. Migrants take on the receiving island's strategy and are scored
+// again under it.
+type islandMigrator struct {
+	topology  IslandTopology
+	nMigrants uint
+	scorers   []*islandScorer
+}
+
+// Apply exchanges migrants between the populations.
+func (m islandMigrator) Apply(pops eaopt.Populations, rng *rand.Rand) {
+	if len(pops) < 2 {
+		return
+	}
+	if len(pops) == 2 {
+		// Every topology links the only two islands once
+		m.exchange(pops, 0, 1, rng)
+		return
+	}
+	switch m.topology {
+	case TopologyFullyConnected:
+		for i := range pops {
+			for j := i + 1; j < len(pops); j++ {
+				m.exchange(pops, i, j, rng)
+			}
+		}
+	case TopologyRandom:
+		for i := range pops {
+			j := rng.Intn(len(pops) - 1)
+			if j >= i {
+				j++
+			}
+			m.exchange(pops, i, j, rng)
+		}
+	default:
+		for i := range pops {
+			m.exchange(pops, i, (i+1)%len(pops), rng)
+		}
+	}
+}
+
+// exchange swaps nMigrants random individuals between islands i and j.
+func (m islandMigrator) exchange(pops eaopt.Populations, i, j int, rng *rand.Rand) {
+	size := min(len(pops[i].Individuals), len(pops[j].Individuals))
+	n := min(int(m.nMigrants), size)
+	for _, k := range rng.Perm(size)[:n] {
+		pops[i].Individuals[k], pops[j].Individuals[k] = pops[j].Individuals[k], pops[i].Individuals[k]
+		m.settle(&pops[i].Individuals[k], i)
+		m.settle(&pops[j].Individuals[k], j)
+	}
+}
+
+// settle re-scores a migrant under its new island's strategy.
+func (m islandMigrator) settle(indi *eaopt.Individual, island int) {
+	if len(m.scorers) == 0 {
+		return
+	}
+	wrapped, ok := indi.Genome.(*eaoptDeckGenome)
+	if !ok || wrapped == nil || wrapped.island == m.scorers[island] {
+		return
+	}
+	wrapped.island = m.scorers[island]
+	indi.Evaluated = false
+	_ = indi.Evaluate()
+}
+
+// Validate checks the migrator's settings.
+func (m islandMigrator) Validate() error {
+	if m.nMigrants == 0 {
+		return errors.New("migration size must be positive")
+	}
+	if !m.topology.valid() {
+		return fmt.Errorf("unknown island topology %q", m.topology)
+	}
+	return nil
+}
+
+// islandProgress summarizes every island's population.
+func islandProgress(ga *eaopt.GA, scorers []*islandScorer) []IslandProgress {
+	if ga == nil || len(ga.Populations) < 2 {
+		return nil
+	}
+	islands := make([]IslandProgress, 0, len(ga.Populations))
+	for i, pop := range ga.Populations {
+		stats := IslandProgress{Island: i + 1}
+		if i < len(scorers) {
+			stats.Strategy = scorers[i].strategy.String()
+		}
+		var decks [][]string
+		sum := 0.0
+		for _, indi := range pop.Individuals {
+			wrapped, ok := indi.Genome.(*eaoptDeckGenome)
+			if !ok || wrapped == nil || wrapped.genome == nil {
+				continue
+			}
+			fitness := wrapped.genome.Fitness
+			if len(decks) == 0 || fitness > stats.BestFitness {
+				stats.BestFitness = fitness
+			}
+			sum += fitness
+			decks = append(decks, wrapped.genome.Cards)
+		}
+		if len(decks) > 0 {
+			// Summing equal fitnesses can round the mean just above them
+			stats.AvgFitness = min(sum/float64(len(decks)), stats.BestFitness)
+		}
+		stats.Diversity = PopulationDiversity(decks)
+		islands = append(islands, stats)
+	}
+	return islands
+}
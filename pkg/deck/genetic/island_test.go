@@ -0,0 +1,208 @@
+package genetic
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/MaxHalford/eaopt"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+)
+
+func TestParseIslandTopology(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    IslandTopology
+		wantErr bool
+	}{
+		{"", TopologyRing, false},
+		{"ring", TopologyRing, false},
+		{"Fully-Connected", TopologyFullyConnected, false},
+		{"full", TopologyFullyConnected, false},
+		{"random", TopologyRandom, false},
+		{"star", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseIslandTopology(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseIslandTopology(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ParseIslandTopology(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseIslandStrategies(t *testing.T) {
+	strategies, err := ParseIslandStrategies("cycle, Defense,beatdown")
+	if err != nil {
+		t.Fatalf("ParseIslandStrategies() error = %v", err)
+	}
+	want := []IslandStrategy{
+		{Archetype: evaluation.ArchetypeCycle},
+		{Objective: ObjectiveDefense},
+		{Archetype: evaluation.ArchetypeBeatdown},
+	}
+	if len(strategies) != len(want) {
+		t.Fatalf("ParseIslandStrategies() = %v, want %v", strategies, want)
+	}
+	for i := range want {
+		if strategies[i] != want[i] {
+			t.Errorf("strategy %d = %+v, want %+v", i, strategies[i], want[i])
+		}
+	}
+
+	if _, err := ParseIslandStrategies("cycle,nonsense"); err == nil {
+		t.Error("expected an error for an unknown strategy")
+	}
+	if got, err := ParseIslandStrategies(""); err != nil || got != nil {
+		t.Errorf("ParseIslandStrategies(\"\") = %v, %v; want nil, nil", got, err)
+	}
+}
+
+func TestValidateIslandSettings(t *testing.T) {
+	config := DefaultGeneticConfig()
+	config.IslandModel = true
+	config.MigrationTopology = "star"
+	if err := config.Validate(); err == nil {
+		t.Error("expected an error for an unknown topology")
+	}
+
+	config.MigrationTopology = TopologyRandom
+	config.IslandStrategies = []IslandStrategy{{Archetype: "nonsense"}}
+	if err := config.Validate(); err == nil {
+		t.Error("expected an error for an unknown island archetype")
+	}
+
+	config.IslandStrategies = []IslandStrategy{{Archetype: evaluation.ArchetypeSiege}, {Objective: ObjectiveAttack}}
+	config.IslandStrategyWeight = 1.5
+	if err := config.Validate(); err == nil {
+		t.Error("expected an error for an out-of-range strategy weight")
+	}
+
+	config.IslandStrategyWeight = 0.3
+	if err := config.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+// labeledPopulations builds islands whose individuals record their origin
+func labeledPopulations(islands, size int) eaopt.Populations {
+	pops := make(eaopt.Populations, islands)
+	for i := range pops {
+		pops[i].Individuals = make(eaopt.Individuals, size)
+		for k := range pops[i].Individuals {
+			pops[i].Individuals[k] = eaopt.Individual{ID: string(rune('A' + i))}
+		}
+	}
+	return pops
+}
+
+// foreigners counts individuals on each island that came from another island
+func foreigners(pops eaopt.Populations) []int {
+	counts := make([]int, len(pops))
+	for i, pop := range pops {
+		for _, indi := range pop.Individuals {
+			if indi.ID != string(rune('A'+i)) {
+				counts[i]++
+			}
+		}
+	}
+	return counts
+}
+
+func TestIslandMigratorTopologies(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	// Fully connected: every island trades one migrant with each of the other
+	// three islands
+	pops := labeledPopulations(4, 20)
+	islandMigrator{topology: TopologyFullyConnected, nMigrants: 1}.Apply(pops, rng)
+	sources := make(map[string]bool)
+	for _, indi := range pops[0].Individuals {
+		sources[indi.ID] = true
+	}
+	if len(sources) < 3 {
+		t.Errorf("fully connected: island 1 holds individuals from %d islands, want at least 3", len(sources))
+	}
+
+	// Ring: the last island sends migrants back to the first
+	pops = labeledPopulations(4, 20)
+	islandMigrator{topology: TopologyRing, nMigrants: 1}.Apply(pops, rng)
+	fromLast := false
+	for _, indi := range pops[0].Individuals {
+		if indi.ID == "D" {
+			fromLast = true
+		}
+	}
+	if !fromLast {
+		t.Error("ring: expected the first island to receive a migrant from the last")
+	}
+
+	// Random: every island exchanges with some other island
+	pops = labeledPopulations(5, 20)
+	islandMigrator{topology: TopologyRandom, nMigrants: 2}.Apply(pops, rng)
+	for i, count := range foreigners(pops) {
+		if count == 0 {
+			t.Errorf("random: island %d received no migrants", i+1)
+		}
+	}
+}
+
+func TestGeneticOptimizerIslandStrategies(t *testing.T) {
+
+	candidates := createMockCandidates(20)
+	config := GeneticConfig{
+		PopulationSize:    40,
+		Generations:       6,
+		MutationRate:      0.2,
+		CrossoverRate:     0.7,
+		EliteCount:        2,
+		TournamentSize:    3,
+		IslandModel:       true,
+		IslandCount:       4,
+		MigrationInterval: 2,
+		MigrationSize:     2,
+		MigrationTopology: TopologyFullyConnected,
+		IslandStrategies:  []IslandStrategy{{Archetype: evaluation.ArchetypeCycle}, {Objective: ObjectiveDefense}},
+	}
+
+	optimizer, err := NewGeneticOptimizer(candidates, deck.StrategyBalanced, &config)
+	if err != nil {
+		t.Fatalf("NewGeneticOptimizer() failed: %v", err)
+	}
+	optimizer.RNG = rand.New(rand.NewSource(7))
+	var last GeneticProgress
+	optimizer.Progress = func(progress GeneticProgress) { last = progress }
+
+	result, err := optimizer.Optimize()
+	if err != nil {
+		t.Fatalf("Optimize() error = %v", err)
+	}
+	if len(result.HallOfFame) == 0 {
+		t.Fatal("Optimize() produced empty hall of fame")
+	}
+	for i := 1; i < len(result.Scores); i++ {
+		if result.Scores[i] > result.Scores[i-1] {
+			t.Errorf("hall of fame not sorted by score: %v", result.Scores)
+		}
+	}
+	for i, genome := range result.HallOfFame {
+		if result.Scores[i] != genome.Fitness {
+			t.Errorf("score %d = %.3f, want evaluation fitness %.3f", i, result.Scores[i], genome.Fitness)
+		}
+	}
+
+	if len(last.Islands) != 4 {
+		t.Fatalf("progress reported %d islands, want 4", len(last.Islands))
+	}
+	wantStrategies := []string{"cycle", "defense", "cycle", "defense"}
+	for i, island := range last.Islands {
+		if island.Island != i+1 || island.Strategy != wantStrategies[i] {
+			t.Errorf("island %d = %+v, want strategy %q", i, island, wantStrategies[i])
+		}
+		if island.BestFitness < island.AvgFitness {
+			t.Errorf("island %d best %.3f below average %.3f", i+1, island.BestFitness, island.AvgFitness)
+		}
+	}
+}
@@ -3,13 +3,19 @@
 package genetic
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
 
 	"github.com/klauer/clash-royale-api/go/pkg/deck"
 )
 
 const (
 	synergyMutationThreshold = 0.6
+
+	// similarSwapPool is how many of the most similar cards a similar-card
+	// swap picks from
+	similarSwapPool = 3
 )
 
 // Mutate applies random mutations to the deck genome.
@@ -33,7 +39,7 @@ func (g *DeckGenome) Mutate() error {
 		delete(used, oldCard)
 
 		var replacement string
-		switch randomInt(5) {
+		switch randomInt(6) {
 		case 0:
 			replacement = g.singleCardSwap(used)
 		case 1:
@@ -42,6 +48,8 @@ func (g *DeckGenome) Mutate() error {
 			replacement = g.synergyGuidedSwap(oldCard, used)
 		case 3:
 			replacement = g.evolutionAwareSwap(oldCard, used)
+		case 4:
+			replacement = g.similarCardSwap(oldCard, used)
 		default:
 			replacement = g.mixedMutationSwap(oldCard, used)
 		}
@@ -73,7 +81,9 @@ func (g *DeckGenome) Mutate() error {
 
 	g.Cards = g.repairDeck(g.Cards, g)
 	g.ensureMutationChanged(originalCards)
+	g.Cards = g.enforceEvolutionConstraints(g.Cards)
 	g.Fitness = 0
+	g.lineage.Record(g.Cards, deck.LineageMutation, originalCards)
 	return nil
 }
 
@@ -189,6 +199,31 @@ func (g *DeckGenome) synergyGuidedSwap(oldCard string, used map[string]bool) str
 	return bestCandidate
 }
 
+// similarCardSwap replaces a card with one of the candidates closest to it
+// in the card embedding, keeping the deck's shape while exploring stand-ins
+func (g *DeckGenome) similarCardSwap(oldCard string, used map[string]bool) string {
+	embedding := deck.DefaultCardEmbedding()
+	var similar []deck.SimilarCard
+	for _, candidate := range g.candidates {
+		if used[candidate.Name] || candidate.Name == oldCard {
+			continue
+		}
+		if score := embedding.Similarity(oldCard, candidate.Name); score > 0 {
+			similar = append(similar, deck.SimilarCard{Name: candidate.Name, Similarity: score})
+		}
+	}
+	if len(similar) == 0 {
+		return g.roleBasedSwap(oldCard, used)
+	}
+	slices.SortFunc(similar, func(a, b deck.SimilarCard) int {
+		if c := cmp.Compare(b.Similarity, a.Similarity); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return similar[randomInt(min(similarSwapPool, len(similar)))].Name
+}
+
 func (g *DeckGenome) evolutionAwareSwap(oldCard string, used map[string]bool) string {
 	var evolved []string
 	var normal []string
@@ -214,11 +249,13 @@ func (g *DeckGenome) evolutionAwareSwap(oldCard string, used map[string]bool) st
 }
 
 func (g *DeckGenome) mixedMutationSwap(oldCard string, used map[string]bool) string {
-	switch randomInt(3) {
+	switch randomInt(4) {
 	case 0:
 		return g.roleBasedSwap(oldCard, used)
 	case 1:
 		return g.synergyGuidedSwap(oldCard, used)
+	case 2:
+		return g.similarCardSwap(oldCard, used)
 	default:
 		return g.evolutionAwareSwap(oldCard, used)
 	}
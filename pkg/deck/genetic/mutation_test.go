@@ -422,3 +422,26 @@ func TestSingleCardSwapNoOptions(t *testing.T) {
 		t.Errorf("singleCardSwap() with no options should return empty, got: %v", replacement)
 	}
 }
+
+func TestSimilarCardSwap(t *testing.T) {
+	names := []string{"Hog Rider", "Musketeer", "Cannon", "Ice Golem", "Ice Spirit", "Skeletons", "Zap", "Fireball",
+		"The Log", "Giant Snowball", "Arrows", "Golem", "P.E.K.K.A", "Lava Hound"}
+	candidates := make([]*deck.CardCandidate, len(names))
+	for i, name := range names {
+		candidates[i] = &deck.CardCandidate{Name: name, Level: 11, MaxLevel: 14}
+	}
+	cfg := DefaultGeneticConfig()
+	genome, err := NewDeckGenomeFromCards(names[:8], candidates, deck.StrategyBalanced, &cfg)
+	if err != nil {
+		t.Fatalf("NewDeckGenomeFromCards() failed: %v", err)
+	}
+
+	used := genome.currentCardSet()
+	delete(used, "Zap")
+	for range 20 {
+		got := genome.similarCardSwap("Zap", used)
+		if got != "The Log" && got != "Giant Snowball" && got != "Arrows" {
+			t.Fatalf("similarCardSwap(Zap) = %q, want a small spell", got)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Package genetic provides genetic algorithm-based deck optimization
+// using the eaopt library for evolutionary deck generation.
+package genetic
+
+import (
+	"slices"
+	"sync"
+)
+
+// DefaultNoveltyNeighbors is the number of nearest decks novelty is measured
+// against when GeneticConfig.NoveltyNeighbors is 0.
+const DefaultNoveltyNeighbors = 15
+
+// deckDistance is the fraction of cards two decks do not share: 0 for the
+// same deck, 1 for decks with no card in common.
+func deckDistance(a, b []string) float64 {
+	size := max(len(a), len(b))
+	if size == 0 {
+		return 0
+	}
+	shared := 0
+	for _, card := range a {
+		if slices.Contains(b, card) {
+			shared++
+		}
+	}
+	return 1 - float64(shared)/float64(size)
+}
+
+// DeckNovelty is the mean distance from a deck to its k nearest decks in
+// reference (see PopulationDiversity for the distance). It is 0 when
+// reference is empty, and higher for decks unlike anything in reference.
+func DeckNovelty(cards []string, reference [][]string, k int) float64 {
+	if len(reference) == 0 {
+		return 0
+	}
+	if k <= 0 {
+		k = DefaultNoveltyNeighbors
+	}
+	distances := make([]float64, len(reference))
+	for i, other := range reference {
+		distances[i] = deckDistance(cards, other)
+	}
+	slices.Sort(distances)
+
+	k = min(k, len(distances))
+	total := 0.0
+	for _, distance := range distances[:k] {
+		total += distance
+	}
+	return total / float64(k)
+}
+
+// noveltyScorer adds a bonus to decks that differ from the previous
+// generation and the archive. The population is replaced every generation
+// while island populations are evaluated in parallel.
+type noveltyScorer struct {
+	weight    float64
+	neighbors int
+	archive   [][]string
+
+	mu         sync.RWMutex
+	population [][]string
+}
+
+// newNoveltyScorer returns nil when novelty search is disabled.
+func newNoveltyScorer(config *GeneticConfig) *noveltyScorer {
+	if config == nil || config.NoveltyWeight <= 0 {
+		return nil
+	}
+	return &noveltyScorer{
+		weight:    config.NoveltyWeight,
+		neighbors: config.NoveltyNeighbors,
+		archive:   config.NoveltyArchive,
+	}
+}
+
+func (n *noveltyScorer) setPopulation(decks [][]string) {
+	population := make([][]string, len(decks))
+	for i, cards := range decks {
+		population[i] = slices.Clone(cards)
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.population = population
+}
+
+// bonus returns the weighted novelty of a deck.
+func (n *noveltyScorer) bonus(cards []string) float64 {
+	if n == nil {
+		return 0
+	}
+	n.mu.RLock()
+	reference := make([][]string, 0, len(n.population)+len(n.archive))
+	reference = append(reference, n.population...)
+	n.mu.RUnlock()
+	reference = append(reference, n.archive...)
+	return n.weight * DeckNovelty(cards, reference, n.neighbors)
+}
@@ -0,0 +1,101 @@
+// Package genetic provides genetic algorithm-based deck optimization
+// using the eaopt library for evolutionary deck generation.
+package genetic
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+func TestDeckNovelty(t *testing.T) {
+	deckA := []string{"A", "B", "C", "D", "E", "F", "G", "H"}
+	deckB := []string{"I", "J", "K", "L", "M", "N", "O", "P"}
+	half := []string{"A", "B", "C", "D", "M", "N", "O", "P"}
+
+	if got := DeckNovelty(deckA, nil, 3); got != 0 {
+		t.Errorf("novelty with no reference = %v, want 0", got)
+	}
+	if got := DeckNovelty(deckA, [][]string{deckA, deckB}, 1); got != 0 {
+		t.Errorf("novelty against a copy of itself = %v, want 0", got)
+	}
+	if got := DeckNovelty(deckA, [][]string{deckA, half, deckB}, 2); got != 0.25 {
+		t.Errorf("novelty to 2 nearest = %v, want 0.25", got)
+	}
+	if got := DeckNovelty(deckA, [][]string{half, deckB}, 10); got != 0.75 {
+		t.Errorf("novelty with k above reference size = %v, want 0.75", got)
+	}
+}
+
+func TestNoveltyScorerBonus(t *testing.T) {
+	if newNoveltyScorer(&GeneticConfig{}) != nil {
+		t.Fatal("expected no scorer without a novelty weight")
+	}
+	var disabled *noveltyScorer
+	if got := disabled.bonus([]string{"A"}); got != 0 {
+		t.Errorf("disabled scorer bonus = %v, want 0", got)
+	}
+
+	archived := []string{"A", "B", "C", "D", "E", "F", "G", "H"}
+	scorer := newNoveltyScorer(&GeneticConfig{NoveltyWeight: 2, NoveltyNeighbors: 1, NoveltyArchive: [][]string{archived}})
+	if got := scorer.bonus(archived); got != 0 {
+		t.Errorf("archived deck bonus = %v, want 0", got)
+	}
+	fresh := []string{"I", "J", "K", "L", "M", "N", "O", "P"}
+	if got := scorer.bonus(fresh); got != 2 {
+		t.Errorf("unseen deck bonus = %v, want 2", got)
+	}
+	scorer.setPopulation([][]string{fresh})
+	if got := scorer.bonus(fresh); got != 0 {
+		t.Errorf("bonus for a deck already in the population = %v, want 0", got)
+	}
+}
+
+func TestOptimizeWithNoveltySearch(t *testing.T) {
+	candidates := createMockCandidates(16)
+	cfg := DefaultGeneticConfig()
+	cfg.PopulationSize = 20
+	cfg.Generations = 5
+	cfg.ConvergenceGenerations = 0
+	cfg.ParallelEvaluations = false
+	cfg.NoveltyWeight = 5
+	cfg.NoveltyArchive = [][]string{{
+		candidates[0].Name, candidates[1].Name, candidates[2].Name, candidates[3].Name,
+		candidates[4].Name, candidates[5].Name, candidates[6].Name, candidates[7].Name,
+	}}
+
+	optimizer, err := NewGeneticOptimizer(candidates, deck.StrategyBalanced, &cfg)
+	if err != nil {
+		t.Fatalf("NewGeneticOptimizer() error = %v", err)
+	}
+	optimizer.RNG = rand.New(rand.NewSource(11))
+	optimizer.FitnessFunc = func(cards []deck.CardCandidate) (float64, error) {
+		total := 0.0
+		for _, card := range cards {
+			total += card.Score
+		}
+		return total, nil
+	}
+
+	var progress []GeneticProgress
+	optimizer.Progress = func(p GeneticProgress) { progress = append(progress, p) }
+	result, err := optimizer.Optimize()
+	if err != nil {
+		t.Fatalf("Optimize() error = %v", err)
+	}
+	if len(progress) == 0 {
+		t.Fatal("expected progress callbacks")
+	}
+	for _, p := range progress {
+		if p.Diversity <= 0 || p.Diversity > 1 {
+			t.Errorf("generation %d diversity = %v, want (0, 1]", p.Generation, p.Diversity)
+		}
+	}
+	// Scores report the evaluation fitness without the novelty bonus
+	for i, genome := range result.HallOfFame {
+		if result.Scores[i] != genome.Fitness {
+			t.Errorf("hall of fame score %d = %v, want fitness %v", i, result.Scores[i], genome.Fitness)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"sort"
 	"time"
 
 	"github.com/MaxHalford/eaopt"
@@ -18,6 +19,12 @@ type GeneticProgress struct {
 	BestFitness float64
 	AvgFitness  float64
 	Populations int
+	// Diversity is the population's PopulationDiversity.
+	Diversity float64
+	// MutationRate is reported when an adaptive controller is set.
+	MutationRate float64
+	// Islands holds per-island statistics when the island model is enabled.
+	Islands []IslandProgress
 }
 
 // GeneticResult captures the final outputs of a genetic optimization run.
@@ -26,6 +33,12 @@ type GeneticResult struct {
 	Scores      []float64
 	Generations uint
 	Duration    time.Duration
+	// Diversity is the final population's PopulationDiversity.
+	Diversity float64
+	// Objectives and ParetoFront are set in multi-objective mode; HallOfFame
+	// then holds the front's decks and Scores their mean objective score.
+	Objectives  []Objective
+	ParetoFront []ParetoSolution
 }
 
 // GeneticOptimizer orchestrates genetic algorithm runs for deck optimization.
@@ -37,6 +50,15 @@ type GeneticOptimizer struct {
 	RNG        *rand.Rand
 	// FitnessFunc overrides default genome fitness evaluation when set.
 	FitnessFunc func([]deck.CardCandidate) (float64, error)
+	// ObjectiveFunc overrides objective scoring in multi-objective mode. It
+	// must return one score per Config.Objectives entry, higher is better.
+	ObjectiveFunc func([]deck.CardCandidate) ([]float64, error)
+	// Adaptive, when set, replaces the fixed Config.MutationRate with a rate
+	// adjusted every generation from fitness progress and diversity.
+	Adaptive *AdaptiveParams
+	// Lineage, when set, records the parents of every deck crossover and
+	// mutation produce.
+	Lineage *deck.LineageRecorder
 }
 
 // NewGeneticOptimizer constructs a genetic optimizer with validation.
@@ -51,6 +73,9 @@ func NewGeneticOptimizer(candidates []*deck.CardCandidate, strategy deck.Strateg
 	if err := config.Validate(); err != nil {
 		return nil, err
 	}
+	if err := validateEvolutionPool(candidates, config); err != nil {
+		return nil, err
+	}
 
 	return &GeneticOptimizer{
 		Config:     config,
@@ -78,6 +103,9 @@ func (o *GeneticOptimizer) Optimize() (*GeneticResult, error) {
 	if rng == nil {
 		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
 	}
+	if o.Config.MultiObjective() {
+		return o.optimizePareto(rng)
+	}
 
 	popSize, nPops := o.populationConfig()
 	hofSize := uint(1)
@@ -90,12 +118,18 @@ func (o *GeneticOptimizer) Optimize() (*GeneticResult, error) {
 		Elite:     uint(o.Config.EliteCount),
 		MutRate:   o.Config.MutationRate,
 		CrossRate: o.Config.CrossoverRate,
+		Adaptive:  o.Adaptive,
 	}
 
 	var (
 		bestScore          = math.Inf(-1)
 		lastImprovementGen uint
 	)
+	novelty := newNoveltyScorer(o.Config)
+	var islands []*islandScorer
+	if o.Config.IslandModel {
+		islands = newIslandScorers(o.Config, int(nPops))
+	}
 
 	gaConfig := eaopt.GAConfig{
 		NPops:        nPops,
@@ -106,16 +140,34 @@ func (o *GeneticOptimizer) Optimize() (*GeneticResult, error) {
 		ParallelEval: o.Config.ParallelEvaluations,
 		RNG:          rng,
 		Callback: func(ga *eaopt.GA) {
-			if o.Progress == nil || ga == nil {
+			if ga == nil {
+				return
+			}
+			// Offspring bred after this callback belong to the next generation
+			o.Lineage.SetGeneration(int(ga.Generations) + 1)
+			decks := populationDecks(ga)
+			if novelty != nil {
+				novelty.setPopulation(decks)
+			}
+			if o.Progress == nil && o.Adaptive == nil {
 				return
 			}
 			best, avg := aggregateFitness(ga)
-			o.Progress(GeneticProgress{
+			progress := GeneticProgress{
 				Generation:  ga.Generations,
 				BestFitness: best,
 				AvgFitness:  avg,
 				Populations: len(ga.Populations),
-			})
+				Diversity:   PopulationDiversity(decks),
+				Islands:     islandProgress(ga, islands),
+			}
+			if o.Adaptive != nil {
+				o.Adaptive.ObserveGeneration(best, progress.Diversity)
+				progress.MutationRate = o.Adaptive.Rate()
+			}
+			if o.Progress != nil {
+				o.Progress(progress)
+			}
 		},
 		EarlyStop: func(ga *eaopt.GA) bool {
 			if ga == nil || len(ga.HallOfFame) == 0 {
@@ -140,7 +192,15 @@ func (o *GeneticOptimizer) Optimize() (*GeneticResult, error) {
 	}
 
 	if o.Config.IslandModel {
-		gaConfig.Migrator = eaopt.MigRing{NMigrants: uint(o.Config.MigrationSize)}
+		topology := o.Config.MigrationTopology
+		if topology == "" {
+			topology = TopologyRing
+		}
+		gaConfig.Migrator = islandMigrator{
+			topology:  topology,
+			nMigrants: uint(o.Config.MigrationSize),
+			scorers:   islands,
+		}
 		gaConfig.MigFrequency = uint(o.Config.MigrationInterval)
 	}
 
@@ -150,17 +210,36 @@ func (o *GeneticOptimizer) Optimize() (*GeneticResult, error) {
 	}
 
 	newGenome := o.genomeFactory()
+	if novelty != nil {
+		newGenome = withNovelty(newGenome, novelty)
+	}
+	if islands != nil {
+		newGenome = withIslands(newGenome, islands, popSize)
+	}
 	if err := ga.Minimize(newGenome); err != nil {
 		return nil, err
 	}
 
 	hallOfFame, scores := extractHallOfFame(ga)
+	if novelty != nil || islands != nil {
+		// Report evaluation fitness, not the novelty-boosted or
+		// island-weighted search fitness
+		for i, genome := range hallOfFame {
+			scores[i] = genome.Fitness
+		}
+	}
+	if islands != nil {
+		// Islands rank decks by their own strategies; rank the hall of fame
+		// by the shared evaluation fitness instead
+		sortHallOfFame(hallOfFame, scores)
+	}
 
 	return &GeneticResult{
 		HallOfFame:  hallOfFame,
 		Scores:      scores,
 		Generations: ga.Generations,
 		Duration:    ga.Age,
+		Diversity:   PopulationDiversity(populationDecks(ga)),
 	}, nil
 }
 
@@ -175,12 +254,20 @@ func (o *GeneticOptimizer) populationConfig() (uint, uint) {
 func (o *GeneticOptimizer) genomeFactory() func(rng *rand.Rand) eaopt.Genome {
 	seeds := o.Config.SeedPopulation
 	seedIndex := 0
+	fitnessFunc := o.FitnessFunc
+	if fitnessFunc == nil {
+		fitnessFunc = defaultFitnessFunc()
+	}
+	cache := newFitnessCache()
 	return func(rng *rand.Rand) eaopt.Genome {
 		if seedIndex < len(seeds) {
 			cards := seeds[seedIndex]
 			seedIndex++
 			if genome, err := NewDeckGenomeFromCards(cards, o.Candidates, o.Strategy, o.Config); err == nil {
-				genome.fitnessEvaluator = o.FitnessFunc
+				genome.Cards = genome.enforceEvolutionConstraints(genome.Cards)
+				genome.fitnessEvaluator = fitnessFunc
+				genome.fitnessCache = cache
+				genome.lineage = o.Lineage
 				return &eaoptDeckGenome{genome: genome}
 			}
 		}
@@ -193,16 +280,35 @@ func (o *GeneticOptimizer) genomeFactory() func(rng *rand.Rand) eaopt.Genome {
 				config:           o.Config,
 				candidates:       o.Candidates,
 				strategy:         o.Strategy,
-				fitnessEvaluator: o.FitnessFunc,
+				fitnessEvaluator: fitnessFunc,
+				fitnessCache:     cache,
 			}}
 		}
-		genome.fitnessEvaluator = o.FitnessFunc
+		genome.fitnessEvaluator = fitnessFunc
+		genome.fitnessCache = cache
+		genome.lineage = o.Lineage
 		return &eaoptDeckGenome{genome: genome}
 	}
 }
 
+// withNovelty attaches a novelty scorer to every genome a factory creates.
+func withNovelty(factory func(rng *rand.Rand) eaopt.Genome, novelty *noveltyScorer) func(rng *rand.Rand) eaopt.Genome {
+	return func(rng *rand.Rand) eaopt.Genome {
+		genome := factory(rng)
+		if wrapped, ok := genome.(*eaoptDeckGenome); ok {
+			wrapped.novelty = novelty
+		}
+		return genome
+	}
+}
+
 type eaoptDeckGenome struct {
 	genome *DeckGenome
+	// novelty, when set, adds a novelty bonus to the search fitness.
+	novelty *noveltyScorer
+	// island, when set, blends the island's strategy score into the search
+	// fitness.
+	island *islandScorer
 }
 
 func (g *eaoptDeckGenome) Evaluate() (float64, error) {
@@ -213,7 +319,7 @@ func (g *eaoptDeckGenome) Evaluate() (float64, error) {
 	if err != nil {
 		return 0, err
 	}
-	return -fitness, nil
+	return -(g.island.fitness(g.genome, fitness) + g.novelty.bonus(g.genome.Cards)), nil
 }
 
 func (g *eaoptDeckGenome) Mutate(rng *rand.Rand) {
@@ -242,13 +348,13 @@ func (g *eaoptDeckGenome) Crossover(genome eaopt.Genome, rng *rand.Rand) {
 
 func (g *eaoptDeckGenome) Clone() eaopt.Genome {
 	if g == nil || g.genome == nil {
-		return &eaoptDeckGenome{genome: nil}
+		return &eaoptDeckGenome{genome: nil, novelty: g.novelty, island: g.island}
 	}
 	clone := g.genome.Clone()
 	if deckClone, ok := clone.(*DeckGenome); ok {
-		return &eaoptDeckGenome{genome: deckClone}
+		return &eaoptDeckGenome{genome: deckClone, novelty: g.novelty, island: g.island}
 	}
-	return &eaoptDeckGenome{genome: nil}
+	return &eaoptDeckGenome{genome: nil, novelty: g.novelty, island: g.island}
 }
 
 type elitismModel struct {
@@ -256,6 +362,8 @@ type elitismModel struct {
 	Elite     uint
 	MutRate   float64
 	CrossRate float64
+	// Adaptive overrides MutRate when set.
+	Adaptive *AdaptiveParams
 }
 
 func (mod elitismModel) Apply(pop *eaopt.Population) error {
@@ -280,8 +388,12 @@ func (mod elitismModel) Apply(pop *eaopt.Population) error {
 		if err != nil {
 			return err
 		}
-		if mod.MutRate > 0 {
-			offsprings.Mutate(mod.MutRate, pop.RNG)
+		mutRate := mod.MutRate
+		if mod.Adaptive != nil {
+			mutRate = mod.Adaptive.Rate()
+		}
+		if mutRate > 0 {
+			offsprings.Mutate(mutRate, pop.RNG)
 		}
 		copy(pop.Individuals, elites)
 		copy(pop.Individuals[mod.Elite:], offsprings)
@@ -351,6 +463,19 @@ func aggregateFitness(ga *eaopt.GA) (float64, float64) {
 	return best, sum / float64(count)
 }
 
+// populationDecks returns the card lists of every individual in every population.
+func populationDecks(ga *eaopt.GA) [][]string {
+	var decks [][]string
+	for _, pop := range ga.Populations {
+		for _, indi := range pop.Individuals {
+			if wrapped, ok := indi.Genome.(*eaoptDeckGenome); ok && wrapped != nil && wrapped.genome != nil {
+				decks = append(decks, wrapped.genome.Cards)
+			}
+		}
+	}
+	return decks
+}
+
 func extractHallOfFame(ga *eaopt.GA) ([]*DeckGenome, []float64) {
 	if ga == nil {
 		return nil, nil
@@ -373,3 +498,20 @@ func extractHallOfFame(ga *eaopt.GA) ([]*DeckGenome, []float64) {
 	}
 	return hall, scores
 }
+
+// sortHallOfFame orders hall of fame decks by score, best first.
+func sortHallOfFame(hall []*DeckGenome, scores []float64) {
+	order := make([]int, len(hall))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return scores[order[a]] > scores[order[b]] })
+	sortedHall := make([]*DeckGenome, len(hall))
+	sortedScores := make([]float64, len(scores))
+	for i, idx := range order {
+		sortedHall[i] = hall[idx]
+		sortedScores[i] = scores[idx]
+	}
+	copy(hall, sortedHall)
+	copy(scores, sortedScores)
+}
@@ -503,6 +503,81 @@ func TestGeneticOptimizerSeedPopulation(t *testing.T) {
 	}
 }
 
+func TestGeneticOptimizerRecordsLineage(t *testing.T) {
+	config := GeneticConfig{
+		PopulationSize:    20,
+		Generations:       10,
+		MutationRate:      0.5,
+		MutationIntensity: 0.3,
+		CrossoverRate:     0.9,
+		EliteCount:        2,
+		TournamentSize:    3,
+	}
+	optimizer, err := NewGeneticOptimizer(createMockCandidates(20), deck.StrategyBalanced, &config)
+	if err != nil {
+		t.Fatalf("NewGeneticOptimizer() failed: %v", err)
+	}
+	optimizer.RNG = rand.New(rand.NewSource(7))
+	optimizer.Lineage = deck.NewLineageRecorder()
+
+	result, err := optimizer.Optimize()
+	if err != nil {
+		t.Fatalf("Optimize() error = %v", err)
+	}
+
+	if optimizer.Lineage.Len() == 0 {
+		t.Fatal("no lineage recorded for bred decks")
+	}
+	// The best decks trace back to their parents with the operation and
+	// generation that bred them
+	for _, genome := range result.HallOfFame {
+		for _, edge := range optimizer.Lineage.Ancestry(genome.Cards, 3) {
+			if edge.Operation != deck.LineageCrossover && edge.Operation != deck.LineageMutation {
+				t.Errorf("unexpected operation %q", edge.Operation)
+			}
+			if edge.Generation < 1 || edge.Generation > config.Generations {
+				t.Errorf("generation %d outside 1-%d", edge.Generation, config.Generations)
+			}
+			if len(edge.Parents) == 0 {
+				t.Error("edge has no parents")
+			}
+		}
+	}
+}
+
+func TestGeneticOptimizerFitnessCacheIsPerRun(t *testing.T) {
+	candidates := createMockCandidates(12)
+	cfg := DefaultGeneticConfig()
+	cfg.PopulationSize = 10
+	cfg.Generations = 2
+	cfg.ConvergenceGenerations = 0
+	cfg.ParallelEvaluations = false
+
+	run := func(fitness float64) *GeneticResult {
+		t.Helper()
+		optimizer, err := NewGeneticOptimizer(candidates, deck.StrategyBalanced, &cfg)
+		if err != nil {
+			t.Fatalf("NewGeneticOptimizer() error = %v", err)
+		}
+		optimizer.RNG = rand.New(rand.NewSource(3))
+		optimizer.FitnessFunc = func([]deck.CardCandidate) (float64, error) { return fitness, nil }
+		result, err := optimizer.Optimize()
+		if err != nil {
+			t.Fatalf("Optimize() error = %v", err)
+		}
+		return result
+	}
+
+	run(1)
+	// The same seed produces the same decks, which must be scored by the
+	// second run's fitness function rather than remembered from the first.
+	for i, genome := range run(2).HallOfFame {
+		if genome.Fitness != 2 {
+			t.Errorf("hall of fame deck %d fitness = %v, want 2", i, genome.Fitness)
+		}
+	}
+}
+
 func TestGeneticOptimizerPopulationConfig(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -606,3 +681,29 @@ func TestGeneticOptimizerPerformance(t *testing.T) {
 
 	t.Logf("Optimization completed in %v for %d generations", duration, result.Generations)
 }
+
+// BenchmarkGeneticGeneration measures one GA generation (population 50) with
+// the default evaluation fitness
+func BenchmarkGeneticGeneration(b *testing.B) {
+	candidates := createMockCandidates(30)
+	config := DefaultGeneticConfig()
+	config.PopulationSize = 50
+	config.Generations = 1
+	config.ConvergenceGenerations = 0
+	config.ParallelEvaluations = false
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		optimizer, err := NewGeneticOptimizer(candidates, deck.StrategyBalanced, &config)
+		if err != nil {
+			b.Fatal(err)
+		}
+		optimizer.RNG = rand.New(rand.NewSource(int64(i)))
+		b.StartTimer()
+		if _, err := optimizer.Optimize(); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "generations/sec")
+}
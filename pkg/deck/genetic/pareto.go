@@ -0,0 +1,511 @@
+// Package genetic provides genetic algorithm-based deck optimization
+// using the eaopt library for evolutionary deck generation.
+package genetic
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+)
+
+// Objective names one axis of multi-objective optimization.
+type Objective string
+
+// Supported objectives, each read from the matching evaluation category score (0-10).
+const (
+	ObjectiveAttack  Objective = "attack"
+	ObjectiveDefense Objective = "defense"
+	ObjectiveSynergy Objective = "synergy"
+	ObjectiveF2P     Objective = "f2p"
+)
+
+// AllObjectives lists every supported objective in display order.
+var AllObjectives = []Objective{ObjectiveAttack, ObjectiveDefense, ObjectiveSynergy, ObjectiveF2P}
+
+// ParseObjectives parses a comma-separated objective list such as
+// "attack,defense,synergy". "all" selects every objective. Duplicates are
+// dropped; an empty string yields nil (single-objective mode).
+func ParseObjectives(value string) ([]Objective, error) {
+	value = strings.TrimSpace(strings.ToLower(value))
+	if value == "" {
+		return nil, nil
+	}
+	if value == "all" {
+		return append([]Objective(nil), AllObjectives...), nil
+	}
+
+	var objectives []Objective
+	seen := make(map[Objective]bool)
+	for part := range strings.SplitSeq(value, ",") {
+		objective := Objective(strings.TrimSpace(part))
+		if objective == "f2p-friendly" || objective == "f2p_friendly" {
+			objective = ObjectiveF2P
+		}
+		if !objective.valid() {
+			return nil, fmt.Errorf("unknown objective %q (supported: attack, defense, synergy, f2p)", part)
+		}
+		if seen[objective] {
+			continue
+		}
+		seen[objective] = true
+		objectives = append(objectives, objective)
+	}
+	return objectives, nil
+}
+
+func (o Objective) valid() bool {
+	for _, known := range AllObjectives {
+		if o == known {
+			return true
+		}
+	}
+	return false
+}
+
+// ObjectiveScores extracts the requested objective scores from an evaluation result.
+func ObjectiveScores(result evaluation.EvaluationResult, objectives []Objective) []float64 {
+	scores := make([]float64, len(objectives))
+	for i, objective := range objectives {
+		switch objective {
+		case ObjectiveAttack:
+			scores[i] = result.Attack.Score
+		case ObjectiveDefense:
+			scores[i] = result.Defense.Score
+		case ObjectiveSynergy:
+			scores[i] = result.Synergy.Score
+		case ObjectiveF2P:
+			scores[i] = result.F2PFriendly.Score
+		}
+	}
+	return scores
+}
+
+// ParetoSolution is one non-dominated deck with its objective scores, in the
+// order of GeneticResult.Objectives.
+type ParetoSolution struct {
+	Genome     *DeckGenome
+	Objectives []float64
+	// Crowding is the NSGA-II crowding distance within the front; boundary
+	// solutions are +Inf.
+	Crowding float64
+}
+
+// MeanScore is the average of the solution's objective scores.
+func (s ParetoSolution) MeanScore() float64 {
+	if len(s.Objectives) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, v := range s.Objectives {
+		sum += v
+	}
+	return sum / float64(len(s.Objectives))
+}
+
+// nsgaIndividual is a population member during multi-objective evolution.
+type nsgaIndividual struct {
+	genome     *DeckGenome
+	objectives []float64
+	rank       int
+	crowding   float64
+}
+
+// optimizePareto runs NSGA-II over Config.Objectives and returns the first
+// non-dominated front. The island model does not apply in this mode.
+func (o *GeneticOptimizer) optimizePareto(rng *rand.Rand) (*GeneticResult, error) {
+	start := time.Now()
+	objectives := o.Config.Objectives
+	popSize := o.Config.PopulationSize
+	objectiveFunc := o.ObjectiveFunc
+	if objectiveFunc == nil {
+		objectiveFunc = defaultObjectiveFunc(objectives)
+	}
+
+	newGenome := o.genomeFactory()
+	population := make([]*nsgaIndividual, 0, popSize)
+	for len(population) < popSize {
+		wrapped, ok := newGenome(rng).(*eaoptDeckGenome)
+		if !ok || wrapped.genome == nil || len(wrapped.genome.Cards) != 8 {
+			return nil, fmt.Errorf("failed to create initial population")
+		}
+		population = append(population, &nsgaIndividual{genome: wrapped.genome})
+	}
+	if err := o.evaluateObjectives(population, objectiveFunc); err != nil {
+		return nil, err
+	}
+	assignRanksAndCrowding(population)
+
+	var (
+		generation   uint
+		lastFrontKey string
+		stableGens   int
+	)
+	for generation = 1; generation <= uint(o.Config.Generations); generation++ {
+		o.Lineage.SetGeneration(int(generation))
+		offspring := o.breedOffspring(population, rng)
+		if err := o.evaluateObjectives(offspring, objectiveFunc); err != nil {
+			return nil, err
+		}
+
+		population = selectNextGeneration(append(population, offspring...), popSize)
+
+		if o.Progress != nil || o.Adaptive != nil {
+			best, avg := meanObjectiveStats(population)
+			progress := GeneticProgress{
+				Generation:  generation,
+				BestFitness: best,
+				AvgFitness:  avg,
+				Populations: 1,
+				Diversity:   PopulationDiversity(individualDecks(population)),
+			}
+			if o.Adaptive != nil {
+				o.Adaptive.ObserveGeneration(best, progress.Diversity)
+				progress.MutationRate = o.Adaptive.Rate()
+			}
+			if o.Progress != nil {
+				o.Progress(progress)
+			}
+		}
+
+		if o.Config.ConvergenceGenerations > 0 {
+			key := frontKey(population)
+			if key == lastFrontKey {
+				stableGens++
+			} else {
+				lastFrontKey = key
+				stableGens = 0
+			}
+			if stableGens >= o.Config.ConvergenceGenerations {
+				break
+			}
+		}
+	}
+	generation = min(generation, uint(o.Config.Generations))
+
+	front := paretoFront(population)
+	result := &GeneticResult{
+		Objectives:  append([]Objective(nil), objectives...),
+		ParetoFront: front,
+		HallOfFame:  make([]*DeckGenome, 0, len(front)),
+		Scores:      make([]float64, 0, len(front)),
+		Generations: generation,
+		Duration:    time.Since(start),
+		Diversity:   PopulationDiversity(individualDecks(population)),
+	}
+	for _, solution := range front {
+		result.HallOfFame = append(result.HallOfFame, solution.Genome)
+		result.Scores = append(result.Scores, solution.MeanScore())
+	}
+	return result, nil
+}
+
+func defaultObjectiveFunc(objectives []Objective) func([]deck.CardCandidate) ([]float64, error) {
+	synergyDB := deck.NewSynergyDatabase()
+	return func(cards []deck.CardCandidate) ([]float64, error) {
+		return ObjectiveScores(evaluation.Evaluate(cards, synergyDB, nil), objectives), nil
+	}
+}
+
+// evaluateObjectives scores every individual that has not been scored yet.
+func (o *GeneticOptimizer) evaluateObjectives(individuals []*nsgaIndividual, objectiveFunc func([]deck.CardCandidate) ([]float64, error)) error {
+	evaluate := func(ind *nsgaIndividual) error {
+		if ind.objectives != nil {
+			return nil
+		}
+		cards := ind.genome.getCardCandidates()
+		if len(cards) != 8 {
+			return fmt.Errorf("failed to resolve all cards: got %d, want 8", len(cards))
+		}
+		scores, err := objectiveFunc(cards)
+		if err != nil {
+			return err
+		}
+		if len(scores) != len(o.Config.Objectives) {
+			return fmt.Errorf("objective function returned %d scores, want %d", len(scores), len(o.Config.Objectives))
+		}
+		ind.objectives = scores
+		ind.genome.Fitness = ParetoSolution{Objectives: scores}.MeanScore()
+		return nil
+	}
+
+	if !o.Config.ParallelEvaluations {
+		for _, ind := range individuals {
+			if err := evaluate(ind); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	for _, ind := range individuals {
+		wg.Go(func() {
+			if err := evaluate(ind); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// breedOffspring creates a full population of children using crowded
+// tournament selection, crossover, and mutation.
+func (o *GeneticOptimizer) breedOffspring(population []*nsgaIndividual, rng *rand.Rand) []*nsgaIndividual {
+	mutationRate := o.Config.MutationRate
+	if o.Adaptive != nil {
+		mutationRate = o.Adaptive.Rate()
+	}
+	offspring := make([]*nsgaIndividual, 0, len(population))
+	for len(offspring) < len(population) {
+		parent := crowdedTournament(population, o.Config.TournamentSize, rng)
+		var child *DeckGenome
+		if rng.Float64() < o.Config.CrossoverRate {
+			mate := crowdedTournament(population, o.Config.TournamentSize, rng)
+			if crossed, err := parent.genome.Crossover(mate.genome); err == nil {
+				child, _ = crossed.(*DeckGenome)
+			}
+		}
+		if child == nil {
+			child, _ = parent.genome.Clone().(*DeckGenome)
+		}
+		if child == nil {
+			continue
+		}
+		if rng.Float64() < mutationRate {
+			_ = child.Mutate()
+		}
+		offspring = append(offspring, &nsgaIndividual{genome: child})
+	}
+	return offspring
+}
+
+// crowdedTournament picks the best of size random individuals by rank, then
+// by crowding distance.
+func crowdedTournament(population []*nsgaIndividual, size int, rng *rand.Rand) *nsgaIndividual {
+	best := population[rng.Intn(len(population))]
+	for i := 1; i < size; i++ {
+		contender := population[rng.Intn(len(population))]
+		if crowdedLess(contender, best) {
+			best = contender
+		}
+	}
+	return best
+}
+
+// crowdedLess is the NSGA-II crowded-comparison operator.
+func crowdedLess(a, b *nsgaIndividual) bool {
+	if a.rank != b.rank {
+		return a.rank < b.rank
+	}
+	return a.crowding > b.crowding
+}
+
+// selectNextGeneration keeps the best size individuals of the combined
+// parent and offspring pool, filling front by front and breaking the last
+// front by crowding distance. Duplicate decks are dropped first so the front
+// does not fill up with copies.
+func selectNextGeneration(pool []*nsgaIndividual, size int) []*nsgaIndividual {
+	unique := make([]*nsgaIndividual, 0, len(pool))
+	seen := make(map[string]bool, len(pool))
+	var duplicates []*nsgaIndividual
+	for _, ind := range pool {
+		key := deckKey(ind.genome.Cards)
+		if seen[key] {
+			duplicates = append(duplicates, ind)
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, ind)
+	}
+
+	fronts := nonDominatedSort(unique)
+	next := make([]*nsgaIndividual, 0, size)
+	for _, front := range fronts {
+		assignCrowding(front)
+		if len(next)+len(front) <= size {
+			next = append(next, front...)
+			continue
+		}
+		sort.SliceStable(front, func(i, j int) bool { return front[i].crowding > front[j].crowding })
+		next = append(next, front[:size-len(next)]...)
+		break
+	}
+	// Top up with duplicates only when there are too few distinct decks.
+	for i := 0; len(next) < size && i < len(duplicates); i++ {
+		duplicates[i].rank = len(fronts)
+		duplicates[i].crowding = 0
+		next = append(next, duplicates[i])
+	}
+	return next
+}
+
+// assignRanksAndCrowding ranks a population in place.
+func assignRanksAndCrowding(population []*nsgaIndividual) {
+	for _, front := range nonDominatedSort(population) {
+		assignCrowding(front)
+	}
+}
+
+// nonDominatedSort groups individuals into fronts (fast non-dominated sort)
+// and sets each individual's rank; front 0 is the Pareto front.
+func nonDominatedSort(population []*nsgaIndividual) [][]*nsgaIndividual {
+	n := len(population)
+	dominates := make([][]int, n)
+	dominatedBy := make([]int, n)
+	var current []int
+	for i := range n {
+		for j := range n {
+			if i == j {
+				continue
+			}
+			switch {
+			case dominatesObjectives(population[i].objectives, population[j].objectives):
+				dominates[i] = append(dominates[i], j)
+			case dominatesObjectives(population[j].objectives, population[i].objectives):
+				dominatedBy[i]++
+			}
+		}
+		if dominatedBy[i] == 0 {
+			population[i].rank = 0
+			current = append(current, i)
+		}
+	}
+
+	var fronts [][]*nsgaIndividual
+	for rank := 0; len(current) > 0; rank++ {
+		front := make([]*nsgaIndividual, 0, len(current))
+		var next []int
+		for _, i := range current {
+			front = append(front, population[i])
+			for _, j := range dominates[i] {
+				dominatedBy[j]--
+				if dominatedBy[j] == 0 {
+					population[j].rank = rank + 1
+					next = append(next, j)
+				}
+			}
+		}
+		fronts = append(fronts, front)
+		current = next
+	}
+	return fronts
+}
+
+// dominatesObjectives reports whether a is at least as good as b on every
+// objective and strictly better on one (all objectives are maximized).
+func dominatesObjectives(a, b []float64) bool {
+	better := false
+	for i := range a {
+		if a[i] < b[i] {
+			return false
+		}
+		if a[i] > b[i] {
+			better = true
+		}
+	}
+	return better
+}
+
+// assignCrowding computes crowding distances within one front.
+func assignCrowding(front []*nsgaIndividual) {
+	for _, ind := range front {
+		ind.crowding = 0
+	}
+	if len(front) <= 2 {
+		for _, ind := range front {
+			ind.crowding = math.Inf(1)
+		}
+		return
+	}
+
+	sorted := append([]*nsgaIndividual(nil), front...)
+	for m := range front[0].objectives {
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].objectives[m] < sorted[j].objectives[m] })
+		lo, hi := sorted[0].objectives[m], sorted[len(sorted)-1].objectives[m]
+		sorted[0].crowding = math.Inf(1)
+		sorted[len(sorted)-1].crowding = math.Inf(1)
+		if hi-lo < 1e-12 {
+			continue
+		}
+		for i := 1; i < len(sorted)-1; i++ {
+			sorted[i].crowding += (sorted[i+1].objectives[m] - sorted[i-1].objectives[m]) / (hi - lo)
+		}
+	}
+}
+
+// paretoFront returns the rank-0 individuals as solutions, best mean score first.
+func paretoFront(population []*nsgaIndividual) []ParetoSolution {
+	var front []ParetoSolution
+	for _, ind := range population {
+		if ind.rank != 0 {
+			continue
+		}
+		genome, ok := ind.genome.Clone().(*DeckGenome)
+		if !ok {
+			continue
+		}
+		front = append(front, ParetoSolution{
+			Genome:     genome,
+			Objectives: append([]float64(nil), ind.objectives...),
+			Crowding:   ind.crowding,
+		})
+	}
+	sort.SliceStable(front, func(i, j int) bool { return front[i].MeanScore() > front[j].MeanScore() })
+	return front
+}
+
+func meanObjectiveStats(population []*nsgaIndividual) (float64, float64) {
+	best := math.Inf(-1)
+	sum := 0.0
+	for _, ind := range population {
+		score := ParetoSolution{Objectives: ind.objectives}.MeanScore()
+		best = math.Max(best, score)
+		sum += score
+	}
+	if len(population) == 0 {
+		return 0, 0
+	}
+	return best, sum / float64(len(population))
+}
+
+func individualDecks(population []*nsgaIndividual) [][]string {
+	decks := make([][]string, len(population))
+	for i, ind := range population {
+		decks[i] = ind.genome.Cards
+	}
+	return decks
+}
+
+// frontKey identifies the set of decks on the current front, for convergence detection.
+func frontKey(population []*nsgaIndividual) string {
+	keys := make([]string, 0, len(population))
+	for _, ind := range population {
+		if ind.rank == 0 {
+			keys = append(keys, deckKey(ind.genome.Cards))
+		}
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, "|")
+}
+
+func deckKey(cards []string) string {
+	sorted := append([]string(nil), cards...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
@@ -0,0 +1,146 @@
+// Package genetic provides genetic algorithm-based deck optimization
+// using the eaopt library for evolutionary deck generation.
+package genetic
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+func TestParseObjectives(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    []Objective
+		wantErr bool
+	}{
+		{input: "", want: nil},
+		{input: "attack,defense", want: []Objective{ObjectiveAttack, ObjectiveDefense}},
+		{input: " Synergy , F2P ,synergy", want: []Objective{ObjectiveSynergy, ObjectiveF2P}},
+		{input: "all", want: AllObjectives},
+		{input: "attack,speed", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseObjectives(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("ParseObjectives(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if len(got) != len(tt.want) {
+			t.Fatalf("ParseObjectives(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("ParseObjectives(%q)[%d] = %s, want %s", tt.input, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+func TestConfigValidateObjectives(t *testing.T) {
+	cfg := DefaultGeneticConfig()
+	cfg.Objectives = []Objective{ObjectiveAttack}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "at least 2") {
+		t.Errorf("expected error for single objective, got %v", err)
+	}
+
+	cfg.Objectives = []Objective{ObjectiveAttack, "speed"}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for unknown objective")
+	}
+
+	cfg.Objectives = []Objective{ObjectiveAttack, ObjectiveDefense}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !cfg.MultiObjective() {
+		t.Error("expected MultiObjective() to be true")
+	}
+}
+
+func TestNonDominatedSort(t *testing.T) {
+	population := []*nsgaIndividual{
+		{objectives: []float64{5, 5}},
+		{objectives: []float64{9, 1}},
+		{objectives: []float64{1, 9}},
+		{objectives: []float64{4, 4}}, // dominated by {5,5}
+		{objectives: []float64{1, 1}}, // dominated by everything above
+	}
+	fronts := nonDominatedSort(population)
+	if len(fronts) != 3 {
+		t.Fatalf("expected 3 fronts, got %d", len(fronts))
+	}
+	if len(fronts[0]) != 3 {
+		t.Errorf("expected 3 individuals on the first front, got %d", len(fronts[0]))
+	}
+	if population[3].rank != 1 || population[4].rank != 2 {
+		t.Errorf("unexpected ranks: %d, %d", population[3].rank, population[4].rank)
+	}
+
+	assignCrowding(fronts[0])
+	if !math.IsInf(population[1].crowding, 1) || !math.IsInf(population[2].crowding, 1) {
+		t.Error("boundary solutions should have infinite crowding distance")
+	}
+	if math.IsInf(population[0].crowding, 1) || population[0].crowding <= 0 {
+		t.Errorf("interior solution crowding = %v, want finite positive", population[0].crowding)
+	}
+}
+
+func TestOptimizeParetoFront(t *testing.T) {
+	candidates := createMockCandidates(16)
+	cfg := DefaultGeneticConfig()
+	cfg.PopulationSize = 20
+	cfg.Generations = 10
+	cfg.ConvergenceGenerations = 0
+	cfg.ParallelEvaluations = false
+	cfg.Objectives = []Objective{ObjectiveAttack, ObjectiveDefense}
+
+	optimizer, err := NewGeneticOptimizer(candidates, deck.StrategyBalanced, &cfg)
+	if err != nil {
+		t.Fatalf("NewGeneticOptimizer() error = %v", err)
+	}
+	optimizer.RNG = rand.New(rand.NewSource(1))
+	// Two directly conflicting objectives, so no single deck wins both.
+	optimizer.ObjectiveFunc = func(cards []deck.CardCandidate) ([]float64, error) {
+		var high, low float64
+		for _, card := range cards {
+			high += card.Score
+			low -= card.Score
+		}
+		return []float64{high, low}, nil
+	}
+
+	var progressCalls int
+	optimizer.Progress = func(GeneticProgress) { progressCalls++ }
+
+	result, err := optimizer.Optimize()
+	if err != nil {
+		t.Fatalf("Optimize() error = %v", err)
+	}
+	if len(result.ParetoFront) == 0 {
+		t.Fatal("expected a non-empty Pareto front")
+	}
+	if len(result.HallOfFame) != len(result.ParetoFront) || len(result.Scores) != len(result.ParetoFront) {
+		t.Errorf("hall of fame (%d) and scores (%d) should mirror the front (%d)",
+			len(result.HallOfFame), len(result.Scores), len(result.ParetoFront))
+	}
+	if len(result.Objectives) != 2 {
+		t.Errorf("expected 2 objectives in result, got %d", len(result.Objectives))
+	}
+	if progressCalls == 0 {
+		t.Error("expected progress callbacks")
+	}
+
+	for i, a := range result.ParetoFront {
+		if len(a.Genome.Cards) != 8 {
+			t.Errorf("front deck %d has %d cards", i, len(a.Genome.Cards))
+		}
+		for j, b := range result.ParetoFront {
+			if i != j && dominatesObjectives(b.Objectives, a.Objectives) {
+				t.Errorf("front solution %d is dominated by %d", i, j)
+			}
+		}
+	}
+}
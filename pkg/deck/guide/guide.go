@@ -0,0 +1,452 @@
+// Package guide writes a play guide for a Clash Royale deck: how to open a
+// match, how to set up defense against each kind of threat, when to use each
+// spell, and how the game plan changes from single to double elixir.
+//
+// The guide is derived from the deck alone. Card roles come from the role
+// classifier, defensive capabilities from the counter matrix and combat
+// stats, pushes from the synergy database, and the overall plan from the
+// detected archetype.
+package guide
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+)
+
+const (
+	// tankKillerDPS is the damage per second above which a troop or
+	// building melts tanks on its own
+	tankKillerDPS = 150
+
+	// cheapElixir is the most a card may cost to be a safe opening or cycle card
+	cheapElixir = 3
+)
+
+// RoleGroup lists the deck's cards that fill one role
+type RoleGroup struct {
+	Role  deck.CardRole `json:"role"`
+	Cards []string      `json:"cards"`
+}
+
+// DefensiveSetup is how the deck defends against one kind of threat
+type DefensiveSetup struct {
+	Threat   string   `json:"threat"`
+	Examples []string `json:"examples"`
+	Cards    []string `json:"cards"`
+	Setup    string   `json:"setup"`
+	// Gap is set when no card in the deck answers the threat well
+	Gap bool `json:"gap,omitempty"`
+}
+
+// SpellRule is when and how to use one of the deck's spells
+type SpellRule struct {
+	Spell string   `json:"spell"`
+	Rules []string `json:"rules"`
+}
+
+// Guide is a structured play guide for one deck
+type Guide struct {
+	Name         string               `json:"name"`
+	Deck         []string             `json:"deck"`
+	Archetype    evaluation.Archetype `json:"archetype"`
+	AvgElixir    float64              `json:"avg_elixir"`
+	Roles        []RoleGroup          `json:"roles"`
+	KeySynergies []string             `json:"key_synergies"`
+	Opening      []string             `json:"opening"`
+	Defense      []DefensiveSetup     `json:"defense"`
+	Spells       []SpellRule          `json:"spells"`
+	SingleElixir []string             `json:"single_elixir"`
+	DoubleElixir []string             `json:"double_elixir"`
+}
+
+// roleOrder is the order roles are listed in a guide. Cards the role
+// classifier does not know come last.
+var roleOrder = []deck.CardRole{
+	deck.RoleWinCondition, deck.RoleBuilding, deck.RoleSpellBig,
+	deck.RoleSpellSmall, deck.RoleSupport, deck.RoleCycle, "",
+}
+
+// Generate writes the guide for cards. Nil dependencies fall back to the
+// built-in synergy database and counter matrix.
+func Generate(name string, cards []deck.CardCandidate, synergyDB *deck.SynergyDatabase, matrix *deck.CounterMatrix) (*Guide, error) {
+	if len(cards) == 0 {
+		return nil, fmt.Errorf("deck has no cards")
+	}
+	if synergyDB == nil {
+		synergyDB = deck.NewSynergyDatabase()
+	}
+	if matrix == nil {
+		matrix = deck.NewCounterMatrixWithDefaults()
+	}
+
+	result := evaluation.Evaluate(cards, synergyDB, nil)
+	g := &generator{cards: cards, matrix: matrix, archetype: result.DetectedArchetype, pairs: result.SynergyMatrix.Pairs}
+	g.groupRoles()
+
+	guide := &Guide{
+		Name:         name,
+		Deck:         result.Deck,
+		Archetype:    result.DetectedArchetype,
+		AvgElixir:    result.AvgElixir,
+		KeySynergies: evaluation.GenerateTopSynergyNarrative(&result.SynergyMatrix),
+	}
+	for _, role := range roleOrder {
+		if len(g.byRole[role]) > 0 {
+			guide.Roles = append(guide.Roles, RoleGroup{Role: role, Cards: g.byRole[role]})
+		}
+	}
+	guide.Opening = g.opening()
+	guide.Defense = g.defense()
+	guide.Spells = g.spells()
+	guide.SingleElixir, guide.DoubleElixir = g.gamePlan(result.AvgElixir)
+	return guide, nil
+}
+
+// generator holds what the guide sections are derived from
+type generator struct {
+	cards     []deck.CardCandidate
+	matrix    *deck.CounterMatrix
+	archetype evaluation.Archetype
+	pairs     []deck.SynergyPair
+	byRole    map[deck.CardRole][]string
+}
+
+func (g *generator) role(card deck.CardCandidate) deck.CardRole {
+	if card.Role != nil {
+		return *card.Role
+	}
+	return config.GetCardRole(card.Name)
+}
+
+func (g *generator) groupRoles() {
+	g.byRole = make(map[deck.CardRole][]string)
+	for _, card := range g.cards {
+		role := g.role(card)
+		g.byRole[role] = append(g.byRole[role], card.Name)
+	}
+}
+
+func (g *generator) isSpell(card deck.CardCandidate) bool {
+	role := g.role(card)
+	return role == deck.RoleSpellBig || role == deck.RoleSpellSmall
+}
+
+// cardsWhere returns the names of the deck's cards matching keep, cheapest first
+func (g *generator) cardsWhere(keep func(deck.CardCandidate) bool) []string {
+	matched := slices.Clone(g.cards)
+	matched = slices.DeleteFunc(matched, func(card deck.CardCandidate) bool { return !keep(card) })
+	slices.SortStableFunc(matched, func(a, b deck.CardCandidate) int { return cmp.Compare(a.Elixir, b.Elixir) })
+	names := make([]string, len(matched))
+	for i, card := range matched {
+		names[i] = card.Name
+	}
+	return names
+}
+
+func (g *generator) has(card deck.CardCandidate, category deck.CounterCategory) bool {
+	return g.matrix.HasCapability(card.Name, category)
+}
+
+func (g *generator) targetsAir(card deck.CardCandidate) bool {
+	if g.has(card, deck.CounterAirDefense) {
+		return true
+	}
+	return card.Stats != nil && !g.isSpell(card) && (card.Stats.Targets == "Air" || card.Stats.Targets == "Air & Ground")
+}
+
+func (g *generator) killsTanks(card deck.CardCandidate) bool {
+	if g.has(card, deck.CounterTankKillers) {
+		return true
+	}
+	return card.Stats != nil && !g.isSpell(card) && card.Stats.Targets != "Buildings" && card.Stats.DamagePerSecond >= tankKillerDPS
+}
+
+func (g *generator) clearsSwarms(card deck.CardCandidate) bool {
+	if g.has(card, deck.CounterSplashDefense) || g.has(card, deck.CounterSwarmClear) {
+		return true
+	}
+	return card.Stats != nil && card.Stats.Radius > 0 && card.Stats.Targets != "Buildings"
+}
+
+func (g *generator) isBuilding(card deck.CardCandidate) bool {
+	return g.role(card) == deck.RoleBuilding || g.has(card, deck.CounterBuildings)
+}
+
+func (g *generator) winConditions() []string {
+	return g.byRole[deck.RoleWinCondition]
+}
+
+// cheapTroops are troops that cost at most cheapElixir and are not win conditions
+func (g *generator) cheapTroops() []string {
+	return g.cardsWhere(func(card deck.CardCandidate) bool {
+		role := g.role(card)
+		return card.Elixir <= cheapElixir && !g.isSpell(card) && role != deck.RoleWinCondition && role != deck.RoleBuilding
+	})
+}
+
+// pushPartners returns the cards the synergy database pairs with card as
+// tank support, win condition, or bridge spam partners
+func (g *generator) pushPartners(card string) []string {
+	var partners []string
+	for _, pair := range g.pairs {
+		switch pair.SynergyType {
+		case deck.SynergyTankSupport, deck.SynergyWinCondition, deck.SynergyBridgeSpam:
+		default:
+			continue
+		}
+		switch card {
+		case pair.Card1:
+			partners = append(partners, pair.Card2)
+		case pair.Card2:
+			partners = append(partners, pair.Card1)
+		}
+	}
+	return partners
+}
+
+func (g *generator) opening() []string {
+	winConditions := g.winConditions()
+	winCondition := first(winConditions, "your win condition")
+	cheap := g.cheapTroops()
+
+	var plays []string
+	switch g.archetype {
+	case evaluation.ArchetypeBeatdown:
+		plays = append(plays, fmt.Sprintf("Let the opponent play first. Start your first push by placing %s behind your king tower, ideally at 10 elixir or after a positive trade.", winCondition))
+	case evaluation.ArchetypeCycle:
+		plays = append(plays, fmt.Sprintf("Open with a cheap card in the back to cycle toward %s, then pressure early before the opponent builds elixir.", winCondition))
+	case evaluation.ArchetypeControl:
+		plays = append(plays, "Play defensively. Open with a cheap troop in the back and build elixir advantage from defending, not from attacking first.")
+	case evaluation.ArchetypeBridge:
+		plays = append(plays, fmt.Sprintf("Wait for the opponent to commit elixir in the back, then punish the opposite lane with %s at the bridge.", winCondition))
+	case evaluation.ArchetypeSiege:
+		plays = append(plays, fmt.Sprintf("Do not place %s until you can defend it. Open with a cheap card and learn what the opponent can send at it.", winCondition))
+	case evaluation.ArchetypeBait:
+		plays = append(plays, "Open with a cheap bait card to see which small spell the opponent carries, then keep baiting that spell out.")
+	case evaluation.ArchetypeGraveyard, evaluation.ArchetypeMiner:
+		plays = append(plays, fmt.Sprintf("Open with a cheap troop in the back and start chip damage with %s in the lane the opponent did not defend.", winCondition))
+	default:
+		plays = append(plays, fmt.Sprintf("Open with a cheap troop in the back, defend the first push, and counter-push with %s.", winCondition))
+	}
+	if len(cheap) > 0 {
+		plays = append(plays, "Safe opening cards: "+strings.Join(cheap, ", ")+".")
+	}
+
+	var avoid []string
+	for _, card := range g.cards {
+		switch {
+		case g.isSpell(card):
+			avoid = append(avoid, card.Name+" (reveals your spell)")
+		case g.isBuilding(card) && g.role(card) != deck.RoleWinCondition:
+			avoid = append(avoid, card.Name+" (wasted before a push arrives)")
+		case card.Elixir >= 5 && g.archetype != evaluation.ArchetypeBeatdown:
+			avoid = append(avoid, card.Name+" (leaves you open to a counter-push)")
+		}
+	}
+	if len(avoid) > 0 {
+		plays = append(plays, "Avoid opening with: "+strings.Join(avoid, ", ")+".")
+	}
+	return plays
+}
+
+// threatKind is a group of threats that call for the same defensive setup
+type threatKind struct {
+	name     string
+	examples []string
+	answers  func(*generator, deck.CardCandidate) bool
+	setup    func(g *generator, answers []string) string
+}
+
+var threatKinds = []threatKind{
+	{
+		name:     "Tanks",
+		examples: []string{"Golem", "Giant", "Mega Knight", "Electro Giant"},
+		answers: func(g *generator, card deck.CardCandidate) bool {
+			return g.killsTanks(card) || g.isBuilding(card)
+		},
+		setup: func(g *generator, answers []string) string {
+			buildings := g.filter(answers, g.isBuilding)
+			killers := g.filter(answers, func(card deck.CardCandidate) bool {
+				return g.killsTanks(card) && !g.isBuilding(card)
+			})
+			switch {
+			case len(buildings) > 0 && len(killers) > 0:
+				return fmt.Sprintf("Place %s in the center, four tiles from the river, to pull the tank, then drop %s on it as it reaches the building.", buildings[0], orList(killers))
+			case len(killers) > 0:
+				return fmt.Sprintf("Let the tank cross the bridge and place %s in front of it; deal with the support troops behind it first if they threaten the tower.", orList(killers))
+			default:
+				return fmt.Sprintf("Pull the tank with %s and spend elixir on the support troops behind it; the deck has no dedicated tank killer.", buildings[0])
+			}
+		},
+	},
+	{
+		name:     "Air",
+		examples: []string{"Balloon", "Lava Hound", "Minion Horde", "Baby Dragon"},
+		answers: func(g *generator, card deck.CardCandidate) bool {
+			return g.targetsAir(card)
+		},
+		setup: func(g *generator, answers []string) string {
+			return fmt.Sprintf("Answer air units with %s, placed so they are in range before the unit reaches your tower. Against Balloon, place them as it crosses the river.", strings.Join(answers, ", "))
+		},
+	},
+	{
+		name:     "Swarms",
+		examples: []string{"Skeleton Army", "Goblin Gang", "Minion Horde", "Goblin Barrel"},
+		answers: func(g *generator, card deck.CardCandidate) bool {
+			return g.clearsSwarms(card)
+		},
+		setup: func(g *generator, answers []string) string {
+			return fmt.Sprintf("Clear swarms with %s. Let the tower take the first hits so splash damage catches the whole group.", strings.Join(answers, ", "))
+		},
+	},
+	{
+		name:     "Fast win conditions",
+		examples: []string{"Hog Rider", "Battle Ram", "Ram Rider", "Royal Hogs"},
+		answers: func(g *generator, card deck.CardCandidate) bool {
+			return g.isBuilding(card) || ((g.has(card, deck.CounterResetRetarget) || g.killsTanks(card)) && card.Elixir <= 4)
+		},
+		setup: func(g *generator, answers []string) string {
+			buildings := g.filter(answers, g.isBuilding)
+			if len(buildings) == 0 {
+				return fmt.Sprintf("Stop the charge at the bridge with %s before it builds speed.", orList(answers))
+			}
+			setup := fmt.Sprintf("Place %s in the center as the unit reaches the bridge so it never targets the tower", buildings[0])
+			if others := slices.DeleteFunc(slices.Clone(answers), func(name string) bool { return name == buildings[0] }); len(others) > 0 {
+				setup += fmt.Sprintf("; finish it with %s", orList(others))
+			}
+			return setup + "."
+		},
+	},
+	{
+		name:     "Siege",
+		examples: []string{"X-Bow", "Mortar"},
+		answers: func(g *generator, card deck.CardCandidate) bool {
+			role := g.role(card)
+			return g.has(card, deck.CounterBuildingCounters) || role == deck.RoleSpellBig ||
+				(role == deck.RoleWinCondition && card.Stats != nil && card.Stats.Targets == "Buildings")
+		},
+		setup: func(g *generator, answers []string) string {
+			return fmt.Sprintf("Reach the siege building quickly with %s, or tank for it and damage it before it locks onto your tower. Counter-push the opposite lane while the opponent is low on elixir.", strings.Join(answers, ", "))
+		},
+	},
+}
+
+// filter returns the names whose deck card matches keep
+func (g *generator) filter(names []string, keep func(deck.CardCandidate) bool) []string {
+	var kept []string
+	for _, card := range g.cards {
+		if slices.Contains(names, card.Name) && keep(card) {
+			kept = append(kept, card.Name)
+		}
+	}
+	return kept
+}
+
+func (g *generator) defense() []DefensiveSetup {
+	setups := make([]DefensiveSetup, 0, len(threatKinds))
+	for _, kind := range threatKinds {
+		answers := g.cardsWhere(func(card deck.CardCandidate) bool { return kind.answers(g, card) })
+		setup := DefensiveSetup{Threat: kind.name, Examples: kind.examples, Cards: answers}
+		if len(answers) == 0 {
+			setup.Gap = true
+			setup.Cards = []string{}
+			setup.Setup = "No card in this deck answers these well. Defend with the tower, save elixir, and counter-push the other lane."
+		} else {
+			setup.Setup = kind.setup(g, answers)
+		}
+		setups = append(setups, setup)
+	}
+	return setups
+}
+
+func (g *generator) spells() []SpellRule {
+	var rules []SpellRule
+	for _, card := range g.cards {
+		if !g.isSpell(card) {
+			continue
+		}
+		rule := SpellRule{Spell: card.Name}
+		if g.role(card) == deck.RoleSpellBig {
+			rule.Rules = append(rule.Rules, "Use it for value: two or more troops, or a troop and the tower together.")
+		} else {
+			rule.Rules = append(rule.Rules, "Use it on swarms and low-hitpoint support troops rather than for tower damage.")
+		}
+		if g.has(card, deck.CounterResetRetarget) {
+			rule.Rules = append(rule.Rules, "Save it to reset Inferno Tower, Inferno Dragon, and Sparky when the opponent has them.")
+		}
+		if g.clearsSwarms(card) {
+			rule.Rules = append(rule.Rules, "Against bait decks, keep it in hand for Goblin Barrel and other swarms.")
+		}
+		for _, pair := range g.pairs {
+			if pair.SynergyType != deck.SynergySpellCombo || (pair.Card1 != card.Name && pair.Card2 != card.Name) {
+				continue
+			}
+			partner := pair.Card1
+			if partner == card.Name {
+				partner = pair.Card2
+			}
+			rule.Rules = append(rule.Rules, fmt.Sprintf("Pair it with %s on offense: %s.", partner, strings.TrimSuffix(pair.Description, ".")))
+		}
+		rule.Rules = append(rule.Rules, "In overtime, use it to finish a tower that is within its tower damage.")
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func (g *generator) gamePlan(avgElixir float64) (single, double []string) {
+	winCondition := first(g.winConditions(), "your win condition")
+	if partners := g.pushPartners(winCondition); len(partners) > 0 {
+		single = append(single, fmt.Sprintf("Build pushes from %s with %s.", winCondition, strings.Join(partners, ", ")))
+	}
+	switch g.archetype {
+	case evaluation.ArchetypeBeatdown:
+		single = append(single, "Take small trades and build one big push; it is fine to take some tower damage while you gather elixir.")
+		double = append(double, fmt.Sprintf("Stack a full push behind %s each time it comes up in rotation, and keep your big spell for the towers.", winCondition))
+	case evaluation.ArchetypeCycle:
+		single = append(single, "Defend cheaply and pressure every time the opponent over-commits, keeping the rotation fast.")
+		double = append(double, fmt.Sprintf("Cycle %s on every rotation and use spare elixir to chip the tower with spells.", winCondition))
+	case evaluation.ArchetypeControl:
+		single = append(single, "Defend for positive elixir trades and counter-push with what survives.")
+		double = append(double, "Keep defending efficiently; double elixir turns each positive trade into a stronger counter-push.")
+	case evaluation.ArchetypeSiege:
+		single = append(single, fmt.Sprintf("Place %s at the bridge when the opponent is low on elixir and defend it.", winCondition))
+		double = append(double, fmt.Sprintf("Place %s on every rotation you can defend it and play defensively with the rest of the deck.", winCondition))
+	case evaluation.ArchetypeBait:
+		single = append(single, "Bait out the opponent's small spell before sending the cards it answers.")
+		double = append(double, "Play two bait cards in a row so one of them connects, and use spells to finish the tower.")
+	case evaluation.ArchetypeBridge:
+		single = append(single, "Punish every elixir commitment with fast pressure in the opposite lane.")
+		double = append(double, "Go dual-lane with bridge spam so the opponent cannot defend both sides at once.")
+	default:
+		single = append(single, "Defend first, then counter-push with the troops that survive.")
+		double = append(double, fmt.Sprintf("Double up the support behind %s and pressure both lanes.", winCondition))
+	}
+	switch {
+	case avgElixir <= 3.0:
+		double = append(double, fmt.Sprintf("At %.1f average elixir you can out-cycle most decks; do not let the opponent reach a full push unpunished.", avgElixir))
+	case avgElixir >= 4.0:
+		single = append(single, fmt.Sprintf("At %.1f average elixir, never start a push below full elixir and avoid over-committing on defense.", avgElixir))
+	}
+	return single, double
+}
+
+// orList joins names as "A, B or C"
+func orList(names []string) string {
+	if len(names) <= 1 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+}
+
+func first(names []string, fallback string) string {
+	if len(names) == 0 {
+		return fallback
+	}
+	return names[0]
+}
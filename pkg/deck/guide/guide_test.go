@@ -0,0 +1,86 @@
+package guide
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+func testCards(names ...string) []deck.CardCandidate {
+	cards := make([]deck.CardCandidate, len(names))
+	for i, name := range names {
+		cards[i] = deck.CardCandidate{Name: name, Elixir: config.GetCardElixir(name, 0)}
+	}
+	return cards
+}
+
+func TestGenerate(t *testing.T) {
+	cards := testCards("Hog Rider", "Musketeer", "Inferno Tower", "Ice Spirit", "Skeletons", "The Log", "Zap", "Ice Golem")
+	g, err := Generate("Hog Inferno", cards, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(g.Roles) == 0 || g.Roles[0].Role != deck.RoleWinCondition || !slices.Equal(g.Roles[0].Cards, []string{"Hog Rider"}) {
+		t.Errorf("roles = %+v, want Hog Rider listed first as the win condition", g.Roles)
+	}
+	if len(g.Opening) == 0 || !slices.ContainsFunc(g.Opening, func(play string) bool { return strings.Contains(play, "Zap (reveals your spell)") }) {
+		t.Errorf("opening = %q, want spells listed as bad openers", g.Opening)
+	}
+
+	defense := make(map[string]DefensiveSetup)
+	for _, setup := range g.Defense {
+		defense[setup.Threat] = setup
+	}
+	if tanks := defense["Tanks"]; tanks.Gap || !slices.Contains(tanks.Cards, "Inferno Tower") {
+		t.Errorf("tank defense = %+v, want Inferno Tower", tanks)
+	}
+	if air := defense["Air"]; air.Gap || !slices.Contains(air.Cards, "Musketeer") {
+		t.Errorf("air defense = %+v, want Musketeer", air)
+	}
+	if siege := defense["Siege"]; !siege.Gap || len(siege.Cards) != 0 {
+		t.Errorf("siege defense = %+v, want a gap", siege)
+	}
+
+	spells := make([]string, len(g.Spells))
+	for i, rule := range g.Spells {
+		spells[i] = rule.Spell
+	}
+	if !slices.Equal(spells, []string{"The Log", "Zap"}) {
+		t.Errorf("spell rules for %v, want The Log and Zap", spells)
+	}
+	if zap := g.Spells[1]; !slices.ContainsFunc(zap.Rules, func(rule string) bool { return strings.Contains(rule, "reset Inferno") }) {
+		t.Errorf("Zap rules = %q, want a reset rule", zap.Rules)
+	}
+	if len(g.SingleElixir) == 0 || len(g.DoubleElixir) == 0 {
+		t.Errorf("game plan = %q / %q, want both phases", g.SingleElixir, g.DoubleElixir)
+	}
+
+	markdown := g.Markdown()
+	for _, want := range []string{"# Hog Inferno Play Guide", "## Opening Plays", "### Siege (X-Bow, Mortar)\n\n⚠️", "### Zap", "### Double Elixir"} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("markdown is missing %q:\n%s", want, markdown)
+		}
+	}
+}
+
+func TestGenerateRejectsEmptyDeck(t *testing.T) {
+	if _, err := Generate("", nil, nil, nil); err == nil {
+		t.Error("expected an error for an empty deck")
+	}
+}
+
+func TestOrList(t *testing.T) {
+	for names, want := range map[string]string{"": "", "A": "A", "A,B": "A or B", "A,B,C": "A, B or C"} {
+		var list []string
+		if names != "" {
+			list = strings.Split(names, ",")
+		}
+		if got := orList(list); got != want {
+			t.Errorf("orList(%q) = %q, want %q", list, got, want)
+		}
+	}
+}
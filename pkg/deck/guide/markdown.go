@@ -0,0 +1,80 @@
+package guide
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+// roleTitles are the headings of each role in the deck overview
+var roleTitles = map[deck.CardRole]string{
+	deck.RoleWinCondition: "Win conditions",
+	deck.RoleBuilding:     "Buildings",
+	deck.RoleSpellBig:     "Big spells",
+	deck.RoleSpellSmall:   "Small spells",
+	deck.RoleSupport:      "Support",
+	deck.RoleCycle:        "Cycle",
+	"":                    "Other",
+}
+
+// Markdown renders the guide as a Markdown document.
+func (g *Guide) Markdown() string {
+	var sb strings.Builder
+
+	title := g.Name
+	if title == "" {
+		title = "Deck"
+	}
+	fmt.Fprintf(&sb, "# %s Play Guide\n\n", title)
+	fmt.Fprintf(&sb, "**Deck:** %s\n\n", strings.Join(g.Deck, ", "))
+	fmt.Fprintf(&sb, "**Archetype:** %s · **Average elixir:** %.1f\n\n", g.Archetype, g.AvgElixir)
+
+	sb.WriteString("## Roles\n\n")
+	for _, group := range g.Roles {
+		fmt.Fprintf(&sb, "- **%s:** %s\n", roleTitles[group.Role], strings.Join(group.Cards, ", "))
+	}
+	sb.WriteString("\n")
+
+	if len(g.KeySynergies) > 0 {
+		sb.WriteString("## Key Synergies\n\n")
+		writeList(&sb, g.KeySynergies)
+	}
+
+	sb.WriteString("## Opening Plays\n\n")
+	writeList(&sb, g.Opening)
+
+	sb.WriteString("## Defense by Threat\n\n")
+	for _, setup := range g.Defense {
+		fmt.Fprintf(&sb, "### %s (%s)\n\n", setup.Threat, strings.Join(setup.Examples, ", "))
+		if setup.Gap {
+			fmt.Fprintf(&sb, "⚠️ %s\n\n", setup.Setup)
+			continue
+		}
+		fmt.Fprintf(&sb, "**Answers:** %s\n\n%s\n\n", strings.Join(setup.Cards, ", "), setup.Setup)
+	}
+
+	sb.WriteString("## Spell Usage\n\n")
+	if len(g.Spells) == 0 {
+		sb.WriteString("This deck has no spells. Defend swarms with splash troops and expect to take chip damage.\n\n")
+	}
+	for _, rule := range g.Spells {
+		fmt.Fprintf(&sb, "### %s\n\n", rule.Spell)
+		writeList(&sb, rule.Rules)
+	}
+
+	sb.WriteString("## Game Plan\n\n")
+	sb.WriteString("### Single Elixir\n\n")
+	writeList(&sb, g.SingleElixir)
+	sb.WriteString("### Double Elixir\n\n")
+	writeList(&sb, g.DoubleElixir)
+
+	return sb.String()
+}
+
+func writeList(sb *strings.Builder, items []string) {
+	for _, item := range items {
+		fmt.Fprintf(sb, "- %s\n", item)
+	}
+	sb.WriteString("\n")
+}
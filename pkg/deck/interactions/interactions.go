@@ -0,0 +1,178 @@
+// Package interactions answers damage questions between two cards at given
+// levels, such as "does Fireball at level 11 kill Musketeer at level 13?".
+//
+// Answers come from the combat stats dataset: the attacker's damage per cast
+// (or per hit for troops) is compared against the target's hitpoints per
+// unit, both scaled to their card levels. Levels are on the shared 1-16 scale
+// (see deck.CardCandidate.StandardLevel).
+package interactions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+// CommonSpellTargets are troops players most often answer with a spell.
+var CommonSpellTargets = []string{
+	"Archers", "Baby Dragon", "Barbarians", "Bats", "Dart Goblin", "Electro Wizard",
+	"Executioner", "Firecracker", "Flying Machine", "Goblin Gang", "Goblins",
+	"Guards", "Ice Spirit", "Inferno Dragon", "Magic Archer", "Minion Horde",
+	"Minions", "Mother Witch", "Musketeer", "Night Witch", "Princess",
+	"Royal Recruits", "Skeleton Army", "Skeleton Dragons", "Spear Goblins",
+	"Three Musketeers", "Witch", "Wizard",
+}
+
+// Card is one side of an interaction. A Level of 0 or less uses the
+// dataset's standard level.
+type Card struct {
+	Name  string `json:"name"`
+	Level int    `json:"level,omitempty"`
+}
+
+func (c Card) String() string {
+	if c.Level <= 0 {
+		return c.Name
+	}
+	return fmt.Sprintf("%s (level %d)", c.Name, c.Level)
+}
+
+// Result is the outcome of one attacker hitting one target.
+type Result struct {
+	Attacker Card `json:"attacker"`
+	Target   Card `json:"target"`
+	// Reachable is false when the attacker cannot hit the target at all,
+	// such as a ground-only spell against an air troop
+	Reachable bool `json:"reachable"`
+	// Damage is the attacker's damage per cast or hit at its level
+	Damage int `json:"damage"`
+	// TargetHitpoints is the target's hitpoints per unit at its level
+	TargetHitpoints int  `json:"target_hitpoints"`
+	Kills           bool `json:"kills"`
+	// Remaining is the target's hitpoints left after one hit (0 when killed)
+	Remaining int `json:"remaining"`
+	// HitsToKill is how many hits the target takes (0 when unreachable)
+	HitsToKill int `json:"hits_to_kill"`
+}
+
+// String describes the result in one line.
+func (r Result) String() string {
+	switch {
+	case !r.Reachable:
+		return fmt.Sprintf("%s cannot hit %s", r.Attacker, r.Target)
+	case r.Kills:
+		return fmt.Sprintf("%s kills %s (%d damage vs %d HP)", r.Attacker, r.Target, r.Damage, r.TargetHitpoints)
+	default:
+		return fmt.Sprintf("%s leaves %s at %d HP (%d damage vs %d HP, %d hits to kill)",
+			r.Attacker, r.Target, r.Remaining, r.Damage, r.TargetHitpoints, r.HitsToKill)
+	}
+}
+
+// Check is a Result together with the outcome at even levels, to spot
+// interactions that a level gap creates or breaks.
+type Check struct {
+	Result
+	// KillsAtEvenLevels is whether the attacker kills the target when the
+	// target is at the attacker's level
+	KillsAtEvenLevels bool `json:"kills_at_even_levels"`
+}
+
+// LevelSensitive reports whether the level gap changes the outcome.
+func (c Check) LevelSensitive() bool {
+	return c.Reachable && c.Kills != c.KillsAtEvenLevels
+}
+
+// Note explains a level-sensitive check from the attacker's side, or returns
+// "" when the levels do not matter.
+func (c Check) Note() string {
+	if !c.LevelSensitive() {
+		return ""
+	}
+	if c.Kills {
+		return fmt.Sprintf("%s one-shots %s only thanks to the level gap (%d damage vs %d HP)",
+			c.Attacker, c.Target, c.Damage, c.TargetHitpoints)
+	}
+	return fmt.Sprintf("%s no longer one-shots %s at this level gap (%d damage vs %d HP, %d HP left)",
+		c.Attacker, c.Target, c.Damage, c.TargetHitpoints, c.Remaining)
+}
+
+// Calculator resolves interactions against a combat stats registry.
+type Calculator struct {
+	stats *clashroyale.CardStatsRegistry
+}
+
+// NewCalculator creates a calculator. A nil registry uses the bundled dataset.
+func NewCalculator(stats *clashroyale.CardStatsRegistry) *Calculator {
+	if stats == nil {
+		stats = clashroyale.DefaultStats()
+	}
+	return &Calculator{stats: stats}
+}
+
+// Resolve computes whether attacker kills target in one hit.
+func (c *Calculator) Resolve(attacker, target Card) (Result, error) {
+	attackerStats := c.stats.StatsAtLevel(attacker.Name, attacker.Level)
+	if attackerStats == nil {
+		return Result{}, fmt.Errorf("no combat stats for %q", attacker.Name)
+	}
+	if attackerStats.Damage <= 0 {
+		return Result{}, fmt.Errorf("%s deals no direct damage", attacker.Name)
+	}
+	targetStats := c.stats.StatsAtLevel(target.Name, target.Level)
+	if targetStats == nil {
+		return Result{}, fmt.Errorf("no combat stats for %q", target.Name)
+	}
+	if targetStats.Hitpoints <= 0 {
+		return Result{}, fmt.Errorf("%s has no hitpoints to damage", target.Name)
+	}
+
+	result := Result{
+		Attacker:        attacker,
+		Target:          target,
+		Reachable:       canHit(attackerStats, targetStats),
+		Damage:          attackerStats.Damage,
+		TargetHitpoints: targetStats.Hitpoints,
+	}
+	if !result.Reachable {
+		return result, nil
+	}
+	result.Kills = result.Damage >= result.TargetHitpoints
+	result.Remaining = max(result.TargetHitpoints-result.Damage, 0)
+	result.HitsToKill = (result.TargetHitpoints + result.Damage - 1) / result.Damage
+	return result, nil
+}
+
+// Check resolves attacker against target at their levels and at even levels.
+func (c *Calculator) Check(attacker, target Card) (Check, error) {
+	result, err := c.Resolve(attacker, target)
+	if err != nil {
+		return Check{}, err
+	}
+	even, err := c.Resolve(attacker, Card{Name: target.Name, Level: attacker.Level})
+	if err != nil {
+		return Check{}, err
+	}
+	return Check{Result: result, KillsAtEvenLevels: even.Kills}, nil
+}
+
+// CanDamage reports whether the card deals direct damage, making it a
+// candidate attacker.
+func (c *Calculator) CanDamage(name string) bool {
+	stats := c.stats.GetStats(name)
+	return stats != nil && stats.Damage > 0
+}
+
+// IsTarget reports whether the card has hitpoints, making it a candidate target.
+func (c *Calculator) IsTarget(name string) bool {
+	stats := c.stats.GetStats(name)
+	return stats != nil && stats.Hitpoints > 0
+}
+
+func canHit(attacker, target *clashroyale.CombatStats) bool {
+	targets := strings.ToLower(attacker.Targets)
+	if target.Flying {
+		return strings.Contains(targets, "air")
+	}
+	return targets == "" || strings.Contains(targets, "ground")
+}
@@ -0,0 +1,101 @@
+package interactions
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	calc := NewCalculator(nil)
+
+	tests := []struct {
+		name      string
+		attacker  Card
+		target    Card
+		reachable bool
+		kills     bool
+		hits      int
+	}{
+		{"even levels", Card{"Fireball", 11}, Card{"Musketeer", 11}, true, true, 1},
+		{"over-leveled target", Card{"Fireball", 11}, Card{"Musketeer", 13}, true, false, 2},
+		{"under-leveled target", Card{"The Log", 13}, Card{"Ice Spirit", 11}, true, true, 1},
+		{"ground spell vs air troop", Card{"The Log", 11}, Card{"Minions", 11}, false, false, 0},
+		{"air spell vs air troop", Card{"Arrows", 11}, Card{"Minions", 11}, true, true, 1},
+		{"troop attacker", Card{"Knight", 11}, Card{"Skeletons", 11}, true, true, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.Resolve(tt.attacker, tt.target)
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			if result.Reachable != tt.reachable || result.Kills != tt.kills || result.HitsToKill != tt.hits {
+				t.Errorf("got reachable=%v kills=%v hits=%d, want %v %v %d (%s)",
+					result.Reachable, result.Kills, result.HitsToKill, tt.reachable, tt.kills, tt.hits, result)
+			}
+			if result.Kills && result.Remaining != 0 {
+				t.Errorf("killed target has %d HP remaining", result.Remaining)
+			}
+		})
+	}
+}
+
+func TestResolveLevelScaling(t *testing.T) {
+	calc := NewCalculator(nil)
+	at11, err := calc.Resolve(Card{"Fireball", 11}, Card{"Musketeer", 11})
+	if err != nil {
+		t.Fatal(err)
+	}
+	at13, err := calc.Resolve(Card{"Fireball", 13}, Card{"Musketeer", 13})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if at13.Damage <= at11.Damage || at13.TargetHitpoints <= at11.TargetHitpoints {
+		t.Errorf("level 13 damage/HP %d/%d should exceed level 11 %d/%d",
+			at13.Damage, at13.TargetHitpoints, at11.Damage, at11.TargetHitpoints)
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	calc := NewCalculator(nil)
+	for _, tt := range []struct {
+		attacker, target, want string
+	}{
+		{"Fireballl", "Musketeer", "no combat stats"},
+		{"Rage", "Musketeer", "no direct damage"},
+		{"Fireball", "Zap", "no hitpoints"},
+	} {
+		_, err := calc.Resolve(Card{Name: tt.attacker}, Card{Name: tt.target})
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s vs %s: error = %v, want %q", tt.attacker, tt.target, err, tt.want)
+		}
+	}
+}
+
+func TestCheckNotes(t *testing.T) {
+	calc := NewCalculator(nil)
+
+	lost, err := calc.Check(Card{"Fireball", 11}, Card{"Wizard", 13})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !lost.LevelSensitive() || !strings.Contains(lost.Note(), "no longer one-shots Wizard (level 13)") {
+		t.Errorf("Fireball vs over-leveled Wizard: sensitive=%v note=%q", lost.LevelSensitive(), lost.Note())
+	}
+
+	gained, err := calc.Check(Card{"Zap", 13}, Card{"Ice Spirit", 11})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(gained.Note(), "only thanks to the level gap") {
+		t.Errorf("Zap vs under-leveled Ice Spirit note = %q", gained.Note())
+	}
+
+	even, err := calc.Check(Card{"Fireball", 11}, Card{"Wizard", 11})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if even.LevelSensitive() || even.Note() != "" {
+		t.Errorf("even levels should not be level sensitive: %q", even.Note())
+	}
+}
@@ -0,0 +1,105 @@
+package deck
+
+import (
+	"math"
+	"slices"
+)
+
+// ladderAverageCards is how many of the player's best-leveled cards make up
+// their ladder average: one deck's worth
+const ladderAverageCards = 8
+
+// LevelWindow limits deck tools to cards within Levels of the player's
+// ladder average, so recommended decks are playable today rather than after
+// months of upgrades. Levels are on the shared 1-16 scale (see
+// CardCandidate.StandardLevel). Cards above the average are always allowed.
+type LevelWindow struct {
+	Levels  int     `json:"levels"`
+	Average float64 `json:"ladder_average"`
+}
+
+// LevelUpgrade is a card that must be leveled from From to To (standard
+// levels) to enter a LevelWindow
+type LevelUpgrade struct {
+	Card string `json:"card"`
+	From int    `json:"from"`
+	To   int    `json:"to"`
+}
+
+// NewLevelWindow returns the window of the given width around a collection's
+// ladder average: the mean standard level of its eight highest-leveled
+// cards. It returns nil for an empty collection.
+func NewLevelWindow(levels int, collection []CardCandidate) *LevelWindow {
+	if len(collection) == 0 {
+		return nil
+	}
+	standard := make([]int, 0, len(collection))
+	for i := range collection {
+		standard = append(standard, collection[i].StandardLevel())
+	}
+	slices.Sort(standard)
+	slices.Reverse(standard)
+	best := standard[:min(ladderAverageCards, len(standard))]
+
+	total := 0
+	for _, level := range best {
+		total += level
+	}
+	average := float64(total) / float64(len(best))
+	return &LevelWindow{Levels: max(levels, 0), Average: math.Round(average*100) / 100}
+}
+
+// Floor is the lowest standard level inside the window
+func (w *LevelWindow) Floor() int {
+	return int(math.Ceil(w.Average - float64(w.Levels)))
+}
+
+// Allows reports whether a card is leveled high enough to play today
+func (w *LevelWindow) Allows(card CardCandidate) bool {
+	return w == nil || card.StandardLevel() >= w.Floor()
+}
+
+// Upgrades lists the cards below the window with the level each needs, in
+// deck order
+func (w *LevelWindow) Upgrades(cards []CardCandidate) []LevelUpgrade {
+	if w == nil {
+		return nil
+	}
+	var upgrades []LevelUpgrade
+	for _, card := range cards {
+		if level := card.StandardLevel(); level < w.Floor() {
+			upgrades = append(upgrades, LevelUpgrade{Card: card.Name, From: level, To: w.Floor()})
+		}
+	}
+	return upgrades
+}
+
+// Raise returns cards with every card below the window leveled up to its
+// floor, for scoring a deck as it would play after the upgrades
+func (w *LevelWindow) Raise(cards []CardCandidate) []CardCandidate {
+	raised := slices.Clone(cards)
+	if w == nil {
+		return raised
+	}
+	for i := range raised {
+		gap := w.Floor() - raised[i].StandardLevel()
+		if gap <= 0 {
+			continue
+		}
+		raised[i].Level += gap
+		if raised[i].MaxLevel > 0 {
+			raised[i].Level = min(raised[i].Level, raised[i].MaxLevel)
+		}
+		raised[i].Stats = nil
+	}
+	return raised
+}
+
+// UpgradeLevels totals the levels across upgrades
+func UpgradeLevels(upgrades []LevelUpgrade) int {
+	total := 0
+	for _, upgrade := range upgrades {
+		total += upgrade.To - upgrade.From
+	}
+	return total
+}
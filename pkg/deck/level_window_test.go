@@ -0,0 +1,86 @@
+package deck
+
+import (
+	"slices"
+	"testing"
+)
+
+func levelWindowCollection() []CardCandidate {
+	collection := make([]CardCandidate, 0, 11)
+	// Eight commons at 14 and 13 set the ladder average at 13.5
+	for i, level := range []int{14, 14, 14, 14, 13, 13, 13, 13} {
+		collection = append(collection, CardCandidate{Name: string(rune('A' + i)), Level: level, MaxLevel: 16, Rarity: "Common"})
+	}
+	return append(collection,
+		// API-relative levels: a level 6 Epic is level 11, a level 7 Rare is 9
+		CardCandidate{Name: "Epic", Level: 6, MaxLevel: 11, Rarity: "Epic"},
+		CardCandidate{Name: "Rare", Level: 7, MaxLevel: 14, Rarity: "Rare"},
+		CardCandidate{Name: "Low", Level: 9, MaxLevel: 16, Rarity: "Common"},
+	)
+}
+
+func TestNewLevelWindow(t *testing.T) {
+	window := NewLevelWindow(2, levelWindowCollection())
+	if window.Average != 13.5 || window.Floor() != 12 {
+		t.Fatalf("window = %+v, floor %d; want average 13.5, floor 12", window, window.Floor())
+	}
+	if NewLevelWindow(2, nil) != nil {
+		t.Error("an empty collection should have no window")
+	}
+
+	if got := NewLevelWindow(0, levelWindowCollection()).Floor(); got != 14 {
+		t.Errorf("zero-width floor = %d, want 14 (the average rounded up)", got)
+	}
+	if got := NewLevelWindow(-1, levelWindowCollection()).Levels; got != 0 {
+		t.Errorf("negative width = %d, want 0", got)
+	}
+}
+
+func TestLevelWindowAllowsAndUpgrades(t *testing.T) {
+	collection := levelWindowCollection()
+	window := NewLevelWindow(2, collection)
+
+	var allowed []string
+	for _, card := range collection {
+		if window.Allows(card) {
+			allowed = append(allowed, card.Name)
+		}
+	}
+	if len(allowed) != 8 || slices.Contains(allowed, "Epic") {
+		t.Errorf("allowed = %v, want only the eight commons at 13+", allowed)
+	}
+	var nilWindow *LevelWindow
+	if !nilWindow.Allows(collection[len(collection)-1]) {
+		t.Error("a nil window should allow every card")
+	}
+
+	upgrades := window.Upgrades(collection[7:])
+	want := []LevelUpgrade{{Card: "Epic", From: 11, To: 12}, {Card: "Rare", From: 9, To: 12}, {Card: "Low", From: 9, To: 12}}
+	if !slices.Equal(upgrades, want) {
+		t.Errorf("upgrades = %+v, want %+v", upgrades, want)
+	}
+	if got := UpgradeLevels(upgrades); got != 7 {
+		t.Errorf("UpgradeLevels = %d, want 7", got)
+	}
+}
+
+func TestLevelWindowRaise(t *testing.T) {
+	collection := levelWindowCollection()
+	window := NewLevelWindow(2, collection)
+	raised := window.Raise(collection)
+
+	for i, card := range raised {
+		if card.StandardLevel() < window.Floor() {
+			t.Errorf("%s raised to %d, below the floor", card.Name, card.StandardLevel())
+		}
+		if collection[i].StandardLevel() >= window.Floor() && card.Level != collection[i].Level {
+			t.Errorf("%s was already in the window but changed level", card.Name)
+		}
+	}
+	if raised[9].Level != 10 || collection[9].Level != 7 {
+		t.Errorf("Rare raised to API level %d (original %d), want 10 (7)", raised[9].Level, collection[9].Level)
+	}
+	if raised[8].Level != 7 {
+		t.Errorf("Epic raised to API level %d, want 7", raised[8].Level)
+	}
+}
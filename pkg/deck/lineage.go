@@ -0,0 +1,131 @@
+package deck
+
+import (
+	"slices"
+	"sync"
+)
+
+// Operations that derive a deck from parent decks, recorded by LineageRecorder
+const (
+	// LineageCrossover combines cards from two parent decks
+	LineageCrossover = "crossover"
+	// LineageMutation swaps cards of one parent deck
+	LineageMutation = "mutation"
+	// LineageVariation swaps cards of a deck chosen with --based-on
+	LineageVariation = "variation"
+)
+
+// LineageEdge records how a deck was derived from its parents.
+type LineageEdge struct {
+	Cards     []string
+	Parents   [][]string
+	Operation string
+	// Generation is the GA generation that produced the deck, 0 outside the GA
+	Generation int
+}
+
+// LineageRecorder collects the parents of decks as mutation, variation, and
+// crossover produce them, so a run can trace how its best decks evolved. A
+// deck keeps the first edge recorded for it. A nil recorder records nothing,
+// and it is safe for concurrent use.
+type LineageRecorder struct {
+	mu         sync.Mutex
+	generation int
+	edges      map[string]LineageEdge
+}
+
+// NewLineageRecorder returns an empty recorder.
+func NewLineageRecorder() *LineageRecorder {
+	return &LineageRecorder{edges: make(map[string]LineageEdge)}
+}
+
+// SetGeneration sets the GA generation stamped on edges recorded from now on.
+func (r *LineageRecorder) SetGeneration(generation int) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.generation = generation
+	r.mu.Unlock()
+}
+
+// Record notes that cards were derived from parents by operation. A deck
+// identical to one of its parents is not recorded.
+func (r *LineageRecorder) Record(cards []string, operation string, parents ...[]string) {
+	if r == nil || len(cards) == 0 || len(parents) == 0 {
+		return
+	}
+	key := CanonicalDeckKey(cards)
+	for _, parent := range parents {
+		if CanonicalDeckKey(parent) == key {
+			return
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.edges[key]; ok {
+		return
+	}
+	edge := LineageEdge{
+		Cards:      slices.Clone(cards),
+		Parents:    make([][]string, len(parents)),
+		Operation:  operation,
+		Generation: r.generation,
+	}
+	for i, parent := range parents {
+		edge.Parents[i] = slices.Clone(parent)
+	}
+	r.edges[key] = edge
+}
+
+// Len returns the number of decks with a recorded edge.
+func (r *LineageRecorder) Len() int {
+	if r == nil {
+		return 0
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.edges)
+}
+
+// Lookup returns the edge recorded for a deck.
+func (r *LineageRecorder) Lookup(cards []string) (LineageEdge, bool) {
+	if r == nil {
+		return LineageEdge{}, false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	edge, ok := r.edges[CanonicalDeckKey(cards)]
+	return edge, ok
+}
+
+// Ancestry returns the edges of a deck and its ancestors up to depth
+// generations back, the deck's own edge first. Each ancestor appears once,
+// even when it is reached along several paths.
+func (r *LineageRecorder) Ancestry(cards []string, depth int) []LineageEdge {
+	var ancestry []LineageEdge
+	seen := map[string]bool{CanonicalDeckKey(cards): true}
+	level := [][]string{cards}
+	for range depth {
+		var next [][]string
+		for _, deckCards := range level {
+			edge, ok := r.Lookup(deckCards)
+			if !ok {
+				continue
+			}
+			ancestry = append(ancestry, edge)
+			for _, parent := range edge.Parents {
+				if key := CanonicalDeckKey(parent); !seen[key] {
+					seen[key] = true
+					next = append(next, parent)
+				}
+			}
+		}
+		if len(next) == 0 {
+			break
+		}
+		level = next
+	}
+	return ancestry
+}
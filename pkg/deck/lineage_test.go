@@ -0,0 +1,48 @@
+package deck
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLineageRecorderAncestry(t *testing.T) {
+	a := []string{"A", "B", "C", "D", "E", "F", "G", "H"}
+	b := []string{"I", "J", "K", "L", "M", "N", "O", "P"}
+	child := []string{"A", "B", "C", "D", "M", "N", "O", "P"}
+	grandchild := []string{"Q", "B", "C", "D", "M", "N", "O", "P"}
+
+	recorder := NewLineageRecorder()
+	recorder.SetGeneration(1)
+	recorder.Record(child, LineageCrossover, a, b)
+	recorder.SetGeneration(2)
+	recorder.Record(grandchild, LineageMutation, child)
+	// A later edge for the same deck, in any card order, is ignored
+	reversed := slices.Clone(grandchild)
+	slices.Reverse(reversed)
+	recorder.Record(reversed, LineageMutation, a)
+	// A deck is never its own parent
+	recorder.Record(a, LineageMutation, a)
+
+	if recorder.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", recorder.Len())
+	}
+	ancestry := recorder.Ancestry(grandchild, 5)
+	if len(ancestry) != 2 {
+		t.Fatalf("Ancestry() = %+v, want 2 edges", ancestry)
+	}
+	if ancestry[0].Operation != LineageMutation || ancestry[0].Generation != 2 || !slices.Equal(ancestry[0].Parents[0], child) {
+		t.Errorf("first edge = %+v, want the mutation from the child", ancestry[0])
+	}
+	if ancestry[1].Operation != LineageCrossover || len(ancestry[1].Parents) != 2 {
+		t.Errorf("second edge = %+v, want the crossover", ancestry[1])
+	}
+	if got := recorder.Ancestry(grandchild, 1); len(got) != 1 {
+		t.Errorf("Ancestry(depth 1) = %d edges, want 1", len(got))
+	}
+
+	var nilRecorder *LineageRecorder
+	nilRecorder.Record(child, LineageCrossover, a, b)
+	if _, ok := nilRecorder.Lookup(child); ok || nilRecorder.Len() != 0 {
+		t.Error("a nil recorder should record nothing")
+	}
+}
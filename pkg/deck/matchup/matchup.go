@@ -0,0 +1,418 @@
+// Package matchup scores one Clash Royale deck against another.
+//
+// A matchup combines three signals for each side: how well the deck's cards
+// answer the opponent's key threats (counter coverage), whether the deck
+// carries spells that cleanly answer the opponent's spell-vulnerable cards
+// (spell coverage), and the standalone evaluation score of the deck. The
+// signals are folded into a predicted win probability for deck A.
+//
+// Each side also lists spell interactions that the two decks' card levels
+// change, such as a Fireball that no longer one-shots an over-leveled Wizard.
+package matchup
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/interactions"
+)
+
+const (
+	// fallbackEffectiveness is used when a threat has no counter matrix data
+	// but the deck still carries a generic answer (tank killer, building, air defense).
+	fallbackEffectiveness = 0.6
+
+	// Weights applied to each differential when computing the win logit.
+	counterWeight = 2.5
+	spellWeight   = 1.5
+	scoreWeight   = 0.6
+
+	minWinProbability = 0.05
+	maxWinProbability = 0.95
+
+	// evenThreshold is the distance from 0.5 under which a matchup is considered even.
+	evenThreshold = 0.05
+)
+
+// Favored identifies which side a matchup favors.
+type Favored string
+
+const (
+	FavoredDeckA Favored = "deck_a"
+	FavoredDeckB Favored = "deck_b"
+	FavoredEven  Favored = "even"
+)
+
+// ThreatAnswer describes how a deck answers one of the opponent's threats.
+type ThreatAnswer struct {
+	Threat        string   `json:"threat"`
+	Answers       []string `json:"answers"`
+	Effectiveness float64  `json:"effectiveness"` // 0.0 to 1.0
+	Source        string   `json:"source"`        // "counter_matrix" or "heuristic"
+}
+
+// SpellAnswer describes how a deck's spells answer a spell-vulnerable card.
+type SpellAnswer struct {
+	Target   string   `json:"target"`
+	Spells   []string `json:"spells"`
+	Answered bool     `json:"answered"`
+}
+
+// SideReport holds the matchup view from one deck's perspective.
+type SideReport struct {
+	Deck              []string       `json:"deck"`
+	Archetype         string         `json:"archetype"`
+	OverallScore      float64        `json:"overall_score"`
+	KeyThreats        []string       `json:"key_threats"`
+	ThreatAnswers     []ThreatAnswer `json:"threat_answers"`
+	UnansweredThreats []string       `json:"unanswered_threats"`
+	CounterCoverage   float64        `json:"counter_coverage"` // 0.0 to 1.0
+	SpellAnswers      []SpellAnswer  `json:"spell_answers"`
+	SpellCoverage     float64        `json:"spell_coverage"` // 0.0 to 1.0
+	// LevelInteractions are this deck's spells whose kill outcome against an
+	// opponent card differs from what it would be at even levels
+	LevelInteractions []interactions.Check `json:"level_interactions,omitempty"`
+}
+
+// Result is the full deck-vs-deck matchup analysis.
+type Result struct {
+	DeckA           SideReport `json:"deck_a"`
+	DeckB           SideReport `json:"deck_b"`
+	WinProbabilityA float64    `json:"win_probability_a"`
+	WinProbabilityB float64    `json:"win_probability_b"`
+	Favored         Favored    `json:"favored"`
+	Summary         string     `json:"summary"`
+	// ModelWinProbabilityA is deck A's chance from a win model trained on
+	// recorded battles; Analyze leaves it unset for callers to fill in
+	ModelWinProbabilityA *float64 `json:"model_win_probability_a,omitempty"`
+}
+
+// Analyzer scores deck matchups using a counter matrix and synergy database.
+type Analyzer struct {
+	matrix       *deck.CounterMatrix
+	synergyDB    *deck.SynergyDatabase
+	interactions *interactions.Calculator
+}
+
+// NewAnalyzer creates a matchup analyzer. Nil dependencies fall back to the
+// built-in defaults.
+func NewAnalyzer(matrix *deck.CounterMatrix, synergyDB *deck.SynergyDatabase) *Analyzer {
+	if matrix == nil {
+		matrix = deck.NewCounterMatrixWithDefaults()
+	}
+	if synergyDB == nil {
+		synergyDB = deck.NewSynergyDatabase()
+	}
+	return &Analyzer{matrix: matrix, synergyDB: synergyDB, interactions: interactions.NewCalculator(nil)}
+}
+
+// Analyze scores deckA against deckB.
+func (a *Analyzer) Analyze(deckA, deckB []deck.CardCandidate) (*Result, error) {
+	if len(deckA) == 0 || len(deckB) == 0 {
+		return nil, fmt.Errorf("both decks must contain cards")
+	}
+
+	evalA := evaluation.Evaluate(deckA, a.synergyDB, nil)
+	evalB := evaluation.Evaluate(deckB, a.synergyDB, nil)
+
+	sideA := a.buildSide(deckA, deckB, evalA)
+	sideB := a.buildSide(deckB, deckA, evalB)
+
+	logit := counterWeight*(sideA.CounterCoverage-sideB.CounterCoverage) +
+		spellWeight*(sideA.SpellCoverage-sideB.SpellCoverage) +
+		scoreWeight*(sideA.OverallScore-sideB.OverallScore)
+	probA := clamp(1.0/(1.0+math.Exp(-logit)), minWinProbability, maxWinProbability)
+	probA = math.Round(probA*1000) / 1000
+
+	result := &Result{
+		DeckA:           sideA,
+		DeckB:           sideB,
+		WinProbabilityA: probA,
+		WinProbabilityB: math.Round((1-probA)*1000) / 1000,
+		Favored:         favoredFor(probA),
+	}
+	result.Summary = summarize(result)
+
+	return result, nil
+}
+
+// buildSide computes the report for `own` playing against `opponent`.
+func (a *Analyzer) buildSide(own, opponent []deck.CardCandidate, eval evaluation.EvaluationResult) SideReport {
+	ownNames := cardNames(own)
+
+	side := SideReport{
+		Deck:              ownNames,
+		Archetype:         eval.DetectedArchetype.String(),
+		OverallScore:      eval.OverallScore,
+		KeyThreats:        a.keyThreats(opponent),
+		ThreatAnswers:     make([]ThreatAnswer, 0),
+		UnansweredThreats: make([]string, 0),
+		SpellAnswers:      make([]SpellAnswer, 0),
+	}
+
+	totalEffectiveness := 0.0
+	for _, threat := range side.KeyThreats {
+		answer := a.answerThreat(own, threat)
+		if len(answer.Answers) == 0 {
+			side.UnansweredThreats = append(side.UnansweredThreats, threat)
+			continue
+		}
+		side.ThreatAnswers = append(side.ThreatAnswers, answer)
+		totalEffectiveness += answer.Effectiveness
+	}
+	if len(side.KeyThreats) > 0 {
+		side.CounterCoverage = totalEffectiveness / float64(len(side.KeyThreats))
+	} else {
+		side.CounterCoverage = 1.0
+	}
+
+	answered := 0
+	for _, target := range cardNames(opponent) {
+		profile, ok := spellTargets[target]
+		if !ok {
+			continue
+		}
+		spells := answeringSpells(ownNames, profile)
+		side.SpellAnswers = append(side.SpellAnswers, SpellAnswer{
+			Target:   target,
+			Spells:   spells,
+			Answered: len(spells) > 0,
+		})
+		if len(spells) > 0 {
+			answered++
+		}
+	}
+	if len(side.SpellAnswers) > 0 {
+		side.SpellCoverage = float64(answered) / float64(len(side.SpellAnswers))
+	} else {
+		side.SpellCoverage = 1.0
+	}
+
+	side.LevelInteractions = a.levelInteractions(own, opponent)
+	return side
+}
+
+// levelInteractions checks own's spells against every opponent card and
+// keeps the outcomes that the level gap changes.
+func (a *Analyzer) levelInteractions(own, opponent []deck.CardCandidate) []interactions.Check {
+	var checks []interactions.Check
+	for _, spell := range own {
+		if !isSpell(spell) || !a.interactions.CanDamage(spell.Name) {
+			continue
+		}
+		for _, target := range opponent {
+			if isSpell(target) || !a.interactions.IsTarget(target.Name) {
+				continue
+			}
+			check, err := a.interactions.Check(
+				interactions.Card{Name: spell.Name, Level: spell.StandardLevel()},
+				interactions.Card{Name: target.Name, Level: target.StandardLevel()},
+			)
+			if err == nil && check.LevelSensitive() {
+				checks = append(checks, check)
+			}
+		}
+	}
+	return checks
+}
+
+// keyThreats returns the opponent cards that must be answered: win conditions
+// plus any card with explicit counter data.
+func (a *Analyzer) keyThreats(opponent []deck.CardCandidate) []string {
+	threats := make([]string, 0, len(opponent))
+	for _, card := range opponent {
+		isWinCon := card.Role != nil && *card.Role == deck.RoleWinCondition
+		if isWinCon || a.matrix.GetCountersForThreat(card.Name) != nil {
+			threats = append(threats, card.Name)
+		}
+	}
+	sort.Strings(threats)
+	return threats
+}
+
+// answerThreat finds the cards in own that answer a threat.
+func (a *Analyzer) answerThreat(own []deck.CardCandidate, threat string) ThreatAnswer {
+	ownNames := cardNames(own)
+	if a.matrix.GetCountersForThreat(threat) != nil {
+		coverage := a.matrix.AnalyzeThreatCoverage(ownNames, threat)
+		answers := make([]string, 0, len(coverage.DeckCounters))
+		for _, counter := range coverage.DeckCounters {
+			answers = append(answers, counter.Card)
+		}
+		if len(answers) > 0 {
+			return ThreatAnswer{
+				Threat:        threat,
+				Answers:       answers,
+				Effectiveness: coverage.Effectiveness,
+				Source:        "counter_matrix",
+			}
+		}
+	}
+
+	// Heuristic fallback: air threats need air targeting, ground threats need
+	// a tank killer or a building to pull them.
+	answers := make([]string, 0)
+	for _, card := range own {
+		if airThreats[threat] {
+			if canTargetAir(card) && !isSpell(card) {
+				answers = append(answers, card.Name)
+			}
+			continue
+		}
+		if a.matrix.HasCapability(card.Name, deck.CounterTankKillers) ||
+			a.matrix.HasCapability(card.Name, deck.CounterBuildings) ||
+			(card.Role != nil && *card.Role == deck.RoleBuilding) {
+			answers = append(answers, card.Name)
+		}
+	}
+
+	answer := ThreatAnswer{Threat: threat, Answers: answers, Source: "heuristic"}
+	if len(answers) > 0 {
+		answer.Effectiveness = fallbackEffectiveness
+	}
+	return answer
+}
+
+// spellProfile describes what a spell can hit.
+type spellProfile struct {
+	hitsAir bool
+	big     bool
+}
+
+// spellTarget describes what it takes for a spell to answer a card.
+type spellTarget struct {
+	air      bool
+	needsBig bool
+}
+
+var spells = map[string]spellProfile{
+	"The Log":          {hitsAir: false},
+	"Barbarian Barrel": {hitsAir: false},
+	"Earthquake":       {hitsAir: false},
+	"Royal Delivery":   {hitsAir: false},
+	"Zap":              {hitsAir: true},
+	"Arrows":           {hitsAir: true},
+	"Giant Snowball":   {hitsAir: true},
+	"Tornado":          {hitsAir: true},
+	"Goblin Curse":     {hitsAir: true},
+	"Fireball":         {hitsAir: true, big: true},
+	"Poison":           {hitsAir: true, big: true},
+	"Void":             {hitsAir: true, big: true},
+	"Rocket":           {hitsAir: true, big: true},
+	"Lightning":        {hitsAir: true, big: true},
+}
+
+var spellTargets = map[string]spellTarget{
+	"Goblin Barrel":    {},
+	"Skeleton Army":    {},
+	"Goblin Gang":      {},
+	"Goblins":          {},
+	"Spear Goblins":    {},
+	"Princess":         {},
+	"Dart Goblin":      {},
+	"Skeleton Barrel":  {},
+	"Rascals":          {},
+	"Firecracker":      {},
+	"Barbarians":       {},
+	"Guards":           {},
+	"Wall Breakers":    {},
+	"Royal Recruits":   {},
+	"Minion Horde":     {air: true},
+	"Minions":          {air: true},
+	"Bats":             {air: true},
+	"Skeleton Dragons": {air: true},
+	"Three Musketeers": {needsBig: true},
+	"Wizard":           {needsBig: true},
+	"Witch":            {needsBig: true},
+	"Elixir Collector": {needsBig: true},
+	"Flying Machine":   {air: true, needsBig: true},
+	"Night Witch":      {needsBig: true},
+}
+
+var airThreats = map[string]bool{
+	"Balloon":          true,
+	"Lava Hound":       true,
+	"Minion Horde":     true,
+	"Electro Dragon":   true,
+	"Baby Dragon":      true,
+	"Inferno Dragon":   true,
+	"Skeleton Dragons": true,
+	"Phoenix":          true,
+	"Flying Machine":   true,
+}
+
+func answeringSpells(deckNames []string, target spellTarget) []string {
+	answering := make([]string, 0)
+	for _, name := range deckNames {
+		profile, ok := spells[name]
+		if !ok {
+			continue
+		}
+		if target.air && !profile.hitsAir {
+			continue
+		}
+		if target.needsBig && !profile.big {
+			continue
+		}
+		answering = append(answering, name)
+	}
+	return answering
+}
+
+func isSpell(card deck.CardCandidate) bool {
+	if _, ok := spells[card.Name]; ok {
+		return true
+	}
+	return card.Role != nil && (*card.Role == deck.RoleSpellBig || *card.Role == deck.RoleSpellSmall)
+}
+
+func canTargetAir(card deck.CardCandidate) bool {
+	return card.Stats != nil &&
+		(card.Stats.Targets == "Air" || card.Stats.Targets == "Air & Ground")
+}
+
+func cardNames(cards []deck.CardCandidate) []string {
+	names := make([]string, 0, len(cards))
+	for _, card := range cards {
+		names = append(names, card.Name)
+	}
+	return names
+}
+
+func favoredFor(probA float64) Favored {
+	switch {
+	case probA >= 0.5+evenThreshold:
+		return FavoredDeckA
+	case probA <= 0.5-evenThreshold:
+		return FavoredDeckB
+	default:
+		return FavoredEven
+	}
+}
+
+func summarize(r *Result) string {
+	var lead string
+	switch r.Favored {
+	case FavoredDeckA:
+		lead = fmt.Sprintf("Deck A is favored (%.0f%% predicted win rate)", r.WinProbabilityA*100)
+	case FavoredDeckB:
+		lead = fmt.Sprintf("Deck B is favored (%.0f%% predicted win rate)", r.WinProbabilityB*100)
+	default:
+		lead = fmt.Sprintf("Even matchup (%.0f%% / %.0f%%)", r.WinProbabilityA*100, r.WinProbabilityB*100)
+	}
+
+	if len(r.DeckA.UnansweredThreats) > 0 {
+		return fmt.Sprintf("%s; deck A has no answer to %v", lead, r.DeckA.UnansweredThreats)
+	}
+	if len(r.DeckB.UnansweredThreats) > 0 {
+		return fmt.Sprintf("%s; deck B has no answer to %v", lead, r.DeckB.UnansweredThreats)
+	}
+	return lead
+}
+
+func clamp(value, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, value))
+}
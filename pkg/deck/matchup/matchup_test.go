@@ -0,0 +1,175 @@
+package matchup
+
+import (
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+func makeDeck(names ...string) []deck.CardCandidate {
+	cards := make([]deck.CardCandidate, 0, len(names))
+	for _, name := range names {
+		role := config.GetCardRole(name)
+		if role == "" {
+			role = deck.RoleSupport
+		}
+		cards = append(cards, deck.CardCandidate{
+			Name:     name,
+			Level:    11,
+			MaxLevel: 15,
+			Elixir:   config.GetCardElixir(name, 4),
+			Role:     &role,
+			Stats:    &clashroyale.CombatStats{Targets: "Air & Ground", DamagePerSecond: 150, Hitpoints: 1000},
+		})
+	}
+	return cards
+}
+
+func TestAnalyzeSymmetricProbabilities(t *testing.T) {
+	hogCycle := makeDeck("Hog Rider", "Musketeer", "Cannon", "Ice Spirit", "Skeletons", "The Log", "Fireball", "Ice Golem")
+	logBait := makeDeck("Goblin Barrel", "Princess", "Goblin Gang", "Knight", "Inferno Tower", "Rocket", "The Log", "Ice Spirit")
+
+	analyzer := NewAnalyzer(nil, nil)
+	result, err := analyzer.Analyze(hogCycle, logBait)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	total := result.WinProbabilityA + result.WinProbabilityB
+	if total < 0.999 || total > 1.001 {
+		t.Errorf("win probabilities should sum to 1, got %.3f", total)
+	}
+	if result.WinProbabilityA < minWinProbability || result.WinProbabilityA > maxWinProbability {
+		t.Errorf("win probability out of bounds: %.3f", result.WinProbabilityA)
+	}
+
+	reverse, err := analyzer.Analyze(logBait, hogCycle)
+	if err != nil {
+		t.Fatalf("Analyze() reverse error = %v", err)
+	}
+	if diff := reverse.WinProbabilityA - result.WinProbabilityB; diff > 0.001 || diff < -0.001 {
+		t.Errorf("reverse matchup should mirror probabilities: %.3f vs %.3f", reverse.WinProbabilityA, result.WinProbabilityB)
+	}
+}
+
+func TestAnalyzeCounterCoverage(t *testing.T) {
+	hogDeck := makeDeck("Hog Rider", "Musketeer", "Valkyrie", "Ice Spirit", "Skeletons", "The Log", "Fireball", "Ice Golem")
+	answersHog := makeDeck("Tornado", "Cannon", "Tesla", "Executioner", "Knight", "Archers", "Zap", "Golem")
+	noAnswers := makeDeck("Golem", "Night Witch", "Baby Dragon", "Lumberjack", "Mega Minion", "Zap", "Lightning", "Barbarian Barrel")
+
+	analyzer := NewAnalyzer(nil, nil)
+
+	good, err := analyzer.Analyze(answersHog, hogDeck)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	bad, err := analyzer.Analyze(noAnswers, hogDeck)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	if good.DeckA.CounterCoverage <= bad.DeckA.CounterCoverage {
+		t.Errorf("deck with Hog answers should have higher coverage: %.2f <= %.2f",
+			good.DeckA.CounterCoverage, bad.DeckA.CounterCoverage)
+	}
+
+	found := false
+	for _, answer := range good.DeckA.ThreatAnswers {
+		if answer.Threat == "Hog Rider" && answer.Source == "counter_matrix" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected counter matrix answer for Hog Rider, got %+v", good.DeckA.ThreatAnswers)
+	}
+}
+
+func TestAnalyzeSpellAnswers(t *testing.T) {
+	bait := makeDeck("Goblin Barrel", "Princess", "Minion Horde", "Knight", "Inferno Tower", "Rocket", "Goblin Gang", "Ice Spirit")
+	logOnly := makeDeck("Hog Rider", "Musketeer", "Cannon", "Ice Spirit", "Skeletons", "The Log", "Earthquake", "Ice Golem")
+	arrows := makeDeck("Hog Rider", "Musketeer", "Cannon", "Ice Spirit", "Skeletons", "Arrows", "Fireball", "Ice Golem")
+
+	analyzer := NewAnalyzer(nil, nil)
+	logResult, err := analyzer.Analyze(logOnly, bait)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	arrowsResult, err := analyzer.Analyze(arrows, bait)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	for _, answer := range logResult.DeckA.SpellAnswers {
+		if answer.Target == "Minion Horde" && answer.Answered {
+			t.Errorf("ground-only spells should not answer Minion Horde: %+v", answer)
+		}
+	}
+	if arrowsResult.DeckA.SpellCoverage <= logResult.DeckA.SpellCoverage {
+		t.Errorf("air-hitting spells should improve coverage: %.2f <= %.2f",
+			arrowsResult.DeckA.SpellCoverage, logResult.DeckA.SpellCoverage)
+	}
+}
+
+func TestAnalyzeLevelInteractions(t *testing.T) {
+	atLevel := func(cards []deck.CardCandidate, level int) []deck.CardCandidate {
+		for i := range cards {
+			cards[i].Level, cards[i].MaxLevel = level, 16
+		}
+		return cards
+	}
+	fireball := makeDeck("Hog Rider", "Musketeer", "Cannon", "Ice Spirit", "Skeletons", "The Log", "Fireball", "Ice Golem")
+	wizard := makeDeck("Golem", "Night Witch", "Wizard", "Baby Dragon", "Lightning", "Zap", "Lumberjack", "Tornado")
+
+	analyzer := NewAnalyzer(nil, nil)
+	even, err := analyzer.Analyze(atLevel(fireball, 11), atLevel(wizard, 11))
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if len(even.DeckA.LevelInteractions) != 0 || len(even.DeckB.LevelInteractions) != 0 {
+		t.Errorf("even levels reported level interactions: %+v %+v", even.DeckA.LevelInteractions, even.DeckB.LevelInteractions)
+	}
+
+	gap, err := analyzer.Analyze(atLevel(fireball, 11), atLevel(wizard, 13))
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	found := false
+	for _, check := range gap.DeckA.LevelInteractions {
+		if check.Attacker.Name == "Fireball" && check.Target.Name == "Wizard" {
+			found = !check.Kills && check.KillsAtEvenLevels
+		}
+	}
+	if !found {
+		t.Errorf("expected Fireball to lose the Wizard one-shot: %+v", gap.DeckA.LevelInteractions)
+	}
+	for _, check := range gap.DeckB.LevelInteractions {
+		if !check.Kills {
+			t.Errorf("the over-leveled deck should only gain kills: %s", check.Note())
+		}
+	}
+}
+
+func TestAnalyzeRejectsEmptyDeck(t *testing.T) {
+	analyzer := NewAnalyzer(nil, nil)
+	if _, err := analyzer.Analyze(nil, makeDeck("Knight")); err == nil {
+		t.Error("expected error for empty deck")
+	}
+}
+
+func TestFavoredFor(t *testing.T) {
+	tests := []struct {
+		prob float64
+		want Favored
+	}{
+		{0.7, FavoredDeckA},
+		{0.3, FavoredDeckB},
+		{0.52, FavoredEven},
+	}
+	for _, tt := range tests {
+		if got := favoredFor(tt.prob); got != tt.want {
+			t.Errorf("favoredFor(%.2f) = %s, want %s", tt.prob, got, tt.want)
+		}
+	}
+}
@@ -23,6 +23,8 @@ type SoftWeights struct {
 type ConstraintConfig struct {
 	Hard HardConstraints `json:"hard"`
 	Soft SoftWeights     `json:"soft"`
+	// Rules are extra count rules, usually parsed with ParseConstraints.
+	Rules []CountRule `json:"rules,omitempty"`
 }
 
 func defaultHardConstraints() HardConstraints {
@@ -92,6 +94,11 @@ func (c ConstraintConfig) Validate() error {
 	if sum <= 0 {
 		return fmt.Errorf("soft weights must sum to > 0")
 	}
+	for _, rule := range c.Rules {
+		if err := rule.validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,363 @@
+package research
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+// CardCategory names the group of cards a count rule applies to.
+type CardCategory string
+
+// Supported card categories.
+const (
+	CategoryCard         CardCategory = "card"
+	CategoryWinCondition CardCategory = "win_condition"
+	CategoryBuilding     CardCategory = "building"
+	CategorySpell        CardCategory = "spell"
+	CategoryBigSpell     CardCategory = "spell_big"
+	CategorySmallSpell   CardCategory = "spell_small"
+	CategorySupport      CardCategory = "support"
+	CategoryCycle        CardCategory = "cycle"
+	CategoryAirDefense   CardCategory = "air_defense"
+	CategoryTankKiller   CardCategory = "tank_killer"
+	CategorySplash       CardCategory = "splash"
+	CategoryChampion     CardCategory = "champion"
+	CategoryLegendary    CardCategory = "legendary"
+	CategoryEpic         CardCategory = "epic"
+	CategoryRare         CardCategory = "rare"
+	CategoryCommon       CardCategory = "common"
+	CategoryEvolution    CardCategory = "evolution"
+)
+
+// AllCardCategories lists every supported category.
+var AllCardCategories = []CardCategory{
+	CategoryCard, CategoryWinCondition, CategoryBuilding, CategorySpell, CategoryBigSpell,
+	CategorySmallSpell, CategorySupport, CategoryCycle, CategoryAirDefense, CategoryTankKiller,
+	CategorySplash, CategoryChampion, CategoryLegendary, CategoryEpic, CategoryRare,
+	CategoryCommon, CategoryEvolution,
+}
+
+// categoryAliases maps the singular words a rule may use to a category.
+// Category names themselves are accepted as well.
+var categoryAliases = map[string]CardCategory{
+	"":              CategoryCard,
+	"win condition": CategoryWinCondition,
+	"win con":       CategoryWinCondition,
+	"wincon":        CategoryWinCondition,
+	"big spell":     CategoryBigSpell,
+	"small spell":   CategorySmallSpell,
+	"air defense":   CategoryAirDefense,
+	"anti-air":      CategoryAirDefense,
+	"anti air":      CategoryAirDefense,
+	"tank killer":   CategoryTankKiller,
+	"evo":           CategoryEvolution,
+	"evolved":       CategoryEvolution,
+}
+
+func (c CardCategory) valid() bool {
+	for _, known := range AllCardCategories {
+		if c == known {
+			return true
+		}
+	}
+	return false
+}
+
+var numberWords = map[string]int{
+	"zero": 0, "one": 1, "two": 2, "three": 3, "four": 4,
+	"five": 5, "six": 6, "seven": 7, "eight": 8,
+}
+
+var (
+	ruleSeparator = regexp.MustCompile(`[;,\n]|\band\b`)
+	// elixirPattern matches "<=2 elixir", "cost >= 5", "costing 3" and "4 elixir"
+	elixirPattern = regexp.MustCompile(`(?:costing|cost|that cost)?\s*(<=|>=|<|>|=)\s*(\d+)(?:\s*elixir)?|(?:costing|cost|that cost)\s+(\d+)(?:\s*elixir)?|(\d+)\s*elixir`)
+)
+
+// CardFilter selects cards by category and elixir cost. A zero elixir bound
+// is unbounded.
+type CardFilter struct {
+	Category  CardCategory `json:"category"`
+	MinElixir int          `json:"min_elixir,omitempty"`
+	MaxElixir int          `json:"max_elixir,omitempty"`
+}
+
+// Matches reports whether a card passes the filter.
+func (f CardFilter) Matches(c deck.CardCandidate) bool {
+	if f.MinElixir > 0 && c.Elixir < f.MinElixir {
+		return false
+	}
+	if f.MaxElixir > 0 && c.Elixir > f.MaxElixir {
+		return false
+	}
+	return categoryMatches(f.Category, c)
+}
+
+func categoryMatches(category CardCategory, c deck.CardCandidate) bool {
+	hasRole := func(roles ...deck.CardRole) bool {
+		if c.Role == nil {
+			return false
+		}
+		for _, role := range roles {
+			if *c.Role == role {
+				return true
+			}
+		}
+		return false
+	}
+	switch category {
+	case CategoryCard:
+		return true
+	case CategoryWinCondition:
+		return hasRole(deck.RoleWinCondition)
+	case CategoryBuilding:
+		return hasRole(deck.RoleBuilding)
+	case CategorySpell:
+		return hasRole(deck.RoleSpellBig, deck.RoleSpellSmall)
+	case CategoryBigSpell:
+		return hasRole(deck.RoleSpellBig)
+	case CategorySmallSpell:
+		return hasRole(deck.RoleSpellSmall)
+	case CategorySupport:
+		return hasRole(deck.RoleSupport)
+	case CategoryCycle:
+		return hasRole(deck.RoleCycle)
+	case CategoryAirDefense:
+		return canTargetAir(c)
+	case CategoryTankKiller:
+		return isTankKiller(c)
+	case CategorySplash:
+		return isSplash(c)
+	case CategoryChampion, CategoryLegendary, CategoryEpic, CategoryRare, CategoryCommon:
+		return strings.EqualFold(c.Rarity, string(category))
+	case CategoryEvolution:
+		return c.EvolutionLevel > 0
+	}
+	return false
+}
+
+// CountRule bounds how many cards of a deck pass a filter. With, when set,
+// further bounds how many of those matching cards pass a second filter, as in
+// "exactly 2 spells with one <=2 elixir".
+type CountRule struct {
+	Text   string     `json:"text,omitempty"`
+	Filter CardFilter `json:"filter"`
+	Min    int        `json:"min"`
+	Max    int        `json:"max"`
+	With   *CountRule `json:"with,omitempty"`
+}
+
+// Check reports whether the deck satisfies the rule.
+func (r CountRule) Check(cards []deck.CardCandidate) bool {
+	matched := make([]deck.CardCandidate, 0, len(cards))
+	for _, c := range cards {
+		if r.Filter.Matches(c) {
+			matched = append(matched, c)
+		}
+	}
+	if len(matched) < r.Min || len(matched) > r.Max {
+		return false
+	}
+	if r.With != nil {
+		return r.With.Check(matched)
+	}
+	return true
+}
+
+func (r CountRule) validate() error {
+	if r.Min < 0 || r.Max > 8 || r.Min > r.Max {
+		return fmt.Errorf("rule %q: count bounds must satisfy 0 <= min <= max <= 8, got [%d,%d]", r.Text, r.Min, r.Max)
+	}
+	if !r.Filter.Category.valid() {
+		return fmt.Errorf("rule %q: unknown card category %q", r.Text, r.Filter.Category)
+	}
+	if r.Filter.MinElixir < 0 || r.Filter.MaxElixir < 0 ||
+		(r.Filter.MaxElixir > 0 && r.Filter.MinElixir > r.Filter.MaxElixir) {
+		return fmt.Errorf("rule %q: invalid elixir range [%d,%d]", r.Text, r.Filter.MinElixir, r.Filter.MaxElixir)
+	}
+	if r.With != nil {
+		if r.With.With != nil {
+			return fmt.Errorf("rule %q: only one with clause is supported", r.Text)
+		}
+		return r.With.validate()
+	}
+	return nil
+}
+
+// ParseConstraints parses rules such as "at least 1 building; exactly 2
+// spells with one <=2 elixir; no more than 1 champion" into the default
+// constraint config. Rules are separated by semicolons, commas, newlines or
+// "and". Each rule is a quantifier (at least, at most, no more than, no fewer
+// than, exactly, or no), a count, and a card category with an optional
+// elixir filter such as "<=2 elixir". A bare count means exactly in a rule
+// and at least in its with clause.
+func ParseConstraints(spec string) (ConstraintConfig, error) {
+	cfg := DefaultConstraintConfig()
+	rules, err := ParseCountRules(spec)
+	if err != nil {
+		return ConstraintConfig{}, err
+	}
+	cfg.Rules = rules
+	return cfg, nil
+}
+
+// ParseCountRules parses a constraint spec into count rules. An empty spec
+// yields nil.
+func ParseCountRules(spec string) ([]CountRule, error) {
+	var rules []CountRule
+	for _, part := range ruleSeparator.Split(strings.ToLower(spec), -1) {
+		text := strings.Join(strings.Fields(part), " ")
+		if text == "" {
+			continue
+		}
+		head, tail, hasWith := strings.Cut(text, " with ")
+		rule, err := parseCountRule(head, false)
+		if err != nil {
+			return nil, fmt.Errorf("constraint %q: %w", text, err)
+		}
+		if hasWith {
+			with, err := parseCountRule(tail, true)
+			if err != nil {
+				return nil, fmt.Errorf("constraint %q: %w", text, err)
+			}
+			rule.With = &with
+		}
+		rule.Text = text
+		if err := rule.validate(); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// parseCountRule parses one quantified filter. Inside a with clause a bare
+// count means at least.
+func parseCountRule(text string, inWith bool) (CountRule, error) {
+	text = strings.TrimSpace(text)
+	var rule CountRule
+	quantifier := "exactly"
+	if inWith {
+		quantifier = "at least"
+	}
+	for _, q := range []string{"no more than", "no fewer than", "no less than", "at least", "at most", "exactly", "only", "no"} {
+		if text == q || strings.HasPrefix(text, q+" ") {
+			quantifier = q
+			text = strings.TrimSpace(strings.TrimPrefix(text, q))
+			break
+		}
+	}
+
+	count := 0
+	if quantifier != "no" {
+		word, rest, _ := strings.Cut(text, " ")
+		n, ok := numberWords[word]
+		if !ok {
+			parsed, err := strconv.Atoi(word)
+			if err != nil {
+				return CountRule{}, fmt.Errorf("expected a count, got %q", word)
+			}
+			n = parsed
+		}
+		count = n
+		text = rest
+	}
+
+	switch quantifier {
+	case "at least", "no fewer than", "no less than":
+		rule.Min, rule.Max = count, 8
+	case "at most", "no more than":
+		rule.Min, rule.Max = 0, count
+	case "no":
+		rule.Min, rule.Max = 0, 0
+	default:
+		rule.Min, rule.Max = count, count
+	}
+
+	filter, err := parseCardFilter(text)
+	if err != nil {
+		return CountRule{}, err
+	}
+	rule.Filter = filter
+	return rule, nil
+}
+
+// parseCardFilter parses a category with an optional elixir filter, such as
+// "spells", "cards >=5 elixir" or "<=2 elixir".
+func parseCardFilter(text string) (CardFilter, error) {
+	var filter CardFilter
+	if m := elixirPattern.FindStringSubmatchIndex(text); m != nil {
+		sub := func(i int) string {
+			if m[2*i] < 0 {
+				return ""
+			}
+			return text[m[2*i]:m[2*i+1]]
+		}
+		op, value := sub(1), sub(2)
+		if value == "" {
+			op, value = "=", sub(3)
+		}
+		if value == "" {
+			op, value = "=", sub(4)
+		}
+		elixir, err := strconv.Atoi(value)
+		if err != nil {
+			return CardFilter{}, fmt.Errorf("invalid elixir cost %q", value)
+		}
+		switch op {
+		case "<=":
+			filter.MaxElixir = elixir
+		case "<":
+			filter.MaxElixir = elixir - 1
+		case ">=":
+			filter.MinElixir = elixir
+		case ">":
+			filter.MinElixir = elixir + 1
+		default:
+			filter.MinElixir, filter.MaxElixir = elixir, elixir
+		}
+		// A zero bound would read as unbounded
+		if (op == "<" || op == "<=" || op == "=") && filter.MaxElixir < 1 {
+			return CardFilter{}, fmt.Errorf("elixir bound %s%d matches no cards", op, elixir)
+		}
+		text = text[:m[0]] + " " + text[m[1]:]
+	}
+
+	name := singular(strings.Join(strings.Fields(text), " "))
+	name = strings.TrimSuffix(strings.TrimSuffix(name, "card"), " ")
+	category, ok := categoryAliases[name]
+	if !ok {
+		category = CardCategory(strings.NewReplacer(" ", "_", "-", "_").Replace(name))
+	}
+	if !category.valid() {
+		return CardFilter{}, fmt.Errorf("unknown card category %q", name)
+	}
+	filter.Category = category
+	return filter, nil
+}
+
+// singular drops the plural ending of a category name
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss"):
+		return strings.TrimSuffix(name, "s")
+	}
+	return name
+}
+
+// RuleViolations lists the count rules the deck breaks.
+func (c ConstraintConfig) RuleViolations(cards []deck.CardCandidate) []string {
+	var violations []string
+	for _, rule := range c.Rules {
+		if !rule.Check(cards) {
+			violations = append(violations, fmt.Sprintf("must satisfy %q", rule.Text))
+		}
+	}
+	return violations
+}
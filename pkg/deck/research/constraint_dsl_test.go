@@ -0,0 +1,72 @@
+package research
+
+import (
+	"testing"
+)
+
+func TestParseCountRules(t *testing.T) {
+	rules, err := ParseCountRules("at least 1 building; exactly 2 spells with one <=2 elixir, no more than 1 champion and no cards >=6 elixir")
+	if err != nil {
+		t.Fatalf("ParseCountRules() error = %v", err)
+	}
+	want := []CountRule{
+		{Filter: CardFilter{Category: CategoryBuilding}, Min: 1, Max: 8},
+		{Filter: CardFilter{Category: CategorySpell}, Min: 2, Max: 2, With: &CountRule{Filter: CardFilter{Category: CategoryCard, MaxElixir: 2}, Min: 1, Max: 8}},
+		{Filter: CardFilter{Category: CategoryChampion}, Min: 0, Max: 1},
+		{Filter: CardFilter{Category: CategoryCard, MinElixir: 6}, Min: 0, Max: 0},
+	}
+	if len(rules) != len(want) {
+		t.Fatalf("got %d rules, want %d: %+v", len(rules), len(want), rules)
+	}
+	for i, rule := range rules {
+		if rule.Filter != want[i].Filter || rule.Min != want[i].Min || rule.Max != want[i].Max {
+			t.Errorf("rule %d = %+v, want %+v", i, rule, want[i])
+		}
+		if (rule.With == nil) != (want[i].With == nil) {
+			t.Fatalf("rule %d with clause = %+v, want %+v", i, rule.With, want[i].With)
+		}
+		if rule.With != nil && (rule.With.Filter != want[i].With.Filter || rule.With.Min != want[i].With.Min || rule.With.Max != want[i].With.Max) {
+			t.Errorf("rule %d with clause = %+v, want %+v", i, *rule.With, *want[i].With)
+		}
+	}
+	if rules[1].Text != "exactly 2 spells with one <=2 elixir" {
+		t.Errorf("rule text = %q", rules[1].Text)
+	}
+}
+
+func TestParseCountRulesRejectsInvalidRules(t *testing.T) {
+	for _, spec := range []string{
+		"at least 1 dragon",
+		"at least many spells",
+		"at least 9 cards",
+		"exactly 2 spells with one <1 elixir",
+	} {
+		if _, err := ParseCountRules(spec); err == nil {
+			t.Errorf("ParseCountRules(%q) expected an error", spec)
+		}
+	}
+	if rules, err := ParseCountRules("  "); err != nil || rules != nil {
+		t.Errorf("ParseCountRules(blank) = %v, %v; want nil, nil", rules, err)
+	}
+}
+
+func TestParseConstraintsChecksDecks(t *testing.T) {
+	cfg, err := ParseConstraints("at least 1 building; exactly 2 spells with one <=2 elixir; no champions")
+	if err != nil {
+		t.Fatalf("ParseConstraints() error = %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if report := ValidateConstraints(testDeck(), cfg); !report.IsValid() {
+		t.Fatalf("expected valid deck, got: %v", report.Violations)
+	}
+
+	cfg, err = ParseConstraints("at most 1 cycle card, at least 3 wincons")
+	if err != nil {
+		t.Fatalf("ParseConstraints() error = %v", err)
+	}
+	if got := cfg.RuleViolations(testDeck()); len(got) != 2 {
+		t.Errorf("RuleViolations() = %v, want 2 violations", got)
+	}
+}
@@ -15,7 +15,8 @@ func (r ConstraintReport) IsValid() bool {
 	return len(r.Violations) == 0
 }
 
-// ValidateConstraints enforces the phase-1 hard constraints.
+// ValidateConstraints enforces the phase-1 hard constraints and any count
+// rules.
 //
 //nolint:gocyclo // Explicit rule checks keep constraints auditable.
 func ValidateConstraints(cards []deck.CardCandidate, cfg ConstraintConfig) ConstraintReport {
@@ -64,6 +65,7 @@ func ValidateConstraints(cards []deck.CardCandidate, cfg ConstraintConfig) Const
 	if tankKillers < hard.MinTankKillers {
 		violations = append(violations, fmt.Sprintf("must include at least %d tank-killer(s)", hard.MinTankKillers))
 	}
+	violations = append(violations, cfg.RuleViolations(cards)...)
 
 	return ConstraintReport{Violations: violations}
 }
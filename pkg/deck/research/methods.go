@@ -373,6 +373,7 @@ func methodByName(name string, builder *deck.Builder) (Method, error) {
 }
 
 // BuildCandidatesFromPlayer converts a player collection to scored candidates.
+// Combat stats come from stats, or from the bundled dataset when it is nil.
 func BuildCandidatesFromPlayer(player *clashroyale.Player, stats *clashroyale.CardStatsRegistry) []deck.CardCandidate {
 	out := make([]deck.CardCandidate, 0, len(player.Cards))
 	for _, card := range player.Cards {
@@ -388,9 +389,7 @@ func BuildCandidatesFromPlayer(player *clashroyale.Player, stats *clashroyale.Ca
 			EvolutionLevel:    card.EvolutionLevel,
 			MaxEvolutionLevel: card.MaxEvolutionLevel,
 		}
-		if stats != nil {
-			candidate.Stats = stats.GetStats(name)
-		}
+		candidate.Stats = deck.CombatStatsFor(stats, name, candidate.StandardLevel())
 		out = append(out, candidate)
 	}
 	return out
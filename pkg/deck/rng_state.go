@@ -0,0 +1,54 @@
+package deck
+
+import (
+	"math/rand"
+	"sync/atomic"
+)
+
+// RNGPosition is how far a random stream has advanced: the seed it started
+// from and the number of values drawn since. It is enough to rebuild the
+// stream exactly, which math/rand cannot serialize on its own.
+type RNGPosition struct {
+	Seed  int64  `json:"seed"`
+	Draws uint64 `json:"draws"`
+}
+
+// countingSource is a rand.Source64 that counts the values drawn from it so
+// its position can be saved and restored.
+type countingSource struct {
+	src   rand.Source64
+	seed  int64
+	draws atomic.Uint64
+}
+
+// newCountingSource returns the stream for seed advanced by draws values.
+func newCountingSource(seed int64, draws uint64) *countingSource {
+	s := &countingSource{src: rand.NewSource(seed).(rand.Source64), seed: seed}
+	for range draws {
+		s.src.Uint64()
+	}
+	s.draws.Store(draws)
+	return s
+}
+
+func (s *countingSource) Int63() int64 {
+	s.draws.Add(1)
+	return s.src.Int63()
+}
+
+func (s *countingSource) Uint64() uint64 {
+	s.draws.Add(1)
+	return s.src.Uint64()
+}
+
+func (s *countingSource) Seed(seed int64) {
+	s.src.Seed(seed)
+	s.seed = seed
+	s.draws.Store(0)
+}
+
+// Position returns the seed and draws so far. It is safe to call while
+// another goroutine draws from the source.
+func (s *countingSource) Position() RNGPosition {
+	return RNGPosition{Seed: s.seed, Draws: s.draws.Load()}
+}
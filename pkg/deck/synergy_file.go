@@ -0,0 +1,308 @@
+// Package deck provides the on-disk synergy pair format.
+//
+// The built-in synergy pairs live in synergy_pairs.json, embedded at build
+// time. Users can add or change pairs with an overrides file in the same
+// format; NewSynergyDatabase re-reads it whenever it changes on disk, so a
+// long-running `serve` picks up edits without a restart.
+package deck
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+//go:embed synergy_pairs.json
+var defaultSynergyJSON []byte
+
+// SynergyOverridesFile is the default name of the user synergy overrides file
+// in the data directory.
+const SynergyOverridesFile = "synergy_overrides.json"
+
+// SynergyCategories lists every known synergy category.
+var SynergyCategories = []SynergyCategory{
+	SynergyTankSupport,
+	SynergyBait,
+	SynergySpellCombo,
+	SynergyWinCondition,
+	SynergyDefensive,
+	SynergyCycle,
+	SynergyBridgeSpam,
+	SynergyLearned,
+}
+
+// SynergyFile is the JSON format of synergy_pairs.json and of user overrides
+type SynergyFile struct {
+	Version     int           `json:"version"`
+	Description string        `json:"description,omitempty"`
+	LastUpdated string        `json:"last_updated,omitempty"`
+	Pairs       []SynergyPair `json:"pairs"`
+	// Remove drops built-in pairs (overrides only)
+	Remove []SynergyPairRef `json:"remove,omitempty"`
+}
+
+// SynergyPairRef names a pair of cards, in either order
+type SynergyPairRef struct {
+	Card1 string `json:"card1"`
+	Card2 string `json:"card2"`
+}
+
+// SynergyMergeStats counts what an overrides file changed
+type SynergyMergeStats struct {
+	Replaced int `json:"replaced"`
+	Added    int `json:"added"`
+	Removed  int `json:"removed"`
+}
+
+// ParseSynergyFile parses synergy pairs JSON
+func ParseSynergyFile(data []byte) (*SynergyFile, error) {
+	var file SynergyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse synergy file: %w", err)
+	}
+	return &file, nil
+}
+
+// ReadSynergyFile reads and parses a synergy pairs JSON file
+func ReadSynergyFile(path string) (*SynergyFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read synergy file: %w", err)
+	}
+	file, err := ParseSynergyFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return file, nil
+}
+
+var (
+	builtinSynergyOnce sync.Once
+	builtinSynergy     *SynergyFile
+)
+
+// DefaultSynergyFile returns a copy of the built-in synergy pairs file
+func DefaultSynergyFile() *SynergyFile {
+	builtinSynergyOnce.Do(func() {
+		file, err := ParseSynergyFile(defaultSynergyJSON)
+		if err != nil {
+			panic(fmt.Sprintf("embedded synergy_pairs.json is invalid: %v", err))
+		}
+		builtinSynergy = file
+	})
+	file := *builtinSynergy
+	file.Pairs = slices.Clone(builtinSynergy.Pairs)
+	return &file
+}
+
+// defaultSynergyPairs returns a copy of the built-in synergy pairs
+func defaultSynergyPairs() []SynergyPair {
+	return DefaultSynergyFile().Pairs
+}
+
+// synergyPairKey identifies a pair regardless of card order
+func synergyPairKey(card1, card2 string) [2]string {
+	if card2 < card1 {
+		card1, card2 = card2, card1
+	}
+	return [2]string{card1, card2}
+}
+
+// Apply returns base with the file's changes: pairs matching a base pair (in
+// either card order) replace it, other pairs are appended, and Remove entries
+// drop base pairs.
+func (f *SynergyFile) Apply(base []SynergyPair) ([]SynergyPair, SynergyMergeStats) {
+	var stats SynergyMergeStats
+	index := make(map[[2]string]int, len(base))
+	merged := slices.Clone(base)
+	for i, pair := range merged {
+		// Lookups use the first matching pair, so that is the one to replace
+		key := synergyPairKey(pair.Card1, pair.Card2)
+		if _, ok := index[key]; !ok {
+			index[key] = i
+		}
+	}
+
+	for _, pair := range f.Pairs {
+		key := synergyPairKey(pair.Card1, pair.Card2)
+		if i, ok := index[key]; ok {
+			merged[i] = pair
+			stats.Replaced++
+			continue
+		}
+		index[key] = len(merged)
+		merged = append(merged, pair)
+		stats.Added++
+	}
+
+	if len(f.Remove) == 0 {
+		return merged, stats
+	}
+	removed := make(map[[2]string]bool, len(f.Remove))
+	for _, ref := range f.Remove {
+		removed[synergyPairKey(ref.Card1, ref.Card2)] = true
+	}
+	kept := merged[:0]
+	for _, pair := range merged {
+		if removed[synergyPairKey(pair.Card1, pair.Card2)] {
+			stats.Removed++
+			continue
+		}
+		kept = append(kept, pair)
+	}
+	return kept, stats
+}
+
+// SynergyIssue is a problem found by SynergyFile.Validate
+type SynergyIssue struct {
+	// Location is the offending entry, e.g. "pairs[3]"
+	Location string `json:"location"`
+	// Warning is true for issues that do not stop the file from loading
+	Warning bool   `json:"warning,omitempty"`
+	Message string `json:"message"`
+}
+
+func (i SynergyIssue) String() string {
+	level := "error"
+	if i.Warning {
+		level = "warning"
+	}
+	return fmt.Sprintf("%s: %s: %s", level, i.Location, i.Message)
+}
+
+// Validate lints the file: missing or self-paired cards, scores outside
+// 0-1, unknown synergy types and duplicate pairs. When knownCard is non-nil,
+// card names it rejects are reported too.
+func (f *SynergyFile) Validate(knownCard func(string) bool) []SynergyIssue {
+	var issues []SynergyIssue
+	report := func(location string, warning bool, format string, args ...any) {
+		issues = append(issues, SynergyIssue{Location: location, Warning: warning, Message: fmt.Sprintf(format, args...)})
+	}
+	checkCards := func(location, card1, card2 string) {
+		for _, card := range []string{card1, card2} {
+			switch {
+			case card == "":
+				report(location, false, "card name is empty")
+			case knownCard != nil && !knownCard(card):
+				report(location, false, "unknown card %q", card)
+			}
+		}
+		if card1 != "" && card1 == card2 {
+			report(location, false, "%s is paired with itself", card1)
+		}
+	}
+
+	seen := make(map[[2]string]int, len(f.Pairs))
+	for i, pair := range f.Pairs {
+		location := fmt.Sprintf("pairs[%d]", i)
+		checkCards(location, pair.Card1, pair.Card2)
+		if !slices.Contains(SynergyCategories, pair.SynergyType) {
+			report(location, false, "unknown synergy_type %q", pair.SynergyType)
+		}
+		if pair.Score < 0 || pair.Score > 1 {
+			report(location, false, "score %.2f is outside 0-1", pair.Score)
+		}
+		key := synergyPairKey(pair.Card1, pair.Card2)
+		if first, ok := seen[key]; ok {
+			report(location, true, "duplicates pairs[%d] (%s + %s)", first, pair.Card1, pair.Card2)
+			continue
+		}
+		seen[key] = i
+	}
+
+	for i, ref := range f.Remove {
+		checkCards(fmt.Sprintf("remove[%d]", i), ref.Card1, ref.Card2)
+	}
+	return issues
+}
+
+// reloadingFile holds a parsed file and re-reads it when its size or
+// modification time changes.
+type reloadingFile[T any] struct {
+	mu      sync.Mutex
+	path    string
+	read    func(path string) (*T, error)
+	modTime time.Time
+	size    int64
+	value   *T
+	// hash is the SHA-256 of the file value was read from
+	hash string
+}
+
+// setPath switches to a new file (empty disables it) and drops the cached value.
+func (c *reloadingFile[T]) setPath(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.path = path
+	c.value, c.modTime, c.size, c.hash = nil, time.Time{}, 0, ""
+}
+
+func (c *reloadingFile[T]) getPath() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.path
+}
+
+// current returns the parsed file, re-reading it if it changed. A file that
+// fails to parse keeps the last good version in effect, so saving a
+// half-edited file does not drop its contents.
+func (c *reloadingFile[T]) current() *T {
+	value, _ := c.load()
+	return value
+}
+
+// fingerprint returns the hash of the file current returns, or "" when there
+// is none.
+func (c *reloadingFile[T]) fingerprint() string {
+	_, hash := c.load()
+	return hash
+}
+
+func (c *reloadingFile[T]) load() (*T, string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.path == "" {
+		return nil, ""
+	}
+
+	info, err := os.Stat(c.path)
+	if err != nil {
+		c.value, c.modTime, c.size, c.hash = nil, time.Time{}, 0, ""
+		return nil, ""
+	}
+	if c.value != nil && info.ModTime().Equal(c.modTime) && info.Size() == c.size {
+		return c.value, c.hash
+	}
+
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return c.value, c.hash
+	}
+	value, err := c.read(c.path)
+	if err != nil {
+		return c.value, c.hash
+	}
+	sum := sha256.Sum256(data)
+	c.value, c.modTime, c.size, c.hash = value, info.ModTime(), info.Size(), hex.EncodeToString(sum[:])
+	return value, c.hash
+}
+
+var synergyOverrides = &reloadingFile[SynergyFile]{read: ReadSynergyFile}
+
+// SetSynergyOverridesPath sets the user overrides file applied by
+// NewSynergyDatabase. An empty path disables overrides. The file does not
+// need to exist yet.
+func SetSynergyOverridesPath(path string) {
+	synergyOverrides.setPath(path)
+}
+
+// SynergyOverridesPath returns the configured user overrides file, if any
+func SynergyOverridesPath() string {
+	return synergyOverrides.getPath()
+}
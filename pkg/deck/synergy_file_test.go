@@ -0,0 +1,134 @@
+package deck
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDefaultSynergyFileIsValid(t *testing.T) {
+	file := DefaultSynergyFile()
+	if len(file.Pairs) == 0 {
+		t.Fatal("built-in synergy file has no pairs")
+	}
+	for _, issue := range file.Validate(nil) {
+		if !issue.Warning {
+			t.Errorf("built-in synergy pairs: %s", issue)
+		}
+	}
+
+	// Callers get a copy they can modify freely
+	file.Pairs[0].Score = 0
+	if DefaultSynergyFile().Pairs[0].Score == 0 {
+		t.Error("DefaultSynergyFile returned shared pairs")
+	}
+}
+
+func TestSynergyFileApply(t *testing.T) {
+	base := []SynergyPair{
+		{Card1: "Giant", Card2: "Witch", SynergyType: SynergyTankSupport, Score: 0.9},
+		{Card1: "Hog Rider", Card2: "Ice Golem", SynergyType: SynergyWinCondition, Score: 0.8},
+	}
+	overrides := &SynergyFile{
+		Pairs: []SynergyPair{
+			{Card1: "Witch", Card2: "Giant", SynergyType: SynergyTankSupport, Score: 0.5},
+			{Card1: "Golem", Card2: "Night Witch", SynergyType: SynergyTankSupport, Score: 0.95},
+		},
+		Remove: []SynergyPairRef{{Card1: "Ice Golem", Card2: "Hog Rider"}},
+	}
+
+	merged, stats := overrides.Apply(base)
+	if stats != (SynergyMergeStats{Replaced: 1, Added: 1, Removed: 1}) {
+		t.Errorf("stats = %+v, want 1 replaced, 1 added, 1 removed", stats)
+	}
+	db := buildSynergyDatabase(merged)
+	if got := db.GetSynergy("Giant", "Witch"); got != 0.5 {
+		t.Errorf("Giant+Witch = %.2f, want overridden 0.5", got)
+	}
+	if got := db.GetSynergy("Golem", "Night Witch"); got != 0.95 {
+		t.Errorf("Golem+Night Witch = %.2f, want added 0.95", got)
+	}
+	if got := db.GetSynergy("Hog Rider", "Ice Golem"); got != 0 {
+		t.Errorf("Hog Rider+Ice Golem = %.2f, want removed", got)
+	}
+	if base[0].Score != 0.9 {
+		t.Error("Apply modified the base pairs")
+	}
+}
+
+func TestSynergyFileValidate(t *testing.T) {
+	known := map[string]bool{"Giant": true, "Witch": true, "Golem": true}
+	file := &SynergyFile{
+		Pairs: []SynergyPair{
+			{Card1: "Giant", Card2: "Witch", SynergyType: SynergyTankSupport, Score: 0.9},
+			{Card1: "Giant", Card2: "Wtich", SynergyType: SynergyTankSupport, Score: 0.9},
+			{Card1: "Golem", Card2: "Golem", SynergyType: "tanky", Score: 1.5},
+			{Card1: "Witch", Card2: "Giant", SynergyType: SynergyTankSupport, Score: 0.8},
+		},
+		Remove: []SynergyPairRef{{Card1: "", Card2: "Giant"}},
+	}
+
+	issues := file.Validate(func(name string) bool { return known[name] })
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.String())
+	}
+	want := []string{
+		`error: pairs[1]: unknown card "Wtich"`,
+		"error: pairs[2]: Golem is paired with itself",
+		`error: pairs[2]: unknown synergy_type "tanky"`,
+		"error: pairs[2]: score 1.50 is outside 0-1",
+		"warning: pairs[3]: duplicates pairs[0] (Witch + Giant)",
+		"error: remove[0]: card name is empty",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("issues:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestNewSynergyDatabaseReloadsOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), SynergyOverridesFile)
+	SetSynergyOverridesPath(path)
+	t.Cleanup(func() { SetSynergyOverridesPath("") })
+
+	builtin := NewSynergyDatabase().GetSynergy("Giant", "Witch")
+	if builtin == 0 {
+		t.Fatal("expected a built-in Giant+Witch synergy")
+	}
+
+	write := func(content string, modTime time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	start := time.Now().Add(-time.Hour)
+	write(`{"version":1,"pairs":[{"card1":"Giant","card2":"Witch","synergy_type":"tank_support","score":0.1}]}`, start)
+	if got := NewSynergyDatabase().GetSynergy("Giant", "Witch"); got != 0.1 {
+		t.Errorf("after override Giant+Witch = %.2f, want 0.1", got)
+	}
+
+	write(`{"version":1,"pairs":[{"card1":"Giant","card2":"Witch","synergy_type":"tank_support","score":0.2}]}`, start.Add(time.Minute))
+	if got := NewSynergyDatabase().GetSynergy("Giant", "Witch"); got != 0.2 {
+		t.Errorf("after edit Giant+Witch = %.2f, want reloaded 0.2", got)
+	}
+
+	// A broken save keeps the last good overrides
+	write(`{"pairs": [`, start.Add(2*time.Minute))
+	if got := NewSynergyDatabase().GetSynergy("Giant", "Witch"); got != 0.2 {
+		t.Errorf("after broken edit Giant+Witch = %.2f, want last good 0.2", got)
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if got := NewSynergyDatabase().GetSynergy("Giant", "Witch"); got != builtin {
+		t.Errorf("after delete Giant+Witch = %.2f, want built-in %.2f", got, builtin)
+	}
+}
@@ -0,0 +1,56 @@
+package deck
+
+// synergyIndex is a dense card-by-card matrix over a database's pairs, so a
+// pair lookup is two map reads and an array index instead of a scan of every
+// pair. Cards are numbered in order of first appearance.
+type synergyIndex struct {
+	cards map[string]int
+	// pairs[i*n+j] is one more than the position in Pairs of the synergy
+	// between cards i and j (the first one, matching a linear scan), or 0
+	pairs []int32
+	n     int
+}
+
+func newSynergyIndex(pairs []SynergyPair) *synergyIndex {
+	idx := &synergyIndex{cards: make(map[string]int)}
+	for _, pair := range pairs {
+		for _, card := range [2]string{pair.Card1, pair.Card2} {
+			if _, ok := idx.cards[card]; !ok {
+				idx.cards[card] = len(idx.cards)
+			}
+		}
+	}
+	idx.n = len(idx.cards)
+	idx.pairs = make([]int32, idx.n*idx.n)
+	for i, pair := range pairs {
+		a, b := idx.cards[pair.Card1], idx.cards[pair.Card2]
+		if idx.pairs[a*idx.n+b] != 0 {
+			continue
+		}
+		idx.pairs[a*idx.n+b] = int32(i + 1)
+		idx.pairs[b*idx.n+a] = int32(i + 1)
+	}
+	return idx
+}
+
+// lookup returns the position in Pairs of the synergy between two cards.
+func (idx *synergyIndex) lookup(card1, card2 string) (int, bool) {
+	a, ok := idx.cards[card1]
+	if !ok {
+		return 0, false
+	}
+	b, ok := idx.cards[card2]
+	if !ok {
+		return 0, false
+	}
+	position := idx.pairs[a*idx.n+b]
+	return int(position) - 1, position != 0
+}
+
+// index returns the database's synergy matrix, building it on first use.
+func (db *SynergyDatabase) index() *synergyIndex {
+	db.indexOnce.Do(func() {
+		db.synergyIndex = newSynergyIndex(db.Pairs)
+	})
+	return db.synergyIndex
+}
@@ -0,0 +1,59 @@
+package deck
+
+import "testing"
+
+// linearSynergyPair is the scan the synergy index replaces.
+func linearSynergyPair(pairs []SynergyPair, card1, card2 string) (int, bool) {
+	for i, pair := range pairs {
+		if (pair.Card1 == card1 && pair.Card2 == card2) || (pair.Card1 == card2 && pair.Card2 == card1) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func TestSynergyIndexMatchesLinearScan(t *testing.T) {
+	db := NewSynergyDatabase()
+	cards := make(map[string]bool)
+	for _, pair := range db.Pairs {
+		cards[pair.Card1] = true
+		cards[pair.Card2] = true
+	}
+	cards["Not A Card"] = true
+
+	index := db.index()
+	for card1 := range cards {
+		for card2 := range cards {
+			want, wantOK := linearSynergyPair(db.Pairs, card1, card2)
+			got, ok := index.lookup(card1, card2)
+			if ok != wantOK || (ok && got != want) {
+				t.Fatalf("lookup(%q, %q) = %d, %v; linear scan = %d, %v", card1, card2, got, ok, want, wantOK)
+			}
+		}
+	}
+}
+
+func TestSynergyIndexKeepsFirstDuplicate(t *testing.T) {
+	db := &SynergyDatabase{Pairs: []SynergyPair{
+		{Card1: "Giant", Card2: "Witch", Score: 0.8},
+		{Card1: "Witch", Card2: "Giant", Score: 0.3},
+	}}
+	if got := db.GetSynergy("Witch", "Giant"); got != 0.8 {
+		t.Errorf("GetSynergy = %v, want the first pair's 0.8", got)
+	}
+	if pair := db.GetSynergyPair("Giant", "Witch"); pair == nil || pair.Score != 0.8 {
+		t.Errorf("GetSynergyPair = %+v", pair)
+	}
+	if db.GetSynergyPair("Giant", "Knight") != nil {
+		t.Error("expected no synergy for an unknown pair")
+	}
+}
+
+func BenchmarkAnalyzeDeckSynergy(b *testing.B) {
+	db := NewSynergyDatabase()
+	deckCards := []string{"Hog Rider", "Musketeer", "Valkyrie", "Ice Spirit", "Skeletons", "Cannon", "Fireball", "The Log"}
+	b.ResetTimer()
+	for b.Loop() {
+		db.AnalyzeDeckSynergy(deckCards)
+	}
+}
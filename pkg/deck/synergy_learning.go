@@ -0,0 +1,245 @@
+// Package deck provides synergy learning from battle outcomes.
+//
+// LearnSynergy compares how often decks containing a card pair win against
+// what the two cards' individual win rates predict. The difference (lift) is
+// stored in a learned overlay that NewSynergyDatabase blends into the curated
+// pairs: each pair's score moves by blend × adjustment.
+package deck
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/storage"
+)
+
+// LearnedSynergyFile is the default name of the learned synergy overlay in
+// the data directory.
+const LearnedSynergyFile = "synergy_learned.json"
+
+// DefaultSynergyBlend is the default weight of learned adjustments.
+const DefaultSynergyBlend = 0.3
+
+// learnedLiftScale converts win rate lift into a synergy adjustment: a pair
+// winning 10 points more than expected moves its score by 0.5 at full blend.
+const learnedLiftScale = 5.0
+
+// DeckOutcome is one side of a battle: the deck played and whether it won.
+type DeckOutcome struct {
+	Cards []string
+	Won   bool
+}
+
+// SynergyLearnOptions controls LearnSynergy.
+type SynergyLearnOptions struct {
+	// MinGames is the fewest games a pair needs to be learned
+	MinGames int
+	// PriorGames shrinks win rates toward their expected value, as if every
+	// card and pair had this many extra games at the expected rate
+	PriorGames float64
+}
+
+// DefaultSynergyLearnOptions returns the default learning thresholds.
+func DefaultSynergyLearnOptions() SynergyLearnOptions {
+	return SynergyLearnOptions{MinGames: 20, PriorGames: 10}
+}
+
+// LearnedSynergyPair is the observed performance of decks playing two cards together.
+type LearnedSynergyPair struct {
+	Card1           string  `json:"card1"`
+	Card2           string  `json:"card2"`
+	Games           int     `json:"games"`
+	Wins            int     `json:"wins"`
+	WinRate         float64 `json:"win_rate"`
+	ExpectedWinRate float64 `json:"expected_win_rate"`
+	// Lift is the shrunk pair win rate minus the expected win rate
+	Lift float64 `json:"lift"`
+	// Adjustment is the change to the pair's synergy score at full blend (-1 to 1)
+	Adjustment float64 `json:"adjustment"`
+}
+
+// LearnedSynergy is a learned synergy overlay.
+type LearnedSynergy struct {
+	Version     int                  `json:"version"`
+	GeneratedAt time.Time            `json:"generated_at"`
+	Decks       int                  `json:"decks"`
+	MinGames    int                  `json:"min_games"`
+	Pairs       []LearnedSynergyPair `json:"pairs"`
+}
+
+// LearnSynergy learns pair adjustments from deck outcomes. Pairs are sorted
+// by adjustment, strongest positive first.
+func LearnSynergy(outcomes []DeckOutcome, opts SynergyLearnOptions) *LearnedSynergy {
+	type tally struct{ games, wins int }
+	cards := make(map[string]*tally)
+	pairs := make(map[[2]string]*tally)
+	add := func(t *tally, won bool) {
+		t.games++
+		if won {
+			t.wins++
+		}
+	}
+
+	decks := 0
+	for _, outcome := range outcomes {
+		deckCards := slices.Compact(slices.Sorted(slices.Values(outcome.Cards)))
+		if len(deckCards) < 2 {
+			continue
+		}
+		decks++
+		for i, card := range deckCards {
+			if cards[card] == nil {
+				cards[card] = &tally{}
+			}
+			add(cards[card], outcome.Won)
+			for _, other := range deckCards[i+1:] {
+				key := [2]string{card, other}
+				if pairs[key] == nil {
+					pairs[key] = &tally{}
+				}
+				add(pairs[key], outcome.Won)
+			}
+		}
+	}
+
+	shrunk := func(t *tally, expected float64) float64 {
+		return (float64(t.wins) + opts.PriorGames*expected) / (float64(t.games) + opts.PriorGames)
+	}
+
+	learned := &LearnedSynergy{Version: 1, GeneratedAt: time.Now().UTC(), Decks: decks, MinGames: opts.MinGames}
+	for key, pair := range pairs {
+		if pair.games < opts.MinGames {
+			continue
+		}
+		expected := (shrunk(cards[key[0]], 0.5) + shrunk(cards[key[1]], 0.5)) / 2
+		lift := shrunk(pair, expected) - expected
+		learned.Pairs = append(learned.Pairs, LearnedSynergyPair{
+			Card1:           key[0],
+			Card2:           key[1],
+			Games:           pair.games,
+			Wins:            pair.wins,
+			WinRate:         float64(pair.wins) / float64(pair.games),
+			ExpectedWinRate: expected,
+			Lift:            lift,
+			Adjustment:      math.Max(-1, math.Min(1, lift*learnedLiftScale)),
+		})
+	}
+	sort.Slice(learned.Pairs, func(i, j int) bool {
+		a, b := learned.Pairs[i], learned.Pairs[j]
+		if a.Adjustment != b.Adjustment {
+			return a.Adjustment > b.Adjustment
+		}
+		if a.Card1 != b.Card1 {
+			return a.Card1 < b.Card1
+		}
+		return a.Card2 < b.Card2
+	})
+	return learned
+}
+
+// ReadLearnedSynergy reads a learned synergy overlay file.
+func ReadLearnedSynergy(path string) (*LearnedSynergy, error) {
+	var learned LearnedSynergy
+	if err := storage.ReadJSON(path, &learned); err != nil {
+		return nil, fmt.Errorf("failed to read learned synergy: %w", err)
+	}
+	return &learned, nil
+}
+
+// Blend returns pairs with the learned adjustments applied at the given
+// weight (0-1). Curated pairs move by blend × adjustment; learned pairs with
+// no curated entry are added when their blended score is positive. Pairs
+// blended down to zero are dropped.
+func (l *LearnedSynergy) Blend(pairs []SynergyPair, blend float64) []SynergyPair {
+	if blend <= 0 || len(l.Pairs) == 0 {
+		return pairs
+	}
+
+	index := make(map[[2]string]int, len(pairs))
+	for i, pair := range pairs {
+		key := synergyPairKey(pair.Card1, pair.Card2)
+		if _, ok := index[key]; !ok {
+			index[key] = i
+		}
+	}
+
+	blended := slices.Clone(pairs)
+	for _, learned := range l.Pairs {
+		delta := blend * learned.Adjustment
+		if i, ok := index[synergyPairKey(learned.Card1, learned.Card2)]; ok {
+			blended[i].Score = math.Max(0, math.Min(1, blended[i].Score+delta))
+			continue
+		}
+		if delta <= 0 {
+			continue
+		}
+		blended = append(blended, SynergyPair{
+			Card1:       learned.Card1,
+			Card2:       learned.Card2,
+			SynergyType: SynergyLearned,
+			Score:       math.Min(1, delta),
+			Description: fmt.Sprintf("Learned: %.0f%% win rate together over %d games (%.0f%% expected)",
+				learned.WinRate*100, learned.Games, learned.ExpectedWinRate*100),
+		})
+	}
+
+	kept := blended[:0]
+	for _, pair := range blended {
+		if pair.Score > 0 {
+			kept = append(kept, pair)
+		}
+	}
+	return kept
+}
+
+var (
+	learnedSynergy      = &reloadingFile[LearnedSynergy]{read: ReadLearnedSynergy}
+	learnedSynergyMu    sync.Mutex
+	learnedSynergyBlend = DefaultSynergyBlend
+)
+
+// SetLearnedSynergy sets the learned overlay blended in by NewSynergyDatabase
+// and its weight. An empty path or zero blend disables learning. The file
+// does not need to exist yet.
+func SetLearnedSynergy(path string, blend float64) {
+	learnedSynergyMu.Lock()
+	learnedSynergyBlend = blend
+	learnedSynergyMu.Unlock()
+	learnedSynergy.setPath(path)
+}
+
+// currentLearnedSynergy returns the learned overlay and blend, or nil when disabled.
+func currentLearnedSynergy() (*LearnedSynergy, float64) {
+	learnedSynergyMu.Lock()
+	blend := learnedSynergyBlend
+	learnedSynergyMu.Unlock()
+	if blend <= 0 {
+		return nil, 0
+	}
+	return learnedSynergy.current(), blend
+}
+
+// SynergyFingerprint identifies the synergy overrides file, the learned
+// overlay, and its blend currently in effect, or returns "" when only the
+// built-in pairs apply. It changes whenever either file's contents do, so
+// cached evaluations can tell the synergy data apart.
+func SynergyFingerprint() string {
+	var parts []string
+	if hash := synergyOverrides.fingerprint(); hash != "" {
+		parts = append(parts, "overrides="+hash)
+	}
+	learnedSynergyMu.Lock()
+	blend := learnedSynergyBlend
+	learnedSynergyMu.Unlock()
+	if blend > 0 {
+		if hash := learnedSynergy.fingerprint(); hash != "" {
+			parts = append(parts, fmt.Sprintf("learned=%s@%g", hash, blend))
+		}
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,170 @@
+package deck
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/internal/storage"
+)
+
+// learningOutcomes returns decks where Hog Rider + Ice Golem wins 80% of its
+// games while decks with only one of the two win 20%.
+func learningOutcomes() []DeckOutcome {
+	var outcomes []DeckOutcome
+	for i := range 50 {
+		outcomes = append(outcomes,
+			DeckOutcome{Cards: []string{"Hog Rider", "Ice Golem", "Fireball"}, Won: i%5 != 0},
+			DeckOutcome{Cards: []string{"Hog Rider", "Knight", "Fireball"}, Won: i%5 < 1},
+			DeckOutcome{Cards: []string{"Ice Golem", "Knight", "Fireball"}, Won: i%5 < 1},
+		)
+	}
+	return outcomes
+}
+
+func findLearnedPair(learned *LearnedSynergy, card1, card2 string) (LearnedSynergyPair, bool) {
+	for _, pair := range learned.Pairs {
+		if synergyPairKey(pair.Card1, pair.Card2) == synergyPairKey(card1, card2) {
+			return pair, true
+		}
+	}
+	return LearnedSynergyPair{}, false
+}
+
+func TestLearnSynergy(t *testing.T) {
+	learned := LearnSynergy(learningOutcomes(), DefaultSynergyLearnOptions())
+	if learned.Decks != 150 {
+		t.Errorf("Decks = %d, want 150", learned.Decks)
+	}
+
+	golem, ok := findLearnedPair(learned, "Ice Golem", "Hog Rider")
+	if !ok {
+		t.Fatal("Hog Rider + Ice Golem was not learned")
+	}
+	if golem.Games != 50 || golem.Wins != 40 {
+		t.Errorf("Hog Rider + Ice Golem games/wins = %d/%d, want 50/40", golem.Games, golem.Wins)
+	}
+	if golem.Lift <= 0.1 || golem.Adjustment <= 0.5 {
+		t.Errorf("Hog Rider + Ice Golem lift %.3f adjustment %.3f, want strongly positive", golem.Lift, golem.Adjustment)
+	}
+	if learned.Pairs[0].Card1 != "Hog Rider" || learned.Pairs[0].Card2 != "Ice Golem" {
+		t.Errorf("strongest pair = %s + %s, want Hog Rider + Ice Golem", learned.Pairs[0].Card1, learned.Pairs[0].Card2)
+	}
+
+	knight, ok := findLearnedPair(learned, "Hog Rider", "Knight")
+	if !ok || knight.Adjustment >= 0 {
+		t.Errorf("Hog Rider + Knight = %+v, want a negative adjustment", knight)
+	}
+
+	strict := LearnSynergy(learningOutcomes(), SynergyLearnOptions{MinGames: 51, PriorGames: 10})
+	if len(strict.Pairs) != 3 {
+		t.Fatalf("MinGames 51 kept %d pairs, want the 3 Fireball pairs with 100 games", len(strict.Pairs))
+	}
+	for _, pair := range strict.Pairs {
+		if pair.Card1 != "Fireball" || pair.Games != 100 {
+			t.Errorf("unexpected pair above MinGames: %+v", pair)
+		}
+	}
+}
+
+func TestLearnedSynergyBlend(t *testing.T) {
+	curated := []SynergyPair{
+		{Card1: "Hog Rider", Card2: "Ice Golem", SynergyType: SynergyWinCondition, Score: 0.8},
+		{Card1: "Giant", Card2: "Witch", SynergyType: SynergyTankSupport, Score: 0.1},
+	}
+	learned := &LearnedSynergy{Pairs: []LearnedSynergyPair{
+		{Card1: "Hog Rider", Card2: "Ice Golem", Adjustment: 1},
+		{Card1: "Giant", Card2: "Witch", Adjustment: -1},
+		{Card1: "Knight", Card2: "Zap", Adjustment: 0.5},
+		{Card1: "Knight", Card2: "Arrows", Adjustment: -0.5},
+	}}
+
+	db := buildSynergyDatabase(learned.Blend(curated, 0.5))
+	if got := db.GetSynergy("Hog Rider", "Ice Golem"); got != 1 {
+		t.Errorf("Hog Rider + Ice Golem = %.2f, want 1 (clamped)", got)
+	}
+	if got := db.GetSynergy("Witch", "Giant"); got != 0 {
+		t.Errorf("Giant + Witch = %.2f, want dropped", got)
+	}
+	if pair := db.GetSynergyPair("Zap", "Knight"); pair == nil || math.Abs(pair.Score-0.25) > 1e-9 || pair.SynergyType != SynergyLearned {
+		t.Errorf("Knight + Zap = %+v, want a learned pair scored 0.25", pair)
+	}
+	if db.GetSynergyPair("Knight", "Arrows") != nil {
+		t.Error("negative learned pairs without a curated entry should not be added")
+	}
+
+	if got := learned.Blend(curated, 0); len(got) != 2 || got[0].Score != 0.8 {
+		t.Errorf("blend 0 changed pairs: %+v", got)
+	}
+}
+
+func TestNewSynergyDatabaseBlendsLearned(t *testing.T) {
+	path := filepath.Join(t.TempDir(), LearnedSynergyFile)
+	learned := &LearnedSynergy{Version: 1, Pairs: []LearnedSynergyPair{{Card1: "Giant", Card2: "Witch", Adjustment: -0.5}}}
+	if err := storage.WriteJSON(path, learned); err != nil {
+		t.Fatal(err)
+	}
+	builtin := NewSynergyDatabase().GetSynergy("Giant", "Witch")
+
+	SetLearnedSynergy(path, 0.2)
+	t.Cleanup(func() { SetLearnedSynergy("", DefaultSynergyBlend) })
+	if got := NewSynergyDatabase().GetSynergy("Giant", "Witch"); math.Abs(got-(builtin-0.1)) > 1e-9 {
+		t.Errorf("blended Giant + Witch = %.3f, want %.3f", got, builtin-0.1)
+	}
+
+	SetLearnedSynergy(path, 0)
+	if got := NewSynergyDatabase().GetSynergy("Giant", "Witch"); got != builtin {
+		t.Errorf("blend 0 Giant + Witch = %.3f, want built-in %.3f", got, builtin)
+	}
+}
+
+func TestSynergyFingerprint(t *testing.T) {
+	dir := t.TempDir()
+	overridesPath := filepath.Join(dir, SynergyOverridesFile)
+	learnedPath := filepath.Join(dir, LearnedSynergyFile)
+	t.Cleanup(func() {
+		SetSynergyOverridesPath("")
+		SetLearnedSynergy("", DefaultSynergyBlend)
+	})
+
+	SetSynergyOverridesPath(overridesPath)
+	SetLearnedSynergy(learnedPath, 0.2)
+	if got := SynergyFingerprint(); got != "" {
+		t.Errorf("fingerprint without files = %q, want empty", got)
+	}
+
+	overrides := &SynergyFile{Version: 1, Pairs: []SynergyPair{{Card1: "Giant", Card2: "Witch", Score: 0.9}}}
+	if err := storage.WriteJSON(overridesPath, overrides); err != nil {
+		t.Fatal(err)
+	}
+	withOverrides := SynergyFingerprint()
+	if withOverrides == "" {
+		t.Fatal("fingerprint should change when an overrides file appears")
+	}
+
+	learned := &LearnedSynergy{Version: 1, Pairs: []LearnedSynergyPair{{Card1: "Giant", Card2: "Witch", Adjustment: -0.5}}}
+	if err := storage.WriteJSON(learnedPath, learned); err != nil {
+		t.Fatal(err)
+	}
+	withLearned := SynergyFingerprint()
+	if withLearned == withOverrides {
+		t.Error("fingerprint should change when a learned overlay appears")
+	}
+
+	SetLearnedSynergy(learnedPath, 0.5)
+	if got := SynergyFingerprint(); got == withLearned {
+		t.Error("fingerprint should change with the blend")
+	}
+	SetLearnedSynergy(learnedPath, 0)
+	if got := SynergyFingerprint(); got != withOverrides {
+		t.Errorf("blend 0 fingerprint = %q, want the overrides-only %q", got, withOverrides)
+	}
+
+	overrides.Pairs[0].Score = 0.25
+	if err := storage.WriteJSON(overridesPath, overrides); err != nil {
+		t.Fatal(err)
+	}
+	if got := SynergyFingerprint(); got == withOverrides {
+		t.Error("fingerprint should change when the overrides file is edited")
+	}
+}
@@ -0,0 +1,366 @@
+// Package templates provides a curated library of proven deck skeletons, such
+// as the 2.6 Hog Cycle shell, and fills their flex slots from a player's
+// collection.
+//
+// A template fixes the core cards that define a deck and leaves the rest as
+// flex slots. Each slot lists preferred options in order and may name a role,
+// in which case any owned card of that role within the slot's elixir limit can
+// fill it as well.
+package templates
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+	"github.com/klauer/clash-royale-api/go/pkg/deckhash"
+)
+
+//go:embed templates.json
+var defaultTemplatesJSON []byte
+
+const (
+	// deckSize is the number of cards in a deck
+	deckSize = 8
+
+	// preferredBonus is added to the first listed option of a flex slot and
+	// shrinks for later options, so the curated choices win ties with other
+	// cards of the role
+	preferredBonus = 0.3
+
+	// defaultOptionsPerSlot is how many ranked options each flex slot
+	// contributes to the combinations considered
+	defaultOptionsPerSlot = 4
+
+	// defaultVariants is how many decks FillVariants returns by default
+	defaultVariants = 5
+)
+
+// FlexSlot is a deck slot the player's collection fills.
+type FlexSlot struct {
+	// Role, when set, lets any owned card of the role fill the slot
+	Role deck.CardRole `json:"role,omitempty"`
+	// Options are the preferred cards, best first
+	Options []string `json:"options,omitempty"`
+	// MaxElixir limits role fallbacks to cards costing at most this much
+	// (0 = no limit)
+	MaxElixir int `json:"max_elixir,omitempty"`
+	// Note describes the slot's job, e.g. "cheap spell"
+	Note string `json:"note,omitempty"`
+}
+
+// Template is a proven deck skeleton.
+type Template struct {
+	ID          string               `json:"id"`
+	Name        string               `json:"name"`
+	Archetype   evaluation.Archetype `json:"archetype"`
+	Description string               `json:"description"`
+	Core        []string             `json:"core"`
+	Flex        []FlexSlot           `json:"flex"`
+}
+
+// Library is a set of templates.
+type Library struct {
+	Templates []Template
+}
+
+// templatesDataFile represents the JSON structure of the template library
+type templatesDataFile struct {
+	Version     int        `json:"version"`
+	Description string     `json:"description"`
+	LastUpdated string     `json:"last_updated"`
+	Templates   []Template `json:"templates"`
+}
+
+// Default returns the built-in template library.
+func Default() (*Library, error) {
+	lib, err := parseLibrary(defaultTemplatesJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse embedded template library: %w", err)
+	}
+	return lib, nil
+}
+
+// Load loads a template library from a JSON file.
+func Load(path string) (*Library, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template library: %w", err)
+	}
+	return parseLibrary(data)
+}
+
+func parseLibrary(data []byte) (*Library, error) {
+	var file templatesDataFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid template library JSON: %w", err)
+	}
+	seen := make(map[string]bool, len(file.Templates))
+	for _, t := range file.Templates {
+		if err := t.validate(); err != nil {
+			return nil, err
+		}
+		id := strings.ToLower(t.ID)
+		if seen[id] {
+			return nil, fmt.Errorf("duplicate template id %q", t.ID)
+		}
+		seen[id] = true
+	}
+	return &Library{Templates: file.Templates}, nil
+}
+
+func (t Template) validate() error {
+	if t.ID == "" {
+		return fmt.Errorf("template %q has no id", t.Name)
+	}
+	if n := len(t.Core) + len(t.Flex); n != deckSize {
+		return fmt.Errorf("template %s has %d core cards and %d flex slots, want %d in total", t.ID, len(t.Core), len(t.Flex), deckSize)
+	}
+	for i, card := range t.Core {
+		if slices.Contains(t.Core[:i], card) {
+			return fmt.Errorf("template %s lists core card %s twice", t.ID, card)
+		}
+	}
+	for i, slot := range t.Flex {
+		if len(slot.Options) == 0 && slot.Role == "" {
+			return fmt.Errorf("template %s flex slot %d needs options or a role", t.ID, i+1)
+		}
+		if slot.Role != "" && !slices.Contains(config.AllCardRoles(), slot.Role) {
+			return fmt.Errorf("template %s flex slot %d has unknown role %q", t.ID, i+1, slot.Role)
+		}
+	}
+	return nil
+}
+
+// Find returns the template with the given id, ignoring case.
+func (l *Library) Find(id string) (Template, bool) {
+	for _, t := range l.Templates {
+		if strings.EqualFold(t.ID, id) {
+			return t, true
+		}
+	}
+	return Template{}, false
+}
+
+// FillMode selects how many decks Fill builds from a template.
+type FillMode string
+
+const (
+	// FillBest builds the single best deck
+	FillBest FillMode = "best"
+	// FillVariants builds the best few decks, each a different flex choice
+	FillVariants FillMode = "variants"
+)
+
+// ParseFillMode parses a fill mode name. An empty string is FillBest.
+func ParseFillMode(value string) (FillMode, error) {
+	switch mode := FillMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return FillBest, nil
+	case FillBest, FillVariants:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown fill mode %q (supported: best, variants)", value)
+	}
+}
+
+// FillOptions configures Fill.
+type FillOptions struct {
+	Mode FillMode
+	// Limit caps the decks FillVariants returns (default 5)
+	Limit int
+	// SynergyDB rates how well flex options pair with the core (default:
+	// the built-in synergy database)
+	SynergyDB *deck.SynergyDatabase
+}
+
+// FlexChoice is the card chosen for one flex slot.
+type FlexChoice struct {
+	Slot      int     `json:"slot"`
+	Card      string  `json:"card"`
+	Note      string  `json:"note,omitempty"`
+	Preferred bool    `json:"preferred"`
+	Score     float64 `json:"score"`
+}
+
+// FilledDeck is a complete deck built from a template.
+type FilledDeck struct {
+	Cards []string     `json:"cards"`
+	Flex  []FlexChoice `json:"flex"`
+	// FillScore is the mean flex choice score: card level ratio, synergy
+	// with the core, and a bonus for the template's preferred options
+	FillScore float64 `json:"fill_score"`
+}
+
+// FillResult is the outcome of filling one template.
+type FillResult struct {
+	Template Template     `json:"template"`
+	Decks    []FilledDeck `json:"decks"`
+	// MissingCore lists core cards the collection lacks
+	MissingCore []string `json:"missing_core,omitempty"`
+	// EmptySlots lists flex slots (1-based) no owned card can fill
+	EmptySlots []int `json:"empty_slots,omitempty"`
+}
+
+// Complete reports whether the collection fills the template. It is false
+// with no missing cards when flex slots compete for too few owned cards.
+func (r FillResult) Complete() bool {
+	return len(r.MissingCore) == 0 && len(r.EmptySlots) == 0 && len(r.Decks) > 0
+}
+
+// flexOption is one owned card that can fill a slot
+type flexOption struct {
+	card      *deck.CardCandidate
+	preferred bool
+	score     float64
+}
+
+// Fill builds decks from the template using the owned cards in collection.
+// Every combination of the best few options per flex slot is scored, and the
+// decks with the highest fill score are returned. When a core card is not
+// owned or a slot has no owned option, no decks are built and the result says
+// what is missing.
+func Fill(t Template, collection []*deck.CardCandidate, opts FillOptions) FillResult {
+	result := FillResult{Template: t}
+	synergyDB := opts.SynergyDB
+	if synergyDB == nil {
+		synergyDB = deck.NewSynergyDatabase()
+	}
+	owned := make(map[string]*deck.CardCandidate, len(collection))
+	for _, c := range collection {
+		owned[c.Name] = c
+	}
+	for _, card := range t.Core {
+		if owned[card] == nil {
+			result.MissingCore = append(result.MissingCore, card)
+		}
+	}
+
+	slotOptions := make([][]flexOption, len(t.Flex))
+	for i, slot := range t.Flex {
+		slotOptions[i] = rankOptions(slot, t.Core, collection, owned, synergyDB)
+		if len(slotOptions[i]) == 0 {
+			result.EmptySlots = append(result.EmptySlots, i+1)
+		}
+	}
+	if len(result.MissingCore) > 0 || len(result.EmptySlots) > 0 {
+		return result
+	}
+
+	limit := 1
+	if opts.Mode == FillVariants {
+		limit = opts.Limit
+		if limit <= 0 {
+			limit = defaultVariants
+		}
+	}
+
+	var decks []FilledDeck
+	seen := make(map[string]bool)
+	choice := make([]flexOption, len(t.Flex))
+	var walk func(slot int, used map[string]bool)
+	walk = func(slot int, used map[string]bool) {
+		if slot == len(t.Flex) {
+			filled := buildDeck(t, choice)
+			key := deckhash.CanonicalDeckKey(filled.Cards)
+			if !seen[key] {
+				seen[key] = true
+				decks = append(decks, filled)
+			}
+			return
+		}
+		for _, option := range slotOptions[slot] {
+			if used[option.card.Name] {
+				continue
+			}
+			used[option.card.Name] = true
+			choice[slot] = option
+			walk(slot+1, used)
+			delete(used, option.card.Name)
+		}
+	}
+	used := make(map[string]bool, deckSize)
+	for _, card := range t.Core {
+		used[card] = true
+	}
+	walk(0, used)
+
+	sort.SliceStable(decks, func(i, j int) bool { return decks[i].FillScore > decks[j].FillScore })
+	if len(decks) > limit {
+		decks = decks[:limit]
+	}
+	result.Decks = decks
+	return result
+}
+
+// rankOptions lists the owned cards that can fill a slot, best first, keeping
+// the top defaultOptionsPerSlot
+func rankOptions(slot FlexSlot, core []string, collection []*deck.CardCandidate, owned map[string]*deck.CardCandidate, synergyDB *deck.SynergyDatabase) []flexOption {
+	var options []flexOption
+	listed := make(map[string]bool, len(slot.Options))
+	for i, name := range slot.Options {
+		listed[name] = true
+		card := owned[name]
+		if card == nil || slices.Contains(core, name) {
+			continue
+		}
+		bonus := preferredBonus * float64(len(slot.Options)-i) / float64(len(slot.Options))
+		options = append(options, flexOption{card: card, preferred: true, score: optionScore(card, core, synergyDB) + bonus})
+	}
+	if slot.Role != "" {
+		for _, card := range collection {
+			if listed[card.Name] || card.Role == nil || *card.Role != slot.Role || slices.Contains(core, card.Name) {
+				continue
+			}
+			if slot.MaxElixir > 0 && card.Elixir > slot.MaxElixir {
+				continue
+			}
+			options = append(options, flexOption{card: card, score: optionScore(card, core, synergyDB)})
+		}
+	}
+	sort.SliceStable(options, func(i, j int) bool { return options[i].score > options[j].score })
+	if len(options) > defaultOptionsPerSlot {
+		options = options[:defaultOptionsPerSlot]
+	}
+	return options
+}
+
+// optionScore is the card's level ratio plus its synergy with the core,
+// capped at 1
+func optionScore(card *deck.CardCandidate, core []string, synergyDB *deck.SynergyDatabase) float64 {
+	synergy := 0.0
+	for _, coreCard := range core {
+		synergy += synergyDB.GetSynergy(card.Name, coreCard)
+	}
+	return card.LevelRatio() + min(synergy, 1)
+}
+
+func buildDeck(t Template, choice []flexOption) FilledDeck {
+	filled := FilledDeck{
+		Cards: append(make([]string, 0, deckSize), t.Core...),
+		Flex:  make([]FlexChoice, len(choice)),
+	}
+	total := 0.0
+	for i, option := range choice {
+		filled.Cards = append(filled.Cards, option.card.Name)
+		filled.Flex[i] = FlexChoice{
+			Slot:      i + 1,
+			Card:      option.card.Name,
+			Note:      t.Flex[i].Note,
+			Preferred: option.preferred,
+			Score:     option.score,
+		}
+		total += option.score
+	}
+	if len(choice) > 0 {
+		filled.FillScore = total / float64(len(choice))
+	}
+	return filled
+}
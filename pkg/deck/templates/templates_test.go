@@ -0,0 +1,128 @@
+package templates
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+func collection(levels map[string]int) []*deck.CardCandidate {
+	cards := make([]*deck.CardCandidate, 0, len(levels))
+	for name, level := range levels {
+		role := config.GetCardRole(name)
+		cards = append(cards, &deck.CardCandidate{
+			Name:     name,
+			Level:    level,
+			MaxLevel: 15,
+			Elixir:   config.GetCardElixir(name, 0),
+			Role:     &role,
+		})
+	}
+	return cards
+}
+
+func TestDefaultLibraryUsesKnownCards(t *testing.T) {
+	lib, err := Default()
+	if err != nil {
+		t.Fatalf("Default() error = %v", err)
+	}
+	if len(lib.Templates) == 0 {
+		t.Fatal("Default() returned no templates")
+	}
+	stats := clashroyale.DefaultStats()
+	for _, tmpl := range lib.Templates {
+		cards := slices.Clone(tmpl.Core)
+		for _, slot := range tmpl.Flex {
+			cards = append(cards, slot.Options...)
+		}
+		for _, card := range cards {
+			if stats.GetStats(card) == nil {
+				t.Errorf("template %s uses unknown card %q", tmpl.ID, card)
+			}
+		}
+	}
+	if _, ok := lib.Find("HOG-2.6"); !ok {
+		t.Error("Find() should ignore case")
+	}
+}
+
+func TestParseLibraryRejectsInvalidTemplates(t *testing.T) {
+	for name, data := range map[string]string{
+		"wrong size":   `{"templates":[{"id":"a","core":["Hog Rider"],"flex":[]}]}`,
+		"unknown role": `{"templates":[{"id":"a","core":["A","B","C","D","E","F","G"],"flex":[{"role":"tanks"}]}]}`,
+		"duplicate id": `{"templates":[{"id":"a","core":["A","B","C","D","E","F","G","H"]},{"id":"A","core":["A","B","C","D","E","F","G","H"]}]}`,
+	} {
+		if _, err := parseLibrary([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestFillPrefersListedOptions(t *testing.T) {
+	lib, err := Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hog, _ := lib.Find("hog-2.6")
+	owned := collection(map[string]int{
+		"Hog Rider": 14, "Musketeer": 14, "Cannon": 14, "Ice Golem": 14, "Ice Spirit": 14, "Skeletons": 14,
+		"The Log": 12, "Zap": 12, "Fireball": 12, "Poison": 12, "Arrows": 12, "Knight": 14,
+	})
+
+	result := Fill(hog, owned, FillOptions{Mode: FillBest})
+	if !result.Complete() || len(result.Decks) != 1 {
+		t.Fatalf("Fill() = %+v, want one complete deck", result)
+	}
+	best := result.Decks[0]
+	if !slices.Contains(best.Cards, "The Log") || !slices.Contains(best.Cards, "Fireball") {
+		t.Errorf("best deck = %v, want The Log and Fireball", best.Cards)
+	}
+	if len(best.Cards) != 8 || !best.Flex[0].Preferred {
+		t.Errorf("best deck = %+v", best)
+	}
+
+	variants := Fill(hog, owned, FillOptions{Mode: FillVariants, Limit: 3})
+	if len(variants.Decks) != 3 {
+		t.Fatalf("FillVariants returned %d decks, want 3", len(variants.Decks))
+	}
+	for i := 1; i < len(variants.Decks); i++ {
+		if variants.Decks[i].FillScore > variants.Decks[i-1].FillScore {
+			t.Errorf("variants not sorted by fill score")
+		}
+	}
+}
+
+func TestFillReportsMissingCards(t *testing.T) {
+	lib, err := Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hog, _ := lib.Find("hog-2.6")
+	result := Fill(hog, collection(map[string]int{
+		"Hog Rider": 14, "Musketeer": 14, "Cannon": 14, "Ice Spirit": 14, "Skeletons": 14, "Fireball": 12,
+	}), FillOptions{})
+	if result.Complete() {
+		t.Fatal("expected an incomplete fill")
+	}
+	if !slices.Equal(result.MissingCore, []string{"Ice Golem"}) {
+		t.Errorf("MissingCore = %v, want [Ice Golem]", result.MissingCore)
+	}
+	if !slices.Equal(result.EmptySlots, []int{1}) {
+		t.Errorf("EmptySlots = %v, want [1]", result.EmptySlots)
+	}
+}
+
+func TestParseFillMode(t *testing.T) {
+	if mode, err := ParseFillMode(""); err != nil || mode != FillBest {
+		t.Errorf("ParseFillMode(\"\") = %q, %v", mode, err)
+	}
+	if mode, err := ParseFillMode("Variants"); err != nil || mode != FillVariants {
+		t.Errorf("ParseFillMode(Variants) = %q, %v", mode, err)
+	}
+	if _, err := ParseFillMode("random"); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+}
@@ -0,0 +1,157 @@
+package deck
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Tower troop names as returned by the API in a player's supportCards.
+const (
+	TowerTroopPrincess      = "Tower Princess"
+	TowerTroopCannoneer     = "Cannoneer"
+	TowerTroopDaggerDuchess = "Dagger Duchess"
+)
+
+// DefaultTowerTroop is the tower troop every player owns.
+const DefaultTowerTroop = TowerTroopPrincess
+
+// TowerTroopProfile describes how a tower troop shifts a deck's defense
+// relative to Tower Princess. Each modifier is in defense score points and is
+// applied in full when the deck lacks that coverage, at half strength otherwise.
+type TowerTroopProfile struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	AntiTank    float64 `json:"anti_tank"`
+	AntiSwarm   float64 `json:"anti_swarm"`
+	AntiAir     float64 `json:"anti_air"`
+}
+
+var towerTroopProfiles = map[string]TowerTroopProfile{
+	TowerTroopPrincess: {
+		Name:        TowerTroopPrincess,
+		Description: "Long-range, steady damage; the balanced baseline",
+	},
+	TowerTroopCannoneer: {
+		Name:        TowerTroopCannoneer,
+		Description: "Heavy single shots that shred tanks but struggle against swarms",
+		AntiTank:    0.6,
+		AntiSwarm:   -0.6,
+		AntiAir:     -0.2,
+	},
+	TowerTroopDaggerDuchess: {
+		Name:        TowerTroopDaggerDuchess,
+		Description: "Fast dagger bursts that clean up small pushes and air, weaker against sustained tanks",
+		AntiTank:    -0.3,
+		AntiSwarm:   0.5,
+		AntiAir:     0.3,
+	},
+}
+
+// LookupTowerTroop returns the profile for a tower troop by name.
+func LookupTowerTroop(name string) (TowerTroopProfile, bool) {
+	for troopName, profile := range towerTroopProfiles {
+		if strings.EqualFold(troopName, strings.TrimSpace(name)) {
+			return profile, true
+		}
+	}
+	return TowerTroopProfile{}, false
+}
+
+// TowerTroopNames returns the known tower troops in alphabetical order.
+func TowerTroopNames() []string {
+	names := make([]string, 0, len(towerTroopProfiles))
+	for name := range towerTroopProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TowerTroopNeeds records which defensive gaps a tower troop could cover.
+type TowerTroopNeeds struct {
+	TankKillers bool
+	SwarmClear  bool
+	AirDefense  bool
+}
+
+// AnalyzeTowerTroopNeeds finds the defensive gaps in deckCards using matrix.
+func AnalyzeTowerTroopNeeds(matrix *CounterMatrix, deckCards []string) TowerTroopNeeds {
+	return TowerTroopNeeds{
+		TankKillers: matrix.CountCardsWithCapability(deckCards, CounterTankKillers) == 0,
+		SwarmClear: matrix.CountCardsWithCapability(deckCards, CounterSwarmClear) == 0 &&
+			matrix.CountCardsWithCapability(deckCards, CounterSplashDefense) == 0,
+		AirDefense: matrix.CountCardsWithCapability(deckCards, CounterAirDefense) < 2,
+	}
+}
+
+// DefenseAdjustment returns the defense score change from pairing this tower
+// troop with a deck that has the given needs.
+func (p TowerTroopProfile) DefenseAdjustment(needs TowerTroopNeeds) float64 {
+	return p.AntiTank*needWeight(needs.TankKillers) +
+		p.AntiSwarm*needWeight(needs.SwarmClear) +
+		p.AntiAir*needWeight(needs.AirDefense)
+}
+
+func needWeight(needed bool) float64 {
+	if needed {
+		return 1.0
+	}
+	return 0.5
+}
+
+// TowerTroopChoice is a recommended tower troop for a deck.
+type TowerTroopChoice struct {
+	Name       string  `json:"name"`
+	Adjustment float64 `json:"defense_adjustment"`
+	Reason     string  `json:"reason"`
+}
+
+// RecommendTowerTroop picks the tower troop from owned that best covers the
+// deck's defensive gaps. Tower Princess is always considered, and an empty
+// owned list considers every known troop.
+func RecommendTowerTroop(matrix *CounterMatrix, deckCards, owned []string) TowerTroopChoice {
+	needs := AnalyzeTowerTroopNeeds(matrix, deckCards)
+
+	candidates := TowerTroopNames()
+	if len(owned) > 0 {
+		candidates = []string{DefaultTowerTroop}
+		for _, name := range owned {
+			if profile, ok := LookupTowerTroop(name); ok && profile.Name != DefaultTowerTroop {
+				candidates = append(candidates, profile.Name)
+			}
+		}
+	}
+
+	best := towerTroopProfiles[DefaultTowerTroop]
+	bestAdjustment := best.DefenseAdjustment(needs)
+	for _, name := range candidates {
+		profile := towerTroopProfiles[name]
+		if adjustment := profile.DefenseAdjustment(needs); adjustment > bestAdjustment {
+			best, bestAdjustment = profile, adjustment
+		}
+	}
+
+	return TowerTroopChoice{
+		Name:       best.Name,
+		Adjustment: bestAdjustment,
+		Reason:     towerTroopReason(best, needs),
+	}
+}
+
+func towerTroopReason(profile TowerTroopProfile, needs TowerTroopNeeds) string {
+	var covers []string
+	if needs.TankKillers && profile.AntiTank > 0 {
+		covers = append(covers, "tank killing")
+	}
+	if needs.SwarmClear && profile.AntiSwarm > 0 {
+		covers = append(covers, "swarm clear")
+	}
+	if needs.AirDefense && profile.AntiAir > 0 {
+		covers = append(covers, "air defense")
+	}
+	if len(covers) == 0 {
+		return profile.Description
+	}
+	return fmt.Sprintf("covers the deck's lack of %s", strings.Join(covers, " and "))
+}
@@ -0,0 +1,78 @@
+package deck
+
+import "testing"
+
+func TestLookupTowerTroop(t *testing.T) {
+	profile, ok := LookupTowerTroop(" dagger duchess ")
+	if !ok || profile.Name != TowerTroopDaggerDuchess {
+		t.Fatalf("LookupTowerTroop() = %+v, %v", profile, ok)
+	}
+	if _, ok := LookupTowerTroop("Royal Giant"); ok {
+		t.Error("expected a regular card not to be a tower troop")
+	}
+}
+
+func TestTowerTroopDefenseAdjustment(t *testing.T) {
+	princess, _ := LookupTowerTroop(TowerTroopPrincess)
+	cannoneer, _ := LookupTowerTroop(TowerTroopCannoneer)
+
+	needsTank := TowerTroopNeeds{TankKillers: true}
+	if princess.DefenseAdjustment(needsTank) != 0 {
+		t.Error("expected Tower Princess to leave defense unchanged")
+	}
+	if cannoneer.DefenseAdjustment(needsTank) <= cannoneer.DefenseAdjustment(TowerTroopNeeds{}) {
+		t.Error("expected Cannoneer to help more when the deck lacks tank killers")
+	}
+	if cannoneer.DefenseAdjustment(TowerTroopNeeds{SwarmClear: true}) >= 0 {
+		t.Error("expected Cannoneer to hurt a deck with no swarm clear")
+	}
+}
+
+func TestRecommendTowerTroop(t *testing.T) {
+	matrix := NewCounterMatrixWithDefaults()
+	// No tank killers, plenty of splash and air coverage
+	deckCards := []string{"Giant", "Wizard", "Baby Dragon", "Musketeer", "Archers", "Fireball", "Zap", "Valkyrie"}
+
+	choice := RecommendTowerTroop(matrix, deckCards, []string{TowerTroopPrincess})
+	if choice.Name != TowerTroopPrincess {
+		t.Errorf("expected only owned troops to be recommended, got %s", choice.Name)
+	}
+
+	needs := AnalyzeTowerTroopNeeds(matrix, deckCards)
+	choice = RecommendTowerTroop(matrix, deckCards, nil)
+	best := towerTroopProfiles[choice.Name].DefenseAdjustment(needs)
+	for _, name := range TowerTroopNames() {
+		if adjustment := towerTroopProfiles[name].DefenseAdjustment(needs); adjustment > best {
+			t.Errorf("%s (%.2f) beats recommended %s (%.2f)", name, adjustment, choice.Name, best)
+		}
+	}
+	if choice.Reason == "" {
+		t.Error("expected a recommendation reason")
+	}
+}
+
+func TestBuilderRecommendsOwnedTowerTroop(t *testing.T) {
+	builder := NewBuilder("testdata")
+	analysis := counterTestAnalysis()
+
+	rec, err := builder.BuildDeckFromAnalysis(analysis)
+	if err != nil {
+		t.Fatalf("BuildDeckFromAnalysis() error = %v", err)
+	}
+	if rec.TowerTroop != "" {
+		t.Errorf("expected no tower troop without ownership data, got %q", rec.TowerTroop)
+	}
+
+	analysis.TowerTroops = []string{TowerTroopPrincess, TowerTroopCannoneer, TowerTroopDaggerDuchess}
+	rec, err = builder.BuildDeckFromAnalysis(analysis)
+	if err != nil {
+		t.Fatalf("BuildDeckFromAnalysis() error = %v", err)
+	}
+	if _, ok := LookupTowerTroop(rec.TowerTroop); !ok {
+		t.Fatalf("expected a known tower troop, got %q", rec.TowerTroop)
+	}
+	want := RecommendTowerTroop(LoadCounterMatrix("testdata", ""), rec.Deck, analysis.TowerTroops).Name
+	if rec.TowerTroop != want {
+		t.Errorf("TowerTroop = %q, want %q", rec.TowerTroop, want)
+	}
+}
@@ -150,6 +150,37 @@ func (cc *CardCandidate) HasRole() bool {
 	return cc.Role != nil
 }
 
+// StandardLevel returns the card's level on the shared 1-16 scale used by
+// combat stats. The API reports levels relative to each rarity's starting
+// level (a level 1 Epic is level 6), so those are shifted up. Levels whose
+// MaxLevel would overflow the shared scale are already standard.
+func (cc *CardCandidate) StandardLevel() int {
+	offset := config.GetStartingLevel(cc.Rarity) - 1
+	if offset <= 0 || cc.MaxLevel <= 0 || cc.MaxLevel+offset > config.GetMaxLevel(cc.Rarity) {
+		return cc.Level
+	}
+	return cc.Level + offset
+}
+
+// CombatStatsFor returns a card's combat stats at a standard level from
+// registry, or from the bundled dataset when registry is nil.
+func CombatStatsFor(registry *clashroyale.CardStatsRegistry, name string, level int) *clashroyale.CombatStats {
+	if registry == nil {
+		registry = clashroyale.DefaultStats()
+	}
+	return registry.StatsAtLevel(name, level)
+}
+
+// ApplyCombatStats fills in Stats for candidates that have none, scaled to
+// each card's level. A nil registry uses the bundled dataset.
+func ApplyCombatStats(cards []CardCandidate, registry *clashroyale.CardStatsRegistry) {
+	for i := range cards {
+		if cards[i].Stats == nil {
+			cards[i].Stats = CombatStatsFor(registry, cards[i].Name, cards[i].StandardLevel())
+		}
+	}
+}
+
 // DeckRecommendation represents a recommended 8-card deck with metadata
 type DeckRecommendation struct {
 	Deck           []string     `json:"deck"`
@@ -158,6 +189,7 @@ type DeckRecommendation struct {
 	AnalysisTime   string       `json:"analysis_time,omitempty"`
 	Notes          []string     `json:"notes"`
 	EvolutionSlots []string     `json:"evolution_slots,omitempty"`
+	TowerTroop     string       `json:"tower_troop,omitempty"`
 }
 
 // CardDetail provides detailed information about a card in a recommended deck
@@ -181,6 +213,32 @@ func (dr *DeckRecommendation) CalculateAvgElixir() float64 {
 	return roundToTwo(avg)
 }
 
+// Candidates converts the deck's card details to candidates for evaluation
+func (dr *DeckRecommendation) Candidates() []CardCandidate {
+	cards := make([]CardCandidate, 0, len(dr.DeckDetail))
+	for _, detail := range dr.DeckDetail {
+		role := CardRole(detail.Role)
+		if role == "" {
+			role = config.GetCardRole(detail.Name)
+		}
+		card := CardCandidate{
+			Name:              detail.Name,
+			Level:             detail.Level,
+			MaxLevel:          detail.MaxLevel,
+			Rarity:            detail.Rarity,
+			Elixir:            config.GetCardElixir(detail.Name, detail.Elixir),
+			EvolutionLevel:    detail.EvolutionLevel,
+			MaxEvolutionLevel: detail.MaxEvolutionLevel,
+		}
+		if role != "" {
+			card.Role = &role
+		}
+		card.Stats = CombatStatsFor(nil, card.Name, card.StandardLevel())
+		cards = append(cards, card)
+	}
+	return cards
+}
+
 // FormatEvolutionBadge returns a formatted evolution badge for a card.
 // Examples: "Evo 1", "Evo 2", or "" if no evolution.
 func FormatEvolutionBadge(evolutionLevel int) string {
@@ -29,7 +29,7 @@ func TestManager_EnsurePlayerDirectories(t *testing.T) {
 	tempDir := t.TempDir()
 	manager := NewManager(tempDir)
 
-	playerTag := "#TEST123"
+	playerTag := "#2PP0Q"
 	err := manager.ensurePlayerDirectories(playerTag)
 	if err != nil {
 		t.Fatalf("ensurePlayerDirectories failed: %v", err)
@@ -58,11 +58,11 @@ func TestManager_EnsurePlayerDirectories(t *testing.T) {
 func TestManager_GetPlayerEventDirCanonicalizesPlayerTag(t *testing.T) {
 	manager := NewManager(t.TempDir())
 
-	playerDir, err := manager.getPlayerEventDir(" abc123 ")
+	playerDir, err := manager.getPlayerEventDir(" 2pq0r ")
 	if err != nil {
 		t.Fatalf("getPlayerEventDir failed: %v", err)
 	}
-	if filepath.Base(playerDir) != "ABC123" {
+	if filepath.Base(playerDir) != "2PQ0R" {
 		t.Fatalf("expected canonical player directory, got %s", filepath.Base(playerDir))
 	}
 }
@@ -74,7 +74,7 @@ func TestManager_SaveEventDeck(t *testing.T) {
 	// Create test event deck
 	eventDeck := &EventDeck{
 		EventID:   "test_event_001",
-		PlayerTag: "#TEST123",
+		PlayerTag: "#2PP0Q",
 		EventName: "Grand Challenge",
 		EventType: EventTypeGrandChallenge,
 		StartTime: time.Now(),
@@ -174,7 +174,7 @@ func TestManager_SaveEventDeck_DifferentTypes(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			eventDeck := &EventDeck{
 				EventID:   "test_" + tt.name,
-				PlayerTag: "#TEST123",
+				PlayerTag: "#2PP0Q",
 				EventName: tt.name,
 				EventType: tt.eventType,
 				StartTime: time.Now(),
@@ -226,7 +226,7 @@ func TestManager_GetEventDecks(t *testing.T) {
 	decks := []*EventDeck{
 		{
 			EventID:   "deck_1",
-			PlayerTag: "#TEST123",
+			PlayerTag: "#2PP0Q",
 			EventName: "Grand Challenge 1",
 			EventType: EventTypeGrandChallenge,
 			StartTime: baseTime,
@@ -239,7 +239,7 @@ func TestManager_GetEventDecks(t *testing.T) {
 		},
 		{
 			EventID:   "deck_2",
-			PlayerTag: "#TEST123",
+			PlayerTag: "#2PP0Q",
 			EventName: "Classic Challenge 1",
 			EventType: EventTypeClassicChallenge,
 			StartTime: baseTime.Add(-2 * 24 * time.Hour), // 2 days ago
@@ -252,7 +252,7 @@ func TestManager_GetEventDecks(t *testing.T) {
 		},
 		{
 			EventID:   "deck_3",
-			PlayerTag: "#TEST123",
+			PlayerTag: "#2PP0Q",
 			EventName: "Tournament 1",
 			EventType: EventTypeTournament,
 			StartTime: baseTime.Add(-10 * 24 * time.Hour), // 10 days ago
@@ -273,7 +273,7 @@ func TestManager_GetEventDecks(t *testing.T) {
 	}
 
 	t.Run("Get all decks", func(t *testing.T) {
-		retrieved, err := manager.GetEventDecks("#TEST123", nil)
+		retrieved, err := manager.GetEventDecks("#2PP0Q", nil)
 		if err != nil {
 			t.Fatalf("GetEventDecks failed: %v", err)
 		}
@@ -296,7 +296,7 @@ func TestManager_GetEventDecks(t *testing.T) {
 			EventType: &eventType,
 		}
 
-		retrieved, err := manager.GetEventDecks("#TEST123", opts)
+		retrieved, err := manager.GetEventDecks("#2PP0Q", opts)
 		if err != nil {
 			t.Fatalf("GetEventDecks failed: %v", err)
 		}
@@ -315,7 +315,7 @@ func TestManager_GetEventDecks(t *testing.T) {
 			DaysBack: &daysBack,
 		}
 
-		retrieved, err := manager.GetEventDecks("#TEST123", opts)
+		retrieved, err := manager.GetEventDecks("#2PP0Q", opts)
 		if err != nil {
 			t.Fatalf("GetEventDecks failed: %v", err)
 		}
@@ -332,7 +332,7 @@ func TestManager_GetEventDecks(t *testing.T) {
 			Limit: &limit,
 		}
 
-		retrieved, err := manager.GetEventDecks("#TEST123", opts)
+		retrieved, err := manager.GetEventDecks("#2PP0Q", opts)
 		if err != nil {
 			t.Fatalf("GetEventDecks failed: %v", err)
 		}
@@ -398,7 +398,7 @@ func TestManager_ImportFromBattleLogs(t *testing.T) {
 		},
 	}
 
-	imported, err := manager.ImportFromBattleLogs(battleLogs, "#TEST123")
+	imported, err := manager.ImportFromBattleLogs(battleLogs, "#2PP0Q")
 	if err != nil {
 		t.Fatalf("ImportFromBattleLogs failed: %v", err)
 	}
@@ -408,7 +408,7 @@ func TestManager_ImportFromBattleLogs(t *testing.T) {
 	}
 
 	// Verify decks were saved
-	retrieved, err := manager.GetEventDecks("#TEST123", nil)
+	retrieved, err := manager.GetEventDecks("#2PP0Q", nil)
 	if err != nil {
 		t.Fatalf("GetEventDecks failed: %v", err)
 	}
@@ -423,7 +423,7 @@ func TestManager_GetCollection(t *testing.T) {
 	manager := NewManager(tempDir)
 
 	t.Run("Empty collection", func(t *testing.T) {
-		collection, err := manager.GetCollection("#TEST123")
+		collection, err := manager.GetCollection("#2PP0Q")
 		if err != nil {
 			t.Fatalf("GetCollection failed: %v", err)
 		}
@@ -440,7 +440,7 @@ func TestManager_GetCollection(t *testing.T) {
 		// Save a deck first
 		eventDeck := &EventDeck{
 			EventID:     "test_collection",
-			PlayerTag:   "#TEST123",
+			PlayerTag:   "#2PP0Q",
 			EventName:   "Test Event",
 			EventType:   EventTypeChallenge,
 			StartTime:   time.Now(),
@@ -454,7 +454,7 @@ func TestManager_GetCollection(t *testing.T) {
 		}
 
 		// Get collection
-		collection, err := manager.GetCollection("#TEST123")
+		collection, err := manager.GetCollection("#2PP0Q")
 		if err != nil {
 			t.Fatalf("GetCollection failed: %v", err)
 		}
@@ -462,8 +462,8 @@ func TestManager_GetCollection(t *testing.T) {
 		if len(collection.Decks) != 1 {
 			t.Errorf("Collection should have 1 deck, got %d", len(collection.Decks))
 		}
-		if collection.PlayerTag != "#TEST123" {
-			t.Errorf("PlayerTag = %s, want #TEST123", collection.PlayerTag)
+		if collection.PlayerTag != "#2PP0Q" {
+			t.Errorf("PlayerTag = %s, want #2PP0Q", collection.PlayerTag)
 		}
 	})
 }
@@ -475,7 +475,7 @@ func TestManager_DeleteEventDeck(t *testing.T) {
 	// Create and save an event deck
 	eventDeck := &EventDeck{
 		EventID:     "delete_test",
-		PlayerTag:   "#TEST123",
+		PlayerTag:   "#2PP0Q",
 		EventName:   "Delete Test",
 		EventType:   EventTypeChallenge,
 		StartTime:   time.Now(),
@@ -489,7 +489,7 @@ func TestManager_DeleteEventDeck(t *testing.T) {
 	}
 
 	// Verify deck exists
-	decks, err := manager.GetEventDecks("#TEST123", nil)
+	decks, err := manager.GetEventDecks("#2PP0Q", nil)
 	if err != nil {
 		t.Fatalf("GetEventDecks failed: %v", err)
 	}
@@ -498,13 +498,13 @@ func TestManager_DeleteEventDeck(t *testing.T) {
 	}
 
 	// Delete the deck
-	err = manager.DeleteEventDeck("#TEST123", "delete_test")
+	err = manager.DeleteEventDeck("#2PP0Q", "delete_test")
 	if err != nil {
 		t.Fatalf("DeleteEventDeck failed: %v", err)
 	}
 
 	// Verify deck was deleted
-	decks, err = manager.GetEventDecks("#TEST123", nil)
+	decks, err = manager.GetEventDecks("#2PP0Q", nil)
 	if err != nil {
 		t.Fatalf("GetEventDecks failed: %v", err)
 	}
@@ -513,7 +513,7 @@ func TestManager_DeleteEventDeck(t *testing.T) {
 	}
 
 	// Verify collection was updated
-	collection, err := manager.GetCollection("#TEST123")
+	collection, err := manager.GetCollection("#2PP0Q")
 	if err != nil {
 		t.Fatalf("GetCollection failed: %v", err)
 	}
@@ -527,7 +527,7 @@ func TestManager_DeleteEventDeck_NotFound(t *testing.T) {
 	manager := NewManager(tempDir)
 
 	// Try to delete non-existent deck
-	err := manager.DeleteEventDeck("#TEST123", "nonexistent")
+	err := manager.DeleteEventDeck("#2PP0Q", "nonexistent")
 	if err == nil {
 		t.Error("DeleteEventDeck should return error for non-existent deck")
 	}
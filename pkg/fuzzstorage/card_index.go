@@ -0,0 +1,78 @@
+package fuzzstorage
+
+import (
+	"fmt"
+	"strings"
+)
+
+// cardIndexSchema keeps deck_cards, one row per card of every stored deck,
+// in step with top_decks so card filters are indexed lookups instead of
+// scans of the cards JSON. Card names compare case-insensitively.
+const cardIndexSchema = `
+	CREATE TABLE IF NOT EXISTS deck_cards (
+		deck_id INTEGER NOT NULL,
+		card TEXT NOT NULL COLLATE NOCASE,
+		PRIMARY KEY (deck_id, card)
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_deck_cards_card ON deck_cards(card);
+
+	CREATE TRIGGER IF NOT EXISTS deck_cards_insert AFTER INSERT ON top_decks
+	BEGIN
+		INSERT OR IGNORE INTO deck_cards (deck_id, card)
+		SELECT NEW.id, value FROM json_each(NEW.cards);
+	END;
+
+	CREATE TRIGGER IF NOT EXISTS deck_cards_update AFTER UPDATE OF cards ON top_decks
+	BEGIN
+		DELETE FROM deck_cards WHERE deck_id = OLD.id;
+		INSERT OR IGNORE INTO deck_cards (deck_id, card)
+		SELECT NEW.id, value FROM json_each(NEW.cards);
+	END;
+
+	CREATE TRIGGER IF NOT EXISTS deck_cards_delete AFTER DELETE ON top_decks
+	BEGIN
+		DELETE FROM deck_cards WHERE deck_id = OLD.id;
+	END;
+`
+
+// initCardIndex creates the card index and fills it in for decks saved
+// before it existed
+func (s *Storage) initCardIndex() error {
+	if _, err := s.db.Exec(cardIndexSchema); err != nil {
+		return fmt.Errorf("failed to create card index: %w", err)
+	}
+	_, err := s.db.Exec(`
+		INSERT OR IGNORE INTO deck_cards (deck_id, card)
+		SELECT top_decks.id, cards.value
+		FROM top_decks, json_each(top_decks.cards) AS cards
+		WHERE top_decks.id NOT IN (SELECT deck_id FROM deck_cards)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to backfill card index: %w", err)
+	}
+	return nil
+}
+
+// appendCardFilters adds the card membership filters of opts to a query
+// over top_decks
+func appendCardFilters(query *strings.Builder, args []any, opts QueryOptions) []any {
+	for _, card := range opts.RequireAllCards {
+		query.WriteString(" AND id IN (SELECT deck_id FROM deck_cards WHERE card = ?)")
+		args = append(args, strings.TrimSpace(card))
+	}
+	for _, anyOf := range [][]string{opts.RequireAnyCards, opts.WinConditions} {
+		if len(anyOf) == 0 {
+			continue
+		}
+		query.WriteString(" AND id IN (SELECT deck_id FROM deck_cards WHERE card IN (?" + strings.Repeat(", ?", len(anyOf)-1) + "))")
+		for _, card := range anyOf {
+			args = append(args, strings.TrimSpace(card))
+		}
+	}
+	for _, card := range opts.ExcludeCards {
+		query.WriteString(" AND id NOT IN (SELECT deck_id FROM deck_cards WHERE card = ?)")
+		args = append(args, strings.TrimSpace(card))
+	}
+	return args
+}
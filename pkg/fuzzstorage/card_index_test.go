@@ -0,0 +1,119 @@
+package fuzzstorage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func cardIndexStorage(t *testing.T, path string) *Storage {
+	t.Helper()
+	storage, err := NewStorage(path)
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	t.Cleanup(func() { storage.Close() })
+	return storage
+}
+
+func deckNames(entries []DeckEntry) []string {
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.Name
+	}
+	return names
+}
+
+func TestQueryCardFilters(t *testing.T) {
+	storage := cardIndexStorage(t, filepath.Join(t.TempDir(), "fuzz_cards.db"))
+	decks := map[string][]string{
+		"Hog Log":   {"Hog Rider", "Musketeer", "Cannon", "Ice Golem", "Ice Spirit", "Skeletons", "Fireball", "The Log"},
+		"Hog Zap":   {"Hog Rider", "Musketeer", "Cannon", "Ice Golem", "Ice Spirit", "Skeletons", "Fireball", "Zap"},
+		"Golem":     {"Golem", "Night Witch", "Baby Dragon", "Lumberjack", "Tornado", "Lightning", "Barbarian Barrel", "Mega Minion"},
+		"MK Bridge": {"Mega Knight", "Royal Hogs", "Bandit", "Zap", "Fireball", "Musketeer", "Bats", "Inferno Dragon"},
+	}
+	score := 9.0
+	for name, cards := range decks {
+		score--
+		if _, _, err := storage.InsertDeck(&DeckEntry{Name: name, Cards: cards, OverallScore: score, Archetype: "cycle", EvaluatedAt: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	query := func(opts QueryOptions) map[string]bool {
+		t.Helper()
+		entries, err := storage.Query(opts)
+		if err != nil {
+			t.Fatalf("Query(%+v) error = %v", opts, err)
+		}
+		found := make(map[string]bool)
+		for _, name := range deckNames(entries) {
+			found[name] = true
+		}
+		return found
+	}
+
+	got := query(QueryOptions{RequireAllCards: []string{"hog rider", "THE LOG"}})
+	if len(got) != 1 || !got["Hog Log"] {
+		t.Errorf("with Hog Rider and The Log = %v, want Hog Log", got)
+	}
+	// Golem must not match Ice Golem
+	if got := query(QueryOptions{RequireAllCards: []string{"Golem"}}); len(got) != 1 || !got["Golem"] {
+		t.Errorf("with Golem = %v, want only the Golem deck", got)
+	}
+	if got := query(QueryOptions{ExcludeCards: []string{"Mega Knight", "Golem"}}); len(got) != 2 || got["MK Bridge"] || got["Golem"] {
+		t.Errorf("without Mega Knight and Golem = %v, want the two hog decks", got)
+	}
+	if got := query(QueryOptions{WinConditions: []string{"Hog Rider", "Royal Hogs"}, RequireAnyCards: []string{"Zap"}}); len(got) != 2 || !got["Hog Zap"] || !got["MK Bridge"] {
+		t.Errorf("hog win condition with Zap = %v, want Hog Zap and MK Bridge", got)
+	}
+
+	histogram, err := storage.ArchetypeHistogram(QueryOptions{RequireAllCards: []string{"Fireball"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if histogram["cycle"] != 3 {
+		t.Errorf("histogram with Fireball = %v, want 3 cycle decks", histogram)
+	}
+
+	entries, err := storage.Query(QueryOptions{RequireAllCards: []string{"Golem"}})
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("Query() = %v, %v", entries, err)
+	}
+	if err := storage.DeleteDeck(entries[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	var indexed int
+	if err := storage.db.QueryRow("SELECT COUNT(*) FROM deck_cards WHERE deck_id = ?", entries[0].ID).Scan(&indexed); err != nil {
+		t.Fatal(err)
+	}
+	if indexed != 0 {
+		t.Errorf("deleted deck still has %d indexed cards", indexed)
+	}
+}
+
+func TestCardIndexBackfillsExistingDecks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fuzz_cards.db")
+	storage, err := NewStorage(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cards := []string{"Hog Rider", "Musketeer", "Cannon", "Ice Golem", "Ice Spirit", "Skeletons", "Fireball", "The Log"}
+	if _, _, err := storage.InsertDeck(&DeckEntry{Name: "Hog", Cards: cards, OverallScore: 8, EvaluatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	// Simulate a database saved before the card index existed
+	if _, err := storage.db.Exec("DROP TRIGGER deck_cards_insert; DROP TRIGGER deck_cards_update; DROP TRIGGER deck_cards_delete; DROP TABLE deck_cards"); err != nil {
+		t.Fatal(err)
+	}
+	storage.Close()
+
+	reopened := cardIndexStorage(t, path)
+	entries, err := reopened.Query(QueryOptions{RequireAllCards: []string{"The Log"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("Query() after backfill returned %d decks, want 1", len(entries))
+	}
+}
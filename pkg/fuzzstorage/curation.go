@@ -0,0 +1,248 @@
+package fuzzstorage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/internal/closeutil"
+	"github.com/klauer/clash-royale-api/go/internal/storageutil"
+)
+
+// ErrDeckNotFound is returned when no stored deck matches an ID or name
+var ErrDeckNotFound = errors.New("deck not found")
+
+// NormalizeTag lowercases a tag and turns spaces into dashes. Tags may use
+// letters, digits, '-', '_', and ':'.
+func NormalizeTag(tag string) (string, error) {
+	normalized := strings.Join(strings.Fields(strings.ToLower(tag)), "-")
+	if normalized == "" {
+		return "", fmt.Errorf("tag is empty")
+	}
+	for _, r := range normalized {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == ':') {
+			return "", fmt.Errorf("tag %q: only letters, digits, '-', '_', and ':' are allowed", tag)
+		}
+	}
+	return normalized, nil
+}
+
+func normalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		t, err := NormalizeTag(tag)
+		if err != nil {
+			return nil, err
+		}
+		normalized = append(normalized, t)
+	}
+	slices.Sort(normalized)
+	return slices.Compact(normalized), nil
+}
+
+// encodeTags stores tags as ",a,b," so a tag can be matched with instr
+// without LIKE wildcards; no tags is NULL
+func encodeTags(tags []string) any {
+	if len(tags) == 0 {
+		return nil
+	}
+	return "," + strings.Join(tags, ",") + ","
+}
+
+func decodeTags(encoded string) []string {
+	trimmed := strings.Trim(encoded, ",")
+	if trimmed == "" {
+		return nil
+	}
+	return strings.Split(trimmed, ",")
+}
+
+// appendCurationFilters adds the tag and favorite filters of opts to a
+// top_decks query
+func appendCurationFilters(query *strings.Builder, args []any, opts QueryOptions) []any {
+	for _, tag := range opts.Tags {
+		normalized, err := NormalizeTag(tag)
+		if err != nil {
+			// An invalid tag cannot be stored, so nothing matches it
+			query.WriteString(" AND 0")
+			continue
+		}
+		query.WriteString(" AND instr(tags, ?) > 0")
+		args = append(args, ","+normalized+",")
+	}
+	if opts.FavoritesOnly {
+		query.WriteString(" AND favorite = 1")
+	}
+	return args
+}
+
+// GetDeck returns the stored deck with id
+func (s *Storage) GetDeck(id int) (*DeckEntry, error) {
+	return s.getDeck("id = ?", id)
+}
+
+// FindDeck returns the stored deck whose ID or name is ref
+func (s *Storage) FindDeck(ref string) (*DeckEntry, error) {
+	ref = strings.TrimSpace(ref)
+	if id, err := strconv.Atoi(ref); err == nil {
+		return s.GetDeck(id)
+	}
+	return s.getDeck("name = ? COLLATE NOCASE", ref)
+}
+
+func (s *Storage) getDeck(where string, arg any) (*DeckEntry, error) {
+	rows, err := s.db.Query(`
+		SELECT id, deck_hash, cards, overall_score, attack_score, defense_score,
+		       synergy_score, versatility_score, avg_elixir, archetype, archetype_conf, evaluated_at, run_id,
+		       evaluation_version, hybrid_primary, hybrid_secondary, name, tags, favorite, notes
+		FROM top_decks
+		WHERE `+where+`
+		ORDER BY id
+		LIMIT 1
+	`, arg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deck: %w", err)
+	}
+	defer closeutil.WithLog("fuzzstorage", rows, "deck rows")
+
+	entries, err := s.scanRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrDeckNotFound, arg)
+	}
+	return &entries[0], nil
+}
+
+// AddTags adds tags to a deck and returns its tags
+func (s *Storage) AddTags(id int, tags ...string) ([]string, error) {
+	added, err := normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateTags(id, func(current []string) []string {
+		return append(current, added...)
+	})
+}
+
+// RemoveTags removes tags from a deck and returns its remaining tags
+func (s *Storage) RemoveTags(id int, tags ...string) ([]string, error) {
+	removed, err := normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateTags(id, func(current []string) []string {
+		return slices.DeleteFunc(current, func(tag string) bool { return slices.Contains(removed, tag) })
+	})
+}
+
+func (s *Storage) updateTags(id int, update func([]string) []string) ([]string, error) {
+	var encoded sql.NullString
+	err := s.db.QueryRow("SELECT tags FROM top_decks WHERE id = ?", id).Scan(&encoded)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %d", ErrDeckNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deck tags: %w", err)
+	}
+
+	tags := update(decodeTags(encoded.String))
+	slices.Sort(tags)
+	tags = slices.Compact(tags)
+	if _, err := s.db.Exec("UPDATE top_decks SET tags = ? WHERE id = ?", encodeTags(tags), id); err != nil {
+		return nil, fmt.Errorf("failed to update deck tags: %w", err)
+	}
+	return tags, nil
+}
+
+// SetFavorite marks or unmarks a deck as a favorite
+func (s *Storage) SetFavorite(id int, favorite bool) error {
+	return s.updateDeckColumn(id, "favorite", favorite)
+}
+
+// SetNotes replaces a deck's notes; empty notes clear them
+func (s *Storage) SetNotes(id int, notes string) error {
+	return s.updateDeckColumn(id, "notes", nullableString(strings.TrimSpace(notes)))
+}
+
+func (s *Storage) updateDeckColumn(id int, column string, value any) error {
+	result, err := s.db.Exec("UPDATE top_decks SET "+column+" = ? WHERE id = ?", value, id)
+	if err != nil {
+		return fmt.Errorf("failed to update deck %s: %w", column, err)
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update deck %s: %w", column, err)
+	}
+	if updated == 0 {
+		return fmt.Errorf("%w: %d", ErrDeckNotFound, id)
+	}
+	return nil
+}
+
+// mergeCuration adds the tags, favorite mark, and notes of from to the deck
+// with id; notes that differ are appended
+func (s *Storage) mergeCuration(id int, from DeckEntry) error {
+	return mergeCuration(s.db, id, from)
+}
+
+// mergeCuration is Storage.mergeCuration on db, which may be a transaction
+func mergeCuration(db storageutil.Querier, id int, from DeckEntry) error {
+	added, err := normalizeTags(from.Tags)
+	if err != nil {
+		return err
+	}
+	var encoded, notes sql.NullString
+	var favorite bool
+	err = db.QueryRow("SELECT tags, favorite, notes FROM top_decks WHERE id = ?", id).Scan(&encoded, &favorite, &notes)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %d", ErrDeckNotFound, id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read deck curation: %w", err)
+	}
+
+	tags := append(decodeTags(encoded.String), added...)
+	slices.Sort(tags)
+	tags = slices.Compact(tags)
+	merged := notes.String
+	if extra := strings.TrimSpace(from.Notes); extra != "" && extra != merged {
+		if merged != "" {
+			extra = fmt.Sprintf("%s\n%s", merged, extra)
+		}
+		merged = extra
+	}
+	if _, err := db.Exec("UPDATE top_decks SET tags = ?, favorite = ?, notes = ? WHERE id = ?",
+		encodeTags(tags), favorite || from.Favorite, nullableString(merged), id); err != nil {
+		return fmt.Errorf("failed to merge deck curation: %w", err)
+	}
+	return nil
+}
+
+// TagCounts returns the number of stored decks with each tag
+func (s *Storage) TagCounts() (map[string]int, error) {
+	rows, err := s.db.Query("SELECT tags FROM top_decks WHERE tags IS NOT NULL")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deck tags: %w", err)
+	}
+	defer closeutil.WithLog("fuzzstorage", rows, "deck tag rows")
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var encoded string
+		if err := rows.Scan(&encoded); err != nil {
+			return nil, fmt.Errorf("failed to scan deck tags: %w", err)
+		}
+		for _, tag := range decodeTags(encoded) {
+			counts[tag]++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate deck tags: %w", err)
+	}
+	return counts, nil
+}
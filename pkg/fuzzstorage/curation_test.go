@@ -0,0 +1,159 @@
+package fuzzstorage
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func newCurationStorage(t *testing.T, decks int) (*Storage, []int) {
+	t.Helper()
+	storage, err := NewStorage(filepath.Join(t.TempDir(), "fuzz_curation.db"))
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	t.Cleanup(func() { storage.Close() })
+
+	ids := make([]int, decks)
+	for i := range decks {
+		entry := &DeckEntry{
+			Cards:        []string{fmt.Sprintf("Card %d", i), "B", "C", "D", "E", "F", "G", "H"},
+			OverallScore: 9 - float64(i),
+			AvgElixir:    3.5,
+			Archetype:    "beatdown",
+			EvaluatedAt:  time.Now(),
+			Name:         fmt.Sprintf("Deck %d", i),
+		}
+		if _, _, err := storage.InsertDeck(entry); err != nil {
+			t.Fatal(err)
+		}
+		ids[i] = entry.ID
+	}
+	return storage, ids
+}
+
+func TestNormalizeTag(t *testing.T) {
+	tests := map[string]string{"Ladder": "ladder", "  push to  7k ": "push-to-7k", "season:42": "season:42"}
+	for tag, want := range tests {
+		if got, err := NormalizeTag(tag); err != nil || got != want {
+			t.Errorf("NormalizeTag(%q) = %q, %v, want %q", tag, got, err, want)
+		}
+	}
+	for _, tag := range []string{"", "a,b", "100%"} {
+		if _, err := NormalizeTag(tag); err == nil {
+			t.Errorf("NormalizeTag(%q) should fail", tag)
+		}
+	}
+}
+
+func TestDeckTags(t *testing.T) {
+	storage, ids := newCurationStorage(t, 3)
+
+	tags, err := storage.AddTags(ids[0], "Ladder", "tournament", "ladder")
+	if err != nil || !slices.Equal(tags, []string{"ladder", "tournament"}) {
+		t.Fatalf("AddTags() = %v, %v", tags, err)
+	}
+	if _, err := storage.AddTags(ids[1], "ladder"); err != nil {
+		t.Fatal(err)
+	}
+	if tags, err = storage.RemoveTags(ids[0], "tournament"); err != nil || !slices.Equal(tags, []string{"ladder"}) {
+		t.Fatalf("RemoveTags() = %v, %v", tags, err)
+	}
+
+	ladder, err := storage.Query(QueryOptions{Tags: []string{"LADDER"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ladder) != 2 || !slices.Equal(ladder[0].Tags, []string{"ladder"}) {
+		t.Errorf("Query(ladder) = %+v", ladder)
+	}
+	// A tag that is a substring of another does not match it
+	if decks, _ := storage.Query(QueryOptions{Tags: []string{"ladd"}}); len(decks) != 0 {
+		t.Errorf("Query(ladd) = %d decks, want 0", len(decks))
+	}
+	histogram, err := storage.ArchetypeHistogram(QueryOptions{Tags: []string{"ladder"}})
+	if err != nil || histogram["beatdown"] != 2 {
+		t.Errorf("ArchetypeHistogram(ladder) = %v, %v", histogram, err)
+	}
+
+	counts, err := storage.TagCounts()
+	if err != nil || counts["ladder"] != 2 || len(counts) != 1 {
+		t.Errorf("TagCounts() = %v, %v", counts, err)
+	}
+
+	if _, err := storage.AddTags(9999, "ladder"); !errors.Is(err, ErrDeckNotFound) {
+		t.Errorf("AddTags(missing) error = %v, want ErrDeckNotFound", err)
+	}
+}
+
+func TestDeckFavoritesAndNotes(t *testing.T) {
+	storage, ids := newCurationStorage(t, 2)
+
+	if err := storage.SetFavorite(ids[1], true); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.SetNotes(ids[1], "  Beats Golem at 7k  "); err != nil {
+		t.Fatal(err)
+	}
+
+	favorites, err := storage.Query(QueryOptions{FavoritesOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(favorites) != 1 || favorites[0].ID != ids[1] || favorites[0].Notes != "Beats Golem at 7k" {
+		t.Fatalf("favorites = %+v", favorites)
+	}
+
+	// Re-scoring keeps the curation
+	favorites[0].OverallScore = 9.9
+	if err := storage.UpdateDeck(&favorites[0]); err != nil {
+		t.Fatal(err)
+	}
+	found, err := storage.FindDeck("deck 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found.Favorite || found.Notes == "" || found.OverallScore != 9.9 {
+		t.Errorf("FindDeck() = %+v, want the re-scored favorite", found)
+	}
+
+	if err := storage.SetNotes(ids[1], ""); err != nil {
+		t.Fatal(err)
+	}
+	if deck, _ := storage.GetDeck(ids[1]); deck.Notes != "" {
+		t.Errorf("notes = %q after clearing", deck.Notes)
+	}
+	if err := storage.SetFavorite(9999, true); !errors.Is(err, ErrDeckNotFound) {
+		t.Errorf("SetFavorite(missing) error = %v, want ErrDeckNotFound", err)
+	}
+	if _, err := storage.FindDeck("no such deck"); !errors.Is(err, ErrDeckNotFound) {
+		t.Errorf("FindDeck(missing) error = %v, want ErrDeckNotFound", err)
+	}
+}
+
+func TestApplyRetentionKeepsCuratedDecks(t *testing.T) {
+	storage, ids := newCurationStorage(t, 5)
+	if err := storage.SetFavorite(ids[2], true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := storage.AddTags(ids[3], "keep"); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.SetNotes(ids[4], "test later"); err != nil {
+		t.Fatal(err)
+	}
+
+	pruned, err := storage.ApplyRetention(RetentionPolicy{PerArchetype: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pruned != 1 {
+		t.Errorf("pruned %d decks, want only the uncurated runner-up", pruned)
+	}
+	if _, err := storage.GetDeck(ids[1]); !errors.Is(err, ErrDeckNotFound) {
+		t.Errorf("uncurated deck %d should be pruned, got %v", ids[1], err)
+	}
+}
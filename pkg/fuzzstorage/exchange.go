@@ -0,0 +1,252 @@
+package fuzzstorage
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/closeutil"
+	"github.com/klauer/clash-royale-api/go/pkg/deckhash"
+)
+
+// ExchangeVersion is the format version written by WriteExchange
+const ExchangeVersion = 1
+
+// Exchange is a shared deck database: the decks of one storage with their
+// scores and curation. Lineage and battle outcomes stay local.
+type Exchange struct {
+	Version    int            `json:"version"`
+	ExportedAt time.Time      `json:"exported_at"`
+	Decks      []ExchangeDeck `json:"decks"`
+}
+
+// ExchangeDeck is one deck of an Exchange
+type ExchangeDeck struct {
+	Cards             []string  `json:"cards"`
+	Name              string    `json:"name,omitempty"`
+	OverallScore      float64   `json:"overall_score"`
+	AttackScore       float64   `json:"attack_score"`
+	DefenseScore      float64   `json:"defense_score"`
+	SynergyScore      float64   `json:"synergy_score"`
+	VersatilityScore  float64   `json:"versatility_score"`
+	AvgElixir         float64   `json:"avg_elixir"`
+	Archetype         string    `json:"archetype"`
+	ArchetypeConf     float64   `json:"archetype_conf"`
+	HybridPrimary     string    `json:"hybrid_primary,omitempty"`
+	HybridSecondary   string    `json:"hybrid_secondary,omitempty"`
+	EvaluatedAt       time.Time `json:"evaluated_at"`
+	EvaluationVersion string    `json:"evaluation_version,omitempty"`
+	Tags              []string  `json:"tags,omitempty"`
+	Favorite          bool      `json:"favorite,omitempty"`
+	Notes             string    `json:"notes,omitempty"`
+}
+
+// NewExchange wraps decks for export
+func NewExchange(decks []DeckEntry, exportedAt time.Time) Exchange {
+	exchange := Exchange{
+		Version:    ExchangeVersion,
+		ExportedAt: exportedAt.UTC(),
+		Decks:      make([]ExchangeDeck, 0, len(decks)),
+	}
+	for _, d := range decks {
+		exchange.Decks = append(exchange.Decks, ExchangeDeck{
+			Cards:             d.Cards,
+			Name:              d.Name,
+			OverallScore:      d.OverallScore,
+			AttackScore:       d.AttackScore,
+			DefenseScore:      d.DefenseScore,
+			SynergyScore:      d.SynergyScore,
+			VersatilityScore:  d.VersatilityScore,
+			AvgElixir:         d.AvgElixir,
+			Archetype:         d.Archetype,
+			ArchetypeConf:     d.ArchetypeConf,
+			HybridPrimary:     d.HybridPrimary,
+			HybridSecondary:   d.HybridSecondary,
+			EvaluatedAt:       d.EvaluatedAt.UTC(),
+			EvaluationVersion: d.EvaluationVersion,
+			Tags:              d.Tags,
+			Favorite:          d.Favorite,
+			Notes:             d.Notes,
+		})
+	}
+	return exchange
+}
+
+// Entries returns the exchanged decks as storage entries without IDs
+func (e Exchange) Entries() []DeckEntry {
+	entries := make([]DeckEntry, 0, len(e.Decks))
+	for _, d := range e.Decks {
+		entries = append(entries, DeckEntry{
+			Cards:             d.Cards,
+			Name:              d.Name,
+			OverallScore:      d.OverallScore,
+			AttackScore:       d.AttackScore,
+			DefenseScore:      d.DefenseScore,
+			SynergyScore:      d.SynergyScore,
+			VersatilityScore:  d.VersatilityScore,
+			AvgElixir:         d.AvgElixir,
+			Archetype:         d.Archetype,
+			ArchetypeConf:     d.ArchetypeConf,
+			HybridPrimary:     d.HybridPrimary,
+			HybridSecondary:   d.HybridSecondary,
+			EvaluatedAt:       d.EvaluatedAt,
+			EvaluationVersion: d.EvaluationVersion,
+			Tags:              d.Tags,
+			Favorite:          d.Favorite,
+			Notes:             d.Notes,
+		})
+	}
+	return entries
+}
+
+// WriteExchange writes exchange as JSON to path, gzip-compressed when path
+// ends in .gz
+func WriteExchange(path string, exchange Exchange) (err error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to close export file: %w", closeErr)
+		}
+	}()
+
+	var w io.Writer = file
+	var gz *gzip.Writer
+	if strings.HasSuffix(strings.ToLower(path), ".gz") {
+		gz = gzip.NewWriter(file)
+		w = gz
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(exchange); err != nil {
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			return fmt.Errorf("failed to write export file: %w", err)
+		}
+	}
+	return nil
+}
+
+// ReadExchange reads an export written by WriteExchange. Compressed files
+// are recognized by their gzip header, whatever their name.
+func ReadExchange(path string) (Exchange, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Exchange{}, fmt.Errorf("failed to open import file: %w", err)
+	}
+	defer closeutil.WithLog("fuzzstorage", file, "import file")
+
+	reader := bufio.NewReader(file)
+	var r io.Reader = reader
+	if magic, err := reader.Peek(2); err == nil && bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(reader)
+		if err != nil {
+			return Exchange{}, fmt.Errorf("failed to read import file: %w", err)
+		}
+		defer closeutil.WithLog("fuzzstorage", gz, "import gzip reader")
+		r = gz
+	}
+
+	var exchange Exchange
+	if err := json.NewDecoder(r).Decode(&exchange); err != nil {
+		return Exchange{}, fmt.Errorf("failed to parse import file: %w", err)
+	}
+	if exchange.Version < 1 || exchange.Version > ExchangeVersion {
+		return Exchange{}, fmt.Errorf("unsupported export version %d (supported: 1-%d)", exchange.Version, ExchangeVersion)
+	}
+	for i, d := range exchange.Decks {
+		if len(d.Cards) != 8 {
+			return Exchange{}, fmt.Errorf("deck %d has %d cards, want 8", i+1, len(d.Cards))
+		}
+		if _, err := normalizeTags(d.Tags); err != nil {
+			return Exchange{}, fmt.Errorf("deck %d: %w", i+1, err)
+		}
+	}
+	return exchange, nil
+}
+
+// ConflictPolicy picks between a stored deck and an imported copy of it
+type ConflictPolicy string
+
+const (
+	// ConflictHigherScore keeps the copy with the higher overall score
+	ConflictHigherScore ConflictPolicy = "score"
+	// ConflictNewer keeps the copy evaluated most recently
+	ConflictNewer ConflictPolicy = "newer"
+)
+
+// ParseConflictPolicy parses a conflict policy name; empty means
+// ConflictHigherScore.
+func ParseConflictPolicy(name string) (ConflictPolicy, error) {
+	switch policy := ConflictPolicy(strings.ToLower(strings.TrimSpace(name))); policy {
+	case "":
+		return ConflictHigherScore, nil
+	case ConflictHigherScore, ConflictNewer:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown conflict policy: %s (supported: score, newer)", name)
+	}
+}
+
+// MergeResult counts what MergeDecks did with each imported deck
+type MergeResult struct {
+	// Added decks were not stored yet
+	Added int `json:"added"`
+	// Replaced decks overwrote the scores of the stored copy
+	Replaced int `json:"replaced"`
+	// Kept decks lost to the stored copy
+	Kept int `json:"kept"`
+}
+
+// MergeDecks adds imported decks to the storage. A deck that is already
+// stored keeps the scores of whichever copy policy picks and its local name;
+// tags, favorite marks, and notes of both copies are combined either way.
+func (s *Storage) MergeDecks(decks []DeckEntry, policy ConflictPolicy) (MergeResult, error) {
+	var result MergeResult
+	for _, d := range decks {
+		imported := d
+		imported.ID = 0
+		existing, err := s.getDeck("deck_hash = ?", deckhash.DeckHash(imported.Cards))
+		switch {
+		case errors.Is(err, ErrDeckNotFound):
+			if _, _, err := s.InsertDeck(&imported); err != nil {
+				return result, err
+			}
+			result.Added++
+		case err != nil:
+			return result, err
+		case importWins(imported, *existing, policy):
+			imported.ID = existing.ID
+			imported.Name = ""
+			if err := s.UpdateDeck(&imported); err != nil {
+				return result, err
+			}
+			result.Replaced++
+		default:
+			imported.ID = existing.ID
+			result.Kept++
+		}
+		if err := s.mergeCuration(imported.ID, d); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+func importWins(imported, existing DeckEntry, policy ConflictPolicy) bool {
+	if policy == ConflictNewer {
+		return imported.EvaluatedAt.After(existing.EvaluatedAt)
+	}
+	return imported.OverallScore > existing.OverallScore
+}
@@ -0,0 +1,130 @@
+package fuzzstorage
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestExchangeRoundTrip(t *testing.T) {
+	storage, ids := newCurationStorage(t, 2)
+	if _, err := storage.AddTags(ids[0], "ladder"); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.SetNotes(ids[0], "strong vs beatdown"); err != nil {
+		t.Fatal(err)
+	}
+	decks, err := storage.Query(QueryOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"decks.json", "decks.json.gz"} {
+		path := filepath.Join(t.TempDir(), name)
+		if err := WriteExchange(path, NewExchange(decks, time.Now())); err != nil {
+			t.Fatalf("WriteExchange(%s) error = %v", name, err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gzipped := data[0] == 0x1f && data[1] == 0x8b; gzipped != (filepath.Ext(name) == ".gz") {
+			t.Errorf("%s: gzipped = %v", name, gzipped)
+		}
+
+		exchange, err := ReadExchange(path)
+		if err != nil {
+			t.Fatalf("ReadExchange(%s) error = %v", name, err)
+		}
+		entries := exchange.Entries()
+		if len(entries) != 2 || entries[0].Name != "Deck 0" || !slices.Equal(entries[0].Tags, []string{"ladder"}) ||
+			entries[0].Notes != "strong vs beatdown" || entries[0].OverallScore != 9 {
+			t.Errorf("%s: entries = %+v", name, entries)
+		}
+	}
+}
+
+func TestReadExchangeRejectsInvalidFiles(t *testing.T) {
+	for name, data := range map[string]string{
+		"future version": `{"version":99,"decks":[]}`,
+		"short deck":     `{"version":1,"decks":[{"cards":["A","B"]}]}`,
+		"bad tag":        `{"version":1,"decks":[{"cards":["A","B","C","D","E","F","G","H"],"tags":["100%"]}]}`,
+	} {
+		path := filepath.Join(t.TempDir(), "decks.json")
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ReadExchange(path); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestMergeDecksConflictPolicies(t *testing.T) {
+	old := time.Now().Add(-48 * time.Hour)
+	newer := time.Now()
+	stored := DeckEntry{
+		Cards: []string{"Card 0", "B", "C", "D", "E", "F", "G", "H"}, OverallScore: 9, EvaluatedAt: old,
+		Tags: []string{"ladder"},
+	}
+
+	tests := []struct {
+		policy    ConflictPolicy
+		want      MergeResult
+		wantScore float64
+	}{
+		{ConflictHigherScore, MergeResult{Added: 1, Kept: 1}, 9},
+		{ConflictNewer, MergeResult{Added: 1, Replaced: 1}, 7},
+	}
+	for _, tt := range tests {
+		storage, ids := newCurationStorage(t, 1)
+		if err := storage.UpdateDeck(&DeckEntry{ID: ids[0], OverallScore: stored.OverallScore, EvaluatedAt: old}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := storage.AddTags(ids[0], stored.Tags...); err != nil {
+			t.Fatal(err)
+		}
+
+		imported := []DeckEntry{
+			{Cards: stored.Cards, OverallScore: 7, EvaluatedAt: newer, Name: "Shared", Tags: []string{"tournament"}, Favorite: true},
+			{Cards: []string{"New", "B", "C", "D", "E", "F", "G", "H"}, OverallScore: 8, EvaluatedAt: newer, Name: "Fresh", Notes: "from a friend"},
+		}
+		result, err := storage.MergeDecks(imported, tt.policy)
+		if err != nil {
+			t.Fatalf("%s: MergeDecks() error = %v", tt.policy, err)
+		}
+		if result != tt.want {
+			t.Errorf("%s: result = %+v, want %+v", tt.policy, result, tt.want)
+		}
+
+		merged, err := storage.GetDeck(ids[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if merged.OverallScore != tt.wantScore || merged.Name != "Deck 0" {
+			t.Errorf("%s: merged deck score %v name %q, want %v and local name", tt.policy, merged.OverallScore, merged.Name, tt.wantScore)
+		}
+		if !slices.Equal(merged.Tags, []string{"ladder", "tournament"}) || !merged.Favorite {
+			t.Errorf("%s: merged curation = %v favorite %v", tt.policy, merged.Tags, merged.Favorite)
+		}
+
+		added, err := storage.FindDeck("Fresh")
+		if err != nil || added.Notes != "from a friend" {
+			t.Errorf("%s: FindDeck(Fresh) = %+v, %v", tt.policy, added, err)
+		}
+	}
+}
+
+func TestParseConflictPolicy(t *testing.T) {
+	if policy, err := ParseConflictPolicy(""); err != nil || policy != ConflictHigherScore {
+		t.Errorf("ParseConflictPolicy(\"\") = %q, %v", policy, err)
+	}
+	if policy, err := ParseConflictPolicy("Newer"); err != nil || policy != ConflictNewer {
+		t.Errorf("ParseConflictPolicy(Newer) = %q, %v", policy, err)
+	}
+	if _, err := ParseConflictPolicy("oldest"); err == nil {
+		t.Error("expected an error for an unknown policy")
+	}
+}
@@ -0,0 +1,174 @@
+package fuzzstorage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/closeutil"
+	"github.com/klauer/clash-royale-api/go/pkg/deckhash"
+)
+
+const lineageSchema = `
+	CREATE TABLE IF NOT EXISTS deck_lineage (
+		deck_hash TEXT NOT NULL,
+		parent_hash TEXT NOT NULL,
+		parent_cards TEXT NOT NULL,
+		operation TEXT NOT NULL,
+		generation INTEGER NOT NULL DEFAULT 0,
+		recorded_at DATETIME NOT NULL,
+		PRIMARY KEY (deck_hash, parent_hash)
+	);
+`
+
+// LineageRecord is how a deck was derived from its parent decks. Decks are
+// matched by their cards, so parents need not be saved decks.
+type LineageRecord struct {
+	Cards     []string
+	Parents   [][]string
+	Operation string
+	// Generation is the GA generation that produced the deck, 0 outside the GA
+	Generation int
+}
+
+// LineageNode is a deck in a family tree built by Lineage.
+type LineageNode struct {
+	Cards []string
+	// Deck is the saved deck with these cards, nil when it was never saved
+	Deck *DeckEntry
+	// Operation, Generation, and RecordedAt describe how and when the deck
+	// was derived from Parents; Operation is empty when no parents were
+	// recorded
+	Operation  string
+	Generation int
+	RecordedAt time.Time
+	Parents    []*LineageNode
+	// Repeated marks an ancestor already shown elsewhere in the tree, whose
+	// parents are not listed again
+	Repeated bool
+}
+
+// SaveLineage stores the parents of decks. A deck keeps the lineage recorded
+// first; later records for it are ignored. It returns the number of decks
+// whose lineage was new.
+func (s *Storage) SaveLineage(records []LineageRecord) (int, error) {
+	if len(records) == 0 {
+		return 0, nil
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	saved := 0
+	now := time.Now()
+	for _, record := range records {
+		if len(record.Parents) == 0 {
+			continue
+		}
+		deckHash := deckhash.DeckHash(record.Cards)
+		var exists int
+		err := tx.QueryRow("SELECT COUNT(*) FROM deck_lineage WHERE deck_hash = ?", deckHash).Scan(&exists)
+		if err != nil {
+			return 0, fmt.Errorf("failed to check lineage: %w", err)
+		}
+		if exists > 0 {
+			continue
+		}
+		for _, parent := range record.Parents {
+			parentJSON, err := json.Marshal(parent)
+			if err != nil {
+				return 0, fmt.Errorf("failed to marshal parent cards: %w", err)
+			}
+			if _, err := tx.Exec(`
+				INSERT OR IGNORE INTO deck_lineage (
+					deck_hash, parent_hash, parent_cards, operation, generation, recorded_at
+				) VALUES (?, ?, ?, ?, ?, ?)
+			`, deckHash, deckhash.DeckHash(parent), string(parentJSON), record.Operation,
+				record.Generation, now); err != nil {
+				return 0, fmt.Errorf("failed to save lineage: %w", err)
+			}
+		}
+		saved++
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit lineage: %w", err)
+	}
+	return saved, nil
+}
+
+// Lineage returns the family tree of the deck with cards, following parents
+// up to depth generations back. An ancestor reached along several paths is
+// expanded once and marked Repeated elsewhere.
+func (s *Storage) Lineage(cards []string, depth int) (*LineageNode, error) {
+	expanded := make(map[string]bool)
+	return s.lineageNode(cards, depth, expanded)
+}
+
+func (s *Storage) lineageNode(cards []string, depth int, expanded map[string]bool) (*LineageNode, error) {
+	deckHash := deckhash.DeckHash(cards)
+	node := &LineageNode{Cards: cards}
+	deck, err := s.getDeck("deck_hash = ?", deckHash)
+	switch {
+	case err == nil:
+		node.Deck = deck
+		node.Cards = deck.Cards
+	case !errors.Is(err, ErrDeckNotFound):
+		return nil, err
+	}
+	if expanded[deckHash] {
+		node.Repeated = true
+		return node, nil
+	}
+	expanded[deckHash] = true
+	if depth <= 0 {
+		return node, nil
+	}
+
+	parents, err := s.recordedParents(node, deckHash)
+	if err != nil {
+		return nil, err
+	}
+	for _, parent := range parents {
+		parentNode, err := s.lineageNode(parent, depth-1, expanded)
+		if err != nil {
+			return nil, err
+		}
+		node.Parents = append(node.Parents, parentNode)
+	}
+	return node, nil
+}
+
+// recordedParents returns the parents stored for deckHash and fills in how
+// node was derived from them
+func (s *Storage) recordedParents(node *LineageNode, deckHash string) ([][]string, error) {
+	rows, err := s.db.Query(`
+		SELECT parent_cards, operation, generation, recorded_at
+		FROM deck_lineage
+		WHERE deck_hash = ?
+		ORDER BY rowid
+	`, deckHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query lineage: %w", err)
+	}
+	defer closeutil.WithLog("fuzzstorage", rows, "lineage rows")
+
+	var parents [][]string
+	for rows.Next() {
+		var parentJSON string
+		if err := rows.Scan(&parentJSON, &node.Operation, &node.Generation, &node.RecordedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan lineage: %w", err)
+		}
+		var parent []string
+		if err := json.Unmarshal([]byte(parentJSON), &parent); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal parent cards: %w", err)
+		}
+		parents = append(parents, parent)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating lineage: %w", err)
+	}
+	return parents, nil
+}
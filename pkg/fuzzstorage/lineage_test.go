@@ -0,0 +1,63 @@
+package fuzzstorage
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestLineage(t *testing.T) {
+	storage, err := NewStorage(filepath.Join(t.TempDir(), "fuzz_lineage.db"))
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer storage.Close()
+
+	a := []string{"A", "B", "C", "D", "E", "F", "G", "H"}
+	b := []string{"I", "J", "K", "L", "M", "N", "O", "P"}
+	child := []string{"A", "B", "C", "D", "M", "N", "O", "P"}
+	best := []string{"Q", "B", "C", "D", "M", "N", "O", "P"}
+	for _, cards := range [][]string{a, best} {
+		entry := &DeckEntry{Cards: cards, OverallScore: 8, Archetype: "cycle", EvaluatedAt: time.Now()}
+		if _, _, err := storage.InsertDeck(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	saved, err := storage.SaveLineage([]LineageRecord{
+		{Cards: best, Parents: [][]string{child}, Operation: "mutation", Generation: 4},
+		{Cards: child, Parents: [][]string{a, b}, Operation: "crossover", Generation: 3},
+		// A second lineage for a deck is ignored
+		{Cards: best, Parents: [][]string{b}, Operation: "variation"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved != 2 {
+		t.Errorf("SaveLineage() = %d, want 2", saved)
+	}
+
+	tree, err := storage.Lineage(best, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tree.Deck == nil || tree.Operation != "mutation" || tree.Generation != 4 || tree.RecordedAt.IsZero() || len(tree.Parents) != 1 {
+		t.Fatalf("root = %+v, want the saved deck mutated from one parent", tree)
+	}
+	parent := tree.Parents[0]
+	if parent.Deck != nil || parent.Operation != "crossover" || len(parent.Parents) != 2 {
+		t.Fatalf("parent = %+v, want an unsaved crossover child", parent)
+	}
+	if grandparent := parent.Parents[0]; grandparent.Deck == nil || !slices.Equal(grandparent.Cards, a) || grandparent.Operation != "" {
+		t.Errorf("grandparent = %+v, want saved deck a without recorded parents", grandparent)
+	}
+
+	shallow, err := storage.Lineage(best, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shallow.Parents) != 1 || len(shallow.Parents[0].Parents) != 0 {
+		t.Errorf("Lineage(depth 1) = %+v, want only the parent", shallow)
+	}
+}
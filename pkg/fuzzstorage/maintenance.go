@@ -0,0 +1,204 @@
+package fuzzstorage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/klauer/clash-royale-api/go/internal/closeutil"
+	"github.com/klauer/clash-royale-api/go/internal/storageutil"
+	"github.com/klauer/clash-royale-api/go/pkg/deckhash"
+)
+
+// MaintenanceReport summarizes a Maintain pass
+type MaintenanceReport = storageutil.MaintenanceReport
+
+// orphanChecks are the index tables kept in step with top_decks. Lineage
+// and battle outcomes are keyed by cards and outlive pruned decks on
+// purpose, so they are not orphans.
+var orphanChecks = []storageutil.OrphanCheck{
+	{Table: "deck_cards", KeyColumn: "deck_id", DeckTable: "top_decks"},
+}
+
+// Maintain compacts a long-lived database. It deletes decks that hold the
+// same cards as a better-scoring deck, moving their tags, favorite flag,
+// and notes onto that deck, and moves lineage and battle outcomes recorded
+// under an old hash of a deck onto its canonical hash. It deletes index rows
+// whose deck is gone, then rebuilds the indexes and vacuums. These changes
+// run in one transaction; with dryRun it is rolled back, so the report only
+// counts what would change.
+func (s *Storage) Maintain(dryRun bool) (MaintenanceReport, error) {
+	report := MaintenanceReport{DBPath: s.dbPath, SizeBeforeBytes: storageutil.FileSize(s.dbPath), DryRun: dryRun}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return report, fmt.Errorf("failed to begin maintenance transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	records, winners, err := queryDeckHashMigrationRows(tx)
+	if err != nil {
+		return report, err
+	}
+	if err := mergeDuplicateCuration(tx, records, winners); err != nil {
+		return report, err
+	}
+	if err := storageutil.ApplyDeckHashMigration(tx, "top_decks", records, winners); err != nil {
+		return report, err
+	}
+	report.DuplicatesRemoved = storageutil.CountDeckHashDuplicates(records, winners)
+	if report.RelinkedRows, err = relinkDeckHashes(tx, records); err != nil {
+		return report, err
+	}
+	if report.OrphansRemoved, err = storageutil.RemoveOrphans(tx, orphanChecks, false); err != nil {
+		return report, err
+	}
+	if dryRun {
+		report.SizeAfterBytes = report.SizeBeforeBytes
+		return report, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return report, fmt.Errorf("failed to commit maintenance: %w", err)
+	}
+
+	if err := storageutil.Compact(s.db); err != nil {
+		return report, err
+	}
+	report.SizeAfterBytes = storageutil.FileSize(s.dbPath)
+	return report, nil
+}
+
+// mergeDuplicateCuration copies the curation of each duplicate deck onto the
+// deck that replaces it
+func mergeDuplicateCuration(db storageutil.Querier, records []deckHashMigrationRow, winners map[string]deckHashMigrationRow) error {
+	for _, row := range records {
+		keptID := winners[row.Canonical].ID
+		if !row.Valid || keptID == row.ID {
+			continue
+		}
+		var duplicate DeckEntry
+		var encoded, notes sql.NullString
+		if err := db.QueryRow("SELECT tags, favorite, notes FROM top_decks WHERE id = ?", row.ID).
+			Scan(&encoded, &duplicate.Favorite, &notes); err != nil {
+			return fmt.Errorf("failed to read curation of deck %d: %w", row.ID, err)
+		}
+		duplicate.Tags, duplicate.Notes = decodeTags(encoded.String), notes.String
+		if err := mergeCuration(db, keptID, duplicate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// relinkDeckHashes moves lineage and battle outcomes recorded under an old
+// hash of a deck onto its canonical hash: the hashes of stored decks, and
+// the parent hashes of lineage, which are recomputed from the parent cards.
+// It returns the number of rows changed or dropped.
+func relinkDeckHashes(db storageutil.Querier, records []deckHashMigrationRow) (int64, error) {
+	var relinked int64
+	for _, row := range records {
+		if !row.Valid || row.DeckHash == row.Canonical {
+			continue
+		}
+		n, err := relinkDeckHash(db, row.DeckHash, row.Canonical)
+		if err != nil {
+			return 0, err
+		}
+		relinked += n
+	}
+
+	parents, err := staleLineageParents(db)
+	if err != nil {
+		return 0, err
+	}
+	for from, to := range parents {
+		n, err := relinkLineageParent(db, from, to)
+		if err != nil {
+			return 0, err
+		}
+		relinked += n
+	}
+	return relinked, nil
+}
+
+// relinkDeckHash moves the outcomes and lineage of the deck hashed from onto
+// hash to. A deck keeps the lineage recorded first, so when to already has
+// lineage, that of from is dropped.
+func relinkDeckHash(db storageutil.Querier, from, to string) (int64, error) {
+	moved, err := execCount(db, "UPDATE deck_outcomes SET deck_hash = ? WHERE deck_hash = ?", to, from)
+	if err != nil {
+		return 0, fmt.Errorf("failed to relink deck outcomes: %w", err)
+	}
+
+	var existing int
+	if err := db.QueryRow("SELECT COUNT(*) FROM deck_lineage WHERE deck_hash = ?", to).Scan(&existing); err != nil {
+		return 0, fmt.Errorf("failed to check lineage: %w", err)
+	}
+	statement := "UPDATE deck_lineage SET deck_hash = ? WHERE deck_hash = ?"
+	args := []any{to, from}
+	if existing > 0 {
+		statement, args = "DELETE FROM deck_lineage WHERE deck_hash = ?", []any{from}
+	}
+	lineage, err := execCount(db, statement, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to relink deck lineage: %w", err)
+	}
+
+	parents, err := relinkLineageParent(db, from, to)
+	if err != nil {
+		return 0, err
+	}
+	return moved + lineage + parents, nil
+}
+
+// relinkLineageParent points lineage at parent hash to instead of from. A
+// deck that already lists to as a parent drops its row for from.
+func relinkLineageParent(db storageutil.Querier, from, to string) (int64, error) {
+	moved, err := execCount(db, "UPDATE OR IGNORE deck_lineage SET parent_hash = ? WHERE parent_hash = ?", to, from)
+	if err != nil {
+		return 0, fmt.Errorf("failed to relink lineage parents: %w", err)
+	}
+	dropped, err := execCount(db, "DELETE FROM deck_lineage WHERE parent_hash = ?", from)
+	if err != nil {
+		return 0, fmt.Errorf("failed to drop duplicate lineage parents: %w", err)
+	}
+	return moved + dropped, nil
+}
+
+// staleLineageParents maps each lineage parent hash that differs from the
+// canonical hash of its parent cards onto that canonical hash
+func staleLineageParents(db storageutil.Querier) (map[string]string, error) {
+	rows, err := db.Query("SELECT DISTINCT parent_hash, parent_cards FROM deck_lineage")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query lineage parents: %w", err)
+	}
+	defer closeutil.WithLog("fuzzstorage", rows, "lineage parent rows")
+
+	stale := make(map[string]string)
+	for rows.Next() {
+		var parentHash, parentJSON string
+		if err := rows.Scan(&parentHash, &parentJSON); err != nil {
+			return nil, fmt.Errorf("failed to scan lineage parent: %w", err)
+		}
+		var cards []string
+		if err := json.Unmarshal([]byte(parentJSON), &cards); err != nil {
+			// Leave rows with unreadable cards as they are
+			continue
+		}
+		if canonical := deckhash.DeckHash(cards); canonical != parentHash {
+			stale[parentHash] = canonical
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate lineage parents: %w", err)
+	}
+	return stale, nil
+}
+
+func execCount(db storageutil.Querier, statement string, args ...any) (int64, error) {
+	result, err := db.Exec(statement, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
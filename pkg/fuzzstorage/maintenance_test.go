@@ -0,0 +1,109 @@
+package fuzzstorage
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deckhash"
+)
+
+func TestMaintainMergesDuplicateCuration(t *testing.T) {
+	storage, err := NewStorage(filepath.Join(t.TempDir(), "fuzz_maintain.db"))
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer storage.Close()
+
+	cards := []string{"Hog Rider", "Musketeer", "Cannon", "Ice Golem", "Ice Spirit", "Skeletons", "Fireball", "The Log"}
+	kept := &DeckEntry{Cards: cards, OverallScore: 9, Archetype: "cycle", EvaluatedAt: time.Now()}
+	if _, _, err := storage.InsertDeck(kept); err != nil {
+		t.Fatal(err)
+	}
+	// A copy under a stale hash, as written by an older version
+	if _, err := storage.db.Exec(`
+		INSERT INTO top_decks (
+			deck_hash, cards, overall_score, attack_score, defense_score, synergy_score,
+			versatility_score, avg_elixir, archetype, archetype_conf, evaluated_at, tags, favorite, notes
+		) VALUES (?, (SELECT cards FROM top_decks WHERE id = ?), 7, 7, 7, 7, 7, 2.6, 'cycle', 0.7, CURRENT_TIMESTAMP, ',ladder,', 1, 'beats golem')
+	`, deckhash.LegacyCompute(cards), kept.ID); err != nil {
+		t.Fatalf("failed to insert duplicate: %v", err)
+	}
+	if _, err := storage.db.Exec("INSERT INTO deck_cards (deck_id, card) VALUES (999, 'Zap')"); err != nil {
+		t.Fatalf("failed to insert orphan: %v", err)
+	}
+	// An outcome and lineage recorded under the stale hash, as the deck and
+	// as a parent
+	legacy, canonical := deckhash.LegacyCompute(cards), deckhash.DeckHash(cards)
+	child := deckhash.DeckHash([]string{"Hog Rider", "Musketeer", "Cannon", "Ice Golem", "Ice Spirit", "Skeletons", "Fireball", "Zap"})
+	if _, err := storage.db.Exec(`
+		INSERT INTO deck_outcomes (battle_key, deck_hash, player_tag, result, battle_time, recorded_at)
+		VALUES ('b1', ?, '#2PP', 'win', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP),
+		       ('b2', ?, '#2PP', 'loss', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP);
+		INSERT INTO deck_lineage (deck_hash, parent_hash, parent_cards, operation, recorded_at)
+		VALUES (?, ?, '["Golem"]', 'mutation', CURRENT_TIMESTAMP),
+		       (?, ?, (SELECT cards FROM top_decks WHERE id = ?), 'mutation', CURRENT_TIMESTAMP);
+	`, legacy, canonical, legacy, deckhash.DeckHash([]string{"Golem"}), child, legacy, kept.ID); err != nil {
+		t.Fatalf("failed to insert stale references: %v", err)
+	}
+	staleReferences := func() int {
+		var count int
+		if err := storage.db.QueryRow(`
+			SELECT (SELECT COUNT(*) FROM deck_outcomes WHERE deck_hash = ?1)
+			     + (SELECT COUNT(*) FROM deck_lineage WHERE deck_hash = ?1 OR parent_hash = ?1)
+		`, legacy).Scan(&count); err != nil {
+			t.Fatal(err)
+		}
+		return count
+	}
+
+	report, err := storage.Maintain(true)
+	if err != nil {
+		t.Fatalf("Maintain(dry run) error = %v", err)
+	}
+	if report.DuplicatesRemoved != 1 || report.OrphansRemoved != 1 || report.RelinkedRows != 3 {
+		t.Errorf("dry run report = %+v, want 1 duplicate, 1 orphan, and 3 relinked rows", report)
+	}
+	if count, _ := storage.Count(); count != 2 || staleReferences() != 3 {
+		t.Fatalf("dry run changed storage: %d decks, %d stale references", count, staleReferences())
+	}
+	if deck, err := storage.GetDeck(kept.ID); err != nil || deck.Favorite {
+		t.Fatalf("dry run merged curation: %+v, %v", deck, err)
+	}
+
+	if report, err = storage.Maintain(false); err != nil {
+		t.Fatalf("Maintain() error = %v", err)
+	}
+	if report.DuplicatesRemoved != 1 || report.OrphansRemoved != 1 || report.RelinkedRows != 3 || report.SizeAfterBytes == 0 {
+		t.Errorf("report = %+v", report)
+	}
+	if count, _ := storage.Count(); count != 1 {
+		t.Fatalf("Count() = %d after Maintain, want 1", count)
+	}
+	deck, err := storage.GetDeck(kept.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deck.OverallScore != 9 || !deck.Favorite || deck.Notes != "beats golem" || !slices.Equal(deck.Tags, []string{"ladder"}) {
+		t.Errorf("kept deck = %+v, want the 9.0 deck with the duplicate's curation", deck)
+	}
+	var orphans int
+	if err := storage.db.QueryRow("SELECT COUNT(*) FROM deck_cards WHERE deck_id NOT IN (SELECT id FROM top_decks)").Scan(&orphans); err != nil || orphans != 0 {
+		t.Errorf("orphaned deck_cards rows = %d, %v", orphans, err)
+	}
+	if stale := staleReferences(); stale != 0 {
+		t.Errorf("%d rows still use the stale hash", stale)
+	}
+	decks := []DeckEntry{*deck}
+	if err := storage.AttachRecords(decks); err != nil {
+		t.Fatal(err)
+	}
+	if decks[0].Record.Games() != 2 {
+		t.Errorf("kept deck record = %+v, want both battles", decks[0].Record)
+	}
+	var parent string
+	if err := storage.db.QueryRow("SELECT parent_hash FROM deck_lineage WHERE deck_hash = ?", child).Scan(&parent); err != nil || parent != canonical {
+		t.Errorf("child's parent hash = %q, %v, want the canonical hash", parent, err)
+	}
+}
@@ -0,0 +1,145 @@
+package fuzzstorage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/closeutil"
+	"github.com/klauer/clash-royale-api/go/pkg/deckhash"
+)
+
+const outcomesSchema = `
+	CREATE TABLE IF NOT EXISTS deck_outcomes (
+		battle_key TEXT PRIMARY KEY,
+		deck_hash TEXT NOT NULL,
+		player_tag TEXT NOT NULL,
+		result TEXT NOT NULL,
+		battle_time DATETIME NOT NULL,
+		recorded_at DATETIME NOT NULL
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_outcomes_deck_hash ON deck_outcomes(deck_hash);
+`
+
+// Battle results recorded by RecordOutcomes
+const (
+	ResultWin  = "win"
+	ResultLoss = "loss"
+	ResultDraw = "draw"
+)
+
+// BattleOutcome is the result of a real battle played with a deck.
+type BattleOutcome struct {
+	// BattleKey identifies the battle so it is only counted once
+	BattleKey  string
+	PlayerTag  string
+	Cards      []string
+	Result     string
+	BattleTime time.Time
+}
+
+// DeckRecord is the real-battle record of a saved deck.
+type DeckRecord struct {
+	Wins   int
+	Losses int
+	Draws  int
+}
+
+// Games returns the number of recorded battles
+func (r DeckRecord) Games() int {
+	return r.Wins + r.Losses + r.Draws
+}
+
+// WinRate returns the share of recorded battles won (0.0-1.0), or 0 when
+// there are none
+func (r DeckRecord) WinRate() float64 {
+	if r.Games() == 0 {
+		return 0
+	}
+	return float64(r.Wins) / float64(r.Games())
+}
+
+// RecordOutcomes stores the results of battles played with saved decks.
+// Battles with decks that are not saved, and battles already recorded, are
+// skipped. Results are matched by cards, so a deck keeps its record when it
+// is re-scored or saved again after being pruned. It returns the number of
+// battles recorded.
+func (s *Storage) RecordOutcomes(outcomes []BattleOutcome) (int, error) {
+	if len(outcomes) == 0 {
+		return 0, nil
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	recorded := 0
+	now := time.Now()
+	for _, outcome := range outcomes {
+		switch outcome.Result {
+		case ResultWin, ResultLoss, ResultDraw:
+		default:
+			return 0, fmt.Errorf("unknown battle result %q", outcome.Result)
+		}
+		deckHash := deckhash.DeckHash(outcome.Cards)
+		var saved int
+		if err := tx.QueryRow("SELECT COUNT(*) FROM top_decks WHERE deck_hash = ?", deckHash).Scan(&saved); err != nil {
+			return 0, fmt.Errorf("failed to look up deck: %w", err)
+		}
+		if saved == 0 {
+			continue
+		}
+		result, err := tx.Exec(`
+			INSERT OR IGNORE INTO deck_outcomes (
+				battle_key, deck_hash, player_tag, result, battle_time, recorded_at
+			) VALUES (?, ?, ?, ?, ?, ?)
+		`, outcome.BattleKey, deckHash, outcome.PlayerTag, outcome.Result, outcome.BattleTime.UTC(), now)
+		if err != nil {
+			return 0, fmt.Errorf("failed to record outcome: %w", err)
+		}
+		if n, err := result.RowsAffected(); err == nil && n > 0 {
+			recorded++
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit outcomes: %w", err)
+	}
+	return recorded, nil
+}
+
+// AttachRecords fills in the Record of each deck from the recorded battle
+// outcomes.
+func (s *Storage) AttachRecords(decks []DeckEntry) error {
+	if len(decks) == 0 {
+		return nil
+	}
+	rows, err := s.db.Query(`
+		SELECT deck_hash,
+		       SUM(result = 'win'), SUM(result = 'loss'), SUM(result = 'draw')
+		FROM deck_outcomes
+		GROUP BY deck_hash
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to query outcomes: %w", err)
+	}
+	defer closeutil.WithLog("fuzzstorage", rows, "outcome rows")
+
+	records := make(map[string]DeckRecord)
+	for rows.Next() {
+		var deckHash string
+		var record DeckRecord
+		if err := rows.Scan(&deckHash, &record.Wins, &record.Losses, &record.Draws); err != nil {
+			return fmt.Errorf("failed to scan outcome: %w", err)
+		}
+		records[deckHash] = record
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating outcomes: %w", err)
+	}
+
+	for i := range decks {
+		decks[i].Record = records[deckhash.DeckHash(decks[i].Cards)]
+	}
+	return nil
+}
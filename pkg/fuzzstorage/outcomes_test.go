@@ -0,0 +1,67 @@
+package fuzzstorage
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRecordOutcomes(t *testing.T) {
+	storage, err := NewStorage(filepath.Join(t.TempDir(), "fuzz_outcomes.db"))
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer storage.Close()
+
+	saved := []string{"A", "B", "C", "D", "E", "F", "G", "H"}
+	other := []string{"I", "J", "K", "L", "M", "N", "O", "P"}
+	entry := &DeckEntry{Cards: saved, OverallScore: 8, Archetype: "cycle", EvaluatedAt: time.Now()}
+	if _, _, err := storage.InsertDeck(entry); err != nil {
+		t.Fatal(err)
+	}
+
+	// Cards in a different order still match the saved deck
+	reordered := slices.Clone(saved)
+	slices.Reverse(reordered)
+	at := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	outcomes := []BattleOutcome{
+		{BattleKey: "1", PlayerTag: "#TAG", Cards: saved, Result: ResultWin, BattleTime: at},
+		{BattleKey: "2", PlayerTag: "#TAG", Cards: reordered, Result: ResultWin, BattleTime: at.Add(time.Minute)},
+		{BattleKey: "3", PlayerTag: "#TAG", Cards: saved, Result: ResultLoss, BattleTime: at.Add(2 * time.Minute)},
+		{BattleKey: "4", PlayerTag: "#TAG", Cards: other, Result: ResultWin, BattleTime: at.Add(3 * time.Minute)},
+	}
+	recorded, err := storage.RecordOutcomes(outcomes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recorded != 3 {
+		t.Errorf("recorded %d outcomes, want 3 (the unsaved deck is skipped)", recorded)
+	}
+
+	// Seeing the same battles again records nothing new
+	if recorded, err := storage.RecordOutcomes(outcomes[:2]); err != nil || recorded != 0 {
+		t.Errorf("re-recording: %d, %v; want 0, nil", recorded, err)
+	}
+	if _, err := storage.RecordOutcomes([]BattleOutcome{{BattleKey: "5", Cards: saved, Result: "forfeit"}}); err == nil {
+		t.Error("expected an error for an unknown result")
+	}
+
+	decks, err := storage.GetTopN(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decks = append(decks, DeckEntry{Cards: other})
+	if err := storage.AttachRecords(decks); err != nil {
+		t.Fatal(err)
+	}
+	if want := (DeckRecord{Wins: 2, Losses: 1}); decks[0].Record != want {
+		t.Errorf("saved deck record = %+v, want %+v", decks[0].Record, want)
+	}
+	if decks[1].Record.Games() != 0 {
+		t.Errorf("unsaved deck record = %+v, want none", decks[1].Record)
+	}
+	if rate := decks[0].Record.WinRate(); rate < 0.66 || rate > 0.67 {
+		t.Errorf("WinRate() = %v, want 2/3", rate)
+	}
+}
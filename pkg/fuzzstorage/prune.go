@@ -0,0 +1,184 @@
+package fuzzstorage
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Reasons a deck is pruned, reported in PruneCandidate.Reason
+const (
+	PruneReasonOld           = "old"
+	PruneReasonLowScore      = "low_score"
+	PruneReasonArchetypeRank = "archetype_rank"
+	PruneReasonDuplicate     = "duplicate"
+)
+
+// deleteBatchSize keeps DELETE statements under SQLite's bound parameter limit
+const deleteBatchSize = 500
+
+// PrunePolicy selects decks for `deck fuzz prune`. A deck is pruned when any
+// rule matches it; a zero value turns a rule off. Tagged, favorite, and
+// annotated decks are always kept.
+type PrunePolicy struct {
+	// KeepPerArchetype prunes decks outside the best N of their archetype
+	KeepPerArchetype int
+	// MinScore prunes decks whose overall score is below it
+	MinScore float64
+	// OlderThan prunes decks evaluated before it
+	OlderThan time.Time
+	// Dedupe prunes decks that share all but one card with a higher-scoring
+	// deck that is kept
+	Dedupe bool
+}
+
+// Enabled reports whether the policy prunes anything.
+func (p PrunePolicy) Enabled() bool {
+	return p.KeepPerArchetype > 0 || p.MinScore > 0 || !p.OlderThan.IsZero() || p.Dedupe
+}
+
+// PruneCandidate is a deck a PrunePolicy deletes, with the first rule that
+// matched it
+type PruneCandidate struct {
+	Deck   DeckEntry
+	Reason string
+	// DuplicateOf is the kept deck a duplicate is close to
+	DuplicateOf int
+}
+
+// PruneCandidates returns the decks policy would delete, best score first,
+// without deleting them.
+func (s *Storage) PruneCandidates(policy PrunePolicy) ([]PruneCandidate, error) {
+	if policy.KeepPerArchetype < 0 || policy.MinScore < 0 {
+		return nil, fmt.Errorf("prune thresholds must be >= 0")
+	}
+	if !policy.Enabled() {
+		return nil, nil
+	}
+
+	decks, err := s.Query(QueryOptions{})
+	if err != nil {
+		return nil, err
+	}
+	// Ties rank by ID like ApplyRetention, so the oldest copy of a score wins
+	slices.SortStableFunc(decks, func(a, b DeckEntry) int {
+		if a.OverallScore != b.OverallScore {
+			if a.OverallScore > b.OverallScore {
+				return -1
+			}
+			return 1
+		}
+		return a.ID - b.ID
+	})
+
+	var candidates []PruneCandidate
+	archetypeRank := make(map[string]int)
+	// keptSubsets maps every (n-1)-card subset of a kept deck to its ID, so
+	// two decks share all but one card exactly when they share a subset
+	keptSubsets := make(map[string]int)
+	for _, deck := range decks {
+		archetypeRank[deck.Archetype]++
+		curated := deck.Favorite || len(deck.Tags) > 0 || deck.Notes != ""
+
+		reason := ""
+		switch {
+		case curated:
+		case !policy.OlderThan.IsZero() && deck.EvaluatedAt.Before(policy.OlderThan):
+			reason = PruneReasonOld
+		case policy.MinScore > 0 && deck.OverallScore < policy.MinScore:
+			reason = PruneReasonLowScore
+		case policy.KeepPerArchetype > 0 && archetypeRank[deck.Archetype] > policy.KeepPerArchetype:
+			reason = PruneReasonArchetypeRank
+		}
+		if reason != "" {
+			candidates = append(candidates, PruneCandidate{Deck: deck, Reason: reason})
+			continue
+		}
+
+		subsets := cardSubsets(deck.Cards)
+		if policy.Dedupe && !curated {
+			if keptID, ok := firstMatch(keptSubsets, subsets); ok {
+				candidates = append(candidates, PruneCandidate{Deck: deck, Reason: PruneReasonDuplicate, DuplicateOf: keptID})
+				continue
+			}
+		}
+		for _, subset := range subsets {
+			if _, ok := keptSubsets[subset]; !ok {
+				keptSubsets[subset] = deck.ID
+			}
+		}
+	}
+	return candidates, nil
+}
+
+// Prune deletes the decks policy selects and returns them.
+func (s *Storage) Prune(policy PrunePolicy) ([]PruneCandidate, error) {
+	candidates, err := s.PruneCandidates(policy)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, len(candidates))
+	for i, candidate := range candidates {
+		ids[i] = candidate.Deck.ID
+	}
+	if _, err := s.DeleteDecks(ids); err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+// DeleteDecks removes decks by ID in one transaction and returns the number
+// deleted
+func (s *Storage) DeleteDecks(ids []int) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var deleted int64
+	for batch := range slices.Chunk(ids, deleteBatchSize) {
+		args := make([]any, len(batch))
+		for i, id := range batch {
+			args[i] = id
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(batch)), ",")
+		result, err := tx.Exec("DELETE FROM top_decks WHERE id IN ("+placeholders+")", args...)
+		if err != nil {
+			return 0, fmt.Errorf("failed to delete decks: %w", err)
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to read delete row count: %w", err)
+		}
+		deleted += n
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit deletes: %w", err)
+	}
+	return deleted, nil
+}
+
+// cardSubsets returns the keys of every subset of cards missing one card
+func cardSubsets(cards []string) []string {
+	sorted := slices.Sorted(slices.Values(cards))
+	subsets := make([]string, 0, len(sorted))
+	for i := range sorted {
+		rest := slices.Concat(sorted[:i], sorted[i+1:])
+		subsets = append(subsets, strings.Join(rest, "|"))
+	}
+	return subsets
+}
+
+func firstMatch(index map[string]int, keys []string) (int, bool) {
+	for _, key := range keys {
+		if id, ok := index[key]; ok {
+			return id, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,87 @@
+package fuzzstorage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPruneCandidates(t *testing.T) {
+	storage, err := NewStorage(filepath.Join(t.TempDir(), "fuzz_prune.db"))
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer storage.Close()
+
+	now := time.Now()
+	hog := []string{"Hog Rider", "Musketeer", "Cannon", "Ice Golem", "Ice Spirit", "Skeletons", "Fireball", "The Log"}
+	add := func(cards []string, archetype string, score float64, evaluatedAt time.Time) int {
+		entry := &DeckEntry{Cards: cards, OverallScore: score, AvgElixir: 3, Archetype: archetype, EvaluatedAt: evaluatedAt}
+		if _, _, err := storage.InsertDeck(entry); err != nil {
+			t.Fatal(err)
+		}
+		return entry.ID
+	}
+	best := add(hog, "cycle", 9, now)
+	// Shares 7 of 8 cards with the best deck
+	nearCopy := add([]string{"Hog Rider", "Musketeer", "Tesla", "Ice Golem", "Ice Spirit", "Skeletons", "Fireball", "The Log"}, "cycle", 8, now)
+	// Shares 6 of 8 cards
+	distinct := add([]string{"Hog Rider", "Musketeer", "Tesla", "Knight", "Ice Spirit", "Skeletons", "Fireball", "The Log"}, "cycle", 7, now)
+	stale := add([]string{"Golem", "Night Witch", "Baby Dragon", "Lumberjack", "Tornado", "Lightning", "Barbarian Barrel", "Mega Minion"}, "beatdown", 8.5, now.AddDate(0, 0, -60))
+	weak := add([]string{"X-Bow", "Tesla", "Archers", "Knight", "Skeletons", "Ice Spirit", "Fireball", "The Log"}, "siege", 3, now)
+
+	candidates, err := storage.PruneCandidates(PrunePolicy{
+		KeepPerArchetype: 2,
+		MinScore:         5,
+		OlderThan:        now.AddDate(0, 0, -30),
+		Dedupe:           true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[int]string{nearCopy: PruneReasonDuplicate, stale: PruneReasonOld, weak: PruneReasonLowScore, distinct: PruneReasonArchetypeRank}
+	if len(candidates) != len(want) {
+		t.Fatalf("PruneCandidates() = %+v, want %d decks", candidates, len(want))
+	}
+	for _, candidate := range candidates {
+		if want[candidate.Deck.ID] != candidate.Reason {
+			t.Errorf("deck %d pruned for %q, want %q", candidate.Deck.ID, candidate.Reason, want[candidate.Deck.ID])
+		}
+		if candidate.Reason == PruneReasonDuplicate && candidate.DuplicateOf != best {
+			t.Errorf("DuplicateOf = %d, want %d", candidate.DuplicateOf, best)
+		}
+	}
+
+	// A dry run deletes nothing; curated decks are kept
+	if count, _ := storage.Count(); count != 5 {
+		t.Errorf("Count() = %d after PruneCandidates, want 5", count)
+	}
+	if err := storage.SetFavorite(weak, true); err != nil {
+		t.Fatal(err)
+	}
+	pruned, err := storage.Prune(PrunePolicy{MinScore: 5, Dedupe: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pruned) != 1 || pruned[0].Deck.ID != nearCopy {
+		t.Errorf("Prune() = %+v, want only the near copy", pruned)
+	}
+	if count, _ := storage.Count(); count != 4 {
+		t.Errorf("Count() = %d after Prune, want 4", count)
+	}
+}
+
+func TestPruneCandidatesRequiresRules(t *testing.T) {
+	storage, err := NewStorage(filepath.Join(t.TempDir(), "fuzz_prune.db"))
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer storage.Close()
+
+	if candidates, err := storage.PruneCandidates(PrunePolicy{}); err != nil || candidates != nil {
+		t.Errorf("PruneCandidates(empty) = %v, %v", candidates, err)
+	}
+	if _, err := storage.PruneCandidates(PrunePolicy{KeepPerArchetype: -1}); err == nil {
+		t.Error("negative quota should fail")
+	}
+}
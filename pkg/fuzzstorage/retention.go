@@ -0,0 +1,98 @@
+package fuzzstorage
+
+import "fmt"
+
+// Default retention quotas applied when top decks are saved.
+const (
+	DefaultKeepPerArchetype    = 50
+	DefaultKeepPerElixirBucket = 50
+)
+
+// elixirBucketSQL assigns each row the same low/medium/high elixir bucket
+// that `deck fuzz --ensure-elixir-buckets` uses.
+const elixirBucketSQL = `CASE
+	WHEN avg_elixir < 3.3 THEN 'low'
+	WHEN avg_elixir <= 4.0 THEN 'medium'
+	ELSE 'high'
+END`
+
+// RetentionPolicy bounds how many decks storage keeps so it does not collapse
+// to one dominant archetype over time. A deck is kept while it ranks within
+// PerArchetype of its archetype or within PerElixirBucket of its elixir
+// bucket, by overall score. A zero quota is ignored; with both zero nothing
+// is pruned. Tagged, favorite, and annotated decks are always kept.
+type RetentionPolicy struct {
+	PerArchetype    int
+	PerElixirBucket int
+}
+
+// DefaultRetentionPolicy returns the quotas used by `deck fuzz --save-top`.
+func DefaultRetentionPolicy() RetentionPolicy {
+	return RetentionPolicy{
+		PerArchetype:    DefaultKeepPerArchetype,
+		PerElixirBucket: DefaultKeepPerElixirBucket,
+	}
+}
+
+// Enabled reports whether the policy prunes anything.
+func (p RetentionPolicy) Enabled() bool {
+	return p.PerArchetype > 0 || p.PerElixirBucket > 0
+}
+
+// ApplyRetention deletes decks that fall outside every quota in policy and
+// returns the number deleted.
+func (s *Storage) ApplyRetention(policy RetentionPolicy) (int64, error) {
+	if policy.PerArchetype < 0 || policy.PerElixirBucket < 0 {
+		return 0, fmt.Errorf("retention quotas must be >= 0")
+	}
+	if !policy.Enabled() {
+		return 0, nil
+	}
+
+	// A zero quota retains no deck, leaving the decision to the other quota.
+	// Decks the user tagged, favorited, or annotated are never pruned.
+	result, err := s.db.Exec(`
+		DELETE FROM top_decks
+		WHERE favorite = 0 AND tags IS NULL AND notes IS NULL
+		  AND id IN (
+			SELECT id
+			FROM (
+				SELECT id,
+				       ROW_NUMBER() OVER (
+				           PARTITION BY archetype
+				           ORDER BY overall_score DESC, id ASC
+				       ) AS rank_in_archetype,
+				       ROW_NUMBER() OVER (
+				           PARTITION BY `+elixirBucketSQL+`
+				           ORDER BY overall_score DESC, id ASC
+				       ) AS rank_in_bucket
+				FROM top_decks
+			)
+			WHERE rank_in_archetype > ? AND rank_in_bucket > ?
+		)
+	`, policy.PerArchetype, policy.PerElixirBucket)
+	if err != nil {
+		return 0, fmt.Errorf("failed to apply retention: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read retention row count: %w", err)
+	}
+	return deleted, nil
+}
+
+// SaveTopDecksWithRetention saves decks like SaveTopDecks, then prunes
+// storage with policy. It returns the number of new decks and the number of
+// decks pruned.
+func (s *Storage) SaveTopDecksWithRetention(decks []DeckEntry, policy RetentionPolicy) (int, int64, error) {
+	saved, err := s.SaveTopDecks(decks)
+	if err != nil {
+		return saved, 0, err
+	}
+	pruned, err := s.ApplyRetention(policy)
+	if err != nil {
+		return saved, 0, err
+	}
+	return saved, pruned, nil
+}
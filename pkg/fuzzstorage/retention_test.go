@@ -0,0 +1,75 @@
+package fuzzstorage
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestApplyRetentionKeepsQuotas(t *testing.T) {
+	storage, err := NewStorage(filepath.Join(t.TempDir(), "fuzz_test.db"))
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer storage.Close()
+
+	// 10 medium-elixir beatdown decks dominate; 2 low-elixir cycle decks and
+	// 1 high-elixir siege deck score worse.
+	var decks []DeckEntry
+	add := func(archetype string, elixir, score float64) {
+		decks = append(decks, DeckEntry{
+			Cards:        []string{fmt.Sprintf("Card %d", len(decks)), "B", "C", "D", "E", "F", "G", "H"},
+			OverallScore: score,
+			AvgElixir:    elixir,
+			Archetype:    archetype,
+			EvaluatedAt:  time.Now(),
+		})
+	}
+	for i := range 10 {
+		add("beatdown", 3.8, 9-float64(i)*0.1)
+	}
+	add("cycle", 2.9, 6)
+	add("cycle", 2.9, 5)
+	add("siege", 4.5, 4)
+
+	saved, pruned, err := storage.SaveTopDecksWithRetention(decks, RetentionPolicy{PerArchetype: 3, PerElixirBucket: 5})
+	if err != nil {
+		t.Fatalf("SaveTopDecksWithRetention() error = %v", err)
+	}
+	if saved != len(decks) {
+		t.Errorf("saved = %d, want %d", saved, len(decks))
+	}
+
+	// Beatdown keeps its top 5 (bucket quota beats the archetype quota of 3);
+	// cycle and siege keep everything.
+	if pruned != 5 {
+		t.Errorf("pruned = %d, want 5", pruned)
+	}
+	histogram, err := storage.ArchetypeHistogram(QueryOptions{})
+	if err != nil {
+		t.Fatalf("ArchetypeHistogram() error = %v", err)
+	}
+	if histogram["beatdown"] != 5 || histogram["cycle"] != 2 || histogram["siege"] != 1 {
+		t.Errorf("unexpected histogram after retention: %v", histogram)
+	}
+	top, err := storage.GetTopN(1)
+	if err != nil || len(top) != 1 || top[0].OverallScore != 9 {
+		t.Errorf("expected best deck to survive, got %+v (err %v)", top, err)
+	}
+}
+
+func TestApplyRetentionDisabled(t *testing.T) {
+	storage, err := NewStorage(filepath.Join(t.TempDir(), "fuzz_test.db"))
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer storage.Close()
+
+	if pruned, err := storage.ApplyRetention(RetentionPolicy{}); err != nil || pruned != 0 {
+		t.Errorf("ApplyRetention(disabled) = %d, %v; want 0, nil", pruned, err)
+	}
+	if _, err := storage.ApplyRetention(RetentionPolicy{PerArchetype: -1}); err == nil {
+		t.Error("expected negative quota to be rejected")
+	}
+}
@@ -0,0 +1,51 @@
+package fuzzstorage
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deckhash"
+)
+
+// DeckSimilarityFunc scores how alike two decks are, from 0 (nothing in
+// common) to 1 (the same deck), e.g. deck.CardEmbedding.DeckSimilarity
+type DeckSimilarityFunc func(a, b []string) float64
+
+// SimilarDeck is a stored deck and its similarity to the queried deck
+type SimilarDeck struct {
+	DeckEntry
+	Similarity float64
+}
+
+// SimilarDecks returns the stored decks most like cards, most similar first,
+// skipping cards itself. Decks are filtered by opts and must score at least
+// minSimilarity; opts.Limit caps the decks returned after ranking and
+// opts.Offset is ignored.
+func (s *Storage) SimilarDecks(cards []string, similarity DeckSimilarityFunc, minSimilarity float64, opts QueryOptions) ([]SimilarDeck, error) {
+	limit := opts.Limit
+	opts.Limit, opts.Offset = 0, 0
+	entries, err := s.Query(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	self := deckhash.CanonicalDeckKey(cards)
+	var similar []SimilarDeck
+	for _, entry := range entries {
+		if deckhash.CanonicalDeckKey(entry.Cards) == self {
+			continue
+		}
+		if score := similarity(cards, entry.Cards); score >= minSimilarity {
+			similar = append(similar, SimilarDeck{DeckEntry: entry, Similarity: score})
+		}
+	}
+	// Query returns decks best first, so equally similar decks stay in
+	// score order
+	slices.SortStableFunc(similar, func(a, b SimilarDeck) int {
+		return cmp.Compare(b.Similarity, a.Similarity)
+	})
+	if limit > 0 && len(similar) > limit {
+		similar = similar[:limit]
+	}
+	return similar, nil
+}
@@ -0,0 +1,50 @@
+package fuzzstorage
+
+import (
+	"slices"
+	"testing"
+)
+
+// sharedCards scores decks by the share of cards in common
+func sharedCards(a, b []string) float64 {
+	shared := 0
+	for _, card := range a {
+		if slices.Contains(b, card) {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a))
+}
+
+func TestSimilarDecks(t *testing.T) {
+	storage, ids := newCurationStorage(t, 4)
+	query := []string{"Card 0", "B", "C", "D", "E", "F", "G", "H"}
+
+	similar, err := storage.SimilarDecks(query, sharedCards, 0, QueryOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("SimilarDecks() error = %v", err)
+	}
+	if len(similar) != 2 {
+		t.Fatalf("SimilarDecks() returned %d decks, want 2", len(similar))
+	}
+	for _, deck := range similar {
+		if deck.ID == ids[0] {
+			t.Error("SimilarDecks() returned the queried deck itself")
+		}
+		if deck.Similarity != 7.0/8 {
+			t.Errorf("deck %d similarity = %.3f, want 0.875", deck.ID, deck.Similarity)
+		}
+	}
+	// Equally similar decks stay in score order
+	if similar[0].ID != ids[1] || similar[1].ID != ids[2] {
+		t.Errorf("SimilarDecks() ids = %d, %d, want %d, %d", similar[0].ID, similar[1].ID, ids[1], ids[2])
+	}
+
+	none, err := storage.SimilarDecks(query, sharedCards, 0.9, QueryOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(none) != 0 {
+		t.Errorf("SimilarDecks() with min similarity 0.9 = %d decks, want 0", len(none))
+	}
+}
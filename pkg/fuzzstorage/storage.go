@@ -28,12 +28,17 @@ type Storage struct {
 	dbPath string
 }
 
+// DefaultDBPath returns where NewStorage("") keeps the database
+func DefaultDBPath() (string, error) {
+	return datapath.FuzzStorageDBPath(defaultDBName)
+}
+
 // NewStorage creates a new Storage instance for fuzzing results
 // The database file is stored at ~/.cr-api/fuzz_top_decks.db by default
 func NewStorage(dbPath string) (*Storage, error) {
 	if dbPath == "" {
 		var err error
-		dbPath, err = datapath.FuzzStorageDBPath(defaultDBName)
+		dbPath, err = DefaultDBPath()
 		if err != nil {
 			return nil, err
 		}
@@ -94,7 +99,14 @@ func (s *Storage) initSchema() error {
 		archetype TEXT NOT NULL,
 		archetype_conf REAL NOT NULL,
 		evaluated_at DATETIME NOT NULL,
-		run_id TEXT
+		run_id TEXT,
+		evaluation_version TEXT,
+		hybrid_primary TEXT,
+		hybrid_secondary TEXT,
+		name TEXT,
+		tags TEXT,
+		favorite INTEGER NOT NULL DEFAULT 0,
+		notes TEXT
 	);
 
 	CREATE INDEX IF NOT EXISTS idx_overall_score ON top_decks(overall_score DESC);
@@ -111,8 +123,70 @@ func (s *Storage) initSchema() error {
 	if err != nil {
 		return err
 	}
+	if _, err := s.db.Exec(lineageSchema); err != nil {
+		return fmt.Errorf("failed to create lineage table: %w", err)
+	}
+	if _, err := s.db.Exec(outcomesSchema); err != nil {
+		return fmt.Errorf("failed to create outcomes table: %w", err)
+	}
+	if err := s.addMissingColumns(); err != nil {
+		return err
+	}
+	if _, err := s.db.Exec("CREATE INDEX IF NOT EXISTS idx_name ON top_decks(name)"); err != nil {
+		return fmt.Errorf("failed to create name index: %w", err)
+	}
+	if err := s.maybeMigrateDeckHashes(); err != nil {
+		return err
+	}
+	return s.initCardIndex()
+}
+
+// addedColumns are top_decks columns added after the table was first
+// released, in the order they were added, with their definitions
+var addedColumns = []struct{ name, definition string }{
+	{"evaluation_version", "TEXT"},
+	{"hybrid_primary", "TEXT"},
+	{"hybrid_secondary", "TEXT"},
+	{"name", "TEXT"},
+	{"tags", "TEXT"},
+	{"favorite", "INTEGER NOT NULL DEFAULT 0"},
+	{"notes", "TEXT"},
+}
 
-	return s.maybeMigrateDeckHashes()
+// addMissingColumns upgrades databases created before later columns existed.
+// Existing rows are left NULL: a NULL evaluation_version is treated by
+// `deck fuzz migrate` as needing a re-score, which also fills in the hybrid
+// archetypes and names.
+func (s *Storage) addMissingColumns() error {
+	rows, err := s.db.Query("PRAGMA table_info(top_decks)")
+	if err != nil {
+		return fmt.Errorf("failed to inspect top_decks: %w", err)
+	}
+	defer closeutil.WithLog("fuzzstorage", rows, "table info rows")
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var (
+			cid, notNull, pk int
+			name, colType    string
+			defaultValue     sql.NullString
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &defaultValue, &pk); err != nil {
+			return fmt.Errorf("failed to inspect top_decks: %w", err)
+		}
+		existing[name] = true
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to inspect top_decks: %w", err)
+	}
+	for _, column := range addedColumns {
+		if existing[column.name] {
+			continue
+		}
+		if _, err := s.db.Exec("ALTER TABLE top_decks ADD COLUMN " + column.name + " " + column.definition); err != nil {
+			return fmt.Errorf("failed to add %s column: %w", column.name, err)
+		}
+	}
+	return nil
 }
 
 func (s *Storage) maybeMigrateDeckHashes() error {
@@ -129,7 +203,11 @@ func (s *Storage) maybeMigrateDeckHashes() error {
 type deckHashMigrationRow = storageutil.DeckHashMigrationRow
 
 func (s *Storage) loadDeckHashMigrationRows() ([]deckHashMigrationRow, map[string]deckHashMigrationRow, error) {
-	rows, err := s.db.Query("SELECT id, deck_hash, cards, overall_score FROM top_decks")
+	return queryDeckHashMigrationRows(s.db)
+}
+
+func queryDeckHashMigrationRows(db storageutil.Querier) ([]deckHashMigrationRow, map[string]deckHashMigrationRow, error) {
+	rows, err := db.Query("SELECT id, deck_hash, cards, overall_score FROM top_decks")
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to load deck hash migration rows: %w", err)
 	}
@@ -165,10 +243,29 @@ type DeckEntry struct {
 	ArchetypeConf    float64
 	EvaluatedAt      time.Time
 	RunID            string
+	// EvaluationVersion is the scoring version that produced the scores;
+	// empty for decks saved before versions were recorded
+	EvaluationVersion string
+	// HybridPrimary and HybridSecondary name the two archetypes of a hybrid
+	// deck; both are empty for other decks
+	HybridPrimary   string
+	HybridSecondary string
+	// Name is the deck's generated name, suffixed with " #2", " #3", ... when
+	// another stored deck already has it; empty for decks saved before names
+	Name string
+	// Tags, Favorite, and Notes are set by the user with AddTags,
+	// SetFavorite, and SetNotes. Saving or re-scoring a deck keeps them, and
+	// retention never prunes a deck that has any of them.
+	Tags     []string
+	Favorite bool
+	Notes    string
+	// Record is the deck's real-battle results, filled in by AttachRecords
+	Record DeckRecord
 }
 
 // SaveTopDecks saves the top N decks from a fuzzing run
-// Returns the number of decks saved
+// Returns the number of decks saved. It does not prune; see
+// SaveTopDecksWithRetention.
 func (s *Storage) SaveTopDecks(decks []DeckEntry) (int, error) {
 	saved := 0
 
@@ -203,17 +300,25 @@ func (s *Storage) InsertDeck(entry *DeckEntry) (int, bool, error) {
 			return &storageutil.ExistingDeckRecord{ID: existingID, Score: existingScore}, nil
 		},
 		Insert: func(deckHash, cardsJSON string) (int, error) {
+			name, err := s.uniqueName(entry.Name, 0)
+			if err != nil {
+				return 0, err
+			}
+			entry.Name = name
 			insertResult, err := s.db.Exec(`
 				INSERT INTO top_decks (
 					deck_hash, cards, overall_score, attack_score, defense_score,
 					synergy_score, versatility_score, avg_elixir,
-					archetype, archetype_conf, evaluated_at, run_id
-				) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+					archetype, archetype_conf, evaluated_at, run_id, evaluation_version,
+					hybrid_primary, hybrid_secondary, name
+				) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 			`,
 				deckHash, cardsJSON, entry.OverallScore, entry.AttackScore,
 				entry.DefenseScore, entry.SynergyScore, entry.VersatilityScore,
 				entry.AvgElixir, entry.Archetype, entry.ArchetypeConf,
-				entry.EvaluatedAt, entry.RunID,
+				entry.EvaluatedAt, entry.RunID, nullableString(entry.EvaluationVersion),
+				nullableString(entry.HybridPrimary), nullableString(entry.HybridSecondary),
+				nullableString(entry.Name),
 			)
 			if err != nil {
 				return 0, fmt.Errorf("failed to insert deck: %w", err)
@@ -230,17 +335,26 @@ func (s *Storage) InsertDeck(entry *DeckEntry) (int, bool, error) {
 				return nil
 			}
 
-			_, err := s.db.Exec(`
+			// A stored deck keeps its name; only unnamed decks get one
+			name, err := s.uniqueName(entry.Name, existing.ID)
+			if err != nil {
+				return err
+			}
+			_, err = s.db.Exec(`
 				UPDATE top_decks SET
 					overall_score = ?, attack_score = ?, defense_score = ?,
 					synergy_score = ?, versatility_score = ?, avg_elixir = ?,
-					archetype = ?, archetype_conf = ?, evaluated_at = ?, run_id = ?
+					archetype = ?, archetype_conf = ?, evaluated_at = ?, run_id = ?,
+					evaluation_version = ?, hybrid_primary = ?, hybrid_secondary = ?,
+					name = COALESCE(name, ?)
 				WHERE id = ?
 			`,
 				entry.OverallScore, entry.AttackScore, entry.DefenseScore,
 				entry.SynergyScore, entry.VersatilityScore, entry.AvgElixir,
 				entry.Archetype, entry.ArchetypeConf, entry.EvaluatedAt,
-				entry.RunID, existing.ID,
+				entry.RunID, nullableString(entry.EvaluationVersion),
+				nullableString(entry.HybridPrimary), nullableString(entry.HybridSecondary),
+				nullableString(name), existing.ID,
 			)
 			if err != nil {
 				return fmt.Errorf("failed to update deck: %w", err)
@@ -257,35 +371,80 @@ func (s *Storage) InsertDeck(entry *DeckEntry) (int, bool, error) {
 }
 
 // UpdateDeck updates an existing deck entry by ID with new evaluation data.
+// A blank Name keeps the stored name.
 func (s *Storage) UpdateDeck(entry *DeckEntry) error {
 	if entry.ID <= 0 {
 		return fmt.Errorf("invalid deck ID: %d", entry.ID)
 	}
 
-	_, err := s.db.Exec(`
+	name, err := s.uniqueName(entry.Name, entry.ID)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
 		UPDATE top_decks SET
 			overall_score = ?, attack_score = ?, defense_score = ?,
 			synergy_score = ?, versatility_score = ?, avg_elixir = ?,
-			archetype = ?, archetype_conf = ?, evaluated_at = ?, run_id = ?
+			archetype = ?, archetype_conf = ?, evaluated_at = ?, run_id = ?,
+			evaluation_version = ?, hybrid_primary = ?, hybrid_secondary = ?,
+			name = COALESCE(?, name)
 		WHERE id = ?
 	`,
 		entry.OverallScore, entry.AttackScore, entry.DefenseScore,
 		entry.SynergyScore, entry.VersatilityScore, entry.AvgElixir,
 		entry.Archetype, entry.ArchetypeConf, entry.EvaluatedAt,
-		entry.RunID, entry.ID,
+		entry.RunID, nullableString(entry.EvaluationVersion),
+		nullableString(entry.HybridPrimary), nullableString(entry.HybridSecondary),
+		nullableString(name), entry.ID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update deck: %w", err)
 	}
+	if name != "" {
+		entry.Name = name
+	}
 
 	return nil
 }
 
+// uniqueName returns name, or name with the lowest free " #N" suffix when a
+// deck other than id already uses it. Blank names stay blank.
+func (s *Storage) uniqueName(name string, id int) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+	rows, err := s.db.Query("SELECT name FROM top_decks WHERE id != ? AND (name = ? OR name LIKE ?)", id, name, name+" #%")
+	if err != nil {
+		return "", fmt.Errorf("failed to check deck name: %w", err)
+	}
+	defer closeutil.WithLog("fuzzstorage", rows, "deck name rows")
+	taken := make(map[string]bool)
+	for rows.Next() {
+		var existing string
+		if err := rows.Scan(&existing); err != nil {
+			return "", fmt.Errorf("failed to check deck name: %w", err)
+		}
+		taken[existing] = true
+	}
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("failed to check deck name: %w", err)
+	}
+	if !taken[name] {
+		return name, nil
+	}
+	for n := 2; ; n++ {
+		if candidate := fmt.Sprintf("%s #%d", name, n); !taken[candidate] {
+			return candidate, nil
+		}
+	}
+}
+
 // GetTopN retrieves the top N decks by overall score
 func (s *Storage) GetTopN(n int) ([]DeckEntry, error) {
 	query := `
 		SELECT id, deck_hash, cards, overall_score, attack_score, defense_score,
-		       synergy_score, versatility_score, avg_elixir, archetype, archetype_conf, evaluated_at, run_id
+		       synergy_score, versatility_score, avg_elixir, archetype, archetype_conf, evaluated_at, run_id,
+		       evaluation_version, hybrid_primary, hybrid_secondary, name, tags, favorite, notes
 		FROM top_decks
 		ORDER BY overall_score DESC
 		LIMIT ?
@@ -304,7 +463,8 @@ func (s *Storage) GetTopN(n int) ([]DeckEntry, error) {
 func (s *Storage) GetByArchetype(archetype string, limit int) ([]DeckEntry, error) {
 	query := `
 		SELECT id, deck_hash, cards, overall_score, attack_score, defense_score,
-		       synergy_score, versatility_score, avg_elixir, archetype, archetype_conf, evaluated_at, run_id
+		       synergy_score, versatility_score, avg_elixir, archetype, archetype_conf, evaluated_at, run_id,
+		       evaluation_version, hybrid_primary, hybrid_secondary, name, tags, favorite, notes
 		FROM top_decks
 		WHERE archetype = ?
 		ORDER BY overall_score DESC
@@ -322,16 +482,28 @@ func (s *Storage) GetByArchetype(archetype string, limit int) ([]DeckEntry, erro
 
 // QueryOptions defines filtering options for querying decks
 type QueryOptions struct {
-	MinScore        float64
-	MaxScore        float64
-	Archetype       string
-	MinAvgElixir    float64
-	MaxAvgElixir    float64
+	MinScore     float64
+	MaxScore     float64
+	Archetype    string
+	MinAvgElixir float64
+	MaxAvgElixir float64
+	// RequireAllCards keeps decks with every one of these cards,
+	// RequireAnyCards and WinConditions each keep decks with at least one
+	// of theirs, and ExcludeCards drops decks with any of these. Card names
+	// must match exactly, ignoring case.
 	RequireAllCards []string
 	RequireAnyCards []string
+	WinConditions   []string
 	ExcludeCards    []string
-	Limit           int
-	Offset          int
+	// ExcludeEvaluationVersion skips decks already scored by this version;
+	// decks without a recorded version are always included
+	ExcludeEvaluationVersion string
+	// Tags keeps decks that have every one of these tags
+	Tags []string
+	// FavoritesOnly keeps favorite decks
+	FavoritesOnly bool
+	Limit         int
+	Offset        int
 }
 
 // Query retrieves deck entries based on the provided options
@@ -339,7 +511,8 @@ func (s *Storage) Query(opts QueryOptions) ([]DeckEntry, error) {
 	var query strings.Builder
 	query.WriteString(`
 		SELECT id, deck_hash, cards, overall_score, attack_score, defense_score,
-		       synergy_score, versatility_score, avg_elixir, archetype, archetype_conf, evaluated_at, run_id
+		       synergy_score, versatility_score, avg_elixir, archetype, archetype_conf, evaluated_at, run_id,
+		       evaluation_version, hybrid_primary, hybrid_secondary, name, tags, favorite, notes
 		FROM top_decks
 		WHERE 1=1
 	`)
@@ -367,33 +540,14 @@ func (s *Storage) Query(opts QueryOptions) ([]DeckEntry, error) {
 		args = append(args, opts.MaxAvgElixir)
 	}
 
-	// Card filters
-	if len(opts.RequireAllCards) > 0 {
-		for _, card := range opts.RequireAllCards {
-			query.WriteString(" AND cards LIKE ?")
-			args = append(args, "%"+card+"%")
-		}
-	}
-	if len(opts.RequireAnyCards) > 0 {
-		var subQuery strings.Builder
-		subQuery.WriteString(" AND (")
-		for i, card := range opts.RequireAnyCards {
-			if i > 0 {
-				subQuery.WriteString(" OR ")
-			}
-			subQuery.WriteString("cards LIKE ?")
-			args = append(args, "%"+card+"%")
-		}
-		subQuery.WriteString(")")
-		query.WriteString(subQuery.String())
-	}
-	if len(opts.ExcludeCards) > 0 {
-		for _, card := range opts.ExcludeCards {
-			query.WriteString(" AND cards NOT LIKE ?")
-			args = append(args, "%"+card+"%")
-		}
+	if opts.ExcludeEvaluationVersion != "" {
+		query.WriteString(" AND (evaluation_version IS NULL OR evaluation_version != ?)")
+		args = append(args, opts.ExcludeEvaluationVersion)
 	}
 
+	args = appendCardFilters(&query, args, opts)
+	args = appendCurationFilters(&query, args, opts)
+
 	query.WriteString(" ORDER BY overall_score DESC")
 
 	// Apply limit and offset
@@ -449,31 +603,8 @@ func (s *Storage) ArchetypeHistogram(opts QueryOptions) (map[string]int, error)
 		args = append(args, opts.MaxAvgElixir)
 	}
 
-	if len(opts.RequireAllCards) > 0 {
-		for _, card := range opts.RequireAllCards {
-			query.WriteString(" AND cards LIKE ?")
-			args = append(args, "%"+card+"%")
-		}
-	}
-	if len(opts.RequireAnyCards) > 0 {
-		var subQuery strings.Builder
-		subQuery.WriteString(" AND (")
-		for i, card := range opts.RequireAnyCards {
-			if i > 0 {
-				subQuery.WriteString(" OR ")
-			}
-			subQuery.WriteString("cards LIKE ?")
-			args = append(args, "%"+card+"%")
-		}
-		subQuery.WriteString(")")
-		query.WriteString(subQuery.String())
-	}
-	if len(opts.ExcludeCards) > 0 {
-		for _, card := range opts.ExcludeCards {
-			query.WriteString(" AND cards NOT LIKE ?")
-			args = append(args, "%"+card+"%")
-		}
-	}
+	args = appendCardFilters(&query, args, opts)
+	args = appendCurationFilters(&query, args, opts)
 
 	query.WriteString(" GROUP BY archetype ORDER BY deck_count DESC, archetype ASC")
 
@@ -505,13 +636,14 @@ func (s *Storage) scanRows(rows *sql.Rows) ([]DeckEntry, error) {
 	for rows.Next() {
 		var entry DeckEntry
 		var cardsJSON string
-		var runIDNull sql.NullString
+		var runIDNull, versionNull, hybridPrimary, hybridSecondary, name, tags, notes sql.NullString
 
 		err := rows.Scan(
 			&entry.ID, new(string), &cardsJSON, &entry.OverallScore,
 			&entry.AttackScore, &entry.DefenseScore, &entry.SynergyScore,
 			&entry.VersatilityScore, &entry.AvgElixir, &entry.Archetype,
-			&entry.ArchetypeConf, &entry.EvaluatedAt, &runIDNull,
+			&entry.ArchetypeConf, &entry.EvaluatedAt, &runIDNull, &versionNull,
+			&hybridPrimary, &hybridSecondary, &name, &tags, &entry.Favorite, &notes,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
@@ -525,6 +657,12 @@ func (s *Storage) scanRows(rows *sql.Rows) ([]DeckEntry, error) {
 		if runIDNull.Valid {
 			entry.RunID = runIDNull.String
 		}
+		entry.EvaluationVersion = versionNull.String
+		entry.HybridPrimary = hybridPrimary.String
+		entry.HybridSecondary = hybridSecondary.String
+		entry.Name = name.String
+		entry.Tags = decodeTags(tags.String)
+		entry.Notes = notes.String
 
 		entries = append(entries, entry)
 	}
@@ -536,6 +674,36 @@ func (s *Storage) scanRows(rows *sql.Rows) ([]DeckEntry, error) {
 	return entries, nil
 }
 
+// VersionCounts returns the number of stored decks per scoring version; decks
+// saved before versions were recorded are counted under "".
+func (s *Storage) VersionCounts() (map[string]int, error) {
+	rows, err := s.db.Query("SELECT COALESCE(evaluation_version, ''), COUNT(*) FROM top_decks GROUP BY 1")
+	if err != nil {
+		return nil, fmt.Errorf("failed to count evaluation versions: %w", err)
+	}
+	defer closeutil.WithLog("fuzzstorage", rows, "version count rows")
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var version string
+		var n int
+		if err := rows.Scan(&version, &n); err != nil {
+			return nil, fmt.Errorf("failed to scan version count: %w", err)
+		}
+		counts[version] = n
+	}
+	return counts, rows.Err()
+}
+
+// nullableString stores an empty value, such as an unknown scoring version,
+// as NULL
+func nullableString(version string) any {
+	if version == "" {
+		return nil
+	}
+	return version
+}
+
 // DeleteDeck removes a deck from storage by ID
 func (s *Storage) DeleteDeck(id int) error {
 	_, err := s.db.Exec("DELETE FROM top_decks WHERE id = ?", id)
@@ -251,3 +251,122 @@ func TestStorageMigratesLegacyAndCanonicalDuplicates(t *testing.T) {
 		t.Fatalf("expected canonical hash %q, got %q", canonicalHash, gotHash)
 	}
 }
+
+func TestStorageAddsEvaluationVersionColumn(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "fuzz_unversioned.db")
+	storage, err := NewStorage(dbPath)
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	// Recreate the table as it was before versions were recorded
+	if _, err := storage.db.Exec(`
+		DROP TABLE top_decks;
+		CREATE TABLE top_decks (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			deck_hash TEXT NOT NULL UNIQUE,
+			cards TEXT NOT NULL,
+			overall_score REAL NOT NULL,
+			attack_score REAL NOT NULL,
+			defense_score REAL NOT NULL,
+			synergy_score REAL NOT NULL,
+			versatility_score REAL NOT NULL,
+			avg_elixir REAL NOT NULL,
+			archetype TEXT NOT NULL,
+			archetype_conf REAL NOT NULL,
+			evaluated_at DATETIME NOT NULL,
+			run_id TEXT
+		);
+		INSERT INTO top_decks (
+			deck_hash, cards, overall_score, attack_score, defense_score, synergy_score,
+			versatility_score, avg_elixir, archetype, archetype_conf, evaluated_at, run_id
+		) VALUES ('old', '["Golem"]', 7, 7, 7, 7, 7, 4.0, 'beatdown', 0.8, CURRENT_TIMESTAMP, 'seed');
+	`); err != nil {
+		t.Fatalf("failed to create legacy table: %v", err)
+	}
+	if err := storage.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := NewStorage(dbPath)
+	if err != nil {
+		t.Fatalf("failed to reopen legacy storage: %v", err)
+	}
+	defer reopened.Close()
+
+	if _, _, err := reopened.InsertDeck(&DeckEntry{
+		Cards: []string{"Hog Rider"}, OverallScore: 8, Archetype: "cycle",
+		EvaluatedAt: time.Now(), EvaluationVersion: "2.0.0",
+		HybridPrimary: "cycle", HybridSecondary: "miner",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	top, err := reopened.GetTopN(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(top) != 1 || top[0].HybridPrimary != "cycle" || top[0].HybridSecondary != "miner" {
+		t.Errorf("GetTopN() = %+v, want the cycle/miner hybrid", top)
+	}
+
+	counts, err := reopened.VersionCounts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counts[""] != 1 || counts["2.0.0"] != 1 {
+		t.Errorf("VersionCounts() = %v", counts)
+	}
+
+	stale, err := reopened.Query(QueryOptions{ExcludeEvaluationVersion: "2.0.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stale) != 1 || stale[0].Cards[0] != "Golem" || stale[0].EvaluationVersion != "" {
+		t.Fatalf("stale decks = %+v", stale)
+	}
+
+	stale[0].EvaluationVersion = "2.0.0"
+	if err := reopened.UpdateDeck(&stale[0]); err != nil {
+		t.Fatal(err)
+	}
+	if rest, _ := reopened.Query(QueryOptions{ExcludeEvaluationVersion: "2.0.0"}); len(rest) != 0 {
+		t.Errorf("after update, stale decks = %+v", rest)
+	}
+}
+
+func TestInsertDeckSuffixesDuplicateNames(t *testing.T) {
+	storage, err := NewStorage(filepath.Join(t.TempDir(), "fuzz_names.db"))
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer storage.Close()
+
+	insert := func(cards []string, score float64) *DeckEntry {
+		t.Helper()
+		entry := &DeckEntry{Cards: cards, OverallScore: score, Archetype: "cycle", EvaluatedAt: time.Now(), Name: "Hog 2.6 Cycle"}
+		if _, _, err := storage.InsertDeck(entry); err != nil {
+			t.Fatal(err)
+		}
+		return entry
+	}
+
+	first := insert([]string{"Hog Rider", "Musketeer", "Cannon", "Ice Golem", "Ice Spirit", "Skeletons", "Fireball", "The Log"}, 8)
+	second := insert([]string{"Hog Rider", "Archers", "Cannon", "Ice Golem", "Ice Spirit", "Skeletons", "Fireball", "The Log"}, 7)
+	third := insert([]string{"Hog Rider", "Knight", "Cannon", "Ice Golem", "Ice Spirit", "Skeletons", "Fireball", "The Log"}, 6)
+	if first.Name != "Hog 2.6 Cycle" || second.Name != "Hog 2.6 Cycle #2" || third.Name != "Hog 2.6 Cycle #3" {
+		t.Errorf("names = %q, %q, %q", first.Name, second.Name, third.Name)
+	}
+
+	// A better score for a stored deck keeps its name
+	insert(second.Cards, 9)
+	// Re-scoring a deck under its own name does not suffix it again
+	if err := storage.UpdateDeck(third); err != nil {
+		t.Fatal(err)
+	}
+	top, err := storage.GetTopN(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(top) != 3 || top[0].Name != "Hog 2.6 Cycle #2" || top[2].Name != "Hog 2.6 Cycle #3" {
+		t.Errorf("stored names = %+v", top)
+	}
+}
@@ -0,0 +1,46 @@
+package leaderboard
+
+import "github.com/klauer/clash-royale-api/go/internal/storageutil"
+
+// MaintenanceReport summarizes a Maintain pass
+type MaintenanceReport = storageutil.MaintenanceReport
+
+// orphanChecks are the index tables kept in step with decks
+var orphanChecks = []storageutil.OrphanCheck{
+	{Table: "deck_cards", KeyColumn: "deck_id", DeckTable: "decks"},
+	{Table: "decks_fts", KeyColumn: "docid", DeckTable: "decks"},
+}
+
+// Maintain compacts a long-lived database: it deletes decks that hold the
+// same cards as a better-scoring deck and index rows whose deck is gone,
+// then rebuilds the indexes and vacuums. With dryRun it only counts what it
+// would delete.
+func (s *Storage) Maintain(dryRun bool) (MaintenanceReport, error) {
+	report := MaintenanceReport{DBPath: s.dbPath, SizeBeforeBytes: storageutil.FileSize(s.dbPath), DryRun: dryRun}
+
+	records, winners, err := s.loadDeckHashMigrationRows()
+	if err != nil {
+		return report, err
+	}
+	report.DuplicatesRemoved = storageutil.CountDeckHashDuplicates(records, winners)
+	if report.OrphansRemoved, err = storageutil.RemoveOrphans(s.db, orphanChecks, dryRun); err != nil {
+		return report, err
+	}
+	if dryRun {
+		report.SizeAfterBytes = report.SizeBeforeBytes
+		return report, nil
+	}
+
+	err = storageutil.ApplyDeckHashMigrationInTx(s.db, "leaderboard", "decks", records, winners, func() error {
+		_, err := s.RecalculateStats()
+		return err
+	})
+	if err != nil {
+		return report, err
+	}
+	if err := storageutil.Compact(s.db, "INSERT INTO decks_fts(decks_fts) VALUES('optimize')"); err != nil {
+		return report, err
+	}
+	report.SizeAfterBytes = storageutil.FileSize(s.dbPath)
+	return report, nil
+}
@@ -0,0 +1,53 @@
+package leaderboard
+
+import (
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deckhash"
+)
+
+func TestMaintainRemovesDuplicatesAndOrphans(t *testing.T) {
+	storage, cleanup := createTestStorage(t)
+	defer cleanup()
+
+	cards := []string{"Giant", "Wizard", "Mini P.E.K.K.A", "Musketeer", "Arrows", "Fireball", "Goblin Gang", "Ice Spirit"}
+	kept := createTestDeckEntry(cards, 9.0)
+	if _, _, err := storage.InsertDeck(kept); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := storage.db.Exec(`
+		INSERT INTO decks (
+			deck_hash, cards, overall_score, attack_score, defense_score, synergy_score,
+			versatility_score, f2p_score, playability_score, archetype, archetype_conf,
+			strategy, avg_elixir, evaluated_at, player_tag, evaluation_version
+		) VALUES (?, (SELECT cards FROM decks WHERE id = ?), 7, 7, 7, 7, 7, 7, 7, 'control', 0.7, 'legacy', 3.0, CURRENT_TIMESTAMP, '#2PP0Q', '1.0.0')
+	`, deckhash.LegacyCompute(cards), kept.ID); err != nil {
+		t.Fatalf("failed to insert duplicate: %v", err)
+	}
+	if _, err := storage.db.Exec("INSERT INTO decks_fts (docid, cards) VALUES (999, 'orphan')"); err != nil {
+		t.Fatalf("failed to insert orphan: %v", err)
+	}
+
+	report, err := storage.Maintain(false)
+	if err != nil {
+		t.Fatalf("Maintain() error = %v", err)
+	}
+	if report.DuplicatesRemoved != 1 || report.OrphansRemoved != 1 || report.SizeBeforeBytes == 0 {
+		t.Errorf("report = %+v, want 1 duplicate and 1 orphan", report)
+	}
+
+	decks, err := storage.Query(QueryOptions{Search: "giant"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decks) != 1 || decks[0].ID != kept.ID {
+		t.Errorf("decks after Maintain = %+v, want only deck %d", decks, kept.ID)
+	}
+	stats, err := storage.GetStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.TotalUniqueDecks != 1 {
+		t.Errorf("TotalUniqueDecks = %d after Maintain, want 1", stats.TotalUniqueDecks)
+	}
+}
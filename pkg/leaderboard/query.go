@@ -0,0 +1,283 @@
+package leaderboard
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// queryIndexSchema keeps two lookup tables in step with decks:
+//   - deck_cards has one row per card of every deck, so card filters are
+//     indexed lookups instead of scans of the cards JSON
+//   - decks_fts is a full-text index over the cards, archetype, and strategy
+//     of every deck, keyed by deck id
+const queryIndexSchema = `
+	CREATE TABLE IF NOT EXISTS deck_cards (
+		deck_id INTEGER NOT NULL,
+		card TEXT NOT NULL COLLATE NOCASE,
+		PRIMARY KEY (deck_id, card)
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_deck_cards_card ON deck_cards(card);
+	CREATE INDEX IF NOT EXISTS idx_player_tag ON decks(player_tag);
+	CREATE INDEX IF NOT EXISTS idx_evaluation_version ON decks(evaluation_version);
+
+	CREATE VIRTUAL TABLE IF NOT EXISTS decks_fts USING fts4(cards, archetype, strategy);
+
+	CREATE TRIGGER IF NOT EXISTS decks_index_insert AFTER INSERT ON decks
+	BEGIN
+		INSERT OR IGNORE INTO deck_cards (deck_id, card)
+		SELECT NEW.id, value FROM json_each(NEW.cards);
+		INSERT INTO decks_fts (docid, cards, archetype, strategy)
+		VALUES (NEW.id, NEW.cards, NEW.archetype, NEW.strategy);
+	END;
+
+	CREATE TRIGGER IF NOT EXISTS decks_index_update AFTER UPDATE ON decks
+	BEGIN
+		DELETE FROM deck_cards WHERE deck_id = OLD.id;
+		INSERT OR IGNORE INTO deck_cards (deck_id, card)
+		SELECT NEW.id, value FROM json_each(NEW.cards);
+		DELETE FROM decks_fts WHERE docid = OLD.id;
+		INSERT INTO decks_fts (docid, cards, archetype, strategy)
+		VALUES (NEW.id, NEW.cards, NEW.archetype, NEW.strategy);
+	END;
+
+	CREATE TRIGGER IF NOT EXISTS decks_index_delete AFTER DELETE ON decks
+	BEGIN
+		DELETE FROM deck_cards WHERE deck_id = OLD.id;
+		DELETE FROM decks_fts WHERE docid = OLD.id;
+	END;
+`
+
+// initQueryIndexes creates the card and full-text indexes and fills them in
+// for decks saved before they existed
+func (s *Storage) initQueryIndexes() error {
+	if _, err := s.db.Exec(queryIndexSchema); err != nil {
+		return fmt.Errorf("failed to create query indexes: %w", err)
+	}
+	_, err := s.db.Exec(`
+		INSERT OR IGNORE INTO deck_cards (deck_id, card)
+		SELECT decks.id, cards.value
+		FROM decks, json_each(decks.cards) AS cards
+		WHERE decks.id NOT IN (SELECT deck_id FROM deck_cards)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to backfill card index: %w", err)
+	}
+	_, err = s.db.Exec(`
+		INSERT INTO decks_fts (docid, cards, archetype, strategy)
+		SELECT id, cards, archetype, strategy FROM decks
+		WHERE id NOT IN (SELECT docid FROM decks_fts)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to backfill full-text index: %w", err)
+	}
+	return nil
+}
+
+// QueryPage returns one page of decks matching opts, at most opts.Limit, and
+// the number of matching decks across all pages. Pass NextCursor back as
+// opts.Cursor with the same filters and sort to get the next page. Unlike
+// Offset, a cursor seeks straight to the next page, so deep pages cost no
+// more than the first and decks saved between calls do not shift them.
+func (s *Storage) QueryPage(opts QueryOptions) (*Page, error) {
+	limit := opts.Limit
+	if limit > 0 {
+		opts.Limit = limit + 1
+	}
+	entries, err := s.Query(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page{Entries: entries}
+	if limit > 0 && len(entries) > limit {
+		page.Entries = entries[:limit]
+		if page.NextCursor, err = encodeCursor(opts, page.Entries[limit-1]); err != nil {
+			return nil, err
+		}
+	}
+	if page.Total, err = s.CountMatching(opts); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// CountMatching returns the number of decks matching the filters of opts,
+// ignoring its cursor, sort, and pagination
+func (s *Storage) CountMatching(opts QueryOptions) (int, error) {
+	filters, args, err := buildDeckFilters(opts)
+	if err != nil {
+		return 0, err
+	}
+	var count int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM decks WHERE 1=1"+filters, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count decks: %w", err)
+	}
+	return count, nil
+}
+
+// applyEvaluationFilters adds player tag and evaluation date filters
+func applyEvaluationFilters(query string, args []any, opts QueryOptions) (string, []any, error) {
+	if tag := strings.TrimSpace(opts.PlayerTag); tag != "" {
+		_, sanitized, err := normalizePlayerTag(tag)
+		if err != nil {
+			return "", nil, err
+		}
+		query += " AND UPPER(LTRIM(player_tag, '#')) = ?"
+		args = append(args, sanitized)
+	}
+	if !opts.EvaluatedAfter.IsZero() {
+		query += " AND evaluated_at >= ?"
+		args = append(args, opts.EvaluatedAfter)
+	}
+	if !opts.EvaluatedBefore.IsZero() {
+		query += " AND evaluated_at < ?"
+		args = append(args, opts.EvaluatedBefore)
+	}
+	return query, args, nil
+}
+
+// scoreColumns are the columns a ScoreBand can limit
+var scoreColumns = []string{
+	defaultSortColumn, "attack_score", "defense_score", "synergy_score",
+	"versatility_score", "f2p_score", "playability_score",
+}
+
+// scoreColumn resolves a ScoreBand field, with or without its _score suffix
+func scoreColumn(field string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(field))
+	for _, column := range scoreColumns {
+		if normalized == column || normalized+"_score" == column {
+			return column, nil
+		}
+	}
+	return "", fmt.Errorf("unknown score band field %q (supported: %s)", field, strings.Join(scoreColumns, ", "))
+}
+
+// applyScoreBands adds per-category score range filters
+func applyScoreBands(query string, args []any, bands []ScoreBand) (string, []any, error) {
+	for _, band := range bands {
+		column, err := scoreColumn(band.Field)
+		if err != nil {
+			return "", nil, err
+		}
+		if band.Min > 0 {
+			query += " AND " + column + " >= ?"
+			args = append(args, band.Min)
+		}
+		if band.Max > 0 {
+			query += " AND " + column + " <= ?"
+			args = append(args, band.Max)
+		}
+	}
+	return query, args, nil
+}
+
+// applySearchFilter adds the full-text search filter
+func applySearchFilter(query string, args []any, opts QueryOptions) (string, []any) {
+	if search := strings.TrimSpace(opts.Search); search != "" {
+		query += " AND id IN (SELECT docid FROM decks_fts WHERE decks_fts MATCH ?)"
+		args = append(args, search)
+	}
+	return query, args
+}
+
+// pageCursor records where a page ended: the sort it was made for and the
+// sort value and id of its last deck
+type pageCursor struct {
+	SortBy string `json:"sort_by"`
+	Order  string `json:"order"`
+	Value  any    `json:"value"`
+	ID     int    `json:"id"`
+}
+
+func encodeCursor(opts QueryOptions, last DeckEntry) (string, error) {
+	sortBy := safeSortColumn(opts.SortBy)
+	data, err := json.Marshal(pageCursor{
+		SortBy: sortBy,
+		Order:  sortDirection(opts.SortOrder),
+		Value:  sortValue(last, sortBy),
+		ID:     last.ID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeCursor(cursor string) (pageCursor, error) {
+	var c pageCursor
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		err = json.Unmarshal(data, &c)
+	}
+	if err != nil {
+		return c, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	return c, nil
+}
+
+// sortValue returns the value of a deck's sort column as stored
+func sortValue(entry DeckEntry, sortBy string) any {
+	switch sortBy {
+	case "attack_score":
+		return entry.AttackScore
+	case "defense_score":
+		return entry.DefenseScore
+	case "synergy_score":
+		return entry.SynergyScore
+	case "versatility_score":
+		return entry.VersatilityScore
+	case "f2p_score":
+		return entry.F2PScore
+	case "playability_score":
+		return entry.PlayabilityScore
+	case "avg_elixir":
+		return entry.AvgElixir
+	case "archetype":
+		return entry.Archetype
+	case "strategy":
+		return entry.Strategy
+	case "evaluated_at":
+		return entry.EvaluatedAt
+	case "id":
+		return entry.ID
+	default:
+		return entry.OverallScore
+	}
+}
+
+// applyCursor restricts the query to decks after the cursor's deck in sort
+// order, breaking ties on id
+func applyCursor(query string, args []any, opts QueryOptions) (string, []any, error) {
+	if opts.Cursor == "" {
+		return query, args, nil
+	}
+	c, err := decodeCursor(opts.Cursor)
+	if err != nil {
+		return "", nil, err
+	}
+	sortBy, order := safeSortColumn(opts.SortBy), sortDirection(opts.SortOrder)
+	if c.SortBy != sortBy || c.Order != order {
+		return "", nil, fmt.Errorf("cursor was made for sort %s %s, not %s %s", c.SortBy, c.Order, sortBy, order)
+	}
+
+	value := c.Value
+	if sortBy == "evaluated_at" {
+		text, _ := value.(string)
+		if value, err = time.Parse(time.RFC3339Nano, text); err != nil {
+			return "", nil, fmt.Errorf("invalid cursor %q", opts.Cursor)
+		}
+	}
+	cmp := "<"
+	if order == "ASC" {
+		cmp = ">"
+	}
+	expr := sortExpression(sortBy)
+	query += fmt.Sprintf(" AND (%s %s ? OR (%s = ? AND id %s ?))", expr, cmp, expr, cmp)
+	args = append(args, value, value, c.ID)
+	return query, args, nil
+}
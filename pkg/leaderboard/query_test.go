@@ -0,0 +1,153 @@
+package leaderboard
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQuery_StructuredFilters(t *testing.T) {
+	storage, cleanup := createTestStorage(t)
+	defer cleanup()
+
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	hog := createTestDeckEntry([]string{"Hog Rider", "Ice Golem", "Musketeer", "Cannon", "Ice Spirit", "Skeletons", "Fireball", "The Log"}, 9.0)
+	hog.Archetype, hog.EvaluatedAt, hog.AttackScore = testArchetypeCycle, base, 9.2
+	golem := createTestDeckEntry([]string{"Golem", "Night Witch", "Baby Dragon", "Lumberjack", "Tornado", "Lightning", "Barbarian Barrel", "Mega Minion"}, 8.0)
+	golem.EvaluatedAt, golem.EvaluationVersion, golem.PlayerTag = base.AddDate(0, 0, 10), "2.0.0", "#9YLQ"
+	for _, deck := range []*DeckEntry{hog, golem} {
+		if _, _, err := storage.InsertDeck(deck); err != nil {
+			t.Fatalf("failed to insert deck: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		opts QueryOptions
+		want []float64
+	}{
+		{"player tag", QueryOptions{PlayerTag: "9ylq"}, []float64{8.0}},
+		{"evaluated after", QueryOptions{EvaluatedAfter: base.AddDate(0, 0, 1)}, []float64{8.0}},
+		{"evaluated before", QueryOptions{EvaluatedBefore: base.AddDate(0, 0, 1)}, []float64{9.0}},
+		{"evaluation version", QueryOptions{EvaluationVersion: "1.0.0"}, []float64{9.0}},
+		{"score band", QueryOptions{ScoreBands: []ScoreBand{{Field: "attack", Min: 9}}}, []float64{9.0}},
+		{"exact card", QueryOptions{RequireAllCards: []string{"golem"}}, []float64{8.0}},
+		{"any card", QueryOptions{RequireAnyCards: []string{"Ice Golem", "Tornado"}}, []float64{9.0, 8.0}},
+		{"search words", QueryOptions{Search: "hog rider"}, []float64{9.0}},
+		{"search archetype", QueryOptions{Search: "archetype:beatdown"}, []float64{8.0}},
+		{"search prefix", QueryOptions{Search: "lumber*"}, []float64{8.0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := storage.Query(tt.opts)
+			if err != nil {
+				t.Fatalf("failed to query: %v", err)
+			}
+			if len(results) != len(tt.want) {
+				t.Fatalf("got %d decks, want %d", len(results), len(tt.want))
+			}
+			for i, want := range tt.want {
+				if results[i].OverallScore != want {
+					t.Errorf("result %d score = %f, want %f", i, results[i].OverallScore, want)
+				}
+			}
+		})
+	}
+
+	if _, err := storage.Query(QueryOptions{ScoreBands: []ScoreBand{{Field: "speed", Min: 1}}}); err == nil {
+		t.Error("expected an error for an unknown score band field")
+	}
+
+	if err := storage.DeleteDeck(golem.ID); err != nil {
+		t.Fatalf("failed to delete deck: %v", err)
+	}
+	if results, err := storage.Query(QueryOptions{Search: "lumberjack"}); err != nil || len(results) != 0 {
+		t.Errorf("search after delete = %d decks, %v; want none", len(results), err)
+	}
+}
+
+func TestQueryPage_Cursor(t *testing.T) {
+	storage, cleanup := createTestStorage(t)
+	defer cleanup()
+
+	base := time.Now()
+	for i := range 7 {
+		// Pairs of equal scores and times exercise the id tie-break
+		entry := createTestDeckEntry([]string{"A", "B", "C", "D", "E", "F", "G", string(rune('H' + i))}, float64(10-i/2))
+		entry.EvaluatedAt = base.Add(time.Duration(i/2) * time.Minute)
+		if _, _, err := storage.InsertDeck(entry); err != nil {
+			t.Fatalf("failed to insert deck: %v", err)
+		}
+	}
+
+	for _, sortBy := range []string{"overall_score", "evaluated_at", "strategy"} {
+		for _, order := range []string{"desc", "asc"} {
+			opts := QueryOptions{Limit: 3, SortBy: sortBy, SortOrder: order}
+			seen := map[int]bool{}
+			pages := 0
+			for {
+				page, err := storage.QueryPage(opts)
+				if err != nil {
+					t.Fatalf("%s %s: QueryPage() error = %v", sortBy, order, err)
+				}
+				if page.Total != 7 {
+					t.Errorf("%s %s: Total = %d, want 7", sortBy, order, page.Total)
+				}
+				for _, entry := range page.Entries {
+					if seen[entry.ID] {
+						t.Errorf("%s %s: deck %d returned twice", sortBy, order, entry.ID)
+					}
+					seen[entry.ID] = true
+				}
+				pages++
+				if page.NextCursor == "" {
+					break
+				}
+				opts.Cursor = page.NextCursor
+			}
+			if len(seen) != 7 || pages != 3 {
+				t.Errorf("%s %s: saw %d decks over %d pages, want 7 over 3", sortBy, order, len(seen), pages)
+			}
+		}
+	}
+
+	page, err := storage.QueryPage(QueryOptions{Limit: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = storage.QueryPage(QueryOptions{Limit: 3, SortOrder: "asc", Cursor: page.NextCursor})
+	if err == nil || !strings.Contains(err.Error(), "cursor was made for") {
+		t.Errorf("mismatched cursor error = %v", err)
+	}
+	if _, err := storage.QueryPage(QueryOptions{Cursor: "not-a-cursor"}); err == nil {
+		t.Error("expected an error for an invalid cursor")
+	}
+}
+
+func TestQueryIndexesBackfillExistingDecks(t *testing.T) {
+	storage, cleanup := createTestStorage(t)
+	defer cleanup()
+
+	if _, _, err := storage.InsertDeck(createTestDeckEntry([]string{"Hog Rider", "B", "C", "D", "E", "F", "G", "H"}, 9.0)); err != nil {
+		t.Fatal(err)
+	}
+	// Simulate a database from before the indexes existed
+	for _, stmt := range []string{
+		"DROP TRIGGER decks_index_insert", "DROP TRIGGER decks_index_update", "DROP TRIGGER decks_index_delete",
+		"DROP TABLE deck_cards", "DROP TABLE decks_fts",
+	} {
+		if _, err := storage.db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	if err := storage.initSchema(); err != nil {
+		t.Fatalf("initSchema() error = %v", err)
+	}
+
+	for _, opts := range []QueryOptions{{RequireAllCards: []string{"Hog Rider"}}, {Search: "hog"}} {
+		results, err := storage.Query(opts)
+		if err != nil || len(results) != 1 {
+			t.Errorf("Query(%+v) = %d decks, %v; want 1", opts, len(results), err)
+		}
+	}
+}
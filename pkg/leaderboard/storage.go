@@ -140,7 +140,10 @@ func (s *Storage) initSchema() error {
 		return err
 	}
 
-	return s.maybeMigrateDeckHashes()
+	if err := s.maybeMigrateDeckHashes(); err != nil {
+		return err
+	}
+	return s.initQueryIndexes()
 }
 
 func (s *Storage) maybeMigrateDeckHashes() error {
@@ -269,7 +272,10 @@ func (s *Storage) InsertDeck(entry *DeckEntry) (int, bool, error) {
 
 // Query retrieves deck entries based on the provided options
 func (s *Storage) Query(opts QueryOptions) ([]DeckEntry, error) {
-	query, args := buildDeckQuery(opts)
+	query, args, err := buildDeckQuery(opts)
+	if err != nil {
+		return nil, err
+	}
 
 	rows, err := s.db.Query(query, args...)
 	if err != nil {
@@ -286,16 +292,35 @@ func (s *Storage) Query(opts QueryOptions) ([]DeckEntry, error) {
 }
 
 // buildDeckQuery constructs the SQL query and arguments from query options
-func buildDeckQuery(opts QueryOptions) (string, []any) {
-	query := "SELECT id, deck_hash, cards, overall_score, attack_score, defense_score, synergy_score, versatility_score, f2p_score, playability_score, archetype, archetype_conf, strategy, avg_elixir, evaluated_at, player_tag, evaluation_version FROM decks WHERE 1=1"
-	args := []any{}
+func buildDeckQuery(opts QueryOptions) (string, []any, error) {
+	filters, args, err := buildDeckFilters(opts)
+	if err != nil {
+		return "", nil, err
+	}
+	query := "SELECT id, deck_hash, cards, overall_score, attack_score, defense_score, synergy_score, versatility_score, f2p_score, playability_score, archetype, archetype_conf, strategy, avg_elixir, evaluated_at, player_tag, evaluation_version FROM decks WHERE 1=1" + filters
 
-	query, args = applyScoreFilters(query, args, opts)
+	query, args, err = applyCursor(query, args, opts)
+	if err != nil {
+		return "", nil, err
+	}
+	query = applySortingAndPagination(query, &args, opts)
+
+	return query, args, nil
+}
+
+// buildDeckFilters constructs the WHERE conditions and arguments for the
+// filters of query options
+func buildDeckFilters(opts QueryOptions) (string, []any, error) {
+	query, args := applyScoreFilters("", []any{}, opts)
 	query, args = applyMetadataFilters(query, args, opts)
 	query, args = applyCardFilters(query, args, opts)
-	query = applySortingAndPagination(query, &args, opts)
+	query, args = applySearchFilter(query, args, opts)
 
-	return query, args
+	query, args, err := applyEvaluationFilters(query, args, opts)
+	if err != nil {
+		return "", nil, err
+	}
+	return applyScoreBands(query, args, opts.ScoreBands)
 }
 
 // applyScoreFilters adds score-based filters to the query
@@ -311,7 +336,7 @@ func applyScoreFilters(query string, args []any, opts QueryOptions) (string, []a
 	return query, args
 }
 
-// applyMetadataFilters adds archetype, strategy, and elixir filters
+// applyMetadataFilters adds archetype, strategy, elixir, and scoring version filters
 func applyMetadataFilters(query string, args []any, opts QueryOptions) (string, []any) {
 	if opts.Archetype != "" {
 		query += " AND archetype = ?"
@@ -329,10 +354,19 @@ func applyMetadataFilters(query string, args []any, opts QueryOptions) (string,
 		query += " AND avg_elixir <= ?"
 		args = append(args, opts.MaxAvgElixir)
 	}
+	if opts.EvaluationVersion != "" {
+		query += " AND evaluation_version = ?"
+		args = append(args, opts.EvaluationVersion)
+	}
+	if opts.ExcludeEvaluationVersion != "" {
+		query += " AND evaluation_version != ?"
+		args = append(args, opts.ExcludeEvaluationVersion)
+	}
 	return query, args
 }
 
-// applyCardFilters adds card-based filters (require all, any, exclude)
+// applyCardFilters adds card-based filters (require all, any, exclude). Card
+// names match exactly, ignoring case.
 func applyCardFilters(query string, args []any, opts QueryOptions) (string, []any) {
 	query, args = applyRequireAllCards(query, args, opts.RequireAllCards)
 	query, args = applyRequireAnyCards(query, args, opts.RequireAnyCards)
@@ -343,8 +377,8 @@ func applyCardFilters(query string, args []any, opts QueryOptions) (string, []an
 // applyRequireAllCards adds filters for cards that must all be present
 func applyRequireAllCards(query string, args []any, cards []string) (string, []any) {
 	for _, card := range cards {
-		query += " AND cards LIKE ?"
-		args = append(args, "%"+card+"%")
+		query += " AND id IN (SELECT deck_id FROM deck_cards WHERE card = ?)"
+		args = append(args, strings.TrimSpace(card))
 	}
 	return query, args
 }
@@ -355,37 +389,27 @@ func applyRequireAnyCards(query string, args []any, cards []string) (string, []a
 		return query, args
 	}
 
-	var subQuery strings.Builder
-	subQuery.WriteString(" AND (")
-	for i, card := range cards {
-		if i > 0 {
-			subQuery.WriteString(" OR ")
-		}
-		subQuery.WriteString("cards LIKE ?")
-		args = append(args, "%"+card+"%")
+	query += " AND id IN (SELECT deck_id FROM deck_cards WHERE card IN (?" + strings.Repeat(", ?", len(cards)-1) + "))"
+	for _, card := range cards {
+		args = append(args, strings.TrimSpace(card))
 	}
-	subQuery.WriteString(")")
-	query += subQuery.String()
 	return query, args
 }
 
 // applyExcludeCards adds filters for cards that must not be present
 func applyExcludeCards(query string, args []any, cards []string) (string, []any) {
 	for _, card := range cards {
-		query += " AND cards NOT LIKE ?"
-		args = append(args, "%"+card+"%")
+		query += " AND id NOT IN (SELECT deck_id FROM deck_cards WHERE card = ?)"
+		args = append(args, strings.TrimSpace(card))
 	}
 	return query, args
 }
 
-// applySortingAndPagination adds ORDER BY, LIMIT, and OFFSET clauses
+// applySortingAndPagination adds ORDER BY, LIMIT, and OFFSET clauses. Ties
+// are broken by id so pages never overlap.
 func applySortingAndPagination(query string, args *[]any, opts QueryOptions) string {
-	sortBy := safeSortColumn(opts.SortBy)
-	sortOrder := strings.ToUpper(opts.SortOrder)
-	if sortOrder != "ASC" && sortOrder != "DESC" {
-		sortOrder = "DESC"
-	}
-	query += fmt.Sprintf(" ORDER BY %s %s", sortBy, sortOrder)
+	sortOrder := sortDirection(opts.SortOrder)
+	query += fmt.Sprintf(" ORDER BY %s %s, id %s", sortExpression(safeSortColumn(opts.SortBy)), sortOrder, sortOrder)
 
 	if opts.Limit > 0 {
 		query += " LIMIT ?"
@@ -416,6 +440,23 @@ var allowedSortColumns = map[string]struct{}{
 	"id":                {},
 }
 
+// sortExpression returns the ORDER BY expression for a sort column; decks
+// saved without a strategy sort as an empty one
+func sortExpression(sortBy string) string {
+	if sortBy == "strategy" {
+		return "COALESCE(strategy, '')"
+	}
+	return sortBy
+}
+
+// sortDirection returns ASC or DESC for a sort order, defaulting to DESC
+func sortDirection(sortOrder string) string {
+	if strings.EqualFold(sortOrder, "asc") {
+		return "ASC"
+	}
+	return "DESC"
+}
+
 func safeSortColumn(sortBy string) string {
 	normalized := strings.ToLower(strings.TrimSpace(sortBy))
 	if normalized == "" {
@@ -25,7 +25,7 @@ func createTestStorage(t *testing.T) (*Storage, func()) {
 	originalHome := os.Getenv("HOME")
 	os.Setenv("HOME", tmpDir)
 
-	storage, err := NewStorage("#TEST123")
+	storage, err := NewStorage("#2PP0Q")
 	if err != nil {
 		os.RemoveAll(tmpDir)
 		t.Fatalf("failed to create storage: %v", err)
@@ -57,7 +57,7 @@ func createTestDeckEntry(cards []string, overallScore float64) *DeckEntry {
 		Strategy:          "balanced",
 		AvgElixir:         3.8,
 		EvaluatedAt:       time.Now(),
-		PlayerTag:         "#TEST123",
+		PlayerTag:         "#2PP0Q",
 		EvaluationVersion: "1.0.0",
 	}
 }
@@ -78,7 +78,7 @@ func TestNewStorage(t *testing.T) {
 
 	// Verify path format
 	expectedDir := filepath.Join(os.Getenv("HOME"), ".cr-api", "leaderboards")
-	expectedPath := filepath.Join(expectedDir, "TEST123.db")
+	expectedPath := filepath.Join(expectedDir, "2PP0Q.db")
 	if dbPath != expectedPath {
 		t.Errorf("expected db path %s, got %s", expectedPath, dbPath)
 	}
@@ -112,13 +112,13 @@ func TestNewStorage_NormalizesPlayerTag(t *testing.T) {
 	os.Setenv("HOME", tmpDir)
 	defer os.Setenv("HOME", originalHome)
 
-	storage, err := NewStorage(" #abc123 ")
+	storage, err := NewStorage(" #2pq0r ")
 	if err != nil {
 		t.Fatalf("expected normalized player tag to be accepted: %v", err)
 	}
 	defer storage.Close()
 
-	expectedPath := filepath.Join(os.Getenv("HOME"), ".cr-api", "leaderboards", "ABC123.db")
+	expectedPath := filepath.Join(os.Getenv("HOME"), ".cr-api", "leaderboards", "2PQ0R.db")
 	if storage.GetDBPath() != expectedPath {
 		t.Fatalf("expected db path %s, got %s", expectedPath, storage.GetDBPath())
 	}
@@ -273,7 +273,7 @@ func TestStorageMigratesLegacyDeckHash(t *testing.T) {
 			deck_hash, cards, overall_score, attack_score, defense_score, synergy_score,
 			versatility_score, f2p_score, playability_score, archetype, archetype_conf,
 			strategy, avg_elixir, evaluated_at, player_tag, evaluation_version
-		) VALUES (?, ?, 5, 5, 5, 5, 5, 5, 5, 'control', 0.5, 'seed', 3.0, CURRENT_TIMESTAMP, '#TEST123', '1.0.0')
+		) VALUES (?, ?, 5, 5, 5, 5, 5, 5, 5, 'control', 0.5, 'seed', 3.0, CURRENT_TIMESTAMP, '#2PP0Q', '1.0.0')
 	`, legacyHash, cardsJSON); err != nil {
 		t.Fatalf("failed to insert legacy row: %v", err)
 	}
@@ -285,7 +285,7 @@ func TestStorageMigratesLegacyDeckHash(t *testing.T) {
 		t.Fatalf("failed to close storage: %v", err)
 	}
 
-	reopened, err := NewStorage("#TEST123")
+	reopened, err := NewStorage("#2PP0Q")
 	if err != nil {
 		t.Fatalf("failed to reopen storage: %v", err)
 	}
@@ -314,7 +314,7 @@ func TestStorageMigratesDuplicateDeckHashesAndRefreshesStats(t *testing.T) {
 			deck_hash, cards, overall_score, attack_score, defense_score, synergy_score,
 			versatility_score, f2p_score, playability_score, archetype, archetype_conf,
 			strategy, avg_elixir, evaluated_at, player_tag, evaluation_version
-		) VALUES (?, ?, 9, 9, 9, 9, 9, 9, 9, 'control', 0.9, 'legacy-winner', 3.0, CURRENT_TIMESTAMP, '#TEST123', '1.0.0')
+		) VALUES (?, ?, 9, 9, 9, 9, 9, 9, 9, 'control', 0.9, 'legacy-winner', 3.0, CURRENT_TIMESTAMP, '#2PP0Q', '1.0.0')
 	`, legacyHash, cardsJSON); err != nil {
 		t.Fatalf("failed to insert legacy row: %v", err)
 	}
@@ -324,14 +324,14 @@ func TestStorageMigratesDuplicateDeckHashesAndRefreshesStats(t *testing.T) {
 			deck_hash, cards, overall_score, attack_score, defense_score, synergy_score,
 			versatility_score, f2p_score, playability_score, archetype, archetype_conf,
 			strategy, avg_elixir, evaluated_at, player_tag, evaluation_version
-		) VALUES (?, ?, 7, 7, 7, 7, 7, 7, 7, 'control', 0.7, 'canonical-loser', 3.0, CURRENT_TIMESTAMP, '#TEST123', '1.0.0')
+		) VALUES (?, ?, 7, 7, 7, 7, 7, 7, 7, 'control', 0.7, 'canonical-loser', 3.0, CURRENT_TIMESTAMP, '#2PP0Q', '1.0.0')
 	`, canonicalHash, cardsJSON); err != nil {
 		t.Fatalf("failed to insert canonical row: %v", err)
 	}
 
 	if _, err := storage.db.Exec(`
 		INSERT OR REPLACE INTO stats (id, player_tag, total_decks_evaluated, total_unique_decks, last_updated, avg_eval_time_ms, top_score, avg_score)
-		VALUES (1, '#TEST123', 2, 2, CURRENT_TIMESTAMP, 0, 7, 7)
+		VALUES (1, '#2PP0Q', 2, 2, CURRENT_TIMESTAMP, 0, 7, 7)
 	`); err != nil {
 		t.Fatalf("failed to seed stale stats: %v", err)
 	}
@@ -343,7 +343,7 @@ func TestStorageMigratesDuplicateDeckHashesAndRefreshesStats(t *testing.T) {
 		t.Fatalf("failed to close storage: %v", err)
 	}
 
-	reopened, err := NewStorage("#TEST123")
+	reopened, err := NewStorage("#2PP0Q")
 	if err != nil {
 		t.Fatalf("failed to reopen storage after duplicate migration: %v", err)
 	}
@@ -460,6 +460,29 @@ func TestQuery_WithFilters(t *testing.T) {
 	}
 }
 
+func TestQuery_ExcludeEvaluationVersion(t *testing.T) {
+	storage, cleanup := createTestStorage(t)
+	defer cleanup()
+
+	old := createTestDeckEntry([]string{"A", "B", "C", "D", "E", "F", "G", "H"}, 9.0)
+	current := createTestDeckEntry([]string{"A", "B", "C", "D", "E", "F", "G", "I"}, 8.5)
+	current.EvaluationVersion = "1.1.0"
+
+	for _, deck := range []*DeckEntry{old, current} {
+		if _, _, err := storage.InsertDeck(deck); err != nil {
+			t.Fatalf("failed to insert deck: %v", err)
+		}
+	}
+
+	results, err := storage.Query(QueryOptions{ExcludeEvaluationVersion: "1.1.0"})
+	if err != nil {
+		t.Fatalf("failed to query: %v", err)
+	}
+	if len(results) != 1 || results[0].EvaluationVersion != "1.0.0" {
+		t.Fatalf("expected only the 1.0.0 deck, got %+v", results)
+	}
+}
+
 func TestQuery_ScoreRange(t *testing.T) {
 	storage, cleanup := createTestStorage(t)
 	defer cleanup()
@@ -628,8 +651,8 @@ func TestGetStats_Empty(t *testing.T) {
 		t.Fatalf("failed to get stats: %v", err)
 	}
 
-	if stats.PlayerTag != "#TEST123" {
-		t.Errorf("expected player tag #TEST123, got %s", stats.PlayerTag)
+	if stats.PlayerTag != "#2PP0Q" {
+		t.Errorf("expected player tag #2PP0Q, got %s", stats.PlayerTag)
 	}
 
 	if stats.TotalUniqueDecks != 0 {
@@ -55,6 +55,30 @@ type QueryOptions struct {
 	RequireAllCards []string // Filter decks containing ALL of these cards (optional)
 	RequireAnyCards []string // Filter decks containing ANY of these cards (optional)
 	ExcludeCards    []string // Filter out decks containing ANY of these cards (optional)
+
+	ExcludeEvaluationVersion string // Skip decks already scored by this evaluation version (optional)
+
+	PlayerTag         string      // Filter by the player tag decks were evaluated for (optional)
+	EvaluatedAfter    time.Time   // Only decks evaluated at or after this time (zero = no filter)
+	EvaluatedBefore   time.Time   // Only decks evaluated before this time (zero = no filter)
+	EvaluationVersion string      // Only decks scored by this evaluation version (optional)
+	ScoreBands        []ScoreBand // Per-category score ranges, all of which must hold (optional)
+	Search            string      // Full-text search over cards, archetype, and strategy in SQLite FTS4 MATCH syntax (optional)
+	Cursor            string      // Resume after the last deck of a previous page, from Page.NextCursor (optional)
+}
+
+// ScoreBand limits one score column to a range
+type ScoreBand struct {
+	Field string  // Score column: "overall_score", "attack_score", ... ("attack" also works)
+	Min   float64 // Minimum score (0 = no minimum)
+	Max   float64 // Maximum score (0 = no maximum)
+}
+
+// Page is one page of leaderboard query results
+type Page struct {
+	Entries    []DeckEntry `json:"entries"`
+	Total      int         `json:"total"`                 // Decks matching the filters across all pages
+	NextCursor string      `json:"next_cursor,omitempty"` // QueryOptions.Cursor for the next page; empty on the last page
 }
 
 // DefaultQueryOptions returns sensible defaults for leaderboard queries
@@ -0,0 +1,118 @@
+package meta
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+// LadderSource is the subset of the API client the ladder scraper needs.
+type LadderSource interface {
+	GetPathOfLegendRankingsWithContext(ctx context.Context, location string, limit int) (*clashroyale.PlayerRankingList, error)
+	GetPlayerBattleLogWithContext(ctx context.Context, tag string) (*clashroyale.BattleLogResponse, error)
+}
+
+// ladderBattleTypes are the competitive 1v1 modes sampled from top players'
+// logs; friendlies, challenges and events would skew the meta.
+var ladderBattleTypes = map[string]bool{
+	"PvP":          true,
+	"pathOfLegend": true,
+}
+
+// ScrapeOptions controls a ladder scrape.
+type ScrapeOptions struct {
+	// Location is the leaderboard location ID (default "global")
+	Location string
+	// Top is how many leaderboard players to sample
+	Top int
+	// Delay is the minimum gap between battle log requests, on top of the
+	// client's own rate limit
+	Delay time.Duration
+	// OnPlayer, when set, is called after each battle log fetch
+	OnPlayer func(index, total int, tag string, battles int, err error)
+}
+
+// ScrapeResult summarizes a ladder scrape.
+type ScrapeResult struct {
+	Location string `json:"location"`
+	Players  int    `json:"players"`
+	Sampled  int    `json:"sampled"`
+	Failed   int    `json:"failed"`
+}
+
+// ScrapeLadder adds the ladder battles of a location's top players to
+// builder. Individual battle log failures are counted, not fatal.
+func ScrapeLadder(ctx context.Context, source LadderSource, builder *Builder, opts ScrapeOptions) (ScrapeResult, error) {
+	if opts.Location == "" {
+		opts.Location = clashroyale.GlobalLocation
+	}
+	if opts.Top <= 0 {
+		return ScrapeResult{}, fmt.Errorf("top must be at least 1")
+	}
+
+	result := ScrapeResult{Location: opts.Location}
+	rankings, err := source.GetPathOfLegendRankingsWithContext(ctx, opts.Location, opts.Top)
+	if err != nil {
+		return result, fmt.Errorf("failed to get rankings for %s: %w", opts.Location, err)
+	}
+	players := rankings.Items
+	if len(players) > opts.Top {
+		players = players[:opts.Top]
+	}
+	result.Players = len(players)
+
+	var last time.Time
+	for i, player := range players {
+		if err := waitForSlot(ctx, last, opts.Delay); err != nil {
+			return result, err
+		}
+		last = time.Now()
+
+		battles, err := source.GetPlayerBattleLogWithContext(ctx, player.Tag)
+		count := 0
+		if err != nil {
+			result.Failed++
+		} else {
+			ladder := ladderBattles(*battles)
+			count = len(ladder)
+			builder.AddBattleLog(player.Tag, ladder)
+			result.Sampled++
+		}
+		if opts.OnPlayer != nil {
+			opts.OnPlayer(i+1, len(players), player.Tag, count, err)
+		}
+	}
+	return result, nil
+}
+
+// ladderBattles keeps only competitive 1v1 battles.
+func ladderBattles(battles []clashroyale.Battle) []clashroyale.Battle {
+	kept := make([]clashroyale.Battle, 0, len(battles))
+	for _, battle := range battles {
+		if ladderBattleTypes[battle.Type] {
+			kept = append(kept, battle)
+		}
+	}
+	return kept
+}
+
+// waitForSlot sleeps until delay has passed since last, or ctx is done.
+func waitForSlot(ctx context.Context, last time.Time, delay time.Duration) error {
+	if last.IsZero() || delay <= 0 {
+		return ctx.Err()
+	}
+	wait := delay - time.Since(last)
+	if wait <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
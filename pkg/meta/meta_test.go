@@ -0,0 +1,248 @@
+package meta
+
+import (
+	"context"
+	"errors"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+func deckCards(names ...string) []clashroyale.Card {
+	cards := make([]clashroyale.Card, 0, len(names))
+	for _, name := range names {
+		cards = append(cards, clashroyale.Card{Name: name})
+	}
+	return cards
+}
+
+var (
+	hogDeck   = []string{"Hog Rider", "Musketeer", "Cannon", "Ice Spirit", "Skeletons", "The Log", "Fireball", "Ice Golem"}
+	golemDeck = []string{"Golem", "Night Witch", "Baby Dragon", "Lumberjack", "Tornado", "Lightning", "Barbarian Barrel", "Mega Minion"}
+)
+
+func battle(at time.Time, teamTag string, team []string, teamCrowns int, oppTag string, opp []string, oppCrowns int) clashroyale.Battle {
+	return clashroyale.Battle{
+		UTCDate:  at,
+		Team:     []clashroyale.BattleTeam{{Tag: teamTag, Crowns: teamCrowns, Cards: deckCards(team...)}},
+		Opponent: []clashroyale.BattleTeam{{Tag: oppTag, Crowns: oppCrowns, Cards: deckCards(opp...)}},
+	}
+}
+
+func TestBuilderDeduplicatesBattles(t *testing.T) {
+	classify := func(cards []string) string {
+		if cards[0] == "Golem" {
+			return "beatdown"
+		}
+		return "cycle"
+	}
+	builder := NewBuilder(classify)
+	at := time.Date(2026, 10, 12, 18, 0, 0, 0, time.UTC)
+
+	// The same battle seen from both players' logs counts once.
+	builder.AddBattleLog("#AAA", []clashroyale.Battle{battle(at, "#AAA", hogDeck, 3, "#BBB", golemDeck, 1)})
+	builder.AddBattleLog("#BBB", []clashroyale.Battle{battle(at, "#BBB", golemDeck, 1, "#AAA", hogDeck, 3)})
+	builder.AddBattleLog("#AAA", []clashroyale.Battle{battle(at.Add(time.Hour), "#AAA", hogDeck, 0, "#CCC", hogDeck, 1)})
+
+	snapshot := builder.Build(WeekOf(at), at)
+	if snapshot.Week != "2026-W42" {
+		t.Errorf("Week = %s, want 2026-W42", snapshot.Week)
+	}
+	if snapshot.Players != 2 || snapshot.Battles != 2 || snapshot.Decks != 4 {
+		t.Errorf("players/battles/decks = %d/%d/%d, want 2/2/4", snapshot.Players, snapshot.Battles, snapshot.Decks)
+	}
+
+	hog, ok := snapshot.Card("Hog Rider")
+	if !ok || hog.Uses != 3 || hog.Wins != 2 || hog.UsageRate != 0.75 {
+		t.Errorf("Hog Rider stat = %+v", hog)
+	}
+	if first, last := snapshot.Cards[0], snapshot.Cards[len(snapshot.Cards)-1]; first.Uses != 3 || last.Uses != 1 {
+		t.Errorf("cards should be sorted by usage: first %+v, last %+v", first, last)
+	}
+	beatdown, ok := snapshot.Archetype("beatdown")
+	if !ok || beatdown.Uses != 1 || beatdown.WinRate != 0 {
+		t.Errorf("beatdown stat = %+v", beatdown)
+	}
+	if len(snapshot.TopDecks) != 2 || snapshot.TopDecks[0].Uses != 3 {
+		t.Errorf("top decks = %+v", snapshot.TopDecks)
+	}
+}
+
+func TestBuilderSkipsNonLadderShapes(t *testing.T) {
+	builder := NewBuilder(nil)
+	twoVTwo := clashroyale.Battle{
+		Team:     []clashroyale.BattleTeam{{Tag: "#A"}, {Tag: "#B"}},
+		Opponent: []clashroyale.BattleTeam{{Tag: "#C"}, {Tag: "#D"}},
+	}
+	builder.AddBattleLog("#A", []clashroyale.Battle{twoVTwo})
+	builder.AddDeck([]string{"Knight"}, true)
+
+	snapshot := builder.Build("2026-W42", time.Now())
+	if snapshot.Battles != 0 || snapshot.Decks != 0 || len(snapshot.Archetypes) != 0 {
+		t.Errorf("expected empty snapshot, got %+v", snapshot)
+	}
+}
+
+func TestSnapshotStore(t *testing.T) {
+	dir := t.TempDir()
+	for _, week := range []string{"2026-W41", "2026-W39", "2026-W40"} {
+		if _, err := SaveSnapshot(dir, &Snapshot{Week: week}); err != nil {
+			t.Fatalf("SaveSnapshot(%s) error = %v", week, err)
+		}
+	}
+	if _, err := SaveSnapshot(dir, &Snapshot{Week: "../escape"}); err == nil {
+		t.Error("expected error for invalid week")
+	}
+
+	snapshots, err := LoadRecent(dir, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 2 || snapshots[0].Week != "2026-W40" || snapshots[1].Week != "2026-W41" {
+		t.Errorf("LoadRecent(2) weeks = %v", snapshots)
+	}
+
+	if _, err := LoadSnapshot(dir, "2026-W01"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("LoadSnapshot(missing) error = %v, want ErrNotExist", err)
+	}
+	if weeks, err := ListWeeks(t.TempDir()); err != nil || len(weeks) != 0 {
+		t.Errorf("ListWeeks(empty) = %v, %v", weeks, err)
+	}
+}
+
+func TestComputeTrends(t *testing.T) {
+	snapshots := []*Snapshot{
+		{Week: "2026-W40", Cards: []UsageStat{
+			{Name: "Hog Rider", Uses: 30, UsageRate: 0.30, WinRate: 0.50},
+			{Name: "Golem", Uses: 20, UsageRate: 0.20, WinRate: 0.52},
+			{Name: "Knight", Uses: 10, UsageRate: 0.10, WinRate: 0.50},
+			{Name: "Rare Pick", Uses: 1, UsageRate: 0.01},
+		}},
+		{Week: "2026-W41", Cards: []UsageStat{
+			{Name: "Hog Rider", Uses: 35, UsageRate: 0.35, WinRate: 0.53},
+			{Name: "Golem", Uses: 15, UsageRate: 0.15, WinRate: 0.48},
+			{Name: "Knight", Uses: 10, UsageRate: 0.105, WinRate: 0.50},
+		}},
+		{Week: "2026-W42", Cards: []UsageStat{
+			{Name: "Hog Rider", Uses: 40, UsageRate: 0.40, WinRate: 0.54},
+			{Name: "Knight", Uses: 11, UsageRate: 0.11, WinRate: 0.51},
+			{Name: "Mortar", Uses: 8, UsageRate: 0.08, WinRate: 0.55},
+		}},
+	}
+
+	report := ComputeTrends(snapshots, DefaultTrendOptions())
+	if len(report.Weeks) != 3 {
+		t.Fatalf("weeks = %v", report.Weeks)
+	}
+	if len(report.Cards) != 4 {
+		t.Fatalf("expected Rare Pick to be filtered by MinUses, got %d cards", len(report.Cards))
+	}
+
+	rising := Rising(report.Cards, 0)
+	if len(rising) != 2 || rising[0].Name != "Hog Rider" || rising[1].Name != "Mortar" {
+		t.Errorf("rising = %+v", rising)
+	}
+	if math.Abs(rising[0].Slope-0.05) > 1e-9 || math.Abs(rising[0].WinRateDelta-0.04) > 1e-9 {
+		t.Errorf("Hog Rider slope/win delta = %v/%v", rising[0].Slope, rising[0].WinRateDelta)
+	}
+	if rising[1].WinRateDelta != 0 {
+		t.Errorf("Mortar was not in the first snapshot, win delta = %v", rising[1].WinRateDelta)
+	}
+
+	falling := Falling(report.Cards, 1)
+	if len(falling) != 1 || falling[0].Name != "Golem" || falling[0].LastUsage != 0 {
+		t.Errorf("falling = %+v", falling)
+	}
+
+	directions := report.CardDirections()
+	if directions["Knight"] != TrendStable || directions["Golem"] != TrendFalling {
+		t.Errorf("directions = %v", directions)
+	}
+}
+
+type fakeLadder struct {
+	rankings []clashroyale.PlayerRanking
+	logs     map[string][]clashroyale.Battle
+	limit    int
+}
+
+func (f *fakeLadder) GetPathOfLegendRankingsWithContext(_ context.Context, _ string, limit int) (*clashroyale.PlayerRankingList, error) {
+	f.limit = limit
+	return &clashroyale.PlayerRankingList{Items: f.rankings}, nil
+}
+
+func (f *fakeLadder) GetPlayerBattleLogWithContext(_ context.Context, tag string) (*clashroyale.BattleLogResponse, error) {
+	battles, ok := f.logs[tag]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	response := clashroyale.BattleLogResponse(battles)
+	return &response, nil
+}
+
+func TestScrapeLadder(t *testing.T) {
+	at := time.Date(2026, 10, 12, 18, 0, 0, 0, time.UTC)
+	ladder := battle(at, "#AAA", hogDeck, 3, "#BBB", golemDeck, 0)
+	ladder.Type = "pathOfLegend"
+	friendly := battle(at.Add(time.Hour), "#AAA", hogDeck, 1, "#FFF", hogDeck, 0)
+	friendly.Type = "friendly"
+
+	source := &fakeLadder{
+		rankings: []clashroyale.PlayerRanking{{Tag: "#AAA"}, {Tag: "#MISSING"}, {Tag: "#BBB"}},
+		logs: map[string][]clashroyale.Battle{
+			"#AAA": {ladder, friendly},
+		},
+	}
+
+	builder := NewBuilder(nil)
+	result, err := ScrapeLadder(context.Background(), source, builder, ScrapeOptions{Top: 2})
+	if err != nil {
+		t.Fatalf("ScrapeLadder() error = %v", err)
+	}
+	if source.limit != 2 {
+		t.Errorf("rankings limit = %d, want 2", source.limit)
+	}
+	if result.Location != clashroyale.GlobalLocation || result.Players != 2 || result.Sampled != 1 || result.Failed != 1 {
+		t.Errorf("result = %+v", result)
+	}
+
+	snapshot := builder.Build(WeekOf(at), at)
+	if snapshot.Battles != 1 || snapshot.Decks != 2 {
+		t.Errorf("battles/decks = %d/%d, want only the ladder battle", snapshot.Battles, snapshot.Decks)
+	}
+
+	if _, err := ScrapeLadder(context.Background(), source, builder, ScrapeOptions{}); err == nil {
+		t.Error("expected error for zero top")
+	}
+}
+
+func TestDeckRelevance(t *testing.T) {
+	at := time.Date(2026, 10, 12, 18, 0, 0, 0, time.UTC)
+	builder := NewBuilder(nil)
+	for i := range 10 {
+		builder.AddDeck(hogDeck, i%2 == 0)
+	}
+	builder.AddDeck(golemDeck, false)
+	snapshot := builder.Build(WeekOf(at), at)
+
+	hog := snapshot.DeckRelevance(hogDeck)
+	golem := snapshot.DeckRelevance(golemDeck)
+	if hog.Score <= golem.Score {
+		t.Errorf("popular deck relevance %.2f should beat rare deck %.2f", hog.Score, golem.Score)
+	}
+	if hog.TopDeckUses != 10 {
+		t.Errorf("TopDeckUses = %d, want 10", hog.TopDeckUses)
+	}
+	// Every card at top usage with an even win rate: 0.7 + 0.3*0.5
+	if math.Abs(hog.Score-8.5) > 1e-9 {
+		t.Errorf("hog relevance = %.4f, want 8.5", hog.Score)
+	}
+
+	unseen := snapshot.DeckRelevance([]string{"Mortar", "Goblin Gang"})
+	if unseen.Score != 0 || len(unseen.Cards) != 2 {
+		t.Errorf("unseen deck relevance = %+v, want zero", unseen)
+	}
+}
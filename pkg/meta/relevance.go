@@ -0,0 +1,91 @@
+package meta
+
+import "strings"
+
+// relevancePriorUses shrinks win rates from small samples toward 50%, as if
+// every card had this many extra uses at an even win rate.
+const relevancePriorUses = 10
+
+// Weights of popularity and win rate in a card's relevance.
+const (
+	relevancePopularityWeight = 0.7
+	relevanceWinRateWeight    = 0.3
+)
+
+// CardRelevance is how present and successful one card is in a snapshot.
+type CardRelevance struct {
+	Name      string  `json:"name"`
+	UsageRate float64 `json:"usage_rate"`
+	WinRate   float64 `json:"win_rate"`
+	// Relevance combines popularity and win rate (0-1)
+	Relevance float64 `json:"relevance"`
+}
+
+// Relevance is a deck's meta relevance score against one snapshot.
+type Relevance struct {
+	Week string `json:"week"`
+	// Score is the average card relevance scaled to 0-10
+	Score float64         `json:"score"`
+	Cards []CardRelevance `json:"cards"`
+	// TopDeckUses is how often this exact deck appeared, 0 when it is not a top deck
+	TopDeckUses int `json:"top_deck_uses,omitempty"`
+}
+
+// DeckRelevance scores how well a deck's cards line up with the snapshot's
+// meta. Popularity is each card's usage relative to the most-used card, and
+// the win rate is shrunk toward 50% for rarely seen cards. Cards the snapshot
+// never saw contribute zero.
+func (s *Snapshot) DeckRelevance(cards []string) Relevance {
+	relevance := Relevance{Week: s.Week}
+	if len(cards) == 0 {
+		return relevance
+	}
+
+	maxUsage := 0.0
+	for _, stat := range s.Cards {
+		maxUsage = max(maxUsage, stat.UsageRate)
+	}
+
+	total := 0.0
+	for _, card := range cards {
+		entry := CardRelevance{Name: card}
+		if stat, ok := s.Card(card); ok && maxUsage > 0 {
+			entry.UsageRate = stat.UsageRate
+			entry.WinRate = stat.WinRate
+			popularity := stat.UsageRate / maxUsage
+			winRate := (float64(stat.Wins) + relevancePriorUses/2) / float64(stat.Uses+relevancePriorUses)
+			entry.Relevance = relevancePopularityWeight*popularity + relevanceWinRateWeight*winRate
+		}
+		total += entry.Relevance
+		relevance.Cards = append(relevance.Cards, entry)
+	}
+	relevance.Score = total / float64(len(cards)) * 10
+
+	for _, deck := range s.TopDecks {
+		if sameCards(deck.Cards, cards) {
+			relevance.TopDeckUses = deck.Uses
+			break
+		}
+	}
+	return relevance
+}
+
+// sameCards reports whether two card lists hold the same cards, ignoring
+// order and case.
+func sameCards(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int, len(a))
+	for _, card := range a {
+		counts[strings.ToLower(card)]++
+	}
+	for _, card := range b {
+		key := strings.ToLower(card)
+		if counts[key] == 0 {
+			return false
+		}
+		counts[key]--
+	}
+	return true
+}
@@ -0,0 +1,253 @@
+// Package meta aggregates card and archetype usage from battle logs into
+// weekly meta snapshots and compares snapshots over time.
+package meta
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+// Snapshot is the aggregated card and archetype usage for one ISO week.
+type Snapshot struct {
+	Week       string          `json:"week"` // ISO week, e.g. "2026-W42"
+	CreatedAt  time.Time       `json:"created_at"`
+	Source     string          `json:"source,omitempty"`
+	Players    int             `json:"players"`
+	Battles    int             `json:"battles"`
+	Decks      int             `json:"decks"`
+	Cards      []UsageStat     `json:"cards"`
+	Archetypes []UsageStat     `json:"archetypes"`
+	TopDecks   []DeckUsageStat `json:"top_decks,omitempty"`
+}
+
+// UsageStat is how often a card or archetype appeared and how often it won.
+type UsageStat struct {
+	Name      string  `json:"name"`
+	Uses      int     `json:"uses"`
+	Wins      int     `json:"wins"`
+	UsageRate float64 `json:"usage_rate"` // Share of decks containing it (0-1)
+	WinRate   float64 `json:"win_rate"`   // Wins / uses (0-1)
+}
+
+// DeckUsageStat is the usage of one exact deck.
+type DeckUsageStat struct {
+	Cards     []string `json:"cards"`
+	Archetype string   `json:"archetype,omitempty"`
+	Uses      int      `json:"uses"`
+	Wins      int      `json:"wins"`
+	WinRate   float64  `json:"win_rate"`
+}
+
+// Card returns the usage of a card, or false when it was not seen.
+func (s *Snapshot) Card(name string) (UsageStat, bool) {
+	return findStat(s.Cards, name)
+}
+
+// Archetype returns the usage of an archetype, or false when it was not seen.
+func (s *Snapshot) Archetype(name string) (UsageStat, bool) {
+	return findStat(s.Archetypes, name)
+}
+
+func findStat(stats []UsageStat, name string) (UsageStat, bool) {
+	for _, stat := range stats {
+		if strings.EqualFold(stat.Name, name) {
+			return stat, true
+		}
+	}
+	return UsageStat{}, false
+}
+
+// WeekOf returns the ISO week label ("2006-W01") containing t.
+func WeekOf(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%04d-W%02d", year, week)
+}
+
+// ArchetypeClassifier names the archetype of a deck given its card names.
+type ArchetypeClassifier func(cards []string) string
+
+// maxTopDecks bounds how many exact decks a snapshot keeps.
+const maxTopDecks = 25
+
+type usageCounter struct {
+	uses int
+	wins int
+}
+
+type deckCounter struct {
+	usageCounter
+	cards     []string
+	archetype string
+}
+
+// Builder accumulates decks seen in battle logs into a Snapshot. Battles seen
+// from both sides (e.g. two tracked clanmates playing each other) are counted
+// once.
+type Builder struct {
+	classify   ArchetypeClassifier
+	players    map[string]bool
+	battles    map[string]bool
+	decks      int
+	cards      map[string]*usageCounter
+	archetypes map[string]*usageCounter
+	exactDecks map[string]*deckCounter
+}
+
+// NewBuilder creates a snapshot builder. classify may be nil, in which case
+// archetype usage is not tracked.
+func NewBuilder(classify ArchetypeClassifier) *Builder {
+	return &Builder{
+		classify:   classify,
+		players:    make(map[string]bool),
+		battles:    make(map[string]bool),
+		cards:      make(map[string]*usageCounter),
+		archetypes: make(map[string]*usageCounter),
+		exactDecks: make(map[string]*deckCounter),
+	}
+}
+
+// AddBattleLog adds every 1v1 battle from a player's battle log. Both the
+// player's deck and the opponent's deck are counted.
+func (b *Builder) AddBattleLog(playerTag string, battles []clashroyale.Battle) {
+	b.players[clashroyale.NormalizeTag(playerTag)] = true
+	for _, battle := range battles {
+		if len(battle.Team) != 1 || len(battle.Opponent) != 1 {
+			continue
+		}
+		team, opponent := battle.Team[0], battle.Opponent[0]
+		key := battleKey(battle.UTCDate, team.Tag, opponent.Tag)
+		if b.battles[key] {
+			continue
+		}
+		b.battles[key] = true
+
+		b.AddDeck(cardNames(team.Cards), team.Crowns > opponent.Crowns)
+		b.AddDeck(cardNames(opponent.Cards), opponent.Crowns > team.Crowns)
+	}
+}
+
+// AddDeck records one deck appearance. Decks without exactly 8 cards are ignored.
+func (b *Builder) AddDeck(cards []string, won bool) {
+	if len(cards) != 8 {
+		return
+	}
+	b.decks++
+	for _, card := range cards {
+		count(b.cards, card, won)
+	}
+
+	archetype := ""
+	if b.classify != nil {
+		archetype = b.classify(cards)
+		if archetype != "" {
+			count(b.archetypes, archetype, won)
+		}
+	}
+
+	sorted := append([]string(nil), cards...)
+	sort.Strings(sorted)
+	key := strings.Join(sorted, "|")
+	deck, ok := b.exactDecks[key]
+	if !ok {
+		deck = &deckCounter{cards: sorted, archetype: archetype}
+		b.exactDecks[key] = deck
+	}
+	deck.uses++
+	if won {
+		deck.wins++
+	}
+}
+
+// Build returns the snapshot for the given week.
+func (b *Builder) Build(week string, createdAt time.Time) *Snapshot {
+	snapshot := &Snapshot{
+		Week:       week,
+		CreatedAt:  createdAt,
+		Players:    len(b.players),
+		Battles:    len(b.battles),
+		Decks:      b.decks,
+		Cards:      usageStats(b.cards, b.decks),
+		Archetypes: usageStats(b.archetypes, b.decks),
+	}
+
+	for _, deck := range b.exactDecks {
+		snapshot.TopDecks = append(snapshot.TopDecks, DeckUsageStat{
+			Cards:     deck.cards,
+			Archetype: deck.archetype,
+			Uses:      deck.uses,
+			Wins:      deck.wins,
+			WinRate:   ratio(deck.wins, deck.uses),
+		})
+	}
+	sort.Slice(snapshot.TopDecks, func(i, j int) bool {
+		a, c := snapshot.TopDecks[i], snapshot.TopDecks[j]
+		if a.Uses != c.Uses {
+			return a.Uses > c.Uses
+		}
+		return strings.Join(a.Cards, "|") < strings.Join(c.Cards, "|")
+	})
+	if len(snapshot.TopDecks) > maxTopDecks {
+		snapshot.TopDecks = snapshot.TopDecks[:maxTopDecks]
+	}
+	return snapshot
+}
+
+func count(counters map[string]*usageCounter, name string, won bool) {
+	counter, ok := counters[name]
+	if !ok {
+		counter = &usageCounter{}
+		counters[name] = counter
+	}
+	counter.uses++
+	if won {
+		counter.wins++
+	}
+}
+
+// usageStats converts counters to stats sorted by usage, most used first.
+func usageStats(counters map[string]*usageCounter, decks int) []UsageStat {
+	stats := make([]UsageStat, 0, len(counters))
+	for name, counter := range counters {
+		stats = append(stats, UsageStat{
+			Name:      name,
+			Uses:      counter.uses,
+			Wins:      counter.wins,
+			UsageRate: ratio(counter.uses, decks),
+			WinRate:   ratio(counter.wins, counter.uses),
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Uses != stats[j].Uses {
+			return stats[i].Uses > stats[j].Uses
+		}
+		return stats[i].Name < stats[j].Name
+	})
+	return stats
+}
+
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
+
+func cardNames(cards []clashroyale.Card) []string {
+	names := make([]string, 0, len(cards))
+	for _, card := range cards {
+		names = append(names, card.Name)
+	}
+	return names
+}
+
+// battleKey identifies a battle independently of which side's log it came from.
+func battleKey(at time.Time, tagA, tagB string) string {
+	if tagA > tagB {
+		tagA, tagB = tagB, tagA
+	}
+	return at.UTC().Format(time.RFC3339) + "|" + tagA + "|" + tagB
+}
@@ -0,0 +1,87 @@
+package meta
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/internal/storage"
+)
+
+var weekPattern = regexp.MustCompile(`^\d{4}-W\d{2}$`)
+
+// SaveSnapshot writes a snapshot to <dataDir>/meta/snapshots/<week>.json,
+// replacing any earlier snapshot for the same week.
+func SaveSnapshot(dataDir string, snapshot *Snapshot) (string, error) {
+	if !weekPattern.MatchString(snapshot.Week) {
+		return "", fmt.Errorf("invalid snapshot week %q (want YYYY-Www)", snapshot.Week)
+	}
+	path := storage.NewPathBuilder(dataDir).GetMetaSnapshotPath(snapshot.Week)
+	if err := storage.WriteJSON(path, snapshot); err != nil {
+		return "", fmt.Errorf("failed to write meta snapshot: %w", err)
+	}
+	return path, nil
+}
+
+// LoadSnapshot reads the snapshot for a week.
+func LoadSnapshot(dataDir, week string) (*Snapshot, error) {
+	path := storage.NewPathBuilder(dataDir).GetMetaSnapshotPath(week)
+	if !storage.FileExists(path) {
+		return nil, fmt.Errorf("meta snapshot %s: %w", week, os.ErrNotExist)
+	}
+	var snapshot Snapshot
+	if err := storage.ReadJSON(path, &snapshot); err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// ReadSnapshotFile reads a snapshot from an arbitrary path, e.g. one copied
+// out of another data directory.
+func ReadSnapshotFile(path string) (*Snapshot, error) {
+	var snapshot Snapshot
+	if err := storage.ReadJSON(path, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to read meta snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// ListWeeks returns the weeks with a stored snapshot, oldest first.
+func ListWeeks(dataDir string) ([]string, error) {
+	files, err := storage.ListJSONFiles(storage.NewPathBuilder(dataDir).GetMetaSnapshotsDir())
+	if err != nil {
+		return nil, err
+	}
+	weeks := make([]string, 0, len(files))
+	for _, file := range files {
+		week := strings.TrimSuffix(filepath.Base(file), ".json")
+		if weekPattern.MatchString(week) {
+			weeks = append(weeks, week)
+		}
+	}
+	sort.Strings(weeks)
+	return weeks, nil
+}
+
+// LoadRecent returns the last n snapshots, oldest first. n <= 0 loads all.
+func LoadRecent(dataDir string, n int) ([]*Snapshot, error) {
+	weeks, err := ListWeeks(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	if n > 0 && len(weeks) > n {
+		weeks = weeks[len(weeks)-n:]
+	}
+	snapshots := make([]*Snapshot, 0, len(weeks))
+	for _, week := range weeks {
+		snapshot, err := LoadSnapshot(dataDir, week)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
@@ -0,0 +1,194 @@
+package meta
+
+import (
+	"math"
+	"sort"
+)
+
+// Trend directions.
+const (
+	TrendRising  = "rising"
+	TrendFalling = "falling"
+	TrendStable  = "stable"
+)
+
+// TrendOptions controls how snapshot series are classified.
+type TrendOptions struct {
+	// Threshold is the usage-rate change (0-1) needed to call a trend rising or
+	// falling. 0.02 means two percentage points of deck share.
+	Threshold float64
+	// MinUses drops names that never reached this many uses in any snapshot.
+	MinUses int
+}
+
+// DefaultTrendOptions returns sensible defaults for weekly snapshots.
+func DefaultTrendOptions() TrendOptions {
+	return TrendOptions{
+		Threshold: 0.02,
+		MinUses:   5,
+	}
+}
+
+// Trend is the usage history of one card or archetype across snapshots.
+type Trend struct {
+	Name         string    `json:"name"`
+	Direction    string    `json:"direction"`
+	UsageSeries  []float64 `json:"usage_series"` // Usage rate per snapshot, oldest first
+	FirstUsage   float64   `json:"first_usage"`
+	LastUsage    float64   `json:"last_usage"`
+	UsageDelta   float64   `json:"usage_delta"`
+	Slope        float64   `json:"slope"` // Least-squares usage change per snapshot
+	LastWinRate  float64   `json:"last_win_rate"`
+	WinRateDelta float64   `json:"win_rate_delta"` // 0 unless seen in the first and last snapshot
+}
+
+// TrendReport compares a run of snapshots.
+type TrendReport struct {
+	Weeks      []string `json:"weeks"`
+	Cards      []Trend  `json:"cards"`
+	Archetypes []Trend  `json:"archetypes"`
+}
+
+// ComputeTrends builds card and archetype trends from snapshots ordered oldest
+// first. Trends are sorted by usage delta, biggest risers first.
+func ComputeTrends(snapshots []*Snapshot, opts TrendOptions) TrendReport {
+	report := TrendReport{Weeks: make([]string, 0, len(snapshots))}
+	for _, snapshot := range snapshots {
+		report.Weeks = append(report.Weeks, snapshot.Week)
+	}
+	report.Cards = computeSeries(snapshots, opts, func(s *Snapshot) []UsageStat { return s.Cards })
+	report.Archetypes = computeSeries(snapshots, opts, func(s *Snapshot) []UsageStat { return s.Archetypes })
+	return report
+}
+
+func computeSeries(snapshots []*Snapshot, opts TrendOptions, stats func(*Snapshot) []UsageStat) []Trend {
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	type history struct {
+		usage   []float64
+		winRate []float64
+		seen    []bool
+		maxUses int
+	}
+	histories := make(map[string]*history)
+	for i, snapshot := range snapshots {
+		for _, stat := range stats(snapshot) {
+			h, ok := histories[stat.Name]
+			if !ok {
+				h = &history{
+					usage:   make([]float64, len(snapshots)),
+					winRate: make([]float64, len(snapshots)),
+					seen:    make([]bool, len(snapshots)),
+				}
+				histories[stat.Name] = h
+			}
+			h.usage[i] = stat.UsageRate
+			h.winRate[i] = stat.WinRate
+			h.seen[i] = true
+			h.maxUses = max(h.maxUses, stat.Uses)
+		}
+	}
+
+	last := len(snapshots) - 1
+	trends := make([]Trend, 0, len(histories))
+	for name, h := range histories {
+		if h.maxUses < opts.MinUses {
+			continue
+		}
+		trend := Trend{
+			Name:        name,
+			UsageSeries: h.usage,
+			FirstUsage:  h.usage[0],
+			LastUsage:   h.usage[last],
+			UsageDelta:  h.usage[last] - h.usage[0],
+			Slope:       slope(h.usage),
+			LastWinRate: h.winRate[last],
+		}
+		if h.seen[0] && h.seen[last] {
+			trend.WinRateDelta = h.winRate[last] - h.winRate[0]
+		}
+		trend.Direction = classifyTrend(trend.UsageDelta, opts.Threshold)
+		trends = append(trends, trend)
+	}
+
+	sort.Slice(trends, func(i, j int) bool {
+		if trends[i].UsageDelta != trends[j].UsageDelta {
+			return trends[i].UsageDelta > trends[j].UsageDelta
+		}
+		return trends[i].Name < trends[j].Name
+	})
+	return trends
+}
+
+func classifyTrend(delta, threshold float64) string {
+	switch {
+	case delta >= threshold:
+		return TrendRising
+	case delta <= -threshold:
+		return TrendFalling
+	default:
+		return TrendStable
+	}
+}
+
+// slope is the least-squares slope of values against their index.
+func slope(values []float64) float64 {
+	n := float64(len(values))
+	if n < 2 {
+		return 0
+	}
+	var sumX, sumY, sumXY, sumXX float64
+	for i, y := range values {
+		x := float64(i)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	denominator := n*sumXX - sumX*sumX
+	if math.Abs(denominator) < 1e-12 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
+
+// Rising returns up to n rising trends, biggest gain first.
+func Rising(trends []Trend, n int) []Trend {
+	var rising []Trend
+	for _, trend := range trends {
+		if trend.Direction == TrendRising {
+			rising = append(rising, trend)
+		}
+	}
+	return limitTrends(rising, n)
+}
+
+// Falling returns up to n falling trends, biggest drop first.
+func Falling(trends []Trend, n int) []Trend {
+	var falling []Trend
+	for i := len(trends) - 1; i >= 0; i-- {
+		if trends[i].Direction == TrendFalling {
+			falling = append(falling, trends[i])
+		}
+	}
+	return limitTrends(falling, n)
+}
+
+func limitTrends(trends []Trend, n int) []Trend {
+	if n > 0 && len(trends) > n {
+		return trends[:n]
+	}
+	return trends
+}
+
+// CardDirections maps each card to its trend direction, for meta-aware
+// evaluation (see evaluation.MetaAnalyzer.SetCardTrends).
+func (r TrendReport) CardDirections() map[string]string {
+	directions := make(map[string]string, len(r.Cards))
+	for _, trend := range r.Cards {
+		directions[trend.Name] = trend.Direction
+	}
+	return directions
+}
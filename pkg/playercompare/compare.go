@@ -0,0 +1,291 @@
+// Package playercompare compares several players side by side: trophies,
+// career and recent win rates, average card levels per rarity, the decks
+// they share, and their head-to-head record from battle logs.
+package playercompare
+
+import (
+	"maps"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deckhash"
+)
+
+// Input is one player's profile and battle log. Battles may be empty when
+// the log could not be fetched.
+type Input struct {
+	Player  *clashroyale.Player
+	Battles []clashroyale.Battle
+}
+
+// DeckUsage is a deck and how it did in a battle log.
+type DeckUsage struct {
+	Cards   []string `json:"cards"`
+	Battles int      `json:"battles"`
+	Wins    int      `json:"wins"`
+}
+
+// PlayerSummary is one player's column in the comparison.
+type PlayerSummary struct {
+	Tag           string  `json:"tag"`
+	Name          string  `json:"name"`
+	Trophies      int     `json:"trophies"`
+	BestTrophies  int     `json:"best_trophies"`
+	Arena         string  `json:"arena,omitempty"`
+	Wins          int     `json:"wins"`
+	Losses        int     `json:"losses"`
+	WinRate       float64 `json:"win_rate"`
+	RecentBattles int     `json:"recent_battles"`
+	RecentWins    int     `json:"recent_wins"`
+	RecentWinRate float64 `json:"recent_win_rate"`
+	// AvgLevels is the average card level per rarity on the shared 1-16 scale
+	AvgLevels   map[string]float64 `json:"avg_levels"`
+	CurrentDeck []string           `json:"current_deck,omitempty"`
+	TopDeck     *DeckUsage         `json:"top_deck,omitempty"`
+}
+
+// SharedDeck is a deck played by more than one of the compared players.
+type SharedDeck struct {
+	Cards   []string `json:"cards"`
+	Players []string `json:"players"` // player tags
+	Battles int      `json:"battles"`
+	Wins    int      `json:"wins"`
+}
+
+// HeadToHead is the record between two compared players in their battle logs.
+type HeadToHead struct {
+	PlayerA string `json:"player_a"`
+	PlayerB string `json:"player_b"`
+	WinsA   int    `json:"wins_a"`
+	WinsB   int    `json:"wins_b"`
+	Draws   int    `json:"draws"`
+}
+
+// Battles is the number of games between the two players.
+func (h HeadToHead) Battles() int {
+	return h.WinsA + h.WinsB + h.Draws
+}
+
+// Comparison is the side-by-side result for the compared players.
+type Comparison struct {
+	Players     []PlayerSummary `json:"players"`
+	SharedDecks []SharedDeck    `json:"shared_decks,omitempty"`
+	HeadToHead  []HeadToHead    `json:"head_to_head,omitempty"`
+}
+
+// Compare builds the comparison in input order. Only 1v1 battles count
+// toward recent win rates, decks, and head-to-head records.
+func Compare(inputs []Input) *Comparison {
+	result := &Comparison{}
+	shared := make(map[string]*SharedDeck)
+	var sharedOrder []string
+
+	for _, in := range inputs {
+		summary := summarize(in)
+		result.Players = append(result.Players, summary)
+
+		decks := deckUsage(in.Battles)
+		if len(summary.CurrentDeck) == 8 {
+			key := deckhash.CanonicalDeckKey(summary.CurrentDeck)
+			if _, ok := decks[key]; !ok {
+				decks[key] = &DeckUsage{Cards: sortedCards(summary.CurrentDeck)}
+			}
+		}
+		for _, key := range slices.Sorted(maps.Keys(decks)) {
+			usage := decks[key]
+			deck, ok := shared[key]
+			if !ok {
+				deck = &SharedDeck{Cards: usage.Cards}
+				shared[key] = deck
+				sharedOrder = append(sharedOrder, key)
+			}
+			deck.Players = append(deck.Players, summary.Tag)
+			deck.Battles += usage.Battles
+			deck.Wins += usage.Wins
+		}
+	}
+
+	for _, key := range sharedOrder {
+		if deck := shared[key]; len(deck.Players) > 1 {
+			result.SharedDecks = append(result.SharedDecks, *deck)
+		}
+	}
+	sort.SliceStable(result.SharedDecks, func(i, j int) bool {
+		a, b := result.SharedDecks[i], result.SharedDecks[j]
+		if len(a.Players) != len(b.Players) {
+			return len(a.Players) > len(b.Players)
+		}
+		return a.Battles > b.Battles
+	})
+
+	result.HeadToHead = headToHead(inputs)
+	return result
+}
+
+func summarize(in Input) PlayerSummary {
+	p := in.Player
+	summary := PlayerSummary{
+		Tag:          clashroyale.NormalizeTag(p.Tag),
+		Name:         p.Name,
+		Trophies:     p.Trophies,
+		BestTrophies: p.BestTrophies,
+		Arena:        p.Arena.Name,
+		Wins:         p.Wins,
+		Losses:       p.Losses,
+		WinRate:      rate(p.Wins, p.Wins+p.Losses),
+		AvgLevels:    averageLevels(p.Cards),
+	}
+	for _, card := range p.CurrentDeck {
+		summary.CurrentDeck = append(summary.CurrentDeck, card.Name)
+	}
+
+	for _, battle := range in.Battles {
+		team, opponent, ok := duel(battle)
+		if !ok {
+			continue
+		}
+		summary.RecentBattles++
+		if team.Crowns > opponent.Crowns {
+			summary.RecentWins++
+		}
+	}
+	summary.RecentWinRate = rate(summary.RecentWins, summary.RecentBattles)
+
+	decks := deckUsage(in.Battles)
+	for _, key := range slices.Sorted(maps.Keys(decks)) {
+		if usage := decks[key]; summary.TopDeck == nil || usage.Battles > summary.TopDeck.Battles {
+			summary.TopDeck = usage
+		}
+	}
+	return summary
+}
+
+// averageLevels averages card levels per rarity on the shared 1-16 scale
+func averageLevels(cards []clashroyale.Card) map[string]float64 {
+	totals := make(map[string]int)
+	counts := make(map[string]int)
+	for _, card := range cards {
+		rarity := config.NormalizeRarity(card.Rarity)
+		candidate := deck.CardCandidate{Level: card.Level, MaxLevel: card.MaxLevel, Rarity: rarity}
+		totals[rarity] += candidate.StandardLevel()
+		counts[rarity]++
+	}
+	avg := make(map[string]float64, len(totals))
+	for rarity, total := range totals {
+		avg[rarity] = float64(total) / float64(counts[rarity])
+	}
+	return avg
+}
+
+// deckUsage groups a battle log's 8-card decks by canonical key
+func deckUsage(battles []clashroyale.Battle) map[string]*DeckUsage {
+	decks := make(map[string]*DeckUsage)
+	for _, battle := range battles {
+		team, opponent, ok := duel(battle)
+		if !ok || len(team.Cards) != 8 {
+			continue
+		}
+		cards := make([]string, 0, len(team.Cards))
+		for _, card := range team.Cards {
+			cards = append(cards, card.Name)
+		}
+		key := deckhash.CanonicalDeckKey(cards)
+		usage, ok := decks[key]
+		if !ok {
+			usage = &DeckUsage{Cards: sortedCards(cards)}
+			decks[key] = usage
+		}
+		usage.Battles++
+		if team.Crowns > opponent.Crowns {
+			usage.Wins++
+		}
+	}
+	return decks
+}
+
+// headToHead finds battles between each pair of compared players. A battle
+// in both players' logs is counted once.
+func headToHead(inputs []Input) []HeadToHead {
+	index := make(map[string]int, len(inputs))
+	for i, in := range inputs {
+		index[clashroyale.NormalizeTag(in.Player.Tag)] = i
+	}
+
+	seen := make(map[string]bool)
+	records := make(map[[2]int]*HeadToHead)
+	for i, in := range inputs {
+		for _, battle := range in.Battles {
+			team, opponent, ok := duel(battle)
+			if !ok {
+				continue
+			}
+			j, compared := index[clashroyale.NormalizeTag(opponent.Tag)]
+			if !compared || j == i {
+				continue
+			}
+			a, b := min(i, j), max(i, j)
+			key := battle.UTCDate.UTC().Format(time.RFC3339) + "|" + inputs[a].Player.Tag + "|" + inputs[b].Player.Tag
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			record, ok := records[[2]int{a, b}]
+			if !ok {
+				record = &HeadToHead{
+					PlayerA: clashroyale.NormalizeTag(inputs[a].Player.Tag),
+					PlayerB: clashroyale.NormalizeTag(inputs[b].Player.Tag),
+				}
+				records[[2]int{a, b}] = record
+			}
+			// Crowns are from player i's side
+			crownsA, crownsB := team.Crowns, opponent.Crowns
+			if i != a {
+				crownsA, crownsB = crownsB, crownsA
+			}
+			switch {
+			case crownsA > crownsB:
+				record.WinsA++
+			case crownsB > crownsA:
+				record.WinsB++
+			default:
+				record.Draws++
+			}
+		}
+	}
+
+	var result []HeadToHead
+	for a := range inputs {
+		for b := a + 1; b < len(inputs); b++ {
+			if record, ok := records[[2]int{a, b}]; ok {
+				result = append(result, *record)
+			}
+		}
+	}
+	return result
+}
+
+// duel returns the two sides of a 1v1 battle
+func duel(battle clashroyale.Battle) (clashroyale.BattleTeam, clashroyale.BattleTeam, bool) {
+	if len(battle.Team) != 1 || len(battle.Opponent) != 1 {
+		return clashroyale.BattleTeam{}, clashroyale.BattleTeam{}, false
+	}
+	return battle.Team[0], battle.Opponent[0], true
+}
+
+func sortedCards(cards []string) []string {
+	sorted := slices.Clone(cards)
+	slices.Sort(sorted)
+	return sorted
+}
+
+func rate(wins, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(wins) / float64(total) * 100
+}
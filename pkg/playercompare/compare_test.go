@@ -0,0 +1,91 @@
+package playercompare
+
+import (
+	"testing"
+	"time"
+
+	"github.com/klauer/clash-royale-api/go/pkg/clashroyale"
+)
+
+var hogCycle = []string{"Hog Rider", "Musketeer", "Ice Spirit", "Skeletons", "Cannon", "Fireball", "The Log", "Ice Golem"}
+
+func cards(names ...string) []clashroyale.Card {
+	out := make([]clashroyale.Card, len(names))
+	for i, name := range names {
+		out[i] = clashroyale.Card{Name: name}
+	}
+	return out
+}
+
+func duelBattle(at time.Time, tag, opponent string, crowns, opponentCrowns int, deck []string) clashroyale.Battle {
+	return clashroyale.Battle{
+		Type:     "PvP",
+		UTCDate:  at,
+		Team:     []clashroyale.BattleTeam{{Tag: tag, Crowns: crowns, Cards: cards(deck...)}},
+		Opponent: []clashroyale.BattleTeam{{Tag: opponent, Crowns: opponentCrowns, Cards: cards("Golem")}},
+	}
+}
+
+func TestCompare(t *testing.T) {
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	alice := &clashroyale.Player{
+		Tag: "#AAA", Name: "Alice", Trophies: 7000, Wins: 60, Losses: 40,
+		Cards: []clashroyale.Card{
+			{Name: "Knight", Rarity: "common", Level: 14, MaxLevel: 16},
+			{Name: "Archers", Rarity: "common", Level: 12, MaxLevel: 16},
+			// Level 5 of an Epic's 11 is standard level 10
+			{Name: "Golem", Rarity: "epic", Level: 5, MaxLevel: 11},
+		},
+	}
+	bob := &clashroyale.Player{Tag: "#BBB", Name: "Bob", Trophies: 6500, Wins: 10, Losses: 30, CurrentDeck: cards(hogCycle...)}
+	carol := &clashroyale.Player{Tag: "#CCC", Name: "Carol"}
+
+	// The Alice vs Bob game appears in both logs and counts once
+	h2h := base.Add(time.Hour)
+	inputs := []Input{
+		{Player: alice, Battles: []clashroyale.Battle{
+			duelBattle(base, "#AAA", "#ZZZ", 3, 0, hogCycle),
+			duelBattle(h2h, "#AAA", "#bbb", 1, 2, hogCycle),
+			{UTCDate: base, Team: []clashroyale.BattleTeam{{}, {}}, Opponent: []clashroyale.BattleTeam{{}, {}}},
+		}},
+		{Player: bob, Battles: []clashroyale.Battle{
+			duelBattle(h2h, "#BBB", "#AAA", 2, 1, []string{"Golem"}),
+		}},
+		{Player: carol},
+	}
+
+	result := Compare(inputs)
+	if len(result.Players) != 3 {
+		t.Fatalf("players = %+v", result.Players)
+	}
+
+	a := result.Players[0]
+	if a.WinRate != 60 || a.RecentBattles != 2 || a.RecentWins != 1 || a.RecentWinRate != 50 {
+		t.Errorf("alice rates = %+v", a)
+	}
+	if a.AvgLevels["Common"] != 13 || a.AvgLevels["Epic"] != 10 {
+		t.Errorf("alice avg levels = %v", a.AvgLevels)
+	}
+	if a.TopDeck == nil || a.TopDeck.Battles != 2 || a.TopDeck.Wins != 1 {
+		t.Errorf("alice top deck = %+v", a.TopDeck)
+	}
+	if carol := result.Players[2]; carol.RecentBattles != 0 || carol.TopDeck != nil || carol.WinRate != 0 {
+		t.Errorf("carol = %+v", carol)
+	}
+
+	// Bob's current deck is Alice's battle deck
+	if len(result.SharedDecks) != 1 {
+		t.Fatalf("shared decks = %+v", result.SharedDecks)
+	}
+	shared := result.SharedDecks[0]
+	if len(shared.Players) != 2 || shared.Players[0] != "#AAA" || shared.Players[1] != "#BBB" || shared.Battles != 2 {
+		t.Errorf("shared deck = %+v", shared)
+	}
+
+	if len(result.HeadToHead) != 1 {
+		t.Fatalf("head to head = %+v", result.HeadToHead)
+	}
+	if h := result.HeadToHead[0]; h.PlayerA != "#AAA" || h.PlayerB != "#BBB" || h.WinsA != 0 || h.WinsB != 1 || h.Battles() != 1 {
+		t.Errorf("head to head = %+v", h)
+	}
+}
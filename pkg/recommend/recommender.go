@@ -8,6 +8,7 @@ import (
 
 	"github.com/klauer/clash-royale-api/go/pkg/archetypes"
 	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
 )
 
 // Recommender generates deck recommendations combining archetype matches with custom variations
@@ -103,13 +104,16 @@ func (r *Recommender) GenerateRecommendations(
 		recommendations = recommendations[:r.options.Limit]
 	}
 
-	// 8. Determine top archetype
+	// 8. Suggest owned stand-ins for missing and underleveled cards
+	r.suggestSubstitutions(recommendations, analysis)
+
+	// 9. Determine top archetype
 	topArchetype := ""
 	if len(recommendations) > 0 {
 		topArchetype = recommendations[0].ArchetypeName
 	}
 
-	// 9. Generate reasons for all recommendations
+	// 10. Generate reasons for all recommendations
 	for _, rec := range recommendations {
 		if len(rec.Reasons) == 0 {
 			rec.Reasons = r.scorer.GenerateReasons(rec)
@@ -174,6 +178,13 @@ func (r *Recommender) scoreRecommendation(
 		archetypeFit = 85.0 // Slightly lower for variations
 	}
 
+	// In event modes, fit means how well the deck suits the mode's rules
+	if preset := evaluation.LookupGameMode(r.options.GameMode); !preset.Standard() {
+		rec.GameMode = preset.Mode
+		rec.GameModeScore = r.scorer.CalculateGameModeFit(rec.Deck.DeckDetail, preset.Mode)
+		archetypeFit = rec.GameModeScore * 10
+	}
+
 	rec.OverallScore = r.scorer.CalculateOverallScore(
 		rec.CompatibilityScore,
 		rec.SynergyScore,
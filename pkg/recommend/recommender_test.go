@@ -8,6 +8,7 @@ import (
 
 	"github.com/klauer/clash-royale-api/go/pkg/archetypes"
 	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
 	"github.com/klauer/clash-royale-api/go/pkg/mulligan"
 )
 
@@ -579,3 +580,41 @@ func TestRecommendationLimit(t *testing.T) {
 		t.Errorf("After applying limit %d, got %d recommendations", limit, len(recommendations))
 	}
 }
+
+// TestSuggestSubstitutions tests that underleveled cards get owned stand-ins
+func TestSuggestSubstitutions(t *testing.T) {
+	analysis := createMockCardAnalysis()
+	var details []deck.CardDetail
+	for _, name := range []string{"Knight", "Archers", "Fireball", "Zap", "Prince", "Hog Rider", "Valkyrie", "Mega Minion"} {
+		level := analysis.CardLevels[name]
+		details = append(details, deck.CardDetail{
+			Name: name, Level: level.Level, MaxLevel: level.MaxLevel, Rarity: level.Rarity, Elixir: level.Elixir,
+		})
+	}
+	newRec := func() *DeckRecommendation {
+		return &DeckRecommendation{Deck: &deck.DeckRecommendation{DeckDetail: details}}
+	}
+
+	recommender := NewRecommender(createTestDataDir(t), DefaultOptions())
+	rec := newRec()
+	recommender.suggestSubstitutions([]*DeckRecommendation{rec}, analysis)
+	if len(rec.Substitutions) == 0 {
+		t.Fatal("expected substitutes for the underleveled Prince")
+	}
+	for _, sub := range rec.Substitutions {
+		if sub.Card != "Prince" || sub.Reason != evaluation.SubstitutionUnderleveled {
+			t.Errorf("unexpected substitution %+v", sub)
+		}
+	}
+	if len(rec.Substitutions) > DefaultOptions().Substitutes {
+		t.Errorf("got %d substitutes, want at most %d", len(rec.Substitutions), DefaultOptions().Substitutes)
+	}
+
+	options := DefaultOptions()
+	options.Substitutes = 0
+	rec = newRec()
+	NewRecommender(createTestDataDir(t), options).suggestSubstitutions([]*DeckRecommendation{rec}, analysis)
+	if rec.Substitutions != nil {
+		t.Errorf("Substitutes 0 should disable suggestions, got %+v", rec.Substitutions)
+	}
+}
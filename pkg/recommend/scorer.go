@@ -5,6 +5,7 @@ import (
 
 	"github.com/klauer/clash-royale-api/go/internal/config"
 	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
 )
 
 // Scoring weights for overall score calculation have been moved to internal/config/constants.go
@@ -124,6 +125,30 @@ func (s *Scorer) CalculateOverallScore(compatibility, synergy, archetypeFit floa
 		(archetypeFit * config.RecommendationWeightArchetypeFit)
 }
 
+// CalculateGameModeFit scores a deck under a game mode preset (0-10) with
+// the deck evaluation engine
+func (s *Scorer) CalculateGameModeFit(deckDetail []deck.CardDetail, mode evaluation.GameMode) float64 {
+	if len(deckDetail) == 0 {
+		return 0
+	}
+	candidates := make([]deck.CardCandidate, 0, len(deckDetail))
+	for _, card := range deckDetail {
+		role := config.GetCardRoleWithEvolution(card.Name, card.EvolutionLevel)
+		candidates = append(candidates, deck.CardCandidate{
+			Name:              card.Name,
+			Level:             card.Level,
+			MaxLevel:          card.MaxLevel,
+			Rarity:            card.Rarity,
+			Elixir:            card.Elixir,
+			Role:              &role,
+			EvolutionLevel:    card.EvolutionLevel,
+			MaxEvolutionLevel: card.MaxEvolutionLevel,
+		})
+	}
+	result := evaluation.EvaluateWithOptions(candidates, s.synergyDB, nil, evaluation.EvaluateOptions{GameMode: mode})
+	return result.OverallScore
+}
+
 // GenerateReasons creates human-readable reasons for why a deck is recommended
 func (s *Scorer) GenerateReasons(rec *DeckRecommendation) []string {
 	reasons := make([]string, 0)
@@ -146,6 +171,15 @@ func (s *Scorer) GenerateReasons(rec *DeckRecommendation) []string {
 		reasons = append(reasons, "Good synergy between key cards")
 	}
 
+	if rec.GameMode != "" {
+		preset := evaluation.LookupGameMode(rec.GameMode)
+		if rec.GameModeScore >= 7 {
+			reasons = append(reasons, fmt.Sprintf("Strong %s fit (%.1f/10)", preset.Name, rec.GameModeScore))
+		} else if rec.GameModeScore < 5 {
+			reasons = append(reasons, fmt.Sprintf("Weak %s fit (%.1f/10) - %s", preset.Name, rec.GameModeScore, preset.Description))
+		}
+	}
+
 	// Archetype-specific reasons
 	switch rec.Archetype {
 	case "cycle":
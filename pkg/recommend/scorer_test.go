@@ -1,9 +1,11 @@
 package recommend
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
 	"github.com/klauer/clash-royale-api/go/pkg/mulligan"
 )
 
@@ -504,3 +506,44 @@ func contains(s, substr string) bool {
 		len(substr) > 0 && (s[:len(substr)] == substr ||
 			(len(s) > len(substr) && contains(s[1:], substr))))
 }
+
+func TestCalculateGameModeFit(t *testing.T) {
+	scorer := NewScorer()
+	deckDetail := []deck.CardDetail{
+		{Name: "Hog Rider", Level: 11, MaxLevel: 14, Rarity: "Rare", Elixir: 4},
+		{Name: "Ice Spirit", Level: 11, MaxLevel: 14, Rarity: "Common", Elixir: 1},
+		{Name: "Skeletons", Level: 11, MaxLevel: 14, Rarity: "Common", Elixir: 1},
+		{Name: "The Log", Level: 11, MaxLevel: 14, Rarity: "Legendary", Elixir: 2},
+		{Name: "Cannon", Level: 11, MaxLevel: 14, Rarity: "Common", Elixir: 3},
+		{Name: "Musketeer", Level: 11, MaxLevel: 14, Rarity: "Rare", Elixir: 4},
+		{Name: "Fireball", Level: 11, MaxLevel: 14, Rarity: "Rare", Elixir: 4},
+		{Name: "Ice Golem", Level: 11, MaxLevel: 14, Rarity: "Rare", Elixir: 2},
+	}
+
+	rage := scorer.CalculateGameModeFit(deckDetail, evaluation.GameModeRage)
+	triple := scorer.CalculateGameModeFit(deckDetail, evaluation.GameModeTripleElixir)
+	if rage <= 0 || rage > 10 || triple <= 0 || triple > 10 {
+		t.Fatalf("fit scores out of range: rage %.2f, triple %.2f", rage, triple)
+	}
+	if triple >= rage {
+		t.Errorf("a 2.6 elixir cycle deck should fit rage (%.2f) better than triple elixir (%.2f)", rage, triple)
+	}
+	if got := scorer.CalculateGameModeFit(nil, evaluation.GameModeRage); got != 0 {
+		t.Errorf("empty deck fit = %.2f, want 0", got)
+	}
+
+	rec := &DeckRecommendation{
+		Deck:               &deck.DeckRecommendation{Deck: []string{"Hog Rider"}, DeckDetail: deckDetail, AvgElixir: 2.6},
+		GameMode:           evaluation.GameModeTripleElixir,
+		GameModeScore:      4,
+		CompatibilityScore: 50,
+	}
+	reasons := scorer.GenerateReasons(rec)
+	found := false
+	for _, reason := range reasons {
+		found = found || strings.HasPrefix(reason, "Weak Triple Elixir fit")
+	}
+	if !found {
+		t.Errorf("reasons %v should flag the weak game mode fit", reasons)
+	}
+}
@@ -0,0 +1,25 @@
+package recommend
+
+import (
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+)
+
+// suggestSubstitutions attaches owned stand-ins for each recommendation's
+// missing and underleveled cards
+func (r *Recommender) suggestSubstitutions(recommendations []*DeckRecommendation, analysis deck.CardAnalysis) {
+	if r.options.Substitutes <= 0 || len(analysis.CardLevels) == 0 {
+		return
+	}
+	playerContext := evaluation.NewPlayerContextFromAnalysis(analysis)
+	opts := evaluation.DefaultSubstitutionOptions()
+	opts.MaxPerCard = r.options.Substitutes
+	opts.Evaluate.GameMode = r.options.GameMode
+
+	for _, rec := range recommendations {
+		if rec.Deck == nil {
+			continue
+		}
+		rec.Substitutions = evaluation.SuggestSubstitutions(rec.Deck.Candidates(), r.scorer.synergyDB, playerContext, opts)
+	}
+}
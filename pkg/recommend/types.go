@@ -4,6 +4,7 @@ package recommend
 
 import (
 	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
 	"github.com/klauer/clash-royale-api/go/pkg/mulligan"
 )
 
@@ -57,6 +58,15 @@ type DeckRecommendation struct {
 
 	// UpgradeCost summarizes resources needed to make this deck competitive
 	UpgradeCost UpgradeCost `json:"upgrade_cost"`
+
+	// GameMode and GameModeScore (0-10) are set when recommending for an
+	// event preset
+	GameMode      evaluation.GameMode `json:"game_mode,omitempty"`
+	GameModeScore float64             `json:"game_mode_score,omitempty"`
+
+	// Substitutions are owned stand-ins for the deck's missing or
+	// underleveled cards, with the score impact of each swap
+	Substitutions []evaluation.Substitution `json:"substitutions,omitempty"`
 }
 
 // RecommendationResult contains all recommendations for a player
@@ -105,6 +115,15 @@ type RecommenderOptions struct {
 
 	// MaxVariationsPerArchetype limits custom variations generated per archetype (default: 2)
 	MaxVariationsPerArchetype int
+
+	// GameMode scores decks for an event preset instead of the ladder. The
+	// archetype-fit share of the overall score then rates how well each
+	// deck suits the mode.
+	GameMode evaluation.GameMode
+
+	// Substitutes is how many owned stand-ins to suggest for each missing or
+	// underleveled card (default: 2, 0 = none)
+	Substitutes int
 }
 
 // DefaultOptions returns default recommender options
@@ -117,5 +136,6 @@ func DefaultOptions() RecommenderOptions {
 		MinCompatibility:          30.0,
 		TargetLevel:               12,
 		MaxVariationsPerArchetype: 2,
+		Substitutes:               evaluation.DefaultSubstitutionOptions().MaxPerCard,
 	}
 }
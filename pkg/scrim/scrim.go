@@ -0,0 +1,126 @@
+// Package scrim pairs decks from two players' collections into balanced
+// friendly-battle matchups: decks with similar evaluation scores and
+// different archetypes, favouring classic style clashes so practice games
+// are competitive and teach something.
+package scrim
+
+import (
+	"math"
+	"sort"
+
+	"github.com/klauer/clash-royale-api/go/pkg/mulligan"
+)
+
+// contrastBonus is how many evaluation points a classic style clash is worth
+// when ranking matchups. Scores are on the 0-10 evaluation scale.
+const contrastBonus = 0.5
+
+// contrasting lists archetype pairs whose play styles clash: one side wins by
+// building a push or out-cycling, the other by defending or chipping.
+var contrasting = map[[2]mulligan.Archetype]bool{
+	{mulligan.ArchetypeBeatdown, mulligan.ArchetypeControl}:     true,
+	{mulligan.ArchetypeBeatdown, mulligan.ArchetypeCycle}:       true,
+	{mulligan.ArchetypeBeatdown, mulligan.ArchetypeSiege}:       true,
+	{mulligan.ArchetypeBeatdown, mulligan.ArchetypeBait}:        true,
+	{mulligan.ArchetypeCycle, mulligan.ArchetypeControl}:        true,
+	{mulligan.ArchetypeCycle, mulligan.ArchetypeSpawndeck}:      true,
+	{mulligan.ArchetypeSiege, mulligan.ArchetypeBridgeSpam}:     true,
+	{mulligan.ArchetypeSiege, mulligan.ArchetypeSpawndeck}:      true,
+	{mulligan.ArchetypeBridgeSpam, mulligan.ArchetypeControl}:   true,
+	{mulligan.ArchetypeBridgeSpam, mulligan.ArchetypeSpawndeck}: true,
+	{mulligan.ArchetypeMidrange, mulligan.ArchetypeBait}:        true,
+}
+
+// Deck is one deck a player can bring to the scrim.
+type Deck struct {
+	Archetype mulligan.Archetype `json:"archetype"`
+	Cards     []string           `json:"cards"`
+	AvgElixir float64            `json:"avg_elixir"`
+	Score     float64            `json:"score"`
+}
+
+// Side is a player and the decks built from their collection.
+type Side struct {
+	Tag   string `json:"tag"`
+	Name  string `json:"name"`
+	Decks []Deck `json:"decks"`
+}
+
+// Matchup pairs one deck from each player.
+type Matchup struct {
+	Home     Deck    `json:"home"`
+	Away     Deck    `json:"away"`
+	ScoreGap float64 `json:"score_gap"`
+	// Contrast reports whether the archetypes are a classic style clash
+	Contrast bool `json:"contrast"`
+}
+
+// Plan is the ranked list of matchups between two players.
+type Plan struct {
+	Home     Side      `json:"home"`
+	Away     Side      `json:"away"`
+	Matchups []Matchup `json:"matchups"`
+}
+
+// Pair ranks every cross-player deck pairing with different archetypes, most
+// balanced first. A classic style clash counts as a smaller score gap. When
+// limit is positive, each deck appears in at most one matchup so the list
+// offers distinct games, and at most limit matchups are returned.
+func Pair(home, away Side, limit int) *Plan {
+	plan := &Plan{Home: home, Away: away}
+
+	var all []Matchup
+	for _, h := range home.Decks {
+		for _, a := range away.Decks {
+			if h.Archetype == a.Archetype {
+				continue
+			}
+			all = append(all, Matchup{
+				Home:     h,
+				Away:     a,
+				ScoreGap: math.Abs(h.Score - a.Score),
+				Contrast: IsContrasting(h.Archetype, a.Archetype),
+			})
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		a, b := all[i], all[j]
+		if ra, rb := rank(a), rank(b); ra != rb {
+			return ra < rb
+		}
+		// Prefer the stronger games when balance is equal
+		return a.Home.Score+a.Away.Score > b.Home.Score+b.Away.Score
+	})
+
+	if limit <= 0 {
+		plan.Matchups = all
+		return plan
+	}
+	usedHome := make(map[mulligan.Archetype]bool)
+	usedAway := make(map[mulligan.Archetype]bool)
+	for _, m := range all {
+		if len(plan.Matchups) == limit {
+			break
+		}
+		if usedHome[m.Home.Archetype] || usedAway[m.Away.Archetype] {
+			continue
+		}
+		usedHome[m.Home.Archetype] = true
+		usedAway[m.Away.Archetype] = true
+		plan.Matchups = append(plan.Matchups, m)
+	}
+	return plan
+}
+
+// IsContrasting reports whether two archetypes are a classic style clash.
+func IsContrasting(a, b mulligan.Archetype) bool {
+	return contrasting[[2]mulligan.Archetype{a, b}] || contrasting[[2]mulligan.Archetype{b, a}]
+}
+
+// rank is the matchup's score gap, less the bonus for a style clash
+func rank(m Matchup) float64 {
+	if m.Contrast {
+		return m.ScoreGap - contrastBonus
+	}
+	return m.ScoreGap
+}
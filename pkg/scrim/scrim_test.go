@@ -0,0 +1,74 @@
+package scrim
+
+import (
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/mulligan"
+)
+
+func TestPairSkipsMirrorArchetypes(t *testing.T) {
+	home := Side{Tag: "#A", Decks: []Deck{{Archetype: mulligan.ArchetypeCycle, Score: 7}}}
+	away := Side{Tag: "#B", Decks: []Deck{{Archetype: mulligan.ArchetypeCycle, Score: 7}}}
+
+	plan := Pair(home, away, 0)
+	if len(plan.Matchups) != 0 {
+		t.Fatalf("expected no matchups for the same archetype, got %+v", plan.Matchups)
+	}
+}
+
+func TestPairRanksBalancedAndContrastingFirst(t *testing.T) {
+	home := Side{Tag: "#A", Decks: []Deck{
+		{Archetype: mulligan.ArchetypeBeatdown, Score: 7.0},
+		{Archetype: mulligan.ArchetypeSiege, Score: 5.0},
+	}}
+	away := Side{Tag: "#B", Decks: []Deck{
+		{Archetype: mulligan.ArchetypeControl, Score: 7.3},
+		{Archetype: mulligan.ArchetypeMidrange, Score: 7.1},
+	}}
+
+	plan := Pair(home, away, 0)
+	if len(plan.Matchups) != 4 {
+		t.Fatalf("expected 4 matchups, got %d", len(plan.Matchups))
+	}
+	// Beatdown vs Control has a 0.3 gap but is a style clash, so it beats the
+	// 0.1 gap of Beatdown vs Midrange.
+	first := plan.Matchups[0]
+	if first.Home.Archetype != mulligan.ArchetypeBeatdown || first.Away.Archetype != mulligan.ArchetypeControl {
+		t.Fatalf("expected beatdown vs control first, got %s vs %s", first.Home.Archetype, first.Away.Archetype)
+	}
+	if !first.Contrast {
+		t.Fatalf("expected beatdown vs control to be contrasting")
+	}
+	if got := plan.Matchups[1].Away.Archetype; got != mulligan.ArchetypeMidrange {
+		t.Fatalf("expected midrange second, got %s", got)
+	}
+}
+
+func TestPairLimitUsesEachDeckOnce(t *testing.T) {
+	home := Side{Decks: []Deck{
+		{Archetype: mulligan.ArchetypeBeatdown, Score: 7},
+		{Archetype: mulligan.ArchetypeCycle, Score: 6},
+	}}
+	away := Side{Decks: []Deck{
+		{Archetype: mulligan.ArchetypeControl, Score: 7},
+		{Archetype: mulligan.ArchetypeBait, Score: 6},
+	}}
+
+	plan := Pair(home, away, 5)
+	if len(plan.Matchups) != 2 {
+		t.Fatalf("expected 2 distinct matchups, got %d", len(plan.Matchups))
+	}
+	if plan.Matchups[0].Home.Archetype == plan.Matchups[1].Home.Archetype ||
+		plan.Matchups[0].Away.Archetype == plan.Matchups[1].Away.Archetype {
+		t.Fatalf("expected each deck once, got %+v", plan.Matchups)
+	}
+}
+
+func TestIsContrastingIsSymmetric(t *testing.T) {
+	if !IsContrasting(mulligan.ArchetypeControl, mulligan.ArchetypeBeatdown) {
+		t.Fatal("expected control vs beatdown to contrast")
+	}
+	if IsContrasting(mulligan.ArchetypeMidrange, mulligan.ArchetypeCycle) {
+		t.Fatal("did not expect midrange vs cycle to contrast")
+	}
+}
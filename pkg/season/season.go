@@ -0,0 +1,212 @@
+// Package season groups a player's trophy history into Clash Royale seasons
+// and reports per-season trophy-road progress: peak, net gain, arena
+// promotions, and a projection for the season in progress.
+package season
+
+import (
+	"sort"
+	"time"
+)
+
+// minProjectionSpan is the shortest stretch of history a projection is fitted to.
+const minProjectionSpan = 6 * time.Hour
+
+// Point is one observed trophy count. Arena is empty when the source (e.g. a
+// battle log entry) does not report it.
+type Point struct {
+	Time     time.Time `json:"time"`
+	Trophies int       `json:"trophies"`
+	Arena    string    `json:"arena,omitempty"`
+}
+
+// Season is one season window. Seasons start on the first Monday of each
+// month (UTC) and end when the next one starts.
+type Season struct {
+	ID    string    `json:"id"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// For returns the season containing t.
+func For(t time.Time) Season {
+	t = t.UTC()
+	start := firstMonday(t.Year(), t.Month())
+	if t.Before(start) {
+		start = firstMonday(t.Year(), t.Month()-1)
+	}
+	end := firstMonday(start.Year(), start.Month()+1)
+	return Season{ID: start.Format("2006-01"), Start: start, End: end}
+}
+
+// firstMonday returns midnight UTC on the first Monday of month. Months
+// outside 1-12 roll over into adjacent years.
+func firstMonday(year int, month time.Month) time.Time {
+	day := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	offset := (int(time.Monday) - int(day.Weekday()) + 7) % 7
+	return day.AddDate(0, 0, offset)
+}
+
+// Promotion records the first time the player was seen in a new arena.
+type Promotion struct {
+	Arena    string    `json:"arena"`
+	Time     time.Time `json:"time"`
+	Trophies int       `json:"trophies"`
+}
+
+// Projection estimates where the season in progress will end.
+type Projection struct {
+	EndTrophies    int     `json:"end_trophies"`
+	TrophiesPerDay float64 `json:"trophies_per_day"`
+	DaysRemaining  float64 `json:"days_remaining"`
+	// Arena is the arena the projected trophies fall in, judged from
+	// arenas and trophy counts seen in the player's own history.
+	Arena string `json:"arena,omitempty"`
+}
+
+// Stats summarizes one season of trophy history.
+type Stats struct {
+	Season
+	StartTrophies int         `json:"start_trophies"`
+	EndTrophies   int         `json:"end_trophies"`
+	PeakTrophies  int         `json:"peak_trophies"`
+	PeakTime      time.Time   `json:"peak_time"`
+	LowTrophies   int         `json:"low_trophies"`
+	NetGain       int         `json:"net_gain"`
+	Samples       int         `json:"samples"`
+	Promotions    []Promotion `json:"promotions,omitempty"`
+	Projection    *Projection `json:"projection,omitempty"`
+}
+
+// Report groups points into seasons, newest first. The season containing now
+// gets a projection when it has enough history to fit a trend.
+func Report(points []Point, now time.Time) []Stats {
+	if len(points) == 0 {
+		return nil
+	}
+	sorted := append([]Point(nil), points...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Time.Before(sorted[j].Time) })
+
+	promotions := findPromotions(sorted)
+
+	var stats []Stats
+	for start := 0; start < len(sorted); {
+		current := For(sorted[start].Time)
+		end := start
+		for end < len(sorted) && sorted[end].Time.Before(current.End) {
+			end++
+		}
+		seasonStats := summarize(current, sorted[start:end])
+		for _, promotion := range promotions {
+			if !promotion.Time.Before(current.Start) && promotion.Time.Before(current.End) {
+				seasonStats.Promotions = append(seasonStats.Promotions, promotion)
+			}
+		}
+		if !now.Before(current.Start) && now.Before(current.End) {
+			seasonStats.Projection = project(sorted[start:end], current, now, sorted)
+		}
+		stats = append(stats, seasonStats)
+		start = end
+	}
+
+	sort.SliceStable(stats, func(i, j int) bool { return stats[i].Start.After(stats[j].Start) })
+	return stats
+}
+
+func summarize(season Season, points []Point) Stats {
+	first, last := points[0], points[len(points)-1]
+	stats := Stats{
+		Season:        season,
+		StartTrophies: first.Trophies,
+		EndTrophies:   last.Trophies,
+		PeakTrophies:  first.Trophies,
+		PeakTime:      first.Time,
+		LowTrophies:   first.Trophies,
+		NetGain:       last.Trophies - first.Trophies,
+		Samples:       len(points),
+	}
+	for _, point := range points {
+		if point.Trophies > stats.PeakTrophies {
+			stats.PeakTrophies = point.Trophies
+			stats.PeakTime = point.Time
+		}
+		stats.LowTrophies = min(stats.LowTrophies, point.Trophies)
+	}
+	return stats
+}
+
+// findPromotions walks history in order and records each move into an arena
+// with more trophies than the one before. Points without an arena are skipped.
+func findPromotions(points []Point) []Promotion {
+	var promotions []Promotion
+	lastArena := ""
+	lastTrophies := 0
+	for _, point := range points {
+		if point.Arena == "" {
+			continue
+		}
+		if lastArena != "" && point.Arena != lastArena && point.Trophies > lastTrophies {
+			promotions = append(promotions, Promotion{Arena: point.Arena, Time: point.Time, Trophies: point.Trophies})
+		}
+		lastArena = point.Arena
+		lastTrophies = point.Trophies
+	}
+	return promotions
+}
+
+// project fits a least-squares trend to the season's points and extends it
+// to the season end.
+func project(points []Point, season Season, now time.Time, history []Point) *Projection {
+	if len(points) < 2 || points[len(points)-1].Time.Sub(points[0].Time) < minProjectionSpan {
+		return nil
+	}
+
+	origin := points[0].Time
+	var sumX, sumY, sumXY, sumXX float64
+	for _, point := range points {
+		x := point.Time.Sub(origin).Hours() / 24
+		y := float64(point.Trophies)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	n := float64(len(points))
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return nil
+	}
+	slope := (n*sumXY - sumX*sumY) / denominator
+
+	last := points[len(points)-1]
+	remaining := season.End.Sub(now).Hours() / 24
+	endTrophies := max(last.Trophies+int(slope*remaining+0.5), 0)
+
+	return &Projection{
+		EndTrophies:    endTrophies,
+		TrophiesPerDay: slope,
+		DaysRemaining:  remaining,
+		Arena:          arenaForTrophies(history, endTrophies),
+	}
+}
+
+// arenaForTrophies returns the arena of the highest observed point at or
+// below trophies, or the lowest observed arena when trophies is below them all.
+func arenaForTrophies(history []Point, trophies int) string {
+	best, bestTrophies := "", -1
+	lowest, lowestTrophies := "", 0
+	for _, point := range history {
+		if point.Arena == "" {
+			continue
+		}
+		if point.Trophies <= trophies && point.Trophies > bestTrophies {
+			best, bestTrophies = point.Arena, point.Trophies
+		}
+		if lowest == "" || point.Trophies < lowestTrophies {
+			lowest, lowestTrophies = point.Arena, point.Trophies
+		}
+	}
+	if best != "" {
+		return best
+	}
+	return lowest
+}
@@ -0,0 +1,82 @@
+package season
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFor(t *testing.T) {
+	tests := []struct {
+		at        time.Time
+		wantID    string
+		wantStart string
+		wantEnd   string
+	}{
+		// October 2026 starts on Monday the 5th
+		{time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC), "2026-10", "2026-10-05", "2026-11-02"},
+		// Before the first Monday still belongs to the previous season
+		{time.Date(2026, 10, 3, 12, 0, 0, 0, time.UTC), "2026-09", "2026-09-07", "2026-10-05"},
+		// Year rollover
+		{time.Date(2027, 1, 2, 0, 0, 0, 0, time.UTC), "2026-12", "2026-12-07", "2027-01-04"},
+	}
+	for _, tt := range tests {
+		got := For(tt.at)
+		if got.ID != tt.wantID || got.Start.Format("2006-01-02") != tt.wantStart || got.End.Format("2006-01-02") != tt.wantEnd {
+			t.Errorf("For(%s) = %s %s→%s, want %s %s→%s", tt.at, got.ID,
+				got.Start.Format("2006-01-02"), got.End.Format("2006-01-02"), tt.wantID, tt.wantStart, tt.wantEnd)
+		}
+	}
+}
+
+func TestReport(t *testing.T) {
+	day := func(month time.Month, d int) time.Time { return time.Date(2026, month, d, 12, 0, 0, 0, time.UTC) }
+	points := []Point{
+		{Time: day(9, 10), Trophies: 5000, Arena: "Arena A"},
+		{Time: day(9, 20), Trophies: 5400, Arena: "Arena A"},
+		{Time: day(9, 25), Trophies: 5300},
+		{Time: day(10, 6), Trophies: 5300, Arena: "Arena A"},
+		{Time: day(10, 9), Trophies: 5600, Arena: "Arena B"},
+		{Time: day(10, 12), Trophies: 5900, Arena: "Arena B"},
+	}
+
+	stats := Report(points, day(10, 14))
+	if len(stats) != 2 {
+		t.Fatalf("got %d seasons, want 2", len(stats))
+	}
+
+	current, previous := stats[0], stats[1]
+	if current.ID != "2026-10" || previous.ID != "2026-09" {
+		t.Fatalf("unexpected season order: %s, %s", current.ID, previous.ID)
+	}
+	if previous.PeakTrophies != 5400 || previous.NetGain != 300 || previous.LowTrophies != 5000 || previous.Samples != 3 {
+		t.Errorf("unexpected previous season stats: %+v", previous)
+	}
+	if previous.Projection != nil {
+		t.Error("expected no projection for a finished season")
+	}
+
+	if len(current.Promotions) != 1 || current.Promotions[0].Arena != "Arena B" || !current.Promotions[0].Time.Equal(day(10, 9)) {
+		t.Errorf("unexpected promotions: %+v", current.Promotions)
+	}
+	projection := current.Projection
+	if projection == nil {
+		t.Fatal("expected a projection for the season in progress")
+	}
+	if projection.TrophiesPerDay <= 0 || projection.EndTrophies <= current.EndTrophies {
+		t.Errorf("expected an upward projection, got %+v", projection)
+	}
+	if projection.Arena != "Arena B" {
+		t.Errorf("projected arena = %q, want Arena B", projection.Arena)
+	}
+}
+
+func TestReportSkipsProjectionWithoutEnoughHistory(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	stats := Report([]Point{{Time: now.Add(-time.Hour), Trophies: 100}, {Time: now, Trophies: 130}}, now)
+	if len(stats) != 1 || stats[0].Projection != nil {
+		t.Errorf("expected no projection from an hour of history, got %+v", stats)
+	}
+	if Report(nil, now) != nil {
+		t.Error("expected no stats without history")
+	}
+}
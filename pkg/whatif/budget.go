@@ -0,0 +1,293 @@
+package whatif
+
+import (
+	"cmp"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/klauer/clash-royale-api/go/internal/config"
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+	"github.com/klauer/clash-royale-api/go/pkg/deck/evaluation"
+)
+
+// DefaultBudgetDecks is the number of best decks a gold budget is spent on
+// when BudgetOptions.TopDecks is unset.
+const DefaultBudgetDecks = 3
+
+// DeckScorer returns a deck's projected score with the given card levels
+type DeckScorer func(levels map[string]deck.CardLevelData, cards []string) float64
+
+// BudgetOptions configures OptimizeBudget
+type BudgetOptions struct {
+	Gold     int // gold available to spend
+	TopDecks int // number of best decks to improve (default DefaultBudgetDecks)
+}
+
+// BudgetUpgrade is one card's planned upgrade and the score it adds across
+// the optimized decks
+type BudgetUpgrade struct {
+	CardUpgrade
+	ScoreGain float64
+}
+
+// BudgetDeck is a deck's projected score before and after the plan
+type BudgetDeck struct {
+	Cards  []string
+	Before float64
+	After  float64
+}
+
+// BudgetPlan is the upgrade set chosen for a gold budget
+type BudgetPlan struct {
+	Budget    int
+	Spent     int
+	Upgrades  []BudgetUpgrade
+	Decks     []BudgetDeck
+	TotalGain float64
+}
+
+// Remaining returns the unspent gold
+func (p *BudgetPlan) Remaining() int {
+	return p.Budget - p.Spent
+}
+
+// budgetStep is the effect of upgrading one card by a single level
+type budgetStep struct {
+	cost   int
+	gain   float64
+	scores []float64 // new scores of the decks containing the card
+}
+
+// OptimizeBudget spends a gold budget on the upgrades that raise the
+// projected scores of the best decks the most. Decks are ranked with score,
+// the top ones kept, and single-level upgrades of their cards are bought
+// greedily by score gained per gold until nothing affordable helps. A nil
+// score uses EvaluationScorer.
+//
+// Gold costs come from the standard upgrade table, so levels without a
+// known cost are never planned.
+func OptimizeBudget(
+	cardLevels map[string]deck.CardLevelData,
+	decks [][]string,
+	opts BudgetOptions,
+	score DeckScorer,
+) (*BudgetPlan, error) {
+	if opts.Gold <= 0 {
+		return nil, errors.New("gold budget must be positive")
+	}
+	if score == nil {
+		score = EvaluationScorer()
+	}
+	topDecks := opts.TopDecks
+	if topDecks <= 0 {
+		topDecks = DefaultBudgetDecks
+	}
+
+	working := maps.Clone(cardLevels)
+	if working == nil {
+		working = make(map[string]deck.CardLevelData)
+	}
+
+	ranked, decksByCard, err := bestBudgetDecks(working, decks, topDecks, score)
+	if err != nil {
+		return nil, err
+	}
+	cards := slices.Sorted(maps.Keys(decksByCard))
+
+	plan := &BudgetPlan{Budget: opts.Gold}
+	planned := make(map[string]int) // card -> index in plan.Upgrades
+	steps := make(map[string]budgetStep)
+
+	for {
+		best, bestRatio := "", 0.0
+		for _, card := range cards {
+			step, ok := steps[card]
+			if !ok {
+				step, ok = nextBudgetStep(working, card, decksByCard[card], ranked, score)
+				if !ok {
+					continue
+				}
+				steps[card] = step
+			}
+			if step.cost > plan.Remaining() || step.gain <= 0 {
+				continue
+			}
+			if ratio := step.gain / float64(step.cost); ratio > bestRatio {
+				best, bestRatio = card, ratio
+			}
+		}
+		if best == "" {
+			break
+		}
+
+		step := steps[best]
+		data := working[best]
+		data.Level++
+		working[best] = data
+		plan.Spent += step.cost
+
+		for i, idx := range decksByCard[best] {
+			ranked[idx].After = step.scores[i]
+			// Every card in a changed deck now starts from a new score
+			for _, card := range ranked[idx].Cards {
+				delete(steps, card)
+			}
+		}
+		delete(steps, best)
+
+		if i, ok := planned[best]; ok {
+			plan.Upgrades[i].ToLevel = data.Level
+			plan.Upgrades[i].GoldCost += step.cost
+			plan.Upgrades[i].ScoreGain += step.gain
+		} else {
+			planned[best] = len(plan.Upgrades)
+			plan.Upgrades = append(plan.Upgrades, BudgetUpgrade{
+				CardUpgrade: CardUpgrade{CardName: best, FromLevel: data.Level - 1, ToLevel: data.Level, GoldCost: step.cost},
+				ScoreGain:   step.gain,
+			})
+		}
+	}
+
+	plan.Decks = ranked
+	for _, d := range ranked {
+		plan.TotalGain += d.After - d.Before
+	}
+	return plan, nil
+}
+
+// bestBudgetDecks keeps the topDecks best distinct decks and maps each owned
+// card in them to the decks it is in; only those cards can move their scores
+func bestBudgetDecks(
+	levels map[string]deck.CardLevelData,
+	decks [][]string,
+	topDecks int,
+	score DeckScorer,
+) ([]BudgetDeck, map[string][]int, error) {
+	ranked := rankBudgetDecks(levels, decks, score)
+	if len(ranked) == 0 {
+		return nil, nil, errors.New("no decks to optimize")
+	}
+	ranked = ranked[:min(topDecks, len(ranked))]
+
+	decksByCard := make(map[string][]int)
+	for i, d := range ranked {
+		for _, card := range d.Cards {
+			if _, owned := levels[card]; owned {
+				decksByCard[card] = append(decksByCard[card], i)
+			}
+		}
+	}
+	return ranked, decksByCard, nil
+}
+
+// rankBudgetDecks scores each distinct deck and orders them best first
+func rankBudgetDecks(levels map[string]deck.CardLevelData, decks [][]string, score DeckScorer) []BudgetDeck {
+	seen := make(map[string]bool, len(decks))
+	ranked := make([]BudgetDeck, 0, len(decks))
+	for _, cards := range decks {
+		if len(cards) == 0 {
+			continue
+		}
+		key := slices.Clone(cards)
+		slices.Sort(key)
+		k := strings.Join(key, "|")
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		s := score(levels, cards)
+		ranked = append(ranked, BudgetDeck{Cards: cards, Before: s, After: s})
+	}
+	slices.SortStableFunc(ranked, func(a, b BudgetDeck) int {
+		return cmp.Compare(b.Before, a.Before)
+	})
+	return ranked
+}
+
+// nextBudgetStep prices upgrading card by one level and re-scores the decks
+// that contain it. ok is false at max level or when the cost is unknown.
+func nextBudgetStep(
+	working map[string]deck.CardLevelData,
+	card string,
+	deckIdx []int,
+	ranked []BudgetDeck,
+	score DeckScorer,
+) (budgetStep, bool) {
+	data := working[card]
+	if data.MaxLevel > 0 && data.Level >= data.MaxLevel {
+		return budgetStep{}, false
+	}
+	candidate := deck.CardCandidate{Level: data.Level, MaxLevel: data.MaxLevel, Rarity: data.Rarity}
+	cost := config.GetGoldCost(candidate.StandardLevel(), data.Rarity)
+	if cost <= 0 {
+		return budgetStep{}, false
+	}
+
+	upgraded := data
+	upgraded.Level++
+	working[card] = upgraded
+	defer func() { working[card] = data }()
+
+	step := budgetStep{cost: cost, scores: make([]float64, len(deckIdx))}
+	for i, idx := range deckIdx {
+		step.scores[i] = score(working, ranked[idx].Cards)
+		step.gain += step.scores[i] - ranked[idx].After
+	}
+	return step, true
+}
+
+// EvaluationScorer scores decks with the evaluation engine and a player
+// context built from the card levels, so upgrades move the overall score
+// the same way they do in `deck evaluate --tag`.
+func EvaluationScorer() DeckScorer {
+	synergyDB := deck.NewSynergyDatabase()
+	return func(levels map[string]deck.CardLevelData, cards []string) float64 {
+		candidates := make([]deck.CardCandidate, 0, len(cards))
+		for _, name := range cards {
+			data, ok := levels[name]
+			if !ok {
+				role := config.GetCardRole(name)
+				candidates = append(candidates, deck.CardCandidate{
+					Name: name, Level: 11, MaxLevel: 15, Rarity: "Common",
+					Elixir: config.GetCardElixir(name, 0), Role: &role,
+				})
+				continue
+			}
+			role := config.GetCardRoleWithEvolution(name, data.EvolutionLevel)
+			candidates = append(candidates, deck.CardCandidate{
+				Name:              name,
+				Level:             data.Level,
+				MaxLevel:          data.MaxLevel,
+				Rarity:            data.Rarity,
+				Elixir:            data.Elixir,
+				Role:              &role,
+				EvolutionLevel:    data.EvolutionLevel,
+				MaxEvolutionLevel: data.MaxEvolutionLevel,
+			})
+		}
+		result := evaluation.EvaluateWithOptions(candidates, synergyDB, playerContextFromLevels(levels), evaluation.EvaluateOptions{})
+		return result.OverallScore
+	}
+}
+
+func playerContextFromLevels(levels map[string]deck.CardLevelData) *evaluation.PlayerContext {
+	ctx := &evaluation.PlayerContext{
+		Collection:         make(map[string]evaluation.CardLevelInfo, len(levels)),
+		UnlockedEvolutions: make(map[string]bool),
+	}
+	for name, data := range levels {
+		ctx.Collection[name] = evaluation.CardLevelInfo{
+			Level:             data.Level,
+			MaxLevel:          data.MaxLevel,
+			EvolutionLevel:    data.EvolutionLevel,
+			MaxEvolutionLevel: data.MaxEvolutionLevel,
+			Rarity:            data.Rarity,
+		}
+		if data.EvolutionLevel > 0 {
+			ctx.UnlockedEvolutions[name] = true
+		}
+	}
+	return ctx
+}
@@ -0,0 +1,104 @@
+package whatif
+
+import (
+	"testing"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+// weightedScorer makes each level of a card worth a fixed number of points
+func weightedScorer(weights map[string]float64) DeckScorer {
+	return func(levels map[string]deck.CardLevelData, cards []string) float64 {
+		total := 0.0
+		for _, c := range cards {
+			total += weights[c] * float64(levels[c].Level)
+		}
+		return total
+	}
+}
+
+func budgetLevels() map[string]deck.CardLevelData {
+	return map[string]deck.CardLevelData{
+		// Standard levels 10 (20000 gold) and 9 (8000 gold)
+		"Knight":  {Level: 10, MaxLevel: 16, Rarity: "Common"},
+		"Archers": {Level: 9, MaxLevel: 16, Rarity: "Common"},
+		// Level 5 Legendary is standard level 13 (100000 gold)
+		"The Log": {Level: 5, MaxLevel: 6, Rarity: "Legendary"},
+		"Golem":   {Level: 10, MaxLevel: 16, Rarity: "Common"},
+	}
+}
+
+func TestOptimizeBudgetPrefersScorePerGold(t *testing.T) {
+	weights := map[string]float64{"Knight": 1.5, "Archers": 1, "The Log": 3, "Golem": 0.1}
+	decks := [][]string{{"Knight", "Archers", "The Log"}, {"Golem"}}
+
+	plan, err := OptimizeBudget(budgetLevels(), decks, BudgetOptions{Gold: 30000, TopDecks: 1}, weightedScorer(weights))
+	if err != nil {
+		t.Fatalf("OptimizeBudget() error = %v", err)
+	}
+	if len(plan.Decks) != 1 || plan.Decks[0].Cards[0] != "Knight" {
+		t.Fatalf("kept decks = %+v, want only the best deck", plan.Decks)
+	}
+
+	// Archers 9->10 (8000) is the best value, then Knight 10->11 (20000).
+	// Archers 10->11 would cost another 20000 and no longer fits.
+	if len(plan.Upgrades) != 2 {
+		t.Fatalf("upgrades = %+v", plan.Upgrades)
+	}
+	first, second := plan.Upgrades[0], plan.Upgrades[1]
+	if first.CardName != "Archers" || first.FromLevel != 9 || first.ToLevel != 10 || first.GoldCost != 8000 {
+		t.Errorf("first upgrade = %+v", first)
+	}
+	if second.CardName != "Knight" || second.ToLevel != 11 || second.GoldCost != 20000 {
+		t.Errorf("second upgrade = %+v", second)
+	}
+	if plan.Spent != 28000 || plan.Remaining() != 2000 {
+		t.Errorf("spent %d, remaining %d", plan.Spent, plan.Remaining())
+	}
+	if plan.TotalGain != 2.5 || plan.Decks[0].After-plan.Decks[0].Before != 2.5 {
+		t.Errorf("total gain = %.2f, deck %+v", plan.TotalGain, plan.Decks[0])
+	}
+}
+
+func TestOptimizeBudgetMergesLevelsAndStopsAtMax(t *testing.T) {
+	levels := map[string]deck.CardLevelData{
+		"Knight": {Level: 14, MaxLevel: 16, Rarity: "Common"}, // no known cost at 14
+		"Zap":    {Level: 11, MaxLevel: 13, Rarity: "Common"},
+	}
+	weights := map[string]float64{"Knight": 5, "Zap": 1}
+
+	plan, err := OptimizeBudget(levels, [][]string{{"Knight", "Zap"}}, BudgetOptions{Gold: 1_000_000}, weightedScorer(weights))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Upgrades) != 1 {
+		t.Fatalf("upgrades = %+v", plan.Upgrades)
+	}
+	zap := plan.Upgrades[0]
+	if zap.CardName != "Zap" || zap.FromLevel != 11 || zap.ToLevel != 13 || zap.GoldCost != 150000 || zap.ScoreGain != 2 {
+		t.Errorf("zap upgrade = %+v, want 11->13 merged into one entry", zap)
+	}
+}
+
+func TestOptimizeBudgetErrors(t *testing.T) {
+	if _, err := OptimizeBudget(budgetLevels(), [][]string{{"Knight"}}, BudgetOptions{}, nil); err == nil {
+		t.Error("expected an error for a zero budget")
+	}
+	if _, err := OptimizeBudget(budgetLevels(), nil, BudgetOptions{Gold: 1000}, nil); err == nil {
+		t.Error("expected an error without decks")
+	}
+}
+
+func TestEvaluationScorerRewardsLevels(t *testing.T) {
+	cards := []string{"Knight", "Archers", "Fireball", "Hog Rider", "The Log", "Musketeer", "Ice Spirit", "Cannon"}
+	low := make(map[string]deck.CardLevelData, len(cards))
+	high := make(map[string]deck.CardLevelData, len(cards))
+	for _, name := range cards {
+		low[name] = deck.CardLevelData{Level: 5, MaxLevel: 16, Rarity: "Common", Elixir: 3}
+		high[name] = deck.CardLevelData{Level: 15, MaxLevel: 16, Rarity: "Common", Elixir: 3}
+	}
+	score := EvaluationScorer()
+	if l, h := score(low, cards), score(high, cards); h <= l {
+		t.Errorf("higher levels scored %.2f, lower %.2f", h, l)
+	}
+}
@@ -0,0 +1,65 @@
+package whatif
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+
+	"github.com/klauer/clash-royale-api/go/pkg/deck"
+)
+
+// UpgradeValue is a card's next single-level upgrade with the score it adds
+// across the best decks
+type UpgradeValue struct {
+	CardUpgrade
+	ScoreGain float64
+	// GainPer1000Gold is ScoreGain for every 1000 gold spent
+	GainPer1000Gold float64
+	// Decks counts the best decks the card is in
+	Decks int
+}
+
+// RankUpgradeValue prices the next level of every card in the best decks and
+// ranks the upgrades by score gained per gold, best value first. Decks are
+// ranked with score and the topDecks best kept (DefaultBudgetDecks when 0
+// or less); upgrades that do not raise their scores are left out. A nil
+// score uses EvaluationScorer.
+func RankUpgradeValue(
+	cardLevels map[string]deck.CardLevelData,
+	decks [][]string,
+	topDecks int,
+	score DeckScorer,
+) ([]UpgradeValue, []BudgetDeck, error) {
+	if score == nil {
+		score = EvaluationScorer()
+	}
+	if topDecks <= 0 {
+		topDecks = DefaultBudgetDecks
+	}
+
+	working := maps.Clone(cardLevels)
+	ranked, decksByCard, err := bestBudgetDecks(working, decks, topDecks, score)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var values []UpgradeValue
+	for _, card := range slices.Sorted(maps.Keys(decksByCard)) {
+		step, ok := nextBudgetStep(working, card, decksByCard[card], ranked, score)
+		if !ok || step.gain <= 0 {
+			continue
+		}
+		level := working[card].Level
+		values = append(values, UpgradeValue{
+			CardUpgrade:     CardUpgrade{CardName: card, FromLevel: level, ToLevel: level + 1, GoldCost: step.cost},
+			ScoreGain:       step.gain,
+			GainPer1000Gold: step.gain / float64(step.cost) * 1000,
+			Decks:           len(decksByCard[card]),
+		})
+	}
+
+	slices.SortStableFunc(values, func(a, b UpgradeValue) int {
+		return cmp.Compare(b.GainPer1000Gold, a.GainPer1000Gold)
+	})
+	return values, ranked, nil
+}
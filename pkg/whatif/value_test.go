@@ -0,0 +1,46 @@
+package whatif
+
+import "testing"
+
+func TestRankUpgradeValueOrdersByGainPerGold(t *testing.T) {
+	weights := map[string]float64{"Knight": 1.5, "Archers": 1, "The Log": 3, "Golem": 0.1}
+	decks := [][]string{{"Knight", "Archers", "The Log"}, {"Golem"}}
+
+	values, ranked, err := RankUpgradeValue(budgetLevels(), decks, 1, weightedScorer(weights))
+	if err != nil {
+		t.Fatalf("RankUpgradeValue() error = %v", err)
+	}
+	if len(ranked) != 1 || ranked[0].Before != 39 {
+		t.Fatalf("ranked decks = %+v, want only the best deck", ranked)
+	}
+
+	// Archers 8000 for +1, Knight 20000 for +1.5, The Log 100000 for +3;
+	// Golem is not in a kept deck
+	want := []struct {
+		card string
+		cost int
+		per  float64
+	}{
+		{"Archers", 8000, 0.125},
+		{"Knight", 20000, 0.075},
+		{"The Log", 100000, 0.03},
+	}
+	if len(values) != len(want) {
+		t.Fatalf("values = %+v", values)
+	}
+	for i, w := range want {
+		v := values[i]
+		if v.CardName != w.card || v.GoldCost != w.cost || v.ToLevel != v.FromLevel+1 || v.Decks != 1 {
+			t.Errorf("values[%d] = %+v, want %s for %d gold", i, v, w.card, w.cost)
+		}
+		if diff := v.GainPer1000Gold - w.per; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("values[%d] gain per 1000 gold = %f, want %f", i, v.GainPer1000Gold, w.per)
+		}
+	}
+}
+
+func TestRankUpgradeValueNoDecks(t *testing.T) {
+	if _, _, err := RankUpgradeValue(budgetLevels(), nil, 0, nil); err == nil {
+		t.Error("expected an error without decks")
+	}
+}